@@ -512,8 +512,11 @@ type TesteeResponse struct {
 	AssessmentStats *AssessmentStats       `protobuf:"bytes,11,opt,name=assessment_stats,json=assessmentStats,proto3" json:"assessment_stats,omitempty"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 创建时间
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // 更新时间
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// PII 脱敏：redacted 为 true 时 name 已打码、iam_profile_id 与 birthday 不返回，以 age_band 代替出生日期
+	AgeBand       string `protobuf:"bytes,14,opt,name=age_band,json=ageBand,proto3" json:"age_band,omitempty"` // 年龄段，如 6-11
+	Redacted      bool   `protobuf:"varint,15,opt,name=redacted,proto3" json:"redacted,omitempty"`             // 是否已按调用方权限脱敏
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TesteeResponse) Reset() {
@@ -638,6 +641,20 @@ func (x *TesteeResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *TesteeResponse) GetAgeBand() string {
+	if x != nil {
+		return x.AgeBand
+	}
+	return ""
+}
+
+func (x *TesteeResponse) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
 // AssessmentStats 测评统计信息
 type AssessmentStats struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -872,6 +889,110 @@ func (x *TesteeCareContextResponse) GetEntrySourceType() string {
 	return ""
 }
 
+type ExportTesteeCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 受试者ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTesteeCalendarRequest) Reset() {
+	*x = ExportTesteeCalendarRequest{}
+	mi := &file_actor_actor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTesteeCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTesteeCalendarRequest) ProtoMessage() {}
+
+func (x *ExportTesteeCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_actor_actor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTesteeCalendarRequest.ProtoReflect.Descriptor instead.
+func (*ExportTesteeCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_actor_actor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportTesteeCalendarRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TesteeCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`        // 建议的下载文件名
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                          // text/calendar 内容
+	EventCount    int32                  `protobuf:"varint,3,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"` // 导出的事件数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TesteeCalendarResponse) Reset() {
+	*x = TesteeCalendarResponse{}
+	mi := &file_actor_actor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TesteeCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TesteeCalendarResponse) ProtoMessage() {}
+
+func (x *TesteeCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_actor_actor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TesteeCalendarResponse.ProtoReflect.Descriptor instead.
+func (*TesteeCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_actor_actor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TesteeCalendarResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *TesteeCalendarResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *TesteeCalendarResponse) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
 var File_actor_actor_proto protoreflect.FileDescriptor
 
 const file_actor_actor_proto_rawDesc = "" +
@@ -912,7 +1033,7 @@ const file_actor_actor_proto_rawDesc = "" +
 	"\x18ListTesteesByUserRequest\x12&\n" +
 	"\x0fiam_profile_ids\x18\x01 \x03(\x04R\riamProfileIds\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xec\x03\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xa3\x04\n" +
 	"\x0eTesteeResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x15\n" +
 	"\x06org_id\x18\x02 \x01(\x04R\x05orgId\x12\x1e\n" +
@@ -930,7 +1051,9 @@ const file_actor_actor_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa4\x01\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x19\n" +
+	"\bage_band\x18\x0e \x01(\tR\aageBand\x12\x1a\n" +
+	"\bredacted\x18\x0f \x01(\bR\bredacted\"\xa4\x01\n" +
 	"\x0fAssessmentStats\x12\x1f\n" +
 	"\vtotal_count\x18\x01 \x01(\x05R\n" +
 	"totalCount\x12H\n" +
@@ -947,7 +1070,14 @@ const file_actor_actor_proto_rawDesc = "" +
 	"\rrelation_type\x18\x03 \x01(\tR\frelationType\x12\x1f\n" +
 	"\ventry_title\x18\x04 \x01(\tR\n" +
 	"entryTitle\x12*\n" +
-	"\x11entry_source_type\x18\x05 \x01(\tR\x0fentrySourceType2\x98\x04\n" +
+	"\x11entry_source_type\x18\x05 \x01(\tR\x0fentrySourceType\"-\n" +
+	"\x1bExportTesteeCalendarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"p\n" +
+	"\x16TesteeCalendarResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x1f\n" +
+	"\vevent_count\x18\x03 \x01(\x05R\n" +
+	"eventCount2\xf3\x04\n" +
 	"\fActorService\x12A\n" +
 	"\fCreateTestee\x12\x1a.actor.CreateTesteeRequest\x1a\x15.actor.TesteeResponse\x12;\n" +
 	"\tGetTestee\x12\x17.actor.GetTesteeRequest\x1a\x15.actor.TesteeResponse\x12A\n" +
@@ -955,7 +1085,8 @@ const file_actor_actor_proto_rawDesc = "" +
 	"\fTesteeExists\x12\x1a.actor.TesteeExistsRequest\x1a\x1b.actor.TesteeExistsResponse\x12M\n" +
 	"\x10ListTesteesByOrg\x12\x1e.actor.ListTesteesByOrgRequest\x1a\x19.actor.TesteeListResponse\x12O\n" +
 	"\x11ListTesteesByUser\x12\x1f.actor.ListTesteesByUserRequest\x1a\x19.actor.TesteeListResponse\x12\\\n" +
-	"\x14GetTesteeCareContext\x12\".actor.GetTesteeCareContextRequest\x1a .actor.TesteeCareContextResponseB6Z4github.com/FangcunMount/qs-server/api/grpc/gen/actorb\x06proto3"
+	"\x14GetTesteeCareContext\x12\".actor.GetTesteeCareContextRequest\x1a .actor.TesteeCareContextResponse\x12Y\n" +
+	"\x14ExportTesteeCalendar\x12\".actor.ExportTesteeCalendarRequest\x1a\x1d.actor.TesteeCalendarResponseB6Z4github.com/FangcunMount/qs-server/api/grpc/gen/actorb\x06proto3"
 
 var (
 	file_actor_actor_proto_rawDescOnce sync.Once
@@ -969,7 +1100,7 @@ func file_actor_actor_proto_rawDescGZIP() []byte {
 	return file_actor_actor_proto_rawDescData
 }
 
-var file_actor_actor_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_actor_actor_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_actor_actor_proto_goTypes = []any{
 	(*CreateTesteeRequest)(nil),         // 0: actor.CreateTesteeRequest
 	(*GetTesteeRequest)(nil),            // 1: actor.GetTesteeRequest
@@ -983,16 +1114,18 @@ var file_actor_actor_proto_goTypes = []any{
 	(*TesteeListResponse)(nil),          // 9: actor.TesteeListResponse
 	(*GetTesteeCareContextRequest)(nil), // 10: actor.GetTesteeCareContextRequest
 	(*TesteeCareContextResponse)(nil),   // 11: actor.TesteeCareContextResponse
-	(*timestamppb.Timestamp)(nil),       // 12: google.protobuf.Timestamp
+	(*ExportTesteeCalendarRequest)(nil), // 12: actor.ExportTesteeCalendarRequest
+	(*TesteeCalendarResponse)(nil),      // 13: actor.TesteeCalendarResponse
+	(*timestamppb.Timestamp)(nil),       // 14: google.protobuf.Timestamp
 }
 var file_actor_actor_proto_depIdxs = []int32{
-	12, // 0: actor.CreateTesteeRequest.birthday:type_name -> google.protobuf.Timestamp
-	12, // 1: actor.UpdateTesteeRequest.birthday:type_name -> google.protobuf.Timestamp
-	12, // 2: actor.TesteeResponse.birthday:type_name -> google.protobuf.Timestamp
+	14, // 0: actor.CreateTesteeRequest.birthday:type_name -> google.protobuf.Timestamp
+	14, // 1: actor.UpdateTesteeRequest.birthday:type_name -> google.protobuf.Timestamp
+	14, // 2: actor.TesteeResponse.birthday:type_name -> google.protobuf.Timestamp
 	8,  // 3: actor.TesteeResponse.assessment_stats:type_name -> actor.AssessmentStats
-	12, // 4: actor.TesteeResponse.created_at:type_name -> google.protobuf.Timestamp
-	12, // 5: actor.TesteeResponse.updated_at:type_name -> google.protobuf.Timestamp
-	12, // 6: actor.AssessmentStats.last_assessment_at:type_name -> google.protobuf.Timestamp
+	14, // 4: actor.TesteeResponse.created_at:type_name -> google.protobuf.Timestamp
+	14, // 5: actor.TesteeResponse.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 6: actor.AssessmentStats.last_assessment_at:type_name -> google.protobuf.Timestamp
 	7,  // 7: actor.TesteeListResponse.items:type_name -> actor.TesteeResponse
 	0,  // 8: actor.ActorService.CreateTestee:input_type -> actor.CreateTesteeRequest
 	1,  // 9: actor.ActorService.GetTestee:input_type -> actor.GetTesteeRequest
@@ -1001,15 +1134,17 @@ var file_actor_actor_proto_depIdxs = []int32{
 	5,  // 12: actor.ActorService.ListTesteesByOrg:input_type -> actor.ListTesteesByOrgRequest
 	6,  // 13: actor.ActorService.ListTesteesByUser:input_type -> actor.ListTesteesByUserRequest
 	10, // 14: actor.ActorService.GetTesteeCareContext:input_type -> actor.GetTesteeCareContextRequest
-	7,  // 15: actor.ActorService.CreateTestee:output_type -> actor.TesteeResponse
-	7,  // 16: actor.ActorService.GetTestee:output_type -> actor.TesteeResponse
-	7,  // 17: actor.ActorService.UpdateTestee:output_type -> actor.TesteeResponse
-	4,  // 18: actor.ActorService.TesteeExists:output_type -> actor.TesteeExistsResponse
-	9,  // 19: actor.ActorService.ListTesteesByOrg:output_type -> actor.TesteeListResponse
-	9,  // 20: actor.ActorService.ListTesteesByUser:output_type -> actor.TesteeListResponse
-	11, // 21: actor.ActorService.GetTesteeCareContext:output_type -> actor.TesteeCareContextResponse
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
+	12, // 15: actor.ActorService.ExportTesteeCalendar:input_type -> actor.ExportTesteeCalendarRequest
+	7,  // 16: actor.ActorService.CreateTestee:output_type -> actor.TesteeResponse
+	7,  // 17: actor.ActorService.GetTestee:output_type -> actor.TesteeResponse
+	7,  // 18: actor.ActorService.UpdateTestee:output_type -> actor.TesteeResponse
+	4,  // 19: actor.ActorService.TesteeExists:output_type -> actor.TesteeExistsResponse
+	9,  // 20: actor.ActorService.ListTesteesByOrg:output_type -> actor.TesteeListResponse
+	9,  // 21: actor.ActorService.ListTesteesByUser:output_type -> actor.TesteeListResponse
+	11, // 22: actor.ActorService.GetTesteeCareContext:output_type -> actor.TesteeCareContextResponse
+	13, // 23: actor.ActorService.ExportTesteeCalendar:output_type -> actor.TesteeCalendarResponse
+	16, // [16:24] is the sub-list for method output_type
+	8,  // [8:16] is the sub-list for method input_type
 	8,  // [8:8] is the sub-list for extension type_name
 	8,  // [8:8] is the sub-list for extension extendee
 	0,  // [0:8] is the sub-list for field type_name
@@ -1026,7 +1161,7 @@ func file_actor_actor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_actor_actor_proto_rawDesc), len(file_actor_actor_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,7 @@ const (
 	ActorService_ListTesteesByOrg_FullMethodName     = "/actor.ActorService/ListTesteesByOrg"
 	ActorService_ListTesteesByUser_FullMethodName    = "/actor.ActorService/ListTesteesByUser"
 	ActorService_GetTesteeCareContext_FullMethodName = "/actor.ActorService/GetTesteeCareContext"
+	ActorService_ExportTesteeCalendar_FullMethodName = "/actor.ActorService/ExportTesteeCalendar"
 )
 
 // ActorServiceClient is the client API for ActorService service.
@@ -50,6 +51,8 @@ type ActorServiceClient interface {
 	ListTesteesByUser(ctx context.Context, in *ListTesteesByUserRequest, opts ...grpc.CallOption) (*TesteeListResponse, error)
 	// GetTesteeCareContext 获取受试者当前照护上下文摘要
 	GetTesteeCareContext(ctx context.Context, in *GetTesteeCareContextRequest, opts ...grpc.CallOption) (*TesteeCareContextResponse, error)
+	// ExportTesteeCalendar 导出受试者待完成计划任务的 ICS 日历
+	ExportTesteeCalendar(ctx context.Context, in *ExportTesteeCalendarRequest, opts ...grpc.CallOption) (*TesteeCalendarResponse, error)
 }
 
 type actorServiceClient struct {
@@ -130,6 +133,16 @@ func (c *actorServiceClient) GetTesteeCareContext(ctx context.Context, in *GetTe
 	return out, nil
 }
 
+func (c *actorServiceClient) ExportTesteeCalendar(ctx context.Context, in *ExportTesteeCalendarRequest, opts ...grpc.CallOption) (*TesteeCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TesteeCalendarResponse)
+	err := c.cc.Invoke(ctx, ActorService_ExportTesteeCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ActorServiceServer is the server API for ActorService service.
 // All implementations must embed UnimplementedActorServiceServer
 // for forward compatibility.
@@ -152,6 +165,8 @@ type ActorServiceServer interface {
 	ListTesteesByUser(context.Context, *ListTesteesByUserRequest) (*TesteeListResponse, error)
 	// GetTesteeCareContext 获取受试者当前照护上下文摘要
 	GetTesteeCareContext(context.Context, *GetTesteeCareContextRequest) (*TesteeCareContextResponse, error)
+	// ExportTesteeCalendar 导出受试者待完成计划任务的 ICS 日历
+	ExportTesteeCalendar(context.Context, *ExportTesteeCalendarRequest) (*TesteeCalendarResponse, error)
 	mustEmbedUnimplementedActorServiceServer()
 }
 
@@ -183,6 +198,9 @@ func (UnimplementedActorServiceServer) ListTesteesByUser(context.Context, *ListT
 func (UnimplementedActorServiceServer) GetTesteeCareContext(context.Context, *GetTesteeCareContextRequest) (*TesteeCareContextResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTesteeCareContext not implemented")
 }
+func (UnimplementedActorServiceServer) ExportTesteeCalendar(context.Context, *ExportTesteeCalendarRequest) (*TesteeCalendarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportTesteeCalendar not implemented")
+}
 func (UnimplementedActorServiceServer) mustEmbedUnimplementedActorServiceServer() {}
 func (UnimplementedActorServiceServer) testEmbeddedByValue()                      {}
 
@@ -330,6 +348,24 @@ func _ActorService_GetTesteeCareContext_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActorService_ExportTesteeCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTesteeCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActorServiceServer).ExportTesteeCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActorService_ExportTesteeCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActorServiceServer).ExportTesteeCalendar(ctx, req.(*ExportTesteeCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ActorService_ServiceDesc is the grpc.ServiceDesc for ActorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -365,6 +401,10 @@ var ActorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTesteeCareContext",
 			Handler:    _ActorService_GetTesteeCareContext_Handler,
 		},
+		{
+			MethodName: "ExportTesteeCalendar",
+			Handler:    _ActorService_ExportTesteeCalendar_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "actor/actor.proto",
@@ -530,6 +530,7 @@ type SaveAnswerSheetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SafeMessaging *SafeMessaging         `protobuf:"bytes,3,opt,name=safe_messaging,json=safeMessaging,proto3" json:"safe_messaging,omitempty"` // 答卷命中关键条目时返回，作答端应立即展示
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -578,6 +579,134 @@ func (x *SaveAnswerSheetResponse) GetMessage() string {
 	return ""
 }
 
+func (x *SaveAnswerSheetResponse) GetSafeMessaging() *SafeMessaging {
+	if x != nil {
+		return x.SafeMessaging
+	}
+	return nil
+}
+
+// 安全提示：答卷命中关键条目时引导作答者联系求助热线。不透露命中的题目与规则。
+type SafeMessaging struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Hotlines      []*Hotline             `protobuf:"bytes,3,rep,name=hotlines,proto3" json:"hotlines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SafeMessaging) Reset() {
+	*x = SafeMessaging{}
+	mi := &file_answersheet_answersheet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SafeMessaging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SafeMessaging) ProtoMessage() {}
+
+func (x *SafeMessaging) ProtoReflect() protoreflect.Message {
+	mi := &file_answersheet_answersheet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SafeMessaging.ProtoReflect.Descriptor instead.
+func (*SafeMessaging) Descriptor() ([]byte, []int) {
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SafeMessaging) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SafeMessaging) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SafeMessaging) GetHotlines() []*Hotline {
+	if x != nil {
+		return x.Hotlines
+	}
+	return nil
+}
+
+type Hotline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Hours         string                 `protobuf:"bytes,3,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hotline) Reset() {
+	*x = Hotline{}
+	mi := &file_answersheet_answersheet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hotline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hotline) ProtoMessage() {}
+
+func (x *Hotline) ProtoReflect() protoreflect.Message {
+	mi := &file_answersheet_answersheet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hotline.ProtoReflect.Descriptor instead.
+func (*Hotline) Descriptor() ([]byte, []int) {
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Hotline) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Hotline) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Hotline) GetHours() string {
+	if x != nil {
+		return x.Hours
+	}
+	return ""
+}
+
 // 按 writer + idempotency_key 回读已完成提交。org_id 使用已持久化答卷中的冻结值。
 type LookupAnswerSheetSubmissionRequest struct {
 	state                protoimpl.MessageState    `protogen:"open.v1"`
@@ -595,7 +724,7 @@ type LookupAnswerSheetSubmissionRequest struct {
 
 func (x *LookupAnswerSheetSubmissionRequest) Reset() {
 	*x = LookupAnswerSheetSubmissionRequest{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[6]
+	mi := &file_answersheet_answersheet_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -607,7 +736,7 @@ func (x *LookupAnswerSheetSubmissionRequest) String() string {
 func (*LookupAnswerSheetSubmissionRequest) ProtoMessage() {}
 
 func (x *LookupAnswerSheetSubmissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[6]
+	mi := &file_answersheet_answersheet_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +749,7 @@ func (x *LookupAnswerSheetSubmissionRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use LookupAnswerSheetSubmissionRequest.ProtoReflect.Descriptor instead.
 func (*LookupAnswerSheetSubmissionRequest) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{6}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LookupAnswerSheetSubmissionRequest) GetWriterId() uint64 {
@@ -690,7 +819,7 @@ type SubmissionIntentAnswer struct {
 
 func (x *SubmissionIntentAnswer) Reset() {
 	*x = SubmissionIntentAnswer{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[7]
+	mi := &file_answersheet_answersheet_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -702,7 +831,7 @@ func (x *SubmissionIntentAnswer) String() string {
 func (*SubmissionIntentAnswer) ProtoMessage() {}
 
 func (x *SubmissionIntentAnswer) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[7]
+	mi := &file_answersheet_answersheet_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,7 +844,7 @@ func (x *SubmissionIntentAnswer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmissionIntentAnswer.ProtoReflect.Descriptor instead.
 func (*SubmissionIntentAnswer) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{7}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SubmissionIntentAnswer) GetQuestionCode() string {
@@ -743,13 +872,14 @@ type LookupAnswerSheetSubmissionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
 	Id            uint64                 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	SafeMessaging *SafeMessaging         `protobuf:"bytes,3,opt,name=safe_messaging,json=safeMessaging,proto3" json:"safe_messaging,omitempty"` // 回读的答卷命中关键条目时返回
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LookupAnswerSheetSubmissionResponse) Reset() {
 	*x = LookupAnswerSheetSubmissionResponse{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[8]
+	mi := &file_answersheet_answersheet_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -761,7 +891,7 @@ func (x *LookupAnswerSheetSubmissionResponse) String() string {
 func (*LookupAnswerSheetSubmissionResponse) ProtoMessage() {}
 
 func (x *LookupAnswerSheetSubmissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[8]
+	mi := &file_answersheet_answersheet_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +904,7 @@ func (x *LookupAnswerSheetSubmissionResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use LookupAnswerSheetSubmissionResponse.ProtoReflect.Descriptor instead.
 func (*LookupAnswerSheetSubmissionResponse) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{8}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *LookupAnswerSheetSubmissionResponse) GetFound() bool {
@@ -791,6 +921,13 @@ func (x *LookupAnswerSheetSubmissionResponse) GetId() uint64 {
 	return 0
 }
 
+func (x *LookupAnswerSheetSubmissionResponse) GetSafeMessaging() *SafeMessaging {
+	if x != nil {
+		return x.SafeMessaging
+	}
+	return nil
+}
+
 // 获取答卷请求
 type GetAnswerSheetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -802,7 +939,7 @@ type GetAnswerSheetRequest struct {
 
 func (x *GetAnswerSheetRequest) Reset() {
 	*x = GetAnswerSheetRequest{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[9]
+	mi := &file_answersheet_answersheet_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -814,7 +951,7 @@ func (x *GetAnswerSheetRequest) String() string {
 func (*GetAnswerSheetRequest) ProtoMessage() {}
 
 func (x *GetAnswerSheetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[9]
+	mi := &file_answersheet_answersheet_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,7 +964,7 @@ func (x *GetAnswerSheetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAnswerSheetRequest.ProtoReflect.Descriptor instead.
 func (*GetAnswerSheetRequest) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{9}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetAnswerSheetRequest) GetId() uint64 {
@@ -854,7 +991,7 @@ type GetAnswerSheetResponse struct {
 
 func (x *GetAnswerSheetResponse) Reset() {
 	*x = GetAnswerSheetResponse{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[10]
+	mi := &file_answersheet_answersheet_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -866,7 +1003,7 @@ func (x *GetAnswerSheetResponse) String() string {
 func (*GetAnswerSheetResponse) ProtoMessage() {}
 
 func (x *GetAnswerSheetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[10]
+	mi := &file_answersheet_answersheet_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -879,7 +1016,7 @@ func (x *GetAnswerSheetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAnswerSheetResponse.ProtoReflect.Descriptor instead.
 func (*GetAnswerSheetResponse) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{10}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetAnswerSheetResponse) GetAnswerSheet() *AnswerSheet {
@@ -904,7 +1041,7 @@ type ListAnswerSheetsRequest struct {
 
 func (x *ListAnswerSheetsRequest) Reset() {
 	*x = ListAnswerSheetsRequest{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[11]
+	mi := &file_answersheet_answersheet_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -916,7 +1053,7 @@ func (x *ListAnswerSheetsRequest) String() string {
 func (*ListAnswerSheetsRequest) ProtoMessage() {}
 
 func (x *ListAnswerSheetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[11]
+	mi := &file_answersheet_answersheet_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -929,7 +1066,7 @@ func (x *ListAnswerSheetsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAnswerSheetsRequest.ProtoReflect.Descriptor instead.
 func (*ListAnswerSheetsRequest) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{11}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListAnswerSheetsRequest) GetQuestionnaireCode() string {
@@ -985,7 +1122,7 @@ type ListAnswerSheetsResponse struct {
 
 func (x *ListAnswerSheetsResponse) Reset() {
 	*x = ListAnswerSheetsResponse{}
-	mi := &file_answersheet_answersheet_proto_msgTypes[12]
+	mi := &file_answersheet_answersheet_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -997,7 +1134,7 @@ func (x *ListAnswerSheetsResponse) String() string {
 func (*ListAnswerSheetsResponse) ProtoMessage() {}
 
 func (x *ListAnswerSheetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_answersheet_answersheet_proto_msgTypes[12]
+	mi := &file_answersheet_answersheet_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1010,7 +1147,7 @@ func (x *ListAnswerSheetsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAnswerSheetsResponse.ProtoReflect.Descriptor instead.
 func (*ListAnswerSheetsResponse) Descriptor() ([]byte, []int) {
-	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{12}
+	return file_answersheet_answersheet_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListAnswerSheetsResponse) GetAnswerSheets() []*AnswerSheetSummary {
@@ -1088,10 +1225,19 @@ const file_answersheet_answersheet_proto_rawDesc = "" +
 	" \x01(\v2\x16.answersheet.OriginRefR\toriginRefJ\x04\b\v\x10\fR\x12historical_context\"/\n" +
 	"\tOriginRef\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"C\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x86\x01\n" +
 	"\x17SaveAnswerSheetResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xfa\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\x0esafe_messaging\x18\x03 \x01(\v2\x1a.answersheet.SafeMessagingR\rsafeMessaging\"q\n" +
+	"\rSafeMessaging\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\bhotlines\x18\x03 \x03(\v2\x14.answersheet.HotlineR\bhotlines\"K\n" +
+	"\aHotline\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x14\n" +
+	"\x05hours\x18\x03 \x01(\tR\x05hours\"\xfa\x02\n" +
 	"\"LookupAnswerSheetSubmissionRequest\x12\x1b\n" +
 	"\twriter_id\x18\x01 \x01(\x04R\bwriterId\x12'\n" +
 	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12-\n" +
@@ -1105,10 +1251,11 @@ const file_answersheet_answersheet_proto_rawDesc = "" +
 	"\x16SubmissionIntentAnswer\x12#\n" +
 	"\rquestion_code\x18\x01 \x01(\tR\fquestionCode\x12#\n" +
 	"\rquestion_type\x18\x02 \x01(\tR\fquestionType\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\tR\x05value\"K\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x8e\x01\n" +
 	"#LookupAnswerSheetSubmissionResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x04R\x02id\"D\n" +
+	"\x02id\x18\x02 \x01(\x04R\x02id\x12A\n" +
+	"\x0esafe_messaging\x18\x03 \x01(\v2\x1a.answersheet.SafeMessagingR\rsafeMessaging\"D\n" +
 	"\x15GetAnswerSheetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
 	"\twriter_id\x18\x02 \x01(\x04R\bwriterId\"U\n" +
@@ -1142,7 +1289,7 @@ func file_answersheet_answersheet_proto_rawDescGZIP() []byte {
 	return file_answersheet_answersheet_proto_rawDescData
 }
 
-var file_answersheet_answersheet_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_answersheet_answersheet_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_answersheet_answersheet_proto_goTypes = []any{
 	(*AnswerSheet)(nil),                         // 0: answersheet.AnswerSheet
 	(*AnswerSheetSummary)(nil),                  // 1: answersheet.AnswerSheetSummary
@@ -1150,35 +1297,40 @@ var file_answersheet_answersheet_proto_goTypes = []any{
 	(*SaveAnswerSheetRequest)(nil),              // 3: answersheet.SaveAnswerSheetRequest
 	(*OriginRef)(nil),                           // 4: answersheet.OriginRef
 	(*SaveAnswerSheetResponse)(nil),             // 5: answersheet.SaveAnswerSheetResponse
-	(*LookupAnswerSheetSubmissionRequest)(nil),  // 6: answersheet.LookupAnswerSheetSubmissionRequest
-	(*SubmissionIntentAnswer)(nil),              // 7: answersheet.SubmissionIntentAnswer
-	(*LookupAnswerSheetSubmissionResponse)(nil), // 8: answersheet.LookupAnswerSheetSubmissionResponse
-	(*GetAnswerSheetRequest)(nil),               // 9: answersheet.GetAnswerSheetRequest
-	(*GetAnswerSheetResponse)(nil),              // 10: answersheet.GetAnswerSheetResponse
-	(*ListAnswerSheetsRequest)(nil),             // 11: answersheet.ListAnswerSheetsRequest
-	(*ListAnswerSheetsResponse)(nil),            // 12: answersheet.ListAnswerSheetsResponse
+	(*SafeMessaging)(nil),                       // 6: answersheet.SafeMessaging
+	(*Hotline)(nil),                             // 7: answersheet.Hotline
+	(*LookupAnswerSheetSubmissionRequest)(nil),  // 8: answersheet.LookupAnswerSheetSubmissionRequest
+	(*SubmissionIntentAnswer)(nil),              // 9: answersheet.SubmissionIntentAnswer
+	(*LookupAnswerSheetSubmissionResponse)(nil), // 10: answersheet.LookupAnswerSheetSubmissionResponse
+	(*GetAnswerSheetRequest)(nil),               // 11: answersheet.GetAnswerSheetRequest
+	(*GetAnswerSheetResponse)(nil),              // 12: answersheet.GetAnswerSheetResponse
+	(*ListAnswerSheetsRequest)(nil),             // 13: answersheet.ListAnswerSheetsRequest
+	(*ListAnswerSheetsResponse)(nil),            // 14: answersheet.ListAnswerSheetsResponse
 }
 var file_answersheet_answersheet_proto_depIdxs = []int32{
 	2,  // 0: answersheet.AnswerSheet.answers:type_name -> answersheet.Answer
 	2,  // 1: answersheet.SaveAnswerSheetRequest.answers:type_name -> answersheet.Answer
 	4,  // 2: answersheet.SaveAnswerSheetRequest.origin_ref:type_name -> answersheet.OriginRef
-	4,  // 3: answersheet.LookupAnswerSheetSubmissionRequest.origin_ref:type_name -> answersheet.OriginRef
-	7,  // 4: answersheet.LookupAnswerSheetSubmissionRequest.answers:type_name -> answersheet.SubmissionIntentAnswer
-	0,  // 5: answersheet.GetAnswerSheetResponse.answer_sheet:type_name -> answersheet.AnswerSheet
-	1,  // 6: answersheet.ListAnswerSheetsResponse.answer_sheets:type_name -> answersheet.AnswerSheetSummary
-	3,  // 7: answersheet.AnswerSheetService.SaveAnswerSheet:input_type -> answersheet.SaveAnswerSheetRequest
-	6,  // 8: answersheet.AnswerSheetService.LookupAnswerSheetSubmission:input_type -> answersheet.LookupAnswerSheetSubmissionRequest
-	9,  // 9: answersheet.AnswerSheetService.GetAnswerSheet:input_type -> answersheet.GetAnswerSheetRequest
-	11, // 10: answersheet.AnswerSheetService.ListAnswerSheets:input_type -> answersheet.ListAnswerSheetsRequest
-	5,  // 11: answersheet.AnswerSheetService.SaveAnswerSheet:output_type -> answersheet.SaveAnswerSheetResponse
-	8,  // 12: answersheet.AnswerSheetService.LookupAnswerSheetSubmission:output_type -> answersheet.LookupAnswerSheetSubmissionResponse
-	10, // 13: answersheet.AnswerSheetService.GetAnswerSheet:output_type -> answersheet.GetAnswerSheetResponse
-	12, // 14: answersheet.AnswerSheetService.ListAnswerSheets:output_type -> answersheet.ListAnswerSheetsResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	6,  // 3: answersheet.SaveAnswerSheetResponse.safe_messaging:type_name -> answersheet.SafeMessaging
+	7,  // 4: answersheet.SafeMessaging.hotlines:type_name -> answersheet.Hotline
+	4,  // 5: answersheet.LookupAnswerSheetSubmissionRequest.origin_ref:type_name -> answersheet.OriginRef
+	9,  // 6: answersheet.LookupAnswerSheetSubmissionRequest.answers:type_name -> answersheet.SubmissionIntentAnswer
+	6,  // 7: answersheet.LookupAnswerSheetSubmissionResponse.safe_messaging:type_name -> answersheet.SafeMessaging
+	0,  // 8: answersheet.GetAnswerSheetResponse.answer_sheet:type_name -> answersheet.AnswerSheet
+	1,  // 9: answersheet.ListAnswerSheetsResponse.answer_sheets:type_name -> answersheet.AnswerSheetSummary
+	3,  // 10: answersheet.AnswerSheetService.SaveAnswerSheet:input_type -> answersheet.SaveAnswerSheetRequest
+	8,  // 11: answersheet.AnswerSheetService.LookupAnswerSheetSubmission:input_type -> answersheet.LookupAnswerSheetSubmissionRequest
+	11, // 12: answersheet.AnswerSheetService.GetAnswerSheet:input_type -> answersheet.GetAnswerSheetRequest
+	13, // 13: answersheet.AnswerSheetService.ListAnswerSheets:input_type -> answersheet.ListAnswerSheetsRequest
+	5,  // 14: answersheet.AnswerSheetService.SaveAnswerSheet:output_type -> answersheet.SaveAnswerSheetResponse
+	10, // 15: answersheet.AnswerSheetService.LookupAnswerSheetSubmission:output_type -> answersheet.LookupAnswerSheetSubmissionResponse
+	12, // 16: answersheet.AnswerSheetService.GetAnswerSheet:output_type -> answersheet.GetAnswerSheetResponse
+	14, // 17: answersheet.AnswerSheetService.ListAnswerSheets:output_type -> answersheet.ListAnswerSheetsResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_answersheet_answersheet_proto_init() }
@@ -1192,7 +1344,7 @@ func file_answersheet_answersheet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_answersheet_answersheet_proto_rawDesc), len(file_answersheet_answersheet_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,863 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: consent/consent.proto
+
+package consent
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConsentDocument 同意书
+type ConsentDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                     // 同意书ID
+	ScopeKind     string                 `protobuf:"bytes,2,opt,name=scope_kind,json=scopeKind,proto3" json:"scope_kind,omitempty"`       // 适用范围：org/model/entry
+	ScopeRef      string                 `protobuf:"bytes,3,opt,name=scope_ref,json=scopeRef,proto3" json:"scope_ref,omitempty"`          // 问卷编码或测评入口ID
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                           // 版本号
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`                                // 标题
+	Body          string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`                                  // 正文
+	ContentHash   string                 `protobuf:"bytes,7,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // 内容摘要（sha256）
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // 发布时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentDocument) Reset() {
+	*x = ConsentDocument{}
+	mi := &file_consent_consent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentDocument) ProtoMessage() {}
+
+func (x *ConsentDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentDocument.ProtoReflect.Descriptor instead.
+func (*ConsentDocument) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConsentDocument) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConsentDocument) GetScopeKind() string {
+	if x != nil {
+		return x.ScopeKind
+	}
+	return ""
+}
+
+func (x *ConsentDocument) GetScopeRef() string {
+	if x != nil {
+		return x.ScopeRef
+	}
+	return ""
+}
+
+func (x *ConsentDocument) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConsentDocument) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ConsentDocument) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *ConsentDocument) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *ConsentDocument) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+// ConsentAcceptance 签署记录
+type ConsentAcceptance struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                    // 签署记录ID
+	DocumentId       uint64                 `protobuf:"varint,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`                  // 同意书ID
+	DocumentVersion  int32                  `protobuf:"varint,3,opt,name=document_version,json=documentVersion,proto3" json:"document_version,omitempty"`   // 同意书版本
+	TesteeId         uint64                 `protobuf:"varint,4,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`                        // 受试者ID
+	FillerUserId     uint64                 `protobuf:"varint,5,opt,name=filler_user_id,json=fillerUserId,proto3" json:"filler_user_id,omitempty"`          // 签署人用户ID
+	FillerType       string                 `protobuf:"bytes,6,opt,name=filler_type,json=fillerType,proto3" json:"filler_type,omitempty"`                   // 签署人类型：self/guardian
+	GuardianRelation string                 `protobuf:"bytes,7,opt,name=guardian_relation,json=guardianRelation,proto3" json:"guardian_relation,omitempty"` // 监护关系：parent/legal_guardian
+	AcceptedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`                   // 签署时间
+	Active           bool                   `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`                                            // 是否有效（未撤回）
+	WithdrawnAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=withdrawn_at,json=withdrawnAt,proto3" json:"withdrawn_at,omitempty"`               // 撤回时间
+	WithdrawReason   string                 `protobuf:"bytes,11,opt,name=withdraw_reason,json=withdrawReason,proto3" json:"withdraw_reason,omitempty"`      // 撤回原因
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConsentAcceptance) Reset() {
+	*x = ConsentAcceptance{}
+	mi := &file_consent_consent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentAcceptance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentAcceptance) ProtoMessage() {}
+
+func (x *ConsentAcceptance) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentAcceptance.ProtoReflect.Descriptor instead.
+func (*ConsentAcceptance) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConsentAcceptance) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConsentAcceptance) GetDocumentId() uint64 {
+	if x != nil {
+		return x.DocumentId
+	}
+	return 0
+}
+
+func (x *ConsentAcceptance) GetDocumentVersion() int32 {
+	if x != nil {
+		return x.DocumentVersion
+	}
+	return 0
+}
+
+func (x *ConsentAcceptance) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *ConsentAcceptance) GetFillerUserId() uint64 {
+	if x != nil {
+		return x.FillerUserId
+	}
+	return 0
+}
+
+func (x *ConsentAcceptance) GetFillerType() string {
+	if x != nil {
+		return x.FillerType
+	}
+	return ""
+}
+
+func (x *ConsentAcceptance) GetGuardianRelation() string {
+	if x != nil {
+		return x.GuardianRelation
+	}
+	return ""
+}
+
+func (x *ConsentAcceptance) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+func (x *ConsentAcceptance) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ConsentAcceptance) GetWithdrawnAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WithdrawnAt
+	}
+	return nil
+}
+
+func (x *ConsentAcceptance) GetWithdrawReason() string {
+	if x != nil {
+		return x.WithdrawReason
+	}
+	return ""
+}
+
+// RequiredConsent 一份需要签署的同意书
+type RequiredConsent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *ConsentDocument       `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Acceptance    *ConsentAcceptance     `protobuf:"bytes,2,opt,name=acceptance,proto3" json:"acceptance,omitempty"` // 未签署或已撤回时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequiredConsent) Reset() {
+	*x = RequiredConsent{}
+	mi := &file_consent_consent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequiredConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequiredConsent) ProtoMessage() {}
+
+func (x *RequiredConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequiredConsent.ProtoReflect.Descriptor instead.
+func (*RequiredConsent) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequiredConsent) GetDocument() *ConsentDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *RequiredConsent) GetAcceptance() *ConsentAcceptance {
+	if x != nil {
+		return x.Acceptance
+	}
+	return nil
+}
+
+// ListRequiredConsentsRequest 查询作答前需要签署的同意书
+type ListRequiredConsentsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TesteeId          uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`                           // 受试者ID
+	QuestionnaireCode string                 `protobuf:"bytes,2,opt,name=questionnaire_code,json=questionnaireCode,proto3" json:"questionnaire_code,omitempty"` // 问卷编码（可选）
+	EntryId           string                 `protobuf:"bytes,3,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`                               // 测评入口ID（可选）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListRequiredConsentsRequest) Reset() {
+	*x = ListRequiredConsentsRequest{}
+	mi := &file_consent_consent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequiredConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequiredConsentsRequest) ProtoMessage() {}
+
+func (x *ListRequiredConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequiredConsentsRequest.ProtoReflect.Descriptor instead.
+func (*ListRequiredConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRequiredConsentsRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *ListRequiredConsentsRequest) GetQuestionnaireCode() string {
+	if x != nil {
+		return x.QuestionnaireCode
+	}
+	return ""
+}
+
+func (x *ListRequiredConsentsRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// ListRequiredConsentsResponse 作答前需要签署的同意书
+type ListRequiredConsentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TesteeId      uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
+	MinorTestee   bool                   `protobuf:"varint,2,opt,name=minor_testee,json=minorTestee,proto3" json:"minor_testee,omitempty"` // 未成年受试者只能由监护人签署
+	Satisfied     bool                   `protobuf:"varint,3,opt,name=satisfied,proto3" json:"satisfied,omitempty"`                        // 是否已全部签署
+	Items         []*RequiredConsent     `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequiredConsentsResponse) Reset() {
+	*x = ListRequiredConsentsResponse{}
+	mi := &file_consent_consent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequiredConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequiredConsentsResponse) ProtoMessage() {}
+
+func (x *ListRequiredConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequiredConsentsResponse.ProtoReflect.Descriptor instead.
+func (*ListRequiredConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRequiredConsentsResponse) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *ListRequiredConsentsResponse) GetMinorTestee() bool {
+	if x != nil {
+		return x.MinorTestee
+	}
+	return false
+}
+
+func (x *ListRequiredConsentsResponse) GetSatisfied() bool {
+	if x != nil {
+		return x.Satisfied
+	}
+	return false
+}
+
+func (x *ListRequiredConsentsResponse) GetItems() []*RequiredConsent {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// AcceptConsentRequest 签署同意书
+type AcceptConsentRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TesteeId         uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`                        // 受试者ID
+	DocumentId       uint64                 `protobuf:"varint,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`                  // 同意书ID
+	FillerUserId     uint64                 `protobuf:"varint,3,opt,name=filler_user_id,json=fillerUserId,proto3" json:"filler_user_id,omitempty"`          // 签署人用户ID
+	FillerType       string                 `protobuf:"bytes,4,opt,name=filler_type,json=fillerType,proto3" json:"filler_type,omitempty"`                   // 签署人类型：self/guardian
+	GuardianRelation string                 `protobuf:"bytes,5,opt,name=guardian_relation,json=guardianRelation,proto3" json:"guardian_relation,omitempty"` // 监护关系：parent/legal_guardian，监护人签署时必填
+	Ip               string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`                                                     // 客户端IP
+	UserAgent        string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`                      // 客户端 User-Agent
+	RequestId        string                 `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                      // 请求ID
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AcceptConsentRequest) Reset() {
+	*x = AcceptConsentRequest{}
+	mi := &file_consent_consent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptConsentRequest) ProtoMessage() {}
+
+func (x *AcceptConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptConsentRequest.ProtoReflect.Descriptor instead.
+func (*AcceptConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AcceptConsentRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *AcceptConsentRequest) GetDocumentId() uint64 {
+	if x != nil {
+		return x.DocumentId
+	}
+	return 0
+}
+
+func (x *AcceptConsentRequest) GetFillerUserId() uint64 {
+	if x != nil {
+		return x.FillerUserId
+	}
+	return 0
+}
+
+func (x *AcceptConsentRequest) GetFillerType() string {
+	if x != nil {
+		return x.FillerType
+	}
+	return ""
+}
+
+func (x *AcceptConsentRequest) GetGuardianRelation() string {
+	if x != nil {
+		return x.GuardianRelation
+	}
+	return ""
+}
+
+func (x *AcceptConsentRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AcceptConsentRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AcceptConsentRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// WithdrawConsentRequest 撤回签署
+type WithdrawConsentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TesteeId       uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`                     // 受试者ID
+	AcceptanceId   uint64                 `protobuf:"varint,2,opt,name=acceptance_id,json=acceptanceId,proto3" json:"acceptance_id,omitempty"`         // 签署记录ID
+	OperatorUserId uint64                 `protobuf:"varint,3,opt,name=operator_user_id,json=operatorUserId,proto3" json:"operator_user_id,omitempty"` // 撤回人用户ID
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                          // 撤回原因
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WithdrawConsentRequest) Reset() {
+	*x = WithdrawConsentRequest{}
+	mi := &file_consent_consent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawConsentRequest) ProtoMessage() {}
+
+func (x *WithdrawConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawConsentRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WithdrawConsentRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *WithdrawConsentRequest) GetAcceptanceId() uint64 {
+	if x != nil {
+		return x.AcceptanceId
+	}
+	return 0
+}
+
+func (x *WithdrawConsentRequest) GetOperatorUserId() uint64 {
+	if x != nil {
+		return x.OperatorUserId
+	}
+	return 0
+}
+
+func (x *WithdrawConsentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CheckSubmissionConsentRequest 答卷提交前的同意校验
+type CheckSubmissionConsentRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrgId             uint64                 `protobuf:"varint,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`                                    // 机构ID（可选，缺省按受试者机构）
+	TesteeId          uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`                           // 受试者ID
+	QuestionnaireCode string                 `protobuf:"bytes,3,opt,name=questionnaire_code,json=questionnaireCode,proto3" json:"questionnaire_code,omitempty"` // 问卷编码
+	EntryId           string                 `protobuf:"bytes,4,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`                               // 测评入口ID（经入口作答时）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CheckSubmissionConsentRequest) Reset() {
+	*x = CheckSubmissionConsentRequest{}
+	mi := &file_consent_consent_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckSubmissionConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckSubmissionConsentRequest) ProtoMessage() {}
+
+func (x *CheckSubmissionConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckSubmissionConsentRequest.ProtoReflect.Descriptor instead.
+func (*CheckSubmissionConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckSubmissionConsentRequest) GetOrgId() uint64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *CheckSubmissionConsentRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *CheckSubmissionConsentRequest) GetQuestionnaireCode() string {
+	if x != nil {
+		return x.QuestionnaireCode
+	}
+	return ""
+}
+
+func (x *CheckSubmissionConsentRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// CheckSubmissionConsentResponse 同意校验结果
+type CheckSubmissionConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Satisfied     bool                   `protobuf:"varint,1,opt,name=satisfied,proto3" json:"satisfied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckSubmissionConsentResponse) Reset() {
+	*x = CheckSubmissionConsentResponse{}
+	mi := &file_consent_consent_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckSubmissionConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckSubmissionConsentResponse) ProtoMessage() {}
+
+func (x *CheckSubmissionConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_consent_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckSubmissionConsentResponse.ProtoReflect.Descriptor instead.
+func (*CheckSubmissionConsentResponse) Descriptor() ([]byte, []int) {
+	return file_consent_consent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckSubmissionConsentResponse) GetSatisfied() bool {
+	if x != nil {
+		return x.Satisfied
+	}
+	return false
+}
+
+var File_consent_consent_proto protoreflect.FileDescriptor
+
+const file_consent_consent_proto_rawDesc = "" +
+	"\n" +
+	"\x15consent/consent.proto\x12\aconsent\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x02\n" +
+	"\x0fConsentDocument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"scope_kind\x18\x02 \x01(\tR\tscopeKind\x12\x1b\n" +
+	"\tscope_ref\x18\x03 \x01(\tR\bscopeRef\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12!\n" +
+	"\fcontent_hash\x18\a \x01(\tR\vcontentHash\x12=\n" +
+	"\fpublished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\"\xbd\x03\n" +
+	"\x11ConsentAcceptance\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\x04R\n" +
+	"documentId\x12)\n" +
+	"\x10document_version\x18\x03 \x01(\x05R\x0fdocumentVersion\x12\x1b\n" +
+	"\ttestee_id\x18\x04 \x01(\x04R\btesteeId\x12$\n" +
+	"\x0efiller_user_id\x18\x05 \x01(\x04R\ffillerUserId\x12\x1f\n" +
+	"\vfiller_type\x18\x06 \x01(\tR\n" +
+	"fillerType\x12+\n" +
+	"\x11guardian_relation\x18\a \x01(\tR\x10guardianRelation\x12;\n" +
+	"\vaccepted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acceptedAt\x12\x16\n" +
+	"\x06active\x18\t \x01(\bR\x06active\x12=\n" +
+	"\fwithdrawn_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vwithdrawnAt\x12'\n" +
+	"\x0fwithdraw_reason\x18\v \x01(\tR\x0ewithdrawReason\"\x83\x01\n" +
+	"\x0fRequiredConsent\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.consent.ConsentDocumentR\bdocument\x12:\n" +
+	"\n" +
+	"acceptance\x18\x02 \x01(\v2\x1a.consent.ConsentAcceptanceR\n" +
+	"acceptance\"\x84\x01\n" +
+	"\x1bListRequiredConsentsRequest\x12\x1b\n" +
+	"\ttestee_id\x18\x01 \x01(\x04R\btesteeId\x12-\n" +
+	"\x12questionnaire_code\x18\x02 \x01(\tR\x11questionnaireCode\x12\x19\n" +
+	"\bentry_id\x18\x03 \x01(\tR\aentryId\"\xac\x01\n" +
+	"\x1cListRequiredConsentsResponse\x12\x1b\n" +
+	"\ttestee_id\x18\x01 \x01(\x04R\btesteeId\x12!\n" +
+	"\fminor_testee\x18\x02 \x01(\bR\vminorTestee\x12\x1c\n" +
+	"\tsatisfied\x18\x03 \x01(\bR\tsatisfied\x12.\n" +
+	"\x05items\x18\x04 \x03(\v2\x18.consent.RequiredConsentR\x05items\"\x96\x02\n" +
+	"\x14AcceptConsentRequest\x12\x1b\n" +
+	"\ttestee_id\x18\x01 \x01(\x04R\btesteeId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\x04R\n" +
+	"documentId\x12$\n" +
+	"\x0efiller_user_id\x18\x03 \x01(\x04R\ffillerUserId\x12\x1f\n" +
+	"\vfiller_type\x18\x04 \x01(\tR\n" +
+	"fillerType\x12+\n" +
+	"\x11guardian_relation\x18\x05 \x01(\tR\x10guardianRelation\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestId\"\x9c\x01\n" +
+	"\x16WithdrawConsentRequest\x12\x1b\n" +
+	"\ttestee_id\x18\x01 \x01(\x04R\btesteeId\x12#\n" +
+	"\racceptance_id\x18\x02 \x01(\x04R\facceptanceId\x12(\n" +
+	"\x10operator_user_id\x18\x03 \x01(\x04R\x0eoperatorUserId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x9d\x01\n" +
+	"\x1dCheckSubmissionConsentRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\x04R\x05orgId\x12\x1b\n" +
+	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\x12-\n" +
+	"\x12questionnaire_code\x18\x03 \x01(\tR\x11questionnaireCode\x12\x19\n" +
+	"\bentry_id\x18\x04 \x01(\tR\aentryId\">\n" +
+	"\x1eCheckSubmissionConsentResponse\x12\x1c\n" +
+	"\tsatisfied\x18\x01 \x01(\bR\tsatisfied2\xfc\x02\n" +
+	"\x0eConsentService\x12c\n" +
+	"\x14ListRequiredConsents\x12$.consent.ListRequiredConsentsRequest\x1a%.consent.ListRequiredConsentsResponse\x12J\n" +
+	"\rAcceptConsent\x12\x1d.consent.AcceptConsentRequest\x1a\x1a.consent.ConsentAcceptance\x12N\n" +
+	"\x0fWithdrawConsent\x12\x1f.consent.WithdrawConsentRequest\x1a\x1a.consent.ConsentAcceptance\x12i\n" +
+	"\x16CheckSubmissionConsent\x12&.consent.CheckSubmissionConsentRequest\x1a'.consent.CheckSubmissionConsentResponseB8Z6github.com/FangcunMount/qs-server/api/grpc/gen/consentb\x06proto3"
+
+var (
+	file_consent_consent_proto_rawDescOnce sync.Once
+	file_consent_consent_proto_rawDescData []byte
+)
+
+func file_consent_consent_proto_rawDescGZIP() []byte {
+	file_consent_consent_proto_rawDescOnce.Do(func() {
+		file_consent_consent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_consent_consent_proto_rawDesc), len(file_consent_consent_proto_rawDesc)))
+	})
+	return file_consent_consent_proto_rawDescData
+}
+
+var file_consent_consent_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_consent_consent_proto_goTypes = []any{
+	(*ConsentDocument)(nil),                // 0: consent.ConsentDocument
+	(*ConsentAcceptance)(nil),              // 1: consent.ConsentAcceptance
+	(*RequiredConsent)(nil),                // 2: consent.RequiredConsent
+	(*ListRequiredConsentsRequest)(nil),    // 3: consent.ListRequiredConsentsRequest
+	(*ListRequiredConsentsResponse)(nil),   // 4: consent.ListRequiredConsentsResponse
+	(*AcceptConsentRequest)(nil),           // 5: consent.AcceptConsentRequest
+	(*WithdrawConsentRequest)(nil),         // 6: consent.WithdrawConsentRequest
+	(*CheckSubmissionConsentRequest)(nil),  // 7: consent.CheckSubmissionConsentRequest
+	(*CheckSubmissionConsentResponse)(nil), // 8: consent.CheckSubmissionConsentResponse
+	(*timestamppb.Timestamp)(nil),          // 9: google.protobuf.Timestamp
+}
+var file_consent_consent_proto_depIdxs = []int32{
+	9,  // 0: consent.ConsentDocument.published_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: consent.ConsentAcceptance.accepted_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: consent.ConsentAcceptance.withdrawn_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: consent.RequiredConsent.document:type_name -> consent.ConsentDocument
+	1,  // 4: consent.RequiredConsent.acceptance:type_name -> consent.ConsentAcceptance
+	2,  // 5: consent.ListRequiredConsentsResponse.items:type_name -> consent.RequiredConsent
+	3,  // 6: consent.ConsentService.ListRequiredConsents:input_type -> consent.ListRequiredConsentsRequest
+	5,  // 7: consent.ConsentService.AcceptConsent:input_type -> consent.AcceptConsentRequest
+	6,  // 8: consent.ConsentService.WithdrawConsent:input_type -> consent.WithdrawConsentRequest
+	7,  // 9: consent.ConsentService.CheckSubmissionConsent:input_type -> consent.CheckSubmissionConsentRequest
+	4,  // 10: consent.ConsentService.ListRequiredConsents:output_type -> consent.ListRequiredConsentsResponse
+	1,  // 11: consent.ConsentService.AcceptConsent:output_type -> consent.ConsentAcceptance
+	1,  // 12: consent.ConsentService.WithdrawConsent:output_type -> consent.ConsentAcceptance
+	8,  // 13: consent.ConsentService.CheckSubmissionConsent:output_type -> consent.CheckSubmissionConsentResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_consent_consent_proto_init() }
+func file_consent_consent_proto_init() {
+	if File_consent_consent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_consent_consent_proto_rawDesc), len(file_consent_consent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consent_consent_proto_goTypes,
+		DependencyIndexes: file_consent_consent_proto_depIdxs,
+		MessageInfos:      file_consent_consent_proto_msgTypes,
+	}.Build()
+	File_consent_consent_proto = out.File
+	file_consent_consent_proto_goTypes = nil
+	file_consent_consent_proto_depIdxs = nil
+}
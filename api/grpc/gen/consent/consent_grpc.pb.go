@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v5.29.3
+// source: consent/consent.proto
+
+package consent
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ConsentService_ListRequiredConsents_FullMethodName   = "/consent.ConsentService/ListRequiredConsents"
+	ConsentService_AcceptConsent_FullMethodName          = "/consent.ConsentService/AcceptConsent"
+	ConsentService_WithdrawConsent_FullMethodName        = "/consent.ConsentService/WithdrawConsent"
+	ConsentService_CheckSubmissionConsent_FullMethodName = "/consent.ConsentService/CheckSubmissionConsent"
+)
+
+// ConsentServiceClient is the client API for ConsentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ConsentService 知情同意服务 - 受试者侧签署与撤回，以及答卷提交前的同意校验
+type ConsentServiceClient interface {
+	// ListRequiredConsents 列出受试者作答前需要签署的同意书及当前签署情况
+	ListRequiredConsents(ctx context.Context, in *ListRequiredConsentsRequest, opts ...grpc.CallOption) (*ListRequiredConsentsResponse, error)
+	// AcceptConsent 受试者本人或监护人签署同意书（已签署时幂等返回原记录）
+	AcceptConsent(ctx context.Context, in *AcceptConsentRequest, opts ...grpc.CallOption) (*ConsentAcceptance, error)
+	// WithdrawConsent 撤回签署，发布 consent.withdrawn 事件
+	WithdrawConsent(ctx context.Context, in *WithdrawConsentRequest, opts ...grpc.CallOption) (*ConsentAcceptance, error)
+	// CheckSubmissionConsent 答卷提交前校验；缺少有效同意时返回 FAILED_PRECONDITION
+	CheckSubmissionConsent(ctx context.Context, in *CheckSubmissionConsentRequest, opts ...grpc.CallOption) (*CheckSubmissionConsentResponse, error)
+}
+
+type consentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsentServiceClient(cc grpc.ClientConnInterface) ConsentServiceClient {
+	return &consentServiceClient{cc}
+}
+
+func (c *consentServiceClient) ListRequiredConsents(ctx context.Context, in *ListRequiredConsentsRequest, opts ...grpc.CallOption) (*ListRequiredConsentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRequiredConsentsResponse)
+	err := c.cc.Invoke(ctx, ConsentService_ListRequiredConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) AcceptConsent(ctx context.Context, in *AcceptConsentRequest, opts ...grpc.CallOption) (*ConsentAcceptance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsentAcceptance)
+	err := c.cc.Invoke(ctx, ConsentService_AcceptConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) WithdrawConsent(ctx context.Context, in *WithdrawConsentRequest, opts ...grpc.CallOption) (*ConsentAcceptance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsentAcceptance)
+	err := c.cc.Invoke(ctx, ConsentService_WithdrawConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) CheckSubmissionConsent(ctx context.Context, in *CheckSubmissionConsentRequest, opts ...grpc.CallOption) (*CheckSubmissionConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckSubmissionConsentResponse)
+	err := c.cc.Invoke(ctx, ConsentService_CheckSubmissionConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConsentServiceServer is the server API for ConsentService service.
+// All implementations must embed UnimplementedConsentServiceServer
+// for forward compatibility.
+//
+// ConsentService 知情同意服务 - 受试者侧签署与撤回，以及答卷提交前的同意校验
+type ConsentServiceServer interface {
+	// ListRequiredConsents 列出受试者作答前需要签署的同意书及当前签署情况
+	ListRequiredConsents(context.Context, *ListRequiredConsentsRequest) (*ListRequiredConsentsResponse, error)
+	// AcceptConsent 受试者本人或监护人签署同意书（已签署时幂等返回原记录）
+	AcceptConsent(context.Context, *AcceptConsentRequest) (*ConsentAcceptance, error)
+	// WithdrawConsent 撤回签署，发布 consent.withdrawn 事件
+	WithdrawConsent(context.Context, *WithdrawConsentRequest) (*ConsentAcceptance, error)
+	// CheckSubmissionConsent 答卷提交前校验；缺少有效同意时返回 FAILED_PRECONDITION
+	CheckSubmissionConsent(context.Context, *CheckSubmissionConsentRequest) (*CheckSubmissionConsentResponse, error)
+	mustEmbedUnimplementedConsentServiceServer()
+}
+
+// UnimplementedConsentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConsentServiceServer struct{}
+
+func (UnimplementedConsentServiceServer) ListRequiredConsents(context.Context, *ListRequiredConsentsRequest) (*ListRequiredConsentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRequiredConsents not implemented")
+}
+func (UnimplementedConsentServiceServer) AcceptConsent(context.Context, *AcceptConsentRequest) (*ConsentAcceptance, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptConsent not implemented")
+}
+func (UnimplementedConsentServiceServer) WithdrawConsent(context.Context, *WithdrawConsentRequest) (*ConsentAcceptance, error) {
+	return nil, status.Error(codes.Unimplemented, "method WithdrawConsent not implemented")
+}
+func (UnimplementedConsentServiceServer) CheckSubmissionConsent(context.Context, *CheckSubmissionConsentRequest) (*CheckSubmissionConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckSubmissionConsent not implemented")
+}
+func (UnimplementedConsentServiceServer) mustEmbedUnimplementedConsentServiceServer() {}
+func (UnimplementedConsentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeConsentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsentServiceServer will
+// result in compilation errors.
+type UnsafeConsentServiceServer interface {
+	mustEmbedUnimplementedConsentServiceServer()
+}
+
+func RegisterConsentServiceServer(s grpc.ServiceRegistrar, srv ConsentServiceServer) {
+	// If the following call panics, it indicates UnimplementedConsentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ConsentService_ServiceDesc, srv)
+}
+
+func _ConsentService_ListRequiredConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequiredConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).ListRequiredConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_ListRequiredConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).ListRequiredConsents(ctx, req.(*ListRequiredConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_AcceptConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).AcceptConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_AcceptConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).AcceptConsent(ctx, req.(*AcceptConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_WithdrawConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).WithdrawConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_WithdrawConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).WithdrawConsent(ctx, req.(*WithdrawConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_CheckSubmissionConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSubmissionConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).CheckSubmissionConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_CheckSubmissionConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).CheckSubmissionConsent(ctx, req.(*CheckSubmissionConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConsentService_ServiceDesc is the grpc.ServiceDesc for ConsentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConsentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "consent.ConsentService",
+	HandlerType: (*ConsentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRequiredConsents",
+			Handler:    _ConsentService_ListRequiredConsents_Handler,
+		},
+		{
+			MethodName: "AcceptConsent",
+			Handler:    _ConsentService_AcceptConsent_Handler,
+		},
+		{
+			MethodName: "WithdrawConsent",
+			Handler:    _ConsentService_WithdrawConsent_Handler,
+		},
+		{
+			MethodName: "CheckSubmissionConsent",
+			Handler:    _ConsentService_CheckSubmissionConsent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consent/consent.proto",
+}
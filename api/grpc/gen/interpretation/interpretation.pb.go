@@ -467,35 +467,814 @@ func (x *ModelExtra) GetRarity() *ModelRarity {
 }
 
 type AssessmentReport struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	AssessmentId  uint64                    `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
-	Conclusion    string                    `protobuf:"bytes,6,opt,name=conclusion,proto3" json:"conclusion,omitempty"`
-	Dimensions    []*DimensionInterpret     `protobuf:"bytes,7,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
-	Suggestions   []*Suggestion             `protobuf:"bytes,8,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
-	CreatedAt     string                    `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ModelExtra    *ModelExtra               `protobuf:"bytes,10,opt,name=model_extra,json=modelExtra,proto3" json:"model_extra,omitempty"`
-	Model         *evaluation.ModelIdentity `protobuf:"bytes,11,opt,name=model,proto3" json:"model,omitempty"`
-	PrimaryScore  *evaluation.ScoreValue    `protobuf:"bytes,12,opt,name=primary_score,json=primaryScore,proto3" json:"primary_score,omitempty"`
-	Level         *evaluation.ResultLevel   `protobuf:"bytes,13,opt,name=level,proto3" json:"level,omitempty"`
+	state             protoimpl.MessageState    `protogen:"open.v1"`
+	AssessmentId      uint64                    `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
+	Conclusion        string                    `protobuf:"bytes,6,opt,name=conclusion,proto3" json:"conclusion,omitempty"`
+	Dimensions        []*DimensionInterpret     `protobuf:"bytes,7,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Suggestions       []*Suggestion             `protobuf:"bytes,8,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	CreatedAt         string                    `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ModelExtra        *ModelExtra               `protobuf:"bytes,10,opt,name=model_extra,json=modelExtra,proto3" json:"model_extra,omitempty"`
+	Model             *evaluation.ModelIdentity `protobuf:"bytes,11,opt,name=model,proto3" json:"model,omitempty"`
+	PrimaryScore      *evaluation.ScoreValue    `protobuf:"bytes,12,opt,name=primary_score,json=primaryScore,proto3" json:"primary_score,omitempty"`
+	Level             *evaluation.ResultLevel   `protobuf:"bytes,13,opt,name=level,proto3" json:"level,omitempty"`
+	ClinicianAddendum *ClinicianAddendum        `protobuf:"bytes,14,opt,name=clinician_addendum,json=clinicianAddendum,proto3" json:"clinician_addendum,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AssessmentReport) Reset() {
+	*x = AssessmentReport{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssessmentReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssessmentReport) ProtoMessage() {}
+
+func (x *AssessmentReport) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssessmentReport.ProtoReflect.Descriptor instead.
+func (*AssessmentReport) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AssessmentReport) GetAssessmentId() uint64 {
+	if x != nil {
+		return x.AssessmentId
+	}
+	return 0
+}
+
+func (x *AssessmentReport) GetConclusion() string {
+	if x != nil {
+		return x.Conclusion
+	}
+	return ""
+}
+
+func (x *AssessmentReport) GetDimensions() []*DimensionInterpret {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *AssessmentReport) GetModelExtra() *ModelExtra {
+	if x != nil {
+		return x.ModelExtra
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetModel() *evaluation.ModelIdentity {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetPrimaryScore() *evaluation.ScoreValue {
+	if x != nil {
+		return x.PrimaryScore
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetLevel() *evaluation.ResultLevel {
+	if x != nil {
+		return x.Level
+	}
+	return nil
+}
+
+func (x *AssessmentReport) GetClinicianAddendum() *ClinicianAddendum {
+	if x != nil {
+		return x.ClinicianAddendum
+	}
+	return nil
+}
+
+type ClinicianAddendum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ReviewerName  string                 `protobuf:"bytes,2,opt,name=reviewer_name,json=reviewerName,proto3" json:"reviewer_name,omitempty"`
+	ReviewStatus  string                 `protobuf:"bytes,3,opt,name=review_status,json=reviewStatus,proto3" json:"review_status,omitempty"`
+	ReviewedAt    string                 `protobuf:"bytes,4,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClinicianAddendum) Reset() {
+	*x = ClinicianAddendum{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClinicianAddendum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClinicianAddendum) ProtoMessage() {}
+
+func (x *ClinicianAddendum) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClinicianAddendum.ProtoReflect.Descriptor instead.
+func (*ClinicianAddendum) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClinicianAddendum) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ClinicianAddendum) GetReviewerName() string {
+	if x != nil {
+		return x.ReviewerName
+	}
+	return ""
+}
+
+func (x *ClinicianAddendum) GetReviewStatus() string {
+	if x != nil {
+		return x.ReviewStatus
+	}
+	return ""
+}
+
+func (x *ClinicianAddendum) GetReviewedAt() string {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return ""
+}
+
+type GetAssessmentReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssessmentId  uint64                 `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
+	TesteeId      uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssessmentReportRequest) Reset() {
+	*x = GetAssessmentReportRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssessmentReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssessmentReportRequest) ProtoMessage() {}
+
+func (x *GetAssessmentReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssessmentReportRequest.ProtoReflect.Descriptor instead.
+func (*GetAssessmentReportRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAssessmentReportRequest) GetAssessmentId() uint64 {
+	if x != nil {
+		return x.AssessmentId
+	}
+	return 0
+}
+
+func (x *GetAssessmentReportRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+type GetAssessmentReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *AssessmentReport      `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssessmentReportResponse) Reset() {
+	*x = GetAssessmentReportResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssessmentReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssessmentReportResponse) ProtoMessage() {}
+
+func (x *GetAssessmentReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssessmentReportResponse.ProtoReflect.Descriptor instead.
+func (*GetAssessmentReportResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAssessmentReportResponse) GetReport() *AssessmentReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type ListMyReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TesteeId      uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyReportsRequest) Reset() {
+	*x = ListMyReportsRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyReportsRequest) ProtoMessage() {}
+
+func (x *ListMyReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyReportsRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListMyReportsRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *ListMyReportsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMyReportsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListMyReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*AssessmentReport    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyReportsResponse) Reset() {
+	*x = ListMyReportsResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyReportsResponse) ProtoMessage() {}
+
+func (x *ListMyReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyReportsResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMyReportsResponse) GetItems() []*AssessmentReport {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListMyReportsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListMyReportsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMyReportsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyReportsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type IssueReportPDFLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssessmentId  uint64                 `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
+	TesteeId      uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueReportPDFLinkRequest) Reset() {
+	*x = IssueReportPDFLinkRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueReportPDFLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueReportPDFLinkRequest) ProtoMessage() {}
+
+func (x *IssueReportPDFLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueReportPDFLinkRequest.ProtoReflect.Descriptor instead.
+func (*IssueReportPDFLinkRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IssueReportPDFLinkRequest) GetAssessmentId() uint64 {
+	if x != nil {
+		return x.AssessmentId
+	}
+	return 0
+}
+
+func (x *IssueReportPDFLinkRequest) GetTesteeId() uint64 {
+	if x != nil {
+		return x.TesteeId
+	}
+	return 0
+}
+
+type IssueReportPDFLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentHash   string                 `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueReportPDFLinkResponse) Reset() {
+	*x = IssueReportPDFLinkResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueReportPDFLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueReportPDFLinkResponse) ProtoMessage() {}
+
+func (x *IssueReportPDFLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueReportPDFLinkResponse.ProtoReflect.Descriptor instead.
+func (*IssueReportPDFLinkResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IssueReportPDFLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueReportPDFLinkResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *IssueReportPDFLinkResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *IssueReportPDFLinkResponse) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type DownloadReportPDFRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadReportPDFRequest) Reset() {
+	*x = DownloadReportPDFRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadReportPDFRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadReportPDFRequest) ProtoMessage() {}
+
+func (x *DownloadReportPDFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadReportPDFRequest.ProtoReflect.Descriptor instead.
+func (*DownloadReportPDFRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DownloadReportPDFRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type DownloadReportPDFResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadReportPDFResponse) Reset() {
+	*x = DownloadReportPDFResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadReportPDFResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadReportPDFResponse) ProtoMessage() {}
+
+func (x *DownloadReportPDFResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadReportPDFResponse.ProtoReflect.Descriptor instead.
+func (*DownloadReportPDFResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DownloadReportPDFResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DownloadReportPDFResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadReportPDFResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type ReportShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssessmentId  uint64                 `protobuf:"varint,2,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Audience      string                 `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	Label         string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	PinProtected  bool                   `protobuf:"varint,6,opt,name=pin_protected,json=pinProtected,proto3" json:"pin_protected,omitempty"`
+	MaxViews      int32                  `protobuf:"varint,7,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	ViewCount     int32                  `protobuf:"varint,8,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastViewedAt  string                 `protobuf:"bytes,12,opt,name=last_viewed_at,json=lastViewedAt,proto3" json:"last_viewed_at,omitempty"`
+	RevokedAt     string                 `protobuf:"bytes,13,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportShare) Reset() {
+	*x = ReportShare{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportShare) ProtoMessage() {}
+
+func (x *ReportShare) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportShare.ProtoReflect.Descriptor instead.
+func (*ReportShare) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReportShare) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReportShare) GetAssessmentId() uint64 {
+	if x != nil {
+		return x.AssessmentId
+	}
+	return 0
+}
+
+func (x *ReportShare) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ReportShare) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *ReportShare) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ReportShare) GetPinProtected() bool {
+	if x != nil {
+		return x.PinProtected
+	}
+	return false
+}
+
+func (x *ReportShare) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *ReportShare) GetViewCount() int32 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *ReportShare) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReportShare) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ReportShare) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ReportShare) GetLastViewedAt() string {
+	if x != nil {
+		return x.LastViewedAt
+	}
+	return ""
+}
+
+func (x *ReportShare) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+type CreateReportShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssessmentId  uint64                 `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
+	TesteeId      uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Audience      string                 `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,5,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	MaxViews      int32                  `protobuf:"varint,6,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	Pin           string                 `protobuf:"bytes,7,opt,name=pin,proto3" json:"pin,omitempty"`
+	Label         string                 `protobuf:"bytes,8,opt,name=label,proto3" json:"label,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssessmentReport) Reset() {
-	*x = AssessmentReport{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[5]
+func (x *CreateReportShareRequest) Reset() {
+	*x = CreateReportShareRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssessmentReport) String() string {
+func (x *CreateReportShareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssessmentReport) ProtoMessage() {}
+func (*CreateReportShareRequest) ProtoMessage() {}
 
-func (x *AssessmentReport) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[5]
+func (x *CreateReportShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,75 +1285,128 @@ func (x *AssessmentReport) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssessmentReport.ProtoReflect.Descriptor instead.
-func (*AssessmentReport) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CreateReportShareRequest.ProtoReflect.Descriptor instead.
+func (*CreateReportShareRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *AssessmentReport) GetAssessmentId() uint64 {
+func (x *CreateReportShareRequest) GetAssessmentId() uint64 {
 	if x != nil {
 		return x.AssessmentId
 	}
 	return 0
 }
 
-func (x *AssessmentReport) GetConclusion() string {
+func (x *CreateReportShareRequest) GetTesteeId() uint64 {
 	if x != nil {
-		return x.Conclusion
+		return x.TesteeId
+	}
+	return 0
+}
+
+func (x *CreateReportShareRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *AssessmentReport) GetDimensions() []*DimensionInterpret {
+func (x *CreateReportShareRequest) GetAudience() string {
 	if x != nil {
-		return x.Dimensions
+		return x.Audience
 	}
-	return nil
+	return ""
 }
 
-func (x *AssessmentReport) GetSuggestions() []*Suggestion {
+func (x *CreateReportShareRequest) GetTtlSeconds() int64 {
 	if x != nil {
-		return x.Suggestions
+		return x.TtlSeconds
 	}
-	return nil
+	return 0
 }
 
-func (x *AssessmentReport) GetCreatedAt() string {
+func (x *CreateReportShareRequest) GetMaxViews() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *CreateReportShareRequest) GetPin() string {
+	if x != nil {
+		return x.Pin
 	}
 	return ""
 }
 
-func (x *AssessmentReport) GetModelExtra() *ModelExtra {
+func (x *CreateReportShareRequest) GetLabel() string {
 	if x != nil {
-		return x.ModelExtra
+		return x.Label
 	}
-	return nil
+	return ""
 }
 
-func (x *AssessmentReport) GetModel() *evaluation.ModelIdentity {
+type CreateReportShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ReportShare           `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	AccessCode    string                 `protobuf:"bytes,3,opt,name=access_code,json=accessCode,proto3" json:"access_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReportShareResponse) Reset() {
+	*x = CreateReportShareResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReportShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportShareResponse) ProtoMessage() {}
+
+func (x *CreateReportShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[17]
 	if x != nil {
-		return x.Model
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AssessmentReport) GetPrimaryScore() *evaluation.ScoreValue {
+// Deprecated: Use CreateReportShareResponse.ProtoReflect.Descriptor instead.
+func (*CreateReportShareResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateReportShareResponse) GetShare() *ReportShare {
 	if x != nil {
-		return x.PrimaryScore
+		return x.Share
 	}
 	return nil
 }
 
-func (x *AssessmentReport) GetLevel() *evaluation.ResultLevel {
+func (x *CreateReportShareResponse) GetToken() string {
 	if x != nil {
-		return x.Level
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-type GetAssessmentReportRequest struct {
+func (x *CreateReportShareResponse) GetAccessCode() string {
+	if x != nil {
+		return x.AccessCode
+	}
+	return ""
+}
+
+type ListReportSharesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AssessmentId  uint64                 `protobuf:"varint,1,opt,name=assessment_id,json=assessmentId,proto3" json:"assessment_id,omitempty"`
 	TesteeId      uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
@@ -582,21 +1414,21 @@ type GetAssessmentReportRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAssessmentReportRequest) Reset() {
-	*x = GetAssessmentReportRequest{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[6]
+func (x *ListReportSharesRequest) Reset() {
+	*x = ListReportSharesRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssessmentReportRequest) String() string {
+func (x *ListReportSharesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssessmentReportRequest) ProtoMessage() {}
+func (*ListReportSharesRequest) ProtoMessage() {}
 
-func (x *GetAssessmentReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[6]
+func (x *ListReportSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,47 +1439,47 @@ func (x *GetAssessmentReportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssessmentReportRequest.ProtoReflect.Descriptor instead.
-func (*GetAssessmentReportRequest) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListReportSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListReportSharesRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetAssessmentReportRequest) GetAssessmentId() uint64 {
+func (x *ListReportSharesRequest) GetAssessmentId() uint64 {
 	if x != nil {
 		return x.AssessmentId
 	}
 	return 0
 }
 
-func (x *GetAssessmentReportRequest) GetTesteeId() uint64 {
+func (x *ListReportSharesRequest) GetTesteeId() uint64 {
 	if x != nil {
 		return x.TesteeId
 	}
 	return 0
 }
 
-type GetAssessmentReportResponse struct {
+type ListReportSharesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Report        *AssessmentReport      `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Items         []*ReportShare         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAssessmentReportResponse) Reset() {
-	*x = GetAssessmentReportResponse{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[7]
+func (x *ListReportSharesResponse) Reset() {
+	*x = ListReportSharesResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssessmentReportResponse) String() string {
+func (x *ListReportSharesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssessmentReportResponse) ProtoMessage() {}
+func (*ListReportSharesResponse) ProtoMessage() {}
 
-func (x *GetAssessmentReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[7]
+func (x *ListReportSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,42 +1490,41 @@ func (x *GetAssessmentReportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssessmentReportResponse.ProtoReflect.Descriptor instead.
-func (*GetAssessmentReportResponse) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListReportSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListReportSharesResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetAssessmentReportResponse) GetReport() *AssessmentReport {
+func (x *ListReportSharesResponse) GetItems() []*ReportShare {
 	if x != nil {
-		return x.Report
+		return x.Items
 	}
 	return nil
 }
 
-type ListMyReportsRequest struct {
+type RevokeReportShareRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TesteeId      uint64                 `protobuf:"varint,1,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	ShareId       uint64                 `protobuf:"varint,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	TesteeId      uint64                 `protobuf:"varint,2,opt,name=testee_id,json=testeeId,proto3" json:"testee_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMyReportsRequest) Reset() {
-	*x = ListMyReportsRequest{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[8]
+func (x *RevokeReportShareRequest) Reset() {
+	*x = RevokeReportShareRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMyReportsRequest) String() string {
+func (x *RevokeReportShareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMyReportsRequest) ProtoMessage() {}
+func (*RevokeReportShareRequest) ProtoMessage() {}
 
-func (x *ListMyReportsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[8]
+func (x *RevokeReportShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,58 +1535,95 @@ func (x *ListMyReportsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMyReportsRequest.ProtoReflect.Descriptor instead.
-func (*ListMyReportsRequest) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RevokeReportShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeReportShareRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListMyReportsRequest) GetTesteeId() uint64 {
+func (x *RevokeReportShareRequest) GetShareId() uint64 {
 	if x != nil {
-		return x.TesteeId
+		return x.ShareId
 	}
 	return 0
 }
 
-func (x *ListMyReportsRequest) GetPage() int32 {
+func (x *RevokeReportShareRequest) GetTesteeId() uint64 {
 	if x != nil {
-		return x.Page
+		return x.TesteeId
 	}
 	return 0
 }
 
-func (x *ListMyReportsRequest) GetPageSize() int32 {
+type RevokeReportShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ReportShare           `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeReportShareResponse) Reset() {
+	*x = RevokeReportShareResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeReportShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeReportShareResponse) ProtoMessage() {}
+
+func (x *RevokeReportShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[21]
 	if x != nil {
-		return x.PageSize
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type ListMyReportsResponse struct {
+// Deprecated: Use RevokeReportShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeReportShareResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RevokeReportShareResponse) GetShare() *ReportShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type OpenReportShareRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*AssessmentReport    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	AccessCode    string                 `protobuf:"bytes,2,opt,name=access_code,json=accessCode,proto3" json:"access_code,omitempty"`
+	Pin           string                 `protobuf:"bytes,3,opt,name=pin,proto3" json:"pin,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMyReportsResponse) Reset() {
-	*x = ListMyReportsResponse{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[9]
+func (x *OpenReportShareRequest) Reset() {
+	*x = OpenReportShareRequest{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMyReportsResponse) String() string {
+func (x *OpenReportShareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMyReportsResponse) ProtoMessage() {}
+func (*OpenReportShareRequest) ProtoMessage() {}
 
-func (x *ListMyReportsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[9]
+func (x *OpenReportShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -766,42 +1634,110 @@ func (x *ListMyReportsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMyReportsResponse.ProtoReflect.Descriptor instead.
-func (*ListMyReportsResponse) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use OpenReportShareRequest.ProtoReflect.Descriptor instead.
+func (*OpenReportShareRequest) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ListMyReportsResponse) GetItems() []*AssessmentReport {
+func (x *OpenReportShareRequest) GetToken() string {
 	if x != nil {
-		return x.Items
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *ListMyReportsResponse) GetTotal() int32 {
+func (x *OpenReportShareRequest) GetAccessCode() string {
 	if x != nil {
-		return x.Total
+		return x.AccessCode
 	}
-	return 0
+	return ""
 }
 
-func (x *ListMyReportsResponse) GetPage() int32 {
+func (x *OpenReportShareRequest) GetPin() string {
 	if x != nil {
-		return x.Page
+		return x.Pin
 	}
-	return 0
+	return ""
 }
 
-func (x *ListMyReportsResponse) GetPageSize() int32 {
+func (x *OpenReportShareRequest) GetClientIp() string {
 	if x != nil {
-		return x.PageSize
+		return x.ClientIp
 	}
-	return 0
+	return ""
 }
 
-func (x *ListMyReportsResponse) GetTotalPages() int32 {
+func (x *OpenReportShareRequest) GetUserAgent() string {
 	if x != nil {
-		return x.TotalPages
+		return x.UserAgent
+	}
+	return ""
+}
+
+type OpenReportShareResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Report         *AssessmentReport      `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Audience       string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	ExpiresAt      string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RemainingViews int32                  `protobuf:"varint,4,opt,name=remaining_views,json=remainingViews,proto3" json:"remaining_views,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenReportShareResponse) Reset() {
+	*x = OpenReportShareResponse{}
+	mi := &file_interpretation_interpretation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenReportShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenReportShareResponse) ProtoMessage() {}
+
+func (x *OpenReportShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_interpretation_interpretation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenReportShareResponse.ProtoReflect.Descriptor instead.
+func (*OpenReportShareResponse) Descriptor() ([]byte, []int) {
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OpenReportShareResponse) GetReport() *AssessmentReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *OpenReportShareResponse) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *OpenReportShareResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *OpenReportShareResponse) GetRemainingViews() int32 {
+	if x != nil {
+		return x.RemainingViews
 	}
 	return 0
 }
@@ -816,7 +1752,7 @@ type GenerateReportFromAssessmentRequest struct {
 
 func (x *GenerateReportFromAssessmentRequest) Reset() {
 	*x = GenerateReportFromAssessmentRequest{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[10]
+	mi := &file_interpretation_interpretation_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -828,7 +1764,7 @@ func (x *GenerateReportFromAssessmentRequest) String() string {
 func (*GenerateReportFromAssessmentRequest) ProtoMessage() {}
 
 func (x *GenerateReportFromAssessmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[10]
+	mi := &file_interpretation_interpretation_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +1777,7 @@ func (x *GenerateReportFromAssessmentRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use GenerateReportFromAssessmentRequest.ProtoReflect.Descriptor instead.
 func (*GenerateReportFromAssessmentRequest) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{10}
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GenerateReportFromAssessmentRequest) GetAssessmentId() uint64 {
@@ -867,7 +1803,7 @@ type GenerateReportFromOutcomeRequest struct {
 
 func (x *GenerateReportFromOutcomeRequest) Reset() {
 	*x = GenerateReportFromOutcomeRequest{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[11]
+	mi := &file_interpretation_interpretation_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -879,7 +1815,7 @@ func (x *GenerateReportFromOutcomeRequest) String() string {
 func (*GenerateReportFromOutcomeRequest) ProtoMessage() {}
 
 func (x *GenerateReportFromOutcomeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[11]
+	mi := &file_interpretation_interpretation_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,7 +1828,7 @@ func (x *GenerateReportFromOutcomeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateReportFromOutcomeRequest.ProtoReflect.Descriptor instead.
 func (*GenerateReportFromOutcomeRequest) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{11}
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GenerateReportFromOutcomeRequest) GetOutcomeId() string {
@@ -927,7 +1863,7 @@ type GenerateReportFromAssessmentResponse struct {
 
 func (x *GenerateReportFromAssessmentResponse) Reset() {
 	*x = GenerateReportFromAssessmentResponse{}
-	mi := &file_interpretation_interpretation_proto_msgTypes[12]
+	mi := &file_interpretation_interpretation_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -939,7 +1875,7 @@ func (x *GenerateReportFromAssessmentResponse) String() string {
 func (*GenerateReportFromAssessmentResponse) ProtoMessage() {}
 
 func (x *GenerateReportFromAssessmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_interpretation_interpretation_proto_msgTypes[12]
+	mi := &file_interpretation_interpretation_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,7 +1888,7 @@ func (x *GenerateReportFromAssessmentResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use GenerateReportFromAssessmentResponse.ProtoReflect.Descriptor instead.
 func (*GenerateReportFromAssessmentResponse) Descriptor() ([]byte, []int) {
-	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{12}
+	return file_interpretation_interpretation_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GenerateReportFromAssessmentResponse) GetSuccess() bool {
@@ -1130,7 +2066,7 @@ const file_interpretation_interpretation_proto_rawDesc = "" +
 	"commentary\x18\t \x01(\tR\n" +
 	"commentary\x123\n" +
 	"\x06rarity\x18\n" +
-	" \x01(\v2\x1b.interpretation.ModelRarityR\x06rarity\"\x9b\x04\n" +
+	" \x01(\v2\x1b.interpretation.ModelRarityR\x06rarity\"\xed\x04\n" +
 	"\x10AssessmentReport\x12#\n" +
 	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1e\n" +
 	"\n" +
@@ -1147,10 +2083,17 @@ const file_interpretation_interpretation_proto_rawDesc = "" +
 	"modelExtra\x12/\n" +
 	"\x05model\x18\v \x01(\v2\x19.evaluation.ModelIdentityR\x05model\x12;\n" +
 	"\rprimary_score\x18\f \x01(\v2\x16.evaluation.ScoreValueR\fprimaryScore\x12-\n" +
-	"\x05level\x18\r \x01(\v2\x17.evaluation.ResultLevelR\x05levelJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04J\x04\b\x04\x10\x05J\x04\b\x05\x10\x06R\n" +
+	"\x05level\x18\r \x01(\v2\x17.evaluation.ResultLevelR\x05level\x12P\n" +
+	"\x12clinician_addendum\x18\x0e \x01(\v2!.interpretation.ClinicianAddendumR\x11clinicianAddendumJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04J\x04\b\x04\x10\x05J\x04\b\x05\x10\x06R\n" +
 	"scale_codeR\n" +
 	"scale_nameR\vtotal_scoreR\n" +
-	"risk_level\"^\n" +
+	"risk_level\"\x98\x01\n" +
+	"\x11ClinicianAddendum\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12#\n" +
+	"\rreviewer_name\x18\x02 \x01(\tR\freviewerName\x12#\n" +
+	"\rreview_status\x18\x03 \x01(\tR\freviewStatus\x12\x1f\n" +
+	"\vreviewed_at\x18\x04 \x01(\tR\n" +
+	"reviewedAt\"^\n" +
 	"\x1aGetAssessmentReportRequest\x12#\n" +
 	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1b\n" +
 	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\"W\n" +
@@ -1166,7 +2109,80 @@ const file_interpretation_interpretation_proto_rawDesc = "" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
 	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
-	"totalPages\"i\n" +
+	"totalPages\"]\n" +
+	"\x19IssueReportPDFLinkRequest\x12#\n" +
+	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1b\n" +
+	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\"\x91\x01\n" +
+	"\x1aIssueReportPDFLinkResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_hash\x18\x04 \x01(\tR\vcontentHash\"0\n" +
+	"\x18DownloadReportPDFRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"u\n" +
+	"\x19DownloadReportPDFResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"\x84\x03\n" +
+	"\vReportShare\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12#\n" +
+	"\rassessment_id\x18\x02 \x01(\x04R\fassessmentId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\baudience\x18\x04 \x01(\tR\baudience\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\x12#\n" +
+	"\rpin_protected\x18\x06 \x01(\bR\fpinProtected\x12\x1b\n" +
+	"\tmax_views\x18\a \x01(\x05R\bmaxViews\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\b \x01(\x05R\tviewCount\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12$\n" +
+	"\x0elast_viewed_at\x18\f \x01(\tR\flastViewedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\r \x01(\tR\trevokedAt\"\xf2\x01\n" +
+	"\x18CreateReportShareRequest\x12#\n" +
+	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1b\n" +
+	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\baudience\x18\x04 \x01(\tR\baudience\x12\x1f\n" +
+	"\vttl_seconds\x18\x05 \x01(\x03R\n" +
+	"ttlSeconds\x12\x1b\n" +
+	"\tmax_views\x18\x06 \x01(\x05R\bmaxViews\x12\x10\n" +
+	"\x03pin\x18\a \x01(\tR\x03pin\x12\x14\n" +
+	"\x05label\x18\b \x01(\tR\x05label\"\x85\x01\n" +
+	"\x19CreateReportShareResponse\x121\n" +
+	"\x05share\x18\x01 \x01(\v2\x1b.interpretation.ReportShareR\x05share\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x1f\n" +
+	"\vaccess_code\x18\x03 \x01(\tR\n" +
+	"accessCode\"[\n" +
+	"\x17ListReportSharesRequest\x12#\n" +
+	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1b\n" +
+	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\"M\n" +
+	"\x18ListReportSharesResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.interpretation.ReportShareR\x05items\"R\n" +
+	"\x18RevokeReportShareRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\x04R\ashareId\x12\x1b\n" +
+	"\ttestee_id\x18\x02 \x01(\x04R\btesteeId\"N\n" +
+	"\x19RevokeReportShareResponse\x121\n" +
+	"\x05share\x18\x01 \x01(\v2\x1b.interpretation.ReportShareR\x05share\"\x9d\x01\n" +
+	"\x16OpenReportShareRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vaccess_code\x18\x02 \x01(\tR\n" +
+	"accessCode\x12\x10\n" +
+	"\x03pin\x18\x03 \x01(\tR\x03pin\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\"\xb7\x01\n" +
+	"\x17OpenReportShareResponse\x128\n" +
+	"\x06report\x18\x01 \x01(\v2 .interpretation.AssessmentReportR\x06report\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudience\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\x12'\n" +
+	"\x0fremaining_views\x18\x04 \x01(\x05R\x0eremainingViews\"i\n" +
 	"#GenerateReportFromAssessmentRequest\x12#\n" +
 	"\rassessment_id\x18\x01 \x01(\x04R\fassessmentId\x12\x1d\n" +
 	"\n" +
@@ -1192,10 +2208,16 @@ const file_interpretation_interpretation_proto_rawDesc = "" +
 	"\x1cremaining_automatic_attempts\x18\x0e \x01(\x05R\x1aremainingAutomaticAttempts\x12&\n" +
 	"\x0fnext_attempt_at\x18\x0f \x01(\tR\rnextAttemptAt\x12$\n" +
 	"\x0eretry_event_id\x18\x10 \x01(\tR\fretryEventId\x12*\n" +
-	"\x11action_request_id\x18\x11 \x01(\tR\x0factionRequestId2\xe8\x01\n" +
+	"\x11action_request_id\x18\x11 \x01(\tR\x0factionRequestId2\xde\x06\n" +
 	"\x18ParticipantReportService\x12n\n" +
 	"\x13GetAssessmentReport\x12*.interpretation.GetAssessmentReportRequest\x1a+.interpretation.GetAssessmentReportResponse\x12\\\n" +
-	"\rListMyReports\x12$.interpretation.ListMyReportsRequest\x1a%.interpretation.ListMyReportsResponse2\xb8\x02\n" +
+	"\rListMyReports\x12$.interpretation.ListMyReportsRequest\x1a%.interpretation.ListMyReportsResponse\x12k\n" +
+	"\x12IssueReportPDFLink\x12).interpretation.IssueReportPDFLinkRequest\x1a*.interpretation.IssueReportPDFLinkResponse\x12h\n" +
+	"\x11DownloadReportPDF\x12(.interpretation.DownloadReportPDFRequest\x1a).interpretation.DownloadReportPDFResponse\x12h\n" +
+	"\x11CreateReportShare\x12(.interpretation.CreateReportShareRequest\x1a).interpretation.CreateReportShareResponse\x12e\n" +
+	"\x10ListReportShares\x12'.interpretation.ListReportSharesRequest\x1a(.interpretation.ListReportSharesResponse\x12h\n" +
+	"\x11RevokeReportShare\x12(.interpretation.RevokeReportShareRequest\x1a).interpretation.RevokeReportShareResponse\x12b\n" +
+	"\x0fOpenReportShare\x12&.interpretation.OpenReportShareRequest\x1a'.interpretation.OpenReportShareResponse2\xb8\x02\n" +
 	"\x1fInterpretationAutomationService\x12\x83\x01\n" +
 	"\x19GenerateReportFromOutcome\x120.interpretation.GenerateReportFromOutcomeRequest\x1a4.interpretation.GenerateReportFromAssessmentResponse\x12\x8e\x01\n" +
 	"\x1cGenerateReportFromAssessment\x123.interpretation.GenerateReportFromAssessmentRequest\x1a4.interpretation.GenerateReportFromAssessmentResponse\"\x03\x88\x02\x01B?Z=github.com/FangcunMount/qs-server/api/grpc/gen/interpretationb\x06proto3"
@@ -1212,7 +2234,7 @@ func file_interpretation_interpretation_proto_rawDescGZIP() []byte {
 	return file_interpretation_interpretation_proto_rawDescData
 }
 
-var file_interpretation_interpretation_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_interpretation_interpretation_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_interpretation_interpretation_proto_goTypes = []any{
 	(*Suggestion)(nil),                           // 0: interpretation.Suggestion
 	(*NormReference)(nil),                        // 1: interpretation.NormReference
@@ -1220,43 +2242,74 @@ var file_interpretation_interpretation_proto_goTypes = []any{
 	(*ModelRarity)(nil),                          // 3: interpretation.ModelRarity
 	(*ModelExtra)(nil),                           // 4: interpretation.ModelExtra
 	(*AssessmentReport)(nil),                     // 5: interpretation.AssessmentReport
-	(*GetAssessmentReportRequest)(nil),           // 6: interpretation.GetAssessmentReportRequest
-	(*GetAssessmentReportResponse)(nil),          // 7: interpretation.GetAssessmentReportResponse
-	(*ListMyReportsRequest)(nil),                 // 8: interpretation.ListMyReportsRequest
-	(*ListMyReportsResponse)(nil),                // 9: interpretation.ListMyReportsResponse
-	(*GenerateReportFromAssessmentRequest)(nil),  // 10: interpretation.GenerateReportFromAssessmentRequest
-	(*GenerateReportFromOutcomeRequest)(nil),     // 11: interpretation.GenerateReportFromOutcomeRequest
-	(*GenerateReportFromAssessmentResponse)(nil), // 12: interpretation.GenerateReportFromAssessmentResponse
-	(*evaluation.ScoreValue)(nil),                // 13: evaluation.ScoreValue
-	(*evaluation.ResultLevel)(nil),               // 14: evaluation.ResultLevel
-	(*evaluation.ModelIdentity)(nil),             // 15: evaluation.ModelIdentity
+	(*ClinicianAddendum)(nil),                    // 6: interpretation.ClinicianAddendum
+	(*GetAssessmentReportRequest)(nil),           // 7: interpretation.GetAssessmentReportRequest
+	(*GetAssessmentReportResponse)(nil),          // 8: interpretation.GetAssessmentReportResponse
+	(*ListMyReportsRequest)(nil),                 // 9: interpretation.ListMyReportsRequest
+	(*ListMyReportsResponse)(nil),                // 10: interpretation.ListMyReportsResponse
+	(*IssueReportPDFLinkRequest)(nil),            // 11: interpretation.IssueReportPDFLinkRequest
+	(*IssueReportPDFLinkResponse)(nil),           // 12: interpretation.IssueReportPDFLinkResponse
+	(*DownloadReportPDFRequest)(nil),             // 13: interpretation.DownloadReportPDFRequest
+	(*DownloadReportPDFResponse)(nil),            // 14: interpretation.DownloadReportPDFResponse
+	(*ReportShare)(nil),                          // 15: interpretation.ReportShare
+	(*CreateReportShareRequest)(nil),             // 16: interpretation.CreateReportShareRequest
+	(*CreateReportShareResponse)(nil),            // 17: interpretation.CreateReportShareResponse
+	(*ListReportSharesRequest)(nil),              // 18: interpretation.ListReportSharesRequest
+	(*ListReportSharesResponse)(nil),             // 19: interpretation.ListReportSharesResponse
+	(*RevokeReportShareRequest)(nil),             // 20: interpretation.RevokeReportShareRequest
+	(*RevokeReportShareResponse)(nil),            // 21: interpretation.RevokeReportShareResponse
+	(*OpenReportShareRequest)(nil),               // 22: interpretation.OpenReportShareRequest
+	(*OpenReportShareResponse)(nil),              // 23: interpretation.OpenReportShareResponse
+	(*GenerateReportFromAssessmentRequest)(nil),  // 24: interpretation.GenerateReportFromAssessmentRequest
+	(*GenerateReportFromOutcomeRequest)(nil),     // 25: interpretation.GenerateReportFromOutcomeRequest
+	(*GenerateReportFromAssessmentResponse)(nil), // 26: interpretation.GenerateReportFromAssessmentResponse
+	(*evaluation.ScoreValue)(nil),                // 27: evaluation.ScoreValue
+	(*evaluation.ResultLevel)(nil),               // 28: evaluation.ResultLevel
+	(*evaluation.ModelIdentity)(nil),             // 29: evaluation.ModelIdentity
 }
 var file_interpretation_interpretation_proto_depIdxs = []int32{
-	13, // 0: interpretation.DimensionInterpret.derived_scores:type_name -> evaluation.ScoreValue
-	14, // 1: interpretation.DimensionInterpret.level:type_name -> evaluation.ResultLevel
+	27, // 0: interpretation.DimensionInterpret.derived_scores:type_name -> evaluation.ScoreValue
+	28, // 1: interpretation.DimensionInterpret.level:type_name -> evaluation.ResultLevel
 	1,  // 2: interpretation.DimensionInterpret.norm_reference:type_name -> interpretation.NormReference
 	3,  // 3: interpretation.ModelExtra.rarity:type_name -> interpretation.ModelRarity
 	2,  // 4: interpretation.AssessmentReport.dimensions:type_name -> interpretation.DimensionInterpret
 	0,  // 5: interpretation.AssessmentReport.suggestions:type_name -> interpretation.Suggestion
 	4,  // 6: interpretation.AssessmentReport.model_extra:type_name -> interpretation.ModelExtra
-	15, // 7: interpretation.AssessmentReport.model:type_name -> evaluation.ModelIdentity
-	13, // 8: interpretation.AssessmentReport.primary_score:type_name -> evaluation.ScoreValue
-	14, // 9: interpretation.AssessmentReport.level:type_name -> evaluation.ResultLevel
-	5,  // 10: interpretation.GetAssessmentReportResponse.report:type_name -> interpretation.AssessmentReport
-	5,  // 11: interpretation.ListMyReportsResponse.items:type_name -> interpretation.AssessmentReport
-	6,  // 12: interpretation.ParticipantReportService.GetAssessmentReport:input_type -> interpretation.GetAssessmentReportRequest
-	8,  // 13: interpretation.ParticipantReportService.ListMyReports:input_type -> interpretation.ListMyReportsRequest
-	11, // 14: interpretation.InterpretationAutomationService.GenerateReportFromOutcome:input_type -> interpretation.GenerateReportFromOutcomeRequest
-	10, // 15: interpretation.InterpretationAutomationService.GenerateReportFromAssessment:input_type -> interpretation.GenerateReportFromAssessmentRequest
-	7,  // 16: interpretation.ParticipantReportService.GetAssessmentReport:output_type -> interpretation.GetAssessmentReportResponse
-	9,  // 17: interpretation.ParticipantReportService.ListMyReports:output_type -> interpretation.ListMyReportsResponse
-	12, // 18: interpretation.InterpretationAutomationService.GenerateReportFromOutcome:output_type -> interpretation.GenerateReportFromAssessmentResponse
-	12, // 19: interpretation.InterpretationAutomationService.GenerateReportFromAssessment:output_type -> interpretation.GenerateReportFromAssessmentResponse
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	29, // 7: interpretation.AssessmentReport.model:type_name -> evaluation.ModelIdentity
+	27, // 8: interpretation.AssessmentReport.primary_score:type_name -> evaluation.ScoreValue
+	28, // 9: interpretation.AssessmentReport.level:type_name -> evaluation.ResultLevel
+	6,  // 10: interpretation.AssessmentReport.clinician_addendum:type_name -> interpretation.ClinicianAddendum
+	5,  // 11: interpretation.GetAssessmentReportResponse.report:type_name -> interpretation.AssessmentReport
+	5,  // 12: interpretation.ListMyReportsResponse.items:type_name -> interpretation.AssessmentReport
+	15, // 13: interpretation.CreateReportShareResponse.share:type_name -> interpretation.ReportShare
+	15, // 14: interpretation.ListReportSharesResponse.items:type_name -> interpretation.ReportShare
+	15, // 15: interpretation.RevokeReportShareResponse.share:type_name -> interpretation.ReportShare
+	5,  // 16: interpretation.OpenReportShareResponse.report:type_name -> interpretation.AssessmentReport
+	7,  // 17: interpretation.ParticipantReportService.GetAssessmentReport:input_type -> interpretation.GetAssessmentReportRequest
+	9,  // 18: interpretation.ParticipantReportService.ListMyReports:input_type -> interpretation.ListMyReportsRequest
+	11, // 19: interpretation.ParticipantReportService.IssueReportPDFLink:input_type -> interpretation.IssueReportPDFLinkRequest
+	13, // 20: interpretation.ParticipantReportService.DownloadReportPDF:input_type -> interpretation.DownloadReportPDFRequest
+	16, // 21: interpretation.ParticipantReportService.CreateReportShare:input_type -> interpretation.CreateReportShareRequest
+	18, // 22: interpretation.ParticipantReportService.ListReportShares:input_type -> interpretation.ListReportSharesRequest
+	20, // 23: interpretation.ParticipantReportService.RevokeReportShare:input_type -> interpretation.RevokeReportShareRequest
+	22, // 24: interpretation.ParticipantReportService.OpenReportShare:input_type -> interpretation.OpenReportShareRequest
+	25, // 25: interpretation.InterpretationAutomationService.GenerateReportFromOutcome:input_type -> interpretation.GenerateReportFromOutcomeRequest
+	24, // 26: interpretation.InterpretationAutomationService.GenerateReportFromAssessment:input_type -> interpretation.GenerateReportFromAssessmentRequest
+	8,  // 27: interpretation.ParticipantReportService.GetAssessmentReport:output_type -> interpretation.GetAssessmentReportResponse
+	10, // 28: interpretation.ParticipantReportService.ListMyReports:output_type -> interpretation.ListMyReportsResponse
+	12, // 29: interpretation.ParticipantReportService.IssueReportPDFLink:output_type -> interpretation.IssueReportPDFLinkResponse
+	14, // 30: interpretation.ParticipantReportService.DownloadReportPDF:output_type -> interpretation.DownloadReportPDFResponse
+	17, // 31: interpretation.ParticipantReportService.CreateReportShare:output_type -> interpretation.CreateReportShareResponse
+	19, // 32: interpretation.ParticipantReportService.ListReportShares:output_type -> interpretation.ListReportSharesResponse
+	21, // 33: interpretation.ParticipantReportService.RevokeReportShare:output_type -> interpretation.RevokeReportShareResponse
+	23, // 34: interpretation.ParticipantReportService.OpenReportShare:output_type -> interpretation.OpenReportShareResponse
+	26, // 35: interpretation.InterpretationAutomationService.GenerateReportFromOutcome:output_type -> interpretation.GenerateReportFromAssessmentResponse
+	26, // 36: interpretation.InterpretationAutomationService.GenerateReportFromAssessment:output_type -> interpretation.GenerateReportFromAssessmentResponse
+	27, // [27:37] is the sub-list for method output_type
+	17, // [17:27] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_interpretation_interpretation_proto_init() }
@@ -1270,7 +2323,7 @@ func file_interpretation_interpretation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interpretation_interpretation_proto_rawDesc), len(file_interpretation_interpretation_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
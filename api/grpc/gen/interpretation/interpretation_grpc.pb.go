@@ -21,6 +21,12 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	ParticipantReportService_GetAssessmentReport_FullMethodName = "/interpretation.ParticipantReportService/GetAssessmentReport"
 	ParticipantReportService_ListMyReports_FullMethodName       = "/interpretation.ParticipantReportService/ListMyReports"
+	ParticipantReportService_IssueReportPDFLink_FullMethodName  = "/interpretation.ParticipantReportService/IssueReportPDFLink"
+	ParticipantReportService_DownloadReportPDF_FullMethodName   = "/interpretation.ParticipantReportService/DownloadReportPDF"
+	ParticipantReportService_CreateReportShare_FullMethodName   = "/interpretation.ParticipantReportService/CreateReportShare"
+	ParticipantReportService_ListReportShares_FullMethodName    = "/interpretation.ParticipantReportService/ListReportShares"
+	ParticipantReportService_RevokeReportShare_FullMethodName   = "/interpretation.ParticipantReportService/RevokeReportShare"
+	ParticipantReportService_OpenReportShare_FullMethodName     = "/interpretation.ParticipantReportService/OpenReportShare"
 )
 
 // ParticipantReportServiceClient is the client API for ParticipantReportService service.
@@ -29,6 +35,12 @@ const (
 type ParticipantReportServiceClient interface {
 	GetAssessmentReport(ctx context.Context, in *GetAssessmentReportRequest, opts ...grpc.CallOption) (*GetAssessmentReportResponse, error)
 	ListMyReports(ctx context.Context, in *ListMyReportsRequest, opts ...grpc.CallOption) (*ListMyReportsResponse, error)
+	IssueReportPDFLink(ctx context.Context, in *IssueReportPDFLinkRequest, opts ...grpc.CallOption) (*IssueReportPDFLinkResponse, error)
+	DownloadReportPDF(ctx context.Context, in *DownloadReportPDFRequest, opts ...grpc.CallOption) (*DownloadReportPDFResponse, error)
+	CreateReportShare(ctx context.Context, in *CreateReportShareRequest, opts ...grpc.CallOption) (*CreateReportShareResponse, error)
+	ListReportShares(ctx context.Context, in *ListReportSharesRequest, opts ...grpc.CallOption) (*ListReportSharesResponse, error)
+	RevokeReportShare(ctx context.Context, in *RevokeReportShareRequest, opts ...grpc.CallOption) (*RevokeReportShareResponse, error)
+	OpenReportShare(ctx context.Context, in *OpenReportShareRequest, opts ...grpc.CallOption) (*OpenReportShareResponse, error)
 }
 
 type participantReportServiceClient struct {
@@ -59,12 +71,78 @@ func (c *participantReportServiceClient) ListMyReports(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *participantReportServiceClient) IssueReportPDFLink(ctx context.Context, in *IssueReportPDFLinkRequest, opts ...grpc.CallOption) (*IssueReportPDFLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueReportPDFLinkResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_IssueReportPDFLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantReportServiceClient) DownloadReportPDF(ctx context.Context, in *DownloadReportPDFRequest, opts ...grpc.CallOption) (*DownloadReportPDFResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadReportPDFResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_DownloadReportPDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantReportServiceClient) CreateReportShare(ctx context.Context, in *CreateReportShareRequest, opts ...grpc.CallOption) (*CreateReportShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateReportShareResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_CreateReportShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantReportServiceClient) ListReportShares(ctx context.Context, in *ListReportSharesRequest, opts ...grpc.CallOption) (*ListReportSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportSharesResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_ListReportShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantReportServiceClient) RevokeReportShare(ctx context.Context, in *RevokeReportShareRequest, opts ...grpc.CallOption) (*RevokeReportShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeReportShareResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_RevokeReportShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantReportServiceClient) OpenReportShare(ctx context.Context, in *OpenReportShareRequest, opts ...grpc.CallOption) (*OpenReportShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenReportShareResponse)
+	err := c.cc.Invoke(ctx, ParticipantReportService_OpenReportShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ParticipantReportServiceServer is the server API for ParticipantReportService service.
 // All implementations must embed UnimplementedParticipantReportServiceServer
 // for forward compatibility.
 type ParticipantReportServiceServer interface {
 	GetAssessmentReport(context.Context, *GetAssessmentReportRequest) (*GetAssessmentReportResponse, error)
 	ListMyReports(context.Context, *ListMyReportsRequest) (*ListMyReportsResponse, error)
+	IssueReportPDFLink(context.Context, *IssueReportPDFLinkRequest) (*IssueReportPDFLinkResponse, error)
+	DownloadReportPDF(context.Context, *DownloadReportPDFRequest) (*DownloadReportPDFResponse, error)
+	CreateReportShare(context.Context, *CreateReportShareRequest) (*CreateReportShareResponse, error)
+	ListReportShares(context.Context, *ListReportSharesRequest) (*ListReportSharesResponse, error)
+	RevokeReportShare(context.Context, *RevokeReportShareRequest) (*RevokeReportShareResponse, error)
+	OpenReportShare(context.Context, *OpenReportShareRequest) (*OpenReportShareResponse, error)
 	mustEmbedUnimplementedParticipantReportServiceServer()
 }
 
@@ -81,6 +159,24 @@ func (UnimplementedParticipantReportServiceServer) GetAssessmentReport(context.C
 func (UnimplementedParticipantReportServiceServer) ListMyReports(context.Context, *ListMyReportsRequest) (*ListMyReportsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListMyReports not implemented")
 }
+func (UnimplementedParticipantReportServiceServer) IssueReportPDFLink(context.Context, *IssueReportPDFLinkRequest) (*IssueReportPDFLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueReportPDFLink not implemented")
+}
+func (UnimplementedParticipantReportServiceServer) DownloadReportPDF(context.Context, *DownloadReportPDFRequest) (*DownloadReportPDFResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownloadReportPDF not implemented")
+}
+func (UnimplementedParticipantReportServiceServer) CreateReportShare(context.Context, *CreateReportShareRequest) (*CreateReportShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateReportShare not implemented")
+}
+func (UnimplementedParticipantReportServiceServer) ListReportShares(context.Context, *ListReportSharesRequest) (*ListReportSharesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReportShares not implemented")
+}
+func (UnimplementedParticipantReportServiceServer) RevokeReportShare(context.Context, *RevokeReportShareRequest) (*RevokeReportShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeReportShare not implemented")
+}
+func (UnimplementedParticipantReportServiceServer) OpenReportShare(context.Context, *OpenReportShareRequest) (*OpenReportShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method OpenReportShare not implemented")
+}
 func (UnimplementedParticipantReportServiceServer) mustEmbedUnimplementedParticipantReportServiceServer() {
 }
 func (UnimplementedParticipantReportServiceServer) testEmbeddedByValue() {}
@@ -139,6 +235,114 @@ func _ParticipantReportService_ListMyReports_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ParticipantReportService_IssueReportPDFLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueReportPDFLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).IssueReportPDFLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_IssueReportPDFLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).IssueReportPDFLink(ctx, req.(*IssueReportPDFLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantReportService_DownloadReportPDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadReportPDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).DownloadReportPDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_DownloadReportPDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).DownloadReportPDF(ctx, req.(*DownloadReportPDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantReportService_CreateReportShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReportShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).CreateReportShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_CreateReportShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).CreateReportShare(ctx, req.(*CreateReportShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantReportService_ListReportShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).ListReportShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_ListReportShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).ListReportShares(ctx, req.(*ListReportSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantReportService_RevokeReportShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeReportShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).RevokeReportShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_RevokeReportShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).RevokeReportShare(ctx, req.(*RevokeReportShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantReportService_OpenReportShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenReportShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantReportServiceServer).OpenReportShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantReportService_OpenReportShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantReportServiceServer).OpenReportShare(ctx, req.(*OpenReportShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ParticipantReportService_ServiceDesc is the grpc.ServiceDesc for ParticipantReportService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +358,30 @@ var ParticipantReportService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMyReports",
 			Handler:    _ParticipantReportService_ListMyReports_Handler,
 		},
+		{
+			MethodName: "IssueReportPDFLink",
+			Handler:    _ParticipantReportService_IssueReportPDFLink_Handler,
+		},
+		{
+			MethodName: "DownloadReportPDF",
+			Handler:    _ParticipantReportService_DownloadReportPDF_Handler,
+		},
+		{
+			MethodName: "CreateReportShare",
+			Handler:    _ParticipantReportService_CreateReportShare_Handler,
+		},
+		{
+			MethodName: "ListReportShares",
+			Handler:    _ParticipantReportService_ListReportShares_Handler,
+		},
+		{
+			MethodName: "RevokeReportShare",
+			Handler:    _ParticipantReportService_RevokeReportShare_Handler,
+		},
+		{
+			MethodName: "OpenReportShare",
+			Handler:    _ParticipantReportService_OpenReportShare_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "interpretation/interpretation.proto",
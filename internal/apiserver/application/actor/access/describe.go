@@ -0,0 +1,113 @@
+package access
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	iambridge "github.com/FangcunMount/qs-server/internal/apiserver/port/iambridge"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+// NewTesteeAccessDescriber 创建访问审计使用的授权依据解析器。
+// 与 ValidateTesteeAccess 使用同一套读模型与 sources，但不做拒绝判断：被拒绝的访问同样需要记录角色与关系。
+func NewTesteeAccessDescriber(
+	operatorReader actorreadmodel.OperatorReader,
+	clinicianReader actorreadmodel.ClinicianReader,
+	relationReader actorreadmodel.RelationReader,
+	snapshot iambridge.AuthzSnapshotReader,
+	sources Sources,
+) accessaudit.AccessResolver {
+	return &service{
+		operatorReader:  operatorReader,
+		clinicianReader: clinicianReader,
+		relationReader:  relationReader,
+		snapshot:        snapshot,
+		sources:         sources,
+		now:             time.Now,
+	}
+}
+
+// DescribeTesteeAccess 解析操作者角色及其与受试者之间生效中的授权关系。
+func (s *service) DescribeTesteeAccess(ctx context.Context, orgID int64, operatorUserID int64, testeeID uint64) (*accessaudit.AccessBasis, error) {
+	operatorItem, err := s.operatorReader.FindOperatorByUser(ctx, orgID, operatorUserID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return &accessaudit.AccessBasis{ActorRole: accessaudit.ActorRoleUnresolved, Relation: accessaudit.RelationNone}, nil
+		}
+		return nil, errors.Wrap(err, "failed to find operator")
+	}
+	snap, err := s.resolveAuthzSnapshot(ctx, orgID, operatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if snap.IsQSAdmin() {
+		return &accessaudit.AccessBasis{ActorRole: accessaudit.ActorRoleQSAdmin, Relation: accessaudit.RelationOrgAdmin}, nil
+	}
+
+	clinicianItem, err := s.clinicianReader.FindClinicianByOperator(ctx, orgID, operatorItem.ID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return &accessaudit.AccessBasis{ActorRole: accessaudit.ActorRoleOperator, Relation: accessaudit.RelationNone}, nil
+		}
+		return nil, errors.Wrap(err, "failed to find clinician by operator")
+	}
+	if testeeID == 0 {
+		return &accessaudit.AccessBasis{ActorRole: accessaudit.ActorRoleClinician, Relation: accessaudit.RelationUnknown}, nil
+	}
+	rows, err := s.relationReader.ListTesteeRelations(ctx, actorreadmodel.RelationFilter{
+		OrgID:         orgID,
+		ClinicianID:   clinicianItem.ID,
+		TesteeID:      testeeID,
+		RelationTypes: accessRelationTypesToStrings(domainRelation.AccessGrantRelationTypes()),
+		ActiveOnly:    true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list testee relations")
+	}
+	relation := joinRelationTypes(rows, clinicianItem.ID)
+	if relation == accessaudit.RelationNone && s.sources.CareTeams != nil {
+		inherited, err := s.sources.CareTeams.HasTeamAccess(ctx, orgID, clinicianItem.ID, testeeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check care team access")
+		}
+		if inherited {
+			relation = accessaudit.RelationCareTeam
+		}
+	}
+	if relation == accessaudit.RelationNone && s.sources.BreakGlass != nil {
+		granted, err := s.sources.BreakGlass.HasActiveGrant(ctx, orgID, clinicianItem.ID, testeeID, s.now())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check break-glass grant")
+		}
+		if granted {
+			relation = accessaudit.RelationBreakGlass
+		}
+	}
+	return &accessaudit.AccessBasis{ActorRole: accessaudit.ActorRoleClinician, Relation: relation}, nil
+}
+
+func joinRelationTypes(rows []actorreadmodel.TesteeRelationRow, clinicianID uint64) string {
+	seen := make(map[string]struct{}, len(rows))
+	types := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Relation.ClinicianID != clinicianID || !row.Relation.IsActive {
+			continue
+		}
+		if _, ok := seen[row.Relation.RelationType]; ok {
+			continue
+		}
+		seen[row.Relation.RelationType] = struct{}{}
+		types = append(types, row.Relation.RelationType)
+	}
+	if len(types) == 0 {
+		return accessaudit.RelationNone
+	}
+	sort.Strings(types)
+	return strings.Join(types, ",")
+}
@@ -13,4 +13,6 @@ type TesteeAccessService interface {
 	ResolveAccessScope(ctx context.Context, orgID int64, operatorUserID int64) (*TesteeAccessScope, error)
 	ValidateTesteeAccess(ctx context.Context, orgID int64, operatorUserID int64, testeeID uint64) error
 	ListAccessibleTesteeIDs(ctx context.Context, orgID int64, operatorUserID int64) ([]uint64, error)
+	// ResolveTesteeRedaction 按查看者与各受试者的关系解析受试者 PII 脱敏策略。
+	ResolveTesteeRedaction(ctx context.Context, orgID int64, operatorUserID int64, testeeIDs []uint64) (TesteeRedaction, error)
 }
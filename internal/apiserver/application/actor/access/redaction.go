@@ -0,0 +1,135 @@
+package access
+
+import (
+	"context"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+)
+
+// TesteeRedactionPolicy 按查看者能力与其和受试者的关系类型决定 PII 脱敏策略：
+//   - 机构管理员（org_admin）与存在授权关系（assigned/primary/attending/collaborator）的从业者：不脱敏；
+//   - 只有来源关系（creator）的从业者：姓名打码、生日换为年龄段、隐藏档案ID与监护人联系方式；
+//   - 统计人员（read_statistics）：去标识，不含姓名、ID 假名化；
+//   - 其余（含缺少授权快照）：按 creator 处理。
+//
+// 显式解除脱敏走 unmask_testee_pii 能力与访问审计，不由本策略放开。
+func TesteeRedactionPolicy(snapshot *authzapp.Snapshot, relationTypes ...domainRelation.RelationType) redaction.Policy {
+	if isOrgAdmin(snapshot) {
+		return redaction.Full
+	}
+	for _, relationType := range relationTypes {
+		if domainRelation.GrantsAccess(relationType) {
+			return redaction.Full
+		}
+	}
+	if len(relationTypes) == 0 && snapshot != nil && authzapp.DecideCapability(snapshot, authzapp.CapabilityReadStatistics).Allowed {
+		return redaction.Deidentified
+	}
+	return redaction.Restricted
+}
+
+// ExportRedactionPolicy 导出文件离开系统后不再受访问控制，一律去标识：ID 假名化、不含姓名与联系方式。
+func ExportRedactionPolicy() redaction.Policy {
+	return redaction.Deidentified
+}
+
+// TesteeRedaction 查看者对一批受试者的脱敏策略。
+type TesteeRedaction map[uint64]redaction.Policy
+
+// PolicyFor 返回受试者的脱敏策略；未解析到的受试者按 Restricted 处理，不因遗漏而放开。
+func (r TesteeRedaction) PolicyFor(testeeID uint64) redaction.Policy {
+	if policy, ok := r[testeeID]; ok {
+		return policy
+	}
+	return redaction.Restricted
+}
+
+// ResolveTesteeRedaction 按查看者与各受试者之间生效中的关系批量解析脱敏策略。
+// 经由照护团队继承或生效中的紧急访问授权与授权关系同等对待；授权快照取自 context。
+func (s *service) ResolveTesteeRedaction(ctx context.Context, orgID int64, operatorUserID int64, testeeIDs []uint64) (TesteeRedaction, error) {
+	result := make(TesteeRedaction, len(testeeIDs))
+	if len(testeeIDs) == 0 {
+		return result, nil
+	}
+	snapshot, _ := authzapp.FromContext(ctx)
+	if isOrgAdmin(snapshot) {
+		for _, testeeID := range testeeIDs {
+			result[testeeID] = redaction.Full
+		}
+		return result, nil
+	}
+
+	relationTypes := make(map[uint64][]domainRelation.RelationType, len(testeeIDs))
+	inherited := make(map[uint64]struct{})
+	clinicianID, err := s.findViewerClinicianID(ctx, orgID, operatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if clinicianID > 0 {
+		rows, err := s.relationReader.ListTesteeRelations(ctx, actorreadmodel.RelationFilter{
+			OrgID:       orgID,
+			ClinicianID: clinicianID,
+			TesteeIDs:   testeeIDs,
+			ActiveOnly:  true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list viewer relations")
+		}
+		for _, row := range rows {
+			if row.Relation.ClinicianID != clinicianID || !row.Relation.IsActive {
+				continue
+			}
+			relationTypes[row.Relation.TesteeID] = append(relationTypes[row.Relation.TesteeID], domainRelation.RelationType(row.Relation.RelationType))
+		}
+		inheritedIDs, err := s.sources.InheritedTesteeIDs(ctx, orgID, clinicianID, s.now())
+		if err != nil {
+			return nil, err
+		}
+		for _, testeeID := range inheritedIDs {
+			inherited[testeeID] = struct{}{}
+		}
+	}
+
+	for _, testeeID := range testeeIDs {
+		if _, ok := inherited[testeeID]; ok {
+			result[testeeID] = redaction.Full
+			continue
+		}
+		result[testeeID] = TesteeRedactionPolicy(snapshot, relationTypes[testeeID]...)
+	}
+	return result, nil
+}
+
+// findViewerClinicianID 查看者在机构下绑定的从业者；未登记为操作者或未绑定从业者时返回 0。
+func (s *service) findViewerClinicianID(ctx context.Context, orgID int64, operatorUserID int64) (uint64, error) {
+	if s.operatorReader == nil || s.clinicianReader == nil || operatorUserID <= 0 {
+		return 0, nil
+	}
+	operatorItem, err := s.operatorReader.FindOperatorByUser(ctx, orgID, operatorUserID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to find operator")
+	}
+	clinicianItem, err := s.clinicianReader.FindClinicianByOperator(ctx, orgID, operatorItem.ID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to find clinician by operator")
+	}
+	if !clinicianItem.IsActive {
+		return 0, nil
+	}
+	return clinicianItem.ID, nil
+}
+
+func isOrgAdmin(snapshot *authzapp.Snapshot) bool {
+	return snapshot != nil && authzapp.DecideCapability(snapshot, authzapp.CapabilityOrgAdmin).Allowed
+}
@@ -2,8 +2,11 @@ package access
 
 import (
 	"context"
+	"time"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
 	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
@@ -18,15 +21,27 @@ type service struct {
 	relationReader  actorreadmodel.RelationReader
 	testeeReader    actorreadmodel.TesteeReader
 	snapshot        iambridge.AuthzSnapshotReader
+	sources         Sources
+	now             func() time.Time
+}
+
+// Sources 授权关系之外的可选访问来源；为空的来源不参与判定。
+type Sources struct {
+	// BreakGlass 生效中的紧急访问授权。
+	BreakGlass breakglass.ActiveGrantReader
+	// CareTeams 经由照护团队成员身份继承的访问。
+	CareTeams careteam.AccessReader
 }
 
 // NewTesteeAccessService 创建 testee 访问控制服务。
+// sources 中提供的来源与授权关系同等放行；为空的来源不参与判定。
 func NewTesteeAccessService(
 	operatorReader actorreadmodel.OperatorReader,
 	clinicianReader actorreadmodel.ClinicianReader,
 	relationReader actorreadmodel.RelationReader,
 	testeeReader actorreadmodel.TesteeReader,
 	snapshot iambridge.AuthzSnapshotReader,
+	sources Sources,
 ) TesteeAccessService {
 	return &service{
 		operatorReader:  operatorReader,
@@ -34,6 +49,8 @@ func NewTesteeAccessService(
 		relationReader:  relationReader,
 		testeeReader:    testeeReader,
 		snapshot:        snapshot,
+		sources:         sources,
+		now:             time.Now,
 	}
 }
 
@@ -112,6 +129,18 @@ func (s *service) ValidateTesteeAccess(ctx context.Context, orgID int64, operato
 	if err != nil {
 		return errors.Wrap(err, "failed to validate testee relation access")
 	}
+	if !allowed && s.sources.CareTeams != nil {
+		allowed, err = s.sources.CareTeams.HasTeamAccess(ctx, orgID, *scope.ClinicianID, testeeID)
+		if err != nil {
+			return errors.Wrap(err, "failed to validate care team access")
+		}
+	}
+	if !allowed && s.sources.BreakGlass != nil {
+		allowed, err = s.sources.BreakGlass.HasActiveGrant(ctx, orgID, *scope.ClinicianID, testeeID, s.now())
+		if err != nil {
+			return errors.Wrap(err, "failed to validate break-glass access")
+		}
+	}
 	if !allowed {
 		return errors.WithCode(code.ErrPermissionDenied, "testee is not assigned to current clinician")
 	}
@@ -138,6 +167,11 @@ func (s *service) ListAccessibleTesteeIDs(ctx context.Context, orgID int64, oper
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list accessible testee ids")
 	}
+	inheritedIDs, err := s.sources.InheritedTesteeIDs(ctx, orgID, *scope.ClinicianID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	ids = append(ids, inheritedIDs...)
 
 	seen := make(map[uint64]struct{}, len(ids))
 	result := make([]uint64, 0, len(ids))
@@ -151,6 +185,29 @@ func (s *service) ListAccessibleTesteeIDs(ctx context.Context, orgID int64, oper
 	return result, nil
 }
 
+// InheritedTesteeIDs 从业者经由照护团队或生效中的紧急访问授权获得访问的受试者，可能重复。
+// 访问控制与临床工作台共用此合并逻辑，保证两处的可见范围一致。
+func (src Sources) InheritedTesteeIDs(ctx context.Context, orgID int64, clinicianID uint64, now time.Time) ([]uint64, error) {
+	var ids []uint64
+	if src.CareTeams != nil {
+		teamIDs, err := src.CareTeams.ListAccessibleTesteeIDs(ctx, orgID, clinicianID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list care team testee ids")
+		}
+		ids = append(ids, teamIDs...)
+	}
+	if src.BreakGlass != nil {
+		grants, err := src.BreakGlass.ListActiveGrants(ctx, breakglass.ActiveGrantQuery{OrgID: orgID, ClinicianID: clinicianID}, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list break-glass grants")
+		}
+		for _, grant := range grants {
+			ids = append(ids, grant.TesteeID)
+		}
+	}
+	return ids, nil
+}
+
 func ensureAccessIDFromUint64(field string, value uint64) error {
 	_, err := safeconv.Uint64ToMetaID(value)
 	if err != nil {
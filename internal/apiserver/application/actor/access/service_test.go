@@ -3,13 +3,17 @@ package access
 import (
 	"context"
 	"testing"
+	"time"
 
 	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
 	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
 	iambridge "github.com/FangcunMount/qs-server/internal/apiserver/port/iambridge"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 )
 
 func TestResolveAccessScopeLoadsSnapshotThroughReaderWhenContextMissing(t *testing.T) {
@@ -21,6 +25,7 @@ func TestResolveAccessScopeLoadsSnapshotThroughReaderWhenContextMissing(t *testi
 		nil,
 		nil,
 		reader,
+		Sources{},
 	)
 
 	scope, err := svc.ResolveAccessScope(context.Background(), 1, 101)
@@ -47,6 +52,7 @@ func TestResolveAccessScopeUsesContextSnapshotBeforeReader(t *testing.T) {
 		nil,
 		nil,
 		reader,
+		Sources{},
 	)
 
 	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{Roles: []string{"qs:admin"}})
@@ -70,6 +76,7 @@ func TestResolveAccessScopeRejectsWhenSnapshotReaderMissing(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		Sources{},
 	)
 
 	_, err := svc.ResolveAccessScope(context.Background(), 1, 101)
@@ -93,6 +100,7 @@ func TestValidateTesteeAccessUsesAccessGrantRelations(t *testing.T) {
 		relationRepo,
 		&stubTesteeReader{item: testeeItem},
 		nil,
+		Sources{},
 	)
 
 	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{})
@@ -111,6 +119,158 @@ func TestValidateTesteeAccessUsesAccessGrantRelations(t *testing.T) {
 	}
 }
 
+func TestDescribeTesteeAccessRecordsClinicianRelations(t *testing.T) {
+	operatorItem := actorreadmodel.OperatorRow{ID: 201, OrgID: 1, UserID: 101, Name: "operator", IsActive: true}
+	clinicianItem := actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, Name: "clinician", IsActive: true}
+	relations := &stubTesteeRelationLister{rows: []actorreadmodel.TesteeRelationRow{
+		{Relation: actorreadmodel.RelationRow{ClinicianID: 301, TesteeID: 401, RelationType: "primary", IsActive: true}},
+		{Relation: actorreadmodel.RelationRow{ClinicianID: 301, TesteeID: 401, RelationType: "collaborator", IsActive: true}},
+	}}
+	describer := NewTesteeAccessDescriber(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, relations, nil, Sources{})
+
+	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{})
+	basis, err := describer.DescribeTesteeAccess(ctx, 1, 101, 401)
+	if err != nil {
+		t.Fatalf("DescribeTesteeAccess() error = %v", err)
+	}
+	if basis.ActorRole != "clinician" || basis.Relation != "collaborator,primary" {
+		t.Fatalf("basis = %+v", basis)
+	}
+	if relations.filter.ClinicianID != 301 || relations.filter.TesteeID != 401 || !relations.filter.ActiveOnly {
+		t.Fatalf("relation filter = %+v", relations.filter)
+	}
+
+	adminCtx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{Roles: []string{"qs:admin"}})
+	basis, err = describer.DescribeTesteeAccess(adminCtx, 1, 101, 401)
+	if err != nil || basis.ActorRole != "qs_admin" || basis.Relation != "org_admin" {
+		t.Fatalf("admin basis = %+v, %v", basis, err)
+	}
+}
+
+func TestTesteeAccessFallsBackToActiveBreakGlassGrant(t *testing.T) {
+	operatorItem := actorreadmodel.OperatorRow{ID: 201, OrgID: 1, UserID: 101, Name: "operator", IsActive: true}
+	clinicianItem := actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, Name: "clinician", IsActive: true}
+	testeeItem := actorreadmodel.TesteeRow{ID: 402, OrgID: 1, Name: "child"}
+	grants := &stubBreakGlassReader{testeeIDs: []uint64{402}}
+	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{})
+
+	withoutGrant := NewTesteeAccessService(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, &stubRelationReader{}, &stubTesteeReader{item: testeeItem}, nil, Sources{})
+	if err := withoutGrant.ValidateTesteeAccess(ctx, 1, 101, 402); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("expected permission denied without break-glass, got %v", err)
+	}
+
+	svc := NewTesteeAccessService(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, &stubRelationReader{}, &stubTesteeReader{item: testeeItem}, nil, Sources{BreakGlass: grants})
+	if err := svc.ValidateTesteeAccess(ctx, 1, 101, 402); err != nil {
+		t.Fatalf("expected break-glass grant to allow access: %v", err)
+	}
+	ids, err := svc.ListAccessibleTesteeIDs(ctx, 1, 101)
+	if err != nil {
+		t.Fatalf("ListAccessibleTesteeIDs() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 401 || ids[1] != 402 {
+		t.Fatalf("accessible ids = %v, want [401 402]", ids)
+	}
+	if grants.clinicianID != 301 {
+		t.Fatalf("break-glass lookup clinician = %d, want 301", grants.clinicianID)
+	}
+
+	relations := &stubTesteeRelationLister{}
+	describer := NewTesteeAccessDescriber(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, relations, nil, Sources{BreakGlass: grants})
+	basis, err := describer.DescribeTesteeAccess(ctx, 1, 101, 402)
+	if err != nil || basis.Relation != "break_glass" {
+		t.Fatalf("break-glass basis = %+v, %v", basis, err)
+	}
+}
+
+func TestTesteeAccessInheritsCareTeamMembership(t *testing.T) {
+	operatorItem := actorreadmodel.OperatorRow{ID: 201, OrgID: 1, UserID: 101, Name: "operator", IsActive: true}
+	clinicianItem := actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, Name: "clinician", IsActive: true}
+	testeeItem := actorreadmodel.TesteeRow{ID: 403, OrgID: 1, Name: "child"}
+	teams := &stubCareTeamReader{testeeIDs: []uint64{401, 403}}
+	grants := &stubBreakGlassReader{testeeIDs: []uint64{403}}
+	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{})
+
+	svc := NewTesteeAccessService(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, &stubRelationReader{}, &stubTesteeReader{item: testeeItem}, nil,
+		Sources{BreakGlass: grants, CareTeams: teams})
+	if err := svc.ValidateTesteeAccess(ctx, 1, 101, 403); err != nil {
+		t.Fatalf("expected care team membership to allow access: %v", err)
+	}
+	if teams.clinicianID != 301 {
+		t.Fatalf("care team lookup clinician = %d, want 301", teams.clinicianID)
+	}
+	ids, err := svc.ListAccessibleTesteeIDs(ctx, 1, 101)
+	if err != nil {
+		t.Fatalf("ListAccessibleTesteeIDs() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 401 || ids[1] != 403 {
+		t.Fatalf("accessible ids = %v, want [401 403]", ids)
+	}
+
+	// 团队继承优先于紧急访问记录为访问依据。
+	describer := NewTesteeAccessDescriber(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, &stubTesteeRelationLister{}, nil,
+		Sources{BreakGlass: grants, CareTeams: teams})
+	basis, err := describer.DescribeTesteeAccess(ctx, 1, 101, 403)
+	if err != nil || basis.Relation != "care_team" {
+		t.Fatalf("care team basis = %+v, %v", basis, err)
+	}
+}
+
+type stubCareTeamReader struct {
+	careteam.AccessReader
+	testeeIDs   []uint64
+	clinicianID uint64
+}
+
+func (s *stubCareTeamReader) HasTeamAccess(_ context.Context, _ int64, clinicianID, testeeID uint64) (bool, error) {
+	s.clinicianID = clinicianID
+	for _, id := range s.testeeIDs {
+		if id == testeeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *stubCareTeamReader) ListAccessibleTesteeIDs(_ context.Context, _ int64, clinicianID uint64) ([]uint64, error) {
+	s.clinicianID = clinicianID
+	return s.testeeIDs, nil
+}
+
+type stubBreakGlassReader struct {
+	testeeIDs   []uint64
+	clinicianID uint64
+}
+
+func (s *stubBreakGlassReader) HasActiveGrant(_ context.Context, _ int64, clinicianID, testeeID uint64, _ time.Time) (bool, error) {
+	s.clinicianID = clinicianID
+	for _, id := range s.testeeIDs {
+		if id == testeeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *stubBreakGlassReader) ListActiveGrants(_ context.Context, query breakglass.ActiveGrantQuery, _ time.Time) ([]breakglass.Grant, error) {
+	s.clinicianID = query.ClinicianID
+	grants := make([]breakglass.Grant, 0, len(s.testeeIDs))
+	for _, id := range s.testeeIDs {
+		grants = append(grants, breakglass.Grant{ClinicianID: query.ClinicianID, TesteeID: id})
+	}
+	return grants, nil
+}
+
+type stubTesteeRelationLister struct {
+	stubRelationReader
+	rows   []actorreadmodel.TesteeRelationRow
+	filter actorreadmodel.RelationFilter
+}
+
+func (s *stubTesteeRelationLister) ListTesteeRelations(_ context.Context, filter actorreadmodel.RelationFilter) ([]actorreadmodel.TesteeRelationRow, error) {
+	s.filter = filter
+	return s.rows, nil
+}
+
 type stubOperatorReader struct {
 	item actorreadmodel.OperatorRow
 }
@@ -219,3 +379,64 @@ func (s *stubAuthzSnapshotReader) LoadAuthzSnapshot(_ context.Context, orgID, us
 	}
 	return s.snapshot, nil
 }
+
+func TestTesteeRedactionPolicyByCapabilityAndRelation(t *testing.T) {
+	admin := &authzapp.Snapshot{Roles: []string{"qs:admin"}}
+	statistician := &authzapp.Snapshot{Permissions: []authzapp.Permission{{Resource: "qs:statistics", Action: "read"}}}
+	clinician := &authzapp.Snapshot{}
+
+	cases := []struct {
+		name      string
+		snapshot  *authzapp.Snapshot
+		relations []domainRelation.RelationType
+		want      redaction.Policy
+	}{
+		{"admin", admin, nil, redaction.Full},
+		{"attending clinician", clinician, []domainRelation.RelationType{domainRelation.RelationTypeAttending}, redaction.Full},
+		{"creator and collaborator", clinician, []domainRelation.RelationType{domainRelation.RelationTypeCreator, domainRelation.RelationTypeCollaborator}, redaction.Full},
+		{"creator only", clinician, []domainRelation.RelationType{domainRelation.RelationTypeCreator}, redaction.Restricted},
+		{"statistician", statistician, nil, redaction.Deidentified},
+		{"statistician with creator relation", statistician, []domainRelation.RelationType{domainRelation.RelationTypeCreator}, redaction.Restricted},
+		{"missing snapshot", nil, nil, redaction.Restricted},
+	}
+	for _, tc := range cases {
+		if got := TesteeRedactionPolicy(tc.snapshot, tc.relations...); got != tc.want {
+			t.Fatalf("%s: policy = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestResolveTesteeRedactionUsesViewerRelationsAndInheritedAccess(t *testing.T) {
+	operatorItem := actorreadmodel.OperatorRow{ID: 201, OrgID: 1, UserID: 101, Name: "operator", IsActive: true}
+	clinicianItem := actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, Name: "clinician", IsActive: true}
+	relations := &stubTesteeRelationLister{rows: []actorreadmodel.TesteeRelationRow{
+		{Relation: actorreadmodel.RelationRow{ClinicianID: 301, TesteeID: 401, RelationType: "creator", IsActive: true}},
+		{Relation: actorreadmodel.RelationRow{ClinicianID: 301, TesteeID: 402, RelationType: "primary", IsActive: true}},
+	}}
+	svc := NewTesteeAccessService(&stubOperatorReader{item: operatorItem}, &stubClinicianReader{item: clinicianItem}, relations, nil, nil,
+		Sources{CareTeams: &stubCareTeamReader{testeeIDs: []uint64{403}}, BreakGlass: &stubBreakGlassReader{}})
+
+	ctx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{})
+	policies, err := svc.ResolveTesteeRedaction(ctx, 1, 101, []uint64{401, 402, 403, 404})
+	if err != nil {
+		t.Fatalf("ResolveTesteeRedaction() error = %v", err)
+	}
+	want := map[uint64]redaction.Policy{401: redaction.Restricted, 402: redaction.Full, 403: redaction.Full, 404: redaction.Restricted}
+	for testeeID, policy := range want {
+		if got := policies.PolicyFor(testeeID); got != policy {
+			t.Fatalf("testee %d policy = %+v, want %+v", testeeID, got, policy)
+		}
+	}
+	if relations.filter.ClinicianID != 301 || len(relations.filter.TesteeIDs) != 4 || !relations.filter.ActiveOnly {
+		t.Fatalf("relation filter = %+v", relations.filter)
+	}
+	if got := policies.PolicyFor(999); got != redaction.Restricted {
+		t.Fatalf("unresolved testee policy = %+v, want restricted", got)
+	}
+
+	adminCtx := authzapp.WithSnapshot(context.Background(), &authzapp.Snapshot{Roles: []string{"qs:admin"}})
+	policies, err = svc.ResolveTesteeRedaction(adminCtx, 1, 101, []uint64{401})
+	if err != nil || policies.PolicyFor(401) != redaction.Full {
+		t.Fatalf("admin policies = %+v, %v", policies, err)
+	}
+}
@@ -0,0 +1,57 @@
+package accessaudit
+
+import (
+	"strconv"
+	"time"
+
+	domainaudit "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/accessaudit"
+)
+
+// hashTimeLayout CSV 导出的记录时间格式，与参与哈希的格式一致。
+const hashTimeLayout = domainaudit.HashTimeLayout
+
+// chainVerifier 按序号顺序逐条校验记录的连续性、前序哈希与本条哈希。
+type chainVerifier struct {
+	head   ChainHead
+	result ChainVerification
+}
+
+func newChainVerifier(orgID int64) *chainVerifier {
+	return &chainVerifier{result: ChainVerification{OrgID: orgID, Valid: true}}
+}
+
+// next 校验下一条记录；返回 false 表示链已断裂，后续记录无需再校验。
+func (v *chainVerifier) next(entry Entry) bool {
+	switch {
+	case entry.Seq != v.head.Seq+1:
+		return v.fail(v.head.Seq+1, "sequence gap: expected "+strconv.FormatUint(v.head.Seq+1, 10)+", got "+strconv.FormatUint(entry.Seq, 10))
+	case entry.PrevHash != v.head.Hash:
+		return v.fail(entry.Seq, "prev_hash does not match previous entry")
+	case entry.Hash != entry.ComputeHash():
+		return v.fail(entry.Seq, "entry hash mismatch")
+	}
+	v.head = ChainHead{Seq: entry.Seq, Hash: entry.Hash}
+	v.result.Checked++
+	return true
+}
+
+// finish 将逐条校验的链尾与存储记录的链尾比对，发现尾部记录被删除的情况。
+func (v *chainVerifier) finish(stored ChainHead) ChainVerification {
+	if v.result.Valid && (stored.Seq != v.head.Seq || stored.Hash != v.head.Hash) {
+		v.fail(v.head.Seq+1, "chain head mismatch: stored head seq "+strconv.FormatUint(stored.Seq, 10))
+	}
+	v.result.HeadSeq = stored.Seq
+	v.result.HeadHash = stored.Hash
+	return v.result
+}
+
+func (v *chainVerifier) fail(seq uint64, reason string) bool {
+	v.result.Valid = false
+	v.result.BrokenAtSeq = seq
+	v.result.Reason = reason
+	return false
+}
+
+func truncateOccurredAt(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
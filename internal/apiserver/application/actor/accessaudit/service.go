@@ -0,0 +1,291 @@
+package accessaudit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/csvexport"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Recorder 记录一次受试者数据读取。
+type Recorder interface {
+	Record(ctx context.Context, event AccessEvent) error
+}
+
+// TransactionalRecorder 在调用方事务内写入审计记录，用于必须与审计记录同时生效的写操作（例如紧急访问授予）。
+// 授权依据由调用方给出，不经 AccessResolver 解析。
+type TransactionalRecorder interface {
+	RecordInTx(ctx context.Context, event AccessEvent, basis AccessBasis) error
+}
+
+// Service 访问审计用例。
+type Service interface {
+	Recorder
+	TransactionalRecorder
+	// List 机构管理员分页查询审计记录（按序号倒序）。
+	List(ctx context.Context, query ListQuery) (*EntryList, error)
+	// ExportCSV 按序号升序导出审计记录，包含哈希列以便离线复核。
+	ExportCSV(ctx context.Context, filter Filter) (*ExportCSV, error)
+	// VerifyChain 从头校验机构审计链是否被篡改。
+	VerifyChain(ctx context.Context, orgID int64) (*ChainVerification, error)
+}
+
+type service struct {
+	store    Store
+	resolver AccessResolver
+	now      func() time.Time
+}
+
+// NewService 创建访问审计服务；resolver 为空时角色与关系记录为 unresolved/unknown。
+func NewService(store Store, resolver AccessResolver) Service {
+	return &service{store: store, resolver: resolver, now: time.Now}
+}
+
+func (s *service) Record(ctx context.Context, event AccessEvent) error {
+	if err := validateEvent(event); err != nil {
+		return err
+	}
+	if err := s.store.Append(ctx, s.newEntry(event, s.describe(ctx, event))); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "写入访问审计失败")
+	}
+	return nil
+}
+
+func (s *service) RecordInTx(ctx context.Context, event AccessEvent, basis AccessBasis) error {
+	if err := validateEvent(event); err != nil {
+		return err
+	}
+	if err := s.store.AppendInTx(ctx, s.newEntry(event, basis)); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "写入访问审计失败")
+	}
+	return nil
+}
+
+func validateEvent(event AccessEvent) error {
+	if event.OrgID <= 0 {
+		return errors.WithCode(code.ErrInvalidArgument, "access audit requires org scope")
+	}
+	if event.ResourceType == "" {
+		return errors.WithCode(code.ErrInvalidArgument, "access audit requires resource type")
+	}
+	return nil
+}
+
+func (s *service) newEntry(event AccessEvent, basis AccessBasis) *Entry {
+	return &Entry{
+		ID:           meta.New().Uint64(),
+		OrgID:        event.OrgID,
+		ActorUserID:  event.ActorUserID,
+		ActorRole:    basis.ActorRole,
+		Relation:     basis.Relation,
+		ResourceType: event.ResourceType,
+		ResourceID:   clip(event.ResourceID, maxResourceIDLen),
+		TesteeID:     event.TesteeID,
+		Purpose:      NormalizePurpose(event.Purpose),
+		Result:       event.Result,
+		StatusCode:   event.StatusCode,
+		Route:        clip(event.Route, maxRouteRunes),
+		RequestID:    clip(event.RequestID, maxRequestIDLen),
+		ClientIP:     clip(event.ClientIP, maxClientIPRunes),
+		OccurredAt:   truncateOccurredAt(s.now()),
+	}
+}
+
+// describe 解析角色与关系；解析失败不阻止审计写入，只记录为 unresolved。
+func (s *service) describe(ctx context.Context, event AccessEvent) AccessBasis {
+	fallback := AccessBasis{ActorRole: ActorRoleUnresolved, Relation: RelationUnknown}
+	if s.resolver == nil || event.ActorUserID <= 0 {
+		return fallback
+	}
+	basis, err := s.resolver.DescribeTesteeAccess(ctx, event.OrgID, event.ActorUserID, event.TesteeID)
+	if err != nil {
+		logger.L(ctx).Warnw("failed to resolve access audit actor",
+			"org_id", event.OrgID,
+			"user_id", event.ActorUserID,
+			"testee_id", event.TesteeID,
+			"error", err.Error(),
+		)
+		return fallback
+	}
+	if basis == nil {
+		return fallback
+	}
+	return *basis
+}
+
+func (s *service) List(ctx context.Context, query ListQuery) (*EntryList, error) {
+	if err := validateFilter(query.Filter); err != nil {
+		return nil, err
+	}
+	page, pageSize := normalizePage(query.Page, query.PageSize)
+	items, total, err := s.store.List(ctx, query.Filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询访问审计失败")
+	}
+	return &EntryList{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) ExportCSV(ctx context.Context, filter Filter) (*ExportCSV, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "export requires from and to")
+	}
+	items, total, err := s.store.List(ctx, filter, 0, exportMaxRows)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "导出访问审计失败")
+	}
+	if total > exportMaxRows {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "export matches %d rows, exceeds %d; narrow the time range", total, exportMaxRows)
+	}
+	rows := make([][]string, 0, len(items)+1)
+	rows = append(rows, exportHeader)
+	// List 按序号倒序返回，导出按链顺序排列。
+	for index := len(items) - 1; index >= 0; index-- {
+		rows = append(rows, exportValues(items[index]))
+	}
+	content, err := csvexport.Encode(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportCSV{
+		FileName: fmt.Sprintf("access_audit_org_%d_%s_%s.csv", filter.OrgID, filter.From.Format("20060102"), filter.To.Format("20060102")),
+		Content:  content,
+	}, nil
+}
+
+func (s *service) VerifyChain(ctx context.Context, orgID int64) (*ChainVerification, error) {
+	if orgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	// 先读取链尾：校验期间新追加的记录不在本次校验范围内。
+	head, err := s.store.Head(ctx, orgID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "读取访问审计链尾失败")
+	}
+	verifier := newChainVerifier(orgID)
+	after := uint64(0)
+	for after < head.Seq {
+		batch, err := s.store.ListChain(ctx, orgID, after, verifyBatchSize)
+		if err != nil {
+			return nil, errors.WrapC(err, code.ErrDatabase, "读取访问审计链失败")
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, entry := range batch {
+			if entry.Seq > head.Seq {
+				break
+			}
+			if !verifier.next(entry) {
+				result := verifier.finish(head)
+				return &result, nil
+			}
+		}
+		after = batch[len(batch)-1].Seq
+	}
+	result := verifier.finish(head)
+	return &result, nil
+}
+
+var exportHeader = []string{
+	"seq", "occurred_at", "actor_user_id", "actor_role", "relation",
+	"resource_type", "resource_id", "testee_id", "purpose", "result", "status_code",
+	"route", "request_id", "client_ip", "prev_hash", "hash",
+}
+
+func exportValues(entry Entry) []string {
+	testeeID := ""
+	if entry.TesteeID > 0 {
+		testeeID = strconv.FormatUint(entry.TesteeID, 10)
+	}
+	return []string{
+		strconv.FormatUint(entry.Seq, 10), entry.OccurredAt.UTC().Format(hashTimeLayout),
+		strconv.FormatInt(entry.ActorUserID, 10), entry.ActorRole, entry.Relation,
+		string(entry.ResourceType), entry.ResourceID, testeeID, entry.Purpose, string(entry.Result), strconv.Itoa(entry.StatusCode),
+		entry.Route, entry.RequestID, entry.ClientIP, entry.PrevHash, entry.Hash,
+	}
+}
+
+func validateFilter(filter Filter) error {
+	if filter.OrgID <= 0 {
+		return errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return errors.WithCode(code.ErrInvalidArgument, "from must be before to")
+	}
+	if filter.Result != "" && !validResult(filter.Result) {
+		return errors.WithCode(code.ErrInvalidArgument, "unsupported result: %s", filter.Result)
+	}
+	return nil
+}
+
+func validResult(result Result) bool {
+	switch result {
+	case ResultAllowed, ResultDenied, ResultNotFound, ResultInvalid, ResultError:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResultFromStatus 按 HTTP 状态码归类访问结果。
+func ResultFromStatus(status int) Result {
+	switch {
+	case status < 400:
+		return ResultAllowed
+	case status == 401 || status == 403:
+		return ResultDenied
+	case status == 404:
+		return ResultNotFound
+	case status < 500:
+		return ResultInvalid
+	default:
+		return ResultError
+	}
+}
+
+// NormalizePurpose 规范化访问目的：去除控制字符、转小写并限制长度，空值记为 unspecified。
+func NormalizePurpose(purpose string) string {
+	purpose = strings.ToLower(strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, purpose)))
+	if purpose == "" {
+		return PurposeUnspecified
+	}
+	return clip(purpose, maxPurposeRunes)
+}
+
+func clip(value string, limit int) string {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
@@ -0,0 +1,207 @@
+package accessaudit
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	componenterrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type memoryStore struct {
+	entries []Entry
+	head    ChainHead
+	inTx    int
+}
+
+func (m *memoryStore) Append(_ context.Context, entry *Entry) error {
+	entry.Seal(m.head)
+	m.entries = append(m.entries, *entry)
+	m.head = ChainHead{Seq: entry.Seq, Hash: entry.Hash}
+	return nil
+}
+
+func (m *memoryStore) AppendInTx(ctx context.Context, entry *Entry) error {
+	m.inTx++
+	return m.Append(ctx, entry)
+}
+
+func (m *memoryStore) List(_ context.Context, filter Filter, offset, limit int) ([]Entry, int64, error) {
+	var matched []Entry
+	for index := len(m.entries) - 1; index >= 0; index-- {
+		entry := m.entries[index]
+		if filter.TesteeID != 0 && entry.TesteeID != filter.TesteeID {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return nil, total, nil
+	}
+	matched = matched[offset:]
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+func (m *memoryStore) ListChain(_ context.Context, _ int64, afterSeq uint64, limit int) ([]Entry, error) {
+	var items []Entry
+	for _, entry := range m.entries {
+		if entry.Seq > afterSeq && len(items) < limit {
+			items = append(items, entry)
+		}
+	}
+	return items, nil
+}
+
+func (m *memoryStore) Head(context.Context, int64) (ChainHead, error) { return m.head, nil }
+
+type resolverStub struct {
+	basis *AccessBasis
+	err   error
+}
+
+func (r resolverStub) DescribeTesteeAccess(context.Context, int64, int64, uint64) (*AccessBasis, error) {
+	return r.basis, r.err
+}
+
+func newTestService(store Store, resolver AccessResolver) *service {
+	clock := time.Date(2026, 10, 1, 8, 0, 0, 123456789, time.UTC)
+	svc := NewService(store, resolver).(*service)
+	svc.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return svc
+}
+
+func recordN(t *testing.T, svc *service, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := svc.Record(context.Background(), AccessEvent{
+			OrgID: 7, ActorUserID: 11, ResourceType: ResourceAnswerSheet, ResourceID: "900",
+			TesteeID: 42, Purpose: " Treatment ", Result: ResultAllowed, StatusCode: 200,
+			Route: "/api/v1/answersheets/:id", RequestID: "req", ClientIP: "10.0.0.1",
+		}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+}
+
+func TestRecordChainsEntriesAndCapturesActorBasis(t *testing.T) {
+	store := &memoryStore{}
+	svc := newTestService(store, resolverStub{basis: &AccessBasis{ActorRole: ActorRoleClinician, Relation: "primary"}})
+	recordN(t, svc, 2)
+
+	first, second := store.entries[0], store.entries[1]
+	if first.Seq != 1 || first.PrevHash != "" || second.Seq != 2 || second.PrevHash != first.Hash {
+		t.Fatalf("chain = %+v / %+v", first, second)
+	}
+	if first.ActorRole != ActorRoleClinician || first.Relation != "primary" || first.Purpose != "treatment" {
+		t.Fatalf("entry = %+v", first)
+	}
+	if first.OccurredAt.Nanosecond()%int(time.Millisecond) != 0 {
+		t.Fatalf("occurred_at %v not truncated to storage precision", first.OccurredAt)
+	}
+}
+
+func TestRecordStillWritesWhenActorCannotBeResolved(t *testing.T) {
+	store := &memoryStore{}
+	svc := newTestService(store, resolverStub{err: errors.New("operator reader down")})
+	recordN(t, svc, 1)
+	if got := store.entries[0]; got.ActorRole != ActorRoleUnresolved || got.Relation != RelationUnknown {
+		t.Fatalf("entry = %+v", got)
+	}
+}
+
+func TestRecordInTxUsesCallerBasisAndJoinsTransaction(t *testing.T) {
+	store := &memoryStore{}
+	svc := newTestService(store, resolverStub{err: errors.New("resolver must not be called")})
+	err := svc.RecordInTx(context.Background(), AccessEvent{
+		OrgID: 7, ActorUserID: 11, ResourceType: ResourceBreakGlassGrant, ResourceID: "41", TesteeID: 9,
+		Purpose: "break_glass", Result: ResultAllowed,
+	}, AccessBasis{ActorRole: ActorRoleClinician, Relation: RelationBreakGlass})
+	if err != nil {
+		t.Fatalf("RecordInTx() error = %v", err)
+	}
+	if store.inTx != 1 || len(store.entries) != 1 {
+		t.Fatalf("inTx = %d, entries = %d", store.inTx, len(store.entries))
+	}
+	if got := store.entries[0]; got.Relation != RelationBreakGlass || got.ActorRole != ActorRoleClinician || got.Seq != 1 {
+		t.Fatalf("entry = %+v", got)
+	}
+}
+
+func TestVerifyChainDetectsTampering(t *testing.T) {
+	store := &memoryStore{}
+	svc := newTestService(store, nil)
+	recordN(t, svc, 3)
+
+	result, err := svc.VerifyChain(context.Background(), 7)
+	if err != nil || !result.Valid || result.Checked != 3 || result.HeadSeq != 3 {
+		t.Fatalf("VerifyChain() = %+v, %v", result, err)
+	}
+
+	store.entries[1].Purpose = "research"
+	result, err = svc.VerifyChain(context.Background(), 7)
+	if err != nil || result.Valid || result.BrokenAtSeq != 2 || result.Checked != 1 {
+		t.Fatalf("tampered VerifyChain() = %+v, %v", result, err)
+	}
+}
+
+func TestVerifyChainDetectsDeletedRows(t *testing.T) {
+	for name, mutate := range map[string]func(*memoryStore){
+		"middle": func(m *memoryStore) { m.entries = append(m.entries[:1], m.entries[2:]...) },
+		"tail":   func(m *memoryStore) { m.entries = m.entries[:2] },
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := &memoryStore{}
+			svc := newTestService(store, nil)
+			recordN(t, svc, 3)
+			mutate(store)
+			result, err := svc.VerifyChain(context.Background(), 7)
+			if err != nil || result.Valid {
+				t.Fatalf("VerifyChain() = %+v, %v", result, err)
+			}
+		})
+	}
+}
+
+func TestExportCSVOrdersByChainAndRequiresRange(t *testing.T) {
+	store := &memoryStore{}
+	svc := newTestService(store, nil)
+	recordN(t, svc, 2)
+
+	if _, err := svc.ExportCSV(context.Background(), Filter{OrgID: 7}); !componenterrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("ExportCSV() without range error = %v", err)
+	}
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	export, err := svc.ExportCSV(context.Background(), Filter{OrgID: 7, From: from, To: from.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.FileName != "access_audit_org_7_20261001_20261002.csv" {
+		t.Fatalf("file name = %s", export.FileName)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(export.Content), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[1][0] != "1" || records[2][0] != "2" || records[2][14] != records[1][15] {
+		t.Fatalf("records = %v", records)
+	}
+}
+
+func TestResultFromStatus(t *testing.T) {
+	for status, want := range map[int]Result{200: ResultAllowed, 403: ResultDenied, 404: ResultNotFound, 400: ResultInvalid, 500: ResultError} {
+		if got := ResultFromStatus(status); got != want {
+			t.Fatalf("ResultFromStatus(%d) = %s, want %s", status, got, want)
+		}
+	}
+}
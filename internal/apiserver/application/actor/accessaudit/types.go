@@ -0,0 +1,140 @@
+// Package accessaudit 受试者敏感数据（PHI）访问审计：记录后台操作者与临床人员
+// 查看受试者档案、答卷、报告与量表分析的每一次读取（谁、以什么角色与关系、
+// 读取了哪个资源、出于什么目的、结果如何），按机构追加写入哈希链，
+// 并为机构管理员提供查询、CSV 导出与链完整性校验。
+package accessaudit
+
+import (
+	"context"
+
+	domainaudit "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/accessaudit"
+)
+
+type (
+	ResourceType = domainaudit.ResourceType
+	Result       = domainaudit.Result
+	Entry        = domainaudit.Entry
+	ChainHead    = domainaudit.ChainHead
+	Filter       = domainaudit.Filter
+)
+
+const (
+	ResourceTestee               = domainaudit.ResourceTestee
+	ResourceAnswerSheet          = domainaudit.ResourceAnswerSheet
+	ResourceAssessmentReport     = domainaudit.ResourceAssessmentReport
+	ResourceAssessmentScores     = domainaudit.ResourceAssessmentScores
+	ResourceInterpretationReport = domainaudit.ResourceInterpretationReport
+	ResourceReportList           = domainaudit.ResourceReportList
+	ResourceScaleAnalysis        = domainaudit.ResourceScaleAnalysis
+	ResourceTesteeUnmask         = domainaudit.ResourceTesteeUnmask
+	ResourceDataSubjectBundle    = domainaudit.ResourceDataSubjectBundle
+	ResourceReportPDFDownload    = domainaudit.ResourceReportPDFDownload
+	ResourceFHIRExport           = domainaudit.ResourceFHIRExport
+	ResourceBreakGlassGrant      = domainaudit.ResourceBreakGlassGrant
+	ResourceGroupReport          = domainaudit.ResourceGroupReport
+
+	ResultAllowed  = domainaudit.ResultAllowed
+	ResultDenied   = domainaudit.ResultDenied
+	ResultNotFound = domainaudit.ResultNotFound
+	ResultInvalid  = domainaudit.ResultInvalid
+	ResultError    = domainaudit.ResultError
+)
+
+// 操作者角色。
+const (
+	ActorRoleQSAdmin    = "qs_admin"   // 机构管理员，可见机构内全部受试者
+	ActorRoleClinician  = "clinician"  // 绑定从业者的操作者，按关系可见
+	ActorRoleOperator   = "operator"   // 未绑定从业者的操作者
+	ActorRoleUnresolved = "unresolved" // 无法解析操作者身份（例如已不在机构内）
+)
+
+// 操作者与受试者的关系。临床人员的关系为生效中的授权关系类型（逗号分隔）。
+const (
+	RelationOrgAdmin   = "org_admin"   // 管理员按机构范围访问
+	RelationNone       = "none"        // 没有授权关系
+	RelationCareTeam   = "care_team"   // 没有授权关系，凭照护团队成员身份继承访问
+	RelationBreakGlass = "break_glass" // 没有授权关系，凭生效中的紧急访问授权访问
+	RelationUnknown    = "unknown"     // 未能确定受试者，或关系解析失败
+)
+
+// PurposeUnspecified 请求未声明访问目的时记录的默认值。
+// 调用方通过 X-Access-Purpose 请求头（或 purpose 查询参数）声明目的，
+// 建议取值：treatment、care_coordination、quality_review、research、patient_request、audit。
+const PurposeUnspecified = "unspecified"
+
+const (
+	maxPurposeRunes  = 64
+	maxRouteRunes    = 200
+	maxClientIPRunes = 64
+	maxRequestIDLen  = 64
+	maxResourceIDLen = 64
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// exportMaxRows 单次 CSV 导出的最大行数；超出时需缩小时间范围。
+	exportMaxRows = 50000
+	// verifyBatchSize 校验哈希链时每批读取的记录数。
+	verifyBatchSize = 500
+)
+
+// AccessEvent 一次读取请求的审计输入。
+type AccessEvent struct {
+	OrgID        int64
+	ActorUserID  int64
+	ResourceType ResourceType
+	ResourceID   string
+	TesteeID     uint64 // 无法从请求确定受试者时为 0
+	Purpose      string
+	Result       Result
+	StatusCode   int
+	Route        string
+	RequestID    string
+	ClientIP     string
+}
+
+// ListQuery 分页查询。
+type ListQuery struct {
+	Filter
+	Page     int
+	PageSize int
+}
+
+// EntryList 审计记录分页。
+type EntryList struct {
+	Items    []Entry
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// ExportCSV CSV 导出结果。
+type ExportCSV struct {
+	FileName string
+	Content  []byte
+}
+
+// ChainVerification 哈希链校验结果。
+type ChainVerification struct {
+	OrgID    int64
+	Valid    bool
+	Checked  uint64
+	HeadSeq  uint64
+	HeadHash string
+	// BrokenAtSeq 第一条校验失败的序号，链完整时为 0。
+	BrokenAtSeq uint64
+	Reason      string
+}
+
+// AccessBasis 操作者访问受试者的授权依据。
+type AccessBasis struct {
+	ActorRole string
+	Relation  string
+}
+
+// AccessResolver 解析操作者的角色及其与受试者的关系。
+type AccessResolver interface {
+	DescribeTesteeAccess(ctx context.Context, orgID, operatorUserID int64, testeeID uint64) (*AccessBasis, error)
+}
+
+// Store 访问审计的追加写持久化端口。
+type Store = domainaudit.Repository
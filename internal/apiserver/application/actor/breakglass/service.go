@@ -0,0 +1,387 @@
+package breakglass
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	domainBreakGlass "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/breakglass"
+	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 紧急访问用例。
+type Service interface {
+	// Request 当前从业者申请紧急访问；已有生效授权时直接返回该授权，不延长有效期。
+	Request(ctx context.Context, dto RequestDTO) (*Grant, error)
+	// ListMine 当前操作者自己的授权记录。
+	ListMine(ctx context.Context, orgID, operatorUserID int64, activeOnly bool, page, pageSize int) (*GrantList, error)
+	// RevokeMine 从业者提前结束自己的授权。
+	RevokeMine(ctx context.Context, orgID, operatorUserID int64, grantID uint64) (*Grant, error)
+
+	// List 机构管理员查询授权与复核队列。
+	List(ctx context.Context, query ListQuery) (*GrantList, error)
+	// Revoke 机构管理员提前结束授权。
+	Revoke(ctx context.Context, orgID, adminUserID int64, grantID uint64) (*Grant, error)
+	// Review 机构管理员事后复核；申请人本人不能复核自己的授权。
+	Review(ctx context.Context, dto ReviewDTO) (*Grant, error)
+}
+
+type service struct {
+	store           Store
+	operatorReader  actorreadmodel.OperatorReader
+	clinicianReader actorreadmodel.ClinicianReader
+	relationReader  actorreadmodel.RelationReader
+	testeeReader    actorreadmodel.TesteeReader
+	tx              apptransaction.Runner
+	audit           accessaudit.TransactionalRecorder
+	events          appEventing.ProfileBinding
+	now             func() time.Time
+}
+
+// NewService 创建紧急访问服务。授予记录、访问审计与管理员通知事件在同一事务内写入。
+func NewService(
+	store Store,
+	operatorReader actorreadmodel.OperatorReader,
+	clinicianReader actorreadmodel.ClinicianReader,
+	relationReader actorreadmodel.RelationReader,
+	testeeReader actorreadmodel.TesteeReader,
+	tx apptransaction.Runner,
+	audit accessaudit.TransactionalRecorder,
+	events appEventing.ProfileBinding,
+) Service {
+	return &service{
+		store:           store,
+		operatorReader:  operatorReader,
+		clinicianReader: clinicianReader,
+		relationReader:  relationReader,
+		testeeReader:    testeeReader,
+		tx:              tx,
+		audit:           audit,
+		events:          events,
+		now:             time.Now,
+	}
+}
+
+func (s *service) Request(ctx context.Context, dto RequestDTO) (*Grant, error) {
+	justification := strings.TrimSpace(dto.Justification)
+	if n := utf8.RuneCountInString(justification); n < minJustificationRunes || n > maxJustificationRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "justification must be %d-%d characters", minJustificationRunes, maxJustificationRunes)
+	}
+	duration := dto.Duration
+	if duration == 0 {
+		duration = DefaultDuration
+	}
+	if duration < MinDuration || duration > MaxDuration {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "duration must be between %s and %s", MinDuration, MaxDuration)
+	}
+	if dto.TesteeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "testee_id is required")
+	}
+
+	testeeItem, err := s.testeeReader.GetTestee(ctx, dto.TesteeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find testee")
+	}
+	if testeeItem == nil {
+		return nil, errors.WithCode(code.ErrUserNotFound, "testee not found")
+	}
+	if testeeItem.OrgID != dto.OrgID {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "testee does not belong to current organization")
+	}
+	clinicianID, err := s.currentClinicianID(ctx, dto.OrgID, dto.OperatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	related, err := s.relationReader.HasActiveRelationForTestee(ctx, dto.OrgID, clinicianID, dto.TesteeID, relationTypesToStrings(domainRelation.AccessGrantRelationTypes()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check clinician relation")
+	}
+	if related {
+		return nil, errors.WithCode(code.ErrBreakGlassConflict, "clinician already has access to this testee")
+	}
+
+	now := s.now()
+	active, err := s.store.ListActiveGrants(ctx, ActiveGrantQuery{OrgID: dto.OrgID, ClinicianID: clinicianID, TesteeIDs: []uint64{dto.TesteeID}}, now)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load active break-glass grants")
+	}
+	if len(active) > 0 {
+		existing := active[0]
+		existing.Status = existing.StatusAt(now)
+		return &existing, nil
+	}
+
+	grant := &Grant{
+		ID:             meta.New().Uint64(),
+		OrgID:          dto.OrgID,
+		ClinicianID:    clinicianID,
+		OperatorUserID: dto.OperatorUserID,
+		TesteeID:       dto.TesteeID,
+		Justification:  justification,
+		GrantedAt:      now,
+		ExpiresAt:      now.Add(duration),
+		ReviewStatus:   ReviewPending,
+	}
+	events := []event.DomainEvent{domainBreakGlass.NewGrantedEvent(grant)}
+	err = s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.store.Create(txCtx, grant); err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "save break-glass grant")
+		}
+		if err := s.audit.RecordInTx(txCtx, grantAccessEvent(grant), accessaudit.AccessBasis{
+			ActorRole: accessaudit.ActorRoleClinician,
+			Relation:  accessaudit.RelationBreakGlass,
+		}); err != nil {
+			return err
+		}
+		return s.stage(txCtx, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.events.PostCommit != nil {
+		s.events.PostCommit.AfterCommit(ctx, events, now)
+	}
+	grant.Status = grant.StatusAt(now)
+	logger.L(ctx).Warnw("break-glass access granted",
+		"org_id", grant.OrgID,
+		"grant_id", grant.ID,
+		"clinician_id", grant.ClinicianID,
+		"operator_user_id", grant.OperatorUserID,
+		"testee_id", grant.TesteeID,
+		"expires_at", grant.ExpiresAt,
+	)
+	return grant, nil
+}
+
+func (s *service) ListMine(ctx context.Context, orgID, operatorUserID int64, activeOnly bool, page, pageSize int) (*GrantList, error) {
+	return s.List(ctx, ListQuery{
+		Filter:   Filter{OrgID: orgID, OperatorUserID: operatorUserID, ActiveOnly: activeOnly},
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+func (s *service) RevokeMine(ctx context.Context, orgID, operatorUserID int64, grantID uint64) (*Grant, error) {
+	grant, err := s.load(ctx, orgID, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.OperatorUserID != operatorUserID {
+		return nil, errors.WithCode(code.ErrBreakGlassGrantNotFound, "break-glass grant not found")
+	}
+	return s.revoke(ctx, grant, operatorUserID, accessaudit.AccessBasis{
+		ActorRole: accessaudit.ActorRoleClinician,
+		Relation:  accessaudit.RelationBreakGlass,
+	})
+}
+
+func (s *service) List(ctx context.Context, query ListQuery) (*GrantList, error) {
+	if query.OrgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id is required")
+	}
+	switch query.ReviewStatus {
+	case "", ReviewPending, ReviewApproved, ReviewFlagged:
+	default:
+		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported review_status %q", query.ReviewStatus)
+	}
+	page, pageSize := normalizePage(query.Page, query.PageSize)
+	now := s.now()
+	items, total, err := s.store.List(ctx, query.Filter, now, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list break-glass grants")
+	}
+	for i := range items {
+		items[i].Status = items[i].StatusAt(now)
+	}
+	return &GrantList{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, orgID, adminUserID int64, grantID uint64) (*Grant, error) {
+	grant, err := s.load(ctx, orgID, grantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.revoke(ctx, grant, adminUserID, adminAuditBasis)
+}
+
+func (s *service) Review(ctx context.Context, dto ReviewDTO) (*Grant, error) {
+	if dto.Decision != ReviewApproved && dto.Decision != ReviewFlagged {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "decision must be approved or flagged")
+	}
+	note := strings.TrimSpace(dto.Note)
+	if utf8.RuneCountInString(note) > maxReviewNoteRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note must be at most %d characters", maxReviewNoteRunes)
+	}
+	if dto.Decision == ReviewFlagged && note == "" {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note is required when flagging a break-glass grant")
+	}
+	grant, err := s.load(ctx, dto.OrgID, dto.GrantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.OperatorUserID == dto.ReviewerID {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "break-glass grant cannot be reviewed by its own requester")
+	}
+	now := s.now()
+	grant.ReviewStatus = dto.Decision
+	grant.ReviewedBy = dto.ReviewerID
+	grant.ReviewedAt = &now
+	grant.ReviewNote = note
+	events := []event.DomainEvent{domainBreakGlass.NewReviewedEvent(grant)}
+	err = s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.store.UpdateReview(txCtx, grant); err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "review break-glass grant")
+		}
+		if err := s.audit.RecordInTx(txCtx, settleAccessEvent(grant, dto.ReviewerID, auditPurposeReview), adminAuditBasis); err != nil {
+			return err
+		}
+		return s.stage(txCtx, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.events.PostCommit != nil {
+		s.events.PostCommit.AfterCommit(ctx, events, now)
+	}
+	grant.Status = grant.StatusAt(now)
+	return grant, nil
+}
+
+// revoke 撤销记录、访问审计与撤销事件在同一事务内写入，与授予保持一致。
+func (s *service) revoke(ctx context.Context, grant *Grant, revokedBy int64, basis accessaudit.AccessBasis) (*Grant, error) {
+	now := s.now()
+	if !grant.ActiveAt(now) {
+		return nil, errors.WithCode(code.ErrBreakGlassConflict, "break-glass grant is no longer active")
+	}
+	grant.RevokedAt = &now
+	grant.RevokedBy = revokedBy
+	events := []event.DomainEvent{domainBreakGlass.NewRevokedEvent(grant)}
+	err := s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		updated, err := s.store.Revoke(txCtx, grant.OrgID, grant.ID, revokedBy, now)
+		if err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "revoke break-glass grant")
+		}
+		if !updated {
+			return errors.WithCode(code.ErrBreakGlassConflict, "break-glass grant is no longer active")
+		}
+		if err := s.audit.RecordInTx(txCtx, settleAccessEvent(grant, revokedBy, auditPurposeRevoke), basis); err != nil {
+			return err
+		}
+		return s.stage(txCtx, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.events.PostCommit != nil {
+		s.events.PostCommit.AfterCommit(ctx, events, now)
+	}
+	grant.Status = grant.StatusAt(now)
+	return grant, nil
+}
+
+func (s *service) stage(ctx context.Context, events []event.DomainEvent) error {
+	if s.events.Stager == nil {
+		return nil
+	}
+	return s.events.Stager.Stage(ctx, events...)
+}
+
+func (s *service) load(ctx context.Context, orgID int64, grantID uint64) (*Grant, error) {
+	grant, err := s.store.Find(ctx, orgID, grantID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load break-glass grant")
+	}
+	if grant == nil {
+		return nil, errors.WithCode(code.ErrBreakGlassGrantNotFound, "break-glass grant not found")
+	}
+	return grant, nil
+}
+
+// currentClinicianID 紧急访问只授予绑定了在职从业者的操作者；机构管理员本身可见全部受试者，无需申请。
+func (s *service) currentClinicianID(ctx context.Context, orgID, operatorUserID int64) (uint64, error) {
+	operatorItem, err := s.operatorReader.FindOperatorByUser(ctx, orgID, operatorUserID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, errors.WithCode(code.ErrPermissionDenied, "operator not found in current organization")
+		}
+		return 0, errors.Wrap(err, "failed to find operator")
+	}
+	if operatorItem == nil || !operatorItem.IsActive {
+		return 0, errors.WithCode(code.ErrPermissionDenied, "operator is inactive")
+	}
+	clinicianItem, err := s.clinicianReader.FindClinicianByOperator(ctx, orgID, operatorItem.ID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, errors.WithCode(code.ErrPermissionDenied, "break-glass access requires a clinician")
+		}
+		return 0, errors.Wrap(err, "failed to find clinician by operator")
+	}
+	if clinicianItem == nil || !clinicianItem.IsActive {
+		return 0, errors.WithCode(code.ErrPermissionDenied, "clinician is inactive")
+	}
+	return clinicianItem.ID, nil
+}
+
+// grantAccessEvent 紧急访问授予本身作为一次受试者数据访问写入审计链。
+func grantAccessEvent(grant *Grant) accessaudit.AccessEvent {
+	return accessaudit.AccessEvent{
+		OrgID:        grant.OrgID,
+		ActorUserID:  grant.OperatorUserID,
+		ResourceType: accessaudit.ResourceBreakGlassGrant,
+		ResourceID:   strconv.FormatUint(grant.ID, 10),
+		TesteeID:     grant.TesteeID,
+		Purpose:      auditPurpose,
+		Result:       accessaudit.ResultAllowed,
+	}
+}
+
+// adminAuditBasis 机构管理员复核或撤销授权时的访问依据。
+var adminAuditBasis = accessaudit.AccessBasis{
+	ActorRole: accessaudit.ActorRoleQSAdmin,
+	Relation:  accessaudit.RelationOrgAdmin,
+}
+
+// settleAccessEvent 复核与撤销由操作人对授权作出，同样写入审计链，便于追溯授权的完整生命周期。
+func settleAccessEvent(grant *Grant, actorUserID int64, purpose string) accessaudit.AccessEvent {
+	return accessaudit.AccessEvent{
+		OrgID:        grant.OrgID,
+		ActorUserID:  actorUserID,
+		ResourceType: accessaudit.ResourceBreakGlassGrant,
+		ResourceID:   strconv.FormatUint(grant.ID, 10),
+		TesteeID:     grant.TesteeID,
+		Purpose:      purpose,
+		Result:       accessaudit.ResultAllowed,
+	}
+}
+
+func relationTypesToStrings(items []domainRelation.RelationType) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, string(item))
+	}
+	return result
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
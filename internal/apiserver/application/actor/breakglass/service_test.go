@@ -0,0 +1,424 @@
+package breakglass
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	domainBreakGlass "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/breakglass"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	grants map[uint64]*Grant
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{grants: map[uint64]*Grant{}}
+}
+
+func (f *fakeStore) HasActiveGrant(_ context.Context, orgID int64, clinicianID, testeeID uint64, now time.Time) (bool, error) {
+	for _, grant := range f.grants {
+		if grant.OrgID == orgID && grant.ClinicianID == clinicianID && grant.TesteeID == testeeID && grant.ActiveAt(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeStore) ListActiveGrants(_ context.Context, query ActiveGrantQuery, now time.Time) ([]Grant, error) {
+	var out []Grant
+	for _, grant := range f.grants {
+		if grant.OrgID != query.OrgID || !grant.ActiveAt(now) {
+			continue
+		}
+		if query.ClinicianID != 0 && grant.ClinicianID != query.ClinicianID {
+			continue
+		}
+		if len(query.TesteeIDs) > 0 && grant.TesteeID != query.TesteeIDs[0] {
+			continue
+		}
+		out = append(out, *grant)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) Create(_ context.Context, grant *Grant) error {
+	copied := *grant
+	f.grants[grant.ID] = &copied
+	return nil
+}
+
+func (f *fakeStore) Find(_ context.Context, orgID int64, id uint64) (*Grant, error) {
+	grant := f.grants[id]
+	if grant == nil || grant.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *grant
+	return &copied, nil
+}
+
+func (f *fakeStore) List(_ context.Context, filter Filter, now time.Time, _, _ int) ([]Grant, int64, error) {
+	var out []Grant
+	for _, grant := range f.grants {
+		if grant.OrgID != filter.OrgID {
+			continue
+		}
+		if filter.OperatorUserID != 0 && grant.OperatorUserID != filter.OperatorUserID {
+			continue
+		}
+		if filter.ReviewStatus != "" && grant.ReviewStatus != filter.ReviewStatus {
+			continue
+		}
+		if filter.ActiveOnly && !grant.ActiveAt(now) {
+			continue
+		}
+		out = append(out, *grant)
+	}
+	return out, int64(len(out)), nil
+}
+
+func (f *fakeStore) Revoke(_ context.Context, orgID int64, id uint64, revokedBy int64, at time.Time) (bool, error) {
+	grant := f.grants[id]
+	if grant == nil || grant.OrgID != orgID || !grant.ActiveAt(at) {
+		return false, nil
+	}
+	grant.RevokedAt = &at
+	grant.RevokedBy = revokedBy
+	return true, nil
+}
+
+func (f *fakeStore) UpdateReview(_ context.Context, grant *Grant) error {
+	stored := f.grants[grant.ID]
+	stored.ReviewStatus = grant.ReviewStatus
+	stored.ReviewedBy = grant.ReviewedBy
+	stored.ReviewedAt = grant.ReviewedAt
+	stored.ReviewNote = grant.ReviewNote
+	return nil
+}
+
+// fakeReadModel 只实现紧急访问用到的读模型方法，其余调用会因嵌入的 nil 接口而 panic。
+type fakeReadModel struct {
+	actorreadmodel.ReadModel
+	related   bool
+	clinician *actorreadmodel.ClinicianRow
+}
+
+func (f *fakeReadModel) FindOperatorByUser(_ context.Context, orgID, userID int64) (*actorreadmodel.OperatorRow, error) {
+	return &actorreadmodel.OperatorRow{ID: 201, OrgID: orgID, UserID: userID, IsActive: true}, nil
+}
+
+func (f *fakeReadModel) FindClinicianByOperator(context.Context, int64, uint64) (*actorreadmodel.ClinicianRow, error) {
+	if f.clinician == nil {
+		return nil, cberrors.WithCode(code.ErrUserNotFound, "clinician not found")
+	}
+	return f.clinician, nil
+}
+
+func (f *fakeReadModel) HasActiveRelationForTestee(context.Context, int64, uint64, uint64, []string) (bool, error) {
+	return f.related, nil
+}
+
+func (f *fakeReadModel) GetTestee(_ context.Context, id uint64) (*actorreadmodel.TesteeRow, error) {
+	return &actorreadmodel.TesteeRow{ID: id, OrgID: 1}, nil
+}
+
+type inlineTx struct {
+	calls int
+}
+
+func (tx *inlineTx) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	tx.calls++
+	return fn(ctx)
+}
+
+type recordingAudit struct {
+	events []accessaudit.AccessEvent
+	bases  []accessaudit.AccessBasis
+	err    error
+}
+
+func (r *recordingAudit) RecordInTx(_ context.Context, event accessaudit.AccessEvent, basis accessaudit.AccessBasis) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, event)
+	r.bases = append(r.bases, basis)
+	return nil
+}
+
+type recordingEvents struct {
+	staged    []event.DomainEvent
+	committed []event.DomainEvent
+}
+
+func (r *recordingEvents) Stage(_ context.Context, events ...event.DomainEvent) error {
+	r.staged = append(r.staged, events...)
+	return nil
+}
+
+func (r *recordingEvents) AfterCommit(_ context.Context, events []event.DomainEvent, _ time.Time) {
+	r.committed = append(r.committed, events...)
+}
+
+func newTestService(store Store, readModel *fakeReadModel, now time.Time) *service {
+	events := &recordingEvents{}
+	svc := NewService(store, readModel, readModel, readModel, readModel, &inlineTx{}, &recordingAudit{}, appEventing.ProfileBinding{Stager: events, PostCommit: events}).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestRequestGrantsTimeBoxedAccessAndReusesActiveGrant(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(store, readModel, now)
+	ctx := context.Background()
+
+	grant, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "  on-call emergency, patient in crisis  "})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if grant.ClinicianID != 301 || grant.Status != StatusActive || grant.ReviewStatus != ReviewPending {
+		t.Fatalf("grant = %+v", grant)
+	}
+	if !grant.ExpiresAt.Equal(now.Add(DefaultDuration)) || grant.Justification != "on-call emergency, patient in crisis" {
+		t.Fatalf("grant expiry/justification = %v/%q", grant.ExpiresAt, grant.Justification)
+	}
+
+	again, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "second request while active", Duration: MaxDuration})
+	if err != nil {
+		t.Fatalf("second Request() error = %v", err)
+	}
+	if again.ID != grant.ID || !again.ExpiresAt.Equal(grant.ExpiresAt) || len(store.grants) != 1 {
+		t.Fatalf("expected active grant to be reused without extension, got %+v", again)
+	}
+
+	svc.now = func() time.Time { return grant.ExpiresAt }
+	mine, err := svc.ListMine(ctx, 1, 101, false, 0, 0)
+	if err != nil {
+		t.Fatalf("ListMine() error = %v", err)
+	}
+	if len(mine.Items) != 1 || mine.Items[0].Status != StatusExpired || mine.PageSize != defaultPageSize {
+		t.Fatalf("mine = %+v", mine)
+	}
+}
+
+func TestRequestRecordsAuditAndStagesGrantedEventInTransaction(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(newFakeStore(), readModel, now)
+	tx := svc.tx.(*inlineTx)
+	audit := svc.audit.(*recordingAudit)
+	events := svc.events.Stager.(*recordingEvents)
+	ctx := context.Background()
+
+	grant, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency, patient in crisis"})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if tx.calls != 1 {
+		t.Fatalf("transaction calls = %d, want 1", tx.calls)
+	}
+	if len(audit.events) != 1 {
+		t.Fatalf("audit events = %d, want 1", len(audit.events))
+	}
+	recorded := audit.events[0]
+	if recorded.OrgID != 1 || recorded.ActorUserID != 101 || recorded.TesteeID != 401 ||
+		recorded.ResourceType != accessaudit.ResourceBreakGlassGrant || recorded.ResourceID != strconv.FormatUint(grant.ID, 10) ||
+		recorded.Purpose != auditPurpose || recorded.Result != accessaudit.ResultAllowed {
+		t.Fatalf("audit event = %+v", recorded)
+	}
+	if basis := audit.bases[0]; basis.ActorRole != accessaudit.ActorRoleClinician || basis.Relation != accessaudit.RelationBreakGlass {
+		t.Fatalf("audit basis = %+v", basis)
+	}
+	if len(events.staged) != 1 || len(events.committed) != 1 {
+		t.Fatalf("staged/committed events = %d/%d, want 1/1", len(events.staged), len(events.committed))
+	}
+	granted, ok := events.staged[0].(domainBreakGlass.GrantedEvent)
+	if !ok || granted.EventType() != domainBreakGlass.EventTypeGranted || granted.Payload().OrgID != 1 || granted.Payload().GrantID != strconv.FormatUint(grant.ID, 10) {
+		t.Fatalf("staged event = %#v", events.staged[0])
+	}
+
+	if _, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "second request while active"}); err != nil {
+		t.Fatalf("second Request() error = %v", err)
+	}
+	if tx.calls != 1 || len(audit.events) != 1 || len(events.staged) != 1 {
+		t.Fatalf("reused grant must not write again: tx=%d audit=%d staged=%d", tx.calls, len(audit.events), len(events.staged))
+	}
+}
+
+func TestRequestFailsWhenAuditCannotBeWritten(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(newFakeStore(), readModel, now)
+	svc.audit.(*recordingAudit).err = cberrors.WithCode(code.ErrDatabase, "audit unavailable")
+	events := svc.events.Stager.(*recordingEvents)
+
+	_, err := svc.Request(context.Background(), RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency, patient in crisis"})
+	if !cberrors.IsCode(err, code.ErrDatabase) {
+		t.Fatalf("Request() error = %v, want database error", err)
+	}
+	if len(events.staged) != 0 || len(events.committed) != 0 {
+		t.Fatalf("expected no event when audit fails, got staged=%d committed=%d", len(events.staged), len(events.committed))
+	}
+}
+
+func TestRequestRejectsInvalidInputAndExistingRelation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	clinicianItem := &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}
+
+	cases := []struct {
+		name      string
+		readModel *fakeReadModel
+		dto       RequestDTO
+		code      int
+	}{
+		{"short justification", &fakeReadModel{clinician: clinicianItem}, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "urgent"}, code.ErrInvalidArgument},
+		{"long justification", &fakeReadModel{clinician: clinicianItem}, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: strings.Repeat("急", maxJustificationRunes+1)}, code.ErrInvalidArgument},
+		{"duration too long", &fakeReadModel{clinician: clinicianItem}, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency", Duration: 5 * time.Hour}, code.ErrInvalidArgument},
+		{"cross org testee", &fakeReadModel{clinician: clinicianItem}, RequestDTO{OrgID: 2, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"}, code.ErrPermissionDenied},
+		{"not a clinician", &fakeReadModel{}, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"}, code.ErrPermissionDenied},
+		{"already related", &fakeReadModel{clinician: clinicianItem, related: true}, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"}, code.ErrBreakGlassConflict},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newFakeStore()
+			_, err := newTestService(store, tc.readModel, now).Request(ctx, tc.dto)
+			if !cberrors.IsCode(err, tc.code) {
+				t.Fatalf("Request() error = %v, want code %d", err, tc.code)
+			}
+			if len(store.grants) != 0 {
+				t.Fatalf("expected no grant to be stored, got %d", len(store.grants))
+			}
+		})
+	}
+}
+
+func TestRevokeAndReview(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(store, readModel, now)
+	ctx := context.Background()
+
+	grant, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := svc.RevokeMine(ctx, 1, 999, grant.ID); !cberrors.IsCode(err, code.ErrBreakGlassGrantNotFound) {
+		t.Fatalf("RevokeMine() by another operator error = %v", err)
+	}
+	revoked, err := svc.RevokeMine(ctx, 1, 101, grant.ID)
+	if err != nil || revoked.Status != StatusRevoked || revoked.RevokedBy != 101 {
+		t.Fatalf("RevokeMine() = %+v, %v", revoked, err)
+	}
+	if _, err := svc.Revoke(ctx, 1, 900, grant.ID); !cberrors.IsCode(err, code.ErrBreakGlassConflict) {
+		t.Fatalf("Revoke() of revoked grant error = %v", err)
+	}
+
+	if _, err := svc.Review(ctx, ReviewDTO{OrgID: 1, GrantID: grant.ID, ReviewerID: 900, Decision: ReviewFlagged}); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("Review() flagged without note error = %v", err)
+	}
+	reviewed, err := svc.Review(ctx, ReviewDTO{OrgID: 1, GrantID: grant.ID, ReviewerID: 900, Decision: ReviewApproved, Note: "confirmed with ward"})
+	if err != nil || reviewed.ReviewStatus != ReviewApproved || reviewed.ReviewedBy != 900 || reviewed.ReviewedAt == nil {
+		t.Fatalf("Review() = %+v, %v", reviewed, err)
+	}
+	pending, err := svc.List(ctx, ListQuery{Filter: Filter{OrgID: 1, ReviewStatus: ReviewPending}})
+	if err != nil || pending.Total != 0 {
+		t.Fatalf("pending queue = %+v, %v", pending, err)
+	}
+	if _, err := svc.Review(ctx, ReviewDTO{OrgID: 2, GrantID: grant.ID, ReviewerID: 900, Decision: ReviewApproved}); !cberrors.IsCode(err, code.ErrBreakGlassGrantNotFound) {
+		t.Fatalf("Review() cross org error = %v", err)
+	}
+}
+
+func TestReviewRejectsRequesterAsReviewer(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(store, readModel, now)
+	ctx := context.Background()
+
+	grant, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := svc.Review(ctx, ReviewDTO{OrgID: 1, GrantID: grant.ID, ReviewerID: 101, Decision: ReviewApproved}); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("Review() by requester error = %v, want permission denied", err)
+	}
+	if stored, _ := store.Find(ctx, 1, grant.ID); stored.ReviewStatus != ReviewPending {
+		t.Fatalf("self review must not change the grant: %+v", stored)
+	}
+}
+
+func TestReviewAndRevokeRecordAuditAndStageEventsInTransaction(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	readModel := &fakeReadModel{clinician: &actorreadmodel.ClinicianRow{ID: 301, OrgID: 1, IsActive: true}}
+	svc := newTestService(newFakeStore(), readModel, now)
+	tx := svc.tx.(*inlineTx)
+	audit := svc.audit.(*recordingAudit)
+	events := svc.events.Stager.(*recordingEvents)
+	ctx := context.Background()
+
+	first, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 401, Justification: "on-call emergency"})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := svc.Review(ctx, ReviewDTO{OrgID: 1, GrantID: first.ID, ReviewerID: 900, Decision: ReviewFlagged, Note: "no ward record"}); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if _, err := svc.Revoke(ctx, 1, 900, first.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	second, err := svc.Request(ctx, RequestDTO{OrgID: 1, OperatorUserID: 101, TesteeID: 402, Justification: "on-call emergency"})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := svc.RevokeMine(ctx, 1, 101, second.ID); err != nil {
+		t.Fatalf("RevokeMine() error = %v", err)
+	}
+
+	if tx.calls != 5 || len(audit.events) != 5 || len(events.staged) != 5 || len(events.committed) != 5 {
+		t.Fatalf("tx=%d audit=%d staged=%d committed=%d, want 5 each", tx.calls, len(audit.events), len(events.staged), len(events.committed))
+	}
+	wantAudit := []struct {
+		actor   int64
+		purpose string
+		role    string
+	}{
+		{101, auditPurpose, accessaudit.ActorRoleClinician},
+		{900, auditPurposeReview, accessaudit.ActorRoleQSAdmin},
+		{900, auditPurposeRevoke, accessaudit.ActorRoleQSAdmin},
+		{101, auditPurpose, accessaudit.ActorRoleClinician},
+		{101, auditPurposeRevoke, accessaudit.ActorRoleClinician},
+	}
+	for i, want := range wantAudit {
+		if got := audit.events[i]; got.ActorUserID != want.actor || got.Purpose != want.purpose || got.ResourceType != accessaudit.ResourceBreakGlassGrant {
+			t.Fatalf("audit[%d] = %+v, want actor %d purpose %s", i, got, want.actor, want.purpose)
+		}
+		if audit.bases[i].ActorRole != want.role {
+			t.Fatalf("audit basis[%d] = %+v, want role %s", i, audit.bases[i], want.role)
+		}
+	}
+
+	reviewed, ok := events.staged[1].(domainBreakGlass.ReviewedEvent)
+	if !ok || reviewed.Payload().Decision != string(ReviewFlagged) || reviewed.Payload().ReviewerUserID != "900" || !reviewed.Payload().ReviewedAt.Equal(now) {
+		t.Fatalf("reviewed event = %#v", events.staged[1])
+	}
+	revoked, ok := events.staged[2].(domainBreakGlass.RevokedEvent)
+	if !ok || revoked.Payload().GrantID != strconv.FormatUint(first.ID, 10) || revoked.Payload().RevokedBy != "900" {
+		t.Fatalf("revoked event = %#v", events.staged[2])
+	}
+	if _, ok := events.staged[4].(domainBreakGlass.RevokedEvent); !ok {
+		t.Fatalf("self revoke event = %#v", events.staged[4])
+	}
+}
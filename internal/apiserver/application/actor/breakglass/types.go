@@ -0,0 +1,93 @@
+// Package breakglass 紧急访问（break-glass）：从业者与受试者之间没有授权关系时，
+// 填写理由即可立即获得对单个受试者的短时访问。授权到期自动失效（按 expires_at 判断，无需后台任务），
+// 每次授予都进入机构管理员的事后复核队列，并在工作台与访问审计中标记。
+package breakglass
+
+import (
+	"context"
+	"time"
+
+	domainbreakglass "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/breakglass"
+)
+
+type (
+	Status           = domainbreakglass.Status
+	ReviewStatus     = domainbreakglass.ReviewStatus
+	Grant            = domainbreakglass.Grant
+	Filter           = domainbreakglass.Filter
+	ActiveGrantQuery = domainbreakglass.ActiveGrantQuery
+)
+
+const (
+	StatusActive  = domainbreakglass.StatusActive
+	StatusExpired = domainbreakglass.StatusExpired
+	StatusRevoked = domainbreakglass.StatusRevoked
+
+	ReviewPending  = domainbreakglass.ReviewPending
+	ReviewApproved = domainbreakglass.ReviewApproved
+	ReviewFlagged  = domainbreakglass.ReviewFlagged
+)
+
+const (
+	// DefaultDuration 未指定时长时的授权时长。
+	DefaultDuration = 60 * time.Minute
+	// MinDuration / MaxDuration 可申请的授权时长范围。
+	MinDuration = 15 * time.Minute
+	MaxDuration = 4 * time.Hour
+
+	minJustificationRunes = 10
+	maxJustificationRunes = 500
+	maxReviewNoteRunes    = 500
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// auditPurpose 授予紧急访问时写入访问审计的访问目的。
+	auditPurpose = "break_glass"
+	// auditPurposeReview / auditPurposeRevoke 复核与撤销时写入访问审计的访问目的。
+	auditPurposeReview = "break_glass_review"
+	auditPurposeRevoke = "break_glass_revoke"
+)
+
+// RequestDTO 从业者申请紧急访问。
+type RequestDTO struct {
+	OrgID          int64
+	OperatorUserID int64
+	TesteeID       uint64
+	Justification  string
+	// Duration 为 0 时使用 DefaultDuration。
+	Duration time.Duration
+}
+
+// ReviewDTO 机构管理员复核。
+type ReviewDTO struct {
+	OrgID      int64
+	GrantID    uint64
+	ReviewerID int64
+	Decision   ReviewStatus
+	Note       string
+}
+
+// ListQuery 分页查询。
+type ListQuery struct {
+	Filter
+	Page     int
+	PageSize int
+}
+
+// GrantList 授权分页。
+type GrantList struct {
+	Items    []Grant
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// ActiveGrantReader 供访问控制、访问审计与工作台读取生效中的紧急访问授权。
+type ActiveGrantReader interface {
+	HasActiveGrant(ctx context.Context, orgID int64, clinicianID, testeeID uint64, now time.Time) (bool, error)
+	ListActiveGrants(ctx context.Context, query ActiveGrantQuery, now time.Time) ([]Grant, error)
+}
+
+// Store 紧急访问授权持久化端口。
+type Store = domainbreakglass.Repository
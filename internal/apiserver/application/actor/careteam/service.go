@@ -0,0 +1,459 @@
+package careteam
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 照护团队用例。
+type Service interface {
+	CreateTeam(ctx context.Context, dto TeamDTO) (*Team, error)
+	UpdateTeam(ctx context.Context, teamID uint64, dto TeamDTO) (*Team, error)
+	DeleteTeam(ctx context.Context, orgID, operatorID int64, teamID uint64) error
+	GetTeam(ctx context.Context, orgID int64, teamID uint64) (*Team, error)
+	ListTeams(ctx context.Context, filter TeamFilter) ([]Team, error)
+	// ListMyTeams 当前操作者绑定的从业者所在的团队。
+	ListMyTeams(ctx context.Context, orgID, operatorUserID int64) ([]Team, error)
+
+	// SetMember 添加成员或调整已有成员的角色；角色不变时直接返回现有成员。
+	SetMember(ctx context.Context, dto MemberDTO) (*Member, error)
+	RemoveMember(ctx context.Context, orgID, operatorID int64, teamID, clinicianID uint64) error
+	ListMembers(ctx context.Context, orgID int64, teamID uint64) ([]Member, error)
+
+	// AssignTestee 把受试者分配给团队；已分配时返回现有分配。
+	AssignTestee(ctx context.Context, orgID, operatorID int64, teamID, testeeID uint64) (*TesteeAssignment, error)
+	UnassignTestee(ctx context.Context, orgID, operatorID int64, teamID, testeeID uint64) error
+	ListTestees(ctx context.Context, orgID int64, teamID uint64, page, pageSize int) (*TesteePage, error)
+
+	ListEvents(ctx context.Context, orgID int64, teamID uint64, page, pageSize int) (*EventPage, error)
+}
+
+type service struct {
+	store           Store
+	operatorReader  actorreadmodel.OperatorReader
+	clinicianReader actorreadmodel.ClinicianReader
+	testeeReader    actorreadmodel.TesteeReader
+	now             func() time.Time
+}
+
+// NewService 创建照护团队服务。
+func NewService(
+	store Store,
+	operatorReader actorreadmodel.OperatorReader,
+	clinicianReader actorreadmodel.ClinicianReader,
+	testeeReader actorreadmodel.TesteeReader,
+) Service {
+	return &service{
+		store:           store,
+		operatorReader:  operatorReader,
+		clinicianReader: clinicianReader,
+		testeeReader:    testeeReader,
+		now:             time.Now,
+	}
+}
+
+func (s *service) CreateTeam(ctx context.Context, dto TeamDTO) (*Team, error) {
+	team := &Team{ID: meta.New().Uint64(), OrgID: dto.OrgID, CreatedBy: dto.OperatorID}
+	if err := applyTeamDTO(team, dto); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, team); err != nil {
+		return nil, err
+	}
+	team.CreatedAt = s.now()
+	team.UpdatedAt = team.CreatedAt
+	if err := s.store.CreateTeam(ctx, team, s.event(team, ActionTeamCreated, dto.OperatorID)); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save care team")
+	}
+	logger.L(ctx).Infow("care team created",
+		"action", "create_care_team",
+		"org_id", team.OrgID,
+		"team_id", team.ID,
+		"department", team.Department,
+		"operator_id", dto.OperatorID,
+	)
+	return team, nil
+}
+
+func (s *service) UpdateTeam(ctx context.Context, teamID uint64, dto TeamDTO) (*Team, error) {
+	team, err := s.load(ctx, dto.OrgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTeamDTO(team, dto); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, team); err != nil {
+		return nil, err
+	}
+	if err := s.ensureMembersMatchDepartment(ctx, team); err != nil {
+		return nil, err
+	}
+	team.UpdatedBy = dto.OperatorID
+	team.UpdatedAt = s.now()
+	if err := s.store.UpdateTeam(ctx, team, s.event(team, ActionTeamUpdated, dto.OperatorID)); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "update care team")
+	}
+	return team, nil
+}
+
+func (s *service) DeleteTeam(ctx context.Context, orgID, operatorID int64, teamID uint64) error {
+	team, err := s.load(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteTeam(ctx, orgID, teamID, s.event(team, ActionTeamDeleted, operatorID)); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "delete care team")
+	}
+	logger.L(ctx).Infow("care team deleted",
+		"action", "delete_care_team",
+		"org_id", orgID,
+		"team_id", teamID,
+		"operator_id", operatorID,
+	)
+	return nil
+}
+
+func (s *service) GetTeam(ctx context.Context, orgID int64, teamID uint64) (*Team, error) {
+	return s.load(ctx, orgID, teamID)
+}
+
+func (s *service) ListTeams(ctx context.Context, filter TeamFilter) ([]Team, error) {
+	if filter.OrgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id is required")
+	}
+	filter.Department = strings.TrimSpace(filter.Department)
+	teams, err := s.store.ListTeams(ctx, filter)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list care teams")
+	}
+	return teams, nil
+}
+
+func (s *service) ListMyTeams(ctx context.Context, orgID, operatorUserID int64) ([]Team, error) {
+	clinicianID, err := s.currentClinicianID(ctx, orgID, operatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListTeams(ctx, TeamFilter{OrgID: orgID, ClinicianID: clinicianID})
+}
+
+func (s *service) SetMember(ctx context.Context, dto MemberDTO) (*Member, error) {
+	if !dto.Role.Valid() {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "role must be lead, member or observer")
+	}
+	team, err := s.load(ctx, dto.OrgID, dto.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	clinicianItem, err := s.loadClinician(ctx, dto.OrgID, dto.ClinicianID)
+	if err != nil {
+		return nil, err
+	}
+	// 科室团队中能继承受试者访问的成员必须来自本科室；观察者不受限制。
+	if team.Department != "" && dto.Role.InheritsAccess() && clinicianItem.Department != team.Department {
+		return nil, errors.WithCode(code.ErrCareTeamConflict, "clinician department %q does not match team department %q", clinicianItem.Department, team.Department)
+	}
+
+	existing, err := s.store.FindMember(ctx, dto.OrgID, dto.TeamID, dto.ClinicianID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find care team member")
+	}
+	now := s.now()
+	if existing != nil {
+		if existing.Role == dto.Role {
+			return existing, nil
+		}
+		event := s.event(team, ActionMemberRoleChanged, dto.OperatorID)
+		event.ClinicianID = dto.ClinicianID
+		event.FromRole = existing.Role
+		event.ToRole = dto.Role
+		existing.Role = dto.Role
+		if err := s.store.UpdateMemberRole(ctx, existing, event); err != nil {
+			return nil, errors.WrapC(err, code.ErrDatabase, "update care team member")
+		}
+		s.logMembership(ctx, event)
+		return existing, nil
+	}
+
+	member := &Member{
+		TeamID:        team.ID,
+		OrgID:         team.OrgID,
+		ClinicianID:   clinicianItem.ID,
+		ClinicianName: clinicianItem.Name,
+		Department:    clinicianItem.Department,
+		Role:          dto.Role,
+		AddedBy:       dto.OperatorID,
+		AddedAt:       now,
+	}
+	event := s.event(team, ActionMemberAdded, dto.OperatorID)
+	event.ClinicianID = member.ClinicianID
+	event.ToRole = member.Role
+	if err := s.store.CreateMember(ctx, member, event); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save care team member")
+	}
+	s.logMembership(ctx, event)
+	return member, nil
+}
+
+func (s *service) RemoveMember(ctx context.Context, orgID, operatorID int64, teamID, clinicianID uint64) error {
+	team, err := s.load(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+	existing, err := s.store.FindMember(ctx, orgID, teamID, clinicianID)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "find care team member")
+	}
+	if existing == nil {
+		return errors.WithCode(code.ErrCareTeamNotFound, "care team member not found")
+	}
+	event := s.event(team, ActionMemberRemoved, operatorID)
+	event.ClinicianID = clinicianID
+	event.FromRole = existing.Role
+	removed, err := s.store.DeleteMember(ctx, orgID, teamID, clinicianID, event)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "remove care team member")
+	}
+	if !removed {
+		return errors.WithCode(code.ErrCareTeamNotFound, "care team member not found")
+	}
+	s.logMembership(ctx, event)
+	return nil
+}
+
+func (s *service) ListMembers(ctx context.Context, orgID int64, teamID uint64) ([]Member, error) {
+	if _, err := s.load(ctx, orgID, teamID); err != nil {
+		return nil, err
+	}
+	members, err := s.store.ListMembers(ctx, orgID, teamID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list care team members")
+	}
+	return members, nil
+}
+
+func (s *service) AssignTestee(ctx context.Context, orgID, operatorID int64, teamID, testeeID uint64) (*TesteeAssignment, error) {
+	team, err := s.load(ctx, orgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if testeeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "testee_id is required")
+	}
+	testeeItem, err := s.testeeReader.GetTestee(ctx, testeeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find testee")
+	}
+	if testeeItem == nil || testeeItem.OrgID != orgID {
+		return nil, errors.WithCode(code.ErrUserNotFound, "testee not found")
+	}
+	assignment := &TesteeAssignment{
+		TeamID:     team.ID,
+		OrgID:      orgID,
+		TesteeID:   testeeID,
+		TesteeName: testeeItem.Name,
+		AssignedBy: operatorID,
+		AssignedAt: s.now(),
+	}
+	event := s.event(team, ActionTesteeAssigned, operatorID)
+	event.TesteeID = testeeID
+	created, err := s.store.AssignTestee(ctx, assignment, event)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "assign testee to care team")
+	}
+	if created {
+		s.logMembership(ctx, event)
+	}
+	return assignment, nil
+}
+
+func (s *service) UnassignTestee(ctx context.Context, orgID, operatorID int64, teamID, testeeID uint64) error {
+	team, err := s.load(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+	event := s.event(team, ActionTesteeUnassigned, operatorID)
+	event.TesteeID = testeeID
+	removed, err := s.store.UnassignTestee(ctx, orgID, teamID, testeeID, event)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "unassign testee from care team")
+	}
+	if !removed {
+		return errors.WithCode(code.ErrCareTeamNotFound, "testee is not assigned to care team")
+	}
+	s.logMembership(ctx, event)
+	return nil
+}
+
+func (s *service) ListTestees(ctx context.Context, orgID int64, teamID uint64, page, pageSize int) (*TesteePage, error) {
+	if _, err := s.load(ctx, orgID, teamID); err != nil {
+		return nil, err
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	items, total, err := s.store.ListTestees(ctx, orgID, teamID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list care team testees")
+	}
+	return &TesteePage{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) ListEvents(ctx context.Context, orgID int64, teamID uint64, page, pageSize int) (*EventPage, error) {
+	// 团队删除后事件仍可查询，因此不校验团队是否存在。
+	if orgID <= 0 || teamID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id and team_id are required")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	items, total, err := s.store.ListEvents(ctx, orgID, teamID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list care team events")
+	}
+	return &EventPage{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) load(ctx context.Context, orgID int64, teamID uint64) (*Team, error) {
+	team, err := s.store.FindTeam(ctx, orgID, teamID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load care team")
+	}
+	if team == nil {
+		return nil, errors.WithCode(code.ErrCareTeamNotFound, "care team not found")
+	}
+	return team, nil
+}
+
+func (s *service) ensureNameAvailable(ctx context.Context, team *Team) error {
+	existing, err := s.store.FindTeamByName(ctx, team.OrgID, team.Name)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "find care team")
+	}
+	if existing != nil && existing.ID != team.ID {
+		return errors.WithCode(code.ErrCareTeamConflict, "care team %s already exists", team.Name)
+	}
+	return nil
+}
+
+// ensureMembersMatchDepartment 团队改为科室团队时，现有负责人与成员必须都属于该科室。
+func (s *service) ensureMembersMatchDepartment(ctx context.Context, team *Team) error {
+	if team.Department == "" {
+		return nil
+	}
+	members, err := s.store.ListMembers(ctx, team.OrgID, team.ID)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "list care team members")
+	}
+	for _, member := range members {
+		if member.Role.InheritsAccess() && member.Department != team.Department {
+			return errors.WithCode(code.ErrCareTeamConflict, "member %d department %q does not match team department %q", member.ClinicianID, member.Department, team.Department)
+		}
+	}
+	return nil
+}
+
+func (s *service) loadClinician(ctx context.Context, orgID int64, clinicianID uint64) (*actorreadmodel.ClinicianRow, error) {
+	if clinicianID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "clinician_id is required")
+	}
+	clinicianItem, err := s.clinicianReader.GetClinician(ctx, clinicianID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return nil, errors.WithCode(code.ErrUserNotFound, "clinician not found")
+		}
+		return nil, errors.Wrap(err, "failed to find clinician")
+	}
+	if clinicianItem == nil || clinicianItem.OrgID != orgID {
+		return nil, errors.WithCode(code.ErrUserNotFound, "clinician not found")
+	}
+	if !clinicianItem.IsActive {
+		return nil, errors.WithCode(code.ErrCareTeamConflict, "clinician is inactive")
+	}
+	return clinicianItem, nil
+}
+
+func (s *service) currentClinicianID(ctx context.Context, orgID, operatorUserID int64) (uint64, error) {
+	operatorItem, err := s.operatorReader.FindOperatorByUser(ctx, orgID, operatorUserID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, errors.WithCode(code.ErrPermissionDenied, "operator not found in current organization")
+		}
+		return 0, errors.Wrap(err, "failed to find operator")
+	}
+	if operatorItem == nil || !operatorItem.IsActive {
+		return 0, errors.WithCode(code.ErrPermissionDenied, "operator is inactive")
+	}
+	clinicianItem, err := s.clinicianReader.FindClinicianByOperator(ctx, orgID, operatorItem.ID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return 0, errors.WithCode(code.ErrPermissionDenied, "operator is not bound to clinician")
+		}
+		return 0, errors.Wrap(err, "failed to find clinician by operator")
+	}
+	if clinicianItem == nil || !clinicianItem.IsActive {
+		return 0, errors.WithCode(code.ErrPermissionDenied, "clinician is inactive")
+	}
+	return clinicianItem.ID, nil
+}
+
+func (s *service) event(team *Team, action Action, operatorID int64) *Event {
+	return &Event{
+		ID:             meta.New().Uint64(),
+		OrgID:          team.OrgID,
+		TeamID:         team.ID,
+		Action:         action,
+		OperatorUserID: operatorID,
+		OccurredAt:     s.now(),
+	}
+}
+
+func (s *service) logMembership(ctx context.Context, event *Event) {
+	logger.L(ctx).Infow("care team membership changed",
+		"action", string(event.Action),
+		"org_id", event.OrgID,
+		"team_id", event.TeamID,
+		"clinician_id", event.ClinicianID,
+		"testee_id", event.TesteeID,
+		"from_role", string(event.FromRole),
+		"to_role", string(event.ToRole),
+		"operator_id", event.OperatorUserID,
+	)
+}
+
+func applyTeamDTO(team *Team, dto TeamDTO) error {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "name must be 1-%d characters", maxNameRunes)
+	}
+	department := strings.TrimSpace(dto.Department)
+	if utf8.RuneCountInString(department) > maxDepartmentRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "department must be at most %d characters", maxDepartmentRunes)
+	}
+	description := strings.TrimSpace(dto.Description)
+	if utf8.RuneCountInString(description) > maxDescriptionRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "description must be at most %d characters", maxDescriptionRunes)
+	}
+	team.Name = name
+	team.Department = department
+	team.Description = description
+	return nil
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
@@ -0,0 +1,294 @@
+package careteam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type memberKey struct{ teamID, clinicianID uint64 }
+
+type fakeStore struct {
+	teams   map[uint64]*Team
+	members map[memberKey]*Member
+	testees map[memberKey]*TesteeAssignment
+	events  []Event
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{teams: map[uint64]*Team{}, members: map[memberKey]*Member{}, testees: map[memberKey]*TesteeAssignment{}}
+}
+
+func (s *fakeStore) HasTeamAccess(_ context.Context, orgID int64, clinicianID, testeeID uint64) (bool, error) {
+	for key, member := range s.members {
+		if member.OrgID == orgID && key.clinicianID == clinicianID && member.Role.InheritsAccess() {
+			if _, ok := s.testees[memberKey{key.teamID, testeeID}]; ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeStore) ListAccessibleTesteeIDs(context.Context, int64, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) ListTeamTesteeIDs(_ context.Context, _ int64, teamID uint64) ([]uint64, error) {
+	var ids []uint64
+	for key := range s.testees {
+		if key.teamID == teamID {
+			ids = append(ids, key.clinicianID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *fakeStore) FindMember(_ context.Context, _ int64, teamID, clinicianID uint64) (*Member, error) {
+	member, ok := s.members[memberKey{teamID, clinicianID}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *member
+	return &copied, nil
+}
+
+func (s *fakeStore) CreateTeam(_ context.Context, team *Team, event *Event) error {
+	copied := *team
+	s.teams[team.ID] = &copied
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func (s *fakeStore) UpdateTeam(ctx context.Context, team *Team, event *Event) error {
+	return s.CreateTeam(ctx, team, event)
+}
+
+func (s *fakeStore) DeleteTeam(_ context.Context, _ int64, teamID uint64, event *Event) error {
+	delete(s.teams, teamID)
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func (s *fakeStore) FindTeam(_ context.Context, orgID int64, teamID uint64) (*Team, error) {
+	team, ok := s.teams[teamID]
+	if !ok || team.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *team
+	return &copied, nil
+}
+
+func (s *fakeStore) FindTeamByName(_ context.Context, orgID int64, name string) (*Team, error) {
+	for _, team := range s.teams {
+		if team.OrgID == orgID && team.Name == name {
+			copied := *team
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) ListTeams(context.Context, TeamFilter) ([]Team, error) { return nil, nil }
+
+func (s *fakeStore) CreateMember(_ context.Context, member *Member, event *Event) error {
+	copied := *member
+	s.members[memberKey{member.TeamID, member.ClinicianID}] = &copied
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func (s *fakeStore) UpdateMemberRole(ctx context.Context, member *Member, event *Event) error {
+	return s.CreateMember(ctx, member, event)
+}
+
+func (s *fakeStore) DeleteMember(_ context.Context, _ int64, teamID, clinicianID uint64, event *Event) (bool, error) {
+	key := memberKey{teamID, clinicianID}
+	if _, ok := s.members[key]; !ok {
+		return false, nil
+	}
+	delete(s.members, key)
+	s.events = append(s.events, *event)
+	return true, nil
+}
+
+func (s *fakeStore) ListMembers(context.Context, int64, uint64) ([]Member, error) { return nil, nil }
+
+func (s *fakeStore) AssignTestee(_ context.Context, assignment *TesteeAssignment, event *Event) (bool, error) {
+	key := memberKey{assignment.TeamID, assignment.TesteeID}
+	if _, ok := s.testees[key]; ok {
+		return false, nil
+	}
+	copied := *assignment
+	s.testees[key] = &copied
+	s.events = append(s.events, *event)
+	return true, nil
+}
+
+func (s *fakeStore) UnassignTestee(_ context.Context, _ int64, teamID, testeeID uint64, event *Event) (bool, error) {
+	key := memberKey{teamID, testeeID}
+	if _, ok := s.testees[key]; !ok {
+		return false, nil
+	}
+	delete(s.testees, key)
+	s.events = append(s.events, *event)
+	return true, nil
+}
+
+func (s *fakeStore) ListTestees(context.Context, int64, uint64, int, int) ([]TesteeAssignment, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeStore) ListEvents(context.Context, int64, uint64, int, int) ([]Event, int64, error) {
+	return nil, 0, nil
+}
+
+// fakeReadModel 只实现照护团队用到的读模型方法，其余调用会因嵌入的 nil 接口而 panic。
+type fakeReadModel struct {
+	actorreadmodel.ReadModel
+	clinicians map[uint64]*actorreadmodel.ClinicianRow
+}
+
+func (f *fakeReadModel) GetClinician(_ context.Context, id uint64) (*actorreadmodel.ClinicianRow, error) {
+	row, ok := f.clinicians[id]
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrUserNotFound, "clinician not found")
+	}
+	return row, nil
+}
+
+func (f *fakeReadModel) GetTestee(_ context.Context, id uint64) (*actorreadmodel.TesteeRow, error) {
+	return &actorreadmodel.TesteeRow{ID: id, OrgID: 1, Name: "受试者"}, nil
+}
+
+func newTestService(store Store) *service {
+	readModel := &fakeReadModel{clinicians: map[uint64]*actorreadmodel.ClinicianRow{
+		301: {ID: 301, OrgID: 1, Name: "王医生", Department: "心理科", IsActive: true},
+		302: {ID: 302, OrgID: 1, Name: "李医生", Department: "儿科", IsActive: true},
+		303: {ID: 303, OrgID: 1, Name: "停用医生", Department: "心理科", IsActive: false},
+		304: {ID: 304, OrgID: 2, Name: "外机构", Department: "心理科", IsActive: true},
+	}}
+	svc := NewService(store, readModel, readModel, readModel).(*service)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestSetMemberEnforcesDepartmentAndRecordsRoleChanges(t *testing.T) {
+	store := newFakeStore()
+	svc := newTestService(store)
+	ctx := context.Background()
+	team, err := svc.CreateTeam(ctx, TeamDTO{OrgID: 1, OperatorID: 900, Name: " 心理科一组 ", Department: "心理科"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "心理科一组" {
+		t.Fatalf("team = %+v", team)
+	}
+	if _, err := svc.CreateTeam(ctx, TeamDTO{OrgID: 1, Name: "心理科一组"}); !cberrors.IsCode(err, code.ErrCareTeamConflict) {
+		t.Fatalf("duplicate name err = %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		dto  MemberDTO
+		want int
+	}{
+		"other department": {MemberDTO{OrgID: 1, TeamID: team.ID, ClinicianID: 302, Role: RoleMember}, code.ErrCareTeamConflict},
+		"inactive":         {MemberDTO{OrgID: 1, TeamID: team.ID, ClinicianID: 303, Role: RoleMember}, code.ErrCareTeamConflict},
+		"other org":        {MemberDTO{OrgID: 1, TeamID: team.ID, ClinicianID: 304, Role: RoleMember}, code.ErrUserNotFound},
+		"bad role":         {MemberDTO{OrgID: 1, TeamID: team.ID, ClinicianID: 301, Role: "owner"}, code.ErrInvalidArgument},
+		"unknown team":     {MemberDTO{OrgID: 1, TeamID: team.ID + 1000, ClinicianID: 301, Role: RoleMember}, code.ErrCareTeamNotFound},
+	} {
+		if _, err := svc.SetMember(ctx, tc.dto); !cberrors.IsCode(err, tc.want) {
+			t.Fatalf("%s: err = %v", name, err)
+		}
+	}
+
+	// 观察者不继承访问，可以来自其他科室。
+	if _, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 900, TeamID: team.ID, ClinicianID: 302, Role: RoleObserver}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 900, TeamID: team.ID, ClinicianID: 301, Role: RoleMember}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 900, TeamID: team.ID, ClinicianID: 301, Role: RoleMember}); err != nil {
+		t.Fatal(err)
+	}
+	lead, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 901, TeamID: team.ID, ClinicianID: 301, Role: RoleLead})
+	if err != nil || lead.Role != RoleLead {
+		t.Fatalf("promote = %+v, %v", lead, err)
+	}
+
+	actions := make([]Action, 0, len(store.events))
+	for _, event := range store.events {
+		actions = append(actions, event.Action)
+	}
+	want := []Action{ActionTeamCreated, ActionMemberAdded, ActionMemberAdded, ActionMemberRoleChanged}
+	if len(actions) != len(want) {
+		t.Fatalf("actions = %v", actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("actions = %v", actions)
+		}
+	}
+	changed := store.events[3]
+	if changed.FromRole != RoleMember || changed.ToRole != RoleLead || changed.ClinicianID != 301 || changed.OperatorUserID != 901 {
+		t.Fatalf("role change event = %+v", changed)
+	}
+}
+
+func TestTesteeAssignmentPropagatesAccessImmediately(t *testing.T) {
+	store := newFakeStore()
+	svc := newTestService(store)
+	ctx := context.Background()
+	team, err := svc.CreateTeam(ctx, TeamDTO{OrgID: 1, OperatorID: 900, Name: "跨科室会诊组"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 900, TeamID: team.ID, ClinicianID: 302, Role: RoleMember}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AssignTestee(ctx, 1, 900, team.ID, 401); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AssignTestee(ctx, 1, 900, team.ID, 401); err != nil {
+		t.Fatal(err)
+	}
+	if allowed, _ := store.HasTeamAccess(ctx, 1, 302, 401); !allowed {
+		t.Fatal("member should inherit access to team testee")
+	}
+
+	if _, err := svc.SetMember(ctx, MemberDTO{OrgID: 1, OperatorID: 900, TeamID: team.ID, ClinicianID: 302, Role: RoleObserver}); err != nil {
+		t.Fatal(err)
+	}
+	if allowed, _ := store.HasTeamAccess(ctx, 1, 302, 401); allowed {
+		t.Fatal("observer should not inherit access")
+	}
+
+	if err := svc.RemoveMember(ctx, 1, 900, team.ID, 302); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RemoveMember(ctx, 1, 900, team.ID, 302); !cberrors.IsCode(err, code.ErrCareTeamNotFound) {
+		t.Fatalf("second remove err = %v", err)
+	}
+	if err := svc.UnassignTestee(ctx, 1, 900, team.ID, 401); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.UnassignTestee(ctx, 1, 900, team.ID, 401); !cberrors.IsCode(err, code.ErrCareTeamNotFound) {
+		t.Fatalf("second unassign err = %v", err)
+	}
+
+	// 重复分配不写事件：创建、加入、分配、降级、移除、取消分配。
+	if len(store.events) != 6 {
+		t.Fatalf("events = %+v", store.events)
+	}
+	removed := store.events[4]
+	if removed.Action != ActionMemberRemoved || removed.FromRole != RoleObserver {
+		t.Fatalf("remove event = %+v", removed)
+	}
+}
@@ -0,0 +1,82 @@
+// Package careteam 照护团队：机构管理员按科室或跨科室组建从业者团队，把受试者分配给团队，
+// 团队成员按团队角色继承对这些受试者的访问（与授权关系、紧急访问并列的第三种访问来源）。
+//
+// 访问判定直接读取成员表与受试者分配表，成员变更即时生效，无需同步；
+// 每次团队、成员与受试者分配变更都与变更本身在同一事务内写入团队事件，作为审计轨迹。
+package careteam
+
+import domaincareteam "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/careteam"
+
+type (
+	MemberRole       = domaincareteam.MemberRole
+	Action           = domaincareteam.Action
+	Team             = domaincareteam.Team
+	Member           = domaincareteam.Member
+	TesteeAssignment = domaincareteam.TesteeAssignment
+	Event            = domaincareteam.Event
+	TeamFilter       = domaincareteam.TeamFilter
+)
+
+const (
+	RoleLead     = domaincareteam.RoleLead
+	RoleMember   = domaincareteam.RoleMember
+	RoleObserver = domaincareteam.RoleObserver
+
+	ActionTeamCreated       = domaincareteam.ActionTeamCreated
+	ActionTeamUpdated       = domaincareteam.ActionTeamUpdated
+	ActionTeamDeleted       = domaincareteam.ActionTeamDeleted
+	ActionMemberAdded       = domaincareteam.ActionMemberAdded
+	ActionMemberRoleChanged = domaincareteam.ActionMemberRoleChanged
+	ActionMemberRemoved     = domaincareteam.ActionMemberRemoved
+	ActionTesteeAssigned    = domaincareteam.ActionTesteeAssigned
+	ActionTesteeUnassigned  = domaincareteam.ActionTesteeUnassigned
+)
+
+const (
+	maxNameRunes        = 100
+	maxDepartmentRunes  = 50
+	maxDescriptionRunes = 500
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// TeamDTO 创建或更新团队。
+type TeamDTO struct {
+	OrgID       int64
+	OperatorID  int64
+	Name        string
+	Department  string
+	Description string
+}
+
+// MemberDTO 添加成员或调整成员角色。
+type MemberDTO struct {
+	OrgID       int64
+	OperatorID  int64
+	TeamID      uint64
+	ClinicianID uint64
+	Role        MemberRole
+}
+
+// TesteePage 团队受试者分页。
+type TesteePage struct {
+	Items    []TesteeAssignment
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// EventPage 团队事件分页。
+type EventPage struct {
+	Items    []Event
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// AccessReader 供访问控制、访问审计与工作台读取团队继承的受试者访问。
+type AccessReader = domaincareteam.AccessReader
+
+// Store 照护团队存储。
+type Store = domaincareteam.Repository
@@ -0,0 +1,50 @@
+package consent
+
+import domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+
+func toDocumentResult(doc *domainConsent.Document) *DocumentResult {
+	return &DocumentResult{
+		ID:          doc.ID().Uint64(),
+		OrgID:       doc.OrgID(),
+		ScopeKind:   doc.Scope().Kind.String(),
+		ScopeRef:    doc.Scope().Ref,
+		Version:     doc.Version(),
+		Title:       doc.Title(),
+		Body:        doc.Body(),
+		ContentHash: doc.ContentHash(),
+		Status:      string(doc.Status()),
+		CreatedBy:   doc.CreatedBy(),
+		CreatedAt:   doc.CreatedAt(),
+		PublishedBy: doc.PublishedBy(),
+		PublishedAt: doc.PublishedAt(),
+		RetiredAt:   doc.RetiredAt(),
+	}
+}
+
+func toAcceptanceResult(acceptance *domainConsent.Acceptance) *AcceptanceResult {
+	evidence := acceptance.Evidence()
+	result := &AcceptanceResult{
+		ID:               acceptance.ID().Uint64(),
+		OrgID:            acceptance.OrgID(),
+		DocumentID:       acceptance.DocumentID().Uint64(),
+		DocumentVersion:  acceptance.DocumentVersion(),
+		ScopeKind:        acceptance.Scope().Kind.String(),
+		ScopeRef:         acceptance.Scope().Ref,
+		ContentHash:      acceptance.ContentHash(),
+		TesteeID:         acceptance.TesteeID(),
+		GuardianRelation: string(acceptance.GuardianRelation()),
+		IP:               evidence.IP,
+		UserAgent:        evidence.UserAgent,
+		RequestID:        evidence.RequestID,
+		AcceptedAt:       acceptance.AcceptedAt(),
+		Active:           acceptance.IsActive(),
+		WithdrawnBy:      acceptance.WithdrawnBy(),
+		WithdrawnAt:      acceptance.WithdrawnAt(),
+		WithdrawReason:   acceptance.WithdrawReason(),
+	}
+	if filler := acceptance.Filler(); filler != nil {
+		result.FillerUserID = uint64(filler.UserID())
+		result.FillerType = filler.FillerType().String()
+	}
+	return result
+}
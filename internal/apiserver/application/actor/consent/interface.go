@@ -0,0 +1,166 @@
+// Package consent 知情同意应用服务：后台维护带版本的同意书与签署记录，
+// 受试者侧查询待签署项、签署与撤回，并为答卷提交提供同意校验。
+package consent
+
+import (
+	"context"
+	"time"
+
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
+)
+
+// Service 知情同意服务。
+type Service interface {
+	CreateDocument(ctx context.Context, dto CreateDocumentDTO) (*DocumentResult, error)
+	PublishDocument(ctx context.Context, orgID int64, documentID, operatorID uint64) (*DocumentResult, error)
+	RetireDocument(ctx context.Context, orgID int64, documentID uint64) (*DocumentResult, error)
+	GetDocument(ctx context.Context, orgID int64, documentID uint64) (*DocumentResult, error)
+	ListDocuments(ctx context.Context, dto ListDocumentsDTO) (*DocumentListResult, error)
+	ListAcceptances(ctx context.Context, dto ListAcceptancesDTO) (*AcceptanceListResult, error)
+	// WithdrawAcceptance 机构管理员代为撤回签署（例如受试者线下提出撤回）。
+	WithdrawAcceptance(ctx context.Context, dto WithdrawDTO) (*AcceptanceResult, error)
+
+	// ListRequirements 列出受试者作答前需要满足的同意书及签署情况。
+	ListRequirements(ctx context.Context, query RequirementQuery) (*RequirementResult, error)
+	Accept(ctx context.Context, dto AcceptDTO) (*AcceptanceResult, error)
+	// Withdraw 受试者或其监护人撤回自己的签署。
+	Withdraw(ctx context.Context, dto WithdrawDTO) (*AcceptanceResult, error)
+
+	consentgate.Checker
+}
+
+// TesteeReader 读取受试者的机构与出生日期。
+type TesteeReader interface {
+	GetByID(ctx context.Context, testeeID uint64) (*testeeApp.TesteeResult, error)
+}
+
+// CreateDocumentDTO 创建同意书草稿。
+type CreateDocumentDTO struct {
+	OrgID      int64
+	ScopeKind  string
+	ScopeRef   string
+	Title      string
+	Body       string
+	OperatorID uint64
+}
+
+// ListDocumentsDTO 查询同意书。
+type ListDocumentsDTO struct {
+	OrgID     int64
+	ScopeKind string
+	ScopeRef  string
+	Status    string
+	Page      int
+	PageSize  int
+}
+
+// ListAcceptancesDTO 查询签署记录。
+type ListAcceptancesDTO struct {
+	OrgID      int64
+	TesteeID   uint64
+	DocumentID uint64
+	ActiveOnly bool
+	Page       int
+	PageSize   int
+}
+
+// RequirementQuery 受试者作答前的同意要求查询。
+type RequirementQuery struct {
+	TesteeID          uint64
+	QuestionnaireCode string
+	EntryID           string
+}
+
+// AcceptDTO 签署同意书。
+type AcceptDTO struct {
+	TesteeID         uint64
+	DocumentID       uint64
+	FillerUserID     uint64
+	FillerType       string // self / guardian
+	GuardianRelation string // parent / legal_guardian，监护人签署时必填
+	IP               string
+	UserAgent        string
+	RequestID        string
+}
+
+// WithdrawDTO 撤回签署。受试者侧撤回时 TesteeID 必填，且必须与签署记录一致；
+// 后台撤回时 OrgID 必填。
+type WithdrawDTO struct {
+	OrgID        int64
+	TesteeID     uint64
+	AcceptanceID uint64
+	OperatorID   uint64
+	Reason       string
+}
+
+// DocumentResult 同意书。
+type DocumentResult struct {
+	ID          uint64
+	OrgID       int64
+	ScopeKind   string
+	ScopeRef    string
+	Version     int
+	Title       string
+	Body        string
+	ContentHash string
+	Status      string
+	CreatedBy   uint64
+	CreatedAt   time.Time
+	PublishedBy uint64
+	PublishedAt *time.Time
+	RetiredAt   *time.Time
+}
+
+// DocumentListResult 同意书分页。
+type DocumentListResult struct {
+	Items    []*DocumentResult
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// AcceptanceResult 签署记录。
+type AcceptanceResult struct {
+	ID               uint64
+	OrgID            int64
+	DocumentID       uint64
+	DocumentVersion  int
+	ScopeKind        string
+	ScopeRef         string
+	ContentHash      string
+	TesteeID         uint64
+	FillerUserID     uint64
+	FillerType       string
+	GuardianRelation string
+	IP               string
+	UserAgent        string
+	RequestID        string
+	AcceptedAt       time.Time
+	Active           bool
+	WithdrawnBy      uint64
+	WithdrawnAt      *time.Time
+	WithdrawReason   string
+}
+
+// AcceptanceListResult 签署记录分页。
+type AcceptanceListResult struct {
+	Items    []*AcceptanceResult
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// RequirementItem 一份需要签署的同意书及受试者当前的有效签署。
+type RequirementItem struct {
+	Document   *DocumentResult
+	Acceptance *AcceptanceResult // 未签署或已撤回时为 nil
+}
+
+// RequirementResult 受试者作答前的同意要求。
+type RequirementResult struct {
+	TesteeID    uint64
+	MinorTestee bool // 未成年受试者只能由监护人签署
+	Satisfied   bool
+	Items       []RequirementItem
+}
@@ -0,0 +1,356 @@
+package consent
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type service struct {
+	repo    domainConsent.Repository
+	testees TesteeReader
+	tx      apptransaction.Runner
+	events  appEventing.ProfileBinding
+	now     func() time.Time
+}
+
+// NewService 创建知情同意服务。撤回事件通过 events 在撤回事务内写入 outbox。
+func NewService(repo domainConsent.Repository, testees TesteeReader, tx apptransaction.Runner, events appEventing.ProfileBinding) Service {
+	return &service{repo: repo, testees: testees, tx: tx, events: events, now: time.Now}
+}
+
+func (s *service) CreateDocument(ctx context.Context, dto CreateDocumentDTO) (*DocumentResult, error) {
+	scope, err := domainConsent.NewScope(domainConsent.ScopeKind(strings.TrimSpace(dto.ScopeKind)), dto.ScopeRef)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.repo.NextVersion(ctx, dto.OrgID, scope)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "allocate consent document version")
+	}
+	doc, err := domainConsent.NewDocument(domainConsent.NewID(meta.New().Uint64()), dto.OrgID, scope, version, dto.Title, dto.Body, dto.OperatorID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveDocument(ctx, doc); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save consent document")
+	}
+	return toDocumentResult(doc), nil
+}
+
+func (s *service) PublishDocument(ctx context.Context, orgID int64, documentID, operatorID uint64) (*DocumentResult, error) {
+	var published *domainConsent.Document
+	err := s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		doc, err := s.loadDocument(txCtx, orgID, documentID)
+		if err != nil {
+			return err
+		}
+		current, err := s.repo.FindPublished(txCtx, orgID, []domainConsent.Scope{doc.Scope()})
+		if err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "load published consent documents")
+		}
+		if err := doc.Publish(operatorID, s.now()); err != nil {
+			return err
+		}
+		for _, previous := range current {
+			previous.Supersede()
+			if err := s.repo.UpdateDocument(txCtx, previous); err != nil {
+				return errors.WrapC(err, code.ErrDatabase, "supersede consent document")
+			}
+		}
+		if err := s.repo.UpdateDocument(txCtx, doc); err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "publish consent document")
+		}
+		published = doc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDocumentResult(published), nil
+}
+
+func (s *service) RetireDocument(ctx context.Context, orgID int64, documentID uint64) (*DocumentResult, error) {
+	doc, err := s.loadDocument(ctx, orgID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Retire(s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateDocument(ctx, doc); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "retire consent document")
+	}
+	return toDocumentResult(doc), nil
+}
+
+func (s *service) GetDocument(ctx context.Context, orgID int64, documentID uint64) (*DocumentResult, error) {
+	doc, err := s.loadDocument(ctx, orgID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	return toDocumentResult(doc), nil
+}
+
+func (s *service) ListDocuments(ctx context.Context, dto ListDocumentsDTO) (*DocumentListResult, error) {
+	filter := domainConsent.DocumentFilter{
+		ScopeKind: domainConsent.ScopeKind(strings.TrimSpace(dto.ScopeKind)),
+		ScopeRef:  strings.TrimSpace(dto.ScopeRef),
+		Status:    domainConsent.DocumentStatus(strings.TrimSpace(dto.Status)),
+	}
+	page, pageSize := normalizePage(dto.Page, dto.PageSize)
+	docs, total, err := s.repo.ListDocuments(ctx, dto.OrgID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list consent documents")
+	}
+	items := make([]*DocumentResult, 0, len(docs))
+	for _, doc := range docs {
+		items = append(items, toDocumentResult(doc))
+	}
+	return &DocumentListResult{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) ListAcceptances(ctx context.Context, dto ListAcceptancesDTO) (*AcceptanceListResult, error) {
+	filter := domainConsent.AcceptanceFilter{TesteeID: dto.TesteeID, DocumentID: dto.DocumentID, ActiveOnly: dto.ActiveOnly}
+	page, pageSize := normalizePage(dto.Page, dto.PageSize)
+	acceptances, total, err := s.repo.ListAcceptances(ctx, dto.OrgID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list consent acceptances")
+	}
+	items := make([]*AcceptanceResult, 0, len(acceptances))
+	for _, acceptance := range acceptances {
+		items = append(items, toAcceptanceResult(acceptance))
+	}
+	return &AcceptanceListResult{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) WithdrawAcceptance(ctx context.Context, dto WithdrawDTO) (*AcceptanceResult, error) {
+	acceptance, err := s.loadAcceptance(ctx, dto.OrgID, dto.AcceptanceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withdraw(ctx, acceptance, dto.OperatorID, dto.Reason)
+}
+
+func (s *service) ListRequirements(ctx context.Context, query RequirementQuery) (*RequirementResult, error) {
+	testee, err := s.loadTestee(ctx, query.TesteeID)
+	if err != nil {
+		return nil, err
+	}
+	required, accepted, err := s.requirements(ctx, testee.OrgID, testee.ID, query.QuestionnaireCode, query.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	result := &RequirementResult{
+		TesteeID:    testee.ID,
+		MinorTestee: domainConsent.IsMinor(testee.Birthday, s.now()),
+		Satisfied:   len(domainConsent.Missing(required, accepted)) == 0,
+		Items:       make([]RequirementItem, 0, len(required)),
+	}
+	for _, doc := range required {
+		item := RequirementItem{Document: toDocumentResult(doc)}
+		for _, acceptance := range accepted {
+			if acceptance.Satisfies(doc) {
+				item.Acceptance = toAcceptanceResult(acceptance)
+				break
+			}
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+func (s *service) Accept(ctx context.Context, dto AcceptDTO) (*AcceptanceResult, error) {
+	testee, err := s.loadTestee(ctx, dto.TesteeID)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.loadDocument(ctx, testee.OrgID, dto.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListActiveAcceptances(ctx, testee.OrgID, testee.ID, []domainConsent.ID{doc.ID()})
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load consent acceptances")
+	}
+	for _, acceptance := range existing {
+		if acceptance.Satisfies(doc) {
+			return toAcceptanceResult(acceptance), nil
+		}
+	}
+
+	filler := actor.NewFillerRef(int64(dto.FillerUserID), actor.FillerType(strings.TrimSpace(dto.FillerType)))
+	acceptance, err := domainConsent.Accept(
+		domainConsent.NewID(meta.New().Uint64()),
+		doc,
+		domainConsent.Subject{TesteeID: testee.ID, OrgID: testee.OrgID, Birthday: testee.Birthday},
+		filler,
+		domainConsent.GuardianRelation(strings.TrimSpace(dto.GuardianRelation)),
+		domainConsent.Evidence{IP: dto.IP, UserAgent: dto.UserAgent, RequestID: dto.RequestID},
+		s.now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveAcceptance(ctx, acceptance); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save consent acceptance")
+	}
+	logger.L(ctx).Infow("知情同意已签署",
+		"action", "accept_consent",
+		"testee_id", testee.ID,
+		"document_id", doc.ID().Uint64(),
+		"document_version", doc.Version(),
+		"filler_type", filler.FillerType().String(),
+	)
+	return toAcceptanceResult(acceptance), nil
+}
+
+func (s *service) Withdraw(ctx context.Context, dto WithdrawDTO) (*AcceptanceResult, error) {
+	testee, err := s.loadTestee(ctx, dto.TesteeID)
+	if err != nil {
+		return nil, err
+	}
+	acceptance, err := s.loadAcceptance(ctx, testee.OrgID, dto.AcceptanceID)
+	if err != nil {
+		return nil, err
+	}
+	if acceptance.TesteeID() != testee.ID {
+		return nil, errors.WithCode(code.ErrConsentAcceptanceNotFound, "consent acceptance not found")
+	}
+	return s.withdraw(ctx, acceptance, dto.OperatorID, dto.Reason)
+}
+
+// CheckSubmission 答卷提交前的同意校验。
+func (s *service) CheckSubmission(ctx context.Context, request consentgate.CheckRequest) error {
+	orgID := request.OrgID
+	if orgID == 0 {
+		testee, err := s.loadTestee(ctx, request.TesteeID)
+		if err != nil {
+			return err
+		}
+		orgID = testee.OrgID
+	}
+	required, accepted, err := s.requirements(ctx, orgID, request.TesteeID, request.QuestionnaireCode, request.EntryID)
+	if err != nil {
+		return err
+	}
+	missing := domainConsent.Missing(required, accepted)
+	if len(missing) == 0 {
+		return nil
+	}
+	refs := make([]string, 0, len(missing))
+	for _, doc := range missing {
+		refs = append(refs, doc.ID().String()+"@v"+strconv.Itoa(doc.Version()))
+	}
+	return errors.WithCode(code.ErrConsentRequired, "testee %d has not accepted consent documents %s", request.TesteeID, strings.Join(refs, ","))
+}
+
+func (s *service) withdraw(ctx context.Context, acceptance *domainConsent.Acceptance, by uint64, reason string) (*AcceptanceResult, error) {
+	if err := acceptance.Withdraw(by, reason, s.now()); err != nil {
+		return nil, err
+	}
+	events := []event.DomainEvent{domainConsent.NewWithdrawnEvent(acceptance)}
+	err := s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.UpdateAcceptance(txCtx, acceptance); err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "withdraw consent acceptance")
+		}
+		if s.events.Stager == nil {
+			return nil
+		}
+		return s.events.Stager.Stage(txCtx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.events.PostCommit != nil {
+		s.events.PostCommit.AfterCommit(ctx, events, s.now())
+	}
+	return toAcceptanceResult(acceptance), nil
+}
+
+func (s *service) requirements(ctx context.Context, orgID int64, testeeID uint64, questionnaireCode, entryID string) ([]*domainConsent.Document, []*domainConsent.Acceptance, error) {
+	required, err := s.repo.FindPublished(ctx, orgID, domainConsent.SubmissionScopes(questionnaireCode, entryID))
+	if err != nil {
+		return nil, nil, errors.WrapC(err, code.ErrDatabase, "load required consent documents")
+	}
+	if len(required) == 0 {
+		return nil, nil, nil
+	}
+	ids := make([]domainConsent.ID, 0, len(required))
+	for _, doc := range required {
+		ids = append(ids, doc.ID())
+	}
+	accepted, err := s.repo.ListActiveAcceptances(ctx, orgID, testeeID, ids)
+	if err != nil {
+		return nil, nil, errors.WrapC(err, code.ErrDatabase, "load consent acceptances")
+	}
+	return required, accepted, nil
+}
+
+func (s *service) loadTestee(ctx context.Context, testeeID uint64) (*testeeApp.TesteeResult, error) {
+	if testeeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "testee_id is required")
+	}
+	testee, err := s.testees.GetByID(ctx, testeeID)
+	if err != nil {
+		return nil, err
+	}
+	if testee == nil {
+		return nil, errors.WithCode(code.ErrUserNotFound, "testee not found")
+	}
+	return testee, nil
+}
+
+func (s *service) loadDocument(ctx context.Context, orgID int64, documentID uint64) (*domainConsent.Document, error) {
+	if documentID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "consent document id is required")
+	}
+	doc, err := s.repo.FindDocument(ctx, orgID, domainConsent.NewID(documentID))
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load consent document")
+	}
+	if doc == nil {
+		return nil, errors.WithCode(code.ErrConsentDocumentNotFound, "consent document not found")
+	}
+	return doc, nil
+}
+
+func (s *service) loadAcceptance(ctx context.Context, orgID int64, acceptanceID uint64) (*domainConsent.Acceptance, error) {
+	if acceptanceID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "consent acceptance id is required")
+	}
+	acceptance, err := s.repo.FindAcceptance(ctx, orgID, domainConsent.NewID(acceptanceID))
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load consent acceptance")
+	}
+	if acceptance == nil {
+		return nil, errors.WithCode(code.ErrConsentAcceptanceNotFound, "consent acceptance not found")
+	}
+	return acceptance, nil
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
@@ -0,0 +1,265 @@
+package consent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeRepo struct {
+	documents   map[uint64]*domainConsent.Document
+	acceptances map[uint64]*domainConsent.Acceptance
+	versions    map[domainConsent.Scope]int
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		documents:   map[uint64]*domainConsent.Document{},
+		acceptances: map[uint64]*domainConsent.Acceptance{},
+		versions:    map[domainConsent.Scope]int{},
+	}
+}
+
+func (f *fakeRepo) NextVersion(_ context.Context, _ int64, scope domainConsent.Scope) (int, error) {
+	f.versions[scope]++
+	return f.versions[scope], nil
+}
+
+func (f *fakeRepo) SaveDocument(_ context.Context, doc *domainConsent.Document) error {
+	f.documents[doc.ID().Uint64()] = doc
+	return nil
+}
+
+func (f *fakeRepo) UpdateDocument(ctx context.Context, doc *domainConsent.Document) error {
+	return f.SaveDocument(ctx, doc)
+}
+
+func (f *fakeRepo) FindDocument(_ context.Context, orgID int64, id domainConsent.ID) (*domainConsent.Document, error) {
+	doc := f.documents[id.Uint64()]
+	if doc == nil || doc.OrgID() != orgID {
+		return nil, nil
+	}
+	return doc, nil
+}
+
+func (f *fakeRepo) ListDocuments(context.Context, int64, domainConsent.DocumentFilter, int, int) ([]*domainConsent.Document, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeRepo) FindPublished(_ context.Context, orgID int64, scopes []domainConsent.Scope) ([]*domainConsent.Document, error) {
+	var out []*domainConsent.Document
+	for _, doc := range f.documents {
+		if doc.OrgID() != orgID || !doc.IsPublished() {
+			continue
+		}
+		for _, scope := range scopes {
+			if doc.Scope() == scope {
+				out = append(out, doc)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) SaveAcceptance(_ context.Context, acceptance *domainConsent.Acceptance) error {
+	f.acceptances[acceptance.ID().Uint64()] = acceptance
+	return nil
+}
+
+func (f *fakeRepo) UpdateAcceptance(ctx context.Context, acceptance *domainConsent.Acceptance) error {
+	return f.SaveAcceptance(ctx, acceptance)
+}
+
+func (f *fakeRepo) FindAcceptance(_ context.Context, orgID int64, id domainConsent.ID) (*domainConsent.Acceptance, error) {
+	acceptance := f.acceptances[id.Uint64()]
+	if acceptance == nil || acceptance.OrgID() != orgID {
+		return nil, nil
+	}
+	return acceptance, nil
+}
+
+func (f *fakeRepo) ListActiveAcceptances(_ context.Context, orgID int64, testeeID uint64, ids []domainConsent.ID) ([]*domainConsent.Acceptance, error) {
+	var out []*domainConsent.Acceptance
+	for _, acceptance := range f.acceptances {
+		if acceptance.OrgID() != orgID || acceptance.TesteeID() != testeeID || !acceptance.IsActive() {
+			continue
+		}
+		for _, id := range ids {
+			if acceptance.DocumentID() == id {
+				out = append(out, acceptance)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) ListAcceptances(context.Context, int64, domainConsent.AcceptanceFilter, int, int) ([]*domainConsent.Acceptance, int64, error) {
+	return nil, 0, nil
+}
+
+type fakeTestees map[uint64]*testeeApp.TesteeResult
+
+func (f fakeTestees) GetByID(_ context.Context, id uint64) (*testeeApp.TesteeResult, error) {
+	if testee, ok := f[id]; ok {
+		return testee, nil
+	}
+	return nil, cberrors.WithCode(code.ErrUserNotFound, "testee not found")
+}
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordingEvents struct {
+	staged    []event.DomainEvent
+	committed []event.DomainEvent
+}
+
+func (r *recordingEvents) Stage(_ context.Context, events ...event.DomainEvent) error {
+	r.staged = append(r.staged, events...)
+	return nil
+}
+
+func (r *recordingEvents) AfterCommit(_ context.Context, events []event.DomainEvent, _ time.Time) {
+	r.committed = append(r.committed, events...)
+}
+
+func newFixture() (*service, *fakeRepo, *recordingEvents) {
+	minorBirthday := time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := newFakeRepo()
+	events := &recordingEvents{}
+	testees := fakeTestees{
+		3: {ID: 3, OrgID: 7},
+		4: {ID: 4, OrgID: 7, Birthday: &minorBirthday},
+	}
+	svc := NewService(repo, testees, inlineTx{}, appEventing.ProfileBinding{Stager: events, PostCommit: events}).(*service)
+	svc.now = func() time.Time { return time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC) }
+	return svc, repo, events
+}
+
+func publish(t *testing.T, svc *service, scopeKind, scopeRef, body string) *DocumentResult {
+	t.Helper()
+	ctx := context.Background()
+	doc, err := svc.CreateDocument(ctx, CreateDocumentDTO{OrgID: 7, ScopeKind: scopeKind, ScopeRef: scopeRef, Title: "知情同意书", Body: body, OperatorID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := svc.PublishDocument(ctx, 7, doc.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return published
+}
+
+func TestPublishSupersedesPreviousVersion(t *testing.T) {
+	svc, repo, _ := newFixture()
+	v1 := publish(t, svc, "model", "SDS", "第一版")
+	v2 := publish(t, svc, "model", "SDS", "第二版")
+	if v1.Version != 1 || v2.Version != 2 {
+		t.Fatalf("versions = %d, %d", v1.Version, v2.Version)
+	}
+	if status := repo.documents[v1.ID].Status(); status != domainConsent.DocumentStatusSuperseded {
+		t.Fatalf("v1 status = %s", status)
+	}
+}
+
+func TestCheckSubmissionRequiresEveryScope(t *testing.T) {
+	svc, _, _ := newFixture()
+	ctx := context.Background()
+	request := consentgate.CheckRequest{OrgID: 7, TesteeID: 3, QuestionnaireCode: "SDS", EntryID: "55"}
+	if err := svc.CheckSubmission(ctx, request); err != nil {
+		t.Fatalf("no consent documents configured: err = %v", err)
+	}
+
+	orgDoc := publish(t, svc, "org", "", "机构同意书")
+	entryDoc := publish(t, svc, "entry", "55", "入口同意书")
+	if err := svc.CheckSubmission(ctx, request); !cberrors.IsCode(err, code.ErrConsentRequired) {
+		t.Fatalf("err = %v, want consent required", err)
+	}
+
+	for _, doc := range []*DocumentResult{orgDoc, entryDoc} {
+		if _, err := svc.Accept(ctx, AcceptDTO{TesteeID: 3, DocumentID: doc.ID, FillerUserID: 30, FillerType: "self"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.CheckSubmission(ctx, request); err != nil {
+		t.Fatalf("all consents accepted: err = %v", err)
+	}
+	if err := svc.CheckSubmission(ctx, consentgate.CheckRequest{TesteeID: 3, QuestionnaireCode: "SDS"}); err != nil {
+		t.Fatalf("org resolved from testee: err = %v", err)
+	}
+
+	publish(t, svc, "org", "", "机构同意书第二版")
+	if err := svc.CheckSubmission(ctx, request); !cberrors.IsCode(err, code.ErrConsentRequired) {
+		t.Fatalf("new version must be re-accepted: err = %v", err)
+	}
+}
+
+func TestAcceptIsIdempotentAndEnforcesGuardianForMinor(t *testing.T) {
+	svc, repo, _ := newFixture()
+	ctx := context.Background()
+	doc := publish(t, svc, "org", "", "机构同意书")
+
+	if _, err := svc.Accept(ctx, AcceptDTO{TesteeID: 4, DocumentID: doc.ID, FillerUserID: 40, FillerType: "self"}); !cberrors.IsCode(err, code.ErrConsentInvalid) {
+		t.Fatalf("minor self consent err = %v", err)
+	}
+	first, err := svc.Accept(ctx, AcceptDTO{TesteeID: 4, DocumentID: doc.ID, FillerUserID: 41, FillerType: "guardian", GuardianRelation: "parent", IP: "10.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := svc.Accept(ctx, AcceptDTO{TesteeID: 4, DocumentID: doc.ID, FillerUserID: 41, FillerType: "guardian", GuardianRelation: "parent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != second.ID || len(repo.acceptances) != 1 {
+		t.Fatalf("accept should be idempotent: %d vs %d (%d records)", first.ID, second.ID, len(repo.acceptances))
+	}
+
+	requirements, err := svc.ListRequirements(ctx, RequirementQuery{TesteeID: 4, QuestionnaireCode: "SDS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !requirements.MinorTestee || !requirements.Satisfied || requirements.Items[0].Acceptance == nil || requirements.Items[0].Acceptance.GuardianRelation != "parent" {
+		t.Fatalf("requirements = %+v", requirements)
+	}
+}
+
+func TestWithdrawEmitsEventAndBlocksSubmission(t *testing.T) {
+	svc, _, events := newFixture()
+	ctx := context.Background()
+	doc := publish(t, svc, "org", "", "机构同意书")
+	accepted, err := svc.Accept(ctx, AcceptDTO{TesteeID: 3, DocumentID: doc.ID, FillerUserID: 30, FillerType: "self"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Withdraw(ctx, WithdrawDTO{TesteeID: 4, AcceptanceID: accepted.ID, OperatorID: 40}); !cberrors.IsCode(err, code.ErrConsentAcceptanceNotFound) {
+		t.Fatalf("withdraw by another testee err = %v", err)
+	}
+	withdrawn, err := svc.Withdraw(ctx, WithdrawDTO{TesteeID: 3, AcceptanceID: accepted.ID, OperatorID: 30, Reason: "不再参与"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdrawn.Active || withdrawn.WithdrawnAt == nil {
+		t.Fatalf("withdrawn = %+v", withdrawn)
+	}
+	if len(events.staged) != 1 || len(events.committed) != 1 || events.staged[0].EventType() != domainConsent.EventTypeWithdrawn {
+		t.Fatalf("events staged=%d committed=%d", len(events.staged), len(events.committed))
+	}
+	if err := svc.CheckSubmission(ctx, consentgate.CheckRequest{OrgID: 7, TesteeID: 3}); !cberrors.IsCode(err, code.ErrConsentRequired) {
+		t.Fatalf("withdrawn consent must block submission: err = %v", err)
+	}
+	if _, err := svc.WithdrawAcceptance(ctx, WithdrawDTO{OrgID: 7, AcceptanceID: accepted.ID, OperatorID: 1}); !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("second withdraw err = %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package customrole
+
+import (
+	"sync"
+	"time"
+
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+)
+
+// grantCacheTTL 自定义角色授权的进程内缓存时长，与 IAM 授权快照缓存保持一致。
+// 本实例上的角色与分配变更立即失效；其他实例最多滞后该时长。
+const grantCacheTTL = 30 * time.Second
+
+type grantCacheKey struct {
+	orgID  int64
+	userID int64
+}
+
+type cachedGrants struct {
+	grants    []authzapp.RoleGrant
+	expiresAt time.Time
+}
+
+// grantCache 按 (机构, 用户) 缓存自定义角色授权，避免每个请求都查询角色分配。
+// 机构代数在每次失效时递增，失效前开始的加载结果不会写回缓存。
+type grantCache struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	entries    map[grantCacheKey]cachedGrants
+	generation map[int64]uint64
+}
+
+func newGrantCache(ttl time.Duration) *grantCache {
+	return &grantCache{
+		ttl:        ttl,
+		entries:    make(map[grantCacheKey]cachedGrants),
+		generation: make(map[int64]uint64),
+	}
+}
+
+// get 返回未过期的缓存授权；未命中时同时返回当前机构代数，供 set 判断结果是否仍然有效。
+func (c *grantCache) get(orgID, userID int64, now time.Time) ([]authzapp.RoleGrant, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := grantCacheKey{orgID: orgID, userID: userID}
+	entry, ok := c.entries[key]
+	if ok && now.Before(entry.expiresAt) {
+		return entry.grants, 0, true
+	}
+	if ok {
+		delete(c.entries, key)
+	}
+	return nil, c.generation[orgID], false
+}
+
+func (c *grantCache) set(orgID, userID int64, generation uint64, grants []authzapp.RoleGrant, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation[orgID] != generation {
+		return
+	}
+	c.entries[grantCacheKey{orgID: orgID, userID: userID}] = cachedGrants{grants: grants, expiresAt: now.Add(c.ttl)}
+}
+
+// invalidateOrg 角色与分配变更不频繁，按机构整体失效即可覆盖角色内容与分配关系的所有变化。
+func (c *grantCache) invalidateOrg(orgID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation[orgID]++
+	for key := range c.entries {
+		if key.orgID == orgID {
+			delete(c.entries, key)
+		}
+	}
+}
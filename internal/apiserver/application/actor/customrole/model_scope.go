@@ -0,0 +1,42 @@
+package customrole
+
+import (
+	"context"
+	stderrors "errors"
+
+	domainmodelcatalog "github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	modelcatalogport "github.com/FangcunMount/qs-server/internal/apiserver/port/modelcatalog"
+)
+
+// ModelScopeResolver 把答卷所属问卷解析为测评模型编码，供按模型范围判定自定义角色。
+type ModelScopeResolver interface {
+	// ResolveModelCode 问卷未绑定已发布模型时返回空串。
+	ResolveModelCode(ctx context.Context, questionnaireCode, questionnaireVersion string) (string, error)
+}
+
+type publishedModelScopeResolver struct {
+	reader modelcatalogport.PublishedModelReader
+}
+
+// NewPublishedModelScopeResolver 基于已发布模型快照解析；reader 为空时返回 nil。
+func NewPublishedModelScopeResolver(reader modelcatalogport.PublishedModelReader) ModelScopeResolver {
+	if reader == nil {
+		return nil
+	}
+	return publishedModelScopeResolver{reader: reader}
+}
+
+func (r publishedModelScopeResolver) ResolveModelCode(ctx context.Context, questionnaireCode, questionnaireVersion string) (string, error) {
+	if questionnaireCode == "" {
+		return "", nil
+	}
+	model, err := r.reader.FindPublishedModelByQuestionnaire(ctx, questionnaireCode, questionnaireVersion)
+	if stderrors.Is(err, domainmodelcatalog.ErrNotFound) || (err == nil && model == nil) {
+		// 未绑定已发布模型按“无模型”处理，有模型范围的角色会被拒绝。
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return model.Code, nil
+}
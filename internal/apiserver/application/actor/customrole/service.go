@@ -0,0 +1,418 @@
+package customrole
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	domaincustomrole "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/customrole"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/iambridge"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+var roleCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,63}$`)
+
+// Service 自定义角色用例。
+type Service interface {
+	GrantLoader
+
+	CreateRole(ctx context.Context, dto RoleDTO) (*Role, error)
+	UpdateRole(ctx context.Context, roleID uint64, dto RoleDTO) (*Role, error)
+	DeleteRole(ctx context.Context, orgID, operatorID int64, roleID uint64) error
+	GetRole(ctx context.Context, orgID int64, roleID uint64) (*Role, error)
+	ListRoles(ctx context.Context, orgID int64) ([]Role, error)
+
+	// AssignRole 把角色分配给后台操作者；已分配时返回现有分配。
+	AssignRole(ctx context.Context, orgID, assignedBy int64, roleID, operatorID uint64) (*Assignment, error)
+	UnassignRole(ctx context.Context, orgID int64, roleID, operatorID uint64) error
+	ListAssignments(ctx context.Context, orgID int64, roleID uint64) ([]Assignment, error)
+
+	// Explain 解释某个操作者能否对目标资源行使能力，以及各授权来源的结论。
+	Explain(ctx context.Context, query ExplainQuery) (*Explanation, error)
+}
+
+type service struct {
+	store          Store
+	operatorReader actorreadmodel.OperatorReader
+	snapshotReader iambridge.AuthzSnapshotReader
+	grants         *grantCache
+	now            func() time.Time
+}
+
+// NewService 创建自定义角色服务；snapshotReader 为空时只能解释当前请求自身的授权。
+func NewService(store Store, operatorReader actorreadmodel.OperatorReader, snapshotReader iambridge.AuthzSnapshotReader) Service {
+	return &service{
+		store:          store,
+		operatorReader: operatorReader,
+		snapshotReader: snapshotReader,
+		grants:         newGrantCache(grantCacheTTL),
+		now:            time.Now,
+	}
+}
+
+func (s *service) CreateRole(ctx context.Context, dto RoleDTO) (*Role, error) {
+	roleCode := strings.TrimSpace(dto.Code)
+	if !roleCodePattern.MatchString(roleCode) {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "code must match %s", roleCodePattern.String())
+	}
+	role := &Role{ID: meta.New().Uint64(), OrgID: dto.OrgID, Code: roleCode, CreatedBy: dto.OperatorID}
+	if err := applyRoleDTO(role, dto); err != nil {
+		return nil, err
+	}
+	existing, err := s.store.FindRoleByCode(ctx, dto.OrgID, roleCode)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find custom role")
+	}
+	if existing != nil {
+		return nil, errors.WithCode(code.ErrCustomRoleConflict, "custom role %s already exists", roleCode)
+	}
+	role.CreatedAt = s.now()
+	role.UpdatedAt = role.CreatedAt
+	if err := s.store.CreateRole(ctx, role); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save custom role")
+	}
+	logger.L(ctx).Infow("custom role created",
+		"action", "create_custom_role",
+		"org_id", role.OrgID,
+		"role_id", role.ID,
+		"role_code", role.Code,
+		"capabilities", role.Capabilities,
+		"sensitivity", role.Sensitivity,
+		"operator_id", dto.OperatorID,
+	)
+	return role, nil
+}
+
+func (s *service) UpdateRole(ctx context.Context, roleID uint64, dto RoleDTO) (*Role, error) {
+	role, err := s.load(ctx, dto.OrgID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRoleDTO(role, dto); err != nil {
+		return nil, err
+	}
+	role.UpdatedAt = s.now()
+	if err := s.store.UpdateRole(ctx, role); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "update custom role")
+	}
+	s.grants.invalidateOrg(role.OrgID)
+	logger.L(ctx).Infow("custom role updated",
+		"action", "update_custom_role",
+		"org_id", role.OrgID,
+		"role_id", role.ID,
+		"capabilities", role.Capabilities,
+		"sensitivity", role.Sensitivity,
+		"operator_id", dto.OperatorID,
+	)
+	return role, nil
+}
+
+func (s *service) DeleteRole(ctx context.Context, orgID, operatorID int64, roleID uint64) error {
+	role, err := s.load(ctx, orgID, roleID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteRole(ctx, orgID, role.ID); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "delete custom role")
+	}
+	s.grants.invalidateOrg(orgID)
+	logger.L(ctx).Infow("custom role deleted",
+		"action", "delete_custom_role",
+		"org_id", orgID,
+		"role_id", role.ID,
+		"role_code", role.Code,
+		"operator_id", operatorID,
+	)
+	return nil
+}
+
+func (s *service) GetRole(ctx context.Context, orgID int64, roleID uint64) (*Role, error) {
+	return s.load(ctx, orgID, roleID)
+}
+
+func (s *service) ListRoles(ctx context.Context, orgID int64) ([]Role, error) {
+	roles, err := s.store.ListRoles(ctx, orgID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list custom roles")
+	}
+	return roles, nil
+}
+
+func (s *service) AssignRole(ctx context.Context, orgID, assignedBy int64, roleID, operatorID uint64) (*Assignment, error) {
+	role, err := s.load(ctx, orgID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := s.operatorReader.GetOperator(ctx, operatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find operator")
+	}
+	if operator == nil || operator.OrgID != orgID {
+		return nil, errors.WithCode(code.ErrUserNotFound, "operator not found")
+	}
+	if !operator.IsActive {
+		return nil, errors.WithCode(code.ErrCustomRoleConflict, "operator is inactive")
+	}
+	assignment := &Assignment{
+		ID:           meta.New().Uint64(),
+		OrgID:        orgID,
+		RoleID:       role.ID,
+		OperatorID:   operator.ID,
+		UserID:       operator.UserID,
+		OperatorName: operator.Name,
+		AssignedBy:   assignedBy,
+		AssignedAt:   s.now(),
+	}
+	created, err := s.store.CreateAssignment(ctx, assignment)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save custom role assignment")
+	}
+	s.grants.invalidateOrg(orgID)
+	if !created {
+		assignments, err := s.ListAssignments(ctx, orgID, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range assignments {
+			if assignments[i].OperatorID == operator.ID {
+				return &assignments[i], nil
+			}
+		}
+	}
+	logger.L(ctx).Infow("custom role assigned",
+		"action", "assign_custom_role",
+		"org_id", orgID,
+		"role_id", role.ID,
+		"role_code", role.Code,
+		"operator_id", operator.ID,
+		"user_id", operator.UserID,
+		"assigned_by", assignedBy,
+	)
+	return assignment, nil
+}
+
+func (s *service) UnassignRole(ctx context.Context, orgID int64, roleID, operatorID uint64) error {
+	if _, err := s.load(ctx, orgID, roleID); err != nil {
+		return err
+	}
+	deleted, err := s.store.DeleteAssignment(ctx, orgID, roleID, operatorID)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "delete custom role assignment")
+	}
+	if !deleted {
+		return errors.WithCode(code.ErrCustomRoleNotFound, "custom role is not assigned to operator %d", operatorID)
+	}
+	s.grants.invalidateOrg(orgID)
+	logger.L(ctx).Infow("custom role unassigned",
+		"action", "unassign_custom_role",
+		"org_id", orgID,
+		"role_id", roleID,
+		"operator_id", operatorID,
+	)
+	return nil
+}
+
+func (s *service) ListAssignments(ctx context.Context, orgID int64, roleID uint64) ([]Assignment, error) {
+	if _, err := s.load(ctx, orgID, roleID); err != nil {
+		return nil, err
+	}
+	assignments, err := s.store.ListAssignments(ctx, orgID, roleID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list custom role assignments")
+	}
+	return assignments, nil
+}
+
+// LoadGrants 每个后台请求都会调用，结果按 (机构, 用户) 短时缓存，角色或分配变更时失效。
+func (s *service) LoadGrants(ctx context.Context, orgID, userID int64) ([]authzapp.RoleGrant, error) {
+	if orgID <= 0 || userID <= 0 {
+		return nil, nil
+	}
+	cached, generation, ok := s.grants.get(orgID, userID, s.now())
+	if ok {
+		return cached, nil
+	}
+	roles, err := s.store.ListRolesForUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]authzapp.RoleGrant, 0, len(roles))
+	for _, role := range roles {
+		grants = append(grants, roleGrant(role))
+	}
+	s.grants.set(orgID, userID, generation, grants, s.now())
+	return grants, nil
+}
+
+func (s *service) Explain(ctx context.Context, query ExplainQuery) (*Explanation, error) {
+	if query.Capability == "" {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "capability is required")
+	}
+	if query.Target.Sensitivity != "" && !query.Target.Sensitivity.Valid() {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported sensitivity %q", query.Target.Sensitivity)
+	}
+	snapshot := query.Snapshot
+	if snapshot == nil {
+		loaded, err := s.loadSnapshot(ctx, query.OrgID, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = loaded
+	}
+	return &Explanation{
+		Explanation: authzapp.ExplainCapability(snapshot, query.Capability, query.Target),
+		UserID:      query.UserID,
+		IAMRoles:    snapshot.RoleNames(),
+	}, nil
+}
+
+// loadSnapshot 为其他操作者组装与其请求时相同的授权快照：IAM 快照叠加自定义角色。
+func (s *service) loadSnapshot(ctx context.Context, orgID, userID int64) (*authzapp.Snapshot, error) {
+	operator, err := s.operatorReader.FindOperatorByUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find operator")
+	}
+	if operator == nil {
+		return nil, errors.WithCode(code.ErrUserNotFound, "operator not found")
+	}
+	if s.snapshotReader == nil {
+		return nil, errors.WithCode(code.ErrUnsupportedOperation, "IAM authorization snapshot is unavailable")
+	}
+	loaded, err := s.snapshotReader.LoadAuthzSnapshot(ctx, orgID, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load authorization snapshot")
+	}
+	snapshot, ok := loaded.(*authzapp.Snapshot)
+	if !ok || snapshot == nil {
+		return nil, errors.WithCode(code.ErrUnsupportedOperation, "IAM authorization snapshot is unavailable")
+	}
+	grants, err := s.LoadGrants(ctx, orgID, userID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load custom role grants")
+	}
+	return snapshot.WithGrants(grants), nil
+}
+
+func (s *service) load(ctx context.Context, orgID int64, roleID uint64) (*Role, error) {
+	role, err := s.store.FindRole(ctx, orgID, roleID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find custom role")
+	}
+	if role == nil {
+		return nil, errors.WithCode(code.ErrCustomRoleNotFound, "custom role not found")
+	}
+	return role, nil
+}
+
+func applyRoleDTO(role *Role, dto RoleDTO) error {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "name must be 1-%d characters", maxNameRunes)
+	}
+	description := strings.TrimSpace(dto.Description)
+	if utf8.RuneCountInString(description) > maxDescriptionRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "description must be at most %d characters", maxDescriptionRunes)
+	}
+	capabilities, err := normalizeCapabilities(dto.Capabilities)
+	if err != nil {
+		return err
+	}
+	sensitivity := authzapp.Sensitivity(strings.TrimSpace(dto.Sensitivity))
+	if !sensitivity.Valid() {
+		return errors.WithCode(code.ErrInvalidArgument, "sensitivity must be aggregate, deidentified or identified")
+	}
+	scope, err := normalizeScope(dto.Scope)
+	if err != nil {
+		return err
+	}
+	role.Name = name
+	role.Description = description
+	role.Capabilities = capabilityCodes(capabilities)
+	role.Scope = domaincustomrole.Scope(scope)
+	role.Sensitivity = string(sensitivity)
+	role.UpdatedBy = dto.OperatorID
+	return nil
+}
+
+func capabilityCodes(capabilities []authzapp.Capability) []string {
+	codes := make([]string, 0, len(capabilities))
+	for _, capability := range capabilities {
+		codes = append(codes, string(capability))
+	}
+	return codes
+}
+
+func normalizeCapabilities(raw []string) ([]authzapp.Capability, error) {
+	seen := make(map[authzapp.Capability]bool, len(raw))
+	capabilities := make([]authzapp.Capability, 0, len(raw))
+	for _, item := range raw {
+		capability := authzapp.Capability(strings.TrimSpace(item))
+		if !authzapp.IsGrantableCapability(capability) {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "capability %q cannot be granted by a custom role", item)
+		}
+		if seen[capability] {
+			continue
+		}
+		seen[capability] = true
+		capabilities = append(capabilities, capability)
+	}
+	if len(capabilities) == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "at least one capability is required")
+	}
+	return capabilities, nil
+}
+
+func normalizeScope(scope authzapp.Scope) (authzapp.Scope, error) {
+	modelCodes, err := normalizeStrings(scope.ModelCodes, "model_codes", maxModelCodeLength)
+	if err != nil {
+		return authzapp.Scope{}, err
+	}
+	departments, err := normalizeStrings(scope.Departments, "departments", maxDepartmentRunes)
+	if err != nil {
+		return authzapp.Scope{}, err
+	}
+	if len(scope.PlanIDs) > maxScopeItems {
+		return authzapp.Scope{}, errors.WithCode(code.ErrInvalidArgument, "plan_ids allows at most %d items", maxScopeItems)
+	}
+	seen := make(map[uint64]bool, len(scope.PlanIDs))
+	planIDs := make([]uint64, 0, len(scope.PlanIDs))
+	for _, id := range scope.PlanIDs {
+		if id == 0 {
+			return authzapp.Scope{}, errors.WithCode(code.ErrInvalidArgument, "plan_ids must not contain 0")
+		}
+		if !seen[id] {
+			seen[id] = true
+			planIDs = append(planIDs, id)
+		}
+	}
+	sort.Slice(planIDs, func(i, j int) bool { return planIDs[i] < planIDs[j] })
+	return authzapp.Scope{ModelCodes: modelCodes, PlanIDs: planIDs, Departments: departments}, nil
+}
+
+func normalizeStrings(raw []string, field string, maxRunes int) ([]string, error) {
+	if len(raw) > maxScopeItems {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "%s allows at most %d items", field, maxScopeItems)
+	}
+	seen := make(map[string]bool, len(raw))
+	items := make([]string, 0, len(raw))
+	for _, item := range raw {
+		item = strings.TrimSpace(item)
+		if item == "" || utf8.RuneCountInString(item) > maxRunes {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "%s items must be 1-%d characters", field, maxRunes)
+		}
+		if !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	return items, nil
+}
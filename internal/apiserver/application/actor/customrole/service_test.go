@@ -0,0 +1,319 @@
+package customrole
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/iambridge"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	roles       map[uint64]*Role
+	assignments []Assignment
+}
+
+func newFakeStore() *fakeStore { return &fakeStore{roles: map[uint64]*Role{}} }
+
+func (s *fakeStore) CreateRole(_ context.Context, role *Role) error {
+	copied := *role
+	s.roles[role.ID] = &copied
+	return nil
+}
+
+func (s *fakeStore) UpdateRole(_ context.Context, role *Role) error {
+	copied := *role
+	s.roles[role.ID] = &copied
+	return nil
+}
+
+func (s *fakeStore) DeleteRole(_ context.Context, _ int64, id uint64) error {
+	delete(s.roles, id)
+	kept := s.assignments[:0]
+	for _, assignment := range s.assignments {
+		if assignment.RoleID != id {
+			kept = append(kept, assignment)
+		}
+	}
+	s.assignments = kept
+	return nil
+}
+
+func (s *fakeStore) FindRole(_ context.Context, orgID int64, id uint64) (*Role, error) {
+	role, ok := s.roles[id]
+	if !ok || role.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *role
+	return &copied, nil
+}
+
+func (s *fakeStore) FindRoleByCode(_ context.Context, orgID int64, roleCode string) (*Role, error) {
+	for _, role := range s.roles {
+		if role.OrgID == orgID && role.Code == roleCode {
+			copied := *role
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) ListRoles(context.Context, int64) ([]Role, error) { return nil, nil }
+
+func (s *fakeStore) CreateAssignment(_ context.Context, assignment *Assignment) (bool, error) {
+	for _, existing := range s.assignments {
+		if existing.RoleID == assignment.RoleID && existing.OperatorID == assignment.OperatorID {
+			return false, nil
+		}
+	}
+	s.assignments = append(s.assignments, *assignment)
+	return true, nil
+}
+
+func (s *fakeStore) DeleteAssignment(_ context.Context, _ int64, roleID, operatorID uint64) (bool, error) {
+	for i, existing := range s.assignments {
+		if existing.RoleID == roleID && existing.OperatorID == operatorID {
+			s.assignments = append(s.assignments[:i], s.assignments[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeStore) ListAssignments(_ context.Context, _ int64, roleID uint64) ([]Assignment, error) {
+	var items []Assignment
+	for _, assignment := range s.assignments {
+		if assignment.RoleID == roleID {
+			items = append(items, assignment)
+		}
+	}
+	return items, nil
+}
+
+func (s *fakeStore) ListRolesForUser(_ context.Context, orgID, userID int64) ([]Role, error) {
+	var roles []Role
+	for _, assignment := range s.assignments {
+		if assignment.OrgID == orgID && assignment.UserID == userID {
+			roles = append(roles, *s.roles[assignment.RoleID])
+		}
+	}
+	return roles, nil
+}
+
+type fakeOperators struct {
+	actorreadmodel.OperatorReader
+	rows map[uint64]*actorreadmodel.OperatorRow
+}
+
+func (f fakeOperators) GetOperator(_ context.Context, id uint64) (*actorreadmodel.OperatorRow, error) {
+	return f.rows[id], nil
+}
+
+func (f fakeOperators) FindOperatorByUser(_ context.Context, orgID, userID int64) (*actorreadmodel.OperatorRow, error) {
+	for _, row := range f.rows {
+		if row.OrgID == orgID && row.UserID == userID {
+			return row, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeSnapshots map[int64]*authzapp.Snapshot
+
+func (f fakeSnapshots) LoadAuthzSnapshot(_ context.Context, _ int64, userID int64) (iambridge.AuthzSnapshot, error) {
+	return f[userID], nil
+}
+
+func newTestService() (*service, *fakeStore) {
+	store := newFakeStore()
+	operators := fakeOperators{rows: map[uint64]*actorreadmodel.OperatorRow{
+		31: {ID: 31, OrgID: 7, UserID: 3001, Name: "研究助理", IsActive: true},
+		32: {ID: 32, OrgID: 7, UserID: 3002, Name: "已停用", IsActive: false},
+		33: {ID: 33, OrgID: 8, UserID: 3003, Name: "外机构", IsActive: true},
+	}}
+	cached := &authzapp.Snapshot{Roles: []string{"qs:evaluator"}}
+	svc := NewService(store, operators, fakeSnapshots{3001: cached}).(*service)
+	return svc, store
+}
+
+func researchAssistantDTO() RoleDTO {
+	return RoleDTO{
+		OrgID:        7,
+		OperatorID:   900,
+		Code:         "research_assistant",
+		Name:         "科研助理",
+		Capabilities: []string{"read_answersheets", "read_answersheets"},
+		Scope:        authzapp.Scope{ModelCodes: []string{" SDS ", "SDS"}},
+		Sensitivity:  "deidentified",
+	}
+}
+
+func TestCreateRoleNormalizesAndRejectsInvalidInput(t *testing.T) {
+	svc, _ := newTestService()
+	role, err := svc.CreateRole(context.Background(), researchAssistantDTO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(role.Capabilities) != 1 || len(role.Scope.ModelCodes) != 1 || role.Scope.ModelCodes[0] != "SDS" {
+		t.Fatalf("role = %#v", role)
+	}
+	if _, err := svc.CreateRole(context.Background(), researchAssistantDTO()); !errors.IsCode(err, code.ErrCustomRoleConflict) {
+		t.Fatalf("duplicate code err = %v", err)
+	}
+
+	for name, mutate := range map[string]func(*RoleDTO){
+		"bad code":          func(d *RoleDTO) { d.Code = "Research Assistant" },
+		"iam only":          func(d *RoleDTO) { d.Code = "shadow_admin"; d.Capabilities = []string{"org_admin"} },
+		"unmask":            func(d *RoleDTO) { d.Code = "unmasker"; d.Capabilities = []string{"unmask_testee_pii"} },
+		"no capability":     func(d *RoleDTO) { d.Code = "empty"; d.Capabilities = nil },
+		"no sensitivity":    func(d *RoleDTO) { d.Code = "no_sensitivity"; d.Sensitivity = "" },
+		"zero plan":         func(d *RoleDTO) { d.Code = "zero_plan"; d.Scope.PlanIDs = []uint64{0} },
+		"blank department":  func(d *RoleDTO) { d.Code = "blank_dept"; d.Scope.Departments = []string{" "} },
+		"unknown sensitive": func(d *RoleDTO) { d.Code = "secret"; d.Sensitivity = "secret" },
+	} {
+		dto := researchAssistantDTO()
+		mutate(&dto)
+		if _, err := svc.CreateRole(context.Background(), dto); !errors.IsCode(err, code.ErrInvalidArgument) {
+			t.Fatalf("%s: err = %v", name, err)
+		}
+	}
+}
+
+func TestAssignRoleFeedsGrantsAndExplainForOtherOperator(t *testing.T) {
+	svc, store := newTestService()
+	role, err := svc.CreateRole(context.Background(), researchAssistantDTO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AssignRole(context.Background(), 7, 900, role.ID, 32); !errors.IsCode(err, code.ErrCustomRoleConflict) {
+		t.Fatalf("inactive operator err = %v", err)
+	}
+	if _, err := svc.AssignRole(context.Background(), 7, 900, role.ID, 33); !errors.IsCode(err, code.ErrUserNotFound) {
+		t.Fatalf("cross-org operator err = %v", err)
+	}
+	first, err := svc.AssignRole(context.Background(), 7, 900, role.ID, 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := svc.AssignRole(context.Background(), 7, 901, role.ID, 31)
+	if err != nil || again.ID != first.ID || len(store.assignments) != 1 {
+		t.Fatalf("repeat assign = %#v, %v", again, err)
+	}
+
+	grants, err := svc.LoadGrants(context.Background(), 7, 3001)
+	if err != nil || len(grants) != 1 || grants[0].RoleCode != "research_assistant" {
+		t.Fatalf("LoadGrants() = %#v, %v", grants, err)
+	}
+
+	explanation, err := svc.Explain(context.Background(), ExplainQuery{
+		OrgID: 7, UserID: 3001, Capability: authzapp.CapabilityReadAnswersheets,
+		Target: authzapp.Target{ModelCode: "SDS"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation.Allowed || len(explanation.Evaluations) != 2 || !strings.Contains(explanation.Evaluations[1].Reason, "limited to deidentified") {
+		t.Fatalf("explanation = %#v", explanation)
+	}
+	if explanation.IAMRoles[0] != "qs:evaluator" {
+		t.Fatalf("iam roles = %v", explanation.IAMRoles)
+	}
+
+	if err := svc.DeleteRole(context.Background(), 7, 900, role.ID); err != nil {
+		t.Fatal(err)
+	}
+	if grants, _ := svc.LoadGrants(context.Background(), 7, 3001); len(grants) != 0 {
+		t.Fatalf("grants after delete = %#v", grants)
+	}
+}
+
+func TestExplainRejectsUnknownOperatorAndSensitivity(t *testing.T) {
+	svc, _ := newTestService()
+	if _, err := svc.Explain(context.Background(), ExplainQuery{OrgID: 7, UserID: 4040, Capability: authzapp.CapabilityReadAnswersheets}); !errors.IsCode(err, code.ErrUserNotFound) {
+		t.Fatalf("unknown operator err = %v", err)
+	}
+	if _, err := svc.Explain(context.Background(), ExplainQuery{
+		OrgID: 7, UserID: 3001, Capability: authzapp.CapabilityReadAnswersheets, Target: authzapp.Target{Sensitivity: "secret"},
+	}); !errors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("invalid sensitivity err = %v", err)
+	}
+}
+
+type countingStore struct {
+	*fakeStore
+	listForUser int
+}
+
+func (s *countingStore) ListRolesForUser(ctx context.Context, orgID, userID int64) ([]Role, error) {
+	s.listForUser++
+	return s.fakeStore.ListRolesForUser(ctx, orgID, userID)
+}
+
+func TestLoadGrantsCachesPerUserAndInvalidatesOnRoleOrAssignmentChange(t *testing.T) {
+	svc, base := newTestService()
+	store := &countingStore{fakeStore: base}
+	svc.store = store
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	role, err := svc.CreateRole(ctx, researchAssistantDTO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	load := func() []authzapp.RoleGrant {
+		t.Helper()
+		grants, err := svc.LoadGrants(ctx, 7, 3001)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return grants
+	}
+
+	if grants := load(); len(grants) != 0 {
+		t.Fatalf("grants before assignment = %#v", grants)
+	}
+	load()
+	if store.listForUser != 1 {
+		t.Fatalf("repeated load queried store %d times, want 1", store.listForUser)
+	}
+
+	if _, err := svc.AssignRole(ctx, 7, 900, role.ID, 31); err != nil {
+		t.Fatal(err)
+	}
+	if grants := load(); len(grants) != 1 || grants[0].Sensitivity != authzapp.Sensitivity("deidentified") {
+		t.Fatalf("grants after assignment = %#v", grants)
+	}
+
+	dto := researchAssistantDTO()
+	dto.Sensitivity = "identified"
+	if _, err := svc.UpdateRole(ctx, role.ID, dto); err != nil {
+		t.Fatal(err)
+	}
+	if grants := load(); len(grants) != 1 || grants[0].Sensitivity != authzapp.Sensitivity("identified") {
+		t.Fatalf("grants after role update = %#v", grants)
+	}
+
+	if err := svc.UnassignRole(ctx, 7, role.ID, 31); err != nil {
+		t.Fatal(err)
+	}
+	if grants := load(); len(grants) != 0 {
+		t.Fatalf("grants after unassignment = %#v", grants)
+	}
+	queried := store.listForUser
+	load()
+	if store.listForUser != queried {
+		t.Fatalf("cached empty grants should not query store again")
+	}
+
+	now = now.Add(grantCacheTTL)
+	load()
+	if store.listForUser != queried+1 {
+		t.Fatalf("expired entry should be reloaded, store queries = %d", store.listForUser)
+	}
+}
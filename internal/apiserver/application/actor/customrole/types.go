@@ -0,0 +1,79 @@
+// Package customrole 机构自定义角色：由机构管理员把 authz.Capability 组合成角色，并限定资源范围
+// （测评模型、计划、从业者科室）与数据敏感级别，再分配给后台操作者。
+//
+// 自定义角色只在 QS 内生效，不同步到 IAM：授权快照加载后由 REST 中间件叠加 authz.RoleGrant。
+// 不限范围的角色直接参与路由级能力判定；有范围的角色只在会逐个资源判定的接口上生效（目前为答卷查询），
+// 其余接口仍按 IAM 授权处理。
+package customrole
+
+import (
+	"context"
+
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	domaincustomrole "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/customrole"
+)
+
+const (
+	maxNameRunes        = 100
+	maxDescriptionRunes = 500
+	maxScopeItems       = 50
+	maxDepartmentRunes  = 50
+	maxModelCodeLength  = 100
+)
+
+type (
+	Role       = domaincustomrole.Role
+	Assignment = domaincustomrole.Assignment
+)
+
+// roleGrant 转换为授权快照中的自定义角色授权。
+func roleGrant(r Role) authzapp.RoleGrant {
+	capabilities := make([]authzapp.Capability, 0, len(r.Capabilities))
+	for _, capability := range r.Capabilities {
+		capabilities = append(capabilities, authzapp.Capability(capability))
+	}
+	return authzapp.RoleGrant{
+		RoleID:       r.ID,
+		RoleCode:     r.Code,
+		RoleName:     r.Name,
+		Capabilities: capabilities,
+		Scope:        authzapp.Scope(r.Scope),
+		Sensitivity:  authzapp.Sensitivity(r.Sensitivity),
+	}
+}
+
+// RoleDTO 创建或更新角色；更新时 Code 不可修改，忽略该字段。
+type RoleDTO struct {
+	OrgID        int64
+	OperatorID   int64
+	Code         string
+	Name         string
+	Description  string
+	Capabilities []string
+	Scope        authzapp.Scope
+	Sensitivity  string
+}
+
+// ExplainQuery 能力判定解释；Snapshot 非空时直接使用（当前请求自身的快照），否则按 UserID 加载。
+type ExplainQuery struct {
+	OrgID      int64
+	UserID     int64
+	Snapshot   *authzapp.Snapshot
+	Capability authzapp.Capability
+	Target     authzapp.Target
+}
+
+// Explanation 解释结果。
+type Explanation struct {
+	authzapp.Explanation
+	UserID   int64
+	IAMRoles []string
+}
+
+// GrantLoader 供授权快照中间件加载当前操作者的自定义角色授权。
+type GrantLoader interface {
+	LoadGrants(ctx context.Context, orgID, userID int64) ([]authzapp.RoleGrant, error)
+}
+
+// Store 自定义角色持久化端口。
+type Store = domaincustomrole.Repository
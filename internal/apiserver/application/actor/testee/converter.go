@@ -19,6 +19,8 @@ func toTesteeResult(testee *domain.Testee) *TesteeResult {
 		Age:        testee.GetAge(),
 		Source:     testee.Source(),
 		IsKeyFocus: testee.IsKeyFocus(),
+
+		PreferredLocale: testee.PreferredLocale(),
 	}
 
 	// 可选字段
@@ -50,6 +52,7 @@ func toTesteeResultFromRow(row *actorreadmodel.TesteeRow) *TesteeResult {
 		Age:              ageFromBirthday(row.Birthday),
 		Source:           row.Source,
 		IsKeyFocus:       row.IsKeyFocus,
+		PreferredLocale:  row.PreferredLocale,
 		LastAssessmentAt: row.LastAssessmentAt,
 		TotalAssessments: row.TotalAssessments,
 		LastRiskLevel:    row.LastRiskLevel,
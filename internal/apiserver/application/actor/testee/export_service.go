@@ -0,0 +1,118 @@
+package testee
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/csvexport"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+)
+
+const (
+	exportBatchSize = 100
+	// exportMaxRows 单次导出的最大受试者数。
+	exportMaxRows = 20000
+	// exportPseudonymKind 导出文件中受试者假名的命名空间。
+	exportPseudonymKind = "testee"
+)
+
+var exportHeader = []string{
+	"testee_ref", "name", "gender", "age_band", "source", "is_key_focus",
+	"total_assessments", "last_risk_level", "last_assessment_date", "created_date",
+}
+
+type exportService struct {
+	query      TesteeQueryService
+	policy     redaction.Policy
+	pseudonyms *redaction.Pseudonymizer
+	now        func() time.Time
+}
+
+// NewExportService 创建受试者导出服务；policy 决定导出字段的脱敏方式，pseudonyms 生成稳定假名。
+func NewExportService(query TesteeQueryService, policy redaction.Policy, pseudonyms *redaction.Pseudonymizer) TesteeExportService {
+	return &exportService{query: query, policy: policy, pseudonyms: pseudonyms, now: time.Now}
+}
+
+func (s *exportService) ExportDeidentified(ctx context.Context, dto ExportTesteeDTO) (*TesteeExportCSV, error) {
+	if dto.OrgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	if s.query == nil || s.pseudonyms == nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "testee export is not configured")
+	}
+	if dto.CreatedAtStart != nil && dto.CreatedAtEnd != nil && !dto.CreatedAtStart.Before(*dto.CreatedAtEnd) {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "created_start_date 不能晚于 created_end_date")
+	}
+
+	now := s.now()
+	rows := [][]string{exportHeader}
+	for offset := 0; ; offset += exportBatchSize {
+		page, err := s.query.ListTestees(ctx, ListTesteeDTO{
+			OrgID:          dto.OrgID,
+			KeyFocus:       dto.KeyFocus,
+			CreatedAtStart: dto.CreatedAtStart,
+			CreatedAtEnd:   dto.CreatedAtEnd,
+			Offset:         offset,
+			Limit:          exportBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if page.TotalCount > exportMaxRows {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "export matches %d testees, exceeds %d; narrow the created date range", page.TotalCount, exportMaxRows)
+		}
+		for _, item := range page.Items {
+			rows = append(rows, s.exportValues(item, now))
+		}
+		if len(page.Items) < exportBatchSize || int64(offset+len(page.Items)) >= page.TotalCount {
+			break
+		}
+	}
+
+	content, err := csvexport.Encode(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &TesteeExportCSV{
+		FileName: fmt.Sprintf("testees_org_%d_%s.csv", dto.OrgID, now.Format("20060102")),
+		Content:  content,
+	}, nil
+}
+
+func (s *exportService) exportValues(item *TesteeResult, now time.Time) []string {
+	ageBand := s.policy.AgeBandOf(item.Birthday, now)
+	if birthday := s.policy.RedactBirthday(item.Birthday); birthday != nil {
+		ageBand = birthday.Format("2006-01-02")
+	}
+	lastAssessment := ""
+	if item.LastAssessmentAt != nil {
+		lastAssessment = item.LastAssessmentAt.Format("2006-01-02")
+	}
+	return []string{
+		s.policy.RedactIdentifier(s.pseudonyms, exportPseudonymKind, item.ID),
+		s.policy.RedactName(item.Name),
+		exportGender(item.Gender),
+		ageBand,
+		item.Source,
+		strconv.FormatBool(item.IsKeyFocus),
+		strconv.Itoa(item.TotalAssessments),
+		item.LastRiskLevel,
+		lastAssessment,
+		item.CreatedAt.Format("2006-01-02"),
+	}
+}
+
+func exportGender(gender int8) string {
+	switch gender {
+	case 1:
+		return "male"
+	case 2:
+		return "female"
+	default:
+		return "unknown"
+	}
+}
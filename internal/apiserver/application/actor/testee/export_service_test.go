@@ -0,0 +1,59 @@
+package testee
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+)
+
+type exportQueryStub struct {
+	TesteeQueryService
+	items   []*TesteeResult
+	offsets []int
+}
+
+func (s *exportQueryStub) ListTestees(_ context.Context, dto ListTesteeDTO) (*TesteeListResult, error) {
+	s.offsets = append(s.offsets, dto.Offset)
+	end := dto.Offset + dto.Limit
+	if end > len(s.items) {
+		end = len(s.items)
+	}
+	return &TesteeListResult{Items: s.items[dto.Offset:end], TotalCount: int64(len(s.items)), Offset: dto.Offset, Limit: dto.Limit}, nil
+}
+
+func TestExportDeidentifiedPseudonymizesAndPages(t *testing.T) {
+	birthday := time.Date(2016, 5, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]*TesteeResult, 0, exportBatchSize+1)
+	for i := 0; i <= exportBatchSize; i++ {
+		items = append(items, &TesteeResult{ID: uint64(9000 + i), Name: "王小明", Gender: 1, Birthday: &birthday, CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)})
+	}
+	query := &exportQueryStub{items: items}
+	svc := NewExportService(query, redaction.Deidentified, redaction.NewPseudonymizer("secret")).(*exportService)
+	svc.now = func() time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	result, err := svc.ExportDeidentified(context.Background(), ExportTesteeDTO{OrgID: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(query.offsets) != 2 || query.offsets[1] != exportBatchSize {
+		t.Fatalf("offsets = %v", query.offsets)
+	}
+	content := string(result.Content)
+	if strings.Contains(content, "王") || strings.Contains(content, "9000") || strings.Contains(content, "2016-05-01") {
+		t.Fatalf("export leaked PII: %s", content)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(items)+1 || !strings.HasPrefix(records[1][0], "testee_") || records[1][3] != "6-11" || records[1][2] != "male" {
+		t.Fatalf("records[1] = %v (rows %d)", records[1], len(records))
+	}
+	if result.FileName != "testees_org_3_20261001.csv" {
+		t.Fatalf("file name = %s", result.FileName)
+	}
+}
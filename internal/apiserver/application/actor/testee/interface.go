@@ -52,6 +52,10 @@ type TesteeManagementService interface {
 
 	// UnmarkKeyFocus 取消重点关注
 	UnmarkKeyFocus(ctx context.Context, testeeID uint64) error
+
+	// ChangePreferredLocale 修改报告内容偏好语言
+	// 场景：受试者需要英文等非默认语言的报告；空值恢复默认语言
+	ChangePreferredLocale(ctx context.Context, testeeID uint64, tag string) error
 }
 
 // TesteeAssessmentAttentionService 测评后置关注同步服务
@@ -110,6 +114,14 @@ type TesteeBackendQueryService interface {
 	ListTesteesWithGuardians(ctx context.Context, dto ListTesteeDTO) (*TesteeBackendListResult, error)
 }
 
+// TesteeExportService 受试者导出服务
+// 行为者：机构管理员、统计人员
+// 职责：按脱敏策略导出受试者 CSV，导出文件中的受试者ID一律假名化
+type TesteeExportService interface {
+	// ExportDeidentified 导出机构受试者（最多 exportMaxRows 行），超出时要求缩小创建时间范围
+	ExportDeidentified(ctx context.Context, dto ExportTesteeDTO) (*TesteeExportCSV, error)
+}
+
 // === DTO ===
 
 // RegisterTesteeDTO 注册受试者 DTO
@@ -152,6 +164,20 @@ type ListTesteeDTO struct {
 	Limit                 int      // 限制数量
 }
 
+// ExportTesteeDTO 受试者导出条件
+type ExportTesteeDTO struct {
+	OrgID          int64
+	KeyFocus       *bool
+	CreatedAtStart *time.Time
+	CreatedAtEnd   *time.Time
+}
+
+// TesteeExportCSV 受试者导出结果
+type TesteeExportCSV struct {
+	FileName string
+	Content  []byte
+}
+
 // TesteeResult 受试者结果 DTO
 type TesteeResult struct {
 	ID         uint64     // 受试者ID
@@ -166,6 +192,8 @@ type TesteeResult struct {
 	Source     string     // 数据来源
 	IsKeyFocus bool       // 是否重点关注
 
+	PreferredLocale string // 报告内容偏好语言，空值表示默认语言
+
 	// 统计信息（仅后台管理需要）
 	LastAssessmentAt *time.Time // 最近测评时间
 	TotalAssessments int        // 总测评次数
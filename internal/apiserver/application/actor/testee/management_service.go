@@ -134,3 +134,28 @@ func (s *managementService) UnmarkKeyFocus(ctx context.Context, testeeID uint64)
 		return nil
 	})
 }
+
+// ChangePreferredLocale 修改报告内容偏好语言
+func (s *managementService) ChangePreferredLocale(ctx context.Context, testeeID uint64, tag string) error {
+	targetTesteeID, err := testeeIDFromUint64("testee_id", testeeID)
+	if err != nil {
+		return err
+	}
+	return s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		// 1. 查找受试者
+		testee, err := s.repo.FindByID(txCtx, targetTesteeID)
+		if err != nil {
+			return errors.Wrap(err, "failed to find testee")
+		}
+
+		// 2. 使用领域服务修改偏好语言
+		if err := s.editor.ChangePreferredLocale(txCtx, testee, tag); err != nil {
+			return err
+		} // 3. 持久化
+		if err := s.repo.Update(txCtx, testee); err != nil {
+			return errors.Wrap(err, "failed to update testee")
+		}
+
+		return nil
+	})
+}
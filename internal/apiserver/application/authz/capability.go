@@ -24,6 +24,10 @@ const (
 	CapabilityManageEvaluationPlans            Capability = "manage_evaluation_plans"
 	CapabilityEvaluateAssessments              Capability = "evaluate_assessments"
 	CapabilityAuditInterpretation              Capability = "audit_interpretation"
+	// CapabilityReadStatistics 统计人员：只读去标识化的受试者数据（年龄段、假名ID）。
+	CapabilityReadStatistics Capability = "read_statistics"
+	// CapabilityUnmaskTesteePII 经审计的受试者个人信息显式解除脱敏。
+	CapabilityUnmaskTesteePII Capability = "unmask_testee_pii"
 )
 
 func hasAnyResourceAction(s *Snapshot, resource string, actions []string) bool {
@@ -36,6 +40,7 @@ func hasAnyResourceAction(s *Snapshot, resource string, actions []string) bool {
 }
 
 // DecideCapability explains 是否 IAM 快照 satisfies 一个能力。
+// 自定义角色只有在不限范围且敏感级别满足时才参与路由级判定；有范围限制的角色见 DecideScopedCapability。
 func DecideCapability(s *Snapshot, c Capability) securityplane.CapabilityDecision {
 	if s == nil {
 		return securityplane.CapabilityDecision{
@@ -61,6 +66,14 @@ func DecideCapability(s *Snapshot, c Capability) securityplane.CapabilityDecisio
 			Reason:     fmt.Sprintf("capability %s allowed by IAM authorization", c),
 		}
 	}
+	if grant, ok := s.unrestrictedGrant(c); ok {
+		return securityplane.CapabilityDecision{
+			Capability: string(c),
+			Allowed:    true,
+			Outcome:    securityplane.CapabilityOutcomeAllowed,
+			Reason:     fmt.Sprintf("capability %s granted by custom role %s", c, grant.RoleCode),
+		}
+	}
 	return securityplane.CapabilityDecision{
 		Capability: string(c),
 		Allowed:    false,
@@ -121,6 +134,8 @@ func isKnownCapability(c Capability) bool {
 	case CapabilityManageEvaluationPlans:
 	case CapabilityEvaluateAssessments:
 	case CapabilityAuditInterpretation:
+	case CapabilityReadStatistics:
+	case CapabilityUnmaskTesteePII:
 	default:
 		return false
 	}
@@ -133,6 +148,10 @@ func capabilityAllowed(s *Snapshot, c Capability) bool {
 		return s.IsQSAdmin()
 	case CapabilityAuditInterpretation:
 		return s.IsQSAdmin() || hasAnyResourceAction(s, "qs:interpretation_reports", []string{"audit"})
+	case CapabilityReadStatistics:
+		return s.IsQSAdmin() || hasAnyResourceAction(s, "qs:statistics", []string{"read", "list"})
+	case CapabilityUnmaskTesteePII:
+		return s.IsQSAdmin() || s.HasResourceAction("qs:testees", "unmask")
 	case CapabilityReadQuestionnaires:
 		if s.IsQSAdmin() {
 			return true
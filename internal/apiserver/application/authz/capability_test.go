@@ -87,3 +87,18 @@ func TestNormTableCapabilities(t *testing.T) {
 		t.Fatal("manage_norm_tables should be allowed")
 	}
 }
+
+func TestPIICapabilities(t *testing.T) {
+	t.Parallel()
+	statistician := &Snapshot{Permissions: []Permission{{Resource: "qs:statistics", Action: "read"}}}
+	if !DecideCapability(statistician, CapabilityReadStatistics).Allowed {
+		t.Fatal("read_statistics should be allowed")
+	}
+	if DecideCapability(statistician, CapabilityUnmaskTesteePII).Allowed {
+		t.Fatal("unmask_testee_pii must require qs:testees unmask")
+	}
+	privacyOfficer := &Snapshot{Permissions: []Permission{{Resource: "qs:testees", Action: "read|unmask"}}}
+	if !DecideCapability(privacyOfficer, CapabilityUnmaskTesteePII).Allowed {
+		t.Fatal("unmask_testee_pii should be allowed")
+	}
+}
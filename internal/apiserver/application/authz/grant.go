@@ -0,0 +1,334 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/securityplane"
+)
+
+// Sensitivity 数据敏感级别，由低到高：聚合统计 < 去标识 < 可识别个人数据。
+type Sensitivity string
+
+const (
+	SensitivityAggregate    Sensitivity = "aggregate"
+	SensitivityDeidentified Sensitivity = "deidentified"
+	SensitivityIdentified   Sensitivity = "identified"
+)
+
+func (s Sensitivity) rank() int {
+	switch s {
+	case SensitivityAggregate:
+		return 1
+	case SensitivityDeidentified:
+		return 2
+	case SensitivityIdentified:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Valid 判断是否为已知敏感级别。
+func (s Sensitivity) Valid() bool {
+	return s.rank() > 0
+}
+
+// Covers 判断允许 s 级别的授权是否覆盖 want 级别的数据。
+func (s Sensitivity) Covers(want Sensitivity) bool {
+	return s.Valid() && want.Valid() && s.rank() >= want.rank()
+}
+
+// Scope 自定义角色的资源范围；每个维度为空表示不限，非空时目标资源必须落在其中。
+type Scope struct {
+	ModelCodes  []string
+	PlanIDs     []uint64
+	Departments []string
+}
+
+// IsEmpty 判断是否不限范围。
+func (s Scope) IsEmpty() bool {
+	return len(s.ModelCodes) == 0 && len(s.PlanIDs) == 0 && len(s.Departments) == 0
+}
+
+// RoleGrant 机构自定义角色在授权快照中的投影，由 QS 本地维护，与 IAM 角色并列生效。
+type RoleGrant struct {
+	RoleID       uint64
+	RoleCode     string
+	RoleName     string
+	Capabilities []Capability
+	Scope        Scope
+	// Sensitivity 该角色可访问的最高敏感级别。
+	Sensitivity Sensitivity
+}
+
+func (g RoleGrant) includes(c Capability) bool {
+	for _, item := range g.Capabilities {
+		if item == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Target 一次能力判定针对的资源；空字段表示资源不具备该维度（例如答卷没有计划）。
+type Target struct {
+	ModelCode  string
+	PlanID     uint64
+	Department string
+	// Sensitivity 为空时按 identified 判定。
+	Sensitivity Sensitivity
+}
+
+func (t Target) sensitivity() Sensitivity {
+	if t.Sensitivity == "" {
+		return SensitivityIdentified
+	}
+	return t.Sensitivity
+}
+
+// GrantSource 授权来源。
+type GrantSource string
+
+const (
+	GrantSourceIAM        GrantSource = "iam"
+	GrantSourceCustomRole GrantSource = "custom_role"
+)
+
+// GrantEvaluation 单个授权来源对本次判定的结论。
+type GrantEvaluation struct {
+	Source   GrantSource
+	RoleCode string
+	RoleName string
+	Matched  bool
+	Reason   string
+}
+
+// Explanation 一次资源级能力判定及其逐条依据，供 /authz/explain 展示。
+type Explanation struct {
+	securityplane.CapabilityDecision
+	Target      Target
+	Evaluations []GrantEvaluation
+}
+
+// grantableCapabilities 可放入自定义角色的能力。机构管理与解除脱敏只能由 IAM 授予，避免自定义角色成为提权入口。
+var grantableCapabilities = []Capability{
+	CapabilityReadQuestionnaires,
+	CapabilityManageQuestionnaires,
+	CapabilityReadAssessmentModels,
+	CapabilityManageAssessmentModels,
+	CapabilityEditAssessmentModelDefinitions,
+	CapabilityPublishAssessmentModels,
+	CapabilityResolvePublishedAssessmentModels,
+	CapabilityReadNormTables,
+	CapabilityManageNormTables,
+	CapabilityReadAnswersheets,
+	CapabilityManageEvaluationPlans,
+	CapabilityEvaluateAssessments,
+	CapabilityAuditInterpretation,
+	CapabilityReadStatistics,
+}
+
+// GrantableCapabilities 返回可放入自定义角色的能力列表副本。
+func GrantableCapabilities() []Capability {
+	return append([]Capability(nil), grantableCapabilities...)
+}
+
+// IsGrantableCapability 判断能力能否放入自定义角色。
+func IsGrantableCapability(c Capability) bool {
+	for _, item := range grantableCapabilities {
+		if item == c {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilitySensitivity 路由级判定时能力本身需要的敏感级别；统计接口只输出去标识数据。
+func capabilitySensitivity(c Capability) Sensitivity {
+	if c == CapabilityReadStatistics {
+		return SensitivityDeidentified
+	}
+	return SensitivityIdentified
+}
+
+// WithGrants 返回附带自定义角色授权的快照副本；IAM 快照会被缓存复用，不能原地修改。
+func (s *Snapshot) WithGrants(grants []RoleGrant) *Snapshot {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	clone.Grants = append([]RoleGrant(nil), grants...)
+	return &clone
+}
+
+// unrestrictedGrant 返回不限范围、敏感级别满足能力要求的自定义角色授权，可直接通过路由级判定。
+func (s *Snapshot) unrestrictedGrant(c Capability) (RoleGrant, bool) {
+	if !IsGrantableCapability(c) {
+		return RoleGrant{}, false
+	}
+	for _, grant := range s.Grants {
+		if grant.includes(c) && grant.Scope.IsEmpty() && grant.Sensitivity.Covers(capabilitySensitivity(c)) {
+			return grant, true
+		}
+	}
+	return RoleGrant{}, false
+}
+
+// DecideScopedCapability 供会自行收窄资源范围的路由使用：IAM 允许，或任一自定义角色包含该能力（不论范围）即放行，
+// 调用方必须随后按 DecideCapabilityFor 逐个资源判定。
+func DecideScopedCapability(s *Snapshot, c Capability) securityplane.CapabilityDecision {
+	decision := DecideCapability(s, c)
+	if decision.Allowed || decision.Outcome != securityplane.CapabilityOutcomeDenied || !IsGrantableCapability(c) {
+		return decision
+	}
+	for _, grant := range s.Grants {
+		if grant.includes(c) {
+			return securityplane.CapabilityDecision{
+				Capability: string(c),
+				Allowed:    true,
+				Outcome:    securityplane.CapabilityOutcomeAllowed,
+				Reason:     fmt.Sprintf("capability %s granted by custom role %s within its scope", c, grant.RoleCode),
+			}
+		}
+	}
+	return decision
+}
+
+// DecideCapabilityFor 对具体资源判定能力：IAM 授权不受范围限制；自定义角色需同时满足范围与敏感级别。
+func DecideCapabilityFor(s *Snapshot, c Capability, target Target) securityplane.CapabilityDecision {
+	return ExplainCapability(s, c, target).CapabilityDecision
+}
+
+// AllowedSensitivity 返回对目标资源可访问的最高敏感级别（忽略 target.Sensitivity）；不可访问时 ok 为 false。
+func AllowedSensitivity(s *Snapshot, c Capability, target Target) (Sensitivity, bool) {
+	for _, level := range []Sensitivity{SensitivityIdentified, SensitivityDeidentified, SensitivityAggregate} {
+		target.Sensitivity = level
+		if DecideCapabilityFor(s, c, target).Allowed {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// ExplainCapability 逐条列出 IAM 与各自定义角色对资源级能力判定的结论。
+func ExplainCapability(s *Snapshot, c Capability, target Target) Explanation {
+	explanation := Explanation{Target: target}
+	switch {
+	case s == nil:
+		explanation.CapabilityDecision = DecideCapability(s, c)
+		return explanation
+	case !isKnownCapability(c):
+		explanation.CapabilityDecision = DecideCapability(s, c)
+		return explanation
+	case !target.sensitivity().Valid():
+		explanation.CapabilityDecision = securityplane.CapabilityDecision{
+			Capability: string(c),
+			Allowed:    false,
+			Outcome:    securityplane.CapabilityOutcomeInvalidScope,
+			Reason:     fmt.Sprintf("sensitivity %s is not registered", target.Sensitivity),
+		}
+		return explanation
+	}
+
+	var matched *GrantEvaluation
+	iam := GrantEvaluation{Source: GrantSourceIAM}
+	if capabilityAllowed(s, c) {
+		iam.Matched = true
+		iam.Reason = fmt.Sprintf("capability %s allowed by IAM authorization", c)
+	} else {
+		iam.Reason = fmt.Sprintf("IAM roles %v do not grant capability %s", s.RoleNames(), c)
+	}
+	explanation.Evaluations = append(explanation.Evaluations, iam)
+	if iam.Matched {
+		matched = &explanation.Evaluations[0]
+	}
+	for _, grant := range s.Grants {
+		evaluation := evaluateGrant(grant, c, target)
+		explanation.Evaluations = append(explanation.Evaluations, evaluation)
+		if matched == nil && evaluation.Matched {
+			matched = &explanation.Evaluations[len(explanation.Evaluations)-1]
+		}
+	}
+
+	if matched != nil {
+		explanation.CapabilityDecision = securityplane.CapabilityDecision{
+			Capability: string(c),
+			Allowed:    true,
+			Outcome:    securityplane.CapabilityOutcomeAllowed,
+			Reason:     matched.Reason,
+		}
+		return explanation
+	}
+	explanation.CapabilityDecision = securityplane.CapabilityDecision{
+		Capability: string(c),
+		Allowed:    false,
+		Outcome:    securityplane.CapabilityOutcomeDenied,
+		Reason:     fmt.Sprintf("no IAM role or custom role grants capability %s for the target", c),
+	}
+	return explanation
+}
+
+func evaluateGrant(grant RoleGrant, c Capability, target Target) GrantEvaluation {
+	evaluation := GrantEvaluation{Source: GrantSourceCustomRole, RoleCode: grant.RoleCode, RoleName: grant.RoleName}
+	switch {
+	case !grant.includes(c):
+		evaluation.Reason = fmt.Sprintf("custom role %s does not include capability %s", grant.RoleCode, c)
+	case !IsGrantableCapability(c):
+		evaluation.Reason = fmt.Sprintf("capability %s can only be granted by IAM", c)
+	case !grant.Sensitivity.Covers(target.sensitivity()):
+		evaluation.Reason = fmt.Sprintf("custom role %s is limited to %s data, target requires %s", grant.RoleCode, grant.Sensitivity, target.sensitivity())
+	default:
+		if reason := scopeMismatch(grant.Scope, target); reason != "" {
+			evaluation.Reason = fmt.Sprintf("custom role %s: %s", grant.RoleCode, reason)
+			break
+		}
+		evaluation.Matched = true
+		evaluation.Reason = fmt.Sprintf("capability %s granted by custom role %s", c, grant.RoleCode)
+	}
+	return evaluation
+}
+
+func scopeMismatch(scope Scope, target Target) string {
+	if len(scope.ModelCodes) > 0 {
+		if target.ModelCode == "" {
+			return "role is scoped to models " + strings.Join(scope.ModelCodes, ",") + " but the target has no model"
+		}
+		if !containsString(scope.ModelCodes, target.ModelCode) {
+			return fmt.Sprintf("model %s is outside role scope", target.ModelCode)
+		}
+	}
+	if len(scope.PlanIDs) > 0 {
+		if target.PlanID == 0 {
+			return "role is scoped to plans but the target has no plan"
+		}
+		found := false
+		for _, id := range scope.PlanIDs {
+			if id == target.PlanID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("plan %d is outside role scope", target.PlanID)
+		}
+	}
+	if len(scope.Departments) > 0 {
+		if target.Department == "" {
+			return "role is scoped to departments but the target has no department"
+		}
+		if !containsString(scope.Departments, target.Department) {
+			return fmt.Sprintf("department %s is outside role scope", target.Department)
+		}
+	}
+	return ""
+}
+
+func containsString(items []string, want string) bool {
+	for _, item := range items {
+		if item == want {
+			return true
+		}
+	}
+	return false
+}
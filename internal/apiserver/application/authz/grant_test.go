@@ -0,0 +1,98 @@
+package authz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/securityplane"
+)
+
+func researchAssistant() RoleGrant {
+	return RoleGrant{
+		RoleID:       1,
+		RoleCode:     "research_assistant",
+		RoleName:     "科研助理",
+		Capabilities: []Capability{CapabilityReadAnswersheets},
+		Scope:        Scope{ModelCodes: []string{"SDS"}},
+		Sensitivity:  SensitivityDeidentified,
+	}
+}
+
+func TestScopedGrantDoesNotPassRouteLevelCapability(t *testing.T) {
+	snap := (&Snapshot{}).WithGrants([]RoleGrant{researchAssistant()})
+
+	if decision := DecideCapability(snap, CapabilityReadAnswersheets); decision.Allowed {
+		t.Fatalf("scoped grant passed route gate: %#v", decision)
+	}
+	decision := DecideScopedCapability(snap, CapabilityReadAnswersheets)
+	if !decision.Allowed || !strings.Contains(decision.Reason, "research_assistant") {
+		t.Fatalf("DecideScopedCapability() = %#v", decision)
+	}
+	if decision := DecideScopedCapability(snap, CapabilityManageEvaluationPlans); decision.Allowed {
+		t.Fatalf("capability outside role passed: %#v", decision)
+	}
+}
+
+func TestUnrestrictedGrantPassesRouteLevelCapability(t *testing.T) {
+	snap := (&Snapshot{}).WithGrants([]RoleGrant{
+		{RoleCode: "analyst", Capabilities: []Capability{CapabilityReadStatistics}, Sensitivity: SensitivityDeidentified},
+		{RoleCode: "admin_like", Capabilities: []Capability{CapabilityOrgAdmin}, Sensitivity: SensitivityIdentified},
+	})
+
+	if decision := DecideCapability(snap, CapabilityReadStatistics); !decision.Allowed || !strings.Contains(decision.Reason, "analyst") {
+		t.Fatalf("read_statistics decision = %#v", decision)
+	}
+	// 机构管理只能由 IAM 授予，即便自定义角色数据里混入了该能力。
+	if decision := DecideCapability(snap, CapabilityOrgAdmin); decision.Allowed {
+		t.Fatalf("org_admin granted by custom role: %#v", decision)
+	}
+}
+
+func TestExplainCapabilityScopeAndSensitivity(t *testing.T) {
+	snap := (&Snapshot{Roles: []string{"qs:evaluator"}}).WithGrants([]RoleGrant{researchAssistant()})
+
+	tests := []struct {
+		name    string
+		target  Target
+		allowed bool
+		reason  string
+	}{
+		{"deidentified in scope", Target{ModelCode: "SDS", Sensitivity: SensitivityDeidentified}, true, "granted by custom role research_assistant"},
+		{"identified in scope", Target{ModelCode: "SDS"}, false, "limited to deidentified data"},
+		{"other model", Target{ModelCode: "SAS", Sensitivity: SensitivityDeidentified}, false, "model SAS is outside role scope"},
+		{"no model", Target{Sensitivity: SensitivityAggregate}, false, "target has no model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation := ExplainCapability(snap, CapabilityReadAnswersheets, tt.target)
+			if explanation.Allowed != tt.allowed {
+				t.Fatalf("allowed = %v: %#v", explanation.Allowed, explanation)
+			}
+			if len(explanation.Evaluations) != 2 || explanation.Evaluations[0].Source != GrantSourceIAM || explanation.Evaluations[0].Matched {
+				t.Fatalf("evaluations = %#v", explanation.Evaluations)
+			}
+			if !strings.Contains(explanation.Evaluations[1].Reason, tt.reason) {
+				t.Fatalf("role reason = %q, want %q", explanation.Evaluations[1].Reason, tt.reason)
+			}
+		})
+	}
+
+	if level, ok := AllowedSensitivity(snap, CapabilityReadAnswersheets, Target{ModelCode: "SDS"}); !ok || level != SensitivityDeidentified {
+		t.Fatalf("AllowedSensitivity() = %q, %v", level, ok)
+	}
+	admin := (&Snapshot{Roles: []string{"qs:admin"}}).WithGrants([]RoleGrant{researchAssistant()})
+	if level, ok := AllowedSensitivity(admin, CapabilityReadAnswersheets, Target{ModelCode: "SAS"}); !ok || level != SensitivityIdentified {
+		t.Fatalf("admin AllowedSensitivity() = %q, %v", level, ok)
+	}
+	if explanation := ExplainCapability(snap, CapabilityReadAnswersheets, Target{Sensitivity: "secret"}); explanation.Outcome != securityplane.CapabilityOutcomeInvalidScope {
+		t.Fatalf("invalid sensitivity outcome = %q", explanation.Outcome)
+	}
+}
+
+func TestWithGrantsDoesNotMutateCachedSnapshot(t *testing.T) {
+	cached := &Snapshot{Roles: []string{"qs:evaluator"}}
+	withGrants := cached.WithGrants([]RoleGrant{researchAssistant()})
+	if len(cached.Grants) != 0 || len(withGrants.Grants) != 1 || withGrants.Roles[0] != "qs:evaluator" {
+		t.Fatalf("cached=%#v withGrants=%#v", cached, withGrants)
+	}
+}
@@ -21,6 +21,8 @@ type Snapshot struct {
 	AuthzVersion int64
 	CasbinDomain string
 	IAMAppName   string
+	// Grants 机构自定义角色授权，由 QS 在 IAM 快照之上叠加，见 RoleGrant。
+	Grants []RoleGrant
 }
 
 // WithSnapshot 将快照写入 context（供 application 层使用）。
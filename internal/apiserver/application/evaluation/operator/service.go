@@ -165,7 +165,8 @@ func (s *queryService) ListAssessmentsOutcome(ctx context.Context, actor Actor,
 }
 
 func (s *queryService) GetScores(ctx context.Context, actor Actor, id uint64) (*Score, error) {
-	if _, err := s.loadAccessible(ctx, actor, id); err != nil {
+	assessment, err := s.loadAccessible(ctx, actor, id)
+	if err != nil {
 		return nil, err
 	}
 	if s.scores == nil {
@@ -175,7 +176,9 @@ func (s *queryService) GetScores(ctx context.Context, actor Actor, id uint64) (*
 	if err != nil {
 		return nil, evalerrors.AssessmentScoreNotFound(err, "得分不存在")
 	}
-	return scoreFromFact(fact), nil
+	score := scoreFromFact(fact)
+	score.TesteeID = assessment.TesteeID().Uint64()
+	return score, nil
 }
 
 func (s *queryService) GetHighRiskFactors(ctx context.Context, actor Actor, id uint64) (*HighRiskFactors, error) {
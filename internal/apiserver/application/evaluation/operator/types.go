@@ -91,6 +91,7 @@ type FactorScore struct {
 }
 type Score struct {
 	AssessmentID uint64
+	TesteeID     uint64
 	TotalScore   float64
 	RiskLevel    string
 	FactorScores []FactorScore
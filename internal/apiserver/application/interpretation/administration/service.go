@@ -14,7 +14,15 @@ import (
 )
 
 type Actor struct{ OrgID, OperatorUserID int64 }
-type GetQuery struct{ AssessmentID uint64 }
+
+// GetQuery 查询单份报告。Audience 为空时按访问决策的默认受众版本返回；
+// 仅机构管理员可显式指定其他受众版本（如 school），受限访问者只能读取默认版本。
+type GetQuery struct {
+	AssessmentID uint64
+	Audience     policy.ReportAudience
+	// Locale 为空时返回报告生成时的内容语言。
+	Locale string
+}
 type ListQuery struct {
 	TesteeID       uint64
 	Page, PageSize int
@@ -23,6 +31,7 @@ type ListQuery struct {
 // ReportAccessDecision is the actor-derived visibility decision for Administration
 // report queries. Audience must come from this decision, never from the package name.
 type ReportAccessDecision struct {
+	TesteeID       uint64 // 被授权测评所属的受试者
 	Audience       policy.Audience
 	IsAdmin        bool
 	Restricted     bool
@@ -47,6 +56,7 @@ type ResultLevel = reportprojection.ResultLevel
 type ModelExtra = reportprojection.ModelExtra
 type Dimension = reportprojection.Dimension
 type Suggestion = reportprojection.Suggestion
+type ClinicianAddendum = reportprojection.ClinicianAddendum
 
 type Access interface {
 	AuthorizeAssessment(ctx context.Context, actor Actor, assessmentID uint64) (ReportAccessDecision, error)
@@ -86,11 +96,20 @@ func (s *service) GetReport(ctx context.Context, actor Actor, query GetQuery) (*
 	if err := validateDecision(decision); err != nil {
 		return nil, err
 	}
+	audience, err := requestedAudience(decision, query.Audience)
+	if err != nil {
+		return nil, err
+	}
 	row, err := s.reader.GetReportByAssessmentID(ctx, query.AssessmentID)
 	if err != nil {
 		return nil, queryerror.MapReadError(err)
 	}
-	return s.projection.FromRow(ctx, *row, decision.Audience)
+	report, err := s.projection.FromRowIn(ctx, *row, decision.Audience, audience, query.Locale)
+	if err != nil {
+		return nil, err
+	}
+	report.TesteeID = decision.TesteeID
+	return report, nil
 }
 
 func (s *service) ListReports(ctx context.Context, actor Actor, query ListQuery) (*ListResult, error) {
@@ -132,18 +151,28 @@ func (s *service) ListReports(ctx context.Context, actor Actor, query ListQuery)
 	if err != nil {
 		return nil, queryerror.MapReadError(err)
 	}
-	items := make([]*Report, 0, len(rows))
-	for _, row := range rows {
-		item, mapErr := s.projection.FromRow(ctx, row, scope.Audience)
-		if mapErr != nil {
-			return nil, mapErr
-		}
-		items = append(items, item)
+	items, err := s.projection.FromRows(ctx, rows, scope.Audience)
+	if err != nil {
+		return nil, err
 	}
 	totalInt := int(total)
 	return &ListResult{Items: items, Total: totalInt, Page: page, PageSize: pageSize, TotalPages: (totalInt + pageSize - 1) / pageSize}, nil
 }
 
+func requestedAudience(decision ReportAccessDecision, requested policy.ReportAudience) (policy.ReportAudience, error) {
+	fallback := policy.ReportAudienceForViewer(decision.Audience)
+	if requested == "" || requested == fallback {
+		return fallback, nil
+	}
+	if !requested.IsValid() {
+		return "", cberrors.WithCode(code.ErrInvalidArgument, "unsupported report audience %q", string(requested))
+	}
+	if !decision.IsAdmin || decision.Restricted {
+		return "", cberrors.WithCode(code.ErrPermissionDenied, "only organization administrators may select a report audience")
+	}
+	return requested, nil
+}
+
 func validateDecision(decision ReportAccessDecision) error {
 	if decision.Audience == "" {
 		return cberrors.WithCode(code.ErrModuleInitializationFailed, "report access decision is missing audience")
@@ -95,6 +95,55 @@ func TestAdminAdministrationKeepsModelExtra(t *testing.T) {
 	}
 }
 
+type schoolVariantReader struct{}
+
+func (schoolVariantReader) FindAudienceVariants(_ context.Context, reportIDs []uint64, audience string) (map[uint64]interpretationreadmodel.ReportRow, error) {
+	rows := map[uint64]interpretationreadmodel.ReportRow{}
+	for _, id := range reportIDs {
+		rows[id] = interpretationreadmodel.ReportRow{ReportID: id, Audience: audience, Conclusion: audience}
+	}
+	return rows, nil
+}
+
+func TestAdministratorMaySelectReportAudience(t *testing.T) {
+	r := &adminReader{row: interpretationreadmodel.ReportRow{ReportID: 5, Conclusion: "canonical"}}
+	s := NewService(r, adminAccess{}, reportprojection.Mapper{Variants: schoolVariantReader{}})
+	result, err := s.GetReport(context.Background(), Actor{OrgID: 1, OperatorUserID: 2}, GetQuery{AssessmentID: 3, Audience: policy.ReportAudienceSchool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Audience != "school" || result.Conclusion != "school" {
+		t.Fatalf("report audience = %s/%q, want school variant", result.Audience, result.Conclusion)
+	}
+}
+
+func TestGetReportCarriesAuthorizedTesteeForAccessAudit(t *testing.T) {
+	r := &adminReader{row: interpretationreadmodel.ReportRow{AssessmentID: 3, ReportID: 5}}
+	s := NewService(r, adminAccess{decision: ReportAccessDecision{
+		TesteeID: 9, Audience: policy.AudienceAdmin, IsAdmin: true, DecisionSource: "test",
+	}}, reportprojection.Mapper{})
+	result, err := s.GetReport(context.Background(), Actor{OrgID: 1, OperatorUserID: 2}, GetQuery{AssessmentID: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TesteeID != 9 {
+		t.Fatalf("report testee = %d, want authorized testee 9", result.TesteeID)
+	}
+}
+
+func TestRestrictedAdministrationCannotSelectReportAudience(t *testing.T) {
+	r := &adminReader{row: interpretationreadmodel.ReportRow{ReportID: 5}}
+	s := NewService(r, adminAccess{decision: ReportAccessDecision{
+		Audience: policy.AudienceClinician, Restricted: true, DecisionSource: "test",
+	}}, reportprojection.Mapper{Variants: schoolVariantReader{}})
+	if _, err := s.GetReport(context.Background(), Actor{OrgID: 1, OperatorUserID: 2}, GetQuery{AssessmentID: 3, Audience: policy.ReportAudienceSchool}); err == nil {
+		t.Fatal("restricted actor selected a non-default report audience")
+	}
+	if r.calls != 0 {
+		t.Fatal("read before audience authorization")
+	}
+}
+
 type adminAccess struct {
 	err      error
 	scope    ListScope
@@ -36,9 +36,11 @@ type InterpretationCommitter interface {
 }
 
 type CommitSuccessRequest struct {
-	Generation           *domaingeneration.ReportGeneration
-	Run                  *interpretationrun.InterpretationRun
-	InterpretReport      *domainreport.InterpretReport
+	Generation      *domaingeneration.ReportGeneration
+	Run             *interpretationrun.InterpretationRun
+	InterpretReport *domainreport.InterpretReport
+	// Variants 是模板版本发布的受众变体，与规范报告同事务写入。
+	Variants             []*domainreport.AudienceVariant
 	BuilderIdentity      string
 	ContentSchemaVersion string
 	CompletedAt          time.Time
@@ -64,6 +66,7 @@ type interpretationCommitter struct {
 	generations domaingeneration.Repository
 	runs        interpretationrun.Repository
 	reports     domainreport.ReportRepository
+	variants    domainreport.AudienceVariantRepository
 	stager      EventStager
 	postCommit  appEventing.PostCommitDispatcher
 	catalog     ReportCatalogProjector
@@ -74,6 +77,7 @@ func NewInterpretationCommitter(
 	generations domaingeneration.Repository,
 	runs interpretationrun.Repository,
 	reports domainreport.ReportRepository,
+	variants domainreport.AudienceVariantRepository,
 	stager EventStager,
 	postCommit appEventing.PostCommitDispatcher,
 	catalog ReportCatalogProjector,
@@ -82,7 +86,7 @@ func NewInterpretationCommitter(
 		return nil, fmt.Errorf("interpretation committer dependencies are required")
 	}
 	return &interpretationCommitter{
-		txRunner: txRunner, generations: generations, runs: runs, reports: reports, stager: stager, postCommit: postCommit, catalog: catalog,
+		txRunner: txRunner, generations: generations, runs: runs, reports: reports, variants: variants, stager: stager, postCommit: postCommit, catalog: catalog,
 	}, nil
 }
 
@@ -117,6 +121,11 @@ func (c *interpretationCommitter) CommitSuccess(ctx context.Context, request Com
 		if err := c.reports.Insert(txCtx, request.InterpretReport); err != nil {
 			return err
 		}
+		if len(request.Variants) > 0 {
+			if err := c.variants.Insert(txCtx, request.Variants); err != nil {
+				return err
+			}
+		}
 		if err := c.catalog.ProjectCurrent(txCtx, request.InterpretReport); err != nil {
 			return err
 		}
@@ -235,6 +244,14 @@ func (c *interpretationCommitter) validateSuccess(request CommitSuccessRequest)
 	if request.InterpretReport.ContentSchemaVersion() != request.ContentSchemaVersion {
 		return fmt.Errorf("artifact content schema version does not match commit request")
 	}
+	if len(request.Variants) > 0 && c.variants == nil {
+		return fmt.Errorf("interpretation audience variant repository is not configured")
+	}
+	for _, variant := range request.Variants {
+		if variant == nil || variant.ReportID() != request.InterpretReport.ID() || variant.GenerationID() != request.Generation.ID() {
+			return fmt.Errorf("audience variant does not belong to the committed report")
+		}
+	}
 	return nil
 }
 
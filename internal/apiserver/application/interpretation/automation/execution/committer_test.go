@@ -32,7 +32,7 @@ func commitFixture(t *testing.T, stager *eventStagerStub) (*interpretationCommit
 	if err != nil {
 		t.Fatal(err)
 	}
-	committer, err := NewInterpretationCommitter(tx, gens, runs, reports, stager, nil, catalogProjectorStub{})
+	committer, err := NewInterpretationCommitter(tx, gens, runs, reports, nil, stager, nil, catalogProjectorStub{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -11,6 +11,7 @@ import (
 	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
 	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
 	interpretationrun "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/run"
 	"github.com/FangcunMount/qs-server/internal/pkg/meta"
 	"github.com/FangcunMount/qs-server/internal/pkg/retryobservability"
@@ -41,23 +42,36 @@ type executor struct {
 	starter       Starter
 	builders      rendering.Registry
 	committer     InterpretationCommitter
+	manifests     domainreporttemplate.ManifestCatalog
 	now           func() time.Time
 	newID         func() meta.ID
+	locales       LocaleResolver
 	logBuildError func(context.Context, string, error, *domaingeneration.ReportGeneration, *interpretationrun.InterpretationRun, rendering.Builder)
 }
 
+// NewExecutor wires the write use case. manifests may be nil, in which case
+// only the canonical report is produced, in the default locale, and no
+// audience variant is published.
 func NewExecutor(
 	starter Starter,
 	builders rendering.Registry,
 	committer InterpretationCommitter,
+	manifests domainreporttemplate.ManifestCatalog,
+	opts ...ExecutorOption,
 ) (Executor, error) {
 	if starter == nil || builders == nil || committer == nil {
 		return nil, fmt.Errorf("interpretation executor dependencies are required")
 	}
-	return &executor{
-		starter: starter, builders: builders, committer: committer, now: time.Now, newID: meta.New,
+	e := &executor{
+		starter: starter, builders: builders, committer: committer, manifests: manifests, now: time.Now, newID: meta.New,
 		logBuildError: logBuildFailure,
-	}, nil
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	return e, nil
 }
 
 func (e *executor) Execute(ctx context.Context, input interpinput.InterpretationInput, traceID string) (*ExecuteResult, error) {
@@ -117,6 +131,7 @@ func (e *executor) buildAndCommit(ctx context.Context, input interpinput.Interpr
 		retryobservability.ObserveBusiness("interpretation", string(runRecord.Origin()), runResult)
 	}()
 
+	input = e.localize(ctx, input)
 	key, ok := rendering.KeyFromInput(input)
 	if !ok {
 		return nil, e.fail(ctx, generationRecord, runRecord, input, interpretationrun.Failure{Kind: interpretationrun.FailureKindInput, Code: "unsupported_mechanism", SafeMessage: "报告生成配置不受支持", Retryable: false})
@@ -144,14 +159,19 @@ func (e *executor) buildAndCommit(ctx context.Context, input interpinput.Interpr
 		ID: e.newID(), GenerationID: generationRecord.ID(), OutcomeID: input.OutcomeID, InterpretationRunID: runRecord.ID(),
 		Association: input.Association, ReportType: input.Report.ReportType, TemplateVersion: input.Report.TemplateVersion,
 		BuilderIdentity: builder.BuilderIdentity(), ContentSchemaVersion: builder.ContentSchemaVersion(),
-		Content: draft.Content(), GeneratedAt: systemCompletedAt,
+		Content: draft.Content(), Locale: input.Locale, GeneratedAt: systemCompletedAt,
 	})
 	if err != nil {
 		e.logBuildError(ctx, "artifact_validation", err, generationRecord, runRecord, builder)
 		return nil, e.fail(ctx, generationRecord, runRecord, input, interpretationrun.Failure{Kind: interpretationrun.FailureKindBuild, Code: "invalid_artifact", SafeMessage: "报告生成失败", Retryable: false})
 	}
+	variants, err := e.buildVariants(ctx, input, key, artifact)
+	if err != nil {
+		e.logBuildError(ctx, "audience_variant", err, generationRecord, runRecord, builder)
+		return nil, e.fail(ctx, generationRecord, runRecord, input, interpretationrun.Failure{Kind: interpretationrun.FailureKindBuild, Code: "variant_build_failed", SafeMessage: "报告生成失败", Retryable: false})
+	}
 	committed, err := e.committer.CommitSuccess(ctx, CommitSuccessRequest{
-		Generation: generationRecord, Run: runRecord, InterpretReport: artifact, BuilderIdentity: builder.BuilderIdentity(),
+		Generation: generationRecord, Run: runRecord, InterpretReport: artifact, Variants: variants, BuilderIdentity: builder.BuilderIdentity(),
 		ContentSchemaVersion: builder.ContentSchemaVersion(), CompletedAt: systemCompletedAt,
 	})
 	if err != nil {
@@ -165,12 +185,50 @@ func (e *executor) buildAndCommit(ctx context.Context, input interpinput.Interpr
 		"run_id", committed.Run.ID().String(),
 		"report_id", committed.InterpretReport.ID().String(),
 		"builder_identity", builder.BuilderIdentity(),
+		"locale", committed.InterpretReport.Locale(),
+		"audience_variants", len(variants),
 		"result", "success",
 	)
 	runResult = executionmetrics.ResultSuccess
 	return &ExecuteResult{Status: ExecuteStatusGenerated, Generation: committed.Generation, Run: committed.Run, InterpretReport: committed.InterpretReport}, nil
 }
 
+// buildVariants renders every audience published by the frozen template release.
+// A release that names an audience the binary cannot render fails the run just
+// like a missing canonical builder: publishing a partial set would make the read
+// side silently fall back for one audience only.
+func (e *executor) buildVariants(ctx context.Context, input interpinput.InterpretationInput, key rendering.Key, artifact *domainreport.InterpretReport) ([]*domainreport.AudienceVariant, error) {
+	if e.manifests == nil || input.Report.TemplateID == "" {
+		return nil, nil
+	}
+	manifest, ok := e.manifests.ResolveManifest(input.Report.TemplateID, input.Report.TemplateVersion)
+	if !ok || len(manifest.Audiences) == 0 {
+		return nil, nil
+	}
+	variants := make([]*domainreport.AudienceVariant, 0, len(manifest.Audiences))
+	for _, audience := range manifest.Audiences {
+		variantKey := key
+		variantKey.Audience = audience
+		builder, err := e.builders.ResolveByMechanism(variantKey)
+		if err != nil {
+			return nil, err
+		}
+		draft, err := builder.Build(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("build %s report variant: %w", audience, err)
+		}
+		if draft == nil {
+			return nil, fmt.Errorf("build %s report variant: empty draft", audience)
+		}
+		variant, err := domainreport.NewAudienceVariant(artifact, audience, draft.Content())
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
 func logBuildFailure(ctx context.Context, phase string, err error, generationRecord *domaingeneration.ReportGeneration, runRecord *interpretationrun.InterpretationRun, builder rendering.Builder) {
 	fields := []interface{}{"phase", phase, "error", err}
 	if generationRecord != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
 	interpretationrun "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/run"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
 	"github.com/FangcunMount/qs-server/internal/pkg/meta"
@@ -93,11 +94,11 @@ func newExecutorFixture(t *testing.T, builder *executorBuilder) (*executor, *mem
 		t.Fatal(err)
 	}
 	stager := &eventStagerStub{}
-	committer, err := NewInterpretationCommitter(tx, gens, runs, reports, stager, nil, catalogProjectorStub{})
+	committer, err := NewInterpretationCommitter(tx, gens, runs, reports, nil, stager, nil, catalogProjectorStub{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	service, err := NewExecutor(starter, registry, committer)
+	service, err := NewExecutor(starter, registry, committer, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -267,3 +268,74 @@ func TestExecutorLogsArtifactValidationFailureWithoutLeakingIt(t *testing.T) {
 		t.Fatalf("reports=%d events=%d", len(reports.items), len(stager.events))
 	}
 }
+
+type manifestCatalogStub struct {
+	manifest domainreporttemplate.ReleaseManifest
+}
+
+func (s manifestCatalogStub) ResolveManifest(templateID string, version policy.TemplateVersion) (domainreporttemplate.ReleaseManifest, bool) {
+	return s.manifest, s.manifest.TemplateID == templateID && s.manifest.TemplateVersion == version
+}
+
+type memoryVariantRepo struct {
+	items []*report.AudienceVariant
+}
+
+func (r *memoryVariantRepo) Insert(_ context.Context, variants []*report.AudienceVariant) error {
+	r.items = append(r.items, variants...)
+	return nil
+}
+
+func TestExecutorCommitsPublishedAudienceVariantsWithCanonicalReport(t *testing.T) {
+	builder := &executorBuilder{}
+	gens, runs := newMemoryGenerationRepo(), newMemoryRunRepo()
+	reports := &memoryArtifactRepo{items: map[meta.ID]*report.InterpretReport{}}
+	variants := &memoryVariantRepo{}
+	tx := &starterTx{}
+	starter, err := NewStarter(tx, gens, runs, reports, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := rendering.NewRegistry(builder,
+		rendering.AudienceVariant(builder, policy.ReportAudienceFamily),
+		rendering.AudienceVariant(builder, policy.ReportAudienceSchool),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := domainreporttemplate.NewReleaseManifest("standard", policy.TemplateVersionV1, policy.ReportTypeStandard, []domainreporttemplate.ManifestRoute{{
+		DecisionKind: modelcatalog.DecisionKindScoreRange, BuilderIdentity: report.BuilderIdentityFactorScoring, ContentSchemaVersion: "report-content/v1",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest, err = manifest.WithAudiences(policy.ReportAudienceSchool, policy.ReportAudienceFamily); err != nil {
+		t.Fatal(err)
+	}
+	committer, err := NewInterpretationCommitter(tx, gens, runs, reports, variants, &eventStagerStub{}, nil, catalogProjectorStub{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewExecutor(starter, registry, committer, manifestCatalogStub{manifest: manifest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := executorInput()
+	input.Report.TemplateID = "standard"
+	result, err := service.Execute(context.Background(), input, "variants")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(variants.items) != 2 || builder.calls != 3 {
+		t.Fatalf("variants=%d builds=%d", len(variants.items), builder.calls)
+	}
+	for index, want := range []policy.ReportAudience{policy.ReportAudienceFamily, policy.ReportAudienceSchool} {
+		variant := variants.items[index]
+		if variant.Audience() != want || variant.ReportID() != result.InterpretReport.ID() {
+			t.Fatalf("variant[%d] = %s for %s", index, variant.Audience(), variant.ReportID())
+		}
+	}
+	if school := variants.items[1].Content(); school.PrimaryScore != nil || len(school.Dimensions) != 0 {
+		t.Fatalf("school variant carries scores: %#v", school)
+	}
+}
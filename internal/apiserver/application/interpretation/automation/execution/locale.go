@@ -0,0 +1,53 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+)
+
+// LocaleResolver returns the content locale a testee prefers; an empty locale
+// means no preference.
+type LocaleResolver interface {
+	PreferredLocale(ctx context.Context, testeeID uint64) (string, error)
+}
+
+// ExecutorOption configures optional executor collaborators.
+type ExecutorOption func(*executor)
+
+// WithLocaleResolver renders each report in the testee's preferred locale when
+// the frozen release and the model's translations both offer it.
+func WithLocaleResolver(resolver LocaleResolver) ExecutorOption {
+	return func(e *executor) { e.locales = resolver }
+}
+
+// localize selects the content locale for a new report. Locale lookup never
+// fails a generation: the default locale is always renderable, so a resolver
+// error only costs the testee their preference.
+func (e *executor) localize(ctx context.Context, input interpinput.InterpretationInput) interpinput.InterpretationInput {
+	if e.locales == nil || input.Association.TesteeID == 0 {
+		return interpinput.Localize(input, locale.Default)
+	}
+	preferred, err := e.locales.PreferredLocale(ctx, input.Association.TesteeID)
+	if err != nil {
+		logger.L(ctx).Warnw("受试者偏好语言读取失败，使用默认语言生成报告",
+			"action", "resolve_report_locale",
+			"outcome_id", input.OutcomeID.String(),
+			"testee_id", input.Association.TesteeID,
+			"error", err,
+		)
+		return interpinput.Localize(input, locale.Default)
+	}
+	if preferred == "" {
+		return interpinput.Localize(input, locale.Default)
+	}
+	published := []string{locale.Default}
+	if e.manifests != nil && input.Report.TemplateID != "" {
+		if manifest, ok := e.manifests.ResolveManifest(input.Report.TemplateID, input.Report.TemplateVersion); ok {
+			published = manifest.ContentLocales()
+		}
+	}
+	return interpinput.Localize(input, locale.Negotiate([]string{preferred}, input.LocalesIn(published)))
+}
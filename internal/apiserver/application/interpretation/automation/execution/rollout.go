@@ -0,0 +1,176 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	domaingeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/generation"
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// rolloutExecutor routes new generations through staged template rollouts
+// before the write use case runs. Routing is sticky: once an Outcome has a
+// generation on either side of a rollout, retries and redeliveries reuse that
+// release even if the rollout has since been promoted or rolled back.
+type rolloutExecutor struct {
+	inner       Executor
+	rollouts    domainreporttemplate.RolloutRepository
+	generations domaingeneration.Repository
+	builders    rendering.Registry
+	comparisons domainreporttemplate.ShadowComparisonRepository
+	now         func() time.Time
+	newID       func() meta.ID
+}
+
+// NewRolloutExecutor decorates the write use case with staged rollout routing
+// and shadow comparisons.
+func NewRolloutExecutor(
+	inner Executor,
+	rollouts domainreporttemplate.RolloutRepository,
+	generations domaingeneration.Repository,
+	builders rendering.Registry,
+	comparisons domainreporttemplate.ShadowComparisonRepository,
+) (Executor, error) {
+	if inner == nil || rollouts == nil || generations == nil || builders == nil || comparisons == nil {
+		return nil, fmt.Errorf("interpretation rollout executor dependencies are required")
+	}
+	return &rolloutExecutor{
+		inner: inner, rollouts: rollouts, generations: generations, builders: builders, comparisons: comparisons,
+		now: time.Now, newID: meta.New,
+	}, nil
+}
+
+func (e *rolloutExecutor) Execute(ctx context.Context, input interpinput.InterpretationInput, traceID string) (*ExecuteResult, error) {
+	if templatePinned(ctx) || input.Report.TemplateID == "" || input.Report.TemplateVersion.IsEmpty() {
+		return e.inner.Execute(ctx, input, traceID)
+	}
+	rollout, err := e.rollouts.FindEffective(ctx, input.Report.TemplateID, input.Report.TemplateVersion)
+	if errors.Is(err, domainreporttemplate.ErrRolloutNotFound) {
+		return e.inner.Execute(ctx, input, traceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find report template rollout: %w", err)
+	}
+	version, err := e.routeVersion(ctx, rollout, input)
+	if err != nil {
+		return nil, err
+	}
+	routed := input
+	routed.Report.TemplateVersion = version
+	if version != input.Report.TemplateVersion {
+		logger.L(ctx).Infow("报告模板灰度路由到候选版本",
+			"action", "route_report_template_rollout",
+			"outcome_id", input.OutcomeID.String(),
+			"rollout_id", rollout.ID().String(),
+			"stable_version", rollout.StableVersion().String(),
+			"candidate_version", version.String(),
+		)
+	}
+	result, err := e.inner.Execute(ctx, routed, traceID)
+	if err != nil {
+		return result, err
+	}
+	if result != nil && result.Status == ExecuteStatusGenerated && result.InterpretReport != nil &&
+		rollout.ShadowSamples(input.Association.OrgID, input.OutcomeID) {
+		e.shadow(ctx, rollout, input, result.InterpretReport)
+	}
+	return result, nil
+}
+
+type pinnedTemplateKey struct{}
+
+// WithPinnedTemplate marks a generation whose template version was chosen
+// explicitly, by an operator or by lease recovery of an existing generation.
+// Staged rollouts never re-route a pinned generation.
+func WithPinnedTemplate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinnedTemplateKey{}, true)
+}
+
+func templatePinned(ctx context.Context) bool {
+	pinned, _ := ctx.Value(pinnedTemplateKey{}).(bool)
+	return pinned
+}
+
+func (e *rolloutExecutor) routeVersion(ctx context.Context, rollout *domainreporttemplate.Rollout, input interpinput.InterpretationInput) (policy.TemplateVersion, error) {
+	existing, err := e.generations.ListByOutcomeID(ctx, input.OutcomeID)
+	if err != nil {
+		return "", fmt.Errorf("list outcome generations for rollout routing: %w", err)
+	}
+	for _, generationRecord := range existing {
+		key := generationRecord.Key()
+		if key.ReportType != input.Report.ReportType {
+			continue
+		}
+		if key.TemplateVersion == rollout.StableVersion() || key.TemplateVersion == rollout.CandidateVersion() {
+			return key.TemplateVersion, nil
+		}
+	}
+	return rollout.RouteVersion(input.Association.OrgID, input.OutcomeID), nil
+}
+
+// shadow renders the candidate draft for a sampled Outcome and stores its diff
+// against the committed report. Shadow failures never affect the committed
+// generation; they are logged so the rollout owner can see missing samples.
+func (e *rolloutExecutor) shadow(ctx context.Context, rollout *domainreporttemplate.Rollout, input interpinput.InterpretationInput, stable *domainreport.InterpretReport) {
+	// The candidate renders in the committed report's locale so the diff only
+	// shows template changes.
+	candidateInput := interpinput.Localize(input, stable.Locale())
+	candidateInput.Report.TemplateVersion = rollout.CandidateVersion()
+	comparison, err := e.renderShadow(ctx, rollout, candidateInput, stable)
+	if err == nil {
+		err = e.comparisons.Insert(ctx, comparison)
+	}
+	if err != nil {
+		logger.L(ctx).Warnw("报告模板影子对比失败",
+			"action", "shadow_report_template_rollout",
+			"outcome_id", input.OutcomeID.String(),
+			"rollout_id", rollout.ID().String(),
+			"candidate_version", rollout.CandidateVersion().String(),
+			"error", err,
+		)
+		return
+	}
+	logger.L(ctx).Infow("报告模板影子对比已记录",
+		"action", "shadow_report_template_rollout",
+		"outcome_id", input.OutcomeID.String(),
+		"rollout_id", rollout.ID().String(),
+		"changes", len(comparison.Changes()),
+	)
+}
+
+func (e *rolloutExecutor) renderShadow(ctx context.Context, rollout *domainreporttemplate.Rollout, input interpinput.InterpretationInput, stable *domainreport.InterpretReport) (*domainreporttemplate.ShadowComparison, error) {
+	key, ok := rendering.KeyFromInput(input)
+	if !ok {
+		return nil, fmt.Errorf("candidate routing key is incomplete")
+	}
+	builder, err := e.builders.ResolveByMechanism(key)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := builder.Build(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("build candidate draft: %w", err)
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("build candidate draft: empty draft")
+	}
+	content := draft.Content()
+	if err := domainreport.CrossMechanismArtifactContract(content); err != nil {
+		return nil, err
+	}
+	if err := domainreport.BuilderSpecificDraftContract(builder.BuilderIdentity(), content); err != nil {
+		return nil, err
+	}
+	return domainreporttemplate.NewShadowComparison(domainreporttemplate.ShadowComparisonInput{
+		ID: e.newID(), RolloutID: rollout.ID(), Stable: stable, CandidateVersion: rollout.CandidateVersion(),
+		CandidateBuilderIdentity: builder.BuilderIdentity(), Candidate: content, CreatedAt: e.now(),
+	})
+}
@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type fixedRolloutRepo struct {
+	rollout *domainreporttemplate.Rollout
+}
+
+func (r *fixedRolloutRepo) Create(context.Context, *domainreporttemplate.Rollout) error { return nil }
+func (r *fixedRolloutRepo) Save(context.Context, *domainreporttemplate.Rollout, uint64) error {
+	return nil
+}
+func (r *fixedRolloutRepo) FindByID(context.Context, meta.ID) (*domainreporttemplate.Rollout, error) {
+	return r.rollout, nil
+}
+func (r *fixedRolloutRepo) FindEffective(_ context.Context, templateID string, stable policy.TemplateVersion) (*domainreporttemplate.Rollout, error) {
+	if r.rollout == nil || !r.rollout.Status().IsEffective() || r.rollout.TemplateID() != templateID || r.rollout.StableVersion() != stable {
+		return nil, domainreporttemplate.ErrRolloutNotFound
+	}
+	return r.rollout, nil
+}
+func (r *fixedRolloutRepo) ListByTemplateID(context.Context, string, int) ([]*domainreporttemplate.Rollout, error) {
+	return nil, nil
+}
+
+type memoryShadowRepo struct {
+	items []*domainreporttemplate.ShadowComparison
+}
+
+func (r *memoryShadowRepo) Insert(_ context.Context, comparison *domainreporttemplate.ShadowComparison) error {
+	r.items = append(r.items, comparison)
+	return nil
+}
+func (r *memoryShadowRepo) ListByRollout(context.Context, meta.ID, int64, int) ([]*domainreporttemplate.ShadowComparison, error) {
+	return r.items, nil
+}
+
+func rolloutFixture(t *testing.T, mode domainreporttemplate.RolloutMode, percent int, orgIDs []int64) *domainreporttemplate.Rollout {
+	t.Helper()
+	rollout, err := domainreporttemplate.NewRollout(domainreporttemplate.StartRolloutInput{
+		ID: meta.FromUint64(900), TemplateID: "scale", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: mode, Percent: percent, OrgIDs: orgIDs, Reason: "pilot", Actor: "user:1",
+		At: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rollout
+}
+
+// candidateBuilder renders the candidate release; builders are registered per
+// template version, so a candidate needs its own registration.
+type candidateBuilder struct {
+	*executorBuilder
+}
+
+func (candidateBuilder) TemplateVersion() policy.TemplateVersion { return "custom-v2" }
+
+func rolloutRegistry(t *testing.T, stable *executorBuilder, inner *executor) rendering.Registry {
+	t.Helper()
+	registry, err := rendering.NewRegistry(stable, candidateBuilder{executorBuilder: stable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner.builders = registry
+	return registry
+}
+
+func rolloutInput(outcomeID uint64) interpinput.InterpretationInput {
+	input := executorInput()
+	input.OutcomeID = meta.FromUint64(outcomeID)
+	input.Report.TemplateID = "scale"
+	return input
+}
+
+func TestRolloutExecutorRoutesCanaryOrganizationsAndKeepsRoutingSticky(t *testing.T) {
+	builder := &executorBuilder{}
+	inner, gens, _, _, _, _ := newExecutorFixture(t, builder)
+	registry := rolloutRegistry(t, builder, inner)
+	rollouts := &fixedRolloutRepo{}
+	service, err := NewRolloutExecutor(inner, rollouts, gens, registry, &memoryShadowRepo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Outcome 50 is generated before the rollout starts, then redelivered.
+	if _, err := service.Execute(ctx, rolloutInput(50), "before"); err != nil {
+		t.Fatal(err)
+	}
+	rollouts.rollout = rolloutFixture(t, domainreporttemplate.RolloutModeCanary, 0, []int64{1})
+	result, err := service.Execute(ctx, rolloutInput(51), "canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.InterpretReport == nil || result.InterpretReport.TemplateVersion() != "custom-v2" {
+		t.Fatalf("canary org report = %#v, want candidate release", result.InterpretReport)
+	}
+	if _, err := service.Execute(ctx, rolloutInput(50), "redelivery"); err != nil {
+		t.Fatal(err)
+	}
+	generations, _ := gens.ListByOutcomeID(ctx, meta.FromUint64(50))
+	if len(generations) != 1 || generations[0].Key().TemplateVersion != policy.TemplateVersionV1 {
+		t.Fatalf("redelivered outcome generations = %d, want one stable generation", len(generations))
+	}
+	if builder.calls != 2 {
+		t.Fatalf("builder calls = %d, want redelivery to reuse the stable generation", builder.calls)
+	}
+}
+
+func TestRolloutExecutorShadowCommitsStableAndRecordsDiff(t *testing.T) {
+	builder := &executorBuilder{}
+	inner, gens, _, reports, _, _ := newExecutorFixture(t, builder)
+	registry := rolloutRegistry(t, builder, inner)
+	shadows := &memoryShadowRepo{}
+	rollouts := &fixedRolloutRepo{rollout: rolloutFixture(t, domainreporttemplate.RolloutModeShadow, 100, nil)}
+	service, err := NewRolloutExecutor(inner, rollouts, gens, registry, shadows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := service.Execute(context.Background(), rolloutInput(60), "shadow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.InterpretReport.TemplateVersion() != policy.TemplateVersionV1 || len(reports.items) != 1 {
+		t.Fatalf("shadow committed %s with %d reports, want only the stable report", result.InterpretReport.TemplateVersion(), len(reports.items))
+	}
+	if len(shadows.items) != 1 {
+		t.Fatalf("shadow comparisons = %d, want 1", len(shadows.items))
+	}
+	comparison := shadows.items[0]
+	if comparison.CandidateVersion() != "custom-v2" || comparison.ReportID() != result.InterpretReport.ID() || !comparison.Identical() {
+		t.Fatalf("comparison = %#v", comparison)
+	}
+	if comparison.Candidate().Conclusion != "ok" || comparison.Candidate().Level == nil || comparison.Candidate().Level.Code != report.LevelFromRisk(report.RiskLevelLow).Code {
+		t.Fatalf("candidate content = %#v", comparison.Candidate())
+	}
+}
@@ -39,8 +39,11 @@ func (r *leaseRecoverer) RecoverExpiredLeases(ctx context.Context, now time.Time
 		if err != nil {
 			return recovered, err
 		}
+		// The expired generation may have been pinned to a release other than
+		// the frozen one, so recovery addresses its own template version.
 		_, err = r.automation.Generate(ctx, GenerateCommand{
 			Actor: TrustedServiceActor("lease-recovery"), OutcomeID: generationRecord.Key().OutcomeID, TraceID: "lease-recovery:" + lease.RunID.String(),
+			TemplateVersion: generationRecord.Key().TemplateVersion,
 		})
 		if err != nil {
 			if _, durable := FailureFrom(err); !durable {
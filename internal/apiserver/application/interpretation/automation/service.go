@@ -11,6 +11,7 @@ import (
 	interpretationexecution "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation/execution"
 	interpretationinput "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation/input"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/admission"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/run"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
 	modeltypology "github.com/FangcunMount/qs-server/internal/apiserver/port/modelcatalog/payload/typology"
@@ -24,12 +25,20 @@ type Actor struct {
 
 func TrustedServiceActor(source string) Actor { return Actor{Source: source} }
 
+// GenerateCommand addresses one Outcome. TemplateVersion, when set, pins the
+// generation to that release instead of the frozen one; TemplateID, when set,
+// must match the template the Outcome froze.
 type GenerateCommand struct {
-	Actor     Actor
-	OutcomeID meta.ID
-	TraceID   string
+	Actor           Actor
+	OutcomeID       meta.ID
+	TraceID         string
+	TemplateID      string
+	TemplateVersion policy.TemplateVersion
 }
 
+// ErrTemplateMismatch reports a pinned template the Outcome did not freeze.
+var ErrTemplateMismatch = errors.New("pinned report template does not match the frozen outcome")
+
 type Status string
 
 const (
@@ -44,6 +53,7 @@ type Result struct {
 	GenerationID  meta.ID
 	RunID         meta.ID
 	ReportID      meta.ID
+	Attempt       int
 	AttemptOrigin retrygovernance.AttemptOrigin
 	RetryDecision *retrygovernance.Decision
 }
@@ -96,6 +106,13 @@ func (s *service) Generate(ctx context.Context, command GenerateCommand) (*Resul
 	if err != nil {
 		return nil, s.rejectAdmission(ctx, command, record, classifyInputError(err), err)
 	}
+	if !command.TemplateVersion.IsEmpty() {
+		if command.TemplateID != "" && command.TemplateID != input.Report.TemplateID {
+			return nil, fmt.Errorf("%w: outcome froze %q", ErrTemplateMismatch, input.Report.TemplateID)
+		}
+		input.Report.TemplateVersion = command.TemplateVersion
+		ctx = interpretationexecution.WithPinnedTemplate(ctx)
+	}
 	executed, err := interpretationexecution.ExecuteOutcome(ctx, s.executor, record, input, command.TraceID)
 	if err != nil {
 		return nil, err
@@ -114,6 +131,7 @@ func (s *service) Generate(ctx context.Context, command GenerateCommand) (*Resul
 	}
 	if executed.Run != nil {
 		result.RunID = executed.Run.ID()
+		result.Attempt = executed.Run.Attempt()
 		result.AttemptOrigin = executed.Run.Origin()
 		result.RetryDecision = executed.Run.RetryDecision()
 	}
@@ -0,0 +1,220 @@
+package clinicalreview
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/queryerror"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 报告临床复核用例。复核人必须是绑定从业者的操作者，且可以访问该受试者。
+type Service interface {
+	// GetReview 返回复核状态与全部备注版本；尚无记录时状态为待复核。
+	GetReview(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*ReviewView, error)
+	// AddNote 追加一版临床备注；报告已签署时状态转为已修订并刷新复核人。
+	AddNote(ctx context.Context, dto NoteDTO) (*ReviewView, error)
+	// SignOff 签署报告复核；报告需已生成，且尚未签署。
+	SignOff(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*Review, error)
+}
+
+type service struct {
+	store           Store
+	access          Access
+	reports         interpretationreadmodel.ReportReader
+	operatorReader  actorreadmodel.OperatorReader
+	clinicianReader actorreadmodel.ClinicianReader
+	now             func() time.Time
+}
+
+// NewService 创建报告临床复核服务。
+func NewService(
+	store Store,
+	access Access,
+	reports interpretationreadmodel.ReportReader,
+	operatorReader actorreadmodel.OperatorReader,
+	clinicianReader actorreadmodel.ClinicianReader,
+) Service {
+	return &service{
+		store:           store,
+		access:          access,
+		reports:         reports,
+		operatorReader:  operatorReader,
+		clinicianReader: clinicianReader,
+		now:             time.Now,
+	}
+}
+
+func (s *service) GetReview(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*ReviewView, error) {
+	if err := s.authorize(ctx, actor, testeeID, assessmentID); err != nil {
+		return nil, err
+	}
+	review, err := s.loadReview(ctx, actor.OrgID, testeeID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, review)
+}
+
+func (s *service) AddNote(ctx context.Context, dto NoteDTO) (*ReviewView, error) {
+	content := strings.TrimSpace(dto.Content)
+	addendum := strings.TrimSpace(dto.Addendum)
+	if content == "" {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note content is required")
+	}
+	if utf8.RuneCountInString(content) > maxContentRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note content must be at most %d characters", maxContentRunes)
+	}
+	if utf8.RuneCountInString(addendum) > maxAddendumRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "addendum must be at most %d characters", maxAddendumRunes)
+	}
+	if err := s.authorize(ctx, dto.Actor, dto.TesteeID, dto.AssessmentID); err != nil {
+		return nil, err
+	}
+	author, err := s.currentReviewer(ctx, dto.Actor)
+	if err != nil {
+		return nil, err
+	}
+	review, err := s.loadReview(ctx, dto.OrgID, dto.TesteeID, dto.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	note := &Note{
+		ID:           meta.New().Uint64(),
+		OrgID:        dto.OrgID,
+		AssessmentID: dto.AssessmentID,
+		TesteeID:     dto.TesteeID,
+		Version:      review.NoteVersion + 1,
+		Content:      content,
+		Addendum:     addendum,
+		Author:       *author,
+		CreatedAt:    now,
+	}
+	review.NoteVersion = note.Version
+	review.UpdatedAt = now
+	if review.Status.Signed() {
+		review.Status = StatusAmended
+		review.Reviewer = author
+		review.ReviewedAt = &now
+	}
+	saved, err := s.store.AppendNote(ctx, review, note)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save clinical note")
+	}
+	if !saved {
+		return nil, errors.WithCode(code.ErrReportReviewConflict, "clinical note version %d already exists, reload and retry", note.Version)
+	}
+	logger.L(ctx).Infow("clinical note added",
+		"action", "add_clinical_note",
+		"org_id", dto.OrgID,
+		"assessment_id", dto.AssessmentID,
+		"version", note.Version,
+		"status", string(review.Status),
+		"clinician_id", author.ClinicianID,
+	)
+	return s.view(ctx, review)
+}
+
+func (s *service) SignOff(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*Review, error) {
+	if err := s.authorize(ctx, actor, testeeID, assessmentID); err != nil {
+		return nil, err
+	}
+	if _, err := s.reports.GetReportByAssessmentID(ctx, assessmentID); err != nil {
+		return nil, queryerror.MapReadError(err)
+	}
+	reviewer, err := s.currentReviewer(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	review, err := s.loadReview(ctx, actor.OrgID, testeeID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status.Signed() {
+		return nil, errors.WithCode(code.ErrReportReviewConflict, "report is already signed off")
+	}
+
+	now := s.now()
+	review.Status = StatusReviewed
+	review.Reviewer = reviewer
+	review.ReviewedAt = &now
+	review.UpdatedAt = now
+	saved, err := s.store.SignOff(ctx, review)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "sign off report review")
+	}
+	if !saved {
+		return nil, errors.WithCode(code.ErrReportReviewConflict, "report is already signed off")
+	}
+	logger.L(ctx).Infow("report signed off",
+		"action", "sign_off_report",
+		"org_id", actor.OrgID,
+		"assessment_id", assessmentID,
+		"note_version", review.NoteVersion,
+		"clinician_id", reviewer.ClinicianID,
+	)
+	return review, nil
+}
+
+func (s *service) authorize(ctx context.Context, actor Actor, testeeID, assessmentID uint64) error {
+	if actor.OrgID <= 0 || actor.OperatorUserID <= 0 || testeeID == 0 || assessmentID == 0 {
+		return errors.WithCode(code.ErrInvalidArgument, "operator identity, testee ID and assessment ID are required")
+	}
+	if s.store == nil || s.access == nil || s.reports == nil || s.operatorReader == nil || s.clinicianReader == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "clinical review service is not configured")
+	}
+	return s.access.AuthorizeAssessment(ctx, actor, testeeID, assessmentID)
+}
+
+// loadReview 读取复核状态；尚无记录时返回待复核的空状态。
+func (s *service) loadReview(ctx context.Context, orgID int64, testeeID, assessmentID uint64) (*Review, error) {
+	review, err := s.store.FindReview(ctx, orgID, assessmentID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find report review")
+	}
+	if review == nil {
+		return &Review{OrgID: orgID, AssessmentID: assessmentID, TesteeID: testeeID, Status: StatusPendingReview}, nil
+	}
+	return review, nil
+}
+
+func (s *service) view(ctx context.Context, review *Review) (*ReviewView, error) {
+	notes, err := s.store.ListNotes(ctx, review.OrgID, review.AssessmentID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list clinical notes")
+	}
+	return &ReviewView{Review: *review, Notes: notes}, nil
+}
+
+func (s *service) currentReviewer(ctx context.Context, actor Actor) (*Reviewer, error) {
+	operatorItem, err := s.operatorReader.FindOperatorByUser(ctx, actor.OrgID, actor.OperatorUserID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return nil, errors.WithCode(code.ErrPermissionDenied, "operator not found in current organization")
+		}
+		return nil, errors.Wrap(err, "failed to find operator")
+	}
+	if operatorItem == nil || !operatorItem.IsActive {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "operator is inactive")
+	}
+	clinicianItem, err := s.clinicianReader.FindClinicianByOperator(ctx, actor.OrgID, operatorItem.ID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return nil, errors.WithCode(code.ErrPermissionDenied, "only clinicians can review reports")
+		}
+		return nil, errors.Wrap(err, "failed to find clinician by operator")
+	}
+	if clinicianItem == nil || !clinicianItem.IsActive {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "clinician is inactive")
+	}
+	return &Reviewer{UserID: actor.OperatorUserID, ClinicianID: clinicianItem.ID, Name: clinicianItem.Name}, nil
+}
@@ -0,0 +1,181 @@
+package clinicalreview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	reviews map[uint64]*Review
+	notes   map[uint64][]Note
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{reviews: map[uint64]*Review{}, notes: map[uint64][]Note{}}
+}
+
+func (s *fakeStore) FindReview(_ context.Context, _ int64, assessmentID uint64) (*Review, error) {
+	review, ok := s.reviews[assessmentID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *review
+	return &copied, nil
+}
+
+func (s *fakeStore) ListNotes(_ context.Context, _ int64, assessmentID uint64) ([]Note, error) {
+	return append([]Note(nil), s.notes[assessmentID]...), nil
+}
+
+func (s *fakeStore) AppendNote(_ context.Context, review *Review, note *Note) (bool, error) {
+	if len(s.notes[note.AssessmentID]) >= note.Version {
+		return false, nil
+	}
+	s.notes[note.AssessmentID] = append(s.notes[note.AssessmentID], *note)
+	copied := *review
+	s.reviews[review.AssessmentID] = &copied
+	return true, nil
+}
+
+func (s *fakeStore) SignOff(_ context.Context, review *Review) (bool, error) {
+	if existing, ok := s.reviews[review.AssessmentID]; ok && existing.Status.Signed() {
+		return false, nil
+	}
+	copied := *review
+	s.reviews[review.AssessmentID] = &copied
+	return true, nil
+}
+
+type fakeAccess struct{ allowed map[uint64]uint64 }
+
+func (a fakeAccess) AuthorizeAssessment(_ context.Context, _ Actor, testeeID, assessmentID uint64) error {
+	if a.allowed[assessmentID] != testeeID {
+		return cberrors.WithCode(code.ErrPermissionDenied, "testee is not accessible")
+	}
+	return nil
+}
+
+type fakeReports struct{ existing map[uint64]bool }
+
+func (r fakeReports) GetReportByAssessmentID(_ context.Context, assessmentID uint64) (*interpretationreadmodel.ReportRow, error) {
+	if !r.existing[assessmentID] {
+		return nil, interpretationreadmodel.ErrReportNotFound
+	}
+	return &interpretationreadmodel.ReportRow{AssessmentID: assessmentID}, nil
+}
+
+func (fakeReports) ListReports(context.Context, interpretationreadmodel.ReportFilter, interpretationreadmodel.PageRequest) ([]interpretationreadmodel.ReportRow, int64, error) {
+	return nil, 0, nil
+}
+
+// fakeReadModel 只实现复核人解析用到的读模型方法，其余调用会因嵌入的 nil 接口而 panic。
+type fakeReadModel struct {
+	actorreadmodel.ReadModel
+	clinicians map[int64]*actorreadmodel.ClinicianRow
+}
+
+func (f *fakeReadModel) FindOperatorByUser(_ context.Context, orgID int64, userID int64) (*actorreadmodel.OperatorRow, error) {
+	return &actorreadmodel.OperatorRow{ID: uint64(userID), OrgID: orgID, UserID: userID, IsActive: true}, nil
+}
+
+func (f *fakeReadModel) FindClinicianByOperator(_ context.Context, _ int64, operatorID uint64) (*actorreadmodel.ClinicianRow, error) {
+	row, ok := f.clinicians[int64(operatorID)]
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrUserNotFound, "clinician not found")
+	}
+	return row, nil
+}
+
+func newTestService(store Store) *service {
+	readModel := &fakeReadModel{clinicians: map[int64]*actorreadmodel.ClinicianRow{
+		900: {ID: 301, OrgID: 1, Name: "王医生", IsActive: true},
+		901: {ID: 302, OrgID: 1, Name: "李医生", IsActive: true},
+	}}
+	svc := NewService(store,
+		fakeAccess{allowed: map[uint64]uint64{5001: 401, 5002: 401}},
+		fakeReports{existing: map[uint64]bool{5001: true}},
+		readModel, readModel,
+	).(*service)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestReviewLifecycleFromPendingToAmended(t *testing.T) {
+	store := newFakeStore()
+	svc := newTestService(store)
+	ctx := context.Background()
+	wang := Actor{OrgID: 1, OperatorUserID: 900}
+	li := Actor{OrgID: 1, OperatorUserID: 901}
+
+	view, err := svc.GetReview(ctx, wang, 401, 5001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Review.Status != StatusPendingReview || len(view.Notes) != 0 {
+		t.Fatalf("initial review = %#v", view)
+	}
+
+	view, err = svc.AddNote(ctx, NoteDTO{Actor: wang, TesteeID: 401, AssessmentID: 5001, Content: " 情绪低落，建议复诊 ", Addendum: "请两周后复诊"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Review.Status != StatusPendingReview || view.Review.NoteVersion != 1 || view.Review.Reviewer != nil {
+		t.Fatalf("note before sign-off must keep pending status: %#v", view.Review)
+	}
+	if note := view.Notes[0]; note.Content != "情绪低落，建议复诊" || note.Author.ClinicianID != 301 || note.Author.Name != "王医生" {
+		t.Fatalf("note = %#v", note)
+	}
+
+	review, err := svc.SignOff(ctx, wang, 401, 5001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if review.Status != StatusReviewed || review.Reviewer.ClinicianID != 301 || review.ReviewedAt == nil {
+		t.Fatalf("signed review = %#v", review)
+	}
+	if _, err := svc.SignOff(ctx, li, 401, 5001); !cberrors.IsCode(err, code.ErrReportReviewConflict) {
+		t.Fatalf("second sign-off error = %v, want conflict", err)
+	}
+
+	view, err = svc.AddNote(ctx, NoteDTO{Actor: li, TesteeID: 401, AssessmentID: 5001, Content: "补充：家属反馈睡眠改善"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Review.Status != StatusAmended || view.Review.NoteVersion != 2 || view.Review.Reviewer.ClinicianID != 302 {
+		t.Fatalf("amended review = %#v", view.Review)
+	}
+	if len(view.Notes) != 2 || view.Notes[0].Version != 1 || view.Notes[1].Version != 2 {
+		t.Fatalf("note history = %#v", view.Notes)
+	}
+}
+
+func TestSignOffRequiresGeneratedReportAndAccess(t *testing.T) {
+	svc := newTestService(newFakeStore())
+	ctx := context.Background()
+	actor := Actor{OrgID: 1, OperatorUserID: 900}
+
+	if _, err := svc.SignOff(ctx, actor, 401, 5002); !cberrors.IsCode(err, code.ErrInterpretReportNotFound) {
+		t.Fatalf("sign-off without report error = %v, want report not found", err)
+	}
+	if _, err := svc.SignOff(ctx, actor, 402, 5001); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("sign-off for other testee error = %v, want permission denied", err)
+	}
+	if _, err := svc.SignOff(ctx, Actor{OrgID: 1, OperatorUserID: 999}, 401, 5001); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("sign-off by non-clinician error = %v, want permission denied", err)
+	}
+}
+
+func TestAddNoteValidatesContent(t *testing.T) {
+	svc := newTestService(newFakeStore())
+	actor := Actor{OrgID: 1, OperatorUserID: 900}
+	if _, err := svc.AddNote(context.Background(), NoteDTO{Actor: actor, TesteeID: 401, AssessmentID: 5001, Content: "  "}); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("empty content error = %v, want invalid argument", err)
+	}
+}
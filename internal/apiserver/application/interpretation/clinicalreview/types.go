@@ -0,0 +1,60 @@
+// Package clinicalreview 报告临床复核：机器生成的解读报告保持不可变，
+// 从业者在其之上追加带版本的临床备注，并对报告签署复核。
+//
+// 复核状态只有三种：尚无记录视为待复核（pending_review）；签署后为已复核（reviewed）；
+// 签署后再追加备注为已修订（amended），同时刷新复核人与复核时间。
+// 备注中的受试者补充说明（addendum）只在报告签署后出现在报告视图中。
+package clinicalreview
+
+import (
+	"context"
+
+	domainreview "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/clinicalreview"
+)
+
+type (
+	Status   = domainreview.Status
+	Reviewer = domainreview.Reviewer
+	Review   = domainreview.Review
+	Note     = domainreview.Note
+)
+
+const (
+	StatusPendingReview = domainreview.StatusPendingReview
+	StatusReviewed      = domainreview.StatusReviewed
+	StatusAmended       = domainreview.StatusAmended
+)
+
+const (
+	maxContentRunes  = 5000
+	maxAddendumRunes = 2000
+)
+
+// ReviewView 复核状态与全部备注版本（按版本升序）。
+type ReviewView struct {
+	Review Review
+	Notes  []Note
+}
+
+// Actor 发起复核操作的后台操作者。
+type Actor struct {
+	OrgID          int64
+	OperatorUserID int64
+}
+
+// NoteDTO 追加一版临床备注。
+type NoteDTO struct {
+	Actor
+	TesteeID     uint64
+	AssessmentID uint64
+	Content      string
+	Addendum     string
+}
+
+// Access 校验操作者可以访问受试者及其测评。
+type Access interface {
+	AuthorizeAssessment(ctx context.Context, actor Actor, testeeID, assessmentID uint64) error
+}
+
+// Store 复核存储。
+type Store = domainreview.Repository
@@ -74,13 +74,9 @@ func (s *service) ListParticipantReports(ctx context.Context, actor Actor, q Lis
 	if err != nil {
 		return nil, queryerror.MapReadError(err)
 	}
-	items := make([]*Report, 0, len(rows))
-	for _, row := range rows {
-		item, mapErr := s.projection.FromRow(ctx, row, policy.AudienceClinician)
-		if mapErr != nil {
-			return nil, mapErr
-		}
-		items = append(items, item)
+	items, err := s.projection.FromRows(ctx, rows, policy.AudienceClinician)
+	if err != nil {
+		return nil, err
 	}
 	n := int(total)
 	return &ListResult{Items: items, Total: n, Page: page, PageSize: size, TotalPages: (n + size - 1) / size}, nil
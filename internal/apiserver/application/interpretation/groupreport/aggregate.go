@@ -0,0 +1,544 @@
+package groupreport
+
+import (
+	"math"
+	"sort"
+
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+)
+
+// pseudonymKind 风险带名单的假名命名空间，与受试者去标识导出一致，两处名单可以互相对照。
+const pseudonymKind = "testee"
+
+// normExpectedShare 正态常模人群中高于均值 1 个标准差的期望占比。
+const normExpectedShare = 0.1587
+
+// severityOrder 严重度从低到高；整体等级分布按该顺序给出全部五档。
+var severityOrder = []string{
+	string(report.RiskLevelNone),
+	string(report.RiskLevelLow),
+	string(report.RiskLevelMedium),
+	string(report.RiskLevelHigh),
+	string(report.RiskLevelSevere),
+}
+
+var severityRank = map[string]int{
+	string(report.RiskLevelNone):   0,
+	string(report.RiskLevelLow):    1,
+	string(report.RiskLevelMedium): 2,
+	string(report.RiskLevelHigh):   3,
+	string(report.RiskLevelSevere): 4,
+}
+
+var severityLabels = map[string]string{
+	string(report.RiskLevelNone):   "无风险",
+	string(report.RiskLevelLow):    "低风险",
+	string(report.RiskLevelMedium): "中风险",
+	string(report.RiskLevelHigh):   "高风险",
+	string(report.RiskLevelSevere): "严重风险",
+}
+
+// normDeviations 支持常模对照的衍生分及其标准差。
+var normDeviations = map[string]float64{
+	report.ScoreKindTScore:        10,
+	report.ScoreKindStandardScore: 15,
+}
+
+// member 一位成员在某个学期采用的测评结果。
+type member struct {
+	testeeID uint64
+	input    interpinput.InterpretationInput
+}
+
+// isAtRisk 与工作台、风险预警一致：高风险及以上视为存在风险。
+func isAtRisk(severity string) bool {
+	return severityRank[severity] >= severityRank[string(report.RiskLevelHigh)]
+}
+
+// severityOf 等级编码本身是风险等级时以编码为准（severe 的 Severity 会被归为 high），
+// 其次取等级的 Severity，最后取因子风险等级；都无法识别时返回空串。
+func severityOf(level *report.ResultLevel, risk report.RiskLevel) string {
+	if level != nil {
+		if _, ok := severityRank[level.Code]; ok {
+			return level.Code
+		}
+		if _, ok := severityRank[level.Severity]; ok {
+			return level.Severity
+		}
+	}
+	if _, ok := severityRank[string(risk)]; ok {
+		return string(risk)
+	}
+	return ""
+}
+
+// overallSeverity 取整体结果等级的严重度；没有整体等级时按最严重的因子计。
+func overallSeverity(input interpinput.InterpretationInput) string {
+	if severity := severityOf(input.Result.Level, ""); severity != "" {
+		return severity
+	}
+	worst := string(report.RiskLevelNone)
+	if input.FactorScoring == nil {
+		return worst
+	}
+	for _, factor := range input.FactorScoring.Factors {
+		if severity := severityOf(factor.Level, factor.RiskLevel); severityRank[severity] > severityRank[worst] {
+			worst = severity
+		}
+	}
+	return worst
+}
+
+// tally 一个待公布的计数单元格。pinned 表示计数已经通过其他途径（风险带名单）公开，不能作为补充抑制的对象。
+type tally struct {
+	code     string
+	label    string
+	severity string
+	count    int
+	hidden   bool
+	pinned   bool
+}
+
+// published 一组单元格的已公布合计；合计已知时，组内恰好一个单元格被抑制就能被反推。
+// 总人数总是公开，因此任何合计的补集也等同公开，twin 指向补集，二者同时抑制。
+type published struct {
+	cells    []int
+	hideable bool
+	hidden   bool
+	twin     *published
+}
+
+func (p *published) hide() {
+	p.hidden = true
+	if p.twin != nil {
+		p.twin.hidden = true
+	}
+}
+
+// withComplements 为总人数之外的每个合计补上其补集。
+func withComplements(size int, sums ...*published) []*published {
+	out := make([]*published, 0, 2*len(sums)+1)
+	total := &published{}
+	for i := 0; i < size; i++ {
+		total.cells = append(total.cells, i)
+	}
+	out = append(out, total)
+	for _, sum := range sums {
+		inside := make(map[int]bool, len(sum.cells))
+		for _, index := range sum.cells {
+			inside[index] = true
+		}
+		complement := &published{hideable: sum.hideable, hidden: sum.hidden, twin: sum}
+		for i := 0; i < size; i++ {
+			if !inside[i] {
+				complement.cells = append(complement.cells, i)
+			}
+		}
+		sum.twin = complement
+		out = append(out, sum, complement)
+	}
+	return out
+}
+
+// protect 最小单元格规则：先抑制 1 ~ minCell-1 的单元格，再对每个已公布合计做补充抑制——
+// 组内恰好一个单元格被抑制时，再抑制组内最小的另一个单元格；组内没有可抑制的单元格时改为抑制合计本身。
+func protect(cells []*tally, sums []*published, minCell int) {
+	for _, cell := range cells {
+		if cell.count > 0 && cell.count < minCell && !cell.pinned {
+			cell.hidden = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, sum := range sums {
+			if sum.hidden {
+				continue
+			}
+			hidden, candidate := 0, -1
+			for _, index := range sum.cells {
+				cell := cells[index]
+				if cell.hidden {
+					hidden++
+					continue
+				}
+				if !cell.pinned && (candidate < 0 || cell.count < cells[candidate].count) {
+					candidate = index
+				}
+			}
+			if hidden != 1 {
+				continue
+			}
+			switch {
+			case candidate >= 0:
+				cells[candidate].hidden = true
+			case sum.hideable:
+				sum.hide()
+			default:
+				continue
+			}
+			changed = true
+		}
+	}
+}
+
+// smallCell 计数或其补数落在 1 ~ minCell-1 时必须抑制。
+func smallCell(count, total, minCell int) bool {
+	return (count > 0 && count < minCell) || (total-count > 0 && total-count < minCell)
+}
+
+func newCell(count, total int, hidden bool) Cell {
+	if hidden || total == 0 {
+		return Cell{Suppressed: hidden}
+	}
+	share := ratio(count, total)
+	return Cell{Count: &count, Share: &share}
+}
+
+func ratio(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(count)/float64(total), 4)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+// aggregate 由两个学期的成员结果组装报告内容；current 按测评从新到旧排列且至少有 minCell 人，由调用方保证。
+// 模型版本、标题与因子名称以最近一次测评为准。
+func aggregate(cohort Cohort, cohortSize int, term, previousTerm Term, current, previous []member, minCell int, pseudonyms Pseudonyms) Content {
+	latest := current[0].input
+	content := Content{
+		ModelCode:    latest.Model.Code,
+		ModelVersion: latest.Model.Version,
+		ModelTitle:   latest.Model.Title,
+		Cohort: CohortSummary{
+			Kind:     cohort.Kind,
+			Ref:      cohort.Ref(),
+			Size:     cohortSize,
+			Assessed: len(current),
+			Coverage: ratio(len(current), cohortSize),
+		},
+		Term:         term,
+		PreviousTerm: previousTerm,
+		MinCellSize:  minCell,
+	}
+	bands, banded := riskBands(current, minCell, pseudonyms)
+	content.RiskBands = bands
+	content.Overall = overallSummary(current, previous, banded, minCell)
+	content.Factors = factorGroups(latest, current, previous, minCell)
+	return content
+}
+
+// bandPlan 风险带在整体等级分布中的公开方式：单一严重度的带公开了该单元格，
+// 合并带只公开合计，组内单元格必须全部抑制。
+type bandPlan struct {
+	pinned  map[string]bool
+	merged  [][]string
+	members map[string][]uint64
+}
+
+// riskBands 从严重到轻依次成带：高风险与严重风险都达到阈值时各自成带，否则合并为“高风险及以上”；
+// 二者合计仍不足阈值时再与中风险合并为“中风险及以上”。合并不跨越风险分界（高风险），
+// 以免风险带人数与整体风险占比相减反推出被抑制的单元格；无法达到阈值的剩余成员不列出。
+func riskBands(current []member, minCell int, pseudonyms Pseudonyms) ([]RiskBand, bandPlan) {
+	plan := bandPlan{pinned: map[string]bool{}, members: map[string][]uint64{}}
+	for _, m := range current {
+		severity := overallSeverity(m.input)
+		plan.members[severity] = append(plan.members[severity], m.testeeID)
+	}
+	medium, high, severe := string(report.RiskLevelMedium), string(report.RiskLevelHigh), string(report.RiskLevelSevere)
+	count := func(severities ...string) int {
+		total := 0
+		for _, severity := range severities {
+			total += len(plan.members[severity])
+		}
+		return total
+	}
+
+	var groups [][]string
+	atRiskBanded := true
+	switch {
+	case count(severe) >= minCell && count(high) >= minCell:
+		groups = append(groups, []string{severe}, []string{high})
+	case count(severe) >= minCell && count(high) == 0:
+		groups = append(groups, []string{severe})
+	case count(high) >= minCell && count(severe) == 0:
+		groups = append(groups, []string{high})
+	case count(high, severe) >= minCell:
+		groups = append(groups, []string{high, severe})
+	default:
+		atRiskBanded = count(high, severe) == 0
+	}
+	switch {
+	case atRiskBanded && count(medium) >= minCell:
+		groups = append(groups, []string{medium})
+	case !atRiskBanded && count(medium, high, severe) >= minCell:
+		groups = append(groups, []string{medium, high, severe})
+	}
+
+	bands := make([]RiskBand, 0, len(groups))
+	for _, severities := range groups {
+		if len(severities) == 1 {
+			plan.pinned[severities[0]] = true
+		} else {
+			plan.merged = append(plan.merged, severities)
+		}
+		band := RiskBand{Band: severities[0], Severities: severities}
+		if len(severities) > 1 {
+			band.Band = severities[0] + "_and_above"
+		}
+		var ids []uint64
+		for _, severity := range severities {
+			ids = append(ids, plan.members[severity]...)
+		}
+		band.Count = len(ids)
+		band.Members = make([]string, 0, len(ids))
+		for _, id := range ids {
+			band.Members = append(band.Members, pseudonyms.Pseudonym(pseudonymKind, id))
+		}
+		sort.Strings(band.Members)
+		bands = append(bands, band)
+	}
+	return bands, plan
+}
+
+// overallSummary 整体等级按严重度五档分布；风险带公开的合计同样参与补充抑制。
+func overallSummary(current, previous []member, plan bandPlan, minCell int) OverallSummary {
+	cells := make([]*tally, 0, len(severityOrder))
+	index := map[string]int{}
+	for _, severity := range severityOrder {
+		index[severity] = len(cells)
+		cells = append(cells, &tally{code: severity, label: severityLabels[severity], severity: severity, count: len(plan.members[severity]), pinned: plan.pinned[severity]})
+	}
+	atRisk := &published{hideable: true}
+	atRiskCount := 0
+	for i, cell := range cells {
+		if isAtRisk(cell.severity) {
+			atRisk.cells = append(atRisk.cells, i)
+			atRiskCount += cell.count
+		}
+	}
+	atRisk.hidden = smallCell(atRiskCount, len(current), minCell)
+	sums := []*published{atRisk}
+	for _, severities := range plan.merged {
+		band := &published{}
+		for _, severity := range severities {
+			cells[index[severity]].hidden = true
+			band.cells = append(band.cells, index[severity])
+		}
+		sums = append(sums, band)
+	}
+	protect(cells, withComplements(len(cells), sums...), minCell)
+
+	summary := OverallSummary{
+		Levels: levelCells(cells, len(current)),
+		AtRisk: newCell(atRiskCount, len(current), atRisk.hidden),
+	}
+	if len(previous) > 0 {
+		previousAtRisk := 0
+		for _, m := range previous {
+			if isAtRisk(overallSeverity(m.input)) {
+				previousAtRisk++
+			}
+		}
+		summary.Previous = compare(summary.AtRisk, nil, len(previous), previousAtRisk, nil, minCell)
+	}
+	return summary
+}
+
+func levelCells(cells []*tally, total int) []LevelCell {
+	out := make([]LevelCell, 0, len(cells))
+	for _, cell := range cells {
+		out = append(out, LevelCell{Code: cell.code, Label: cell.label, Severity: cell.severity, Cell: newCell(cell.count, total, cell.hidden)})
+	}
+	return out
+}
+
+// compare 与上一学期比较：上一学期人数不足阈值时只给出人数；风险占比与均分在两个学期都公开时才给出差值。
+func compare(current Cell, currentMean *float64, previousAssessed, previousAtRisk int, previousMean *float64, minCell int) *Comparison {
+	comparison := &Comparison{Assessed: previousAssessed}
+	if previousAssessed < minCell {
+		comparison.Suppressed = true
+		return comparison
+	}
+	if !smallCell(previousAtRisk, previousAssessed, minCell) {
+		share := ratio(previousAtRisk, previousAssessed)
+		comparison.AtRiskShare = &share
+		if current.Share != nil {
+			delta := round(*current.Share-share, 4)
+			comparison.AtRiskDelta = &delta
+		}
+	}
+	if previousMean != nil {
+		comparison.MeanScore = previousMean
+		if currentMean != nil {
+			delta := round(*currentMean-*previousMean, 2)
+			comparison.MeanDelta = &delta
+		}
+	}
+	return comparison
+}
+
+// factorStats 一个学期内单个因子的原始计数。
+type factorStats struct {
+	code, name   string
+	isTotal      bool
+	sortOrder    int
+	assessed     int
+	sum          float64
+	levels       map[string]*tally
+	levelOrder   []string
+	atRisk       int
+	normKind     string
+	benchmark    float64
+	normAssessed int
+	normAbove    int
+}
+
+func collectFactors(members []member) map[string]*factorStats {
+	stats := map[string]*factorStats{}
+	for _, m := range members {
+		if m.input.FactorScoring == nil {
+			continue
+		}
+		for _, factor := range m.input.FactorScoring.Factors {
+			s, ok := stats[factor.FactorCode]
+			if !ok {
+				s = &factorStats{code: factor.FactorCode, name: factor.FactorName, isTotal: factor.IsTotalScore, sortOrder: factor.SortOrder, levels: map[string]*tally{}}
+				stats[factor.FactorCode] = s
+			}
+			s.assessed++
+			s.sum += factor.RawScore
+			severity := severityOf(factor.Level, factor.RiskLevel)
+			if severity == "" {
+				severity = string(report.RiskLevelNone)
+			}
+			levelCode, label := severity, severityLabels[severity]
+			if factor.Level != nil && factor.Level.Code != "" {
+				levelCode, label = factor.Level.Code, factor.Level.Label
+				if label == "" || label == levelCode {
+					if known, ok := severityLabels[levelCode]; ok {
+						label = known
+					}
+				}
+			}
+			cell, ok := s.levels[levelCode]
+			if !ok {
+				cell = &tally{code: levelCode, label: label, severity: severity}
+				s.levels[levelCode] = cell
+				s.levelOrder = append(s.levelOrder, levelCode)
+			}
+			cell.count++
+			if isAtRisk(severity) {
+				s.atRisk++
+			}
+			if ref := factor.NormReference; ref != nil {
+				deviation, supported := normDeviations[ref.ScoreKind]
+				for _, score := range factor.DerivedScores {
+					if !supported || score.Kind != ref.ScoreKind {
+						continue
+					}
+					if s.normKind == "" {
+						s.normKind, s.benchmark = ref.ScoreKind, ref.Benchmark
+					}
+					s.normAssessed++
+					if score.Value >= ref.Benchmark+deviation {
+						s.normAbove++
+					}
+					break
+				}
+			}
+		}
+	}
+	return stats
+}
+
+func (s *factorStats) mean(minCell int) *float64 {
+	if s == nil || s.assessed < minCell {
+		return nil
+	}
+	mean := round(s.sum/float64(s.assessed), 2)
+	return &mean
+}
+
+// factorGroups 按因子汇总；因子顺序沿用模型定义（总分在前）。
+func factorGroups(latest interpinput.InterpretationInput, current, previous []member, minCell int) []FactorGroup {
+	currentStats := collectFactors(current)
+	previousStats := collectFactors(previous)
+	ordered := make([]*factorStats, 0, len(currentStats))
+	for _, s := range currentStats {
+		ordered = append(ordered, s)
+	}
+	titles := map[string]string{}
+	if latest.FactorScoring != nil && latest.FactorScoring.Model != nil {
+		for _, factor := range latest.FactorScoring.Model.Factors {
+			titles[factor.Code] = factor.Title
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].isTotal != ordered[j].isTotal {
+			return ordered[i].isTotal
+		}
+		if ordered[i].sortOrder != ordered[j].sortOrder {
+			return ordered[i].sortOrder < ordered[j].sortOrder
+		}
+		return ordered[i].code < ordered[j].code
+	})
+
+	groups := make([]FactorGroup, 0, len(ordered))
+	for _, s := range ordered {
+		group := FactorGroup{FactorCode: s.code, FactorName: s.name, IsTotalScore: s.isTotal, Assessed: s.assessed}
+		if title := titles[s.code]; title != "" {
+			group.FactorName = title
+		}
+		if s.assessed < minCell {
+			// 因子只在少数成员的结果中出现：不公布任何分布。
+			group.Levels = []LevelCell{}
+			group.AtRisk = Cell{Suppressed: true}
+			groups = append(groups, group)
+			continue
+		}
+		group.MeanScore = s.mean(minCell)
+
+		sort.SliceStable(s.levelOrder, func(i, j int) bool {
+			a, b := s.levels[s.levelOrder[i]], s.levels[s.levelOrder[j]]
+			if severityRank[a.severity] != severityRank[b.severity] {
+				return severityRank[a.severity] < severityRank[b.severity]
+			}
+			return a.code < b.code
+		})
+		cells := make([]*tally, 0, len(s.levelOrder))
+		atRisk := &published{hideable: true, hidden: smallCell(s.atRisk, s.assessed, minCell)}
+		for i, levelCode := range s.levelOrder {
+			cell := s.levels[levelCode]
+			cells = append(cells, cell)
+			if isAtRisk(cell.severity) {
+				atRisk.cells = append(atRisk.cells, i)
+			}
+		}
+		protect(cells, withComplements(len(cells), atRisk), minCell)
+		group.Levels = levelCells(cells, s.assessed)
+		group.AtRisk = newCell(s.atRisk, s.assessed, atRisk.hidden)
+
+		if s.normAssessed > 0 {
+			norm := &NormCell{
+				ScoreKind:     s.normKind,
+				Benchmark:     s.benchmark,
+				Threshold:     s.benchmark + normDeviations[s.normKind],
+				ExpectedShare: normExpectedShare,
+				Assessed:      s.normAssessed,
+			}
+			norm.Cell = newCell(s.normAbove, s.normAssessed, s.normAssessed < minCell || smallCell(s.normAbove, s.normAssessed, minCell))
+			group.Norm = norm
+		}
+		if prev := previousStats[s.code]; prev != nil {
+			group.Previous = compare(group.AtRisk, group.MeanScore, prev.assessed, prev.atRisk, prev.mean(minCell), minCell)
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
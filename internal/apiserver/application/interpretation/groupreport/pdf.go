@@ -0,0 +1,356 @@
+package groupreport
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/pkg/pdfdoc"
+)
+
+const (
+	defaultPrimaryColor = "#1F6FEB"
+
+	marginX       = 48.0
+	contentWidth  = pdfdoc.PageWidth - 2*marginX
+	contentTop    = 96.0
+	continuedTop  = 48.0
+	contentBottom = pdfdoc.PageHeight - 72.0
+
+	bodySize   = 10.5
+	bodyLeader = 16.0
+	rowHeight  = 20.0
+
+	// suppressedMark 被最小单元格规则抑制的数值在表格中的占位符。
+	suppressedMark = "*"
+)
+
+var (
+	textGray  = pdfdoc.Color{R: 96, G: 96, B: 96}
+	lineGray  = pdfdoc.Color{R: 210, G: 210, B: 210}
+	trackGray = pdfdoc.Color{R: 236, G: 238, B: 241}
+)
+
+var cohortKindLabels = map[CohortKind]string{
+	CohortEntry:   "测评入口",
+	CohortPlan:    "测评计划",
+	CohortTag:     "标签",
+	CohortTestees: "指定受试者",
+}
+
+// Render 将群体报告排版为分页 PDF：概览、整体等级分布、因子汇总（风险占比、常模对照、与上学期比较）、
+// 各因子等级分布与风险带假名名单；页脚注明最小单元格阈值与报告溯源信息。
+func Render(report *Report, branding reportpdf.Branding) []byte {
+	primary, ok := pdfdoc.ParseHexColor(branding.PrimaryColor)
+	if !ok {
+		primary, _ = pdfdoc.ParseHexColor(defaultPrimaryColor)
+	}
+	content := report.Content
+	title := content.ModelTitle
+	if title == "" {
+		title = content.ModelCode
+	}
+	title += " 群体报告"
+	l := &layout{doc: pdfdoc.New(title), primary: primary}
+	l.firstPage(branding)
+
+	l.heading(title)
+	cohort := cohortKindLabels[content.Cohort.Kind]
+	if content.Cohort.Ref != "" {
+		cohort += " " + content.Cohort.Ref
+	}
+	l.muted(fmt.Sprintf("群体 %s    学期 %s    对比学期 %s", cohort, formatTerm(content.Term), formatTerm(content.PreviousTerm)))
+	l.muted("生成时间 " + report.GeneratedAt.Format("2006-01-02 15:04"))
+	l.gap(8)
+	l.summary(content)
+
+	l.section("整体等级分布")
+	l.distribution(content.Overall.Levels)
+	if previous := content.Overall.Previous; previous != nil {
+		l.paragraph("与上学期比较："+comparisonText(previous), textGray)
+	}
+
+	l.section("因子汇总")
+	l.factorTable(content.Factors)
+	for _, factor := range content.Factors {
+		if len(factor.Levels) == 0 {
+			continue
+		}
+		l.subheading(fmt.Sprintf("%s（%d 人）", factorName(factor), factor.Assessed))
+		l.distribution(factor.Levels)
+	}
+
+	l.section("风险带名单")
+	if len(content.RiskBands) == 0 {
+		l.paragraph("没有达到最小单元格阈值的风险带。", textGray)
+	}
+	for _, band := range content.RiskBands {
+		labels := make([]string, 0, len(band.Severities))
+		for _, severity := range band.Severities {
+			labels = append(labels, severityLabels[severity])
+		}
+		l.subheading(fmt.Sprintf("%s（%d 人）", strings.Join(labels, "、"), band.Count))
+		l.paragraph(strings.Join(band.Members, "  "), pdfdoc.Black)
+	}
+	l.gap(6)
+	l.paragraph(fmt.Sprintf("注：人数少于 %d 的数值以 %s 表示，不予公布；名单以假名列出，可与受试者去标识导出对照。", content.MinCellSize, suppressedMark), textGray)
+
+	l.footers(report, branding)
+	return l.doc.Bytes()
+}
+
+type layout struct {
+	doc     *pdfdoc.Document
+	page    *pdfdoc.Page
+	primary pdfdoc.Color
+	y       float64
+}
+
+func (l *layout) firstPage(branding reportpdf.Branding) {
+	l.page = l.doc.AddPage()
+	l.page.FillRect(0, 0, pdfdoc.PageWidth, 64, l.primary)
+	orgName := branding.OrgName
+	if orgName == "" {
+		orgName = "群体测评报告"
+	}
+	l.page.Text(marginX, 40, 16, pdfdoc.White, orgName)
+	label := "群体测评报告"
+	l.page.Text(pdfdoc.PageWidth-marginX-pdfdoc.TextWidth(label, 11), 40, 11, pdfdoc.White, label)
+	l.y = contentTop
+}
+
+// ensure 剩余高度不足时换页；续页只保留细条页眉。
+func (l *layout) ensure(height float64) {
+	if l.y+height <= contentBottom {
+		return
+	}
+	l.page = l.doc.AddPage()
+	l.page.FillRect(0, 0, pdfdoc.PageWidth, 8, l.primary)
+	l.y = continuedTop
+}
+
+func (l *layout) gap(height float64) {
+	l.y += height
+}
+
+func (l *layout) heading(text string) {
+	for _, line := range pdfdoc.Wrap(text, 18, contentWidth) {
+		l.ensure(27)
+		l.y += 21.6
+		l.page.Text(marginX, l.y, 18, pdfdoc.Black, line)
+		l.y += 5.4
+	}
+}
+
+func (l *layout) subheading(text string) {
+	l.ensure(bodyLeader * 2)
+	l.y += bodyLeader + 4
+	l.page.Text(marginX, l.y, 11, l.primary, text)
+}
+
+func (l *layout) muted(text string) {
+	l.ensure(bodyLeader)
+	l.y += bodyLeader
+	l.page.Text(marginX, l.y, 9, textGray, text)
+}
+
+func (l *layout) section(title string) {
+	l.ensure(40)
+	l.y += 28
+	l.page.FillRect(marginX, l.y-11, 3, 13, l.primary)
+	l.page.Text(marginX+8, l.y, 13, pdfdoc.Black, title)
+	l.y += 4
+}
+
+func (l *layout) paragraph(text string, color pdfdoc.Color) {
+	for _, line := range pdfdoc.Wrap(text, bodySize, contentWidth) {
+		l.ensure(bodyLeader)
+		l.y += bodyLeader
+		l.page.Text(marginX, l.y, bodySize, color, line)
+	}
+}
+
+// summary 概览卡片：群体人数、参测人数与覆盖率、整体风险占比。
+func (l *layout) summary(content Content) {
+	l.ensure(56)
+	top := l.y + 8
+	l.page.FillRect(marginX, top, contentWidth, 44, trackGray)
+	items := []struct{ label, value string }{
+		{"群体人数", strconv.Itoa(content.Cohort.Size)},
+		{"本学期参测", fmt.Sprintf("%d（%s）", content.Cohort.Assessed, percent(content.Cohort.Coverage))},
+		{"存在风险", cellText(content.Overall.AtRisk)},
+	}
+	x := marginX + 14
+	for _, item := range items {
+		l.page.Text(x, top+18, 9, textGray, item.label)
+		l.page.Text(x, top+36, 14, pdfdoc.Black, item.value)
+		x += contentWidth / float64(len(items))
+	}
+	l.y = top + 44
+}
+
+// distribution 等级分布条形图：按占比绘制，被抑制的等级只显示占位符。
+func (l *layout) distribution(levels []LevelCell) {
+	const (
+		labelWidth = 130.0
+		valueWidth = 90.0
+		barHeight  = 10.0
+	)
+	barWidth := contentWidth - labelWidth - valueWidth
+	l.gap(4)
+	for _, level := range levels {
+		l.ensure(rowHeight)
+		label := level.Label
+		if label == "" {
+			label = level.Code
+		}
+		l.page.Text(marginX, l.y+14, bodySize, pdfdoc.Black, truncate(label, bodySize, labelWidth-8))
+		barX := marginX + labelWidth
+		l.page.FillRect(barX, l.y+5, barWidth, barHeight, trackGray)
+		if level.Share != nil && *level.Share > 0 {
+			l.page.FillRect(barX, l.y+5, barWidth*math.Min(*level.Share, 1), barHeight, l.primary)
+		}
+		l.page.Text(barX+barWidth+8, l.y+14, 9, textGray, cellText(level.Cell))
+		l.y += rowHeight
+	}
+}
+
+// factorTable 因子汇总表：参测人数、平均分、风险占比、常模对照与上学期风险占比变化。
+func (l *layout) factorTable(factors []FactorGroup) {
+	columns := []struct {
+		title string
+		width float64
+	}{{"因子", 130}, {"人数", 50}, {"平均分", 60}, {"存在风险", 90}, {"高于常模 1SD", 90}, {"较上学期", 79.28}}
+	l.ensure(rowHeight * 2)
+	x := marginX
+	for _, column := range columns {
+		l.page.Text(x, l.y+14, 9, textGray, column.title)
+		x += column.width
+	}
+	l.y += rowHeight
+	l.page.Line(marginX, l.y, marginX+contentWidth, l.y, 0.5, lineGray)
+	for _, factor := range factors {
+		l.ensure(rowHeight)
+		values := []string{
+			factorName(factor),
+			strconv.Itoa(factor.Assessed),
+			optionalNumber(factor.MeanScore),
+			cellText(factor.AtRisk),
+			"-",
+			"-",
+		}
+		if norm := factor.Norm; norm != nil {
+			values[4] = fmt.Sprintf("%s / 期望 %s", shareText(norm.Cell), percent(norm.ExpectedShare))
+		}
+		if previous := factor.Previous; previous != nil {
+			values[5] = deltaText(previous)
+		}
+		x := marginX
+		for i, column := range columns {
+			l.page.Text(x, l.y+14, 9, pdfdoc.Black, truncate(values[i], 9, column.width-6))
+			x += column.width
+		}
+		l.y += rowHeight
+	}
+}
+
+// footers 在所有页面写阈值说明、溯源页脚与页码；必须在正文排版完成、总页数确定后调用。
+func (l *layout) footers(report *Report, branding reportpdf.Branding) {
+	total := l.doc.PageCount()
+	model := report.Content.ModelCode
+	if report.Content.ModelVersion != "" {
+		model += "@" + report.Content.ModelVersion
+	}
+	line1 := fmt.Sprintf("模型 %s    报告 %d    最小单元格 %d", model, report.ID, report.MinCellSize)
+	line2 := "输入指纹 sha256:" + report.InputFingerprint
+	footY := pdfdoc.PageHeight - 48
+	for index := 0; index < total; index++ {
+		page := l.doc.Page(index)
+		page.Line(marginX, footY-12, pdfdoc.PageWidth-marginX, footY-12, 0.5, lineGray)
+		page.Text(marginX, footY, 7.5, textGray, line1)
+		page.Text(marginX, footY+11, 7.5, textGray, line2)
+		if branding.Footnote != "" {
+			page.Text(marginX, footY+22, 7.5, textGray, truncate(branding.Footnote, 7.5, contentWidth-70))
+		}
+		number := fmt.Sprintf("第 %d / %d 页", index+1, total)
+		page.Text(pdfdoc.PageWidth-marginX-pdfdoc.TextWidth(number, 8), footY+22, 8, textGray, number)
+	}
+}
+
+func factorName(factor FactorGroup) string {
+	if factor.FactorName != "" {
+		return factor.FactorName
+	}
+	return factor.FactorCode
+}
+
+func cellText(cell Cell) string {
+	if cell.Count == nil {
+		return suppressedMark
+	}
+	return fmt.Sprintf("%d（%s）", *cell.Count, percent(*cell.Share))
+}
+
+func shareText(cell Cell) string {
+	if cell.Share == nil {
+		return suppressedMark
+	}
+	return percent(*cell.Share)
+}
+
+func comparisonText(previous *Comparison) string {
+	if previous.Suppressed || previous.AtRiskShare == nil {
+		return fmt.Sprintf("上学期参测 %d 人，风险占比 %s", previous.Assessed, suppressedMark)
+	}
+	text := fmt.Sprintf("上学期参测 %d 人，风险占比 %s", previous.Assessed, percent(*previous.AtRiskShare))
+	if previous.AtRiskDelta != nil {
+		text += "，变化 " + signedPercent(*previous.AtRiskDelta)
+	}
+	return text
+}
+
+func deltaText(previous *Comparison) string {
+	if previous.AtRiskDelta == nil {
+		return suppressedMark
+	}
+	return signedPercent(*previous.AtRiskDelta)
+}
+
+func formatTerm(term Term) string {
+	if term.From.IsZero() {
+		return "-"
+	}
+	// 时间窗右开，展示为包含的最后一天。
+	return term.From.Format("2006-01-02") + " ~ " + term.To.Add(-1).Format("2006-01-02")
+}
+
+func percent(share float64) string {
+	return strconv.FormatFloat(math.Round(share*1000)/10, 'f', -1, 64) + "%"
+}
+
+func signedPercent(delta float64) string {
+	if delta > 0 {
+		return "+" + percent(delta)
+	}
+	return percent(delta)
+}
+
+func optionalNumber(value *float64) string {
+	if value == nil {
+		return suppressedMark
+	}
+	return strconv.FormatFloat(math.Round(*value*100)/100, 'f', -1, 64)
+}
+
+func truncate(text string, size, width float64) string {
+	if pdfdoc.TextWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfdoc.TextWidth(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,350 @@
+package groupreport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	outcomeinput "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	domainAssessment "github.com/FangcunMount/qs-server/internal/apiserver/domain/evaluation/assessment"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	// DefaultMinCellSize 默认最小单元格。
+	DefaultMinCellSize = 5
+	// DefaultMaxCohortSize 默认群体人数上限。
+	DefaultMaxCohortSize = 5000
+	// MaxTermSpan 单个学期时间窗的最长跨度。
+	MaxTermSpan = 366 * 24 * time.Hour
+
+	assessmentPageSize = 100
+)
+
+// Service 群体报告用例。
+type Service interface {
+	// Generate 按群体与学期生成报告；相同输入已生成过时返回已有报告。
+	Generate(ctx context.Context, actor Actor, request Request) (*Report, error)
+	// Get 返回本机构的一份群体报告。
+	Get(ctx context.Context, actor Actor, id uint64) (*Report, error)
+	// RenderPDF 把本机构的一份群体报告排版为 PDF。
+	RenderPDF(ctx context.Context, actor Actor, id uint64) (*Report, []byte, error)
+}
+
+type service struct {
+	store       Store
+	cohorts     CohortResolver
+	assessments evaluationreadmodel.AssessmentReader
+	facts       evaluationfact.Repository
+	pseudonyms  Pseudonyms
+	branding    reportpdf.BrandingResolver
+	config      Config
+	now         func() time.Time
+}
+
+// NewService 创建群体报告服务；Config 中未设置的阈值使用默认值。
+func NewService(
+	store Store,
+	cohorts CohortResolver,
+	assessments evaluationreadmodel.AssessmentReader,
+	facts evaluationfact.Repository,
+	pseudonyms Pseudonyms,
+	branding reportpdf.BrandingResolver,
+	config Config,
+) Service {
+	if config.MinCellSize <= 0 {
+		config.MinCellSize = DefaultMinCellSize
+	}
+	if config.MaxCohortSize <= 0 {
+		config.MaxCohortSize = DefaultMaxCohortSize
+	}
+	return &service{
+		store:       store,
+		cohorts:     cohorts,
+		assessments: assessments,
+		facts:       facts,
+		pseudonyms:  pseudonyms,
+		branding:    branding,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+func (s *service) Generate(ctx context.Context, actor Actor, request Request) (*Report, error) {
+	previousTerm, err := normalizeRequest(&request)
+	if err != nil {
+		return nil, err
+	}
+	if request.Cohort.Kind == CohortTestees && len(request.Cohort.TesteeIDs) > s.config.MaxCohortSize {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "cohort has more than %d testees; split it into smaller cohorts", s.config.MaxCohortSize)
+	}
+	testees, err := s.cohorts.ResolveTestees(ctx, actor.OrgID, request.Cohort, s.config.MaxCohortSize+1)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "resolve cohort")
+	}
+	if len(testees) > s.config.MaxCohortSize {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "cohort has more than %d testees; split it into smaller cohorts", s.config.MaxCohortSize)
+	}
+	minCell := s.config.MinCellSize
+	if len(testees) < minCell {
+		return nil, cberrors.WithCode(code.ErrGroupReportCohortTooSmall, "cohort has %d testees, fewer than the minimum cell size %d", len(testees), minCell)
+	}
+
+	current, currentFrozen, err := s.collect(ctx, actor.OrgID, testees, request.ModelCode, request.Term)
+	if err != nil {
+		return nil, err
+	}
+	if len(current) < minCell {
+		return nil, cberrors.WithCode(code.ErrGroupReportCohortTooSmall, "%d cohort members have outcomes in the term, fewer than the minimum cell size %d", len(current), minCell)
+	}
+	previous, previousFrozen, err := s.collect(ctx, actor.OrgID, testees, request.ModelCode, previousTerm)
+	if err != nil {
+		return nil, err
+	}
+	frozen := FrozenInput{CohortSize: len(testees), Current: currentFrozen, Previous: previousFrozen}
+	fingerprint, err := Fingerprint(request.Cohort, request.ModelCode, request.Term, previousTerm, minCell, frozen)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "fingerprint group report input")
+	}
+	existing, err := s.store.FindByFingerprint(ctx, actor.OrgID, fingerprint)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load group report")
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	saved, err := s.store.Save(ctx, &Report{
+		OrgID:            actor.OrgID,
+		CohortKind:       request.Cohort.Kind,
+		CohortRef:        request.Cohort.Ref(),
+		ModelCode:        request.ModelCode,
+		TermFrom:         request.Term.From,
+		TermTo:           request.Term.To,
+		MinCellSize:      minCell,
+		InputFingerprint: fingerprint,
+		Input:            frozen,
+		Content:          aggregate(request.Cohort, len(testees), request.Term, previousTerm, current, previous, minCell, s.pseudonyms),
+		GeneratedBy:      actor.OperatorUserID,
+		GeneratedAt:      s.now(),
+	})
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "save group report")
+	}
+	logger.L(ctx).Infow("group report generated",
+		"action", "generate_group_report",
+		"org_id", saved.OrgID,
+		"report_id", saved.ID,
+		"cohort_kind", string(saved.CohortKind),
+		"cohort_size", len(testees),
+		"assessed", len(current),
+		"previous_assessed", len(previous),
+	)
+	return saved, nil
+}
+
+func (s *service) Get(ctx context.Context, actor Actor, id uint64) (*Report, error) {
+	if id == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "report id is required")
+	}
+	report, err := s.store.FindByID(ctx, id)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load group report")
+	}
+	// 其他机构的报告与不存在同样处理。
+	if report == nil || report.OrgID != actor.OrgID {
+		return nil, cberrors.WithCode(code.ErrGroupReportNotFound, "group report not found")
+	}
+	return report, nil
+}
+
+func (s *service) RenderPDF(ctx context.Context, actor Actor, id uint64) (*Report, []byte, error) {
+	report, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var branding reportpdf.Branding
+	if s.branding != nil {
+		branding = s.branding.Branding(report.OrgID)
+	}
+	return report, Render(report, branding), nil
+}
+
+// normalizeRequest 校验请求并返回对比学期；未指定时取紧邻本学期之前的等长时间窗。
+func normalizeRequest(request *Request) (Term, error) {
+	request.ModelCode = strings.TrimSpace(request.ModelCode)
+	if request.ModelCode == "" {
+		return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "model_code is required")
+	}
+	cohort := &request.Cohort
+	switch cohort.Kind {
+	case CohortEntry:
+		if cohort.EntryID == 0 {
+			return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "entry_id is required for an entry cohort")
+		}
+	case CohortPlan:
+		if cohort.PlanID == 0 {
+			return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "plan_id is required for a plan cohort")
+		}
+	case CohortTag:
+		cohort.Tag = strings.TrimSpace(cohort.Tag)
+		if cohort.Tag == "" {
+			return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "tag is required for a tag cohort")
+		}
+	case CohortTestees:
+		cohort.TesteeIDs = uniqueIDs(cohort.TesteeIDs)
+		if len(cohort.TesteeIDs) == 0 {
+			return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "testee_ids are required for an explicit cohort")
+		}
+	default:
+		return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "unsupported cohort kind %q", string(cohort.Kind))
+	}
+	if err := validateTerm(request.Term, "term"); err != nil {
+		return Term{}, err
+	}
+	if request.PreviousTerm == nil {
+		span := request.Term.To.Sub(request.Term.From)
+		return Term{From: request.Term.From.Add(-span), To: request.Term.From}, nil
+	}
+	previous := *request.PreviousTerm
+	if err := validateTerm(previous, "previous_term"); err != nil {
+		return Term{}, err
+	}
+	if previous.To.After(request.Term.From) {
+		return Term{}, cberrors.WithCode(code.ErrInvalidArgument, "previous_term must end before term starts")
+	}
+	return previous, nil
+}
+
+func validateTerm(term Term, name string) error {
+	if term.From.IsZero() || term.To.IsZero() || !term.To.After(term.From) {
+		return cberrors.WithCode(code.ErrInvalidArgument, "%s must have from before to", name)
+	}
+	if term.To.Sub(term.From) > MaxTermSpan {
+		return cberrors.WithCode(code.ErrInvalidArgument, "%s must not span more than 366 days", name)
+	}
+	return nil
+}
+
+// collect 取每位成员在时间窗内该模型最近一次已完成的测评结果；结果按测评从新到旧排列，冻结快照按测评 ID 升序。
+// 测评已完成但结果尚未提交的成员本次不计入。
+func (s *service) collect(ctx context.Context, orgID int64, testees []uint64, modelCode string, term Term) ([]member, []FrozenOutcome, error) {
+	filter := evaluationreadmodel.AssessmentFilter{
+		OrgID:                 orgID,
+		AccessibleTesteeIDs:   testees,
+		RestrictToAccessScope: true,
+		Statuses:              []string{string(domainAssessment.StatusEvaluated)},
+		ModelCode:             modelCode,
+		DateFrom:              &term.From,
+		DateTo:                &term.To,
+	}
+	seen := make(map[uint64]bool, len(testees))
+	var selected []evaluationreadmodel.AssessmentRow
+	for page := 1; ; page++ {
+		rows, total, err := s.assessments.ListAssessments(ctx, filter, evaluationreadmodel.PageRequest{Page: page, PageSize: assessmentPageSize})
+		if err != nil {
+			return nil, nil, cberrors.WrapC(err, code.ErrDatabase, "list cohort assessments")
+		}
+		for _, row := range rows {
+			if seen[row.TesteeID] {
+				continue
+			}
+			seen[row.TesteeID] = true
+			selected = append(selected, row)
+		}
+		if len(rows) < assessmentPageSize || int64(page*assessmentPageSize) >= total {
+			break
+		}
+	}
+
+	members := make([]member, 0, len(selected))
+	frozen := make([]FrozenOutcome, 0, len(selected))
+	pending := 0
+	for _, row := range selected {
+		record, err := s.facts.FindByAssessmentID(ctx, meta.FromUint64(row.ID))
+		if stderrors.Is(err, evaluationfact.ErrNotFound) || (err == nil && record == nil) {
+			pending++
+			continue
+		}
+		if err != nil {
+			return nil, nil, cberrors.WrapC(err, code.ErrDatabase, "load evaluation outcome")
+		}
+		input, err := outcomeinput.FromOutcomeRecord(record)
+		if err != nil {
+			return nil, nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "restore outcome of assessment %d", row.ID)
+		}
+		if input.FactorScoring == nil {
+			return nil, nil, cberrors.WithCode(code.ErrGroupReportUnsupported, "assessment model %s has no factor scores", input.Model.Code)
+		}
+		members = append(members, member{testeeID: row.TesteeID, input: input})
+		frozen = append(frozen, FrozenOutcome{
+			AssessmentID: record.AssessmentID().String(),
+			OutcomeID:    record.ID().String(),
+			VersionToken: record.VersionToken(),
+		})
+	}
+	if pending > 0 {
+		logger.L(ctx).Warnw("group report skipped assessments without committed outcomes",
+			"action", "generate_group_report",
+			"org_id", orgID,
+			"pending", pending,
+		)
+	}
+	sort.Slice(frozen, func(i, j int) bool {
+		a, _ := strconv.ParseUint(frozen[i].AssessmentID, 10, 64)
+		b, _ := strconv.ParseUint(frozen[j].AssessmentID, 10, 64)
+		return a < b
+	})
+	return members, frozen, nil
+}
+
+// Fingerprint 群体定义、学期、阈值与冻结输入的 SHA-256；用于判断是否已按相同输入生成过报告。
+func Fingerprint(cohort Cohort, modelCode string, term, previousTerm Term, minCell int, frozen FrozenInput) (string, error) {
+	payload, err := json.Marshal(struct {
+		CohortKind   CohortKind  `json:"cohort_kind"`
+		CohortRef    string      `json:"cohort_ref"`
+		ModelCode    string      `json:"model_code"`
+		Term         Term        `json:"term"`
+		PreviousTerm Term        `json:"previous_term"`
+		MinCellSize  int         `json:"min_cell_size"`
+		Input        FrozenInput `json:"input"`
+	}{cohort.Kind, cohort.Ref(), modelCode, utcTerm(term), utcTerm(previousTerm), minCell, frozen})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func utcTerm(term Term) Term {
+	return Term{From: term.From.UTC(), To: term.To.UTC()}
+}
+
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	out := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
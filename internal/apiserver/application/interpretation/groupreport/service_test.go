@@ -0,0 +1,425 @@
+package groupreport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationinput"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type fakeStore struct{ reports []*Report }
+
+func (s *fakeStore) Save(_ context.Context, report *Report) (*Report, error) {
+	if existing, _ := s.FindByFingerprint(context.Background(), report.OrgID, report.InputFingerprint); existing != nil {
+		return existing, nil
+	}
+	saved := *report
+	saved.ID = uint64(len(s.reports) + 1)
+	s.reports = append(s.reports, &saved)
+	return &saved, nil
+}
+
+func (s *fakeStore) FindByFingerprint(_ context.Context, orgID int64, fingerprint string) (*Report, error) {
+	for _, report := range s.reports {
+		if report.OrgID == orgID && report.InputFingerprint == fingerprint {
+			return report, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) FindByID(_ context.Context, id uint64) (*Report, error) {
+	for _, report := range s.reports {
+		if report.ID == id {
+			return report, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeCohorts map[string][]uint64
+
+func (f fakeCohorts) ResolveTestees(_ context.Context, orgID int64, cohort Cohort, limit int) ([]uint64, error) {
+	if orgID != 7 {
+		return nil, nil
+	}
+	ids := f[string(cohort.Kind)+":"+cohort.Ref()]
+	if cohort.Kind == CohortTestees {
+		ids = cohort.TesteeIDs
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+type fakeAssessments []evaluationreadmodel.AssessmentRow
+
+func (f fakeAssessments) GetAssessment(context.Context, uint64) (*evaluationreadmodel.AssessmentRow, error) {
+	return nil, nil
+}
+
+func (f fakeAssessments) GetAssessmentByAnswerSheetID(context.Context, uint64) (*evaluationreadmodel.AssessmentRow, error) {
+	return nil, nil
+}
+
+func (f fakeAssessments) ListAssessments(_ context.Context, filter evaluationreadmodel.AssessmentFilter, page evaluationreadmodel.PageRequest) ([]evaluationreadmodel.AssessmentRow, int64, error) {
+	allowed := map[uint64]bool{}
+	for _, id := range filter.AccessibleTesteeIDs {
+		allowed[id] = true
+	}
+	var matched []evaluationreadmodel.AssessmentRow
+	for _, row := range f {
+		if row.OrgID != filter.OrgID || !allowed[row.TesteeID] ||
+			row.SubmittedAt.Before(*filter.DateFrom) || !row.SubmittedAt.Before(*filter.DateTo) {
+			continue
+		}
+		matched = append(matched, row)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID > matched[j].ID })
+	start := page.Offset()
+	if start >= len(matched) {
+		return nil, int64(len(matched)), nil
+	}
+	end := min(start+page.Limit(), len(matched))
+	return matched[start:end], int64(len(matched)), nil
+}
+
+type fakeFacts map[uint64]*evaluationfact.Record
+
+func (f fakeFacts) FindByID(context.Context, meta.ID) (*evaluationfact.Record, error) {
+	return nil, evaluationfact.ErrNotFound
+}
+
+func (f fakeFacts) FindByAssessmentID(_ context.Context, assessmentID meta.ID) (*evaluationfact.Record, error) {
+	if record, ok := f[assessmentID.Uint64()]; ok {
+		return record, nil
+	}
+	return nil, evaluationfact.ErrNotFound
+}
+
+type fakePseudonyms struct{}
+
+func (fakePseudonyms) Pseudonym(kind string, id uint64) string {
+	return fmt.Sprintf("%s_%d", kind, id)
+}
+
+type fixedBranding struct{}
+
+func (fixedBranding) Branding(int64) reportpdf.Branding {
+	return reportpdf.Branding{OrgName: "实验中学", PrimaryColor: "#0B7A75"}
+}
+
+var (
+	termStart = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	termEnd   = time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// screening 一次已完成的测评：整体等级、总分，以及情绪因子的 T 分与等级。
+type screening struct {
+	assessmentID, testeeID uint64
+	at                     time.Time
+	level                  string
+	total                  float64
+	moodT                  float64
+	moodLevel              string
+}
+
+func outcomeRecord(t *testing.T, s screening) *evaluationfact.Record {
+	t.Helper()
+	reportInput, err := evaluationinput.MarshalReportInput(evaluationinput.ReportInputFreezeOptions{
+		Assets: &interpretationassets.Assets{
+			ReportSpec: interpretationassets.ReportSpec{Sections: []interpretationassets.ReportSection{{
+				Code: "mht_scores", Kind: "factor_scores", SourceRefs: []string{"total", "mood"}, TemplateID: "standard", TemplateVersion: "legacy-v1",
+			}}},
+		},
+		ModelRef: evaluationinput.ModelRef{
+			Kind: evaluationinput.EvaluationModelKindScale, Algorithm: string(modelcatalog.AlgorithmScaleDefault), Code: "MHT", Version: "1.0.0", Title: "心理健康诊断测验",
+		},
+		DecisionKind: modelcatalog.DecisionKindScoreRange,
+		FactorCatalog: []evaluationinput.FactorCatalogEntry{
+			{Code: "total", Title: "总分", IsTotalScore: true},
+			{Code: "mood", Title: "情绪"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return evaluationfact.NewRecord(evaluationfact.NewRecordInput{
+		ID: meta.FromUint64(s.assessmentID + 1000), OrgID: 7, AssessmentID: meta.FromUint64(s.assessmentID), TesteeID: s.testeeID, RunID: fmt.Sprintf("%d:1", s.assessmentID),
+		Model: evaluationfact.ModelIdentity{
+			Kind: modelcatalog.KindScale, Algorithm: modelcatalog.AlgorithmScaleDefault, Code: "MHT", Version: "1.0.0", Title: "心理健康诊断测验",
+		},
+		Runtime:       evaluationfact.RuntimeIdentity{DecisionKind: modelcatalog.DecisionKindScoreRange},
+		SchemaVersion: 2, EvaluatedAt: s.at, ReportInput: reportInput,
+		Payload: []byte(fmt.Sprintf(`{
+			"Primary":{"Kind":"raw_total","Value":%g},
+			"Level":{"Code":%q},
+			"Dimensions":[
+				{"Code":"total","Role":"total","Score":{"Kind":"raw_total","Value":%g},"Level":{"Code":%q}},
+				{"Code":"mood","Score":{"Kind":"raw_total","Value":%g},"DerivedScores":[{"Kind":"t_score","Value":%g}],"NormReference":{"ScoreKind":"t_score","Benchmark":50},"Level":{"Code":%q}}
+			]
+		}`, s.total, s.level, s.total, s.level, s.moodT/10, s.moodT, s.moodLevel)),
+	})
+}
+
+func newTestService(t *testing.T, screenings []screening, cohorts fakeCohorts) (Service, *fakeStore) {
+	t.Helper()
+	facts := fakeFacts{}
+	var rows fakeAssessments
+	for _, s := range screenings {
+		facts[s.assessmentID] = outcomeRecord(t, s)
+		at := s.at
+		rows = append(rows, evaluationreadmodel.AssessmentRow{ID: s.assessmentID, OrgID: 7, TesteeID: s.testeeID, Status: "evaluated", SubmittedAt: &at})
+	}
+	store := &fakeStore{}
+	svc := NewService(store, cohorts, rows, facts, fakePseudonyms{}, fixedBranding{}, Config{MinCellSize: 3})
+	return svc, store
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 9, 0, 0, 0, time.UTC)
+}
+
+// gradeScreenings 12 人的年级：本学期 10 人参测（4 无风险、2 低风险、3 高风险、1 严重风险），
+// 上学期 6 人参测（3 高风险、3 无风险）。受试者 1 本学期测过两次，以较新的一次为准。
+func gradeScreenings() []screening {
+	var out []screening
+	current := []struct {
+		level string
+		total float64
+		moodT float64
+		mood  string
+	}{
+		{"none", 5, 45, "none"}, {"none", 5, 45, "none"}, {"none", 5, 45, "none"}, {"none", 5, 45, "none"},
+		{"low", 10, 55, "none"}, {"low", 10, 55, "none"},
+		{"high", 18, 62, "high"}, {"high", 18, 62, "high"}, {"high", 18, 62, "high"},
+		{"severe", 24, 70, "high"},
+	}
+	out = append(out, screening{assessmentID: 101, testeeID: 1, at: day(3, 10), level: "high", total: 18, moodT: 62, moodLevel: "high"})
+	for i, c := range current {
+		out = append(out, screening{assessmentID: uint64(201 + i), testeeID: uint64(1 + i), at: day(5, 1+i), level: c.level, total: c.total, moodT: c.moodT, moodLevel: c.mood})
+	}
+	for i := 0; i < 6; i++ {
+		s := screening{assessmentID: uint64(11 + i), testeeID: uint64(1 + i), at: time.Date(2025, 12, 1+i, 9, 0, 0, 0, time.UTC), level: "none", total: 5, moodT: 45, moodLevel: "none"}
+		if i < 3 {
+			s.level, s.total, s.moodT, s.moodLevel = "high", 18, 62, "high"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func gradeCohorts() fakeCohorts {
+	return fakeCohorts{"entry:900": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}
+}
+
+func levelByCode(levels []LevelCell, code string) LevelCell {
+	for _, level := range levels {
+		if level.Code == code {
+			return level
+		}
+	}
+	return LevelCell{}
+}
+
+func TestGenerateAggregatesCohortWithPrivacyGuard(t *testing.T) {
+	svc, store := newTestService(t, gradeScreenings(), gradeCohorts())
+	actor := Actor{OrgID: 7, OperatorUserID: 3}
+	request := Request{Cohort: Cohort{Kind: CohortEntry, EntryID: 900}, ModelCode: "MHT", Term: Term{From: termStart, To: termEnd}}
+
+	report, err := svc.Generate(context.Background(), actor, request)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(report.Input.Current) != 10 || len(report.Input.Previous) != 6 || report.Input.Current[0].AssessmentID != "201" {
+		t.Fatalf("frozen input = %#v", report.Input)
+	}
+	content := report.Content
+	if content.Cohort.Size != 12 || content.Cohort.Assessed != 10 || content.Cohort.Coverage != 0.8333 || content.Cohort.Ref != "900" {
+		t.Fatalf("cohort = %#v", content.Cohort)
+	}
+	wantPrevious := Term{From: termStart.Add(-termEnd.Sub(termStart)), To: termStart}
+	if content.PreviousTerm != wantPrevious {
+		t.Fatalf("previous term = %#v, want %#v", content.PreviousTerm, wantPrevious)
+	}
+
+	// 整体：无风险 4 人公开；低风险 2 人被抑制，中风险（0 人）连带抑制以免由“非风险”合计反推；
+	// 高风险 3 人与严重风险 1 人合并成带，只公开合计。
+	overall := content.Overall
+	if none := levelByCode(overall.Levels, "none"); none.Count == nil || *none.Count != 4 {
+		t.Fatalf("none cell = %#v", none)
+	}
+	for _, code := range []string{"low", "medium", "high", "severe"} {
+		if cell := levelByCode(overall.Levels, code); !cell.Suppressed || cell.Count != nil {
+			t.Fatalf("%s cell = %#v, want suppressed", code, cell)
+		}
+	}
+	if overall.AtRisk.Count == nil || *overall.AtRisk.Count != 4 || *overall.AtRisk.Share != 0.4 {
+		t.Fatalf("overall at risk = %#v", overall.AtRisk)
+	}
+	if overall.Previous == nil || overall.Previous.Assessed != 6 || *overall.Previous.AtRiskShare != 0.5 || *overall.Previous.AtRiskDelta != -0.1 {
+		t.Fatalf("overall previous = %#v", overall.Previous)
+	}
+
+	if len(content.RiskBands) != 1 {
+		t.Fatalf("risk bands = %#v", content.RiskBands)
+	}
+	band := content.RiskBands[0]
+	if band.Band != "high_and_above" || band.Count != 4 || fmt.Sprint(band.Members) != "[testee_10 testee_7 testee_8 testee_9]" {
+		t.Fatalf("band = %#v", band)
+	}
+
+	if len(content.Factors) != 2 || content.Factors[0].FactorCode != "total" || content.Factors[1].FactorCode != "mood" {
+		t.Fatalf("factors = %#v", content.Factors)
+	}
+	total := content.Factors[0]
+	if total.FactorName != "总分" || *total.MeanScore != 11.8 || total.Previous == nil || *total.Previous.MeanScore != 11.5 || *total.Previous.MeanDelta != 0.3 {
+		t.Fatalf("total factor = %#v", total)
+	}
+	mood := content.Factors[1]
+	if mood.Norm == nil || mood.Norm.Threshold != 60 || *mood.Norm.Count != 4 || *mood.Norm.Share != 0.4 || mood.Norm.ExpectedShare != 0.1587 {
+		t.Fatalf("mood norm = %#v", mood.Norm)
+	}
+	if high := levelByCode(mood.Levels, "high"); high.Count == nil || *high.Count != 4 {
+		t.Fatalf("mood levels = %#v", mood.Levels)
+	}
+
+	again, err := svc.Generate(context.Background(), actor, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != report.ID || len(store.reports) != 1 {
+		t.Fatalf("repeat generation created %d reports, want idempotent", len(store.reports))
+	}
+}
+
+func TestGenerateRejectsCohortBelowMinimumCell(t *testing.T) {
+	svc, store := newTestService(t, gradeScreenings(), gradeCohorts())
+	actor := Actor{OrgID: 7}
+	request := Request{Cohort: Cohort{Kind: CohortTestees, TesteeIDs: []uint64{1, 2, 11, 12, 2}}, ModelCode: "MHT", Term: Term{From: termStart, To: termEnd}}
+
+	if _, err := svc.Generate(context.Background(), actor, request); !cberrors.IsCode(err, code.ErrGroupReportCohortTooSmall) {
+		t.Fatalf("two assessed members = %v, want ErrGroupReportCohortTooSmall", err)
+	}
+	if _, err := svc.Generate(context.Background(), Actor{OrgID: 8}, Request{Cohort: Cohort{Kind: CohortEntry, EntryID: 900}, ModelCode: "MHT", Term: Term{From: termStart, To: termEnd}}); !cberrors.IsCode(err, code.ErrGroupReportCohortTooSmall) {
+		t.Fatalf("other org cohort = %v, want ErrGroupReportCohortTooSmall", err)
+	}
+	if len(store.reports) != 0 {
+		t.Fatalf("stored %d reports", len(store.reports))
+	}
+}
+
+func TestGenerateValidatesRequest(t *testing.T) {
+	svc, _ := newTestService(t, nil, gradeCohorts())
+	actor := Actor{OrgID: 7}
+	term := Term{From: termStart, To: termEnd}
+	cases := map[string]Request{
+		"missing model":     {Cohort: Cohort{Kind: CohortEntry, EntryID: 900}, Term: term},
+		"unknown cohort":    {Cohort: Cohort{Kind: "grade"}, ModelCode: "MHT", Term: term},
+		"missing tag":       {Cohort: Cohort{Kind: CohortTag, Tag: " "}, ModelCode: "MHT", Term: term},
+		"inverted term":     {Cohort: Cohort{Kind: CohortPlan, PlanID: 5}, ModelCode: "MHT", Term: Term{From: termEnd, To: termStart}},
+		"term too long":     {Cohort: Cohort{Kind: CohortPlan, PlanID: 5}, ModelCode: "MHT", Term: Term{From: termStart.AddDate(-2, 0, 0), To: termEnd}},
+		"overlapping terms": {Cohort: Cohort{Kind: CohortPlan, PlanID: 5}, ModelCode: "MHT", Term: term, PreviousTerm: &Term{From: termStart.AddDate(0, -3, 0), To: termStart.AddDate(0, 1, 0)}},
+	}
+	for name, request := range cases {
+		if _, err := svc.Generate(context.Background(), actor, request); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+			t.Errorf("%s: err = %v, want ErrInvalidArgument", name, err)
+		}
+	}
+}
+
+func TestGetAndRenderPDFAreOrgScoped(t *testing.T) {
+	svc, _ := newTestService(t, gradeScreenings(), gradeCohorts())
+	report, err := svc.Generate(context.Background(), Actor{OrgID: 7}, Request{Cohort: Cohort{Kind: CohortEntry, EntryID: 900}, ModelCode: "MHT", Term: Term{From: termStart, To: termEnd}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Get(context.Background(), Actor{OrgID: 8}, report.ID); !cberrors.IsCode(err, code.ErrGroupReportNotFound) {
+		t.Fatalf("other org Get = %v, want not found", err)
+	}
+	if _, _, err := svc.RenderPDF(context.Background(), Actor{OrgID: 8}, report.ID); !cberrors.IsCode(err, code.ErrGroupReportNotFound) {
+		t.Fatalf("other org RenderPDF = %v, want not found", err)
+	}
+	got, pdf, err := svc.RenderPDF(context.Background(), Actor{OrgID: 7}, report.ID)
+	if err != nil || got.ID != report.ID {
+		t.Fatalf("RenderPDF = %#v, %v", got, err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("%%EOF")) {
+		t.Fatalf("rendered output is not a PDF: %q", pdf[:min(len(pdf), 16)])
+	}
+}
+
+func TestProtectSuppressesComplementsOfPublishedSums(t *testing.T) {
+	// 合计 10、风险 4 公开时，非风险合计 6 同样可知：低风险 2 被抑制后，必须连带抑制同组的另一格。
+	cells := []*tally{
+		{code: "none", severity: "none", count: 4},
+		{code: "low", severity: "low", count: 2},
+		{code: "medium", severity: "medium", count: 0},
+		{code: "high", severity: "high", count: 4},
+	}
+	atRisk := &published{cells: []int{3}, hideable: true}
+	protect(cells, withComplements(len(cells), atRisk), 3)
+	if !cells[1].hidden || !cells[2].hidden || cells[0].hidden || cells[3].hidden || atRisk.hidden {
+		t.Fatalf("hidden = %v %v %v %v, at risk hidden = %v", cells[0].hidden, cells[1].hidden, cells[2].hidden, cells[3].hidden, atRisk.hidden)
+	}
+
+	// 风险组只有一个非零格且被抑制时，组内没有可连带抑制的格子，只能抑制风险合计本身。
+	cells = []*tally{
+		{code: "none", severity: "none", count: 8},
+		{code: "low", severity: "low", count: 5},
+		{code: "high", severity: "high", count: 2},
+	}
+	atRisk = &published{cells: []int{2}, hideable: true}
+	protect(cells, withComplements(len(cells), atRisk), 3)
+	if !cells[2].hidden || !cells[1].hidden || !atRisk.hidden {
+		t.Fatalf("hidden = %v %v %v, at risk hidden = %v", cells[0].hidden, cells[1].hidden, cells[2].hidden, atRisk.hidden)
+	}
+}
+
+func TestRiskBandsMergeWithoutCrossingRiskBoundary(t *testing.T) {
+	cases := []struct {
+		name   string
+		counts map[string]int
+		want   []string
+	}{
+		{"separate bands", map[string]int{"severe": 3, "high": 4, "medium": 3}, []string{"severe:3", "high:4", "medium:3"}},
+		{"high merged with severe", map[string]int{"severe": 3, "high": 1, "medium": 5}, []string{"high_and_above:4", "medium:5"}},
+		{"small medium dropped", map[string]int{"high": 4, "medium": 2}, []string{"high:4"}},
+		{"at risk remnant merged into medium", map[string]int{"severe": 1, "high": 1, "medium": 2, "none": 9}, []string{"medium_and_above:4"}},
+		{"nothing listable", map[string]int{"high": 1, "medium": 1, "none": 9}, nil},
+	}
+	for _, tc := range cases {
+		var current []member
+		id := uint64(1)
+		for _, severity := range severityOrder {
+			for i := 0; i < tc.counts[severity]; i++ {
+				m := member{testeeID: id}
+				m.input.Result.Level = &report.ResultLevel{Code: severity}
+				current = append(current, m)
+				id++
+			}
+		}
+		bands, _ := riskBands(current, 3, fakePseudonyms{})
+		var got []string
+		for _, band := range bands {
+			got = append(got, fmt.Sprintf("%s:%d", band.Band, band.Count))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: bands = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package groupreport 群体报告：把一个群体（测评入口、计划、标签或指定受试者集合）在一个学期内的冻结测评结果
+// 汇总为班级/年级层面的报告。
+//
+// 报告按因子给出等级分布、风险占比与常模对照，并与上一学期（紧邻的等长时间窗）比较；
+// 另按风险带列出供心理老师跟进的假名名单。每位成员只取时间窗内同一模型最近一次已完成的测评结果，
+// 所用结果 ID 与版本令牌冻结为输入快照，以快照指纹去重。
+// 所有计数都经过最小单元格隐私保护：小于阈值的单元格不公布计数与占比，必要时连带抑制一个相邻单元格，
+// 避免由合计反推；人数不足阈值的风险带与相邻一档合并后再列出，合并后仍不足阈值的成员不列入名单。
+package groupreport
+
+import (
+	"context"
+
+	domaingroup "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/groupreport"
+)
+
+type (
+	CohortKind     = domaingroup.CohortKind
+	Term           = domaingroup.Term
+	Report         = domaingroup.Report
+	FrozenInput    = domaingroup.FrozenInput
+	FrozenOutcome  = domaingroup.FrozenOutcome
+	Content        = domaingroup.Content
+	CohortSummary  = domaingroup.CohortSummary
+	OverallSummary = domaingroup.OverallSummary
+	Cell           = domaingroup.Cell
+	LevelCell      = domaingroup.LevelCell
+	NormCell       = domaingroup.NormCell
+	Comparison     = domaingroup.Comparison
+	FactorGroup    = domaingroup.FactorGroup
+	RiskBand       = domaingroup.RiskBand
+)
+
+const (
+	CohortEntry   = domaingroup.CohortEntry
+	CohortPlan    = domaingroup.CohortPlan
+	CohortTag     = domaingroup.CohortTag
+	CohortTestees = domaingroup.CohortTestees
+)
+
+// Cohort 群体定义；按 Kind 只使用对应的字段。
+type Cohort struct {
+	Kind      CohortKind
+	EntryID   uint64
+	PlanID    uint64
+	Tag       string
+	TesteeIDs []uint64
+}
+
+// Ref 群体的稳定引用，写入报告用于展示与检索；显式受试者集合没有引用。
+func (c Cohort) Ref() string {
+	switch c.Kind {
+	case CohortEntry:
+		return formatID(c.EntryID)
+	case CohortPlan:
+		return formatID(c.PlanID)
+	case CohortTag:
+		return c.Tag
+	default:
+		return ""
+	}
+}
+
+// Request 生成群体报告的请求。
+type Request struct {
+	Cohort    Cohort
+	ModelCode string
+	Term      Term
+	// PreviousTerm 对比学期；为空时取紧邻 Term 之前的等长时间窗。
+	PreviousTerm *Term
+}
+
+// Actor 生成或查看群体报告的机构管理员。
+type Actor struct {
+	OrgID          int64
+	OperatorUserID int64
+}
+
+// Config 群体报告的隐私阈值与规模上限。
+type Config struct {
+	// MinCellSize 最小单元格：本学期有结果的成员不足该值时拒绝生成，小于该值的计数一律抑制。
+	MinCellSize int
+	// MaxCohortSize 单份报告的群体人数上限。
+	MaxCohortSize int
+}
+
+// CohortResolver 把群体定义解析为本机构内有效受试者 ID（去重、升序）。
+type CohortResolver interface {
+	ResolveTestees(ctx context.Context, orgID int64, cohort Cohort, limit int) ([]uint64, error)
+}
+
+// Pseudonyms 为风险带名单生成受试者假名。
+type Pseudonyms interface {
+	Pseudonym(kind string, id uint64) string
+}
+
+// Store 群体报告存储。
+type Store = domaingroup.Repository
@@ -77,13 +77,9 @@ func (s *service) ListMyReports(ctx context.Context, actor Actor, query ListQuer
 	if err != nil {
 		return nil, queryerror.MapReadError(err)
 	}
-	items := make([]*Report, 0, len(rows))
-	for _, row := range rows {
-		item, mapErr := s.projection.FromRow(ctx, row, policy.AudienceParticipant)
-		if mapErr != nil {
-			return nil, mapErr
-		}
-		items = append(items, item)
+	items, err := s.projection.FromRows(ctx, rows, policy.AudienceParticipant)
+	if err != nil {
+		return nil, err
 	}
 	totalInt := int(total)
 	return &ListResult{Items: items, Total: totalInt, Page: page, PageSize: pageSize, TotalPages: (totalInt + pageSize - 1) / pageSize}, nil
@@ -0,0 +1,41 @@
+package planreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	domainplan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+)
+
+// NewTaskTerminalConsumer 在 task.completed / task.expired / task.canceled 之后检查参与轮次是否已关闭，关闭时生成纵向报告。
+// 生成按输入指纹幂等；最后一次测评结果尚未提交时返回错误，由事件重投递重试。
+func NewTaskTerminalConsumer(service Service) func(context.Context, string, []byte) error {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		switch eventType {
+		case domainplan.EventTypeTaskCompleted, domainplan.EventTypeTaskExpired, domainplan.EventTypeTaskCanceled:
+		default:
+			return nil
+		}
+		if service == nil {
+			return fmt.Errorf("plan report service is unavailable")
+		}
+
+		env, err := eventcodec.DecodeEnvelope(payload)
+		if err != nil {
+			return err
+		}
+		var data struct {
+			TaskID string `json:"task_id"`
+		}
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			return fmt.Errorf("decode task event payload: %w", err)
+		}
+		if data.TaskID == "" {
+			return fmt.Errorf("task id missing in %s payload", eventType)
+		}
+		_, err = service.GenerateForTask(ctx, data.TaskID)
+		return err
+	}
+}
@@ -0,0 +1,91 @@
+package planreport
+
+import "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+
+// contentFromDomain 把纵向构建器产出的领域内容转换为可持久化、可直接返回的内容快照。
+func contentFromDomain(content report.Content) Content {
+	out := Content{
+		ModelCode:    content.Model.Code,
+		ModelVersion: content.Model.Version,
+		ModelTitle:   content.Model.Title,
+		PrimaryScore: scoreFromDomain(content.PrimaryScore),
+		Level:        levelFromDomain(content.Level),
+		Conclusion:   content.Conclusion,
+		Suggestions:  make([]string, 0, len(content.Suggestions)),
+	}
+	for _, suggestion := range content.Suggestions {
+		if suggestion.Content != "" {
+			out.Suggestions = append(out.Suggestions, suggestion.Content)
+		}
+	}
+	section := content.Longitudinal
+	if section == nil {
+		return out
+	}
+	out.Direction = string(section.Direction)
+	out.Adherence = Adherence{
+		PlannedTasks:   section.Adherence.PlannedTasks,
+		CompletedTasks: section.Adherence.CompletedTasks,
+		ExpiredTasks:   section.Adherence.ExpiredTasks,
+		CanceledTasks:  section.Adherence.CanceledTasks,
+		Rate:           section.Adherence.Rate,
+	}
+	out.Timepoints = make([]Timepoint, 0, len(section.Timepoints))
+	for _, point := range section.Timepoints {
+		out.Timepoints = append(out.Timepoints, Timepoint{
+			Seq:          point.Seq,
+			AssessmentID: point.AssessmentID,
+			OutcomeID:    point.OutcomeID,
+			OccurredAt:   point.OccurredAt,
+			Score:        scoreFromDomain(point.PrimaryScore),
+			Level:        levelFromDomain(point.Level),
+		})
+	}
+	if section.Overall != nil {
+		out.Overall = &ScoreChange{
+			BaselineScore: section.Overall.BaselineScore,
+			CurrentScore:  section.Overall.CurrentScore,
+			Delta:         section.Overall.Delta,
+			Change:        string(section.Overall.Change),
+		}
+	}
+	out.Factors = make([]FactorTrajectory, 0, len(section.Factors))
+	for _, factor := range section.Factors {
+		trajectory := FactorTrajectory{
+			FactorCode:   factor.FactorCode,
+			FactorName:   factor.FactorName,
+			IsTotalScore: factor.IsTotalScore,
+			MaxScore:     factor.MaxScore,
+			Baseline:     observationFromDomain(factor.Baseline),
+			FollowUps:    make([]FactorFollowUp, 0, len(factor.FollowUps)),
+			Change:       string(factor.Change),
+		}
+		for _, followUp := range factor.FollowUps {
+			trajectory.FollowUps = append(trajectory.FollowUps, FactorFollowUp{
+				FactorObservation: observationFromDomain(followUp.FactorObservation),
+				Delta:             followUp.Delta,
+				Change:            string(followUp.Change),
+			})
+		}
+		out.Factors = append(out.Factors, trajectory)
+	}
+	return out
+}
+
+func scoreFromDomain(score *report.ScoreValue) *Score {
+	if score == nil {
+		return nil
+	}
+	return &Score{Kind: score.Kind, Value: score.Value, Label: score.Label, Max: score.Max}
+}
+
+func levelFromDomain(level *report.ResultLevel) *Level {
+	if level == nil {
+		return nil
+	}
+	return &Level{Code: level.Code, Label: level.Label, Severity: level.Severity}
+}
+
+func observationFromDomain(observation report.FactorObservation) FactorObservation {
+	return FactorObservation{Seq: observation.Seq, Score: observation.Score, RiskLevel: string(observation.RiskLevel)}
+}
@@ -0,0 +1,313 @@
+package planreport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"sort"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	outcomeinput "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation/input"
+	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainplan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 纵向计划报告用例。
+type Service interface {
+	// GenerateForTask 在任务进入终态后调用：所属参与轮次已关闭时生成报告，否则跳过并返回 nil。
+	GenerateForTask(ctx context.Context, taskID string) (*Report, error)
+	// Generate 后台操作者按需生成参与轮次的纵向报告；轮次无需已关闭。
+	Generate(ctx context.Context, actor Actor, testeeID, enrollmentID uint64) (*Report, error)
+	// GetLatest 返回参与轮次最新一份纵向报告。
+	GetLatest(ctx context.Context, actor Actor, testeeID, enrollmentID uint64) (*Report, error)
+}
+
+type service struct {
+	store     Store
+	timelines planApp.EnrollmentTimelineReader
+	facts     evaluationfact.Repository
+	builders  rendering.Registry
+	clinician ClinicianAccess
+	now       func() time.Time
+}
+
+// NewService 创建纵向计划报告服务。
+func NewService(
+	store Store,
+	timelines planApp.EnrollmentTimelineReader,
+	facts evaluationfact.Repository,
+	builders rendering.Registry,
+	clinician ClinicianAccess,
+) Service {
+	return &service{
+		store:     store,
+		timelines: timelines,
+		facts:     facts,
+		builders:  builders,
+		clinician: clinician,
+		now:       time.Now,
+	}
+}
+
+func (s *service) GenerateForTask(ctx context.Context, taskID string) (*Report, error) {
+	enrollmentID, err := s.timelines.FindTaskEnrollmentID(ctx, taskID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load task enrollment")
+	}
+	if enrollmentID == 0 {
+		return nil, nil
+	}
+	timeline, err := s.timelines.GetEnrollmentTimeline(ctx, enrollmentID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load enrollment timeline")
+	}
+	if timeline == nil || timeline.Status != string(domainplan.EnrollmentStatusClosed) {
+		return nil, nil
+	}
+	return s.generate(ctx, timeline, TriggerEnrollmentClosed)
+}
+
+func (s *service) Generate(ctx context.Context, actor Actor, testeeID, enrollmentID uint64) (*Report, error) {
+	timeline, err := s.authorizedTimeline(ctx, actor, testeeID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, timeline, TriggerOnDemand)
+}
+
+func (s *service) GetLatest(ctx context.Context, actor Actor, testeeID, enrollmentID uint64) (*Report, error) {
+	if _, err := s.authorizedTimeline(ctx, actor, testeeID, enrollmentID); err != nil {
+		return nil, err
+	}
+	latest, err := s.store.FindLatestByEnrollment(ctx, enrollmentID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load plan report")
+	}
+	if latest == nil {
+		return nil, cberrors.WithCode(code.ErrPlanReportNotFound, "plan report has not been generated")
+	}
+	return latest, nil
+}
+
+func (s *service) authorizedTimeline(ctx context.Context, actor Actor, testeeID, enrollmentID uint64) (*planApp.EnrollmentTimeline, error) {
+	if testeeID == 0 || enrollmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and enrollment_id are required")
+	}
+	if s.clinician == nil {
+		return nil, cberrors.WithCode(code.ErrModuleInitializationFailed, "plan report clinician access is not configured")
+	}
+	if err := s.clinician.AuthorizeParticipant(ctx, actor, testeeID); err != nil {
+		return nil, err
+	}
+	timeline, err := s.timelines.GetEnrollmentTimeline(ctx, enrollmentID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load enrollment timeline")
+	}
+	// 轮次不属于该受试者或机构时与不存在同样处理，避免泄露其他受试者的计划。
+	if timeline == nil || timeline.TesteeID != testeeID || timeline.OrgID != actor.OrgID {
+		return nil, cberrors.WithCode(code.ErrPlanReportNotFound, "plan enrollment not found")
+	}
+	return timeline, nil
+}
+
+func (s *service) generate(ctx context.Context, timeline *planApp.EnrollmentTimeline, trigger Trigger) (*Report, error) {
+	frozen, inputs, err := s.collect(ctx, timeline)
+	if err != nil {
+		return nil, err
+	}
+	latest := inputs[len(inputs)-1].input
+	fingerprint, err := Fingerprint(frozen, latest.Report.TemplateVersion)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "fingerprint plan report input")
+	}
+	existing, err := s.store.FindByFingerprint(ctx, timeline.EnrollmentID, fingerprint)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load plan report")
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	input := longitudinalInput(timeline, frozen, inputs)
+	key, ok := rendering.KeyFromInput(input)
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrPlanReportUnsupported, "assessment model has no longitudinal report mechanism")
+	}
+	builder, err := s.builders.ResolveByMechanism(key)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrPlanReportUnsupported, "resolve longitudinal report builder")
+	}
+	draft, err := builder.Build(ctx, input)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "build longitudinal report")
+	}
+	content := draft.Content()
+	if err := report.CrossMechanismArtifactContract(content); err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "validate longitudinal report")
+	}
+	if err := report.BuilderSpecificDraftContract(builder.BuilderIdentity(), content); err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "validate longitudinal report")
+	}
+
+	saved, err := s.store.Save(ctx, &Report{
+		OrgID:                timeline.OrgID,
+		EnrollmentID:         timeline.EnrollmentID,
+		PlanID:               timeline.PlanID,
+		TesteeID:             timeline.TesteeID,
+		Round:                timeline.Round,
+		Trigger:              trigger,
+		TemplateVersion:      input.Report.TemplateVersion.String(),
+		BuilderIdentity:      builder.BuilderIdentity(),
+		ContentSchemaVersion: builder.ContentSchemaVersion(),
+		InputFingerprint:     fingerprint,
+		Input:                frozen,
+		Content:              contentFromDomain(content),
+		GeneratedAt:          s.now(),
+	})
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "save plan report")
+	}
+	logger.L(ctx).Infow("longitudinal plan report generated",
+		"action", "generate_plan_report",
+		"org_id", saved.OrgID,
+		"enrollment_id", saved.EnrollmentID,
+		"report_id", saved.ID,
+		"trigger", string(trigger),
+		"outcomes", len(frozen.Outcomes),
+	)
+	return saved, nil
+}
+
+// collect 读取轮次内每个已完成任务的冻结测评结果；已完成但结果尚未提交时返回 ErrPlanReportNotReady，事件消费方据此重试。
+// 不同模型的结果无法对比，只保留与最近一次结果同一模型的时间点。
+func (s *service) collect(ctx context.Context, timeline *planApp.EnrollmentTimeline) (FrozenInput, []collectedPoint, error) {
+	frozen := FrozenInput{PlannedTasks: len(timeline.Tasks)}
+	type point struct {
+		task   planApp.EnrollmentTimelineTask
+		record *evaluationfact.Record
+		input  interpinput.InterpretationInput
+	}
+	points := make([]point, 0, len(timeline.Tasks))
+	for _, task := range timeline.Tasks {
+		switch domainplan.TaskStatus(task.Status) {
+		case domainplan.TaskStatusExpired:
+			frozen.ExpiredTasks++
+		case domainplan.TaskStatusCanceled:
+			frozen.CanceledTasks++
+		case domainplan.TaskStatusCompleted:
+			frozen.CompletedTasks++
+			if task.AssessmentID == 0 {
+				continue
+			}
+			record, err := s.facts.FindByAssessmentID(ctx, meta.FromUint64(task.AssessmentID))
+			if stderrors.Is(err, evaluationfact.ErrNotFound) || (err == nil && record == nil) {
+				return FrozenInput{}, nil, cberrors.WithCode(code.ErrPlanReportNotReady, "outcome of assessment %d is not committed yet", task.AssessmentID)
+			}
+			if err != nil {
+				return FrozenInput{}, nil, cberrors.WrapC(err, code.ErrDatabase, "load evaluation outcome")
+			}
+			input, err := outcomeinput.FromOutcomeRecord(record)
+			if err != nil {
+				return FrozenInput{}, nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "restore outcome of assessment %d", task.AssessmentID)
+			}
+			if input.FactorScoring == nil {
+				return FrozenInput{}, nil, cberrors.WithCode(code.ErrPlanReportUnsupported, "assessment model %s has no score trajectory", input.Model.Code)
+			}
+			points = append(points, point{task: task, record: record, input: input})
+		}
+	}
+	if len(points) == 0 {
+		return FrozenInput{}, nil, cberrors.WithCode(code.ErrPlanReportNotReady, "enrollment has no completed assessments")
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].task.Seq < points[j].task.Seq })
+	modelCode := points[len(points)-1].input.Model.Code
+	collected := make([]collectedPoint, 0, len(points))
+	for _, p := range points {
+		if p.input.Model.Code != modelCode {
+			continue
+		}
+		frozen.Outcomes = append(frozen.Outcomes, FrozenOutcome{
+			Seq:          p.task.Seq,
+			TaskID:       meta.FromUint64(p.task.TaskID).String(),
+			AssessmentID: p.record.AssessmentID().String(),
+			OutcomeID:    p.record.ID().String(),
+			VersionToken: p.record.VersionToken(),
+		})
+		point := collectedPoint{seq: p.task.Seq, input: p.input}
+		if p.task.CompletedAt != nil {
+			point.occurredAt = *p.task.CompletedAt
+		}
+		collected = append(collected, point)
+	}
+	return frozen, collected, nil
+}
+
+// collectedPoint 一次已完成任务及其冻结结果恢复出的解读输入。
+type collectedPoint struct {
+	seq        int
+	occurredAt time.Time
+	input      interpinput.InterpretationInput
+}
+
+// longitudinalInput 以最近一次结果的模型、运行时、展示配置与模板版本为准组装纵向输入。
+func longitudinalInput(timeline *planApp.EnrollmentTimeline, frozen FrozenInput, points []collectedPoint) interpinput.InterpretationInput {
+	latest := points[len(points)-1].input
+	facts := &interpinput.LongitudinalFacts{
+		EnrollmentID:   meta.FromUint64(timeline.EnrollmentID),
+		PlanID:         meta.FromUint64(timeline.PlanID),
+		Round:          timeline.Round,
+		PlannedTasks:   frozen.PlannedTasks,
+		CompletedTasks: frozen.CompletedTasks,
+		ExpiredTasks:   frozen.ExpiredTasks,
+		CanceledTasks:  frozen.CanceledTasks,
+		Points:         make([]interpinput.LongitudinalPoint, 0, len(points)),
+	}
+	for _, point := range points {
+		facts.Points = append(facts.Points, interpinput.LongitudinalPoint{
+			Seq:          point.seq,
+			OutcomeID:    point.input.OutcomeID,
+			AssessmentID: point.input.Association.AssessmentID,
+			OccurredAt:   point.occurredAt,
+			Primary:      point.input.Result.Primary,
+			Level:        point.input.Result.Level,
+			Scoring:      point.input.FactorScoring,
+		})
+	}
+	return interpinput.InterpretationInput{
+		Association:         report.Association{OrgID: timeline.OrgID, TesteeID: timeline.TesteeID},
+		Model:               latest.Model,
+		Runtime:             latest.Runtime,
+		PresentationProfile: latest.PresentationProfile,
+		Report: interpinput.ReportSpec{
+			ReportType:      policy.ReportTypeLongitudinal,
+			TemplateVersion: latest.Report.TemplateVersion,
+			Algorithm:       latest.Report.Algorithm,
+			ReportProfile:   latest.Report.ReportProfile,
+		},
+		Longitudinal: facts,
+	}
+}
+
+// Fingerprint 冻结输入与模板版本的 SHA-256；用于判断同一参与轮次是否已按相同输入生成过报告。
+func Fingerprint(frozen FrozenInput, templateVersion policy.TemplateVersion) (string, error) {
+	payload, err := json.Marshal(struct {
+		Input           FrozenInput `json:"input"`
+		TemplateVersion string      `json:"template_version"`
+	}{frozen, templateVersion.String()})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
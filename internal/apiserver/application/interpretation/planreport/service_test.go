@@ -0,0 +1,272 @@
+package planreport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/builder"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+	domainplan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationinput"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type fakeStore struct{ reports []*Report }
+
+func (s *fakeStore) Save(_ context.Context, report *Report) (*Report, error) {
+	if existing, _ := s.FindByFingerprint(context.Background(), report.EnrollmentID, report.InputFingerprint); existing != nil {
+		return existing, nil
+	}
+	saved := *report
+	saved.ID = uint64(len(s.reports) + 1)
+	s.reports = append(s.reports, &saved)
+	return &saved, nil
+}
+
+func (s *fakeStore) FindByFingerprint(_ context.Context, enrollmentID uint64, fingerprint string) (*Report, error) {
+	for _, report := range s.reports {
+		if report.EnrollmentID == enrollmentID && report.InputFingerprint == fingerprint {
+			return report, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) FindLatestByEnrollment(_ context.Context, enrollmentID uint64) (*Report, error) {
+	var latest *Report
+	for _, report := range s.reports {
+		if report.EnrollmentID == enrollmentID {
+			latest = report
+		}
+	}
+	return latest, nil
+}
+
+type fakeTimelines struct {
+	timeline    *planApp.EnrollmentTimeline
+	taskToEnrol map[string]uint64
+}
+
+func (f fakeTimelines) GetEnrollmentTimeline(_ context.Context, enrollmentID uint64) (*planApp.EnrollmentTimeline, error) {
+	if f.timeline == nil || f.timeline.EnrollmentID != enrollmentID {
+		return nil, nil
+	}
+	return f.timeline, nil
+}
+
+func (f fakeTimelines) FindTaskEnrollmentID(_ context.Context, taskID string) (uint64, error) {
+	return f.taskToEnrol[taskID], nil
+}
+
+type fakeFacts map[uint64]*evaluationfact.Record
+
+func (f fakeFacts) FindByID(context.Context, meta.ID) (*evaluationfact.Record, error) {
+	return nil, evaluationfact.ErrNotFound
+}
+
+func (f fakeFacts) FindByAssessmentID(_ context.Context, assessmentID meta.ID) (*evaluationfact.Record, error) {
+	if record, ok := f[assessmentID.Uint64()]; ok {
+		return record, nil
+	}
+	return nil, evaluationfact.ErrNotFound
+}
+
+type fakeClinicianAccess struct{ allowed map[uint64]bool }
+
+func (a fakeClinicianAccess) AuthorizeParticipant(_ context.Context, _ Actor, testeeID uint64) error {
+	if !a.allowed[testeeID] {
+		return cberrors.WithCode(code.ErrPermissionDenied, "testee is not assigned to operator")
+	}
+	return nil
+}
+
+func phqOutcome(t *testing.T, assessmentID uint64, total, mood float64, level string) *evaluationfact.Record {
+	t.Helper()
+	reportInput, err := evaluationinput.MarshalReportInput(evaluationinput.ReportInputFreezeOptions{
+		Assets: &interpretationassets.Assets{
+			Outcomes: []interpretationassets.OutcomePresentation{{OutcomeCode: level, Title: level}},
+			ReportSpec: interpretationassets.ReportSpec{Sections: []interpretationassets.ReportSection{{
+				Code: "phq_scores", Kind: "factor_scores", SourceRefs: []string{"total", "mood"}, TemplateID: "standard", TemplateVersion: "legacy-v1",
+			}}},
+		},
+		ModelRef: evaluationinput.ModelRef{
+			Kind: evaluationinput.EvaluationModelKindScale, Algorithm: string(modelcatalog.AlgorithmScaleDefault), Code: "PHQ-9", Version: "1.0.0", Title: "患者健康问卷",
+		},
+		DecisionKind: modelcatalog.DecisionKindScoreRange,
+		FactorCatalog: []evaluationinput.FactorCatalogEntry{
+			{Code: "total", Title: "总分", IsTotalScore: true},
+			{Code: "mood", Title: "情绪"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return evaluationfact.NewRecord(evaluationfact.NewRecordInput{
+		ID: meta.FromUint64(assessmentID + 400), OrgID: 7, AssessmentID: meta.FromUint64(assessmentID), TesteeID: 401, RunID: fmt.Sprintf("%d:1", assessmentID),
+		Model: evaluationfact.ModelIdentity{
+			Kind: modelcatalog.KindScale, Algorithm: modelcatalog.AlgorithmScaleDefault, Code: "PHQ-9", Version: "1.0.0", Title: "患者健康问卷",
+		},
+		Runtime:       evaluationfact.RuntimeIdentity{DecisionKind: modelcatalog.DecisionKindScoreRange},
+		SchemaVersion: 2, EvaluatedAt: time.Unix(int64(assessmentID), 0), ReportInput: reportInput,
+		Payload: []byte(fmt.Sprintf(`{
+			"Primary":{"Kind":"raw_total","Value":%g},
+			"Level":{"Code":%q,"Severity":%q},
+			"Dimensions":[
+				{"Code":"total","Role":"total","Score":{"Kind":"raw_total","Value":%g},"Level":{"Code":%q}},
+				{"Code":"mood","Score":{"Kind":"raw_total","Value":%g},"Level":{"Code":%q}}
+			]
+		}`, total, level, level, total, level, mood, level)),
+	})
+}
+
+func completedAt(day int) *time.Time {
+	at := time.Date(2026, 6, day, 9, 0, 0, 0, time.UTC)
+	return &at
+}
+
+func newTestService(t *testing.T, status domainplan.EnrollmentStatus, facts fakeFacts) (Service, *fakeStore) {
+	t.Helper()
+	registry, err := rendering.NewDefaultRegistry(builder.NewDefaultReportBuilder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeline := &planApp.EnrollmentTimeline{
+		EnrollmentID: 77, OrgID: 7, PlanID: 88, TesteeID: 401, Round: 1, Status: string(status),
+		Tasks: []planApp.EnrollmentTimelineTask{
+			{TaskID: 1, Seq: 1, Status: string(domainplan.TaskStatusCompleted), AssessmentID: 501, CompletedAt: completedAt(1)},
+			{TaskID: 2, Seq: 2, Status: string(domainplan.TaskStatusExpired)},
+			{TaskID: 3, Seq: 3, Status: string(domainplan.TaskStatusCompleted), AssessmentID: 503, CompletedAt: completedAt(29)},
+		},
+	}
+	store := &fakeStore{}
+	svc := NewService(store, fakeTimelines{timeline: timeline, taskToEnrol: map[string]uint64{"3": 77}}, facts, registry, fakeClinicianAccess{allowed: map[uint64]bool{401: true, 402: true}})
+	return svc, store
+}
+
+func TestGenerateForTaskBuildsReportOnceEnrollmentClosed(t *testing.T) {
+	svc, store := newTestService(t, domainplan.EnrollmentStatusClosed, fakeFacts{
+		501: phqOutcome(t, 501, 18, 7, "high"),
+		503: phqOutcome(t, 503, 6, 2, "low"),
+	})
+
+	report, err := svc.GenerateForTask(context.Background(), "3")
+	if err != nil {
+		t.Fatalf("GenerateForTask: %v", err)
+	}
+	if report == nil || report.Trigger != TriggerEnrollmentClosed || report.BuilderIdentity != "longitudinal" || report.TemplateVersion != "legacy-v1" {
+		t.Fatalf("report = %#v", report)
+	}
+	if len(report.Input.Outcomes) != 2 || report.Input.Outcomes[1].OutcomeID != "903" || report.Input.ExpiredTasks != 1 {
+		t.Fatalf("frozen input = %#v", report.Input)
+	}
+	content := report.Content
+	if content.Adherence.Rate != 0.6667 || len(content.Timepoints) != 2 || !content.Timepoints[1].OccurredAt.Equal(*completedAt(29)) {
+		t.Fatalf("content = %#v", content)
+	}
+	if content.Overall == nil || content.Overall.Delta != -12 || content.Overall.Change != "improved" {
+		t.Fatalf("overall = %#v", content.Overall)
+	}
+
+	again, err := svc.GenerateForTask(context.Background(), "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != report.ID || len(store.reports) != 1 {
+		t.Fatalf("repeat delivery created %d reports, want idempotent", len(store.reports))
+	}
+}
+
+func TestGenerateForTaskSkipsOpenEnrollmentAndWaitsForOutcomes(t *testing.T) {
+	svc, store := newTestService(t, domainplan.EnrollmentStatusActive, fakeFacts{})
+	if report, err := svc.GenerateForTask(context.Background(), "3"); err != nil || report != nil || len(store.reports) != 0 {
+		t.Fatalf("active enrollment generated %#v, %v", report, err)
+	}
+
+	svc, _ = newTestService(t, domainplan.EnrollmentStatusClosed, fakeFacts{501: phqOutcome(t, 501, 18, 7, "high")})
+	if _, err := svc.GenerateForTask(context.Background(), "3"); !cberrors.IsCode(err, code.ErrPlanReportNotReady) {
+		t.Fatalf("missing outcome error = %v, want ErrPlanReportNotReady", err)
+	}
+}
+
+func TestClinicianGenerateChecksEnrollmentOwnership(t *testing.T) {
+	svc, _ := newTestService(t, domainplan.EnrollmentStatusActive, fakeFacts{501: phqOutcome(t, 501, 18, 7, "high"), 503: phqOutcome(t, 503, 6, 2, "low")})
+	actor := Actor{OrgID: 7, OperatorUserID: 9}
+
+	if _, err := svc.GetLatest(context.Background(), actor, 401, 77); !cberrors.IsCode(err, code.ErrPlanReportNotFound) {
+		t.Fatalf("GetLatest before generation = %v", err)
+	}
+	if _, err := svc.Generate(context.Background(), actor, 402, 77); !cberrors.IsCode(err, code.ErrPlanReportNotFound) {
+		t.Fatalf("other testee enrollment = %v, want not found", err)
+	}
+	report, err := svc.Generate(context.Background(), actor, 401, 77)
+	if err != nil || report.Trigger != TriggerOnDemand {
+		t.Fatalf("Generate = %#v, %v", report, err)
+	}
+	latest, err := svc.GetLatest(context.Background(), actor, 401, 77)
+	if err != nil || latest.ID != report.ID {
+		t.Fatalf("GetLatest = %#v, %v", latest, err)
+	}
+}
+
+func TestTaskTerminalConsumerGeneratesFromEvent(t *testing.T) {
+	svc, store := newTestService(t, domainplan.EnrollmentStatusClosed, fakeFacts{
+		501: phqOutcome(t, 501, 18, 7, "high"),
+		503: phqOutcome(t, 503, 6, 2, "low"),
+	})
+	payload, err := eventcodec.EncodeDomainEvent(event.Event[domainplan.TaskCompletedData]{
+		BaseEvent: event.BaseEvent{ID: "evt-1", EventTypeValue: domainplan.EventTypeTaskCompleted, AggregateTypeValue: "AssessmentTask", AggregateIDValue: "3"},
+		Data:      domainplan.TaskCompletedData{TaskID: "3", PlanID: "88", TesteeID: "401", AssessmentID: "503"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	consume := NewTaskTerminalConsumer(svc)
+	if err := consume(context.Background(), domainplan.EventTypeTaskOpened, payload); err != nil || len(store.reports) != 0 {
+		t.Fatalf("unrelated event handled: %v", err)
+	}
+	if err := consume(context.Background(), domainplan.EventTypeTaskCompleted, payload); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.reports) != 1 {
+		t.Fatalf("reports = %d, want 1", len(store.reports))
+	}
+}
+
+func TestContentChartsDeriveSparklines(t *testing.T) {
+	full := 27.0
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	content := Content{
+		ModelTitle:   "PHQ-9",
+		PrimaryScore: &Score{Kind: "raw_total", Value: 8, Label: "总分", Max: &full},
+		Timepoints: []Timepoint{
+			{Seq: 1, OutcomeID: "o1", OccurredAt: day, Score: &Score{Value: 18, Max: &full}},
+			{Seq: 2, OutcomeID: "o2", OccurredAt: day.AddDate(0, 0, 14), Score: &Score{Value: 8, Max: &full}},
+		},
+		Factors: []FactorTrajectory{
+			{FactorCode: "total", IsTotalScore: true, Baseline: FactorObservation{Seq: 1, Score: 18}, FollowUps: []FactorFollowUp{{FactorObservation: FactorObservation{Seq: 2, Score: 8}}}},
+			{FactorCode: "sleep", FactorName: "睡眠", Baseline: FactorObservation{Seq: 1, Score: 3}, FollowUps: []FactorFollowUp{{FactorObservation: FactorObservation{Seq: 2, Score: 1}}}},
+			{FactorCode: "mood", FactorName: "情绪", Baseline: FactorObservation{Seq: 1, Score: 2}},
+		},
+	}
+	charts := content.Charts()
+	if len(charts) != 2 || charts[0].ID != ChartSparkline || charts[1].ID != "sparkline.sleep" {
+		t.Fatalf("charts = %+v", charts)
+	}
+	primary := charts[0]
+	if primary.Title != "总分" || primary.Max != 27 || *primary.Reference != 18 || primary.Points[1].Label != "2026-03-15" {
+		t.Fatalf("primary sparkline = %+v", primary)
+	}
+	if sleep := charts[1]; len(sleep.Points) != 2 || sleep.Points[1].Value != 1 || sleep.Max != 0 {
+		t.Fatalf("factor sparkline = %+v", sleep)
+	}
+}
@@ -0,0 +1,51 @@
+// Package planreport 纵向计划报告：把同一计划参与轮次内的全部冻结测评结果合成一份报告。
+//
+// 报告在参与轮次关闭（最后一个任务完成或过期）后自动生成，也可由后台操作者按需生成。
+// 每次生成都把所用的测评结果 ID、版本令牌与任务履约计数冻结为输入快照，
+// 以快照指纹去重：同一组结果重复生成只返回已有报告，新结果到达后才会产生新的一份。
+// 报告内容由 rendering.Registry 中注册的纵向构建器产出，与单次测评报告使用同一套因子组装规则。
+package planreport
+
+import (
+	"context"
+
+	domainplanreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/planreport"
+)
+
+type (
+	Trigger           = domainplanreport.Trigger
+	Report            = domainplanreport.Report
+	FrozenInput       = domainplanreport.FrozenInput
+	FrozenOutcome     = domainplanreport.FrozenOutcome
+	Content           = domainplanreport.Content
+	Score             = domainplanreport.Score
+	Level             = domainplanreport.Level
+	Adherence         = domainplanreport.Adherence
+	Timepoint         = domainplanreport.Timepoint
+	ScoreChange       = domainplanreport.ScoreChange
+	FactorObservation = domainplanreport.FactorObservation
+	FactorFollowUp    = domainplanreport.FactorFollowUp
+	FactorTrajectory  = domainplanreport.FactorTrajectory
+)
+
+const (
+	TriggerEnrollmentClosed = domainplanreport.TriggerEnrollmentClosed
+	TriggerOnDemand         = domainplanreport.TriggerOnDemand
+
+	// ChartSparkline 纵向图表 ID 前缀，见 Content.Charts。
+	ChartSparkline = domainplanreport.ChartSparkline
+)
+
+// Actor 按需生成或查看报告的后台操作者。
+type Actor struct {
+	OrgID          int64
+	OperatorUserID int64
+}
+
+// ClinicianAccess 校验操作者可以查看受试者的报告。
+type ClinicianAccess interface {
+	AuthorizeParticipant(ctx context.Context, actor Actor, testeeID uint64) error
+}
+
+// Store 纵向报告存储。
+type Store = domainplanreport.Repository
@@ -0,0 +1,268 @@
+package regeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/admission"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/pkg/retrygovernance"
+)
+
+const (
+	runningBatchScan = 20
+	// processingRecheck is how long an item waits before the processor looks
+	// at a generation another worker is still running.
+	processingRecheck = time.Minute
+)
+
+// Processor advances running batches. Every Outcome goes through the normal
+// automation use case, so generations keep their lease, retry decisions and
+// idempotency; the processor only decides when to ask again.
+type Processor interface {
+	ProcessOnce(ctx context.Context, limit int) (int, error)
+}
+
+type processor struct {
+	batches    domainregeneration.BatchRepository
+	items      domainregeneration.ItemRepository
+	candidates domainregeneration.CandidateReader
+	automation automation.Service
+	reports    domainreport.ReportRepository
+	tx         apptransaction.Runner
+	now        func() time.Time
+}
+
+func NewProcessor(
+	batches domainregeneration.BatchRepository,
+	items domainregeneration.ItemRepository,
+	candidates domainregeneration.CandidateReader,
+	automationService automation.Service,
+	reports domainreport.ReportRepository,
+	tx apptransaction.Runner,
+) (Processor, error) {
+	if batches == nil || items == nil || candidates == nil || automationService == nil || reports == nil || tx == nil {
+		return nil, fmt.Errorf("report regeneration processor dependencies are required")
+	}
+	return &processor{
+		batches: batches, items: items, candidates: candidates, automation: automationService, reports: reports, tx: tx,
+		now: time.Now,
+	}, nil
+}
+
+// ProcessOnce spends at most limit generation calls across running batches,
+// oldest first. A transient error stops the tick without advancing the batch
+// that hit it.
+func (p *processor) ProcessOnce(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+	batches, err := p.batches.ListRunning(ctx, runningBatchScan)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, batch := range batches {
+		if processed >= limit {
+			break
+		}
+		count, err := p.processBatch(ctx, batch, limit-processed)
+		processed += count
+		if err != nil {
+			return processed, err
+		}
+	}
+	return processed, nil
+}
+
+func (p *processor) processBatch(ctx context.Context, batch *domainregeneration.Batch, budget int) (int, error) {
+	processed := 0
+	due, err := p.items.ListPending(ctx, batch.ID(), p.now(), budget)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range due {
+		saved, err := p.redrive(ctx, batch, item)
+		processed++
+		if err != nil || !saved {
+			return processed, err
+		}
+	}
+	if processed >= budget {
+		return processed, nil
+	}
+	candidates, err := p.candidates.ListAfter(ctx, batch.Selector(), batch.TargetVersion(), batch.Cursor(), budget-processed)
+	if err != nil {
+		return processed, err
+	}
+	for _, candidate := range candidates {
+		saved, err := p.selectCandidate(ctx, batch, candidate)
+		processed++
+		if err != nil || !saved {
+			return processed, err
+		}
+	}
+	if len(candidates) == 0 && batch.Progress().Pending == 0 {
+		expected := batch.Version()
+		if err := batch.Complete(p.now()); err != nil {
+			return processed, err
+		}
+		if _, err := p.saveBatch(ctx, batch, expected, nil); err != nil {
+			return processed, err
+		}
+		logger.L(ctx).Infow("报告批量重生成完成",
+			"action", "complete_report_regeneration",
+			"batch_id", batch.ID().String(),
+			"generated", batch.Progress().Generated,
+			"skipped", batch.Progress().Skipped,
+			"failed", batch.Progress().Failed,
+		)
+	}
+	return processed, nil
+}
+
+func (p *processor) selectCandidate(ctx context.Context, batch *domainregeneration.Batch, candidate domainregeneration.Candidate) (bool, error) {
+	item, err := domainregeneration.NewItem(batch.ID(), candidate, p.now())
+	if err != nil {
+		return false, err
+	}
+	if err := p.generate(ctx, batch, item); err != nil {
+		return false, err
+	}
+	expected := batch.Version()
+	if err := batch.RecordItem(item.OutcomeID(), item.Status(), p.now()); err != nil {
+		return false, err
+	}
+	return p.saveBatch(ctx, batch, expected, func(txCtx context.Context) error {
+		return p.items.Insert(txCtx, item)
+	})
+}
+
+func (p *processor) redrive(ctx context.Context, batch *domainregeneration.Batch, item *domainregeneration.Item) (bool, error) {
+	if err := p.generate(ctx, batch, item); err != nil {
+		return false, err
+	}
+	if item.Status() == domainregeneration.ItemStatusPending {
+		return true, p.items.Save(ctx, item)
+	}
+	expected := batch.Version()
+	if err := batch.ResolveItem(item.Status(), p.now()); err != nil {
+		return false, err
+	}
+	return p.saveBatch(ctx, batch, expected, func(txCtx context.Context) error {
+		return p.items.Save(txCtx, item)
+	})
+}
+
+// saveBatch stores the batch with its item in one transaction. A version
+// conflict means the batch was cancelled meanwhile; the generation already
+// requested stays valid and the item is simply not recorded.
+func (p *processor) saveBatch(ctx context.Context, batch *domainregeneration.Batch, expected uint64, withItem func(context.Context) error) (bool, error) {
+	err := p.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if withItem != nil {
+			if err := withItem(txCtx); err != nil {
+				return err
+			}
+		}
+		return p.batches.Save(txCtx, batch, expected)
+	})
+	if errors.Is(err, domainregeneration.ErrBatchConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// generate asks the automation use case for the target generation and moves
+// the item accordingly. A blocked generation whose automatic retry is due is
+// asked again with the same authorization the retry event would carry.
+func (p *processor) generate(ctx context.Context, batch *domainregeneration.Batch, item *domainregeneration.Item) error {
+	command := automation.GenerateCommand{
+		Actor: automation.TrustedServiceActor(regenerationActor), OutcomeID: item.OutcomeID(),
+		TraceID:    "report-regeneration:" + batch.ID().String(),
+		TemplateID: batch.TemplateID(), TemplateVersion: batch.TargetVersion(),
+	}
+	result, err := p.automation.Generate(ctx, command)
+	if err == nil && result != nil && result.Status == automation.StatusBlocked && automaticRetryDue(result.RetryDecision, p.now()) {
+		result, err = p.automation.Generate(retrygovernance.WithAuthorization(ctx, retrygovernance.Authorization{
+			EventID: result.RetryDecision.RetryEventID, ExpectedAttempt: result.Attempt, Origin: retrygovernance.AttemptOriginAutomatic,
+		}), command)
+	}
+	return p.apply(ctx, item, result, err)
+}
+
+func (p *processor) apply(ctx context.Context, item *domainregeneration.Item, result *automation.Result, err error) error {
+	now := p.now()
+	if err != nil {
+		if errors.Is(err, automation.ErrTemplateMismatch) {
+			return item.MarkSkipped(err.Error(), now)
+		}
+		if rejected, ok := admission.RejectedFrom(err); ok && rejected.Failure != nil {
+			if rejected.Failure.Retryable() {
+				return err
+			}
+			return item.MarkFailed(rejected.Failure.Code()+": "+rejected.Failure.SafeMessage(), now)
+		}
+		failure, durable := automation.FailureFrom(err)
+		if !durable {
+			return err
+		}
+		if retryAt, ok := automaticRetryAt(failure.RetryDecision); ok {
+			return item.Accept(failure.GenerationID, retryAt, failure.Code, now)
+		}
+		return item.MarkFailed(failure.Code+": "+failure.SafeMessage, now)
+	}
+	if result == nil {
+		return fmt.Errorf("report regeneration received no generation result")
+	}
+	switch result.Status {
+	case automation.StatusGenerated:
+		diff, err := p.diff(ctx, item, result)
+		if err != nil {
+			return err
+		}
+		return item.MarkGenerated(result.GenerationID, result.ReportID, diff, now)
+	case automation.StatusProcessing:
+		return item.Accept(result.GenerationID, now.Add(processingRecheck), "processing", now)
+	case automation.StatusBlocked:
+		if retryAt, ok := automaticRetryAt(result.RetryDecision); ok {
+			return item.Accept(result.GenerationID, retryAt, "waiting for retry", now)
+		}
+		disposition := "unknown"
+		if result.RetryDecision != nil {
+			disposition = string(result.RetryDecision.Disposition)
+		}
+		return item.MarkFailed("retry disposition "+disposition+" requires a governed retry of the generation", now)
+	default:
+		return fmt.Errorf("unsupported automation status %s", result.Status)
+	}
+}
+
+func (p *processor) diff(ctx context.Context, item *domainregeneration.Item, result *automation.Result) (domainregeneration.DiffSummary, error) {
+	previous, err := p.reports.FindByID(ctx, item.PreviousReportID())
+	if err != nil {
+		return domainregeneration.DiffSummary{}, fmt.Errorf("load previous report for regeneration diff: %w", err)
+	}
+	regenerated, err := p.reports.FindByID(ctx, result.ReportID)
+	if err != nil {
+		return domainregeneration.DiffSummary{}, fmt.Errorf("load regenerated report for regeneration diff: %w", err)
+	}
+	return domainregeneration.SummarizeChanges(domainreport.DiffContent(previous.Content(), regenerated.Content())), nil
+}
+
+func automaticRetryAt(decision *retrygovernance.Decision) (time.Time, bool) {
+	if decision == nil || decision.Disposition != retrygovernance.DispositionAutomatic || decision.NextAttemptAt == nil {
+		return time.Time{}, false
+	}
+	return *decision.NextAttemptAt, true
+}
+
+func automaticRetryDue(decision *retrygovernance.Decision, now time.Time) bool {
+	retryAt, ok := automaticRetryAt(decision)
+	return ok && decision.RetryEventID != "" && decision.ActionRequestID == "" && !retryAt.After(now)
+}
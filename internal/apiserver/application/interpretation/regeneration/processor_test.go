@@ -0,0 +1,400 @@
+package regeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"github.com/FangcunMount/qs-server/internal/pkg/retrygovernance"
+)
+
+type memoryBatches struct {
+	items map[meta.ID]*domainregeneration.Batch
+	saved map[meta.ID]uint64
+}
+
+func newMemoryBatches() *memoryBatches {
+	return &memoryBatches{items: map[meta.ID]*domainregeneration.Batch{}, saved: map[meta.ID]uint64{}}
+}
+
+func (r *memoryBatches) Create(_ context.Context, batch *domainregeneration.Batch) error {
+	for _, item := range r.items {
+		if batch.RequestID() != "" && item.RequestID() == batch.RequestID() {
+			return domainregeneration.ErrBatchConflict
+		}
+	}
+	r.items[batch.ID()] = batch
+	r.saved[batch.ID()] = batch.Version()
+	return nil
+}
+
+func (r *memoryBatches) Save(_ context.Context, batch *domainregeneration.Batch, expectedVersion uint64) error {
+	if r.saved[batch.ID()] != expectedVersion {
+		return domainregeneration.ErrBatchConflict
+	}
+	r.items[batch.ID()] = batch
+	r.saved[batch.ID()] = batch.Version()
+	return nil
+}
+
+func (r *memoryBatches) FindByID(_ context.Context, id meta.ID) (*domainregeneration.Batch, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domainregeneration.ErrBatchNotFound
+	}
+	return item, nil
+}
+
+func (r *memoryBatches) FindByRequestID(_ context.Context, requestID string) (*domainregeneration.Batch, error) {
+	for _, item := range r.items {
+		if item.RequestID() == requestID {
+			return item, nil
+		}
+	}
+	return nil, domainregeneration.ErrBatchNotFound
+}
+
+func (r *memoryBatches) ListByOrg(_ context.Context, orgID int64, _ int) ([]*domainregeneration.Batch, error) {
+	items := make([]*domainregeneration.Batch, 0)
+	for _, item := range r.items {
+		if item.Selector().OrgID == orgID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *memoryBatches) ListRunning(_ context.Context, _ int) ([]*domainregeneration.Batch, error) {
+	items := make([]*domainregeneration.Batch, 0)
+	for _, item := range r.items {
+		if item.Status() == domainregeneration.BatchStatusRunning {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+type memoryItems struct {
+	items map[meta.ID]*domainregeneration.Item
+}
+
+func (r *memoryItems) Insert(_ context.Context, item *domainregeneration.Item) error {
+	if _, ok := r.items[item.OutcomeID()]; ok {
+		return domainregeneration.ErrItemConflict
+	}
+	r.items[item.OutcomeID()] = item
+	return nil
+}
+
+func (r *memoryItems) Save(_ context.Context, item *domainregeneration.Item) error {
+	r.items[item.OutcomeID()] = item
+	return nil
+}
+
+func (r *memoryItems) ListPending(_ context.Context, _ meta.ID, dueAt time.Time, limit int) ([]*domainregeneration.Item, error) {
+	items := make([]*domainregeneration.Item, 0)
+	for _, item := range r.items {
+		if item.Status() == domainregeneration.ItemStatusPending && item.RetryAt() != nil && !item.RetryAt().After(dueAt) && len(items) < limit {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *memoryItems) ListByBatch(_ context.Context, _ meta.ID, status domainregeneration.ItemStatus, _ int) ([]*domainregeneration.Item, error) {
+	items := make([]*domainregeneration.Item, 0)
+	for _, item := range r.items {
+		if status == "" || item.Status() == status {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+type staticCandidates struct {
+	candidates []domainregeneration.Candidate
+	onTarget   int64
+}
+
+func (c *staticCandidates) Count(context.Context, domainregeneration.Selector, policy.TemplateVersion) (domainregeneration.Counts, error) {
+	return domainregeneration.Counts{Matched: int64(len(c.candidates)) + c.onTarget, OnTarget: c.onTarget}, nil
+}
+
+func (c *staticCandidates) ListAfter(_ context.Context, _ domainregeneration.Selector, _ policy.TemplateVersion, after meta.ID, limit int) ([]domainregeneration.Candidate, error) {
+	items := make([]domainregeneration.Candidate, 0)
+	for _, candidate := range c.candidates {
+		if candidate.OutcomeID.Uint64() > after.Uint64() && len(items) < limit {
+			items = append(items, candidate)
+		}
+	}
+	sort.Slice(items, func(left, right int) bool { return items[left].OutcomeID.Uint64() < items[right].OutcomeID.Uint64() })
+	return items, nil
+}
+
+type scriptedAutomation struct {
+	generate func(ctx context.Context, command automation.GenerateCommand) (*automation.Result, error)
+	commands []automation.GenerateCommand
+}
+
+func (s *scriptedAutomation) Generate(ctx context.Context, command automation.GenerateCommand) (*automation.Result, error) {
+	s.commands = append(s.commands, command)
+	return s.generate(ctx, command)
+}
+
+type memoryReports struct {
+	domainreport.ReportRepository
+	items map[meta.ID]*domainreport.InterpretReport
+}
+
+func (r *memoryReports) FindByID(_ context.Context, id meta.ID) (*domainreport.InterpretReport, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, fmt.Errorf("report %s not found", id)
+	}
+	return item, nil
+}
+
+func testReport(t *testing.T, id, outcomeID uint64, version policy.TemplateVersion, conclusion string) *domainreport.InterpretReport {
+	t.Helper()
+	report, err := domainreport.NewInterpretReport(domainreport.InterpretReportInput{
+		ID: meta.FromUint64(id), GenerationID: meta.FromUint64(id + 1000), OutcomeID: meta.FromUint64(outcomeID),
+		InterpretationRunID: meta.FromUint64(id + 2000),
+		Association:         domainreport.Association{OrgID: 7, AssessmentID: meta.FromUint64(3), TesteeID: 8},
+		ReportType:          policy.ReportTypeStandard, TemplateVersion: version,
+		BuilderIdentity: domainreport.BuilderIdentityFactorScoring, ContentSchemaVersion: domainreport.ContentSchemaVersionV1,
+		Content: domainreport.Content{
+			Model:        domainreport.ModelIdentity{Kind: "scale", Code: "PHQ9", Version: "v1"},
+			PrimaryScore: domainreport.NewRawTotalScore(8, nil),
+			Conclusion:   conclusion,
+			Dimensions: []domainreport.DimensionInterpret{
+				domainreport.NewDimensionInterpret(domainreport.NewFactorCode("TOTAL"), "总分", 8, nil, domainreport.RiskLevelLow, "ok", "ok"),
+			},
+		},
+		GeneratedAt: time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+type processorFixture struct {
+	batches    *memoryBatches
+	items      *memoryItems
+	candidates *staticCandidates
+	automation *scriptedAutomation
+	reports    *memoryReports
+	processor  *processor
+	batch      *domainregeneration.Batch
+	now        time.Time
+}
+
+func newProcessorFixture(t *testing.T, outcomes ...uint64) *processorFixture {
+	t.Helper()
+	fixture := &processorFixture{
+		batches:    newMemoryBatches(),
+		items:      &memoryItems{items: map[meta.ID]*domainregeneration.Item{}},
+		candidates: &staticCandidates{},
+		automation: &scriptedAutomation{},
+		reports:    &memoryReports{items: map[meta.ID]*domainreport.InterpretReport{}},
+		now:        time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	}
+	for _, outcome := range outcomes {
+		fixture.candidates.candidates = append(fixture.candidates.candidates, domainregeneration.Candidate{
+			OutcomeID: meta.FromUint64(outcome), OrgID: 7, AssessmentID: meta.FromUint64(3), TesteeID: 8,
+			PreviousReportID: meta.FromUint64(outcome + 100), PreviousVersion: policy.TemplateVersionV1,
+		})
+		fixture.reports.items[meta.FromUint64(outcome+100)] = testReport(t, outcome+100, outcome, policy.TemplateVersionV1, "旧结论")
+	}
+	batch, err := domainregeneration.NewBatch(domainregeneration.StartBatchInput{
+		ID: meta.FromUint64(1), Selector: domainregeneration.Selector{OrgID: 7, ModelCode: "PHQ9"}, TemplateID: "phq9",
+		TargetVersion: "custom-v2", Estimated: int64(len(outcomes)), Reason: "new wording", Actor: "user:9", At: fixture.now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.batches.Create(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	fixture.batch = batch
+	created, err := NewProcessor(fixture.batches, fixture.items, fixture.candidates, fixture.automation, fixture.reports,
+		apptransaction.RunnerFunc(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture.processor = created.(*processor)
+	fixture.processor.now = func() time.Time { return fixture.now }
+	return fixture
+}
+
+func TestProcessorRegeneratesWithDiffSkipsMismatchAndCompletes(t *testing.T) {
+	fixture := newProcessorFixture(t, 10, 11)
+	fixture.reports.items[meta.FromUint64(500)] = testReport(t, 500, 10, "custom-v2", "新结论")
+	fixture.automation.generate = func(_ context.Context, command automation.GenerateCommand) (*automation.Result, error) {
+		if command.TemplateID != "phq9" || command.TemplateVersion != "custom-v2" {
+			t.Fatalf("command pin = %s/%s", command.TemplateID, command.TemplateVersion)
+		}
+		if command.OutcomeID == meta.FromUint64(11) {
+			return nil, fmt.Errorf("%w: outcome froze %q", automation.ErrTemplateMismatch, "gad7")
+		}
+		return &automation.Result{Status: automation.StatusGenerated, GenerationID: meta.FromUint64(400), ReportID: meta.FromUint64(500)}, nil
+	}
+
+	processed, err := fixture.processor.ProcessOnce(context.Background(), 10)
+	if err != nil || processed != 2 {
+		t.Fatalf("processed=%d err=%v", processed, err)
+	}
+	generated := fixture.items.items[meta.FromUint64(10)]
+	if generated.Status() != domainregeneration.ItemStatusGenerated || generated.ReportID() != meta.FromUint64(500) {
+		t.Fatalf("outcome 10 = %s report %s", generated.Status(), generated.ReportID())
+	}
+	if diff := generated.Diff(); diff.ChangedFields != 1 || diff.Paths[0] != "conclusion" {
+		t.Fatalf("diff = %+v", diff)
+	}
+	if skipped := fixture.items.items[meta.FromUint64(11)]; skipped.Status() != domainregeneration.ItemStatusSkipped {
+		t.Fatalf("outcome 11 = %s", skipped.Status())
+	}
+
+	if _, err := fixture.processor.ProcessOnce(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	batch := fixture.batches.items[fixture.batch.ID()]
+	if batch.Status() != domainregeneration.BatchStatusCompleted {
+		t.Fatalf("batch status = %s", batch.Status())
+	}
+	if progress := batch.Progress(); progress.Selected != 2 || progress.Generated != 1 || progress.Skipped != 1 {
+		t.Fatalf("progress = %+v", progress)
+	}
+}
+
+func TestProcessorRedrivesDueAutomaticRetryWithAuthorization(t *testing.T) {
+	fixture := newProcessorFixture(t, 10)
+	fixture.reports.items[meta.FromUint64(500)] = testReport(t, 500, 10, "custom-v2", "旧结论")
+	retryAt := fixture.now.Add(time.Minute)
+	blocked := &automation.Result{
+		Status: automation.StatusBlocked, GenerationID: meta.FromUint64(400), Attempt: 1,
+		RetryDecision: &retrygovernance.Decision{Disposition: retrygovernance.DispositionAutomatic, NextAttemptAt: &retryAt, RetryEventID: "evt-1"},
+	}
+	fixture.automation.generate = func(ctx context.Context, _ automation.GenerateCommand) (*automation.Result, error) {
+		if authorization, ok := retrygovernance.AuthorizationFromContext(ctx); ok {
+			if authorization.EventID != "evt-1" || authorization.ExpectedAttempt != 1 || authorization.Origin != retrygovernance.AttemptOriginAutomatic {
+				t.Fatalf("authorization = %+v", authorization)
+			}
+			return &automation.Result{Status: automation.StatusGenerated, GenerationID: meta.FromUint64(400), ReportID: meta.FromUint64(500)}, nil
+		}
+		return blocked, nil
+	}
+
+	if _, err := fixture.processor.ProcessOnce(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	item := fixture.items.items[meta.FromUint64(10)]
+	if item.Status() != domainregeneration.ItemStatusPending || item.RetryAt() == nil || !item.RetryAt().Equal(retryAt) {
+		t.Fatalf("item = %s retry=%v", item.Status(), item.RetryAt())
+	}
+	if _, err := fixture.processor.ProcessOnce(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.automation.commands) != 1 {
+		t.Fatalf("generation asked %d times before the retry window", len(fixture.automation.commands))
+	}
+
+	fixture.now = retryAt.Add(time.Second)
+	if _, err := fixture.processor.ProcessOnce(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	if item := fixture.items.items[meta.FromUint64(10)]; item.Status() != domainregeneration.ItemStatusGenerated {
+		t.Fatalf("item = %s after due retry", item.Status())
+	}
+	if progress := fixture.batches.items[fixture.batch.ID()].Progress(); progress.Pending != 0 || progress.Generated != 1 {
+		t.Fatalf("progress = %+v", progress)
+	}
+}
+
+func TestProcessorStopsQuietlyWhenBatchChangesMeanwhile(t *testing.T) {
+	fixture := newProcessorFixture(t, 10, 11)
+	concurrent := fixture.batch.Version() + 1
+	fixture.automation.generate = func(context.Context, automation.GenerateCommand) (*automation.Result, error) {
+		fixture.batches.saved[fixture.batch.ID()] = concurrent
+		return &automation.Result{Status: automation.StatusProcessing, GenerationID: meta.FromUint64(400)}, nil
+	}
+
+	processed, err := fixture.processor.ProcessOnce(context.Background(), 10)
+	if err != nil || processed != 1 {
+		t.Fatalf("processed=%d err=%v, want a quiet stop after the first outcome", processed, err)
+	}
+	if fixture.batches.saved[fixture.batch.ID()] != concurrent {
+		t.Fatal("processor overwrote a batch changed by another writer")
+	}
+}
+
+type publishedTemplates struct {
+	domainreporttemplate.Repository
+}
+
+func (publishedTemplates) FindPublished(_ context.Context, templateID string, version policy.TemplateVersion) (*domainreporttemplate.ReportTemplate, error) {
+	if templateID != "phq9" {
+		return nil, domainreporttemplate.ErrNotFound
+	}
+	return nil, nil
+}
+
+type staticRollouts struct {
+	domainreporttemplate.RolloutRepository
+	items []*domainreporttemplate.Rollout
+}
+
+func (r staticRollouts) ListByTemplateID(context.Context, string, int) ([]*domainreporttemplate.Rollout, error) {
+	return r.items, nil
+}
+
+func TestServiceStartIsIdempotentPerRequestAndRejectsStagedTarget(t *testing.T) {
+	batches := newMemoryBatches()
+	candidates := &staticCandidates{
+		candidates: []domainregeneration.Candidate{{OutcomeID: meta.FromUint64(10)}, {OutcomeID: meta.FromUint64(11)}},
+		onTarget:   1,
+	}
+	svc := NewService(batches, &memoryItems{items: map[meta.ID]*domainregeneration.Item{}}, candidates, publishedTemplates{}, staticRollouts{}).(*service)
+	command := StartCommand{
+		Actor: Actor{OperatorUserID: 9}, RequestID: "req-1", Selector: domainregeneration.Selector{OrgID: 7, ModelCode: "PHQ9"},
+		TemplateID: "phq9", TargetVersion: "custom-v2", Reason: "new wording",
+	}
+
+	first, err := svc.Start(context.Background(), command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Estimated() != 2 || first.RequestedBy() != "user:9" {
+		t.Fatalf("estimated=%d requested_by=%s", first.Estimated(), first.RequestedBy())
+	}
+	again, err := svc.Start(context.Background(), command)
+	if err != nil || again.ID() != first.ID() || len(batches.items) != 1 {
+		t.Fatalf("repeated start = %v, %v (batches=%d)", again, err, len(batches.items))
+	}
+	if _, err := svc.GetBatch(context.Background(), 8, first.ID()); !errors.Is(err, domainregeneration.ErrBatchNotFound) {
+		t.Fatalf("other org read err = %v", err)
+	}
+
+	rollout, err := domainreporttemplate.NewRollout(domainreporttemplate.StartRolloutInput{
+		ID: meta.FromUint64(2), TemplateID: "phq9", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: domainreporttemplate.RolloutModeCanary, Percent: 10, Reason: "pilot", Actor: "user:1", At: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.rollouts = staticRollouts{items: []*domainreporttemplate.Rollout{rollout}}
+	command.RequestID = "req-2"
+	if _, err := svc.Start(context.Background(), command); !errors.Is(err, domainregeneration.ErrBatchConflict) {
+		t.Fatalf("staged target err = %v, want conflict", err)
+	}
+}
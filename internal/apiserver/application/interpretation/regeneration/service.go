@@ -0,0 +1,250 @@
+package regeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	defaultListLimit  = 50
+	maxListLimit      = 200
+	rolloutScanLimit  = 100
+	regenerationActor = "report-regeneration"
+)
+
+type Actor struct {
+	OperatorUserID int64
+}
+
+// DryRunCommand counts what a batch with the same selection would regenerate.
+type DryRunCommand struct {
+	Selector      domainregeneration.Selector
+	TemplateID    string
+	TargetVersion policy.TemplateVersion
+}
+
+type DryRunResult struct {
+	Matched      int64
+	OnTarget     int64
+	ToRegenerate int64
+}
+
+// StartCommand starts a batch. RequestID makes the start idempotent per
+// governed action request.
+type StartCommand struct {
+	Actor         Actor
+	RequestID     string
+	Selector      domainregeneration.Selector
+	TemplateID    string
+	TargetVersion policy.TemplateVersion
+	Reason        string
+}
+
+// CancelCommand stops a running batch. ExpectedVersion guards against
+// cancelling a batch whose progress the operator has not seen.
+type CancelCommand struct {
+	Actor           Actor
+	OrgID           int64
+	BatchID         meta.ID
+	ExpectedVersion uint64
+	Reason          string
+}
+
+type Service interface {
+	DryRun(ctx context.Context, command DryRunCommand) (*DryRunResult, error)
+	Start(ctx context.Context, command StartCommand) (*domainregeneration.Batch, error)
+	Cancel(ctx context.Context, command CancelCommand) (*domainregeneration.Batch, error)
+	GetBatch(ctx context.Context, orgID int64, batchID meta.ID) (*domainregeneration.Batch, error)
+	ListBatches(ctx context.Context, orgID int64, limit int) ([]*domainregeneration.Batch, error)
+	ListItems(ctx context.Context, orgID int64, batchID meta.ID, status domainregeneration.ItemStatus, limit int) ([]*domainregeneration.Item, error)
+}
+
+type service struct {
+	batches    domainregeneration.BatchRepository
+	items      domainregeneration.ItemRepository
+	candidates domainregeneration.CandidateReader
+	templates  domainreporttemplate.Repository
+	rollouts   domainreporttemplate.RolloutRepository
+	now        func() time.Time
+	newID      func() meta.ID
+}
+
+func NewService(
+	batches domainregeneration.BatchRepository,
+	items domainregeneration.ItemRepository,
+	candidates domainregeneration.CandidateReader,
+	templates domainreporttemplate.Repository,
+	rollouts domainreporttemplate.RolloutRepository,
+) Service {
+	return &service{
+		batches: batches, items: items, candidates: candidates, templates: templates, rollouts: rollouts,
+		now: time.Now, newID: meta.New,
+	}
+}
+
+func (s *service) configured() error {
+	if s == nil || s.batches == nil || s.items == nil || s.candidates == nil || s.templates == nil || s.rollouts == nil {
+		return fmt.Errorf("report regeneration service is not configured")
+	}
+	return nil
+}
+
+func (s *service) DryRun(ctx context.Context, command DryRunCommand) (*DryRunResult, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	selector, err := s.validateTarget(ctx, command.Selector, command.TemplateID, command.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.candidates.Count(ctx, selector, command.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &DryRunResult{Matched: counts.Matched, OnTarget: counts.OnTarget, ToRegenerate: counts.ToRegenerate()}, nil
+}
+
+// Start validates the target release and records the dry-run estimate. The
+// batch only selects Outcomes; the scheduled processor generates them.
+func (s *service) Start(ctx context.Context, command StartCommand) (*domainregeneration.Batch, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	if command.Actor.OperatorUserID == 0 {
+		return nil, fmt.Errorf("operator identity is required")
+	}
+	if command.RequestID != "" {
+		existing, err := s.batches.FindByRequestID(ctx, command.RequestID)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, domainregeneration.ErrBatchNotFound) {
+			return nil, err
+		}
+	}
+	selector, err := s.validateTarget(ctx, command.Selector, command.TemplateID, command.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.candidates.Count(ctx, selector, command.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	if counts.ToRegenerate() == 0 {
+		return nil, fmt.Errorf("%w: no outcome matches the selection", domainregeneration.ErrBatchConflict)
+	}
+	batch, err := domainregeneration.NewBatch(domainregeneration.StartBatchInput{
+		ID: s.newID(), RequestID: command.RequestID, Selector: selector, TemplateID: command.TemplateID,
+		TargetVersion: command.TargetVersion, Estimated: counts.ToRegenerate(), Reason: command.Reason,
+		Actor: fmt.Sprintf("user:%d", command.Actor.OperatorUserID), At: s.now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.batches.Create(ctx, batch); err != nil {
+		if errors.Is(err, domainregeneration.ErrBatchConflict) && command.RequestID != "" {
+			return s.batches.FindByRequestID(ctx, command.RequestID)
+		}
+		return nil, err
+	}
+	return batch, nil
+}
+
+// validateTarget requires a published target release that no active rollout
+// is still staging: regenerating history under a canary candidate would
+// bypass the rollout's own selection.
+func (s *service) validateTarget(ctx context.Context, selector domainregeneration.Selector, templateID string, target policy.TemplateVersion) (domainregeneration.Selector, error) {
+	normalized, err := selector.Normalize()
+	if err != nil {
+		return domainregeneration.Selector{}, err
+	}
+	if templateID == "" || target.IsEmpty() {
+		return domainregeneration.Selector{}, fmt.Errorf("report regeneration template_id and target_version are required")
+	}
+	if _, err := s.templates.FindPublished(ctx, templateID, target); err != nil {
+		return domainregeneration.Selector{}, err
+	}
+	rollouts, err := s.rollouts.ListByTemplateID(ctx, templateID, rolloutScanLimit)
+	if err != nil {
+		return domainregeneration.Selector{}, err
+	}
+	for _, rollout := range rollouts {
+		if rollout.Status() == domainreporttemplate.RolloutStatusActive && rollout.CandidateVersion() == target {
+			return domainregeneration.Selector{}, fmt.Errorf("%w: target release is staged by active rollout %s",
+				domainregeneration.ErrBatchConflict, rollout.ID())
+		}
+	}
+	return normalized, nil
+}
+
+func (s *service) Cancel(ctx context.Context, command CancelCommand) (*domainregeneration.Batch, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	if command.Actor.OperatorUserID == 0 {
+		return nil, fmt.Errorf("operator identity is required")
+	}
+	batch, err := s.GetBatch(ctx, command.OrgID, command.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Version() != command.ExpectedVersion {
+		return nil, domainregeneration.ErrBatchConflict
+	}
+	if err := batch.Cancel(fmt.Sprintf("user:%d", command.Actor.OperatorUserID), command.Reason, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.batches.Save(ctx, batch, command.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetBatch hides batches of other organizations behind not found.
+func (s *service) GetBatch(ctx context.Context, orgID int64, batchID meta.ID) (*domainregeneration.Batch, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	batch, err := s.batches.FindByID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Selector().OrgID != orgID {
+		return nil, domainregeneration.ErrBatchNotFound
+	}
+	return batch, nil
+}
+
+func (s *service) ListBatches(ctx context.Context, orgID int64, limit int) ([]*domainregeneration.Batch, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	return s.batches.ListByOrg(ctx, orgID, normalizeLimit(limit))
+}
+
+func (s *service) ListItems(ctx context.Context, orgID int64, batchID meta.ID, status domainregeneration.ItemStatus, limit int) ([]*domainregeneration.Item, error) {
+	if _, err := s.GetBatch(ctx, orgID, batchID); err != nil {
+		return nil, err
+	}
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("report regeneration item status is invalid: %s", status)
+	}
+	return s.items.ListByBatch(ctx, batchID, status, normalizeLimit(limit))
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
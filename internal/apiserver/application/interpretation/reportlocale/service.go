@@ -0,0 +1,144 @@
+// Package reportlocale renders immutable reports in another content locale on
+// demand. A rendition uses the report's own frozen input, template version and
+// builder; only the interpretation assets are swapped for the requested
+// locale. Renditions are stored once and reused.
+package reportlocale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	interpretationinput "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	domainoutcome "github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Store 持久化并读取按需渲染的语言正文。
+type Store interface {
+	domainreport.LocalizedReportRepository
+	interpretationreadmodel.LocalizedReportReader
+}
+
+type service struct {
+	store     Store
+	reports   domainreport.ReportRepository
+	outcomes  domainoutcome.Repository
+	builders  rendering.Registry
+	manifests domainreporttemplate.ManifestCatalog
+	now       func() time.Time
+}
+
+var _ reportprojection.LocalizedReportSource = (*service)(nil)
+
+// NewService 创建按需语言渲染服务，作为 reportprojection.Mapper 的 Locales 使用。
+func NewService(
+	store Store,
+	reports domainreport.ReportRepository,
+	outcomes domainoutcome.Repository,
+	builders rendering.Registry,
+	manifests domainreporttemplate.ManifestCatalog,
+) reportprojection.LocalizedReportSource {
+	return &service{store: store, reports: reports, outcomes: outcomes, builders: builders, manifests: manifests, now: time.Now}
+}
+
+// LocalizedReport 返回已选受众正文的 tag 语言版本。报告的模板发布或模型译文不提供
+// 该语言时返回 nil, nil，调用方保留报告生成时的语言。
+func (s *service) LocalizedReport(ctx context.Context, row interpretationreadmodel.ReportRow, tag string) (*interpretationreadmodel.ReportRow, error) {
+	if s == nil || s.store == nil || s.reports == nil || s.outcomes == nil || s.builders == nil || s.manifests == nil {
+		return nil, fmt.Errorf("report locale service is not configured")
+	}
+	tag, err := locale.Normalize(tag)
+	if err != nil || tag == "" || row.ReportID == 0 {
+		return nil, nil
+	}
+	stored, err := s.store.FindLocalizedReport(ctx, row.ReportID, row.Audience, tag)
+	if err != nil || stored != nil {
+		return stored, err
+	}
+	localized, err := s.render(ctx, meta.FromUint64(row.ReportID), policy.ReportAudience(row.Audience), tag)
+	if err != nil || localized == nil {
+		return nil, err
+	}
+	if err := s.store.Insert(ctx, localized); err != nil {
+		if !errors.Is(err, domainreport.ErrInterpretReportAlreadyExists) {
+			return nil, err
+		}
+	} else {
+		logger.L(ctx).Infow("报告已按需渲染为其他语言",
+			"action", "render_report_locale",
+			"report_id", localized.ReportID().String(),
+			"audience", string(localized.Audience()),
+			"locale", localized.Locale(),
+			"template_version", localized.TemplateVersion().String(),
+		)
+	}
+	return s.store.FindLocalizedReport(ctx, row.ReportID, row.Audience, tag)
+}
+
+func (s *service) render(ctx context.Context, reportID meta.ID, audience policy.ReportAudience, tag string) (*domainreport.LocalizedReport, error) {
+	canonical, err := s.reports.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("load interpretation report: %w", err)
+	}
+	if canonical == nil || canonical.Locale() == tag {
+		return nil, nil
+	}
+	record, err := s.outcomes.FindByID(ctx, canonical.OutcomeID())
+	if err != nil {
+		return nil, fmt.Errorf("load evaluation outcome: %w", err)
+	}
+	input, err := interpretationinput.FromOutcomeRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	// The report's own release decides what it can render, not the release the
+	// outcome would route to today.
+	input.Report.TemplateVersion = canonical.TemplateVersion()
+	manifest, ok := s.manifests.ResolveManifest(input.Report.TemplateID, input.Report.TemplateVersion)
+	if !ok || !contains(input.LocalesIn(manifest.ContentLocales()), tag) {
+		return nil, nil
+	}
+	if !audience.IsCanonical() && !manifest.PublishesAudience(audience) {
+		return nil, nil
+	}
+	key, ok := rendering.KeyFromInput(input)
+	if !ok {
+		return nil, fmt.Errorf("report routing key is incomplete")
+	}
+	key.Audience = audience
+	builder, err := s.builders.ResolveByMechanism(key)
+	if err != nil {
+		return nil, err
+	}
+	if builder.BuilderIdentity() != canonical.BuilderIdentity() {
+		return nil, fmt.Errorf("report builder %s no longer matches %s", builder.BuilderIdentity(), canonical.BuilderIdentity())
+	}
+	draft, err := builder.Build(ctx, interpinput.Localize(input, tag))
+	if err != nil {
+		return nil, fmt.Errorf("build %s report: %w", tag, err)
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("build %s report: empty draft", tag)
+	}
+	return domainreport.NewLocalizedReport(canonical, audience, tag, draft.Content(), s.now())
+}
+
+func contains(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package reportlocale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	domainoutcome "github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type storeStub struct {
+	rows     map[string]interpretationreadmodel.ReportRow
+	inserted []*domainreport.LocalizedReport
+}
+
+func (s *storeStub) Insert(_ context.Context, localized *domainreport.LocalizedReport) error {
+	s.inserted = append(s.inserted, localized)
+	return nil
+}
+
+func (s *storeStub) FindLocalizedReport(_ context.Context, reportID uint64, audience, tag string) (*interpretationreadmodel.ReportRow, error) {
+	row, ok := s.rows[audience+"/"+tag]
+	if !ok || row.ReportID != reportID {
+		return nil, nil
+	}
+	return &row, nil
+}
+
+type reportsStub struct {
+	domainreport.ReportRepository
+	lookups int
+}
+
+func (s *reportsStub) FindByID(context.Context, meta.ID) (*domainreport.InterpretReport, error) {
+	s.lookups++
+	return nil, nil
+}
+
+type outcomesStub struct{ domainoutcome.Repository }
+
+type registryStub struct{ rendering.Registry }
+
+type manifestsStub struct{}
+
+func (manifestsStub) ResolveManifest(string, policy.TemplateVersion) (domainreporttemplate.ReleaseManifest, bool) {
+	return domainreporttemplate.ReleaseManifest{}, false
+}
+
+func newTestService(store *storeStub, reports *reportsStub) *service {
+	return NewService(store, reports, outcomesStub{}, registryStub{}, manifestsStub{}).(*service)
+}
+
+func TestLocalizedReportReusesStoredRendition(t *testing.T) {
+	store := &storeStub{rows: map[string]interpretationreadmodel.ReportRow{
+		"family/en-US": {ReportID: 11, Audience: "family", Locale: "en-US", Conclusion: "english"},
+	}}
+	reports := &reportsStub{}
+	got, err := newTestService(store, reports).LocalizedReport(context.Background(), interpretationreadmodel.ReportRow{ReportID: 11, Audience: "family"}, "en_us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Conclusion != "english" || reports.lookups != 0 {
+		t.Fatalf("stored rendition = %#v, report lookups = %d", got, reports.lookups)
+	}
+}
+
+func TestLocalizedReportSkipsUnrenderableRequests(t *testing.T) {
+	store := &storeStub{}
+	reports := &reportsStub{}
+	svc := newTestService(store, reports)
+	for _, request := range []struct {
+		row interpretationreadmodel.ReportRow
+		tag string
+	}{
+		{row: interpretationreadmodel.ReportRow{AssessmentID: 3}, tag: "en-US"},
+		{row: interpretationreadmodel.ReportRow{ReportID: 11}, tag: "not a locale"},
+		{row: interpretationreadmodel.ReportRow{ReportID: 12}, tag: "en-US"},
+	} {
+		got, err := svc.LocalizedReport(context.Background(), request.row, request.tag)
+		if err != nil || got != nil {
+			t.Fatalf("LocalizedReport(%#v, %q) = %#v, %v", request.row, request.tag, got, err)
+		}
+	}
+	if reports.lookups != 1 || len(store.inserted) != 0 {
+		t.Fatalf("report lookups = %d, inserted = %d", reports.lookups, len(store.inserted))
+	}
+}
@@ -0,0 +1,67 @@
+package reportpdf
+
+import (
+	"github.com/FangcunMount/qs-server/internal/pkg/pdfdoc"
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+// charts 按报告投影中的图表规格绘制，版式与 SVG 图表资源一致；放不下的图表整张移到下一页。
+func (l *layout) charts(specs []reportchart.Spec) {
+	if len(specs) == 0 {
+		return
+	}
+	l.section("图表")
+	theme := reportchart.ThemeFor(reportchart.Color(l.primary))
+	for _, spec := range specs {
+		width, height := reportchart.Size(spec)
+		l.ensure(height + 12)
+		l.y += 12
+		// 图表固定宽度小于正文宽度，居中放置。
+		reportchart.Draw(spec, &chartCanvas{page: l.page, x: marginX + (contentWidth-width)/2, y: l.y}, theme)
+		l.y += height
+	}
+}
+
+// chartCanvas 把图表坐标平移到页面上的 (x, y)。
+type chartCanvas struct {
+	page *pdfdoc.Page
+	x, y float64
+}
+
+func (c *chartCanvas) Rect(x, y, width, height float64, fill reportchart.Color) {
+	c.page.FillRect(c.x+x, c.y+y, width, height, pdfdoc.Color(fill))
+}
+
+func (c *chartCanvas) Line(x1, y1, x2, y2, width float64, stroke reportchart.Color) {
+	c.page.Line(c.x+x1, c.y+y1, c.x+x2, c.y+y2, width, pdfdoc.Color(stroke))
+}
+
+func (c *chartCanvas) Polyline(points []reportchart.Vec, width float64, stroke reportchart.Color) {
+	c.page.Polyline(c.points(points), width, pdfdoc.Color(stroke))
+}
+
+func (c *chartCanvas) Polygon(points []reportchart.Vec, fill reportchart.Color) {
+	c.page.Polygon(c.points(points), pdfdoc.Color(fill))
+}
+
+func (c *chartCanvas) Circle(cx, cy, r float64, fill reportchart.Color) {
+	c.page.Circle(c.x+cx, c.y+cy, r, pdfdoc.Color(fill))
+}
+
+func (c *chartCanvas) Text(x, y, size float64, color reportchart.Color, anchor reportchart.Anchor, text string) {
+	switch anchor {
+	case reportchart.AnchorMiddle:
+		x -= pdfdoc.TextWidth(text, size) / 2
+	case reportchart.AnchorEnd:
+		x -= pdfdoc.TextWidth(text, size)
+	}
+	c.page.Text(c.x+x, c.y+y, size, pdfdoc.Color(color), text)
+}
+
+func (c *chartCanvas) points(points []reportchart.Vec) []pdfdoc.Point {
+	out := make([]pdfdoc.Point, len(points))
+	for index, point := range points {
+		out[index] = pdfdoc.Point{X: c.x + point.X, Y: c.y + point.Y}
+	}
+	return out
+}
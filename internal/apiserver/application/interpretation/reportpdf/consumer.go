@@ -0,0 +1,48 @@
+package reportpdf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation"
+)
+
+// NewReportGeneratedConsumer 在 interpretation.report.generated 之后异步渲染报告 PDF。
+// 渲染按报告 ID 幂等，重复投递只会返回已有记录。
+func NewReportGeneratedConsumer(service Service) func(context.Context, string, []byte) error {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		if eventType != interpretation.EventTypeReportGenerated {
+			return nil
+		}
+		if service == nil {
+			return fmt.Errorf("report pdf service is unavailable")
+		}
+
+		env, err := eventcodec.DecodeEnvelope(payload)
+		if err != nil {
+			return err
+		}
+		var data interpretation.ReportGeneratedOutcomeData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			return fmt.Errorf("decode report generated payload: %w", err)
+		}
+		assessmentID, err := strconv.ParseUint(data.AssessmentID, 10, 64)
+		if err != nil || assessmentID == 0 {
+			return fmt.Errorf("invalid assessment id in report generated payload: %q", data.AssessmentID)
+		}
+		if data.ReportID == "" {
+			return fmt.Errorf("report id missing in report generated payload for assessment %d", assessmentID)
+		}
+		_, err = service.Render(ctx, RenderRequest{
+			ReportID:        data.ReportID,
+			OrgID:           data.OrgID,
+			AssessmentID:    assessmentID,
+			TesteeID:        data.TesteeID,
+			TemplateVersion: data.TemplateVersion,
+		})
+		return err
+	}
+}
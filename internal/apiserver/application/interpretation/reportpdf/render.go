@@ -0,0 +1,351 @@
+package reportpdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/pkg/pdfdoc"
+)
+
+// Provenance 页脚溯源信息，使打印件可以对应回生成它的模型、模板与报告内容。
+type Provenance struct {
+	ReportID        string
+	ModelCode       string
+	ModelVersion    string
+	TemplateVersion string
+	ContentHash     string
+}
+
+const (
+	defaultPrimaryColor = "#1F6FEB"
+
+	marginX       = 48.0
+	contentWidth  = pdfdoc.PageWidth - 2*marginX
+	contentTop    = 96.0
+	continuedTop  = 48.0
+	contentBottom = pdfdoc.PageHeight - 72.0
+
+	bodySize   = 10.5
+	bodyLeader = 16.0
+)
+
+var (
+	textGray  = pdfdoc.Color{R: 96, G: 96, B: 96}
+	lineGray  = pdfdoc.Color{R: 210, G: 210, B: 210}
+	trackGray = pdfdoc.Color{R: 236, G: 238, B: 241}
+)
+
+// ContentHash 报告投影内容的 SHA-256（十六进制），与渲染结果一同记录，用于核对打印件与线上报告是否一致。
+func ContentHash(report *reportprojection.Report) (string, error) {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("encode report content: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Render 将报告排版为分页 PDF：品牌页眉、得分与等级、结论、维度得分条形图、报告图表、维度说明、建议、
+// 模型附加解读，以及每页的溯源页脚。
+func Render(report *reportprojection.Report, provenance Provenance, branding Branding) []byte {
+	primary, ok := pdfdoc.ParseHexColor(branding.PrimaryColor)
+	if !ok {
+		primary, _ = pdfdoc.ParseHexColor(defaultPrimaryColor)
+	}
+	title := report.Model.Title
+	if title == "" {
+		title = "测评解读报告"
+	}
+	l := &layout{doc: pdfdoc.New(title), primary: primary}
+	l.firstPage(branding)
+
+	l.heading(title, 18)
+	l.muted(fmt.Sprintf("测评编号 %d    生成时间 %s", report.AssessmentID, report.CreatedAt.Format("2006-01-02 15:04")))
+	l.gap(8)
+	l.summary(report)
+	if conclusion := strings.TrimSpace(report.Conclusion); conclusion != "" {
+		l.section("结论")
+		l.paragraph(conclusion, pdfdoc.Black)
+	}
+	l.dimensionChart(report.Dimensions)
+	l.charts(report.Charts)
+	l.dimensionDetails(report.Dimensions)
+	if len(report.Suggestions) > 0 {
+		l.section("建议")
+		for _, suggestion := range report.Suggestions {
+			l.bullet(suggestion.Content)
+		}
+	}
+	l.modelExtra(report.ModelExtra)
+	l.footers(provenance, branding)
+	return l.doc.Bytes()
+}
+
+type layout struct {
+	doc     *pdfdoc.Document
+	page    *pdfdoc.Page
+	primary pdfdoc.Color
+	y       float64
+}
+
+func (l *layout) firstPage(branding Branding) {
+	l.page = l.doc.AddPage()
+	l.page.FillRect(0, 0, pdfdoc.PageWidth, 64, l.primary)
+	orgName := branding.OrgName
+	if orgName == "" {
+		orgName = "测评解读报告"
+	}
+	l.page.Text(marginX, 40, 16, pdfdoc.White, orgName)
+	label := "测评解读报告"
+	l.page.Text(pdfdoc.PageWidth-marginX-pdfdoc.TextWidth(label, 11), 40, 11, pdfdoc.White, label)
+	l.y = contentTop
+}
+
+// ensure 剩余高度不足时换页；续页只保留细条页眉。
+func (l *layout) ensure(height float64) {
+	if l.y+height <= contentBottom {
+		return
+	}
+	l.page = l.doc.AddPage()
+	l.page.FillRect(0, 0, pdfdoc.PageWidth, 8, l.primary)
+	l.y = continuedTop
+}
+
+func (l *layout) gap(height float64) {
+	l.y += height
+}
+
+func (l *layout) heading(text string, size float64) {
+	for _, line := range pdfdoc.Wrap(text, size, contentWidth) {
+		l.ensure(size * 1.5)
+		l.y += size * 1.2
+		l.page.Text(marginX, l.y, size, pdfdoc.Black, line)
+		l.y += size * 0.3
+	}
+}
+
+func (l *layout) muted(text string) {
+	l.ensure(bodyLeader)
+	l.y += bodyLeader
+	l.page.Text(marginX, l.y, 9, textGray, text)
+}
+
+func (l *layout) section(title string) {
+	l.ensure(40)
+	l.y += 28
+	l.page.FillRect(marginX, l.y-11, 3, 13, l.primary)
+	l.page.Text(marginX+8, l.y, 13, pdfdoc.Black, title)
+	l.y += 4
+}
+
+func (l *layout) paragraph(text string, color pdfdoc.Color) {
+	l.paragraphAt(marginX, text, color)
+}
+
+func (l *layout) paragraphAt(x float64, text string, color pdfdoc.Color) {
+	for _, line := range pdfdoc.Wrap(text, bodySize, contentWidth-(x-marginX)) {
+		l.ensure(bodyLeader)
+		l.y += bodyLeader
+		l.page.Text(x, l.y, bodySize, color, line)
+	}
+}
+
+func (l *layout) bullet(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	l.ensure(bodyLeader)
+	l.page.Text(marginX, l.y+bodyLeader, bodySize, l.primary, "·")
+	l.paragraphAt(marginX+12, text, pdfdoc.Black)
+}
+
+func (l *layout) summary(report *reportprojection.Report) {
+	if report.PrimaryScore == nil && report.Level == nil {
+		return
+	}
+	l.ensure(56)
+	top := l.y + 8
+	l.page.FillRect(marginX, top, contentWidth, 44, trackGray)
+	x := marginX + 14
+	if score := report.PrimaryScore; score != nil {
+		label := score.Label
+		if label == "" {
+			label = "总分"
+		}
+		value := formatNumber(score.Value)
+		if score.Max != nil {
+			value += " / " + formatNumber(*score.Max)
+		}
+		l.page.Text(x, top+18, 9, textGray, label)
+		l.page.Text(x, top+36, 15, pdfdoc.Black, value)
+		x += 180
+	}
+	if level := report.Level; level != nil {
+		label := level.Label
+		if label == "" {
+			label = level.Code
+		}
+		l.page.Text(x, top+18, 9, textGray, "结果等级")
+		l.page.Text(x, top+36, 15, l.primary, label)
+	}
+	l.y = top + 44
+}
+
+// dimensionChart 绘制维度得分条形图：有满分的维度按得分率，否则按各维度最高得分归一。
+func (l *layout) dimensionChart(dimensions []reportprojection.Dimension) {
+	if len(dimensions) == 0 {
+		return
+	}
+	var maxRaw float64
+	for _, dimension := range dimensions {
+		maxRaw = math.Max(maxRaw, dimension.RawScore)
+	}
+	l.section("维度得分")
+	l.gap(6)
+	const (
+		labelWidth = 130.0
+		valueWidth = 60.0
+		rowHeight  = 22.0
+		barHeight  = 10.0
+	)
+	barWidth := contentWidth - labelWidth - valueWidth
+	for _, dimension := range dimensions {
+		l.ensure(rowHeight)
+		name := dimension.FactorName
+		if name == "" {
+			name = dimension.FactorCode
+		}
+		indent := float64(max(dimension.HierarchyLevel-1, 0)) * 10
+		label := truncate(name, bodySize, labelWidth-indent-8)
+		l.page.Text(marginX+indent, l.y+14, bodySize, pdfdoc.Black, label)
+
+		ratio := 0.0
+		switch {
+		case dimension.MaxScore != nil && *dimension.MaxScore > 0:
+			ratio = dimension.RawScore / *dimension.MaxScore
+		case maxRaw > 0:
+			ratio = dimension.RawScore / maxRaw
+		}
+		ratio = math.Min(math.Max(ratio, 0), 1)
+		barX := marginX + labelWidth
+		l.page.FillRect(barX, l.y+5, barWidth, barHeight, trackGray)
+		if ratio > 0 {
+			l.page.FillRect(barX, l.y+5, barWidth*ratio, barHeight, l.primary)
+		}
+
+		value := formatNumber(dimension.RawScore)
+		if dimension.MaxScore != nil {
+			value += "/" + formatNumber(*dimension.MaxScore)
+		}
+		l.page.Text(barX+barWidth+8, l.y+14, 9, textGray, value)
+		l.y += rowHeight
+	}
+}
+
+func (l *layout) dimensionDetails(dimensions []reportprojection.Dimension) {
+	var described []reportprojection.Dimension
+	for _, dimension := range dimensions {
+		if strings.TrimSpace(dimension.Description) != "" || strings.TrimSpace(dimension.Suggestion) != "" {
+			described = append(described, dimension)
+		}
+	}
+	if len(described) == 0 {
+		return
+	}
+	l.section("维度解读")
+	for _, dimension := range described {
+		name := dimension.FactorName
+		if name == "" {
+			name = dimension.FactorCode
+		}
+		if dimension.Level != nil && dimension.Level.Label != "" {
+			name += "（" + dimension.Level.Label + "）"
+		}
+		l.ensure(bodyLeader * 2)
+		l.y += bodyLeader + 4
+		l.page.Text(marginX, l.y, 11, l.primary, name)
+		if description := strings.TrimSpace(dimension.Description); description != "" {
+			l.paragraph(description, pdfdoc.Black)
+		}
+		if suggestion := strings.TrimSpace(dimension.Suggestion); suggestion != "" {
+			l.paragraph("建议："+suggestion, textGray)
+		}
+	}
+}
+
+func (l *layout) modelExtra(extra *reportprojection.ModelExtra) {
+	if extra == nil || (extra.TypeName == "" && extra.OneLiner == "" && extra.Commentary == "") {
+		return
+	}
+	l.section("类型解读")
+	name := extra.TypeName
+	if extra.TypeCode != "" && extra.TypeCode != name {
+		name = strings.TrimSpace(name + " " + extra.TypeCode)
+	}
+	if extra.MatchPercent > 0 {
+		name += fmt.Sprintf("  匹配度 %s%%", formatNumber(extra.MatchPercent))
+	}
+	if name != "" {
+		l.ensure(bodyLeader)
+		l.y += bodyLeader + 2
+		l.page.Text(marginX, l.y, 12, l.primary, name)
+	}
+	if extra.OneLiner != "" {
+		l.paragraph(extra.OneLiner, pdfdoc.Black)
+	}
+	if extra.Commentary != "" {
+		l.paragraph(extra.Commentary, textGray)
+	}
+}
+
+// footers 在所有页面写溯源页脚与页码；必须在正文排版完成、总页数确定后调用。
+func (l *layout) footers(provenance Provenance, branding Branding) {
+	total := l.doc.PageCount()
+	model := provenance.ModelCode
+	if provenance.ModelVersion != "" {
+		model += "@" + provenance.ModelVersion
+	}
+	line1 := fmt.Sprintf("模型 %s    模板 %s    报告 %s", orDash(model), orDash(provenance.TemplateVersion), orDash(provenance.ReportID))
+	line2 := "内容哈希 sha256:" + provenance.ContentHash
+	footY := pdfdoc.PageHeight - 48
+	for index := 0; index < total; index++ {
+		page := l.doc.Page(index)
+		page.Line(marginX, footY-12, pdfdoc.PageWidth-marginX, footY-12, 0.5, lineGray)
+		page.Text(marginX, footY, 7.5, textGray, line1)
+		page.Text(marginX, footY+11, 7.5, textGray, line2)
+		if branding.Footnote != "" {
+			page.Text(marginX, footY+22, 7.5, textGray, truncate(branding.Footnote, 7.5, contentWidth-70))
+		}
+		number := fmt.Sprintf("第 %d / %d 页", index+1, total)
+		page.Text(pdfdoc.PageWidth-marginX-pdfdoc.TextWidth(number, 8), footY+22, 8, textGray, number)
+	}
+}
+
+func truncate(text string, size, width float64) string {
+	if pdfdoc.TextWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfdoc.TextWidth(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
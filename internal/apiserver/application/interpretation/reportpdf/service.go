@@ -0,0 +1,233 @@
+package reportpdf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/queryerror"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+// DefaultLinkTTL 未配置时下载链接的有效期。
+const DefaultLinkTTL = 15 * time.Minute
+
+// Service 报告 PDF 用例。
+type Service interface {
+	// Render 渲染并保存报告 PDF；同一报告已渲染时直接返回已有记录。
+	Render(ctx context.Context, req RenderRequest) (*Rendition, error)
+	// IssueClinicianLink 为后台操作者签发测评最新报告 PDF 的下载链接。
+	IssueClinicianLink(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*Link, error)
+	// IssueParticipantLink 为受试者本人签发测评最新报告 PDF 的下载链接。
+	IssueParticipantLink(ctx context.Context, testeeID, assessmentID uint64) (*Link, error)
+	// Open 校验下载令牌并打开 PDF。
+	Open(ctx context.Context, token string) (*Download, error)
+}
+
+// Config 服务配置。
+type Config struct {
+	LinkTTL  time.Duration
+	Branding BrandingResolver
+}
+
+type service struct {
+	store       Store
+	objects     ObjectStore
+	reports     interpretationreadmodel.ReportReader
+	signer      *LinkSigner
+	clinician   ClinicianAccess
+	participant ParticipantAccess
+	config      Config
+	now         func() time.Time
+}
+
+// NewService 创建报告 PDF 服务。
+func NewService(
+	store Store,
+	objects ObjectStore,
+	reports interpretationreadmodel.ReportReader,
+	signer *LinkSigner,
+	clinician ClinicianAccess,
+	participant ParticipantAccess,
+	config Config,
+) Service {
+	if config.LinkTTL <= 0 {
+		config.LinkTTL = DefaultLinkTTL
+	}
+	return &service{
+		store:       store,
+		objects:     objects,
+		reports:     reports,
+		signer:      signer,
+		clinician:   clinician,
+		participant: participant,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+func (s *service) Render(ctx context.Context, req RenderRequest) (*Rendition, error) {
+	if req.ReportID == "" || req.AssessmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "report_id and assessment_id are required")
+	}
+	existing, err := s.store.FindByReportID(ctx, req.ReportID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load report pdf rendition")
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	row, err := s.reports.GetReportByAssessmentID(ctx, req.AssessmentID)
+	if err != nil {
+		return nil, queryerror.MapReadError(err)
+	}
+	// 官方 PDF 面向受试者本人与机构存档，按受试者可见范围投影；临床补充说明在签署后另行展示，不进入 PDF。
+	report, err := reportprojection.Mapper{}.FromRow(ctx, *row, policy.AudienceParticipant)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "project report for pdf")
+	}
+	hash, err := ContentHash(report)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "hash report content")
+	}
+	provenance := Provenance{
+		ReportID:        req.ReportID,
+		ModelCode:       report.Model.Code,
+		ModelVersion:    report.Model.Version,
+		TemplateVersion: req.TemplateVersion,
+		ContentHash:     hash,
+	}
+	body := Render(report, provenance, s.branding(req.OrgID))
+
+	key := fmt.Sprintf("%s%d/%s.pdf", SubjectPrefix(req.OrgID, req.TesteeID), req.AssessmentID, req.ReportID)
+	if err := s.objects.Put(ctx, key, ContentType, body); err != nil {
+		return nil, cberrors.WrapC(err, code.ErrUnknown, "store report pdf")
+	}
+	rendition := &Rendition{
+		ReportID:        req.ReportID,
+		OrgID:           req.OrgID,
+		AssessmentID:    req.AssessmentID,
+		TesteeID:        req.TesteeID,
+		ObjectKey:       key,
+		ContentHash:     hash,
+		TemplateVersion: req.TemplateVersion,
+		ModelCode:       report.Model.Code,
+		ModelVersion:    report.Model.Version,
+		SizeBytes:       int64(len(body)),
+		ReportCreatedAt: report.CreatedAt,
+		RenderedAt:      s.now(),
+	}
+	if err := s.store.Save(ctx, rendition); err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "save report pdf rendition")
+	}
+	logger.L(ctx).Infow("report pdf rendered",
+		"action", "render_report_pdf",
+		"org_id", req.OrgID,
+		"assessment_id", req.AssessmentID,
+		"report_id", req.ReportID,
+		"size_bytes", rendition.SizeBytes,
+	)
+	return rendition, nil
+}
+
+func (s *service) IssueClinicianLink(ctx context.Context, actor Actor, testeeID, assessmentID uint64) (*Link, error) {
+	if testeeID == 0 || assessmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and assessment_id are required")
+	}
+	if s.clinician == nil {
+		return nil, cberrors.WithCode(code.ErrModuleInitializationFailed, "report pdf clinician access is not configured")
+	}
+	if err := s.clinician.AuthorizeAssessment(ctx, actor, testeeID, assessmentID); err != nil {
+		return nil, err
+	}
+	return s.issueLink(ctx, testeeID, assessmentID)
+}
+
+func (s *service) IssueParticipantLink(ctx context.Context, testeeID, assessmentID uint64) (*Link, error) {
+	if testeeID == 0 || assessmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and assessment_id are required")
+	}
+	if s.participant == nil {
+		return nil, cberrors.WithCode(code.ErrModuleInitializationFailed, "report pdf participant access is not configured")
+	}
+	if err := s.participant.AuthorizeOwnAssessment(ctx, testeeID, assessmentID); err != nil {
+		return nil, err
+	}
+	return s.issueLink(ctx, testeeID, assessmentID)
+}
+
+func (s *service) issueLink(ctx context.Context, testeeID, assessmentID uint64) (*Link, error) {
+	rendition, err := s.store.FindLatestByAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load report pdf rendition")
+	}
+	if rendition == nil || rendition.TesteeID != testeeID {
+		return nil, cberrors.WithCode(code.ErrReportPDFNotReady, "report pdf is not ready yet")
+	}
+	expiresAt := s.now().Add(s.config.LinkTTL).Truncate(time.Second)
+	return &Link{
+		Token:       s.signer.Sign(LinkClaims{AssessmentID: assessmentID, ReportID: rendition.ReportID, ExpiresAt: expiresAt}),
+		ExpiresAt:   expiresAt,
+		FileName:    fileName(assessmentID),
+		ContentHash: rendition.ContentHash,
+	}, nil
+}
+
+func (s *service) Open(ctx context.Context, token string) (*Download, error) {
+	claims, err := s.signer.Verify(strings.TrimSpace(token), s.now())
+	if err != nil {
+		return nil, cberrors.WithCode(code.ErrReportPDFLinkInvalid, "%s", err.Error())
+	}
+	rendition, err := s.store.FindByReportID(ctx, claims.ReportID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load report pdf rendition")
+	}
+	if rendition == nil || rendition.AssessmentID != claims.AssessmentID {
+		return nil, cberrors.WithCode(code.ErrReportPDFLinkInvalid, "report pdf link does not match a rendition")
+	}
+	body, size, err := s.objects.Open(ctx, rendition.ObjectKey)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrReportPDFNotReady, "open report pdf")
+	}
+	if size <= 0 {
+		size = rendition.SizeBytes
+	}
+	logger.L(ctx).Infow("report pdf downloaded",
+		"action", "download_report_pdf",
+		"org_id", rendition.OrgID,
+		"assessment_id", rendition.AssessmentID,
+		"report_id", rendition.ReportID,
+	)
+	return &Download{
+		OrgID:        rendition.OrgID,
+		TesteeID:     rendition.TesteeID,
+		AssessmentID: rendition.AssessmentID,
+		Body:         body,
+		SizeBytes:    size,
+		FileName:     fileName(rendition.AssessmentID),
+		ContentType:  ContentType,
+	}, nil
+}
+
+func (s *service) branding(orgID int64) Branding {
+	if s.config.Branding == nil {
+		return Branding{}
+	}
+	return s.config.Branding.Branding(orgID)
+}
+
+// SubjectPrefix 受试者报告 PDF 在对象存储中的前缀，数据主体擦除时整体删除。
+func SubjectPrefix(orgID int64, testeeID uint64) string {
+	return fmt.Sprintf("report-pdf/%d/%d/", orgID, testeeID)
+}
+
+func fileName(assessmentID uint64) string {
+	return fmt.Sprintf("report-%d.pdf", assessmentID)
+}
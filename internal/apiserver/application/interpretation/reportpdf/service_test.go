@@ -0,0 +1,242 @@
+package reportpdf
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+type fakeStore struct{ renditions map[string]*Rendition }
+
+func (s *fakeStore) Save(_ context.Context, rendition *Rendition) error {
+	if _, ok := s.renditions[rendition.ReportID]; !ok {
+		copied := *rendition
+		s.renditions[rendition.ReportID] = &copied
+	}
+	return nil
+}
+
+func (s *fakeStore) FindByReportID(_ context.Context, reportID string) (*Rendition, error) {
+	return s.renditions[reportID], nil
+}
+
+func (s *fakeStore) FindLatestByAssessment(_ context.Context, assessmentID uint64) (*Rendition, error) {
+	var latest *Rendition
+	for _, rendition := range s.renditions {
+		if rendition.AssessmentID == assessmentID && (latest == nil || rendition.ReportCreatedAt.After(latest.ReportCreatedAt)) {
+			latest = rendition
+		}
+	}
+	return latest, nil
+}
+
+type fakeObjects struct{ objects map[string][]byte }
+
+func (o *fakeObjects) Put(_ context.Context, key, _ string, body []byte) error {
+	o.objects[key] = body
+	return nil
+}
+
+func (o *fakeObjects) Open(_ context.Context, key string) (io.ReadCloser, int64, error) {
+	body := o.objects[key]
+	return io.NopCloser(bytes.NewReader(body)), int64(len(body)), nil
+}
+
+type fakeReports struct {
+	row *interpretationreadmodel.ReportRow
+}
+
+func (r fakeReports) GetReportByAssessmentID(_ context.Context, assessmentID uint64) (*interpretationreadmodel.ReportRow, error) {
+	if r.row == nil || r.row.AssessmentID != assessmentID {
+		return nil, interpretationreadmodel.ErrReportNotFound
+	}
+	return r.row, nil
+}
+
+func (fakeReports) ListReports(context.Context, interpretationreadmodel.ReportFilter, interpretationreadmodel.PageRequest) ([]interpretationreadmodel.ReportRow, int64, error) {
+	return nil, 0, nil
+}
+
+type fakeParticipantAccess struct{ owner map[uint64]uint64 }
+
+func (a fakeParticipantAccess) AuthorizeOwnAssessment(_ context.Context, testeeID, assessmentID uint64) error {
+	if a.owner[assessmentID] != testeeID {
+		return cberrors.WithCode(code.ErrPermissionDenied, "assessment does not belong to testee")
+	}
+	return nil
+}
+
+type staticBranding Branding
+
+func (b staticBranding) Branding(int64) Branding { return Branding(b) }
+
+func newTestService(t *testing.T) (*service, *fakeStore, *fakeObjects) {
+	t.Helper()
+	maxScore := 27.0
+	row := &interpretationreadmodel.ReportRow{
+		AssessmentID: 5001,
+		Model:        interpretationreadmodel.ModelIdentityRow{Kind: "scale", Code: "PHQ-9", Version: "1.2.0", Title: "患者健康问卷"},
+		PrimaryScore: &interpretationreadmodel.ScoreValueRow{Kind: "total", Label: "总分", Value: 14, Max: &maxScore},
+		Level:        &interpretationreadmodel.ResultLevelRow{Code: "moderate", Label: "中度", Severity: "medium"},
+		Conclusion:   "存在中度抑郁症状，建议进一步评估。",
+		Dimensions: []interpretationreadmodel.ReportDimensionRow{
+			{FactorCode: "mood", FactorName: "情绪", RawScore: 6, MaxScore: &maxScore, Description: "情绪低落较明显"},
+			{FactorCode: "hidden", FactorName: "隐藏维度", RawScore: 3},
+		},
+		Suggestions: []interpretationreadmodel.ReportSuggestionRow{{Category: "general", Content: "保持规律作息"}},
+		PresentationProfile: &interpretationreadmodel.PresentationProfileRow{
+			VisibleFactorCodes: []string{"mood"},
+			Source:             string(domainreport.PresentationProfileSourceFrozen),
+		},
+		CreatedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}
+	store := &fakeStore{renditions: map[string]*Rendition{}}
+	objects := &fakeObjects{objects: map[string][]byte{}}
+	svc := NewService(store, objects, fakeReports{row: row}, NewLinkSigner("test-secret"), nil,
+		fakeParticipantAccess{owner: map[uint64]uint64{5001: 401}},
+		Config{LinkTTL: 10 * time.Minute, Branding: staticBranding{OrgName: "示例心理门诊", PrimaryColor: "#0A7B83"}},
+	).(*service)
+	svc.now = func() time.Time { return time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC) }
+	return svc, store, objects
+}
+
+func TestRenderStoresPDFWithProvenanceAndIsIdempotent(t *testing.T) {
+	svc, store, objects := newTestService(t)
+	req := RenderRequest{ReportID: "rpt-1", OrgID: 7, AssessmentID: 5001, TesteeID: 401, TemplateVersion: "tpl-3"}
+
+	rendition, err := svc.Render(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendition.ObjectKey != "report-pdf/7/401/5001/rpt-1.pdf" || rendition.ModelVersion != "1.2.0" || len(rendition.ContentHash) != 64 {
+		t.Fatalf("rendition = %#v", rendition)
+	}
+	body := objects.objects[rendition.ObjectKey]
+	if !bytes.HasPrefix(body, []byte("%PDF-")) || int64(len(body)) != rendition.SizeBytes {
+		t.Fatalf("stored object is not the rendered pdf (%d bytes)", len(body))
+	}
+	// 页脚写入内容哈希；隐藏维度不得出现在 PDF 中（文字以 UTF-16BE 十六进制编码）。
+	if !bytes.Contains(body, []byte(hexText(rendition.ContentHash[:8]))) {
+		t.Fatal("content hash missing from footer")
+	}
+	if bytes.Contains(body, []byte(hexText("隐藏维度"))) {
+		t.Fatal("dimension hidden by the frozen presentation profile was rendered")
+	}
+
+	objects.objects = map[string][]byte{}
+	again, err := svc.Render(context.Background(), req)
+	if err != nil || again.ObjectKey != rendition.ObjectKey || len(objects.objects) != 0 || len(store.renditions) != 1 {
+		t.Fatalf("second render = %#v, %v; want existing rendition without re-upload", again, err)
+	}
+}
+
+func TestParticipantLinkRoundTripAndRejectsTampering(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+	if _, err := svc.IssueParticipantLink(ctx, 401, 5001); !cberrors.IsCode(err, code.ErrReportPDFNotReady) {
+		t.Fatalf("link before render err = %v, want not ready", err)
+	}
+	if _, err := svc.Render(ctx, RenderRequest{ReportID: "rpt-1", OrgID: 7, AssessmentID: 5001, TesteeID: 401}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.IssueParticipantLink(ctx, 402, 5001); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("other testee err = %v, want permission denied", err)
+	}
+
+	link, err := svc.IssueParticipantLink(ctx, 401, 5001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !link.ExpiresAt.Equal(time.Date(2026, 10, 1, 10, 10, 0, 0, time.UTC)) || link.FileName != "report-5001.pdf" {
+		t.Fatalf("link = %#v", link)
+	}
+	download, err := svc.Open(ctx, link.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(download.Body)
+	if !bytes.HasPrefix(content, []byte("%PDF-")) || download.ContentType != ContentType {
+		t.Fatalf("download = %#v", download)
+	}
+	if download.OrgID != 7 || download.TesteeID != 401 || download.AssessmentID != 5001 {
+		t.Fatalf("download audit subject = %d/%d/%d", download.OrgID, download.TesteeID, download.AssessmentID)
+	}
+
+	payload, signature, _ := strings.Cut(link.Token, ".")
+	raw, _ := base64.RawURLEncoding.DecodeString(payload)
+	forged := base64.RawURLEncoding.EncodeToString(bytes.Replace(raw, []byte("5001"), []byte("5002"), 1)) + "." + signature
+	if _, err := svc.Open(ctx, forged); !cberrors.IsCode(err, code.ErrReportPDFLinkInvalid) {
+		t.Fatalf("forged token err = %v, want invalid link", err)
+	}
+	svc.now = func() time.Time { return link.ExpiresAt }
+	if _, err := svc.Open(ctx, link.Token); !cberrors.IsCode(err, code.ErrReportPDFLinkInvalid) {
+		t.Fatalf("expired token err = %v, want invalid link", err)
+	}
+}
+
+func TestReportGeneratedConsumerRendersFromEvent(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	evt := event.Event[interpretation.ReportGeneratedOutcomeData]{
+		BaseEvent: event.BaseEvent{ID: "evt-1", EventTypeValue: interpretation.EventTypeReportGenerated, AggregateTypeValue: interpretation.AggregateType, AggregateIDValue: "generation-1"},
+		Data:      interpretation.ReportGeneratedOutcomeData{OrgID: 7, ReportID: "rpt-9", AssessmentID: "5001", TesteeID: 401, TemplateVersion: "tpl-3"},
+	}
+	payload, err := eventcodec.EncodeDomainEvent(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	consume := NewReportGeneratedConsumer(svc)
+	if err := consume(context.Background(), "interpretation.report.failed", payload); err != nil || len(store.renditions) != 0 {
+		t.Fatalf("unrelated event handled: %v", err)
+	}
+	if err := consume(context.Background(), interpretation.EventTypeReportGenerated, payload); err != nil {
+		t.Fatal(err)
+	}
+	if rendition := store.renditions["rpt-9"]; rendition == nil || rendition.TemplateVersion != "tpl-3" || rendition.TesteeID != 401 {
+		t.Fatalf("rendition = %#v", rendition)
+	}
+}
+
+func TestRenderDrawsReportCharts(t *testing.T) {
+	reference := 50.0
+	report := &reportprojection.Report{
+		AssessmentID: 5001,
+		Model:        reportprojection.ModelIdentity{Kind: "scale", Code: "SCL-90", Title: "症状自评量表"},
+		Charts: []reportchart.Spec{{
+			ID: "profile", Kind: reportchart.KindProfile, Title: "T 分剖面", Min: 20, Max: 80, Reference: &reference,
+			Bands:  []reportchart.Band{{From: 60, To: 70, Label: "偏高", Tone: reportchart.ToneElevated}},
+			Points: []reportchart.Point{{Code: "anx", Label: "焦虑", Value: 66}, {Code: "dep", Label: "抑郁", Value: 48}},
+		}},
+	}
+	body := Render(report, Provenance{ReportID: "rpt-1"}, Branding{})
+	for _, text := range []string{"图表", "T 分剖面", "偏高", "焦虑"} {
+		if !bytes.Contains(body, []byte(hexText(text))) {
+			t.Fatalf("chart text %q missing from pdf", text)
+		}
+	}
+	if !bytes.Contains(body, []byte(" c f\n")) || !bytes.Contains(body, []byte(" l S\n")) {
+		t.Fatal("chart points and profile line were not drawn")
+	}
+}
+
+func hexText(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		fmt.Fprintf(&builder, "%04X", r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,103 @@
+package reportpdf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidLink 令牌格式错误或签名不匹配。
+	ErrInvalidLink = errors.New("report pdf link is invalid")
+	// ErrLinkExpired 令牌已过期。
+	ErrLinkExpired = errors.New("report pdf link has expired")
+)
+
+// LinkClaims 下载令牌绑定的内容。
+type LinkClaims struct {
+	AssessmentID uint64
+	ReportID     string
+	ExpiresAt    time.Time
+}
+
+type linkPayload struct {
+	AssessmentID string `json:"aid"`
+	ReportID     string `json:"rid"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// LinkSigner 以 HMAC-SHA256 签发与校验下载令牌。
+// 令牌形如 base64url(payload).base64url(signature)，不含受试者信息。
+type LinkSigner struct {
+	key       []byte
+	ephemeral bool
+}
+
+// NewLinkSigner 创建令牌签名器；secret 为空时使用进程级随机密钥，已签发的链接在重启后失效。
+func NewLinkSigner(secret string) *LinkSigner {
+	if secret != "" {
+		return &LinkSigner{key: []byte(secret)}
+	}
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic("reportpdf: failed to generate link signing key: " + err.Error())
+	}
+	return &LinkSigner{key: key, ephemeral: true}
+}
+
+// Ephemeral 报告是否使用了进程级随机密钥。
+func (s *LinkSigner) Ephemeral() bool {
+	return s != nil && s.ephemeral
+}
+
+// Sign 签发令牌。
+func (s *LinkSigner) Sign(claims LinkClaims) string {
+	payload, _ := json.Marshal(linkPayload{
+		AssessmentID: strconv.FormatUint(claims.AssessmentID, 10),
+		ReportID:     claims.ReportID,
+		ExpiresAt:    claims.ExpiresAt.Unix(),
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify 校验签名与有效期。
+func (s *LinkSigner) Verify(token string, now time.Time) (LinkClaims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || encoded == "" || signature == "" {
+		return LinkClaims{}, ErrInvalidLink
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, s.mac(encoded)) {
+		return LinkClaims{}, ErrInvalidLink
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return LinkClaims{}, ErrInvalidLink
+	}
+	var payload linkPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ReportID == "" {
+		return LinkClaims{}, ErrInvalidLink
+	}
+	assessmentID, err := strconv.ParseUint(payload.AssessmentID, 10, 64)
+	if err != nil || assessmentID == 0 {
+		return LinkClaims{}, ErrInvalidLink
+	}
+	claims := LinkClaims{AssessmentID: assessmentID, ReportID: payload.ReportID, ExpiresAt: time.Unix(payload.ExpiresAt, 0)}
+	if !now.Before(claims.ExpiresAt) {
+		return LinkClaims{}, ErrLinkExpired
+	}
+	return claims, nil
+}
+
+func (s *LinkSigner) mac(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,88 @@
+// Package reportpdf 解读报告的服务端 PDF 渲染与签名下载。
+//
+// 报告生成事件（interpretation.report.generated）到达后，按报告冻结的展示配置投影内容，
+// 排版为带机构品牌、维度图表与溯源页脚（模型版本、模板版本、内容哈希）的分页 PDF，
+// 写入对象存储并登记渲染记录。同一报告只渲染一次；测评重新生成报告时以最新一份为准。
+// 下载通过短期有效的签名令牌完成，令牌绑定测评与报告，过期或被篡改即失效。
+package reportpdf
+
+import (
+	"context"
+	"io"
+	"time"
+
+	domainpdf "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportpdf"
+)
+
+// ContentType PDF 的 MIME 类型。
+const ContentType = "application/pdf"
+
+// Rendition 一份已渲染并保存的报告 PDF。
+type Rendition = domainpdf.Rendition
+
+// RenderRequest 渲染一份已生成的报告。
+type RenderRequest struct {
+	ReportID        string
+	OrgID           int64
+	AssessmentID    uint64
+	TesteeID        uint64
+	TemplateVersion string
+}
+
+// Branding 报告页眉的机构品牌。
+type Branding struct {
+	OrgName string
+	// PrimaryColor 页眉与图表主色，格式为 "#RRGGBB"。
+	PrimaryColor string
+	// Footnote 页脚附加声明，例如“本报告仅供临床参考”。
+	Footnote string
+}
+
+// BrandingResolver 按机构返回品牌；未配置的机构使用默认品牌。
+type BrandingResolver interface {
+	Branding(orgID int64) Branding
+}
+
+// Link 报告 PDF 的签名下载链接。
+type Link struct {
+	Token       string
+	ExpiresAt   time.Time
+	FileName    string
+	ContentHash string
+}
+
+// Download 已校验令牌的 PDF 内容；调用方负责关闭 Body。OrgID、TesteeID 与 AssessmentID 供访问审计归属。
+type Download struct {
+	OrgID        int64
+	TesteeID     uint64
+	AssessmentID uint64
+	Body         io.ReadCloser
+	SizeBytes    int64
+	FileName     string
+	ContentType  string
+}
+
+// Actor 签发链接的后台操作者。
+type Actor struct {
+	OrgID          int64
+	OperatorUserID int64
+}
+
+// ClinicianAccess 校验操作者可以查看受试者的测评报告。
+type ClinicianAccess interface {
+	AuthorizeAssessment(ctx context.Context, actor Actor, testeeID, assessmentID uint64) error
+}
+
+// ParticipantAccess 校验测评属于该受试者。
+type ParticipantAccess interface {
+	AuthorizeOwnAssessment(ctx context.Context, testeeID, assessmentID uint64) error
+}
+
+// Store 渲染记录存储。
+type Store = domainpdf.Repository
+
+// ObjectStore PDF 文件存储。
+type ObjectStore interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	Open(ctx context.Context, key string) (io.ReadCloser, int64, error)
+}
@@ -0,0 +1,159 @@
+package reportprojection
+
+import (
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+// 图表 ID 在同一份报告内唯一，也是报告图表资源地址的最后一段。
+const (
+	ChartProfile  = "profile"
+	ChartRadar    = "radar"
+	ChartPoleBars = "pole_bars"
+)
+
+// T 分常模区间：均值 50、标准差 10，区间边界即常用的 ±1、+2 个标准差。
+const (
+	tScoreMin  = 20.0
+	tScoreMax  = 80.0
+	tScoreMean = 50.0
+)
+
+// chartCopy 图表标题与区间名称；英文报告用英文，其余沿用默认语言。
+type chartCopy struct {
+	profile, radar, poleBars string
+	tBands                   [4]string
+}
+
+var (
+	chartCopyZH = chartCopy{profile: "T 分剖面", radar: "因子雷达", poleBars: "类型倾向", tBands: [4]string{"低于平均", "平均", "偏高", "显著偏高"}}
+	chartCopyEN = chartCopy{profile: "T-score profile", radar: "Factor radar", poleBars: "Type preferences", tBands: [4]string{"Below average", "Average", "Elevated", "Markedly elevated"}}
+)
+
+func chartCopyFor(tag string) chartCopy {
+	if locale.Language(tag) == "en" {
+		return chartCopyEN
+	}
+	return chartCopyZH
+}
+
+// Charts 从报告已按受众过滤、已本地化的维度确定性地派生图表：有 T 分的维度画剖面图，
+// 因子计分模型画雷达图，类型学模型画两极条形图。同一份报告正文总是得到同一组图表。
+func Charts(report *Report) []reportchart.Spec {
+	if report == nil {
+		return nil
+	}
+	text := chartCopyFor(report.Locale)
+	var charts []reportchart.Spec
+	if spec, ok := profileChart(report.Dimensions, text); ok {
+		charts = append(charts, spec)
+	}
+	model := domainreport.ModelIdentity{Kind: report.Model.Kind, Algorithm: report.Model.Algorithm, Code: report.Model.Code, Version: report.Model.Version}
+	if domainreport.UsesFactorScoreVisibility(model) {
+		if spec, ok := radarChart(report.Dimensions, text); ok {
+			charts = append(charts, spec)
+		}
+	} else if spec, ok := poleBarsChart(report.Dimensions, text); ok {
+		charts = append(charts, spec)
+	}
+	return charts
+}
+
+// profileChart 以各维度的 T 分连线，背景为常模 T 分区间带；参考线取首个 T 分常模的基准，缺省为 50。
+func profileChart(dimensions []Dimension, text chartCopy) (reportchart.Spec, bool) {
+	var (
+		points     []reportchart.Point
+		reference  = tScoreMean
+		referenced bool
+	)
+	for _, dimension := range dimensions {
+		score, ok := derivedScore(dimension, domainreport.ScoreKindTScore)
+		if !ok {
+			continue
+		}
+		points = append(points, reportchart.Point{Code: dimension.FactorCode, Label: dimensionLabel(dimension), Value: score.Value})
+		if norm := dimension.NormReference; norm != nil && norm.ScoreKind == domainreport.ScoreKindTScore && norm.Benchmark > 0 && !referenced {
+			reference, referenced = norm.Benchmark, true
+		}
+	}
+	if len(points) < 2 {
+		return reportchart.Spec{}, false
+	}
+	return reportchart.Spec{
+		ID: ChartProfile, Kind: reportchart.KindProfile, Title: text.profile,
+		Min: tScoreMin, Max: tScoreMax, Reference: &reference,
+		Bands: []reportchart.Band{
+			{From: tScoreMin, To: 40, Label: text.tBands[0], Tone: reportchart.ToneLow},
+			{From: 40, To: 60, Label: text.tBands[1], Tone: reportchart.ToneNormal},
+			{From: 60, To: 70, Label: text.tBands[2], Tone: reportchart.ToneElevated},
+			{From: 70, To: tScoreMax, Label: text.tBands[3], Tone: reportchart.ToneHigh},
+		},
+		Points: points,
+	}, true
+}
+
+// radarChart 以顶层计分因子的原始分按各自满分归一；总分与效度因子不参与，少于三个因子不成图。
+func radarChart(dimensions []Dimension, text chartCopy) (reportchart.Spec, bool) {
+	spec := reportchart.Spec{ID: ChartRadar, Kind: reportchart.KindRadar, Title: text.radar}
+	for _, dimension := range dimensions {
+		if dimension.ParentCode != "" || dimension.MaxScore == nil || *dimension.MaxScore <= 0 {
+			continue
+		}
+		if dimension.Role != "" && dimension.Role != "dimension" {
+			continue
+		}
+		full := *dimension.MaxScore
+		spec.Max = max(spec.Max, full)
+		spec.Points = append(spec.Points, reportchart.Point{Code: dimension.FactorCode, Label: dimensionLabel(dimension), Value: dimension.RawScore, Max: &full})
+	}
+	if len(spec.Points) < 3 {
+		return reportchart.Spec{}, false
+	}
+	return spec, true
+}
+
+// poleBarsChart 画类型学维度的条形：各维度满分相同时以中点为两极分界，
+// 否则各自按满分（没有满分时按最高得分）归一、不设分界。
+func poleBarsChart(dimensions []Dimension, text chartCopy) (reportchart.Spec, bool) {
+	if len(dimensions) == 0 {
+		return reportchart.Spec{}, false
+	}
+	spec := reportchart.Spec{ID: ChartPoleBars, Kind: reportchart.KindPoleBars, Title: text.poleBars}
+	shared := dimensions[0].MaxScore
+	for _, dimension := range dimensions {
+		point := reportchart.Point{Code: dimension.FactorCode, Label: dimensionLabel(dimension), Value: dimension.RawScore}
+		if dimension.MaxScore != nil && *dimension.MaxScore > 0 {
+			full := *dimension.MaxScore
+			point.Max = &full
+			spec.Max = max(spec.Max, full)
+		} else {
+			spec.Max = max(spec.Max, dimension.RawScore)
+		}
+		if shared != nil && (point.Max == nil || *point.Max != *shared) {
+			shared = nil
+		}
+		spec.Points = append(spec.Points, point)
+	}
+	if shared != nil {
+		mid := *shared / 2
+		spec.Reference = &mid
+	}
+	return spec, true
+}
+
+func derivedScore(dimension Dimension, kind string) (ScoreValue, bool) {
+	for _, score := range dimension.DerivedScores {
+		if score.Kind == kind {
+			return score, true
+		}
+	}
+	return ScoreValue{}, false
+}
+
+func dimensionLabel(dimension Dimension) string {
+	if dimension.FactorName != "" {
+		return dimension.FactorName
+	}
+	return dimension.FactorCode
+}
@@ -0,0 +1,82 @@
+package reportprojection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestChartsDeriveProfileAndRadarFromVisibleFactors(t *testing.T) {
+	t.Parallel()
+
+	tScore := func(v float64) []interpretationreadmodel.ScoreValueRow {
+		return []interpretationreadmodel.ScoreValueRow{{Kind: domainreport.ScoreKindTScore, Value: v}}
+	}
+	norm := &interpretationreadmodel.NormReferenceRow{ScoreKind: domainreport.ScoreKindTScore, Benchmark: 50}
+	row := interpretationreadmodel.ReportRow{
+		AssessmentID: 42,
+		Locale:       "en",
+		Model:        interpretationreadmodel.ModelIdentityRow{Kind: "scale", Code: "scl-1", Version: "1.0.0"},
+		Dimensions: []interpretationreadmodel.ReportDimensionRow{
+			{FactorCode: "total", Role: "total", RawScore: 30, MaxScore: floatPtr(60)},
+			{FactorCode: "anx", FactorName: "Anxiety", Role: "dimension", RawScore: 12, MaxScore: floatPtr(20), DerivedScores: tScore(68), NormReference: norm},
+			{FactorCode: "dep", FactorName: "Depression", RawScore: 6, MaxScore: floatPtr(20), DerivedScores: tScore(52), NormReference: norm},
+			{FactorCode: "som", FactorName: "Somatic", RawScore: 4, MaxScore: floatPtr(10)},
+			{FactorCode: "hidden", RawScore: 9, MaxScore: floatPtr(10), DerivedScores: tScore(75)},
+		},
+		PresentationProfile: &interpretationreadmodel.PresentationProfileRow{
+			VisibleFactorCodes: []string{"total", "anx", "dep", "som"},
+			Source:             string(domainreport.PresentationProfileSourceFrozen),
+		},
+	}
+	got, err := (Mapper{}).FromRow(context.Background(), row, policy.AudienceParticipant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Charts) != 2 || got.Charts[0].ID != ChartProfile || got.Charts[1].ID != ChartRadar {
+		t.Fatalf("charts = %+v", got.Charts)
+	}
+	profile := got.Charts[0]
+	if profile.Title != "T-score profile" || len(profile.Points) != 2 || len(profile.Bands) != 4 || *profile.Reference != 50 {
+		t.Fatalf("profile = %+v", profile)
+	}
+	radar := got.Charts[1]
+	if len(radar.Points) != 3 || radar.Points[0].Code != "anx" || *radar.Points[2].Max != 10 {
+		t.Fatalf("radar must skip total and hidden factors: %+v", radar.Points)
+	}
+	again, _ := (Mapper{}).FromRow(context.Background(), row, policy.AudienceParticipant)
+	if !reflect.DeepEqual(again.Charts, got.Charts) {
+		t.Fatal("charts are not derived deterministically")
+	}
+}
+
+func TestChartsDerivePoleBarsForTypology(t *testing.T) {
+	t.Parallel()
+
+	report := &Report{
+		Model: ModelIdentity{Kind: "typology", Code: "MBTI"},
+		Dimensions: []Dimension{
+			{FactorCode: "EI", FactorName: "外向-内向", RawScore: 28, MaxScore: floatPtr(40)},
+			{FactorCode: "SN", FactorName: "实感-直觉", RawScore: 12, MaxScore: floatPtr(40)},
+		},
+	}
+	charts := Charts(report)
+	if len(charts) != 1 || charts[0].Kind != reportchart.KindPoleBars || charts[0].Title != "类型倾向" {
+		t.Fatalf("charts = %+v", charts)
+	}
+	if charts[0].Reference == nil || *charts[0].Reference != 20 {
+		t.Fatalf("pole reference = %v, want midpoint 20", charts[0].Reference)
+	}
+
+	report.Dimensions = append(report.Dimensions, Dimension{FactorCode: "O", RawScore: 55})
+	if charts := Charts(report); charts[0].Reference != nil || charts[0].Max != 55 {
+		t.Fatalf("trait dimensions without a shared max must not draw poles: %+v", charts[0])
+	}
+}
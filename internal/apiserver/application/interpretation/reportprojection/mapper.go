@@ -8,12 +8,155 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/presentation"
 	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
 )
 
 // Mapper projects read-model rows into audience-aware report DTOs.
-type Mapper struct{}
+// Addenda 为 nil 时报告不携带临床补充说明；Variants 为 nil 时只读 canonical 正文；
+// Locales 为 nil 时只返回报告生成时的语言。
+type Mapper struct {
+	Addenda  AddendumReader
+	Variants interpretationreadmodel.AudienceVariantReader
+	Locales  LocalizedReportSource
+}
+
+// LocalizedReportSource 返回已选受众正文在指定内容语言下的版本，必要时按需渲染；
+// 报告的模板发布或模型译文不提供该语言时返回 nil, nil。
+type LocalizedReportSource interface {
+	LocalizedReport(ctx context.Context, row interpretationreadmodel.ReportRow, locale string) (*interpretationreadmodel.ReportRow, error)
+}
+
+// AddendumReader 读取已签署复核的临床补充说明；报告尚未签署或没有补充说明时返回 nil, nil。
+type AddendumReader interface {
+	FindSignedAddendum(ctx context.Context, assessmentID uint64) (*ClinicianAddendum, error)
+}
+
+// FromRow 以读取路径的默认受众版本投影报告，见 policy.ReportAudienceForViewer。
+func (m Mapper) FromRow(ctx context.Context, row interpretationreadmodel.ReportRow, viewer policy.Audience) (*Report, error) {
+	return m.FromRowAs(ctx, row, viewer, policy.ReportAudienceForViewer(viewer))
+}
+
+// FromRowAs 按指定受众版本投影报告；未发布该受众时按 ReportAudience.Fallbacks 回落到 canonical。
+func (m Mapper) FromRowAs(ctx context.Context, row interpretationreadmodel.ReportRow, viewer policy.Audience, audience policy.ReportAudience) (*Report, error) {
+	reports, err := m.fromRows(ctx, []interpretationreadmodel.ReportRow{row}, viewer, audience)
+	if err != nil {
+		return nil, err
+	}
+	return reports[0], nil
+}
+
+// FromRowIn 按指定受众版本与内容语言投影报告；locale 为空或无法渲染时返回报告生成时的语言。
+func (m Mapper) FromRowIn(ctx context.Context, row interpretationreadmodel.ReportRow, viewer policy.Audience, audience policy.ReportAudience, tag string) (*Report, error) {
+	selected, err := m.selectAudience(ctx, []interpretationreadmodel.ReportRow{row}, audience)
+	if err != nil {
+		return nil, err
+	}
+	localized, err := m.selectLocale(ctx, selected[0], tag)
+	if err != nil {
+		return nil, err
+	}
+	return m.project(ctx, localized, viewer)
+}
+
+// FromRows 批量投影列表页，受众变体按页一次读取。
+func (m Mapper) FromRows(ctx context.Context, rows []interpretationreadmodel.ReportRow, viewer policy.Audience) ([]*Report, error) {
+	return m.fromRows(ctx, rows, viewer, policy.ReportAudienceForViewer(viewer))
+}
+
+func (m Mapper) fromRows(ctx context.Context, rows []interpretationreadmodel.ReportRow, viewer policy.Audience, audience policy.ReportAudience) ([]*Report, error) {
+	selected, err := m.selectAudience(ctx, rows, audience)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*Report, 0, len(selected))
+	for _, row := range selected {
+		report, err := m.project(ctx, row, viewer)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (m Mapper) project(ctx context.Context, row interpretationreadmodel.ReportRow, viewer policy.Audience) (*Report, error) {
+	report, err := m.fromRow(row, viewer)
+	if err != nil {
+		return nil, err
+	}
+	report.Audience = policy.ReportAudience(row.Audience).String()
+	report.Locale = row.Locale
+	if report.Locale == "" {
+		report.Locale = locale.Default
+	}
+	report.Charts = Charts(report)
+	if m.Addenda != nil {
+		addendum, err := m.Addenda.FindSignedAddendum(ctx, row.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("load clinician addendum: %w", err)
+		}
+		report.ClinicianAddendum = addendum
+	}
+	return report, nil
+}
+
+// selectLocale 把已选受众正文换成请求的内容语言；归档报告没有 artifact，
+// 与报告已是该语言时一样原样返回。
+func (m Mapper) selectLocale(ctx context.Context, row interpretationreadmodel.ReportRow, tag string) (interpretationreadmodel.ReportRow, error) {
+	current := row.Locale
+	if current == "" {
+		current = locale.Default
+	}
+	if m.Locales == nil || tag == "" || tag == current || row.ReportID == 0 {
+		return row, nil
+	}
+	localized, err := m.Locales.LocalizedReport(ctx, row, tag)
+	if err != nil {
+		return interpretationreadmodel.ReportRow{}, fmt.Errorf("load %s report: %w", tag, err)
+	}
+	if localized == nil {
+		return row, nil
+	}
+	return *localized, nil
+}
+
+// selectAudience 沿受众回落链替换正文：先取本受众变体，找不到的保留 canonical。
+// 归档报告没有 artifact ID，始终是 canonical。
+func (m Mapper) selectAudience(ctx context.Context, rows []interpretationreadmodel.ReportRow, audience policy.ReportAudience) ([]interpretationreadmodel.ReportRow, error) {
+	selected := append([]interpretationreadmodel.ReportRow(nil), rows...)
+	if m.Variants == nil {
+		return selected, nil
+	}
+	resolved := make([]bool, len(selected))
+	for _, candidate := range audience.Fallbacks() {
+		if candidate.IsCanonical() {
+			break
+		}
+		reportIDs := make([]uint64, 0, len(selected))
+		for index, row := range selected {
+			if !resolved[index] && row.ReportID != 0 {
+				reportIDs = append(reportIDs, row.ReportID)
+			}
+		}
+		if len(reportIDs) == 0 {
+			break
+		}
+		variants, err := m.Variants.FindAudienceVariants(ctx, reportIDs, string(candidate))
+		if err != nil {
+			return nil, fmt.Errorf("load %s report variants: %w", candidate, err)
+		}
+		for index, row := range selected {
+			variant, ok := variants[row.ReportID]
+			if resolved[index] || row.ReportID == 0 || !ok {
+				continue
+			}
+			selected[index], resolved[index] = variant, true
+		}
+	}
+	return selected, nil
+}
 
-func (Mapper) FromRow(_ context.Context, row interpretationreadmodel.ReportRow, audience policy.Audience) (*Report, error) {
+func (Mapper) fromRow(row interpretationreadmodel.ReportRow, audience policy.Audience) (*Report, error) {
 	model := modelIdentityFromRow(row)
 	storedProfile := presentationProfileFromRow(&row)
 	configured := storedProfile != nil && storedProfile.Configured()
@@ -67,3 +67,133 @@ func TestMapperFromRowAppliesAudienceAfterFrozenVisibility(t *testing.T) {
 		t.Fatal("clinician audience must hide model extra after projection")
 	}
 }
+
+type addendumReaderStub map[uint64]*ClinicianAddendum
+
+func (s addendumReaderStub) FindSignedAddendum(_ context.Context, assessmentID uint64) (*ClinicianAddendum, error) {
+	return s[assessmentID], nil
+}
+
+func TestMapperFromRowAttachesSignedAddendum(t *testing.T) {
+	t.Parallel()
+
+	mapper := Mapper{Addenda: addendumReaderStub{42: {Content: "请两周后复诊", ReviewerName: "王医生", ReviewStatus: "reviewed"}}}
+	row := interpretationreadmodel.ReportRow{
+		AssessmentID: 42,
+		Model:        interpretationreadmodel.ModelIdentityRow{Kind: "scale", Code: "scl-1"},
+		PresentationProfile: &interpretationreadmodel.PresentationProfileRow{
+			VisibleFactorCodes: []string{"f1"},
+			Source:             string(domainreport.PresentationProfileSourceFrozen),
+		},
+	}
+	got, err := mapper.FromRow(context.Background(), row, policy.AudienceParticipant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ClinicianAddendum == nil || got.ClinicianAddendum.Content != "请两周后复诊" {
+		t.Fatalf("addendum = %#v", got.ClinicianAddendum)
+	}
+
+	row.AssessmentID = 43
+	got, err = mapper.FromRow(context.Background(), row, policy.AudienceParticipant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ClinicianAddendum != nil {
+		t.Fatalf("unsigned report must not carry an addendum: %#v", got.ClinicianAddendum)
+	}
+}
+
+type variantReaderStub struct {
+	rows      map[string]map[uint64]interpretationreadmodel.ReportRow
+	requested []string
+}
+
+func (s *variantReaderStub) FindAudienceVariants(_ context.Context, reportIDs []uint64, audience string) (map[uint64]interpretationreadmodel.ReportRow, error) {
+	s.requested = append(s.requested, audience)
+	result := map[uint64]interpretationreadmodel.ReportRow{}
+	for _, id := range reportIDs {
+		if row, ok := s.rows[audience][id]; ok {
+			result[id] = row
+		}
+	}
+	return result, nil
+}
+
+func TestMapperSelectsViewerAudienceVariantAndFallsBackToCanonical(t *testing.T) {
+	t.Parallel()
+
+	model := interpretationreadmodel.ModelIdentityRow{Kind: "typology", Code: "MBTI", Title: "MBTI"}
+	published := interpretationreadmodel.ReportRow{AssessmentID: 1, ReportID: 11, Model: model, Conclusion: "canonical"}
+	unpublished := interpretationreadmodel.ReportRow{AssessmentID: 2, ReportID: 12, Model: model, Conclusion: "canonical"}
+	archived := interpretationreadmodel.ReportRow{AssessmentID: 3, Model: model, Conclusion: "archived"}
+	variants := &variantReaderStub{rows: map[string]map[uint64]interpretationreadmodel.ReportRow{
+		"family": {11: {AssessmentID: 1, ReportID: 11, Audience: "family", Model: model, Conclusion: "family"}},
+	}}
+	mapper := Mapper{Variants: variants}
+
+	reports, err := mapper.FromRows(context.Background(), []interpretationreadmodel.ReportRow{published, unpublished, archived}, policy.AudienceParticipant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reports[0].Audience != "family" || reports[0].Conclusion != "family" {
+		t.Fatalf("published report = %s/%q, want family variant", reports[0].Audience, reports[0].Conclusion)
+	}
+	if reports[1].Audience != "canonical" || reports[1].Conclusion != "canonical" {
+		t.Fatalf("unpublished audience = %s/%q, want canonical fallback", reports[1].Audience, reports[1].Conclusion)
+	}
+	if reports[2].Audience != "canonical" || len(variants.requested) != 1 {
+		t.Fatalf("archived report = %s, variant lookups = %v", reports[2].Audience, variants.requested)
+	}
+
+	school, err := mapper.FromRowAs(context.Background(), published, policy.AudienceAdmin, policy.ReportAudienceSchool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if school.Audience != "canonical" || school.Conclusion != "canonical" {
+		t.Fatalf("school fallback = %s/%q, must not borrow another audience", school.Audience, school.Conclusion)
+	}
+}
+
+type localizedSourceStub struct{ requested []string }
+
+func (s *localizedSourceStub) LocalizedReport(_ context.Context, row interpretationreadmodel.ReportRow, tag string) (*interpretationreadmodel.ReportRow, error) {
+	s.requested = append(s.requested, row.Audience+"/"+tag)
+	if tag != "en-US" {
+		return nil, nil
+	}
+	row.Locale, row.Conclusion = tag, "english "+row.Conclusion
+	return &row, nil
+}
+
+func TestMapperFromRowInLocalizesSelectedAudience(t *testing.T) {
+	t.Parallel()
+
+	model := interpretationreadmodel.ModelIdentityRow{Kind: "typology", Code: "MBTI", Title: "MBTI"}
+	row := interpretationreadmodel.ReportRow{AssessmentID: 1, ReportID: 11, Model: model, Conclusion: "canonical"}
+	locales := &localizedSourceStub{}
+	mapper := Mapper{Variants: &variantReaderStub{rows: map[string]map[uint64]interpretationreadmodel.ReportRow{
+		"family": {11: {AssessmentID: 1, ReportID: 11, Audience: "family", Model: model, Conclusion: "family"}},
+	}}, Locales: locales}
+
+	english, err := mapper.FromRowIn(context.Background(), row, policy.AudienceAdmin, policy.ReportAudienceFamily, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if english.Locale != "en-US" || english.Audience != "family" || english.Conclusion != "english family" {
+		t.Fatalf("localized report = %s/%s/%q", english.Locale, english.Audience, english.Conclusion)
+	}
+	fallback, err := mapper.FromRowIn(context.Background(), row, policy.AudienceAdmin, policy.ReportAudienceCanonical, "ja")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fallback.Locale != "zh-CN" || fallback.Conclusion != "canonical" {
+		t.Fatalf("unrenderable locale = %s/%q, want generated locale", fallback.Locale, fallback.Conclusion)
+	}
+	if _, err := mapper.FromRowIn(context.Background(), row, policy.AudienceAdmin, policy.ReportAudienceCanonical, "zh-CN"); err != nil {
+		t.Fatal(err)
+	}
+	if len(locales.requested) != 2 {
+		t.Fatalf("generated locale must not be re-rendered: %v", locales.requested)
+	}
+}
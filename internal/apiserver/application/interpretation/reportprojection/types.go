@@ -1,6 +1,10 @@
 package reportprojection
 
-import "time"
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
 
 type ModelIdentity struct {
 	Kind, Algorithm, Code, Version, Title string
@@ -56,6 +60,7 @@ type Suggestion struct {
 
 type Report struct {
 	AssessmentID       uint64
+	TesteeID           uint64 // 按测评读取单份报告时由授权结果填充，供访问审计；列表投影为 0
 	Model              ModelIdentity
 	PrimaryScore       *ScoreValue
 	Level              *ResultLevel
@@ -65,6 +70,23 @@ type Report struct {
 	ModelExtra         *ModelExtra
 	CreatedAt          time.Time
 	PresentationSource string
+	// Audience 是实际返回的受众版本（canonical/clinician/family/school）；
+	// 请求的受众未发布时为回落后的版本。
+	Audience string
+	// Locale 是实际返回正文的内容语言；请求的语言无法渲染时为报告生成时的语言。
+	Locale string
+	// Charts 由本正文的维度派生的图表，见 Charts；客户端与 PDF 都按它绘制，不再各自计算。
+	Charts []reportchart.Spec
+	// ClinicianAddendum 从业者签署复核后附加的补充说明；机器生成的报告内容本身不变。
+	ClinicianAddendum *ClinicianAddendum
+}
+
+// ClinicianAddendum 报告签署后面向受试者展示的临床补充说明。
+type ClinicianAddendum struct {
+	Content      string
+	ReviewerName string
+	ReviewStatus string
+	ReviewedAt   time.Time
 }
 
 type ListResult struct {
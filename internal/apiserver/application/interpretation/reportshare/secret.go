@@ -0,0 +1,84 @@
+package reportshare
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// codeAlphabet 去掉易混淆的 0/O、1/I，长度 32 以便按位取值时分布均匀。
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 10
+	tokenBytes   = 32
+)
+
+// newToken 生成分享链接令牌（256 位随机数的 base64url 形式）。
+func newToken() (string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// newAccessCode 生成形如 "ABCDE-FGH23" 的访问码（50 位熵）。
+func newAccessCode() (string, error) {
+	raw := make([]byte, codeLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for index, value := range raw {
+		if index == codeLength/2 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(codeAlphabet[int(value)&(len(codeAlphabet)-1)])
+	}
+	return b.String(), nil
+}
+
+// NormalizeAccessCode 忽略大小写、空白与分隔符，便于接收方手工输入。
+func NormalizeAccessCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '-' || r == ' ' || r == '\t':
+			return -1
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return r
+		}
+	}, strings.TrimSpace(code))
+}
+
+// secretHash 令牌与访问码的存储摘要；二者都是高熵随机值，无需加盐。
+func secretHash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashPIN(salt, pin string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(pin))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func pinMatches(grant *Grant, pin string) bool {
+	return hmac.Equal([]byte(hashPIN(grant.PINSalt, pin)), []byte(grant.PINHash))
+}
+
+func validPIN(pin string) bool {
+	if len(pin) < 4 || len(pin) > 8 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,372 @@
+package reportshare
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/queryerror"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	// DefaultTTL 未指定有效期时的分享时长。
+	DefaultTTL = 72 * time.Hour
+	// DefaultMaxTTL 分享有效期上限的默认值。
+	DefaultMaxTTL = 30 * 24 * time.Hour
+	// DefaultMaxViews 未指定浏览次数时的上限。
+	DefaultMaxViews = 10
+	// DefaultViewLimit 单个授权可设置的浏览次数上限的默认值。
+	DefaultViewLimit = 100
+	// maxUserAgentLength 访问记录中 User-Agent 的截断长度。
+	maxUserAgentLength = 255
+)
+
+// Service 报告分享用例。
+type Service interface {
+	// Create 为受试者本人的测评报告创建分享授权。
+	Create(ctx context.Context, in CreateInput) (*Created, error)
+	// List 列出受试者在某次测评上创建过的全部授权，含已失效的。
+	List(ctx context.Context, testeeID, assessmentID uint64) ([]*Share, error)
+	// Revoke 撤销授权；重复撤销保持首次撤销时间。
+	Revoke(ctx context.Context, testeeID, shareID uint64) (*Share, error)
+	// Open 以链接令牌或访问码打开只读报告，并记录本次访问。
+	Open(ctx context.Context, in OpenInput) (*View, error)
+}
+
+// Config 服务配置。
+type Config struct {
+	DefaultTTL      time.Duration
+	MaxTTL          time.Duration
+	DefaultMaxViews int
+	// ViewLimit 单个授权可设置的最大浏览次数。
+	ViewLimit int
+}
+
+type service struct {
+	store       Store
+	reports     interpretationreadmodel.ReportReader
+	projection  reportprojection.Mapper
+	participant ParticipantAccess
+	config      Config
+	now         func() time.Time
+}
+
+// NewService 创建报告分享服务。projection 决定受众版本的正文来源，应与受试者读取路径共用同一映射。
+func NewService(
+	store Store,
+	reports interpretationreadmodel.ReportReader,
+	projection reportprojection.Mapper,
+	participant ParticipantAccess,
+	config Config,
+) Service {
+	if config.MaxTTL <= 0 {
+		config.MaxTTL = DefaultMaxTTL
+	}
+	if config.DefaultTTL <= 0 || config.DefaultTTL > config.MaxTTL {
+		config.DefaultTTL = min(DefaultTTL, config.MaxTTL)
+	}
+	if config.ViewLimit <= 0 {
+		config.ViewLimit = DefaultViewLimit
+	}
+	if config.DefaultMaxViews <= 0 || config.DefaultMaxViews > config.ViewLimit {
+		config.DefaultMaxViews = min(DefaultMaxViews, config.ViewLimit)
+	}
+	return &service{
+		store:       store,
+		reports:     reports,
+		projection:  projection,
+		participant: participant,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+func (s *service) Create(ctx context.Context, in CreateInput) (*Created, error) {
+	if in.TesteeID == 0 || in.AssessmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and assessment_id are required")
+	}
+	if in.Kind != KindLink && in.Kind != KindCode {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "kind must be link or code")
+	}
+	audience, ok := policy.ParseReportAudience(strings.TrimSpace(in.Audience))
+	if !ok || audience.IsCanonical() {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "audience must be one of clinician, family or school")
+	}
+	ttl := in.TTL
+	if ttl == 0 {
+		ttl = s.config.DefaultTTL
+	}
+	if ttl < time.Minute || ttl > s.config.MaxTTL {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "ttl must be within [1m, %s]", s.config.MaxTTL)
+	}
+	maxViews := in.MaxViews
+	if maxViews == 0 {
+		maxViews = s.config.DefaultMaxViews
+	}
+	if maxViews < 1 || maxViews > s.config.ViewLimit {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "max_views must be within [1, %d]", s.config.ViewLimit)
+	}
+	if in.PIN != "" && !validPIN(in.PIN) {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "pin must be 4 to 8 digits")
+	}
+	label := strings.TrimSpace(in.Label)
+	if utf8.RuneCountInString(label) > MaxLabelLength {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "label must be at most %d characters", MaxLabelLength)
+	}
+	if s.participant == nil {
+		return nil, cberrors.WithCode(code.ErrModuleInitializationFailed, "report share participant access is not configured")
+	}
+	if err := s.participant.AuthorizeOwnAssessment(ctx, in.TesteeID, in.AssessmentID); err != nil {
+		return nil, err
+	}
+	// 报告尚未生成时不允许分享，避免接收方拿到一个暂时打不开的链接。
+	if _, err := s.reports.GetReportByAssessmentID(ctx, in.AssessmentID); err != nil {
+		return nil, queryerror.MapReadError(err)
+	}
+
+	now := s.now()
+	active, err := s.store.CountActive(ctx, in.AssessmentID, now)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "count active report shares")
+	}
+	if active >= MaxActivePerAssessment {
+		return nil, cberrors.WithCode(code.ErrReportShareLimitExceeded, "at most %d active shares per report", MaxActivePerAssessment)
+	}
+
+	created := &Created{}
+	var secret string
+	if in.Kind == KindLink {
+		created.Token, err = newToken()
+		secret = created.Token
+	} else {
+		created.AccessCode, err = newAccessCode()
+		secret = NormalizeAccessCode(created.AccessCode)
+	}
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrUnknown, "generate report share secret")
+	}
+	grant := &Grant{
+		ID:           meta.New().Uint64(),
+		TesteeID:     in.TesteeID,
+		AssessmentID: in.AssessmentID,
+		Kind:         in.Kind,
+		Audience:     audience,
+		Label:        label,
+		SecretHash:   secretHash(secret),
+		MaxViews:     maxViews,
+		ExpiresAt:    now.Add(ttl).Truncate(time.Second),
+		CreatedAt:    now,
+	}
+	if in.PIN != "" {
+		salt, err := newToken()
+		if err != nil {
+			return nil, cberrors.WrapC(err, code.ErrUnknown, "generate report share pin salt")
+		}
+		grant.PINSalt = salt
+		grant.PINHash = hashPIN(salt, in.PIN)
+	}
+	if err := s.store.Create(ctx, grant); err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "save report share")
+	}
+	logger.L(ctx).Infow("report share created",
+		"action", "create_report_share",
+		"share_id", grant.ID,
+		"assessment_id", grant.AssessmentID,
+		"kind", string(grant.Kind),
+		"audience", grant.Audience.String(),
+		"max_views", grant.MaxViews,
+		"expires_at", grant.ExpiresAt,
+	)
+	created.Share = toShare(grant, now)
+	return created, nil
+}
+
+func (s *service) List(ctx context.Context, testeeID, assessmentID uint64) ([]*Share, error) {
+	if testeeID == 0 || assessmentID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and assessment_id are required")
+	}
+	if s.participant == nil {
+		return nil, cberrors.WithCode(code.ErrModuleInitializationFailed, "report share participant access is not configured")
+	}
+	if err := s.participant.AuthorizeOwnAssessment(ctx, testeeID, assessmentID); err != nil {
+		return nil, err
+	}
+	grants, err := s.store.ListByAssessment(ctx, testeeID, assessmentID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "list report shares")
+	}
+	now := s.now()
+	shares := make([]*Share, 0, len(grants))
+	for _, grant := range grants {
+		shares = append(shares, toShare(grant, now))
+	}
+	return shares, nil
+}
+
+func (s *service) Revoke(ctx context.Context, testeeID, shareID uint64) (*Share, error) {
+	if testeeID == 0 || shareID == 0 {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "testee_id and share_id are required")
+	}
+	grant, err := s.store.FindByID(ctx, shareID)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load report share")
+	}
+	// 他人的授权与不存在的授权返回同一错误，不暴露授权是否存在。
+	if grant == nil || grant.TesteeID != testeeID {
+		return nil, cberrors.WithCode(code.ErrReportShareNotFound, "report share not found")
+	}
+	now := s.now()
+	if grant.RevokedAt == nil {
+		if err := s.store.Revoke(ctx, grant.ID, now); err != nil {
+			return nil, cberrors.WrapC(err, code.ErrDatabase, "revoke report share")
+		}
+		grant.RevokedAt = &now
+		logger.L(ctx).Infow("report share revoked",
+			"action", "revoke_report_share",
+			"share_id", grant.ID,
+			"assessment_id", grant.AssessmentID,
+			"view_count", grant.ViewCount,
+		)
+	}
+	return toShare(grant, now), nil
+}
+
+func (s *service) Open(ctx context.Context, in OpenInput) (*View, error) {
+	var hash string
+	switch token, accessCode := strings.TrimSpace(in.Token), NormalizeAccessCode(in.AccessCode); {
+	case token != "" && accessCode == "":
+		hash = secretHash(token)
+	case accessCode != "" && token == "":
+		hash = secretHash(accessCode)
+	default:
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "exactly one of token or access_code is required")
+	}
+	grant, err := s.store.FindBySecretHash(ctx, hash)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "load report share")
+	}
+	if grant == nil {
+		logger.L(ctx).Warnw("unknown report share presented",
+			"action", "open_report_share",
+			"client_ip", in.Client.IP,
+		)
+		return nil, cberrors.WithCode(code.ErrReportShareUnavailable, "report share is invalid")
+	}
+
+	now := s.now()
+	switch grant.StatusAt(now) {
+	case StatusRevoked:
+		return nil, s.deny(ctx, grant, OutcomeRevoked, in.Client, now, code.ErrReportShareUnavailable, "report share has been revoked")
+	case StatusExpired:
+		return nil, s.deny(ctx, grant, OutcomeExpired, in.Client, now, code.ErrReportShareUnavailable, "report share has expired")
+	case StatusExhausted:
+		return nil, s.deny(ctx, grant, OutcomeExhausted, in.Client, now, code.ErrReportShareUnavailable, "report share has no views left")
+	case StatusLocked:
+		return nil, s.deny(ctx, grant, OutcomeLocked, in.Client, now, code.ErrReportShareUnavailable, "report share is locked after too many wrong PINs")
+	}
+	if grant.PINProtected() {
+		if in.PIN == "" {
+			return nil, s.deny(ctx, grant, OutcomePINRequired, in.Client, now, code.ErrReportSharePINRequired, "pin is required")
+		}
+		if !pinMatches(grant, in.PIN) {
+			if err := s.store.RecordFailedPIN(ctx, grant.ID); err != nil {
+				return nil, cberrors.WrapC(err, code.ErrDatabase, "record report share pin failure")
+			}
+			return nil, s.deny(ctx, grant, OutcomePINRejected, in.Client, now, code.ErrReportSharePINRequired, "pin is incorrect")
+		}
+	}
+
+	row, err := s.reports.GetReportByAssessmentID(ctx, grant.AssessmentID)
+	if err != nil {
+		return nil, queryerror.MapReadError(err)
+	}
+	// 接收方看到的可见范围与受试者本人一致，正文按授权选定的受众版本投影。
+	report, err := s.projection.FromRowAs(ctx, *row, policy.AudienceParticipant, grant.Audience)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrInterpretReportInvalid, "project shared report")
+	}
+	access := newAccess(grant, OutcomeViewed, in.Client, now)
+	access.ReportID = row.ReportID
+	consumed, err := s.store.ConsumeView(ctx, access)
+	if err != nil {
+		return nil, cberrors.WrapC(err, code.ErrDatabase, "record report share view")
+	}
+	if !consumed {
+		return nil, s.deny(ctx, grant, OutcomeExhausted, in.Client, now, code.ErrReportShareUnavailable, "report share is no longer available")
+	}
+	logger.L(ctx).Infow("report share viewed",
+		"action", "open_report_share",
+		"share_id", grant.ID,
+		"assessment_id", grant.AssessmentID,
+		"report_id", row.ReportID,
+		"audience", report.Audience,
+	)
+	// 公开视图不携带内部测评 ID。
+	report.AssessmentID = 0
+	return &View{
+		Report:         report,
+		Audience:       report.Audience,
+		ExpiresAt:      grant.ExpiresAt,
+		RemainingViews: grant.MaxViews - grant.ViewCount - 1,
+	}, nil
+}
+
+// deny 记录被拒绝的访问并返回对应错误。拒绝本身不放行任何内容，访问记录写入失败只记日志。
+func (s *service) deny(ctx context.Context, grant *Grant, outcome Outcome, client Client, now time.Time, errCode int, message string) error {
+	if err := s.store.AppendAccess(ctx, newAccess(grant, outcome, client, now)); err != nil {
+		logger.L(ctx).Errorw("failed to record report share access",
+			"action", "open_report_share",
+			"share_id", grant.ID,
+			"outcome", string(outcome),
+			"error", err.Error(),
+		)
+	}
+	logger.L(ctx).Infow("report share access denied",
+		"action", "open_report_share",
+		"share_id", grant.ID,
+		"outcome", string(outcome),
+	)
+	return cberrors.WithCode(errCode, "%s", message)
+}
+
+func newAccess(grant *Grant, outcome Outcome, client Client, now time.Time) *Access {
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	return &Access{
+		GrantID:      grant.ID,
+		TesteeID:     grant.TesteeID,
+		AssessmentID: grant.AssessmentID,
+		Outcome:      outcome,
+		ClientIP:     client.IP,
+		UserAgent:    userAgent,
+		AccessedAt:   now,
+	}
+}
+
+func toShare(grant *Grant, now time.Time) *Share {
+	return &Share{
+		ID:           grant.ID,
+		AssessmentID: grant.AssessmentID,
+		Kind:         grant.Kind,
+		Audience:     grant.Audience,
+		Label:        grant.Label,
+		PINProtected: grant.PINProtected(),
+		MaxViews:     grant.MaxViews,
+		ViewCount:    grant.ViewCount,
+		Status:       grant.StatusAt(now),
+		ExpiresAt:    grant.ExpiresAt,
+		CreatedAt:    grant.CreatedAt,
+		LastViewedAt: grant.LastViewedAt,
+		RevokedAt:    grant.RevokedAt,
+	}
+}
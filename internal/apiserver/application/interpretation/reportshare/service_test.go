@@ -0,0 +1,320 @@
+package reportshare
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	grants   map[uint64]*Grant
+	accesses []Access
+}
+
+func (s *fakeStore) Create(_ context.Context, grant *Grant) error {
+	copied := *grant
+	s.grants[grant.ID] = &copied
+	return nil
+}
+
+func (s *fakeStore) FindByID(_ context.Context, id uint64) (*Grant, error) {
+	if grant, ok := s.grants[id]; ok {
+		copied := *grant
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) FindBySecretHash(_ context.Context, hash string) (*Grant, error) {
+	for _, grant := range s.grants {
+		if grant.SecretHash == hash {
+			copied := *grant
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) ListByAssessment(_ context.Context, testeeID, assessmentID uint64) ([]*Grant, error) {
+	var grants []*Grant
+	for _, grant := range s.grants {
+		if grant.TesteeID == testeeID && grant.AssessmentID == assessmentID {
+			grants = append(grants, grant)
+		}
+	}
+	return grants, nil
+}
+
+func (s *fakeStore) CountActive(_ context.Context, assessmentID uint64, now time.Time) (int, error) {
+	count := 0
+	for _, grant := range s.grants {
+		if grant.AssessmentID == assessmentID && grant.StatusAt(now) == StatusActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeStore) Revoke(_ context.Context, id uint64, at time.Time) error {
+	s.grants[id].RevokedAt = &at
+	return nil
+}
+
+func (s *fakeStore) RecordFailedPIN(_ context.Context, id uint64) error {
+	s.grants[id].FailedPINAttempts++
+	return nil
+}
+
+func (s *fakeStore) ConsumeView(_ context.Context, access *Access) (bool, error) {
+	grant := s.grants[access.GrantID]
+	if grant.StatusAt(access.AccessedAt) != StatusActive {
+		return false, nil
+	}
+	grant.ViewCount++
+	grant.FailedPINAttempts = 0
+	at := access.AccessedAt
+	grant.LastViewedAt = &at
+	s.accesses = append(s.accesses, *access)
+	return true, nil
+}
+
+func (s *fakeStore) AppendAccess(_ context.Context, access *Access) error {
+	s.accesses = append(s.accesses, *access)
+	return nil
+}
+
+func (s *fakeStore) outcomes() []Outcome {
+	outcomes := make([]Outcome, 0, len(s.accesses))
+	for _, access := range s.accesses {
+		outcomes = append(outcomes, access.Outcome)
+	}
+	return outcomes
+}
+
+type fakeReports struct {
+	row *interpretationreadmodel.ReportRow
+}
+
+func (r fakeReports) GetReportByAssessmentID(_ context.Context, assessmentID uint64) (*interpretationreadmodel.ReportRow, error) {
+	if r.row == nil || r.row.AssessmentID != assessmentID {
+		return nil, interpretationreadmodel.ErrReportNotFound
+	}
+	copied := *r.row
+	return &copied, nil
+}
+
+func (fakeReports) ListReports(context.Context, interpretationreadmodel.ReportFilter, interpretationreadmodel.PageRequest) ([]interpretationreadmodel.ReportRow, int64, error) {
+	return nil, 0, nil
+}
+
+type fakeVariants map[string]interpretationreadmodel.ReportRow
+
+func (v fakeVariants) FindAudienceVariants(_ context.Context, reportIDs []uint64, audience string) (map[uint64]interpretationreadmodel.ReportRow, error) {
+	result := map[uint64]interpretationreadmodel.ReportRow{}
+	if row, ok := v[audience]; ok {
+		for _, id := range reportIDs {
+			result[id] = row
+		}
+	}
+	return result, nil
+}
+
+type fakeParticipantAccess struct{ owner map[uint64]uint64 }
+
+func (a fakeParticipantAccess) AuthorizeOwnAssessment(_ context.Context, testeeID, assessmentID uint64) error {
+	if a.owner[assessmentID] != testeeID {
+		return cberrors.WithCode(code.ErrPermissionDenied, "assessment does not belong to testee")
+	}
+	return nil
+}
+
+func newTestService(t *testing.T) (*service, *fakeStore) {
+	t.Helper()
+	row := &interpretationreadmodel.ReportRow{
+		AssessmentID: 5001,
+		ReportID:     9001,
+		Model:        interpretationreadmodel.ModelIdentityRow{Kind: "scale", Code: "SDQ", Version: "1.0.0"},
+		Conclusion:   "规范结论",
+		PresentationProfile: &interpretationreadmodel.PresentationProfileRow{
+			VisibleFactorCodes: []string{"conduct"},
+			Source:             string(domainreport.PresentationProfileSourceFrozen),
+		},
+		CreatedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}
+	school := *row
+	school.Audience = "school"
+	school.Conclusion = "面向学校的结论"
+	store := &fakeStore{grants: map[uint64]*Grant{}}
+	svc := NewService(store, fakeReports{row: row},
+		reportprojection.Mapper{Variants: fakeVariants{"school": school}},
+		fakeParticipantAccess{owner: map[uint64]uint64{5001: 401}},
+		Config{MaxTTL: 7 * 24 * time.Hour},
+	).(*service)
+	svc.now = func() time.Time { return time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC) }
+	return svc, store
+}
+
+func TestLinkShareServesChosenAudienceUntilViewsRunOut(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+	created, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "school", MaxViews: 2, Label: " 班主任 "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Token == "" || created.AccessCode != "" || created.Share.Label != "班主任" || created.Share.Status != StatusActive {
+		t.Fatalf("created = %#v, share = %#v", created, created.Share)
+	}
+	if !created.Share.ExpiresAt.Equal(time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expires at = %s, want default ttl", created.Share.ExpiresAt)
+	}
+	if stored := store.grants[created.Share.ID]; stored.SecretHash == created.Token || stored.SecretHash != secretHash(created.Token) {
+		t.Fatal("store must keep only the token digest")
+	}
+
+	client := Client{IP: "203.0.113.9", UserAgent: "Mozilla/5.0"}
+	view, err := svc.Open(ctx, OpenInput{Token: created.Token, Client: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Report.Conclusion != "面向学校的结论" || view.Audience != "school" || view.Report.AssessmentID != 0 || view.RemainingViews != 1 {
+		t.Fatalf("view = %#v, report = %#v", view, view.Report)
+	}
+	if _, err := svc.Open(ctx, OpenInput{Token: created.Token, Client: client}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Open(ctx, OpenInput{Token: created.Token, Client: client}); !cberrors.IsCode(err, code.ErrReportShareUnavailable) {
+		t.Fatalf("third open err = %v, want unavailable", err)
+	}
+	if _, err := svc.Open(ctx, OpenInput{Token: "not-a-share", Client: client}); !cberrors.IsCode(err, code.ErrReportShareUnavailable) {
+		t.Fatalf("unknown token err = %v, want unavailable", err)
+	}
+
+	outcomes := store.outcomes()
+	if len(outcomes) != 3 || outcomes[0] != OutcomeViewed || outcomes[1] != OutcomeViewed || outcomes[2] != OutcomeExhausted {
+		t.Fatalf("access outcomes = %v", outcomes)
+	}
+	if store.accesses[0].ReportID != 9001 || store.accesses[0].ClientIP != "203.0.113.9" || store.accesses[2].ReportID != 0 {
+		t.Fatalf("accesses = %#v", store.accesses)
+	}
+}
+
+func TestCodeShareRequiresPINAndLocksAfterRepeatedFailures(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+	created, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindCode, Audience: "clinician", PIN: "2468"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.AccessCode) != codeLength+1 || created.Token != "" || !created.Share.PINProtected {
+		t.Fatalf("created = %#v", created)
+	}
+	// 接收方手工输入时大小写与分隔符都不影响匹配。
+	normalized := NormalizeAccessCode(created.AccessCode)
+	typed := " " + strings.ToLower(normalized[:3]) + "-" + normalized[3:] + " "
+	if _, err := svc.Open(ctx, OpenInput{AccessCode: typed}); !cberrors.IsCode(err, code.ErrReportSharePINRequired) {
+		t.Fatalf("open without pin err = %v, want pin required", err)
+	}
+	for attempt := 0; attempt < MaxPINAttempts; attempt++ {
+		if _, err := svc.Open(ctx, OpenInput{AccessCode: created.AccessCode, PIN: "0000"}); !cberrors.IsCode(err, code.ErrReportSharePINRequired) {
+			t.Fatalf("wrong pin err = %v, want pin required", err)
+		}
+	}
+	if _, err := svc.Open(ctx, OpenInput{AccessCode: created.AccessCode, PIN: "2468"}); !cberrors.IsCode(err, code.ErrReportShareUnavailable) {
+		t.Fatalf("correct pin after lock err = %v, want unavailable", err)
+	}
+	outcomes := store.outcomes()
+	if outcomes[0] != OutcomePINRequired || outcomes[1] != OutcomePINRejected || outcomes[len(outcomes)-1] != OutcomeLocked {
+		t.Fatalf("access outcomes = %v", outcomes)
+	}
+	shares, err := svc.List(ctx, 401, 5001)
+	if err != nil || len(shares) != 1 || shares[0].Status != StatusLocked || shares[0].ViewCount != 0 {
+		t.Fatalf("List() = %#v, %v", shares, err)
+	}
+}
+
+func TestCodeShareCorrectPINResetsConsecutiveFailures(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+	created, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindCode, Audience: "clinician", PIN: "2468"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 两轮各差一次即锁定，中间一次正确 PIN 清零计数，授权保持可用。
+	for round := 0; round < 2; round++ {
+		for attempt := 0; attempt < MaxPINAttempts-1; attempt++ {
+			if _, err := svc.Open(ctx, OpenInput{AccessCode: created.AccessCode, PIN: "0000"}); !cberrors.IsCode(err, code.ErrReportSharePINRequired) {
+				t.Fatalf("round %d wrong pin err = %v, want pin required", round, err)
+			}
+		}
+		if _, err := svc.Open(ctx, OpenInput{AccessCode: created.AccessCode, PIN: "2468"}); err != nil {
+			t.Fatalf("round %d correct pin err = %v", round, err)
+		}
+	}
+	if grant := store.grants[created.Share.ID]; grant.FailedPINAttempts != 0 || grant.ViewCount != 2 {
+		t.Fatalf("grant = %#v, want failures reset and two views", grant)
+	}
+}
+
+func TestRevokeIsScopedToOwnerAndStopsAccess(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+	created, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "family"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Revoke(ctx, 402, created.Share.ID); !cberrors.IsCode(err, code.ErrReportShareNotFound) {
+		t.Fatalf("revoke by other testee err = %v, want not found", err)
+	}
+	revoked, err := svc.Revoke(ctx, 401, created.Share.ID)
+	if err != nil || revoked.Status != StatusRevoked {
+		t.Fatalf("Revoke() = %#v, %v", revoked, err)
+	}
+	svc.now = func() time.Time { return time.Date(2026, 10, 2, 11, 0, 0, 0, time.UTC) }
+	again, err := svc.Revoke(ctx, 401, created.Share.ID)
+	if err != nil || !again.RevokedAt.Equal(*revoked.RevokedAt) {
+		t.Fatalf("second Revoke() = %#v, %v; want first revocation kept", again, err)
+	}
+	if _, err := svc.Open(ctx, OpenInput{Token: created.Token}); !cberrors.IsCode(err, code.ErrReportShareUnavailable) {
+		t.Fatalf("open revoked err = %v, want unavailable", err)
+	}
+	if outcomes := store.outcomes(); len(outcomes) != 1 || outcomes[0] != OutcomeRevoked {
+		t.Fatalf("access outcomes = %v", outcomes)
+	}
+}
+
+func TestCreateValidatesAudienceLimitsAndOwnership(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	cases := []struct {
+		name  string
+		input CreateInput
+		code  int
+	}{
+		{"canonical audience", CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "canonical"}, code.ErrInvalidArgument},
+		{"ttl above max", CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "family", TTL: 8 * 24 * time.Hour}, code.ErrInvalidArgument},
+		{"too many views", CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "family", MaxViews: DefaultViewLimit + 1}, code.ErrInvalidArgument},
+		{"short pin", CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindCode, Audience: "family", PIN: "12"}, code.ErrInvalidArgument},
+		{"unknown kind", CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: "email", Audience: "family"}, code.ErrInvalidArgument},
+		{"other testee", CreateInput{TesteeID: 402, AssessmentID: 5001, Kind: KindLink, Audience: "family"}, code.ErrPermissionDenied},
+	}
+	for _, tc := range cases {
+		if _, err := svc.Create(ctx, tc.input); !cberrors.IsCode(err, tc.code) {
+			t.Fatalf("%s: err = %v, want code %d", tc.name, err, tc.code)
+		}
+	}
+	for index := 0; index < MaxActivePerAssessment; index++ {
+		if _, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "family"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.Create(ctx, CreateInput{TesteeID: 401, AssessmentID: 5001, Kind: KindLink, Audience: "family"}); !cberrors.IsCode(err, code.ErrReportShareLimitExceeded) {
+		t.Fatalf("over limit err = %v, want limit exceeded", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Package reportshare 解读报告的对外分享授权。
+//
+// 受试者（通常是家长）可为没有账号的第三方（学校心理老师、儿科医生）创建分享授权：
+// 有时效的分享链接，或可附加 PIN 的访问码。每个授权绑定一份测评的报告与一个受众版本，
+// 访问时按该受众投影只读正文；授权可随时撤销，到期、撤销、浏览次数用尽或 PIN 连续错误后即失效。
+// 链接令牌与访问码只在创建时返回一次，存储中仅保留摘要；每次访问（无论是否放行）都写入访问记录。
+package reportshare
+
+import (
+	"context"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainshare "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportshare"
+)
+
+const (
+	// MaxPINAttempts PIN 连续错误达到该次数后授权锁定，只能撤销后重新分享。
+	MaxPINAttempts = domainshare.MaxPINAttempts
+	// MaxActivePerAssessment 同一测评同时有效的分享授权上限。
+	MaxActivePerAssessment = 20
+	// MaxLabelLength 接收方备注的最大字符数。
+	MaxLabelLength = 64
+)
+
+type (
+	Kind    = domainshare.Kind
+	Status  = domainshare.Status
+	Outcome = domainshare.Outcome
+	Grant   = domainshare.Grant
+	Access  = domainshare.Access
+)
+
+const (
+	KindLink = domainshare.KindLink
+	KindCode = domainshare.KindCode
+
+	StatusActive    = domainshare.StatusActive
+	StatusExpired   = domainshare.StatusExpired
+	StatusRevoked   = domainshare.StatusRevoked
+	StatusExhausted = domainshare.StatusExhausted
+	StatusLocked    = domainshare.StatusLocked
+
+	OutcomeViewed      = domainshare.OutcomeViewed
+	OutcomePINRequired = domainshare.OutcomePINRequired
+	OutcomePINRejected = domainshare.OutcomePINRejected
+	OutcomeExpired     = domainshare.OutcomeExpired
+	OutcomeRevoked     = domainshare.OutcomeRevoked
+	OutcomeExhausted   = domainshare.OutcomeExhausted
+	OutcomeLocked      = domainshare.OutcomeLocked
+)
+
+// Share 面向分享者展示的授权摘要，不含令牌或 PIN 摘要。
+type Share struct {
+	ID           uint64
+	AssessmentID uint64
+	Kind         Kind
+	Audience     policy.ReportAudience
+	Label        string
+	PINProtected bool
+	MaxViews     int
+	ViewCount    int
+	Status       Status
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	LastViewedAt *time.Time
+	RevokedAt    *time.Time
+}
+
+// Client 访问方的请求元数据，由公开入口透传。
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// CreateInput 创建分享授权。TTL 与 MaxViews 为零时取配置默认值。
+type CreateInput struct {
+	TesteeID     uint64
+	AssessmentID uint64
+	Kind         Kind
+	Audience     string
+	TTL          time.Duration
+	MaxViews     int
+	PIN          string
+	Label        string
+}
+
+// Created 新建的授权；Token 与 AccessCode 只在此处返回一次。
+type Created struct {
+	Share      *Share
+	Token      string
+	AccessCode string
+}
+
+// OpenInput 公开访问；Token 与 AccessCode 二选一。
+type OpenInput struct {
+	Token      string
+	AccessCode string
+	PIN        string
+	Client     Client
+}
+
+// View 放行后的只读报告视图。
+type View struct {
+	Report         *reportprojection.Report
+	Audience       string
+	ExpiresAt      time.Time
+	RemainingViews int
+}
+
+// ParticipantAccess 校验测评属于该受试者。
+type ParticipantAccess interface {
+	AuthorizeOwnAssessment(ctx context.Context, testeeID, assessmentID uint64) error
+}
+
+// Store 分享授权与访问记录存储。
+type Store = domainshare.Repository
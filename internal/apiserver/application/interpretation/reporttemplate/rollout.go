@@ -0,0 +1,215 @@
+package reporttemplate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// StartRolloutCommand stages a candidate release against the stable release.
+type StartRolloutCommand struct {
+	Actor            Actor
+	TemplateID       string
+	StableVersion    policy.TemplateVersion
+	CandidateVersion policy.TemplateVersion
+	Mode             domainreporttemplate.RolloutMode
+	Percent          int
+	OrgIDs           []int64
+	Reason           string
+}
+
+// CloseRolloutCommand promotes or rolls back one rollout. ExpectedVersion guards
+// against acting on a rollout another operator has already changed.
+type CloseRolloutCommand struct {
+	Actor           Actor
+	RolloutID       meta.ID
+	ExpectedVersion uint64
+	Reason          string
+}
+
+type RolloutService interface {
+	StartRollout(ctx context.Context, command StartRolloutCommand) (*domainreporttemplate.Rollout, error)
+	PromoteRollout(ctx context.Context, command CloseRolloutCommand) (*domainreporttemplate.Rollout, error)
+	RollbackRollout(ctx context.Context, command CloseRolloutCommand) (*domainreporttemplate.Rollout, error)
+	GetRollout(ctx context.Context, rolloutID meta.ID) (*domainreporttemplate.Rollout, error)
+	ListRollouts(ctx context.Context, templateID string, limit int) ([]*domainreporttemplate.Rollout, error)
+	ListShadowComparisons(ctx context.Context, rolloutID meta.ID, orgID int64, limit int) ([]*domainreporttemplate.ShadowComparison, error)
+}
+
+type rolloutService struct {
+	templates   domainreporttemplate.Repository
+	rollouts    domainreporttemplate.RolloutRepository
+	comparisons domainreporttemplate.ShadowComparisonRepository
+	manifests   domainreporttemplate.ManifestCatalog
+	tx          apptransaction.Runner
+	now         func() time.Time
+	newID       func() meta.ID
+}
+
+func NewRolloutService(
+	templates domainreporttemplate.Repository,
+	rollouts domainreporttemplate.RolloutRepository,
+	comparisons domainreporttemplate.ShadowComparisonRepository,
+	manifests domainreporttemplate.ManifestCatalog,
+	tx apptransaction.Runner,
+) RolloutService {
+	return &rolloutService{
+		templates: templates, rollouts: rollouts, comparisons: comparisons, manifests: manifests, tx: tx,
+		now: time.Now, newID: meta.New,
+	}
+}
+
+func (s *rolloutService) configured() error {
+	if s == nil || s.templates == nil || s.rollouts == nil || s.comparisons == nil || s.manifests == nil || s.tx == nil {
+		return fmt.Errorf("report template rollout service is not configured")
+	}
+	return nil
+}
+
+// StartRollout requires a published stable release and a registered, not yet
+// disabled candidate that renders every decision the stable release renders.
+func (s *rolloutService) StartRollout(ctx context.Context, command StartRolloutCommand) (*domainreporttemplate.Rollout, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	actor := actorLabel(command.Actor)
+	if actor == "" {
+		return nil, fmt.Errorf("operator identity is required")
+	}
+	stable, err := s.templates.FindPublished(ctx, command.TemplateID, command.StableVersion)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := s.templates.FindByKey(ctx, command.TemplateID, command.CandidateVersion)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.Status() == domainreporttemplate.StatusDisabled {
+		return nil, fmt.Errorf("disabled report template release cannot be rolled out: %s@%s", candidate.TemplateID(), candidate.TemplateVersion())
+	}
+	if err := validateReleaseMetadata(candidate, s.manifests); err != nil {
+		return nil, err
+	}
+	if err := candidate.Manifest().Covers(stable.Manifest()); err != nil {
+		return nil, err
+	}
+	rollout, err := domainreporttemplate.NewRollout(domainreporttemplate.StartRolloutInput{
+		ID: s.newID(), TemplateID: command.TemplateID, StableVersion: command.StableVersion, CandidateVersion: command.CandidateVersion,
+		Mode: command.Mode, Percent: command.Percent, OrgIDs: command.OrgIDs, Reason: command.Reason, Actor: actor, At: s.now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rollouts.Create(ctx, rollout); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// PromoteRollout closes the rollout and publishes a still-draft candidate in one
+// transaction, so the catalog can freeze the candidate from then on while
+// generations already frozen on the stable release are routed to it.
+func (s *rolloutService) PromoteRollout(ctx context.Context, command CloseRolloutCommand) (*domainreporttemplate.Rollout, error) {
+	rollout, actor, err := s.loadForClose(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := s.templates.FindByKey(ctx, rollout.TemplateID(), rollout.CandidateVersion())
+	if err != nil {
+		return nil, err
+	}
+	at := s.now()
+	publish := candidate.Status() == domainreporttemplate.StatusDraft
+	if publish {
+		if err := validateReleaseMetadata(candidate, s.manifests); err != nil {
+			return nil, err
+		}
+		if err := candidate.Publish(actor, at); err != nil {
+			return nil, err
+		}
+	} else if !candidate.IsPublished() {
+		return nil, fmt.Errorf("disabled report template release cannot be promoted: %s@%s", candidate.TemplateID(), candidate.TemplateVersion())
+	}
+	if err := rollout.Promote(actor, command.Reason, at); err != nil {
+		return nil, err
+	}
+	if err := s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if publish {
+			if err := s.templates.Save(txCtx, candidate); err != nil {
+				return err
+			}
+		}
+		return s.rollouts.Save(txCtx, rollout, command.ExpectedVersion)
+	}); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// RollbackRollout stops routing to the candidate. Generations already committed
+// with the candidate keep their reports; only subsequent generations change.
+func (s *rolloutService) RollbackRollout(ctx context.Context, command CloseRolloutCommand) (*domainreporttemplate.Rollout, error) {
+	rollout, actor, err := s.loadForClose(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	if err := rollout.Rollback(actor, command.Reason, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.rollouts.Save(ctx, rollout, command.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+func (s *rolloutService) loadForClose(ctx context.Context, command CloseRolloutCommand) (*domainreporttemplate.Rollout, string, error) {
+	if err := s.configured(); err != nil {
+		return nil, "", err
+	}
+	actor := actorLabel(command.Actor)
+	if actor == "" {
+		return nil, "", fmt.Errorf("operator identity is required")
+	}
+	if command.RolloutID.IsZero() || command.ExpectedVersion == 0 {
+		return nil, "", fmt.Errorf("rollout id and expected version are required")
+	}
+	rollout, err := s.rollouts.FindByID(ctx, command.RolloutID)
+	if err != nil {
+		return nil, "", err
+	}
+	if rollout.Version() != command.ExpectedVersion {
+		return nil, "", domainreporttemplate.ErrRolloutConflict
+	}
+	return rollout, actor, nil
+}
+
+func (s *rolloutService) GetRollout(ctx context.Context, rolloutID meta.ID) (*domainreporttemplate.Rollout, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	return s.rollouts.FindByID(ctx, rolloutID)
+}
+
+func (s *rolloutService) ListRollouts(ctx context.Context, templateID string, limit int) ([]*domainreporttemplate.Rollout, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	return s.rollouts.ListByTemplateID(ctx, templateID, limit)
+}
+
+// ListShadowComparisons only returns samples from the caller's organization;
+// rollouts are global but the compared reports are not.
+func (s *rolloutService) ListShadowComparisons(ctx context.Context, rolloutID meta.ID, orgID int64, limit int) ([]*domainreporttemplate.ShadowComparison, error) {
+	if err := s.configured(); err != nil {
+		return nil, err
+	}
+	if _, err := s.rollouts.FindByID(ctx, rolloutID); err != nil {
+		return nil, err
+	}
+	return s.comparisons.ListByRollout(ctx, rolloutID, orgID, limit)
+}
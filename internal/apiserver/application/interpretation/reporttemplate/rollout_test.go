@@ -0,0 +1,157 @@
+package reporttemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type memoryManifests map[string]domainreporttemplate.ReleaseManifest
+
+func (c memoryManifests) ResolveManifest(templateID string, version policy.TemplateVersion) (domainreporttemplate.ReleaseManifest, bool) {
+	manifest, ok := c[templateID+"|"+version.String()]
+	return manifest.Clone(), ok
+}
+
+type memoryRolloutRepo struct {
+	items map[meta.ID]*domainreporttemplate.Rollout
+}
+
+func (r *memoryRolloutRepo) Create(_ context.Context, rollout *domainreporttemplate.Rollout) error {
+	for _, item := range r.items {
+		if item.Status().IsEffective() && item.TemplateID() == rollout.TemplateID() && item.StableVersion() == rollout.StableVersion() {
+			return domainreporttemplate.ErrRolloutConflict
+		}
+	}
+	r.items[rollout.ID()] = rollout
+	return nil
+}
+
+func (r *memoryRolloutRepo) Save(_ context.Context, rollout *domainreporttemplate.Rollout, expectedVersion uint64) error {
+	if rollout.Version() != expectedVersion+1 {
+		return domainreporttemplate.ErrRolloutConflict
+	}
+	r.items[rollout.ID()] = rollout
+	return nil
+}
+
+func (r *memoryRolloutRepo) FindByID(_ context.Context, id meta.ID) (*domainreporttemplate.Rollout, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domainreporttemplate.ErrRolloutNotFound
+	}
+	return item, nil
+}
+
+func (r *memoryRolloutRepo) FindEffective(_ context.Context, templateID string, stable policy.TemplateVersion) (*domainreporttemplate.Rollout, error) {
+	for _, item := range r.items {
+		if item.Status().IsEffective() && item.TemplateID() == templateID && item.StableVersion() == stable {
+			return item, nil
+		}
+	}
+	return nil, domainreporttemplate.ErrRolloutNotFound
+}
+
+func (r *memoryRolloutRepo) ListByTemplateID(_ context.Context, templateID string, _ int) ([]*domainreporttemplate.Rollout, error) {
+	items := make([]*domainreporttemplate.Rollout, 0)
+	for _, item := range r.items {
+		if item.TemplateID() == templateID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+type memoryComparisonRepo struct{}
+
+func (memoryComparisonRepo) Insert(context.Context, *domainreporttemplate.ShadowComparison) error {
+	return nil
+}
+
+func (memoryComparisonRepo) ListByRollout(context.Context, meta.ID, int64, int) ([]*domainreporttemplate.ShadowComparison, error) {
+	return nil, nil
+}
+
+func TestRolloutServicePromotePublishesDraftCandidateInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	manifests := memoryManifests{}
+	repo := &memoryRepo{items: map[string]*domainreporttemplate.ReportTemplate{}}
+	for _, version := range []policy.TemplateVersion{policy.TemplateVersionV1, "custom-v2"} {
+		manifest, err := domainreporttemplate.NewReleaseManifest("mbti", version, policy.ReportTypeStandard, []domainreporttemplate.ManifestRoute{{
+			DecisionKind: modelcatalog.DecisionKindPoleComposition, BuilderIdentity: "typology",
+			ContentSchemaVersion: "report-content/v2", AdapterKey: "personality_type",
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests["mbti|"+version.String()] = manifest
+		tmpl, err := domainreporttemplate.NewDraft(domainreporttemplate.CreateInput{ID: meta.New(), Manifest: manifest, CreatedAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version == policy.TemplateVersionV1 {
+			if err := tmpl.Publish("user:1", now); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_ = repo.Save(ctx, tmpl)
+	}
+	rollouts := &memoryRolloutRepo{items: map[meta.ID]*domainreporttemplate.Rollout{}}
+	transactions := 0
+	tx := apptransaction.RunnerFunc(func(ctx context.Context, fn func(context.Context) error) error {
+		transactions++
+		return fn(ctx)
+	})
+	svc := NewRolloutService(repo, rollouts, memoryComparisonRepo{}, manifests, tx)
+	svc.(*rolloutService).now = func() time.Time { return now }
+	svc.(*rolloutService).newID = func() meta.ID { return meta.FromUint64(42) }
+
+	rollout, err := svc.StartRollout(ctx, StartRolloutCommand{
+		Actor: Actor{OperatorUserID: 2}, TemplateID: "mbti", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: domainreporttemplate.RolloutModeCanary, Percent: 5, Reason: "pilot",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.StartRollout(ctx, StartRolloutCommand{
+		Actor: Actor{OperatorUserID: 2}, TemplateID: "mbti", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: domainreporttemplate.RolloutModeShadow, Percent: 5, Reason: "second",
+	}); !errors.Is(err, domainreporttemplate.ErrRolloutConflict) {
+		t.Fatalf("second effective rollout err = %v, want conflict", err)
+	}
+
+	if _, err := svc.PromoteRollout(ctx, CloseRolloutCommand{
+		Actor: Actor{OperatorUserID: 3}, RolloutID: rollout.ID(), ExpectedVersion: 2, Reason: "stale",
+	}); !errors.Is(err, domainreporttemplate.ErrRolloutConflict) {
+		t.Fatalf("stale promote err = %v, want conflict", err)
+	}
+	promoted, err := svc.PromoteRollout(ctx, CloseRolloutCommand{
+		Actor: Actor{OperatorUserID: 3}, RolloutID: rollout.ID(), ExpectedVersion: 1, Reason: "canary healthy",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoted.Status() != domainreporttemplate.RolloutStatusPromoted || promoted.ClosedBy() != "user:3" || transactions != 1 {
+		t.Fatalf("promote = status %s by %q in %d transactions", promoted.Status(), promoted.ClosedBy(), transactions)
+	}
+	candidate, err := repo.FindPublished(ctx, "mbti", "custom-v2")
+	if err != nil || candidate.PublishedBy() != "user:3" {
+		t.Fatalf("candidate publish = %v, %v", candidate, err)
+	}
+	if _, err := svc.RollbackRollout(ctx, CloseRolloutCommand{
+		Actor: Actor{OperatorUserID: 4}, RolloutID: rollout.ID(), ExpectedVersion: 2, Reason: "regression",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rollouts.FindEffective(ctx, "mbti", policy.TemplateVersionV1); !errors.Is(err, domainreporttemplate.ErrRolloutNotFound) {
+		t.Fatalf("rolled back rollout still effective: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package riskalert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+)
+
+// Match 规则命中结果。Baseline 仅 score_change 规则有值。
+type Match struct {
+	Observed float64
+	Baseline *float64
+	Summary  string
+}
+
+// Evaluate 按规则评估一次测评结果；条件引用的题目、因子或上次结果不存在时视为未命中。
+func Evaluate(rule Rule, obs Observation) (*Match, bool) {
+	cond := rule.Condition
+	switch rule.Kind {
+	case RuleKindItemAnswer:
+		for _, answer := range obs.Answers {
+			if answer.QuestionCode != cond.QuestionCode {
+				continue
+			}
+			if !cond.Operator.Compare(answer.Score, cond.Threshold) {
+				return nil, false
+			}
+			return &Match{
+				Observed: answer.Score,
+				Summary:  fmt.Sprintf("题目 %s 得分 %s %s %s", cond.QuestionCode, formatValue(answer.Score), cond.Operator.Symbol(), formatValue(cond.Threshold)),
+			}, true
+		}
+		return nil, false
+	case RuleKindFactorScore:
+		value, ok := factorScore(obs.Execution, cond.FactorCode, evaluationfact.ScoreKind(cond.ScoreKind))
+		if !ok || !cond.Operator.Compare(value, cond.Threshold) {
+			return nil, false
+		}
+		return &Match{
+			Observed: value,
+			Summary:  fmt.Sprintf("因子 %s %s %s %s %s", cond.FactorCode, scoreKindLabel(cond.ScoreKind), formatValue(value), cond.Operator.Symbol(), formatValue(cond.Threshold)),
+		}, true
+	case RuleKindScoreChange:
+		current, ok := changeScore(obs.Execution, cond.FactorCode)
+		if !ok {
+			return nil, false
+		}
+		previous, ok := changeScore(obs.Previous, cond.FactorCode)
+		if !ok || current-previous <= cond.Threshold {
+			return nil, false
+		}
+		subject := "主分数"
+		if cond.FactorCode != "" {
+			subject = "因子 " + cond.FactorCode
+		}
+		return &Match{
+			Observed: current,
+			Baseline: &previous,
+			Summary: fmt.Sprintf("%s较上次测评升高 %s（%s → %s），超过 %s",
+				subject, formatValue(current-previous), formatValue(previous), formatValue(current), formatValue(cond.Threshold)),
+		}, true
+	case RuleKindRiskLevel:
+		if obs.Execution == nil || obs.Execution.Level == nil {
+			return nil, false
+		}
+		level := obs.Execution.Level
+		for _, code := range cond.Levels {
+			if strings.EqualFold(strings.TrimSpace(code), level.Code) {
+				label := level.Label
+				if label == "" {
+					label = level.Code
+				}
+				return &Match{Summary: "风险等级 " + label}, true
+			}
+		}
+		return nil, false
+	default:
+		return nil, false
+	}
+}
+
+// factorScore 取因子分数：kind 为空时取因子主分数，否则在主分数与派生分数中按类型查找。
+func factorScore(execution *evaluationfact.Execution, factorCode string, kind evaluationfact.ScoreKind) (float64, bool) {
+	if execution == nil {
+		return 0, false
+	}
+	for _, dim := range execution.Dimensions {
+		if dim.Code != factorCode {
+			continue
+		}
+		if dim.Score != nil && (kind == "" || dim.Score.Kind == kind) {
+			return dim.Score.Value, true
+		}
+		for _, derived := range dim.DerivedScores {
+			if derived.Kind == kind {
+				return derived.Value, true
+			}
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+// changeScore 取分数变化规则比较的分数：因子编码为空时取结果主分数。
+func changeScore(execution *evaluationfact.Execution, factorCode string) (float64, bool) {
+	if execution == nil {
+		return 0, false
+	}
+	if factorCode != "" {
+		return factorScore(execution, factorCode, "")
+	}
+	if execution.Primary == nil {
+		return 0, false
+	}
+	return execution.Primary.Value, true
+}
+
+func scoreKindLabel(kind ScoreKind) string {
+	switch kind {
+	case ScoreKindTScore:
+		return "T 分"
+	case "", ScoreKindRawTotal:
+		return "得分"
+	default:
+		return string(kind)
+	}
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,42 @@
+package riskalert
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+)
+
+// AggregateType 风险预警聚合根类型。
+const AggregateType = "RiskAlert"
+
+// PageData 寻呼事件数据。
+type PageData = eventpayload.RiskAlertPageData
+
+// newPageEvent 以预警当前寻呼到的渠道与级数创建寻呼事件：首次寻呼为 risk_alert.raised，之后为 risk_alert.escalated。
+func newPageEvent(alert *Alert, pagedAt time.Time) event.DomainEvent {
+	eventType := eventcatalog.RiskAlertRaised
+	if alert.EscalationLevel > 0 {
+		eventType = eventcatalog.RiskAlertEscalated
+	}
+	id := strconv.FormatUint(alert.ID, 10)
+	return event.New(eventType, AggregateType, id, PageData{
+		OrgID:           alert.OrgID,
+		AlertID:         id,
+		RuleID:          strconv.FormatUint(alert.RuleID, 10),
+		RuleName:        alert.RuleName,
+		RuleKind:        string(alert.RuleKind),
+		Severity:        string(alert.Severity),
+		Channel:         alert.Channel,
+		EscalationLevel: alert.EscalationLevel,
+		AssessmentID:    strconv.FormatUint(alert.AssessmentID, 10),
+		TesteeID:        strconv.FormatUint(alert.TesteeID, 10),
+		ModelCode:       alert.ModelCode,
+		Summary:         alert.Summary,
+		ObservedValue:   alert.ObservedValue,
+		TriggeredAt:     alert.TriggeredAt,
+		PagedAt:         pagedAt,
+	})
+}
@@ -0,0 +1,309 @@
+package riskalert
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact/codec"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Monitor 风险预警后台扫描：评估新完成的测评结果并触发预警，升级超时未确认的预警。
+// 由调度器在 leader 锁内周期调用。
+type Monitor interface {
+	// EvaluatePending 按规则评估尚未评估的测评结果，返回触发的预警数。
+	EvaluatePending(ctx context.Context, limit int) (int, error)
+	// EscalateOverdue 把超过确认时限的预警升级到升级链的下一级，返回升级的预警数。
+	EscalateOverdue(ctx context.Context, limit int) (int, error)
+}
+
+type monitor struct {
+	store    Store
+	facts    evaluationfact.Repository
+	tx       apptransaction.Runner
+	events   appEventing.ProfileBinding
+	lookback time.Duration
+	now      func() time.Time
+}
+
+// NewMonitor 创建风险预警扫描。lookback 限定只评估最近完成的测评结果；
+// 寻呼事件通过 events 与预警在同一事务内写入 outbox。
+func NewMonitor(store Store, facts evaluationfact.Repository, tx apptransaction.Runner, events appEventing.ProfileBinding, lookback time.Duration) Monitor {
+	return &monitor{store: store, facts: facts, tx: tx, events: events, lookback: lookback, now: time.Now}
+}
+
+func (m *monitor) EvaluatePending(ctx context.Context, limit int) (int, error) {
+	if m.store == nil || m.facts == nil || m.tx == nil {
+		return 0, errors.WithCode(code.ErrModuleInitializationFailed, "risk alert monitor is not configured")
+	}
+	outcomes, err := m.store.ListPendingOutcomes(ctx, m.now().Add(-m.lookback), limit)
+	if err != nil {
+		return 0, errors.WrapC(err, code.ErrDatabase, "list outcomes pending risk alert evaluation")
+	}
+	rulesByOrg := make(map[int64][]Rule)
+	raised := 0
+	for _, outcome := range outcomes {
+		rules, ok := rulesByOrg[outcome.OrgID]
+		if !ok {
+			if rules, err = m.store.ListEnabledRules(ctx, outcome.OrgID); err != nil {
+				return raised, errors.WrapC(err, code.ErrDatabase, "list risk alert rules")
+			}
+			rulesByOrg[outcome.OrgID] = rules
+		}
+		count, err := m.evaluateOutcome(ctx, outcome, rules)
+		if err != nil {
+			return raised, err
+		}
+		raised += count
+	}
+	return raised, nil
+}
+
+// evaluateOutcome 评估单个测评结果，并在同一事务内写入评估进度、预警与寻呼事件。
+func (m *monitor) evaluateOutcome(ctx context.Context, outcome OutcomeRef, rules []Rule) (int, error) {
+	applicable := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.AppliesTo(outcome) {
+			applicable = append(applicable, rule)
+		}
+	}
+	now := m.now()
+	var alerts []Alert
+	if len(applicable) > 0 {
+		obs, err := m.observe(ctx, outcome, applicable)
+		if err != nil {
+			return 0, err
+		}
+		if obs != nil {
+			for _, rule := range applicable {
+				if match, ok := Evaluate(rule, *obs); ok {
+					alerts = append(alerts, newAlert(rule, outcome, match, now))
+				}
+			}
+		}
+	}
+
+	alertEvents := make([]AlertEvent, 0, len(alerts))
+	pages := make([]event.DomainEvent, 0, len(alerts))
+	for i := range alerts {
+		alert := &alerts[i]
+		alertEvents = append(alertEvents, AlertEvent{
+			ID:              meta.New().Uint64(),
+			OrgID:           alert.OrgID,
+			AlertID:         alert.ID,
+			Action:          AlertActionRaised,
+			Channel:         alert.Channel,
+			EscalationLevel: alert.EscalationLevel,
+			OccurredAt:      now,
+		})
+		pages = append(pages, newPageEvent(alert, now))
+	}
+	recorded := false
+	err := m.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		saved, err := m.store.RecordScan(txCtx, outcome, alerts, alertEvents, now)
+		if err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "record risk alert scan")
+		}
+		recorded = saved
+		if !saved || len(pages) == 0 || m.events.Stager == nil {
+			return nil
+		}
+		return m.events.Stager.Stage(txCtx, pages...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !recorded {
+		return 0, nil
+	}
+	if len(pages) > 0 && m.events.PostCommit != nil {
+		m.events.PostCommit.AfterCommit(ctx, pages, now)
+	}
+	for _, alert := range alerts {
+		logger.L(ctx).Warnw("risk alert raised",
+			"action", "raise_risk_alert",
+			"org_id", alert.OrgID,
+			"alert_id", alert.ID,
+			"rule_id", alert.RuleID,
+			"severity", string(alert.Severity),
+			"assessment_id", alert.AssessmentID,
+			"testee_id", alert.TesteeID,
+			"channel", alert.Channel,
+		)
+	}
+	return len(alerts), nil
+}
+
+// observe 读取规则评估所需的测评结果；结果事实缺失或无法解码时返回 nil，该结果记为已评估且不触发预警。
+func (m *monitor) observe(ctx context.Context, outcome OutcomeRef, rules []Rule) (*Observation, error) {
+	record, err := m.facts.FindByID(ctx, meta.FromUint64(outcome.ID))
+	if err != nil {
+		if stderrors.Is(err, evaluationfact.ErrNotFound) {
+			logger.L(ctx).Warnw("risk alert skipped outcome without evaluation fact", "outcome_id", outcome.ID)
+			return nil, nil
+		}
+		return nil, errors.WrapC(err, code.ErrDatabase, "find evaluation outcome")
+	}
+	execution, err := codec.DecodeExecution(record)
+	if err != nil {
+		logger.L(ctx).Warnw("risk alert skipped undecodable evaluation outcome", "outcome_id", outcome.ID, "error", err.Error())
+		return nil, nil
+	}
+	obs := &Observation{Outcome: outcome, Execution: execution}
+
+	needsAnswers, needsPrevious := false, false
+	for _, rule := range rules {
+		needsAnswers = needsAnswers || rule.Kind == RuleKindItemAnswer
+		needsPrevious = needsPrevious || rule.Kind == RuleKindScoreChange
+	}
+	if needsAnswers {
+		snapshot, err := codec.DecodeReportInput(record)
+		if err != nil {
+			logger.L(ctx).Warnw("risk alert item rules skipped without answer snapshot", "outcome_id", outcome.ID, "error", err.Error())
+		} else if snapshot.AnswerSheet != nil {
+			obs.Answers = snapshot.AnswerSheet.Answers
+		}
+	}
+	if needsPrevious {
+		previous, err := m.previousExecution(ctx, outcome)
+		if err != nil {
+			return nil, err
+		}
+		obs.Previous = previous
+	}
+	return obs, nil
+}
+
+func (m *monitor) previousExecution(ctx context.Context, outcome OutcomeRef) (*evaluationfact.Execution, error) {
+	ref, err := m.store.FindPreviousOutcome(ctx, outcome)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find previous evaluation outcome")
+	}
+	if ref == nil {
+		return nil, nil
+	}
+	record, err := m.facts.FindByID(ctx, meta.FromUint64(ref.ID))
+	if err != nil {
+		if stderrors.Is(err, evaluationfact.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, errors.WrapC(err, code.ErrDatabase, "find previous evaluation outcome")
+	}
+	execution, err := codec.DecodeExecution(record)
+	if err != nil {
+		logger.L(ctx).Warnw("risk alert score change skipped undecodable previous outcome", "outcome_id", ref.ID, "error", err.Error())
+		return nil, nil
+	}
+	return execution, nil
+}
+
+func (m *monitor) EscalateOverdue(ctx context.Context, limit int) (int, error) {
+	if m.store == nil || m.tx == nil {
+		return 0, errors.WithCode(code.ErrModuleInitializationFailed, "risk alert monitor is not configured")
+	}
+	now := m.now()
+	due, err := m.store.ListDueEscalations(ctx, now, limit)
+	if err != nil {
+		return 0, errors.WrapC(err, code.ErrDatabase, "list risk alerts due for escalation")
+	}
+	escalated := 0
+	for i := range due {
+		alert := &due[i]
+		level := alert.EscalationLevel + 1
+		if alert.Status != AlertStatusOpen || level > len(alert.EscalationChannels) {
+			continue
+		}
+		expected := alert.Version
+		alert.EscalationLevel = level
+		alert.Channel = alert.EscalationChannels[level-1]
+		alert.NextEscalationAt = nextEscalationAt(*alert, now)
+		alert.Version++
+		alert.UpdatedAt = now
+		page := newPageEvent(alert, now)
+		saved := false
+		err := m.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+			ok, err := m.store.SaveAlert(txCtx, alert, expected, &AlertEvent{
+				ID:              meta.New().Uint64(),
+				OrgID:           alert.OrgID,
+				AlertID:         alert.ID,
+				Action:          AlertActionEscalated,
+				Channel:         alert.Channel,
+				EscalationLevel: level,
+				OccurredAt:      now,
+			})
+			if err != nil {
+				return errors.WrapC(err, code.ErrDatabase, "escalate risk alert")
+			}
+			saved = ok
+			if !ok || m.events.Stager == nil {
+				return nil
+			}
+			return m.events.Stager.Stage(txCtx, page)
+		})
+		if err != nil {
+			return escalated, err
+		}
+		if !saved {
+			// 扫描期间已被确认或被其他实例升级。
+			continue
+		}
+		if m.events.PostCommit != nil {
+			m.events.PostCommit.AfterCommit(ctx, []event.DomainEvent{page}, now)
+		}
+		escalated++
+		logger.L(ctx).Warnw("risk alert escalated",
+			"action", "escalate_risk_alert",
+			"org_id", alert.OrgID,
+			"alert_id", alert.ID,
+			"escalation_level", level,
+			"channel", alert.Channel,
+		)
+	}
+	return escalated, nil
+}
+
+// newAlert 以规则快照创建首次寻呼的预警。
+func newAlert(rule Rule, outcome OutcomeRef, match *Match, now time.Time) Alert {
+	alert := Alert{
+		ID:                 meta.New().Uint64(),
+		OrgID:              outcome.OrgID,
+		RuleID:             rule.ID,
+		RuleName:           rule.Name,
+		RuleKind:           rule.Kind,
+		Severity:           rule.Severity,
+		OutcomeID:          outcome.ID,
+		AssessmentID:       outcome.AssessmentID,
+		TesteeID:           outcome.TesteeID,
+		ModelCode:          outcome.ModelCode,
+		Status:             AlertStatusOpen,
+		Channel:            rule.PageChannel,
+		EscalationChannels: append([]string(nil), rule.EscalationChannels...),
+		AckTimeout:         rule.AckTimeout,
+		Summary:            match.Summary,
+		ObservedValue:      match.Observed,
+		BaselineValue:      match.Baseline,
+		TriggeredAt:        now,
+		Version:            1,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	alert.NextEscalationAt = nextEscalationAt(alert, now)
+	return alert
+}
+
+// nextEscalationAt 升级链仍有下一级时返回下一次升级时间，否则返回 nil。
+func nextEscalationAt(alert Alert, from time.Time) *time.Time {
+	if alert.EscalationLevel >= len(alert.EscalationChannels) || alert.AckTimeout <= 0 {
+		return nil
+	}
+	at := from.Add(alert.AckTimeout)
+	return &at
+}
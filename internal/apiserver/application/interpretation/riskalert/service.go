@@ -0,0 +1,354 @@
+package riskalert
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 风险预警规则配置、预警查询与确认用例。
+type Service interface {
+	CreateRule(ctx context.Context, dto RuleDTO) (*Rule, error)
+	// UpdateRule 更新规则；已触发的预警保留触发时的规则快照。
+	UpdateRule(ctx context.Context, dto RuleDTO) (*Rule, error)
+	DeleteRule(ctx context.Context, orgID int64, ruleID uint64) error
+	GetRule(ctx context.Context, orgID int64, ruleID uint64) (*Rule, error)
+	ListRules(ctx context.Context, orgID int64) ([]Rule, error)
+
+	ListAlerts(ctx context.Context, filter AlertFilter) (*AlertPage, error)
+	GetAlert(ctx context.Context, orgID int64, alertID uint64) (*AlertView, error)
+	// Acknowledge 确认预警并停止升级；每条预警只能确认一次。
+	Acknowledge(ctx context.Context, dto AcknowledgeDTO) (*AlertView, error)
+}
+
+type service struct {
+	store     Store
+	operators actorreadmodel.OperatorReader
+	now       func() time.Time
+}
+
+// NewService 创建风险预警服务。
+func NewService(store Store, operators actorreadmodel.OperatorReader) Service {
+	return &service{store: store, operators: operators, now: time.Now}
+}
+
+func (s *service) CreateRule(ctx context.Context, dto RuleDTO) (*Rule, error) {
+	if err := s.ready(dto.OrgID); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	rule := &Rule{
+		ID:        meta.New().Uint64(),
+		OrgID:     dto.OrgID,
+		CreatedBy: dto.OperatorUserID,
+		CreatedAt: now,
+	}
+	if err := applyRuleDTO(rule, dto, now); err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveRule(ctx, rule); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save risk alert rule")
+	}
+	logger.L(ctx).Infow("risk alert rule created",
+		"action", "create_risk_alert_rule",
+		"org_id", rule.OrgID,
+		"rule_id", rule.ID,
+		"kind", string(rule.Kind),
+		"model_code", rule.ModelCode,
+	)
+	return rule, nil
+}
+
+func (s *service) UpdateRule(ctx context.Context, dto RuleDTO) (*Rule, error) {
+	rule, err := s.GetRule(ctx, dto.OrgID, dto.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRuleDTO(rule, dto, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveRule(ctx, rule); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save risk alert rule")
+	}
+	logger.L(ctx).Infow("risk alert rule updated",
+		"action", "update_risk_alert_rule",
+		"org_id", rule.OrgID,
+		"rule_id", rule.ID,
+		"enabled", rule.Enabled,
+	)
+	return rule, nil
+}
+
+func (s *service) DeleteRule(ctx context.Context, orgID int64, ruleID uint64) error {
+	if err := s.ready(orgID); err != nil {
+		return err
+	}
+	deleted, err := s.store.DeleteRule(ctx, orgID, ruleID, s.now())
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "delete risk alert rule")
+	}
+	if !deleted {
+		return errors.WithCode(code.ErrRiskAlertRuleNotFound, "risk alert rule %d not found", ruleID)
+	}
+	logger.L(ctx).Infow("risk alert rule deleted",
+		"action", "delete_risk_alert_rule",
+		"org_id", orgID,
+		"rule_id", ruleID,
+	)
+	return nil
+}
+
+func (s *service) GetRule(ctx context.Context, orgID int64, ruleID uint64) (*Rule, error) {
+	if err := s.ready(orgID); err != nil {
+		return nil, err
+	}
+	rule, err := s.store.FindRule(ctx, orgID, ruleID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find risk alert rule")
+	}
+	if rule == nil {
+		return nil, errors.WithCode(code.ErrRiskAlertRuleNotFound, "risk alert rule %d not found", ruleID)
+	}
+	return rule, nil
+}
+
+func (s *service) ListRules(ctx context.Context, orgID int64) ([]Rule, error) {
+	if err := s.ready(orgID); err != nil {
+		return nil, err
+	}
+	rules, err := s.store.ListRules(ctx, orgID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list risk alert rules")
+	}
+	return rules, nil
+}
+
+func (s *service) ListAlerts(ctx context.Context, filter AlertFilter) (*AlertPage, error) {
+	if err := s.ready(filter.OrgID); err != nil {
+		return nil, err
+	}
+	if filter.Status != "" && filter.Status != AlertStatusOpen && filter.Status != AlertStatusAcknowledged {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported risk alert status %q", filter.Status)
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+	items, total, err := s.store.ListAlerts(ctx, filter)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list risk alerts")
+	}
+	return &AlertPage{Items: items, Total: total, Page: filter.Page, PageSize: filter.PageSize}, nil
+}
+
+func (s *service) GetAlert(ctx context.Context, orgID int64, alertID uint64) (*AlertView, error) {
+	alert, err := s.loadAlert(ctx, orgID, alertID)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, alert)
+}
+
+func (s *service) Acknowledge(ctx context.Context, dto AcknowledgeDTO) (*AlertView, error) {
+	note := strings.TrimSpace(dto.Note)
+	if utf8.RuneCountInString(note) > maxAckNoteRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note must be at most %d characters", maxAckNoteRunes)
+	}
+	if dto.OperatorUserID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "operator identity is required")
+	}
+	alert, err := s.loadAlert(ctx, dto.OrgID, dto.AlertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status == AlertStatusAcknowledged {
+		return nil, errors.WithCode(code.ErrRiskAlertConflict, "risk alert is already acknowledged")
+	}
+	actor, err := s.currentActor(ctx, dto.OrgID, dto.OperatorUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	expected := alert.Version
+	alert.Status = AlertStatusAcknowledged
+	alert.AcknowledgedBy = actor
+	alert.AcknowledgedAt = &now
+	alert.AckNote = note
+	alert.NextEscalationAt = nil
+	alert.Version++
+	alert.UpdatedAt = now
+	saved, err := s.store.SaveAlert(ctx, alert, expected, &AlertEvent{
+		ID:              meta.New().Uint64(),
+		OrgID:           alert.OrgID,
+		AlertID:         alert.ID,
+		Action:          AlertActionAcknowledged,
+		Channel:         alert.Channel,
+		EscalationLevel: alert.EscalationLevel,
+		Operator:        actor,
+		Note:            note,
+		OccurredAt:      now,
+	})
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "acknowledge risk alert")
+	}
+	if !saved {
+		return nil, errors.WithCode(code.ErrRiskAlertConflict, "risk alert was changed concurrently, reload and retry")
+	}
+	logger.L(ctx).Infow("risk alert acknowledged",
+		"action", "acknowledge_risk_alert",
+		"org_id", alert.OrgID,
+		"alert_id", alert.ID,
+		"escalation_level", alert.EscalationLevel,
+		"operator_user_id", actor.UserID,
+		"ack_latency", now.Sub(alert.TriggeredAt).String(),
+	)
+	return s.view(ctx, alert)
+}
+
+func (s *service) ready(orgID int64) error {
+	if orgID <= 0 {
+		return errors.WithCode(code.ErrInvalidArgument, "organization is required")
+	}
+	if s.store == nil || s.operators == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "risk alert service is not configured")
+	}
+	return nil
+}
+
+func (s *service) loadAlert(ctx context.Context, orgID int64, alertID uint64) (*Alert, error) {
+	if err := s.ready(orgID); err != nil {
+		return nil, err
+	}
+	alert, err := s.store.FindAlert(ctx, orgID, alertID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find risk alert")
+	}
+	if alert == nil {
+		return nil, errors.WithCode(code.ErrRiskAlertNotFound, "risk alert %d not found", alertID)
+	}
+	return alert, nil
+}
+
+func (s *service) view(ctx context.Context, alert *Alert) (*AlertView, error) {
+	events, err := s.store.ListAlertEvents(ctx, alert.OrgID, alert.ID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list risk alert events")
+	}
+	return &AlertView{Alert: *alert, Events: events}, nil
+}
+
+func (s *service) currentActor(ctx context.Context, orgID, userID int64) (*Actor, error) {
+	operatorItem, err := s.operators.FindOperatorByUser(ctx, orgID, userID)
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return nil, errors.WithCode(code.ErrPermissionDenied, "operator not found in current organization")
+		}
+		return nil, errors.Wrap(err, "failed to find operator")
+	}
+	if operatorItem == nil || !operatorItem.IsActive {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "operator is inactive")
+	}
+	return &Actor{UserID: userID, Name: operatorItem.Name}, nil
+}
+
+// applyRuleDTO 校验规则配置并写入规则。
+func applyRuleDTO(rule *Rule, dto RuleDTO, now time.Time) error {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxRuleNameRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "rule name is required and must be at most %d characters", maxRuleNameRunes)
+	}
+	if !dto.Kind.Valid() {
+		return errors.WithCode(code.ErrInvalidArgument, "unsupported rule kind %q", dto.Kind)
+	}
+	if !dto.Severity.Valid() {
+		return errors.WithCode(code.ErrInvalidArgument, "unsupported severity %q", dto.Severity)
+	}
+	cond, err := normalizeCondition(dto.Kind, dto.Condition)
+	if err != nil {
+		return err
+	}
+	pageChannel := strings.TrimSpace(dto.PageChannel)
+	if pageChannel == "" || utf8.RuneCountInString(pageChannel) > maxChannelRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "page channel is required and must be at most %d characters", maxChannelRunes)
+	}
+	if len(dto.EscalationChannels) > maxEscalationSteps {
+		return errors.WithCode(code.ErrInvalidArgument, "at most %d escalation channels are allowed", maxEscalationSteps)
+	}
+	chain := make([]string, 0, len(dto.EscalationChannels))
+	for _, channel := range dto.EscalationChannels {
+		channel = strings.TrimSpace(channel)
+		if channel == "" || utf8.RuneCountInString(channel) > maxChannelRunes {
+			return errors.WithCode(code.ErrInvalidArgument, "escalation channels must be non-empty and at most %d characters", maxChannelRunes)
+		}
+		chain = append(chain, channel)
+	}
+	if dto.AckTimeout < minAckTimeout || dto.AckTimeout > maxAckTimeout {
+		return errors.WithCode(code.ErrInvalidArgument, "ack timeout must be between %s and %s", minAckTimeout, maxAckTimeout)
+	}
+
+	rule.Name = name
+	rule.Kind = dto.Kind
+	rule.ModelCode = strings.TrimSpace(dto.ModelCode)
+	rule.Condition = cond
+	rule.Severity = dto.Severity
+	rule.PageChannel = pageChannel
+	rule.EscalationChannels = chain
+	rule.AckTimeout = dto.AckTimeout
+	rule.Enabled = dto.Enabled
+	rule.UpdatedBy = dto.OperatorUserID
+	rule.UpdatedAt = now
+	return nil
+}
+
+// normalizeCondition 按规则类型校验条件，并清空该类型不使用的字段。
+func normalizeCondition(kind RuleKind, in Condition) (Condition, error) {
+	switch kind {
+	case RuleKindItemAnswer:
+		out := Condition{QuestionCode: strings.TrimSpace(in.QuestionCode), Operator: in.Operator, Threshold: in.Threshold}
+		if out.QuestionCode == "" || !out.Operator.Valid() {
+			return Condition{}, errors.WithCode(code.ErrInvalidArgument, "item_answer rule requires question_code and a valid operator")
+		}
+		return out, nil
+	case RuleKindFactorScore:
+		out := Condition{FactorCode: strings.TrimSpace(in.FactorCode), ScoreKind: in.ScoreKind, Operator: in.Operator, Threshold: in.Threshold}
+		if out.ScoreKind == "" {
+			out.ScoreKind = ScoreKindRawTotal
+		}
+		if out.FactorCode == "" || !out.Operator.Valid() {
+			return Condition{}, errors.WithCode(code.ErrInvalidArgument, "factor_score rule requires factor_code and a valid operator")
+		}
+		if out.ScoreKind != ScoreKindRawTotal && out.ScoreKind != ScoreKindTScore {
+			return Condition{}, errors.WithCode(code.ErrInvalidArgument, "factor_score rule supports score_kind raw_total or t_score")
+		}
+		return out, nil
+	case RuleKindScoreChange:
+		out := Condition{FactorCode: strings.TrimSpace(in.FactorCode), Threshold: in.Threshold}
+		if out.Threshold <= 0 {
+			return Condition{}, errors.WithCode(code.ErrInvalidArgument, "score_change rule requires a positive threshold")
+		}
+		return out, nil
+	case RuleKindRiskLevel:
+		out := Condition{}
+		for _, level := range in.Levels {
+			if level = strings.TrimSpace(level); level != "" {
+				out.Levels = append(out.Levels, level)
+			}
+		}
+		if len(out.Levels) == 0 {
+			return Condition{}, errors.WithCode(code.ErrInvalidArgument, "risk_level rule requires at least one level")
+		}
+		return out, nil
+	default:
+		return Condition{}, errors.WithCode(code.ErrInvalidArgument, "unsupported rule kind %q", kind)
+	}
+}
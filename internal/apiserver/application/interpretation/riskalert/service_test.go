@@ -0,0 +1,358 @@
+package riskalert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/event"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationinput"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	eventcatalog "github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type fakeStore struct {
+	rules    []Rule
+	outcomes []OutcomeRef
+	previous map[uint64]*OutcomeRef
+	scanned  map[uint64]bool
+	alerts   map[uint64]*Alert
+	events   []AlertEvent
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{previous: map[uint64]*OutcomeRef{}, scanned: map[uint64]bool{}, alerts: map[uint64]*Alert{}}
+}
+
+func (s *fakeStore) SaveRule(_ context.Context, rule *Rule) error {
+	for i := range s.rules {
+		if s.rules[i].ID == rule.ID {
+			s.rules[i] = *rule
+			return nil
+		}
+	}
+	s.rules = append(s.rules, *rule)
+	return nil
+}
+
+func (s *fakeStore) FindRule(_ context.Context, _ int64, ruleID uint64) (*Rule, error) {
+	for _, rule := range s.rules {
+		if rule.ID == ruleID {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) ListRules(context.Context, int64) ([]Rule, error) { return s.rules, nil }
+
+func (s *fakeStore) DeleteRule(context.Context, int64, uint64, time.Time) (bool, error) {
+	return false, nil
+}
+
+func (s *fakeStore) ListEnabledRules(_ context.Context, orgID int64) ([]Rule, error) {
+	var rules []Rule
+	for _, rule := range s.rules {
+		if rule.OrgID == orgID && rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (s *fakeStore) ListPendingOutcomes(context.Context, time.Time, int) ([]OutcomeRef, error) {
+	var pending []OutcomeRef
+	for _, outcome := range s.outcomes {
+		if !s.scanned[outcome.ID] {
+			pending = append(pending, outcome)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) FindPreviousOutcome(_ context.Context, outcome OutcomeRef) (*OutcomeRef, error) {
+	return s.previous[outcome.ID], nil
+}
+
+func (s *fakeStore) RecordScan(_ context.Context, outcome OutcomeRef, alerts []Alert, events []AlertEvent, _ time.Time) (bool, error) {
+	if s.scanned[outcome.ID] {
+		return false, nil
+	}
+	s.scanned[outcome.ID] = true
+	for i := range alerts {
+		alert := alerts[i]
+		s.alerts[alert.ID] = &alert
+	}
+	s.events = append(s.events, events...)
+	return true, nil
+}
+
+func (s *fakeStore) FindAlert(_ context.Context, _ int64, alertID uint64) (*Alert, error) {
+	alert, ok := s.alerts[alertID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *alert
+	return &copied, nil
+}
+
+func (s *fakeStore) ListAlerts(context.Context, AlertFilter) ([]Alert, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeStore) ListAlertEvents(_ context.Context, _ int64, alertID uint64) ([]AlertEvent, error) {
+	var events []AlertEvent
+	for _, evt := range s.events {
+		if evt.AlertID == alertID {
+			events = append(events, evt)
+		}
+	}
+	return events, nil
+}
+
+func (s *fakeStore) ListDueEscalations(_ context.Context, now time.Time, _ int) ([]Alert, error) {
+	var due []Alert
+	for _, alert := range s.alerts {
+		if alert.Status == AlertStatusOpen && alert.NextEscalationAt != nil && !alert.NextEscalationAt.After(now) {
+			due = append(due, *alert)
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeStore) SaveAlert(_ context.Context, alert *Alert, expectedVersion int, event *AlertEvent) (bool, error) {
+	if existing := s.alerts[alert.ID]; existing == nil || existing.Version != expectedVersion {
+		return false, nil
+	}
+	copied := *alert
+	s.alerts[alert.ID] = &copied
+	s.events = append(s.events, *event)
+	return true, nil
+}
+
+type fakeFacts map[uint64]*evaluationfact.Record
+
+func (f fakeFacts) FindByID(_ context.Context, id meta.ID) (*evaluationfact.Record, error) {
+	if record, ok := f[id.Uint64()]; ok {
+		return record, nil
+	}
+	return nil, evaluationfact.ErrNotFound
+}
+
+func (f fakeFacts) FindByAssessmentID(context.Context, meta.ID) (*evaluationfact.Record, error) {
+	return nil, evaluationfact.ErrNotFound
+}
+
+type recordingStager struct{ staged []event.DomainEvent }
+
+func (s *recordingStager) Stage(_ context.Context, events ...event.DomainEvent) error {
+	s.staged = append(s.staged, events...)
+	return nil
+}
+
+// fakeOperators 只实现确认人解析用到的读模型方法。
+type fakeOperators struct {
+	actorreadmodel.OperatorReader
+	names map[int64]string
+}
+
+func (r fakeOperators) FindOperatorByUser(_ context.Context, orgID, userID int64) (*actorreadmodel.OperatorRow, error) {
+	name, ok := r.names[userID]
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrUserNotFound, "operator not found")
+	}
+	return &actorreadmodel.OperatorRow{OrgID: orgID, UserID: userID, Name: name, IsActive: true}, nil
+}
+
+var passthroughTx = apptransaction.RunnerFunc(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+func outcomeRecord(id uint64, payload string) *evaluationfact.Record {
+	return evaluationfact.NewRecord(evaluationfact.NewRecordInput{
+		ID: meta.FromUint64(id), OrgID: 7, AssessmentID: meta.FromUint64(id + 100), TesteeID: 9,
+		Model: evaluationfact.ModelIdentity{Code: "SCL90"}, SchemaVersion: 2, Payload: []byte(payload),
+	})
+}
+
+func TestEvaluateRuleKinds(t *testing.T) {
+	obs := Observation{
+		Execution: &evaluationfact.Execution{
+			Primary: &evaluationfact.ScoreValue{Kind: evaluationfact.ScoreKindRawTotal, Value: 30},
+			Level:   &evaluationfact.ResultLevel{Code: "severe", Label: "重度"},
+			Dimensions: []evaluationfact.DimensionResult{{
+				Code:          "DEP",
+				Score:         &evaluationfact.ScoreValue{Kind: evaluationfact.ScoreKindRawTotal, Value: 24},
+				DerivedScores: []evaluationfact.ScoreValue{{Kind: evaluationfact.ScoreKindTScore, Value: 72}},
+			}},
+		},
+		Answers:  []evaluationinput.AnswerSnapshot{{QuestionCode: "Q9", Score: 2}},
+		Previous: &evaluationfact.Execution{Primary: &evaluationfact.ScoreValue{Value: 18}},
+	}
+	cases := []struct {
+		name  string
+		rule  Rule
+		match bool
+		value float64
+	}{
+		{"item answer hit", Rule{Kind: RuleKindItemAnswer, Condition: Condition{QuestionCode: "Q9", Operator: OperatorGTE, Threshold: 2}}, true, 2},
+		{"item answer below threshold", Rule{Kind: RuleKindItemAnswer, Condition: Condition{QuestionCode: "Q9", Operator: OperatorGT, Threshold: 2}}, false, 0},
+		{"item answer missing question", Rule{Kind: RuleKindItemAnswer, Condition: Condition{QuestionCode: "Q1", Operator: OperatorGTE, Threshold: 0}}, false, 0},
+		{"factor t score", Rule{Kind: RuleKindFactorScore, Condition: Condition{FactorCode: "DEP", ScoreKind: ScoreKindTScore, Operator: OperatorGTE, Threshold: 70}}, true, 72},
+		{"factor raw score", Rule{Kind: RuleKindFactorScore, Condition: Condition{FactorCode: "DEP", ScoreKind: ScoreKindRawTotal, Operator: OperatorGTE, Threshold: 30}}, false, 0},
+		{"score change over threshold", Rule{Kind: RuleKindScoreChange, Condition: Condition{Threshold: 10}}, true, 30},
+		{"score change within threshold", Rule{Kind: RuleKindScoreChange, Condition: Condition{Threshold: 12}}, false, 0},
+		{"risk level", Rule{Kind: RuleKindRiskLevel, Condition: Condition{Levels: []string{"SEVERE"}}}, true, 0},
+	}
+	for _, tc := range cases {
+		match, ok := Evaluate(tc.rule, obs)
+		if ok != tc.match {
+			t.Fatalf("%s: match = %v, want %v", tc.name, ok, tc.match)
+		}
+		if ok && (match.Observed != tc.value || match.Summary == "") {
+			t.Fatalf("%s: match = %#v", tc.name, match)
+		}
+	}
+
+	if match, _ := Evaluate(Rule{Kind: RuleKindScoreChange, Condition: Condition{Threshold: 10}}, obs); match.Baseline == nil || *match.Baseline != 18 {
+		t.Fatalf("score change baseline = %#v", match)
+	}
+	obs.Previous = nil
+	if _, ok := Evaluate(Rule{Kind: RuleKindScoreChange, Condition: Condition{Threshold: 1}}, obs); ok {
+		t.Fatal("score change without previous outcome must not match")
+	}
+}
+
+func TestEvaluatePendingRaisesAlertsOnlyForRulesCreatedBeforeOutcome(t *testing.T) {
+	ruleAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	store.rules = []Rule{
+		{ID: 1, OrgID: 7, Name: "抑郁因子", Kind: RuleKindFactorScore, Severity: SeverityHigh, Enabled: true, CreatedAt: ruleAt,
+			Condition:   Condition{FactorCode: "DEP", ScoreKind: ScoreKindTScore, Operator: OperatorGTE, Threshold: 70},
+			PageChannel: "oncall-primary", EscalationChannels: []string{"oncall-secondary"}, AckTimeout: 15 * time.Minute},
+		{ID: 2, OrgID: 7, Name: "其他模型", Kind: RuleKindRiskLevel, ModelCode: "PHQ9", Severity: SeverityHigh, Enabled: true, CreatedAt: ruleAt,
+			Condition: Condition{Levels: []string{"severe"}}, PageChannel: "oncall-primary"},
+		{ID: 3, OrgID: 7, Name: "新规则", Kind: RuleKindRiskLevel, Severity: SeverityCritical, Enabled: true, CreatedAt: ruleAt.Add(2 * time.Hour),
+			Condition: Condition{Levels: []string{"severe"}}, PageChannel: "oncall-primary"},
+	}
+	store.outcomes = []OutcomeRef{{ID: 11, OrgID: 7, AssessmentID: 111, TesteeID: 9, ModelCode: "SCL90", EvaluatedAt: ruleAt.Add(time.Hour)}}
+	facts := fakeFacts{11: outcomeRecord(11, `{"Level":{"Code":"severe"},"Dimensions":[{"Code":"DEP","Score":{"Kind":"raw_total","Value":24},"DerivedScores":[{"Kind":"t_score","Value":74}]}]}`)}
+	stager := &recordingStager{}
+	now := ruleAt.Add(90 * time.Minute)
+	m := NewMonitor(store, facts, passthroughTx, appEventing.ProfileBinding{Stager: stager}, 24*time.Hour).(*monitor)
+	m.now = func() time.Time { return now }
+
+	raised, err := m.EvaluatePending(context.Background(), 50)
+	if err != nil || raised != 1 {
+		t.Fatalf("EvaluatePending() = %d, %v; want 1 alert", raised, err)
+	}
+	if len(store.alerts) != 1 || len(stager.staged) != 1 || stager.staged[0].EventType() != eventcatalog.RiskAlertRaised {
+		t.Fatalf("alerts = %#v, staged = %#v", store.alerts, stager.staged)
+	}
+	for _, alert := range store.alerts {
+		if alert.RuleID != 1 || alert.ObservedValue != 74 || alert.Channel != "oncall-primary" ||
+			alert.NextEscalationAt == nil || !alert.NextEscalationAt.Equal(now.Add(15*time.Minute)) {
+			t.Fatalf("alert = %#v", alert)
+		}
+	}
+	if !store.scanned[11] {
+		t.Fatal("outcome must be marked as evaluated")
+	}
+
+	if raised, err := m.EvaluatePending(context.Background(), 50); err != nil || raised != 0 {
+		t.Fatalf("second EvaluatePending() = %d, %v; want no duplicate alerts", raised, err)
+	}
+}
+
+func TestEscalateOverdueWalksChainUntilExhausted(t *testing.T) {
+	triggeredAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	next := triggeredAt.Add(10 * time.Minute)
+	store := newFakeStore()
+	store.alerts[31] = &Alert{ID: 31, OrgID: 7, RuleID: 1, Status: AlertStatusOpen, Channel: "oncall-primary",
+		EscalationChannels: []string{"oncall-secondary", "clinical-lead"}, AckTimeout: 10 * time.Minute,
+		TriggeredAt: triggeredAt, NextEscalationAt: &next, Version: 1}
+	stager := &recordingStager{}
+	m := NewMonitor(store, fakeFacts{}, passthroughTx, appEventing.ProfileBinding{Stager: stager}, time.Hour).(*monitor)
+
+	now := next
+	m.now = func() time.Time { return now }
+	if escalated, err := m.EscalateOverdue(context.Background(), 50); err != nil || escalated != 1 {
+		t.Fatalf("first EscalateOverdue() = %d, %v", escalated, err)
+	}
+	alert := store.alerts[31]
+	if alert.EscalationLevel != 1 || alert.Channel != "oncall-secondary" || alert.NextEscalationAt == nil {
+		t.Fatalf("after first escalation = %#v", alert)
+	}
+
+	now = *alert.NextEscalationAt
+	if escalated, err := m.EscalateOverdue(context.Background(), 50); err != nil || escalated != 1 {
+		t.Fatalf("second EscalateOverdue() = %d, %v", escalated, err)
+	}
+	alert = store.alerts[31]
+	if alert.EscalationLevel != 2 || alert.Channel != "clinical-lead" || alert.NextEscalationAt != nil {
+		t.Fatalf("after chain exhausted = %#v", alert)
+	}
+	if len(stager.staged) != 2 || stager.staged[1].EventType() != eventcatalog.RiskAlertEscalated {
+		t.Fatalf("staged = %#v", stager.staged)
+	}
+
+	now = now.Add(time.Hour)
+	if escalated, _ := m.EscalateOverdue(context.Background(), 50); escalated != 0 {
+		t.Fatalf("exhausted chain escalated again: %d", escalated)
+	}
+}
+
+func TestAcknowledgeRecordsOperatorAndStopsEscalation(t *testing.T) {
+	triggeredAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	next := triggeredAt.Add(10 * time.Minute)
+	store := newFakeStore()
+	store.alerts[31] = &Alert{ID: 31, OrgID: 7, Status: AlertStatusOpen, Channel: "oncall-primary",
+		EscalationChannels: []string{"oncall-secondary"}, AckTimeout: 10 * time.Minute,
+		TriggeredAt: triggeredAt, NextEscalationAt: &next, Version: 1}
+	svc := NewService(store, fakeOperators{names: map[int64]string{501: "值班医生"}}).(*service)
+	svc.now = func() time.Time { return triggeredAt.Add(5 * time.Minute) }
+
+	view, err := svc.Acknowledge(context.Background(), AcknowledgeDTO{OrgID: 7, OperatorUserID: 501, AlertID: 31, Note: " 已电话联系 "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Alert.Status != AlertStatusAcknowledged || view.Alert.NextEscalationAt != nil ||
+		view.Alert.AcknowledgedBy == nil || view.Alert.AcknowledgedBy.Name != "值班医生" || view.Alert.AckNote != "已电话联系" {
+		t.Fatalf("acknowledged alert = %#v", view.Alert)
+	}
+	if len(view.Events) != 1 || view.Events[0].Action != AlertActionAcknowledged || view.Events[0].Operator.UserID != 501 {
+		t.Fatalf("events = %#v", view.Events)
+	}
+
+	_, err = svc.Acknowledge(context.Background(), AcknowledgeDTO{OrgID: 7, OperatorUserID: 501, AlertID: 31})
+	if !cberrors.IsCode(err, code.ErrRiskAlertConflict) {
+		t.Fatalf("second acknowledge err = %v, want conflict", err)
+	}
+}
+
+func TestCreateRuleValidatesConditionByKind(t *testing.T) {
+	svc := NewService(newFakeStore(), fakeOperators{}).(*service)
+	base := RuleDTO{OrgID: 7, OperatorUserID: 501, Name: "自杀意念", Severity: SeverityCritical, PageChannel: "oncall-primary", AckTimeout: 10 * time.Minute, Enabled: true}
+
+	invalid := base
+	invalid.Kind = RuleKindItemAnswer
+	invalid.Condition = Condition{Operator: OperatorGTE, Threshold: 2}
+	if _, err := svc.CreateRule(context.Background(), invalid); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("missing question code err = %v", err)
+	}
+
+	valid := base
+	valid.Kind = RuleKindFactorScore
+	valid.Condition = Condition{FactorCode: "DEP", Operator: OperatorGTE, Threshold: 70, QuestionCode: "ignored"}
+	rule, err := svc.CreateRule(context.Background(), valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Condition.ScoreKind != ScoreKindRawTotal || rule.Condition.QuestionCode != "" {
+		t.Fatalf("normalized condition = %#v", rule.Condition)
+	}
+}
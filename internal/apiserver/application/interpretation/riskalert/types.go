@@ -0,0 +1,127 @@
+// Package riskalert 风险预警：机构配置预警规则，测评结果落库后按规则评估，
+// 命中时立即寻呼值班渠道，并在无人确认时沿升级链逐级升级。
+//
+// 规则有四类：单题作答（如自杀意念题得分 ≥ 2）、因子分数（如某因子 T 分 ≥ 70）、
+// 与同一受试者同一模型上次测评相比的分数变化，以及结果风险等级。
+// 规则只评估创建之后完成的测评，避免新建规则对历史结果批量寻呼。
+// 每条预警的触发、升级与确认都记入历史；确认后停止升级。
+package riskalert
+
+import (
+	"time"
+
+	domainriskalert "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/riskalert"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationfact"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationinput"
+)
+
+type (
+	RuleKind    = domainriskalert.RuleKind
+	Operator    = domainriskalert.Operator
+	Severity    = domainriskalert.Severity
+	ScoreKind   = domainriskalert.ScoreKind
+	Condition   = domainriskalert.Condition
+	Rule        = domainriskalert.Rule
+	OutcomeRef  = domainriskalert.OutcomeRef
+	AlertStatus = domainriskalert.AlertStatus
+	AlertAction = domainriskalert.AlertAction
+	Actor       = domainriskalert.Actor
+	Alert       = domainriskalert.Alert
+	AlertEvent  = domainriskalert.AlertEvent
+	AlertFilter = domainriskalert.AlertFilter
+)
+
+const (
+	RuleKindItemAnswer  = domainriskalert.RuleKindItemAnswer
+	RuleKindFactorScore = domainriskalert.RuleKindFactorScore
+	RuleKindScoreChange = domainriskalert.RuleKindScoreChange
+	RuleKindRiskLevel   = domainriskalert.RuleKindRiskLevel
+)
+
+const (
+	OperatorGTE = domainriskalert.OperatorGTE
+	OperatorGT  = domainriskalert.OperatorGT
+	OperatorLTE = domainriskalert.OperatorLTE
+	OperatorLT  = domainriskalert.OperatorLT
+	OperatorEQ  = domainriskalert.OperatorEQ
+)
+
+const (
+	SeverityCritical = domainriskalert.SeverityCritical
+	SeverityHigh     = domainriskalert.SeverityHigh
+	SeverityMedium   = domainriskalert.SeverityMedium
+)
+
+const (
+	ScoreKindRawTotal = domainriskalert.ScoreKindRawTotal
+	ScoreKindTScore   = domainriskalert.ScoreKindTScore
+)
+
+const (
+	AlertStatusOpen         = domainriskalert.AlertStatusOpen
+	AlertStatusAcknowledged = domainriskalert.AlertStatusAcknowledged
+)
+
+const (
+	AlertActionRaised       = domainriskalert.AlertActionRaised
+	AlertActionEscalated    = domainriskalert.AlertActionEscalated
+	AlertActionAcknowledged = domainriskalert.AlertActionAcknowledged
+)
+
+const (
+	maxRuleNameRunes   = 100
+	maxChannelRunes    = 100
+	maxEscalationSteps = 5
+	maxAckNoteRunes    = 1000
+	minAckTimeout      = time.Minute
+	maxAckTimeout      = 24 * time.Hour
+)
+
+// AlertView 预警及其完整历史（按时间升序）。
+type AlertView struct {
+	Alert  Alert
+	Events []AlertEvent
+}
+
+// Observation 规则评估所需的测评结果：结果事实、作答快照，以及 score_change 规则用到的上次结果。
+type Observation struct {
+	Outcome   OutcomeRef
+	Execution *evaluationfact.Execution
+	Answers   []evaluationinput.AnswerSnapshot
+	Previous  *evaluationfact.Execution
+}
+
+// RuleDTO 创建或更新预警规则。
+type RuleDTO struct {
+	OrgID              int64
+	OperatorUserID     int64
+	RuleID             uint64
+	Name               string
+	Kind               RuleKind
+	ModelCode          string
+	Condition          Condition
+	Severity           Severity
+	PageChannel        string
+	EscalationChannels []string
+	AckTimeout         time.Duration
+	Enabled            bool
+}
+
+// AlertPage 预警分页结果。
+type AlertPage struct {
+	Items    []Alert
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// AcknowledgeDTO 确认预警。
+type AcknowledgeDTO struct {
+	OrgID          int64
+	OperatorUserID int64
+	AlertID        uint64
+	Note           string
+}
+
+// Store 预警规则、预警与评估进度存储。
+type Store = domainriskalert.Repository
@@ -0,0 +1,515 @@
+package fhir
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	evaluationoutcome "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/outcome"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+	domainQuestionnaire "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/validation"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+)
+
+// resultObservationSuffix 测评结论等级 Observation 的 ID 后缀；因子得分按冻结顺序编号。
+const resultObservationSuffix = "result"
+
+// riskLevelDisplay 风险等级的展示名称。
+var riskLevelDisplay = map[string]string{
+	"none":   "No risk",
+	"low":    "Low risk",
+	"medium": "Medium risk",
+	"high":   "High risk",
+	"severe": "Severe risk",
+}
+
+// Systems 本服务发布的本地标识与术语体系，均位于 FHIR base URL 之下。
+type Systems struct {
+	base string
+}
+
+// NewSystems 以 FHIR base URL（例如 https://host/api/v1/fhir）创建标识体系。
+func NewSystems(baseURL string) Systems {
+	return Systems{base: strings.TrimRight(baseURL, "/")}
+}
+
+// QuestionnaireURL 问卷的 canonical URL。
+func (s Systems) QuestionnaireURL(code string) string {
+	return s.base + "/" + ResourceQuestionnaire + "/" + code
+}
+
+func (s Systems) namingSystem(name string) string { return s.base + "/NamingSystem/" + name }
+func (s Systems) codeSystem(name string) string   { return s.base + "/CodeSystem/" + name }
+
+func (s Systems) optionSystem(questionnaireCode string) string {
+	return s.codeSystem("questionnaire-option/" + questionnaireCode)
+}
+
+func (s Systems) factorSystem(modelCode string) string {
+	if modelCode == "" {
+		return s.codeSystem("model-factor")
+	}
+	return s.codeSystem("model-factor/" + modelCode)
+}
+
+func (s Systems) levelSystem(modelCode string) string {
+	if modelCode == "" {
+		return s.codeSystem("result-level")
+	}
+	return s.codeSystem("result-level/" + modelCode)
+}
+
+// subject 受试者的逻辑引用；不导出 Patient 资源。
+func (s Systems) subject(testeeID uint64) *Reference {
+	if testeeID == 0 {
+		return nil
+	}
+	return &Reference{
+		Type:       "Patient",
+		Identifier: &Identifier{System: s.namingSystem("testee"), Value: strconv.FormatUint(testeeID, 10)},
+	}
+}
+
+// SubjectTesteeID 从逻辑引用中取回受试者 ID，供访问审计使用；无法识别时返回 0。
+func SubjectTesteeID(ref *Reference) uint64 {
+	if ref == nil || ref.Identifier == nil {
+		return 0
+	}
+	id, err := strconv.ParseUint(ref.Identifier.Value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// ObservationID 因子得分 Observation 的 ID：测评 ID 与因子在冻结结果中的序号（从 1 开始）。
+func ObservationID(assessmentID uint64, ordinal int) string {
+	return fmt.Sprintf("%d-%d", assessmentID, ordinal)
+}
+
+// ResultObservationID 测评结论等级 Observation 的 ID。
+func ResultObservationID(assessmentID uint64) string {
+	return fmt.Sprintf("%d-%s", assessmentID, resultObservationSuffix)
+}
+
+// ParseObservationID 解析 Observation ID；结论等级返回 ordinal 0。
+func ParseObservationID(id string) (assessmentID uint64, ordinal int, ok bool) {
+	head, tail, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	assessmentID, err := strconv.ParseUint(head, 10, 64)
+	if err != nil || assessmentID == 0 {
+		return 0, 0, false
+	}
+	if tail == resultObservationSuffix {
+		return assessmentID, 0, true
+	}
+	ordinal, err = strconv.Atoi(tail)
+	if err != nil || ordinal < 1 {
+		return 0, 0, false
+	}
+	return assessmentID, ordinal, true
+}
+
+// MapQuestionnaire 将已发布问卷映射为 Questionnaire：题目、选项（分值作为 ordinalValue）与显示条件。
+func MapQuestionnaire(systems Systems, q *domainQuestionnaire.Questionnaire) *Questionnaire {
+	code := q.GetCode().String()
+	resource := &Questionnaire{
+		ResourceType: ResourceQuestionnaire,
+		ID:           code,
+		Meta:         &Meta{VersionID: q.GetVersion().String(), LastUpdated: formatTime(q.GetUpdatedAt())},
+		URL:          systems.QuestionnaireURL(code),
+		Identifier:   []Identifier{{System: systems.namingSystem("questionnaire"), Value: code}},
+		Version:      q.GetVersion().String(),
+		Title:        q.GetTitle(),
+		Status:       questionnaireStatus(q),
+		Description:  q.GetDescription(),
+	}
+	if q.GetPublishedAt() != nil {
+		resource.Date = formatTime(*q.GetPublishedAt())
+	}
+	optionSystem := systems.optionSystem(code)
+	for _, question := range q.GetQuestions() {
+		resource.Item = append(resource.Item, mapQuestionnaireItem(optionSystem, question))
+	}
+	return resource
+}
+
+func questionnaireStatus(q *domainQuestionnaire.Questionnaire) string {
+	switch {
+	case q.IsArchived():
+		return "retired"
+	case q.IsPublishedSnapshot() && !q.IsActivePublished():
+		return "retired"
+	case q.IsPublished():
+		return "active"
+	default:
+		return "draft"
+	}
+}
+
+func mapQuestionnaireItem(optionSystem string, question domainQuestionnaire.Question) QuestionnaireItem {
+	item := QuestionnaireItem{
+		LinkID: question.GetCode().String(),
+		Text:   question.GetStem(),
+		Type:   itemType(question.GetType()),
+	}
+	if item.Type != "display" {
+		for _, rule := range question.GetValidationRules() {
+			switch rule.GetRuleType() {
+			case validation.RuleTypeRequired:
+				item.Required = boolPtr(true)
+			case validation.RuleTypeMaxLength:
+				if item.Type == "string" || item.Type == "text" {
+					if n, err := strconv.Atoi(rule.GetTargetValue()); err == nil && n > 0 {
+						item.MaxLength = &n
+					}
+				}
+			}
+		}
+	}
+	if question.GetType() == domainQuestionnaire.TypeCheckbox {
+		item.Repeats = boolPtr(true)
+	}
+	if item.Type == "choice" {
+		for _, option := range question.GetOptions() {
+			score := option.GetScore()
+			item.AnswerOption = append(item.AnswerOption, AnswerOption{
+				Extension:   []Extension{{URL: ExtensionOrdinalValue, ValueDecimal: &score}},
+				ValueCoding: &Coding{System: optionSystem, Code: option.GetCode().String(), Display: option.GetContent()},
+			})
+		}
+	}
+	applyShowController(&item, optionSystem, question.GetShowController())
+	return item
+}
+
+func itemType(questionType domainQuestionnaire.QuestionType) string {
+	switch questionType {
+	case domainQuestionnaire.TypeSection:
+		return "display"
+	case domainQuestionnaire.TypeRadio, domainQuestionnaire.TypeCheckbox:
+		return "choice"
+	case domainQuestionnaire.TypeTextarea:
+		return "text"
+	case domainQuestionnaire.TypeNumber:
+		return "decimal"
+	default:
+		return "string"
+	}
+}
+
+// applyShowController 将 ShowController 映射为 enableWhen。
+// 每个条件在所选题目的答案命中任一选项时成立，条件之间按 Rule 组合：
+// or 与「每个条件只有一个选项的 and」可以用 enableWhen 等价表达；
+// and 中出现多选项条件时 enableWhen 无法表达条件内的「任一」，改用 SDC enableWhenExpression。
+func applyShowController(item *QuestionnaireItem, optionSystem string, controller *domainQuestionnaire.ShowController) {
+	if controller.IsEmpty() {
+		return
+	}
+	conditions := controller.GetQuestions()
+	anyOf := strings.EqualFold(controller.GetRule(), "or")
+	expressible := true
+	if !anyOf {
+		for _, condition := range conditions {
+			if len(condition.SelectOptionCodes) > 1 {
+				expressible = false
+				break
+			}
+		}
+	}
+	if !expressible {
+		item.Extension = append(item.Extension, Extension{
+			URL:             ExtensionEnableWhenExpression,
+			ValueExpression: &Expression{Language: "text/fhirpath", Expression: enableWhenExpression(conditions)},
+		})
+		return
+	}
+	for _, condition := range conditions {
+		for _, optionCode := range condition.SelectOptionCodes {
+			item.EnableWhen = append(item.EnableWhen, EnableWhen{
+				Question:     condition.Code.String(),
+				Operator:     "=",
+				AnswerCoding: &Coding{System: optionSystem, Code: optionCode.String()},
+			})
+		}
+	}
+	if len(item.EnableWhen) > 1 {
+		item.EnableBehavior = "all"
+		if anyOf {
+			item.EnableBehavior = "any"
+		}
+	}
+}
+
+func enableWhenExpression(conditions []domainQuestionnaire.ShowControllerCondition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		codes := make([]string, 0, len(condition.SelectOptionCodes))
+		for _, optionCode := range condition.SelectOptionCodes {
+			codes = append(codes, fhirPathString(optionCode.String()))
+		}
+		parts = append(parts, fmt.Sprintf("%%resource.repeat(item).where(linkId = %s).answer.value.where(code in (%s)).exists()",
+			fhirPathString(condition.Code.String()), strings.Join(codes, " | ")))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func fhirPathString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// MapQuestionnaireResponse 将答卷映射为 QuestionnaireResponse。
+// 问卷可用时按问卷题目顺序输出并补全题干与选项名称；答卷中不在问卷内的题目追加在末尾。
+func MapQuestionnaireResponse(systems Systems, sheet *answersheet.AnswerSheet, q *domainQuestionnaire.Questionnaire, testeeID uint64) *QuestionnaireResponse {
+	id := sheet.ID().String()
+	code, version, _ := sheet.QuestionnaireInfo()
+	resource := &QuestionnaireResponse{
+		ResourceType:  ResourceQuestionnaireResponse,
+		ID:            id,
+		Identifier:    &Identifier{System: systems.namingSystem("answer-sheet"), Value: id},
+		Questionnaire: systems.QuestionnaireURL(code),
+		Status:        "completed",
+		Subject:       systems.subject(testeeID),
+		Authored:      formatTime(sheet.FilledAt()),
+	}
+	if version != "" {
+		resource.Questionnaire += "|" + version
+	}
+	answers := make(map[string]answersheet.Answer, len(sheet.Answers()))
+	for _, answer := range sheet.Answers() {
+		answers[answer.QuestionCode()] = answer
+	}
+	optionSystem := systems.optionSystem(code)
+	if q != nil {
+		for _, question := range q.GetQuestions() {
+			linkID := question.GetCode().String()
+			answer, ok := answers[linkID]
+			if !ok {
+				continue
+			}
+			delete(answers, linkID)
+			if item, ok := mapResponseItem(optionSystem, answer, question); ok {
+				resource.Item = append(resource.Item, item)
+			}
+		}
+	}
+	for _, answer := range sheet.Answers() {
+		if _, ok := answers[answer.QuestionCode()]; !ok {
+			continue
+		}
+		if item, ok := mapResponseItem(optionSystem, answer, nil); ok {
+			resource.Item = append(resource.Item, item)
+		}
+	}
+	return resource
+}
+
+func mapResponseItem(optionSystem string, answer answersheet.Answer, question domainQuestionnaire.Question) (QuestionnaireResponseItem, bool) {
+	item := QuestionnaireResponseItem{LinkID: answer.QuestionCode()}
+	display := map[string]string{}
+	if question != nil {
+		item.Text = question.GetStem()
+		for _, option := range question.GetOptions() {
+			display[option.GetCode().String()] = option.GetContent()
+		}
+	}
+	if answer.Value() == nil {
+		return item, false
+	}
+	choice := answer.QuestionType() == string(domainQuestionnaire.TypeRadio) || answer.QuestionType() == string(domainQuestionnaire.TypeCheckbox)
+	switch raw := answer.Value().Raw().(type) {
+	case string:
+		if raw == "" {
+			return item, false
+		}
+		if choice {
+			item.Answer = append(item.Answer, QuestionnaireResponseAnswer{ValueCoding: &Coding{System: optionSystem, Code: raw, Display: display[raw]}})
+		} else {
+			value := raw
+			item.Answer = append(item.Answer, QuestionnaireResponseAnswer{ValueString: &value})
+		}
+	case []string:
+		for _, code := range raw {
+			if code == "" {
+				continue
+			}
+			item.Answer = append(item.Answer, QuestionnaireResponseAnswer{ValueCoding: &Coding{System: optionSystem, Code: code, Display: display[code]}})
+		}
+	case float64:
+		value := raw
+		item.Answer = append(item.Answer, QuestionnaireResponseAnswer{ValueDecimal: &value})
+	}
+	return item, len(item.Answer) > 0
+}
+
+// MapObservations 将冻结的因子得分映射为 Observation，并在测评有结论等级时追加一条结论 Observation。
+func MapObservations(systems Systems, assessment evaluationreadmodel.AssessmentRow, scores *evaluationoutcome.ScoreFact) []*Observation {
+	modelCode := stringValue(assessment.EvaluationModelCode)
+	base := Observation{
+		ResourceType: ResourceObservation,
+		Status:       "final",
+		Category: []CodeableConcept{{
+			Coding: []Coding{{System: SystemObservationCategory, Code: "survey", Display: "Survey"}},
+		}},
+		Subject:           systems.subject(assessment.TesteeID),
+		EffectiveDateTime: formatOptionalTime(firstTime(assessment.SubmittedAt, assessment.EvaluatedAt)),
+		Issued:            formatOptionalTime(firstTime(assessment.EvaluatedAt, assessment.SubmittedAt)),
+	}
+	if assessment.AnswerSheetID != 0 {
+		base.DerivedFrom = []Reference{{Reference: fmt.Sprintf("%s/%d", ResourceQuestionnaireResponse, assessment.AnswerSheetID)}}
+	}
+
+	observations := make([]*Observation, 0)
+	if scores != nil {
+		for i, factor := range scores.FactorScores {
+			observation := base
+			observation.ID = ObservationID(assessment.ID, i+1)
+			observation.Code = CodeableConcept{
+				Coding: []Coding{{System: systems.factorSystem(modelCode), Code: factor.FactorCode, Display: factor.FactorName}},
+				Text:   firstNonEmpty(factor.FactorName, factor.FactorCode),
+			}
+			value := factor.RawScore
+			observation.ValueQuantity = scoreQuantity(&value)
+			if factor.MaxScore != nil {
+				maxScore := *factor.MaxScore
+				observation.ReferenceRange = []ObservationReferenceRange{{High: scoreQuantity(&maxScore)}}
+			}
+			if interpretation := riskInterpretation(systems, factor.RiskLevel); interpretation != nil {
+				observation.Interpretation = []CodeableConcept{*interpretation}
+			}
+			observations = append(observations, &observation)
+		}
+	}
+	if result := resultObservation(systems, base, assessment, modelCode); result != nil {
+		observations = append(observations, result)
+	}
+	return observations
+}
+
+func resultObservation(systems Systems, base Observation, assessment evaluationreadmodel.AssessmentRow, modelCode string) *Observation {
+	level := resultLevel(systems, assessment, modelCode)
+	if level == nil {
+		return nil
+	}
+	observation := base
+	observation.ID = ResultObservationID(assessment.ID)
+	modelTitle := stringValue(assessment.EvaluationModelTitle)
+	observation.Code = CodeableConcept{
+		Coding: []Coding{{System: systems.codeSystem("assessment-model"), Code: modelCode, Display: modelTitle}},
+		Text:   firstNonEmpty(modelTitle, modelCode, "Assessment result"),
+	}
+	if modelCode == "" {
+		observation.Code.Coding = nil
+	}
+	observation.ValueCodeableConcept = level
+	if interpretation := riskInterpretation(systems, firstNonEmpty(stringValue(assessment.Severity), stringValue(assessment.RiskLevel))); interpretation != nil {
+		observation.Interpretation = []CodeableConcept{*interpretation}
+	}
+	return &observation
+}
+
+func resultLevel(systems Systems, assessment evaluationreadmodel.AssessmentRow, modelCode string) *CodeableConcept {
+	if code := stringValue(assessment.LevelCode); code != "" {
+		label := stringValue(assessment.LevelLabel)
+		return &CodeableConcept{
+			Coding: []Coding{{System: systems.levelSystem(modelCode), Code: code, Display: label}},
+			Text:   firstNonEmpty(label, code),
+		}
+	}
+	return riskInterpretation(systems, stringValue(assessment.RiskLevel))
+}
+
+func riskInterpretation(systems Systems, riskLevel string) *CodeableConcept {
+	if riskLevel == "" {
+		return nil
+	}
+	display := firstNonEmpty(riskLevelDisplay[riskLevel], riskLevel)
+	return &CodeableConcept{
+		Coding: []Coding{{System: systems.codeSystem("risk-level"), Code: riskLevel, Display: display}},
+		Text:   display,
+	}
+}
+
+// MapDiagnosticReport 将解读报告映射为 DiagnosticReport，result 引用同一测评的 Observation。
+func MapDiagnosticReport(systems Systems, assessment evaluationreadmodel.AssessmentRow, report *reportprojection.Report, observations []*Observation) *DiagnosticReport {
+	id := strconv.FormatUint(assessment.ID, 10)
+	modelCode := firstNonEmpty(report.Model.Code, stringValue(assessment.EvaluationModelCode))
+	modelTitle := firstNonEmpty(report.Model.Title, stringValue(assessment.EvaluationModelTitle))
+	resource := &DiagnosticReport{
+		ResourceType: ResourceDiagnosticReport,
+		ID:           id,
+		Identifier:   []Identifier{{System: systems.namingSystem("assessment"), Value: id}},
+		Status:       "final",
+		Code: CodeableConcept{
+			Coding: []Coding{{System: systems.codeSystem("assessment-model"), Code: modelCode, Display: modelTitle}},
+			Text:   firstNonEmpty(modelTitle, modelCode, "Assessment report"),
+		},
+		Subject:           systems.subject(assessment.TesteeID),
+		EffectiveDateTime: formatOptionalTime(firstTime(assessment.SubmittedAt, assessment.EvaluatedAt)),
+		Issued:            formatTime(report.CreatedAt),
+		Conclusion:        report.Conclusion,
+	}
+	if modelCode == "" {
+		resource.Code.Coding = nil
+	}
+	for _, observation := range observations {
+		resource.Result = append(resource.Result, Reference{Reference: ResourceObservation + "/" + observation.ID})
+	}
+	if report.Level != nil && report.Level.Code != "" {
+		resource.ConclusionCode = []CodeableConcept{{
+			Coding: []Coding{{System: systems.levelSystem(modelCode), Code: report.Level.Code, Display: report.Level.Label}},
+			Text:   firstNonEmpty(report.Level.Label, report.Level.Code),
+		}}
+	}
+	return resource
+}
+
+func scoreQuantity(value *float64) *Quantity {
+	return &Quantity{Value: value, Unit: "score", System: SystemUCUM, Code: "{score}"}
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return formatTime(*value)
+}
+
+func firstTime(values ...*time.Time) *time.Time {
+	for _, value := range values {
+		if value != nil && !value.IsZero() {
+			return value
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func boolPtr(value bool) *bool { return &value }
@@ -0,0 +1,362 @@
+package fhir
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	evaluationoutcome "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/outcome"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+	domainQuestionnaire "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const testBaseURL = "https://qs.example.org/api/v1/fhir"
+
+func TestMapQuestionnaireCoversItemsOptionsAndEnableWhen(t *testing.T) {
+	got := MapQuestionnaire(NewSystems(testBaseURL), newTestQuestionnaire(t))
+	mustValidate(t, got)
+
+	if got.ID != "PHQ" || got.Version != "1.0.0" || got.Status != "active" || got.URL != testBaseURL+"/Questionnaire/PHQ" {
+		t.Fatalf("questionnaire header = %+v", got)
+	}
+	items := itemsByLinkID(got.Item)
+	if items["S1"].Type != "display" || items["S1"].Required != nil {
+		t.Fatalf("section item = %+v", items["S1"])
+	}
+	mood := items["Q1"]
+	if mood.Type != "choice" || mood.Required == nil || !*mood.Required || len(mood.AnswerOption) != 2 {
+		t.Fatalf("radio item = %+v", mood)
+	}
+	if option := mood.AnswerOption[1]; option.ValueCoding.Code != "B" || option.ValueCoding.Display != "Often" ||
+		*option.Extension[0].ValueDecimal != 2 {
+		t.Fatalf("answer option = %+v", option)
+	}
+	if items["Q2"].Repeats == nil || !*items["Q2"].Repeats {
+		t.Fatal("checkbox item must repeat")
+	}
+	if items["Q4"].Type != "decimal" || items["Q5"].Type != "text" || items["Q5"].MaxLength == nil || *items["Q5"].MaxLength != 200 {
+		t.Fatalf("number/textarea items = %+v %+v", items["Q4"], items["Q5"])
+	}
+
+	anyOf := items["Q3"]
+	if anyOf.EnableBehavior != "any" || len(anyOf.EnableWhen) != 3 {
+		t.Fatalf("or rule = %+v", anyOf)
+	}
+	if condition := anyOf.EnableWhen[0]; condition.Question != "Q1" || condition.Operator != "=" || condition.AnswerCoding.Code != "B" {
+		t.Fatalf("enableWhen = %+v", condition)
+	}
+	allOf := items["Q4"]
+	if allOf.EnableBehavior != "all" || len(allOf.EnableWhen) != 2 || len(allOf.Extension) != 0 {
+		t.Fatalf("and rule with single options = %+v", allOf)
+	}
+	// and 中的多选项条件无法用 enableWhen 表达，改用 enableWhenExpression。
+	expression := items["Q5"]
+	if len(expression.EnableWhen) != 0 || len(expression.Extension) != 1 || expression.Extension[0].URL != ExtensionEnableWhenExpression {
+		t.Fatalf("and rule with multi-option condition = %+v", expression)
+	}
+	if want := "where(linkId = 'Q2').answer.value.where(code in ('X' | 'Y')).exists() and "; !strings.Contains(expression.Extension[0].ValueExpression.Expression, want) {
+		t.Fatalf("expression = %q", expression.Extension[0].ValueExpression.Expression)
+	}
+}
+
+func TestMapQuestionnaireResponseFollowsQuestionnaireOrder(t *testing.T) {
+	q := newTestQuestionnaire(t)
+	sheet := newTestAnswerSheet(t, 7)
+	got := MapQuestionnaireResponse(NewSystems(testBaseURL), sheet, q, 900)
+	mustValidate(t, got)
+
+	if got.ID != "55" || got.Questionnaire != testBaseURL+"/Questionnaire/PHQ|1.0.0" || got.Status != "completed" {
+		t.Fatalf("response header = %+v", got)
+	}
+	if SubjectTesteeID(got.Subject) != 900 || got.Subject.Type != "Patient" || got.Subject.Reference != "" {
+		t.Fatalf("subject = %+v", got.Subject)
+	}
+	linkIDs := make([]string, 0, len(got.Item))
+	for _, item := range got.Item {
+		linkIDs = append(linkIDs, item.LinkID)
+	}
+	if strings.Join(linkIDs, ",") != "Q1,Q2,Q4,Q5,EXTRA" {
+		t.Fatalf("items = %v", linkIDs)
+	}
+	if coding := got.Item[0].Answer[0].ValueCoding; coding.Code != "B" || coding.Display != "Often" || got.Item[0].Text != "Low mood" {
+		t.Fatalf("radio answer = %+v", got.Item[0])
+	}
+	if len(got.Item[1].Answer) != 2 || got.Item[1].Answer[1].ValueCoding.Code != "Y" {
+		t.Fatalf("checkbox answers = %+v", got.Item[1].Answer)
+	}
+	if value := got.Item[2].Answer[0].ValueDecimal; value == nil || *value != 0 {
+		t.Fatalf("number answer = %+v", got.Item[2].Answer)
+	}
+	if value := got.Item[3].Answer[0].ValueString; value == nil || *value != "sleeping badly" {
+		t.Fatalf("text answer = %+v", got.Item[3].Answer)
+	}
+}
+
+func TestMapObservationsAndDiagnosticReport(t *testing.T) {
+	systems := NewSystems(testBaseURL)
+	row := newTestAssessmentRow(42, 8)
+	observations := MapObservations(systems, row, newTestScoreFact(42))
+	if len(observations) != 3 {
+		t.Fatalf("observations = %d, want 2 factors + 1 result", len(observations))
+	}
+	for _, observation := range observations {
+		mustValidate(t, observation)
+	}
+	total := observations[0]
+	if total.ID != "42-1" || total.ValueQuantity == nil || *total.ValueQuantity.Value != 0 ||
+		total.Code.Coding[0].System != testBaseURL+"/CodeSystem/model-factor/PHQ9" || total.Code.Coding[0].Code != "TOTAL" {
+		t.Fatalf("total observation = %+v", total)
+	}
+	if total.ReferenceRange[0].High == nil || *total.ReferenceRange[0].High.Value != 27 {
+		t.Fatalf("reference range = %+v", total.ReferenceRange)
+	}
+	if total.DerivedFrom[0].Reference != "QuestionnaireResponse/55" || total.Category[0].Coding[0].Code != "survey" {
+		t.Fatalf("observation links = %+v", total)
+	}
+	if observations[1].Interpretation[0].Coding[0].Code != "high" {
+		t.Fatalf("factor interpretation = %+v", observations[1].Interpretation)
+	}
+	result := observations[2]
+	if result.ID != "42-result" || result.ValueQuantity != nil || result.ValueCodeableConcept.Coding[0].Code != "moderate" {
+		t.Fatalf("result observation = %+v", result)
+	}
+
+	report := MapDiagnosticReport(systems, row, &reportprojection.Report{
+		Conclusion: "Moderate depressive symptoms.",
+		Model:      reportprojection.ModelIdentity{Code: "PHQ9", Title: "PHQ-9"},
+		Level:      &reportprojection.ResultLevel{Code: "moderate", Label: "Moderate"},
+		CreatedAt:  time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+	}, observations)
+	mustValidate(t, report)
+	if report.ID != "42" || report.Conclusion != "Moderate depressive symptoms." || len(report.Result) != 3 ||
+		report.Result[2].Reference != "Observation/42-result" || report.ConclusionCode[0].Coding[0].Code != "moderate" {
+		t.Fatalf("diagnostic report = %+v", report)
+	}
+}
+
+func TestParseObservationID(t *testing.T) {
+	for id, want := range map[string][2]uint64{"42-3": {42, 3}, "42-result": {42, 0}} {
+		assessmentID, ordinal, ok := ParseObservationID(id)
+		if !ok || assessmentID != want[0] || uint64(ordinal) != want[1] {
+			t.Fatalf("ParseObservationID(%q) = %d, %d, %v", id, assessmentID, ordinal, ok)
+		}
+	}
+	for _, id := range []string{"42", "0-1", "42-0", "x-1", "42-total"} {
+		if _, _, ok := ParseObservationID(id); ok {
+			t.Fatalf("ParseObservationID(%q) accepted", id)
+		}
+	}
+}
+
+func TestValidateRejectsStructuralViolations(t *testing.T) {
+	value := 1.0
+	cases := map[string]struct {
+		resource any
+		want     string
+	}{
+		"questionnaire status": {
+			resource: &Questionnaire{ResourceType: ResourceQuestionnaire, Status: "published"},
+			want:     "Questionnaire.status",
+		},
+		"duplicate linkId": {
+			resource: &Questionnaire{ResourceType: ResourceQuestionnaire, Status: "active", Item: []QuestionnaireItem{
+				{LinkID: "Q1", Type: "string"}, {LinkID: "Q1", Type: "string"},
+			}},
+			want: "duplicate linkId",
+		},
+		"dangling enableWhen": {
+			resource: &Questionnaire{ResourceType: ResourceQuestionnaire, Status: "active", Item: []QuestionnaireItem{
+				{LinkID: "Q1", Type: "string", EnableWhen: []EnableWhen{{Question: "Q9", Operator: "=", AnswerCoding: &Coding{Code: "A"}}}},
+			}},
+			want: "unknown linkId",
+		},
+		"missing enableBehavior": {
+			resource: &Questionnaire{ResourceType: ResourceQuestionnaire, Status: "active", Item: []QuestionnaireItem{
+				{LinkID: "Q1", Type: "choice"},
+				{LinkID: "Q2", Type: "string", EnableWhen: []EnableWhen{
+					{Question: "Q1", Operator: "=", AnswerCoding: &Coding{Code: "A"}},
+					{Question: "Q1", Operator: "=", AnswerCoding: &Coding{Code: "B"}},
+				}},
+			}},
+			want: "enableBehavior",
+		},
+		"answer with two values": {
+			resource: &QuestionnaireResponse{ResourceType: ResourceQuestionnaireResponse, Status: "completed", Item: []QuestionnaireResponseItem{
+				{LinkID: "Q1", Answer: []QuestionnaireResponseAnswer{{ValueDecimal: &value, ValueCoding: &Coding{Code: "A"}}}},
+			}},
+			want: "value[x]",
+		},
+		"observation without code": {
+			resource: &Observation{ResourceType: ResourceObservation, Status: "final"},
+			want:     "Observation.code",
+		},
+		"invalid id": {
+			resource: &Observation{ResourceType: ResourceObservation, ID: "42_total", Status: "final", Code: CodeableConcept{Text: "x"}},
+			want:     "Observation.id",
+		},
+		"report result type": {
+			resource: &DiagnosticReport{ResourceType: ResourceDiagnosticReport, Status: "final", Code: CodeableConcept{Text: "x"},
+				Result: []Reference{{Reference: "QuestionnaireResponse/1"}}},
+			want: "must reference an Observation",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.resource)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Validate() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func mustValidate(t *testing.T, resource any) {
+	t.Helper()
+	if err := Validate(resource); err != nil {
+		t.Fatalf("Validate(%T) error = %v", resource, err)
+	}
+	// 结构校验之外确认资源可序列化且保留 resourceType。
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded["resourceType"] == "" {
+		t.Fatalf("encoded resource = %s, err = %v", raw, err)
+	}
+}
+
+func itemsByLinkID(items []QuestionnaireItem) map[string]QuestionnaireItem {
+	result := make(map[string]QuestionnaireItem, len(items))
+	for _, item := range items {
+		result[item.LinkID] = item
+	}
+	return result
+}
+
+func newTestQuestionnaire(t *testing.T) *domainQuestionnaire.Questionnaire {
+	t.Helper()
+	codes := func(values ...string) []meta.Code {
+		result := make([]meta.Code, 0, len(values))
+		for _, value := range values {
+			result = append(result, meta.NewCode(value))
+		}
+		return result
+	}
+	condition := domainQuestionnaire.NewShowControllerCondition
+	questions := []struct {
+		name string
+		opts []domainQuestionnaire.QuestionParamsOption
+	}{
+		{"S1", []domainQuestionnaire.QuestionParamsOption{domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeSection), domainQuestionnaire.WithStem("Mood")}},
+		{"Q1", []domainQuestionnaire.QuestionParamsOption{
+			domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeRadio), domainQuestionnaire.WithStem("Low mood"),
+			domainQuestionnaire.WithOption("A", "Never", 0), domainQuestionnaire.WithOption("B", "Often", 2), domainQuestionnaire.WithRequired(),
+		}},
+		{"Q2", []domainQuestionnaire.QuestionParamsOption{
+			domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeCheckbox), domainQuestionnaire.WithStem("Symptoms"),
+			domainQuestionnaire.WithOption("X", "Sleep", 1), domainQuestionnaire.WithOption("Y", "Appetite", 1),
+		}},
+		{"Q3", []domainQuestionnaire.QuestionParamsOption{
+			domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeText), domainQuestionnaire.WithStem("Describe"),
+			domainQuestionnaire.WithShowController(domainQuestionnaire.NewShowController("or", []domainQuestionnaire.ShowControllerCondition{
+				condition(meta.NewCode("Q1"), codes("B")), condition(meta.NewCode("Q2"), codes("X", "Y")),
+			})),
+		}},
+		{"Q4", []domainQuestionnaire.QuestionParamsOption{
+			domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeNumber), domainQuestionnaire.WithStem("Days affected"),
+			domainQuestionnaire.WithShowController(domainQuestionnaire.NewShowController("and", []domainQuestionnaire.ShowControllerCondition{
+				condition(meta.NewCode("Q1"), codes("B")), condition(meta.NewCode("Q2"), codes("X")),
+			})),
+		}},
+		{"Q5", []domainQuestionnaire.QuestionParamsOption{
+			domainQuestionnaire.WithQuestionType(domainQuestionnaire.TypeTextarea), domainQuestionnaire.WithStem("Notes"),
+			domainQuestionnaire.WithMaxLength(200),
+			domainQuestionnaire.WithShowController(domainQuestionnaire.NewShowController("and", []domainQuestionnaire.ShowControllerCondition{
+				condition(meta.NewCode("Q2"), codes("X", "Y")), condition(meta.NewCode("Q1"), codes("B")),
+			})),
+		}},
+	}
+	built := make([]domainQuestionnaire.Question, 0, len(questions))
+	for _, spec := range questions {
+		question, err := domainQuestionnaire.NewQuestion(append([]domainQuestionnaire.QuestionParamsOption{domainQuestionnaire.WithCode(meta.NewCode(spec.name))}, spec.opts...)...)
+		if err != nil {
+			t.Fatalf("NewQuestion(%s) error = %v", spec.name, err)
+		}
+		built = append(built, question)
+	}
+	publishedAt := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	q, err := domainQuestionnaire.NewQuestionnaire(meta.NewCode("PHQ"), "Patient Health Questionnaire",
+		domainQuestionnaire.WithVersion(domainQuestionnaire.Version("1.0.0")),
+		domainQuestionnaire.WithStatus(domainQuestionnaire.STATUS_PUBLISHED),
+		domainQuestionnaire.WithRecordRole(domainQuestionnaire.RecordRolePublishedSnapshot),
+		domainQuestionnaire.WithActivePublished(true),
+		domainQuestionnaire.WithReleaseStatus(domainQuestionnaire.ReleaseStatusActive),
+		domainQuestionnaire.WithPublishedAt(&publishedAt),
+		domainQuestionnaire.WithQuestions(built),
+	)
+	if err != nil {
+		t.Fatalf("NewQuestionnaire() error = %v", err)
+	}
+	return q
+}
+
+func newTestAnswerSheet(t *testing.T, orgID uint64) *answersheet.AnswerSheet {
+	t.Helper()
+	answer := func(code string, questionType domainQuestionnaire.QuestionType, value answersheet.AnswerValue) answersheet.Answer {
+		result, err := answersheet.NewAnswer(meta.NewCode(code), questionType, value, 0)
+		if err != nil {
+			t.Fatalf("NewAnswer(%s) error = %v", code, err)
+		}
+		return result
+	}
+	ref, err := answersheet.NewQuestionnaireRef("PHQ", "1.0.0", "Patient Health Questionnaire")
+	if err != nil {
+		t.Fatal(err)
+	}
+	submission := answersheet.ReconstructSubmissionContext(
+		actor.NewFillerRef(3, actor.FillerTypeSelf),
+		actor.NewTesteeRef(testee.NewID(900)),
+		meta.FromUint64(orgID),
+		"",
+	)
+	return answersheet.ReconstructWithSubmissionContext(meta.FromUint64(55), ref, submission, []answersheet.Answer{
+		answer("EXTRA", domainQuestionnaire.TypeText, answersheet.NewStringValue("legacy")),
+		answer("Q5", domainQuestionnaire.TypeTextarea, answersheet.NewStringValue("sleeping badly")),
+		answer("Q4", domainQuestionnaire.TypeNumber, answersheet.NewNumberValue(0)),
+		answer("Q2", domainQuestionnaire.TypeCheckbox, answersheet.NewOptionsValue([]string{"X", "Y"})),
+		answer("Q1", domainQuestionnaire.TypeRadio, answersheet.NewOptionValue("B")),
+	}, time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC), 4)
+}
+
+func newTestAssessmentRow(id uint64, orgID int64) evaluationreadmodel.AssessmentRow {
+	submitted := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	evaluated := submitted.Add(time.Minute)
+	str := func(value string) *string { return &value }
+	return evaluationreadmodel.AssessmentRow{
+		ID: id, OrgID: orgID, TesteeID: 900, AnswerSheetID: 55,
+		QuestionnaireCode: "PHQ", QuestionnaireVersion: "1.0.0",
+		EvaluationModelCode: str("PHQ9"), EvaluationModelTitle: str("PHQ-9"),
+		LevelCode: str("moderate"), LevelLabel: str("Moderate"), Severity: str("medium"), RiskLevel: str("medium"),
+		Status: "evaluated", SubmittedAt: &submitted, EvaluatedAt: &evaluated,
+	}
+}
+
+func newTestScoreFact(assessmentID uint64) *evaluationoutcome.ScoreFact {
+	maxTotal := 27.0
+	return &evaluationoutcome.ScoreFact{
+		AssessmentID: assessmentID,
+		TotalScore:   0,
+		RiskLevel:    "medium",
+		FactorScores: []evaluationoutcome.FactorScoreFact{
+			{FactorCode: "TOTAL", FactorName: "Total", RawScore: 0, MaxScore: &maxTotal, RiskLevel: "medium", IsTotalScore: true},
+			{FactorCode: "sleep_quality", FactorName: "Sleep", RawScore: 3, RiskLevel: "high"},
+		},
+	}
+}
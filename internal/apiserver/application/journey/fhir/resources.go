@@ -0,0 +1,217 @@
+// Package fhir maps published questionnaires, answer sheets, frozen
+// evaluation outcomes and interpretation reports onto HL7 FHIR R4 resources
+// so hospital partners can pull results into their EHR.
+//
+// The mapping is read-only and org scoped: Questionnaire resources come from
+// the shared published catalog, every other resource is reachable only when
+// its Assessment belongs to the caller's org. Subjects are referenced by a
+// logical testee identifier instead of a Patient resource; demographic data
+// never leaves through this surface.
+package fhir
+
+// 资源类型。
+const (
+	ResourceQuestionnaire         = "Questionnaire"
+	ResourceQuestionnaireResponse = "QuestionnaireResponse"
+	ResourceObservation           = "Observation"
+	ResourceDiagnosticReport      = "DiagnosticReport"
+	ResourceOperationOutcome      = "OperationOutcome"
+)
+
+// ExportableResourceTypes 批量导出支持的资源类型，按导出顺序排列。
+var ExportableResourceTypes = []string{
+	ResourceQuestionnaire,
+	ResourceQuestionnaireResponse,
+	ResourceObservation,
+	ResourceDiagnosticReport,
+}
+
+// 标准术语与扩展。
+const (
+	SystemObservationCategory = "http://terminology.hl7.org/CodeSystem/observation-category"
+	SystemUCUM                = "http://unitsofmeasure.org"
+	ExtensionOrdinalValue     = "http://hl7.org/fhir/StructureDefinition/ordinalValue"
+	// ExtensionEnableWhenExpression SDC 显示条件表达式，用于 enableWhen 无法等价表达的组合条件。
+	ExtensionEnableWhenExpression = "http://hl7.org/fhir/uv/sdc/StructureDefinition/sdc-questionnaire-enableWhenExpression"
+)
+
+// Meta 资源元数据。
+type Meta struct {
+	VersionID   string `json:"versionId,omitempty"`
+	LastUpdated string `json:"lastUpdated,omitempty"`
+}
+
+// Identifier 业务标识。
+type Identifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+// Reference 资源引用；受试者以 Type + Identifier 的逻辑引用表示。
+type Reference struct {
+	Reference  string      `json:"reference,omitempty"`
+	Type       string      `json:"type,omitempty"`
+	Identifier *Identifier `json:"identifier,omitempty"`
+	Display    string      `json:"display,omitempty"`
+}
+
+// Coding 编码。
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// CodeableConcept 概念。
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+// Quantity 数量。
+type Quantity struct {
+	Value  *float64 `json:"value,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+	System string   `json:"system,omitempty"`
+	Code   string   `json:"code,omitempty"`
+}
+
+// Expression 表达式。
+type Expression struct {
+	Language   string `json:"language"`
+	Expression string `json:"expression"`
+}
+
+// Extension 扩展；value[x] 恰有一个。
+type Extension struct {
+	URL             string      `json:"url"`
+	ValueDecimal    *float64    `json:"valueDecimal,omitempty"`
+	ValueExpression *Expression `json:"valueExpression,omitempty"`
+}
+
+// Questionnaire FHIR Questionnaire。
+type Questionnaire struct {
+	ResourceType string              `json:"resourceType"`
+	ID           string              `json:"id,omitempty"`
+	Meta         *Meta               `json:"meta,omitempty"`
+	URL          string              `json:"url,omitempty"`
+	Identifier   []Identifier        `json:"identifier,omitempty"`
+	Version      string              `json:"version,omitempty"`
+	Title        string              `json:"title,omitempty"`
+	Status       string              `json:"status"`
+	Date         string              `json:"date,omitempty"`
+	Description  string              `json:"description,omitempty"`
+	Item         []QuestionnaireItem `json:"item,omitempty"`
+}
+
+// QuestionnaireItem 题目。
+type QuestionnaireItem struct {
+	Extension      []Extension    `json:"extension,omitempty"`
+	LinkID         string         `json:"linkId"`
+	Text           string         `json:"text,omitempty"`
+	Type           string         `json:"type"`
+	EnableWhen     []EnableWhen   `json:"enableWhen,omitempty"`
+	EnableBehavior string         `json:"enableBehavior,omitempty"`
+	Required       *bool          `json:"required,omitempty"`
+	Repeats        *bool          `json:"repeats,omitempty"`
+	MaxLength      *int           `json:"maxLength,omitempty"`
+	AnswerOption   []AnswerOption `json:"answerOption,omitempty"`
+}
+
+// EnableWhen 显示条件。
+type EnableWhen struct {
+	Question     string  `json:"question"`
+	Operator     string  `json:"operator"`
+	AnswerCoding *Coding `json:"answerCoding,omitempty"`
+}
+
+// AnswerOption 选项。
+type AnswerOption struct {
+	Extension   []Extension `json:"extension,omitempty"`
+	ValueCoding *Coding     `json:"valueCoding,omitempty"`
+}
+
+// QuestionnaireResponse FHIR QuestionnaireResponse。
+type QuestionnaireResponse struct {
+	ResourceType  string                      `json:"resourceType"`
+	ID            string                      `json:"id,omitempty"`
+	Identifier    *Identifier                 `json:"identifier,omitempty"`
+	Questionnaire string                      `json:"questionnaire,omitempty"`
+	Status        string                      `json:"status"`
+	Subject       *Reference                  `json:"subject,omitempty"`
+	Authored      string                      `json:"authored,omitempty"`
+	Item          []QuestionnaireResponseItem `json:"item,omitempty"`
+}
+
+// QuestionnaireResponseItem 作答题目。
+type QuestionnaireResponseItem struct {
+	LinkID string                        `json:"linkId"`
+	Text   string                        `json:"text,omitempty"`
+	Answer []QuestionnaireResponseAnswer `json:"answer,omitempty"`
+}
+
+// QuestionnaireResponseAnswer 作答值；value[x] 恰有一个。
+type QuestionnaireResponseAnswer struct {
+	ValueString  *string  `json:"valueString,omitempty"`
+	ValueDecimal *float64 `json:"valueDecimal,omitempty"`
+	ValueCoding  *Coding  `json:"valueCoding,omitempty"`
+}
+
+// ObservationReferenceRange 参考范围。
+type ObservationReferenceRange struct {
+	Low  *Quantity `json:"low,omitempty"`
+	High *Quantity `json:"high,omitempty"`
+}
+
+// Observation FHIR Observation：因子得分或测评结论等级。
+type Observation struct {
+	ResourceType         string                      `json:"resourceType"`
+	ID                   string                      `json:"id,omitempty"`
+	Status               string                      `json:"status"`
+	Category             []CodeableConcept           `json:"category,omitempty"`
+	Code                 CodeableConcept             `json:"code"`
+	Subject              *Reference                  `json:"subject,omitempty"`
+	EffectiveDateTime    string                      `json:"effectiveDateTime,omitempty"`
+	Issued               string                      `json:"issued,omitempty"`
+	ValueQuantity        *Quantity                   `json:"valueQuantity,omitempty"`
+	ValueCodeableConcept *CodeableConcept            `json:"valueCodeableConcept,omitempty"`
+	Interpretation       []CodeableConcept           `json:"interpretation,omitempty"`
+	ReferenceRange       []ObservationReferenceRange `json:"referenceRange,omitempty"`
+	DerivedFrom          []Reference                 `json:"derivedFrom,omitempty"`
+}
+
+// DiagnosticReport FHIR DiagnosticReport：解读报告。
+type DiagnosticReport struct {
+	ResourceType      string            `json:"resourceType"`
+	ID                string            `json:"id,omitempty"`
+	Identifier        []Identifier      `json:"identifier,omitempty"`
+	Status            string            `json:"status"`
+	Code              CodeableConcept   `json:"code"`
+	Subject           *Reference        `json:"subject,omitempty"`
+	EffectiveDateTime string            `json:"effectiveDateTime,omitempty"`
+	Issued            string            `json:"issued,omitempty"`
+	Result            []Reference       `json:"result,omitempty"`
+	Conclusion        string            `json:"conclusion,omitempty"`
+	ConclusionCode    []CodeableConcept `json:"conclusionCode,omitempty"`
+}
+
+// OperationOutcome FHIR 错误结果。
+type OperationOutcome struct {
+	ResourceType string                  `json:"resourceType"`
+	Issue        []OperationOutcomeIssue `json:"issue"`
+}
+
+// OperationOutcomeIssue 错误项。
+type OperationOutcomeIssue struct {
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	Diagnostics string `json:"diagnostics,omitempty"`
+}
+
+// NewOperationOutcome 创建单条错误的 OperationOutcome。
+func NewOperationOutcome(severity, code, diagnostics string) *OperationOutcome {
+	return &OperationOutcome{
+		ResourceType: ResourceOperationOutcome,
+		Issue:        []OperationOutcomeIssue{{Severity: severity, Code: code, Diagnostics: diagnostics}},
+	}
+}
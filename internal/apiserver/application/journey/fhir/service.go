@@ -0,0 +1,446 @@
+package fhir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	evaluationoutcome "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/outcome"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/queryerror"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	domainAssessment "github.com/FangcunMount/qs-server/internal/apiserver/domain/evaluation/assessment"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+	domainQuestionnaire "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	// ExportMaxWindow 单次批量导出的最大时间跨度。
+	ExportMaxWindow = 366 * 24 * time.Hour
+	// ExportMaxAssessments 单次批量导出的最大测评数；超出时需缩小时间范围。
+	ExportMaxAssessments = 5000
+	// exportPageSize 批量导出分页读取测评的页大小（读模型上限）。
+	exportPageSize = 100
+)
+
+// Scope 调用范围：机构与本次请求的 FHIR base URL（用于 canonical URL 与本地术语体系）。
+type Scope struct {
+	OrgID   int64
+	BaseURL string
+}
+
+// ExportQuery 批量导出条件：按测评创建时间 [Since, Until) 筛选；Types 为空表示全部资源类型。
+type ExportQuery struct {
+	Since, Until time.Time
+	Types        []string
+}
+
+// ExportSummary 批量导出结果统计。
+type ExportSummary struct {
+	Assessments int
+	Resources   map[string]int
+}
+
+// QuestionnaireRepository 读取已发布问卷（含题目）。
+type QuestionnaireRepository interface {
+	FindPublishedByCode(ctx context.Context, code string) (*domainQuestionnaire.Questionnaire, error)
+	FindByCodeVersion(ctx context.Context, code, version string) (*domainQuestionnaire.Questionnaire, error)
+}
+
+// AnswerSheetRepository 读取完整答卷。
+type AnswerSheetRepository interface {
+	FindByID(ctx context.Context, id meta.ID) (*answersheet.AnswerSheet, error)
+}
+
+// Service FHIR R4 只读映射服务。
+type Service interface {
+	// GetQuestionnaire 读取问卷；version 为空时返回当前生效的发布版本。
+	GetQuestionnaire(ctx context.Context, scope Scope, code, version string) (*Questionnaire, error)
+	// GetQuestionnaireResponse 读取本机构的答卷。
+	GetQuestionnaireResponse(ctx context.Context, scope Scope, answerSheetID uint64) (*QuestionnaireResponse, error)
+	// GetObservation 读取本机构测评的因子得分或结论等级。
+	GetObservation(ctx context.Context, scope Scope, id string) (*Observation, error)
+	// GetDiagnosticReport 读取本机构测评的解读报告。
+	GetDiagnosticReport(ctx context.Context, scope Scope, assessmentID uint64) (*DiagnosticReport, error)
+	// Export 以 NDJSON 写出机构在时间范围内已完成评估的测评及其问卷、答卷、得分与报告。
+	Export(ctx context.Context, scope Scope, query ExportQuery, w io.Writer) (*ExportSummary, error)
+}
+
+type service struct {
+	questionnaires QuestionnaireRepository
+	answerSheets   AnswerSheetRepository
+	assessments    evaluationreadmodel.AssessmentReader
+	scores         evaluationoutcome.ScoreFactReader
+	reports        interpretationreadmodel.ReportReader
+	projection     reportprojection.Mapper
+	now            func() time.Time
+}
+
+// NewService 创建 FHIR 映射服务。
+func NewService(
+	questionnaires QuestionnaireRepository,
+	answerSheets AnswerSheetRepository,
+	assessments evaluationreadmodel.AssessmentReader,
+	scores evaluationoutcome.ScoreFactReader,
+	reports interpretationreadmodel.ReportReader,
+	projection reportprojection.Mapper,
+) Service {
+	return &service{
+		questionnaires: questionnaires,
+		answerSheets:   answerSheets,
+		assessments:    assessments,
+		scores:         scores,
+		reports:        reports,
+		projection:     projection,
+		now:            time.Now,
+	}
+}
+
+func (s *service) GetQuestionnaire(ctx context.Context, scope Scope, code, version string) (*Questionnaire, error) {
+	q, err := s.loadQuestionnaire(ctx, code, version)
+	if err != nil {
+		return nil, err
+	}
+	return MapQuestionnaire(NewSystems(scope.BaseURL), q), nil
+}
+
+func (s *service) GetQuestionnaireResponse(ctx context.Context, scope Scope, answerSheetID uint64) (*QuestionnaireResponse, error) {
+	sheet, err := s.answerSheets.FindByID(ctx, meta.FromUint64(answerSheetID))
+	if err != nil {
+		return nil, err
+	}
+	if sheet == nil {
+		return nil, cberrors.WithCode(code.ErrAnswerSheetNotFound, "answer sheet not found")
+	}
+	submission := sheet.SubmissionContext()
+	testeeID := submission.TesteeID().Uint64()
+	if submission.OrgID().Int64() != scope.OrgID {
+		// 历史答卷没有提交上下文，按关联测评确定机构。
+		if !submission.OrgID().IsZero() {
+			return nil, cberrors.WithCode(code.ErrAnswerSheetNotFound, "answer sheet not found")
+		}
+		row, err := s.assessments.GetAssessmentByAnswerSheetID(ctx, answerSheetID)
+		if err != nil || row == nil || row.OrgID != scope.OrgID {
+			return nil, cberrors.WithCode(code.ErrAnswerSheetNotFound, "answer sheet not found")
+		}
+		testeeID = row.TesteeID
+	}
+	questionnaireCode, version, _ := sheet.QuestionnaireInfo()
+	q, err := s.questionnaires.FindByCodeVersion(ctx, questionnaireCode, version)
+	if err != nil {
+		return nil, err
+	}
+	return MapQuestionnaireResponse(NewSystems(scope.BaseURL), sheet, q, testeeID), nil
+}
+
+func (s *service) GetObservation(ctx context.Context, scope Scope, id string) (*Observation, error) {
+	assessmentID, _, ok := ParseObservationID(id)
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrAssessmentNotFound, "observation not found")
+	}
+	row, err := s.loadAssessment(ctx, scope, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	observations, err := s.observations(ctx, NewSystems(scope.BaseURL), *row)
+	if err != nil {
+		return nil, err
+	}
+	for _, observation := range observations {
+		if observation.ID == id {
+			return observation, nil
+		}
+	}
+	return nil, cberrors.WithCode(code.ErrAssessmentNotFound, "observation not found")
+}
+
+func (s *service) GetDiagnosticReport(ctx context.Context, scope Scope, assessmentID uint64) (*DiagnosticReport, error) {
+	row, err := s.loadAssessment(ctx, scope, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	systems := NewSystems(scope.BaseURL)
+	observations, err := s.observations(ctx, systems, *row)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.diagnosticReport(ctx, systems, *row, observations)
+	if err != nil {
+		return nil, queryerror.MapReadError(err)
+	}
+	return report, nil
+}
+
+func (s *service) Export(ctx context.Context, scope Scope, query ExportQuery, w io.Writer) (*ExportSummary, error) {
+	types, err := s.normalizeExport(&query)
+	if err != nil {
+		return nil, err
+	}
+	filter := evaluationreadmodel.AssessmentFilter{
+		OrgID:    scope.OrgID,
+		Statuses: []string{string(domainAssessment.StatusEvaluated)},
+		DateFrom: &query.Since,
+		DateTo:   &query.Until,
+	}
+	page := evaluationreadmodel.PageRequest{Page: 1, PageSize: exportPageSize}
+	rows, total, err := s.assessments.ListAssessments(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	if total > ExportMaxAssessments {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "export covers %d assessments, more than the limit of %d; narrow the date range", total, ExportMaxAssessments)
+	}
+
+	run := &exportRun{
+		service:        s,
+		systems:        NewSystems(scope.BaseURL),
+		types:          typeSet(types),
+		encoder:        json.NewEncoder(w),
+		questionnaires: map[string]*domainQuestionnaire.Questionnaire{},
+		summary:        &ExportSummary{Resources: map[string]int{}},
+	}
+	run.encoder.SetEscapeHTML(false)
+	seen := make(map[uint64]bool, len(rows))
+	for {
+		for _, row := range rows {
+			if seen[row.ID] {
+				continue
+			}
+			seen[row.ID] = true
+			if err := run.assessment(ctx, row); err != nil {
+				return run.summary, run.abort(ctx, scope, err)
+			}
+		}
+		if len(rows) < exportPageSize || int64(page.Page*exportPageSize) >= total {
+			break
+		}
+		page.Page++
+		if rows, _, err = s.assessments.ListAssessments(ctx, filter, page); err != nil {
+			return run.summary, run.abort(ctx, scope, err)
+		}
+	}
+	return run.summary, nil
+}
+
+// normalizeExport 校验时间范围与资源类型；截止时间不晚于当前时刻，使分页期间新增的测评不会挤动页码。
+func (s *service) normalizeExport(query *ExportQuery) ([]string, error) {
+	if query.Since.IsZero() {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "_since is required")
+	}
+	now := s.now()
+	if query.Until.IsZero() || query.Until.After(now) {
+		query.Until = now
+	}
+	if !query.Until.After(query.Since) {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "_since must be earlier than _until")
+	}
+	if query.Until.Sub(query.Since) > ExportMaxWindow {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "export window cannot exceed %d days", int(ExportMaxWindow/(24*time.Hour)))
+	}
+	if len(query.Types) == 0 {
+		return ExportableResourceTypes, nil
+	}
+	types := sortedTypes(query.Types)
+	if len(types) != len(uniqueStrings(query.Types)) {
+		return nil, cberrors.WithCode(code.ErrInvalidArgument, "_type supports %s", strings.Join(ExportableResourceTypes, ","))
+	}
+	return types, nil
+}
+
+func (s *service) loadQuestionnaire(ctx context.Context, questionnaireCode, version string) (*domainQuestionnaire.Questionnaire, error) {
+	var (
+		q   *domainQuestionnaire.Questionnaire
+		err error
+	)
+	if version == "" {
+		q, err = s.questionnaires.FindPublishedByCode(ctx, questionnaireCode)
+	} else {
+		q, err = s.questionnaires.FindByCodeVersion(ctx, questionnaireCode, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 只对外提供发布过的版本（含已归档），草稿与未定状态的记录一律视为不存在。
+	if q == nil || !(q.IsPublished() || q.IsArchived()) {
+		return nil, cberrors.WithCode(code.ErrQuestionnaireNotFound, "questionnaire not found")
+	}
+	return q, nil
+}
+
+func (s *service) loadAssessment(ctx context.Context, scope Scope, assessmentID uint64) (*evaluationreadmodel.AssessmentRow, error) {
+	row, err := s.assessments.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil || row.OrgID != scope.OrgID {
+		return nil, cberrors.WithCode(code.ErrAssessmentNotFound, "assessment not found")
+	}
+	return row, nil
+}
+
+func (s *service) observations(ctx context.Context, systems Systems, row evaluationreadmodel.AssessmentRow) ([]*Observation, error) {
+	var scores *evaluationoutcome.ScoreFact
+	if row.Status == string(domainAssessment.StatusEvaluated) {
+		fact, err := s.scores.Get(ctx, row.ID)
+		if err != nil {
+			return nil, err
+		}
+		scores = fact
+	}
+	return MapObservations(systems, row, scores), nil
+}
+
+func (s *service) diagnosticReport(ctx context.Context, systems Systems, row evaluationreadmodel.AssessmentRow, observations []*Observation) (*DiagnosticReport, error) {
+	reportRow, err := s.reports.GetReportByAssessmentID(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.projection.FromRowAs(ctx, *reportRow, policy.AudienceClinician, policy.ReportAudienceClinician)
+	if err != nil {
+		return nil, err
+	}
+	return MapDiagnosticReport(systems, row, report, observations), nil
+}
+
+// exportRun 一次批量导出的写出状态。
+type exportRun struct {
+	service        *service
+	systems        Systems
+	types          map[string]bool
+	encoder        *json.Encoder
+	questionnaires map[string]*domainQuestionnaire.Questionnaire
+	summary        *ExportSummary
+}
+
+func (r *exportRun) assessment(ctx context.Context, row evaluationreadmodel.AssessmentRow) error {
+	r.summary.Assessments++
+	if r.types[ResourceQuestionnaire] || r.types[ResourceQuestionnaireResponse] {
+		if err := r.survey(ctx, row); err != nil {
+			return err
+		}
+	}
+	if !r.types[ResourceObservation] && !r.types[ResourceDiagnosticReport] {
+		return nil
+	}
+	observations, err := r.service.observations(ctx, r.systems, row)
+	if err != nil {
+		return err
+	}
+	if r.types[ResourceObservation] {
+		for _, observation := range observations {
+			if err := r.write(ResourceObservation, observation); err != nil {
+				return err
+			}
+		}
+	}
+	if r.types[ResourceDiagnosticReport] {
+		report, err := r.service.diagnosticReport(ctx, r.systems, row, observations)
+		switch {
+		case errors.Is(err, interpretationreadmodel.ErrReportNotFound):
+			// 已评估但尚未生成解读报告，只导出得分。
+		case err != nil:
+			return err
+		default:
+			if err := r.write(ResourceDiagnosticReport, report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// survey 写出测评的问卷（每个版本一次）与答卷。
+func (r *exportRun) survey(ctx context.Context, row evaluationreadmodel.AssessmentRow) error {
+	key := row.QuestionnaireCode + "|" + row.QuestionnaireVersion
+	q, loaded := r.questionnaires[key]
+	if !loaded {
+		var err error
+		q, err = r.service.questionnaires.FindByCodeVersion(ctx, row.QuestionnaireCode, row.QuestionnaireVersion)
+		if err != nil {
+			return err
+		}
+		r.questionnaires[key] = q
+		if q != nil && r.types[ResourceQuestionnaire] {
+			if err := r.write(ResourceQuestionnaire, MapQuestionnaire(r.systems, q)); err != nil {
+				return err
+			}
+		}
+	}
+	if !r.types[ResourceQuestionnaireResponse] || row.AnswerSheetID == 0 {
+		return nil
+	}
+	sheet, err := r.service.answerSheets.FindByID(ctx, meta.FromUint64(row.AnswerSheetID))
+	if err != nil {
+		return err
+	}
+	if sheet == nil {
+		return nil
+	}
+	return r.write(ResourceQuestionnaireResponse, MapQuestionnaireResponse(r.systems, sheet, q, row.TesteeID))
+}
+
+func (r *exportRun) write(resourceType string, resource any) error {
+	if err := r.encoder.Encode(resource); err != nil {
+		return err
+	}
+	r.summary.Resources[resourceType]++
+	return nil
+}
+
+// abort 在已开始写出后失败时追加一行 OperationOutcome，使调用方能识别不完整的导出。
+func (r *exportRun) abort(ctx context.Context, scope Scope, err error) error {
+	logger.L(ctx).Errorw("FHIR bulk export aborted",
+		"action", "fhir_export",
+		"org_id", scope.OrgID,
+		"assessments", r.summary.Assessments,
+		"error", err.Error(),
+	)
+	_ = r.encoder.Encode(NewOperationOutcome("fatal", "exception", "export aborted; the output is incomplete"))
+	return err
+}
+
+// sortedTypes 过滤出支持的资源类型，去重并按导出顺序排列。
+func sortedTypes(types []string) []string {
+	order := make(map[string]int, len(ExportableResourceTypes))
+	for i, resourceType := range ExportableResourceTypes {
+		order[resourceType] = i
+	}
+	result := make([]string, 0, len(types))
+	for _, resourceType := range uniqueStrings(types) {
+		if _, ok := order[resourceType]; ok {
+			result = append(result, resourceType)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return order[result[i]] < order[result[j]] })
+	return result
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func typeSet(types []string) map[string]bool {
+	result := make(map[string]bool, len(types))
+	for _, resourceType := range types {
+		result[resourceType] = true
+	}
+	return result
+}
@@ -0,0 +1,325 @@
+package fhir
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	evaluationoutcome "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/outcome"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+	domainQuestionnaire "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func TestGetQuestionnaireResponseIsOrgScoped(t *testing.T) {
+	ctx := context.Background()
+	svc, fakes := newTestService(t)
+
+	got, err := svc.GetQuestionnaireResponse(ctx, Scope{OrgID: 7, BaseURL: testBaseURL}, 55)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustValidate(t, got)
+	if _, err := svc.GetQuestionnaireResponse(ctx, Scope{OrgID: 8, BaseURL: testBaseURL}, 55); !cberrors.IsCode(err, code.ErrAnswerSheetNotFound) {
+		t.Fatalf("other org error = %v", err)
+	}
+
+	// 历史答卷没有机构，按关联测评判定。
+	fakes.sheets.sheets[55] = newTestAnswerSheet(t, 0)
+	fakes.assessments.rows[1] = newTestAssessmentRow(1, 7)
+	if _, err := svc.GetQuestionnaireResponse(ctx, Scope{OrgID: 7, BaseURL: testBaseURL}, 55); err != nil {
+		t.Fatalf("legacy sheet error = %v", err)
+	}
+	if _, err := svc.GetQuestionnaireResponse(ctx, Scope{OrgID: 8, BaseURL: testBaseURL}, 55); !cberrors.IsCode(err, code.ErrAnswerSheetNotFound) {
+		t.Fatalf("legacy sheet other org error = %v", err)
+	}
+}
+
+func TestGetObservationAndDiagnosticReportAreOrgScoped(t *testing.T) {
+	ctx := context.Background()
+	svc, fakes := newTestService(t)
+	fakes.assessments.rows[42] = newTestAssessmentRow(42, 7)
+	fakes.scores.facts[42] = newTestScoreFact(42)
+	fakes.reports.rows[42] = newTestReportRow(42)
+	scope := Scope{OrgID: 7, BaseURL: testBaseURL}
+
+	observation, err := svc.GetObservation(ctx, scope, "42-result")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustValidate(t, observation)
+	if _, err := svc.GetObservation(ctx, scope, "42-9"); !cberrors.IsCode(err, code.ErrAssessmentNotFound) {
+		t.Fatalf("unknown ordinal error = %v", err)
+	}
+	report, err := svc.GetDiagnosticReport(ctx, scope, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustValidate(t, report)
+
+	other := Scope{OrgID: 8, BaseURL: testBaseURL}
+	if _, err := svc.GetObservation(ctx, other, "42-1"); !cberrors.IsCode(err, code.ErrAssessmentNotFound) {
+		t.Fatalf("other org observation error = %v", err)
+	}
+	if _, err := svc.GetDiagnosticReport(ctx, other, 42); !cberrors.IsCode(err, code.ErrAssessmentNotFound) {
+		t.Fatalf("other org report error = %v", err)
+	}
+	delete(fakes.reports.rows, 42)
+	if _, err := svc.GetDiagnosticReport(ctx, scope, 42); !cberrors.IsCode(err, code.ErrInterpretReportNotFound) {
+		t.Fatalf("missing report error = %v", err)
+	}
+}
+
+func TestGetQuestionnaireHidesDrafts(t *testing.T) {
+	svc, fakes := newTestService(t)
+	draft, err := domainQuestionnaire.NewQuestionnaire(meta.NewCode("DRAFT"), "Draft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakes.questionnaires.published["DRAFT"] = draft
+	if _, err := svc.GetQuestionnaire(context.Background(), Scope{OrgID: 7, BaseURL: testBaseURL}, "DRAFT", ""); !cberrors.IsCode(err, code.ErrQuestionnaireNotFound) {
+		t.Fatalf("draft error = %v", err)
+	}
+	got, err := svc.GetQuestionnaire(context.Background(), Scope{OrgID: 7, BaseURL: testBaseURL}, "PHQ", "1.0.0")
+	if err != nil || got.Version != "1.0.0" {
+		t.Fatalf("GetQuestionnaire() = %+v, %v", got, err)
+	}
+}
+
+func TestExportWritesValidNDJSON(t *testing.T) {
+	ctx := context.Background()
+	svc, fakes := newTestService(t)
+	for _, id := range []uint64{42, 43} {
+		fakes.assessments.rows[id] = newTestAssessmentRow(id, 7)
+		fakes.scores.facts[id] = newTestScoreFact(id)
+	}
+	// 43 尚未生成解读报告：导出得分但跳过 DiagnosticReport。
+	fakes.reports.rows[42] = newTestReportRow(42)
+
+	var out bytes.Buffer
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	summary, err := svc.Export(ctx, Scope{OrgID: 7, BaseURL: testBaseURL}, ExportQuery{Since: since}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := fakes.assessments.lastFilter
+	if filter.OrgID != 7 || len(filter.Statuses) != 1 || filter.Statuses[0] != "evaluated" ||
+		!filter.DateFrom.Equal(since) || !filter.DateTo.Equal(testNow) {
+		t.Fatalf("export filter = %+v", filter)
+	}
+
+	counts := map[string]int{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var header struct {
+			ResourceType string `json:"resourceType"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		counts[header.ResourceType]++
+		mustValidate(t, decodeResource(t, header.ResourceType, scanner.Bytes()))
+	}
+	want := map[string]int{ResourceQuestionnaire: 1, ResourceQuestionnaireResponse: 2, ResourceObservation: 6, ResourceDiagnosticReport: 1}
+	for resourceType, n := range want {
+		if counts[resourceType] != n || summary.Resources[resourceType] != n {
+			t.Fatalf("%s lines = %d, summary = %d, want %d", resourceType, counts[resourceType], summary.Resources[resourceType], n)
+		}
+	}
+	if summary.Assessments != 2 || len(counts) != len(want) {
+		t.Fatalf("summary = %+v, lines = %v", summary, counts)
+	}
+
+	out.Reset()
+	summary, err = svc.Export(ctx, Scope{OrgID: 7, BaseURL: testBaseURL}, ExportQuery{Since: since, Types: []string{ResourceDiagnosticReport}}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(out.String(), "\n") != 1 || summary.Resources[ResourceDiagnosticReport] != 1 {
+		t.Fatalf("_type export = %q", out.String())
+	}
+}
+
+func TestExportRejectsInvalidQueries(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]ExportQuery{
+		"missing since":  {},
+		"inverted range": {Since: since, Until: since.Add(-time.Hour)},
+		"window too big": {Since: testNow.Add(-ExportMaxWindow - time.Hour)},
+		"unknown type":   {Since: since, Types: []string{ResourceObservation, "Patient"}},
+	}
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			var out bytes.Buffer
+			if _, err := svc.Export(context.Background(), Scope{OrgID: 7}, query, &out); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if out.Len() != 0 {
+				t.Fatalf("rejected export wrote %q", out.String())
+			}
+		})
+	}
+
+	svc, fakes := newTestService(t)
+	fakes.assessments.total = ExportMaxAssessments + 1
+	var out bytes.Buffer
+	if _, err := svc.Export(context.Background(), Scope{OrgID: 7}, ExportQuery{Since: since}, &out); !cberrors.IsCode(err, code.ErrInvalidArgument) || out.Len() != 0 {
+		t.Fatalf("oversized export error = %v, output %q", err, out.String())
+	}
+}
+
+var testNow = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+type testFakes struct {
+	questionnaires *fakeQuestionnaires
+	sheets         *fakeAnswerSheets
+	assessments    *fakeAssessments
+	scores         *fakeScores
+	reports        *fakeReports
+}
+
+func newTestService(t *testing.T) (*service, *testFakes) {
+	t.Helper()
+	q := newTestQuestionnaire(t)
+	fakes := &testFakes{
+		questionnaires: &fakeQuestionnaires{published: map[string]*domainQuestionnaire.Questionnaire{"PHQ": q}, versions: map[string]*domainQuestionnaire.Questionnaire{"PHQ|1.0.0": q}},
+		sheets:         &fakeAnswerSheets{sheets: map[uint64]*answersheet.AnswerSheet{55: newTestAnswerSheet(t, 7)}},
+		assessments:    &fakeAssessments{rows: map[uint64]evaluationreadmodel.AssessmentRow{}},
+		scores:         &fakeScores{facts: map[uint64]*evaluationoutcome.ScoreFact{}},
+		reports:        &fakeReports{rows: map[uint64]interpretationreadmodel.ReportRow{}},
+	}
+	svc := NewService(fakes.questionnaires, fakes.sheets, fakes.assessments, fakes.scores, fakes.reports, reportprojection.Mapper{}).(*service)
+	svc.now = func() time.Time { return testNow }
+	return svc, fakes
+}
+
+func decodeResource(t *testing.T, resourceType string, raw []byte) any {
+	t.Helper()
+	var resource any
+	switch resourceType {
+	case ResourceQuestionnaire:
+		resource = &Questionnaire{}
+	case ResourceQuestionnaireResponse:
+		resource = &QuestionnaireResponse{}
+	case ResourceObservation:
+		resource = &Observation{}
+	case ResourceDiagnosticReport:
+		resource = &DiagnosticReport{}
+	default:
+		t.Fatalf("unexpected resource type %q", resourceType)
+	}
+	if err := json.Unmarshal(raw, resource); err != nil {
+		t.Fatal(err)
+	}
+	return resource
+}
+
+func newTestReportRow(assessmentID uint64) interpretationreadmodel.ReportRow {
+	return interpretationreadmodel.ReportRow{
+		AssessmentID: assessmentID,
+		Model:        interpretationreadmodel.ModelIdentityRow{Kind: "typology", Title: "PHQ-9"},
+		Level:        &interpretationreadmodel.ResultLevelRow{Code: "moderate", Label: "Moderate"},
+		Conclusion:   "Moderate depressive symptoms.",
+		CreatedAt:    time.Date(2026, 3, 1, 10, 32, 0, 0, time.UTC),
+	}
+}
+
+type fakeQuestionnaires struct {
+	published map[string]*domainQuestionnaire.Questionnaire
+	versions  map[string]*domainQuestionnaire.Questionnaire
+}
+
+func (f *fakeQuestionnaires) FindPublishedByCode(_ context.Context, code string) (*domainQuestionnaire.Questionnaire, error) {
+	return f.published[code], nil
+}
+
+func (f *fakeQuestionnaires) FindByCodeVersion(_ context.Context, code, version string) (*domainQuestionnaire.Questionnaire, error) {
+	return f.versions[code+"|"+version], nil
+}
+
+type fakeAnswerSheets struct {
+	sheets map[uint64]*answersheet.AnswerSheet
+}
+
+func (f *fakeAnswerSheets) FindByID(_ context.Context, id meta.ID) (*answersheet.AnswerSheet, error) {
+	return f.sheets[id.Uint64()], nil
+}
+
+type fakeAssessments struct {
+	rows       map[uint64]evaluationreadmodel.AssessmentRow
+	total      int64
+	lastFilter evaluationreadmodel.AssessmentFilter
+}
+
+func (f *fakeAssessments) GetAssessment(_ context.Context, id uint64) (*evaluationreadmodel.AssessmentRow, error) {
+	row, ok := f.rows[id]
+	if !ok {
+		return nil, cberrors.WithCode(code.ErrAssessmentNotFound, "assessment not found")
+	}
+	return &row, nil
+}
+
+func (f *fakeAssessments) GetAssessmentByAnswerSheetID(_ context.Context, answerSheetID uint64) (*evaluationreadmodel.AssessmentRow, error) {
+	for _, row := range f.rows {
+		if row.AnswerSheetID == answerSheetID {
+			return &row, nil
+		}
+	}
+	return nil, cberrors.WithCode(code.ErrAssessmentNotFound, "assessment not found")
+}
+
+func (f *fakeAssessments) ListAssessments(_ context.Context, filter evaluationreadmodel.AssessmentFilter, page evaluationreadmodel.PageRequest) ([]evaluationreadmodel.AssessmentRow, int64, error) {
+	f.lastFilter = filter
+	rows := make([]evaluationreadmodel.AssessmentRow, 0, len(f.rows))
+	for _, id := range []uint64{43, 42, 1} {
+		if row, ok := f.rows[id]; ok && row.OrgID == filter.OrgID {
+			rows = append(rows, row)
+		}
+	}
+	total := f.total
+	if total == 0 {
+		total = int64(len(rows))
+	}
+	if page.Page > 1 {
+		return nil, total, nil
+	}
+	return rows, total, nil
+}
+
+type fakeScores struct {
+	facts map[uint64]*evaluationoutcome.ScoreFact
+}
+
+func (f *fakeScores) Get(_ context.Context, assessmentID uint64) (*evaluationoutcome.ScoreFact, error) {
+	return f.facts[assessmentID], nil
+}
+
+func (f *fakeScores) Trend(context.Context, uint64, string, int) (*evaluationoutcome.FactorTrendFact, error) {
+	return nil, nil
+}
+
+type fakeReports struct {
+	rows map[uint64]interpretationreadmodel.ReportRow
+}
+
+func (f *fakeReports) GetReportByAssessmentID(_ context.Context, assessmentID uint64) (*interpretationreadmodel.ReportRow, error) {
+	row, ok := f.rows[assessmentID]
+	if !ok {
+		return nil, interpretationreadmodel.ErrReportNotFound
+	}
+	return &row, nil
+}
+
+func (f *fakeReports) ListReports(context.Context, interpretationreadmodel.ReportFilter, interpretationreadmodel.PageRequest) ([]interpretationreadmodel.ReportRow, int64, error) {
+	return nil, 0, nil
+}
@@ -0,0 +1,360 @@
+package fhir
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Validate 按 FHIR R4 结构约束校验资源：必填元素、取值集合、id 与日期格式、
+// value[x] 基数、引用格式，以及 Questionnaire 的 linkId 唯一性与 enableWhen 指向。
+// 它不是完整的 profile 校验器，只覆盖本服务生成的元素。
+func Validate(resource any) error {
+	v := &validator{}
+	switch r := resource.(type) {
+	case *Questionnaire:
+		v.questionnaire(r)
+	case *QuestionnaireResponse:
+		v.questionnaireResponse(r)
+	case *Observation:
+		v.observation(r)
+	case *DiagnosticReport:
+		v.diagnosticReport(r)
+	default:
+		return fmt.Errorf("unsupported FHIR resource %T", resource)
+	}
+	return errors.Join(v.errs...)
+}
+
+var (
+	idPattern        = regexp.MustCompile(`^[A-Za-z0-9\-.]{1,64}$`)
+	codePattern      = regexp.MustCompile(`^[^\s]+( [^\s]+)*$`)
+	referencePattern = regexp.MustCompile(`^[A-Z][A-Za-z]+/[A-Za-z0-9\-.]{1,64}$`)
+
+	questionnaireStatuses    = set("draft", "active", "retired", "unknown")
+	responseStatuses         = set("in-progress", "completed", "amended", "entered-in-error", "stopped")
+	observationStatuses      = set("registered", "preliminary", "final", "amended", "corrected", "cancelled", "entered-in-error", "unknown")
+	diagnosticReportStatuses = set("registered", "partial", "preliminary", "final", "amended", "corrected", "appended", "cancelled", "entered-in-error", "unknown")
+	itemTypes                = set("group", "display", "boolean", "decimal", "integer", "date", "dateTime", "time", "string", "text", "url", "choice", "open-choice", "attachment", "reference", "quantity")
+	enableWhenOperators      = set("exists", "=", "!=", ">", "<", ">=", "<=")
+	enableBehaviors          = set("all", "any")
+)
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) resourceType(path, got, want string) {
+	if got != want {
+		v.fail(path+".resourceType", "expected %q, got %q", want, got)
+	}
+}
+
+func (v *validator) id(path, id string) {
+	if id != "" && !idPattern.MatchString(id) {
+		v.fail(path+".id", "invalid id %q", id)
+	}
+}
+
+func (v *validator) code(path, value string, allowed map[string]bool) {
+	switch {
+	case value == "":
+		v.fail(path, "is required")
+	case !codePattern.MatchString(value):
+		v.fail(path, "invalid code %q", value)
+	case allowed != nil && !allowed[value]:
+		v.fail(path, "unsupported value %q", value)
+	}
+}
+
+func (v *validator) dateTime(path, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return
+		}
+	}
+	v.fail(path, "invalid dateTime %q", value)
+}
+
+// instant 要求精确到秒且带时区。
+func (v *validator) instant(path, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		v.fail(path, "invalid instant %q", value)
+	}
+}
+
+func (v *validator) uri(path, value string) {
+	if value != "" && strings.ContainsAny(value, " \t\n") {
+		v.fail(path, "invalid uri %q", value)
+	}
+}
+
+func (v *validator) coding(path string, coding *Coding) {
+	if coding == nil {
+		return
+	}
+	v.uri(path+".system", coding.System)
+	if coding.Code != "" && !codePattern.MatchString(coding.Code) {
+		v.fail(path+".code", "invalid code %q", coding.Code)
+	}
+	if coding.System == "" && coding.Code == "" && coding.Display == "" {
+		v.fail(path, "coding must have content")
+	}
+}
+
+func (v *validator) concept(path string, concept *CodeableConcept, required bool) {
+	if concept == nil || (len(concept.Coding) == 0 && concept.Text == "") {
+		if required {
+			v.fail(path, "is required")
+		}
+		return
+	}
+	for i := range concept.Coding {
+		v.coding(fmt.Sprintf("%s.coding[%d]", path, i), &concept.Coding[i])
+	}
+}
+
+func (v *validator) reference(path string, ref *Reference) {
+	if ref == nil {
+		return
+	}
+	if ref.Reference == "" && ref.Identifier == nil && ref.Display == "" {
+		v.fail(path, "reference must have reference, identifier or display")
+	}
+	if ref.Reference != "" && !referencePattern.MatchString(ref.Reference) {
+		v.fail(path+".reference", "invalid literal reference %q", ref.Reference)
+	}
+	if ref.Identifier != nil && ref.Identifier.Value == "" {
+		v.fail(path+".identifier.value", "is required")
+	}
+}
+
+func (v *validator) quantity(path string, quantity *Quantity) {
+	if quantity == nil {
+		return
+	}
+	if quantity.Value == nil {
+		v.fail(path+".value", "is required")
+	}
+	if quantity.Code != "" && quantity.System == "" {
+		v.fail(path+".system", "is required when code is present")
+	}
+}
+
+func (v *validator) questionnaire(q *Questionnaire) {
+	if q == nil {
+		v.fail("Questionnaire", "is nil")
+		return
+	}
+	v.resourceType("Questionnaire", q.ResourceType, ResourceQuestionnaire)
+	v.id("Questionnaire", q.ID)
+	v.code("Questionnaire.status", q.Status, questionnaireStatuses)
+	v.uri("Questionnaire.url", q.URL)
+	v.dateTime("Questionnaire.date", q.Date)
+	if q.Meta != nil {
+		v.instant("Questionnaire.meta.lastUpdated", q.Meta.LastUpdated)
+		v.id("Questionnaire.meta.versionId", q.Meta.VersionID)
+	}
+
+	items := make(map[string]QuestionnaireItem, len(q.Item))
+	for i, item := range q.Item {
+		path := fmt.Sprintf("Questionnaire.item[%d]", i)
+		if item.LinkID == "" {
+			v.fail(path+".linkId", "is required")
+		} else if _, dup := items[item.LinkID]; dup {
+			v.fail(path+".linkId", "duplicate linkId %q", item.LinkID)
+		}
+		items[item.LinkID] = item
+	}
+	for i, item := range q.Item {
+		v.questionnaireItem(fmt.Sprintf("Questionnaire.item[%d]", i), item, items)
+	}
+}
+
+func (v *validator) questionnaireItem(path string, item QuestionnaireItem, items map[string]QuestionnaireItem) {
+	v.code(path+".type", item.Type, itemTypes)
+	if item.Type == "display" && (item.Required != nil || item.Repeats != nil || len(item.AnswerOption) > 0) {
+		v.fail(path, "display items cannot be required, repeat or have answer options")
+	}
+	if len(item.AnswerOption) > 0 && item.Type != "choice" && item.Type != "open-choice" {
+		v.fail(path+".answerOption", "only allowed on choice items")
+	}
+	if item.MaxLength != nil && item.Type != "string" && item.Type != "text" && item.Type != "url" && item.Type != "open-choice" {
+		v.fail(path+".maxLength", "only allowed on simple text items")
+	}
+	for i, option := range item.AnswerOption {
+		optionPath := fmt.Sprintf("%s.answerOption[%d]", path, i)
+		if option.ValueCoding == nil {
+			v.fail(optionPath+".value[x]", "is required")
+		}
+		v.coding(optionPath+".valueCoding", option.ValueCoding)
+		v.extensions(optionPath, option.Extension)
+	}
+	v.extensions(path, item.Extension)
+	for i, condition := range item.EnableWhen {
+		conditionPath := fmt.Sprintf("%s.enableWhen[%d]", path, i)
+		v.code(conditionPath+".operator", condition.Operator, enableWhenOperators)
+		target, ok := items[condition.Question]
+		switch {
+		case condition.Question == "":
+			v.fail(conditionPath+".question", "is required")
+		case !ok:
+			v.fail(conditionPath+".question", "refers to unknown linkId %q", condition.Question)
+		case condition.Question == item.LinkID:
+			v.fail(conditionPath+".question", "item cannot depend on itself")
+		case condition.AnswerCoding != nil && target.Type != "choice" && target.Type != "open-choice":
+			v.fail(conditionPath+".answerCoding", "target %q is not a choice item", condition.Question)
+		}
+		if condition.AnswerCoding == nil {
+			v.fail(conditionPath+".answer[x]", "is required")
+		}
+		v.coding(conditionPath+".answerCoding", condition.AnswerCoding)
+	}
+	if item.EnableBehavior != "" {
+		v.code(path+".enableBehavior", item.EnableBehavior, enableBehaviors)
+	}
+	if len(item.EnableWhen) > 1 && item.EnableBehavior == "" {
+		v.fail(path+".enableBehavior", "is required when there are multiple enableWhen conditions")
+	}
+}
+
+func (v *validator) extensions(path string, extensions []Extension) {
+	for i, extension := range extensions {
+		extensionPath := fmt.Sprintf("%s.extension[%d]", path, i)
+		if extension.URL == "" {
+			v.fail(extensionPath+".url", "is required")
+		}
+		values := 0
+		if extension.ValueDecimal != nil {
+			values++
+		}
+		if extension.ValueExpression != nil {
+			values++
+			if extension.ValueExpression.Language == "" || extension.ValueExpression.Expression == "" {
+				v.fail(extensionPath+".valueExpression", "language and expression are required")
+			}
+		}
+		if values != 1 {
+			v.fail(extensionPath+".value[x]", "expected exactly one value, got %d", values)
+		}
+	}
+}
+
+func (v *validator) questionnaireResponse(r *QuestionnaireResponse) {
+	if r == nil {
+		v.fail("QuestionnaireResponse", "is nil")
+		return
+	}
+	v.resourceType("QuestionnaireResponse", r.ResourceType, ResourceQuestionnaireResponse)
+	v.id("QuestionnaireResponse", r.ID)
+	v.code("QuestionnaireResponse.status", r.Status, responseStatuses)
+	v.uri("QuestionnaireResponse.questionnaire", r.Questionnaire)
+	v.reference("QuestionnaireResponse.subject", r.Subject)
+	v.dateTime("QuestionnaireResponse.authored", r.Authored)
+	for i, item := range r.Item {
+		path := fmt.Sprintf("QuestionnaireResponse.item[%d]", i)
+		if item.LinkID == "" {
+			v.fail(path+".linkId", "is required")
+		}
+		for j, answer := range item.Answer {
+			answerPath := fmt.Sprintf("%s.answer[%d]", path, j)
+			values := 0
+			if answer.ValueString != nil {
+				values++
+			}
+			if answer.ValueDecimal != nil {
+				values++
+			}
+			if answer.ValueCoding != nil {
+				values++
+				v.coding(answerPath+".valueCoding", answer.ValueCoding)
+			}
+			if values != 1 {
+				v.fail(answerPath+".value[x]", "expected exactly one value, got %d", values)
+			}
+		}
+	}
+}
+
+func (v *validator) observation(o *Observation) {
+	if o == nil {
+		v.fail("Observation", "is nil")
+		return
+	}
+	v.resourceType("Observation", o.ResourceType, ResourceObservation)
+	v.id("Observation", o.ID)
+	v.code("Observation.status", o.Status, observationStatuses)
+	v.concept("Observation.code", &o.Code, true)
+	for i := range o.Category {
+		v.concept(fmt.Sprintf("Observation.category[%d]", i), &o.Category[i], true)
+	}
+	v.reference("Observation.subject", o.Subject)
+	v.dateTime("Observation.effectiveDateTime", o.EffectiveDateTime)
+	v.instant("Observation.issued", o.Issued)
+	if o.ValueQuantity != nil && o.ValueCodeableConcept != nil {
+		v.fail("Observation.value[x]", "only one value is allowed")
+	}
+	v.quantity("Observation.valueQuantity", o.ValueQuantity)
+	v.concept("Observation.valueCodeableConcept", o.ValueCodeableConcept, false)
+	for i := range o.Interpretation {
+		v.concept(fmt.Sprintf("Observation.interpretation[%d]", i), &o.Interpretation[i], true)
+	}
+	for i, referenceRange := range o.ReferenceRange {
+		path := fmt.Sprintf("Observation.referenceRange[%d]", i)
+		if referenceRange.Low == nil && referenceRange.High == nil {
+			v.fail(path, "must have low or high")
+		}
+		v.quantity(path+".low", referenceRange.Low)
+		v.quantity(path+".high", referenceRange.High)
+	}
+	for i := range o.DerivedFrom {
+		v.reference(fmt.Sprintf("Observation.derivedFrom[%d]", i), &o.DerivedFrom[i])
+	}
+}
+
+func (v *validator) diagnosticReport(r *DiagnosticReport) {
+	if r == nil {
+		v.fail("DiagnosticReport", "is nil")
+		return
+	}
+	v.resourceType("DiagnosticReport", r.ResourceType, ResourceDiagnosticReport)
+	v.id("DiagnosticReport", r.ID)
+	v.code("DiagnosticReport.status", r.Status, diagnosticReportStatuses)
+	v.concept("DiagnosticReport.code", &r.Code, true)
+	v.reference("DiagnosticReport.subject", r.Subject)
+	v.dateTime("DiagnosticReport.effectiveDateTime", r.EffectiveDateTime)
+	v.instant("DiagnosticReport.issued", r.Issued)
+	for i := range r.Result {
+		path := fmt.Sprintf("DiagnosticReport.result[%d]", i)
+		v.reference(path, &r.Result[i])
+		if !strings.HasPrefix(r.Result[i].Reference, ResourceObservation+"/") {
+			v.fail(path, "must reference an Observation")
+		}
+	}
+	for i := range r.ConclusionCode {
+		v.concept(fmt.Sprintf("DiagnosticReport.conclusionCode[%d]", i), &r.ConclusionCode[i], true)
+	}
+}
+
+func set(values ...string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
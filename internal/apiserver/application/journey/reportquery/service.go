@@ -30,6 +30,9 @@ type AssessmentListProjection struct {
 // Scope 范围
 type Scope struct{ OrgID, OperatorUserID int64 }
 
+// GetQuery 单份报告查询
+type GetQuery = interpretationAdmin.GetQuery
+
 // ListQuery 列表查询
 type ListQuery = interpretationAdmin.ListQuery
 
@@ -56,7 +59,7 @@ type Service interface {
 	// ProjectAssessment 投影评估
 	ProjectAssessment(context.Context, *evaluationoperator.Assessment) (*AssessmentProjection, error)
 	// GetReport 获取报告
-	GetReport(context.Context, Scope, uint64) (*Report, error)
+	GetReport(context.Context, Scope, GetQuery) (*Report, error)
 	// ListReports 获取报告列表
 	ListReports(context.Context, Scope, ListQuery) (*ReportList, error)
 }
@@ -181,8 +184,8 @@ func (s *service) ProjectAssessment(ctx context.Context, result *evaluationopera
 }
 
 // GetReport 获取报告
-func (s *service) GetReport(ctx context.Context, scope Scope, id uint64) (*Report, error) {
-	return s.admin.GetReport(ctx, actor(scope), interpretationAdmin.GetQuery{AssessmentID: id})
+func (s *service) GetReport(ctx context.Context, scope Scope, q GetQuery) (*Report, error) {
+	return s.admin.GetReport(ctx, actor(scope), q)
 }
 
 // ListReports 获取报告列表
@@ -0,0 +1,176 @@
+package subjectrights
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const bundleFormatVersion = 1
+
+// reportSections 导出包中需要渲染为可读报告的集合。
+var reportSections = map[string]struct{}{
+	"interpret_report_artifacts": {},
+	"archived_reports":           {},
+}
+
+type bundleManifest struct {
+	FormatVersion int               `json:"format_version"`
+	RequestID     uint64            `json:"request_id"`
+	OrgID         int64             `json:"org_id"`
+	TesteeID      uint64            `json:"testee_id"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	Sections      []manifestSection `json:"sections"`
+	Reports       []string          `json:"reports"`
+}
+
+type manifestSection struct {
+	Store string `json:"store"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// writeBundle 生成导出包：manifest.json 描述内容，subject.json 为按来源分组的全部记录，
+// reports/ 下为每份报告渲染出的 Markdown。
+func writeBundle(req *Request, sections []Section, generatedAt time.Time) ([]byte, error) {
+	manifest := bundleManifest{
+		FormatVersion: bundleFormatVersion,
+		RequestID:     req.ID,
+		OrgID:         req.OrgID,
+		TesteeID:      req.TesteeID,
+		GeneratedAt:   generatedAt,
+		Sections:      make([]manifestSection, 0, len(sections)),
+		Reports:       []string{},
+	}
+	reports := make(map[string]string)
+	for _, section := range sections {
+		manifest.Sections = append(manifest.Sections, manifestSection{Store: section.Store, Name: section.Name, Count: len(section.Records)})
+		if _, ok := reportSections[section.Name]; !ok {
+			continue
+		}
+		for i, record := range section.Records {
+			var view reportView
+			if err := json.Unmarshal(record, &view); err != nil {
+				return nil, fmt.Errorf("decode %s report: %w", section.Name, err)
+			}
+			path := fmt.Sprintf("reports/%s/%s.md", section.Name, view.fileID(i))
+			reports[path] = view.render()
+			manifest.Reports = append(manifest.Reports, path)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeJSON(zw, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "subject.json", struct {
+		RequestID uint64    `json:"request_id"`
+		OrgID     int64     `json:"org_id"`
+		TesteeID  uint64    `json:"testee_id"`
+		Sections  []Section `json:"sections"`
+	}{req.ID, req.OrgID, req.TesteeID, sections}); err != nil {
+		return nil, err
+	}
+	for _, path := range manifest.Reports {
+		w, err := zw.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("create %s: %w", path, err)
+		}
+		if _, err := w.Write([]byte(reports[path])); err != nil {
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close export bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeJSON(zw *zip.Writer, name string, value any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// reportView 渲染报告所需的字段，兼容解读报告与归档报告两种文档。
+type reportView struct {
+	DomainID     json.Number `json:"domain_id"`
+	AssessmentID json.Number `json:"assessment_id"`
+	ScaleName    string      `json:"scale_name"`
+	ScaleCode    string      `json:"scale_code"`
+	TotalScore   float64     `json:"total_score"`
+	RiskLevel    string      `json:"risk_level"`
+	Conclusion   string      `json:"conclusion"`
+	Dimensions   []struct {
+		FactorName  string  `json:"factor_name"`
+		RawScore    float64 `json:"raw_score"`
+		RiskLevel   string  `json:"risk_level"`
+		Description string  `json:"description"`
+		Suggestion  string  `json:"suggestion"`
+	} `json:"dimensions"`
+	Suggestions []struct {
+		Category string `json:"category"`
+		Content  string `json:"content"`
+	} `json:"suggestions"`
+}
+
+func (v reportView) fileID(index int) string {
+	if v.AssessmentID != "" {
+		return v.AssessmentID.String()
+	}
+	if v.DomainID != "" {
+		return v.DomainID.String()
+	}
+	return fmt.Sprintf("%d", index+1)
+}
+
+func (v reportView) render() string {
+	var b strings.Builder
+	title := v.ScaleName
+	if title == "" {
+		title = v.ScaleCode
+	}
+	fmt.Fprintf(&b, "# %s\n\n", strings.TrimSpace(title+" 测评报告"))
+	if v.AssessmentID != "" {
+		fmt.Fprintf(&b, "- 测评ID：%s\n", v.AssessmentID)
+	}
+	fmt.Fprintf(&b, "- 总分：%g\n", v.TotalScore)
+	if v.RiskLevel != "" {
+		fmt.Fprintf(&b, "- 风险等级：%s\n", v.RiskLevel)
+	}
+	if v.Conclusion != "" {
+		fmt.Fprintf(&b, "\n## 结论\n\n%s\n", v.Conclusion)
+	}
+	if len(v.Dimensions) > 0 {
+		b.WriteString("\n## 维度解读\n\n| 维度 | 得分 | 风险等级 | 解读 |\n| --- | --- | --- | --- |\n")
+		for _, d := range v.Dimensions {
+			fmt.Fprintf(&b, "| %s | %g | %s | %s |\n", cell(d.FactorName), d.RawScore, cell(d.RiskLevel), cell(d.Description))
+		}
+	}
+	if len(v.Suggestions) > 0 {
+		b.WriteString("\n## 建议\n\n")
+		for _, s := range v.Suggestions {
+			if s.Category != "" {
+				fmt.Fprintf(&b, "- [%s] %s\n", s.Category, s.Content)
+				continue
+			}
+			fmt.Fprintf(&b, "- %s\n", s.Content)
+		}
+	}
+	return b.String()
+}
+
+func cell(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(value)
+}
@@ -0,0 +1,487 @@
+package subjectrights
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	maxReasonRunes = 500
+	// runLease 执行中的请求超过该时长未完成视为中断，允许重新认领续做。
+	runLease = 15 * time.Minute
+	// objectPrefix 受试者在对象存储中的文件前缀，擦除时整体删除。
+	objectPrefix       = "data-subject"
+	bundleContentType  = "application/zip"
+	pseudonymKind      = "testee"
+	certificateVersion = 1
+)
+
+// plannedStep 擦除步骤及其在两种方式下的处理。
+type plannedStep struct {
+	Name      StepName
+	Store     string
+	Anonymize StepAction
+	Delete    StepAction
+}
+
+// erasurePlan 擦除步骤顺序：先切断访问与待办，再处理答卷、报告与各投影，
+// 最后处理对象存储与受试者档案，保证中断后受试者仍可定位以便续做。
+var erasurePlan = []plannedStep{
+	{Name: StepCareRelations, Store: StoreMySQL, Anonymize: ActionRevoke, Delete: ActionDelete},
+	{Name: StepPlanSchedule, Store: StoreMySQL, Anonymize: ActionRevoke, Delete: ActionDelete},
+	{Name: StepAnswerSheets, Store: StoreMongo, Anonymize: ActionPseudonymize, Delete: ActionDelete},
+	{Name: StepReports, Store: StoreMongo, Anonymize: ActionRetain, Delete: ActionDelete},
+	{Name: StepClinicalRecords, Store: StoreMySQL, Anonymize: ActionRetain, Delete: ActionDelete},
+	{Name: StepStatistics, Store: StoreMySQL, Anonymize: ActionRetain, Delete: ActionDelete},
+	{Name: StepAttention, Store: StoreMongo, Anonymize: ActionDelete, Delete: ActionDelete},
+	{Name: StepObjectAssets, Store: StoreObject, Anonymize: ActionDelete, Delete: ActionDelete},
+	{Name: StepTesteeProfile, Store: StoreMySQL, Anonymize: ActionPseudonymize, Delete: ActionDelete},
+}
+
+// PlannedSteps 返回指定擦除方式下的步骤与处理方式。
+func PlannedSteps(mode ErasureMode) []StepResult {
+	steps := make([]StepResult, 0, len(erasurePlan))
+	for _, step := range erasurePlan {
+		action := step.Anonymize
+		if mode == ErasureModeDelete {
+			action = step.Delete
+		}
+		steps = append(steps, StepResult{Name: step.Name, Store: step.Store, Action: action, Status: StepStatusPending})
+	}
+	return steps
+}
+
+// Service 数据主体请求用例。
+type Service interface {
+	// Create 受理并执行导出或擦除请求；执行失败时请求保留为 failed，可通过 Resume 续做。
+	Create(ctx context.Context, cmd CreateCommand) (*Request, error)
+	// Resume 续做失败或中断的请求，已完成的步骤不会重复执行。
+	Resume(ctx context.Context, orgID int64, requestID, operatorID uint64) (*Request, error)
+	Get(ctx context.Context, orgID int64, requestID uint64) (*Request, error)
+	List(ctx context.Context, orgID int64, filter Filter, page, pageSize int) (*RequestList, error)
+	// OpenBundle 打开已完成导出请求的导出包。
+	OpenBundle(ctx context.Context, orgID int64, requestID uint64) (*Bundle, error)
+	// GetCertificate 返回已完成擦除请求的证书。
+	GetCertificate(ctx context.Context, orgID int64, requestID uint64) (*Certificate, error)
+}
+
+type service struct {
+	store      Store
+	documents  DocumentStore
+	bundles    BundleStore
+	pseudonyms Pseudonymizer
+	cache      TesteeCacheEvictor
+	now        func() time.Time
+}
+
+// NewService 创建数据主体请求服务；bundles 与 cache 可为空。
+// 未配置对象存储时导出包不落盘，下载时按当前数据现场生成。
+func NewService(store Store, documents DocumentStore, bundles BundleStore, pseudonyms Pseudonymizer, cache TesteeCacheEvictor) Service {
+	return &service{store: store, documents: documents, bundles: bundles, pseudonyms: pseudonyms, cache: cache, now: time.Now}
+}
+
+func (s *service) Create(ctx context.Context, cmd CreateCommand) (*Request, error) {
+	reason := strings.TrimSpace(cmd.Reason)
+	if cmd.OrgID <= 0 || cmd.TesteeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id and testee_id are required")
+	}
+	if reason == "" {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxReasonRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "reason exceeds %d characters", maxReasonRunes)
+	}
+	req := &Request{
+		ID:          meta.New().Uint64(),
+		OrgID:       cmd.OrgID,
+		TesteeID:    cmd.TesteeID,
+		Kind:        cmd.Kind,
+		Status:      StatusPending,
+		Reason:      reason,
+		RequestedBy: cmd.OperatorID,
+		RequestedAt: s.now(),
+	}
+	switch cmd.Kind {
+	case KindExport:
+		if cmd.Mode != "" {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "mode only applies to erasure requests")
+		}
+	case KindErasure:
+		req.Mode = cmd.Mode
+		if req.Mode == "" {
+			req.Mode = ErasureModeAnonymize
+		}
+		if req.Mode != ErasureModeAnonymize && req.Mode != ErasureModeDelete {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "mode must be anonymize or delete")
+		}
+		req.Steps = PlannedSteps(req.Mode)
+	default:
+		return nil, errors.WithCode(code.ErrInvalidArgument, "kind must be export or erasure")
+	}
+
+	snapshot, err := s.store.GetTestee(ctx, cmd.OrgID, cmd.TesteeID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询受试者失败")
+	}
+	if snapshot == nil || snapshot.Deleted {
+		return nil, errors.WithCode(code.ErrUserNotFound, "testee %d not found", cmd.TesteeID)
+	}
+	open, err := s.store.FindOpenRequest(ctx, cmd.OrgID, cmd.TesteeID, cmd.Kind)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询数据主体请求失败")
+	}
+	if open != nil {
+		return nil, errors.WithCode(code.ErrDataSubjectConflict, "受试者已有未完成的%s请求 %d，请续做该请求", cmd.Kind, open.ID)
+	}
+	if err := s.store.CreateRequest(ctx, req); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "创建数据主体请求失败")
+	}
+	logger.L(ctx).Infow("Data subject request accepted",
+		"action", "create_data_subject_request",
+		"org_id", req.OrgID,
+		"request_id", req.ID,
+		"testee_id", req.TesteeID,
+		"kind", req.Kind,
+		"mode", req.Mode,
+	)
+	return s.run(ctx, req, cmd.OperatorID)
+}
+
+func (s *service) Resume(ctx context.Context, orgID int64, requestID, operatorID uint64) (*Request, error) {
+	req, err := s.Get(ctx, orgID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status == StatusCompleted {
+		return nil, errors.WithCode(code.ErrDataSubjectConflict, "请求已完成")
+	}
+	return s.run(ctx, req, operatorID)
+}
+
+func (s *service) Get(ctx context.Context, orgID int64, requestID uint64) (*Request, error) {
+	if orgID <= 0 || requestID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id and request_id are required")
+	}
+	req, err := s.store.GetRequest(ctx, orgID, requestID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询数据主体请求失败")
+	}
+	if req == nil {
+		return nil, errors.WithCode(code.ErrDataSubjectRequestNotFound, "data subject request not found")
+	}
+	return req, nil
+}
+
+func (s *service) List(ctx context.Context, orgID int64, filter Filter, page, pageSize int) (*RequestList, error) {
+	if orgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	if filter.Kind != "" && filter.Kind != KindExport && filter.Kind != KindErasure {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "kind must be export or erasure")
+	}
+	switch filter.Status {
+	case "", StatusPending, StatusRunning, StatusFailed, StatusCompleted:
+	default:
+		return nil, errors.WithCode(code.ErrInvalidArgument, "invalid status %q", filter.Status)
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	items, total, err := s.store.ListRequests(ctx, orgID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询数据主体请求失败")
+	}
+	return &RequestList{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) OpenBundle(ctx context.Context, orgID int64, requestID uint64) (*Bundle, error) {
+	req, err := s.Get(ctx, orgID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Kind != KindExport || req.Status != StatusCompleted {
+		return nil, errors.WithCode(code.ErrDataSubjectConflict, "只有已完成的导出请求可以下载导出包")
+	}
+	bundle := &Bundle{FileName: bundleFileName(req), ContentType: bundleContentType}
+	if req.BundleKey != "" {
+		if s.bundles == nil {
+			return nil, errors.WithCode(code.ErrDataSubjectBundleUnavailable, "对象存储未配置，无法读取导出包")
+		}
+		body, size, err := s.bundles.Open(ctx, req.BundleKey)
+		if err != nil {
+			logger.L(ctx).Warnw("Open data subject bundle failed",
+				"action", "open_data_subject_bundle",
+				"request_id", req.ID,
+				"error", err.Error(),
+			)
+			return nil, errors.WithCode(code.ErrDataSubjectBundleUnavailable, "导出包不存在或已随擦除删除")
+		}
+		bundle.Body, bundle.Size = body, size
+		return bundle, nil
+	}
+	snapshot, err := s.store.GetTestee(ctx, req.OrgID, req.TesteeID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询受试者失败")
+	}
+	if snapshot == nil || snapshot.Deleted {
+		return nil, errors.WithCode(code.ErrDataSubjectBundleUnavailable, "受试者已擦除，导出包不可再生成")
+	}
+	body, err := s.buildBundle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Body, bundle.Size = io.NopCloser(bytes.NewReader(body)), int64(len(body))
+	return bundle, nil
+}
+
+func (s *service) GetCertificate(ctx context.Context, orgID int64, requestID uint64) (*Certificate, error) {
+	req, err := s.Get(ctx, orgID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Kind != KindErasure || req.Status != StatusCompleted {
+		return nil, errors.WithCode(code.ErrDataSubjectConflict, "只有已完成的擦除请求才有证书")
+	}
+	cert, err := s.store.GetCertificate(ctx, orgID, requestID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询擦除证书失败")
+	}
+	if cert == nil {
+		return nil, errors.WithCode(code.ErrDataSubjectRequestNotFound, "erasure certificate not found")
+	}
+	return cert, nil
+}
+
+// run 认领并执行请求；每个步骤完成后立即保存进度，失败时记录错误并保留为 failed。
+func (s *service) run(ctx context.Context, req *Request, operatorID uint64) (*Request, error) {
+	now := s.now()
+	claimed, err := s.store.ClaimRequest(ctx, req.OrgID, req.ID, now, now.Add(-runLease))
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "认领数据主体请求失败")
+	}
+	if !claimed {
+		return nil, errors.WithCode(code.ErrDataSubjectConflict, "请求正在执行或已完成")
+	}
+	req.Status = StatusRunning
+	req.StartedAt = &now
+	req.Attempts++
+	req.LastError = ""
+
+	var runErr error
+	switch req.Kind {
+	case KindExport:
+		runErr = s.export(ctx, req)
+	case KindErasure:
+		runErr = s.erase(ctx, req, operatorID)
+	default:
+		runErr = fmt.Errorf("unsupported request kind %q", req.Kind)
+	}
+	if runErr != nil {
+		req.Status = StatusFailed
+		req.LastError = runErr.Error()
+		if err := s.store.SaveProgress(ctx, req); err != nil {
+			logger.L(ctx).Errorw("Save failed data subject request",
+				"action", "save_data_subject_request",
+				"request_id", req.ID,
+				"error", err.Error(),
+			)
+		}
+		logger.L(ctx).Warnw("Data subject request failed",
+			"action", "run_data_subject_request",
+			"org_id", req.OrgID,
+			"request_id", req.ID,
+			"kind", req.Kind,
+			"attempts", req.Attempts,
+			"error", runErr.Error(),
+		)
+		return nil, errors.WrapC(runErr, code.ErrDatabase, "数据主体请求执行失败，可稍后续做")
+	}
+
+	completedAt := s.now()
+	req.Status = StatusCompleted
+	req.CompletedAt = &completedAt
+	if err := s.store.SaveProgress(ctx, req); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "保存数据主体请求失败")
+	}
+	logger.L(ctx).Infow("Data subject request completed",
+		"action", "run_data_subject_request",
+		"org_id", req.OrgID,
+		"request_id", req.ID,
+		"testee_id", req.TesteeID,
+		"kind", req.Kind,
+		"mode", req.Mode,
+	)
+	return req, nil
+}
+
+func (s *service) export(ctx context.Context, req *Request) error {
+	body, err := s.buildBundle(ctx, req)
+	if err != nil {
+		return err
+	}
+	if s.bundles == nil {
+		return nil
+	}
+	key := fmt.Sprintf("%s/exports/%d.zip", subjectPrefix(req.OrgID, req.TesteeID), req.ID)
+	if err := s.bundles.Put(ctx, key, bundleContentType, body); err != nil {
+		return fmt.Errorf("store export bundle: %w", err)
+	}
+	req.BundleKey = key
+	req.BundleSize = int64(len(body))
+	return nil
+}
+
+func (s *service) buildBundle(ctx context.Context, req *Request) ([]byte, error) {
+	records, err := s.store.CollectRecords(ctx, req.OrgID, req.TesteeID)
+	if err != nil {
+		return nil, fmt.Errorf("collect mysql records: %w", err)
+	}
+	documents, err := s.documents.CollectDocuments(ctx, req.TesteeID)
+	if err != nil {
+		return nil, fmt.Errorf("collect mongo documents: %w", err)
+	}
+	return writeBundle(req, append(records, documents...), s.now())
+}
+
+func (s *service) erase(ctx context.Context, req *Request, operatorID uint64) error {
+	if len(req.Steps) == 0 {
+		req.Steps = PlannedSteps(req.Mode)
+	}
+	subject := Subject{
+		OrgID:      req.OrgID,
+		TesteeID:   req.TesteeID,
+		Pseudonym:  s.pseudonym(req.TesteeID),
+		OperatorID: operatorID,
+		At:         s.now(),
+	}
+	for i := range req.Steps {
+		step := &req.Steps[i]
+		if step.Status == StepStatusCompleted {
+			continue
+		}
+		affected, err := s.applyStep(ctx, subject, step)
+		if err != nil {
+			step.Status = StepStatusFailed
+			step.Error = err.Error()
+			return fmt.Errorf("step %s: %w", step.Name, err)
+		}
+		completedAt := s.now()
+		step.Status = StepStatusCompleted
+		step.Affected = affected
+		step.Error = ""
+		step.CompletedAt = &completedAt
+		if err := s.store.SaveProgress(ctx, req); err != nil {
+			return fmt.Errorf("save step %s: %w", step.Name, err)
+		}
+	}
+	if s.cache != nil {
+		_ = s.cache.Evict(ctx, testee.NewID(req.TesteeID))
+	}
+	cert := &Certificate{
+		ID:         meta.New().Uint64(),
+		RequestID:  req.ID,
+		OrgID:      req.OrgID,
+		TesteeID:   req.TesteeID,
+		SubjectRef: subject.Pseudonym,
+		Mode:       req.Mode,
+		Steps:      req.Steps,
+		IssuedBy:   operatorID,
+		IssuedAt:   s.now().Truncate(time.Millisecond), // 与存储精度一致，摘要可被复算
+	}
+	digest, err := CertificateDigest(cert)
+	if err != nil {
+		return err
+	}
+	cert.Digest = digest
+	if err := s.store.IssueCertificate(ctx, cert); err != nil {
+		return fmt.Errorf("issue certificate: %w", err)
+	}
+	return nil
+}
+
+func (s *service) applyStep(ctx context.Context, subject Subject, step *StepResult) (int64, error) {
+	if step.Action == ActionRetain {
+		return 0, nil
+	}
+	switch step.Store {
+	case StoreMongo:
+		return s.documents.EraseDocuments(ctx, subject.TesteeID, step.Name, step.Action)
+	case StoreObject:
+		if s.bundles == nil {
+			return 0, nil
+		}
+		// 报告 PDF 可由报告重新渲染，与导出包一并删除。
+		var affected int64
+		for _, prefix := range []string{subjectPrefix(subject.OrgID, subject.TesteeID) + "/", reportpdf.SubjectPrefix(subject.OrgID, subject.TesteeID)} {
+			deleted, err := s.bundles.DeletePrefix(ctx, prefix)
+			affected += deleted
+			if err != nil {
+				return affected, err
+			}
+		}
+		return affected, nil
+	default:
+		return s.store.EraseRecords(ctx, subject, step.Name, step.Action)
+	}
+}
+
+func (s *service) pseudonym(testeeID uint64) string {
+	if s.pseudonyms == nil {
+		return fmt.Sprintf("%s-%d", pseudonymKind, testeeID)
+	}
+	return s.pseudonyms.Pseudonym(pseudonymKind, testeeID)
+}
+
+// CertificateDigest 计算证书内容的 SHA-256，用于核对证书未被篡改。
+func CertificateDigest(cert *Certificate) (string, error) {
+	payload, err := json.Marshal(struct {
+		Version    int          `json:"version"`
+		RequestID  uint64       `json:"request_id"`
+		OrgID      int64        `json:"org_id"`
+		TesteeID   uint64       `json:"testee_id"`
+		SubjectRef string       `json:"subject_ref"`
+		Mode       ErasureMode  `json:"mode"`
+		Steps      []StepResult `json:"steps"`
+		IssuedBy   uint64       `json:"issued_by"`
+		IssuedAt   string       `json:"issued_at"`
+	}{certificateVersion, cert.RequestID, cert.OrgID, cert.TesteeID, cert.SubjectRef, cert.Mode, cert.Steps, cert.IssuedBy, cert.IssuedAt.UTC().Format(time.RFC3339Nano)})
+	if err != nil {
+		return "", fmt.Errorf("marshal certificate: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func subjectPrefix(orgID int64, testeeID uint64) string {
+	return fmt.Sprintf("%s/%d/%d", objectPrefix, orgID, testeeID)
+}
+
+func bundleFileName(req *Request) string {
+	return fmt.Sprintf("data-subject-%d-%d.zip", req.TesteeID, req.ID)
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
@@ -0,0 +1,360 @@
+package subjectrights
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	testee       *TesteeSnapshot
+	requests     map[uint64]*Request
+	erased       []StepName
+	failStep     StepName
+	certificates map[uint64]*Certificate
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		testee:       &TesteeSnapshot{ID: 401, OrgID: 7, Name: "张三"},
+		requests:     map[uint64]*Request{},
+		certificates: map[uint64]*Certificate{},
+	}
+}
+
+func (s *fakeStore) GetTestee(_ context.Context, orgID int64, testeeID uint64) (*TesteeSnapshot, error) {
+	if s.testee == nil || s.testee.OrgID != orgID || s.testee.ID != testeeID {
+		return nil, nil
+	}
+	snapshot := *s.testee
+	return &snapshot, nil
+}
+
+func (s *fakeStore) CreateRequest(_ context.Context, req *Request) error {
+	saved := *req
+	s.requests[req.ID] = &saved
+	return nil
+}
+
+func (s *fakeStore) GetRequest(_ context.Context, orgID int64, requestID uint64) (*Request, error) {
+	req, ok := s.requests[requestID]
+	if !ok || req.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *req
+	copied.Steps = append([]StepResult(nil), req.Steps...)
+	return &copied, nil
+}
+
+func (s *fakeStore) FindOpenRequest(_ context.Context, orgID int64, testeeID uint64, kind Kind) (*Request, error) {
+	for _, req := range s.requests {
+		if req.OrgID == orgID && req.TesteeID == testeeID && req.Kind == kind && req.Status != StatusCompleted {
+			copied := *req
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) ListRequests(context.Context, int64, Filter, int, int) ([]Request, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeStore) ClaimRequest(_ context.Context, orgID int64, requestID uint64, now, staleBefore time.Time) (bool, error) {
+	req, ok := s.requests[requestID]
+	if !ok || req.OrgID != orgID {
+		return false, nil
+	}
+	switch {
+	case req.Status == StatusPending || req.Status == StatusFailed:
+	case req.Status == StatusRunning && req.StartedAt != nil && req.StartedAt.Before(staleBefore):
+	default:
+		return false, nil
+	}
+	req.Status = StatusRunning
+	req.StartedAt = &now
+	req.Attempts++
+	return true, nil
+}
+
+func (s *fakeStore) SaveProgress(_ context.Context, req *Request) error {
+	saved := *req
+	saved.Steps = append([]StepResult(nil), req.Steps...)
+	s.requests[req.ID] = &saved
+	return nil
+}
+
+func (s *fakeStore) CollectRecords(_ context.Context, _ int64, testeeID uint64) ([]Section, error) {
+	return []Section{{Store: StoreMySQL, Name: "testee", Records: []json.RawMessage{json.RawMessage(`{"id":401,"name":"张三"}`)}}}, nil
+}
+
+func (s *fakeStore) EraseRecords(_ context.Context, subject Subject, step StepName, _ StepAction) (int64, error) {
+	if step == s.failStep {
+		return 0, stderrors.New("lock wait timeout")
+	}
+	s.erased = append(s.erased, step)
+	if step == StepTesteeProfile {
+		s.testee.Name = subject.Pseudonym
+		s.testee.Deleted = true
+	}
+	return 1, nil
+}
+
+func (s *fakeStore) IssueCertificate(_ context.Context, cert *Certificate) error {
+	if _, ok := s.certificates[cert.RequestID]; !ok {
+		s.certificates[cert.RequestID] = cert
+	}
+	return nil
+}
+
+func (s *fakeStore) GetCertificate(_ context.Context, _ int64, requestID uint64) (*Certificate, error) {
+	return s.certificates[requestID], nil
+}
+
+type fakeDocuments struct {
+	erased []StepName
+}
+
+func (d *fakeDocuments) CollectDocuments(context.Context, uint64) ([]Section, error) {
+	return []Section{
+		{Store: StoreMongo, Name: "answersheets", Records: []json.RawMessage{json.RawMessage(`{"testee_id":401,"answers":[]}`)}},
+		{Store: StoreMongo, Name: "interpret_report_artifacts", Records: []json.RawMessage{json.RawMessage(
+			`{"assessment_id":9001,"scale_name":"SDS","total_score":52.5,"risk_level":"medium","conclusion":"轻度抑郁",` +
+				`"dimensions":[{"factor_name":"情绪","raw_score":12,"risk_level":"medium","description":"偶有低落"}],` +
+				`"suggestions":[{"category":"生活","content":"规律作息"}]}`)}},
+	}, nil
+}
+
+func (d *fakeDocuments) EraseDocuments(_ context.Context, _ uint64, step StepName, _ StepAction) (int64, error) {
+	d.erased = append(d.erased, step)
+	return 2, nil
+}
+
+type fakeBundles struct {
+	objects  map[string][]byte
+	prefixes []string
+}
+
+func (b *fakeBundles) Put(_ context.Context, key, _ string, body []byte) error {
+	b.objects[key] = body
+	return nil
+}
+
+func (b *fakeBundles) Open(_ context.Context, key string) (io.ReadCloser, int64, error) {
+	body, ok := b.objects[key]
+	if !ok {
+		return nil, 0, stderrors.New("object not found")
+	}
+	return io.NopCloser(bytes.NewReader(body)), int64(len(body)), nil
+}
+
+func (b *fakeBundles) DeletePrefix(_ context.Context, prefix string) (int64, error) {
+	b.prefixes = append(b.prefixes, prefix)
+	var deleted int64
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.objects, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type fakePseudonyms struct{}
+
+func (fakePseudonyms) Pseudonym(kind string, id uint64) string { return kind + "_fixed" }
+
+func newTestService() (*service, *fakeStore, *fakeDocuments, *fakeBundles) {
+	store := newFakeStore()
+	documents := &fakeDocuments{}
+	bundles := &fakeBundles{objects: map[string][]byte{}}
+	svc := NewService(store, documents, bundles, fakePseudonyms{}, nil).(*service)
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, store, documents, bundles
+}
+
+func readZip(t *testing.T, body []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func TestExportStoresBundleWithRenderedReports(t *testing.T) {
+	svc, _, _, bundles := newTestService()
+	req, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindExport, Reason: "家属申请查阅", OperatorID: 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Status != StatusCompleted || !strings.HasPrefix(req.BundleKey, "data-subject/7/401/exports/") {
+		t.Fatalf("request = %+v", req)
+	}
+
+	bundle, err := svc.OpenBundle(context.Background(), 7, req.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(bundle.Body)
+	files := readZip(t, body)
+	if _, ok := files["manifest.json"]; !ok {
+		t.Fatalf("bundle files = %v", files)
+	}
+	if !strings.Contains(files["subject.json"], `"name": "answersheets"`) || !strings.Contains(files["subject.json"], "张三") {
+		t.Fatalf("subject.json = %s", files["subject.json"])
+	}
+	report := files["reports/interpret_report_artifacts/9001.md"]
+	for _, want := range []string{"# SDS 测评报告", "- 风险等级：medium", "轻度抑郁", "| 情绪 | 12 | medium | 偶有低落 |", "- [生活] 规律作息"} {
+		if !strings.Contains(report, want) {
+			t.Fatalf("report missing %q:\n%s", want, report)
+		}
+	}
+	if len(bundles.objects) != 1 {
+		t.Fatalf("stored objects = %d", len(bundles.objects))
+	}
+}
+
+func TestAnonymizeErasureRunsPlanAndIssuesCertificate(t *testing.T) {
+	svc, store, documents, bundles := newTestService()
+	bundles.objects["data-subject/7/401/exports/1.zip"] = []byte("zip")
+	bundles.objects["data-subject/7/4010/exports/2.zip"] = []byte("other testee")
+	bundles.objects["report-pdf/7/401/5001/rpt-1.pdf"] = []byte("pdf")
+
+	req, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindErasure, Reason: "家属撤回同意", OperatorID: 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Status != StatusCompleted || req.Mode != ErasureModeAnonymize {
+		t.Fatalf("request = %+v", req)
+	}
+	// 保留类步骤不触达存储，测评与报告的来源链路保持不变。
+	wantMySQL := []StepName{StepCareRelations, StepPlanSchedule, StepTesteeProfile}
+	if strings.Join(stepNames(store.erased), ",") != strings.Join(stepNames(wantMySQL), ",") {
+		t.Fatalf("mysql steps = %v", store.erased)
+	}
+	if strings.Join(stepNames(documents.erased), ",") != strings.Join(stepNames([]StepName{StepAnswerSheets, StepAttention}), ",") {
+		t.Fatalf("mongo steps = %v", documents.erased)
+	}
+	if _, ok := bundles.objects["data-subject/7/4010/exports/2.zip"]; !ok || len(bundles.objects) != 1 {
+		t.Fatalf("objects after erasure = %v", bundles.objects)
+	}
+	if store.testee.Name != "testee_fixed" {
+		t.Fatalf("testee name = %q", store.testee.Name)
+	}
+
+	cert, err := svc.GetCertificate(context.Background(), 7, req.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.SubjectRef != "testee_fixed" || len(cert.Steps) != len(erasurePlan) {
+		t.Fatalf("certificate = %+v", cert)
+	}
+	digest, err := CertificateDigest(cert)
+	if err != nil || digest != cert.Digest {
+		t.Fatalf("digest = %s, %v; want %s", digest, err, cert.Digest)
+	}
+	for _, step := range cert.Steps {
+		if step.Status != StepStatusCompleted {
+			t.Fatalf("step %s status = %s", step.Name, step.Status)
+		}
+	}
+}
+
+func TestFailedErasureResumesFromFailedStep(t *testing.T) {
+	svc, store, documents, _ := newTestService()
+	store.failStep = StepStatistics
+
+	_, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindErasure, Mode: ErasureModeDelete, Reason: "家属撤回同意"})
+	if err == nil {
+		t.Fatal("expected erasure to fail")
+	}
+	var failed *Request
+	for _, req := range store.requests {
+		failed = req
+	}
+	if failed.Status != StatusFailed || failed.LastError == "" {
+		t.Fatalf("failed request = %+v", failed)
+	}
+	if _, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindErasure, Reason: "重复提交"}); !errors.IsCode(err, code.ErrDataSubjectConflict) {
+		t.Fatalf("duplicate erasure err = %v", err)
+	}
+
+	store.failStep = ""
+	store.erased = nil
+	documents.erased = nil
+	resumed, err := svc.Resume(context.Background(), 7, failed.ID, 900)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Status != StatusCompleted || resumed.Attempts != 2 {
+		t.Fatalf("resumed = %+v", resumed)
+	}
+	if strings.Join(stepNames(store.erased), ",") != "statistics,testee_profile" || strings.Join(stepNames(documents.erased), ",") != "attention" {
+		t.Fatalf("resumed steps mysql=%v mongo=%v", store.erased, documents.erased)
+	}
+	if _, err := svc.Resume(context.Background(), 7, failed.ID, 900); !errors.IsCode(err, code.ErrDataSubjectConflict) {
+		t.Fatalf("resume completed err = %v", err)
+	}
+}
+
+func TestCreateRejectsInvalidCommands(t *testing.T) {
+	svc, store, _, _ := newTestService()
+	cases := []CreateCommand{
+		{OrgID: 7, TesteeID: 401, Kind: "purge", Reason: "r"},
+		{OrgID: 7, TesteeID: 401, Kind: KindErasure, Mode: "shred", Reason: "r"},
+		{OrgID: 7, TesteeID: 401, Kind: KindExport, Mode: ErasureModeDelete, Reason: "r"},
+		{OrgID: 7, TesteeID: 401, Kind: KindExport},
+	}
+	for _, cmd := range cases {
+		if _, err := svc.Create(context.Background(), cmd); !errors.IsCode(err, code.ErrInvalidArgument) {
+			t.Fatalf("Create(%+v) err = %v", cmd, err)
+		}
+	}
+	store.testee.Deleted = true
+	if _, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindExport, Reason: "r"}); !errors.IsCode(err, code.ErrUserNotFound) {
+		t.Fatalf("erased testee err = %v", err)
+	}
+}
+
+func TestOpenBundleAfterErasureIsUnavailable(t *testing.T) {
+	svc, _, _, _ := newTestService()
+	export, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindExport, Reason: "查阅"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Create(context.Background(), CreateCommand{OrgID: 7, TesteeID: 401, Kind: KindErasure, Reason: "撤回同意"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.OpenBundle(context.Background(), 7, export.ID); !errors.IsCode(err, code.ErrDataSubjectBundleUnavailable) {
+		t.Fatalf("OpenBundle() err = %v", err)
+	}
+}
+
+func stepNames(steps []StepName) []string {
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, string(step))
+	}
+	return names
+}
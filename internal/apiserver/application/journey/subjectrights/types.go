@@ -0,0 +1,127 @@
+// Package subjectrights fulfils data-subject requests for one testee: a
+// machine-readable export bundle (JSON plus rendered reports) and a
+// right-to-erasure saga. The saga walks every store that holds the testee's
+// data (MySQL, Mongo and object storage) step by step, persists each step's
+// result so an interrupted request can be resumed idempotently, and issues a
+// certificate once every step has completed.
+//
+// Erasure defaults to anonymization: evaluation and report provenance is kept
+// against the now anonymous testee ID and the testee row itself is
+// pseudonymized. Delete mode removes the clinical records as well. Consent
+// acceptances and access audit logs are legal evidence and are retained in
+// both modes.
+package subjectrights
+
+import (
+	"context"
+	"io"
+
+	domainsubject "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+)
+
+type (
+	Kind           = domainsubject.Kind
+	ErasureMode    = domainsubject.ErasureMode
+	Status         = domainsubject.Status
+	StepName       = domainsubject.StepName
+	StepAction     = domainsubject.StepAction
+	StepStatus     = domainsubject.StepStatus
+	StepResult     = domainsubject.StepResult
+	Request        = domainsubject.Request
+	Certificate    = domainsubject.Certificate
+	Subject        = domainsubject.Subject
+	TesteeSnapshot = domainsubject.TesteeSnapshot
+	Section        = domainsubject.Section
+	Filter         = domainsubject.Filter
+)
+
+const (
+	KindExport  = domainsubject.KindExport
+	KindErasure = domainsubject.KindErasure
+
+	ErasureModeAnonymize = domainsubject.ErasureModeAnonymize
+	ErasureModeDelete    = domainsubject.ErasureModeDelete
+
+	StatusPending   = domainsubject.StatusPending
+	StatusRunning   = domainsubject.StatusRunning
+	StatusFailed    = domainsubject.StatusFailed
+	StatusCompleted = domainsubject.StatusCompleted
+
+	StepCareRelations   = domainsubject.StepCareRelations
+	StepPlanSchedule    = domainsubject.StepPlanSchedule
+	StepAnswerSheets    = domainsubject.StepAnswerSheets
+	StepReports         = domainsubject.StepReports
+	StepClinicalRecords = domainsubject.StepClinicalRecords
+	StepStatistics      = domainsubject.StepStatistics
+	StepAttention       = domainsubject.StepAttention
+	StepObjectAssets    = domainsubject.StepObjectAssets
+	StepTesteeProfile   = domainsubject.StepTesteeProfile
+
+	ActionRetain       = domainsubject.ActionRetain
+	ActionPseudonymize = domainsubject.ActionPseudonymize
+	ActionRevoke       = domainsubject.ActionRevoke
+	ActionDelete       = domainsubject.ActionDelete
+
+	StoreMySQL  = domainsubject.StoreMySQL
+	StoreMongo  = domainsubject.StoreMongo
+	StoreObject = domainsubject.StoreObject
+
+	StepStatusPending   = domainsubject.StepStatusPending
+	StepStatusCompleted = domainsubject.StepStatusCompleted
+	StepStatusFailed    = domainsubject.StepStatusFailed
+)
+
+// RequestList 请求分页。
+type RequestList struct {
+	Items    []Request
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// CreateCommand 受理数据主体请求。
+type CreateCommand struct {
+	OrgID      int64
+	TesteeID   uint64
+	Kind       Kind
+	Mode       ErasureMode
+	Reason     string
+	OperatorID uint64
+}
+
+// Bundle 导出包内容。
+type Bundle struct {
+	FileName    string
+	ContentType string
+	Body        io.ReadCloser
+	Size        int64
+}
+
+// Store 数据主体请求的 MySQL 持久化端口。
+type Store = domainsubject.Repository
+
+// DocumentStore Mongo 侧的导出采集与擦除步骤（答卷与报告）。
+type DocumentStore interface {
+	CollectDocuments(ctx context.Context, testeeID uint64) ([]Section, error)
+	// EraseDocuments 对 Mongo 执行一个擦除步骤，返回受影响文档数；重复执行是安全的。
+	EraseDocuments(ctx context.Context, testeeID uint64, step StepName, action StepAction) (int64, error)
+}
+
+// BundleStore 导出包的对象存储。
+type BundleStore interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	Open(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	// DeletePrefix 删除前缀下的全部对象，返回删除数量。
+	DeletePrefix(ctx context.Context, prefix string) (int64, error)
+}
+
+// Pseudonymizer 生成受试者假名。
+type Pseudonymizer interface {
+	Pseudonym(kind string, id uint64) string
+}
+
+// TesteeCacheEvictor 失效受试者缓存（由带缓存的受试者仓储实现）。
+type TesteeCacheEvictor interface {
+	Evict(ctx context.Context, id testee.ID) error
+}
@@ -0,0 +1,230 @@
+package testeeimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainTestee "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+)
+
+type importColumn string
+
+const (
+	columnName      importColumn = "name"
+	columnGender    importColumn = "gender"
+	columnBirthday  importColumn = "birthday"
+	columnProfileID importColumn = "profile_id"
+)
+
+// headerAliases 表头别名；匹配前统一去空白并转小写。
+var headerAliases = map[string]importColumn{
+	"name": columnName, "姓名": columnName, "受试者姓名": columnName,
+	"gender": columnGender, "sex": columnGender, "性别": columnGender,
+	"birthday": columnBirthday, "birth_date": columnBirthday, "birthdate": columnBirthday, "出生日期": columnBirthday, "生日": columnBirthday,
+	"profile_id": columnProfileID, "profileid": columnProfileID, "档案id": columnProfileID, "用户档案id": columnProfileID,
+}
+
+var birthdayLayouts = []string{"2006-01-02", "2006/01/02", "2006.01.02", "20060102", "2006-1-2", "2006/1/2", "2006.1.2", "2006年1月2日"}
+
+// excelEpoch 是 1900 日期系统的序列号零点（已计入 Excel 的 1900-02-29 兼容缺陷）。
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// DetectFormat 根据文件扩展名识别导入格式。
+func DetectFormat(fileName string) (FileFormat, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FileFormatCSV, nil
+	case ".xlsx":
+		return FileFormatXLSX, nil
+	default:
+		return "", fmt.Errorf("仅支持 .csv 与 .xlsx 文件")
+	}
+}
+
+// ParseRows 解析文件并逐行校验、规范化、在文件内去重。
+// 返回的行均带有文件行号；校验失败与重复的行也会保留，便于按行回报结果。
+func ParseRows(format FileFormat, content []byte, now time.Time) ([]Row, error) {
+	records, err := readRecords(format, content)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("文件为空")
+	}
+	columns, err := mapHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	validator := domainTestee.NewValidator(nil)
+	profileRows := make(map[uint64]int)
+	identityRows := make(map[string]int)
+	rows := make([]Row, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) >= MaxRows {
+			return nil, fmt.Errorf("数据行超过上限 %d", MaxRows)
+		}
+		row := normalizeRecord(record, columns, validator, now)
+		row.RowNo = i + 2
+		if row.Status == RowStatusPending {
+			key := identityKey(row)
+			if row.ProfileID != nil {
+				if first, ok := profileRows[*row.ProfileID]; ok {
+					row.Status, row.Error = RowStatusDuplicate, fmt.Sprintf("与第 %d 行的档案ID重复", first)
+				} else {
+					profileRows[*row.ProfileID] = row.RowNo
+				}
+			} else if first, ok := identityRows[key]; ok {
+				row.Status, row.Error = RowStatusDuplicate, fmt.Sprintf("与第 %d 行的姓名、性别和出生日期重复", first)
+			}
+			if _, ok := identityRows[key]; !ok && row.Status == RowStatusPending {
+				identityRows[key] = row.RowNo
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("文件不包含数据行")
+	}
+	return rows, nil
+}
+
+func readRecords(format FileFormat, content []byte) ([][]string, error) {
+	switch format {
+	case FileFormatCSV:
+		content = bytes.TrimPrefix(content, []byte("\ufeff"))
+		if !utf8.Valid(content) {
+			return nil, fmt.Errorf("CSV 文件必须使用 UTF-8 编码")
+		}
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("解析 CSV 失败: %w", err)
+		}
+		return records, nil
+	case FileFormatXLSX:
+		return readXLSX(content)
+	default:
+		return nil, fmt.Errorf("不支持的文件格式 %q", format)
+	}
+}
+
+func mapHeader(header []string) (map[importColumn]int, error) {
+	columns := make(map[importColumn]int, len(header))
+	for i, raw := range header {
+		key := strings.ToLower(strings.Join(strings.Fields(strings.TrimPrefix(raw, "\ufeff")), ""))
+		column, ok := headerAliases[key]
+		if !ok {
+			continue
+		}
+		if _, exists := columns[column]; exists {
+			return nil, fmt.Errorf("表头列 %q 重复", raw)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[columnName]; !ok {
+		return nil, fmt.Errorf("缺少姓名列（name/姓名）")
+	}
+	return columns, nil
+}
+
+func normalizeRecord(record []string, columns map[importColumn]int, validator domainTestee.Validator, now time.Time) Row {
+	cell := func(column importColumn) string {
+		index, ok := columns[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+	row := Row{Status: RowStatusPending}
+	var problems []string
+
+	row.Name = strings.Join(strings.Fields(cell(columnName)), " ")
+	if err := validator.ValidateName(row.Name, true); err != nil {
+		problems = append(problems, "姓名为空或过长")
+	}
+	gender, err := parseGenderCell(cell(columnGender))
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	row.Gender = gender
+	if raw := cell(columnBirthday); raw != "" {
+		birthday, err := parseBirthdayCell(raw)
+		if err == nil && (birthday.After(now) || now.Year()-birthday.Year() > 150) {
+			err = fmt.Errorf("出生日期 %q 超出有效范围", raw)
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			row.Birthday = &birthday
+		}
+	}
+	if raw := cell(columnProfileID); raw != "" {
+		profileID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || profileID == 0 {
+			problems = append(problems, fmt.Sprintf("档案ID %q 无效", raw))
+		} else {
+			row.ProfileID = &profileID
+		}
+	}
+	if len(problems) > 0 {
+		row.Status = RowStatusInvalid
+		row.Error = strings.Join(problems, "; ")
+	}
+	return row
+}
+
+func parseGenderCell(raw string) (int8, error) {
+	switch strings.ToLower(raw) {
+	case "", "0", "未知", "unknown", "u":
+		return int8(domainTestee.GenderUnknown), nil
+	case "1", "男", "male", "m":
+		return int8(domainTestee.GenderMale), nil
+	case "2", "女", "female", "f":
+		return int8(domainTestee.GenderFemale), nil
+	default:
+		return 0, fmt.Errorf("性别 %q 无效", raw)
+	}
+}
+
+// parseBirthdayCell 支持常见日期写法与 XLSX 日期序列号（不超过 5 位整数部分）。
+func parseBirthdayCell(raw string) (time.Time, error) {
+	for _, layout := range birthdayLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial >= 1 && serial < 100000 {
+		return excelEpoch.AddDate(0, 0, int(math.Floor(serial))), nil
+	}
+	return time.Time{}, fmt.Errorf("出生日期 %q 无法识别", raw)
+}
+
+func identityKey(row Row) string {
+	birthday := ""
+	if row.Birthday != nil {
+		birthday = row.Birthday.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s|%d|%s", row.Name, row.Gender, birthday)
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
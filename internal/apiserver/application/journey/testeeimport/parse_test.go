@@ -0,0 +1,138 @@
+package testeeimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+var parseNow = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParseRowsCSVNormalizesAndDeduplicates(t *testing.T) {
+	content := "\ufeff姓名,性别,出生日期,档案ID\n" +
+		" 张  三 ,男,2015/03/04,\n" +
+		"李四,F,2016-07-08,9001\n" +
+		",,,\n" +
+		"张三,1,2015-03-04,\n" +
+		"王五,2,2014-01-01,9001\n" +
+		"赵六,x,2030-01-01,abc\n"
+	rows, err := ParseRows(FileFormatCSV, []byte(content), parseNow)
+	if err != nil {
+		t.Fatalf("ParseRows: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("rows = %d, want 5 (blank row skipped)", len(rows))
+	}
+	first := rows[0]
+	if first.RowNo != 2 || first.Name != "张 三" || first.Gender != 1 || first.Status != RowStatusPending {
+		t.Fatalf("first row = %+v", first)
+	}
+	if first.Birthday == nil || first.Birthday.Format("2006-01-02") != "2015-03-04" {
+		t.Fatalf("first birthday = %v", first.Birthday)
+	}
+	if rows[1].ProfileID == nil || *rows[1].ProfileID != 9001 || rows[1].Gender != 2 {
+		t.Fatalf("second row = %+v", rows[1])
+	}
+	if rows[2].RowNo != 5 || rows[2].Status != RowStatusPending {
+		t.Fatalf("whitespace differs so 张三 is not a duplicate of 张 三: %+v", rows[2])
+	}
+	if rows[3].Status != RowStatusDuplicate || !strings.Contains(rows[3].Error, "第 3 行") {
+		t.Fatalf("profile duplicate row = %+v", rows[3])
+	}
+	invalid := rows[4]
+	if invalid.Status != RowStatusInvalid {
+		t.Fatalf("invalid row status = %s", invalid.Status)
+	}
+	for _, want := range []string{"性别", "出生日期", "档案ID"} {
+		if !strings.Contains(invalid.Error, want) {
+			t.Fatalf("invalid row error %q missing %q", invalid.Error, want)
+		}
+	}
+}
+
+func TestParseRowsIdentityDuplicate(t *testing.T) {
+	content := "name,gender,birthday\n李雷,男,2015-03-04\n李雷,1,20150304\n李雷,1,\n"
+	rows, err := ParseRows(FileFormatCSV, []byte(content), parseNow)
+	if err != nil {
+		t.Fatalf("ParseRows: %v", err)
+	}
+	if rows[1].Status != RowStatusDuplicate || rows[2].Status != RowStatusPending {
+		t.Fatalf("statuses = %s,%s", rows[1].Status, rows[2].Status)
+	}
+}
+
+func TestParseRowsRejectsBadFiles(t *testing.T) {
+	cases := map[string]string{
+		"missing name":  "gender,birthday\n1,2015-01-01\n",
+		"header only":   "name\n",
+		"dup column":    "name,姓名\na,b\n",
+		"invalid utf-8": "name\n\xff\xfe\n",
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseRows(FileFormatCSV, []byte(content), parseNow); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestParseRowsXLSX(t *testing.T) {
+	content := buildXLSX(t, []string{"姓名", "性别"},
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>出生日期</t></is></c></row>`+
+			`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>42068</v></c></row>`)
+	rows, err := ParseRows(FileFormatXLSX, content, parseNow)
+	if err != nil {
+		t.Fatalf("ParseRows: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("rows = %d", len(rows))
+	}
+	row := rows[0]
+	if row.Name != "韩梅梅" || row.Gender != 0 || row.Birthday == nil || row.Birthday.Format("2006-01-02") != "2015-03-05" {
+		t.Fatalf("row = %+v birthday=%v", row, row.Birthday)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	if format, err := DetectFormat("roster.XLSX"); err != nil || format != FileFormatXLSX {
+		t.Fatalf("xlsx = %s, %v", format, err)
+	}
+	if _, err := DetectFormat("roster.xls"); err == nil {
+		t.Fatal("expected legacy xls to be rejected")
+	}
+}
+
+// buildXLSX 生成只含一个工作表的最小 XLSX；共享字符串为 header 加上“韩梅梅”。
+func buildXLSX(t *testing.T, header []string, sheetRows string) []byte {
+	t.Helper()
+	var shared strings.Builder
+	for _, value := range append(header, "韩梅梅") {
+		shared.WriteString("<si><t>" + value + "</t></si>")
+	}
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":     `<sst>` + shared.String() + `</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` + sheetRows + `</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, body := range parts {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
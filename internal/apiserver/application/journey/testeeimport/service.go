@@ -0,0 +1,482 @@
+package testeeimport
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
+	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
+	domainPlan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const rowErrorMaxRunes = 500
+
+// Service 受试者批量导入用例。
+type Service interface {
+	// CreateJob 校验并持久化导入文件，返回待后台处理的任务。
+	CreateJob(ctx context.Context, cmd CreateJobCommand) (*JobResult, error)
+	GetJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error)
+	ListJobs(ctx context.Context, orgID int64, page, pageSize int) (*JobListResult, error)
+	ListRows(ctx context.Context, orgID int64, jobID uint64, status string, page, pageSize int) (*RowListResult, error)
+	// ResumeJob 将失败行重新排队；已完成的步骤不会重复执行。
+	ResumeJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error)
+	CancelJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error)
+}
+
+// Processor 后台处理入口，由调度器在 leader 锁内调用。
+type Processor interface {
+	// ProcessOnce 处理最早一个可运行任务的至多 limit 行，返回处理行数。
+	ProcessOnce(ctx context.Context, limit int) (int, error)
+}
+
+// TesteeRegistrar 创建受试者（由 TesteeRegistrationService 实现）。
+type TesteeRegistrar interface {
+	Register(ctx context.Context, dto testeeApp.RegisterTesteeDTO) (*testeeApp.TesteeResult, error)
+}
+
+// RelationAssigner 建立从业者关系（由 ClinicianRelationshipService 实现）。
+type RelationAssigner interface {
+	AssignTestee(ctx context.Context, dto clinicianApp.AssignTesteeDTO) (*clinicianApp.RelationResult, error)
+}
+
+// ClinicianReader 读取从业者（由 ClinicianQueryService 实现）。
+type ClinicianReader interface {
+	GetBasicByID(ctx context.Context, clinicianID uint64) (*clinicianApp.ClinicianResult, error)
+}
+
+// PlanEnroller 受试者入组（由 PlanCommandService 实现，重复入组幂等）。
+type PlanEnroller interface {
+	EnrollTestee(ctx context.Context, dto planApp.EnrollTesteeDTO) (*planApp.EnrollmentResult, error)
+}
+
+// PlanReader 读取计划（由 PlanQueryService 实现）。
+type PlanReader interface {
+	GetPlan(ctx context.Context, orgID int64, planID string) (*planApp.PlanResult, error)
+}
+
+// CreateJobCommand 创建导入任务命令。
+type CreateJobCommand struct {
+	OrgID         int64
+	OperatorID    uint64
+	FileName      string
+	Content       []byte
+	ClinicianID   uint64
+	RelationType  string // 为空时按 attending 建立关系
+	PlanID        string // 可选；填写后所有成功行加入该计划
+	PlanStartDate string // YYYY-MM-DD；PlanID 非空时必填
+}
+
+// JobResult 导入任务视图。
+type JobResult struct {
+	Job    Job
+	Counts RowCounts
+}
+
+// JobListResult 导入任务列表。
+type JobListResult struct {
+	Items    []JobResult
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// RowListResult 导入行列表。
+type RowListResult struct {
+	Items    []Row
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type service struct {
+	store      JobStore
+	matcher    TesteeMatcher
+	testees    TesteeRegistrar
+	relations  RelationAssigner
+	clinicians ClinicianReader
+	plans      PlanReader
+	enroller   PlanEnroller
+	now        func() time.Time
+}
+
+// NewService 创建批量导入服务；返回值同时实现 Service 与 Processor。
+func NewService(
+	store JobStore,
+	matcher TesteeMatcher,
+	testees TesteeRegistrar,
+	relations RelationAssigner,
+	clinicians ClinicianReader,
+	plans PlanReader,
+	enroller PlanEnroller,
+) interface {
+	Service
+	Processor
+} {
+	return &service{
+		store:      store,
+		matcher:    matcher,
+		testees:    testees,
+		relations:  relations,
+		clinicians: clinicians,
+		plans:      plans,
+		enroller:   enroller,
+		now:        time.Now,
+	}
+}
+
+func (s *service) CreateJob(ctx context.Context, cmd CreateJobCommand) (*JobResult, error) {
+	if cmd.OrgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	if len(cmd.Content) == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "导入文件为空")
+	}
+	if len(cmd.Content) > MaxFileBytes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "导入文件超过 %d 字节上限", MaxFileBytes)
+	}
+	format, err := DetectFormat(cmd.FileName)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "%s", err.Error())
+	}
+	relationType := domainRelation.NormalizeAssignableRelationType(domainRelation.RelationType(strings.TrimSpace(cmd.RelationType)))
+	if !domainRelation.IsSupportedAssignmentRelationType(relationType) {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported clinician relation type")
+	}
+	if err := s.ensureClinician(ctx, cmd.OrgID, cmd.ClinicianID); err != nil {
+		return nil, err
+	}
+	planID, startDate, err := s.resolvePlan(ctx, cmd.OrgID, cmd.PlanID, cmd.PlanStartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	rows, err := ParseRows(format, cmd.Content, now)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "%s", err.Error())
+	}
+	job := &Job{
+		ID:            meta.New().Uint64(),
+		OrgID:         cmd.OrgID,
+		FileName:      truncateRunes(cmd.FileName, 255),
+		FileFormat:    format,
+		ClinicianID:   cmd.ClinicianID,
+		RelationType:  string(relationType),
+		PlanID:        planID,
+		PlanStartDate: startDate,
+		Status:        JobStatusPending,
+		TotalRows:     len(rows),
+		CreatedBy:     cmd.OperatorID,
+		CreatedAt:     now,
+	}
+	for i := range rows {
+		rows[i].JobID = job.ID
+	}
+	if err := s.store.Create(ctx, job, rows); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "保存导入任务失败")
+	}
+	logger.L(ctx).Infow("Testee import job created",
+		"action", "create_testee_import",
+		"org_id", cmd.OrgID,
+		"job_id", job.ID,
+		"total_rows", job.TotalRows,
+		"plan_id", planID,
+	)
+	return s.view(ctx, job)
+}
+
+func (s *service) ensureClinician(ctx context.Context, orgID int64, clinicianID uint64) error {
+	if clinicianID == 0 {
+		return errors.WithCode(code.ErrInvalidArgument, "clinician_id is required")
+	}
+	clinician, err := s.clinicians.GetBasicByID(ctx, clinicianID)
+	if err != nil {
+		return err
+	}
+	if clinician == nil || clinician.OrgID != orgID {
+		return errors.WithCode(code.ErrInvalidArgument, "clinician does not belong to the requested organization")
+	}
+	if !clinician.IsActive {
+		return errors.WithCode(code.ErrInvalidArgument, "clinician is inactive")
+	}
+	return nil
+}
+
+func (s *service) resolvePlan(ctx context.Context, orgID int64, rawPlanID, rawStartDate string) (uint64, string, error) {
+	rawPlanID, rawStartDate = strings.TrimSpace(rawPlanID), strings.TrimSpace(rawStartDate)
+	if rawPlanID == "" {
+		if rawStartDate != "" {
+			return 0, "", errors.WithCode(code.ErrInvalidArgument, "plan_start_date requires plan_id")
+		}
+		return 0, "", nil
+	}
+	planID, err := domainPlan.ParseAssessmentPlanID(rawPlanID)
+	if err != nil {
+		return 0, "", errors.WithCode(code.ErrInvalidArgument, "无效的计划ID: %v", err)
+	}
+	if _, err := time.Parse("2006-01-02", rawStartDate); err != nil {
+		return 0, "", errors.WithCode(code.ErrInvalidArgument, "plan_start_date must be YYYY-MM-DD")
+	}
+	plan, err := s.plans.GetPlan(ctx, orgID, rawPlanID)
+	if err != nil {
+		return 0, "", err
+	}
+	if plan == nil || plan.Status != string(domainPlan.PlanStatusActive) {
+		return 0, "", errors.WithCode(code.ErrInvalidArgument, "只能导入到进行中的计划")
+	}
+	return planID.Uint64(), rawStartDate, nil
+}
+
+func (s *service) GetJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error) {
+	job, err := s.loadJob(ctx, orgID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, job)
+}
+
+func (s *service) ListJobs(ctx context.Context, orgID int64, page, pageSize int) (*JobListResult, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	jobs, total, err := s.store.List(ctx, orgID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询导入任务失败")
+	}
+	result := &JobListResult{Items: make([]JobResult, 0, len(jobs)), Total: total, Page: page, PageSize: pageSize}
+	for i := range jobs {
+		view, err := s.view(ctx, &jobs[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *view)
+	}
+	return result, nil
+}
+
+func (s *service) ListRows(ctx context.Context, orgID int64, jobID uint64, status string, page, pageSize int) (*RowListResult, error) {
+	rowStatus := RowStatus(strings.TrimSpace(status))
+	switch rowStatus {
+	case "", RowStatusPending, RowStatusInvalid, RowStatusDuplicate, RowStatusSucceeded, RowStatusFailed:
+	default:
+		return nil, errors.WithCode(code.ErrInvalidArgument, "invalid row status %q", status)
+	}
+	if _, err := s.loadJob(ctx, orgID, jobID); err != nil {
+		return nil, err
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	rows, total, err := s.store.ListRows(ctx, jobID, rowStatus, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询导入行失败")
+	}
+	return &RowListResult{Items: rows, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) ResumeJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error) {
+	job, err := s.loadJob(ctx, orgID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == JobStatusCanceled {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "导入任务已取消")
+	}
+	if _, err := s.store.RequeueFailedRows(ctx, jobID); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "重置失败行失败")
+	}
+	return s.GetJob(ctx, orgID, jobID)
+}
+
+func (s *service) CancelJob(ctx context.Context, orgID int64, jobID uint64) (*JobResult, error) {
+	job, err := s.loadJob(ctx, orgID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == JobStatusCompleted {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "导入任务已完成")
+	}
+	if job.Status != JobStatusCanceled {
+		if err := s.store.MarkCanceled(ctx, jobID, s.now()); err != nil {
+			return nil, errors.WrapC(err, code.ErrDatabase, "取消导入任务失败")
+		}
+	}
+	return s.GetJob(ctx, orgID, jobID)
+}
+
+func (s *service) ProcessOnce(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+	job, err := s.store.NextRunnable(ctx)
+	if err != nil || job == nil {
+		return 0, err
+	}
+	if job.Status == JobStatusPending {
+		if err := s.store.MarkRunning(ctx, job.ID, s.now()); err != nil {
+			return 0, err
+		}
+	}
+	rows, err := s.store.ListPendingRows(ctx, job.ID, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if err := s.store.SaveRow(ctx, s.processRow(ctx, job, row)); err != nil {
+			_ = s.store.RecordError(ctx, job.ID, truncateRunes(err.Error(), rowErrorMaxRunes))
+			return 0, err
+		}
+	}
+	if len(rows) < limit {
+		if err := s.store.MarkCompleted(ctx, job.ID, s.now()); err != nil {
+			return len(rows), err
+		}
+		logger.L(ctx).Infow("Testee import job completed",
+			"action", "process_testee_import",
+			"org_id", job.OrgID,
+			"job_id", job.ID,
+		)
+	}
+	return len(rows), nil
+}
+
+// processRow 依次执行“受试者 → 关系 → 入组”；每一步完成即保存进度，
+// 因此中断后的重跑会跳过已完成的步骤。三个步骤本身也都是幂等的。
+func (s *service) processRow(ctx context.Context, job *Job, row Row) Row {
+	row.Attempts++
+	save := func() error { return s.store.SaveRow(ctx, row) }
+	fail := func(err error) Row {
+		now := s.now()
+		row.Status, row.Error, row.ProcessedAt = RowStatusFailed, truncateRunes(err.Error(), rowErrorMaxRunes), &now
+		return row
+	}
+
+	if row.TesteeID == 0 {
+		testeeID, action, err := s.resolveTestee(ctx, job, row)
+		if err != nil {
+			return fail(err)
+		}
+		row.TesteeID, row.TesteeAction = testeeID, action
+		if err := save(); err != nil {
+			return fail(err)
+		}
+	}
+	if row.RelationID == 0 {
+		relation, err := s.relations.AssignTestee(ctx, clinicianApp.AssignTesteeDTO{
+			OrgID:        job.OrgID,
+			ClinicianID:  job.ClinicianID,
+			TesteeID:     row.TesteeID,
+			RelationType: job.RelationType,
+			SourceType:   string(domainRelation.SourceTypeImport),
+			SourceID:     &job.ID,
+		})
+		if err != nil {
+			return fail(err)
+		}
+		row.RelationID = relation.ID
+		if err := save(); err != nil {
+			return fail(err)
+		}
+	}
+	if job.PlanID != 0 && row.EnrollmentID == 0 {
+		enrollment, err := s.enroller.EnrollTestee(ctx, planApp.EnrollTesteeDTO{
+			OrgID:     job.OrgID,
+			PlanID:    strconv.FormatUint(job.PlanID, 10),
+			TesteeID:  strconv.FormatUint(row.TesteeID, 10),
+			StartDate: job.PlanStartDate,
+		})
+		if err != nil {
+			return fail(err)
+		}
+		enrollmentID, err := strconv.ParseUint(enrollment.EnrollmentID, 10, 64)
+		if err != nil {
+			return fail(errors.WithCode(code.ErrInternalServerError, "invalid enrollment id %q", enrollment.EnrollmentID))
+		}
+		row.EnrollmentID = enrollmentID
+	}
+	now := s.now()
+	row.Status, row.Error, row.ProcessedAt = RowStatusSucceeded, "", &now
+	return row
+}
+
+// resolveTestee 按档案ID或“姓名+性别+出生日期”在机构内去重；多条命中时拒绝猜测。
+func (s *service) resolveTestee(ctx context.Context, job *Job, row Row) (uint64, TesteeAction, error) {
+	var (
+		matches []uint64
+		err     error
+	)
+	if row.ProfileID != nil {
+		matches, err = s.matcher.FindByProfile(ctx, job.OrgID, *row.ProfileID)
+	} else {
+		matches, err = s.matcher.FindByIdentity(ctx, job.OrgID, row.Name, row.Gender, row.Birthday)
+	}
+	if err != nil {
+		return 0, "", errors.WrapC(err, code.ErrDatabase, "查询已有受试者失败")
+	}
+	switch len(matches) {
+	case 0:
+	case 1:
+		return matches[0], TesteeActionMatched, nil
+	default:
+		return 0, "", errors.WithCode(code.ErrInvalidArgument, "机构内有 %d 名同名、同性别、同出生日期的受试者，请补充档案ID后重试", len(matches))
+	}
+	created, err := s.testees.Register(ctx, testeeApp.RegisterTesteeDTO{
+		OrgID:     job.OrgID,
+		ProfileID: row.ProfileID,
+		Name:      row.Name,
+		Gender:    row.Gender,
+		Birthday:  row.Birthday,
+		Source:    string(domainRelation.SourceTypeImport),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return created.ID, TesteeActionCreated, nil
+}
+
+func (s *service) loadJob(ctx context.Context, orgID int64, jobID uint64) (*Job, error) {
+	job, err := s.store.Get(ctx, orgID, jobID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询导入任务失败")
+	}
+	if job == nil {
+		return nil, errors.WithCode(code.ErrPageNotFound, "导入任务不存在")
+	}
+	return job, nil
+}
+
+func (s *service) view(ctx context.Context, job *Job) (*JobResult, error) {
+	counts, err := s.store.CountRows(ctx, job.ID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "统计导入行失败")
+	}
+	return &JobResult{Job: *job, Counts: counts}, nil
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 200 {
+		pageSize = 200
+	}
+	return page, pageSize
+}
+
+func truncateRunes(value string, maxRunes int) string {
+	runes := []rune(value)
+	if len(runes) <= maxRunes {
+		return value
+	}
+	return string(runes[:maxRunes])
+}
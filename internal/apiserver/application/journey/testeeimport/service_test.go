@@ -0,0 +1,408 @@
+package testeeimport
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type memStore struct {
+	jobs      map[uint64]*Job
+	rows      map[uint64][]Row
+	saveCalls int
+	failSave  int // 第 N 次 SaveRow 返回错误（从 1 开始）
+}
+
+func newMemStore() *memStore {
+	return &memStore{jobs: map[uint64]*Job{}, rows: map[uint64][]Row{}}
+}
+
+func (s *memStore) Create(_ context.Context, job *Job, rows []Row) error {
+	copied := *job
+	s.jobs[job.ID] = &copied
+	s.rows[job.ID] = append([]Row(nil), rows...)
+	return nil
+}
+
+func (s *memStore) Get(_ context.Context, orgID int64, jobID uint64) (*Job, error) {
+	job, ok := s.jobs[jobID]
+	if !ok || job.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (s *memStore) List(_ context.Context, orgID int64, offset, limit int) ([]Job, int64, error) {
+	var jobs []Job
+	for _, job := range s.jobs {
+		if job.OrgID == orgID {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	total := int64(len(jobs))
+	if offset >= len(jobs) {
+		return nil, total, nil
+	}
+	jobs = jobs[offset:]
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, total, nil
+}
+
+func (s *memStore) CountRows(_ context.Context, jobID uint64) (RowCounts, error) {
+	var counts RowCounts
+	for _, row := range s.rows[jobID] {
+		switch row.Status {
+		case RowStatusPending:
+			counts.Pending++
+		case RowStatusInvalid:
+			counts.Invalid++
+		case RowStatusDuplicate:
+			counts.Duplicate++
+		case RowStatusFailed:
+			counts.Failed++
+		case RowStatusSucceeded:
+			counts.Succeeded++
+			if row.TesteeAction == TesteeActionCreated {
+				counts.Created++
+			} else {
+				counts.Matched++
+			}
+			if row.EnrollmentID != 0 {
+				counts.Enrolled++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (s *memStore) ListRows(_ context.Context, jobID uint64, status RowStatus, offset, limit int) ([]Row, int64, error) {
+	var rows []Row
+	for _, row := range s.rows[jobID] {
+		if status == "" || row.Status == status {
+			rows = append(rows, row)
+		}
+	}
+	return rows, int64(len(rows)), nil
+}
+
+func (s *memStore) NextRunnable(context.Context) (*Job, error) {
+	for _, job := range s.jobs {
+		if job.Status == JobStatusPending || job.Status == JobStatusRunning {
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) ListPendingRows(_ context.Context, jobID uint64, limit int) ([]Row, error) {
+	var rows []Row
+	for _, row := range s.rows[jobID] {
+		if row.Status == RowStatusPending && len(rows) < limit {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *memStore) SaveRow(_ context.Context, row Row) error {
+	s.saveCalls++
+	if s.failSave == s.saveCalls {
+		return errors.New("connection lost")
+	}
+	for i := range s.rows[row.JobID] {
+		if s.rows[row.JobID][i].RowNo == row.RowNo {
+			s.rows[row.JobID][i] = row
+		}
+	}
+	return nil
+}
+
+func (s *memStore) MarkRunning(_ context.Context, jobID uint64, at time.Time) error {
+	s.jobs[jobID].Status, s.jobs[jobID].StartedAt = JobStatusRunning, &at
+	return nil
+}
+
+func (s *memStore) MarkCompleted(_ context.Context, jobID uint64, at time.Time) error {
+	s.jobs[jobID].Status, s.jobs[jobID].FinishedAt = JobStatusCompleted, &at
+	return nil
+}
+
+func (s *memStore) MarkCanceled(_ context.Context, jobID uint64, at time.Time) error {
+	s.jobs[jobID].Status, s.jobs[jobID].FinishedAt = JobStatusCanceled, &at
+	return nil
+}
+
+func (s *memStore) RecordError(_ context.Context, jobID uint64, message string) error {
+	s.jobs[jobID].LastError = message
+	return nil
+}
+
+func (s *memStore) RequeueFailedRows(_ context.Context, jobID uint64) (int64, error) {
+	var count int64
+	for i := range s.rows[jobID] {
+		if s.rows[jobID][i].Status == RowStatusFailed {
+			s.rows[jobID][i].Status, s.rows[jobID][i].Error = RowStatusPending, ""
+			count++
+		}
+	}
+	if count > 0 {
+		s.jobs[jobID].Status, s.jobs[jobID].FinishedAt = JobStatusPending, nil
+	}
+	return count, nil
+}
+
+type matcherStub struct {
+	byName map[string][]uint64
+}
+
+func (m matcherStub) FindByProfile(context.Context, int64, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (m matcherStub) FindByIdentity(_ context.Context, _ int64, name string, _ int8, _ *time.Time) ([]uint64, error) {
+	return m.byName[name], nil
+}
+
+type actorStub struct {
+	nextTesteeID uint64
+	registered   []testeeApp.RegisterTesteeDTO
+	assigned     []clinicianApp.AssignTesteeDTO
+	failAssign   map[uint64]error
+	clinician    *clinicianApp.ClinicianResult
+}
+
+func (a *actorStub) Register(_ context.Context, dto testeeApp.RegisterTesteeDTO) (*testeeApp.TesteeResult, error) {
+	a.registered = append(a.registered, dto)
+	a.nextTesteeID++
+	return &testeeApp.TesteeResult{ID: a.nextTesteeID, OrgID: dto.OrgID}, nil
+}
+
+func (a *actorStub) AssignTestee(_ context.Context, dto clinicianApp.AssignTesteeDTO) (*clinicianApp.RelationResult, error) {
+	if err := a.failAssign[dto.TesteeID]; err != nil {
+		return nil, err
+	}
+	a.assigned = append(a.assigned, dto)
+	return &clinicianApp.RelationResult{ID: 500 + dto.TesteeID}, nil
+}
+
+func (a *actorStub) GetBasicByID(context.Context, uint64) (*clinicianApp.ClinicianResult, error) {
+	return a.clinician, nil
+}
+
+type planStub struct {
+	status   string
+	enrolled []planApp.EnrollTesteeDTO
+}
+
+func (p *planStub) GetPlan(_ context.Context, _ int64, planID string) (*planApp.PlanResult, error) {
+	return &planApp.PlanResult{ID: planID, Status: p.status}, nil
+}
+
+func (p *planStub) EnrollTestee(_ context.Context, dto planApp.EnrollTesteeDTO) (*planApp.EnrollmentResult, error) {
+	p.enrolled = append(p.enrolled, dto)
+	return &planApp.EnrollmentResult{EnrollmentID: "9" + dto.TesteeID}, nil
+}
+
+type serviceFixture struct {
+	store *memStore
+	actor *actorStub
+	plans *planStub
+	svc   interface {
+		Service
+		Processor
+	}
+}
+
+func newServiceFixture(existing map[string][]uint64) *serviceFixture {
+	f := &serviceFixture{
+		store: newMemStore(),
+		actor: &actorStub{nextTesteeID: 100, clinician: &clinicianApp.ClinicianResult{ID: 7, OrgID: 1, IsActive: true}},
+		plans: &planStub{status: "active"},
+	}
+	f.svc = NewService(f.store, matcherStub{byName: existing}, f.actor, f.actor, f.actor, f.plans, f.plans)
+	return f
+}
+
+const rosterCSV = "name,gender,birthday\n甲,1,2015-01-01\n乙,2,2015-02-02\n甲,1,2015-01-01\n丙,9,\n"
+
+func TestCreateAndProcessJobWithPlanEnrollment(t *testing.T) {
+	f := newServiceFixture(map[string][]uint64{"乙": {42}})
+	ctx := context.Background()
+	created, err := f.svc.CreateJob(ctx, CreateJobCommand{
+		OrgID: 1, OperatorID: 3, FileName: "roster.csv", Content: []byte(rosterCSV),
+		ClinicianID: 7, PlanID: "88", PlanStartDate: "2026-05-01",
+	})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if created.Job.Status != JobStatusPending || created.Job.TotalRows != 4 || created.Job.PlanID != 88 || created.Job.RelationType != "attending" {
+		t.Fatalf("job = %+v", created.Job)
+	}
+	if created.Counts.Pending != 2 || created.Counts.Duplicate != 1 || created.Counts.Invalid != 1 {
+		t.Fatalf("initial counts = %+v", created.Counts)
+	}
+
+	processed, err := f.svc.ProcessOnce(ctx, 10)
+	if err != nil || processed != 2 {
+		t.Fatalf("ProcessOnce = %d, %v", processed, err)
+	}
+	result, err := f.svc.GetJob(ctx, 1, created.Job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if result.Job.Status != JobStatusCompleted {
+		t.Fatalf("status = %s", result.Job.Status)
+	}
+	want := RowCounts{Invalid: 1, Duplicate: 1, Succeeded: 2, Created: 1, Matched: 1, Enrolled: 2}
+	if result.Counts != want {
+		t.Fatalf("counts = %+v, want %+v", result.Counts, want)
+	}
+	if len(f.actor.registered) != 1 || f.actor.registered[0].Source != "import" || f.actor.registered[0].Name != "甲" {
+		t.Fatalf("registered = %+v", f.actor.registered)
+	}
+	if len(f.actor.assigned) != 2 || f.actor.assigned[1].TesteeID != 42 || f.actor.assigned[1].SourceType != "import" || *f.actor.assigned[1].SourceID != created.Job.ID {
+		t.Fatalf("assigned = %+v", f.actor.assigned)
+	}
+	if len(f.plans.enrolled) != 2 || f.plans.enrolled[0].PlanID != "88" || f.plans.enrolled[0].StartDate != "2026-05-01" {
+		t.Fatalf("enrolled = %+v", f.plans.enrolled)
+	}
+}
+
+func TestProcessOnceResumesAfterInterruptionWithoutRepeatingSteps(t *testing.T) {
+	f := newServiceFixture(nil)
+	ctx := context.Background()
+	created, err := f.svc.CreateJob(ctx, CreateJobCommand{OrgID: 1, FileName: "a.csv", Content: []byte("name\n甲\n乙\n"), ClinicianID: 7})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	// 第 1 行的受试者与关系均已保存，写入最终状态时进程“中断”。
+	f.store.failSave = 3
+	if _, err := f.svc.ProcessOnce(ctx, 10); err == nil {
+		t.Fatal("expected interrupted batch to return error")
+	}
+	if f.store.jobs[created.Job.ID].Status != JobStatusRunning || f.store.jobs[created.Job.ID].LastError == "" {
+		t.Fatalf("job after interruption = %+v", f.store.jobs[created.Job.ID])
+	}
+	f.store.failSave = 0
+	if _, err := f.svc.ProcessOnce(ctx, 10); err != nil {
+		t.Fatalf("resume ProcessOnce: %v", err)
+	}
+	if len(f.actor.registered) != 2 || len(f.actor.assigned) != 2 {
+		t.Fatalf("registered=%d assigned=%d, want 2 each (no repeated steps on resume)", len(f.actor.registered), len(f.actor.assigned))
+	}
+	counts, _ := f.store.CountRows(ctx, created.Job.ID)
+	if counts.Succeeded != 2 || counts.Created != 2 || f.store.jobs[created.Job.ID].Status != JobStatusCompleted {
+		t.Fatalf("counts = %+v status=%s", counts, f.store.jobs[created.Job.ID].Status)
+	}
+}
+
+func TestResumeJobRetriesFailedRows(t *testing.T) {
+	f := newServiceFixture(nil)
+	ctx := context.Background()
+	f.actor.failAssign = map[uint64]error{101: errors.New("relation store unavailable")}
+	created, err := f.svc.CreateJob(ctx, CreateJobCommand{OrgID: 1, FileName: "a.csv", Content: []byte("name\n甲\n"), ClinicianID: 7})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if _, err := f.svc.ProcessOnce(ctx, 10); err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	failed := f.store.rows[created.Job.ID][0]
+	if failed.Status != RowStatusFailed || failed.TesteeID != 101 || failed.Attempts != 1 || failed.Error == "" {
+		t.Fatalf("failed row = %+v", failed)
+	}
+
+	f.actor.failAssign = nil
+	resumed, err := f.svc.ResumeJob(ctx, 1, created.Job.ID)
+	if err != nil || resumed.Job.Status != JobStatusPending || resumed.Counts.Pending != 1 {
+		t.Fatalf("ResumeJob = %+v, %v", resumed, err)
+	}
+	if _, err := f.svc.ProcessOnce(ctx, 10); err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	row := f.store.rows[created.Job.ID][0]
+	if row.Status != RowStatusSucceeded || row.Attempts != 2 || row.RelationID != 601 || len(f.actor.registered) != 1 {
+		t.Fatalf("row after resume = %+v registered=%d", row, len(f.actor.registered))
+	}
+}
+
+func TestProcessOnceRejectsAmbiguousIdentityMatch(t *testing.T) {
+	f := newServiceFixture(map[string][]uint64{"甲": {1, 2}})
+	ctx := context.Background()
+	created, err := f.svc.CreateJob(ctx, CreateJobCommand{OrgID: 1, FileName: "a.csv", Content: []byte("name\n甲\n"), ClinicianID: 7})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if _, err := f.svc.ProcessOnce(ctx, 10); err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	if row := f.store.rows[created.Job.ID][0]; row.Status != RowStatusFailed || row.TesteeID != 0 || len(f.actor.registered) != 0 {
+		t.Fatalf("row = %+v", row)
+	}
+}
+
+func TestCreateJobValidation(t *testing.T) {
+	base := CreateJobCommand{OrgID: 1, FileName: "a.csv", Content: []byte("name\n甲\n"), ClinicianID: 7}
+	cases := map[string]func(f *serviceFixture, cmd *CreateJobCommand){
+		"unsupported format": func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.FileName = "a.xls" },
+		"foreign clinician":  func(f *serviceFixture, _ *CreateJobCommand) { f.actor.clinician.OrgID = 2 },
+		"inactive clinician": func(f *serviceFixture, _ *CreateJobCommand) { f.actor.clinician.IsActive = false },
+		"creator relation":   func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.RelationType = "creator" },
+		"start without plan": func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.PlanStartDate = "2026-05-01" },
+		"plan without start": func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.PlanID = "88" },
+		"paused plan": func(f *serviceFixture, cmd *CreateJobCommand) {
+			cmd.PlanID, cmd.PlanStartDate, f.plans.status = "88", "2026-05-01", "paused"
+		},
+		"header without rows":  func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.Content = []byte("name\n") },
+		"oversized upload":     func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.Content = make([]byte, MaxFileBytes+1) },
+		"missing clinician id": func(_ *serviceFixture, cmd *CreateJobCommand) { cmd.ClinicianID = 0 },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := newServiceFixture(nil)
+			cmd := base
+			mutate(f, &cmd)
+			_, err := f.svc.CreateJob(context.Background(), cmd)
+			if !cberrors.IsCode(err, code.ErrInvalidArgument) {
+				t.Fatalf("err = %v, want ErrInvalidArgument", err)
+			}
+			if len(f.store.jobs) != 0 {
+				t.Fatal("job should not be persisted")
+			}
+		})
+	}
+}
+
+func TestCancelJobStopsProcessing(t *testing.T) {
+	f := newServiceFixture(nil)
+	ctx := context.Background()
+	created, err := f.svc.CreateJob(ctx, CreateJobCommand{OrgID: 1, FileName: "a.csv", Content: []byte("name\n甲\n"), ClinicianID: 7})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if _, err := f.svc.CancelJob(ctx, 1, created.Job.ID); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	if processed, err := f.svc.ProcessOnce(ctx, 10); err != nil || processed != 0 {
+		t.Fatalf("ProcessOnce = %d, %v", processed, err)
+	}
+	if _, err := f.svc.ResumeJob(ctx, 1, created.Job.ID); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("ResumeJob err = %v", err)
+	}
+	if _, err := f.svc.GetJob(ctx, 2, created.Job.ID); !cberrors.IsCode(err, code.ErrPageNotFound) {
+		t.Fatalf("cross-org GetJob err = %v", err)
+	}
+}
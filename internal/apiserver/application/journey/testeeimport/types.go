@@ -0,0 +1,59 @@
+// Package testeeimport coordinates bulk onboarding across Actor and Plan:
+// an uploaded CSV/XLSX roster is normalized into import rows, deduplicated
+// against the organization, and then processed asynchronously row by row so
+// that an interrupted job resumes from the first unfinished row.
+package testeeimport
+
+import (
+	"context"
+	"time"
+
+	domainimport "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeeimport"
+)
+
+type (
+	FileFormat   = domainimport.FileFormat
+	JobStatus    = domainimport.JobStatus
+	RowStatus    = domainimport.RowStatus
+	TesteeAction = domainimport.TesteeAction
+	Job          = domainimport.Job
+	Row          = domainimport.Row
+	RowCounts    = domainimport.RowCounts
+)
+
+const (
+	FileFormatCSV  = domainimport.FileFormatCSV
+	FileFormatXLSX = domainimport.FileFormatXLSX
+
+	JobStatusPending   = domainimport.JobStatusPending
+	JobStatusRunning   = domainimport.JobStatusRunning
+	JobStatusCompleted = domainimport.JobStatusCompleted
+	JobStatusCanceled  = domainimport.JobStatusCanceled
+
+	RowStatusPending   = domainimport.RowStatusPending
+	RowStatusInvalid   = domainimport.RowStatusInvalid
+	RowStatusDuplicate = domainimport.RowStatusDuplicate
+	RowStatusSucceeded = domainimport.RowStatusSucceeded
+	RowStatusFailed    = domainimport.RowStatusFailed
+
+	TesteeActionCreated = domainimport.TesteeActionCreated
+	TesteeActionMatched = domainimport.TesteeActionMatched
+)
+
+const (
+	// MaxFileBytes 单个导入文件的大小上限。
+	MaxFileBytes = 5 << 20
+	// MaxRows 单个导入任务的数据行上限（不含表头）。
+	MaxRows = 5000
+)
+
+// JobStore 导入任务持久化端口。
+type JobStore = domainimport.Repository
+
+// TesteeMatcher 在机构内查找可复用的受试者。
+type TesteeMatcher interface {
+	// FindByProfile 返回绑定该档案的受试者ID（最多一个）。
+	FindByProfile(ctx context.Context, orgID int64, profileID uint64) ([]uint64, error)
+	// FindByIdentity 返回姓名、性别与出生日期（可为空）完全一致的受试者ID。
+	FindByIdentity(ctx context.Context, orgID int64, name string, gender int8, birthday *time.Time) ([]uint64, error)
+}
@@ -0,0 +1,373 @@
+package testeemerge
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// Service 受试者判重与合并用例。
+type Service interface {
+	// ListCandidates 分页列出机构内的重复候选。
+	ListCandidates(ctx context.Context, orgID int64, page, pageSize int) (*CandidateList, error)
+	// FindDuplicates 列出与指定受试者疑似重复的候选，并附带阻止合并的冲突。
+	FindDuplicates(ctx context.Context, orgID int64, testeeID uint64) ([]Candidate, error)
+	// Merge 把重复受试者合并到保留受试者，返回可回滚的合并记录。
+	Merge(ctx context.Context, cmd MergeCommand) (*MergeLog, error)
+	// Revert 按合并记录回滚，把迁移过的记录迁回重复受试者并恢复它。
+	Revert(ctx context.Context, cmd RevertCommand) (*MergeLog, error)
+	GetMerge(ctx context.Context, orgID int64, mergeID uint64) (*MergeLog, error)
+	ListMerges(ctx context.Context, orgID int64, testeeID uint64, page, pageSize int) (*MergeLogList, error)
+}
+
+type service struct {
+	store     Store
+	documents DocumentStore
+	cache     TesteeCacheEvictor
+	now       func() time.Time
+}
+
+// NewService 创建受试者合并服务；cache 可为空。
+func NewService(store Store, documents DocumentStore, cache TesteeCacheEvictor) Service {
+	return &service{store: store, documents: documents, cache: cache, now: time.Now}
+}
+
+func (s *service) ListCandidates(ctx context.Context, orgID int64, page, pageSize int) (*CandidateList, error) {
+	if orgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	pairs, total, err := s.store.ListCandidatePairs(ctx, orgID, 0, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询重复候选失败")
+	}
+	items := make([]Candidate, 0, len(pairs))
+	for _, pair := range pairs {
+		if candidate, ok := newCandidate(pair); ok {
+			items = append(items, candidate)
+		}
+	}
+	return &CandidateList{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) FindDuplicates(ctx context.Context, orgID int64, testeeID uint64) ([]Candidate, error) {
+	if _, err := s.activeTestee(ctx, orgID, testeeID); err != nil {
+		return nil, err
+	}
+	pairs, _, err := s.store.ListCandidatePairs(ctx, orgID, testeeID, 0, maxCandidatesPerTestee)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询重复候选失败")
+	}
+	items := make([]Candidate, 0, len(pairs))
+	for _, pair := range pairs {
+		candidate, ok := newCandidate(pair)
+		if !ok {
+			continue
+		}
+		duplicateID := candidate.Testees[0].ID
+		if duplicateID == candidate.SuggestedSurvivorID {
+			duplicateID = candidate.Testees[1].ID
+		}
+		conflicts, err := s.store.FindConflicts(ctx, orgID, candidate.SuggestedSurvivorID, duplicateID)
+		if err != nil {
+			return nil, errors.WrapC(err, code.ErrDatabase, "检查合并冲突失败")
+		}
+		candidate.Conflicts = conflicts
+		items = append(items, candidate)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Score > items[j].Score })
+	return items, nil
+}
+
+func (s *service) Merge(ctx context.Context, cmd MergeCommand) (*MergeLog, error) {
+	reason := strings.TrimSpace(cmd.Reason)
+	if cmd.SurvivorID == 0 || cmd.DuplicateID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "survivor_id and duplicate_id are required")
+	}
+	if cmd.SurvivorID == cmd.DuplicateID {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "survivor_id and duplicate_id must differ")
+	}
+	if err := validateReason(reason); err != nil {
+		return nil, err
+	}
+	survivor, err := s.activeTestee(ctx, cmd.OrgID, cmd.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.activeTestee(ctx, cmd.OrgID, cmd.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.store.FindConflicts(ctx, cmd.OrgID, survivor.ID, duplicate.ID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "检查合并冲突失败")
+	}
+	if len(conflicts) > 0 {
+		return nil, errors.WithCode(code.ErrConflict, "受试者无法合并：%s", describeConflicts(conflicts))
+	}
+
+	log := &MergeLog{
+		ID:           meta.New().Uint64(),
+		OrgID:        cmd.OrgID,
+		SurvivorID:   survivor.ID,
+		DuplicateID:  duplicate.ID,
+		Status:       MergeStatusMerged,
+		MatchReasons: classify(*survivor, *duplicate),
+		Reason:       reason,
+		MergedBy:     cmd.OperatorID,
+		MergedAt:     s.now(),
+	}
+	if duplicate.ProfileID != nil && survivor.ProfileID == nil {
+		profileID := *duplicate.ProfileID
+		log.TransferredProfile = &profileID
+	}
+
+	// Mongo 文档先迁移并记录；MySQL 事务失败时按记录迁回，避免两侧指向不同受试者。
+	documents, err := s.documents.Repoint(ctx, duplicate.ID, survivor.ID)
+	if err != nil {
+		return nil, s.compensate(ctx, documents, survivor.ID, duplicate.ID, errors.WrapC(err, code.ErrDatabase, "迁移答卷与报告失败"))
+	}
+	log.Moved = append(log.Moved, documents...)
+	if err := s.store.ApplyMerge(ctx, log); err != nil {
+		if !errors.IsCode(err, code.ErrConflict) {
+			err = errors.WrapC(err, code.ErrDatabase, "合并受试者失败")
+		}
+		return nil, s.compensate(ctx, documents, survivor.ID, duplicate.ID, err)
+	}
+	s.evict(ctx, survivor.ID, duplicate.ID)
+	logger.L(ctx).Infow("Testees merged",
+		"action", "merge_testee",
+		"org_id", cmd.OrgID,
+		"merge_id", log.ID,
+		"survivor_id", survivor.ID,
+		"duplicate_id", duplicate.ID,
+		"moved", movedSummary(log.Moved),
+	)
+	return log, nil
+}
+
+func (s *service) Revert(ctx context.Context, cmd RevertCommand) (*MergeLog, error) {
+	reason := strings.TrimSpace(cmd.Reason)
+	if err := validateReason(reason); err != nil {
+		return nil, err
+	}
+	log, err := s.GetMerge(ctx, cmd.OrgID, cmd.MergeID)
+	if err != nil {
+		return nil, err
+	}
+	if log.Status != MergeStatusMerged {
+		return nil, errors.WithCode(code.ErrConflict, "合并记录已回滚")
+	}
+	now := s.now()
+	log.Status = MergeStatusReverted
+	log.RevertedBy = cmd.OperatorID
+	log.RevertedAt = &now
+	log.RevertReason = reason
+
+	documents := documentRecords(log.Moved)
+	if err := s.documents.Move(ctx, documents, log.SurvivorID, log.DuplicateID); err != nil {
+		return nil, s.compensate(ctx, documents, log.DuplicateID, log.SurvivorID, errors.WrapC(err, code.ErrDatabase, "迁回答卷与报告失败"))
+	}
+	if err := s.store.ApplyRevert(ctx, log); err != nil {
+		if !errors.IsCode(err, code.ErrConflict) {
+			err = errors.WrapC(err, code.ErrDatabase, "回滚受试者合并失败")
+		}
+		return nil, s.compensate(ctx, documents, log.DuplicateID, log.SurvivorID, err)
+	}
+	s.evict(ctx, log.SurvivorID, log.DuplicateID)
+	logger.L(ctx).Infow("Testee merge reverted",
+		"action", "revert_testee_merge",
+		"org_id", cmd.OrgID,
+		"merge_id", log.ID,
+		"survivor_id", log.SurvivorID,
+		"duplicate_id", log.DuplicateID,
+	)
+	return log, nil
+}
+
+func (s *service) GetMerge(ctx context.Context, orgID int64, mergeID uint64) (*MergeLog, error) {
+	if orgID <= 0 || mergeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id and merge_id are required")
+	}
+	log, err := s.store.GetLog(ctx, orgID, mergeID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询合并记录失败")
+	}
+	if log == nil {
+		return nil, errors.WithCode(code.ErrPageNotFound, "merge log not found")
+	}
+	return log, nil
+}
+
+func (s *service) ListMerges(ctx context.Context, orgID int64, testeeID uint64, page, pageSize int) (*MergeLogList, error) {
+	if orgID <= 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id must be positive")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	items, total, err := s.store.ListLogs(ctx, orgID, testeeID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询合并记录失败")
+	}
+	return &MergeLogList{Items: items, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *service) activeTestee(ctx context.Context, orgID int64, testeeID uint64) (*TesteeSnapshot, error) {
+	if orgID <= 0 || testeeID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "org_id and testee_id are required")
+	}
+	snapshot, err := s.store.GetTestee(ctx, orgID, testeeID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "查询受试者失败")
+	}
+	if snapshot == nil || snapshot.Deleted {
+		return nil, errors.WithCode(code.ErrUserNotFound, "testee %d not found", testeeID)
+	}
+	return snapshot, nil
+}
+
+// compensate 把已迁移的文档从 from 迁回 to；补偿失败时与原错误一起返回，便于人工处理。
+func (s *service) compensate(ctx context.Context, documents []MovedRecords, from, to uint64, cause error) error {
+	if len(documents) == 0 {
+		return cause
+	}
+	if err := s.documents.Move(ctx, documents, from, to); err != nil {
+		logger.L(ctx).Errorw("Testee merge document compensation failed",
+			"action", "merge_testee_compensate",
+			"from", from,
+			"to", to,
+			"error", err.Error(),
+		)
+		return stderrors.Join(cause, fmt.Errorf("compensate moved documents: %w", err))
+	}
+	return cause
+}
+
+func (s *service) evict(ctx context.Context, ids ...uint64) {
+	if s.cache == nil {
+		return
+	}
+	for _, id := range ids {
+		_ = s.cache.Evict(ctx, testee.NewID(id))
+	}
+}
+
+// newCandidate 判定一对受试者的命中依据；召回条件宽于判定条件，未命中时返回 false。
+func newCandidate(pair CandidatePair) (Candidate, bool) {
+	reasons := classify(pair.A, pair.B)
+	if len(reasons) == 0 {
+		return Candidate{}, false
+	}
+	score := 0
+	for _, reason := range reasons {
+		if matchScores[reason] > score {
+			score = matchScores[reason]
+		}
+	}
+	return Candidate{
+		Testees:             [2]TesteeSnapshot{pair.A, pair.B},
+		Reasons:             reasons,
+		Score:               score,
+		SuggestedSurvivorID: suggestSurvivor(pair.A, pair.B),
+	}, true
+}
+
+func classify(a, b TesteeSnapshot) []MatchReason {
+	var reasons []MatchReason
+	if a.ProfileID != nil && b.ProfileID != nil && *a.ProfileID == *b.ProfileID {
+		reasons = append(reasons, MatchReasonProfile)
+	}
+	if strings.TrimSpace(a.Name) == "" || strings.TrimSpace(a.Name) != strings.TrimSpace(b.Name) {
+		return reasons
+	}
+	sameBirthday := a.Birthday != nil && b.Birthday != nil && sameDate(*a.Birthday, *b.Birthday)
+	missingBirthday := a.Birthday == nil || b.Birthday == nil
+	knownGenders := a.Gender != int8(testee.GenderUnknown) && b.Gender != int8(testee.GenderUnknown)
+	switch {
+	case sameBirthday && knownGenders && a.Gender == b.Gender:
+		reasons = append(reasons, MatchReasonIdentity)
+	case sameBirthday && !knownGenders:
+		reasons = append(reasons, MatchReasonNameBirthday)
+	case missingBirthday && knownGenders && a.Gender == b.Gender:
+		reasons = append(reasons, MatchReasonNameGender)
+	}
+	return reasons
+}
+
+// suggestSurvivor 优先保留绑定用户档案的受试者，其次保留更早建档的受试者。
+func suggestSurvivor(a, b TesteeSnapshot) uint64 {
+	if (a.ProfileID != nil) != (b.ProfileID != nil) {
+		if a.ProfileID != nil {
+			return a.ID
+		}
+		return b.ID
+	}
+	if b.CreatedAt.Before(a.CreatedAt) {
+		return b.ID
+	}
+	return a.ID
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
+
+func validateReason(reason string) error {
+	if reason == "" {
+		return errors.WithCode(code.ErrInvalidArgument, "reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxReasonRunes {
+		return errors.WithCode(code.ErrInvalidArgument, "reason exceeds %d characters", maxReasonRunes)
+	}
+	return nil
+}
+
+func describeConflicts(conflicts []Conflict) string {
+	parts := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		parts = append(parts, conflict.Detail)
+	}
+	return strings.Join(parts, "；")
+}
+
+func documentRecords(records []MovedRecords) []MovedRecords {
+	var out []MovedRecords
+	for _, record := range records {
+		if record.Store == RecordStoreMongo {
+			out = append(out, record)
+		}
+	}
+	return out
+}
+
+func movedSummary(records []MovedRecords) map[string]int {
+	summary := make(map[string]int, len(records))
+	for _, record := range records {
+		summary[record.Table] += len(record.IDs)
+	}
+	return summary
+}
+
+const maxCandidatesPerTestee = 20
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
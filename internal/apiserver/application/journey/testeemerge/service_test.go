@@ -0,0 +1,278 @@
+package testeemerge
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type fakeStore struct {
+	testees     map[uint64]*TesteeSnapshot
+	pairs       []CandidatePair
+	conflicts   []Conflict
+	logs        map[uint64]*MergeLog
+	mergeErr    error
+	mysqlMoved  []MovedRecords
+	applyMerges int
+}
+
+func (f *fakeStore) GetTestee(_ context.Context, orgID int64, id uint64) (*TesteeSnapshot, error) {
+	snapshot, ok := f.testees[id]
+	if !ok || snapshot.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *snapshot
+	return &copied, nil
+}
+
+func (f *fakeStore) ListCandidatePairs(_ context.Context, _ int64, testeeID uint64, _, _ int) ([]CandidatePair, int64, error) {
+	var out []CandidatePair
+	for _, pair := range f.pairs {
+		if testeeID == 0 || pair.A.ID == testeeID || pair.B.ID == testeeID {
+			out = append(out, pair)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (f *fakeStore) FindConflicts(context.Context, int64, uint64, uint64) ([]Conflict, error) {
+	return f.conflicts, nil
+}
+
+func (f *fakeStore) ApplyMerge(_ context.Context, log *MergeLog) error {
+	f.applyMerges++
+	if f.mergeErr != nil {
+		return f.mergeErr
+	}
+	log.Moved = append(log.Moved, f.mysqlMoved...)
+	f.testees[log.DuplicateID].Deleted = true
+	if log.TransferredProfile != nil {
+		f.testees[log.SurvivorID].ProfileID = log.TransferredProfile
+		f.testees[log.DuplicateID].ProfileID = nil
+	}
+	copied := *log
+	f.logs[log.ID] = &copied
+	return nil
+}
+
+func (f *fakeStore) ApplyRevert(_ context.Context, log *MergeLog) error {
+	f.testees[log.DuplicateID].Deleted = false
+	copied := *log
+	f.logs[log.ID] = &copied
+	return nil
+}
+
+func (f *fakeStore) GetLog(_ context.Context, orgID int64, id uint64) (*MergeLog, error) {
+	log, ok := f.logs[id]
+	if !ok || log.OrgID != orgID {
+		return nil, nil
+	}
+	copied := *log
+	return &copied, nil
+}
+
+func (f *fakeStore) ListLogs(context.Context, int64, uint64, int, int) ([]MergeLog, int64, error) {
+	return nil, 0, nil
+}
+
+type move struct {
+	from, to uint64
+	records  []MovedRecords
+}
+
+type fakeDocuments struct {
+	repointed []MovedRecords
+	moves     []move
+}
+
+func (f *fakeDocuments) Repoint(context.Context, uint64, uint64) ([]MovedRecords, error) {
+	return f.repointed, nil
+}
+
+func (f *fakeDocuments) Move(_ context.Context, records []MovedRecords, from, to uint64) error {
+	f.moves = append(f.moves, move{from: from, to: to, records: records})
+	return nil
+}
+
+type fakeEvictor struct{ ids []uint64 }
+
+func (f *fakeEvictor) Evict(_ context.Context, id testee.ID) error {
+	f.ids = append(f.ids, id.Uint64())
+	return nil
+}
+
+func date(value string) *time.Time {
+	parsed, _ := time.Parse("2006-01-02", value)
+	return &parsed
+}
+
+func newMergeFixture() (*service, *fakeStore, *fakeDocuments, *fakeEvictor) {
+	profile := uint64(900)
+	store := &fakeStore{
+		testees: map[uint64]*TesteeSnapshot{
+			1: {ID: 1, OrgID: 7, Name: "张三", Gender: 1, Birthday: date("2015-03-04"), CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			2: {ID: 2, OrgID: 7, Name: "张三", Gender: 1, Birthday: date("2015-03-04"), ProfileID: &profile, CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		logs:       map[uint64]*MergeLog{},
+		mysqlMoved: []MovedRecords{{Store: RecordStoreMySQL, Table: "assessment", IDs: []string{"11", "12"}}},
+	}
+	documents := &fakeDocuments{repointed: []MovedRecords{{Store: RecordStoreMongo, Table: "answersheets", IDs: []string{"a1"}}}}
+	evictor := &fakeEvictor{}
+	svc := NewService(store, documents, evictor).(*service)
+	svc.now = func() time.Time { return time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC) }
+	return svc, store, documents, evictor
+}
+
+func TestClassify(t *testing.T) {
+	profile := uint64(5)
+	base := TesteeSnapshot{Name: "李四", Gender: 2, Birthday: date("2016-01-02")}
+	cases := map[string]struct {
+		mutate func(b *TesteeSnapshot)
+		want   []MatchReason
+	}{
+		"identity":            {func(*TesteeSnapshot) {}, []MatchReason{MatchReasonIdentity}},
+		"unknown gender":      {func(b *TesteeSnapshot) { b.Gender = 0 }, []MatchReason{MatchReasonNameBirthday}},
+		"missing birthday":    {func(b *TesteeSnapshot) { b.Birthday = nil }, []MatchReason{MatchReasonNameGender}},
+		"different gender":    {func(b *TesteeSnapshot) { b.Gender = 1 }, nil},
+		"different birthday":  {func(b *TesteeSnapshot) { b.Birthday = date("2016-01-03") }, nil},
+		"different name":      {func(b *TesteeSnapshot) { b.Name = "李四四" }, nil},
+		"weak on both fields": {func(b *TesteeSnapshot) { b.Gender, b.Birthday = 0, nil }, nil},
+		"shared profile":      {func(b *TesteeSnapshot) { b.Name = "小李"; b.ProfileID = &profile }, []MatchReason{MatchReasonProfile}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := base
+			if name == "shared profile" {
+				a.ProfileID = &profile
+			}
+			b := base
+			tc.mutate(&b)
+			got := classify(a, b)
+			if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) {
+				t.Fatalf("classify = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicatesSuggestsProfileBoundSurvivorAndAttachesConflicts(t *testing.T) {
+	svc, store, _, _ := newMergeFixture()
+	store.pairs = []CandidatePair{{A: *store.testees[1], B: *store.testees[2]}}
+	store.conflicts = []Conflict{{Kind: ConflictSharedPlan, Detail: "两者均参与计划 88"}}
+
+	items, err := svc.FindDuplicates(context.Background(), 7, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Score != 90 || items[0].SuggestedSurvivorID != 2 {
+		t.Fatalf("items = %+v", items)
+	}
+	if len(items[0].Conflicts) != 1 {
+		t.Fatalf("conflicts = %+v", items[0].Conflicts)
+	}
+}
+
+func TestMergeMovesRecordsTransfersProfileAndEvictsCache(t *testing.T) {
+	svc, store, _, evictor := newMergeFixture()
+
+	log, err := svc.Merge(context.Background(), MergeCommand{OrgID: 7, SurvivorID: 1, DuplicateID: 2, OperatorID: 3, Reason: "入口与人工重复建档"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Status != MergeStatusMerged || log.MergedBy != 3 || len(log.MatchReasons) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	if log.TransferredProfile == nil || *log.TransferredProfile != 900 {
+		t.Fatalf("transferred profile = %v", log.TransferredProfile)
+	}
+	if len(log.Moved) != 2 || log.Moved[0].Store != RecordStoreMongo || log.Moved[1].Table != "assessment" {
+		t.Fatalf("moved = %+v", log.Moved)
+	}
+	if !store.testees[2].Deleted {
+		t.Fatal("duplicate should be soft-deleted")
+	}
+	if len(evictor.ids) != 2 {
+		t.Fatalf("evicted = %v", evictor.ids)
+	}
+
+	if _, err := svc.Merge(context.Background(), MergeCommand{OrgID: 7, SurvivorID: 1, DuplicateID: 2, Reason: "again"}); !cberrors.IsCode(err, code.ErrUserNotFound) {
+		t.Fatalf("merging a merged testee: err = %v", err)
+	}
+}
+
+func TestMergeBlockedByConflicts(t *testing.T) {
+	svc, store, documents, _ := newMergeFixture()
+	store.conflicts = []Conflict{{Kind: ConflictPrimaryClinician, Detail: "主责从业者不同"}}
+
+	_, err := svc.Merge(context.Background(), MergeCommand{OrgID: 7, SurvivorID: 1, DuplicateID: 2, Reason: "dup"})
+	if !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+	if store.applyMerges != 0 || len(documents.moves) != 0 {
+		t.Fatal("blocked merge must not touch any record")
+	}
+}
+
+func TestMergeCompensatesDocumentsWhenMySQLFails(t *testing.T) {
+	svc, store, documents, evictor := newMergeFixture()
+	store.mergeErr = stderrors.New("deadlock")
+
+	if _, err := svc.Merge(context.Background(), MergeCommand{OrgID: 7, SurvivorID: 1, DuplicateID: 2, Reason: "dup"}); !cberrors.IsCode(err, code.ErrDatabase) {
+		t.Fatalf("err = %v, want database error", err)
+	}
+	if len(documents.moves) != 1 || documents.moves[0].from != 1 || documents.moves[0].to != 2 {
+		t.Fatalf("compensation moves = %+v", documents.moves)
+	}
+	if len(evictor.ids) != 0 {
+		t.Fatal("cache should not be evicted for a failed merge")
+	}
+}
+
+func TestRevertMovesDocumentsBackOnce(t *testing.T) {
+	svc, store, documents, _ := newMergeFixture()
+	log, err := svc.Merge(context.Background(), MergeCommand{OrgID: 7, SurvivorID: 1, DuplicateID: 2, Reason: "dup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reverted, err := svc.Revert(context.Background(), RevertCommand{OrgID: 7, MergeID: log.ID, OperatorID: 4, Reason: "合并错误"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Status != MergeStatusReverted || reverted.RevertedBy != 4 || reverted.RevertedAt == nil {
+		t.Fatalf("reverted = %+v", reverted)
+	}
+	if len(documents.moves) != 1 || documents.moves[0].from != 1 || documents.moves[0].to != 2 || len(documents.moves[0].records) != 1 {
+		t.Fatalf("revert moves = %+v", documents.moves)
+	}
+	if store.testees[2].Deleted {
+		t.Fatal("duplicate should be restored")
+	}
+	if _, err := svc.Revert(context.Background(), RevertCommand{OrgID: 7, MergeID: log.ID, Reason: "again"}); !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("second revert err = %v", err)
+	}
+}
+
+func TestMergeValidation(t *testing.T) {
+	svc, _, _, _ := newMergeFixture()
+	cases := map[string]MergeCommand{
+		"same testee":     {OrgID: 7, SurvivorID: 1, DuplicateID: 1, Reason: "x"},
+		"missing reason":  {OrgID: 7, SurvivorID: 1, DuplicateID: 2},
+		"missing testee":  {OrgID: 7, SurvivorID: 1, Reason: "x"},
+		"other org":       {OrgID: 8, SurvivorID: 1, DuplicateID: 2, Reason: "x"},
+		"unknown testee":  {OrgID: 7, SurvivorID: 1, DuplicateID: 99, Reason: "x"},
+		"reason too long": {OrgID: 7, SurvivorID: 1, DuplicateID: 2, Reason: string(make([]rune, 501))},
+	}
+	for name, cmd := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := svc.Merge(context.Background(), cmd); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
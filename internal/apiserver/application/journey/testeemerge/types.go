@@ -0,0 +1,110 @@
+// Package testeemerge detects probable duplicate testees inside an
+// organization and merges a duplicate into a surviving testee. A merge moves
+// every record that references the duplicate (answer sheets, assessments,
+// outcomes, reports, plan enrollments/tasks, clinician relations and
+// statistics facts) and logs exactly which records moved, so that the merge
+// can later be reverted record by record.
+package testeemerge
+
+import (
+	"context"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+	domainmerge "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeemerge"
+)
+
+type (
+	MatchReason    = domainmerge.MatchReason
+	ConflictKind   = domainmerge.ConflictKind
+	MergeStatus    = domainmerge.MergeStatus
+	TesteeSnapshot = domainmerge.TesteeSnapshot
+	CandidatePair  = domainmerge.CandidatePair
+	Conflict       = domainmerge.Conflict
+	MovedRecords   = domainmerge.MovedRecords
+	MergeLog       = domainmerge.MergeLog
+)
+
+const (
+	MatchReasonProfile      = domainmerge.MatchReasonProfile
+	MatchReasonIdentity     = domainmerge.MatchReasonIdentity
+	MatchReasonNameBirthday = domainmerge.MatchReasonNameBirthday
+	MatchReasonNameGender   = domainmerge.MatchReasonNameGender
+
+	ConflictSharedPlan       = domainmerge.ConflictSharedPlan
+	ConflictPrimaryClinician = domainmerge.ConflictPrimaryClinician
+	ConflictProfile          = domainmerge.ConflictProfile
+
+	MergeStatusMerged   = domainmerge.MergeStatusMerged
+	MergeStatusReverted = domainmerge.MergeStatusReverted
+
+	RecordStoreMySQL = domainmerge.RecordStoreMySQL
+	RecordStoreMongo = domainmerge.RecordStoreMongo
+)
+
+// matchScores 命中依据的置信分，候选按最高分排序展示。
+var matchScores = map[MatchReason]int{
+	MatchReasonProfile:      100,
+	MatchReasonIdentity:     90,
+	MatchReasonNameBirthday: 70,
+	MatchReasonNameGender:   50,
+}
+
+const maxReasonRunes = 500
+
+// Candidate 重复候选。
+type Candidate struct {
+	Testees             [2]TesteeSnapshot
+	Reasons             []MatchReason
+	Score               int
+	SuggestedSurvivorID uint64
+	Conflicts           []Conflict // 仅在按受试者查询时计算
+}
+
+// MergeLogList 合并记录分页。
+type MergeLogList struct {
+	Items    []MergeLog
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// CandidateList 重复候选分页。
+type CandidateList struct {
+	Items    []Candidate
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// MergeCommand 合并命令。
+type MergeCommand struct {
+	OrgID       int64
+	SurvivorID  uint64
+	DuplicateID uint64
+	OperatorID  uint64
+	Reason      string
+}
+
+// RevertCommand 回滚命令。
+type RevertCommand struct {
+	OrgID      int64
+	MergeID    uint64
+	OperatorID uint64
+	Reason     string
+}
+
+// Store 受试者合并的 MySQL 持久化端口。
+type Store = domainmerge.Repository
+
+// DocumentStore 受试者合并的 Mongo 文档迁移（答卷、报告与报告目录）。
+type DocumentStore interface {
+	// Repoint 把引用 from 的文档改为引用 to，返回被迁移的文档。
+	Repoint(ctx context.Context, from, to uint64) ([]MovedRecords, error)
+	// Move 只迁移 records 中仍引用 from 的文档，用于回滚与补偿。
+	Move(ctx context.Context, records []MovedRecords, from, to uint64) error
+}
+
+// TesteeCacheEvictor 失效受试者缓存（由带缓存的受试者仓储实现）。
+type TesteeCacheEvictor interface {
+	Evict(ctx context.Context, id testee.ID) error
+}
@@ -41,6 +41,7 @@ func ComposePublishValidation(
 	issues := model.ValidateForPublish().Issues
 	issues = append(issues, ValidateDefinitionForPublish(ctx, model, opts.NormRepo)...)
 	issues = append(issues, ValidateReportTemplateRoutes(model, opts.PublishedTemplates)...)
+	issues = append(issues, ValidateTranslationCoverage(model)...)
 	issues = append(issues, ValidateDerivedConclusionNormRefs(model)...)
 	if opts.IncludeBehavioralSemantic {
 		issues = append(issues, ValidateBehavioralSemantic(model)...)
@@ -0,0 +1,38 @@
+package definition
+
+import (
+	"fmt"
+	"sort"
+
+	domain "github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	modeldefinition "github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/definition"
+)
+
+// ValidateTranslationCoverage 以 warning 报告各语言尚未翻译、会回落到基础语言的报告文案 key。
+// 缺译不阻断发布：报告仍可按该语言生成，未翻译字段保持基础文案。
+func ValidateTranslationCoverage(model *domain.AssessmentModel) []domain.DomainValidationIssue {
+	if model == nil || model.DefinitionV2 == nil {
+		return nil
+	}
+	missing := modeldefinition.UntranslatedKeys(*model.DefinitionV2)
+	if len(missing) == 0 {
+		return nil
+	}
+	locales := make([]string, 0, len(missing))
+	for tag := range missing {
+		locales = append(locales, tag)
+	}
+	sort.Strings(locales)
+	issues := make([]domain.DomainValidationIssue, 0)
+	for _, tag := range locales {
+		for _, key := range missing[tag] {
+			issues = append(issues, domain.DomainValidationIssue{
+				Field:   "translations." + tag + "." + key,
+				Code:    "translation.untranslated",
+				Message: fmt.Sprintf("%s 缺少 %s 的译文，报告将使用基础语言文案", tag, key),
+				Level:   domain.ValidationLevelWarning,
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,35 @@
+package definition
+
+import (
+	"testing"
+
+	domain "github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/conclusion"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+)
+
+func TestValidateTranslationCoverageWarnsPerUntranslatedKey(t *testing.T) {
+	model := &domain.AssessmentModel{Kind: domain.KindScale, DefinitionV2: &domain.Definition{
+		Outcomes: []conclusion.Outcome{{Code: "low", Title: "低", Summary: "状态良好"}},
+		Translations: []interpretationassets.LocalizedAssets{{
+			Locale: "en-US", ModelTitle: "Anxiety",
+			Outcomes: []interpretationassets.OutcomePresentation{{OutcomeCode: "low", Title: "Low"}},
+		}},
+	}}
+	issues := ValidateTranslationCoverage(model)
+	if len(issues) != 1 {
+		t.Fatalf("issues = %#v", issues)
+	}
+	issue := issues[0]
+	if issue.Code != "translation.untranslated" || issue.Field != "translations.en-US.outcomes.low.summary" || issue.Level != domain.ValidationLevelWarning {
+		t.Fatalf("issue = %#v", issue)
+	}
+	if domain.HasValidationErrors(issues) {
+		t.Fatalf("untranslated keys must not block publishing")
+	}
+
+	model.DefinitionV2.Translations = nil
+	if issues := ValidateTranslationCoverage(model); len(issues) != 0 {
+		t.Fatalf("single-language model issues = %#v", issues)
+	}
+}
@@ -0,0 +1,155 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	domainPlan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/planreadmodel"
+	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	calendarProductID      = "-//FangcunMount//qs-server plan calendar//ZH"
+	calendarUTCLayout      = "20060102T150405Z"
+	calendarEventDuration  = time.Hour
+	calendarLineOctetLimit = 75
+)
+
+// TesteeCalendarResult 受试者待办任务日历导出结果（iCalendar / RFC 5545）
+type TesteeCalendarResult struct {
+	FileName   string // 建议的下载文件名
+	Content    []byte // text/calendar 内容
+	EventCount int    // 导出的事件数
+}
+
+// ExportTesteeCalendar 导出受试者尚未完成的计划任务为 ICS 日历。
+// 只包含 pending/opened 且截止时间晚于当前时间的任务；时间以 UTC 输出，由日历客户端换算本地时区。
+func (s *queryService) ExportTesteeCalendar(ctx context.Context, testeeID string) (*TesteeCalendarResult, error) {
+	testeeIDDomain, err := toTesteeID(testeeID)
+	if err != nil {
+		return nil, errors.WithCode(errorCode.ErrInvalidArgument, "无效的受试者ID: %v", err)
+	}
+	if s.taskReader == nil {
+		return nil, errors.WithCode(errorCode.ErrModuleInitializationFailed, "task read model is not configured")
+	}
+	rows, err := s.taskReader.ListTasksByTesteeID(ctx, testeeIDDomain.Uint64())
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "查询任务失败")
+	}
+
+	now := time.Now()
+	upcoming := selectUpcomingTaskRows(rows, now)
+	scaleTitles := s.resolveScaleTitles(ctx, collectTaskScaleCodesFromRows(upcoming))
+	content := buildTaskCalendar(upcoming, scaleTitles, now)
+	return &TesteeCalendarResult{
+		FileName:   fmt.Sprintf("testee_%s_tasks.ics", testeeIDDomain.String()),
+		Content:    content,
+		EventCount: len(upcoming),
+	}, nil
+}
+
+func selectUpcomingTaskRows(rows []planreadmodel.TaskRow, now time.Time) []planreadmodel.TaskRow {
+	upcoming := make([]planreadmodel.TaskRow, 0, len(rows))
+	for _, row := range rows {
+		status := domainPlan.TaskStatus(row.Status)
+		if status != domainPlan.TaskStatusPending && status != domainPlan.TaskStatusOpened {
+			continue
+		}
+		if !taskRowDueAt(row).After(now) {
+			continue
+		}
+		upcoming = append(upcoming, row)
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].PlannedAt.Before(upcoming[j].PlannedAt)
+	})
+	return upcoming
+}
+
+func taskRowDueAt(row planreadmodel.TaskRow) time.Time {
+	if row.DueAt != nil && !row.DueAt.IsZero() {
+		return *row.DueAt
+	}
+	return domainPlan.TaskDueAtIn(row.PlannedAt, domainPlan.LoadPlanLocation(row.TimeZone))
+}
+
+// buildTaskCalendar 将任务行渲染为 VCALENDAR；UID 只取任务ID，重新导入时客户端按 UID 更新已有事件。
+func buildTaskCalendar(rows []planreadmodel.TaskRow, scaleTitles map[string]string, now time.Time) []byte {
+	var b strings.Builder
+	writeCalendarLine(&b, "BEGIN:VCALENDAR")
+	writeCalendarLine(&b, "VERSION:2.0")
+	writeCalendarLine(&b, "PRODID:"+calendarProductID)
+	writeCalendarLine(&b, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&b, "METHOD:PUBLISH")
+	writeCalendarLine(&b, "X-WR-CALNAME:"+escapeCalendarText("测评计划任务"))
+
+	stamp := now.UTC().Format(calendarUTCLayout)
+	for _, row := range rows {
+		title := scaleTitles[row.ScaleCode]
+		if title == "" {
+			title = row.ScaleCode
+		}
+		format := taskTimeFormatter(row.TimeZone)
+		dueAt := taskRowDueAt(row)
+		description := fmt.Sprintf("第 %d 次测评，请在 %s 前完成。", row.Seq, format(dueAt))
+		if row.TimeZone != "" {
+			description += fmt.Sprintf("（时区：%s）", row.TimeZone)
+		}
+
+		writeCalendarLine(&b, "BEGIN:VEVENT")
+		writeCalendarLine(&b, fmt.Sprintf("UID:task-%s@qs-server", meta.FromUint64(row.ID).String()))
+		writeCalendarLine(&b, "DTSTAMP:"+stamp)
+		writeCalendarLine(&b, "DTSTART:"+row.PlannedAt.UTC().Format(calendarUTCLayout))
+		writeCalendarLine(&b, "DTEND:"+row.PlannedAt.Add(calendarEventDuration).UTC().Format(calendarUTCLayout))
+		writeCalendarLine(&b, "SUMMARY:"+escapeCalendarText(fmt.Sprintf("%s（第 %d 次）", title, row.Seq)))
+		writeCalendarLine(&b, "DESCRIPTION:"+escapeCalendarText(description))
+		if row.EntryURL != "" {
+			writeCalendarLine(&b, "URL:"+row.EntryURL)
+		}
+		writeCalendarLine(&b, "STATUS:CONFIRMED")
+		writeCalendarLine(&b, "TRANSP:TRANSPARENT")
+		writeCalendarLine(&b, "END:VEVENT")
+	}
+	writeCalendarLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeCalendarText 按 RFC 5545 §3.3.11 转义 TEXT 值。
+func escapeCalendarText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeCalendarLine 按 RFC 5545 §3.1 以 75 字节折行（不拆分 UTF-8 字符），行尾使用 CRLF。
+func writeCalendarLine(b *strings.Builder, line string) {
+	limit := calendarLineOctetLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Boundary(line, cut) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// 续行以一个空格开头，占用 1 字节
+		limit = calendarLineOctetLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Boundary(s string, i int) bool {
+	return i >= len(s) || s[i]&0xC0 != 0x80
+}
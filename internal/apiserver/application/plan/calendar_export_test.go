@@ -0,0 +1,86 @@
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/planreadmodel"
+)
+
+func TestExportTesteeCalendarIncludesOnlyUpcomingOpenTasks(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-30 * 24 * time.Hour)
+	reader := &taskReadModelStub{testeeRows: []planreadmodel.TaskRow{
+		{ID: 2, Seq: 2, TesteeID: 3001, ScaleCode: "phq9", PlannedAt: future.Add(7 * 24 * time.Hour), Status: "pending", TimeZone: "Europe/London"},
+		{ID: 1, Seq: 1, TesteeID: 3001, ScaleCode: "phq9", PlannedAt: future, Status: "opened", EntryURL: "https://example.com/entry?token=abc"},
+		{ID: 3, Seq: 3, TesteeID: 3001, ScaleCode: "phq9", PlannedAt: future, Status: "completed"},
+		{ID: 4, Seq: 4, TesteeID: 3001, ScaleCode: "phq9", PlannedAt: past, Status: "pending"},
+	}}
+	service := NewQueryService(&planReadModelStub{}, reader, &scaleCatalogStub{titles: map[string]string{"phq9": "PHQ-9, 抑郁筛查"}})
+
+	result, err := service.ExportTesteeCalendar(context.Background(), "3001")
+	if err != nil {
+		t.Fatalf("ExportTesteeCalendar returned error: %v", err)
+	}
+	if result.EventCount != 2 || result.FileName != "testee_3001_tasks.ics" {
+		t.Fatalf("result = %+v", result)
+	}
+	content := string(result.Content)
+	if !strings.HasPrefix(content, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(content, "END:VCALENDAR\r\n") {
+		t.Fatalf("unexpected calendar envelope: %q", content)
+	}
+	first := strings.Index(content, "UID:task-1@qs-server")
+	second := strings.Index(content, "UID:task-2@qs-server")
+	if first < 0 || second < 0 || first > second {
+		t.Fatalf("events should be ordered by planned_at: %q", content)
+	}
+	if strings.Contains(content, "task-3@") || strings.Contains(content, "task-4@") {
+		t.Fatalf("completed and overdue tasks should be excluded: %q", content)
+	}
+	if !strings.Contains(content, "DTSTART:"+future.Format(calendarUTCLayout)) {
+		t.Fatalf("missing UTC DTSTART: %q", content)
+	}
+	if !strings.Contains(content, `SUMMARY:PHQ-9\, 抑郁筛查（第 1 次）`) {
+		t.Fatalf("summary should be escaped: %q", content)
+	}
+	if !strings.Contains(unfoldCalendar(content), "（时区：Europe/London）") {
+		t.Fatalf("description should mention the plan time zone: %q", content)
+	}
+}
+
+func TestWriteCalendarLineFoldsWithoutSplittingUTF8(t *testing.T) {
+	var b strings.Builder
+	line := "DESCRIPTION:" + strings.Repeat("测评", 40)
+	writeCalendarLine(&b, line)
+
+	physical := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(physical) < 2 {
+		t.Fatalf("expected folded output, got %q", b.String())
+	}
+	for i, part := range physical {
+		if len(part) > calendarLineOctetLimit {
+			t.Fatalf("line %d has %d octets", i, len(part))
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Fatalf("continuation line %d must start with a space: %q", i, part)
+		}
+		if !isUTF8Boundary(part, 0) {
+			t.Fatalf("line %d starts inside a UTF-8 sequence", i)
+		}
+	}
+	if got := unfoldCalendar(b.String()); got != line+"\r\n" {
+		t.Fatalf("unfolded = %q", got)
+	}
+}
+
+func TestEscapeCalendarText(t *testing.T) {
+	if got, want := escapeCalendarText("a;b,c\\d\ne"), `a\;b\,c\\d\ne`; got != want {
+		t.Fatalf("escapeCalendarText = %q, want %q", got, want)
+	}
+}
+
+func unfoldCalendar(content string) string {
+	return strings.ReplaceAll(content, "\r\n ", "")
+}
@@ -34,6 +34,10 @@ type PlanResult struct {
 	FixedDates    []string // 固定日期列表
 	RelativeWeeks []int    // 相对周次列表
 	Status        string   // 状态
+
+	TimeZone       string   // IANA 时区（为空表示未指定）
+	RecurrenceRule string   // RRULE 日历规则
+	ExceptionDates []string // EXDATE 排除日期列表
 }
 
 // TaskResult 任务结果
@@ -55,6 +59,7 @@ type TaskResult struct {
 	AssessmentID     *string // 关联的测评ID
 	EntryToken       string  // 入口令牌
 	EntryURL         string  // 入口URL
+	TimeZone         string  // 计划时区；非空时各时间点按该时区展示
 }
 
 // EnrollmentResult 加入计划结果
@@ -130,6 +135,10 @@ func toPlanResult(p *plan.AssessmentPlan) *PlanResult {
 	for _, date := range p.GetFixedDates() {
 		fixedDates = append(fixedDates, date.Format("2006-01-02"))
 	}
+	var exceptionDates []string
+	for _, date := range p.GetExceptionDates() {
+		exceptionDates = append(exceptionDates, date.Format("2006-01-02"))
+	}
 	return &PlanResult{
 		ID:            p.GetID().String(),
 		OrgID:         p.GetOrgID(),
@@ -141,6 +150,10 @@ func toPlanResult(p *plan.AssessmentPlan) *PlanResult {
 		FixedDates:    fixedDates,
 		RelativeWeeks: p.GetRelativeWeeks(),
 		Status:        string(p.GetStatus()),
+
+		TimeZone:       p.GetTimeZone(),
+		RecurrenceRule: p.GetRecurrenceRule(),
+		ExceptionDates: exceptionDates,
 	}
 }
 
@@ -156,6 +169,10 @@ func toPlanResultFromRow(row planreadmodel.PlanRow) *PlanResult {
 		FixedDates:    append([]string(nil), row.FixedDates...),
 		RelativeWeeks: append([]int(nil), row.RelativeWeeks...),
 		Status:        row.Status,
+
+		TimeZone:       row.TimeZone,
+		RecurrenceRule: row.RecurrenceRule,
+		ExceptionDates: append([]string(nil), row.ExceptionDates...),
 	}
 }
 
@@ -165,6 +182,7 @@ func toTaskResult(t *plan.AssessmentTask) *TaskResult {
 		return nil
 	}
 
+	format := taskTimeFormatter(t.GetTimeZone())
 	result := &TaskResult{
 		ID:         t.GetID().String(),
 		PlanID:     t.GetPlanID().String(),
@@ -172,12 +190,13 @@ func toTaskResult(t *plan.AssessmentTask) *TaskResult {
 		OrgID:      t.GetOrgID(),
 		TesteeID:   t.GetTesteeID().String(),
 		ScaleCode:  t.GetScaleCode(),
-		PlannedAt:  t.GetPlannedAt().Format("2006-01-02 15:04:05"),
+		PlannedAt:  format(t.GetPlannedAt()),
 		Status:     string(t.GetStatus()),
 		EntryToken: t.GetEntryToken(),
 		EntryURL:   t.GetEntryURL(),
+		TimeZone:   t.GetTimeZone(),
 	}
-	dueAtStr := t.GetDueAt().Format("2006-01-02 15:04:05")
+	dueAtStr := format(t.GetDueAt())
 	result.DueAt = &dueAtStr
 	if reason := t.GetExpirationReason(); reason != "" {
 		reasonStr := reason.String()
@@ -185,15 +204,15 @@ func toTaskResult(t *plan.AssessmentTask) *TaskResult {
 	}
 
 	if openAt := t.GetOpenAt(); openAt != nil {
-		openAtStr := openAt.Format("2006-01-02 15:04:05")
+		openAtStr := format(*openAt)
 		result.OpenAt = &openAtStr
 	}
 	if expireAt := t.GetExpireAt(); expireAt != nil {
-		expireAtStr := expireAt.Format("2006-01-02 15:04:05")
+		expireAtStr := format(*expireAt)
 		result.ExpireAt = &expireAtStr
 	}
 	if completedAt := t.GetCompletedAt(); completedAt != nil {
-		completedAtStr := completedAt.Format("2006-01-02 15:04:05")
+		completedAtStr := format(*completedAt)
 		result.CompletedAt = &completedAtStr
 	}
 	if assessmentID := t.GetAssessmentID(); assessmentID != nil {
@@ -205,6 +224,7 @@ func toTaskResult(t *plan.AssessmentTask) *TaskResult {
 }
 
 func toTaskResultFromRow(row planreadmodel.TaskRow) *TaskResult {
+	format := taskTimeFormatter(row.TimeZone)
 	result := &TaskResult{
 		ID:         meta.FromUint64(row.ID).String(),
 		PlanID:     meta.FromUint64(row.PlanID).String(),
@@ -212,16 +232,17 @@ func toTaskResultFromRow(row planreadmodel.TaskRow) *TaskResult {
 		OrgID:      row.OrgID,
 		TesteeID:   meta.FromUint64(row.TesteeID).String(),
 		ScaleCode:  row.ScaleCode,
-		PlannedAt:  row.PlannedAt.Format("2006-01-02 15:04:05"),
+		PlannedAt:  format(row.PlannedAt),
 		Status:     row.Status,
 		EntryToken: row.EntryToken,
 		EntryURL:   row.EntryURL,
+		TimeZone:   row.TimeZone,
 	}
 	if row.DueAt != nil {
-		dueAt := row.DueAt.Format("2006-01-02 15:04:05")
+		dueAt := format(*row.DueAt)
 		result.DueAt = &dueAt
 	} else {
-		dueAt := plan.TaskDueAt(row.PlannedAt).Format("2006-01-02 15:04:05")
+		dueAt := format(plan.TaskDueAtIn(row.PlannedAt, plan.LoadPlanLocation(row.TimeZone)))
 		result.DueAt = &dueAt
 	}
 	if row.ExpirationReason != "" {
@@ -229,15 +250,15 @@ func toTaskResultFromRow(row planreadmodel.TaskRow) *TaskResult {
 		result.ExpirationReason = &reason
 	}
 	if row.OpenAt != nil {
-		openAt := row.OpenAt.Format("2006-01-02 15:04:05")
+		openAt := format(*row.OpenAt)
 		result.OpenAt = &openAt
 	}
 	if row.ExpireAt != nil {
-		expireAt := row.ExpireAt.Format("2006-01-02 15:04:05")
+		expireAt := format(*row.ExpireAt)
 		result.ExpireAt = &expireAt
 	}
 	if row.CompletedAt != nil {
-		completedAt := row.CompletedAt.Format("2006-01-02 15:04:05")
+		completedAt := format(*row.CompletedAt)
 		result.CompletedAt = &completedAt
 	}
 	if row.AssessmentID != nil {
@@ -247,6 +268,17 @@ func toTaskResultFromRow(row planreadmodel.TaskRow) *TaskResult {
 	return result
 }
 
+// taskTimeFormatter 返回任务时间的展示格式化函数；任务带计划时区时先换算到该时区。
+func taskTimeFormatter(timeZone string) func(time.Time) string {
+	loc := plan.LoadPlanLocation(timeZone)
+	return func(t time.Time) string {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
 // toTaskResults 批量转换任务结果
 func toTaskResults(tasks []*plan.AssessmentTask) []*TaskResult {
 	if len(tasks) == 0 {
@@ -308,6 +340,8 @@ func toPlanScheduleType(s string) plan.PlanScheduleType {
 		return plan.PlanScheduleCustom
 	case "fixed_date":
 		return plan.PlanScheduleFixedDate
+	case "rrule":
+		return plan.PlanScheduleRRule
 	default:
 		return plan.PlanScheduleByWeek
 	}
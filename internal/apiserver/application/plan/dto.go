@@ -7,12 +7,16 @@ package plan
 type CreatePlanDTO struct {
 	OrgID         int64    // 机构ID
 	ScaleCode     string   // 量表编码
-	ScheduleType  string   // 周期类型：by_week, by_day, custom, fixed_date
+	ScheduleType  string   // 周期类型：by_week, by_day, custom, fixed_date, rrule
 	TriggerTime   string   // 触发时间（格式：HH:MM 或 HH:MM:SS）
 	Interval      int      // 间隔（用于 by_week/by_day）
 	TotalTimes    int      // 总次数
 	FixedDates    []string // 固定日期列表（用于 fixed_date，格式：YYYY-MM-DD）
 	RelativeWeeks []int    // 相对周次列表（用于 custom，如 [2,4,8,12,18]）
+
+	TimeZone       string   // IANA 时区（可选，如 Asia/Shanghai；为空沿用服务器本地时区）
+	RecurrenceRule string   // RRULE 日历规则（用于 rrule，如 FREQ=MONTHLY;BYDAY=1MO;COUNT=6）
+	ExceptionDates []string // EXDATE 排除日期（用于 rrule，格式：YYYY-MM-DD 或 YYYYMMDD）
 }
 
 // EnrollTesteeDTO 受试者加入计划 DTO
@@ -0,0 +1,79 @@
+package plan
+
+import (
+	"context"
+	"strings"
+
+	domainplan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+type repositoryEnrollmentTimelineReader struct {
+	taskRepo        domainplan.AssessmentTaskRepository
+	enrollmentTasks domainplan.EnrollmentTaskRepository
+	enrollmentRepo  domainplan.EnrollmentRepository
+}
+
+func NewEnrollmentTimelineReader(
+	taskRepo domainplan.AssessmentTaskRepository,
+	enrollmentRepo domainplan.EnrollmentRepository,
+) EnrollmentTimelineReader {
+	enrollmentTasks, ok := taskRepo.(domainplan.EnrollmentTaskRepository)
+	if !ok || enrollmentRepo == nil {
+		return nil
+	}
+	return &repositoryEnrollmentTimelineReader{taskRepo: taskRepo, enrollmentTasks: enrollmentTasks, enrollmentRepo: enrollmentRepo}
+}
+
+func (r *repositoryEnrollmentTimelineReader) GetEnrollmentTimeline(ctx context.Context, enrollmentID uint64) (*EnrollmentTimeline, error) {
+	if enrollmentID == 0 {
+		return nil, nil
+	}
+	enrollment, err := r.enrollmentRepo.FindByID(ctx, meta.FromUint64(enrollmentID))
+	if err != nil || enrollment == nil {
+		return nil, err
+	}
+	tasks, err := r.enrollmentTasks.FindByEnrollmentID(ctx, enrollment.ID())
+	if err != nil {
+		return nil, err
+	}
+	timeline := &EnrollmentTimeline{
+		EnrollmentID: enrollment.ID().Uint64(),
+		OrgID:        enrollment.OrgID(),
+		PlanID:       enrollment.PlanID().Uint64(),
+		TesteeID:     enrollment.TesteeID().Uint64(),
+		Round:        enrollment.Round(),
+		Status:       string(enrollment.Status()),
+		ClosedAt:     enrollment.ClosedAt(),
+		Tasks:        make([]EnrollmentTimelineTask, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		item := EnrollmentTimelineTask{
+			TaskID:      task.GetID().Uint64(),
+			Seq:         task.GetSeq(),
+			Status:      task.GetStatus().String(),
+			PlannedAt:   task.GetPlannedAt(),
+			CompletedAt: task.GetCompletedAt(),
+		}
+		if assessmentID := task.GetAssessmentID(); assessmentID != nil {
+			item.AssessmentID = assessmentID.Uint64()
+		}
+		timeline.Tasks = append(timeline.Tasks, item)
+	}
+	return timeline, nil
+}
+
+func (r *repositoryEnrollmentTimelineReader) FindTaskEnrollmentID(ctx context.Context, taskIDRaw string) (uint64, error) {
+	taskID, err := domainplan.ParseAssessmentTaskID(strings.TrimSpace(taskIDRaw))
+	if err != nil {
+		return 0, err
+	}
+	task, err := r.taskRepo.FindByID(ctx, taskID)
+	if err != nil || task == nil {
+		return 0, err
+	}
+	return task.GetEnrollmentID().Uint64(), nil
+}
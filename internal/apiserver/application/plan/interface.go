@@ -149,6 +149,10 @@ type PlanQueryService interface {
 	// ListTasksByTesteeAndPlan 查询受试者在某个计划下的所有任务
 	// 场景：查看某个受试者在某个计划下的所有任务
 	ListTasksByTesteeAndPlan(ctx context.Context, testeeID string, planID string) ([]*TaskResult, error)
+
+	// ExportTesteeCalendar 导出受试者待完成任务的 ICS 日历
+	// 场景：家长把后续测评加入手机/邮箱日历
+	ExportTesteeCalendar(ctx context.Context, testeeID string) (*TesteeCalendarResult, error)
 }
 
 // TaskAssessmentResolver 为答卷转测评流程识别计划任务上下文。
@@ -195,3 +199,33 @@ type TaskNotificationContext struct {
 	TotalTimes                 int
 	UnfinishedSameDayTaskCount int
 }
+
+// EnrollmentTimelineReader 为纵向计划报告提供参与轮次的任务时间线。
+// 行为者：解读服务
+// 职责：隐藏 plan/task 仓储与领域对象，只暴露报告冻结输入所需的任务事实。
+type EnrollmentTimelineReader interface {
+	// GetEnrollmentTimeline 返回参与轮次及其全部任务；轮次不存在时返回 nil。
+	GetEnrollmentTimeline(ctx context.Context, enrollmentID uint64) (*EnrollmentTimeline, error)
+	// FindTaskEnrollmentID 返回任务所属的参与轮次；历史任务未关联轮次时返回 0。
+	FindTaskEnrollmentID(ctx context.Context, taskID string) (uint64, error)
+}
+
+type EnrollmentTimeline struct {
+	EnrollmentID uint64
+	OrgID        int64
+	PlanID       uint64
+	TesteeID     uint64
+	Round        uint32
+	Status       string
+	ClosedAt     *time.Time
+	Tasks        []EnrollmentTimelineTask
+}
+
+type EnrollmentTimelineTask struct {
+	TaskID       uint64
+	Seq          int
+	Status       string
+	AssessmentID uint64
+	PlannedAt    time.Time
+	CompletedAt  *time.Time
+}
@@ -17,11 +17,13 @@ type planCreateWorkflow struct {
 }
 
 type planCreateCommand struct {
-	scheduleType domainPlan.PlanScheduleType
-	triggerTime  string
-	fixedDates   []time.Time
-	totalTimes   int
-	options      []domainPlan.PlanOption
+	scheduleType   domainPlan.PlanScheduleType
+	triggerTime    string
+	timeZone       string
+	fixedDates     []time.Time
+	exceptionDates []time.Time
+	totalTimes     int
+	options        []domainPlan.PlanOption
 }
 
 func newPlanCreateWorkflow(
@@ -47,6 +49,9 @@ func (w *planCreateWorkflow) create(ctx context.Context, dto CreatePlanDTO) (*do
 		"total_times", dto.TotalTimes,
 		"fixed_dates", dto.FixedDates,
 		"relative_weeks", dto.RelativeWeeks,
+		"time_zone", dto.TimeZone,
+		"recurrence_rule", dto.RecurrenceRule,
+		"exception_dates", dto.ExceptionDates,
 	)
 
 	if err := w.validateScale(ctx, dto.ScaleCode); err != nil {
@@ -127,19 +132,47 @@ func (w *planCreateWorkflow) assembleCommand(ctx context.Context, dto CreatePlan
 		return planCreateCommand{}, errors.WithCode(errorCode.ErrInvalidArgument, "无效的触发时间: %s", dto.TriggerTime)
 	}
 
+	timeZone, err := domainPlan.NormalizePlanTimeZone(dto.TimeZone)
+	if err != nil {
+		logger.L(ctx).Errorw("CreatePlan invalid time_zone",
+			"action", "create_plan",
+			"time_zone", dto.TimeZone,
+			"error", err.Error(),
+		)
+		return planCreateCommand{}, errors.WithCode(errorCode.ErrInvalidArgument, "无效的时区: %s", dto.TimeZone)
+	}
+
 	fixedDates, err := parseFixedDates(ctx, dto.FixedDates)
 	if err != nil {
 		return planCreateCommand{}, err
 	}
 
+	exceptionDates, err := domainPlan.ParseRecurrenceExceptionDates(dto.ExceptionDates, domainPlan.LoadPlanLocation(timeZone))
+	if err != nil {
+		logger.L(ctx).Errorw("CreatePlan invalid exception_dates",
+			"action", "create_plan",
+			"exception_dates", dto.ExceptionDates,
+			"error", err.Error(),
+		)
+		return planCreateCommand{}, errors.WithCode(errorCode.ErrInvalidArgument, "无效的排除日期: %v", dto.ExceptionDates)
+	}
+
 	totalTimes := derivePlanTotalTimes(ctx, scheduleType, dto.TotalTimes, fixedDates, dto.RelativeWeeks)
 	options := buildPlanOptions(ctx, triggerTime, fixedDates, dto.RelativeWeeks)
+	if timeZone != "" {
+		options = append(options, domainPlan.WithTimeZone(timeZone))
+	}
+	if scheduleType == domainPlan.PlanScheduleRRule {
+		options = append(options, domainPlan.WithRecurrenceRule(dto.RecurrenceRule, exceptionDates))
+	}
 	return planCreateCommand{
-		scheduleType: scheduleType,
-		triggerTime:  triggerTime,
-		fixedDates:   fixedDates,
-		totalTimes:   totalTimes,
-		options:      options,
+		scheduleType:   scheduleType,
+		triggerTime:    triggerTime,
+		timeZone:       timeZone,
+		fixedDates:     fixedDates,
+		exceptionDates: exceptionDates,
+		totalTimes:     totalTimes,
+		options:        options,
 	}, nil
 }
 
@@ -159,7 +192,9 @@ func (w *planCreateWorkflow) validateDomain(ctx context.Context, dto CreatePlanD
 	if validator == nil {
 		validator = domainPlan.NewPlanValidator()
 	}
-	if errs := validator.ValidateForCreation(dto.OrgID, dto.ScaleCode, command.scheduleType, command.triggerTime, dto.Interval, command.totalTimes, command.fixedDates, dto.RelativeWeeks); len(errs) > 0 {
+	errs := validator.ValidateForCreation(dto.OrgID, dto.ScaleCode, command.scheduleType, command.triggerTime, dto.Interval, command.totalTimes, command.fixedDates, dto.RelativeWeeks)
+	errs = append(errs, validator.ValidateRecurrence(command.scheduleType, dto.RecurrenceRule, command.timeZone)...)
+	if len(errs) > 0 {
 		logger.L(ctx).Errorw("CreatePlan validation failed",
 			"action", "create_plan",
 			"org_id", dto.OrgID,
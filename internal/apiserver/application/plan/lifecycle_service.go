@@ -18,6 +18,7 @@ import (
 type lifecycleService struct {
 	planRepo           plan.AssessmentPlanRepository
 	taskRepo           plan.AssessmentTaskRepository
+	enrollments        resumeEnrollmentRepository
 	tx                 apptransaction.Runner
 	lifecycle          *plan.PlanLifecycle
 	createWorkflow     *planCreateWorkflow
@@ -35,10 +36,12 @@ func NewLifecycleServiceWithEnrollment(
 	taskGenerator := plan.NewTaskGenerator()
 	taskLifecycle := plan.NewTaskLifecycle()
 	lifecycle := plan.NewPlanLifecycle(taskRepo, taskGenerator, taskLifecycle)
+	resumeEnrollments, _ := enrollments.(resumeEnrollmentRepository)
 
 	return &lifecycleService{
 		planRepo:           planRepo,
 		taskRepo:           taskRepo,
+		enrollments:        resumeEnrollments,
 		tx:                 tx,
 		lifecycle:          lifecycle,
 		createWorkflow:     newPlanCreateWorkflow(planRepo, scaleCatalog, plan.NewPlanValidator()),
@@ -116,6 +119,9 @@ func (s *lifecycleService) ResumePlan(ctx context.Context, orgID int64, planID s
 		if loadErr != nil {
 			return loadErr
 		}
+		if loadErr := s.fillResumeStartDatesFromEnrollments(txCtx, tasks, testeeStartDateMap); loadErr != nil {
+			return loadErr
+		}
 		result, lifecycleErr := s.lifecycle.ResumeWithTasksAt(txCtx, p, tasks, testeeStartDateMap, actionAt)
 		if lifecycleErr != nil {
 			return lifecycleErr
@@ -187,6 +193,38 @@ func (s *lifecycleService) loadPlanForResume(ctx context.Context, orgID int64, i
 	return loadPlanInOrg(ctx, s.planRepo, orgID, id.String(), "resume_plan")
 }
 
+type resumeEnrollmentRepository interface {
+	FindByID(context.Context, plan.PlanEnrollmentID) (*plan.Enrollment, error)
+}
+
+// fillResumeStartDatesFromEnrollments 未显式指定开始日期的受试者，以参与轮次持久化的开始日期作为 DTSTART。
+// 首个任务的计划时间不一定是 DTSTART（EXDATE 可能剔除了首个发生日），从任务反推只作为旧数据的兜底。
+func (s *lifecycleService) fillResumeStartDatesFromEnrollments(ctx context.Context, tasks []*plan.AssessmentTask, startDates map[testee.ID]time.Time) error {
+	if s.enrollments == nil {
+		return nil
+	}
+	firstTasks := make(map[testee.ID]*plan.AssessmentTask)
+	for _, task := range tasks {
+		if first, ok := firstTasks[task.GetTesteeID()]; !ok || task.GetSeq() < first.GetSeq() {
+			firstTasks[task.GetTesteeID()] = task
+		}
+	}
+	for testeeID, task := range firstTasks {
+		if _, ok := startDates[testeeID]; ok || task.GetEnrollmentID().IsZero() {
+			continue
+		}
+		enrollment, err := s.enrollments.FindByID(ctx, task.GetEnrollmentID())
+		if err != nil {
+			return errors.WrapC(err, errorCode.ErrDatabase, "查询计划参与轮次失败")
+		}
+		if enrollment == nil || enrollment.RecordOrigin() != plan.EnrollmentRecordOriginNative || enrollment.StartDate().IsZero() {
+			continue
+		}
+		startDates[testeeID] = enrollment.StartDate()
+	}
+	return nil
+}
+
 func (s *lifecycleService) loadTasksForResume(ctx context.Context, id plan.AssessmentPlanID) ([]*plan.AssessmentTask, error) {
 	if locking, ok := s.taskRepo.(resumeTaskLockingRepository); ok {
 		return locking.FindByPlanIDForUpdate(ctx, id)
@@ -26,6 +26,7 @@ type planReadModelStub struct {
 type taskReadModelStub struct {
 	windowRows       []planreadmodel.TaskRow
 	windowHasMore    bool
+	testeeRows       []planreadmodel.TaskRow
 	lastWindowFilter planreadmodel.TaskWindowFilter
 	lastWindowPage   planreadmodel.PageRequest
 }
@@ -78,7 +79,7 @@ func (r *taskReadModelStub) ListTasksByPlanIDAndTesteeIDs(context.Context, uint6
 }
 
 func (r *taskReadModelStub) ListTasksByTesteeID(context.Context, uint64) ([]planreadmodel.TaskRow, error) {
-	return nil, nil
+	return r.testeeRows, nil
 }
 
 func (r *taskReadModelStub) ListTasksByTesteeIDAndPlanID(context.Context, uint64, uint64) ([]planreadmodel.TaskRow, error) {
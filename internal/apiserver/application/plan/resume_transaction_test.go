@@ -35,6 +35,12 @@ type resumeTaskRepositoryStub struct {
 	lockCalls         int
 	expectedRevisions []uint32
 	saveErr           error
+	newTasks          []*domainPlan.AssessmentTask
+}
+
+func (r *resumeTaskRepositoryStub) Save(_ context.Context, task *domainPlan.AssessmentTask) error {
+	r.newTasks = append(r.newTasks, task)
+	return nil
 }
 
 func (r *resumeTaskRepositoryStub) FindByPlanIDForUpdate(context.Context, domainPlan.AssessmentPlanID) ([]*domainPlan.AssessmentTask, error) {
@@ -47,6 +53,18 @@ func (r *resumeTaskRepositoryStub) SaveRescheduled(_ context.Context, _ *domainP
 	return r.saveErr
 }
 
+type resumeEnrollmentRepositoryStub struct {
+	domainPlan.PlanEnrollmentLifecycleRepository
+	enrollment *domainPlan.Enrollment
+}
+
+func (r *resumeEnrollmentRepositoryStub) FindByID(_ context.Context, id domainPlan.PlanEnrollmentID) (*domainPlan.Enrollment, error) {
+	if r.enrollment == nil || r.enrollment.ID() != id {
+		return nil, nil
+	}
+	return r.enrollment, nil
+}
+
 type recordingTransactionRunner struct {
 	called, committed, rolledBack bool
 }
@@ -133,3 +151,50 @@ func TestResumePlanFailsClosedWithoutTransactionRunner(t *testing.T) {
 		t.Fatalf("resume mutated state without transaction: status=%s plan_locks=%d plan_saves=%d task_locks=%d", plan.GetStatus(), plans.lockCalls, plans.saveCalls, tasks.lockCalls)
 	}
 }
+
+func TestResumePlanUsesEnrollmentStartDateWhenExDateRemovedFirstOccurrence(t *testing.T) {
+	exdates, err := domainPlan.ParseRecurrenceExceptionDates([]string{"20260805"}, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := domainPlan.NewAssessmentPlan(7, "scale", domainPlan.PlanScheduleRRule, 0, 0,
+		domainPlan.WithRecurrenceRule("FREQ=DAILY;COUNT=3", exdates),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan.RestoreFromRepository(plan.GetID(), domainPlan.PlanStatusPaused)
+	testeeID := domainTestee.NewID(99)
+	startDate := time.Date(2026, 8, 5, 0, 0, 0, 0, time.Local)
+	enrollment := domainPlan.NewEnrollment(7, plan.GetID(), testeeID, 1, startDate, startDate)
+
+	// DTSTART=08-05 被 EXDATE 剔除但仍计入 COUNT，只剩 08-06、08-07 两次
+	generated := domainPlan.NewTaskGenerator().GenerateTasks(plan, testeeID, startDate)
+	if len(generated) != 2 {
+		t.Fatalf("generated=%d want=2", len(generated))
+	}
+	for _, task := range generated {
+		task.AssignEnrollment(enrollment.ID())
+		if err := domainPlan.NewTaskLifecycle().Cancel(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plans := &resumePlanRepositoryStub{plan: plan}
+	tasks := &resumeTaskRepositoryStub{tasks: generated}
+	enrollments := &resumeEnrollmentRepositoryStub{enrollment: enrollment}
+	service := NewLifecycleServiceWithEnrollment(plans, tasks, nil, enrollments, &recordingTransactionRunner{}, nil)
+
+	if _, err := service.ResumePlan(context.Background(), 7, plan.GetID().String(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks.expectedRevisions) != 2 || len(tasks.newTasks) != 0 {
+		t.Fatalf("rescheduled=%d new=%d, want the two original occurrences only", len(tasks.expectedRevisions), len(tasks.newTasks))
+	}
+	for i, want := range []time.Time{time.Date(2026, 8, 6, 0, 0, 0, 0, time.Local), time.Date(2026, 8, 7, 0, 0, 0, 0, time.Local)} {
+		planned := generated[i].GetPlannedAt()
+		if planned.Year() != want.Year() || planned.YearDay() != want.YearDay() {
+			t.Fatalf("task seq=%d planned_at=%s want date %s", generated[i].GetSeq(), planned, want.Format("2006-01-02"))
+		}
+	}
+}
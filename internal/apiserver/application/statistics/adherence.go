@@ -0,0 +1,334 @@
+package statistics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	domainstats "github.com/FangcunMount/qs-server/internal/apiserver/domain/statistics"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+// AdherenceDimension 履约分解维度
+type AdherenceDimension string
+
+const (
+	AdherenceByClinician AdherenceDimension = "clinician"
+	AdherenceByEntry     AdherenceDimension = "entry"
+)
+
+func (d AdherenceDimension) Validate() error {
+	switch d {
+	case AdherenceByClinician, AdherenceByEntry:
+		return nil
+	default:
+		return errors.WithCode(code.ErrInvalidArgument, "unsupported adherence dimension: %s", d)
+	}
+}
+
+// AdherenceExportView CSV 导出视图
+type AdherenceExportView string
+
+const (
+	AdherenceExportOccurrences AdherenceExportView = "occurrences"
+	AdherenceExportDropout     AdherenceExportView = "dropout"
+	AdherenceExportClinicians  AdherenceExportView = "clinicians"
+	AdherenceExportEntries     AdherenceExportView = "entries"
+)
+
+// adherenceExportMaxRows 限制单次 CSV 导出的分解行数。
+const adherenceExportMaxRows = 10000
+
+// AdherenceMetrics 履约计数、比例（百分数）与打开到完成的中位耗时。
+type AdherenceMetrics struct {
+	domainstats.AdherenceCounts
+	CompletionRate              float64  `json:"completion_rate" gorm:"-"`
+	OnTimeRate                  float64  `json:"on_time_rate" gorm:"-"`
+	MedianOpenToCompleteSeconds *float64 `json:"median_open_to_complete_seconds,omitempty"`
+}
+
+func (m *AdherenceMetrics) fillRates() {
+	m.CompletionRate = m.AdherenceCounts.CompletionRate()
+	m.OnTimeRate = m.AdherenceCounts.OnTimeRate()
+}
+
+// PlanAdherenceItem 单个计划在入组队列窗口内的履约汇总。
+type PlanAdherenceItem struct {
+	PlanID          uint64 `json:"plan_id,string"`
+	ScaleCode       string `json:"scale_code"`
+	PlanStatus      string `json:"plan_status"`
+	EnrollmentCount int64  `json:"enrollment_count"`
+	AdherenceMetrics
+}
+
+// OccurrenceAdherenceItem 计划第 Seq 次测评的履约情况。
+type OccurrenceAdherenceItem struct {
+	Seq int `json:"seq"`
+	AdherenceMetrics
+}
+
+// AdherenceBreakdownItem 按医生或入口分解的履约情况；ID 为 0 表示未归因。
+type AdherenceBreakdownItem struct {
+	Dimension AdherenceDimension `json:"dimension" gorm:"-"`
+	ID        uint64             `json:"id,string"`
+	Name      string             `json:"name,omitempty"`
+	AdherenceMetrics
+}
+
+// PlanAdherence 计划履约详情：汇总、按次序的完成率与留存（流失）曲线。
+type PlanAdherence struct {
+	Plan        PlanAdherenceItem          `json:"plan"`
+	Occurrences []OccurrenceAdherenceItem  `json:"occurrences"`
+	Dropout     []domainstats.DropoutPoint `json:"dropout"`
+	TimeRange   DateRange                  `json:"time_range"`
+	Freshness   Freshness                  `json:"freshness"`
+}
+
+// AdherenceCSV 履约 CSV 导出结果
+type AdherenceCSV struct {
+	FileName string
+	Content  []byte
+}
+
+// AdherenceReadStore 履约投影读取端口；from/to 为入组时间的半开区间。
+type AdherenceReadStore interface {
+	ListPlanAdherence(ctx context.Context, orgID int64, planID *uint64, from, to time.Time, page, size int) ([]PlanAdherenceItem, int64, error)
+	PlanOccurrenceAdherence(ctx context.Context, orgID int64, planID uint64, from, to time.Time) ([]OccurrenceAdherenceItem, error)
+	PlanDropoutCohorts(ctx context.Context, orgID int64, planID uint64, from, to time.Time) ([]domainstats.DropoutCohort, error)
+	PlanAdherenceBreakdown(ctx context.Context, orgID int64, planID uint64, dimension AdherenceDimension, from, to time.Time, page, size int) ([]AdherenceBreakdownItem, int64, error)
+}
+
+// PlanAdherenceList 按计划列出履约汇总；时间窗口按入组日期筛选队列。
+func (s *ReadService) PlanAdherenceList(ctx context.Context, orgID int64, filter QueryFilter, page, size int) (*Page[PlanAdherenceItem], error) {
+	r, freshness, permit, err := s.resolve(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	page, size = normalizePage(page, size)
+	from, to := queryBounds(r)
+	key := cacheKey("plan_adherence", r.Preset, r.From, r.To, page, size)
+	cached := &Page[PlanAdherenceItem]{}
+	if hit, stale := s.cacheGet(ctx, orgID, key, cached); hit {
+		if stale {
+			cached.Freshness.IsStale = true
+		}
+		return cached, nil
+	}
+	if err := ensurePublishedResults(permit.readable); err != nil {
+		return nil, err
+	}
+	items, total, err := s.store.ListPlanAdherence(ctx, orgID, nil, from, to, page, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePublishedResults(ctx, orgID, permit); err != nil {
+		return nil, err
+	}
+	for index := range items {
+		items[index].fillRates()
+	}
+	value := &Page[PlanAdherenceItem]{Items: items, Total: total, Page: page, PageSize: size, TotalPages: int((total + int64(size) - 1) / int64(size)), TimeRange: r, Freshness: freshness}
+	s.cacheSet(ctx, orgID, key, value)
+	return value, nil
+}
+
+// PlanAdherence 查询单个计划的履约详情。
+func (s *ReadService) PlanAdherence(ctx context.Context, orgID int64, planID uint64, filter QueryFilter) (*PlanAdherence, error) {
+	r, freshness, permit, err := s.resolve(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	from, to := queryBounds(r)
+	key := cacheKey("plan_adherence_detail", planID, r.Preset, r.From, r.To)
+	cached := &PlanAdherence{}
+	if hit, stale := s.cacheGet(ctx, orgID, key, cached); hit {
+		if stale {
+			cached.Freshness.IsStale = true
+		}
+		return cached, nil
+	}
+	if err := ensurePublishedResults(permit.readable); err != nil {
+		return nil, err
+	}
+	plans, _, err := s.store.ListPlanAdherence(ctx, orgID, &planID, from, to, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	occurrences, err := s.store.PlanOccurrenceAdherence(ctx, orgID, planID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	cohorts, err := s.store.PlanDropoutCohorts(ctx, orgID, planID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePublishedResults(ctx, orgID, permit); err != nil {
+		return nil, err
+	}
+	value := &PlanAdherence{Plan: PlanAdherenceItem{PlanID: planID}, Occurrences: occurrences, Dropout: domainstats.BuildDropoutCurve(cohorts), TimeRange: r, Freshness: freshness}
+	if len(plans) > 0 {
+		value.Plan = plans[0]
+	}
+	value.Plan.fillRates()
+	for index := range value.Occurrences {
+		value.Occurrences[index].fillRates()
+	}
+	s.cacheSet(ctx, orgID, key, value)
+	return value, nil
+}
+
+// PlanAdherenceBreakdown 按医生或入口分解计划履约。
+func (s *ReadService) PlanAdherenceBreakdown(ctx context.Context, orgID int64, planID uint64, dimension AdherenceDimension, filter QueryFilter, page, size int) (*Page[AdherenceBreakdownItem], error) {
+	if err := dimension.Validate(); err != nil {
+		return nil, err
+	}
+	r, freshness, permit, err := s.resolve(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	page, size = normalizePage(page, size)
+	from, to := queryBounds(r)
+	key := cacheKey("plan_adherence_breakdown", planID, dimension, r.Preset, r.From, r.To, page, size)
+	cached := &Page[AdherenceBreakdownItem]{}
+	if hit, stale := s.cacheGet(ctx, orgID, key, cached); hit {
+		if stale {
+			cached.Freshness.IsStale = true
+		}
+		return cached, nil
+	}
+	if err := ensurePublishedResults(permit.readable); err != nil {
+		return nil, err
+	}
+	items, total, err := s.store.PlanAdherenceBreakdown(ctx, orgID, planID, dimension, from, to, page, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePublishedResults(ctx, orgID, permit); err != nil {
+		return nil, err
+	}
+	for index := range items {
+		items[index].Dimension = dimension
+		items[index].fillRates()
+	}
+	value := &Page[AdherenceBreakdownItem]{Items: items, Total: total, Page: page, PageSize: size, TotalPages: int((total + int64(size) - 1) / int64(size)), TimeRange: r, Freshness: freshness}
+	s.cacheSet(ctx, orgID, key, value)
+	return value, nil
+}
+
+// ExportPlanAdherenceCSV 将计划履约的某个视图导出为 CSV（UTF-8 BOM，便于表格软件直接打开）。
+// 导出直接读取已发布的数据库投影，不经过查询缓存。
+func (s *ReadService) ExportPlanAdherenceCSV(ctx context.Context, orgID int64, planID uint64, view AdherenceExportView, filter QueryFilter) (*AdherenceCSV, error) {
+	r, _, permit, err := s.resolve(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensurePublishedResults(permit.readable); err != nil {
+		return nil, err
+	}
+	from, to := queryBounds(r)
+	var rows [][]string
+	switch view {
+	case AdherenceExportOccurrences:
+		items, err := s.store.PlanOccurrenceAdherence(ctx, orgID, planID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, append([]string{"seq"}, adherenceCSVHeader...))
+		for _, item := range items {
+			item.fillRates()
+			rows = append(rows, append([]string{strconv.Itoa(item.Seq)}, adherenceCSVValues(item.AdherenceMetrics)...))
+		}
+	case AdherenceExportDropout:
+		cohorts, err := s.store.PlanDropoutCohorts(ctx, orgID, planID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{"week", "eligible_count", "retained_count", "retention_rate", "dropout_rate"})
+		for _, point := range domainstats.BuildDropoutCurve(cohorts) {
+			rows = append(rows, []string{strconv.Itoa(point.Week), formatCSVInt(point.EligibleCount), formatCSVInt(point.RetainedCount), formatCSVRate(point.RetentionRate), formatCSVRate(point.DropoutRate)})
+		}
+	case AdherenceExportClinicians, AdherenceExportEntries:
+		dimension := AdherenceByClinician
+		if view == AdherenceExportEntries {
+			dimension = AdherenceByEntry
+		}
+		items, _, err := s.store.PlanAdherenceBreakdown(ctx, orgID, planID, dimension, from, to, 1, adherenceExportMaxRows)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, append([]string{string(dimension) + "_id", "name"}, adherenceCSVHeader...))
+		for _, item := range items {
+			item.fillRates()
+			rows = append(rows, append([]string{strconv.FormatUint(item.ID, 10), item.Name}, adherenceCSVValues(item.AdherenceMetrics)...))
+		}
+	default:
+		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported adherence export view: %s", view)
+	}
+	if err := s.validatePublishedResults(ctx, orgID, permit); err != nil {
+		return nil, err
+	}
+	content, err := encodeCSV(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &AdherenceCSV{
+		FileName: fmt.Sprintf("plan_%d_adherence_%s_%s_%s.csv", planID, view, r.From.Format("20060102"), r.To.Format("20060102")),
+		Content:  content,
+	}, nil
+}
+
+var adherenceCSVHeader = []string{
+	"task_count", "on_time_count", "late_count", "expired_count", "pending_count",
+	"completion_rate", "on_time_rate", "median_open_to_complete_seconds",
+}
+
+func adherenceCSVValues(m AdherenceMetrics) []string {
+	median := ""
+	if m.MedianOpenToCompleteSeconds != nil {
+		median = strconv.FormatFloat(*m.MedianOpenToCompleteSeconds, 'f', 0, 64)
+	}
+	return []string{
+		formatCSVInt(m.TaskCount), formatCSVInt(m.OnTimeCount), formatCSVInt(m.LateCount), formatCSVInt(m.ExpiredCount), formatCSVInt(m.PendingCount),
+		formatCSVRate(m.CompletionRate), formatCSVRate(m.OnTimeRate), median,
+	}
+}
+
+func formatCSVInt(value int64) string { return strconv.FormatInt(value, 10) }
+
+func formatCSVRate(value float64) string { return strconv.FormatFloat(value, 'f', 2, 64) }
+
+// encodeCSV 写出 CSV；以 = + - @ 开头的文本单元格加前导单引号，防止表格软件将其当作公式执行。
+func encodeCSV(rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("\ufeff")
+	writer := csv.NewWriter(&buffer)
+	for _, row := range rows {
+		safe := make([]string, len(row))
+		for index, cell := range row {
+			safe[index] = neutralizeCSVFormula(cell)
+		}
+		if err := writer.Write(safe); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func neutralizeCSVFormula(cell string) string {
+	if cell == "" || strings.IndexByte("=+-@", cell[0]) < 0 {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
@@ -0,0 +1,100 @@
+package statistics
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	componenterrors "github.com/FangcunMount/component-base/pkg/errors"
+	domainstats "github.com/FangcunMount/qs-server/internal/apiserver/domain/statistics"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+func newAdherenceTestService(store *readStoreStub) *ReadService {
+	store.snapshot = &Snapshot{AsOfDate: time.Date(2026, 7, 21, 0, 0, 0, 0, time.UTC), SnapshotAt: time.Date(2026, 7, 22, 0, 30, 0, 0, time.UTC), DatabaseReadable: true}
+	service := NewReadService(store)
+	service.now = func() time.Time { return time.Date(2026, 7, 22, 9, 0, 0, 0, domainstats.Shanghai) }
+	return service
+}
+
+func TestPlanAdherenceFillsRatesAndDropoutCurve(t *testing.T) {
+	store := &readStoreStub{
+		adherencePlans: []PlanAdherenceItem{{PlanID: 42, EnrollmentCount: 3, AdherenceMetrics: AdherenceMetrics{AdherenceCounts: domainstats.AdherenceCounts{TaskCount: 6, OnTimeCount: 2, LateCount: 1, ExpiredCount: 1, PendingCount: 2}}}},
+		occurrences: []OccurrenceAdherenceItem{
+			{Seq: 1, AdherenceMetrics: AdherenceMetrics{AdherenceCounts: domainstats.AdherenceCounts{TaskCount: 3, OnTimeCount: 2, LateCount: 1}}},
+			{Seq: 2, AdherenceMetrics: AdherenceMetrics{AdherenceCounts: domainstats.AdherenceCounts{TaskCount: 3, ExpiredCount: 1, PendingCount: 2}}},
+		},
+		dropout: []domainstats.DropoutCohort{{LastCompletedWeek: 0, ObservedWeeks: 1, EnrollmentCount: 1}, {LastCompletedWeek: 1, ObservedWeeks: 1, EnrollmentCount: 2}},
+	}
+	value, err := newAdherenceTestService(store).PlanAdherence(context.Background(), 7, 42, QueryFilter{Preset: "30d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Plan.CompletionRate != 75 || value.Plan.OnTimeRate != 50 {
+		t.Fatalf("plan rates=%v/%v", value.Plan.CompletionRate, value.Plan.OnTimeRate)
+	}
+	if value.Occurrences[0].CompletionRate != 100 || value.Occurrences[1].CompletionRate != 0 {
+		t.Fatalf("occurrences=%+v", value.Occurrences)
+	}
+	if len(value.Dropout) != 2 || value.Dropout[1].RetainedCount != 2 || value.Dropout[1].EligibleCount != 3 {
+		t.Fatalf("dropout=%+v", value.Dropout)
+	}
+	if value.TimeRange.Preset != "30d" || value.Freshness.AsOfDate != "2026-07-21" {
+		t.Fatalf("range=%+v freshness=%+v", value.TimeRange, value.Freshness)
+	}
+}
+
+func TestPlanAdherenceBreakdownRejectsUnknownDimension(t *testing.T) {
+	_, err := newAdherenceTestService(&readStoreStub{}).PlanAdherenceBreakdown(context.Background(), 7, 42, "testee", QueryFilter{}, 1, 20)
+	if !componenterrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestExportPlanAdherenceCSVNeutralizesFormulasAndUsesExportLimit(t *testing.T) {
+	median := 90.0
+	store := &readStoreStub{breakdown: []AdherenceBreakdownItem{
+		{ID: 9, Name: "=HYPERLINK(\"x\")", AdherenceMetrics: AdherenceMetrics{AdherenceCounts: domainstats.AdherenceCounts{TaskCount: 2, OnTimeCount: 1, ExpiredCount: 1}, MedianOpenToCompleteSeconds: &median}},
+	}}
+	export, err := newAdherenceTestService(store).ExportPlanAdherenceCSV(context.Background(), 7, 42, AdherenceExportEntries, QueryFilter{Preset: "7d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.breakdownSize != adherenceExportMaxRows {
+		t.Fatalf("breakdown size=%d", store.breakdownSize)
+	}
+	if export.FileName != "plan_42_adherence_entries_20260715_20260721.csv" {
+		t.Fatalf("file name=%s", export.FileName)
+	}
+	content := string(export.Content)
+	if !strings.HasPrefix(content, "\ufeff") {
+		t.Fatal("CSV should start with a UTF-8 BOM")
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0][0] != "entry_id" || records[1][1] != "'=HYPERLINK(\"x\")" {
+		t.Fatalf("records=%v", records)
+	}
+	if got := records[1][7:]; got[0] != "50.00" || got[1] != "50.00" || got[2] != "90" {
+		t.Fatalf("rate columns=%v", got)
+	}
+}
+
+func TestExportPlanAdherenceCSVRejectsUnknownView(t *testing.T) {
+	_, err := newAdherenceTestService(&readStoreStub{}).ExportPlanAdherenceCSV(context.Background(), 7, 42, "raw", QueryFilter{})
+	if !componenterrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestNeutralizeCSVFormulaKeepsSignedNumbers(t *testing.T) {
+	for input, want := range map[string]string{"-1.5": "-1.5", "+3": "+3", "@cmd": "'@cmd", "-x": "'-x", "plain": "plain"} {
+		if got := neutralizeCSVFormula(input); got != want {
+			t.Fatalf("neutralizeCSVFormula(%q)=%q want %q", input, got, want)
+		}
+	}
+}
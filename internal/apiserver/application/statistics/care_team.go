@@ -0,0 +1,59 @@
+package statistics
+
+import (
+	"context"
+	"time"
+)
+
+// CareTeamItem 照护团队汇总：成员活动量按继承访问的成员（负责人与成员）汇总，受试者按团队分配统计。
+type CareTeamItem struct {
+	ID                     uint64 `json:"id,string"`
+	Name                   string `json:"name"`
+	Department             string `json:"department,omitempty"`
+	MemberCount            int64  `json:"member_count"`
+	AccessMemberCount      int64  `json:"access_member_count"`
+	TesteeCount            int64  `json:"testee_count"`
+	KeyFocusTesteeCount    int64  `json:"key_focus_testee_count"`
+	AssessedInWindowCount  int64  `json:"assessed_in_window_count"`
+	EntryOpenedCount       int64  `json:"entry_opened_count"`
+	IntakeConfirmedCount   int64  `json:"intake_confirmed_count"`
+	AssessmentCreatedCount int64  `json:"assessment_created_count"`
+	OutcomeCommittedCount  int64  `json:"outcome_committed_count"`
+	ReportGeneratedCount   int64  `json:"report_generated_count"`
+}
+
+// CareTeamReadStore 照护团队汇总读取端口；department 为空表示不限科室。
+type CareTeamReadStore interface {
+	ListCareTeams(ctx context.Context, orgID int64, teamID *uint64, department string, from, to time.Time, page, size int) ([]CareTeamItem, int64, error)
+}
+
+// CareTeams 按照护团队汇总成员活动与团队受试者。
+func (s *ReadService) CareTeams(ctx context.Context, orgID int64, teamID *uint64, department string, filter QueryFilter, page, size int) (*Page[CareTeamItem], error) {
+	r, freshness, permit, err := s.resolve(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	page, size = normalizePage(page, size)
+	from, to := queryBounds(r)
+	key := cacheKey("care_teams", teamID, department, r.Preset, r.From, r.To, page, size)
+	cached := &Page[CareTeamItem]{}
+	if hit, stale := s.cacheGet(ctx, orgID, key, cached); hit {
+		if stale {
+			cached.Freshness.IsStale = true
+		}
+		return cached, nil
+	}
+	if err := ensurePublishedResults(permit.readable); err != nil {
+		return nil, err
+	}
+	items, total, err := s.store.ListCareTeams(ctx, orgID, teamID, department, from, to, page, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePublishedResults(ctx, orgID, permit); err != nil {
+		return nil, err
+	}
+	value := &Page[CareTeamItem]{Items: items, Total: total, Page: page, PageSize: size, TotalPages: int((total + int64(size) - 1) / int64(size)), TimeRange: r, Freshness: freshness}
+	s.cacheSet(ctx, orgID, key, value)
+	return value, nil
+}
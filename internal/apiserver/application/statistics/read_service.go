@@ -183,6 +183,8 @@ type ReadStore interface {
 	CurrentClinicianID(context.Context, int64, int64) (uint64, error)
 	CurrentClinicianTesteeSummary(context.Context, int64, uint64, time.Time, time.Time) (TesteeSummary, error)
 	ContentBatch(context.Context, int64, time.Time, []ContentRef) ([]ContentItem, error)
+	AdherenceReadStore
+	CareTeamReadStore
 }
 
 type ReadService struct {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	componenterrors "github.com/FangcunMount/component-base/pkg/errors"
+	domainstats "github.com/FangcunMount/qs-server/internal/apiserver/domain/statistics"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
 )
 
@@ -16,6 +17,11 @@ type readStoreStub struct {
 	from, to        time.Time
 	contentAsOf     time.Time
 	overviewReadHit int
+	adherencePlans  []PlanAdherenceItem
+	occurrences     []OccurrenceAdherenceItem
+	dropout         []domainstats.DropoutCohort
+	breakdown       []AdherenceBreakdownItem
+	breakdownSize   int
 }
 
 func (s *readStoreStub) LatestVisibleSnapshot(context.Context, int64) (*Snapshot, error) {
@@ -42,6 +48,9 @@ func (*readStoreStub) ListClinicians(context.Context, int64, *uint64, *int64, ti
 func (*readStoreStub) ListEntries(context.Context, int64, *uint64, *uint64, *bool, time.Time, time.Time, int, int) ([]EntryItem, int64, error) {
 	return nil, 0, nil
 }
+func (*readStoreStub) ListCareTeams(context.Context, int64, *uint64, string, time.Time, time.Time, int, int) ([]CareTeamItem, int64, error) {
+	return nil, 0, nil
+}
 func (*readStoreStub) CurrentClinicianID(context.Context, int64, int64) (uint64, error) {
 	return 1, nil
 }
@@ -53,6 +62,20 @@ func (s *readStoreStub) ContentBatch(_ context.Context, _ int64, asOf time.Time,
 	return nil, nil
 }
 
+func (s *readStoreStub) ListPlanAdherence(context.Context, int64, *uint64, time.Time, time.Time, int, int) ([]PlanAdherenceItem, int64, error) {
+	return s.adherencePlans, int64(len(s.adherencePlans)), nil
+}
+func (s *readStoreStub) PlanOccurrenceAdherence(context.Context, int64, uint64, time.Time, time.Time) ([]OccurrenceAdherenceItem, error) {
+	return s.occurrences, nil
+}
+func (s *readStoreStub) PlanDropoutCohorts(context.Context, int64, uint64, time.Time, time.Time) ([]domainstats.DropoutCohort, error) {
+	return s.dropout, nil
+}
+func (s *readStoreStub) PlanAdherenceBreakdown(_ context.Context, _ int64, _ uint64, _ AdherenceDimension, _, _ time.Time, _ int, size int) ([]AdherenceBreakdownItem, int64, error) {
+	s.breakdownSize = size
+	return s.breakdown, int64(len(s.breakdown)), nil
+}
+
 type readCacheStub struct {
 	hit   bool
 	stale bool
@@ -26,6 +26,10 @@ type AnswerSheetResult struct {
 	Answers            []AnswerResult // 答案列表
 	// AdmissionPurpose is independent_questionnaire | assessment | "" (legacy).
 	AdmissionPurpose string
+	// CriticalItems 提交时命中的关键条目；仅提交与提交回读结果填充。
+	CriticalItems []CriticalItemResult
+	// SafeMessaging 命中关键条目时返回给作答端的安全提示；未命中或未配置时为空。
+	SafeMessaging *SafeMessaging
 }
 
 // AnswerResult 答案结果
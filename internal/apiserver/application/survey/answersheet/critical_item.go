@@ -0,0 +1,81 @@
+package answersheet
+
+import (
+	"context"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	domainanswersheet "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+)
+
+// SafeMessaging 安全提示：答卷命中关键条目时随提交结果返回给作答端，引导其联系求助热线。
+type SafeMessaging struct {
+	Title    string
+	Message  string
+	Hotlines []Hotline
+}
+
+// Hotline 求助热线
+type Hotline struct {
+	Name   string
+	Number string
+	Hours  string
+}
+
+// CriticalItemResult 答卷命中的关键条目
+type CriticalItemResult struct {
+	QuestionCode string
+	Category     string
+	Trigger      string
+}
+
+// SetSafeMessaging 安装安全提示；未安装时关键条目仍会标记并产生事件，但提交结果不带安全提示。
+func (s *submissionService) SetSafeMessaging(messaging SafeMessaging) {
+	s.safeMessaging = &messaging
+}
+
+type SafeMessagingInjector interface {
+	SetSafeMessaging(SafeMessaging)
+}
+
+// withCriticalItems 在提交结果上附加命中的关键条目与安全提示。
+func (s *submissionService) withCriticalItems(result *AnswerSheetResult, items []domainanswersheet.CriticalItem) *AnswerSheetResult {
+	if result == nil || len(items) == 0 {
+		return result
+	}
+	result.CriticalItems = make([]CriticalItemResult, 0, len(items))
+	for _, item := range items {
+		result.CriticalItems = append(result.CriticalItems, CriticalItemResult{
+			QuestionCode: item.QuestionCode,
+			Category:     string(item.Category),
+			Trigger:      item.Trigger,
+		})
+	}
+	if s.safeMessaging != nil {
+		messaging := *s.safeMessaging
+		messaging.Hotlines = append([]Hotline(nil), s.safeMessaging.Hotlines...)
+		result.SafeMessaging = &messaging
+	}
+	return result
+}
+
+// redetectCriticalItems 提交回读时按冻结的问卷版本重新判定关键条目，使重试的作答端同样拿到安全提示。
+// 关键条目事件已随原提交事务写入，这里只影响响应；问卷读取失败时不影响回读结果。
+func (s *submissionService) redetectCriticalItems(ctx context.Context, sheet *domainanswersheet.AnswerSheet) []domainanswersheet.CriticalItem {
+	if sheet == nil || s.questionnaireRepo == nil {
+		return nil
+	}
+	code, version, _ := sheet.QuestionnaireInfo()
+	qnr, err := s.questionnaireRepo.FindByCodeVersion(ctx, code, version)
+	if err != nil || qnr == nil {
+		if err != nil {
+			logger.L(ctx).Warnw("回读答卷时读取问卷失败，跳过关键条目判定",
+				"action", "lookup_answersheet_submission",
+				"answersheet_id", sheet.ID().Uint64(),
+				"questionnaire_code", code,
+				"error", err.Error(),
+			)
+		}
+		return nil
+	}
+	return domainanswersheet.DetectCriticalItems(qnr.GetQuestions(), sheet.Answers())
+}
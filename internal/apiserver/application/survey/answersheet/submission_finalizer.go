@@ -12,6 +12,7 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
 	attributionport "github.com/FangcunMount/qs-server/internal/apiserver/port/answersheetattribution"
 	submitport "github.com/FangcunMount/qs-server/internal/apiserver/port/answersheetsubmit"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
 	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
 )
 
@@ -22,6 +23,7 @@ func (s *submissionService) createAndSaveAnswerSheet(
 	dto SubmitAnswerSheetDTO,
 	qnr *questionnaire.Questionnaire,
 	answers []answersheet.Answer,
+	criticalItems []answersheet.CriticalItem,
 ) (*answersheet.AnswerSheet, error) {
 	filledAt := time.Now()
 	admission, err := s.resolveAdmission(ctx, dto.QuestionnaireCode, dto.QuestionnaireVer)
@@ -40,6 +42,9 @@ func (s *submissionService) createAndSaveAnswerSheet(
 	if existing, err := s.findExistingSubmissionBeforeAttribution(ctx, dto, qnr, answers, admission, filledAt); err != nil || existing != nil {
 		return existing, err
 	}
+	if err := s.checkConsent(ctx, l, dto); err != nil {
+		return nil, err
+	}
 	attribution, err := s.resolveAttribution(ctx, dto, admission, filledAt)
 	if err != nil {
 		return nil, err
@@ -48,6 +53,17 @@ func (s *submissionService) createAndSaveAnswerSheet(
 	if err != nil {
 		return nil, err
 	}
+	// 关键条目事件与 answersheet.submitted 在同一提交事务中写入 outbox。
+	sheet.FlagCriticalItems(criticalItems, filledAt)
+	if len(criticalItems) > 0 {
+		l.Warnw("答卷命中关键条目",
+			"action", "submit_answersheet",
+			"stage", "critical_item",
+			"questionnaire_code", dto.QuestionnaireCode,
+			"testee_id", dto.TesteeID,
+			"critical_item_count", len(criticalItems),
+		)
+	}
 	return s.persistSubmittedAnswerSheet(ctx, l, dto, sheet)
 }
 
@@ -151,6 +167,28 @@ func createAnswerSheet(
 	return sheet, nil
 }
 
+// checkConsent 校验受试者已签署本次作答所需的知情同意。幂等重放在此之前返回，
+// 已落库的答卷不因之后撤回同意而失效。
+func (s *submissionService) checkConsent(ctx context.Context, l *logger.RequestLogger, dto SubmitAnswerSheetDTO) error {
+	if s.consent == nil {
+		return nil
+	}
+	ref, err := originRefFromDTO(dto)
+	if err != nil {
+		return err
+	}
+	request := consentgate.CheckRequest{OrgID: int64(dto.OrgID), TesteeID: dto.TesteeID, QuestionnaireCode: dto.QuestionnaireCode}
+	if ref.Type == answersheet.OriginTypeAssessmentEntry {
+		request.EntryID = ref.ID
+	}
+	if err := s.consent.CheckSubmission(ctx, request); err != nil {
+		l.Warnw("答卷提交失败：缺少有效的知情同意", "action", "submit_answersheet", "stage", "consent", "result", "failed",
+			"testee_id", dto.TesteeID, "questionnaire_code", dto.QuestionnaireCode, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
 func (s *submissionService) resolveAttribution(ctx context.Context, dto SubmitAnswerSheetDTO, admission answersheet.Admission, capturedAt time.Time) (answersheet.AttributionSnapshot, error) {
 	ref, err := originRefFromDTO(dto)
 	if err != nil {
@@ -51,7 +51,7 @@ func (s *submissionService) LookupAcceptedSubmission(ctx context.Context, dto Lo
 
 	observeDurableOperation("explicit_readback", "hit")
 	observeDurableSubmit("idempotency_hit")
-	return toAnswerSheetResult(completed.Sheet), true, nil
+	return s.withCriticalItems(toAnswerSheetResult(completed.Sheet), s.redetectCriticalItems(ctx, completed.Sheet)), true, nil
 }
 
 func validateLookupSubmissionDTO(dto LookupSubmissionDTO) error {
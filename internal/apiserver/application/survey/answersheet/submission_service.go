@@ -11,6 +11,7 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
 	attributionport "github.com/FangcunMount/qs-server/internal/apiserver/port/answersheetattribution"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
 	rulesetport "github.com/FangcunMount/qs-server/internal/apiserver/port/modelcatalog"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/surveyreadmodel"
 	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -25,6 +26,8 @@ type submissionService struct {
 	questionnaireRepo questionnaire.Repository
 	binding           rulesetport.AssessmentBindingResolver
 	attribution       attributionport.Resolver
+	consent           consentgate.Checker
+	safeMessaging     *SafeMessaging
 }
 
 func (s *submissionService) SetAttributionResolver(resolver attributionport.Resolver) {
@@ -35,6 +38,15 @@ type AttributionResolverInjector interface {
 	SetAttributionResolver(attributionport.Resolver)
 }
 
+// SetConsentChecker 安装知情同意校验；未安装时不校验（隔离的单元/引导环境）。
+func (s *submissionService) SetConsentChecker(checker consentgate.Checker) {
+	s.consent = checker
+}
+
+type ConsentCheckerInjector interface {
+	SetConsentChecker(consentgate.Checker)
+}
+
 // NewSubmissionService 创建答卷提交服务
 func NewSubmissionService(
 	repo answersheet.Repository,
@@ -86,8 +98,11 @@ func (s *submissionService) Submit(ctx context.Context, dto SubmitAnswerSheetDTO
 
 	l.Infow("答案验证完成", "validated_count", len(answers), "total_count", len(dto.Answers), "result", "success")
 
-	// 5. 创建并保存答卷
-	sheet, err := s.createAndSaveAnswerSheet(ctx, l, dto, qnr, answers)
+	// 5. 同步判定关键条目，不依赖模型评估
+	criticalItems := answersheet.DetectCriticalItems(qnr.GetQuestions(), answers)
+
+	// 6. 创建并保存答卷
+	sheet, err := s.createAndSaveAnswerSheet(ctx, l, dto, qnr, answers, criticalItems)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +116,11 @@ func (s *submissionService) Submit(ctx context.Context, dto SubmitAnswerSheetDTO
 		"questionnaire_code", dto.QuestionnaireCode,
 		"filler_id", dto.FillerID,
 		"answer_count", len(answers),
+		"critical_item_count", len(criticalItems),
 		"duration_ms", duration.Milliseconds(),
 	)
 
-	return toAnswerSheetResult(sheet), nil
+	return s.withCriticalItems(toAnswerSheetResult(sheet), criticalItems), nil
 }
 
 // ==================== Submit 辅助方法 ====================
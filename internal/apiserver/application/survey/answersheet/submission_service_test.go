@@ -5,11 +5,14 @@ import (
 	"testing"
 	"time"
 
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
 	"github.com/FangcunMount/component-base/pkg/logger"
 	domainAnswerSheet "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
 	domainQuestionnaire "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
 	attributionport "github.com/FangcunMount/qs-server/internal/apiserver/port/answersheetattribution"
 	submitport "github.com/FangcunMount/qs-server/internal/apiserver/port/answersheetsubmit"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
+	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
 	"github.com/FangcunMount/qs-server/internal/pkg/meta"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -84,7 +87,7 @@ func TestSubmissionServiceCreateAndSaveAnswerSheetPassesDurableSubmitMeta(t *tes
 		TaskID:            "task-1",
 		QuestionnaireCode: "QNR-1",
 		QuestionnaireVer:  "1.0.0",
-	}, qnr, []domainAnswerSheet.Answer{answer})
+	}, qnr, []domainAnswerSheet.Answer{answer}, nil)
 	if err != nil {
 		t.Fatalf("createAndSaveAnswerSheet() error = %v", err)
 	}
@@ -117,7 +120,7 @@ func TestSubmissionServiceUsesCurrentFilledAndCapturedAt(t *testing.T) {
 	ctx := context.Background()
 	result, err := svc.createAndSaveAnswerSheet(ctx, logger.L(ctx), SubmitAnswerSheetDTO{
 		FillerID: 301, TesteeID: 401, OrgID: 501, QuestionnaireCode: "QNR-1", QuestionnaireVer: "1.0.0",
-	}, qnr, mustAnswersForSubmissionTest(t))
+	}, qnr, mustAnswersForSubmissionTest(t), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -144,7 +147,7 @@ func TestSubmissionServiceCreateAndSaveAnswerSheetReturnsExistingSheet(t *testin
 		OrgID:             501,
 		QuestionnaireCode: "QNR-1",
 		QuestionnaireVer:  "1.0.0",
-	}, qnr, mustAnswersForSubmissionTest(t))
+	}, qnr, mustAnswersForSubmissionTest(t), nil)
 	if err != nil {
 		t.Fatalf("createAndSaveAnswerSheet() error = %v", err)
 	}
@@ -164,7 +167,7 @@ func TestSubmissionServiceReturnsIdempotentAnswerBeforeMutableAttributionRevalid
 	result, err := svc.createAndSaveAnswerSheet(context.Background(), logger.L(context.Background()), SubmitAnswerSheetDTO{
 		IdempotencyKey: "idem-existing", FillerID: 301, TesteeID: 401, OrgID: 501,
 		QuestionnaireCode: "QNR-1", QuestionnaireVer: "1.0.0", OriginRef: &OriginRefDTO{Type: "assessment_entry", ID: "9001"},
-	}, qnr, mustAnswersForSubmissionTest(t))
+	}, qnr, mustAnswersForSubmissionTest(t), nil)
 	if err != nil || result != existing {
 		t.Fatalf("result=%p existing=%p err=%v", result, existing, err)
 	}
@@ -232,3 +235,35 @@ func mustAnswersForSubmissionTest(t *testing.T) []domainAnswerSheet.Answer {
 func nowForSubmissionTest() time.Time {
 	return time.Unix(1, 0)
 }
+
+type consentCheckerStub struct {
+	requests []consentgate.CheckRequest
+	err      error
+}
+
+func (s *consentCheckerStub) CheckSubmission(_ context.Context, request consentgate.CheckRequest) error {
+	s.requests = append(s.requests, request)
+	return s.err
+}
+
+func TestSubmissionServiceRejectsSubmissionWithoutConsent(t *testing.T) {
+	store := &preflightDurableStoreStub{}
+	checker := &consentCheckerStub{err: cberrors.WithCode(errorCode.ErrConsentRequired, "missing consent")}
+	svc := &submissionService{durableStore: store}
+	svc.SetConsentChecker(checker)
+	qnr, _ := domainQuestionnaire.NewQuestionnaire(meta.NewCode("QNR-1"), "Questionnaire")
+	_, err := svc.createAndSaveAnswerSheet(context.Background(), logger.L(context.Background()), SubmitAnswerSheetDTO{
+		IdempotencyKey: "idem-consent", FillerID: 301, TesteeID: 401, OrgID: 501,
+		QuestionnaireCode: "QNR-1", QuestionnaireVer: "1.0.0", OriginRef: &OriginRefDTO{Type: "assessment_entry", ID: "9001"},
+	}, qnr, mustAnswersForSubmissionTest(t), nil)
+	if !cberrors.IsCode(err, errorCode.ErrConsentRequired) {
+		t.Fatalf("err = %v, want consent required", err)
+	}
+	if store.createCalls != 0 {
+		t.Fatalf("answer sheet was written without consent: create=%d", store.createCalls)
+	}
+	want := consentgate.CheckRequest{OrgID: 501, TesteeID: 401, QuestionnaireCode: "QNR-1", EntryID: "9001"}
+	if len(checker.requests) != 1 || checker.requests[0] != want {
+		t.Fatalf("consent requests = %+v", checker.requests)
+	}
+}
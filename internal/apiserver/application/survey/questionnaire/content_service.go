@@ -64,7 +64,7 @@ func (s *contentService) AddQuestion(ctx context.Context, dto AddQuestionDTO) (*
 	}
 
 	q, err := s.applyQuestionMutation(ctx, dto.QuestionnaireCode, "add_question", func(q *questionnaire.Questionnaire) error {
-		question, err := buildQuestionFromDTO(dto.Code, dto.Stem, dto.Type, dto.Options, dto.Required, dto.Description, nil, nil, nil)
+		question, err := buildQuestionFromDTO(dto.Code, dto.Stem, dto.Type, dto.Options, dto.Required, dto.Description, nil, nil, nil, nil)
 		if err != nil {
 			l.Errorw("创建问题失败",
 				"action", "add_question",
@@ -130,7 +130,7 @@ func (s *contentService) UpdateQuestion(ctx context.Context, dto UpdateQuestionD
 	}
 
 	q, err := s.applyQuestionMutation(ctx, dto.QuestionnaireCode, "update_question", func(q *questionnaire.Questionnaire) error {
-		newQuestion, err := buildQuestionFromDTO(dto.Code, dto.Stem, dto.Type, dto.Options, dto.Required, dto.Description, nil, nil, nil)
+		newQuestion, err := buildQuestionFromDTO(dto.Code, dto.Stem, dto.Type, dto.Options, dto.Required, dto.Description, nil, nil, nil, nil)
 		if err != nil {
 			l.Errorw("创建问题失败",
 				"action", "update_question",
@@ -36,7 +36,8 @@ func buildQuestionsFromDTOs(ctx context.Context, questionnaireCode string, quest
 		validationRules := toDomainValidationRules(qDTO.ValidationRules)
 		calculationRule := toDomainCalculationRule(qDTO.CalculationRule)
 		showController := toDomainShowController(qDTO.ShowController)
-		question, err := buildQuestionFromDTO(qDTO.Code, qDTO.Stem, qDTO.Type, qDTO.Options, qDTO.Required, qDTO.Description, validationRules, calculationRule, showController)
+		criticalRule := toDomainCriticalRule(qDTO.CriticalRule)
+		question, err := buildQuestionFromDTO(qDTO.Code, qDTO.Stem, qDTO.Type, qDTO.Options, qDTO.Required, qDTO.Description, validationRules, calculationRule, showController, criticalRule)
 		if err != nil {
 			logger.L(ctx).Errorw("创建问题失败",
 				"action", "batch_update_questions",
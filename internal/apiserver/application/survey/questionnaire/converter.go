@@ -54,6 +54,7 @@ type QuestionResult struct {
 	Required        bool                   // 是否必填
 	Description     string                 // 问题描述
 	ShowController  *ShowControllerResult  // 显示控制器
+	CriticalRule    *CriticalRuleResult    // 关键条目规则
 }
 
 // ShowControllerResult is the application-layer projection of a question's
@@ -68,6 +69,14 @@ type ShowControllerConditionResult struct {
 	OptionCodes  []string
 }
 
+// CriticalRuleResult 关键条目规则结果
+type CriticalRuleResult struct {
+	Category    string
+	OptionCodes []string
+	MinValue    *float64
+	Keywords    []string
+}
+
 // ValidationRuleResult 校验规则结果
 type ValidationRuleResult struct {
 	RuleType    string // 规则类型
@@ -207,6 +216,20 @@ func toQuestionResult(q domainQuestionnaire.Question) QuestionResult {
 		result.ShowController = &ShowControllerResult{Rule: controller.GetRule(), Conditions: conditions}
 	}
 
+	// 转换关键条目规则（如果有）
+	if rule := q.GetCriticalRule(); !rule.IsEmpty() {
+		optionCodes := make([]string, 0, len(rule.OptionCodes))
+		for _, optionCode := range rule.OptionCodes {
+			optionCodes = append(optionCodes, optionCode.Value())
+		}
+		result.CriticalRule = &CriticalRuleResult{
+			Category:    string(rule.Category),
+			OptionCodes: optionCodes,
+			MinValue:    rule.MinValue,
+			Keywords:    append([]string(nil), rule.Keywords...),
+		}
+	}
+
 	return result
 }
 
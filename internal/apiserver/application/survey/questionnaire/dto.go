@@ -67,6 +67,7 @@ type QuestionDTO struct {
 	ValidationRules []ValidationRuleDTO // 校验规则
 	CalculationRule *CalculationRuleDTO // 计算规则
 	ShowController  *ShowControllerDTO  // 显示控制器
+	CriticalRule    *CriticalRuleDTO    // 关键条目规则
 }
 
 // ValidationRuleDTO 是应用层接收的校验规则 DTO。
@@ -92,6 +93,14 @@ type ShowControllerConditionDTO struct {
 	SelectOptionCodes []string // 被选中的选项编码
 }
 
+// CriticalRuleDTO 是应用层接收的关键条目规则 DTO。
+type CriticalRuleDTO struct {
+	Category    string   // 类别：self_harm/abuse/other
+	OptionCodes []string // 选中即命中的选项编码（选择题）
+	MinValue    *float64 // 不小于该值即命中（数字题）
+	Keywords    []string // 包含即命中的关键词（文本题）
+}
+
 // ListQuestionnairesDTO 查询问卷列表 DTO
 type ListQuestionnairesDTO struct {
 	Page     int                     // 页码
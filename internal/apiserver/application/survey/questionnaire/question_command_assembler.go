@@ -20,6 +20,7 @@ func buildQuestionFromDTO(
 	validationRules []validation.ValidationRule,
 	calculationRule *calculation.CalculationRule,
 	showController *domainQuestionnaire.ShowController,
+	criticalRule *domainQuestionnaire.CriticalRule,
 ) (domainQuestionnaire.Question, error) {
 	for _, rule := range validationRules {
 		if !surveyvalidation.IsSupportedRule(string(rule.GetRuleType())) {
@@ -63,6 +64,9 @@ func buildQuestionFromDTO(
 	if showController != nil {
 		qOptions = append(qOptions, domainQuestionnaire.WithShowController(showController))
 	}
+	if criticalRule != nil {
+		qOptions = append(qOptions, domainQuestionnaire.WithCriticalRule(criticalRule))
+	}
 
 	return domainQuestionnaire.NewQuestion(qOptions...)
 }
@@ -99,3 +103,14 @@ func toDomainShowController(controller *ShowControllerDTO) *domainQuestionnaire.
 	}
 	return domainQuestionnaire.NewShowController(controller.Rule, conditions)
 }
+
+func toDomainCriticalRule(rule *CriticalRuleDTO) *domainQuestionnaire.CriticalRule {
+	if rule == nil {
+		return nil
+	}
+	optionCodes := make([]meta.Code, 0, len(rule.OptionCodes))
+	for _, code := range rule.OptionCodes {
+		optionCodes = append(optionCodes, meta.NewCode(code))
+	}
+	return domainQuestionnaire.NewCriticalRule(domainQuestionnaire.CriticalCategory(rule.Category), optionCodes, rule.MinValue, rule.Keywords)
+}
@@ -8,7 +8,7 @@ import (
 
 func TestBuildQuestionFromDTORejectsUnsupportedValidationRule(t *testing.T) {
 	_, err := buildQuestionFromDTO("Q1", "Question", "Text", nil, false, "",
-		[]validation.ValidationRule{validation.NewValidationRule(validation.RuleType("custom"), "x")}, nil, nil)
+		[]validation.ValidationRule{validation.NewValidationRule(validation.RuleType("custom"), "x")}, nil, nil, nil)
 	if err == nil {
 		t.Fatal("expected unsupported validation rule error")
 	}
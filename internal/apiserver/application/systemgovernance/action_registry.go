@@ -117,6 +117,11 @@ func defaultActions(enabled map[string]bool) []ActionDescriptor {
 		governedRetryAction("interpretation.force_retry", DomainEvents, "Force retry terminal interpretation", "high", manualActionsEnabled),
 		reportTemplateAction("interpretation.report_template_publish", "Publish report template version", "draft", manualActionsEnabled),
 		reportTemplateAction("interpretation.report_template_disable", "Disable report template version", "published", manualActionsEnabled),
+		reportTemplateRolloutStartAction(manualActionsEnabled),
+		reportTemplateRolloutCloseAction("interpretation.report_template_rollout_promote", "Promote report template rollout", manualActionsEnabled),
+		reportTemplateRolloutCloseAction("interpretation.report_template_rollout_rollback", "Roll back report template rollout", manualActionsEnabled),
+		reportRegenerationStartAction(manualActionsEnabled),
+		reportRegenerationCancelAction(manualActionsEnabled),
 		readmissionAction(manualActionsEnabled),
 		catalogRepairAction(manualActionsEnabled),
 		{
@@ -187,6 +192,78 @@ func reportTemplateAction(id, label, expectedStatus string, enabled bool) Action
 	}
 }
 
+func reportTemplateRolloutStartAction(enabled bool) ActionDescriptor {
+	return ActionDescriptor{
+		ID: "interpretation.report_template_rollout_start", Domain: DomainActions, Label: "Start report template rollout",
+		RiskLevel: "high", Enabled: enabled, RequiresConfirmation: true,
+		InputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"template_id", "stable_version", "candidate_version", "mode", "reason"},
+			"properties": map[string]interface{}{
+				"template_id":       map[string]interface{}{"type": "string", "minLength": 1},
+				"stable_version":    map[string]interface{}{"type": "string", "minLength": 1},
+				"candidate_version": map[string]interface{}{"type": "string", "minLength": 1},
+				"mode":              map[string]interface{}{"type": "string", "enum": []string{"canary", "shadow"}},
+				"percent":           map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100},
+				"org_ids":           map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer", "minimum": 1}},
+				"reason":            map[string]interface{}{"type": "string", "minLength": 1},
+			},
+		},
+	}
+}
+
+func reportTemplateRolloutCloseAction(id, label string, enabled bool) ActionDescriptor {
+	return ActionDescriptor{
+		ID: id, Domain: DomainActions, Label: label, RiskLevel: "high",
+		Enabled: enabled, RequiresConfirmation: true,
+		InputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"rollout_id", "expected_version", "reason"},
+			"properties": map[string]interface{}{
+				"rollout_id":       map[string]interface{}{"type": "string", "minLength": 1},
+				"expected_version": map[string]interface{}{"type": "integer", "minimum": 1},
+				"reason":           map[string]interface{}{"type": "string", "minLength": 1},
+			},
+		},
+	}
+}
+
+func reportRegenerationStartAction(enabled bool) ActionDescriptor {
+	return ActionDescriptor{
+		ID: "interpretation.report_regeneration_start", Domain: DomainActions, Label: "Start bulk report regeneration",
+		RiskLevel: "high", Enabled: enabled, RequiresConfirmation: true,
+		InputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"model_code", "template_id", "target_version", "reason"},
+			"properties": map[string]interface{}{
+				"model_code":     map[string]interface{}{"type": "string", "minLength": 1},
+				"model_version":  map[string]interface{}{"type": "string"},
+				"generated_from": map[string]interface{}{"type": "string", "format": "date-time"},
+				"generated_to":   map[string]interface{}{"type": "string", "format": "date-time"},
+				"template_id":    map[string]interface{}{"type": "string", "minLength": 1},
+				"target_version": map[string]interface{}{"type": "string", "minLength": 1},
+				"reason":         map[string]interface{}{"type": "string", "minLength": 1},
+			},
+		},
+	}
+}
+
+func reportRegenerationCancelAction(enabled bool) ActionDescriptor {
+	return ActionDescriptor{
+		ID: "interpretation.report_regeneration_cancel", Domain: DomainActions, Label: "Cancel bulk report regeneration",
+		RiskLevel: "high", Enabled: enabled, RequiresConfirmation: true,
+		InputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"batch_id", "expected_version", "reason"},
+			"properties": map[string]interface{}{
+				"batch_id":         map[string]interface{}{"type": "string", "minLength": 1},
+				"expected_version": map[string]interface{}{"type": "integer", "minimum": 1},
+				"reason":           map[string]interface{}{"type": "string", "minLength": 1},
+			},
+		},
+	}
+}
+
 func replayDeliverySchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object", "required": []string{"targets", "reason"},
@@ -0,0 +1,65 @@
+package workbench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	domainAnswerSheet "github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/answersheet"
+	domaincriticalitem "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/criticalitem"
+)
+
+// CriticalItemFlag 答卷命中关键条目的工作台投影，以答卷 ID 为条目主体。
+type CriticalItemFlag = domaincriticalitem.Flag
+
+// CriticalItemFlagRecorder 记录关键条目投影；同一答卷重复投递时必须幂等。
+type CriticalItemFlagRecorder = domaincriticalitem.Repository
+
+// NewCriticalItemEventConsumer 将 answersheet.critical_item_flagged 事件投影为工作台关键条目队列。
+// 事件与答卷提交在同一事务写入 outbox，因此队列不依赖测评模型评估是否成功。
+func NewCriticalItemEventConsumer(recorder CriticalItemFlagRecorder) func(context.Context, string, []byte) error {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		if eventType != domainAnswerSheet.EventTypeCriticalItemFlagged {
+			return nil
+		}
+		if recorder == nil {
+			return fmt.Errorf("workbench critical item recorder is unavailable")
+		}
+
+		env, err := eventcodec.DecodeEnvelope(payload)
+		if err != nil {
+			return err
+		}
+		var data domainAnswerSheet.AnswerSheetCriticalItemFlaggedData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			return fmt.Errorf("decode answersheet critical item payload: %w", err)
+		}
+		answerSheetID, err := strconv.ParseUint(data.AnswerSheetID, 10, 64)
+		if err != nil || answerSheetID == 0 {
+			return fmt.Errorf("invalid answersheet id in critical item payload: %q", data.AnswerSheetID)
+		}
+		if data.OrgID == 0 || data.TesteeID == 0 || len(data.Items) == 0 {
+			return fmt.Errorf("incomplete critical item payload for answersheet %d", answerSheetID)
+		}
+
+		flaggedAt := data.FlaggedAt
+		if flaggedAt.IsZero() {
+			flaggedAt = env.OccurredAt
+		}
+		items := make([]CriticalItemHit, 0, len(data.Items))
+		for _, item := range data.Items {
+			items = append(items, CriticalItemHit{QuestionCode: item.QuestionCode, Category: item.Category, Trigger: item.Trigger})
+		}
+		return recorder.RecordCriticalItemFlag(ctx, CriticalItemFlag{
+			AnswerSheetID:        answerSheetID,
+			OrgID:                int64(data.OrgID),
+			TesteeID:             data.TesteeID,
+			QuestionnaireCode:    data.QuestionnaireCode,
+			QuestionnaireVersion: data.QuestionnaireVersion,
+			Items:                items,
+			FlaggedAt:            flaggedAt,
+		})
+	}
+}
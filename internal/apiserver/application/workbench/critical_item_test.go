@@ -0,0 +1,59 @@
+package workbench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/component-base/pkg/eventcodec"
+	eventcatalog "github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+)
+
+type criticalItemRecorderCapture struct{ flags []CriticalItemFlag }
+
+func (r *criticalItemRecorderCapture) RecordCriticalItemFlag(_ context.Context, flag CriticalItemFlag) error {
+	r.flags = append(r.flags, flag)
+	return nil
+}
+
+func TestCriticalItemEventConsumerProjectsFlag(t *testing.T) {
+	recorder := &criticalItemRecorderCapture{}
+	flaggedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	evt := event.Event[map[string]any]{
+		BaseEvent: event.BaseEvent{ID: "evt-1", EventTypeValue: eventcatalog.AnswerSheetCriticalItemFlagged, OccurredAtValue: flaggedAt, AggregateTypeValue: "AnswerSheet", AggregateIDValue: "42"},
+		Data: map[string]any{
+			"answersheet_id": "42", "questionnaire_code": "PHQ9", "questionnaire_version": "1.0.0",
+			"org_id": 7, "testee_id": 9, "filler_id": 3, "flagged_at": flaggedAt,
+			"items": []map[string]any{{"question_code": "Q9", "category": "self_harm", "trigger": "opt-3"}},
+		},
+	}
+	payload, err := eventcodec.EncodeDomainEvent(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCriticalItemEventConsumer(recorder)(context.Background(), eventcatalog.AnswerSheetCriticalItemFlagged, payload); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.flags) != 1 {
+		t.Fatalf("flags = %#v", recorder.flags)
+	}
+	flag := recorder.flags[0]
+	if flag.AnswerSheetID != 42 || flag.OrgID != 7 || flag.TesteeID != 9 || len(flag.Items) != 1 || flag.Items[0].Trigger != "opt-3" || !flag.FlaggedAt.Equal(flaggedAt) {
+		t.Fatalf("flag = %#v", flag)
+	}
+}
+
+func TestCriticalItemEventConsumerRejectsIncompletePayload(t *testing.T) {
+	evt := event.Event[map[string]any]{
+		BaseEvent: event.BaseEvent{ID: "evt-2", EventTypeValue: eventcatalog.AnswerSheetCriticalItemFlagged, OccurredAtValue: time.Now(), AggregateTypeValue: "AnswerSheet", AggregateIDValue: "42"},
+		Data:      map[string]any{"answersheet_id": "42", "org_id": 7},
+	}
+	payload, err := eventcodec.EncodeDomainEvent(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCriticalItemEventConsumer(&criticalItemRecorderCapture{})(context.Background(), eventcatalog.AnswerSheetCriticalItemFlagged, payload); err == nil {
+		t.Fatal("consumer error = nil, want incomplete payload error")
+	}
+}
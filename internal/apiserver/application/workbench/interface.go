@@ -3,14 +3,19 @@ package workbench
 import (
 	"context"
 	"time"
+
+	domaincriticalitem "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/criticalitem"
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
 )
 
-type QueueType string
+type QueueType = domaintriage.QueueType
 
 const (
-	QueueTypeHighRisk QueueType = "high_risk"
-	QueueTypeFollowUp QueueType = "follow_up"
-	QueueTypeKeyFocus QueueType = "key_focus"
+	QueueTypeHighRisk       = domaintriage.QueueTypeHighRisk
+	QueueTypeFollowUp       = domaintriage.QueueTypeFollowUp
+	QueueTypeKeyFocus       = domaintriage.QueueTypeKeyFocus
+	QueueTypeAwaitingReview = domaintriage.QueueTypeAwaitingReview
+	QueueTypeCriticalItem   = domaintriage.QueueTypeCriticalItem
 )
 
 type ScopeKind string
@@ -18,11 +23,23 @@ type ScopeKind string
 const (
 	ScopeKindClinicianMe ScopeKind = "clinician_me"
 	ScopeKindOrgAdmin    ScopeKind = "org_admin"
+	// ScopeKindCareTeam 当前从业者以团队成员身份查看某个照护团队的受试者，需为继承访问的团队角色。
+	ScopeKindCareTeam ScopeKind = "care_team"
 )
 
 type Service interface {
 	GetSummary(ctx context.Context, scope Scope) (*SummaryResult, error)
 	ListQueue(ctx context.Context, dto ListQueueDTO) (*QueuePage, error)
+	// GetTriageItem 返回队列条目的分诊状态与完整历史；尚无分诊记录时状态为 open。
+	GetTriageItem(ctx context.Context, dto TriageDTO) (*TriageItemView, error)
+	// Claim 认领条目；已被他人认领时冲突，机构管理员可接管。
+	Claim(ctx context.Context, dto TriageDTO) (*TriageItemView, error)
+	// Snooze 暂缓条目到指定时间，到期后条目重新回到 open。
+	Snooze(ctx context.Context, dto TriageDTO) (*TriageItemView, error)
+	// Resolve 以结论代码和说明解决条目。
+	Resolve(ctx context.Context, dto TriageDTO) (*TriageItemView, error)
+	// Reopen 将已认领、暂缓或已解决的条目重新打开。
+	Reopen(ctx context.Context, dto TriageDTO) (*TriageItemView, error)
 }
 
 type Scope struct {
@@ -30,23 +47,37 @@ type Scope struct {
 	OrgID          int64
 	OperatorUserID int64
 	ClinicianID    *uint64
+	// TeamID 照护团队范围：care_team 视角必填；机构管理员视角可选，按团队受试者过滤。
+	TeamID *uint64
 }
 
 type ListQueueDTO struct {
 	Scope
 	QueueType QueueType
-	Page      int
-	PageSize  int
+	// TriageView 分诊状态过滤：为空时只列出待处理（open 与 claimed）条目，all 表示不过滤。
+	TriageView string
+	Page       int
+	PageSize   int
 }
 
+// SummaryResult 工作台汇总。接入分诊后 Counts 为待处理条目数，Open 为其中尚未认领的条目数，
+// Handled 为已解决或暂缓中的条目数；未接入分诊时 Counts 为队列全部条目数。
 type SummaryResult struct {
-	Counts QueueCounts
+	Counts  QueueCounts
+	Open    QueueCounts
+	Handled QueueCounts
+	// EscalatedHighRisk 超过认领时限升级且仍未认领的高风险条目数。
+	EscalatedHighRisk int64
 }
 
 type QueueCounts struct {
 	HighRisk int64
 	FollowUp int64
 	KeyFocus int64
+	// AwaitingReview 待复核报告数；未接入临床复核时恒为 0。
+	AwaitingReview int64
+	// CriticalItem 命中关键条目的答卷数；未接入关键条目投影时恒为 0。
+	CriticalItem int64
 }
 
 type QueuePage struct {
@@ -59,15 +90,24 @@ type QueuePage struct {
 }
 
 type QueueItem struct {
+	// SubjectID 分诊动作作用的条目主体：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，
+	// key_focus 为受试者 ID，critical_item 为答卷 ID。
+	SubjectID          uint64
 	Testee             Testee
 	ReasonCode         string
 	Reason             string
 	ReasonAt           *time.Time
 	RiskLevel          string
 	Task               *TaskSummary
+	Review             *ReviewSummary
+	CriticalItem       *CriticalItemSummary
 	PrimaryClinician   *ClinicianAssignment
 	AssignedClinicians []ClinicianAssignment
 	IsUnassigned       *bool
+	// BreakGlass 受试者上生效中的紧急访问授权；为空表示按常规授权关系访问。
+	BreakGlass []BreakGlassAccess
+	// Triage 分诊状态；未接入分诊时为空。
+	Triage *TriageState
 }
 
 type Testee struct {
@@ -97,6 +137,31 @@ type TaskSummary struct {
 	EntryURL  string
 }
 
+// ReviewSummary 待复核队列中的测评报告。
+type ReviewSummary struct {
+	AssessmentID uint64
+	// NoteVersion 已追加但尚未签署的备注版本，0 表示尚无备注。
+	NoteVersion int
+}
+
+// CriticalItemSummary 关键条目队列中的答卷与命中条目。
+type CriticalItemSummary struct {
+	AnswerSheetID        uint64
+	QuestionnaireCode    string
+	QuestionnaireVersion string
+	Categories           []string
+	Items                []CriticalItemHit
+}
+
+// CriticalItemHit 命中的关键条目；Trigger 为命中的选项编码、数值或关键词，不含答案原文。
+type CriticalItemHit = domaincriticalitem.Hit
+
+type BreakGlassAccess struct {
+	GrantID     uint64
+	ClinicianID uint64
+	ExpiresAt   time.Time
+}
+
 type ClinicianAssignment struct {
 	ID            uint64
 	OrgID         int64
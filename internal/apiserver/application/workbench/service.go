@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
@@ -45,9 +48,19 @@ type service struct {
 	testeeReader            testeeReader
 	latestRiskReader        workbenchreadmodel.LatestRiskReader
 	followUpQueueReader     planreadmodel.FollowUpQueueReader
+	breakGlassReader        breakglass.ActiveGrantReader
+	careTeamReader          careteam.AccessReader
+	awaitingReviewReader    workbenchreadmodel.AwaitingReviewReader
+	criticalItemReader      workbenchreadmodel.CriticalItemReader
+	triageStore             TriageStore
+	highRiskClaimSLA        time.Duration
 	assessmentSummaryReader actorreadmodel.AssessmentSummaryReader
+	now                     func() time.Time
 }
 
+// NewService 创建临床工作台服务。breakGlassReader 为 nil 时不合并、不标记紧急访问；
+// careTeamReader 为 nil 时不合并团队继承的受试者，也不支持照护团队视角；
+// awaitingReviewReader 为 nil 时待复核队列恒为空；criticalItemReader 为 nil 时关键条目队列恒为空；triage 为 nil 时队列不区分分诊状态，分诊动作不可用。
 func NewService(
 	operatorQuery operatorByUserQuery,
 	clinicianQuery clinicianByOperatorQuery,
@@ -56,12 +69,25 @@ func NewService(
 	testeeReader testeeReader,
 	latestRiskReader workbenchreadmodel.LatestRiskReader,
 	followUpQueueReader planreadmodel.FollowUpQueueReader,
+	breakGlassReader breakglass.ActiveGrantReader,
+	careTeamReader careteam.AccessReader,
+	awaitingReviewReader workbenchreadmodel.AwaitingReviewReader,
+	criticalItemReader workbenchreadmodel.CriticalItemReader,
+	triage *TriageConfig,
 	assessmentSummaryReaders ...actorreadmodel.AssessmentSummaryReader,
 ) Service {
 	var assessmentSummaryReader actorreadmodel.AssessmentSummaryReader
 	if len(assessmentSummaryReaders) > 0 {
 		assessmentSummaryReader = assessmentSummaryReaders[0]
 	}
+	var (
+		triageStore      TriageStore
+		highRiskClaimSLA time.Duration
+	)
+	if triage != nil {
+		triageStore = triage.Store
+		highRiskClaimSLA = triage.HighRiskClaimSLA
+	}
 	return &service{
 		operatorQuery:           operatorQuery,
 		clinicianQuery:          clinicianQuery,
@@ -70,7 +96,14 @@ func NewService(
 		testeeReader:            testeeReader,
 		latestRiskReader:        latestRiskReader,
 		followUpQueueReader:     followUpQueueReader,
+		breakGlassReader:        breakGlassReader,
+		careTeamReader:          careTeamReader,
+		awaitingReviewReader:    awaitingReviewReader,
+		criticalItemReader:      criticalItemReader,
+		triageStore:             triageStore,
+		highRiskClaimSLA:        highRiskClaimSLA,
 		assessmentSummaryReader: assessmentSummaryReader,
+		now:                     time.Now,
 	}
 }
 
@@ -86,29 +119,84 @@ func (s *service) GetSummary(ctx context.Context, scope Scope) (*SummaryResult,
 		return &SummaryResult{}, nil
 	}
 
-	highRiskPage, err := s.latestRiskReader.ListLatestRiskQueue(ctx, latestRiskQueueFilter(resolved), workbenchreadmodel.PageRequest{Page: 1, PageSize: 1})
+	if s.triageStore == nil {
+		counts, err := s.countQueues(ctx, resolved, workbenchreadmodel.TriageViewAll)
+		if err != nil {
+			return nil, err
+		}
+		return &SummaryResult{Counts: counts}, nil
+	}
+
+	counts, err := s.countQueues(ctx, resolved, workbenchreadmodel.TriageViewActive)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to count high risk queue")
+		return nil, err
 	}
-	followUpPage, err := s.followUpQueueReader.ListFollowUpQueueTasks(ctx, planreadmodel.FollowUpQueueFilter{
-		OrgID:               resolved.OrgID,
-		TesteeIDs:           resolved.TesteeIDs,
-		RestrictToTesteeIDs: resolved.RestrictToTesteeIDs,
-	}, planreadmodel.PageRequest{Page: 1, PageSize: 1})
+	openCounts, err := s.countQueues(ctx, resolved, workbenchreadmodel.TriageViewOpen)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to count follow-up queue")
+		return nil, err
 	}
-	keyFocusCount, err := s.testeeReader.CountTestees(ctx, s.keyFocusFilter(resolved, 0, 0))
+	handledCounts, err := s.countQueues(ctx, resolved, workbenchreadmodel.TriageViewHandled)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to count key focus queue")
+		return nil, err
+	}
+	escalatedPage, err := s.latestRiskReader.ListLatestRiskQueue(ctx,
+		latestRiskQueueFilter(resolved, s.triageFilter(QueueTypeHighRisk, workbenchreadmodel.TriageViewEscalated)),
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count escalated high risk queue")
 	}
-
 	return &SummaryResult{
-		Counts: QueueCounts{
-			HighRisk: highRiskPage.Total,
-			FollowUp: followUpPage.Total,
-			KeyFocus: keyFocusCount,
-		},
+		Counts:            counts,
+		Open:              openCounts,
+		Handled:           handledCounts,
+		EscalatedHighRisk: escalatedPage.Total,
+	}, nil
+}
+
+// countQueues 按分诊视图统计各队列条目数。
+func (s *service) countQueues(ctx context.Context, resolved resolvedScope, view workbenchreadmodel.TriageView) (QueueCounts, error) {
+	highRiskPage, err := s.latestRiskReader.ListLatestRiskQueue(ctx,
+		latestRiskQueueFilter(resolved, s.triageFilter(QueueTypeHighRisk, view)),
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 1})
+	if err != nil {
+		return QueueCounts{}, errors.Wrap(err, "failed to count high risk queue")
+	}
+	followUpPage, err := s.followUpQueueReader.ListFollowUpQueueTasks(ctx,
+		followUpQueueFilter(resolved, s.triageFilter(QueueTypeFollowUp, view)),
+		planreadmodel.PageRequest{Page: 1, PageSize: 1})
+	if err != nil {
+		return QueueCounts{}, errors.Wrap(err, "failed to count follow-up queue")
+	}
+	keyFocusCount, err := s.testeeReader.CountTestees(ctx, s.keyFocusFilter(resolved, s.triageFilter(QueueTypeKeyFocus, view), 0, 0))
+	if err != nil {
+		return QueueCounts{}, errors.Wrap(err, "failed to count key focus queue")
+	}
+	var awaitingReviewCount int64
+	if s.awaitingReviewReader != nil {
+		reviewPage, err := s.awaitingReviewReader.ListAwaitingReviewQueue(ctx,
+			awaitingReviewQueueFilter(resolved, s.triageFilter(QueueTypeAwaitingReview, view)),
+			workbenchreadmodel.PageRequest{Page: 1, PageSize: 1})
+		if err != nil {
+			return QueueCounts{}, errors.Wrap(err, "failed to count awaiting review queue")
+		}
+		awaitingReviewCount = reviewPage.Total
+	}
+	var criticalItemCount int64
+	if s.criticalItemReader != nil {
+		criticalPage, err := s.criticalItemReader.ListCriticalItemQueue(ctx,
+			criticalItemQueueFilter(resolved, s.triageFilter(QueueTypeCriticalItem, view)),
+			workbenchreadmodel.PageRequest{Page: 1, PageSize: 1})
+		if err != nil {
+			return QueueCounts{}, errors.Wrap(err, "failed to count critical item queue")
+		}
+		criticalItemCount = criticalPage.Total
+	}
+	return QueueCounts{
+		HighRisk:       highRiskPage.Total,
+		FollowUp:       followUpPage.Total,
+		KeyFocus:       keyFocusCount,
+		AwaitingReview: awaitingReviewCount,
+		CriticalItem:   criticalItemCount,
 	}, nil
 }
 
@@ -120,6 +208,10 @@ func (s *service) ListQueue(ctx context.Context, dto ListQueueDTO) (*QueuePage,
 	if err != nil {
 		return nil, err
 	}
+	view, err := s.normalizeTriageView(dto.TriageView)
+	if err != nil {
+		return nil, err
+	}
 	page, pageSize := normalizePage(dto.Page, dto.PageSize)
 	resolved, ok, err := s.resolveScope(ctx, dto.Scope)
 	if err != nil {
@@ -129,20 +221,34 @@ func (s *service) ListQueue(ctx context.Context, dto ListQueueDTO) (*QueuePage,
 		return emptyQueuePage(queueType, page, pageSize), nil
 	}
 
+	var result *QueuePage
+	triage := s.triageFilter(queueType, view)
 	switch queueType {
 	case QueueTypeHighRisk:
-		return s.listHighRiskQueue(ctx, resolved, page, pageSize)
+		result, err = s.listHighRiskQueue(ctx, resolved, triage, page, pageSize)
 	case QueueTypeFollowUp:
-		return s.listFollowUpQueue(ctx, resolved, page, pageSize)
+		result, err = s.listFollowUpQueue(ctx, resolved, triage, page, pageSize)
 	case QueueTypeKeyFocus:
-		return s.listKeyFocusQueue(ctx, resolved, page, pageSize)
+		result, err = s.listKeyFocusQueue(ctx, resolved, triage, page, pageSize)
+	case QueueTypeAwaitingReview:
+		result, err = s.listAwaitingReviewQueue(ctx, resolved, triage, page, pageSize)
+	case QueueTypeCriticalItem:
+		result, err = s.listCriticalItemQueue(ctx, resolved, triage, page, pageSize)
 	default:
 		return nil, errors.WithCode(code.ErrInvalidArgument, "unsupported workbench queue type")
 	}
+	if err != nil {
+		return nil, err
+	}
+	result.Items, err = s.withTriage(ctx, resolved.OrgID, queueType, result.Items)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-func (s *service) listHighRiskQueue(ctx context.Context, resolved resolvedScope, page, pageSize int) (*QueuePage, error) {
-	riskPage, err := s.latestRiskReader.ListLatestRiskQueue(ctx, latestRiskQueueFilter(resolved), workbenchreadmodel.PageRequest{Page: page, PageSize: pageSize})
+func (s *service) listHighRiskQueue(ctx context.Context, resolved resolvedScope, triage workbenchreadmodel.TriageFilter, page, pageSize int) (*QueuePage, error) {
+	riskPage, err := s.latestRiskReader.ListLatestRiskQueue(ctx, latestRiskQueueFilter(resolved, triage), workbenchreadmodel.PageRequest{Page: page, PageSize: pageSize})
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +265,7 @@ func (s *service) listHighRiskQueue(ctx context.Context, resolved resolvedScope,
 		}
 		reasonAt := row.OccurredAt
 		items = append(items, QueueItem{
+			SubjectID:  row.AssessmentID,
 			Testee:     testee,
 			ReasonCode: latestRiskReasonCode(row.RiskLevel),
 			Reason:     latestRiskReason(row.RiskLevel),
@@ -172,16 +279,111 @@ func (s *service) listHighRiskQueue(ctx context.Context, resolved resolvedScope,
 			return nil, err
 		}
 	}
+	items, err = s.withBreakGlass(ctx, resolved, items)
+	if err != nil {
+		return nil, err
+	}
 
 	return queuePage(QueueTypeHighRisk, items, riskPage.Total, page, pageSize), nil
 }
 
-func (s *service) listFollowUpQueue(ctx context.Context, resolved resolvedScope, page, pageSize int) (*QueuePage, error) {
-	taskPage, err := s.followUpQueueReader.ListFollowUpQueueTasks(ctx, planreadmodel.FollowUpQueueFilter{
-		OrgID:               resolved.OrgID,
-		TesteeIDs:           resolved.TesteeIDs,
-		RestrictToTesteeIDs: resolved.RestrictToTesteeIDs,
-	}, planreadmodel.PageRequest{Page: page, PageSize: pageSize})
+func (s *service) listAwaitingReviewQueue(ctx context.Context, resolved resolvedScope, triage workbenchreadmodel.TriageFilter, page, pageSize int) (*QueuePage, error) {
+	if s.awaitingReviewReader == nil {
+		return emptyQueuePage(QueueTypeAwaitingReview, page, pageSize), nil
+	}
+	reviewPage, err := s.awaitingReviewReader.ListAwaitingReviewQueue(ctx, awaitingReviewQueueFilter(resolved, triage), workbenchreadmodel.PageRequest{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, err
+	}
+	testeeIDs := make([]uint64, 0, len(reviewPage.Items))
+	for _, row := range reviewPage.Items {
+		testeeIDs = append(testeeIDs, row.TesteeID)
+	}
+	testeesByID, err := s.hydrateTestees(ctx, resolved.OrgID, uniqueUint64(testeeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]QueueItem, 0, len(reviewPage.Items))
+	for _, row := range reviewPage.Items {
+		testee, ok := testeesByID[row.TesteeID]
+		if !ok {
+			continue
+		}
+		reasonAt := row.EvaluatedAt
+		items = append(items, QueueItem{
+			SubjectID:  row.AssessmentID,
+			Testee:     testee,
+			ReasonCode: awaitingReviewReasonCode(),
+			Reason:     awaitingReviewReason(),
+			ReasonAt:   &reasonAt,
+			RiskLevel:  strings.ToLower(row.RiskLevel),
+			Review:     &ReviewSummary{AssessmentID: row.AssessmentID, NoteVersion: row.NoteVersion},
+		})
+	}
+	if resolved.IncludeAssignments {
+		items, err = s.withAssignments(ctx, resolved.OrgID, items)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err = s.withBreakGlass(ctx, resolved, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return queuePage(QueueTypeAwaitingReview, items, reviewPage.Total, page, pageSize), nil
+}
+
+func (s *service) listCriticalItemQueue(ctx context.Context, resolved resolvedScope, triage workbenchreadmodel.TriageFilter, page, pageSize int) (*QueuePage, error) {
+	if s.criticalItemReader == nil {
+		return emptyQueuePage(QueueTypeCriticalItem, page, pageSize), nil
+	}
+	criticalPage, err := s.criticalItemReader.ListCriticalItemQueue(ctx, criticalItemQueueFilter(resolved, triage), workbenchreadmodel.PageRequest{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, err
+	}
+	testeeIDs := make([]uint64, 0, len(criticalPage.Items))
+	for _, row := range criticalPage.Items {
+		testeeIDs = append(testeeIDs, row.TesteeID)
+	}
+	testeesByID, err := s.hydrateTestees(ctx, resolved.OrgID, uniqueUint64(testeeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]QueueItem, 0, len(criticalPage.Items))
+	for _, row := range criticalPage.Items {
+		testee, ok := testeesByID[row.TesteeID]
+		if !ok {
+			continue
+		}
+		reasonAt := row.FlaggedAt
+		items = append(items, QueueItem{
+			SubjectID:    row.AnswerSheetID,
+			Testee:       testee,
+			ReasonCode:   criticalItemReasonCode(row.Categories),
+			Reason:       criticalItemReason(row.Categories),
+			ReasonAt:     &reasonAt,
+			CriticalItem: criticalItemSummary(row),
+		})
+	}
+	if resolved.IncludeAssignments {
+		items, err = s.withAssignments(ctx, resolved.OrgID, items)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err = s.withBreakGlass(ctx, resolved, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return queuePage(QueueTypeCriticalItem, items, criticalPage.Total, page, pageSize), nil
+}
+
+func (s *service) listFollowUpQueue(ctx context.Context, resolved resolvedScope, triage workbenchreadmodel.TriageFilter, page, pageSize int) (*QueuePage, error) {
+	taskPage, err := s.followUpQueueReader.ListFollowUpQueueTasks(ctx, followUpQueueFilter(resolved, triage), planreadmodel.PageRequest{Page: page, PageSize: pageSize})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list follow-up queue")
 	}
@@ -198,6 +400,7 @@ func (s *service) listFollowUpQueue(ctx context.Context, resolved resolvedScope,
 		}
 		reasonAt := followUpReasonAt(task)
 		items = append(items, QueueItem{
+			SubjectID:  task.ID,
 			Testee:     testee,
 			ReasonCode: followUpReasonCode(),
 			Reason:     followUpReason(),
@@ -211,12 +414,16 @@ func (s *service) listFollowUpQueue(ctx context.Context, resolved resolvedScope,
 			return nil, err
 		}
 	}
+	items, err = s.withBreakGlass(ctx, resolved, items)
+	if err != nil {
+		return nil, err
+	}
 
 	return queuePage(QueueTypeFollowUp, items, taskPage.Total, page, pageSize), nil
 }
 
-func (s *service) listKeyFocusQueue(ctx context.Context, resolved resolvedScope, page, pageSize int) (*QueuePage, error) {
-	filter := s.keyFocusFilter(resolved, (page-1)*pageSize, pageSize)
+func (s *service) listKeyFocusQueue(ctx context.Context, resolved resolvedScope, triage workbenchreadmodel.TriageFilter, page, pageSize int) (*QueuePage, error) {
+	filter := s.keyFocusFilter(resolved, triage, (page-1)*pageSize, pageSize)
 	rows, err := s.testeeReader.ListTestees(ctx, filter)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list key focus queue")
@@ -232,6 +439,7 @@ func (s *service) listKeyFocusQueue(ctx context.Context, resolved resolvedScope,
 	items := make([]QueueItem, 0, len(rows))
 	for _, row := range rows {
 		items = append(items, QueueItem{
+			SubjectID:  row.ID,
 			Testee:     testeeFromRow(row),
 			ReasonCode: "key_focus",
 			Reason:     "重点关注",
@@ -243,6 +451,10 @@ func (s *service) listKeyFocusQueue(ctx context.Context, resolved resolvedScope,
 			return nil, err
 		}
 	}
+	items, err = s.withBreakGlass(ctx, resolved, items)
+	if err != nil {
+		return nil, err
+	}
 	return queuePage(QueueTypeKeyFocus, items, total, page, pageSize), nil
 }
 
@@ -251,6 +463,8 @@ type resolvedScope struct {
 	TesteeIDs           []uint64
 	RestrictToTesteeIDs bool
 	IncludeAssignments  bool
+	// BreakGlassClinicianID 非 0 时只标记该从业者的紧急访问；机构管理员视角标记所有从业者的紧急访问。
+	BreakGlassClinicianID uint64
 }
 
 func (s resolvedScope) isEmpty() bool {
@@ -260,16 +474,32 @@ func (s resolvedScope) isEmpty() bool {
 func (s *service) resolveScope(ctx context.Context, scope Scope) (resolvedScope, bool, error) {
 	switch scope.Kind {
 	case "", ScopeKindClinicianMe:
-		ids, ok, err := s.assignedTesteeIDs(ctx, scope)
+		ids, clinicianID, ok, err := s.assignedTesteeIDs(ctx, scope)
 		return resolvedScope{
-			OrgID:               scope.OrgID,
-			TesteeIDs:           ids,
-			RestrictToTesteeIDs: true,
+			OrgID:                 scope.OrgID,
+			TesteeIDs:             ids,
+			RestrictToTesteeIDs:   true,
+			BreakGlassClinicianID: clinicianID,
 		}, ok, err
 	case ScopeKindOrgAdmin:
 		if scope.OrgID <= 0 {
 			return resolvedScope{}, false, nil
 		}
+		if scope.ClinicianID != nil && scope.TeamID != nil {
+			return resolvedScope{}, false, errors.WithCode(code.ErrInvalidArgument, "clinician_id and team_id cannot be combined")
+		}
+		if scope.TeamID != nil {
+			ids, err := s.teamTesteeIDs(ctx, scope.OrgID, *scope.TeamID)
+			if err != nil {
+				return resolvedScope{}, false, err
+			}
+			return resolvedScope{
+				OrgID:               scope.OrgID,
+				TesteeIDs:           ids,
+				RestrictToTesteeIDs: true,
+				IncludeAssignments:  true,
+			}, true, nil
+		}
 		if scope.ClinicianID == nil {
 			return resolvedScope{OrgID: scope.OrgID, IncludeAssignments: true}, true, nil
 		}
@@ -283,40 +513,100 @@ func (s *service) resolveScope(ctx context.Context, scope Scope) (resolvedScope,
 			RestrictToTesteeIDs: true,
 			IncludeAssignments:  true,
 		}, true, nil
+	case ScopeKindCareTeam:
+		return s.resolveCareTeamScope(ctx, scope)
 	default:
 		return resolvedScope{}, false, errors.WithCode(code.ErrInvalidArgument, "unsupported workbench scope")
 	}
 }
 
-func (s *service) assignedTesteeIDs(ctx context.Context, scope Scope) ([]uint64, bool, error) {
+func (s *service) assignedTesteeIDs(ctx context.Context, scope Scope) ([]uint64, uint64, bool, error) {
+	clinicianItem, err := s.currentClinician(ctx, scope)
+	if err != nil || clinicianItem == nil {
+		return nil, 0, false, err
+	}
+	ids, err := s.relationshipService.ListAssignedTesteeIDs(ctx, scope.OrgID, clinicianItem.ID)
+	if err != nil {
+		return nil, 0, false, errors.Wrap(err, "failed to list assigned testees")
+	}
+	sources := access.Sources{BreakGlass: s.breakGlassReader, CareTeams: s.careTeamReader}
+	inheritedIDs, err := sources.InheritedTesteeIDs(ctx, scope.OrgID, clinicianItem.ID, s.now())
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return uniqueUint64(append(ids, inheritedIDs...)), clinicianItem.ID, true, nil
+}
+
+// currentClinician 解析当前操作者绑定的在职从业者；未绑定或已停用时返回 nil, nil，工作台按空范围处理。
+func (s *service) currentClinician(ctx context.Context, scope Scope) (*clinicianApp.ClinicianResult, error) {
 	if scope.OrgID <= 0 || scope.OperatorUserID <= 0 {
-		return nil, false, nil
+		return nil, nil
 	}
 	operatorItem, err := s.operatorQuery.GetByUser(ctx, scope.OrgID, scope.OperatorUserID)
 	if err != nil {
 		if errors.IsCode(err, code.ErrUserNotFound) {
-			return nil, false, nil
+			return nil, nil
 		}
-		return nil, false, errors.Wrap(err, "failed to find current operator")
+		return nil, errors.Wrap(err, "failed to find current operator")
 	}
 	if operatorItem == nil || !operatorItem.IsActive {
-		return nil, false, nil
+		return nil, nil
 	}
 	clinicianItem, err := s.clinicianQuery.GetByOperator(ctx, scope.OrgID, operatorItem.ID)
 	if err != nil {
 		if errors.IsCode(err, code.ErrUserNotFound) {
-			return nil, false, nil
+			return nil, nil
 		}
-		return nil, false, errors.Wrap(err, "failed to find current clinician")
+		return nil, errors.Wrap(err, "failed to find current clinician")
 	}
 	if clinicianItem == nil || !clinicianItem.IsActive {
-		return nil, false, nil
+		return nil, nil
 	}
-	ids, err := s.relationshipService.ListAssignedTesteeIDs(ctx, scope.OrgID, clinicianItem.ID)
+	return clinicianItem, nil
+}
+
+// resolveCareTeamScope 照护团队视角：当前从业者必须是继承访问的团队成员，范围为团队受试者。
+// 团队受试者的紧急访问标记仍只针对当前从业者自己的授权。
+func (s *service) resolveCareTeamScope(ctx context.Context, scope Scope) (resolvedScope, bool, error) {
+	if scope.TeamID == nil || *scope.TeamID == 0 {
+		return resolvedScope{}, false, errors.WithCode(code.ErrInvalidArgument, "team_id is required for care team scope")
+	}
+	if s.careTeamReader == nil {
+		return resolvedScope{}, false, errors.WithCode(code.ErrInvalidArgument, "care team scope is not supported")
+	}
+	clinicianItem, err := s.currentClinician(ctx, scope)
+	if err != nil || clinicianItem == nil {
+		return resolvedScope{}, false, err
+	}
+	member, err := s.careTeamReader.FindMember(ctx, scope.OrgID, *scope.TeamID, clinicianItem.ID)
 	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to list assigned testees")
+		return resolvedScope{}, false, errors.Wrap(err, "failed to find care team membership")
 	}
-	return uniqueUint64(ids), true, nil
+	if member == nil || !member.Role.InheritsAccess() {
+		return resolvedScope{}, false, errors.WithCode(code.ErrPermissionDenied, "current clinician has no access through this care team")
+	}
+	ids, err := s.teamTesteeIDs(ctx, scope.OrgID, *scope.TeamID)
+	if err != nil {
+		return resolvedScope{}, false, err
+	}
+	return resolvedScope{
+		OrgID:                 scope.OrgID,
+		TesteeIDs:             ids,
+		RestrictToTesteeIDs:   true,
+		IncludeAssignments:    true,
+		BreakGlassClinicianID: clinicianItem.ID,
+	}, true, nil
+}
+
+func (s *service) teamTesteeIDs(ctx context.Context, orgID int64, teamID uint64) ([]uint64, error) {
+	if s.careTeamReader == nil {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "care team filter is not supported")
+	}
+	ids, err := s.careTeamReader.ListTeamTesteeIDs(ctx, orgID, teamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list care team testees")
+	}
+	return uniqueUint64(ids), nil
 }
 
 func (s *service) hydrateTestees(ctx context.Context, orgID int64, ids []uint64) (map[uint64]Testee, error) {
@@ -361,7 +651,7 @@ func (s *service) enrichTesteeRows(ctx context.Context, orgID int64, rows []acto
 	return nil
 }
 
-func (s *service) keyFocusFilter(scope resolvedScope, offset, limit int) actorreadmodel.TesteeFilter {
+func (s *service) keyFocusFilter(scope resolvedScope, triage workbenchreadmodel.TriageFilter, offset, limit int) actorreadmodel.TesteeFilter {
 	keyFocus := true
 	filter := actorreadmodel.TesteeFilter{
 		OrgID:                 scope.OrgID,
@@ -370,16 +660,45 @@ func (s *service) keyFocusFilter(scope resolvedScope, offset, limit int) actorre
 		RestrictToAccessScope: scope.RestrictToTesteeIDs,
 		Offset:                offset,
 		Limit:                 limit,
+		Triage:                triage,
 	}
 	return filter
 }
 
-func latestRiskQueueFilter(scope resolvedScope) workbenchreadmodel.LatestRiskQueueFilter {
+func latestRiskQueueFilter(scope resolvedScope, triage workbenchreadmodel.TriageFilter) workbenchreadmodel.LatestRiskQueueFilter {
 	return workbenchreadmodel.LatestRiskQueueFilter{
 		OrgID:               scope.OrgID,
 		TesteeIDs:           scope.TesteeIDs,
 		RestrictToTesteeIDs: scope.RestrictToTesteeIDs,
 		RiskLevels:          []string{"high", "severe"},
+		Triage:              triage,
+	}
+}
+
+func followUpQueueFilter(scope resolvedScope, triage workbenchreadmodel.TriageFilter) planreadmodel.FollowUpQueueFilter {
+	return planreadmodel.FollowUpQueueFilter{
+		OrgID:               scope.OrgID,
+		TesteeIDs:           scope.TesteeIDs,
+		RestrictToTesteeIDs: scope.RestrictToTesteeIDs,
+		Triage:              triage,
+	}
+}
+
+func awaitingReviewQueueFilter(scope resolvedScope, triage workbenchreadmodel.TriageFilter) workbenchreadmodel.AwaitingReviewQueueFilter {
+	return workbenchreadmodel.AwaitingReviewQueueFilter{
+		OrgID:               scope.OrgID,
+		TesteeIDs:           scope.TesteeIDs,
+		RestrictToTesteeIDs: scope.RestrictToTesteeIDs,
+		Triage:              triage,
+	}
+}
+
+func criticalItemQueueFilter(scope resolvedScope, triage workbenchreadmodel.TriageFilter) workbenchreadmodel.CriticalItemQueueFilter {
+	return workbenchreadmodel.CriticalItemQueueFilter{
+		OrgID:               scope.OrgID,
+		TesteeIDs:           scope.TesteeIDs,
+		RestrictToTesteeIDs: scope.RestrictToTesteeIDs,
+		Triage:              triage,
 	}
 }
 
@@ -407,6 +726,36 @@ func (s *service) withAssignments(ctx context.Context, orgID int64, items []Queu
 	return result, nil
 }
 
+// withBreakGlass 标记凭紧急访问授权进入队列的受试者，便于从业者与管理员识别非常规访问。
+func (s *service) withBreakGlass(ctx context.Context, scope resolvedScope, items []QueueItem) ([]QueueItem, error) {
+	if s.breakGlassReader == nil || len(items) == 0 {
+		return items, nil
+	}
+	grants, err := s.breakGlassReader.ListActiveGrants(ctx, breakglass.ActiveGrantQuery{
+		OrgID:       scope.OrgID,
+		ClinicianID: scope.BreakGlassClinicianID,
+		TesteeIDs:   queueItemTesteeIDs(items),
+	}, s.now())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hydrate queue break-glass grants")
+	}
+	if len(grants) == 0 {
+		return items, nil
+	}
+	byTesteeID := make(map[uint64][]BreakGlassAccess, len(grants))
+	for _, grant := range grants {
+		byTesteeID[grant.TesteeID] = append(byTesteeID[grant.TesteeID], BreakGlassAccess{
+			GrantID:     grant.ID,
+			ClinicianID: grant.ClinicianID,
+			ExpiresAt:   grant.ExpiresAt,
+		})
+	}
+	for i := range items {
+		items[i].BreakGlass = byTesteeID[items[i].Testee.ID]
+	}
+	return items, nil
+}
+
 func groupAssignmentsByTesteeID(rows []actorreadmodel.TesteeRelationRow) map[uint64][]ClinicianAssignment {
 	result := make(map[uint64][]ClinicianAssignment)
 	for _, row := range rows {
@@ -468,6 +817,10 @@ func normalizeQueueType(raw QueueType) (QueueType, error) {
 		return QueueTypeFollowUp, nil
 	case QueueTypeKeyFocus:
 		return QueueTypeKeyFocus, nil
+	case QueueTypeAwaitingReview:
+		return QueueTypeAwaitingReview, nil
+	case QueueTypeCriticalItem:
+		return QueueTypeCriticalItem, nil
 	default:
 		return "", errors.WithCode(code.ErrInvalidArgument, "unsupported workbench queue type")
 	}
@@ -522,6 +875,55 @@ func latestRiskReason(riskLevel string) string {
 	return "最近测评高风险"
 }
 
+func awaitingReviewReasonCode() string {
+	return "report_awaiting_review"
+}
+
+func awaitingReviewReason() string {
+	return "报告待临床复核"
+}
+
+// criticalItemReasonCode 同一答卷命中多类关键条目时，自伤类优先于其他类别。
+func criticalItemReasonCode(categories []string) string {
+	return "critical_item_" + primaryCriticalCategory(categories)
+}
+
+func criticalItemReason(categories []string) string {
+	switch primaryCriticalCategory(categories) {
+	case "self_harm":
+		return "答卷命中自伤关键条目"
+	case "abuse":
+		return "答卷命中受虐关键条目"
+	default:
+		return "答卷命中关键条目"
+	}
+}
+
+func primaryCriticalCategory(categories []string) string {
+	for _, preferred := range []string{"self_harm", "abuse"} {
+		for _, category := range categories {
+			if category == preferred {
+				return preferred
+			}
+		}
+	}
+	return "other"
+}
+
+func criticalItemSummary(row workbenchreadmodel.CriticalItemRow) *CriticalItemSummary {
+	hits := make([]CriticalItemHit, 0, len(row.Items))
+	for _, item := range row.Items {
+		hits = append(hits, CriticalItemHit{QuestionCode: item.QuestionCode, Category: item.Category, Trigger: item.Trigger})
+	}
+	return &CriticalItemSummary{
+		AnswerSheetID:        row.AnswerSheetID,
+		QuestionnaireCode:    row.QuestionnaireCode,
+		QuestionnaireVersion: row.QuestionnaireVersion,
+		Categories:           row.Categories,
+		Items:                hits,
+	}
+}
+
 func followUpReasonCode() string {
 	return "follow_up_opened"
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
@@ -151,6 +153,124 @@ func TestServiceListKeyFocusQueueUsesAssignedScope(t *testing.T) {
 	}
 }
 
+func TestServiceClinicianScopeIncludesAndFlagsBreakGlassTestees(t *testing.T) {
+	testees := &testeeReaderStub{
+		listRows: []actorreadmodel.TesteeRow{{ID: 2, OrgID: 9, Name: "B", IsKeyFocus: true}, {ID: 7, OrgID: 9, Name: "G", IsKeyFocus: true}},
+		count:    2,
+	}
+	expiresAt := time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)
+	grants := &breakGlassReaderStub{grants: []breakglass.Grant{{ID: 55, OrgID: 9, ClinicianID: 20, TesteeID: 7, ExpiresAt: expiresAt}}}
+	svc := NewService(
+		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
+		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, IsActive: true}},
+		&assignmentReaderStub{ids: []uint64{2}},
+		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, grants, nil, nil, nil, nil, &assessmentSummaryReaderStub{},
+	)
+
+	page, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}, QueueType: QueueTypeKeyFocus, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := testees.lastFilter.AccessibleTesteeIDs; len(ids) != 2 || ids[0] != 2 || ids[1] != 7 {
+		t.Fatalf("accessible ids = %v, want [2 7]", ids)
+	}
+	if len(page.Items) != 2 || len(page.Items[0].BreakGlass) != 0 || len(page.Items[1].BreakGlass) != 1 {
+		t.Fatalf("break-glass flags = %#v", page.Items)
+	}
+	if flag := page.Items[1].BreakGlass[0]; flag.GrantID != 55 || !flag.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("break-glass flag = %#v", flag)
+	}
+	if grants.lastQuery.ClinicianID != 20 {
+		t.Fatalf("break-glass query = %#v, want clinician 20", grants.lastQuery)
+	}
+}
+
+func TestServiceCareTeamScopeRequiresAccessInheritingMembership(t *testing.T) {
+	testees := &testeeReaderStub{
+		listRows: []actorreadmodel.TesteeRow{{ID: 5, OrgID: 9, Name: "E", IsKeyFocus: true}},
+		count:    1,
+	}
+	teams := &careTeamReaderStub{
+		members:   map[uint64]careteam.MemberRole{20: careteam.RoleMember},
+		teamIDs:   []uint64{5, 6, 5},
+		inherited: []uint64{5},
+	}
+	svc := NewService(
+		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
+		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, IsActive: true}},
+		&assignmentReaderStub{ids: []uint64{2}},
+		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, nil, teams, nil, nil, nil, &assessmentSummaryReaderStub{},
+	)
+	teamID := uint64(88)
+
+	if _, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: Scope{Kind: ScopeKindCareTeam, OrgID: 9, OperatorUserID: 701, TeamID: &teamID}, QueueType: QueueTypeKeyFocus}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := testees.lastFilter.AccessibleTesteeIDs; len(ids) != 2 || ids[0] != 5 || ids[1] != 6 {
+		t.Fatalf("team scope ids = %v, want [5 6]", ids)
+	}
+
+	// 本人视角合并团队继承的受试者。
+	if _, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}, QueueType: QueueTypeKeyFocus}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := testees.lastFilter.AccessibleTesteeIDs; len(ids) != 2 || ids[0] != 2 || ids[1] != 5 {
+		t.Fatalf("clinician scope ids = %v, want [2 5]", ids)
+	}
+
+	teams.members[20] = careteam.RoleObserver
+	if _, err := svc.GetSummary(context.Background(), Scope{Kind: ScopeKindCareTeam, OrgID: 9, OperatorUserID: 701, TeamID: &teamID}); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("observer err = %v, want permission denied", err)
+	}
+	if _, err := svc.GetSummary(context.Background(), Scope{Kind: ScopeKindCareTeam, OrgID: 9, OperatorUserID: 701}); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("missing team err = %v, want invalid argument", err)
+	}
+	clinicianID := uint64(20)
+	if _, err := svc.GetSummary(context.Background(), Scope{Kind: ScopeKindOrgAdmin, OrgID: 9, ClinicianID: &clinicianID, TeamID: &teamID}); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("combined filter err = %v, want invalid argument", err)
+	}
+}
+
+type careTeamReaderStub struct {
+	members   map[uint64]careteam.MemberRole
+	teamIDs   []uint64
+	inherited []uint64
+}
+
+func (s *careTeamReaderStub) HasTeamAccess(context.Context, int64, uint64, uint64) (bool, error) {
+	panic("unexpected call")
+}
+
+func (s *careTeamReaderStub) ListAccessibleTesteeIDs(context.Context, int64, uint64) ([]uint64, error) {
+	return s.inherited, nil
+}
+
+func (s *careTeamReaderStub) ListTeamTesteeIDs(context.Context, int64, uint64) ([]uint64, error) {
+	return s.teamIDs, nil
+}
+
+func (s *careTeamReaderStub) FindMember(_ context.Context, _ int64, teamID, clinicianID uint64) (*careteam.Member, error) {
+	role, ok := s.members[clinicianID]
+	if !ok {
+		return nil, nil
+	}
+	return &careteam.Member{TeamID: teamID, ClinicianID: clinicianID, Role: role}, nil
+}
+
+type breakGlassReaderStub struct {
+	grants    []breakglass.Grant
+	lastQuery breakglass.ActiveGrantQuery
+}
+
+func (s *breakGlassReaderStub) HasActiveGrant(context.Context, int64, uint64, uint64, time.Time) (bool, error) {
+	panic("unexpected call")
+}
+
+func (s *breakGlassReaderStub) ListActiveGrants(_ context.Context, query breakglass.ActiveGrantQuery, _ time.Time) ([]breakglass.Grant, error) {
+	s.lastQuery = query
+	return s.grants, nil
+}
+
 type assessmentSummaryReaderStub struct {
 	calls  int
 	err    error
@@ -162,6 +282,85 @@ func (s *assessmentSummaryReaderStub) ReadAssessmentSummaries(context.Context, i
 	return s.values, s.err
 }
 
+func TestServiceAwaitingReviewQueueListsUnsignedReportsInScope(t *testing.T) {
+	evaluatedAt := time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC)
+	testees := &testeeReaderStub{rowsByID: map[uint64]actorreadmodel.TesteeRow{2: testeeRow(2, "B")}}
+	reviews := &awaitingReviewReaderStub{rows: []evaluationreadmodel.AwaitingReviewRow{
+		{AssessmentID: 5001, OrgID: 9, TesteeID: 2, RiskLevel: "HIGH", NoteVersion: 1, EvaluatedAt: evaluatedAt},
+	}}
+	svc := NewService(
+		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
+		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, IsActive: true}},
+		&assignmentReaderStub{ids: []uint64{2}},
+		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, nil, nil, reviews, nil, nil, &assessmentSummaryReaderStub{},
+	)
+	scope := Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}
+
+	page, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: scope, QueueType: QueueTypeAwaitingReview, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 {
+		t.Fatalf("total/items = %d/%d, want 1/1", page.Total, len(page.Items))
+	}
+	item := page.Items[0]
+	if item.Testee.ID != 2 || item.ReasonCode != "report_awaiting_review" || item.RiskLevel != "high" || item.Review == nil || item.Review.AssessmentID != 5001 || item.Review.NoteVersion != 1 {
+		t.Fatalf("unexpected item: %#v", item)
+	}
+	if !reviews.lastFilter.RestrictToTesteeIDs || len(reviews.lastFilter.TesteeIDs) != 1 || reviews.lastFilter.TesteeIDs[0] != 2 {
+		t.Fatalf("awaiting review filter did not keep assigned scope: %#v", reviews.lastFilter)
+	}
+
+	summary, err := svc.GetSummary(context.Background(), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Counts.AwaitingReview != 1 {
+		t.Fatalf("awaiting review count = %d, want 1", summary.Counts.AwaitingReview)
+	}
+}
+
+func TestServiceCriticalItemQueueListsFlaggedAnswerSheetsInScope(t *testing.T) {
+	flaggedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	testees := &testeeReaderStub{rowsByID: map[uint64]actorreadmodel.TesteeRow{2: testeeRow(2, "B")}}
+	criticals := &criticalItemReaderStub{rows: []evaluationreadmodel.CriticalItemRow{{
+		AnswerSheetID: 7001, OrgID: 9, TesteeID: 2, QuestionnaireCode: "PHQ9", QuestionnaireVersion: "1.0.0",
+		Categories: []string{"other", "self_harm"},
+		Items:      []evaluationreadmodel.CriticalItemHit{{QuestionCode: "Q9", Category: "self_harm", Trigger: "opt-3"}},
+		FlaggedAt:  flaggedAt,
+	}}}
+	svc := NewService(
+		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
+		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, IsActive: true}},
+		&assignmentReaderStub{ids: []uint64{2}},
+		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, nil, nil, nil, criticals, nil, &assessmentSummaryReaderStub{},
+	)
+	scope := Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}
+
+	page, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: scope, QueueType: QueueTypeCriticalItem, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 {
+		t.Fatalf("total/items = %d/%d, want 1/1", page.Total, len(page.Items))
+	}
+	item := page.Items[0]
+	if item.SubjectID != 7001 || item.ReasonCode != "critical_item_self_harm" || item.CriticalItem == nil || len(item.CriticalItem.Items) != 1 || item.CriticalItem.Items[0].Trigger != "opt-3" {
+		t.Fatalf("unexpected item: %#v", item)
+	}
+	if !criticals.lastFilter.RestrictToTesteeIDs || len(criticals.lastFilter.TesteeIDs) != 1 || criticals.lastFilter.TesteeIDs[0] != 2 {
+		t.Fatalf("critical item filter did not keep assigned scope: %#v", criticals.lastFilter)
+	}
+
+	summary, err := svc.GetSummary(context.Background(), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Counts.CriticalItem != 1 {
+		t.Fatalf("critical item count = %d, want 1", summary.Counts.CriticalItem)
+	}
+}
+
 func TestServiceKeyFocusQueueUsesEvaluationSummaryAndPropagatesFailure(t *testing.T) {
 	stale := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	latest := time.Date(2026, 8, 2, 8, 0, 0, 0, time.UTC)
@@ -176,7 +375,7 @@ func TestServiceKeyFocusQueueUsesEvaluationSummaryAndPropagatesFailure(t *testin
 		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
 		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, IsActive: true}},
 		&assignmentReaderStub{ids: []uint64{2}},
-		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, summary,
+		&assignmentHydratorStub{}, testees, &latestRiskReaderStub{}, &followUpReaderStub{}, nil, nil, nil, nil, nil, summary,
 	)
 
 	page, err := svc.ListQueue(context.Background(), ListQueueDTO{Scope: Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}, QueueType: QueueTypeKeyFocus, Page: 1, PageSize: 10})
@@ -202,6 +401,11 @@ func TestServiceGetSummaryReturnsEmptyWhenOperatorIsNotBoundToClinician(t *testi
 		&testeeReaderStub{},
 		&latestRiskReaderStub{},
 		&followUpReaderStub{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 		&assessmentSummaryReaderStub{},
 	)
 
@@ -285,6 +489,11 @@ func TestServiceListOrgAdminQueueWithClinicianFilterUsesAssignedScope(t *testing
 		testees,
 		&latestRiskReaderStub{},
 		&followUpReaderStub{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 		&assessmentSummaryReaderStub{},
 	)
 	clinicianID := uint64(20)
@@ -320,6 +529,11 @@ func newTestService(
 		testees,
 		latestRisks,
 		followUps,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 		&assessmentSummaryReaderStub{},
 	)
 }
@@ -401,6 +615,36 @@ func (s *latestRiskReaderStub) ListLatestRiskQueue(_ context.Context, filter eva
 	}, nil
 }
 
+type awaitingReviewReaderStub struct {
+	rows       []evaluationreadmodel.AwaitingReviewRow
+	lastFilter evaluationreadmodel.AwaitingReviewQueueFilter
+}
+
+func (s *awaitingReviewReaderStub) ListAwaitingReviewQueue(_ context.Context, filter evaluationreadmodel.AwaitingReviewQueueFilter, page evaluationreadmodel.PageRequest) (evaluationreadmodel.AwaitingReviewPage, error) {
+	s.lastFilter = filter
+	return evaluationreadmodel.AwaitingReviewPage{
+		Items:    append([]evaluationreadmodel.AwaitingReviewRow(nil), s.rows...),
+		Total:    int64(len(s.rows)),
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}, nil
+}
+
+type criticalItemReaderStub struct {
+	rows       []evaluationreadmodel.CriticalItemRow
+	lastFilter evaluationreadmodel.CriticalItemQueueFilter
+}
+
+func (s *criticalItemReaderStub) ListCriticalItemQueue(_ context.Context, filter evaluationreadmodel.CriticalItemQueueFilter, page evaluationreadmodel.PageRequest) (evaluationreadmodel.CriticalItemPage, error) {
+	s.lastFilter = filter
+	return evaluationreadmodel.CriticalItemPage{
+		Items:    append([]evaluationreadmodel.CriticalItemRow(nil), s.rows...),
+		Total:    int64(len(s.rows)),
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}, nil
+}
+
 type followUpReaderStub struct {
 	page       planreadmodel.TaskPage
 	err        error
@@ -0,0 +1,92 @@
+package workbench
+
+import (
+	"context"
+	"time"
+
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+)
+
+type (
+	TriageStatus   = domaintriage.Status
+	TriageAction   = domaintriage.Action
+	ResolutionCode = domaintriage.ResolutionCode
+	TriageActor    = domaintriage.Actor
+	TriageItem     = domaintriage.Item
+	TriageEvent    = domaintriage.Event
+)
+
+const (
+	TriageStatusOpen     = domaintriage.StatusOpen
+	TriageStatusClaimed  = domaintriage.StatusClaimed
+	TriageStatusSnoozed  = domaintriage.StatusSnoozed
+	TriageStatusResolved = domaintriage.StatusResolved
+
+	TriageActionClaim    = domaintriage.ActionClaim
+	TriageActionSnooze   = domaintriage.ActionSnooze
+	TriageActionResolve  = domaintriage.ActionResolve
+	TriageActionReopen   = domaintriage.ActionReopen
+	TriageActionEscalate = domaintriage.ActionEscalate
+
+	ResolutionContacted            = domaintriage.ResolutionContacted
+	ResolutionAppointmentScheduled = domaintriage.ResolutionAppointmentScheduled
+	ResolutionReferred             = domaintriage.ResolutionReferred
+	ResolutionFalsePositive        = domaintriage.ResolutionFalsePositive
+	ResolutionNoActionNeeded       = domaintriage.ResolutionNoActionNeeded
+	ResolutionOther                = domaintriage.ResolutionOther
+)
+
+const (
+	maxTriageNoteRunes = 1000
+	maxSnoozeDuration  = 30 * 24 * time.Hour
+)
+
+// TriageItemView 条目当前分诊状态与完整历史。
+type TriageItemView struct {
+	Item   TriageItem
+	Events []TriageEvent
+}
+
+// TriageState 队列列表中条目的分诊状态摘要。
+type TriageState struct {
+	Status         TriageStatus
+	ClaimedBy      *TriageActor
+	ClaimedAt      *time.Time
+	SnoozedUntil   *time.Time
+	ResolutionCode ResolutionCode
+	EscalatedAt    *time.Time
+	Version        int
+	// SLADueAt 高风险条目的认领截止时间；未配置认领时限或非高风险队列时为空。
+	SLADueAt *time.Time
+	// SLABreached 已超过认领截止时间且仍未认领。
+	SLABreached bool
+}
+
+// TriageDTO 队列条目分诊请求。SnoozedUntil 仅用于暂缓，ResolutionCode 仅用于解决。
+type TriageDTO struct {
+	Scope
+	QueueType      QueueType
+	SubjectID      uint64
+	SnoozedUntil   *time.Time
+	ResolutionCode ResolutionCode
+	Note           string
+}
+
+// TriageConfig 工作台分诊配置；为 nil 时队列不区分分诊状态，分诊动作不可用。
+type TriageConfig struct {
+	Store TriageStore
+	// HighRiskClaimSLA 高风险条目的认领时限，0 表示不计算 SLA。
+	HighRiskClaimSLA time.Duration
+}
+
+// TriageStore 分诊状态与历史存储。
+type TriageStore interface {
+	FindTriageItem(ctx context.Context, orgID int64, queueType QueueType, subjectID uint64) (*TriageItem, error)
+	ListTriageItems(ctx context.Context, orgID int64, queueType QueueType, subjectIDs []uint64) ([]TriageItem, error)
+	ListTriageEvents(ctx context.Context, orgID int64, itemID uint64) ([]TriageEvent, error)
+	// SaveTriageItem 按 expectedVersion 乐观锁保存条目并在同一事务中追加历史；expectedVersion 为 0 表示新建。
+	// 返回 false 表示条目已被并发修改。
+	SaveTriageItem(ctx context.Context, item *TriageItem, expectedVersion int, event *TriageEvent) (bool, error)
+	// FindSubjectTesteeID 校验条目主体属于该机构并返回所属受试者 ID；不存在时返回 0。
+	FindSubjectTesteeID(ctx context.Context, orgID int64, queueType QueueType, subjectID uint64) (uint64, error)
+}
@@ -0,0 +1,110 @@
+package workbench
+
+import (
+	"context"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// EscalationCandidate 超过认领时限仍未认领、尚未升级的高风险条目。
+type EscalationCandidate = domaintriage.EscalationCandidate
+
+// EscalationStore 升级扫描所需的存储能力，即分诊仓储。
+type EscalationStore = domaintriage.Repository
+
+// Escalator 高风险条目认领 SLA 升级。
+type Escalator interface {
+	// EscalateOverdue 升级一批超时未认领的高风险条目，返回本次升级的条目数。
+	EscalateOverdue(ctx context.Context, limit int) (int, error)
+}
+
+type escalator struct {
+	store EscalationStore
+	sla   time.Duration
+	now   func() time.Time
+}
+
+// NewEscalator 创建高风险条目升级器；sla 为认领时限。
+func NewEscalator(store EscalationStore, sla time.Duration) Escalator {
+	return &escalator{store: store, sla: sla, now: time.Now}
+}
+
+func (e *escalator) EscalateOverdue(ctx context.Context, limit int) (int, error) {
+	if e.store == nil || e.sla <= 0 {
+		return 0, errors.WithCode(code.ErrModuleInitializationFailed, "workbench triage escalation is not configured")
+	}
+	now := e.now().UTC()
+	candidates, err := e.store.ListEscalationCandidates(ctx, now.Add(-e.sla), now, limit)
+	if err != nil {
+		return 0, errors.WrapC(err, code.ErrDatabase, "list workbench escalation candidates")
+	}
+	escalated := 0
+	for _, candidate := range candidates {
+		ok, err := e.escalate(ctx, candidate, now)
+		if err != nil {
+			return escalated, err
+		}
+		if ok {
+			escalated++
+		}
+	}
+	return escalated, nil
+}
+
+// escalate 标记条目已升级并记录系统事件；条目在扫描后已被认领、处理或并发修改时跳过。
+func (e *escalator) escalate(ctx context.Context, candidate EscalationCandidate, now time.Time) (bool, error) {
+	item, err := e.store.FindTriageItem(ctx, candidate.OrgID, QueueTypeHighRisk, candidate.AssessmentID)
+	if err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "find workbench triage item")
+	}
+	if item == nil {
+		item = &TriageItem{
+			ID:        meta.New().Uint64(),
+			OrgID:     candidate.OrgID,
+			QueueType: QueueTypeHighRisk,
+			SubjectID: candidate.AssessmentID,
+			TesteeID:  candidate.TesteeID,
+			Status:    TriageStatusOpen,
+			CreatedAt: now,
+		}
+	}
+	from := item.EffectiveStatus(now)
+	if from != TriageStatusOpen || item.EscalatedAt != nil {
+		return false, nil
+	}
+	expectedVersion := item.Version
+	item.Status = TriageStatusOpen
+	item.SnoozedUntil = nil
+	item.EscalatedAt = &now
+	item.Version = expectedVersion + 1
+	item.UpdatedAt = now
+	saved, err := e.store.SaveTriageItem(ctx, item, expectedVersion, &TriageEvent{
+		ID:         meta.New().Uint64(),
+		OrgID:      item.OrgID,
+		ItemID:     item.ID,
+		Action:     TriageActionEscalate,
+		FromStatus: from,
+		ToStatus:   TriageStatusOpen,
+		Note:       "high risk item unclaimed past SLA",
+		OccurredAt: now,
+	})
+	if err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "escalate workbench triage item")
+	}
+	if saved {
+		logger.L(ctx).Warnw("high risk workbench item escalated",
+			"action", "escalate_workbench_item",
+			"org_id", item.OrgID,
+			"assessment_id", item.SubjectID,
+			"testee_id", item.TesteeID,
+			"occurred_at", candidate.OccurredAt,
+			"sla", e.sla.String(),
+		)
+	}
+	return saved, nil
+}
@@ -0,0 +1,355 @@
+package workbench
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func (s *service) GetTriageItem(ctx context.Context, dto TriageDTO) (*TriageItemView, error) {
+	target, err := s.triageTarget(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return s.triageView(ctx, target.item)
+}
+
+func (s *service) Claim(ctx context.Context, dto TriageDTO) (*TriageItemView, error) {
+	return s.applyTriage(ctx, dto, TriageActionClaim, func(target *triageTarget, from TriageStatus, now time.Time) (bool, error) {
+		item := target.item
+		switch {
+		case from == TriageStatusResolved:
+			return false, errors.WithCode(code.ErrWorkbenchTriageConflict, "resolved item must be reopened before claiming")
+		case from == TriageStatusClaimed && item.ClaimedBy != nil && item.ClaimedBy.UserID == target.actor.UserID:
+			return false, nil
+		}
+		if err := target.ensureNotClaimedByOther(from); err != nil {
+			return false, err
+		}
+		item.Status = TriageStatusClaimed
+		item.ClaimedBy = &target.actor
+		item.ClaimedAt = &now
+		item.SnoozedUntil = nil
+		return true, nil
+	})
+}
+
+func (s *service) Snooze(ctx context.Context, dto TriageDTO) (*TriageItemView, error) {
+	return s.applyTriage(ctx, dto, TriageActionSnooze, func(target *triageTarget, from TriageStatus, now time.Time) (bool, error) {
+		if dto.SnoozedUntil == nil || !dto.SnoozedUntil.After(now) {
+			return false, errors.WithCode(code.ErrInvalidArgument, "snoozed_until must be in the future")
+		}
+		if dto.SnoozedUntil.Sub(now) > maxSnoozeDuration {
+			return false, errors.WithCode(code.ErrInvalidArgument, "snooze cannot exceed %d days", int(maxSnoozeDuration/(24*time.Hour)))
+		}
+		if from == TriageStatusResolved {
+			return false, errors.WithCode(code.ErrWorkbenchTriageConflict, "resolved item cannot be snoozed")
+		}
+		if err := target.ensureNotClaimedByOther(from); err != nil {
+			return false, err
+		}
+		until := dto.SnoozedUntil.UTC()
+		item := target.item
+		item.Status = TriageStatusSnoozed
+		item.SnoozedUntil = &until
+		item.ClaimedBy = nil
+		item.ClaimedAt = nil
+		return true, nil
+	})
+}
+
+func (s *service) Resolve(ctx context.Context, dto TriageDTO) (*TriageItemView, error) {
+	return s.applyTriage(ctx, dto, TriageActionResolve, func(target *triageTarget, from TriageStatus, now time.Time) (bool, error) {
+		resolution := ResolutionCode(strings.TrimSpace(string(dto.ResolutionCode)))
+		if !resolution.Valid() {
+			return false, errors.WithCode(code.ErrInvalidArgument, "unsupported resolution code %q", dto.ResolutionCode)
+		}
+		if resolution == ResolutionOther && target.note == "" {
+			return false, errors.WithCode(code.ErrInvalidArgument, "note is required when resolution code is other")
+		}
+		if from == TriageStatusResolved {
+			return false, errors.WithCode(code.ErrWorkbenchTriageConflict, "item is already resolved")
+		}
+		if err := target.ensureNotClaimedByOther(from); err != nil {
+			return false, err
+		}
+		item := target.item
+		item.Status = TriageStatusResolved
+		item.SnoozedUntil = nil
+		item.ResolutionCode = resolution
+		item.ResolutionNote = target.note
+		item.ResolvedBy = &target.actor
+		item.ResolvedAt = &now
+		return true, nil
+	})
+}
+
+func (s *service) Reopen(ctx context.Context, dto TriageDTO) (*TriageItemView, error) {
+	return s.applyTriage(ctx, dto, TriageActionReopen, func(target *triageTarget, from TriageStatus, _ time.Time) (bool, error) {
+		if from == TriageStatusOpen {
+			return false, errors.WithCode(code.ErrWorkbenchTriageConflict, "item is already open")
+		}
+		if err := target.ensureNotClaimedByOther(from); err != nil {
+			return false, err
+		}
+		item := target.item
+		item.Status = TriageStatusOpen
+		item.ClaimedBy = nil
+		item.ClaimedAt = nil
+		item.SnoozedUntil = nil
+		item.ResolutionCode = ""
+		item.ResolutionNote = ""
+		item.ResolvedBy = nil
+		item.ResolvedAt = nil
+		return true, nil
+	})
+}
+
+// triageTarget 分诊动作的目标条目与操作人。
+type triageTarget struct {
+	item  *TriageItem
+	actor TriageActor
+	// override 机构管理员可以处理他人已认领的条目。
+	override bool
+	note     string
+}
+
+func (t *triageTarget) ensureNotClaimedByOther(from TriageStatus) error {
+	if from != TriageStatusClaimed || t.override || t.item.ClaimedBy == nil || t.item.ClaimedBy.UserID == t.actor.UserID {
+		return nil
+	}
+	return errors.WithCode(code.ErrWorkbenchTriageConflict, "item is claimed by %s", claimedByLabel(t.item.ClaimedBy))
+}
+
+// applyTriage 校验范围后按 mutate 修改条目，并以乐观锁保存状态与历史。mutate 返回 false 表示无需变更（幂等）。
+func (s *service) applyTriage(
+	ctx context.Context,
+	dto TriageDTO,
+	action TriageAction,
+	mutate func(target *triageTarget, from TriageStatus, now time.Time) (bool, error),
+) (*TriageItemView, error) {
+	note := strings.TrimSpace(dto.Note)
+	if utf8.RuneCountInString(note) > maxTriageNoteRunes {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "note must be at most %d characters", maxTriageNoteRunes)
+	}
+	target, err := s.triageTarget(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	target.note = note
+
+	now := s.now().UTC()
+	item := target.item
+	expectedVersion := item.Version
+	from := item.EffectiveStatus(now)
+	changed, err := mutate(target, from, now)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return s.triageView(ctx, item)
+	}
+
+	if item.ID == 0 {
+		item.ID = meta.New().Uint64()
+		item.CreatedAt = now
+	}
+	item.Version = expectedVersion + 1
+	item.UpdatedAt = now
+	actor := target.actor
+	event := &TriageEvent{
+		ID:           meta.New().Uint64(),
+		OrgID:        item.OrgID,
+		ItemID:       item.ID,
+		Action:       action,
+		FromStatus:   from,
+		ToStatus:     item.Status,
+		Operator:     &actor,
+		ReasonCode:   string(item.ResolutionCode),
+		Note:         note,
+		SnoozedUntil: item.SnoozedUntil,
+		OccurredAt:   now,
+	}
+	if action != TriageActionResolve {
+		event.ReasonCode = ""
+	}
+	saved, err := s.triageStore.SaveTriageItem(ctx, item, expectedVersion, event)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save workbench triage item")
+	}
+	if !saved {
+		return nil, errors.WithCode(code.ErrWorkbenchTriageConflict, "item was changed by someone else, reload and retry")
+	}
+	logger.L(ctx).Infow("workbench item triaged",
+		"action", "triage_workbench_item",
+		"triage_action", string(action),
+		"org_id", item.OrgID,
+		"queue_type", string(item.QueueType),
+		"subject_id", item.SubjectID,
+		"from_status", string(from),
+		"to_status", string(item.Status),
+		"operator_user_id", actor.UserID,
+	)
+	return s.triageView(ctx, item)
+}
+
+// triageTarget 解析操作人范围并加载条目；条目所属受试者必须在当前视角范围内。
+func (s *service) triageTarget(ctx context.Context, dto TriageDTO) (*triageTarget, error) {
+	if s.triageStore == nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "workbench triage is not configured")
+	}
+	if err := s.ensureConfigured(); err != nil {
+		return nil, err
+	}
+	queueType, err := normalizeQueueType(dto.QueueType)
+	if err != nil {
+		return nil, err
+	}
+	if dto.OrgID <= 0 || dto.OperatorUserID <= 0 || dto.SubjectID == 0 {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "operator identity and subject ID are required")
+	}
+	resolved, ok, err := s.resolveScope(ctx, dto.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "current operator cannot triage workbench items")
+	}
+
+	item, err := s.triageStore.FindTriageItem(ctx, dto.OrgID, queueType, dto.SubjectID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "find workbench triage item")
+	}
+	if item == nil {
+		testeeID, err := s.triageStore.FindSubjectTesteeID(ctx, dto.OrgID, queueType, dto.SubjectID)
+		if err != nil {
+			return nil, errors.WrapC(err, code.ErrDatabase, "find workbench queue subject")
+		}
+		if testeeID == 0 {
+			return nil, errors.WithCode(code.ErrWorkbenchTriageItemNotFound, "%s item %d not found", queueType, dto.SubjectID)
+		}
+		item = &TriageItem{OrgID: dto.OrgID, QueueType: queueType, SubjectID: dto.SubjectID, TesteeID: testeeID, Status: TriageStatusOpen}
+	}
+	if resolved.RestrictToTesteeIDs && !containsUint64(resolved.TesteeIDs, item.TesteeID) {
+		return nil, errors.WithCode(code.ErrPermissionDenied, "testee is not in current workbench scope")
+	}
+
+	actor := TriageActor{UserID: dto.OperatorUserID}
+	clinicianItem, err := s.currentClinician(ctx, dto.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if clinicianItem != nil {
+		actor.ClinicianID = clinicianItem.ID
+		actor.Name = clinicianItem.Name
+	}
+	return &triageTarget{item: item, actor: actor, override: dto.Kind == ScopeKindOrgAdmin}, nil
+}
+
+func (s *service) triageView(ctx context.Context, item *TriageItem) (*TriageItemView, error) {
+	view := &TriageItemView{Item: *item, Events: []TriageEvent{}}
+	view.Item.Status = item.EffectiveStatus(s.now())
+	if item.ID == 0 || item.Version == 0 {
+		return view, nil
+	}
+	events, err := s.triageStore.ListTriageEvents(ctx, item.OrgID, item.ID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "list workbench triage events")
+	}
+	view.Events = events
+	return view, nil
+}
+
+// withTriage 为队列条目附加分诊状态；高风险条目同时计算认领 SLA。
+func (s *service) withTriage(ctx context.Context, orgID int64, queueType QueueType, items []QueueItem) ([]QueueItem, error) {
+	if s.triageStore == nil || len(items) == 0 {
+		return items, nil
+	}
+	subjectIDs := make([]uint64, 0, len(items))
+	for _, item := range items {
+		subjectIDs = append(subjectIDs, item.SubjectID)
+	}
+	rows, err := s.triageStore.ListTriageItems(ctx, orgID, queueType, subjectIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hydrate queue triage state")
+	}
+	bySubjectID := make(map[uint64]TriageItem, len(rows))
+	for _, row := range rows {
+		bySubjectID[row.SubjectID] = row
+	}
+	now := s.now()
+	for i := range items {
+		row := bySubjectID[items[i].SubjectID]
+		state := &TriageState{
+			Status:         row.EffectiveStatus(now),
+			ClaimedBy:      row.ClaimedBy,
+			ClaimedAt:      row.ClaimedAt,
+			ResolutionCode: row.ResolutionCode,
+			EscalatedAt:    row.EscalatedAt,
+			Version:        row.Version,
+		}
+		if state.Status == TriageStatusSnoozed {
+			state.SnoozedUntil = row.SnoozedUntil
+		}
+		if queueType == QueueTypeHighRisk && s.highRiskClaimSLA > 0 && items[i].ReasonAt != nil {
+			dueAt := items[i].ReasonAt.Add(s.highRiskClaimSLA)
+			state.SLADueAt = &dueAt
+			state.SLABreached = state.Status == TriageStatusOpen && now.After(dueAt)
+		}
+		items[i].Triage = state
+	}
+	return items, nil
+}
+
+// triageFilter 生成读模型的分诊过滤条件；未接入分诊时不过滤。
+func (s *service) triageFilter(queueType QueueType, view workbenchreadmodel.TriageView) workbenchreadmodel.TriageFilter {
+	if s.triageStore == nil {
+		return workbenchreadmodel.TriageFilter{}
+	}
+	return workbenchreadmodel.TriageFilter{QueueType: string(queueType), View: view, Now: s.now()}
+}
+
+func (s *service) normalizeTriageView(raw string) (workbenchreadmodel.TriageView, error) {
+	view := workbenchreadmodel.TriageView(strings.ToLower(strings.TrimSpace(raw)))
+	switch view {
+	case "":
+		if s.triageStore == nil {
+			return workbenchreadmodel.TriageViewAll, nil
+		}
+		return workbenchreadmodel.TriageViewActive, nil
+	case "all":
+		return workbenchreadmodel.TriageViewAll, nil
+	case workbenchreadmodel.TriageViewActive, workbenchreadmodel.TriageViewOpen, workbenchreadmodel.TriageViewClaimed,
+		workbenchreadmodel.TriageViewSnoozed, workbenchreadmodel.TriageViewResolved, workbenchreadmodel.TriageViewHandled,
+		workbenchreadmodel.TriageViewEscalated:
+		if s.triageStore == nil {
+			return "", errors.WithCode(code.ErrInvalidArgument, "workbench triage is not configured")
+		}
+		return view, nil
+	default:
+		return "", errors.WithCode(code.ErrInvalidArgument, "unsupported triage_status %q", raw)
+	}
+}
+
+func claimedByLabel(actor *TriageActor) string {
+	if actor.Name != "" {
+		return actor.Name
+	}
+	return "another operator"
+}
+
+func containsUint64(items []uint64, target uint64) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
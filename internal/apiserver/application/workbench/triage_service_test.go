@@ -0,0 +1,297 @@
+package workbench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
+	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
+	evaluationreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+type triageStoreStub struct {
+	items      map[string]TriageItem
+	events     map[uint64][]TriageEvent
+	subjects   map[uint64]uint64
+	candidates []EscalationCandidate
+}
+
+func newTriageStoreStub() *triageStoreStub {
+	return &triageStoreStub{
+		items:    map[string]TriageItem{},
+		events:   map[uint64][]TriageEvent{},
+		subjects: map[uint64]uint64{101: 1, 109: 99},
+	}
+}
+
+func triageKey(queueType QueueType, subjectID uint64) string {
+	return fmt.Sprintf("%s/%d", queueType, subjectID)
+}
+
+func (s *triageStoreStub) FindTriageItem(_ context.Context, _ int64, queueType QueueType, subjectID uint64) (*TriageItem, error) {
+	item, ok := s.items[triageKey(queueType, subjectID)]
+	if !ok {
+		return nil, nil
+	}
+	return &item, nil
+}
+
+func (s *triageStoreStub) ListTriageItems(_ context.Context, _ int64, queueType QueueType, subjectIDs []uint64) ([]TriageItem, error) {
+	var result []TriageItem
+	for _, id := range subjectIDs {
+		if item, ok := s.items[triageKey(queueType, id)]; ok {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (s *triageStoreStub) ListTriageEvents(_ context.Context, _ int64, itemID uint64) ([]TriageEvent, error) {
+	return append([]TriageEvent(nil), s.events[itemID]...), nil
+}
+
+func (s *triageStoreStub) SaveTriageItem(_ context.Context, item *TriageItem, expectedVersion int, event *TriageEvent) (bool, error) {
+	key := triageKey(item.QueueType, item.SubjectID)
+	if s.items[key].Version != expectedVersion {
+		return false, nil
+	}
+	s.items[key] = *item
+	s.events[item.ID] = append(s.events[item.ID], *event)
+	return true, nil
+}
+
+func (s *triageStoreStub) FindSubjectTesteeID(_ context.Context, _ int64, _ QueueType, subjectID uint64) (uint64, error) {
+	return s.subjects[subjectID], nil
+}
+
+func (s *triageStoreStub) ListEscalationCandidates(context.Context, time.Time, time.Time, int) ([]EscalationCandidate, error) {
+	return s.candidates, nil
+}
+
+func newTriageTestService(store TriageStore, latestRisks *latestRiskReaderStub, now time.Time) *service {
+	svc := NewService(
+		&operatorQueryStub{result: &operatorApp.OperatorResult{ID: 10, OrgID: 9, UserID: 701, IsActive: true}},
+		&clinicianQueryStub{result: &clinicianApp.ClinicianResult{ID: 20, OrgID: 9, Name: "王医生", IsActive: true}},
+		&assignmentReaderStub{ids: []uint64{1, 2, 3}},
+		&assignmentHydratorStub{},
+		&testeeReaderStub{rowsByID: map[uint64]actorreadmodel.TesteeRow{1: testeeRow(1, "A")}},
+		latestRisks,
+		&followUpReaderStub{},
+		nil,
+		nil,
+		nil,
+		nil,
+		&TriageConfig{Store: store, HighRiskClaimSLA: 4 * time.Hour},
+		&assessmentSummaryReaderStub{},
+	).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestTriageLifecycleRecordsHistoryAndGuardsClaims(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	store := newTriageStoreStub()
+	svc := newTriageTestService(store, &latestRiskReaderStub{}, now)
+	ctx := context.Background()
+	wang := Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}
+	li := Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 702}
+	admin := Scope{Kind: ScopeKindOrgAdmin, OrgID: 9, OperatorUserID: 900}
+	target := TriageDTO{QueueType: QueueTypeHighRisk, SubjectID: 101}
+
+	view, err := svc.GetTriageItem(ctx, withScope(target, wang))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusOpen || view.Item.TesteeID != 1 || len(view.Events) != 0 {
+		t.Fatalf("initial triage item = %#v", view)
+	}
+
+	view, err = svc.Claim(ctx, withScope(target, wang))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusClaimed || view.Item.ClaimedBy.UserID != 701 || view.Item.ClaimedBy.Name != "王医生" {
+		t.Fatalf("claimed item = %#v", view.Item)
+	}
+	if _, err := svc.Claim(ctx, withScope(target, wang)); err != nil {
+		t.Fatalf("re-claim by same operator should be idempotent: %v", err)
+	}
+	if _, err := svc.Claim(ctx, withScope(target, li)); !cberrors.IsCode(err, code.ErrWorkbenchTriageConflict) {
+		t.Fatalf("claim by another clinician error = %v, want conflict", err)
+	}
+
+	resolve := withScope(target, li)
+	resolve.ResolutionCode = ResolutionOther
+	if _, err := svc.Resolve(ctx, resolve); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("resolve other without note error = %v, want invalid argument", err)
+	}
+	resolve.Note = "家属已自行就医"
+	if _, err := svc.Resolve(ctx, resolve); !cberrors.IsCode(err, code.ErrWorkbenchTriageConflict) {
+		t.Fatalf("resolve item claimed by another error = %v, want conflict", err)
+	}
+	resolve.Scope = admin
+	view, err = svc.Resolve(ctx, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusResolved || view.Item.ResolutionCode != ResolutionOther || view.Item.ResolvedBy.UserID != 900 {
+		t.Fatalf("resolved item = %#v", view.Item)
+	}
+	if _, err := svc.Claim(ctx, withScope(target, wang)); !cberrors.IsCode(err, code.ErrWorkbenchTriageConflict) {
+		t.Fatalf("claim resolved item error = %v, want conflict", err)
+	}
+
+	view, err = svc.Reopen(ctx, withScope(target, wang))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusOpen || view.Item.ResolutionCode != "" || view.Item.Version != 3 {
+		t.Fatalf("reopened item = %#v", view.Item)
+	}
+	actions := make([]TriageAction, 0, len(view.Events))
+	for _, event := range view.Events {
+		actions = append(actions, event.Action)
+	}
+	if fmt.Sprint(actions) != "[claim resolve reopen]" || view.Events[1].ReasonCode != "other" || view.Events[1].FromStatus != TriageStatusClaimed {
+		t.Fatalf("history = %#v", view.Events)
+	}
+}
+
+func TestTriageSnoozeExpiresBackToOpen(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	store := newTriageStoreStub()
+	svc := newTriageTestService(store, &latestRiskReaderStub{}, now)
+	ctx := context.Background()
+	dto := TriageDTO{Scope: Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}, QueueType: QueueTypeHighRisk, SubjectID: 101}
+
+	past := now.Add(-time.Hour)
+	dto.SnoozedUntil = &past
+	if _, err := svc.Snooze(ctx, dto); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("snooze into the past error = %v, want invalid argument", err)
+	}
+	until := now.Add(2 * time.Hour)
+	dto.SnoozedUntil = &until
+	view, err := svc.Snooze(ctx, dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusSnoozed {
+		t.Fatalf("snoozed item = %#v", view.Item)
+	}
+
+	svc.now = func() time.Time { return until.Add(time.Minute) }
+	view, err = svc.GetTriageItem(ctx, dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Item.Status != TriageStatusOpen {
+		t.Fatalf("expired snooze status = %s, want open", view.Item.Status)
+	}
+	if _, err := svc.Claim(ctx, dto); err != nil {
+		t.Fatalf("claim after snooze expired: %v", err)
+	}
+}
+
+func TestTriageRejectsSubjectsOutsideScope(t *testing.T) {
+	svc := newTriageTestService(newTriageStoreStub(), &latestRiskReaderStub{}, time.Now())
+	scope := Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701}
+	if _, err := svc.Claim(context.Background(), TriageDTO{Scope: scope, QueueType: QueueTypeHighRisk, SubjectID: 109}); !cberrors.IsCode(err, code.ErrPermissionDenied) {
+		t.Fatalf("claim outside scope error = %v, want permission denied", err)
+	}
+	if _, err := svc.Claim(context.Background(), TriageDTO{Scope: scope, QueueType: QueueTypeHighRisk, SubjectID: 404}); !cberrors.IsCode(err, code.ErrWorkbenchTriageItemNotFound) {
+		t.Fatalf("claim unknown subject error = %v, want not found", err)
+	}
+}
+
+func TestListQueueDefaultsToActiveTriageViewAndReportsSLA(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	store := newTriageStoreStub()
+	claimedAt := now.Add(-time.Hour)
+	store.items[triageKey(QueueTypeHighRisk, 102)] = TriageItem{
+		ID: 1, OrgID: 9, QueueType: QueueTypeHighRisk, SubjectID: 102, TesteeID: 1, Status: TriageStatusClaimed,
+		ClaimedBy: &TriageActor{UserID: 702, Name: "李医生"}, ClaimedAt: &claimedAt, Version: 1,
+	}
+	latestRisks := &latestRiskReaderStub{rows: []evaluationreadmodel.LatestRiskRow{
+		{AssessmentID: 101, OrgID: 9, TesteeID: 1, RiskLevel: "high", OccurredAt: now.Add(-5 * time.Hour)},
+		{AssessmentID: 102, OrgID: 9, TesteeID: 1, RiskLevel: "severe", OccurredAt: now.Add(-6 * time.Hour)},
+	}}
+	svc := newTriageTestService(store, latestRisks, now)
+
+	page, err := svc.ListQueue(context.Background(), ListQueueDTO{
+		Scope:     Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701},
+		QueueType: QueueTypeHighRisk,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	triage := latestRisks.lastFilter.Triage
+	if triage.View != evaluationreadmodel.TriageViewActive || triage.QueueType != "high_risk" || !triage.Now.Equal(now) {
+		t.Fatalf("triage filter = %#v, want active high_risk view", triage)
+	}
+	open, claimed := page.Items[0].Triage, page.Items[1].Triage
+	if page.Items[0].SubjectID != 101 || open.Status != TriageStatusOpen || !open.SLABreached || !open.SLADueAt.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("open item triage = %#v", open)
+	}
+	if claimed.Status != TriageStatusClaimed || claimed.SLABreached || claimed.ClaimedBy.Name != "李医生" {
+		t.Fatalf("claimed item triage = %#v", claimed)
+	}
+
+	if _, err := svc.ListQueue(context.Background(), ListQueueDTO{
+		Scope:      Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701},
+		QueueType:  QueueTypeHighRisk,
+		TriageView: "all",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if latestRisks.lastFilter.Triage.Enabled() {
+		t.Fatalf("all view should not filter by triage: %#v", latestRisks.lastFilter.Triage)
+	}
+	if _, err := svc.ListQueue(context.Background(), ListQueueDTO{
+		Scope:      Scope{Kind: ScopeKindClinicianMe, OrgID: 9, OperatorUserID: 701},
+		QueueType:  QueueTypeHighRisk,
+		TriageView: "done",
+	}); !cberrors.IsCode(err, code.ErrInvalidArgument) {
+		t.Fatalf("unknown triage view error = %v, want invalid argument", err)
+	}
+}
+
+func TestEscalatorMarksUnclaimedHighRiskItemsOnce(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	store := newTriageStoreStub()
+	claimedAt := now.Add(-time.Hour)
+	store.items[triageKey(QueueTypeHighRisk, 102)] = TriageItem{
+		ID: 1, OrgID: 9, QueueType: QueueTypeHighRisk, SubjectID: 102, TesteeID: 2, Status: TriageStatusClaimed,
+		ClaimedBy: &TriageActor{UserID: 702}, ClaimedAt: &claimedAt, Version: 1,
+	}
+	store.candidates = []EscalationCandidate{
+		{OrgID: 9, AssessmentID: 101, TesteeID: 1, OccurredAt: now.Add(-5 * time.Hour)},
+		{OrgID: 9, AssessmentID: 102, TesteeID: 2, OccurredAt: now.Add(-5 * time.Hour)},
+	}
+	esc := NewEscalator(store, 4*time.Hour).(*escalator)
+	esc.now = func() time.Time { return now }
+
+	count, err := esc.EscalateOverdue(context.Background(), 100)
+	if err != nil || count != 1 {
+		t.Fatalf("EscalateOverdue() = %d, %v; want 1", count, err)
+	}
+	item := store.items[triageKey(QueueTypeHighRisk, 101)]
+	if item.Status != TriageStatusOpen || item.EscalatedAt == nil || item.Version != 1 {
+		t.Fatalf("escalated item = %#v", item)
+	}
+	if events := store.events[item.ID]; len(events) != 1 || events[0].Action != TriageActionEscalate || events[0].Operator != nil {
+		t.Fatalf("escalation history = %#v", events)
+	}
+	if count, err := esc.EscalateOverdue(context.Background(), 100); err != nil || count != 0 {
+		t.Fatalf("second EscalateOverdue() = %d, %v; want 0", count, err)
+	}
+}
+
+func withScope(dto TriageDTO, scope Scope) TriageDTO {
+	dto.Scope = scope
+	return dto
+}
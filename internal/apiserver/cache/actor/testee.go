@@ -108,6 +108,11 @@ func (r *CachedTesteeRepository) Delete(ctx context.Context, id testee.ID) error
 	return err
 }
 
+// Evict 失效受试者缓存，用于绕过仓储直接改写受试者的流程（如受试者合并）
+func (r *CachedTesteeRepository) Evict(ctx context.Context, id testee.ID) error {
+	return r.deleteCache(ctx, id)
+}
+
 // deleteCache 删除缓存
 func (r *CachedTesteeRepository) deleteCache(ctx context.Context, id testee.ID) error {
 	return r.store.Delete(ctx, r.buildCacheKey(id))
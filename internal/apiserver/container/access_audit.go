@@ -0,0 +1,26 @@
+package container
+
+import (
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	accessAuditInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/accessaudit"
+)
+
+// accessAuditService 组装受试者数据访问审计服务。
+// 审计记录由传输层在各模块的读接口上写入，因此由容器根装配。
+func (c *Container) accessAuditService() accessAuditApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.accessAudit != nil {
+		return c.accessAudit
+	}
+	if c.mysqlDB == nil {
+		return nil
+	}
+	var resolver accessAuditApp.AccessResolver
+	if c.ActorModule != nil && c.ActorModule.TesteeAccessDescriber != nil {
+		resolver = c.ActorModule.TesteeAccessDescriber
+	}
+	c.accessAudit = accessAuditApp.NewService(accessAuditInfra.NewEntryRepository(c.mysqlDB), resolver)
+	return c.accessAudit
+}
@@ -0,0 +1,69 @@
+package container
+
+import (
+	"context"
+
+	clinicalReviewApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
+	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	clinicalReviewInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+)
+
+// clinicalReviewReadModel 报告临床复核读模型。
+// 复核状态同时被报告投影（补充说明）与工作台（待复核队列）读取，因此由容器根持有。
+func (c *Container) clinicalReviewReadModel() clinicalReviewInfra.ReadModel {
+	if c == nil || c.mysqlDB == nil {
+		return nil
+	}
+	if c.clinicalReviews == nil {
+		c.clinicalReviews = clinicalReviewInfra.NewReadModel(c.mysqlDB)
+	}
+	return c.clinicalReviews
+}
+
+// clinicalReviewService 组装报告临床复核服务；复核人沿用从业者查看受试者报告的访问判定。
+func (c *Container) clinicalReviewService() clinicalReviewApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.clinicalReview != nil {
+		return c.clinicalReview
+	}
+	if c.mysqlDB == nil || c.ReportModule == nil || c.ActorModule == nil || c.EvaluationModule == nil ||
+		c.ActorModule.TesteeAccessService == nil || c.ActorModule.ReadModel == nil || c.EvaluationModule.TesteeService == nil {
+		return nil
+	}
+	c.clinicalReview = clinicalReviewApp.NewService(
+		clinicalReviewInfra.NewReviewRepository(c.mysqlDB),
+		clinicalReviewAccess{clinician: clinicianInterpretationAccess{relations: c.ActorModule.TesteeAccessService, ownership: c.EvaluationModule.TesteeService}},
+		c.ReportModule.ReportReader(),
+		c.ActorModule.ReadModel,
+		c.ActorModule.ReadModel,
+	)
+	return c.clinicalReview
+}
+
+// reportProjection 报告投影；接入临床复核时在报告中附带已签署的补充说明。
+func (c *Container) reportProjection() reportprojection.Mapper {
+	if reads := c.clinicalReviewReadModel(); reads != nil {
+		return reportprojection.Mapper{Addenda: reads}
+	}
+	return reportprojection.Mapper{}
+}
+
+// awaitingReviewReader 工作台待复核队列；未接入 MySQL 时返回 nil，队列恒为空。
+func (c *Container) awaitingReviewReader() workbenchreadmodel.AwaitingReviewReader {
+	if reads := c.clinicalReviewReadModel(); reads != nil {
+		return reads
+	}
+	return nil
+}
+
+type clinicalReviewAccess struct {
+	clinician clinicianInterpretationAccess
+}
+
+func (a clinicalReviewAccess) AuthorizeAssessment(ctx context.Context, actor clinicalReviewApp.Actor, testeeID, assessmentID uint64) error {
+	return a.clinician.AuthorizeParticipantAssessment(ctx, interpretationclinician.Actor{OrgID: actor.OrgID, OperatorUserID: actor.OperatorUserID}, testeeID, assessmentID)
+}
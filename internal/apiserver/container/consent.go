@@ -0,0 +1,29 @@
+package container
+
+import (
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+	modtx "github.com/FangcunMount/qs-server/internal/apiserver/container/internal/transaction"
+	consentInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/consent"
+	eventcatalog "github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+)
+
+// consentService 组装知情同意服务。
+// 同意校验同时被答卷提交（Survey 模块）与传输层使用，因此由容器根装配。
+func (c *Container) consentService() consentApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.consent != nil {
+		return c.consent
+	}
+	if c.mysqlDB == nil || c.ActorModule == nil || c.ActorModule.TesteeQueryService == nil {
+		return nil
+	}
+	c.consent = consentApp.NewService(
+		consentInfra.NewConsentRepository(c.mysqlDB),
+		c.ActorModule.TesteeQueryService,
+		modtx.NewMySQLRunner(c.mysqlDB),
+		c.EventProfile(eventcatalog.OutboxProfileAssessmentMySQL),
+	)
+	return c.consent
+}
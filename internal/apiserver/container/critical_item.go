@@ -0,0 +1,42 @@
+package container
+
+import (
+	asApp "github.com/FangcunMount/qs-server/internal/apiserver/application/survey/answersheet"
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
+	criticalItemInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/criticalitem"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+)
+
+// criticalItemRecorder 关键条目事件消费者的写入端；未接入 MySQL 时返回 nil，消费者拒绝并重投消息。
+func (c *Container) criticalItemRecorder() workbenchApp.CriticalItemFlagRecorder {
+	if c == nil || c.mysqlDB == nil {
+		return nil
+	}
+	return criticalItemInfra.NewFlagRepository(c.mysqlDB)
+}
+
+// criticalItemReader 工作台关键条目队列；未接入 MySQL 时返回 nil，队列恒为空。
+func (c *Container) criticalItemReader() workbenchreadmodel.CriticalItemReader {
+	if c == nil || c.mysqlDB == nil {
+		return nil
+	}
+	return criticalItemInfra.NewQueueReadModel(c.mysqlDB)
+}
+
+// safeMessagingConfig 将配置的安全提示转换为答卷提交结果中的安全提示；未配置标题与热线时不返回。
+func (c *Container) safeMessagingConfig() (asApp.SafeMessaging, bool) {
+	if c == nil || c.safeMessaging == nil {
+		return asApp.SafeMessaging{}, false
+	}
+	messaging := asApp.SafeMessaging{Title: c.safeMessaging.Title, Message: c.safeMessaging.Message}
+	for _, hotline := range c.safeMessaging.Hotlines {
+		if hotline == nil || hotline.Number == "" {
+			continue
+		}
+		messaging.Hotlines = append(messaging.Hotlines, asApp.Hotline{Name: hotline.Name, Number: hotline.Number, Hours: hotline.Hours})
+	}
+	if messaging.Title == "" && messaging.Message == "" && len(messaging.Hotlines) == 0 {
+		return asApp.SafeMessaging{}, false
+	}
+	return messaging, true
+}
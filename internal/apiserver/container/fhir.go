@@ -0,0 +1,31 @@
+package container
+
+import (
+	fhirApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
+)
+
+// fhirService 组装 FHIR R4 映射服务；问卷与答卷仓储、测评读模型或解读模块缺失时返回 nil。
+// DiagnosticReport 与临床人员读取路径共用报告投影。
+func (c *Container) fhirService() fhirApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.fhir != nil {
+		return c.fhir
+	}
+	infra := c.surveyRuntimeInfra
+	if infra == nil || infra.QuestionnaireRepo == nil || infra.AnswerSheetRepo == nil ||
+		c.EvaluationModule == nil || c.EvaluationModule.AssessmentReader() == nil || c.EvaluationModule.ScoreFactReader() == nil ||
+		c.ReportModule == nil || c.ReportModule.ReportReader() == nil {
+		return nil
+	}
+	c.fhir = fhirApp.NewService(
+		infra.QuestionnaireRepo,
+		infra.AnswerSheetRepo,
+		c.EvaluationModule.AssessmentReader(),
+		c.EvaluationModule.ScoreFactReader(),
+		c.ReportModule.ReportReader(),
+		c.ReportModule.ProjectionMapper(),
+	)
+	return c.fhir
+}
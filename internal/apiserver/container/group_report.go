@@ -0,0 +1,40 @@
+package container
+
+import (
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	groupReportInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/groupreport"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+)
+
+// groupReportService 组装群体报告服务；未接入 MySQL 或测评模块时返回 nil。
+// 风险带名单使用与去标识化导出相同的假名，PDF 沿用报告 PDF 的机构品牌。
+func (c *Container) groupReportService() groupReportApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.groupReport != nil {
+		return c.groupReport
+	}
+	if c.mysqlDB == nil || c.EvaluationModule == nil || c.EvaluationModule.AssessmentReader() == nil ||
+		c.EvaluationModule.OutcomeRepository() == nil {
+		return nil
+	}
+	opts := c.groupReportOptions
+	if opts == nil {
+		opts = apiserveroptions.NewGroupReportOptions()
+	}
+	pdfOpts := c.reportPDFOptions
+	if pdfOpts == nil {
+		pdfOpts = apiserveroptions.NewReportPDFOptions()
+	}
+	c.groupReport = groupReportApp.NewService(
+		groupReportInfra.NewReportRepository(c.mysqlDB),
+		groupReportInfra.NewCohortResolver(c.mysqlDB),
+		c.EvaluationModule.AssessmentReader(),
+		c.EvaluationModule.OutcomeRepository(),
+		c.pseudonymizer(),
+		newReportPDFBranding(pdfOpts),
+		groupReportApp.Config{MinCellSize: opts.MinCellSize, MaxCohortSize: opts.MaxCohortSize},
+	)
+	return c.groupReport
+}
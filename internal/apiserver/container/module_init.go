@@ -11,8 +11,8 @@ import (
 	interpretationadmin "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/administration"
 	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
 	interpretationparticipant "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/participant"
-	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
 	modelcatalogHotRank "github.com/FangcunMount/qs-server/internal/apiserver/application/modelcatalog/hotrank"
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
 	actormod "github.com/FangcunMount/qs-server/internal/apiserver/container/modules/actor"
 	evalmod "github.com/FangcunMount/qs-server/internal/apiserver/container/modules/evaluation"
 	reportmod "github.com/FangcunMount/qs-server/internal/apiserver/container/modules/interpretation"
@@ -30,6 +30,14 @@ func (c *Container) initSurveyModule() error {
 	if err := surveymod.InstallFrom(c); err != nil {
 		return fmt.Errorf("failed to initialize survey module: %w", err)
 	}
+	if messaging, ok := c.safeMessagingConfig(); ok && c.SurveyModule != nil {
+		c.SurveyModule.SetSafeMessaging(messaging)
+	}
+	if c.eventSubsystem != nil {
+		if err := c.eventSubsystem.RegisterConsumer("workbench.critical_item_projection", workbenchApp.NewCriticalItemEventConsumer(c.criticalItemRecorder())); err != nil {
+			return fmt.Errorf("register workbench critical-item event consumer: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -51,6 +59,9 @@ func (c *Container) initActorModule() error {
 	if err := actormod.InstallFrom(c); err != nil {
 		return fmt.Errorf("failed to initialize actor module: %w", err)
 	}
+	if service := c.consentService(); service != nil && c.SurveyModule != nil {
+		c.SurveyModule.SetConsentChecker(service)
+	}
 	return nil
 }
 
@@ -59,6 +70,16 @@ func (c *Container) initReportModule() error {
 	if err := reportmod.InstallFrom(c); err != nil {
 		return fmt.Errorf("failed to initialize report module: %w", err)
 	}
+	if c.eventSubsystem != nil {
+		if err := c.eventSubsystem.RegisterConsumer("interpretation.report_pdf_render", c.reportPDFConsumer()); err != nil {
+			return fmt.Errorf("register interpretation report pdf event consumer: %w", err)
+		}
+		for _, id := range planReportConsumerIDs {
+			if err := c.eventSubsystem.RegisterConsumer(id, c.planReportConsumer()); err != nil {
+				return fmt.Errorf("register longitudinal plan report event consumer: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -73,10 +94,11 @@ func (c *Container) initEvaluationModule() error {
 	if c.ActorModule == nil {
 		return fmt.Errorf("actor module must be installed before binding interpretation access")
 	}
+	c.ReportModule.BindTesteeLocales(testeeReportLocales{testees: c.ActorModule.TesteeQueryService})
 	if err := c.ReportModule.BindOutcomeRepository(c.EvaluationModule.OutcomeRepository()); err != nil {
 		return fmt.Errorf("failed to bind interpretation outcome service: %w", err)
 	}
-	c.ReportModule.BindReportProjection(reportprojection.Mapper{})
+	c.ReportModule.BindReportProjection(c.reportProjection())
 	if err := c.ReportModule.BindParticipantAccess(participantInterpretationAccess{testees: c.ActorModule.TesteeQueryService, assessments: c.EvaluationModule.TesteeService}); err != nil {
 		return fmt.Errorf("failed to bind interpretation participant service: %w", err)
 	}
@@ -121,6 +143,22 @@ func (a participantInterpretationAccess) AuthorizeOwnAssessment(ctx context.Cont
 	return a.assessments.AuthorizeAssessment(ctx, evaluationtestee.Actor{TesteeID: testeeID}, assessmentID)
 }
 
+// testeeReportLocales 把受试者偏好语言提供给报告生成；受试者缺失时视为无偏好。
+type testeeReportLocales struct {
+	testees actortestee.TesteeQueryService
+}
+
+func (l testeeReportLocales) PreferredLocale(ctx context.Context, testeeID uint64) (string, error) {
+	if l.testees == nil {
+		return "", nil
+	}
+	testee, err := l.testees.GetByID(ctx, testeeID)
+	if err != nil || testee == nil {
+		return "", err
+	}
+	return testee.PreferredLocale, nil
+}
+
 type administrationInterpretationAccess struct {
 	access evaluationoperator.QueryService
 	actors actoraccess.TesteeAccessService
@@ -147,10 +185,16 @@ func (a administrationInterpretationAccess) AuthorizeAssessment(ctx context.Cont
 	if a.access == nil {
 		return interpretationadmin.ReportAccessDecision{}, fmt.Errorf("administration assessment access service is not configured")
 	}
-	if _, err := a.access.GetAssessment(ctx, evaluationoperator.Actor{OrgID: actor.OrgID, OperatorUserID: actor.OperatorUserID}, assessmentID); err != nil {
+	assessment, err := a.access.GetAssessment(ctx, evaluationoperator.Actor{OrgID: actor.OrgID, OperatorUserID: actor.OperatorUserID}, assessmentID)
+	if err != nil {
+		return interpretationadmin.ReportAccessDecision{}, err
+	}
+	decision, err := a.decide(ctx, actor)
+	if err != nil {
 		return interpretationadmin.ReportAccessDecision{}, err
 	}
-	return a.decide(ctx, actor)
+	decision.TesteeID = assessment.TesteeID
+	return decision, nil
 }
 
 func (a administrationInterpretationAccess) ScopeReports(ctx context.Context, actor interpretationadmin.Actor, testeeID uint64) (interpretationadmin.ListScope, error) {
@@ -1,16 +1,23 @@
 package actor
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
 	redis "github.com/redis/go-redis/v9"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
 	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	assessmentEntryApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/assessmententry"
+	breakGlassApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	careTeamApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
 	actorcache "github.com/FangcunMount/qs-server/internal/apiserver/cache/actor"
 	modtx "github.com/FangcunMount/qs-server/internal/apiserver/container/internal/transaction"
 	"github.com/FangcunMount/qs-server/internal/apiserver/container/modules"
@@ -19,7 +26,11 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/operator"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
 	"github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
+	accessAuditInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/accessaudit"
 	actorInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/actor"
+	breakGlassInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/breakglass"
+	careTeamInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/careteam"
+	customRoleInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/customrole"
 	evaluationInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/evaluation"
 	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
 	sharedcache "github.com/FangcunMount/qs-server/internal/pkg/cache"
@@ -46,10 +57,23 @@ type Module struct {
 	ClinicianRelationshipService  clinicianApp.ClinicianRelationshipService
 	AssessmentEntryService        assessmentEntryApp.AssessmentEntryService
 	TesteeAccessService           actorAccessApp.TesteeAccessService
+	TesteeAccessDescriber         accessAuditApp.AccessResolver
+	BreakGlassService             breakGlassApp.Service
+	BreakGlassReader              breakGlassApp.ActiveGrantReader
+	CustomRoleService             customRoleApp.Service
+	CareTeamService               careTeamApp.Service
+	CareTeamReader                careTeamApp.AccessReader
 	ActiveOperatorChecker         operatorApp.ActiveOperatorChecker
 	OperatorRoleProjectionUpdater operatorApp.OperatorRoleProjectionUpdater
 	ReadModel                     actorreadmodel.ReadModel
 	AssessmentSummaryReader       actorreadmodel.AssessmentSummaryReader
+	// TesteeCacheEvictor 失效单个受试者缓存，供绕过仓储改写受试者的流程使用；未启用 Redis 时为 nil。
+	TesteeCacheEvictor TesteeCacheEvictor
+}
+
+// TesteeCacheEvictor 受试者缓存失效能力。
+type TesteeCacheEvictor interface {
+	Evict(ctx context.Context, id testee.ID) error
 }
 
 // Deps defines explicit constructor dependencies for the actor module.
@@ -64,6 +88,7 @@ type Deps struct {
 	OperationAccountSvc *iam.OperationAccountService
 	Observer            *observability.ComponentObserver
 	MySQLLimiter        backpressure.Acquirer
+	OutboxProfile       appEventing.ProfileBinding
 }
 
 // New assembles the actor module.
@@ -97,6 +122,9 @@ func New(deps Deps) (*Module, error) {
 	var testeeRepo testee.Repository
 	if deps.RedisClient != nil {
 		testeeRepo = actorcache.NewCachedTesteeRepositoryWithBuilderProviderAndObserver(baseTesteeRepo, deps.RedisClient, deps.CacheBuilder, deps.CachePolicies, deps.Observer)
+		if evictor, ok := testeeRepo.(TesteeCacheEvictor); ok {
+			module.TesteeCacheEvictor = evictor
+		}
 	} else {
 		testeeRepo = baseTesteeRepo
 	}
@@ -188,12 +216,46 @@ func New(deps Deps) (*Module, error) {
 		actorReadModel,
 		assessmentSummaryReader,
 	)
+	breakGlassStore := breakGlassInfra.NewGrantRepository(mysqlDB, mysqlOptions)
+	module.BreakGlassReader = breakGlassStore
+	module.BreakGlassService = breakGlassApp.NewService(
+		breakGlassStore,
+		actorReadModel,
+		actorReadModel,
+		actorReadModel,
+		actorReadModel,
+		txRunner,
+		accessAuditApp.NewService(accessAuditInfra.NewEntryRepository(mysqlDB), nil),
+		deps.OutboxProfile,
+	)
+	module.CustomRoleService = customRoleApp.NewService(
+		customRoleInfra.NewRoleRepository(mysqlDB, mysqlOptions),
+		actorReadModel,
+		authzSnapshotReader,
+	)
+	careTeamStore := careTeamInfra.NewTeamRepository(mysqlDB, mysqlOptions)
+	module.CareTeamReader = careTeamStore
+	module.CareTeamService = careTeamApp.NewService(
+		careTeamStore,
+		actorReadModel,
+		actorReadModel,
+		actorReadModel,
+	)
+	accessSources := actorAccessApp.Sources{BreakGlass: breakGlassStore, CareTeams: careTeamStore}
 	module.TesteeAccessService = actorAccessApp.NewTesteeAccessService(
 		actorReadModel,
 		actorReadModel,
 		actorReadModel,
 		actorReadModel,
 		authzSnapshotReader,
+		accessSources,
+	)
+	module.TesteeAccessDescriber = actorAccessApp.NewTesteeAccessDescriber(
+		actorReadModel,
+		actorReadModel,
+		actorReadModel,
+		authzSnapshotReader,
+		accessSources,
 	)
 	module.AssessmentEntryService = assessmentEntryApp.NewService(
 		assessmentEntryRepo,
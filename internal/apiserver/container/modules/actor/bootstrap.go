@@ -5,6 +5,7 @@ import (
 
 	redis "github.com/redis/go-redis/v9"
 
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
 	"github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
 	sharedcache "github.com/FangcunMount/qs-server/internal/pkg/cache"
 	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
@@ -24,6 +25,7 @@ type BootstrapInput struct {
 	CachePolicies       sharedcache.PolicyProvider
 	Observer            *observability.ComponentObserver
 	MySQLLimiter        backpressure.Acquirer
+	OutboxProfile       appEventing.ProfileBinding
 }
 
 // Bootstrap assembles the actor module from container integration inputs.
@@ -39,5 +41,6 @@ func Bootstrap(in BootstrapInput) (*Module, error) {
 		OperationAccountSvc: in.OperationAccountSvc,
 		Observer:            in.Observer,
 		MySQLLimiter:        in.MySQLLimiter,
+		OutboxProfile:       in.OutboxProfile,
 	})
 }
@@ -22,6 +22,9 @@ func (m *Module) ExportRESTDeps(qrCodeService qrcodeApp.QRCodeService) resttrans
 	deps.ClinicianQueryService = m.ClinicianQueryService
 	deps.ClinicianRelationshipService = m.ClinicianRelationshipService
 	deps.AssessmentEntryService = m.AssessmentEntryService
+	deps.BreakGlassService = m.BreakGlassService
+	deps.CustomRoleService = m.CustomRoleService
+	deps.CareTeamService = m.CareTeamService
 	deps.QRCodeService = qrCodeService
 	deps.ActiveOperatorChecker = m.ActiveOperatorChecker
 	deps.OperatorRoleProjectionUpdater = m.OperatorRoleProjectionUpdater
@@ -3,6 +3,7 @@ package actor
 import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/cache/catalog"
 	"github.com/FangcunMount/qs-server/internal/apiserver/container/compose"
+	eventcatalog "github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
 	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime"
 )
 
@@ -28,6 +29,7 @@ func InstallFrom(host InstallHost) error {
 		CachePolicies:       provider,
 		Observer:            host.CacheObserver(),
 		MySQLLimiter:        host.MySQLLimiter(),
+		OutboxProfile:       host.EventProfile(eventcatalog.OutboxProfileAssessmentMySQL),
 		IAMEnabled:          iamPorts.Enabled,
 		ProfileLinkService:  iamPorts.ProfileLinkService,
 		IdentityService:     iamPorts.IdentityService,
@@ -1,6 +1,7 @@
 package actor
 
 import (
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
 	"github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
 	sharedcache "github.com/FangcunMount/qs-server/internal/pkg/cache"
 	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
@@ -18,6 +19,7 @@ type WireInput struct {
 	CachePolicies       sharedcache.PolicyProvider
 	Observer            *observability.ComponentObserver
 	MySQLLimiter        backpressure.Acquirer
+	OutboxProfile       appEventing.ProfileBinding
 	IAMEnabled          bool
 	ProfileLinkService  *iam.ProfileLinkService
 	IdentityService     *iam.IdentityService
@@ -35,6 +37,7 @@ func Wire(in WireInput) (*Module, error) {
 		CachePolicies:       in.CachePolicies,
 		Observer:            in.Observer,
 		MySQLLimiter:        in.MySQLLimiter,
+		OutboxProfile:       in.OutboxProfile,
 		ProfileLinkService:  in.ProfileLinkService,
 		IdentityService:     in.IdentityService,
 		OperationAccountSvc: in.OperationAccountSvc,
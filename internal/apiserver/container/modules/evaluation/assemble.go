@@ -58,6 +58,8 @@ type Module struct {
 
 	outcomeRepository         domainoutcome.Repository
 	workbenchLatestRiskReader workbenchreadmodel.LatestRiskReader
+	assessmentReader          evaluationreadmodel.AssessmentReader
+	scoreFactReader           evaluationoutcome.ScoreFactReader
 }
 
 // Deps defines explicit constructor dependencies for the evaluation module.
@@ -232,6 +234,8 @@ func (m *Module) wireAssessmentApplications(normalized Deps, infra *evaluationIn
 	m.GovernedRetry = evaluationoperator.NewGovernedRetryService(infra.assessmentRepo, infra.runRepo, infra.txRunner, infra.assessmentOutboxStore, normalized.TesteeAccessChecker)
 	m.ScaleAnalysis = evaluationoperator.NewScaleAnalysisService(m.OperatorQuery)
 	m.workbenchLatestRiskReader = infra.latestRiskReader
+	m.assessmentReader = infra.assessmentReader
+	m.scoreFactReader = scoreFacts
 }
 
 func (m *Module) wireScheduler(infra *evaluationInfra) {
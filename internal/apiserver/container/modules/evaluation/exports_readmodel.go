@@ -0,0 +1,24 @@
+package evaluation
+
+import (
+	evaluationoutcome "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/outcome"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
+)
+
+// AssessmentReader exposes the Assessment read model to cross-module read
+// surfaces such as the FHIR export, which map results but never mutate them.
+func (m *Module) AssessmentReader() evaluationreadmodel.AssessmentReader {
+	if m == nil {
+		return nil
+	}
+	return m.assessmentReader
+}
+
+// ScoreFactReader exposes the frozen score facts behind the same read
+// surfaces; it is the reader used by the testee and operator queries.
+func (m *Module) ScoreFactReader() evaluationoutcome.ScoreFactReader {
+	if m == nil {
+		return nil
+	}
+	return m.scoreFactReader
+}
@@ -18,6 +18,8 @@ import (
 	interpretationoperations "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/operations"
 	interpretationparticipant "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/participant"
 	interpretationreadmission "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/readmission"
+	interpretationregeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportlocale"
 	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
 	appreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reporttemplate"
 	apptransaction "github.com/FangcunMount/qs-server/internal/apiserver/application/transaction"
@@ -41,12 +43,26 @@ import (
 type Module struct {
 	reader                evaluationreadmodel.ReportReader
 	executionExecutor     interpretationexecution.Executor
+	builders              rendering.Registry
 	generationRepo        *mongoEval.GenerationRepository
 	runRepo               *mongoEval.RunRepository
 	reportRepo            *mongoEval.ReportRepository
+	reportVariantRepo     *mongoEval.ReportVariantRepository
+	reportLocaleRepo      *mongoEval.ReportLocaleRepository
+	reportLocales         reportprojection.LocalizedReportSource
+	testeeLocales         *testeeLocaleResolver
+	manifests             domainreporttemplate.ManifestCatalog
 	admissionRepo         *mongoEval.AdmissionFailureRepository
 	reportTemplateRepo    *mongoEval.ReportTemplateRepository
 	reportTemplateService appreporttemplate.Service
+	rolloutRepo           *mongoEval.ReportTemplateRolloutRepository
+	shadowRepo            *mongoEval.ReportTemplateShadowComparisonRepository
+	rolloutService        appreporttemplate.RolloutService
+	regenerationBatches   *mongoEval.ReportRegenerationBatchRepository
+	regenerationItems     *mongoEval.ReportRegenerationItemRepository
+	regenerationReader    *mongoEval.ReportRegenerationCandidateReader
+	regenerationService   interpretationregeneration.Service
+	regenerationProcessor interpretationregeneration.Processor
 	automationService     interpretationautomation.Service
 	projectionMapper      reportprojection.Mapper
 	participantService    interpretationparticipant.Service
@@ -110,6 +126,16 @@ func New(deps Deps) (*Module, error) {
 		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation report repository: %v", err)
 	}
 	module.reportRepo = reportRepo
+	reportVariantRepo, err := mongoEval.NewReportVariantRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation report variant repository: %v", err)
+	}
+	module.reportVariantRepo = reportVariantRepo
+	reportLocaleRepo, err := mongoEval.NewReportLocaleRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation report locale repository: %v", err)
+	}
+	module.reportLocaleRepo = reportLocaleRepo
 	admissionRepo, err := mongoEval.NewAdmissionFailureRepository(deps.MongoDB, mongoOptions)
 	if err != nil {
 		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation admission failure repository: %v", err)
@@ -119,12 +145,35 @@ func New(deps Deps) (*Module, error) {
 	if err != nil {
 		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report template manifest catalog: %v", err)
 	}
+	module.manifests = reportTemplateManifests
 	reportTemplateRepo, err := mongoEval.NewReportTemplateRepository(deps.MongoDB, reportTemplateManifests, mongoOptions)
 	if err != nil {
 		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report template repository: %v", err)
 	}
 	module.reportTemplateRepo = reportTemplateRepo
 	module.reportTemplateService = appreporttemplate.NewService(reportTemplateRepo, reportTemplateManifests)
+	rolloutRepo, err := mongoEval.NewReportTemplateRolloutRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report template rollout repository: %v", err)
+	}
+	module.rolloutRepo = rolloutRepo
+	shadowRepo, err := mongoEval.NewReportTemplateShadowComparisonRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report template shadow comparison repository: %v", err)
+	}
+	module.shadowRepo = shadowRepo
+	regenerationBatches, err := mongoEval.NewReportRegenerationBatchRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report regeneration batch repository: %v", err)
+	}
+	module.regenerationBatches = regenerationBatches
+	regenerationItems, err := mongoEval.NewReportRegenerationItemRepository(deps.MongoDB, mongoOptions)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report regeneration item repository: %v", err)
+	}
+	module.regenerationItems = regenerationItems
+	module.regenerationReader = mongoEval.NewReportRegenerationCandidateReader(deps.MongoDB)
+	module.regenerationService = interpretationregeneration.NewService(regenerationBatches, regenerationItems, module.regenerationReader, reportTemplateRepo, rolloutRepo)
 	catalogProjector, err := mongoEval.NewReportCatalogProjector(deps.MongoDB, mongoOptions)
 	if err != nil {
 		return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report catalog projector: %v", err)
@@ -135,24 +184,31 @@ func New(deps Deps) (*Module, error) {
 	}
 	mongoTxRunner := modtx.NewMongoRunner(deps.MongoDB)
 	module.txRunner = mongoTxRunner
+	module.rolloutService = appreporttemplate.NewRolloutService(reportTemplateRepo, rolloutRepo, shadowRepo, reportTemplateManifests, mongoTxRunner)
 	module.eventStager = deps.OutboxProfile.Stager
 	{
 		registry, err := buildReportBuilderRegistry()
 		if err != nil {
 			return nil, err
 		}
+		module.builders = registry
 		starter, err := interpretationexecution.NewStarter(mongoTxRunner, module.generationRepo, module.runRepo, module.reportRepo, deps.RunLeaseDuration)
 		if err != nil {
 			return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report generation starter: %v", err)
 		}
-		committer, err := interpretationexecution.NewInterpretationCommitter(mongoTxRunner, module.generationRepo, module.runRepo, module.reportRepo, deps.OutboxProfile.Stager, deps.OutboxProfile.PostCommit, catalogProjector)
+		committer, err := interpretationexecution.NewInterpretationCommitter(mongoTxRunner, module.generationRepo, module.runRepo, module.reportRepo, module.reportVariantRepo, deps.OutboxProfile.Stager, deps.OutboxProfile.PostCommit, catalogProjector)
 		if err != nil {
 			return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation committer: %v", err)
 		}
-		executor, err := interpretationexecution.NewExecutor(starter, registry, committer)
+		module.testeeLocales = &testeeLocaleResolver{}
+		executor, err := interpretationexecution.NewExecutor(starter, registry, committer, reportTemplateManifests, interpretationexecution.WithLocaleResolver(module.testeeLocales))
 		if err != nil {
 			return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize interpretation execution: %v", err)
 		}
+		executor, err = interpretationexecution.NewRolloutExecutor(executor, rolloutRepo, module.generationRepo, registry, shadowRepo)
+		if err != nil {
+			return nil, errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report template rollout routing: %v", err)
+		}
 		module.executionExecutor = executor
 	}
 
@@ -182,9 +238,36 @@ func (m *Module) BindOutcomeRepository(repo domainoutcome.Repository) error {
 	)
 	m.governedRetryService = interpretationautomation.NewGovernedRetryService(m.generationRepo, m.runRepo, repo, m.txRunner, m.eventStager)
 	m.leaseRecoverer = interpretationautomation.NewLeaseRecoverer(m.runRepo, m.generationRepo, m.automationService)
+	processor, err := interpretationregeneration.NewProcessor(m.regenerationBatches, m.regenerationItems, m.regenerationReader, automationService, m.reportRepo, m.txRunner)
+	if err != nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "failed to initialize report regeneration processor: %v", err)
+	}
+	m.regenerationProcessor = processor
+	m.reportLocales = reportlocale.NewService(m.reportLocaleRepo, m.reportRepo, repo, m.builders, m.manifests)
 	return nil
 }
 
+// BindTesteeLocales installs the testee preference lookup used to pick each new
+// report's content locale. Reports render in the default locale until bound.
+func (m *Module) BindTesteeLocales(resolver interpretationexecution.LocaleResolver) {
+	if m == nil || m.testeeLocales == nil {
+		return
+	}
+	m.testeeLocales.resolver = resolver
+}
+
+// testeeLocaleResolver lets the executor be built before Actor is installed.
+type testeeLocaleResolver struct {
+	resolver interpretationexecution.LocaleResolver
+}
+
+func (r *testeeLocaleResolver) PreferredLocale(ctx context.Context, testeeID uint64) (string, error) {
+	if r == nil || r.resolver == nil {
+		return "", nil
+	}
+	return r.resolver.PreferredLocale(ctx, testeeID)
+}
+
 func (m *Module) ReadmissionService() interpretationreadmission.Service {
 	if m == nil {
 		return nil
@@ -498,9 +581,23 @@ func (m *Module) BindReportProjection(projection reportprojection.Mapper) {
 	if m == nil {
 		return
 	}
+	if projection.Variants == nil && m.reportVariantRepo != nil {
+		projection.Variants = m.reportVariantRepo
+	}
+	if projection.Locales == nil && m.reportLocales != nil {
+		projection.Locales = m.reportLocales
+	}
 	m.projectionMapper = projection
 }
 
+// ProjectionMapper 返回受试者读取路径使用的报告投影，含受众变体读取。
+func (m *Module) ProjectionMapper() reportprojection.Mapper {
+	if m == nil {
+		return reportprojection.Mapper{}
+	}
+	return m.projectionMapper
+}
+
 func (m *Module) BindParticipantAccess(access interpretationparticipant.Access) error {
 	if m == nil || access == nil || m.reader == nil {
 		return errors.WithCode(code.ErrModuleInitializationFailed, "interpretation participant service dependencies are not configured")
@@ -552,6 +649,38 @@ func (m *Module) ReportTemplateService() appreporttemplate.Service {
 	return m.reportTemplateService
 }
 
+// ReportTemplateRolloutService 返回报告模板灰度发布服务；开始、提升与回滚都经由治理动作。
+func (m *Module) ReportTemplateRolloutService() appreporttemplate.RolloutService {
+	if m == nil {
+		return nil
+	}
+	return m.rolloutService
+}
+
+// ReportRegenerationService 返回报告批量重生成服务；启动与取消都经由治理动作。
+func (m *Module) ReportRegenerationService() interpretationregeneration.Service {
+	if m == nil {
+		return nil
+	}
+	return m.regenerationService
+}
+
+// ReportRegenerationProcessor 返回按批推进重生成的处理器，由调度 runner 驱动。
+func (m *Module) ReportRegenerationProcessor() interpretationregeneration.Processor {
+	if m == nil {
+		return nil
+	}
+	return m.regenerationProcessor
+}
+
+// RenderingRegistry 返回报告构建器注册表；纵向计划报告与单次测评报告共用同一套构建器解析。
+func (m *Module) RenderingRegistry() rendering.Registry {
+	if m == nil {
+		return nil
+	}
+	return m.builders
+}
+
 func (m *Module) ReportTemplateCatalog() domainreporttemplate.Catalog {
 	if m == nil {
 		return nil
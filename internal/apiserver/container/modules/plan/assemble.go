@@ -35,6 +35,7 @@ type Module struct {
 	EnrollmentQueryService        planApp.EnrollmentQueryService
 	TaskAssessmentResolver        planApp.TaskAssessmentResolver
 	TaskNotificationContextReader planApp.TaskNotificationContextReader
+	EnrollmentTimelineReader      planApp.EnrollmentTimelineReader
 	FollowUpQueueReader           planreadmodel.FollowUpQueueReader
 
 	eventPublisher      event.EventPublisher
@@ -102,6 +103,7 @@ func New(deps Deps) (*Module, error) {
 	module.EnrollmentQueryService = planApp.NewEnrollmentQueryService(planInfra.NewEnrollmentReadStore(normalized.MySQLDB, normalized.MySQLLimiter), scaleCatalog)
 	module.TaskAssessmentResolver = planApp.NewTaskAssessmentResolver(taskRepo)
 	module.TaskNotificationContextReader = planApp.NewTaskNotificationContextReader(taskRepo, planRepo)
+	module.EnrollmentTimelineReader = planApp.NewEnrollmentTimelineReader(taskRepo, enrollmentRepo)
 
 	return module, nil
 }
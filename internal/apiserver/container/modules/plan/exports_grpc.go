@@ -9,6 +9,7 @@ func (m *Module) ExportGRPCDeps() grpctransport.PlanDeps {
 		return deps
 	}
 	deps.CommandService = m.CommandService
+	deps.QueryService = m.QueryService
 	deps.TaskAssessmentResolver = m.TaskAssessmentResolver
 	return deps
 }
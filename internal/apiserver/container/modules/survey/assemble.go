@@ -18,6 +18,7 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
 	attributioninfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/answersheetattribution"
 	ruleengineInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/ruleengine"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
 	rulesetport "github.com/FangcunMount/qs-server/internal/apiserver/port/modelcatalog"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/surveyreadmodel"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -163,6 +164,28 @@ func (m *Module) SetAssessmentBindingResolver(binding rulesetport.AssessmentBind
 	}
 }
 
+// SetConsentChecker injects informed-consent enforcement into answer-sheet submit
+// after the actor module (testee facts) is available.
+func (m *Module) SetConsentChecker(checker consentgate.Checker) {
+	if m == nil || m.AnswerSheet == nil {
+		return
+	}
+	if injector, ok := m.AnswerSheet.SubmissionService.(asApp.ConsentCheckerInjector); ok {
+		injector.SetConsentChecker(checker)
+	}
+}
+
+// SetSafeMessaging installs the safe-messaging payload returned to respondents
+// whose submission hits a questionnaire critical item.
+func (m *Module) SetSafeMessaging(messaging asApp.SafeMessaging) {
+	if m == nil || m.AnswerSheet == nil {
+		return
+	}
+	if injector, ok := m.AnswerSheet.SubmissionService.(asApp.SafeMessagingInjector); ok {
+		injector.SetSafeMessaging(messaging)
+	}
+}
+
 func (m *Module) initAnswerSheetSubModule(mongoDB *mongo.Database, mysqlDB *gorm.DB, identitySvc *iam.IdentityService, repo AnswerSheetStore, reader surveyreadmodel.AnswerSheetReader, questionnaireRepo questionnaire.Repository, profile appEventing.ProfileBinding) error {
 	sub := m.AnswerSheet
 
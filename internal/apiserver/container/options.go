@@ -1,6 +1,8 @@
 package container
 
 import (
+	"time"
+
 	systemgov "github.com/FangcunMount/qs-server/internal/apiserver/application/systemgovernance"
 	"github.com/FangcunMount/qs-server/internal/apiserver/cache/subsystem"
 	eventsubsystem "github.com/FangcunMount/qs-server/internal/apiserver/eventing/subsystem"
@@ -24,6 +26,20 @@ type ContainerOptions struct {
 	Resilience *resiliencesubsystem.Subsystem
 	// PlanEntryBaseURL 测评计划任务入口基础地址
 	PlanEntryBaseURL string
+	// PseudonymSecret 受试者假名的 HMAC 密钥，为空时使用进程级随机密钥
+	PseudonymSecret string
+	// WorkbenchHighRiskClaimSLA 工作台高风险条目的认领时限，0 表示不计算 SLA、不做超时升级
+	WorkbenchHighRiskClaimSLA time.Duration
+	// RiskAlertLookback 风险预警只评估该窗口内完成的测评结果，0 表示不启动规则评估
+	RiskAlertLookback time.Duration
+	// SafeMessaging 答卷命中关键条目时返回给作答端的安全提示，nil 表示不返回
+	SafeMessaging *apiserveroptions.SafeMessagingOptions
+	// ReportPDF 报告 PDF 下载链接签名与页眉品牌配置，nil 时使用默认品牌与进程级随机密钥
+	ReportPDF *apiserveroptions.ReportPDFOptions
+	// ReportShare 报告对外分享的有效期与浏览次数配置，nil 时使用默认值
+	ReportShare *apiserveroptions.ReportShareOptions
+	// GroupReport 群体报告的最小单元格与群体规模配置，nil 时使用默认值
+	GroupReport *apiserveroptions.GroupReportOptions
 	// StatisticsRepairWindowDays 统计夜间批处理默认回补窗口
 	StatisticsRepairWindowDays int
 	// ReportStatus report_status 与 signaling YAML 配置
@@ -0,0 +1,64 @@
+package container
+
+import (
+	"context"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
+	planReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/planreport"
+	planReportInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/planreport"
+)
+
+// planReportConsumerIDs 任务终态事件上的纵向计划报告消费者；三类事件共用同一个处理函数。
+var planReportConsumerIDs = []string{
+	"interpretation.plan_report_on_task_completed",
+	"interpretation.plan_report_on_task_expired",
+	"interpretation.plan_report_on_task_canceled",
+}
+
+// planReportService 组装纵向计划报告服务；未接入 MySQL 或依赖模块缺失时返回 nil。
+func (c *Container) planReportService() planReportApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.planReport != nil {
+		return c.planReport
+	}
+	if c.mysqlDB == nil || c.PlanModule == nil || c.PlanModule.EnrollmentTimelineReader == nil ||
+		c.ReportModule == nil || c.ReportModule.RenderingRegistry() == nil ||
+		c.EvaluationModule == nil || c.EvaluationModule.OutcomeRepository() == nil ||
+		c.ActorModule == nil || c.ActorModule.TesteeAccessService == nil || c.EvaluationModule.TesteeService == nil {
+		return nil
+	}
+	c.planReport = planReportApp.NewService(
+		planReportInfra.NewReportRepository(c.mysqlDB),
+		c.PlanModule.EnrollmentTimelineReader,
+		c.EvaluationModule.OutcomeRepository(),
+		c.ReportModule.RenderingRegistry(),
+		planReportClinicianAccess{clinician: clinicianInterpretationAccess{relations: c.ActorModule.TesteeAccessService, ownership: c.EvaluationModule.TesteeService}},
+	)
+	return c.planReport
+}
+
+// planReportConsumer 任务终态事件的纵向报告消费者；服务在收到事件时才解析，不可用时记录告警并确认消息。
+func (c *Container) planReportConsumer() func(ctx context.Context, eventType string, payload []byte) error {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		service := c.planReportService()
+		if service == nil {
+			logger.L(ctx).Warnw("plan report service unavailable, skip generation",
+				"action", "generate_plan_report",
+				"event_type", eventType,
+			)
+			return nil
+		}
+		return planReportApp.NewTaskTerminalConsumer(service)(ctx, eventType, payload)
+	}
+}
+
+type planReportClinicianAccess struct {
+	clinician clinicianInterpretationAccess
+}
+
+func (a planReportClinicianAccess) AuthorizeParticipant(ctx context.Context, actor planReportApp.Actor, testeeID uint64) error {
+	return a.clinician.AuthorizeParticipant(ctx, interpretationclinician.Actor{OrgID: actor.OrgID, OperatorUserID: actor.OperatorUserID}, testeeID)
+}
@@ -0,0 +1,109 @@
+package container
+
+import (
+	"context"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
+	reportPDFApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	reportPDFInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+)
+
+// reportPDFService 组装报告 PDF 渲染与下载服务。
+// 对象存储在 Initialize 之后才注入，因此按需组装；未接入 MySQL 或对象存储时返回 nil。
+func (c *Container) reportPDFService() reportPDFApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.reportPDF != nil {
+		return c.reportPDF
+	}
+	store := c.AssessmentAssetStore
+	if store == nil {
+		store = c.QRCodeObjectStore
+	}
+	if c.mysqlDB == nil || store == nil || c.ReportModule == nil || c.ActorModule == nil || c.EvaluationModule == nil ||
+		c.ActorModule.TesteeAccessService == nil || c.EvaluationModule.TesteeService == nil {
+		return nil
+	}
+	opts := c.reportPDFOptions
+	if opts == nil {
+		opts = apiserveroptions.NewReportPDFOptions()
+	}
+	signer := reportPDFApp.NewLinkSigner(opts.SigningSecret)
+	if signer.Ephemeral() {
+		c.printf("⚠️  report_pdf.signing_secret not configured, report PDF download links are not valid across restarts or instances\n")
+	}
+	c.reportPDF = reportPDFApp.NewService(
+		reportPDFInfra.NewRenditionRepository(c.mysqlDB),
+		objectstorage.NewReportPDFStore(store),
+		c.ReportModule.ReportReader(),
+		signer,
+		reportPDFClinicianAccess{clinician: clinicianInterpretationAccess{relations: c.ActorModule.TesteeAccessService, ownership: c.EvaluationModule.TesteeService}},
+		participantInterpretationAccess{testees: c.ActorModule.TesteeQueryService, assessments: c.EvaluationModule.TesteeService},
+		reportPDFApp.Config{LinkTTL: opts.LinkTTL, Branding: newReportPDFBranding(opts)},
+	)
+	return c.reportPDF
+}
+
+// reportPDFConsumer 报告生成事件的 PDF 渲染消费者；服务在收到事件时才解析，未接入对象存储时记录告警并确认消息。
+func (c *Container) reportPDFConsumer() func(ctx context.Context, eventType string, payload []byte) error {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		service := c.reportPDFService()
+		if service == nil {
+			logger.L(ctx).Warnw("report pdf service unavailable, skip rendering",
+				"action", "render_report_pdf",
+				"event_type", eventType,
+			)
+			return nil
+		}
+		return reportPDFApp.NewReportGeneratedConsumer(service)(ctx, eventType, payload)
+	}
+}
+
+type reportPDFClinicianAccess struct {
+	clinician clinicianInterpretationAccess
+}
+
+func (a reportPDFClinicianAccess) AuthorizeAssessment(ctx context.Context, actor reportPDFApp.Actor, testeeID, assessmentID uint64) error {
+	return a.clinician.AuthorizeParticipantAssessment(ctx, interpretationclinician.Actor{OrgID: actor.OrgID, OperatorUserID: actor.OperatorUserID}, testeeID, assessmentID)
+}
+
+// reportPDFBranding 按机构解析页眉品牌，机构覆盖中未填写的字段沿用默认品牌。
+type reportPDFBranding struct {
+	defaults reportPDFApp.Branding
+	orgs     map[int64]reportPDFApp.Branding
+}
+
+func newReportPDFBranding(opts *apiserveroptions.ReportPDFOptions) reportPDFBranding {
+	resolver := reportPDFBranding{orgs: make(map[int64]reportPDFApp.Branding)}
+	if opts.Branding != nil {
+		resolver.defaults = reportPDFApp.Branding{OrgName: opts.Branding.OrgName, PrimaryColor: opts.Branding.PrimaryColor, Footnote: opts.Branding.Footnote}
+	}
+	for _, org := range opts.OrgBrandings {
+		if org == nil || org.OrgID <= 0 {
+			continue
+		}
+		branding := resolver.defaults
+		if org.OrgName != "" {
+			branding.OrgName = org.OrgName
+		}
+		if org.PrimaryColor != "" {
+			branding.PrimaryColor = org.PrimaryColor
+		}
+		if org.Footnote != "" {
+			branding.Footnote = org.Footnote
+		}
+		resolver.orgs[org.OrgID] = branding
+	}
+	return resolver
+}
+
+func (r reportPDFBranding) Branding(orgID int64) reportPDFApp.Branding {
+	if branding, ok := r.orgs[orgID]; ok {
+		return branding
+	}
+	return r.defaults
+}
@@ -0,0 +1,39 @@
+package container
+
+import (
+	reportShareApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportshare"
+	reportShareInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/reportshare"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+)
+
+// reportShareService 组装报告对外分享服务；未接入 MySQL 或解读模块时返回 nil。
+// 投影与受试者读取路径共用，分享选定的受众版本与受试者看到的变体来自同一来源。
+func (c *Container) reportShareService() reportShareApp.Service {
+	if c == nil {
+		return nil
+	}
+	if c.reportShare != nil {
+		return c.reportShare
+	}
+	if c.mysqlDB == nil || c.ReportModule == nil || c.ActorModule == nil || c.EvaluationModule == nil ||
+		c.EvaluationModule.TesteeService == nil {
+		return nil
+	}
+	opts := c.reportShareOptions
+	if opts == nil {
+		opts = apiserveroptions.NewReportShareOptions()
+	}
+	c.reportShare = reportShareApp.NewService(
+		reportShareInfra.NewGrantRepository(c.mysqlDB),
+		c.ReportModule.ReportReader(),
+		c.ReportModule.ProjectionMapper(),
+		participantInterpretationAccess{testees: c.ActorModule.TesteeQueryService, assessments: c.EvaluationModule.TesteeService},
+		reportShareApp.Config{
+			DefaultTTL:      opts.DefaultTTL,
+			MaxTTL:          opts.MaxTTL,
+			DefaultMaxViews: opts.DefaultMaxViews,
+			ViewLimit:       opts.ViewLimit,
+		},
+	)
+	return c.reportShare
+}
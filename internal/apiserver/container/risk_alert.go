@@ -0,0 +1,47 @@
+package container
+
+import (
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	modtx "github.com/FangcunMount/qs-server/internal/apiserver/container/internal/transaction"
+	riskAlertInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/riskalert"
+	eventcatalog "github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+)
+
+// riskAlertStore 风险预警规则与预警存储；规则管理、确认用例与后台扫描共用，因此由容器根持有。
+func (c *Container) riskAlertStore() riskAlertApp.Store {
+	if c == nil || c.mysqlDB == nil {
+		return nil
+	}
+	if c.riskAlerts == nil {
+		c.riskAlerts = riskAlertInfra.NewAlertRepository(c.mysqlDB)
+	}
+	return c.riskAlerts
+}
+
+// riskAlertService 组装风险预警规则管理与确认服务；操作者名称取自后台操作者读模型。
+func (c *Container) riskAlertService() riskAlertApp.Service {
+	store := c.riskAlertStore()
+	if store == nil || c.ActorModule == nil || c.ActorModule.ReadModel == nil {
+		return nil
+	}
+	return riskAlertApp.NewService(store, c.ActorModule.ReadModel)
+}
+
+// riskAlertMonitor 风险预警后台扫描；未配置评估窗口或测评结果不可读时返回 nil，不启动扫描。
+func (c *Container) riskAlertMonitor() riskAlertApp.Monitor {
+	store := c.riskAlertStore()
+	if store == nil || c.riskAlertLookback <= 0 || c.EvaluationModule == nil {
+		return nil
+	}
+	facts := c.EvaluationModule.OutcomeRepository()
+	if facts == nil {
+		return nil
+	}
+	return riskAlertApp.NewMonitor(
+		store,
+		facts,
+		modtx.NewMySQLRunner(c.mysqlDB),
+		c.EventProfile(eventcatalog.OutboxProfileAssessmentMySQL),
+		c.riskAlertLookback,
+	)
+}
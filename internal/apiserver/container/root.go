@@ -3,20 +3,35 @@ package container
 import (
 	"context"
 	"fmt"
+	"time"
 
 	redis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
 	"github.com/FangcunMount/component-base/pkg/event"
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+	clinicalReviewApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	planReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/planreport"
+	reportPDFApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	reportShareApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportshare"
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	fhirApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
+	subjectRights "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
 	systemgov "github.com/FangcunMount/qs-server/internal/apiserver/application/systemgovernance"
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
 	"github.com/FangcunMount/qs-server/internal/apiserver/cache/subsystem"
 	eventsubsystem "github.com/FangcunMount/qs-server/internal/apiserver/eventing/subsystem"
+	clinicalReviewInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/clinicalreview"
 	objectstorageport "github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage/port"
 	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
 	wechatmini "github.com/FangcunMount/qs-server/internal/apiserver/port/wechatmini"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
 	resiliencesubsystem "github.com/FangcunMount/qs-server/internal/apiserver/resilience/subsystem"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 	"github.com/FangcunMount/qs-server/internal/pkg/reportstatus"
 	locksubsystem "github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease/subsystem"
 	"github.com/FangcunMount/qs-server/internal/pkg/retrygovernance"
@@ -42,6 +57,13 @@ type Container struct {
 	resilienceCancel           context.CancelFunc
 	planEntryURL               string
 	statisticsRepairWindowDays int
+	pseudonymSecret            string
+	workbenchHighRiskClaimSLA  time.Duration
+	riskAlertLookback          time.Duration
+	safeMessaging              *apiserveroptions.SafeMessagingOptions
+	reportPDFOptions           *apiserveroptions.ReportPDFOptions
+	reportShareOptions         *apiserveroptions.ReportShareOptions
+	groupReportOptions         *apiserveroptions.GroupReportOptions
 	reportStatusConfig         reportstatus.Config
 	systemGovernanceOptions    *apiserveroptions.SystemGovernanceOptions
 	actionAuditStore           systemgov.ActionAuditStore
@@ -64,6 +86,21 @@ type Container struct {
 	CodesService          codesapp.CodesService  // CodesService 应用服务（code 申请）
 
 	workbenchLatestRiskReader workbenchreadmodel.LatestRiskReader
+	testeeImport              testeeImportRuntime
+	testeeMerge               testeeMerge.Service
+	consent                   consentApp.Service
+	accessAudit               accessAuditApp.Service
+	subjectRights             subjectRights.Service
+	pseudonyms                *redaction.Pseudonymizer
+	clinicalReviews           clinicalReviewInfra.ReadModel
+	clinicalReview            clinicalReviewApp.Service
+	workbenchTriage           workbenchApp.EscalationStore
+	riskAlerts                riskAlertApp.Store
+	reportPDF                 reportPDFApp.Service
+	reportShare               reportShareApp.Service
+	planReport                planReportApp.Service
+	groupReport               groupReportApp.Service
+	fhir                      fhirApp.Service
 
 	// Survey/Scale 基础设施由容器持有，业务模块只暴露应用服务。
 	surveyRuntimeInfra *surveymod.SurveyRuntimeInfra
@@ -155,6 +192,13 @@ func NewContainerWithOptions(mysqlDB *gorm.DB, mongoDB *mongo.Database, redisCac
 	}
 	c.planEntryURL = opts.PlanEntryBaseURL
 	c.statisticsRepairWindowDays = opts.StatisticsRepairWindowDays
+	c.pseudonymSecret = opts.PseudonymSecret
+	c.workbenchHighRiskClaimSLA = opts.WorkbenchHighRiskClaimSLA
+	c.riskAlertLookback = opts.RiskAlertLookback
+	c.safeMessaging = opts.SafeMessaging
+	c.reportPDFOptions = opts.ReportPDF
+	c.reportShareOptions = opts.ReportShare
+	c.groupReportOptions = opts.GroupReport
 	c.reportStatusConfig = reportstatus.ConfigFromOptions(opts.ReportStatus, opts.Signaling, "apiserver")
 	c.systemGovernanceOptions = opts.SystemGovernance
 	c.actionAuditStore = opts.ActionAuditStore
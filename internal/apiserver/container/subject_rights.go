@@ -0,0 +1,38 @@
+package container
+
+import (
+	subjectRights "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	subjectRightsMongo "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo/subjectrights"
+	subjectRightsInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage"
+)
+
+// subjectRightsService 组装数据主体导出与擦除服务。
+// 请求需要同时处理 MySQL、Mongo 与对象存储，因此由容器根装配；未启用 OSS 时导出包在下载时生成。
+func (c *Container) subjectRightsService() subjectRights.Service {
+	if c == nil {
+		return nil
+	}
+	if c.subjectRights != nil {
+		return c.subjectRights
+	}
+	if c.mysqlDB == nil || c.mongoDB == nil {
+		return nil
+	}
+	store := c.AssessmentAssetStore
+	if store == nil {
+		store = c.QRCodeObjectStore
+	}
+	var cache subjectRights.TesteeCacheEvictor
+	if c.ActorModule != nil && c.ActorModule.TesteeCacheEvictor != nil {
+		cache = c.ActorModule.TesteeCacheEvictor
+	}
+	c.subjectRights = subjectRights.NewService(
+		subjectRightsInfra.NewRequestRepository(c.mysqlDB),
+		subjectRightsMongo.NewDocumentStore(c.mongoDB),
+		objectstorage.NewSubjectBundleStore(store),
+		c.pseudonymizer(),
+		cache,
+	)
+	return c.subjectRights
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	testeeImportInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/testeeimport"
+)
+
+// testeeImportService 组装受试者批量导入服务（REST 与后台处理器共用同一实例）。
+// 导入编排跨越 actor 与 plan 模块，因此由容器根装配而不是归属单个模块。
+func (c *Container) testeeImportService() testeeImportRuntime {
+	if c == nil {
+		return nil
+	}
+	if c.testeeImport != nil {
+		return c.testeeImport
+	}
+	if c.mysqlDB == nil || c.ActorModule == nil || c.PlanModule == nil {
+		return nil
+	}
+	if c.ActorModule.TesteeRegistrationService == nil ||
+		c.ActorModule.ClinicianRelationshipService == nil ||
+		c.ActorModule.ClinicianQueryService == nil ||
+		c.PlanModule.QueryService == nil ||
+		c.PlanModule.CommandService == nil {
+		return nil
+	}
+	c.testeeImport = testeeImport.NewService(
+		testeeImportInfra.NewJobRepository(c.mysqlDB),
+		testeeImportInfra.NewTesteeMatcher(c.mysqlDB),
+		c.ActorModule.TesteeRegistrationService,
+		c.ActorModule.ClinicianRelationshipService,
+		c.ActorModule.ClinicianQueryService,
+		c.PlanModule.QueryService,
+		c.PlanModule.CommandService,
+	)
+	return c.testeeImport
+}
+
+type testeeImportRuntime interface {
+	testeeImport.Service
+	testeeImport.Processor
+}
@@ -0,0 +1,31 @@
+package container
+
+import (
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
+	testeeMergeMongo "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo/testeemerge"
+	testeeMergeInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/testeemerge"
+)
+
+// testeeMergeService 组装受试者判重与合并服务。
+// 合并需要同时迁移 MySQL 记录与 Mongo 文档，因此由容器根装配。
+func (c *Container) testeeMergeService() testeeMerge.Service {
+	if c == nil {
+		return nil
+	}
+	if c.testeeMerge != nil {
+		return c.testeeMerge
+	}
+	if c.mysqlDB == nil || c.mongoDB == nil {
+		return nil
+	}
+	var cache testeeMerge.TesteeCacheEvictor
+	if c.ActorModule != nil && c.ActorModule.TesteeCacheEvictor != nil {
+		cache = c.ActorModule.TesteeCacheEvictor
+	}
+	c.testeeMerge = testeeMerge.NewService(
+		testeeMergeInfra.NewMergeLogRepository(c.mysqlDB),
+		testeeMergeMongo.NewDocumentStore(c.mongoDB),
+		cache,
+	)
+	return c.testeeMerge
+}
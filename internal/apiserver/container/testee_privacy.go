@@ -0,0 +1,31 @@
+package container
+
+import (
+	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+)
+
+// pseudonymizer 返回受试者假名生成器。
+// 未配置 redaction.pseudonym_secret 时使用进程内随机密钥，假名仅在本次运行内稳定。
+func (c *Container) pseudonymizer() *redaction.Pseudonymizer {
+	if c == nil {
+		return nil
+	}
+	if c.pseudonyms != nil {
+		return c.pseudonyms
+	}
+	c.pseudonyms = redaction.NewPseudonymizer(c.pseudonymSecret)
+	if c.pseudonyms.Ephemeral() {
+		c.printf("⚠️  redaction.pseudonym_secret not configured, de-identified export pseudonyms are not stable across restarts\n")
+	}
+	return c.pseudonyms
+}
+
+// testeeExportService 组装受试者去标识化导出服务。
+func (c *Container) testeeExportService() testeeApp.TesteeExportService {
+	if c == nil || c.ActorModule == nil || c.ActorModule.TesteeQueryService == nil {
+		return nil
+	}
+	return testeeApp.NewExportService(c.ActorModule.TesteeQueryService, actorAccessApp.ExportRedactionPolicy(), c.pseudonymizer())
+}
@@ -12,6 +12,7 @@ import (
 	auth "github.com/FangcunMount/iam/v2/pkg/sdk/auth/verifier"
 	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
 	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/actorctx"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	cachegovernance "github.com/FangcunMount/qs-server/internal/apiserver/application/cachegovernance"
 	evaluationOperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
@@ -20,9 +21,12 @@ import (
 	interpretationAutomation "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation"
 	interpretationcatalog "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/catalogreconcile"
 	interpretationReadmission "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/readmission"
+	interpretationRegeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
 	interpretationReportTemplate "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reporttemplate"
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
 	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
 	reportwaitjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportwait"
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
 	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
 	statisticsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/statistics"
 	systemgovApp "github.com/FangcunMount/qs-server/internal/apiserver/application/systemgovernance"
@@ -33,6 +37,7 @@ import (
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/admission"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/generation"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
 	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
 	interpretationrun "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/run"
 	iaminfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
@@ -81,6 +86,7 @@ func (c *Container) BuildRESTDeps(rateCfg *options.RateLimitOptions) resttranspo
 		deps.Survey = c.SurveyModule.ExportRESTDeps(surveymod.RESTExportOptions{
 			QRCodeService: c.QRCodeService,
 		})
+		deps.Survey.ModelScopeResolver = customRoleApp.NewPublishedModelScopeResolver(c.PublishedModelCatalog())
 	}
 	if c.AssessmentModelModule != nil {
 		exports := c.AssessmentModelModule.ExportRESTDeps(c.QRCodeService, c.CodesService, deps.Survey.QuestionnaireQueryService)
@@ -107,6 +113,23 @@ func (c *Container) BuildRESTDeps(rateCfg *options.RateLimitOptions) resttranspo
 		deps.Interpretation.OperationsService = c.ReportModule.OperationsService()
 		deps.Interpretation.CatalogReconcile = c.ReportModule.CatalogReconcileService()
 		deps.Interpretation.ReportTemplates = c.ReportModule.ReportTemplateService()
+		deps.Interpretation.ReportTemplateRollouts = c.ReportModule.ReportTemplateRolloutService()
+		deps.Interpretation.ReportRegenerations = c.ReportModule.ReportRegenerationService()
+	}
+	if service := c.clinicalReviewService(); service != nil {
+		deps.Interpretation.ClinicalReview = service
+	}
+	if service := c.riskAlertService(); service != nil {
+		deps.Interpretation.RiskAlerts = service
+	}
+	if service := c.reportPDFService(); service != nil {
+		deps.Interpretation.ReportPDF = service
+	}
+	if service := c.planReportService(); service != nil {
+		deps.Interpretation.PlanReports = service
+	}
+	if service := c.groupReportService(); service != nil {
+		deps.Interpretation.GroupReports = service
 	}
 	if c.PlanModule != nil {
 		var testeeAccess actorAccessApp.TesteeAccessService
@@ -116,6 +139,27 @@ func (c *Container) BuildRESTDeps(rateCfg *options.RateLimitOptions) resttranspo
 		deps.Plan = c.PlanModule.ExportRESTDeps(testeeAccess)
 	}
 	deps.Workbench = composeRESTWorkbenchDeps(c)
+	if service := c.testeeImportService(); service != nil {
+		deps.TesteeImport.Service = service
+	}
+	if service := c.testeeMergeService(); service != nil {
+		deps.TesteeMerge.Service = service
+	}
+	if service := c.consentService(); service != nil {
+		deps.Consent.Service = service
+	}
+	if service := c.accessAuditService(); service != nil {
+		deps.AccessAudit.Service = service
+	}
+	if service := c.testeeExportService(); service != nil {
+		deps.TesteePrivacy.ExportService = service
+	}
+	if service := c.subjectRightsService(); service != nil {
+		deps.SubjectRights.Service = service
+	}
+	if service := c.fhirService(); service != nil {
+		deps.FHIR.Service = service
+	}
 	if c.StatisticsModule != nil {
 		deps.Statistics = c.StatisticsModule.ExportRESTDeps()
 	}
@@ -168,6 +212,38 @@ type reportTemplateActionInput struct {
 	Reason          string `json:"reason"`
 }
 
+type reportTemplateRolloutStartInput struct {
+	TemplateID       string  `json:"template_id"`
+	StableVersion    string  `json:"stable_version"`
+	CandidateVersion string  `json:"candidate_version"`
+	Mode             string  `json:"mode"`
+	Percent          int     `json:"percent"`
+	OrgIDs           []int64 `json:"org_ids"`
+	Reason           string  `json:"reason"`
+}
+
+type reportTemplateRolloutCloseInput struct {
+	RolloutID       string `json:"rollout_id"`
+	ExpectedVersion uint64 `json:"expected_version"`
+	Reason          string `json:"reason"`
+}
+
+type reportRegenerationStartInput struct {
+	ModelCode     string     `json:"model_code"`
+	ModelVersion  string     `json:"model_version"`
+	GeneratedFrom *time.Time `json:"generated_from"`
+	GeneratedTo   *time.Time `json:"generated_to"`
+	TemplateID    string     `json:"template_id"`
+	TargetVersion string     `json:"target_version"`
+	Reason        string     `json:"reason"`
+}
+
+type reportRegenerationCancelInput struct {
+	BatchID         string `json:"batch_id"`
+	ExpectedVersion uint64 `json:"expected_version"`
+	Reason          string `json:"reason"`
+}
+
 type readmissionActionInput struct {
 	FailureFingerprint     string `json:"failure_fingerprint"`
 	ExpectedReason         string `json:"expected_reason"`
@@ -239,6 +315,17 @@ func (c *Container) retryGovernanceActionHandlers() map[string]systemgovApp.Acti
 		handlers["interpretation.report_template_publish"] = reportTemplateGovernanceHandler(service, true)
 		handlers["interpretation.report_template_disable"] = reportTemplateGovernanceHandler(service, false)
 	}
+	if c != nil && c.ReportModule != nil && c.ReportModule.ReportTemplateRolloutService() != nil {
+		service := c.ReportModule.ReportTemplateRolloutService()
+		handlers["interpretation.report_template_rollout_start"] = reportTemplateRolloutStartHandler(service)
+		handlers["interpretation.report_template_rollout_promote"] = reportTemplateRolloutCloseHandler(service, true)
+		handlers["interpretation.report_template_rollout_rollback"] = reportTemplateRolloutCloseHandler(service, false)
+	}
+	if c != nil && c.ReportModule != nil && c.ReportModule.ReportRegenerationService() != nil {
+		service := c.ReportModule.ReportRegenerationService()
+		handlers["interpretation.report_regeneration_start"] = reportRegenerationStartHandler(service)
+		handlers["interpretation.report_regeneration_cancel"] = reportRegenerationCancelHandler(service)
+	}
 	if c != nil && c.ReportModule != nil && c.ReportModule.ReadmissionService() != nil {
 		handlers["interpretation.readmit_outcome"] = func(ctx context.Context, orgID int64, requestID string, input map[string]interface{}) (map[string]interface{}, error) {
 			var request readmissionActionInput
@@ -332,6 +419,146 @@ func reportTemplateGovernanceHandler(service interpretationReportTemplate.Servic
 	}
 }
 
+func reportTemplateRolloutStartHandler(service interpretationReportTemplate.RolloutService) systemgovApp.ActionHandler {
+	return func(ctx context.Context, _ int64, _ string, input map[string]interface{}) (map[string]interface{}, error) {
+		var request reportTemplateRolloutStartInput
+		payload, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, err
+		}
+		if request.TemplateID == "" || request.StableVersion == "" || request.CandidateVersion == "" || request.Mode == "" || request.Reason == "" {
+			return nil, fmt.Errorf("template_id, stable_version, candidate_version, mode and reason are required")
+		}
+		rollout, err := service.StartRollout(ctx, interpretationReportTemplate.StartRolloutCommand{
+			Actor:      interpretationReportTemplate.Actor{OperatorUserID: int64(actorctx.GrantingUserID(ctx))},
+			TemplateID: request.TemplateID, StableVersion: policy.TemplateVersion(request.StableVersion),
+			CandidateVersion: policy.TemplateVersion(request.CandidateVersion), Mode: domainreporttemplate.RolloutMode(request.Mode),
+			Percent: request.Percent, OrgIDs: request.OrgIDs, Reason: request.Reason,
+		})
+		if err != nil {
+			return nil, normalizeReportTemplateRolloutError(err)
+		}
+		return reportTemplateRolloutActionResult(rollout), nil
+	}
+}
+
+func reportTemplateRolloutCloseHandler(service interpretationReportTemplate.RolloutService, promote bool) systemgovApp.ActionHandler {
+	return func(ctx context.Context, _ int64, _ string, input map[string]interface{}) (map[string]interface{}, error) {
+		var request reportTemplateRolloutCloseInput
+		payload, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, err
+		}
+		rolloutID, err := meta.ParseID(request.RolloutID)
+		if err != nil || rolloutID.IsZero() || request.ExpectedVersion == 0 || request.Reason == "" {
+			return nil, fmt.Errorf("rollout_id, expected_version and reason are required")
+		}
+		command := interpretationReportTemplate.CloseRolloutCommand{
+			Actor:     interpretationReportTemplate.Actor{OperatorUserID: int64(actorctx.GrantingUserID(ctx))},
+			RolloutID: rolloutID, ExpectedVersion: request.ExpectedVersion, Reason: request.Reason,
+		}
+		var rollout *domainreporttemplate.Rollout
+		if promote {
+			rollout, err = service.PromoteRollout(ctx, command)
+		} else {
+			rollout, err = service.RollbackRollout(ctx, command)
+		}
+		if err != nil {
+			return nil, normalizeReportTemplateRolloutError(err)
+		}
+		return reportTemplateRolloutActionResult(rollout), nil
+	}
+}
+
+func reportTemplateRolloutActionResult(rollout *domainreporttemplate.Rollout) map[string]interface{} {
+	return map[string]interface{}{
+		"rollout_id": rollout.ID().String(), "template_id": rollout.TemplateID(),
+		"stable_version": rollout.StableVersion().String(), "candidate_version": rollout.CandidateVersion().String(),
+		"mode": rollout.Mode(), "status": rollout.Status(), "version": rollout.Version(),
+	}
+}
+
+func reportRegenerationStartHandler(service interpretationRegeneration.Service) systemgovApp.ActionHandler {
+	return func(ctx context.Context, orgID int64, requestID string, input map[string]interface{}) (map[string]interface{}, error) {
+		var request reportRegenerationStartInput
+		payload, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, err
+		}
+		if request.ModelCode == "" || request.TemplateID == "" || request.TargetVersion == "" || request.Reason == "" {
+			return nil, fmt.Errorf("model_code, template_id, target_version and reason are required")
+		}
+		batch, err := service.Start(ctx, interpretationRegeneration.StartCommand{
+			Actor:     interpretationRegeneration.Actor{OperatorUserID: int64(actorctx.GrantingUserID(ctx))},
+			RequestID: requestID,
+			Selector: domainregeneration.Selector{
+				OrgID: orgID, ModelCode: request.ModelCode, ModelVersion: request.ModelVersion,
+				GeneratedFrom: request.GeneratedFrom, GeneratedTo: request.GeneratedTo,
+			},
+			TemplateID: request.TemplateID, TargetVersion: policy.TemplateVersion(request.TargetVersion), Reason: request.Reason,
+		})
+		if err != nil {
+			return nil, normalizeReportRegenerationError(err)
+		}
+		return reportRegenerationActionResult(batch), nil
+	}
+}
+
+func reportRegenerationCancelHandler(service interpretationRegeneration.Service) systemgovApp.ActionHandler {
+	return func(ctx context.Context, orgID int64, _ string, input map[string]interface{}) (map[string]interface{}, error) {
+		var request reportRegenerationCancelInput
+		payload, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, err
+		}
+		batchID, err := meta.ParseID(request.BatchID)
+		if err != nil || batchID.IsZero() || request.ExpectedVersion == 0 || request.Reason == "" {
+			return nil, fmt.Errorf("batch_id, expected_version and reason are required")
+		}
+		batch, err := service.Cancel(ctx, interpretationRegeneration.CancelCommand{
+			Actor: interpretationRegeneration.Actor{OperatorUserID: int64(actorctx.GrantingUserID(ctx))},
+			OrgID: orgID, BatchID: batchID, ExpectedVersion: request.ExpectedVersion, Reason: request.Reason,
+		})
+		if err != nil {
+			return nil, normalizeReportRegenerationError(err)
+		}
+		return reportRegenerationActionResult(batch), nil
+	}
+}
+
+func reportRegenerationActionResult(batch *domainregeneration.Batch) map[string]interface{} {
+	return map[string]interface{}{
+		"batch_id": batch.ID().String(), "template_id": batch.TemplateID(), "target_version": batch.TargetVersion().String(),
+		"estimated": batch.Estimated(), "status": batch.Status(), "version": batch.Version(),
+	}
+}
+
+func normalizeReportRegenerationError(err error) error {
+	if stderrors.Is(err, domainregeneration.ErrBatchConflict) {
+		return baseerrors.WithCode(code.ErrConflict, "%s", err.Error())
+	}
+	return err
+}
+
+func normalizeReportTemplateRolloutError(err error) error {
+	if stderrors.Is(err, domainreporttemplate.ErrRolloutConflict) {
+		return baseerrors.WithCode(code.ErrConflict, "%s", err.Error())
+	}
+	return err
+}
+
 func normalizeGovernedRetryError(err error) error {
 	if err == nil {
 		return nil
@@ -417,6 +644,12 @@ func (c *Container) BuildGRPCDeps(server *grpcpkg.Server) grpctransport.Deps {
 	if c.ReportModule != nil {
 		deps.Interpretation = c.ReportModule.ExportGRPCDeps()
 	}
+	if service := c.reportPDFService(); service != nil {
+		deps.Interpretation.ReportPDF = service
+	}
+	if service := c.reportShareService(); service != nil {
+		deps.Interpretation.ReportShare = service
+	}
 	if c.AssessmentModelModule != nil {
 		exports := c.AssessmentModelModule.ExportGRPCDeps()
 		deps.AssessmentModelCatalog = exports.AssessmentModelCatalog
@@ -424,6 +657,9 @@ func (c *Container) BuildGRPCDeps(server *grpcpkg.Server) grpctransport.Deps {
 	if c.PlanModule != nil {
 		deps.Plan = c.PlanModule.ExportGRPCDeps()
 	}
+	if service := c.consentService(); service != nil {
+		deps.Consent.Service = service
+	}
 	return deps
 }
 
@@ -460,6 +696,11 @@ func composeRESTWorkbenchDeps(c *Container) resttransport.WorkbenchDeps {
 		c.ActorModule.ReadModel,
 		c.workbenchLatestRiskReader,
 		c.PlanModule.FollowUpQueueReader,
+		c.ActorModule.BreakGlassReader,
+		c.ActorModule.CareTeamReader,
+		c.awaitingReviewReader(),
+		c.criticalItemReader(),
+		c.workbenchTriageConfig(),
 		c.ActorModule.AssessmentSummaryReader,
 	)
 	return deps
@@ -484,6 +725,10 @@ type ServerRuntimeDeps struct {
 	StatisticsCoordinator                 *statisticsApp.Coordinator
 	EvaluationConsistencyReconcileService evaluationScheduler.Service
 	ReportCatalogAuditService             interpretationcatalog.RunnerService
+	TesteeImportProcessor                 testeeImport.Processor
+	WorkbenchEscalator                    workbenchApp.Escalator
+	RiskAlertMonitor                      riskAlertApp.Monitor
+	ReportRegenerationProcessor           interpretationRegeneration.Processor
 }
 
 func (c *Container) BuildServerGRPCBootstrapDeps() ServerGRPCBootstrapDeps {
@@ -522,6 +767,16 @@ func (c *Container) BuildServerRuntimeDeps() ServerRuntimeDeps {
 	}
 	if c.ReportModule != nil {
 		deps.ReportCatalogAuditService = c.ReportModule.CatalogAuditService()
+		deps.ReportRegenerationProcessor = c.ReportModule.ReportRegenerationProcessor()
+	}
+	if service := c.testeeImportService(); service != nil {
+		deps.TesteeImportProcessor = service
+	}
+	if escalator := c.workbenchEscalator(); escalator != nil {
+		deps.WorkbenchEscalator = escalator
+	}
+	if monitor := c.riskAlertMonitor(); monitor != nil {
+		deps.RiskAlertMonitor = monitor
 	}
 	if c.EvaluationModule != nil {
 		leaseRecoveryEnabled := c.systemGovernanceOptions == nil || c.systemGovernanceOptions.Retry == nil || c.systemGovernanceOptions.Retry.LeaseReconcileEnabled
@@ -0,0 +1,35 @@
+package container
+
+import (
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
+	workbenchTriageInfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
+)
+
+// workbenchTriageStore 工作台分诊仓储；分诊用例与超时升级扫描共用，因此由容器根持有。
+func (c *Container) workbenchTriageStore() workbenchApp.EscalationStore {
+	if c == nil || c.mysqlDB == nil {
+		return nil
+	}
+	if c.workbenchTriage == nil {
+		c.workbenchTriage = workbenchTriageInfra.NewItemRepository(c.mysqlDB)
+	}
+	return c.workbenchTriage
+}
+
+// workbenchTriageConfig 工作台分诊配置；未接入 MySQL 时返回 nil，队列不区分分诊状态。
+func (c *Container) workbenchTriageConfig() *workbenchApp.TriageConfig {
+	store := c.workbenchTriageStore()
+	if store == nil {
+		return nil
+	}
+	return &workbenchApp.TriageConfig{Store: store, HighRiskClaimSLA: c.workbenchHighRiskClaimSLA}
+}
+
+// workbenchEscalator 高风险条目认领超时升级；未配置认领时限时返回 nil，不启动扫描。
+func (c *Container) workbenchEscalator() workbenchApp.Escalator {
+	store := c.workbenchTriageStore()
+	if store == nil || c.workbenchHighRiskClaimSLA <= 0 {
+		return nil
+	}
+	return workbenchApp.NewEscalator(store, c.workbenchHighRiskClaimSLA)
+}
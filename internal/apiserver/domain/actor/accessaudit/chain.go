@@ -0,0 +1,63 @@
+package accessaudit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+)
+
+// HashTimeLayout 参与哈希的时间格式；记录时间先截断到毫秒，与 DATETIME(3) 的存储精度一致。
+const HashTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// canonicalEntry 参与哈希的字段，字段顺序固定，新增字段只能追加。
+type canonicalEntry struct {
+	OrgID        int64  `json:"org_id"`
+	Seq          uint64 `json:"seq"`
+	ActorUserID  int64  `json:"actor_user_id"`
+	ActorRole    string `json:"actor_role"`
+	Relation     string `json:"relation"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	TesteeID     string `json:"testee_id"`
+	Purpose      string `json:"purpose"`
+	Result       string `json:"result"`
+	StatusCode   int    `json:"status_code"`
+	Route        string `json:"route"`
+	RequestID    string `json:"request_id"`
+	ClientIP     string `json:"client_ip"`
+	OccurredAt   string `json:"occurred_at"`
+}
+
+// Seal 把记录接到链尾：设置序号与前序哈希并计算本条哈希。由 Repository.Append 在持有链尾锁时调用。
+func (e *Entry) Seal(head ChainHead) {
+	e.Seq = head.Seq + 1
+	e.PrevHash = head.Hash
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash 计算 sha256(PrevHash + "\n" + 规范化 JSON)。
+func (e *Entry) ComputeHash() string {
+	payload, _ := json.Marshal(canonicalEntry{
+		OrgID:        e.OrgID,
+		Seq:          e.Seq,
+		ActorUserID:  e.ActorUserID,
+		ActorRole:    e.ActorRole,
+		Relation:     e.Relation,
+		ResourceType: string(e.ResourceType),
+		ResourceID:   e.ResourceID,
+		TesteeID:     strconv.FormatUint(e.TesteeID, 10),
+		Purpose:      e.Purpose,
+		Result:       string(e.Result),
+		StatusCode:   e.StatusCode,
+		Route:        e.Route,
+		RequestID:    e.RequestID,
+		ClientIP:     e.ClientIP,
+		OccurredAt:   e.OccurredAt.UTC().Format(HashTimeLayout),
+	})
+	sum := sha256.New()
+	sum.Write([]byte(e.PrevHash))
+	sum.Write([]byte("\n"))
+	sum.Write(payload)
+	return hex.EncodeToString(sum.Sum(nil))
+}
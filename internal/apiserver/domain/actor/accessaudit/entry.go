@@ -0,0 +1,75 @@
+// Package accessaudit 受试者敏感数据访问审计记录：每个机构一条只追加的哈希链，
+// 记录的序号在机构内连续递增，哈希覆盖前序哈希与规范化字段，任何改写或删除都会使校验失败。
+package accessaudit
+
+import "time"
+
+// ResourceType 被访问的资源类型。
+type ResourceType string
+
+const (
+	ResourceTestee               ResourceType = "testee"                // 受试者档案
+	ResourceAnswerSheet          ResourceType = "answer_sheet"          // 答卷
+	ResourceAssessmentReport     ResourceType = "assessment_report"     // 测评报告（后台）
+	ResourceAssessmentScores     ResourceType = "assessment_scores"     // 测评得分
+	ResourceInterpretationReport ResourceType = "interpretation_report" // 临床人员查看的受试者报告
+	ResourceReportList           ResourceType = "report_list"           // 受试者报告列表
+	ResourceScaleAnalysis        ResourceType = "scale_analysis"        // 受试者量表趋势分析
+	ResourceTesteeUnmask         ResourceType = "testee_pii_unmask"     // 显式解除受试者 PII 脱敏
+	ResourceDataSubjectBundle    ResourceType = "data_subject_bundle"   // 数据主体导出包下载
+	ResourceReportPDFDownload    ResourceType = "report_pdf_download"   // 凭签名令牌下载报告 PDF
+	ResourceFHIRExport           ResourceType = "fhir_export"           // 机构 FHIR 批量导出
+	ResourceBreakGlassGrant      ResourceType = "break_glass_grant"     // 紧急访问授予（与授权同一事务写入）
+	ResourceGroupReport          ResourceType = "group_report"          // 机构群体报告及其 PDF
+)
+
+// Result 访问结果。
+type Result string
+
+const (
+	ResultAllowed  Result = "allowed"   // 已返回数据
+	ResultDenied   Result = "denied"    // 权限不足
+	ResultNotFound Result = "not_found" // 资源不存在或不在机构范围内
+	ResultInvalid  Result = "invalid"   // 请求参数无效
+	ResultError    Result = "error"     // 服务端错误
+)
+
+// Entry 追加到机构审计链上的一条记录。
+// Seq 在机构内从 1 连续递增，Hash = sha256(PrevHash + 规范化字段)。
+type Entry struct {
+	ID           uint64
+	OrgID        int64
+	Seq          uint64
+	ActorUserID  int64
+	ActorRole    string
+	Relation     string
+	ResourceType ResourceType
+	ResourceID   string
+	TesteeID     uint64
+	Purpose      string
+	Result       Result
+	StatusCode   int
+	Route        string
+	RequestID    string
+	ClientIP     string
+	OccurredAt   time.Time
+	PrevHash     string
+	Hash         string
+}
+
+// ChainHead 机构审计链的链尾；空链的 Seq 为 0、Hash 为空。
+type ChainHead struct {
+	Seq  uint64
+	Hash string
+}
+
+// Filter 审计记录查询条件；时间为 [From, To) 半开区间，零值表示不限。
+type Filter struct {
+	OrgID        int64
+	ActorUserID  int64
+	TesteeID     uint64
+	ResourceType ResourceType
+	Result       Result
+	From         time.Time
+	To           time.Time
+}
@@ -0,0 +1,16 @@
+package accessaudit
+
+import "context"
+
+// Repository 访问审计的追加写仓储；不提供修改与删除。
+type Repository interface {
+	// Append 在一个事务内锁定机构链尾，调用 entry.Seal 计算序号与哈希后写入记录并推进链尾。
+	Append(ctx context.Context, entry *Entry) error
+	// AppendInTx 与 Append 相同，但加入上下文中的事务：记录与调用方的业务写入一并提交或回滚。
+	// 上下文中没有事务时等同于 Append。
+	AppendInTx(ctx context.Context, entry *Entry) error
+	List(ctx context.Context, filter Filter, offset, limit int) ([]Entry, int64, error)
+	// ListChain 按序号升序读取 afterSeq 之后的记录，用于校验哈希链。
+	ListChain(ctx context.Context, orgID int64, afterSeq uint64, limit int) ([]Entry, error)
+	Head(ctx context.Context, orgID int64) (ChainHead, error)
+}
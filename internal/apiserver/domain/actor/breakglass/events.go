@@ -0,0 +1,93 @@
+package breakglass
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+)
+
+const (
+	// AggregateTypeGrant 紧急访问授权聚合根类型
+	AggregateTypeGrant = "BreakGlassGrant"
+
+	// EventTypeGranted 紧急访问授予事件
+	EventTypeGranted = eventcatalog.BreakGlassGranted
+
+	// EventTypeReviewed 紧急访问复核事件
+	EventTypeReviewed = eventcatalog.BreakGlassReviewed
+
+	// EventTypeRevoked 紧急访问撤销事件
+	EventTypeRevoked = eventcatalog.BreakGlassRevoked
+)
+
+// GrantedData 紧急访问授予事件数据
+type GrantedData = eventpayload.BreakGlassGrantedData
+
+// GrantedEvent 紧急访问授予事件
+type GrantedEvent = event.Event[GrantedData]
+
+// ReviewedData 紧急访问复核事件数据
+type ReviewedData = eventpayload.BreakGlassReviewedData
+
+// ReviewedEvent 紧急访问复核事件
+type ReviewedEvent = event.Event[ReviewedData]
+
+// RevokedData 紧急访问撤销事件数据
+type RevokedData = eventpayload.BreakGlassRevokedData
+
+// RevokedEvent 紧急访问撤销事件
+type RevokedEvent = event.Event[RevokedData]
+
+// NewGrantedEvent 根据新授予的紧急访问创建授予事件，供机构管理员事后复核
+func NewGrantedEvent(g *Grant) GrantedEvent {
+	grantID := strconv.FormatUint(g.ID, 10)
+	return event.New(EventTypeGranted, AggregateTypeGrant, grantID, GrantedData{
+		OrgID:          g.OrgID,
+		GrantID:        grantID,
+		ClinicianID:    strconv.FormatUint(g.ClinicianID, 10),
+		OperatorUserID: strconv.FormatInt(g.OperatorUserID, 10),
+		TesteeID:       strconv.FormatUint(g.TesteeID, 10),
+		Justification:  g.Justification,
+		GrantedAt:      g.GrantedAt,
+		ExpiresAt:      g.ExpiresAt,
+	})
+}
+
+// NewReviewedEvent 根据已复核的紧急访问创建复核事件
+func NewReviewedEvent(g *Grant) ReviewedEvent {
+	grantID := strconv.FormatUint(g.ID, 10)
+	var reviewedAt time.Time
+	if g.ReviewedAt != nil {
+		reviewedAt = *g.ReviewedAt
+	}
+	return event.New(EventTypeReviewed, AggregateTypeGrant, grantID, ReviewedData{
+		OrgID:          g.OrgID,
+		GrantID:        grantID,
+		OperatorUserID: strconv.FormatInt(g.OperatorUserID, 10),
+		TesteeID:       strconv.FormatUint(g.TesteeID, 10),
+		ReviewerUserID: strconv.FormatInt(g.ReviewedBy, 10),
+		Decision:       string(g.ReviewStatus),
+		Note:           g.ReviewNote,
+		ReviewedAt:     reviewedAt,
+	})
+}
+
+// NewRevokedEvent 根据已撤销的紧急访问创建撤销事件
+func NewRevokedEvent(g *Grant) RevokedEvent {
+	grantID := strconv.FormatUint(g.ID, 10)
+	var revokedAt time.Time
+	if g.RevokedAt != nil {
+		revokedAt = *g.RevokedAt
+	}
+	return event.New(EventTypeRevoked, AggregateTypeGrant, grantID, RevokedData{
+		OrgID:          g.OrgID,
+		GrantID:        grantID,
+		OperatorUserID: strconv.FormatInt(g.OperatorUserID, 10),
+		TesteeID:       strconv.FormatUint(g.TesteeID, 10),
+		RevokedBy:      strconv.FormatInt(g.RevokedBy, 10),
+		RevokedAt:      revokedAt,
+	})
+}
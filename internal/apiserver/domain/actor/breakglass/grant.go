@@ -0,0 +1,77 @@
+// Package breakglass 紧急访问授权：从业者与受试者之间没有授权关系时的短时单受试者访问。
+// 授权按 ExpiresAt/RevokedAt 判断是否生效，到期无需后台任务。
+package breakglass
+
+import "time"
+
+// Status 授权当前状态，由 ExpiresAt/RevokedAt 与当前时间推导，不落库。
+type Status string
+
+const (
+	StatusActive  Status = "active"  // 生效中
+	StatusExpired Status = "expired" // 已到期
+	StatusRevoked Status = "revoked" // 已提前结束
+)
+
+// ReviewStatus 机构管理员的事后复核结论。
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"  // 待复核
+	ReviewApproved ReviewStatus = "approved" // 复核通过
+	ReviewFlagged  ReviewStatus = "flagged"  // 复核存疑，需线下跟进
+)
+
+// Grant 一次紧急访问授权。
+type Grant struct {
+	ID             uint64
+	OrgID          int64
+	ClinicianID    uint64
+	OperatorUserID int64
+	TesteeID       uint64
+	Justification  string
+	GrantedAt      time.Time
+	ExpiresAt      time.Time
+	RevokedAt      *time.Time
+	RevokedBy      int64
+	ReviewStatus   ReviewStatus
+	ReviewedBy     int64
+	ReviewedAt     *time.Time
+	ReviewNote     string
+	// Status 查询结果中按当前时间填充。
+	Status Status
+}
+
+// StatusAt 返回授权在 now 时刻的状态。
+func (g *Grant) StatusAt(now time.Time) Status {
+	switch {
+	case g.RevokedAt != nil:
+		return StatusRevoked
+	case !now.Before(g.ExpiresAt):
+		return StatusExpired
+	default:
+		return StatusActive
+	}
+}
+
+// ActiveAt 判断授权在 now 时刻是否生效。
+func (g *Grant) ActiveAt(now time.Time) bool {
+	return g.StatusAt(now) == StatusActive
+}
+
+// Filter 授权查询条件；ActiveOnly 只返回查询时刻仍生效的授权。
+type Filter struct {
+	OrgID          int64
+	ClinicianID    uint64
+	OperatorUserID int64
+	TesteeID       uint64
+	ReviewStatus   ReviewStatus
+	ActiveOnly     bool
+}
+
+// ActiveGrantQuery 查询生效中的授权；ClinicianID 为 0 表示不限从业者，TesteeIDs 为空表示不限受试者。
+type ActiveGrantQuery struct {
+	OrgID       int64
+	ClinicianID uint64
+	TesteeIDs   []uint64
+}
@@ -0,0 +1,19 @@
+package breakglass
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 紧急访问授权仓储接口；写操作加入上下文中的事务。
+type Repository interface {
+	HasActiveGrant(ctx context.Context, orgID int64, clinicianID, testeeID uint64, now time.Time) (bool, error)
+	ListActiveGrants(ctx context.Context, query ActiveGrantQuery, now time.Time) ([]Grant, error)
+	Create(ctx context.Context, grant *Grant) error
+	// Find 不存在时返回 nil, nil。
+	Find(ctx context.Context, orgID int64, id uint64) (*Grant, error)
+	List(ctx context.Context, filter Filter, now time.Time, offset, limit int) ([]Grant, int64, error)
+	// Revoke 仅对尚未撤销且未到期的授权生效，返回是否有记录被更新。
+	Revoke(ctx context.Context, orgID int64, id uint64, revokedBy int64, at time.Time) (bool, error)
+	UpdateReview(ctx context.Context, grant *Grant) error
+}
@@ -0,0 +1,44 @@
+package careteam
+
+import "context"
+
+// AccessReader 供访问控制、访问审计与工作台读取团队继承的受试者访问。
+// 只有继承访问的角色（见 AccessRoles）参与判定。
+type AccessReader interface {
+	// HasTeamAccess 从业者是否经由任一团队继承对受试者的访问。
+	HasTeamAccess(ctx context.Context, orgID int64, clinicianID, testeeID uint64) (bool, error)
+	// ListAccessibleTesteeIDs 从业者经由团队继承访问的全部受试者。
+	ListAccessibleTesteeIDs(ctx context.Context, orgID int64, clinicianID uint64) ([]uint64, error)
+	// ListTeamTesteeIDs 分配给团队的全部受试者。
+	ListTeamTesteeIDs(ctx context.Context, orgID int64, teamID uint64) ([]uint64, error)
+	// FindMember 不存在时返回 nil, nil。
+	FindMember(ctx context.Context, orgID int64, teamID, clinicianID uint64) (*Member, error)
+}
+
+// Repository 照护团队仓储接口。所有变更方法都在同一事务内写入传入的事件。
+type Repository interface {
+	AccessReader
+
+	CreateTeam(ctx context.Context, team *Team, event *Event) error
+	UpdateTeam(ctx context.Context, team *Team, event *Event) error
+	// DeleteTeam 删除团队及其成员与受试者分配；删除后同一名称可重新创建，事件保留。
+	DeleteTeam(ctx context.Context, orgID int64, teamID uint64, event *Event) error
+	// FindTeam / FindTeamByName 不存在时返回 nil, nil。
+	FindTeam(ctx context.Context, orgID int64, teamID uint64) (*Team, error)
+	FindTeamByName(ctx context.Context, orgID int64, name string) (*Team, error)
+	ListTeams(ctx context.Context, filter TeamFilter) ([]Team, error)
+
+	CreateMember(ctx context.Context, member *Member, event *Event) error
+	UpdateMemberRole(ctx context.Context, member *Member, event *Event) error
+	// DeleteMember 返回是否有成员被删除；未删除时不写事件。
+	DeleteMember(ctx context.Context, orgID int64, teamID, clinicianID uint64, event *Event) (bool, error)
+	ListMembers(ctx context.Context, orgID int64, teamID uint64) ([]Member, error)
+
+	// AssignTestee 已分配时返回 false 且不写事件。
+	AssignTestee(ctx context.Context, assignment *TesteeAssignment, event *Event) (bool, error)
+	// UnassignTestee 返回是否有分配被删除；未删除时不写事件。
+	UnassignTestee(ctx context.Context, orgID int64, teamID, testeeID uint64, event *Event) (bool, error)
+	ListTestees(ctx context.Context, orgID int64, teamID uint64, offset, limit int) ([]TesteeAssignment, int64, error)
+
+	ListEvents(ctx context.Context, orgID int64, teamID uint64, offset, limit int) ([]Event, int64, error)
+}
@@ -0,0 +1,106 @@
+// Package careteam 照护团队：从业者团队、团队成员、团队负责的受试者与团队变更事件。
+// 团队成员按团队角色继承对团队受试者的访问。
+package careteam
+
+import "time"
+
+// MemberRole 成员在团队中的角色。
+type MemberRole string
+
+const (
+	RoleLead     MemberRole = "lead"     // 团队负责人，继承受试者访问
+	RoleMember   MemberRole = "member"   // 团队成员，继承受试者访问
+	RoleObserver MemberRole = "observer" // 观察者，只出现在团队名单与统计中，不继承受试者访问
+)
+
+// Valid 判断角色是否受支持。
+func (r MemberRole) Valid() bool {
+	switch r {
+	case RoleLead, RoleMember, RoleObserver:
+		return true
+	default:
+		return false
+	}
+}
+
+// InheritsAccess 该角色的成员是否继承团队受试者的访问。
+func (r MemberRole) InheritsAccess() bool {
+	return r == RoleLead || r == RoleMember
+}
+
+// AccessRoles 继承受试者访问的团队角色。
+func AccessRoles() []MemberRole {
+	return []MemberRole{RoleLead, RoleMember}
+}
+
+// Action 团队事件类型。
+type Action string
+
+const (
+	ActionTeamCreated       Action = "team_created"
+	ActionTeamUpdated       Action = "team_updated"
+	ActionTeamDeleted       Action = "team_deleted"
+	ActionMemberAdded       Action = "member_added"
+	ActionMemberRoleChanged Action = "member_role_changed"
+	ActionMemberRemoved     Action = "member_removed"
+	ActionTesteeAssigned    Action = "testee_assigned"
+	ActionTesteeUnassigned  Action = "testee_unassigned"
+)
+
+// Team 照护团队。MemberCount/TesteeCount 仅在查询结果中填充。
+type Team struct {
+	ID          uint64
+	OrgID       int64
+	Name        string
+	Department  string
+	Description string
+	CreatedBy   int64
+	UpdatedBy   int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	MemberCount int64
+	TesteeCount int64
+}
+
+// Member 团队成员。
+type Member struct {
+	TeamID        uint64
+	OrgID         int64
+	ClinicianID   uint64
+	ClinicianName string
+	Department    string
+	Role          MemberRole
+	AddedBy       int64
+	AddedAt       time.Time
+}
+
+// TesteeAssignment 分配给团队的受试者。
+type TesteeAssignment struct {
+	TeamID     uint64
+	OrgID      int64
+	TesteeID   uint64
+	TesteeName string
+	AssignedBy int64
+	AssignedAt time.Time
+}
+
+// Event 团队变更审计事件。
+type Event struct {
+	ID             uint64
+	OrgID          int64
+	TeamID         uint64
+	Action         Action
+	ClinicianID    uint64
+	TesteeID       uint64
+	FromRole       MemberRole
+	ToRole         MemberRole
+	OperatorUserID int64
+	OccurredAt     time.Time
+}
+
+// TeamFilter 团队查询条件；ClinicianID 非 0 时只返回该从业者所在的团队。
+type TeamFilter struct {
+	OrgID       int64
+	Department  string
+	ClinicianID uint64
+}
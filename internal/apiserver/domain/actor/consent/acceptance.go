@@ -0,0 +1,145 @@
+package consent
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+const (
+	maxReasonRunes    = 500
+	maxUserAgentBytes = 512
+)
+
+// Subject 签署同意的受试者。
+type Subject struct {
+	TesteeID uint64
+	OrgID    int64
+	Birthday *time.Time
+}
+
+// Evidence 签署时的请求留痕。
+type Evidence struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+// Acceptance 同意签署记录聚合根。记录受试者、签署人（本人或监护人）、所签同意书的
+// 版本与内容摘要；撤回后不再满足作答前的同意校验。
+type Acceptance struct {
+	id               ID
+	orgID            int64
+	documentID       ID
+	documentVersion  int
+	scope            Scope
+	contentHash      string
+	testeeID         uint64
+	filler           *actor.FillerRef
+	guardianRelation GuardianRelation
+	evidence         Evidence
+	acceptedAt       time.Time
+	withdrawnBy      uint64
+	withdrawnAt      *time.Time
+	withdrawReason   string
+}
+
+// Accept 受试者本人或其监护人签署已发布的同意书。未成年受试者必须由监护人签署。
+func Accept(id ID, doc *Document, subject Subject, filler *actor.FillerRef, relation GuardianRelation, evidence Evidence, at time.Time) (*Acceptance, error) {
+	if doc == nil || !doc.IsPublished() {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "consent document is not published")
+	}
+	if subject.TesteeID == 0 || subject.OrgID != doc.OrgID() {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "consent document does not belong to the testee's organization")
+	}
+	if filler == nil || filler.UserID() <= 0 {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "filler is required")
+	}
+	switch {
+	case filler.IsSelf():
+		if IsMinor(subject.Birthday, at) {
+			return nil, errors.WithCode(code.ErrConsentInvalid, "a minor's consent must be given by a guardian")
+		}
+		relation = ""
+	case filler.IsGuardian():
+		if !relation.IsValid() {
+			return nil, errors.WithCode(code.ErrConsentInvalid, "guardian consent requires guardian_relation parent or legal_guardian")
+		}
+	default:
+		return nil, errors.WithCode(code.ErrConsentInvalid, "consent can only be given by the testee or a guardian")
+	}
+	if len(evidence.UserAgent) > maxUserAgentBytes {
+		evidence.UserAgent = evidence.UserAgent[:maxUserAgentBytes]
+	}
+	return &Acceptance{
+		id:               id,
+		orgID:            doc.OrgID(),
+		documentID:       doc.ID(),
+		documentVersion:  doc.Version(),
+		scope:            doc.Scope(),
+		contentHash:      doc.ContentHash(),
+		testeeID:         subject.TesteeID,
+		filler:           filler,
+		guardianRelation: relation,
+		evidence:         evidence,
+		acceptedAt:       at,
+	}, nil
+}
+
+// RestoreAcceptance 从持久化数据重建签署记录。
+func RestoreAcceptance(
+	id ID, orgID int64, documentID ID, documentVersion int, scope Scope, contentHash string,
+	testeeID uint64, filler *actor.FillerRef, relation GuardianRelation, evidence Evidence, acceptedAt time.Time,
+	withdrawnBy uint64, withdrawnAt *time.Time, withdrawReason string,
+) *Acceptance {
+	return &Acceptance{
+		id: id, orgID: orgID, documentID: documentID, documentVersion: documentVersion, scope: scope,
+		contentHash: contentHash, testeeID: testeeID, filler: filler, guardianRelation: relation,
+		evidence: evidence, acceptedAt: acceptedAt, withdrawnBy: withdrawnBy, withdrawnAt: withdrawnAt,
+		withdrawReason: withdrawReason,
+	}
+}
+
+// Withdraw 撤回同意。撤回后该受试者需重新签署才能继续作答。
+func (a *Acceptance) Withdraw(by uint64, reason string, at time.Time) error {
+	if a.withdrawnAt != nil {
+		return errors.WithCode(code.ErrConflict, "consent has already been withdrawn")
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxReasonRunes {
+		return errors.WithCode(code.ErrConsentInvalid, "reason exceeds %d characters", maxReasonRunes)
+	}
+	a.withdrawnBy = by
+	a.withdrawnAt = &at
+	a.withdrawReason = reason
+	return nil
+}
+
+// IsActive 是否仍然有效（未撤回）。
+func (a *Acceptance) IsActive() bool {
+	return a.withdrawnAt == nil
+}
+
+// Satisfies 是否满足指定同意书：有效、版本一致且签署时的内容摘要与当前一致。
+func (a *Acceptance) Satisfies(doc *Document) bool {
+	return doc != nil && a.IsActive() && a.documentID == doc.ID() && a.contentHash == doc.ContentHash()
+}
+
+func (a *Acceptance) ID() ID                             { return a.id }
+func (a *Acceptance) OrgID() int64                       { return a.orgID }
+func (a *Acceptance) DocumentID() ID                     { return a.documentID }
+func (a *Acceptance) DocumentVersion() int               { return a.documentVersion }
+func (a *Acceptance) Scope() Scope                       { return a.scope }
+func (a *Acceptance) ContentHash() string                { return a.contentHash }
+func (a *Acceptance) TesteeID() uint64                   { return a.testeeID }
+func (a *Acceptance) Filler() *actor.FillerRef           { return a.filler }
+func (a *Acceptance) GuardianRelation() GuardianRelation { return a.guardianRelation }
+func (a *Acceptance) Evidence() Evidence                 { return a.evidence }
+func (a *Acceptance) AcceptedAt() time.Time              { return a.acceptedAt }
+func (a *Acceptance) WithdrawnBy() uint64                { return a.withdrawnBy }
+func (a *Acceptance) WithdrawnAt() *time.Time            { return a.withdrawnAt }
+func (a *Acceptance) WithdrawReason() string             { return a.withdrawReason }
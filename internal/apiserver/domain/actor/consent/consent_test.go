@@ -0,0 +1,143 @@
+package consent
+
+import (
+	"testing"
+	"time"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+var now = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func publishedDoc(t *testing.T, id uint64, scope Scope, version int, body string) *Document {
+	t.Helper()
+	doc, err := NewDocument(NewID(id), 7, scope, version, "知情同意书", body, 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Publish(1, now); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestNewScope(t *testing.T) {
+	cases := []struct {
+		kind ScopeKind
+		ref  string
+		ok   bool
+	}{
+		{ScopeKindOrg, "", true},
+		{ScopeKindOrg, "x", false},
+		{ScopeKindModel, "SDS", true},
+		{ScopeKindModel, " ", false},
+		{ScopeKindEntry, "123", true},
+		{ScopeKindEntry, "abc", false},
+		{ScopeKind("site"), "", false},
+	}
+	for _, tc := range cases {
+		_, err := NewScope(tc.kind, tc.ref)
+		if (err == nil) != tc.ok {
+			t.Fatalf("NewScope(%q, %q) err = %v", tc.kind, tc.ref, err)
+		}
+	}
+	if got := SubmissionScopes("SDS", ""); len(got) != 2 || got[1].Ref != "SDS" {
+		t.Fatalf("SubmissionScopes = %+v", got)
+	}
+}
+
+func TestIsMinor(t *testing.T) {
+	if IsMinor(nil, now) {
+		t.Fatal("unknown birthday should not be treated as a minor")
+	}
+	birthday := time.Date(2008, 5, 2, 0, 0, 0, 0, time.UTC)
+	if !IsMinor(&birthday, now) {
+		t.Fatal("testee turning 18 tomorrow is still a minor")
+	}
+	if IsMinor(&birthday, now.AddDate(0, 0, 1)) {
+		t.Fatal("testee is an adult on the 18th birthday")
+	}
+}
+
+func TestDocumentLifecycle(t *testing.T) {
+	doc := publishedDoc(t, 1, Scope{Kind: ScopeKindOrg}, 1, "正文")
+	if err := doc.Publish(1, now); !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("republish err = %v", err)
+	}
+	doc.Supersede()
+	if doc.Status() != DocumentStatusSuperseded {
+		t.Fatalf("status = %s", doc.Status())
+	}
+	if err := doc.Retire(now); !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("retire superseded err = %v", err)
+	}
+}
+
+func TestAcceptRules(t *testing.T) {
+	doc := publishedDoc(t, 1, Scope{Kind: ScopeKindOrg}, 1, "正文")
+	minorBirthday := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	adult := Subject{TesteeID: 3, OrgID: 7}
+	minor := Subject{TesteeID: 4, OrgID: 7, Birthday: &minorBirthday}
+
+	cases := map[string]struct {
+		subject  Subject
+		filler   *actor.FillerRef
+		relation GuardianRelation
+		ok       bool
+	}{
+		"adult self":             {adult, actor.NewFillerRef(3, actor.FillerTypeSelf), "", true},
+		"minor self":             {minor, actor.NewFillerRef(4, actor.FillerTypeSelf), "", false},
+		"minor guardian":         {minor, actor.NewFillerRef(9, actor.FillerTypeGuardian), GuardianRelationParent, true},
+		"guardian w/o relation":  {minor, actor.NewFillerRef(9, actor.FillerTypeGuardian), "", false},
+		"staff cannot consent":   {adult, actor.NewFillerRef(5, actor.FillerTypeOperator), "", false},
+		"other org":              {Subject{TesteeID: 3, OrgID: 8}, actor.NewFillerRef(3, actor.FillerTypeSelf), "", false},
+		"missing filler user id": {adult, actor.NewFillerRef(0, actor.FillerTypeSelf), "", false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Accept(NewID(10), doc, tc.subject, tc.filler, tc.relation, Evidence{}, now)
+			if (err == nil) != tc.ok {
+				t.Fatalf("err = %v", err)
+			}
+			if err != nil && !cberrors.IsCode(err, code.ErrConsentInvalid) {
+				t.Fatalf("err code = %v", err)
+			}
+		})
+	}
+
+	draft, _ := NewDocument(NewID(2), 7, Scope{Kind: ScopeKindOrg}, 2, "草稿", "正文", 1, now)
+	if _, err := Accept(NewID(11), draft, adult, actor.NewFillerRef(3, actor.FillerTypeSelf), "", Evidence{}, now); err == nil {
+		t.Fatal("accepting a draft should fail")
+	}
+}
+
+func TestMissingAndWithdraw(t *testing.T) {
+	orgDoc := publishedDoc(t, 1, Scope{Kind: ScopeKindOrg}, 1, "机构同意书")
+	modelDoc := publishedDoc(t, 2, Scope{Kind: ScopeKindModel, Ref: "SDS"}, 3, "量表同意书")
+	accepted, err := Accept(NewID(10), orgDoc, Subject{TesteeID: 3, OrgID: 7}, actor.NewFillerRef(3, actor.FillerTypeSelf), "", Evidence{IP: "10.0.0.1"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := Missing([]*Document{orgDoc, modelDoc}, []*Acceptance{accepted})
+	if len(missing) != 1 || missing[0].ID() != modelDoc.ID() {
+		t.Fatalf("missing = %+v", missing)
+	}
+
+	if err := accepted.Withdraw(3, "不再参与", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := accepted.Withdraw(3, "", now); !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("double withdraw err = %v", err)
+	}
+	if got := Missing([]*Document{orgDoc}, []*Acceptance{accepted}); len(got) != 1 {
+		t.Fatal("withdrawn consent must not satisfy the requirement")
+	}
+
+	evt := NewWithdrawnEvent(accepted)
+	if evt.EventType() != EventTypeWithdrawn || evt.Payload().TesteeID != "3" || evt.Payload().FillerType != "self" || evt.Payload().DocumentVersion != 1 {
+		t.Fatalf("event = %+v", evt)
+	}
+}
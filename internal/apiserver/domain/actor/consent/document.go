@@ -0,0 +1,131 @@
+package consent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+)
+
+const (
+	maxTitleRunes = 200
+	maxBodyBytes  = 64 * 1024
+)
+
+// Document 同意书聚合根。同一机构、同一范围下版本号递增；发布新版本会替代旧版本，
+// 已签署旧版本的受试者需要重新签署。
+type Document struct {
+	id          ID
+	orgID       int64
+	scope       Scope
+	version     int
+	title       string
+	body        string
+	contentHash string
+	status      DocumentStatus
+	createdBy   uint64
+	createdAt   time.Time
+	publishedBy uint64
+	publishedAt *time.Time
+	retiredAt   *time.Time
+}
+
+// NewDocument 创建同意书草稿。
+func NewDocument(id ID, orgID int64, scope Scope, version int, title, body string, createdBy uint64, at time.Time) (*Document, error) {
+	title = strings.TrimSpace(title)
+	if orgID <= 0 {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "orgID must be positive")
+	}
+	if version <= 0 {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "version must be positive")
+	}
+	if title == "" || utf8.RuneCountInString(title) > maxTitleRunes {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "title is required (max %d characters)", maxTitleRunes)
+	}
+	if strings.TrimSpace(body) == "" || len(body) > maxBodyBytes {
+		return nil, errors.WithCode(code.ErrConsentInvalid, "body is required (max %d bytes)", maxBodyBytes)
+	}
+	return &Document{
+		id:          id,
+		orgID:       orgID,
+		scope:       scope,
+		version:     version,
+		title:       title,
+		body:        body,
+		contentHash: ContentHash(title, body),
+		status:      DocumentStatusDraft,
+		createdBy:   createdBy,
+		createdAt:   at,
+	}, nil
+}
+
+// RestoreDocument 从持久化数据重建同意书。
+func RestoreDocument(
+	id ID, orgID int64, scope Scope, version int, title, body, contentHash string, status DocumentStatus,
+	createdBy uint64, createdAt time.Time, publishedBy uint64, publishedAt, retiredAt *time.Time,
+) *Document {
+	return &Document{
+		id: id, orgID: orgID, scope: scope, version: version, title: title, body: body,
+		contentHash: contentHash, status: status, createdBy: createdBy, createdAt: createdAt,
+		publishedBy: publishedBy, publishedAt: publishedAt, retiredAt: retiredAt,
+	}
+}
+
+// ContentHash 计算同意书内容摘要，签署记录保存该摘要以证明签署时看到的文本。
+func ContentHash(title, body string) string {
+	sum := sha256.Sum256([]byte(title + "\n" + body))
+	return hex.EncodeToString(sum[:])
+}
+
+// Publish 发布草稿；同范围的旧版本由调用方在同一事务内标记为已替代。
+func (d *Document) Publish(by uint64, at time.Time) error {
+	if d.status != DocumentStatusDraft {
+		return errors.WithCode(code.ErrConflict, "only draft consent documents can be published (status %s)", d.status)
+	}
+	d.status = DocumentStatusPublished
+	d.publishedBy = by
+	d.publishedAt = &at
+	return nil
+}
+
+// Supersede 标记为被新版本替代。
+func (d *Document) Supersede() {
+	if d.status == DocumentStatusPublished {
+		d.status = DocumentStatusSuperseded
+	}
+}
+
+// Retire 停用草稿或生效版本。停用后该范围不再要求签署，直到发布新版本。
+func (d *Document) Retire(at time.Time) error {
+	if d.status != DocumentStatusDraft && d.status != DocumentStatusPublished {
+		return errors.WithCode(code.ErrConflict, "consent document is already %s", d.status)
+	}
+	d.status = DocumentStatusRetired
+	d.retiredAt = &at
+	return nil
+}
+
+func (d *Document) ID() ID                 { return d.id }
+func (d *Document) OrgID() int64           { return d.orgID }
+func (d *Document) Scope() Scope           { return d.scope }
+func (d *Document) Version() int           { return d.version }
+func (d *Document) Title() string          { return d.title }
+func (d *Document) Body() string           { return d.body }
+func (d *Document) ContentHash() string    { return d.contentHash }
+func (d *Document) Status() DocumentStatus { return d.status }
+func (d *Document) CreatedBy() uint64      { return d.createdBy }
+func (d *Document) CreatedAt() time.Time   { return d.createdAt }
+func (d *Document) PublishedBy() uint64    { return d.publishedBy }
+func (d *Document) PublishedAt() *time.Time {
+	return d.publishedAt
+}
+func (d *Document) RetiredAt() *time.Time { return d.retiredAt }
+
+// IsPublished 是否为当前生效版本。
+func (d *Document) IsPublished() bool {
+	return d.status == DocumentStatusPublished
+}
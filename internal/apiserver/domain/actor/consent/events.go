@@ -0,0 +1,46 @@
+package consent
+
+import (
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/event"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+)
+
+const (
+	// AggregateTypeAcceptance 同意签署记录聚合根类型
+	AggregateTypeAcceptance = "ConsentAcceptance"
+
+	// EventTypeWithdrawn 同意撤回事件
+	EventTypeWithdrawn = eventcatalog.ConsentWithdrawn
+)
+
+// WithdrawnData 同意撤回事件数据
+type WithdrawnData = eventpayload.ConsentWithdrawnData
+
+// WithdrawnEvent 同意撤回事件
+type WithdrawnEvent = event.Event[WithdrawnData]
+
+// NewWithdrawnEvent 根据已撤回的签署记录创建撤回事件
+func NewWithdrawnEvent(a *Acceptance) WithdrawnEvent {
+	data := WithdrawnData{
+		OrgID:           a.OrgID(),
+		AcceptanceID:    a.ID().String(),
+		DocumentID:      a.DocumentID().String(),
+		DocumentVersion: a.DocumentVersion(),
+		ScopeKind:       a.Scope().Kind.String(),
+		ScopeRef:        a.Scope().Ref,
+		TesteeID:        strconv.FormatUint(a.TesteeID(), 10),
+		WithdrawnBy:     strconv.FormatUint(a.WithdrawnBy(), 10),
+		Reason:          a.WithdrawReason(),
+	}
+	if filler := a.Filler(); filler != nil {
+		data.FillerUserID = strconv.FormatInt(filler.UserID(), 10)
+		data.FillerType = filler.FillerType().String()
+	}
+	if at := a.WithdrawnAt(); at != nil {
+		data.WithdrawnAt = *at
+	}
+	return event.New(EventTypeWithdrawn, AggregateTypeAcceptance, a.ID().String(), data)
+}
@@ -0,0 +1,36 @@
+package consent
+
+import "context"
+
+// DocumentFilter 同意书列表过滤条件。
+type DocumentFilter struct {
+	ScopeKind ScopeKind
+	ScopeRef  string
+	Status    DocumentStatus
+}
+
+// AcceptanceFilter 签署记录列表过滤条件。
+type AcceptanceFilter struct {
+	TesteeID   uint64
+	DocumentID uint64
+	ActiveOnly bool
+}
+
+// Repository 知情同意仓储接口。未找到时 Find* 返回 nil, nil。
+type Repository interface {
+	// NextVersion 返回机构在指定范围下的下一个同意书版本号。
+	NextVersion(ctx context.Context, orgID int64, scope Scope) (int, error)
+	SaveDocument(ctx context.Context, doc *Document) error
+	UpdateDocument(ctx context.Context, doc *Document) error
+	FindDocument(ctx context.Context, orgID int64, id ID) (*Document, error)
+	ListDocuments(ctx context.Context, orgID int64, filter DocumentFilter, offset, limit int) ([]*Document, int64, error)
+	// FindPublished 返回机构在给定范围内当前生效的同意书。
+	FindPublished(ctx context.Context, orgID int64, scopes []Scope) ([]*Document, error)
+
+	SaveAcceptance(ctx context.Context, acceptance *Acceptance) error
+	UpdateAcceptance(ctx context.Context, acceptance *Acceptance) error
+	FindAcceptance(ctx context.Context, orgID int64, id ID) (*Acceptance, error)
+	// ListActiveAcceptances 返回受试者对指定同意书的有效签署。
+	ListActiveAcceptances(ctx context.Context, orgID int64, testeeID uint64, documentIDs []ID) ([]*Acceptance, error)
+	ListAcceptances(ctx context.Context, orgID int64, filter AcceptanceFilter, offset, limit int) ([]*Acceptance, int64, error)
+}
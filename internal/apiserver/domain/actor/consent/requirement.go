@@ -0,0 +1,19 @@
+package consent
+
+// Missing 返回 required 中尚未被 accepted 里任何有效签署满足的同意书。
+func Missing(required []*Document, accepted []*Acceptance) []*Document {
+	var missing []*Document
+	for _, doc := range required {
+		satisfied := false
+		for _, acceptance := range accepted {
+			if acceptance.Satisfies(doc) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			missing = append(missing, doc)
+		}
+	}
+	return missing
+}
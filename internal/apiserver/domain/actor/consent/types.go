@@ -0,0 +1,110 @@
+// Package consent 知情同意子域：机构按范围（机构/测评模型/测评入口）发布带版本的
+// 同意书，受试者本人或监护人签署后才能提交答卷；签署可以撤回。
+package consent
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// ID 同意书 / 签署记录ID类型。
+type ID = meta.ID
+
+// NewID 创建ID。
+func NewID(id uint64) ID {
+	return meta.FromUint64(id)
+}
+
+// ScopeKind 同意书适用范围。
+type ScopeKind string
+
+const (
+	ScopeKindOrg   ScopeKind = "org"   // 机构内所有作答
+	ScopeKindModel ScopeKind = "model" // 指定问卷/量表编码的作答
+	ScopeKindEntry ScopeKind = "entry" // 经指定测评入口的作答
+)
+
+// String 返回原始字符串值。
+func (k ScopeKind) String() string {
+	return string(k)
+}
+
+// Scope 同意书适用范围值对象。机构范围没有 Ref；模型范围的 Ref 为问卷编码；
+// 入口范围的 Ref 为测评入口ID。
+type Scope struct {
+	Kind ScopeKind
+	Ref  string
+}
+
+// NewScope 创建并校验适用范围。
+func NewScope(kind ScopeKind, ref string) (Scope, error) {
+	ref = strings.TrimSpace(ref)
+	switch kind {
+	case ScopeKindOrg:
+		if ref != "" {
+			return Scope{}, errors.WithCode(code.ErrConsentInvalid, "org scope does not take a scope_ref")
+		}
+	case ScopeKindModel:
+		if ref == "" || len(ref) > 100 {
+			return Scope{}, errors.WithCode(code.ErrConsentInvalid, "model scope requires a questionnaire code (max 100 characters)")
+		}
+	case ScopeKindEntry:
+		if id, err := strconv.ParseUint(ref, 10, 64); err != nil || id == 0 {
+			return Scope{}, errors.WithCode(code.ErrConsentInvalid, "entry scope requires an assessment entry id")
+		}
+	default:
+		return Scope{}, errors.WithCode(code.ErrConsentInvalid, "invalid consent scope kind %q", kind)
+	}
+	return Scope{Kind: kind, Ref: ref}, nil
+}
+
+// SubmissionScopes 返回一次作答需要满足的全部范围：机构、问卷编码，以及经测评入口作答时的入口。
+func SubmissionScopes(questionnaireCode, entryID string) []Scope {
+	scopes := []Scope{{Kind: ScopeKindOrg}}
+	if questionnaireCode = strings.TrimSpace(questionnaireCode); questionnaireCode != "" {
+		scopes = append(scopes, Scope{Kind: ScopeKindModel, Ref: questionnaireCode})
+	}
+	if entryID = strings.TrimSpace(entryID); entryID != "" {
+		scopes = append(scopes, Scope{Kind: ScopeKindEntry, Ref: entryID})
+	}
+	return scopes
+}
+
+// DocumentStatus 同意书状态。
+type DocumentStatus string
+
+const (
+	DocumentStatusDraft      DocumentStatus = "draft"      // 草稿，不参与校验
+	DocumentStatusPublished  DocumentStatus = "published"  // 当前生效版本
+	DocumentStatusSuperseded DocumentStatus = "superseded" // 已被同范围的新版本替代
+	DocumentStatusRetired    DocumentStatus = "retired"    // 已停用
+)
+
+// GuardianRelation 监护人与受试者的关系。
+type GuardianRelation string
+
+const (
+	GuardianRelationParent        GuardianRelation = "parent"         // 父母
+	GuardianRelationLegalGuardian GuardianRelation = "legal_guardian" // 其他法定监护人
+)
+
+// IsValid 是否为受支持的监护关系。
+func (r GuardianRelation) IsValid() bool {
+	return r == GuardianRelationParent || r == GuardianRelationLegalGuardian
+}
+
+// MinorAgeYears 未满该年龄的受试者必须由监护人签署同意。
+const MinorAgeYears = 18
+
+// IsMinor 按出生日期判断受试者在 at 时是否未成年；出生日期未知时不视为未成年。
+func IsMinor(birthday *time.Time, at time.Time) bool {
+	if birthday == nil || birthday.IsZero() {
+		return false
+	}
+	return at.Before(birthday.AddDate(MinorAgeYears, 0, 0))
+}
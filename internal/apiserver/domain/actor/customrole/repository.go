@@ -0,0 +1,22 @@
+package customrole
+
+import "context"
+
+// Repository 自定义角色仓储接口。角色与分配删除后同一编码、同一操作者可重新创建，因此按物理删除处理。
+type Repository interface {
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	// DeleteRole 删除角色及其全部分配；删除后同一编码可重新创建。
+	DeleteRole(ctx context.Context, orgID int64, id uint64) error
+	// FindRole / FindRoleByCode 不存在时返回 nil, nil。
+	FindRole(ctx context.Context, orgID int64, id uint64) (*Role, error)
+	FindRoleByCode(ctx context.Context, orgID int64, code string) (*Role, error)
+	ListRoles(ctx context.Context, orgID int64) ([]Role, error)
+
+	// CreateAssignment 已存在相同分配时返回 false。
+	CreateAssignment(ctx context.Context, assignment *Assignment) (bool, error)
+	DeleteAssignment(ctx context.Context, orgID int64, roleID, operatorID uint64) (bool, error)
+	ListAssignments(ctx context.Context, orgID int64, roleID uint64) ([]Assignment, error)
+	// ListRolesForUser 返回分配给该用户的全部角色。
+	ListRolesForUser(ctx context.Context, orgID, userID int64) ([]Role, error)
+}
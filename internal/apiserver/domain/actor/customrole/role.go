@@ -0,0 +1,40 @@
+// Package customrole 机构自定义角色与角色分配。
+// 能力、范围与敏感级别按授权模型的编码存储，由应用层转换为授权快照中的角色授权。
+package customrole
+
+import "time"
+
+// Scope 角色的资源范围；每个维度为空表示不限。
+type Scope struct {
+	ModelCodes  []string
+	PlanIDs     []uint64
+	Departments []string
+}
+
+// Role 机构自定义角色。
+type Role struct {
+	ID           uint64
+	OrgID        int64
+	Code         string
+	Name         string
+	Description  string
+	Capabilities []string
+	Scope        Scope
+	Sensitivity  string
+	CreatedBy    int64
+	UpdatedBy    int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Assignment 角色分配给后台操作者；按 UserID 参与授权快照叠加。
+type Assignment struct {
+	ID           uint64
+	OrgID        int64
+	RoleID       uint64
+	OperatorID   uint64
+	UserID       int64
+	OperatorName string
+	AssignedBy   int64
+	AssignedAt   time.Time
+}
@@ -0,0 +1,26 @@
+package subjectrights
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 数据主体请求仓储接口：请求、证书、导出采集与 MySQL 侧擦除步骤。
+type Repository interface {
+	GetTestee(ctx context.Context, orgID int64, testeeID uint64) (*TesteeSnapshot, error)
+	CreateRequest(ctx context.Context, req *Request) error
+	GetRequest(ctx context.Context, orgID int64, requestID uint64) (*Request, error)
+	// FindOpenRequest 返回受试者尚未完成的同类请求。
+	FindOpenRequest(ctx context.Context, orgID int64, testeeID uint64, kind Kind) (*Request, error)
+	ListRequests(ctx context.Context, orgID int64, filter Filter, offset, limit int) ([]Request, int64, error)
+	// ClaimRequest 原子地把待执行、失败或租约过期的请求置为执行中；未抢到返回 false。
+	ClaimRequest(ctx context.Context, orgID int64, requestID uint64, now, staleBefore time.Time) (bool, error)
+	// SaveProgress 保存状态、步骤结果、导出包与错误信息。
+	SaveProgress(ctx context.Context, req *Request) error
+	CollectRecords(ctx context.Context, orgID int64, testeeID uint64) ([]Section, error)
+	// EraseRecords 对 MySQL 执行一个擦除步骤，返回受影响记录数；重复执行是安全的。
+	EraseRecords(ctx context.Context, subject Subject, step StepName, action StepAction) (int64, error)
+	// IssueCertificate 写入证书；同一请求已有证书时保持原证书不变。
+	IssueCertificate(ctx context.Context, cert *Certificate) error
+	GetCertificate(ctx context.Context, orgID int64, requestID uint64) (*Certificate, error)
+}
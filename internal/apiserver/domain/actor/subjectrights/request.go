@@ -0,0 +1,152 @@
+// Package subjectrights 数据主体请求：受试者数据导出与擦除请求、逐步骤执行结果与擦除证书。
+package subjectrights
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Kind 数据主体请求类型。
+type Kind string
+
+const (
+	KindExport  Kind = "export"  // 导出受试者全部数据
+	KindErasure Kind = "erasure" // 擦除/匿名化受试者数据
+)
+
+// ErasureMode 擦除方式。
+type ErasureMode string
+
+const (
+	// ErasureModeAnonymize 假名化受试者并保留测评、结果与报告的来源链路（默认）。
+	ErasureModeAnonymize ErasureMode = "anonymize"
+	// ErasureModeDelete 连同测评、结果与报告一并删除。
+	ErasureModeDelete ErasureMode = "delete"
+)
+
+// Status 请求状态。
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusFailed    Status = "failed"
+	StatusCompleted Status = "completed"
+)
+
+// StepName 擦除步骤。
+type StepName string
+
+const (
+	StepCareRelations   StepName = "care_relations"   // 从业者关系与紧急访问授权
+	StepPlanSchedule    StepName = "plan_schedule"    // 计划入组与未完成任务
+	StepAnswerSheets    StepName = "answer_sheets"    // 答卷
+	StepReports         StepName = "reports"          // 解读报告、归档报告与报告目录
+	StepClinicalRecords StepName = "clinical_records" // 测评、得分、结果与入口接入记录
+	StepStatistics      StepName = "statistics"       // 统计事实投影
+	StepAttention       StepName = "attention"        // 报告关注投影
+	StepObjectAssets    StepName = "object_assets"    // 对象存储中的受试者文件（导出包等）
+	StepTesteeProfile   StepName = "testee_profile"   // 受试者档案
+)
+
+// StepAction 步骤对数据采取的处理。
+type StepAction string
+
+const (
+	ActionRetain       StepAction = "retain"       // 保留（仅以匿名受试者 ID 关联）
+	ActionPseudonymize StepAction = "pseudonymize" // 清除直接标识，保留记录
+	ActionRevoke       StepAction = "revoke"       // 解除关系、取消待办
+	ActionDelete       StepAction = "delete"       // 删除
+)
+
+// 步骤所在存储。
+const (
+	StoreMySQL  = "mysql"
+	StoreMongo  = "mongo"
+	StoreObject = "object_storage"
+)
+
+// StepStatus 步骤状态。
+type StepStatus string
+
+const (
+	StepStatusPending   StepStatus = "pending"
+	StepStatusCompleted StepStatus = "completed"
+	StepStatusFailed    StepStatus = "failed"
+)
+
+// StepResult 擦除步骤的执行结果，随请求持久化用于断点续做。
+type StepResult struct {
+	Name        StepName   `json:"name"`
+	Store       string     `json:"store"`
+	Action      StepAction `json:"action"`
+	Status      StepStatus `json:"status"`
+	Affected    int64      `json:"affected"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Request 数据主体请求。
+type Request struct {
+	ID          uint64
+	OrgID       int64
+	TesteeID    uint64
+	Kind        Kind
+	Mode        ErasureMode // 仅擦除请求
+	Status      Status
+	Reason      string
+	Steps       []StepResult // 仅擦除请求
+	BundleKey   string       // 导出包在对象存储中的 key；为空表示下载时现场生成
+	BundleSize  int64
+	Attempts    int
+	LastError   string
+	RequestedBy uint64
+	RequestedAt time.Time
+	StartedAt   *time.Time // 最近一次开始执行的时间，作为执行租约
+	CompletedAt *time.Time
+}
+
+// Certificate 擦除完成证书，记录各步骤结果与摘要，写入后不再修改。
+type Certificate struct {
+	ID         uint64
+	RequestID  uint64
+	OrgID      int64
+	TesteeID   uint64
+	SubjectRef string // 受试者假名，证书本身不含直接标识
+	Mode       ErasureMode
+	Steps      []StepResult
+	Digest     string // 证书内容的 SHA-256
+	IssuedBy   uint64
+	IssuedAt   time.Time
+}
+
+// Subject 擦除步骤的作用对象。
+type Subject struct {
+	OrgID      int64
+	TesteeID   uint64
+	Pseudonym  string
+	OperatorID uint64
+	At         time.Time
+}
+
+// TesteeSnapshot 受理请求所需的受试者字段。
+type TesteeSnapshot struct {
+	ID      uint64
+	OrgID   int64
+	Name    string
+	Deleted bool
+}
+
+// Section 导出包中一个来源表/集合的记录。
+type Section struct {
+	Store   string            `json:"store"`
+	Name    string            `json:"name"`
+	Records []json.RawMessage `json:"records"`
+}
+
+// Filter 请求列表筛选。
+type Filter struct {
+	TesteeID uint64
+	Kind     Kind
+	Status   Status
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/FangcunMount/component-base/pkg/errors"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
 )
 
 // Editor 受试者信息编辑领域服务
@@ -19,6 +20,9 @@ type Editor interface {
 
 	// UnmarkAsKeyFocus 取消重点关注
 	UnmarkAsKeyFocus(ctx context.Context, testee *Testee) error
+
+	// ChangePreferredLocale 修改报告内容偏好语言，空值清除偏好
+	ChangePreferredLocale(ctx context.Context, testee *Testee, tag string) error
 }
 
 // editor 编辑器实现
@@ -100,3 +104,20 @@ func (e *editor) UnmarkAsKeyFocus(_ context.Context, testee *Testee) error {
 
 	return nil
 }
+
+// ChangePreferredLocale 修改报告内容偏好语言
+// 标签按 BCP 47 规范化后保存；能否按该语言出报告由模板发布与模型译文在生成时决定
+func (e *editor) ChangePreferredLocale(_ context.Context, testee *Testee, tag string) error {
+	if testee == nil {
+		return errors.WithCode(code.ErrInvalidArgument, "testee cannot be nil")
+	}
+
+	normalized, err := locale.Normalize(tag)
+	if err != nil {
+		return errors.WithCode(code.ErrInvalidArgument, "preferred locale is invalid: %s", tag)
+	}
+
+	testee.preferredLocale = normalized
+
+	return nil
+}
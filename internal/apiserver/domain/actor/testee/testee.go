@@ -27,6 +27,9 @@ type Testee struct {
 	tags       []Tag  // 历史兼容字段；当前产品不展示、不筛选
 	source     Source // 数据来源
 	isKeyFocus bool   // 是否重点关注对象
+
+	// === 偏好 ===
+	preferredLocale string // 报告内容偏好语言，空值表示使用默认语言
 }
 
 // NewTestee 创建新的受试者
@@ -142,6 +145,13 @@ func (t *Testee) IsKeyFocus() bool {
 	return t.isKeyFocus
 }
 
+// === 偏好 ===
+
+// PreferredLocale 获取报告内容偏好语言（规范化的 BCP 47 标签），空值表示无偏好
+func (t *Testee) PreferredLocale() string {
+	return t.preferredLocale
+}
+
 // === 数据来源 ===
 
 // Source 获取数据来源
@@ -176,6 +186,11 @@ func (t *Testee) SetKeyFocus(isKeyFocus bool) {
 	t.isKeyFocus = isKeyFocus
 }
 
+// SetPreferredLocale 设置报告内容偏好语言（仅用于从数据库加载）
+func (t *Testee) SetPreferredLocale(tag string) {
+	t.preferredLocale = tag
+}
+
 // SetTagsFromStrings 从字符串列表设置历史标签（仅用于从数据库加载）
 func (t *Testee) SetTagsFromStrings(tags []string) {
 	if tags == nil {
@@ -0,0 +1,91 @@
+// Package testeeimport 受试者批量导入任务：一次上传的名册被规范化为导入行，
+// 由后台逐行处理；每行的处理进度独立持久化，中断后从第一条未完成的行继续。
+package testeeimport
+
+import "time"
+
+// FileFormat 导入文件格式。
+type FileFormat string
+
+const (
+	FileFormatCSV  FileFormat = "csv"
+	FileFormatXLSX FileFormat = "xlsx"
+)
+
+// JobStatus 导入任务状态。
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"   // 已解析，等待后台处理
+	JobStatusRunning   JobStatus = "running"   // 后台处理中（进程中断后由调度器继续）
+	JobStatusCompleted JobStatus = "completed" // 所有可处理行均已结束；失败行可通过 resume 重试
+	JobStatusCanceled  JobStatus = "canceled"  // 已取消，剩余待处理行不再执行
+)
+
+// RowStatus 导入行状态。
+type RowStatus string
+
+const (
+	RowStatusPending   RowStatus = "pending"   // 等待处理（可能已完成部分步骤）
+	RowStatusInvalid   RowStatus = "invalid"   // 校验失败，不会处理
+	RowStatusDuplicate RowStatus = "duplicate" // 与文件中更早的行重复，不会处理
+	RowStatusSucceeded RowStatus = "succeeded" // 受试者、关系与（可选）入组均已完成
+	RowStatusFailed    RowStatus = "failed"    // 处理失败，可重试
+)
+
+// TesteeAction 导入行对受试者的处理结果。
+type TesteeAction string
+
+const (
+	TesteeActionCreated TesteeAction = "created" // 新建受试者
+	TesteeActionMatched TesteeAction = "matched" // 命中机构内已有受试者
+)
+
+// Job 导入任务。
+type Job struct {
+	ID            uint64
+	OrgID         int64
+	FileName      string
+	FileFormat    FileFormat
+	ClinicianID   uint64
+	RelationType  string
+	PlanID        uint64 // 0 表示不入组
+	PlanStartDate string // YYYY-MM-DD；PlanID 为 0 时为空
+	Status        JobStatus
+	TotalRows     int
+	CreatedBy     uint64
+	LastError     string
+	CreatedAt     time.Time
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+}
+
+// Row 导入行。RowNo 为文件中的行号（表头为第 1 行）。
+type Row struct {
+	JobID        uint64
+	RowNo        int
+	Name         string
+	Gender       int8
+	Birthday     *time.Time
+	ProfileID    *uint64
+	Status       RowStatus
+	TesteeID     uint64
+	TesteeAction TesteeAction
+	RelationID   uint64
+	EnrollmentID uint64
+	Attempts     int
+	Error        string
+	ProcessedAt  *time.Time
+}
+
+// RowCounts 按行状态汇总的计数，始终由导入行实时聚合，避免计数与行结果漂移。
+type RowCounts struct {
+	Pending   int64 `json:"pending"`
+	Invalid   int64 `json:"invalid"`
+	Duplicate int64 `json:"duplicate"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	Created   int64 `json:"created"`  // 成功行中新建的受试者
+	Matched   int64 `json:"matched"`  // 成功行中命中的已有受试者
+	Enrolled  int64 `json:"enrolled"` // 成功加入计划的行
+}
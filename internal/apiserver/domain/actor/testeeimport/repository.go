@@ -0,0 +1,28 @@
+package testeeimport
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 导入任务仓储接口；导入行作为任务的子实体随任务一起持久化。
+type Repository interface {
+	// Create 原子写入任务与全部导入行。
+	Create(ctx context.Context, job *Job, rows []Row) error
+	Get(ctx context.Context, orgID int64, jobID uint64) (*Job, error)
+	List(ctx context.Context, orgID int64, offset, limit int) ([]Job, int64, error)
+	CountRows(ctx context.Context, jobID uint64) (RowCounts, error)
+	ListRows(ctx context.Context, jobID uint64, status RowStatus, offset, limit int) ([]Row, int64, error)
+
+	// NextRunnable 返回最早创建的 pending/running 任务；没有时返回 nil。
+	NextRunnable(ctx context.Context) (*Job, error)
+	ListPendingRows(ctx context.Context, jobID uint64, limit int) ([]Row, error)
+	// SaveRow 持久化单行的处理进度；每个步骤完成后立即调用，保证中断后可续跑。
+	SaveRow(ctx context.Context, row Row) error
+	MarkRunning(ctx context.Context, jobID uint64, at time.Time) error
+	MarkCompleted(ctx context.Context, jobID uint64, at time.Time) error
+	MarkCanceled(ctx context.Context, jobID uint64, at time.Time) error
+	RecordError(ctx context.Context, jobID uint64, message string) error
+	// RequeueFailedRows 将失败行重置为 pending 并把任务恢复为 pending，返回重置行数。
+	RequeueFailedRows(ctx context.Context, jobID uint64) (int64, error)
+}
@@ -0,0 +1,89 @@
+// Package testeemerge 受试者合并：把重复受试者上的全部记录迁移到保留受试者，
+// 并逐条记录迁移过的记录，使合并可以按记录回滚。
+package testeemerge
+
+import "time"
+
+// MatchReason 重复候选的命中依据。
+type MatchReason string
+
+const (
+	MatchReasonProfile      MatchReason = "profile"              // 绑定同一用户档案
+	MatchReasonIdentity     MatchReason = "name_gender_birthday" // 姓名、性别、出生日期均一致
+	MatchReasonNameBirthday MatchReason = "name_birthday"        // 姓名、出生日期一致，一方性别未知
+	MatchReasonNameGender   MatchReason = "name_gender"          // 姓名、性别一致，出生日期缺失
+)
+
+// ConflictKind 阻止合并的冲突类型。
+type ConflictKind string
+
+const (
+	ConflictSharedPlan       ConflictKind = "shared_plan"       // 两者参与过同一计划，入组轮次与任务序号会冲突
+	ConflictPrimaryClinician ConflictKind = "primary_clinician" // 两者的有效主责从业者不同
+	ConflictProfile          ConflictKind = "profile"           // 两者绑定了不同的用户档案
+)
+
+// MergeStatus 合并记录状态。
+type MergeStatus string
+
+const (
+	MergeStatusMerged   MergeStatus = "merged"
+	MergeStatusReverted MergeStatus = "reverted"
+)
+
+// 迁移记录所在存储。
+const (
+	RecordStoreMySQL = "mysql"
+	RecordStoreMongo = "mongo"
+)
+
+// TesteeSnapshot 判重与合并所需的受试者字段。
+type TesteeSnapshot struct {
+	ID        uint64
+	OrgID     int64
+	ProfileID *uint64
+	Name      string
+	Gender    int8
+	Birthday  *time.Time
+	Source    string
+	CreatedAt time.Time
+	Deleted   bool
+}
+
+// CandidatePair 存储层按宽松条件召回的一对受试者（A.ID < B.ID）。
+type CandidatePair struct {
+	A TesteeSnapshot
+	B TesteeSnapshot
+}
+
+// Conflict 阻止合并的原因。
+type Conflict struct {
+	Kind   ConflictKind
+	Detail string
+}
+
+// MovedRecords 一次合并中从重复受试者迁移到保留受试者的记录。
+type MovedRecords struct {
+	Store string   `json:"store"`
+	Table string   `json:"table"`
+	IDs   []string `json:"ids"`
+}
+
+// MergeLog 可回滚的合并记录。
+type MergeLog struct {
+	ID                 uint64
+	OrgID              int64
+	SurvivorID         uint64
+	DuplicateID        uint64
+	Status             MergeStatus
+	MatchReasons       []MatchReason
+	Reason             string
+	Moved              []MovedRecords
+	SkippedRelationIDs []uint64 // 保留受试者已有相同关系，留在重复受试者上的从业者关系
+	TransferredProfile *uint64  // 从重复受试者转移到保留受试者的用户档案
+	MergedBy           uint64
+	MergedAt           time.Time
+	RevertedBy         uint64
+	RevertedAt         *time.Time
+	RevertReason       string
+}
@@ -0,0 +1,18 @@
+package testeemerge
+
+import "context"
+
+// Repository 受试者合并仓储接口；合并与回滚各自在一个事务内迁移 MySQL 记录并写入合并记录。
+type Repository interface {
+	GetTestee(ctx context.Context, orgID int64, testeeID uint64) (*TesteeSnapshot, error)
+	// ListCandidatePairs 召回机构内的候选对；testeeID 非 0 时只召回包含该受试者的候选对。
+	ListCandidatePairs(ctx context.Context, orgID int64, testeeID uint64, offset, limit int) ([]CandidatePair, int64, error)
+	FindConflicts(ctx context.Context, orgID int64, survivorID, duplicateID uint64) ([]Conflict, error)
+	// ApplyMerge 在一个事务内迁移 MySQL 记录、软删除重复受试者并写入合并记录；
+	// 迁移的记录与跳过的关系回填到 log。
+	ApplyMerge(ctx context.Context, log *MergeLog) error
+	// ApplyRevert 在一个事务内按合并记录把 MySQL 记录迁回、恢复重复受试者并标记记录已回滚。
+	ApplyRevert(ctx context.Context, log *MergeLog) error
+	GetLog(ctx context.Context, orgID int64, mergeID uint64) (*MergeLog, error)
+	ListLogs(ctx context.Context, orgID int64, testeeID uint64, offset, limit int) ([]MergeLog, int64, error)
+}
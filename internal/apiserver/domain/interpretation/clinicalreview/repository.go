@@ -0,0 +1,14 @@
+package clinicalreview
+
+import "context"
+
+// Repository 复核仓储接口。备注只追加，同一测评内版本唯一。
+type Repository interface {
+	// FindReview 不存在时返回 nil, nil。
+	FindReview(ctx context.Context, orgID int64, assessmentID uint64) (*Review, error)
+	ListNotes(ctx context.Context, orgID int64, assessmentID uint64) ([]Note, error)
+	// AppendNote 在同一事务内写入新版本备注并保存复核状态；版本已被占用时返回 false。
+	AppendNote(ctx context.Context, review *Review, note *Note) (bool, error)
+	// SignOff 仅在报告尚未签署时保存复核状态；已签署时返回 false。
+	SignOff(ctx context.Context, review *Review) (bool, error)
+}
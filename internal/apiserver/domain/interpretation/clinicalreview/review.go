@@ -0,0 +1,56 @@
+// Package clinicalreview 报告临床复核：复核状态与带版本的临床备注。
+// 机器生成的解读报告保持不可变，复核只在其之上追加备注与签署状态。
+package clinicalreview
+
+import "time"
+
+// Status 报告复核状态。
+type Status string
+
+const (
+	StatusPendingReview Status = "pending_review"
+	StatusReviewed      Status = "reviewed"
+	StatusAmended       Status = "amended"
+)
+
+// Signed 报告是否已签署（已复核或签署后修订）。
+func (s Status) Signed() bool {
+	return s == StatusReviewed || s == StatusAmended
+}
+
+// SignedStatuses 已签署的复核状态。
+func SignedStatuses() []Status {
+	return []Status{StatusReviewed, StatusAmended}
+}
+
+// Reviewer 复核人或备注作者：操作者账号与其绑定的从业者。
+type Reviewer struct {
+	UserID      int64
+	ClinicianID uint64
+	Name        string
+}
+
+// Review 单个测评报告的复核状态，以测评标识。NoteVersion 为当前生效的备注版本，0 表示尚无备注。
+type Review struct {
+	OrgID        int64
+	AssessmentID uint64
+	TesteeID     uint64
+	Status       Status
+	NoteVersion  int
+	Reviewer     *Reviewer
+	ReviewedAt   *time.Time
+	UpdatedAt    time.Time
+}
+
+// Note 临床备注的一个版本。Content 仅从业者可见；Addendum 为面向受试者的补充说明，可为空。
+type Note struct {
+	ID           uint64
+	OrgID        int64
+	AssessmentID uint64
+	TesteeID     uint64
+	Version      int
+	Content      string
+	Addendum     string
+	Author       Reviewer
+	CreatedAt    time.Time
+}
@@ -0,0 +1,141 @@
+// Package groupreport 群体报告：一个群体在一个学期内冻结测评结果的班级/年级层面汇总。
+// 报告以冻结输入的指纹去重，同一机构的相同输入只保存一份；内容在保存前已按最小单元格规则抑制。
+package groupreport
+
+import "time"
+
+// CohortKind 群体的来源。
+type CohortKind string
+
+const (
+	// CohortEntry 通过同一测评入口登记的受试者。
+	CohortEntry CohortKind = "entry"
+	// CohortPlan 参与同一测评计划的受试者。
+	CohortPlan CohortKind = "plan"
+	// CohortTag 带有同一标签的受试者。
+	CohortTag CohortKind = "tag"
+	// CohortTestees 显式指定的受试者集合。
+	CohortTestees CohortKind = "testees"
+)
+
+// Term 左闭右开的时间窗。
+type Term struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Report 一份已生成并保存的群体报告。
+type Report struct {
+	ID               uint64
+	OrgID            int64
+	CohortKind       CohortKind
+	CohortRef        string
+	ModelCode        string
+	TermFrom         time.Time
+	TermTo           time.Time
+	MinCellSize      int
+	InputFingerprint string
+	Input            FrozenInput
+	Content          Content
+	GeneratedBy      int64
+	GeneratedAt      time.Time
+}
+
+// FrozenInput 生成报告时冻结的输入：群体人数与两个学期各自采用的测评结果。
+type FrozenInput struct {
+	CohortSize int             `json:"cohort_size"`
+	Current    []FrozenOutcome `json:"current"`
+	Previous   []FrozenOutcome `json:"previous"`
+}
+
+// FrozenOutcome 报告引用的一次测评结果；VersionToken 标识结果的不可变版本。
+type FrozenOutcome struct {
+	AssessmentID string `json:"assessment_id"`
+	OutcomeID    string `json:"outcome_id"`
+	VersionToken string `json:"version_token"`
+}
+
+// Content 群体报告内容快照。计数为 nil 表示被最小单元格规则抑制。
+type Content struct {
+	ModelCode    string         `json:"model_code"`
+	ModelVersion string         `json:"model_version"`
+	ModelTitle   string         `json:"model_title"`
+	Cohort       CohortSummary  `json:"cohort"`
+	Term         Term           `json:"term"`
+	PreviousTerm Term           `json:"previous_term"`
+	MinCellSize  int            `json:"min_cell_size"`
+	Overall      OverallSummary `json:"overall"`
+	Factors      []FactorGroup  `json:"factors"`
+	RiskBands    []RiskBand     `json:"risk_bands"`
+}
+
+type CohortSummary struct {
+	Kind CohortKind `json:"kind"`
+	Ref  string     `json:"ref,omitempty"`
+	Size int        `json:"size"`
+	// Assessed 本学期有测评结果的成员数；Coverage 为其占群体人数的比例。
+	Assessed int     `json:"assessed"`
+	Coverage float64 `json:"coverage"`
+}
+
+// OverallSummary 整体结果等级的分布与风险占比。
+type OverallSummary struct {
+	Levels   []LevelCell `json:"levels"`
+	AtRisk   Cell        `json:"at_risk"`
+	Previous *Comparison `json:"previous,omitempty"`
+}
+
+// Cell 一个计数单元格；Suppressed 时 Count 与 Share 均为空。
+type Cell struct {
+	Count      *int     `json:"count,omitempty"`
+	Share      *float64 `json:"share,omitempty"`
+	Suppressed bool     `json:"suppressed"`
+}
+
+type LevelCell struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Severity string `json:"severity,omitempty"`
+	Cell
+}
+
+// NormCell 常模对照：衍生分达到 Threshold（常模均值 + 1 个标准差）的人数占比，ExpectedShare 为常模人群中的期望占比。
+type NormCell struct {
+	ScoreKind     string  `json:"score_kind"`
+	Benchmark     float64 `json:"benchmark"`
+	Threshold     float64 `json:"threshold"`
+	ExpectedShare float64 `json:"expected_share"`
+	Assessed      int     `json:"assessed"`
+	Cell
+}
+
+// Comparison 与上一学期的比较；上一学期人数不足最小单元格时只给出 Suppressed。
+type Comparison struct {
+	Assessed    int      `json:"assessed"`
+	AtRiskShare *float64 `json:"at_risk_share,omitempty"`
+	AtRiskDelta *float64 `json:"at_risk_delta,omitempty"`
+	MeanScore   *float64 `json:"mean_score,omitempty"`
+	MeanDelta   *float64 `json:"mean_delta,omitempty"`
+	Suppressed  bool     `json:"suppressed"`
+}
+
+// FactorGroup 单个因子的群体汇总。
+type FactorGroup struct {
+	FactorCode   string      `json:"factor_code"`
+	FactorName   string      `json:"factor_name"`
+	IsTotalScore bool        `json:"is_total_score"`
+	Assessed     int         `json:"assessed"`
+	MeanScore    *float64    `json:"mean_score,omitempty"`
+	Levels       []LevelCell `json:"levels"`
+	AtRisk       Cell        `json:"at_risk"`
+	Norm         *NormCell   `json:"norm,omitempty"`
+	Previous     *Comparison `json:"previous,omitempty"`
+}
+
+// RiskBand 风险带假名名单；Severities 为该带覆盖的严重度，人数不足阈值的严重度会与相邻一档合并。
+type RiskBand struct {
+	Band       string   `json:"band"`
+	Severities []string `json:"severities"`
+	Count      int      `json:"count"`
+	Members    []string `json:"members"`
+}
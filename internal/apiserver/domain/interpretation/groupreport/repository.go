@@ -0,0 +1,13 @@
+package groupreport
+
+import "context"
+
+// Repository 群体报告仓储接口。
+type Repository interface {
+	// Save 写入新报告并回填 ID；同一机构相同输入指纹已存在时返回已有记录。
+	Save(ctx context.Context, report *Report) (*Report, error)
+	// FindByFingerprint 不存在时返回 nil, nil。
+	FindByFingerprint(ctx context.Context, orgID int64, fingerprint string) (*Report, error)
+	// FindByID 不存在时返回 nil, nil。
+	FindByID(ctx context.Context, id uint64) (*Report, error)
+}
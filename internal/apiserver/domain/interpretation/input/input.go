@@ -4,6 +4,8 @@
 package input
 
 import (
+	"time"
+
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
 	reportscore "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/scoring"
@@ -26,6 +28,10 @@ type InterpretationInput struct {
 	FactorScoring       *FactorScoringFacts
 	PersonalityType     *PersonalityTypeFacts
 	TraitProfile        *TraitProfileFacts
+	Longitudinal        *LongitudinalFacts
+	// Locale is the content locale display copy is rendered in; empty means
+	// locale.Default. See Localize.
+	Locale string
 }
 
 type RuntimeIdentity struct {
@@ -61,3 +67,29 @@ type PersonalityTypeFacts struct {
 type TraitProfileFacts struct {
 	Detail reporttypology.TraitProfileReportDetail
 }
+
+// LongitudinalFacts freezes the outcomes of one plan enrollment for a composite
+// report. Points are the per-outcome facts already restored from immutable
+// EvaluationOutcomes; the builder never reads plan or evaluation state itself.
+type LongitudinalFacts struct {
+	EnrollmentID   meta.ID
+	PlanID         meta.ID
+	Round          uint32
+	PlannedTasks   int
+	CompletedTasks int
+	ExpiredTasks   int
+	CanceledTasks  int
+	Points         []LongitudinalPoint
+}
+
+// LongitudinalPoint is one completed plan task and the scoring facts frozen for
+// its outcome.
+type LongitudinalPoint struct {
+	Seq          int
+	OutcomeID    meta.ID
+	AssessmentID meta.ID
+	OccurredAt   time.Time
+	Primary      *report.ScoreValue
+	Level        *report.ResultLevel
+	Scoring      *FactorScoringFacts
+}
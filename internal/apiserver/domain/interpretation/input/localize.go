@@ -0,0 +1,135 @@
+package input
+
+import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	reportscore "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/scoring"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+)
+
+// Locales returns the content locales the input can render, locale.Default
+// first. Only factor-scoring copy is translated; typology facts are frozen
+// display copy and render in the default locale.
+func (in InterpretationInput) Locales() []string {
+	if assets := in.scoringAssets(); assets != nil {
+		return assets.Locales()
+	}
+	return []string{locale.Default}
+}
+
+// LocalesIn returns the input locales a template release publishes, keeping
+// locale.Default first. Both sides must offer a locale for it to render.
+func (in InterpretationInput) LocalesIn(published []string) []string {
+	out := make([]string, 0, len(published))
+	for _, tag := range in.Locales() {
+		if contains(published, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// Localize returns a copy of the input whose display copy is in tag. Scores,
+// outcome codes and routing are untouched; only titles, level labels and the
+// interpretation assets builders read copy from are swapped, falling back per
+// key to the base copy. An unrenderable tag yields the default locale.
+func Localize(in InterpretationInput, tag string) InterpretationInput {
+	out := in
+	out.Locale = locale.Default
+	if !contains(in.Locales(), tag) || tag == locale.Default {
+		return out
+	}
+	out.Locale = tag
+	assets := *in.scoringAssets()
+	out.Model.Title = assets.ModelTitle(tag, in.Model.Title)
+	out.Result.Level = localizeLevel(assets, tag, in.Result.Level)
+	out.FactorScoring = localizeScoring(assets, tag, in.FactorScoring)
+	if in.Longitudinal != nil {
+		longitudinal := *in.Longitudinal
+		longitudinal.Points = make([]LongitudinalPoint, len(in.Longitudinal.Points))
+		for index, point := range in.Longitudinal.Points {
+			point.Level = localizeLevel(assets, tag, point.Level)
+			point.Scoring = localizeScoring(assets, tag, point.Scoring)
+			longitudinal.Points[index] = point
+		}
+		out.Longitudinal = &longitudinal
+	}
+	return out
+}
+
+func (in InterpretationInput) scoringAssets() *interpretationassets.Assets {
+	if in.PersonalityType != nil || in.TraitProfile != nil {
+		return nil
+	}
+	if in.FactorScoring != nil && in.FactorScoring.Model != nil && in.FactorScoring.Model.Assets != nil {
+		return in.FactorScoring.Model.Assets
+	}
+	if in.Longitudinal != nil {
+		for _, point := range in.Longitudinal.Points {
+			if point.Scoring != nil && point.Scoring.Model != nil && point.Scoring.Model.Assets != nil {
+				return point.Scoring.Model.Assets
+			}
+		}
+	}
+	return nil
+}
+
+func localizeScoring(assets interpretationassets.Assets, tag string, facts *FactorScoringFacts) *FactorScoringFacts {
+	if facts == nil {
+		return nil
+	}
+	localizedAssets := assets.Localize(tag)
+	out := &FactorScoringFacts{Factors: make([]reportscore.FactorReportScore, len(facts.Factors))}
+	if facts.Model != nil {
+		model := *facts.Model
+		model.Title = assets.ModelTitle(tag, model.Title)
+		model.Factors = make([]reportscore.FactorReportModel, len(facts.Model.Factors))
+		for index, factor := range facts.Model.Factors {
+			factor.Title = assets.FactorTitle(tag, factor.Code, factor.Title)
+			model.Factors[index] = factor
+		}
+		if model.Assets != nil {
+			localized := model.Assets.Localize(tag)
+			model.Assets = &localized
+		}
+		out.Model = &model
+	}
+	for index, factor := range facts.Factors {
+		factor.FactorName = assets.FactorTitle(tag, factor.FactorCode, factor.FactorName)
+		if factor.Level != nil && translatesOutcome(assets, localizedAssets, factor.Level.Code) {
+			// Norm prose is frozen in the default locale; a translated outcome
+			// lets the builder resolve conclusion and suggestion from assets.
+			factor.Conclusion, factor.Suggestion = "", ""
+		}
+		factor.Level = localizeLevel(assets, tag, factor.Level)
+		out.Factors[index] = factor
+	}
+	return out
+}
+
+func translatesOutcome(base, localized interpretationassets.Assets, code string) bool {
+	original, ok := base.FindOutcome(code)
+	if !ok {
+		return false
+	}
+	translated, _ := localized.FindOutcome(code)
+	return translated != original
+}
+
+func localizeLevel(assets interpretationassets.Assets, tag string, level *report.ResultLevel) *report.ResultLevel {
+	if level == nil {
+		return nil
+	}
+	localized := *level
+	localized.Label = assets.OutcomeTitle(tag, level.Code, level.Label)
+	return &localized
+}
+
+func contains(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
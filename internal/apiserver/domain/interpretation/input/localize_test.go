@@ -0,0 +1,63 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	reportscore "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/scoring"
+	reporttypology "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/typology/patterns"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+)
+
+func localizableInput() InterpretationInput {
+	assets := &interpretationassets.Assets{
+		Outcomes: []interpretationassets.OutcomePresentation{{OutcomeCode: "high", Title: "高", Summary: "需要关注"}},
+		Translations: []interpretationassets.LocalizedAssets{{
+			Locale: "en-US", ModelTitle: "Anxiety",
+			Factors:  []interpretationassets.FactorTitle{{FactorCode: "total", Title: "Total"}},
+			Outcomes: []interpretationassets.OutcomePresentation{{OutcomeCode: "high", Title: "High", Summary: "Needs attention"}},
+		}},
+	}
+	return InterpretationInput{
+		Model:  report.ModelIdentity{Title: "焦虑量表"},
+		Result: ResultFacts{Level: &report.ResultLevel{Code: "high", Label: "高"}},
+		FactorScoring: &FactorScoringFacts{
+			Model: &reportscore.ReportModel{Title: "焦虑量表", Factors: []reportscore.FactorReportModel{{Code: "total", Title: "总分"}}, Assets: assets},
+			Factors: []reportscore.FactorReportScore{{
+				FactorCode: "total", FactorName: "总分", Level: &report.ResultLevel{Code: "high", Label: "高"},
+				Conclusion: "常模结论", Suggestion: "常模建议",
+			}},
+		},
+	}
+}
+
+func TestLocalizeSwapsDisplayCopyWithoutTouchingBase(t *testing.T) {
+	base := localizableInput()
+	localized := Localize(base, "en-US")
+	if localized.Locale != "en-US" || localized.Model.Title != "Anxiety" || localized.Result.Level.Label != "High" {
+		t.Fatalf("localized identity = %+v level=%+v", localized.Model, localized.Result.Level)
+	}
+	factor := localized.FactorScoring.Factors[0]
+	if factor.FactorName != "Total" || factor.Level.Label != "High" || factor.Conclusion != "" {
+		t.Fatalf("localized factor = %+v", factor)
+	}
+	if presentation, _ := localized.FactorScoring.Model.Assets.FindOutcome("high"); presentation.Summary != "Needs attention" {
+		t.Fatalf("localized assets = %+v", presentation)
+	}
+	if base.FactorScoring.Factors[0].FactorName != "总分" || base.Result.Level.Label != "高" || base.FactorScoring.Model.Title != "焦虑量表" {
+		t.Fatal("Localize mutated the base input")
+	}
+}
+
+func TestLocalizeFallsBackToDefaultLocale(t *testing.T) {
+	if got := Localize(localizableInput(), "fr-FR"); got.Locale != "zh-CN" || got.Model.Title != "焦虑量表" {
+		t.Fatalf("unknown locale = %q %q", got.Locale, got.Model.Title)
+	}
+	if got := localizableInput().LocalesIn([]string{"zh-CN", "ja"}); len(got) != 1 || got[0] != "zh-CN" {
+		t.Fatalf("renderable locales = %v", got)
+	}
+	typology := InterpretationInput{PersonalityType: &PersonalityTypeFacts{Detail: reporttypology.PersonalityTypeReportDetail{TypeCode: "INTJ"}}}
+	if locales := typology.Locales(); len(locales) != 1 || locales[0] != "zh-CN" {
+		t.Fatalf("typology locales = %v", locales)
+	}
+}
@@ -0,0 +1,79 @@
+package planreport
+
+import (
+	"fmt"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+// 纵向图表 ID：ChartSparkline 为主分数趋势，因子趋势为 ChartSparkline + "." + 因子编码。
+const ChartSparkline = "sparkline"
+
+// Charts 从报告内容派生纵向迷你折线：主分数一条，每个非总分因子一条，少于两个时间点的不画。
+// 图表不随内容保存，而是在读取时派生，保证已生成的报告也按当前版式出图。
+func (c Content) Charts() []reportchart.Spec {
+	labels := make(map[int]string, len(c.Timepoints))
+	for _, timepoint := range c.Timepoints {
+		labels[timepoint.Seq] = timepointLabel(timepoint)
+	}
+	label := func(seq int) string {
+		if value, ok := labels[seq]; ok {
+			return value
+		}
+		return fmt.Sprintf("第 %d 次", seq)
+	}
+
+	var charts []reportchart.Spec
+	primary := reportchart.Spec{ID: ChartSparkline, Kind: reportchart.KindSparkline, Title: c.ModelTitle}
+	for _, timepoint := range c.Timepoints {
+		if timepoint.Score == nil {
+			continue
+		}
+		if timepoint.Score.Max != nil && *timepoint.Score.Max > 0 {
+			primary.Max = *timepoint.Score.Max
+		}
+		primary.Points = append(primary.Points, reportchart.Point{Code: timepoint.OutcomeID, Label: label(timepoint.Seq), Value: timepoint.Score.Value})
+	}
+	if c.PrimaryScore != nil && c.PrimaryScore.Label != "" {
+		primary.Title = c.PrimaryScore.Label
+	}
+	if len(primary.Points) >= 2 {
+		primary.Reference = baselineReference(primary.Points)
+		charts = append(charts, primary)
+	}
+
+	for _, factor := range c.Factors {
+		if factor.IsTotalScore || len(factor.FollowUps) == 0 {
+			continue
+		}
+		title := factor.FactorName
+		if title == "" {
+			title = factor.FactorCode
+		}
+		spec := reportchart.Spec{ID: ChartSparkline + "." + factor.FactorCode, Kind: reportchart.KindSparkline, Title: title}
+		if factor.MaxScore != nil && *factor.MaxScore > 0 {
+			spec.Max = *factor.MaxScore
+		}
+		spec.Points = append(spec.Points, reportchart.Point{Code: factor.FactorCode, Label: label(factor.Baseline.Seq), Value: factor.Baseline.Score})
+		for _, followUp := range factor.FollowUps {
+			spec.Points = append(spec.Points, reportchart.Point{Code: factor.FactorCode, Label: label(followUp.Seq), Value: followUp.Score})
+		}
+		spec.Reference = baselineReference(spec.Points)
+		charts = append(charts, spec)
+	}
+	return charts
+}
+
+// timepointLabel 以测评日期标注时间点，没有日期时退回序号。
+func timepointLabel(timepoint Timepoint) string {
+	if timepoint.OccurredAt.IsZero() {
+		return fmt.Sprintf("第 %d 次", timepoint.Seq)
+	}
+	return timepoint.OccurredAt.Format("2006-01-02")
+}
+
+// baselineReference 以基线得分作为参考线，便于看出相对基线的升降。
+func baselineReference(points []reportchart.Point) *float64 {
+	baseline := points[0].Value
+	return &baseline
+}
@@ -0,0 +1,126 @@
+// Package planreport 纵向计划报告：同一计划参与轮次内全部冻结测评结果合成的一份报告。
+// 报告以冻结输入的指纹去重，同一参与轮次的相同输入只保存一份。
+package planreport
+
+import "time"
+
+// Trigger 报告生成的触发来源。
+type Trigger string
+
+const (
+	// TriggerEnrollmentClosed 参与轮次关闭后由任务事件触发。
+	TriggerEnrollmentClosed Trigger = "enrollment_closed"
+	// TriggerOnDemand 后台操作者按需生成。
+	TriggerOnDemand Trigger = "on_demand"
+)
+
+// Report 一份已生成并保存的纵向计划报告。
+type Report struct {
+	ID                   uint64
+	OrgID                int64
+	EnrollmentID         uint64
+	PlanID               uint64
+	TesteeID             uint64
+	Round                uint32
+	Trigger              Trigger
+	TemplateVersion      string
+	BuilderIdentity      string
+	ContentSchemaVersion string
+	InputFingerprint     string
+	Input                FrozenInput
+	Content              Content
+	GeneratedAt          time.Time
+}
+
+// FrozenInput 生成报告时冻结的输入：参与的测评结果及任务履约计数。
+type FrozenInput struct {
+	Outcomes       []FrozenOutcome `json:"outcomes"`
+	PlannedTasks   int             `json:"planned_tasks"`
+	CompletedTasks int             `json:"completed_tasks"`
+	ExpiredTasks   int             `json:"expired_tasks"`
+	CanceledTasks  int             `json:"canceled_tasks"`
+}
+
+// FrozenOutcome 报告引用的一次测评结果；VersionToken 标识结果的不可变版本。
+type FrozenOutcome struct {
+	Seq          int    `json:"seq"`
+	TaskID       string `json:"task_id"`
+	AssessmentID string `json:"assessment_id"`
+	OutcomeID    string `json:"outcome_id"`
+	VersionToken string `json:"version_token"`
+}
+
+// Content 纵向报告内容快照。
+type Content struct {
+	ModelCode    string             `json:"model_code"`
+	ModelVersion string             `json:"model_version"`
+	ModelTitle   string             `json:"model_title"`
+	PrimaryScore *Score             `json:"primary_score,omitempty"`
+	Level        *Level             `json:"level,omitempty"`
+	Conclusion   string             `json:"conclusion"`
+	Suggestions  []string           `json:"suggestions"`
+	Direction    string             `json:"direction"`
+	Adherence    Adherence          `json:"adherence"`
+	Timepoints   []Timepoint        `json:"timepoints"`
+	Overall      *ScoreChange       `json:"overall,omitempty"`
+	Factors      []FactorTrajectory `json:"factors"`
+}
+
+type Score struct {
+	Kind  string   `json:"kind"`
+	Value float64  `json:"value"`
+	Label string   `json:"label,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+type Level struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Severity string `json:"severity"`
+}
+
+type Adherence struct {
+	PlannedTasks   int     `json:"planned_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	ExpiredTasks   int     `json:"expired_tasks"`
+	CanceledTasks  int     `json:"canceled_tasks"`
+	Rate           float64 `json:"rate"`
+}
+
+type Timepoint struct {
+	Seq          int       `json:"seq"`
+	AssessmentID string    `json:"assessment_id"`
+	OutcomeID    string    `json:"outcome_id"`
+	OccurredAt   time.Time `json:"occurred_at"`
+	Score        *Score    `json:"score,omitempty"`
+	Level        *Level    `json:"level,omitempty"`
+}
+
+type ScoreChange struct {
+	BaselineScore float64 `json:"baseline_score"`
+	CurrentScore  float64 `json:"current_score"`
+	Delta         float64 `json:"delta"`
+	Change        string  `json:"change"`
+}
+
+type FactorObservation struct {
+	Seq       int     `json:"seq"`
+	Score     float64 `json:"score"`
+	RiskLevel string  `json:"risk_level"`
+}
+
+type FactorFollowUp struct {
+	FactorObservation
+	Delta  float64 `json:"delta"`
+	Change string  `json:"change"`
+}
+
+type FactorTrajectory struct {
+	FactorCode   string            `json:"factor_code"`
+	FactorName   string            `json:"factor_name"`
+	IsTotalScore bool              `json:"is_total_score"`
+	MaxScore     *float64          `json:"max_score,omitempty"`
+	Baseline     FactorObservation `json:"baseline"`
+	FollowUps    []FactorFollowUp  `json:"follow_ups"`
+	Change       string            `json:"change,omitempty"`
+}
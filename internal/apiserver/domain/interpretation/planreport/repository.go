@@ -0,0 +1,13 @@
+package planreport
+
+import "context"
+
+// Repository 纵向计划报告仓储接口。
+type Repository interface {
+	// Save 写入新报告并回填 ID；同一参与轮次的相同输入指纹已存在时返回已有记录。
+	Save(ctx context.Context, report *Report) (*Report, error)
+	// FindByFingerprint 不存在时返回 nil, nil。
+	FindByFingerprint(ctx context.Context, enrollmentID uint64, fingerprint string) (*Report, error)
+	// FindLatestByEnrollment 返回参与轮次最新一份报告；不存在时返回 nil, nil。
+	FindLatestByEnrollment(ctx context.Context, enrollmentID uint64) (*Report, error)
+}
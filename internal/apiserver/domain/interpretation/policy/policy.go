@@ -17,3 +17,67 @@ const (
 	// ReportProfileDefault 表示未指定 profile，路由时作为通配符回落到 broad builder。
 	ReportProfileDefault ReportProfile = ""
 )
+
+// ReportAudience 标识报告正文面向的读者版本。
+// 空值是规范版本（canonical），即报告模板发布时必定生成的那一份；
+// 其余取值是模板清单显式发布的受众变体，由同一 outcome 派生。
+type ReportAudience string
+
+const (
+	ReportAudienceCanonical ReportAudience = ""
+	ReportAudienceClinician ReportAudience = "clinician"
+	ReportAudienceFamily    ReportAudience = "family"
+	ReportAudienceSchool    ReportAudience = "school"
+)
+
+func (a ReportAudience) String() string {
+	if a == ReportAudienceCanonical {
+		return "canonical"
+	}
+	return string(a)
+}
+
+func (a ReportAudience) IsCanonical() bool {
+	return a == ReportAudienceCanonical
+}
+
+// IsValid 只接受已知受众；canonical 也是合法取值。
+func (a ReportAudience) IsValid() bool {
+	switch a {
+	case ReportAudienceCanonical, ReportAudienceClinician, ReportAudienceFamily, ReportAudienceSchool:
+		return true
+	default:
+		return false
+	}
+}
+
+// Fallbacks 返回读取该受众报告时的候选顺序：先取本受众变体，
+// 未发布时回落到 canonical。回落从不跨受众（family 不会读到 clinician 版）。
+func (a ReportAudience) Fallbacks() []ReportAudience {
+	if a.IsCanonical() {
+		return []ReportAudience{ReportAudienceCanonical}
+	}
+	return []ReportAudience{a, ReportAudienceCanonical}
+}
+
+// ParseReportAudience 解析外部传入的受众；"canonical" 与空串等价。
+func ParseReportAudience(value string) (ReportAudience, bool) {
+	if value == "canonical" {
+		return ReportAudienceCanonical, true
+	}
+	audience := ReportAudience(value)
+	return audience, audience.IsValid()
+}
+
+// ReportAudienceForViewer 给出读取路径的默认受众版本：
+// 受测者/监护人读 family，临床医生与机构管理员读 clinician。
+func ReportAudienceForViewer(viewer Audience) ReportAudience {
+	switch viewer {
+	case AudienceParticipant:
+		return ReportAudienceFamily
+	case AudienceClinician, AudienceAdmin:
+		return ReportAudienceClinician
+	default:
+		return ReportAudienceCanonical
+	}
+}
@@ -1,9 +1,12 @@
 package policy
 
-// ReportType 报告模板类型；v1 仅支持 standard 默认报告。
+// ReportType 报告模板类型：standard 覆盖单次测评结果，longitudinal 汇总同一计划参与轮次的全部测评结果。
 type ReportType string
 
-const ReportTypeStandard ReportType = "standard"
+const (
+	ReportTypeStandard     ReportType = "standard"
+	ReportTypeLongitudinal ReportType = "longitudinal"
+)
 
 func (t ReportType) String() string {
 	return string(t)
@@ -26,6 +29,12 @@ const (
 	// TemplateVersionCurrent is the first explicitly published report semantics
 	// release selected by governed ModelCatalog snapshots.
 	TemplateVersionCurrent TemplateVersion = "2026-08-v1"
+	// TemplateVersionAudienceVariants keeps 2026-08-v1 semantics for the
+	// canonical report and additionally publishes audience-specific variants.
+	TemplateVersionAudienceVariants TemplateVersion = "2026-10-v1"
+	// TemplateVersionLocalized keeps 2026-10-v1 semantics and audiences and
+	// additionally renders the content locales listed on the release.
+	TemplateVersionLocalized TemplateVersion = "2026-11-v1"
 )
 
 func (v TemplateVersion) String() string {
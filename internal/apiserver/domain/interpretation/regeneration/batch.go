@@ -0,0 +1,313 @@
+package regeneration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// BatchStatus is the lifecycle state of one regeneration batch.
+type BatchStatus string
+
+const (
+	BatchStatusRunning   BatchStatus = "running"
+	BatchStatusCompleted BatchStatus = "completed"
+	BatchStatusCanceled  BatchStatus = "canceled"
+)
+
+func (s BatchStatus) IsValid() bool {
+	switch s {
+	case BatchStatusRunning, BatchStatusCompleted, BatchStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Selector picks historical Outcomes by the frozen model identity of their
+// reports. The date range applies to the Outcome's first report, so a batch
+// never re-selects Outcomes that only became eligible through its own output.
+type Selector struct {
+	OrgID         int64
+	ModelCode     string
+	ModelVersion  string
+	GeneratedFrom *time.Time
+	GeneratedTo   *time.Time
+}
+
+// Normalize validates the selector and trims its identity fields.
+func (s Selector) Normalize() (Selector, error) {
+	if s.OrgID <= 0 {
+		return Selector{}, fmt.Errorf("report regeneration org_id is required")
+	}
+	s.ModelCode = strings.TrimSpace(s.ModelCode)
+	s.ModelVersion = strings.TrimSpace(s.ModelVersion)
+	if s.ModelCode == "" {
+		return Selector{}, fmt.Errorf("report regeneration model_code is required")
+	}
+	if s.GeneratedFrom != nil && s.GeneratedTo != nil && !s.GeneratedFrom.Before(*s.GeneratedTo) {
+		return Selector{}, fmt.Errorf("report regeneration generated_from must precede generated_to")
+	}
+	s.GeneratedFrom = cloneTime(s.GeneratedFrom)
+	s.GeneratedTo = cloneTime(s.GeneratedTo)
+	return s, nil
+}
+
+// Progress counts the items a batch has selected. Pending items have an
+// accepted generation that is still running or waiting for its retry window.
+type Progress struct {
+	Selected  int
+	Generated int
+	Skipped   int
+	Failed    int
+	Pending   int
+}
+
+// Batch regenerates historical reports under one target template release. It
+// walks the selected Outcomes in id order; the cursor is the last Outcome that
+// has an item, so a restarted processor resumes without re-selecting.
+type Batch struct {
+	id            meta.ID
+	requestID     string
+	selector      Selector
+	templateID    string
+	targetVersion policy.TemplateVersion
+	estimated     int64
+	status        BatchStatus
+	progress      Progress
+	cursor        meta.ID
+	version       uint64
+	reason        string
+	requestedBy   string
+	createdAt     time.Time
+	updatedAt     time.Time
+	closedAt      *time.Time
+	closedBy      string
+	closeReason   string
+}
+
+// StartBatchInput constructs a running batch. Estimated is the dry-run count
+// at start time; the processor may select fewer if reports change meanwhile.
+type StartBatchInput struct {
+	ID            meta.ID
+	RequestID     string
+	Selector      Selector
+	TemplateID    string
+	TargetVersion policy.TemplateVersion
+	Estimated     int64
+	Reason        string
+	Actor         string
+	At            time.Time
+}
+
+func NewBatch(input StartBatchInput) (*Batch, error) {
+	if input.ID.IsZero() {
+		return nil, fmt.Errorf("report regeneration batch id is required")
+	}
+	selector, err := input.Selector.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	templateID := strings.TrimSpace(input.TemplateID)
+	if templateID == "" || input.TargetVersion.IsEmpty() {
+		return nil, fmt.Errorf("report regeneration template_id and target_version are required")
+	}
+	if input.Estimated < 0 {
+		return nil, fmt.Errorf("report regeneration estimate is invalid")
+	}
+	actor := strings.TrimSpace(input.Actor)
+	reason := strings.TrimSpace(input.Reason)
+	if actor == "" || reason == "" {
+		return nil, fmt.Errorf("report regeneration actor and reason are required")
+	}
+	if input.At.IsZero() {
+		return nil, fmt.Errorf("report regeneration start time is required")
+	}
+	return &Batch{
+		id: input.ID, requestID: strings.TrimSpace(input.RequestID), selector: selector, templateID: templateID,
+		targetVersion: input.TargetVersion, estimated: input.Estimated, status: BatchStatusRunning, version: 1,
+		reason: reason, requestedBy: actor, createdAt: input.At, updatedAt: input.At,
+	}, nil
+}
+
+// PersistedBatch is the storage shape for Batch.
+type PersistedBatch struct {
+	StartBatchInput
+	Status      BatchStatus
+	Progress    Progress
+	Cursor      meta.ID
+	Version     uint64
+	UpdatedAt   time.Time
+	ClosedAt    *time.Time
+	ClosedBy    string
+	CloseReason string
+}
+
+// RehydrateBatch restores a persisted batch.
+func RehydrateBatch(input PersistedBatch) (*Batch, error) {
+	batch, err := NewBatch(input.StartBatchInput)
+	if err != nil {
+		return nil, err
+	}
+	if !input.Status.IsValid() || input.Version == 0 || input.UpdatedAt.Before(input.StartBatchInput.At) {
+		return nil, fmt.Errorf("report regeneration batch persistence state is invalid")
+	}
+	progress := input.Progress
+	if progress.Generated < 0 || progress.Skipped < 0 || progress.Failed < 0 || progress.Pending < 0 ||
+		progress.Generated+progress.Skipped+progress.Failed+progress.Pending != progress.Selected {
+		return nil, fmt.Errorf("report regeneration batch progress is inconsistent")
+	}
+	closed := input.ClosedAt != nil && !input.ClosedAt.IsZero()
+	if closed != (input.Status != BatchStatusRunning) {
+		return nil, fmt.Errorf("report regeneration batch close audit is invalid")
+	}
+	batch.status = input.Status
+	batch.progress = progress
+	batch.cursor = input.Cursor
+	batch.version = input.Version
+	batch.updatedAt = input.UpdatedAt
+	batch.closedAt = cloneTime(input.ClosedAt)
+	batch.closedBy = strings.TrimSpace(input.ClosedBy)
+	batch.closeReason = strings.TrimSpace(input.CloseReason)
+	return batch, nil
+}
+
+func (b *Batch) ID() meta.ID                           { return b.id }
+func (b *Batch) RequestID() string                     { return b.requestID }
+func (b *Batch) Selector() Selector                    { return b.selector.clone() }
+func (b *Batch) TemplateID() string                    { return b.templateID }
+func (b *Batch) TargetVersion() policy.TemplateVersion { return b.targetVersion }
+func (b *Batch) Estimated() int64                      { return b.estimated }
+func (b *Batch) Status() BatchStatus                   { return b.status }
+func (b *Batch) Progress() Progress                    { return b.progress }
+func (b *Batch) Cursor() meta.ID                       { return b.cursor }
+func (b *Batch) Version() uint64                       { return b.version }
+func (b *Batch) Reason() string                        { return b.reason }
+func (b *Batch) RequestedBy() string                   { return b.requestedBy }
+func (b *Batch) CreatedAt() time.Time                  { return b.createdAt }
+func (b *Batch) UpdatedAt() time.Time                  { return b.updatedAt }
+func (b *Batch) ClosedAt() *time.Time                  { return cloneTime(b.closedAt) }
+func (b *Batch) ClosedBy() string                      { return b.closedBy }
+func (b *Batch) CloseReason() string                   { return b.closeReason }
+
+// RecordItem advances the cursor past a newly selected Outcome and counts the
+// item in its first state.
+func (b *Batch) RecordItem(outcomeID meta.ID, status ItemStatus, at time.Time) error {
+	if err := b.requireRunning(at); err != nil {
+		return err
+	}
+	if outcomeID.IsZero() || outcomeID.Uint64() <= b.cursor.Uint64() {
+		return fmt.Errorf("report regeneration cursor must advance")
+	}
+	if err := b.progress.add(status, 1); err != nil {
+		return err
+	}
+	b.progress.Selected++
+	b.cursor = outcomeID
+	b.touch(at)
+	return nil
+}
+
+// ResolveItem moves one pending item to its final state.
+func (b *Batch) ResolveItem(status ItemStatus, at time.Time) error {
+	if err := b.requireRunning(at); err != nil {
+		return err
+	}
+	if status == ItemStatusPending || b.progress.Pending == 0 {
+		return fmt.Errorf("report regeneration item is not pending")
+	}
+	if err := b.progress.add(status, 1); err != nil {
+		return err
+	}
+	b.progress.Pending--
+	b.touch(at)
+	return nil
+}
+
+// Complete closes a batch whose selection is exhausted and whose items have
+// all reached a final state.
+func (b *Batch) Complete(at time.Time) error {
+	if err := b.requireRunning(at); err != nil {
+		return err
+	}
+	if b.progress.Pending > 0 {
+		return fmt.Errorf("report regeneration batch still has pending items")
+	}
+	b.close(BatchStatusCompleted, "system", "", at)
+	return nil
+}
+
+// Cancel stops selecting Outcomes. Generations already accepted keep running
+// under the normal retry and lease machinery; their items stay pending.
+func (b *Batch) Cancel(actor, reason string, at time.Time) error {
+	if err := b.requireRunning(at); err != nil {
+		return err
+	}
+	actor = strings.TrimSpace(actor)
+	reason = strings.TrimSpace(reason)
+	if actor == "" || reason == "" {
+		return fmt.Errorf("report regeneration actor and reason are required")
+	}
+	b.close(BatchStatusCanceled, actor, reason, at)
+	return nil
+}
+
+func (b *Batch) requireRunning(at time.Time) error {
+	if b == nil {
+		return fmt.Errorf("report regeneration batch is required")
+	}
+	if b.status != BatchStatusRunning {
+		return fmt.Errorf("report regeneration batch is %s", b.status)
+	}
+	if at.IsZero() || at.Before(b.createdAt) {
+		return fmt.Errorf("report regeneration time is invalid")
+	}
+	return nil
+}
+
+func (b *Batch) close(status BatchStatus, actor, reason string, at time.Time) {
+	b.status = status
+	closedAt := at
+	b.closedAt = &closedAt
+	b.closedBy = actor
+	b.closeReason = reason
+	b.touch(at)
+}
+
+func (b *Batch) touch(at time.Time) {
+	b.version++
+	b.updatedAt = at
+}
+
+func (p *Progress) add(status ItemStatus, delta int) error {
+	switch status {
+	case ItemStatusGenerated:
+		p.Generated += delta
+	case ItemStatusSkipped:
+		p.Skipped += delta
+	case ItemStatusFailed:
+		p.Failed += delta
+	case ItemStatusPending:
+		p.Pending += delta
+	default:
+		return fmt.Errorf("report regeneration item status is invalid: %s", status)
+	}
+	return nil
+}
+
+func (s Selector) clone() Selector {
+	s.GeneratedFrom = cloneTime(s.GeneratedFrom)
+	s.GeneratedTo = cloneTime(s.GeneratedTo)
+	return s
+}
+
+func cloneTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
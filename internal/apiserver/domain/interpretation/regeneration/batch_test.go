@@ -0,0 +1,128 @@
+package regeneration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func newTestBatch(t *testing.T, at time.Time) *Batch {
+	t.Helper()
+	batch, err := NewBatch(StartBatchInput{
+		ID: meta.FromUint64(1), RequestID: "req-1", Selector: Selector{OrgID: 7, ModelCode: " PHQ9 "},
+		TemplateID: "phq9", TargetVersion: "custom-v2", Estimated: 3, Reason: "new wording", Actor: "user:9", At: at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return batch
+}
+
+func TestBatchTracksProgressThroughPendingItemsAndCompletes(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	batch := newTestBatch(t, at)
+	if batch.Selector().ModelCode != "PHQ9" {
+		t.Fatalf("model code = %q, want trimmed", batch.Selector().ModelCode)
+	}
+	if err := batch.RecordItem(meta.FromUint64(10), ItemStatusGenerated, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.RecordItem(meta.FromUint64(10), ItemStatusGenerated, at); err == nil {
+		t.Fatal("cursor must advance past recorded outcomes")
+	}
+	if err := batch.RecordItem(meta.FromUint64(12), ItemStatusPending, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Complete(at); err == nil {
+		t.Fatal("batch with pending items must not complete")
+	}
+	if err := batch.ResolveItem(ItemStatusFailed, at.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Complete(at.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	progress := batch.Progress()
+	if progress != (Progress{Selected: 2, Generated: 1, Failed: 1}) {
+		t.Fatalf("progress = %+v", progress)
+	}
+	if batch.Cursor() != meta.FromUint64(12) || batch.Status() != BatchStatusCompleted || batch.Version() != 5 {
+		t.Fatalf("cursor=%s status=%s version=%d", batch.Cursor(), batch.Status(), batch.Version())
+	}
+	if err := batch.RecordItem(meta.FromUint64(13), ItemStatusGenerated, at.Add(time.Hour)); err == nil {
+		t.Fatal("closed batch must not select more outcomes")
+	}
+}
+
+func TestBatchCancelRequiresReasonAndRehydratesAudit(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	batch := newTestBatch(t, at)
+	if err := batch.Cancel("user:9", " ", at); err == nil {
+		t.Fatal("cancel without reason must fail")
+	}
+	if err := batch.RecordItem(meta.FromUint64(10), ItemStatusPending, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Cancel("user:9", "wrong target", at.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RehydrateBatch(PersistedBatch{
+		StartBatchInput: StartBatchInput{
+			ID: batch.ID(), RequestID: batch.RequestID(), Selector: batch.Selector(), TemplateID: batch.TemplateID(),
+			TargetVersion: batch.TargetVersion(), Estimated: batch.Estimated(), Reason: batch.Reason(),
+			Actor: batch.RequestedBy(), At: batch.CreatedAt(),
+		},
+		Status: batch.Status(), Progress: batch.Progress(), Cursor: batch.Cursor(), Version: batch.Version(),
+		UpdatedAt: batch.UpdatedAt(), ClosedAt: batch.ClosedAt(), ClosedBy: batch.ClosedBy(), CloseReason: batch.CloseReason(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Status() != BatchStatusCanceled || restored.CloseReason() != "wrong target" || restored.Progress().Pending != 1 {
+		t.Fatalf("restored status=%s reason=%q progress=%+v", restored.Status(), restored.CloseReason(), restored.Progress())
+	}
+}
+
+func TestItemKeepsBoundedDiffAndClearsRetryWhenFinal(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	item, err := NewItem(meta.FromUint64(1), Candidate{
+		OutcomeID: meta.FromUint64(10), OrgID: 7, AssessmentID: meta.FromUint64(3), TesteeID: 4,
+		PreviousReportID: meta.FromUint64(20), PreviousVersion: policy.TemplateVersionV1,
+	}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := item.Accept(meta.FromUint64(30), at.Add(time.Minute), "waiting for retry", at); err != nil {
+		t.Fatal(err)
+	}
+	if item.RetryAt() == nil || item.Status() != ItemStatusPending {
+		t.Fatalf("accepted item = %s retry=%v", item.Status(), item.RetryAt())
+	}
+	changes := make([]domainreport.ContentChange, 0, MaxDiffPaths+5)
+	for index := 0; index < MaxDiffPaths+5; index++ {
+		changes = append(changes, domainreport.ContentChange{Path: fmt.Sprintf("suggestions[%d]", index)})
+	}
+	if err := item.MarkGenerated(meta.FromUint64(30), meta.FromUint64(40), SummarizeChanges(changes), at.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	diff := item.Diff()
+	if diff.ChangedFields != MaxDiffPaths+5 || len(diff.Paths) != MaxDiffPaths {
+		t.Fatalf("diff = %d fields, %d paths", diff.ChangedFields, len(diff.Paths))
+	}
+	if item.RetryAt() != nil || item.Message() != "" {
+		t.Fatalf("generated item kept retry=%v message=%q", item.RetryAt(), item.Message())
+	}
+	if err := item.MarkFailed("late failure", at.Add(time.Hour)); err == nil {
+		t.Fatal("final item must not change state")
+	}
+}
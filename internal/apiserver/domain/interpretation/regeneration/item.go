@@ -0,0 +1,240 @@
+package regeneration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// ItemStatus is the state of one selected Outcome inside a batch.
+type ItemStatus string
+
+const (
+	// ItemStatusPending means the target generation was accepted but has not
+	// committed yet: it is running or waiting for its retry window.
+	ItemStatusPending   ItemStatus = "pending"
+	ItemStatusGenerated ItemStatus = "generated"
+	// ItemStatusSkipped means the Outcome cannot be rendered by the target
+	// release, for example because it froze another template.
+	ItemStatusSkipped ItemStatus = "skipped"
+	ItemStatusFailed  ItemStatus = "failed"
+)
+
+func (s ItemStatus) IsValid() bool {
+	switch s {
+	case ItemStatusPending, ItemStatusGenerated, ItemStatusSkipped, ItemStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxDiffPaths bounds the changed paths kept per item; ChangedFields keeps the
+// full count.
+const MaxDiffPaths = 20
+
+// DiffSummary describes how the regenerated report differs from the report it
+// supersedes. Only field paths are kept; both reports stay readable as history.
+type DiffSummary struct {
+	ChangedFields int
+	Paths         []string
+}
+
+// SummarizeChanges reduces a content diff to its changed paths.
+func SummarizeChanges(changes []domainreport.ContentChange) DiffSummary {
+	summary := DiffSummary{ChangedFields: len(changes)}
+	for _, change := range changes {
+		if len(summary.Paths) == MaxDiffPaths {
+			break
+		}
+		summary.Paths = append(summary.Paths, change.Path)
+	}
+	return summary
+}
+
+// Candidate is one historical Outcome selected for regeneration, with the
+// latest report it currently serves.
+type Candidate struct {
+	OutcomeID        meta.ID
+	OrgID            int64
+	AssessmentID     meta.ID
+	TesteeID         uint64
+	PreviousReportID meta.ID
+	PreviousVersion  policy.TemplateVersion
+}
+
+// Item records what a batch did with one Outcome.
+type Item struct {
+	batchID          meta.ID
+	outcomeID        meta.ID
+	orgID            int64
+	assessmentID     meta.ID
+	testeeID         uint64
+	previousReportID meta.ID
+	previousVersion  policy.TemplateVersion
+	status           ItemStatus
+	generationID     meta.ID
+	reportID         meta.ID
+	diff             DiffSummary
+	message          string
+	retryAt          *time.Time
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+func NewItem(batchID meta.ID, candidate Candidate, at time.Time) (*Item, error) {
+	if batchID.IsZero() || candidate.OutcomeID.IsZero() || candidate.PreviousReportID.IsZero() {
+		return nil, fmt.Errorf("report regeneration item batch, outcome and previous report are required")
+	}
+	if candidate.OrgID <= 0 || candidate.PreviousVersion.IsEmpty() {
+		return nil, fmt.Errorf("report regeneration item org and previous version are required")
+	}
+	if at.IsZero() {
+		return nil, fmt.Errorf("report regeneration item time is required")
+	}
+	return &Item{
+		batchID: batchID, outcomeID: candidate.OutcomeID, orgID: candidate.OrgID, assessmentID: candidate.AssessmentID,
+		testeeID: candidate.TesteeID, previousReportID: candidate.PreviousReportID, previousVersion: candidate.PreviousVersion,
+		status: ItemStatusPending, createdAt: at, updatedAt: at,
+	}, nil
+}
+
+// PersistedItem is the storage shape for Item.
+type PersistedItem struct {
+	BatchID      meta.ID
+	Candidate    Candidate
+	Status       ItemStatus
+	GenerationID meta.ID
+	ReportID     meta.ID
+	Diff         DiffSummary
+	Message      string
+	RetryAt      *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RehydrateItem restores a persisted item.
+func RehydrateItem(input PersistedItem) (*Item, error) {
+	item, err := NewItem(input.BatchID, input.Candidate, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !input.Status.IsValid() || input.UpdatedAt.Before(input.CreatedAt) {
+		return nil, fmt.Errorf("report regeneration item persistence state is invalid")
+	}
+	if (input.Status == ItemStatusGenerated) != !input.ReportID.IsZero() {
+		return nil, fmt.Errorf("report regeneration item report reference is invalid")
+	}
+	item.status = input.Status
+	item.generationID = input.GenerationID
+	item.reportID = input.ReportID
+	item.diff = DiffSummary{ChangedFields: input.Diff.ChangedFields, Paths: append([]string(nil), input.Diff.Paths...)}
+	item.message = input.Message
+	if input.Status == ItemStatusPending {
+		item.retryAt = cloneTime(input.RetryAt)
+	}
+	item.updatedAt = input.UpdatedAt
+	return item, nil
+}
+
+func (i *Item) BatchID() meta.ID                        { return i.batchID }
+func (i *Item) OutcomeID() meta.ID                      { return i.outcomeID }
+func (i *Item) OrgID() int64                            { return i.orgID }
+func (i *Item) AssessmentID() meta.ID                   { return i.assessmentID }
+func (i *Item) TesteeID() uint64                        { return i.testeeID }
+func (i *Item) PreviousReportID() meta.ID               { return i.previousReportID }
+func (i *Item) PreviousVersion() policy.TemplateVersion { return i.previousVersion }
+func (i *Item) Status() ItemStatus                      { return i.status }
+func (i *Item) GenerationID() meta.ID                   { return i.generationID }
+func (i *Item) ReportID() meta.ID                       { return i.reportID }
+func (i *Item) Message() string                         { return i.message }
+func (i *Item) RetryAt() *time.Time                     { return cloneTime(i.retryAt) }
+func (i *Item) CreatedAt() time.Time                    { return i.createdAt }
+func (i *Item) UpdatedAt() time.Time                    { return i.updatedAt }
+
+func (i *Item) Diff() DiffSummary {
+	return DiffSummary{ChangedFields: i.diff.ChangedFields, Paths: append([]string(nil), i.diff.Paths...)}
+}
+
+// Candidate returns the selection snapshot the item was created from.
+func (i *Item) Candidate() Candidate {
+	return Candidate{
+		OutcomeID: i.outcomeID, OrgID: i.orgID, AssessmentID: i.assessmentID, TesteeID: i.testeeID,
+		PreviousReportID: i.previousReportID, PreviousVersion: i.previousVersion,
+	}
+}
+
+// Accept records the target generation while it is still running or waiting
+// for its retry window. The processor does not look at the item again before
+// retryAt, which is the generation's own next attempt time when it has one.
+func (i *Item) Accept(generationID meta.ID, retryAt time.Time, message string, at time.Time) error {
+	if err := i.requirePending(at); err != nil {
+		return err
+	}
+	if generationID.IsZero() || retryAt.IsZero() {
+		return fmt.Errorf("report regeneration generation id and retry time are required")
+	}
+	i.generationID = generationID
+	i.retryAt = &retryAt
+	i.message = strings.TrimSpace(message)
+	i.updatedAt = at
+	return nil
+}
+
+// MarkGenerated records the committed target report and its diff summary.
+func (i *Item) MarkGenerated(generationID, reportID meta.ID, diff DiffSummary, at time.Time) error {
+	if err := i.requirePending(at); err != nil {
+		return err
+	}
+	if generationID.IsZero() || reportID.IsZero() {
+		return fmt.Errorf("report regeneration generation and report are required")
+	}
+	i.status = ItemStatusGenerated
+	i.generationID = generationID
+	i.reportID = reportID
+	i.diff = DiffSummary{ChangedFields: diff.ChangedFields, Paths: append([]string(nil), diff.Paths...)}
+	i.message = ""
+	i.retryAt = nil
+	i.updatedAt = at
+	return nil
+}
+
+func (i *Item) MarkSkipped(message string, at time.Time) error {
+	return i.finish(ItemStatusSkipped, message, at)
+}
+
+func (i *Item) MarkFailed(message string, at time.Time) error {
+	return i.finish(ItemStatusFailed, message, at)
+}
+
+func (i *Item) finish(status ItemStatus, message string, at time.Time) error {
+	if err := i.requirePending(at); err != nil {
+		return err
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("report regeneration item %s reason is required", status)
+	}
+	i.status = status
+	i.message = message
+	i.retryAt = nil
+	i.updatedAt = at
+	return nil
+}
+
+func (i *Item) requirePending(at time.Time) error {
+	if i == nil {
+		return fmt.Errorf("report regeneration item is required")
+	}
+	if i.status != ItemStatusPending {
+		return fmt.Errorf("report regeneration item is already %s", i.status)
+	}
+	if at.IsZero() || at.Before(i.createdAt) {
+		return fmt.Errorf("report regeneration item time is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package regeneration
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+var (
+	ErrBatchNotFound = errors.New("report regeneration batch not found")
+	ErrBatchConflict = errors.New("report regeneration batch state conflict")
+	ErrItemConflict  = errors.New("report regeneration item already exists")
+)
+
+// BatchRepository persists batches. Create enforces one batch per non-empty
+// request id and Save compares the expected version.
+type BatchRepository interface {
+	Create(ctx context.Context, batch *Batch) error
+	Save(ctx context.Context, batch *Batch, expectedVersion uint64) error
+	FindByID(ctx context.Context, id meta.ID) (*Batch, error)
+	FindByRequestID(ctx context.Context, requestID string) (*Batch, error)
+	ListByOrg(ctx context.Context, orgID int64, limit int) ([]*Batch, error)
+	ListRunning(ctx context.Context, limit int) ([]*Batch, error)
+}
+
+// ItemRepository stores at most one item per batch and Outcome. ListPending
+// returns pending items whose retry time is not after dueAt.
+type ItemRepository interface {
+	Insert(ctx context.Context, item *Item) error
+	Save(ctx context.Context, item *Item) error
+	ListPending(ctx context.Context, batchID meta.ID, dueAt time.Time, limit int) ([]*Item, error)
+	ListByBatch(ctx context.Context, batchID meta.ID, status ItemStatus, limit int) ([]*Item, error)
+}
+
+// Counts is a dry-run over a selector: Matched Outcomes, of which OnTarget
+// already have a report under the target release.
+type Counts struct {
+	Matched  int64
+	OnTarget int64
+}
+
+func (c Counts) ToRegenerate() int64 { return c.Matched - c.OnTarget }
+
+// CandidateReader selects historical Outcomes from committed reports. Outcomes
+// that already have a report under the target release are never listed.
+type CandidateReader interface {
+	Count(ctx context.Context, selector Selector, target policy.TemplateVersion) (Counts, error)
+	ListAfter(ctx context.Context, selector Selector, target policy.TemplateVersion, after meta.ID, limit int) ([]Candidate, error)
+}
@@ -0,0 +1,66 @@
+package rendering
+
+import (
+	"context"
+
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+)
+
+type audienceBuilder struct {
+	inner    Builder
+	audience policy.ReportAudience
+}
+
+// AudienceVariant registers an audience-specific rendering of the same builder.
+// The inner builder produces the canonical draft which is then rewritten for the
+// audience, so every variant stays derived from one outcome.
+func AudienceVariant(inner Builder, audience policy.ReportAudience) Builder {
+	if inner == nil || audience.IsCanonical() {
+		return inner
+	}
+	return audienceBuilder{inner: inner, audience: audience}
+}
+
+func (b audienceBuilder) ReportType() policy.ReportType { return b.inner.ReportType() }
+func (b audienceBuilder) TemplateVersion() policy.TemplateVersion {
+	return b.inner.TemplateVersion()
+}
+func (b audienceBuilder) BuilderIdentity() string      { return b.inner.BuilderIdentity() }
+func (b audienceBuilder) ContentSchemaVersion() string { return b.inner.ContentSchemaVersion() }
+func (b audienceBuilder) Build(ctx context.Context, input interpinput.InterpretationInput) (*report.Draft, error) {
+	draft, err := b.inner.Build(ctx, input)
+	if err != nil || draft == nil {
+		return draft, err
+	}
+	content, err := report.AdaptContentForAudienceIn(draft.Content(), b.audience, input.Locale)
+	if err != nil {
+		return nil, err
+	}
+	return report.NewDraft(content), nil
+}
+
+func (b audienceBuilder) MechanismKey() Key {
+	keyed, ok := b.inner.(KeyedBuilder)
+	if !ok {
+		return Key{ReportType: b.ReportType(), TemplateVersion: b.TemplateVersion(), Audience: b.audience}
+	}
+	key := keyed.MechanismKey()
+	key.Audience = b.audience
+	return key
+}
+
+func (b audienceBuilder) MechanismKeys() []Key {
+	multi, ok := b.inner.(MultiKeyedBuilder)
+	if !ok {
+		return []Key{b.MechanismKey()}
+	}
+	keys := multi.MechanismKeys()
+	out := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		key.Audience = b.audience
+		out = append(out, key)
+	}
+	return out
+}
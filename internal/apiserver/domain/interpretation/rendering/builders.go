@@ -154,11 +154,27 @@ func (b TypologyBuilder) Build(_ context.Context, input interpinput.Interpretati
 }
 
 func DefaultBuilders(composer report.DraftBuilder) []Builder {
-	legacy := []Builder{NewFactorScoringBuilder(composer), NewTypologyBuilder(), NewNormProfileBuilder(composer), NewTaskPerformanceBuilder(composer)}
-	builders := make([]Builder, 0, len(legacy)*2)
+	legacy := []Builder{NewFactorScoringBuilder(composer), NewTypologyBuilder(), NewNormProfileBuilder(composer), NewTaskPerformanceBuilder(composer), NewLongitudinalBuilder(composer)}
+	// 受众变体只覆盖按因子计分的机制；typology 与纵向报告暂不发布受众版本。
+	// 2026-11-v1 沿用 2026-10-v1 的 builder，多语言由输入本地化与发布的 Locales 决定。
+	audienceScoped := []Builder{NewFactorScoringBuilder(composer), NewNormProfileBuilder(composer), NewTaskPerformanceBuilder(composer)}
+	audiences := []policy.ReportAudience{policy.ReportAudienceClinician, policy.ReportAudienceFamily, policy.ReportAudienceSchool}
+	audienceVersions := []policy.TemplateVersion{policy.TemplateVersionAudienceVariants, policy.TemplateVersionLocalized}
+	builders := make([]Builder, 0, len(legacy)*4+len(audienceScoped)*len(audiences)*len(audienceVersions))
 	builders = append(builders, legacy...)
 	for _, builder := range legacy {
-		builders = append(builders, Versioned(builder, policy.TemplateVersionCurrent))
+		builders = append(builders,
+			Versioned(builder, policy.TemplateVersionCurrent),
+			Versioned(builder, policy.TemplateVersionAudienceVariants),
+			Versioned(builder, policy.TemplateVersionLocalized),
+		)
+	}
+	for _, version := range audienceVersions {
+		for _, builder := range audienceScoped {
+			for _, audience := range audiences {
+				builders = append(builders, AudienceVariant(Versioned(builder, version), audience))
+			}
+		}
 	}
 	return builders
 }
@@ -0,0 +1,194 @@
+package rendering
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+)
+
+// LongitudinalBuilder composes one report from the frozen outcomes of a plan
+// enrollment: baseline versus every follow-up per factor, change
+// classification, adherence and the latest conclusion. Each point is first
+// composed exactly like its standard factor-scoring report, so names, maxima,
+// risk levels and conclusions match the per-outcome reports. Only scored
+// mechanisms are registered; typology results have no score trajectory.
+type LongitudinalBuilder struct{ scoring FactorScoringBuilder }
+
+func NewLongitudinalBuilder(composer report.DraftBuilder) LongitudinalBuilder {
+	return LongitudinalBuilder{scoring: NewFactorScoringBuilder(composer)}
+}
+func (LongitudinalBuilder) ReportType() policy.ReportType {
+	return policy.ReportTypeLongitudinal
+}
+func (LongitudinalBuilder) TemplateVersion() policy.TemplateVersion { return policy.TemplateVersionV1 }
+func (LongitudinalBuilder) BuilderIdentity() string                 { return report.BuilderIdentityLongitudinal }
+func (LongitudinalBuilder) ContentSchemaVersion() string            { return "report-content/v1" }
+func (b LongitudinalBuilder) MechanismKey() Key                     { return b.MechanismKeys()[0] }
+func (LongitudinalBuilder) MechanismKeys() []Key {
+	return []Key{
+		{DecisionKind: modelcatalog.DecisionKindScoreRange, ReportType: policy.ReportTypeLongitudinal},
+		{DecisionKind: modelcatalog.DecisionKindNormLookup, ReportType: policy.ReportTypeLongitudinal},
+		{DecisionKind: modelcatalog.DecisionKindAbilityLevel, ReportType: policy.ReportTypeLongitudinal},
+	}
+}
+
+func (b LongitudinalBuilder) Build(ctx context.Context, input interpinput.InterpretationInput) (*report.Draft, error) {
+	facts := input.Longitudinal
+	if facts == nil || len(facts.Points) == 0 {
+		return nil, fmt.Errorf("longitudinal interpretation facts are required")
+	}
+	if facts.EnrollmentID.IsZero() {
+		return nil, fmt.Errorf("longitudinal plan enrollment id is required")
+	}
+	points := append([]interpinput.LongitudinalPoint(nil), facts.Points...)
+	sort.SliceStable(points, func(i, j int) bool {
+		if points[i].Seq != points[j].Seq {
+			return points[i].Seq < points[j].Seq
+		}
+		return points[i].OccurredAt.Before(points[j].OccurredAt)
+	})
+	direction := report.ScoreDirectionHigherIsWorse
+	if input.Runtime.DecisionKind == modelcatalog.DecisionKindAbilityLevel {
+		direction = report.ScoreDirectionHigherIsBetter
+	}
+	var visible map[string]bool
+	if input.PresentationProfile != nil && input.PresentationProfile.Configured() {
+		visible = input.PresentationProfile.VisibleSet()
+	}
+
+	section := &report.LongitudinalSection{
+		EnrollmentID: facts.EnrollmentID.String(),
+		PlanID:       facts.PlanID.String(),
+		Round:        facts.Round,
+		Direction:    direction,
+		Adherence:    report.NewLongitudinalAdherence(facts.PlannedTasks, facts.CompletedTasks, facts.ExpiredTasks, facts.CanceledTasks),
+		Timepoints:   make([]report.LongitudinalTimepoint, 0, len(points)),
+	}
+	tracker := newFactorTracker(direction)
+	var latest report.Content
+	for _, point := range points {
+		section.Timepoints = append(section.Timepoints, report.LongitudinalTimepoint{
+			Seq: point.Seq, AssessmentID: point.AssessmentID.String(), OutcomeID: point.OutcomeID.String(),
+			OccurredAt: point.OccurredAt, PrimaryScore: point.Primary, Level: point.Level,
+		})
+		draft, err := b.scoring.Build(ctx, pointInput(input, point))
+		if err != nil {
+			return nil, fmt.Errorf("compose longitudinal timepoint %d: %w", point.Seq, err)
+		}
+		latest = draft.Content()
+		dimensions := latest.Dimensions
+		if visible != nil {
+			dimensions = report.FilterDimensionInterprets(dimensions, visible)
+		}
+		tracker.observe(point.Seq, dimensions, totalFactorCodes(point))
+	}
+	section.Factors = tracker.trajectories
+
+	baseline, last := points[0], points[len(points)-1]
+	if len(points) > 1 && baseline.Primary != nil && last.Primary != nil {
+		section.Overall = &report.ScoreChange{
+			BaselineScore: baseline.Primary.Value,
+			CurrentScore:  last.Primary.Value,
+			Delta:         roundDelta(last.Primary.Value - baseline.Primary.Value),
+			Change: report.ClassifyChange(
+				report.ChangeMeasure{Score: baseline.Primary.Value, Max: baseline.Primary.Max, Severity: levelSeverity(baseline.Level)},
+				report.ChangeMeasure{Score: last.Primary.Value, Max: last.Primary.Max, Severity: levelSeverity(last.Level)},
+				direction,
+			),
+		}
+	}
+
+	content := report.Content{
+		Model:        input.Model,
+		PrimaryScore: last.Primary,
+		Level:        last.Level,
+		Conclusion:   latest.Conclusion,
+		Suggestions:  latest.Suggestions,
+		Longitudinal: section,
+	}
+	if input.PresentationProfile != nil {
+		copy := *input.PresentationProfile
+		content.PresentationProfile = &copy
+	}
+	return report.NewDraft(content), nil
+}
+
+func pointInput(input interpinput.InterpretationInput, point interpinput.LongitudinalPoint) interpinput.InterpretationInput {
+	return interpinput.InterpretationInput{
+		OutcomeID:           point.OutcomeID,
+		Association:         report.Association{OrgID: input.Association.OrgID, AssessmentID: point.AssessmentID, TesteeID: input.Association.TesteeID},
+		Model:               input.Model,
+		Runtime:             input.Runtime,
+		Result:              interpinput.ResultFacts{Primary: point.Primary, Level: point.Level},
+		PresentationProfile: input.PresentationProfile,
+		FactorScoring:       point.Scoring,
+	}
+}
+
+func totalFactorCodes(point interpinput.LongitudinalPoint) map[string]bool {
+	totals := make(map[string]bool)
+	if point.Scoring == nil {
+		return totals
+	}
+	for _, factor := range point.Scoring.Factors {
+		if factor.IsTotalScore {
+			totals[factor.FactorCode] = true
+		}
+	}
+	return totals
+}
+
+// factorTracker keeps factors in the order of the first point that reports
+// them; that first observation is the factor's baseline.
+type factorTracker struct {
+	direction    report.ScoreDirection
+	index        map[string]int
+	trajectories []report.FactorTrajectory
+}
+
+func newFactorTracker(direction report.ScoreDirection) *factorTracker {
+	return &factorTracker{direction: direction, index: make(map[string]int)}
+}
+
+func (t *factorTracker) observe(seq int, dimensions []report.DimensionInterpret, totals map[string]bool) {
+	for _, dimension := range dimensions {
+		code := dimension.Code().String()
+		observation := report.FactorObservation{Seq: seq, Score: dimension.RawScore(), RiskLevel: report.RiskLevel(dimension.Severity())}
+		i, seen := t.index[code]
+		if !seen {
+			t.index[code] = len(t.trajectories)
+			t.trajectories = append(t.trajectories, report.FactorTrajectory{
+				FactorCode: code, FactorName: dimension.Name(), IsTotalScore: totals[code],
+				MaxScore: dimension.MaxScore(), Baseline: observation,
+			})
+			continue
+		}
+		trajectory := &t.trajectories[i]
+		change := report.ClassifyChange(
+			report.ChangeMeasure{Score: trajectory.Baseline.Score, Max: trajectory.MaxScore, Severity: string(trajectory.Baseline.RiskLevel)},
+			report.ChangeMeasure{Score: observation.Score, Max: dimension.MaxScore(), Severity: string(observation.RiskLevel)},
+			t.direction,
+		)
+		trajectory.FollowUps = append(trajectory.FollowUps, report.FactorFollowUp{
+			FactorObservation: observation, Delta: roundDelta(observation.Score - trajectory.Baseline.Score), Change: change,
+		})
+		trajectory.Change = change
+	}
+}
+
+func levelSeverity(level *report.ResultLevel) string {
+	if level == nil {
+		return ""
+	}
+	return level.Severity
+}
+
+func roundDelta(value float64) float64 {
+	return math.Round(value*100) / 100
+}
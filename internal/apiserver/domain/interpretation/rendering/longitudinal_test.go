@@ -0,0 +1,131 @@
+package rendering_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/builder"
+	interpinput "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/input"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/rendering"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	reportscore "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/scoring"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func longitudinalPoint(seq int, total float64, totalRisk report.RiskLevel, severity string, mood float64, moodRisk report.RiskLevel, sleep float64, conclusion string, model *reportscore.ReportModel) interpinput.LongitudinalPoint {
+	totalMax := 27.0
+	return interpinput.LongitudinalPoint{
+		Seq:          seq,
+		OutcomeID:    meta.FromUint64(uint64(900 + seq)),
+		AssessmentID: meta.FromUint64(uint64(500 + seq)),
+		OccurredAt:   time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, 42*(seq-1)),
+		Primary:      report.NewRawTotalScore(total, &totalMax),
+		Level:        &report.ResultLevel{Code: string(totalRisk), Label: string(totalRisk), Severity: severity},
+		Scoring: &interpinput.FactorScoringFacts{
+			Model: model,
+			Factors: []reportscore.FactorReportScore{
+				{FactorCode: "TOTAL", RawScore: total, RiskLevel: totalRisk, IsTotalScore: true, Conclusion: conclusion, Suggestion: "按计划复评"},
+				{FactorCode: "mood", RawScore: mood, RiskLevel: moodRisk, Conclusion: "情绪"},
+				{FactorCode: "sleep", RawScore: sleep, Conclusion: "睡眠"},
+				{FactorCode: "hidden", RawScore: 3, Conclusion: "隐藏"},
+			},
+		},
+	}
+}
+
+func TestLongitudinalBuilderComparesBaselineWithEachFollowUp(t *testing.T) {
+	t.Parallel()
+
+	totalMax, factorMax := 27.0, 9.0
+	model := &reportscore.ReportModel{Code: "PHQ-9", Title: "患者健康问卷", Factors: []reportscore.FactorReportModel{
+		{Code: "TOTAL", Title: "总分", MaxScore: &totalMax, IsTotalScore: true},
+		{Code: "mood", Title: "情绪", MaxScore: &factorMax},
+		{Code: "sleep", Title: "睡眠", MaxScore: &factorMax},
+		{Code: "hidden", Title: "隐藏因子", MaxScore: &factorMax},
+	}}
+	profile := report.NewFrozenPresentationProfile([]string{"TOTAL", "mood", "sleep"})
+	registry, err := rendering.NewDefaultRegistry(builder.NewDefaultReportBuilder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportBuilder, err := registry.ResolveByMechanism(rendering.Key{
+		DecisionKind: modelcatalog.DecisionKindScoreRange, ReportType: policy.ReportTypeLongitudinal, TemplateVersion: policy.TemplateVersionCurrent,
+	})
+	if err != nil {
+		t.Fatalf("resolve longitudinal builder: %v", err)
+	}
+	if reportBuilder.BuilderIdentity() != report.BuilderIdentityLongitudinal {
+		t.Fatalf("builder identity = %s", reportBuilder.BuilderIdentity())
+	}
+
+	draft, err := reportBuilder.Build(context.Background(), interpinput.InterpretationInput{
+		Association:         report.Association{OrgID: 7, TesteeID: 401},
+		Model:               report.ModelIdentity{Kind: "scale", Code: "PHQ-9", Version: "1.0.0", Title: "患者健康问卷"},
+		Runtime:             interpinput.RuntimeIdentity{DecisionKind: modelcatalog.DecisionKindScoreRange},
+		PresentationProfile: &profile,
+		Longitudinal: &interpinput.LongitudinalFacts{
+			EnrollmentID: meta.FromUint64(77), PlanID: meta.FromUint64(88), Round: 1,
+			PlannedTasks: 4, CompletedTasks: 3, ExpiredTasks: 1,
+			// 乱序传入，构建器按任务序号排列。
+			Points: []interpinput.LongitudinalPoint{
+				longitudinalPoint(3, 11, report.RiskLevelMedium, "medium", 2, report.RiskLevelLow, 6, "轻中度抑郁症状", model),
+				longitudinalPoint(1, 18, report.RiskLevelHigh, "high", 7, report.RiskLevelHigh, 4, "中重度抑郁症状", model),
+				longitudinalPoint(2, 12, report.RiskLevelMedium, "medium", 5, report.RiskLevelMedium, 4.5, "中度抑郁症状", model),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	content := draft.Content()
+	if err := report.BuilderSpecificDraftContract(report.BuilderIdentityLongitudinal, content); err != nil {
+		t.Fatal(err)
+	}
+	if content.Conclusion != "轻中度抑郁症状" || content.PrimaryScore == nil || content.PrimaryScore.Value != 11 {
+		t.Fatalf("latest summary = %q / %#v", content.Conclusion, content.PrimaryScore)
+	}
+	section := content.Longitudinal
+	if section == nil || section.EnrollmentID != "77" || len(section.Timepoints) != 3 || section.Timepoints[0].Seq != 1 {
+		t.Fatalf("section = %#v", section)
+	}
+	if section.Adherence.Rate != 0.75 {
+		t.Fatalf("adherence = %#v", section.Adherence)
+	}
+	if section.Overall == nil || section.Overall.Delta != -7 || section.Overall.Change != report.ChangeImproved {
+		t.Fatalf("overall = %#v", section.Overall)
+	}
+	byCode := map[string]report.FactorTrajectory{}
+	for _, factor := range section.Factors {
+		byCode[factor.FactorCode] = factor
+	}
+	if _, ok := byCode["hidden"]; ok || len(section.Factors) != 3 {
+		t.Fatalf("factors = %#v, want hidden factor omitted", section.Factors)
+	}
+	total := byCode["TOTAL"]
+	if !total.IsTotalScore || total.FactorName != "总分" || total.MaxScore == nil || *total.MaxScore != 27 {
+		t.Fatalf("total trajectory = %#v", total)
+	}
+	mood := byCode["mood"]
+	if len(mood.FollowUps) != 2 || mood.FollowUps[0].Change != report.ChangeImproved || mood.FollowUps[1].Delta != -5 || mood.Change != report.ChangeImproved {
+		t.Fatalf("mood trajectory = %#v", mood)
+	}
+	sleep := byCode["sleep"]
+	if sleep.FollowUps[0].Change != report.ChangeStable || sleep.FollowUps[1].Change != report.ChangeWorsened || sleep.Change != report.ChangeWorsened {
+		t.Fatalf("sleep trajectory = %#v", sleep)
+	}
+}
+
+func TestLongitudinalBuilderRequiresEnrollmentFacts(t *testing.T) {
+	t.Parallel()
+
+	reportBuilder := rendering.NewLongitudinalBuilder(builder.NewDefaultReportBuilder())
+	if _, err := reportBuilder.Build(context.Background(), interpinput.InterpretationInput{}); err == nil {
+		t.Fatal("expected missing longitudinal facts to fail")
+	}
+	if _, err := rendering.NewRegistry(rendering.DefaultBuilders(nil)...); err != nil {
+		t.Fatalf("default registry with longitudinal builder: %v", err)
+	}
+}
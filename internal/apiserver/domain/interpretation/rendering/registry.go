@@ -20,12 +20,16 @@ type Builder interface {
 	Build(ctx context.Context, input interpinput.InterpretationInput) (*report.Draft, error)
 }
 
+// Key identifies one rendering mechanism. Audience is empty for the canonical
+// report; audience variants are registered under their own keys and never
+// satisfy a lookup for another audience.
 type Key struct {
 	DecisionKind    modelcatalog.DecisionKind
 	ReportType      policy.ReportType
 	TemplateVersion policy.TemplateVersion
 	Algorithm       modelcatalog.Algorithm
 	ReportProfile   policy.ReportProfile
+	Audience        policy.ReportAudience
 }
 
 func (k Key) String() string {
@@ -36,6 +40,9 @@ func (k Key) String() string {
 	if k.ReportProfile != "" {
 		base += "/" + string(k.ReportProfile)
 	}
+	if !k.Audience.IsCanonical() {
+		base += "@" + string(k.Audience)
+	}
 	return base
 }
 
@@ -146,6 +153,7 @@ type RoutingContext struct {
 	TemplateVersion policy.TemplateVersion
 	Algorithm       modelcatalog.Algorithm
 	ReportProfile   policy.ReportProfile
+	Audience        policy.ReportAudience
 }
 
 func RoutingContextFromInput(input interpinput.InterpretationInput) (RoutingContext, bool) {
@@ -189,6 +197,7 @@ func fallbackCandidates(key Key) []Key {
 	seen := make(map[Key]struct{}, len(base))
 	for _, candidate := range base {
 		candidate.TemplateVersion = key.TemplateVersion
+		candidate.Audience = key.Audience
 		if _, exists := seen[candidate]; exists {
 			continue
 		}
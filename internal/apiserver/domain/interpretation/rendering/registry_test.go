@@ -124,3 +124,25 @@ func TestRegistryRejectsIncompleteRuntimeRoute(t *testing.T) {
 		t.Fatal("incomplete runtime input produced a routing context")
 	}
 }
+
+func TestRegistryResolvesAudienceVariantsWithoutCrossAudienceFallback(t *testing.T) {
+	key := registryKey(modelcatalog.DecisionKindPoleComposition)
+	canonical := registryBuilder{key: key, keys: []Key{key}, name: "canonical"}
+	registry, err := NewRegistry(canonical, AudienceVariant(canonical, policy.ReportAudienceFamily))
+	if err != nil {
+		t.Fatal(err)
+	}
+	family := key
+	family.Audience = policy.ReportAudienceFamily
+	if _, err := registry.ResolveByMechanism(family); err != nil {
+		t.Fatalf("family resolve: %v", err)
+	}
+	school := key
+	school.Audience = policy.ReportAudienceSchool
+	if _, err := registry.ResolveByMechanism(school); err == nil {
+		t.Fatal("unpublished audience must not resolve to another builder")
+	}
+	if got := family.String(); got != key.String()+"@family" {
+		t.Fatalf("family key = %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
 	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
 )
@@ -43,9 +44,13 @@ func BuiltinReleaseManifests() ([]domainreporttemplate.ReleaseManifest, error) {
 		templateID   string
 		adapterKey   string
 		decisionKind []modelcatalog.DecisionKind
+		audiences    []policy.ReportAudience
+		locales      []string
 	}
 	specs := []manifestSpec{
-		{templateID: "standard", decisionKind: []modelcatalog.DecisionKind{
+		{templateID: "standard", audiences: []policy.ReportAudience{
+			policy.ReportAudienceClinician, policy.ReportAudienceFamily, policy.ReportAudienceSchool,
+		}, locales: []string{"en-US"}, decisionKind: []modelcatalog.DecisionKind{
 			modelcatalog.DecisionKindScoreRange,
 			modelcatalog.DecisionKindNormLookup,
 			modelcatalog.DecisionKindAbilityLevel,
@@ -68,7 +73,10 @@ func BuiltinReleaseManifests() ([]domainreporttemplate.ReleaseManifest, error) {
 		}},
 	}
 
-	versions := []policy.TemplateVersion{policy.TemplateVersionV1, policy.TemplateVersionCurrent}
+	// Audience variants are published only from 2026-10-v1 and content locales
+	// only from 2026-11-v1; earlier releases are frozen with their original
+	// fingerprints and serve the canonical report in the default locale.
+	versions := []policy.TemplateVersion{policy.TemplateVersionV1, policy.TemplateVersionCurrent, policy.TemplateVersionAudienceVariants, policy.TemplateVersionLocalized}
 	manifests := make([]domainreporttemplate.ReleaseManifest, 0, len(specs)*len(versions))
 	for _, version := range versions {
 		for _, spec := range specs {
@@ -82,8 +90,61 @@ func BuiltinReleaseManifests() ([]domainreporttemplate.ReleaseManifest, error) {
 			if err != nil {
 				return nil, fmt.Errorf("build report template manifest %s@%s: %w", spec.templateID, version, err)
 			}
+			publishesAudiences := version == policy.TemplateVersionAudienceVariants || version == policy.TemplateVersionLocalized
+			if publishesAudiences && len(spec.audiences) > 0 {
+				if err := audienceRoutes(registry, manifest, spec.audiences); err != nil {
+					return nil, fmt.Errorf("resolve report template manifest %s@%s: %w", spec.templateID, version, err)
+				}
+				if manifest, err = manifest.WithAudiences(spec.audiences...); err != nil {
+					return nil, fmt.Errorf("build report template manifest %s@%s: %w", spec.templateID, version, err)
+				}
+			}
+			if version == policy.TemplateVersionLocalized && len(spec.locales) > 0 {
+				if err := audienceLocales(manifest, spec.locales); err != nil {
+					return nil, fmt.Errorf("resolve report template manifest %s@%s: %w", spec.templateID, version, err)
+				}
+				if manifest, err = manifest.WithLocales(spec.locales...); err != nil {
+					return nil, fmt.Errorf("build report template manifest %s@%s: %w", spec.templateID, version, err)
+				}
+			}
 			manifests = append(manifests, manifest)
 		}
 	}
 	return manifests, nil
 }
+
+// audienceRoutes verifies that every published audience resolves to a variant
+// of the same builder for every route, so a release never names an audience the
+// binary cannot render.
+func audienceRoutes(registry Registry, manifest domainreporttemplate.ReleaseManifest, audiences []policy.ReportAudience) error {
+	for _, route := range manifest.Routes {
+		for _, audience := range audiences {
+			builder, err := registry.ResolveByMechanism(Key{
+				DecisionKind: route.DecisionKind, ReportType: manifest.ReportType,
+				TemplateVersion: manifest.TemplateVersion, Audience: audience,
+			})
+			if err != nil {
+				return err
+			}
+			if builder.BuilderIdentity() != route.BuilderIdentity || builder.ContentSchemaVersion() != route.ContentSchemaVersion {
+				return fmt.Errorf("%s variant of %s does not match route builder", audience, route.DecisionKind)
+			}
+		}
+	}
+	return nil
+}
+
+// audienceLocales verifies that audience variants have plain-language copy for
+// every published locale; model copy itself falls back per key, so only the
+// builder-owned audience phrasing can make a locale unrenderable.
+func audienceLocales(manifest domainreporttemplate.ReleaseManifest, locales []string) error {
+	if len(manifest.Audiences) == 0 {
+		return nil
+	}
+	for _, tag := range locales {
+		if !report.SupportsAudienceLocale(tag) {
+			return fmt.Errorf("audience variants have no %s copy", tag)
+		}
+	}
+	return nil
+}
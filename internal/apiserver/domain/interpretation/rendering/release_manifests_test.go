@@ -14,7 +14,12 @@ func TestBuiltinReleaseManifestsMatchRegisteredBuilders(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	wantIDs := []string{"standard", "mbti", "sbti", "bigfive", "enneagram", "standard", "mbti", "sbti", "bigfive", "enneagram"}
+	wantIDs := []string{
+		"standard", "mbti", "sbti", "bigfive", "enneagram",
+		"standard", "mbti", "sbti", "bigfive", "enneagram",
+		"standard", "mbti", "sbti", "bigfive", "enneagram",
+		"standard", "mbti", "sbti", "bigfive", "enneagram",
+	}
 	wantFingerprints := map[string]string{
 		"legacy-v1/standard":   "c5d758a0901ed1e0c77aec5aa6606dd47b12a98e914e619fb41f1271f571fa76",
 		"legacy-v1/mbti":       "38976e0b0c2a6d9b4ddb5250a9411011c294bc497e17f171ebe87db8a66349fb",
@@ -26,6 +31,16 @@ func TestBuiltinReleaseManifestsMatchRegisteredBuilders(t *testing.T) {
 		"2026-08-v1/sbti":      "d9d4ed92fcd6bfcd7cc9f3c11145627232bd73a3a6e7b0a4f6a1fbd9b1ee9d54",
 		"2026-08-v1/bigfive":   "6b893f75f2a90c853da9493e0d7e75c8acd253c7cbb95ad829308766374e229a",
 		"2026-08-v1/enneagram": "b490c2a8317c674b45468be5bb4ea109c4b58f8bce0eb1cc1d3050526fa134d4",
+		"2026-10-v1/standard":  "0b7ba50fb344ad47bc1ce3fa0da62aef39602df45c577ac989d4ccff002b0d4e",
+		"2026-10-v1/mbti":      "8e1f45b4105b8af3fb4241f07f77d9d50892193856bfc226c91d24782a78b805",
+		"2026-10-v1/sbti":      "bfaba30d67f3738b77e5f8f0684c64c128997707dfd858d12c51ee7c8b485f2b",
+		"2026-10-v1/bigfive":   "cdb345d4fc2d5db01620162adcd751be68c1b450ff40ec4d4e23067c8c56ad94",
+		"2026-10-v1/enneagram": "a83a130326dceabc317b7efc495e1a7e527c760e8e97df30c806f32677e02c7e",
+		"2026-11-v1/standard":  "5dfd3b149a95df04f5340d53ecf69bb1c623651c734660b16175c0cf7e97406f",
+		"2026-11-v1/mbti":      "a6f09e8b6a732ee164a1d38ac9150aacfd3a5e834be9d45bd37ea149835685a5",
+		"2026-11-v1/sbti":      "2c872dfefdd44654af7ebd6f54934174979118f4cc820c7db5361e71910e1361",
+		"2026-11-v1/bigfive":   "00684e4c2ba6d85f3e3ab3fdf08ab9ae1610e67df560b369221a0a245ecb8691",
+		"2026-11-v1/enneagram": "dddd92f9aad78ce5a16d74e90f662dbed026d14fdec008d40f14bb88e31316e5",
 	}
 	if len(manifests) != len(wantIDs) {
 		t.Fatalf("manifest count = %d, want %d", len(manifests), len(wantIDs))
@@ -61,6 +76,14 @@ func TestBuiltinReleaseManifestsMatchRegisteredBuilders(t *testing.T) {
 			if route.BuilderIdentity != builder.BuilderIdentity() || route.ContentSchemaVersion != builder.ContentSchemaVersion() {
 				t.Fatalf("manifest %s route %s does not match builder", manifest.TemplateID, route.DecisionKind)
 			}
+			for _, audience := range manifest.Audiences {
+				if _, err := registry.ResolveByMechanism(Key{
+					DecisionKind: route.DecisionKind, ReportType: policy.ReportTypeStandard,
+					TemplateVersion: manifest.TemplateVersion, Audience: audience,
+				}); err != nil {
+					t.Fatalf("manifest %s route %s audience %s: %v", manifest.TemplateID, route.DecisionKind, audience, err)
+				}
+			}
 		}
 	}
 }
@@ -92,3 +115,23 @@ func TestBuiltinReleaseManifestsCoverAllRegisteredDecisionKinds(t *testing.T) {
 		}
 	}
 }
+
+func TestBuiltinReleaseManifestsPublishAudiencesAndLocalesOnlyFromTheirReleases(t *testing.T) {
+	t.Parallel()
+
+	manifests, err := BuiltinReleaseManifests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, manifest := range manifests {
+		audienceRelease := manifest.TemplateVersion == policy.TemplateVersionAudienceVariants || manifest.TemplateVersion == policy.TemplateVersionLocalized
+		want := audienceRelease && manifest.TemplateID == "standard"
+		if got := len(manifest.Audiences) > 0; got != want {
+			t.Fatalf("manifest %s@%s audiences = %v", manifest.TemplateID, manifest.TemplateVersion, manifest.Audiences)
+		}
+		wantLocales := manifest.TemplateVersion == policy.TemplateVersionLocalized && manifest.TemplateID == "standard"
+		if got := len(manifest.Locales) > 0; got != wantLocales {
+			t.Fatalf("manifest %s@%s locales = %v", manifest.TemplateID, manifest.TemplateVersion, manifest.Locales)
+		}
+	}
+}
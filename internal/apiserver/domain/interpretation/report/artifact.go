@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
 	"github.com/FangcunMount/qs-server/internal/pkg/meta"
 )
 
@@ -18,6 +19,7 @@ type InterpretReport struct {
 	association          Association
 	reportType           policy.ReportType
 	templateVersion      policy.TemplateVersion
+	locale               string
 	builderIdentity      string
 	contentSchemaVersion string
 	content              Content
@@ -35,6 +37,8 @@ type Content struct {
 	Suggestions         []Suggestion
 	ModelExtra          *ModelExtra
 	PresentationProfile *PresentationProfile
+	// Longitudinal 仅纵向复合报告使用，汇总同一计划参与轮次的基线与各次随访。
+	Longitudinal *LongitudinalSection
 }
 
 // Association is a frozen read-side correlation copied from EvaluationOutcome.
@@ -46,13 +50,15 @@ type Association struct {
 }
 
 type InterpretReportInput struct {
-	ID                   meta.ID
-	GenerationID         meta.ID
-	OutcomeID            meta.ID
-	InterpretationRunID  meta.ID
-	Association          Association
-	ReportType           policy.ReportType
-	TemplateVersion      policy.TemplateVersion
+	ID                  meta.ID
+	GenerationID        meta.ID
+	OutcomeID           meta.ID
+	InterpretationRunID meta.ID
+	Association         Association
+	ReportType          policy.ReportType
+	TemplateVersion     policy.TemplateVersion
+	// Locale 是正文的内容语言；为空视为 locale.Default。
+	Locale               string
 	BuilderIdentity      string
 	ContentSchemaVersion string
 	Content              Content
@@ -100,6 +106,9 @@ func validateInterpretReportInput(input InterpretReportInput) error {
 	if input.GeneratedAt.IsZero() {
 		return fmt.Errorf("report generated at is required")
 	}
+	if tag, err := locale.Normalize(input.Locale); err != nil || tag != input.Locale {
+		return fmt.Errorf("report locale is invalid: %q", input.Locale)
+	}
 	return nil
 }
 
@@ -112,6 +121,7 @@ func buildInterpretReport(input InterpretReportInput) *InterpretReport {
 		association:          input.Association,
 		reportType:           input.ReportType,
 		templateVersion:      input.TemplateVersion,
+		locale:               contentLocale(input.Locale),
 		builderIdentity:      input.BuilderIdentity,
 		contentSchemaVersion: input.ContentSchemaVersion,
 		content:              cloneContent(input.Content),
@@ -133,6 +143,9 @@ func (r *InterpretReport) ReportType() policy.ReportType { return r.reportType }
 
 func (r *InterpretReport) TemplateVersion() policy.TemplateVersion { return r.templateVersion }
 
+// Locale 返回正文的内容语言；语言上线前生成的报告为 locale.Default。
+func (r *InterpretReport) Locale() string { return r.locale }
+
 func (r *InterpretReport) BuilderIdentity() string { return r.builderIdentity }
 
 func (r *InterpretReport) ContentSchemaVersion() string { return r.contentSchemaVersion }
@@ -141,6 +154,13 @@ func (r *InterpretReport) Content() Content { return cloneContent(r.content) }
 
 func (r *InterpretReport) GeneratedAt() time.Time { return r.generatedAt }
 
+func contentLocale(tag string) string {
+	if tag == "" {
+		return locale.Default
+	}
+	return tag
+}
+
 func cloneContent(content Content) Content {
 	cloned := Content{
 		Model:               content.Model,
@@ -148,6 +168,7 @@ func cloneContent(content Content) Content {
 		Dimensions:          cloneDimensions(content.Dimensions),
 		Suggestions:         cloneSuggestions(content.Suggestions),
 		PresentationProfile: clonePresentationProfile(content.PresentationProfile),
+		Longitudinal:        cloneLongitudinalSection(content.Longitudinal),
 	}
 	if content.PrimaryScore != nil {
 		cloned.PrimaryScore = &ScoreValue{Kind: content.PrimaryScore.Kind, Value: content.PrimaryScore.Value, Label: content.PrimaryScore.Label}
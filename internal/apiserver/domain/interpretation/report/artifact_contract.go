@@ -56,6 +56,13 @@ func BuilderSpecificDraftContract(builderIdentity string, content Content) error
 		if content.PrimaryScore == nil && !hasAbilityDimension(content.Dimensions) {
 			return fmt.Errorf("task performance report requires primary score or ability dimensions")
 		}
+	case BuilderIdentityLongitudinal:
+		if content.Longitudinal == nil || len(content.Longitudinal.Timepoints) == 0 {
+			return fmt.Errorf("longitudinal report requires at least one timepoint")
+		}
+		if content.Longitudinal.EnrollmentID == "" {
+			return fmt.Errorf("longitudinal report requires plan enrollment identity")
+		}
 	default:
 		return fmt.Errorf("unsupported builder identity %q", builderIdentity)
 	}
@@ -85,6 +92,9 @@ func isContentEmpty(content Content) bool {
 	if content.ModelExtra != nil && !content.ModelExtra.IsEmpty() {
 		return false
 	}
+	if content.Longitudinal != nil && len(content.Longitudinal.Timepoints) > 0 {
+		return false
+	}
 	return true
 }
 
@@ -14,3 +14,16 @@ type ReportRepository interface {
 	FindByGenerationID(ctx context.Context, generationID meta.ID) (*InterpretReport, error)
 	ListByAssessmentID(ctx context.Context, assessmentID meta.ID) ([]*InterpretReport, error)
 }
+
+// AudienceVariantRepository stores audience variants next to their canonical
+// report. Implementations must enforce one variant per (report, audience).
+type AudienceVariantRepository interface {
+	Insert(ctx context.Context, variants []*AudienceVariant) error
+}
+
+// LocalizedReportRepository stores on-demand renditions of a report in another
+// content locale. Implementations must enforce one rendition per (report,
+// audience, locale) and report a duplicate as ErrInterpretReportAlreadyExists.
+type LocalizedReportRepository interface {
+	Insert(ctx context.Context, localized *LocalizedReport) error
+}
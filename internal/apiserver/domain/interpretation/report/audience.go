@@ -0,0 +1,256 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// AudienceVariant 是同一份 InterpretReport 面向特定受众派生的不可变正文。
+// 它与规范报告在同一事务中提交，共享 generation/run/outcome 溯源，
+// 以 (reportID, audience) 唯一标识；canonical 报告本身不是变体。
+type AudienceVariant struct {
+	reportID             meta.ID
+	audience             policy.ReportAudience
+	generationID         meta.ID
+	outcomeID            meta.ID
+	association          Association
+	reportType           policy.ReportType
+	templateVersion      policy.TemplateVersion
+	locale               string
+	builderIdentity      string
+	contentSchemaVersion string
+	content              Content
+	generatedAt          time.Time
+}
+
+// NewAudienceVariant 以规范报告为溯源派生一个受众变体。
+// 变体正文只受跨机制契约约束：家长/学校版按设计会去掉分数与维度，
+// 不能套用 builder 的最小内容契约。
+func NewAudienceVariant(canonical *InterpretReport, audience policy.ReportAudience, content Content) (*AudienceVariant, error) {
+	if canonical == nil {
+		return nil, fmt.Errorf("audience variant requires a canonical report")
+	}
+	if audience.IsCanonical() || !audience.IsValid() {
+		return nil, fmt.Errorf("audience variant audience is invalid: %q", string(audience))
+	}
+	if err := CrossMechanismArtifactContract(content); err != nil {
+		return nil, fmt.Errorf("%s report variant: %w", audience, err)
+	}
+	return &AudienceVariant{
+		reportID:             canonical.ID(),
+		audience:             audience,
+		generationID:         canonical.GenerationID(),
+		outcomeID:            canonical.OutcomeID(),
+		association:          canonical.Association(),
+		reportType:           canonical.ReportType(),
+		templateVersion:      canonical.TemplateVersion(),
+		locale:               canonical.Locale(),
+		builderIdentity:      canonical.BuilderIdentity(),
+		contentSchemaVersion: canonical.ContentSchemaVersion(),
+		content:              cloneContent(content),
+		generatedAt:          canonical.GeneratedAt(),
+	}, nil
+}
+
+func (v *AudienceVariant) ReportID() meta.ID { return v.reportID }
+
+func (v *AudienceVariant) Audience() policy.ReportAudience { return v.audience }
+
+func (v *AudienceVariant) GenerationID() meta.ID { return v.generationID }
+
+func (v *AudienceVariant) OutcomeID() meta.ID { return v.outcomeID }
+
+func (v *AudienceVariant) Association() Association { return v.association }
+
+func (v *AudienceVariant) ReportType() policy.ReportType { return v.reportType }
+
+func (v *AudienceVariant) TemplateVersion() policy.TemplateVersion { return v.templateVersion }
+
+// Locale 与规范报告一致：变体和规范正文在同一次生成中按同一语言渲染。
+func (v *AudienceVariant) Locale() string { return v.locale }
+
+func (v *AudienceVariant) BuilderIdentity() string { return v.builderIdentity }
+
+func (v *AudienceVariant) ContentSchemaVersion() string { return v.contentSchemaVersion }
+
+func (v *AudienceVariant) Content() Content { return cloneContent(v.content) }
+
+func (v *AudienceVariant) GeneratedAt() time.Time { return v.generatedAt }
+
+// AdaptContentForAudience 把规范正文改写成受众版本：
+//   - clinician：保留全部分数、常模引用与维度，即规范正文；
+//   - family：去掉派生分数（T 分、百分位等）与常模引用，等级与结论改为通俗、不贴标签的表述；
+//   - school：只保留模型、通俗等级与面向学校的结论，不含分数、维度与建议。
+//
+// canonical 原样返回。受众文案使用 locale.Default。
+func AdaptContentForAudience(content Content, audience policy.ReportAudience) (Content, error) {
+	return AdaptContentForAudienceIn(content, audience, locale.Default)
+}
+
+// AdaptContentForAudienceIn 与 AdaptContentForAudience 相同，但通俗等级与受众结论使用指定语言；
+// 没有该语言受众文案时使用 locale.Default，发布前应以 SupportsAudienceLocale 校验。
+func AdaptContentForAudienceIn(content Content, audience policy.ReportAudience, tag string) (Content, error) {
+	content = cloneContent(content)
+	phrases := audienceCopyFor(tag)
+	switch audience {
+	case policy.ReportAudienceCanonical, policy.ReportAudienceClinician:
+		return content, nil
+	case policy.ReportAudienceFamily:
+		return familyContent(content, phrases), nil
+	case policy.ReportAudienceSchool:
+		return schoolContent(content, phrases), nil
+	default:
+		return Content{}, fmt.Errorf("unsupported report audience: %q", string(audience))
+	}
+}
+
+// SupportsAudienceLocale reports whether family/school copy exists for the locale's language.
+func SupportsAudienceLocale(tag string) bool {
+	_, ok := audienceCopies[locale.Language(tag)]
+	return ok
+}
+
+func familyContent(content Content, phrases audienceCopy) Content {
+	severity := contentSeverity(content)
+	if content.PrimaryScore != nil && content.PrimaryScore.Kind != ScoreKindRawTotal {
+		content.PrimaryScore = nil
+	}
+	content.Level = plainLevel(content.Level, phrases)
+	for index := range content.Dimensions {
+		dimension := content.Dimensions[index]
+		dimension.derivedScores = nil
+		dimension.normReference = nil
+		dimension.level = plainLevel(dimension.level, phrases)
+		content.Dimensions[index] = dimension
+	}
+	content.Conclusion = phrases.family[severity]
+	return content
+}
+
+func schoolContent(content Content, phrases audienceCopy) Content {
+	severity := contentSeverity(content)
+	return Content{
+		Model:               content.Model,
+		Level:               plainLevel(content.Level, phrases),
+		Conclusion:          phrases.school[severity],
+		PresentationProfile: content.PresentationProfile,
+	}
+}
+
+// contentSeverity 取报告整体等级的严重度；没有整体等级时按最严重的维度计。
+func contentSeverity(content Content) string {
+	if content.Level != nil {
+		if severity := normalizeSeverity(content.Level.Severity, content.Level.Code); severity != "" {
+			return severity
+		}
+	}
+	worst := "none"
+	for _, dimension := range content.Dimensions {
+		severity := normalizeSeverity(dimension.severity, string(dimension.riskLevel))
+		if severityRank[severity] > severityRank[worst] {
+			worst = severity
+		}
+	}
+	return worst
+}
+
+func normalizeSeverity(values ...string) string {
+	for _, value := range values {
+		if _, ok := severityRank[value]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func plainLevel(level *ResultLevel, phrases audienceCopy) *ResultLevel {
+	if level == nil {
+		return nil
+	}
+	severity := normalizeSeverity(level.Severity, level.Code)
+	label, ok := phrases.plainLevels[severity]
+	if !ok {
+		return level
+	}
+	return &ResultLevel{Code: level.Code, Label: label, Severity: level.Severity}
+}
+
+var severityRank = map[string]int{
+	string(RiskLevelNone):   0,
+	string(RiskLevelLow):    1,
+	string(RiskLevelMedium): 2,
+	string(RiskLevelHigh):   3,
+	string(RiskLevelSevere): 4,
+}
+
+// audienceCopy 是一种语言的受众固定文案，按严重度索引。
+type audienceCopy struct {
+	plainLevels map[string]string
+	family      map[string]string
+	school      map[string]string
+}
+
+// audienceCopies 按语言部分索引；zh 即 locale.Default 的文案。
+var audienceCopies = map[string]audienceCopy{
+	"zh": {plainLevels: plainLevelLabels, family: familyConclusions, school: schoolConclusions},
+	"en": {plainLevels: plainLevelLabelsEN, family: familyConclusionsEN, school: schoolConclusionsEN},
+}
+
+func audienceCopyFor(tag string) audienceCopy {
+	if phrases, ok := audienceCopies[locale.Language(tag)]; ok {
+		return phrases
+	}
+	return audienceCopies[locale.Language(locale.Default)]
+}
+
+var plainLevelLabels = map[string]string{
+	string(RiskLevelNone):   "状态良好",
+	string(RiskLevelLow):    "需要留意",
+	string(RiskLevelMedium): "建议多加关注",
+	string(RiskLevelHigh):   "建议寻求专业支持",
+	string(RiskLevelSevere): "请尽快联系专业人员",
+}
+
+var familyConclusions = map[string]string{
+	string(RiskLevelNone):   "本次测评未发现需要特别关注的情况，请继续保持良好的日常习惯与亲子沟通。",
+	string(RiskLevelLow):    "本次测评显示孩子在部分方面需要留意，日常多一些陪伴和倾听通常就能带来帮助。",
+	string(RiskLevelMedium): "本次测评显示孩子在部分方面需要更多关注，建议与医生或心理老师沟通，一起制定支持方式。",
+	string(RiskLevelHigh):   "本次测评显示孩子目前承受的压力较大，建议尽快与医生或专业人员沟通，获得更有针对性的支持。",
+	string(RiskLevelSevere): "本次测评显示孩子当前很需要专业支持，请尽快联系医生或专业机构；如遇紧急情况，请立即拨打当地急救或心理援助热线。",
+}
+
+var schoolConclusions = map[string]string{
+	string(RiskLevelNone):   "本次测评未提示需要学校额外支持。",
+	string(RiskLevelLow):    "建议在日常学习生活中给予适度关注与鼓励。",
+	string(RiskLevelMedium): "建议班主任或心理老师给予持续关注，并与家长保持沟通。",
+	string(RiskLevelHigh):   "建议学校心理老师主动关注，并配合家长与专业人员提供支持。",
+	string(RiskLevelSevere): "建议学校尽快与家长及专业人员取得联系，共同提供支持。",
+}
+
+var plainLevelLabelsEN = map[string]string{
+	string(RiskLevelNone):   "Doing well",
+	string(RiskLevelLow):    "Worth keeping an eye on",
+	string(RiskLevelMedium): "Deserves more attention",
+	string(RiskLevelHigh):   "Professional support recommended",
+	string(RiskLevelSevere): "Please contact a professional soon",
+}
+
+var familyConclusionsEN = map[string]string{
+	string(RiskLevelNone):   "This assessment found nothing that needs special attention. Keep up your everyday routines and open conversations with your child.",
+	string(RiskLevelLow):    "This assessment suggests a few areas worth watching. A little more time together and listening at home usually helps.",
+	string(RiskLevelMedium): "This assessment suggests some areas need more attention. Consider talking with a doctor or school counselor to plan support together.",
+	string(RiskLevelHigh):   "This assessment suggests your child is under considerable strain. Please talk with a doctor or other professional soon for more targeted support.",
+	string(RiskLevelSevere): "This assessment suggests your child urgently needs professional support. Please contact a doctor or professional service as soon as possible; in an emergency, call your local emergency or crisis line right away.",
+}
+
+var schoolConclusionsEN = map[string]string{
+	string(RiskLevelNone):   "This assessment does not indicate a need for additional school support.",
+	string(RiskLevelLow):    "Moderate attention and encouragement in everyday school life is recommended.",
+	string(RiskLevelMedium): "The class teacher or school counselor should keep an ongoing eye on the student and stay in touch with the family.",
+	string(RiskLevelHigh):   "The school counselor should reach out proactively and work with the family and professionals to provide support.",
+	string(RiskLevelSevere): "The school should contact the family and professionals as soon as possible to provide support together.",
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func audienceTestContent() Content {
+	max := 80.0
+	factor := NewFactorCode("anxiety")
+	level := &ResultLevel{Code: "high", Label: "重度焦虑", Severity: "high"}
+	return Content{
+		Model:        ModelIdentity{Kind: "scale", Code: "SCARED", Version: "v1"},
+		PrimaryScore: &ScoreValue{Kind: ScoreKindTScore, Value: 72},
+		Level:        level,
+		Conclusion:   "焦虑因子 T 分 72，超过常模 2 个标准差。",
+		Dimensions: []DimensionInterpret{
+			NewDimensionInterpret(factor, "焦虑", 30, &max, RiskLevelHigh, "焦虑显著", "干预").WithScoreContext(
+				[]ScoreValue{{Kind: ScoreKindTScore, Value: 72}}, level, &NormReference{ScoreKind: ScoreKindTScore, TableVersion: "2024"},
+			),
+		},
+		Suggestions: []Suggestion{{Category: SuggestionCategoryGeneral, Content: "尽快评估"}},
+	}
+}
+
+func TestAdaptContentForAudienceKeepsClinicianContent(t *testing.T) {
+	content, err := AdaptContentForAudience(audienceTestContent(), policy.ReportAudienceClinician)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.PrimaryScore == nil || content.Dimensions[0].NormReference() == nil || content.Level.Label != "重度焦虑" {
+		t.Fatalf("clinician content = %#v", content)
+	}
+}
+
+func TestAdaptContentForAudienceFamilyDropsDerivedScoresAndClinicalWording(t *testing.T) {
+	content, err := AdaptContentForAudience(audienceTestContent(), policy.ReportAudienceFamily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.PrimaryScore != nil {
+		t.Fatalf("family primary score = %#v, want derived score removed", content.PrimaryScore)
+	}
+	dimension := content.Dimensions[0]
+	if len(dimension.DerivedScores()) != 0 || dimension.NormReference() != nil || dimension.RawScore() != 30 {
+		t.Fatalf("family dimension = %#v", dimension)
+	}
+	if content.Level.Label != plainLevelLabels["high"] || dimension.Level().Label != plainLevelLabels["high"] {
+		t.Fatalf("family level = %#v / %#v", content.Level, dimension.Level())
+	}
+	if content.Conclusion != familyConclusions["high"] || len(content.Suggestions) != 1 {
+		t.Fatalf("family conclusion = %q suggestions=%d", content.Conclusion, len(content.Suggestions))
+	}
+}
+
+func TestAdaptContentForAudienceSchoolKeepsOnlySummary(t *testing.T) {
+	content, err := AdaptContentForAudience(audienceTestContent(), policy.ReportAudienceSchool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.PrimaryScore != nil || len(content.Dimensions) != 0 || len(content.Suggestions) != 0 {
+		t.Fatalf("school content carries scores or details: %#v", content)
+	}
+	if content.Model.Code != "SCARED" || content.Conclusion != schoolConclusions["high"] {
+		t.Fatalf("school content = %#v", content)
+	}
+}
+
+func TestNewAudienceVariantCopiesCanonicalProvenance(t *testing.T) {
+	canonical, err := NewInterpretReport(InterpretReportInput{
+		ID: meta.FromUint64(1), GenerationID: meta.FromUint64(2), OutcomeID: meta.FromUint64(3), InterpretationRunID: meta.FromUint64(4),
+		Association: Association{OrgID: 7, AssessmentID: meta.FromUint64(5), TesteeID: 6},
+		ReportType:  policy.ReportTypeStandard, TemplateVersion: policy.TemplateVersionAudienceVariants,
+		BuilderIdentity: BuilderIdentityFactorScoring, ContentSchemaVersion: ContentSchemaVersionV1,
+		Content: audienceTestContent(), GeneratedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	school, _ := AdaptContentForAudience(canonical.Content(), policy.ReportAudienceSchool)
+	variant, err := NewAudienceVariant(canonical, policy.ReportAudienceSchool, school)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variant.ReportID() != canonical.ID() || variant.GenerationID() != canonical.GenerationID() || variant.Audience() != policy.ReportAudienceSchool {
+		t.Fatalf("variant provenance = %#v", variant)
+	}
+	if _, err := NewAudienceVariant(canonical, policy.ReportAudienceCanonical, school); err == nil {
+		t.Fatal("canonical audience must not be stored as a variant")
+	}
+}
+
+func TestAdaptContentForAudienceInUsesLocaleCopyWithDefaultFallback(t *testing.T) {
+	content, err := AdaptContentForAudienceIn(audienceTestContent(), policy.ReportAudienceFamily, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Level.Label != plainLevelLabelsEN["high"] || content.Conclusion != familyConclusionsEN["high"] {
+		t.Fatalf("en family content = %#v", content)
+	}
+	school, _ := AdaptContentForAudienceIn(audienceTestContent(), policy.ReportAudienceSchool, "fr")
+	if school.Conclusion != schoolConclusions["high"] {
+		t.Fatalf("unsupported locale must fall back to default copy: %q", school.Conclusion)
+	}
+	if !SupportsAudienceLocale("en-GB") || !SupportsAudienceLocale("zh-CN") || SupportsAudienceLocale("fr") {
+		t.Fatal("audience locale support mismatch")
+	}
+}
+
+func TestNewLocalizedReportRequiresAnotherLocale(t *testing.T) {
+	canonical, err := NewInterpretReport(InterpretReportInput{
+		ID: meta.FromUint64(1), GenerationID: meta.FromUint64(2), OutcomeID: meta.FromUint64(3), InterpretationRunID: meta.FromUint64(4),
+		Association: Association{OrgID: 7, AssessmentID: meta.FromUint64(5), TesteeID: 6},
+		ReportType:  policy.ReportTypeStandard, TemplateVersion: policy.TemplateVersionLocalized,
+		BuilderIdentity: BuilderIdentityFactorScoring, ContentSchemaVersion: ContentSchemaVersionV1,
+		Content: audienceTestContent(), GeneratedAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canonical.Locale() != "zh-CN" {
+		t.Fatalf("canonical locale = %q, want default", canonical.Locale())
+	}
+	renderedAt := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	localized, err := NewLocalizedReport(canonical, policy.ReportAudienceCanonical, "en-US", audienceTestContent(), renderedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localized.ReportID() != canonical.ID() || localized.Locale() != "en-US" || localized.TemplateVersion() != canonical.TemplateVersion() {
+		t.Fatalf("localized provenance = %#v", localized)
+	}
+	for _, tag := range []string{"zh-CN", "en_us", ""} {
+		if _, err := NewLocalizedReport(canonical, policy.ReportAudienceCanonical, tag, audienceTestContent(), renderedAt); err == nil {
+			t.Fatalf("locale %q must be rejected", tag)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentChange 是两份报告正文在同一字段上的并排差异；缺失一侧记为空串。
+type ContentChange struct {
+	Path      string
+	Current   string
+	Candidate string
+}
+
+// DiffContent 逐字段比较当前正文与候选正文，按路径排序返回差异。
+// 维度按 code 对齐、建议按顺序对齐，只比较读者可见的字段。
+func DiffContent(current, candidate Content) []ContentChange {
+	currentFields := flattenContent(current)
+	candidateFields := flattenContent(candidate)
+	paths := make(map[string]struct{}, len(currentFields)+len(candidateFields))
+	for path := range currentFields {
+		paths[path] = struct{}{}
+	}
+	for path := range candidateFields {
+		paths[path] = struct{}{}
+	}
+	changes := make([]ContentChange, 0)
+	for path := range paths {
+		if currentFields[path] != candidateFields[path] {
+			changes = append(changes, ContentChange{Path: path, Current: currentFields[path], Candidate: candidateFields[path]})
+		}
+	}
+	sort.Slice(changes, func(left, right int) bool { return changes[left].Path < changes[right].Path })
+	return changes
+}
+
+func flattenContent(content Content) map[string]string {
+	fields := make(map[string]string)
+	put := func(path, value string) {
+		if value != "" {
+			fields[path] = value
+		}
+	}
+	put("model.code", content.Model.Code)
+	put("model.version", content.Model.Version)
+	put("model.title", content.Model.Title)
+	putScore(put, "primary_score", content.PrimaryScore)
+	putLevel(put, "level", content.Level)
+	put("conclusion", content.Conclusion)
+	for _, dimension := range content.Dimensions {
+		prefix := "dimensions[" + dimension.Code().String() + "]"
+		put(prefix+".name", dimension.Name())
+		put(prefix+".raw_score", formatFloat(dimension.RawScore()))
+		if dimension.MaxScore() != nil {
+			put(prefix+".max_score", formatFloat(*dimension.MaxScore()))
+		}
+		put(prefix+".severity", dimension.Severity())
+		putLevel(put, prefix+".level", dimension.Level())
+		for _, score := range dimension.DerivedScores() {
+			put(prefix+".derived_scores["+score.Kind+"]", formatFloat(score.Value))
+		}
+		if reference := dimension.NormReference(); reference != nil {
+			put(prefix+".norm_reference", fmt.Sprintf("%s@%s", reference.ScoreKind, reference.TableVersion))
+		}
+		put(prefix+".description", dimension.Description())
+		put(prefix+".suggestion", dimension.Suggestion())
+	}
+	for index, suggestion := range content.Suggestions {
+		put("suggestions["+strconv.Itoa(index)+"]", strings.TrimSpace(string(suggestion.Category)+": "+suggestion.Content))
+	}
+	if extra := content.ModelExtra; extra != nil {
+		put("model_extra.type_code", extra.TypeCode)
+		put("model_extra.type_name", extra.TypeName)
+		put("model_extra.one_liner", extra.OneLiner)
+		put("model_extra.commentary", extra.Commentary)
+	}
+	return fields
+}
+
+func putScore(put func(string, string), path string, score *ScoreValue) {
+	if score == nil {
+		return
+	}
+	put(path, score.Kind+"="+formatFloat(score.Value))
+}
+
+func putLevel(put func(string, string), path string, level *ResultLevel) {
+	if level == nil {
+		return
+	}
+	put(path+".code", level.Code)
+	put(path+".label", level.Label)
+	put(path+".severity", level.Severity)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,39 @@
+package report
+
+import "testing"
+
+func TestDiffContentAlignsDimensionsByCode(t *testing.T) {
+	max := 20.0
+	current := Content{
+		PrimaryScore: NewRawTotalScore(12, &max),
+		Conclusion:   "轻度焦虑",
+		Dimensions: []DimensionInterpret{
+			NewDimensionInterpret(NewFactorCode("anxiety"), "焦虑", 8, &max, RiskLevelLow, "偏高", ""),
+			NewDimensionInterpret(NewFactorCode("sleep"), "睡眠", 4, &max, RiskLevelNone, "正常", ""),
+		},
+		Suggestions: []Suggestion{{Content: "规律作息"}},
+	}
+	candidate := Content{
+		PrimaryScore: NewRawTotalScore(12, &max),
+		Conclusion:   "存在轻度焦虑倾向",
+		Dimensions: []DimensionInterpret{
+			NewDimensionInterpret(NewFactorCode("sleep"), "睡眠", 4, &max, RiskLevelNone, "正常", ""),
+			NewDimensionInterpret(NewFactorCode("anxiety"), "焦虑", 8, &max, RiskLevelLow, "偏高", "练习放松"),
+		},
+		Suggestions: []Suggestion{{Content: "规律作息"}},
+	}
+
+	changes := DiffContent(current, candidate)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %#v, want conclusion and anxiety suggestion only", changes)
+	}
+	if changes[0].Path != "conclusion" || changes[0].Current != "轻度焦虑" || changes[0].Candidate != "存在轻度焦虑倾向" {
+		t.Fatalf("conclusion change = %#v", changes[0])
+	}
+	if changes[1].Path != "dimensions[anxiety].suggestion" || changes[1].Current != "" || changes[1].Candidate != "练习放松" {
+		t.Fatalf("dimension change = %#v", changes[1])
+	}
+	if len(DiffContent(current, current)) != 0 {
+		t.Fatal("identical content must not produce changes")
+	}
+}
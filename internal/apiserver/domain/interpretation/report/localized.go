@@ -0,0 +1,89 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// LocalizedReport 是一份 InterpretReport 按需以另一种内容语言渲染的不可变正文。
+// 它由同一冻结输入、同一模板版本与 builder 渲染，只是解读资产换成目标语言；
+// 以 (reportID, audience, locale) 唯一标识，audience 为空表示 canonical 正文。
+type LocalizedReport struct {
+	reportID             meta.ID
+	audience             policy.ReportAudience
+	locale               string
+	generationID         meta.ID
+	outcomeID            meta.ID
+	association          Association
+	reportType           policy.ReportType
+	templateVersion      policy.TemplateVersion
+	builderIdentity      string
+	contentSchemaVersion string
+	content              Content
+	renderedAt           time.Time
+}
+
+// NewLocalizedReport 以规范报告为溯源派生一个其他语言的正文。
+// 目标语言必须规范化且不同于规范报告的语言；受众正文与受众变体一样只受跨机制契约约束。
+func NewLocalizedReport(canonical *InterpretReport, audience policy.ReportAudience, tag string, content Content, renderedAt time.Time) (*LocalizedReport, error) {
+	if canonical == nil {
+		return nil, fmt.Errorf("localized report requires a canonical report")
+	}
+	if !audience.IsCanonical() && !audience.IsValid() {
+		return nil, fmt.Errorf("localized report audience is invalid: %q", string(audience))
+	}
+	if normalized, err := locale.Normalize(tag); err != nil || normalized == "" || normalized != tag {
+		return nil, fmt.Errorf("localized report locale is invalid: %q", tag)
+	}
+	if tag == canonical.Locale() {
+		return nil, fmt.Errorf("localized report locale %s is the canonical report locale", tag)
+	}
+	if renderedAt.IsZero() {
+		return nil, fmt.Errorf("localized report rendered at is required")
+	}
+	if err := CrossMechanismArtifactContract(content); err != nil {
+		return nil, fmt.Errorf("%s report: %w", tag, err)
+	}
+	return &LocalizedReport{
+		reportID:             canonical.ID(),
+		audience:             audience,
+		locale:               tag,
+		generationID:         canonical.GenerationID(),
+		outcomeID:            canonical.OutcomeID(),
+		association:          canonical.Association(),
+		reportType:           canonical.ReportType(),
+		templateVersion:      canonical.TemplateVersion(),
+		builderIdentity:      canonical.BuilderIdentity(),
+		contentSchemaVersion: canonical.ContentSchemaVersion(),
+		content:              cloneContent(content),
+		renderedAt:           renderedAt,
+	}, nil
+}
+
+func (r *LocalizedReport) ReportID() meta.ID { return r.reportID }
+
+func (r *LocalizedReport) Audience() policy.ReportAudience { return r.audience }
+
+func (r *LocalizedReport) Locale() string { return r.locale }
+
+func (r *LocalizedReport) GenerationID() meta.ID { return r.generationID }
+
+func (r *LocalizedReport) OutcomeID() meta.ID { return r.outcomeID }
+
+func (r *LocalizedReport) Association() Association { return r.association }
+
+func (r *LocalizedReport) ReportType() policy.ReportType { return r.reportType }
+
+func (r *LocalizedReport) TemplateVersion() policy.TemplateVersion { return r.templateVersion }
+
+func (r *LocalizedReport) BuilderIdentity() string { return r.builderIdentity }
+
+func (r *LocalizedReport) ContentSchemaVersion() string { return r.contentSchemaVersion }
+
+func (r *LocalizedReport) Content() Content { return cloneContent(r.content) }
+
+func (r *LocalizedReport) RenderedAt() time.Time { return r.renderedAt }
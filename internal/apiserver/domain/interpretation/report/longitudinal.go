@@ -0,0 +1,190 @@
+package report
+
+import (
+	"math"
+	"time"
+)
+
+// ChangeClass 随访结果相对基线的变化分类。
+type ChangeClass string
+
+const (
+	ChangeImproved ChangeClass = "improved"
+	ChangeStable   ChangeClass = "stable"
+	ChangeWorsened ChangeClass = "worsened"
+)
+
+// ScoreDirection 分数方向：症状类量表分数越高越严重，能力类测评分数越高越好。
+type ScoreDirection string
+
+const (
+	ScoreDirectionHigherIsWorse  ScoreDirection = "higher_is_worse"
+	ScoreDirectionHigherIsBetter ScoreDirection = "higher_is_better"
+)
+
+// StableChangeRatio 风险等级不变时，分数变化不超过满分（未知满分时为基线分数）的该比例视为稳定。
+const StableChangeRatio = 0.1
+
+// LongitudinalSection 纵向复合报告的专属内容：同一计划参与轮次内按任务序号排列的各次结果。
+// 第一个时间点为基线，其余为随访；所有数值均取自冻结的 EvaluationOutcome。
+type LongitudinalSection struct {
+	EnrollmentID string
+	PlanID       string
+	Round        uint32
+	Direction    ScoreDirection
+	Adherence    LongitudinalAdherence
+	Timepoints   []LongitudinalTimepoint
+	Overall      *ScoreChange
+	Factors      []FactorTrajectory
+}
+
+// LongitudinalAdherence 参与轮次的任务履约情况；Rate 为完成数占未取消任务数的比例。
+type LongitudinalAdherence struct {
+	PlannedTasks   int
+	CompletedTasks int
+	ExpiredTasks   int
+	CanceledTasks  int
+	Rate           float64
+}
+
+// NewLongitudinalAdherence 按任务计数计算履约率；取消的任务不计入分母。
+func NewLongitudinalAdherence(planned, completed, expired, canceled int) LongitudinalAdherence {
+	adherence := LongitudinalAdherence{PlannedTasks: planned, CompletedTasks: completed, ExpiredTasks: expired, CanceledTasks: canceled}
+	if due := planned - canceled; due > 0 {
+		adherence.Rate = math.Round(float64(completed)/float64(due)*10000) / 10000
+	}
+	return adherence
+}
+
+// LongitudinalTimepoint 一次已完成测评在纵向报告中的摘要。
+type LongitudinalTimepoint struct {
+	Seq          int
+	AssessmentID string
+	OutcomeID    string
+	OccurredAt   time.Time
+	PrimaryScore *ScoreValue
+	Level        *ResultLevel
+}
+
+// ScoreChange 基线到某次随访的分数变化。
+type ScoreChange struct {
+	BaselineScore float64
+	CurrentScore  float64
+	Delta         float64
+	Change        ChangeClass
+}
+
+// FactorObservation 某个因子在一个时间点的得分。
+type FactorObservation struct {
+	Seq       int
+	Score     float64
+	RiskLevel RiskLevel
+}
+
+// FactorFollowUp 某个因子在一次随访中的得分及相对基线的变化。
+type FactorFollowUp struct {
+	FactorObservation
+	Delta  float64
+	Change ChangeClass
+}
+
+// FactorTrajectory 某个因子的基线与各次随访；Change 为基线到最近一次随访的变化。
+type FactorTrajectory struct {
+	FactorCode   string
+	FactorName   string
+	IsTotalScore bool
+	MaxScore     *float64
+	Baseline     FactorObservation
+	FollowUps    []FactorFollowUp
+	Change       ChangeClass
+}
+
+// ChangeMeasure 变化分类的一侧输入；Severity 为空表示该时间点没有可比的风险等级。
+type ChangeMeasure struct {
+	Score    float64
+	Max      *float64
+	Severity string
+}
+
+// ClassifyChange 比较基线与随访：两侧风险等级都可比且不同时以等级升降为准，
+// 否则按分数方向判断，变化幅度不超过 StableChangeRatio 视为稳定。
+func ClassifyChange(baseline, current ChangeMeasure, direction ScoreDirection) ChangeClass {
+	baseRank, baseOK := SeverityRank(baseline.Severity)
+	currentRank, currentOK := SeverityRank(current.Severity)
+	if baseOK && currentOK && baseRank != currentRank {
+		if currentRank < baseRank {
+			return ChangeImproved
+		}
+		return ChangeWorsened
+	}
+	delta := current.Score - baseline.Score
+	reference := math.Abs(baseline.Score)
+	if max := current.Max; max != nil && *max > 0 {
+		reference = *max
+	} else if max := baseline.Max; max != nil && *max > 0 {
+		reference = *max
+	}
+	if math.Abs(delta) <= reference*StableChangeRatio {
+		return ChangeStable
+	}
+	if (delta > 0) == (direction == ScoreDirectionHigherIsBetter) {
+		return ChangeImproved
+	}
+	return ChangeWorsened
+}
+
+// SeverityRank 把风险等级或结果严重度映射为可比较的序号。
+func SeverityRank(severity string) (int, bool) {
+	switch severity {
+	case string(RiskLevelNone):
+		return 0, true
+	case string(RiskLevelLow):
+		return 1, true
+	case string(RiskLevelMedium):
+		return 2, true
+	case string(RiskLevelHigh):
+		return 3, true
+	case string(RiskLevelSevere):
+		return 4, true
+	default:
+		return 0, false
+	}
+}
+
+func cloneLongitudinalSection(section *LongitudinalSection) *LongitudinalSection {
+	if section == nil {
+		return nil
+	}
+	cloned := *section
+	if len(section.Timepoints) > 0 {
+		cloned.Timepoints = make([]LongitudinalTimepoint, len(section.Timepoints))
+		for i, point := range section.Timepoints {
+			cloned.Timepoints[i] = point
+			if point.PrimaryScore != nil {
+				score := *point.PrimaryScore
+				if score.Max != nil {
+					max := *score.Max
+					score.Max = &max
+				}
+				cloned.Timepoints[i].PrimaryScore = &score
+			}
+			cloned.Timepoints[i].Level = cloneResultLevel(point.Level)
+		}
+	}
+	if section.Overall != nil {
+		overall := *section.Overall
+		cloned.Overall = &overall
+	}
+	if len(section.Factors) > 0 {
+		cloned.Factors = make([]FactorTrajectory, len(section.Factors))
+		for i, factor := range section.Factors {
+			cloned.Factors[i] = factor
+			if factor.MaxScore != nil {
+				max := *factor.MaxScore
+				cloned.Factors[i].MaxScore = &max
+			}
+			cloned.Factors[i].FollowUps = append([]FactorFollowUp(nil), factor.FollowUps...)
+		}
+	}
+	return &cloned
+}
@@ -0,0 +1,45 @@
+package report
+
+import "testing"
+
+func TestClassifyChangePrefersSeverityThenScoreDirection(t *testing.T) {
+	max := 20.0
+	cases := []struct {
+		name      string
+		baseline  ChangeMeasure
+		current   ChangeMeasure
+		direction ScoreDirection
+		want      ChangeClass
+	}{
+		{"severity drop wins over small score change", ChangeMeasure{Score: 10, Max: &max, Severity: "high"}, ChangeMeasure{Score: 10.5, Max: &max, Severity: "medium"}, ScoreDirectionHigherIsWorse, ChangeImproved},
+		{"within ratio is stable", ChangeMeasure{Score: 10, Max: &max, Severity: "medium"}, ChangeMeasure{Score: 12, Max: &max, Severity: "medium"}, ScoreDirectionHigherIsWorse, ChangeStable},
+		{"symptom score rise worsens", ChangeMeasure{Score: 10, Max: &max}, ChangeMeasure{Score: 15, Max: &max}, ScoreDirectionHigherIsWorse, ChangeWorsened},
+		{"ability score rise improves", ChangeMeasure{Score: 10, Max: &max}, ChangeMeasure{Score: 15, Max: &max}, ScoreDirectionHigherIsBetter, ChangeImproved},
+		{"unknown max falls back to baseline", ChangeMeasure{Score: 40}, ChangeMeasure{Score: 43}, ScoreDirectionHigherIsWorse, ChangeStable},
+	}
+	for _, tc := range cases {
+		if got := ClassifyChange(tc.baseline, tc.current, tc.direction); got != tc.want {
+			t.Errorf("%s: ClassifyChange() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestLongitudinalSectionIsCopiedWithContent(t *testing.T) {
+	max := 9.0
+	draft := NewDraft(Content{Longitudinal: &LongitudinalSection{
+		EnrollmentID: "77",
+		Timepoints:   []LongitudinalTimepoint{{Seq: 1, PrimaryScore: NewRawTotalScore(5, &max)}},
+		Factors:      []FactorTrajectory{{FactorCode: "mood", FollowUps: []FactorFollowUp{{Change: ChangeStable}}}},
+	}})
+	content := draft.Content()
+	*content.Longitudinal.Timepoints[0].PrimaryScore.Max = 27
+	content.Longitudinal.Factors[0].FollowUps[0].Change = ChangeWorsened
+
+	again := draft.Content().Longitudinal
+	if *again.Timepoints[0].PrimaryScore.Max != 9 || again.Factors[0].FollowUps[0].Change != ChangeStable {
+		t.Fatalf("longitudinal section = %#v, want immutable copy", again)
+	}
+	if NewLongitudinalAdherence(6, 4, 1, 1).Rate != 0.8 {
+		t.Fatal("canceled tasks must not count against adherence")
+	}
+}
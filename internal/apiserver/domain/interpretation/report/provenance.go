@@ -5,6 +5,7 @@ const (
 	BuilderIdentityNormProfile     = "norm-profile"
 	BuilderIdentityTypology        = "typology"
 	BuilderIdentityTaskPerformance = "task-performance"
+	BuilderIdentityLongitudinal    = "longitudinal"
 
 	ContentSchemaVersionV1 = "report-content/v1"
 
@@ -0,0 +1,20 @@
+// Package reportpdf 解读报告 PDF 渲染记录：每份报告最多渲染一次，同一测评以最新报告的 PDF 为准。
+package reportpdf
+
+import "time"
+
+// Rendition 一份已渲染并保存的报告 PDF。
+type Rendition struct {
+	ReportID        string
+	OrgID           int64
+	AssessmentID    uint64
+	TesteeID        uint64
+	ObjectKey       string
+	ContentHash     string
+	TemplateVersion string
+	ModelCode       string
+	ModelVersion    string
+	SizeBytes       int64
+	ReportCreatedAt time.Time
+	RenderedAt      time.Time
+}
@@ -0,0 +1,13 @@
+package reportpdf
+
+import "context"
+
+// Repository 渲染记录仓储接口。
+type Repository interface {
+	// Save 按报告 ID 幂等写入；记录已存在时保持原记录。
+	Save(ctx context.Context, rendition *Rendition) error
+	// FindByReportID 不存在时返回 nil, nil。
+	FindByReportID(ctx context.Context, reportID string) (*Rendition, error)
+	// FindLatestByAssessment 返回测评最新一份报告的渲染记录；不存在时返回 nil, nil。
+	FindLatestByAssessment(ctx context.Context, assessmentID uint64) (*Rendition, error)
+}
@@ -0,0 +1,100 @@
+// Package reportshare 解读报告对外分享授权：有时效的分享链接或可附加 PIN 的访问码，
+// 每个授权绑定一份测评的报告与一个受众版本，到期、撤销、浏览次数用尽或 PIN 连续错误后即失效。
+package reportshare
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+)
+
+// MaxPINAttempts PIN 连续错误达到该次数后授权锁定，只能撤销后重新分享。
+const MaxPINAttempts = 5
+
+// Kind 分享方式。
+type Kind string
+
+const (
+	// KindLink 分享链接，令牌即凭证。
+	KindLink Kind = "link"
+	// KindCode 访问码，接收方在公开页面输入。
+	KindCode Kind = "code"
+)
+
+// Status 授权当前状态。
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusExpired   Status = "expired"
+	StatusRevoked   Status = "revoked"
+	StatusExhausted Status = "exhausted"
+	StatusLocked    Status = "locked"
+)
+
+// Outcome 一次访问的结果。
+type Outcome string
+
+const (
+	OutcomeViewed      Outcome = "viewed"
+	OutcomePINRequired Outcome = "pin_required"
+	OutcomePINRejected Outcome = "pin_rejected"
+	OutcomeExpired     Outcome = "expired"
+	OutcomeRevoked     Outcome = "revoked"
+	OutcomeExhausted   Outcome = "exhausted"
+	OutcomeLocked      Outcome = "locked"
+)
+
+// Grant 一条分享授权的存储形态。SecretHash 为链接令牌或规范化访问码的 SHA-256。
+type Grant struct {
+	ID                uint64
+	TesteeID          uint64
+	AssessmentID      uint64
+	Kind              Kind
+	Audience          policy.ReportAudience
+	Label             string
+	SecretHash        string
+	PINSalt           string
+	PINHash           string
+	MaxViews          int
+	ViewCount         int
+	FailedPINAttempts int
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	LastViewedAt      *time.Time
+	RevokedAt         *time.Time
+}
+
+// PINProtected 授权是否要求 PIN。
+func (g *Grant) PINProtected() bool {
+	return g.PINHash != ""
+}
+
+// StatusAt 按时间点判定授权状态；撤销优先于其它失效原因。
+func (g *Grant) StatusAt(now time.Time) Status {
+	switch {
+	case g.RevokedAt != nil:
+		return StatusRevoked
+	case !now.Before(g.ExpiresAt):
+		return StatusExpired
+	case g.ViewCount >= g.MaxViews:
+		return StatusExhausted
+	case g.FailedPINAttempts >= MaxPINAttempts:
+		return StatusLocked
+	default:
+		return StatusActive
+	}
+}
+
+// Access 一条访问记录。
+type Access struct {
+	GrantID      uint64
+	TesteeID     uint64
+	AssessmentID uint64
+	// ReportID 放行时实际展示的报告；拒绝时为 0。
+	ReportID   uint64
+	Outcome    Outcome
+	ClientIP   string
+	UserAgent  string
+	AccessedAt time.Time
+}
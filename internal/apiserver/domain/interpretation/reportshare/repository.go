@@ -0,0 +1,24 @@
+package reportshare
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 分享授权与访问记录仓储接口。
+type Repository interface {
+	Create(ctx context.Context, grant *Grant) error
+	// FindByID 不存在时返回 nil, nil。
+	FindByID(ctx context.Context, id uint64) (*Grant, error)
+	// FindBySecretHash 不存在时返回 nil, nil。
+	FindBySecretHash(ctx context.Context, hash string) (*Grant, error)
+	ListByAssessment(ctx context.Context, testeeID, assessmentID uint64) ([]*Grant, error)
+	CountActive(ctx context.Context, assessmentID uint64, now time.Time) (int, error)
+	Revoke(ctx context.Context, id uint64, at time.Time) error
+	// RecordFailedPIN 累加一次 PIN 错误。
+	RecordFailedPIN(ctx context.Context, id uint64) error
+	// ConsumeView 在授权仍可用时原子地计数一次浏览、清零连续 PIN 错误计数并写入访问记录；
+	// 返回 false 表示授权已在并发访问或撤销中失效，此时不写入任何内容。
+	ConsumeView(ctx context.Context, access *Access) (bool, error)
+	AppendAccess(ctx context.Context, access *Access) error
+}
@@ -7,3 +7,8 @@ var (
 	ErrAlreadyExists = errors.New("report template already exists")
 	ErrConflict      = errors.New("report template state conflict")
 )
+
+var (
+	ErrRolloutNotFound = errors.New("report template rollout not found")
+	ErrRolloutConflict = errors.New("report template rollout state conflict")
+)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
 )
 
 const ManifestSchemaVersion = "interpretation-report-template-manifest/v1"
@@ -29,12 +30,18 @@ type ManifestRoute struct {
 
 // ReleaseManifest is the immutable, self-contained identity of one report
 // template release. Its fingerprint is calculated from the canonical form.
+// Audiences lists the audience variants published next to the canonical report
+// for every route; releases without audiences publish the canonical report only.
+// Locales lists the content locales the release renders besides locale.Default;
+// releases without locales render the default locale only.
 type ReleaseManifest struct {
-	SchemaVersion   string                 `json:"schema_version" bson:"schema_version"`
-	TemplateID      string                 `json:"template_id" bson:"template_id"`
-	TemplateVersion policy.TemplateVersion `json:"template_version" bson:"template_version"`
-	ReportType      policy.ReportType      `json:"report_type" bson:"report_type"`
-	Routes          []ManifestRoute        `json:"routes" bson:"routes"`
+	SchemaVersion   string                  `json:"schema_version" bson:"schema_version"`
+	TemplateID      string                  `json:"template_id" bson:"template_id"`
+	TemplateVersion policy.TemplateVersion  `json:"template_version" bson:"template_version"`
+	ReportType      policy.ReportType       `json:"report_type" bson:"report_type"`
+	Routes          []ManifestRoute         `json:"routes" bson:"routes"`
+	Audiences       []policy.ReportAudience `json:"audiences,omitempty" bson:"audiences,omitempty"`
+	Locales         []string                `json:"locales,omitempty" bson:"locales,omitempty"`
 }
 
 func NewReleaseManifest(
@@ -57,6 +64,32 @@ func NewReleaseManifest(
 	return manifest, nil
 }
 
+// WithAudiences returns a copy of the manifest publishing the given audience
+// variants in canonical order.
+func (m ReleaseManifest) WithAudiences(audiences ...policy.ReportAudience) (ReleaseManifest, error) {
+	manifest := m.Clone()
+	manifest.Audiences = append([]policy.ReportAudience(nil), audiences...)
+	sort.Slice(manifest.Audiences, func(left, right int) bool {
+		return manifest.Audiences[left] < manifest.Audiences[right]
+	})
+	if err := manifest.Validate(); err != nil {
+		return ReleaseManifest{}, err
+	}
+	return manifest, nil
+}
+
+// WithLocales returns a copy of the manifest rendering the given non-default
+// content locales in canonical order.
+func (m ReleaseManifest) WithLocales(locales ...string) (ReleaseManifest, error) {
+	manifest := m.Clone()
+	manifest.Locales = append([]string(nil), locales...)
+	sort.Strings(manifest.Locales)
+	if err := manifest.Validate(); err != nil {
+		return ReleaseManifest{}, err
+	}
+	return manifest, nil
+}
+
 func (m ReleaseManifest) Validate() error {
 	if m.SchemaVersion != ManifestSchemaVersion {
 		return fmt.Errorf("unsupported report template manifest schema: %s", m.SchemaVersion)
@@ -94,6 +127,22 @@ func (m ReleaseManifest) Validate() error {
 			return fmt.Errorf("report template manifest route values must be normalized")
 		}
 	}
+	for index, audience := range m.Audiences {
+		if audience.IsCanonical() || !audience.IsValid() {
+			return fmt.Errorf("report template manifest audience is invalid: %q", string(audience))
+		}
+		if index > 0 && m.Audiences[index-1] >= audience {
+			return fmt.Errorf("report template manifest audiences must be unique and canonically sorted")
+		}
+	}
+	for index, tag := range m.Locales {
+		if normalized, err := locale.Normalize(tag); err != nil || normalized == "" || normalized != tag || tag == locale.Default {
+			return fmt.Errorf("report template manifest locale is invalid: %q", tag)
+		}
+		if index > 0 && m.Locales[index-1] >= tag {
+			return fmt.Errorf("report template manifest locales must be unique and canonically sorted")
+		}
+	}
 	canonical := m
 	canonical.normalizeRoutes()
 	for index := range canonical.Routes {
@@ -125,9 +174,67 @@ func (m ReleaseManifest) RouteFor(decisionKind modelcatalog.DecisionKind) (Manif
 	return ManifestRoute{}, false
 }
 
+// Covers reports whether the release can render every decision the other release
+// renders, so that routing a share of its generations here never strands a
+// mechanism without a builder. Adapters must match for typology routes.
+func (m ReleaseManifest) Covers(other ReleaseManifest) error {
+	if m.TemplateID != other.TemplateID {
+		return fmt.Errorf("report template releases belong to different templates: %s != %s", m.TemplateID, other.TemplateID)
+	}
+	if m.ReportType != other.ReportType {
+		return fmt.Errorf("report template releases produce different report types: %s != %s", m.ReportType, other.ReportType)
+	}
+	for _, route := range other.Routes {
+		candidate, ok := m.RouteFor(route.DecisionKind)
+		if !ok {
+			return fmt.Errorf("report template release %s does not route %s", m.TemplateVersion, route.DecisionKind)
+		}
+		if candidate.AdapterKey != route.AdapterKey {
+			return fmt.Errorf("report template release %s changes the %s adapter", m.TemplateVersion, route.DecisionKind)
+		}
+	}
+	return nil
+}
+
+// PublishesAudience reports whether the release publishes a variant for the
+// audience. The canonical report is always published.
+func (m ReleaseManifest) PublishesAudience(audience policy.ReportAudience) bool {
+	if audience.IsCanonical() {
+		return true
+	}
+	for _, published := range m.Audiences {
+		if published == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentLocales returns locale.Default followed by the published locales.
+func (m ReleaseManifest) ContentLocales() []string {
+	return append([]string{locale.Default}, m.Locales...)
+}
+
+// PublishesLocale reports whether the release renders content in the locale.
+// The default locale is always rendered.
+func (m ReleaseManifest) PublishesLocale(tag string) bool {
+	for _, published := range m.ContentLocales() {
+		if published == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func (m ReleaseManifest) Clone() ReleaseManifest {
 	cloned := m
 	cloned.Routes = append([]ManifestRoute(nil), m.Routes...)
+	if m.Audiences != nil {
+		cloned.Audiences = append([]policy.ReportAudience(nil), m.Audiences...)
+	}
+	if m.Locales != nil {
+		cloned.Locales = append([]string(nil), m.Locales...)
+	}
 	return cloned
 }
 
@@ -83,3 +83,32 @@ func TestReleaseManifestRouteFor(t *testing.T) {
 		t.Fatal("unsupported decision kind must not resolve")
 	}
 }
+
+func TestReleaseManifestLocalesAreCanonicalAndExcludeDefault(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := NewReleaseManifest("standard", policy.TemplateVersionLocalized, policy.ReportTypeStandard, []ManifestRoute{
+		{DecisionKind: modelcatalog.DecisionKindScoreRange, BuilderIdentity: "builder", ContentSchemaVersion: "schema/v1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !manifest.PublishesLocale("zh-CN") || manifest.PublishesLocale("en-US") {
+		t.Fatalf("single-language release locales = %v", manifest.ContentLocales())
+	}
+	localized, err := manifest.WithLocales("ja", "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localized.ContentLocales(); len(got) != 3 || got[0] != "zh-CN" || got[1] != "en-US" || got[2] != "ja" {
+		t.Fatalf("content locales = %v", got)
+	}
+	if !localized.PublishesLocale("en-US") || len(manifest.Locales) != 0 {
+		t.Fatalf("WithLocales must not mutate the receiver: %v", manifest.Locales)
+	}
+	for _, invalid := range [][]string{{"zh-CN"}, {"en_us"}, {"en-US", "en-US"}} {
+		if _, err := manifest.WithLocales(invalid...); err == nil {
+			t.Fatalf("locales %v must be rejected", invalid)
+		}
+	}
+}
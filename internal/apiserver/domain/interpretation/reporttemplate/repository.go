@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
 )
 
 // Repository persists Interpretation-owned report template releases.
@@ -24,3 +25,20 @@ type Catalog interface {
 type ManifestCatalog interface {
 	ResolveManifest(templateID string, version policy.TemplateVersion) (ReleaseManifest, bool)
 }
+
+// RolloutRepository persists staged rollouts. At most one effective (active or
+// promoted) rollout may exist per stable release; Create reports
+// ErrRolloutConflict otherwise, and Save compares the expected version.
+type RolloutRepository interface {
+	Create(ctx context.Context, rollout *Rollout) error
+	Save(ctx context.Context, rollout *Rollout, expectedVersion uint64) error
+	FindByID(ctx context.Context, id meta.ID) (*Rollout, error)
+	FindEffective(ctx context.Context, templateID string, stable policy.TemplateVersion) (*Rollout, error)
+	ListByTemplateID(ctx context.Context, templateID string, limit int) ([]*Rollout, error)
+}
+
+// ShadowComparisonRepository stores at most one comparison per rollout and Outcome.
+type ShadowComparisonRepository interface {
+	Insert(ctx context.Context, comparison *ShadowComparison) error
+	ListByRollout(ctx context.Context, rolloutID meta.ID, orgID int64, limit int) ([]*ShadowComparison, error)
+}
@@ -0,0 +1,290 @@
+package reporttemplate
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// RolloutMode decides what a selected generation does with the candidate release.
+type RolloutMode string
+
+const (
+	// RolloutModeCanary commits the selected generations with the candidate release.
+	RolloutModeCanary RolloutMode = "canary"
+	// RolloutModeShadow keeps committing the stable release and only renders an
+	// unpublished candidate draft next to it for comparison.
+	RolloutModeShadow RolloutMode = "shadow"
+)
+
+func (m RolloutMode) IsValid() bool {
+	return m == RolloutModeCanary || m == RolloutModeShadow
+}
+
+// RolloutStatus is the lifecycle state of one staged rollout.
+type RolloutStatus string
+
+const (
+	RolloutStatusActive     RolloutStatus = "active"
+	RolloutStatusPromoted   RolloutStatus = "promoted"
+	RolloutStatusRolledBack RolloutStatus = "rolled_back"
+)
+
+func (s RolloutStatus) IsValid() bool {
+	switch s {
+	case RolloutStatusActive, RolloutStatusPromoted, RolloutStatusRolledBack:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsEffective reports whether the rollout still routes generations. A promoted
+// rollout keeps routing every generation frozen on the stable release to the
+// candidate until the catalog freezes the candidate itself.
+func (s RolloutStatus) IsEffective() bool {
+	return s == RolloutStatusActive || s == RolloutStatusPromoted
+}
+
+// Rollout stages a candidate template release against the stable release that
+// Outcomes have frozen. Selection is deterministic per Outcome so retries and
+// redeliveries keep the same release; the selection rule itself is immutable,
+// and widening a rollout means closing it and starting another one.
+type Rollout struct {
+	id               meta.ID
+	templateID       string
+	stableVersion    policy.TemplateVersion
+	candidateVersion policy.TemplateVersion
+	mode             RolloutMode
+	percent          int
+	orgIDs           []int64
+	status           RolloutStatus
+	version          uint64
+	reason           string
+	startedAt        time.Time
+	startedBy        string
+	closedAt         *time.Time
+	closedBy         string
+	closeReason      string
+}
+
+// StartRolloutInput constructs an active rollout.
+type StartRolloutInput struct {
+	ID               meta.ID
+	TemplateID       string
+	StableVersion    policy.TemplateVersion
+	CandidateVersion policy.TemplateVersion
+	Mode             RolloutMode
+	Percent          int
+	OrgIDs           []int64
+	Reason           string
+	Actor            string
+	At               time.Time
+}
+
+// NewRollout validates and starts a rollout. Canary rollouts select by percent,
+// by organization or both; shadow rollouts sample by percent only.
+func NewRollout(input StartRolloutInput) (*Rollout, error) {
+	if input.ID.IsZero() {
+		return nil, fmt.Errorf("report template rollout id is required")
+	}
+	templateID := strings.TrimSpace(input.TemplateID)
+	if !templateIDPattern.MatchString(templateID) {
+		return nil, fmt.Errorf("report template rollout template_id is invalid")
+	}
+	if input.StableVersion.IsEmpty() || input.CandidateVersion.IsEmpty() {
+		return nil, fmt.Errorf("report template rollout stable and candidate versions are required")
+	}
+	if input.StableVersion == input.CandidateVersion {
+		return nil, fmt.Errorf("report template rollout candidate must differ from the stable release")
+	}
+	if !input.Mode.IsValid() {
+		return nil, fmt.Errorf("report template rollout mode is invalid: %s", input.Mode)
+	}
+	if input.Percent < 0 || input.Percent > 100 {
+		return nil, fmt.Errorf("report template rollout percent must be between 0 and 100")
+	}
+	orgIDs, err := normalizeOrgIDs(input.OrgIDs)
+	if err != nil {
+		return nil, err
+	}
+	switch input.Mode {
+	case RolloutModeCanary:
+		if input.Percent == 0 && len(orgIDs) == 0 {
+			return nil, fmt.Errorf("canary rollout requires a percent or organizations")
+		}
+	case RolloutModeShadow:
+		if input.Percent == 0 || len(orgIDs) > 0 {
+			return nil, fmt.Errorf("shadow rollout samples by percent only")
+		}
+	}
+	actor := strings.TrimSpace(input.Actor)
+	reason := strings.TrimSpace(input.Reason)
+	if actor == "" || reason == "" {
+		return nil, fmt.Errorf("report template rollout actor and reason are required")
+	}
+	if input.At.IsZero() {
+		return nil, fmt.Errorf("report template rollout start time is required")
+	}
+	return &Rollout{
+		id: input.ID, templateID: templateID, stableVersion: input.StableVersion, candidateVersion: input.CandidateVersion,
+		mode: input.Mode, percent: input.Percent, orgIDs: orgIDs, status: RolloutStatusActive, version: 1,
+		reason: reason, startedAt: input.At, startedBy: actor,
+	}, nil
+}
+
+// PersistedRollout is the storage shape for Rollout.
+type PersistedRollout struct {
+	StartRolloutInput
+	Status      RolloutStatus
+	Version     uint64
+	ClosedAt    *time.Time
+	ClosedBy    string
+	CloseReason string
+}
+
+// RehydrateRollout restores a persisted rollout.
+func RehydrateRollout(input PersistedRollout) (*Rollout, error) {
+	rollout, err := NewRollout(input.StartRolloutInput)
+	if err != nil {
+		return nil, err
+	}
+	if !input.Status.IsValid() || input.Version == 0 {
+		return nil, fmt.Errorf("report template rollout status or version is invalid")
+	}
+	closed := input.ClosedAt != nil && !input.ClosedAt.IsZero() && strings.TrimSpace(input.ClosedBy) != ""
+	if closed != (input.Status != RolloutStatusActive) {
+		return nil, fmt.Errorf("report template rollout close audit is invalid")
+	}
+	rollout.status = input.Status
+	rollout.version = input.Version
+	rollout.closedAt = cloneTime(input.ClosedAt)
+	rollout.closedBy = strings.TrimSpace(input.ClosedBy)
+	rollout.closeReason = strings.TrimSpace(input.CloseReason)
+	return rollout, nil
+}
+
+func (r *Rollout) ID() meta.ID                              { return r.id }
+func (r *Rollout) TemplateID() string                       { return r.templateID }
+func (r *Rollout) StableVersion() policy.TemplateVersion    { return r.stableVersion }
+func (r *Rollout) CandidateVersion() policy.TemplateVersion { return r.candidateVersion }
+func (r *Rollout) Mode() RolloutMode                        { return r.mode }
+func (r *Rollout) Percent() int                             { return r.percent }
+func (r *Rollout) OrgIDs() []int64                          { return append([]int64(nil), r.orgIDs...) }
+func (r *Rollout) Status() RolloutStatus                    { return r.status }
+func (r *Rollout) Version() uint64                          { return r.version }
+func (r *Rollout) Reason() string                           { return r.reason }
+func (r *Rollout) StartedAt() time.Time                     { return r.startedAt }
+func (r *Rollout) StartedBy() string                        { return r.startedBy }
+func (r *Rollout) ClosedAt() *time.Time                     { return cloneTime(r.closedAt) }
+func (r *Rollout) ClosedBy() string                         { return r.closedBy }
+func (r *Rollout) CloseReason() string                      { return r.closeReason }
+
+// Selects reports whether one Outcome falls into the rollout sample. Explicit
+// organizations are always selected; the percentage bucket is a stable hash of
+// the rollout and Outcome ids.
+func (r *Rollout) Selects(orgID int64, outcomeID meta.ID) bool {
+	if r == nil {
+		return false
+	}
+	for _, selected := range r.orgIDs {
+		if selected == orgID {
+			return true
+		}
+	}
+	return rolloutBucket(r.id, outcomeID) < r.percent
+}
+
+// RouteVersion returns the release a new generation for the Outcome must commit.
+// Shadow and rolled-back rollouts never change the committed release.
+func (r *Rollout) RouteVersion(orgID int64, outcomeID meta.ID) policy.TemplateVersion {
+	if r == nil {
+		return ""
+	}
+	switch {
+	case r.status == RolloutStatusPromoted:
+		return r.candidateVersion
+	case r.status == RolloutStatusActive && r.mode == RolloutModeCanary && r.Selects(orgID, outcomeID):
+		return r.candidateVersion
+	default:
+		return r.stableVersion
+	}
+}
+
+// ShadowSamples reports whether an active shadow rollout renders a candidate
+// draft for the Outcome.
+func (r *Rollout) ShadowSamples(orgID int64, outcomeID meta.ID) bool {
+	return r != nil && r.status == RolloutStatusActive && r.mode == RolloutModeShadow && r.Selects(orgID, outcomeID)
+}
+
+// Promote closes an active rollout in favour of the candidate release.
+func (r *Rollout) Promote(actor, reason string, at time.Time) error {
+	if r == nil {
+		return fmt.Errorf("report template rollout is required")
+	}
+	if r.status != RolloutStatusActive {
+		return fmt.Errorf("only active report template rollouts can be promoted")
+	}
+	return r.close(RolloutStatusPromoted, actor, reason, at)
+}
+
+// Rollback stops routing to the candidate release. A promoted rollout can be
+// rolled back as well; the candidate release keeps its own lifecycle and is
+// disabled separately if it must not be frozen by the catalog any more.
+func (r *Rollout) Rollback(actor, reason string, at time.Time) error {
+	if r == nil {
+		return fmt.Errorf("report template rollout is required")
+	}
+	if !r.status.IsEffective() {
+		return fmt.Errorf("report template rollout is already rolled back")
+	}
+	return r.close(RolloutStatusRolledBack, actor, reason, at)
+}
+
+func (r *Rollout) close(status RolloutStatus, actor, reason string, at time.Time) error {
+	actor = strings.TrimSpace(actor)
+	reason = strings.TrimSpace(reason)
+	if actor == "" || reason == "" {
+		return fmt.Errorf("report template rollout actor and reason are required")
+	}
+	if at.IsZero() || at.Before(r.startedAt) {
+		return fmt.Errorf("report template rollout close time is invalid")
+	}
+	r.status = status
+	r.version++
+	closedAt := at
+	r.closedAt = &closedAt
+	r.closedBy = actor
+	r.closeReason = reason
+	return nil
+}
+
+func rolloutBucket(rolloutID, outcomeID meta.ID) int {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprintf(hash, "%s|%s", rolloutID.String(), outcomeID.String())
+	return int(hash.Sum32() % 100)
+}
+
+func normalizeOrgIDs(values []int64) ([]int64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	orgIDs := append([]int64(nil), values...)
+	sort.Slice(orgIDs, func(left, right int) bool { return orgIDs[left] < orgIDs[right] })
+	normalized := make([]int64, 0, len(orgIDs))
+	for _, orgID := range orgIDs {
+		if orgID <= 0 {
+			return nil, fmt.Errorf("report template rollout organization id is invalid: %d", orgID)
+		}
+		if len(normalized) > 0 && normalized[len(normalized)-1] == orgID {
+			continue
+		}
+		normalized = append(normalized, orgID)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,106 @@
+package reporttemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func TestRolloutCanaryRoutesSelectedOrganizationsAndStaysDeterministic(t *testing.T) {
+	t.Parallel()
+
+	rollout, err := NewRollout(StartRolloutInput{
+		ID: meta.FromUint64(1), TemplateID: "mbti", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: RolloutModeCanary, Percent: 10, OrgIDs: []int64{7, 3, 7}, Reason: "pilot", Actor: "user:1",
+		At: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rollout.OrgIDs(); len(got) != 2 || got[0] != 3 || got[1] != 7 {
+		t.Fatalf("org ids = %v, want sorted and deduplicated", got)
+	}
+	if version := rollout.RouteVersion(3, meta.FromUint64(100)); version != "custom-v2" {
+		t.Fatalf("listed org routed to %s", version)
+	}
+	selected := 0
+	for outcome := uint64(1); outcome <= 1000; outcome++ {
+		first := rollout.RouteVersion(99, meta.FromUint64(outcome))
+		if first != rollout.RouteVersion(99, meta.FromUint64(outcome)) {
+			t.Fatalf("outcome %d routing is not deterministic", outcome)
+		}
+		if first == "custom-v2" {
+			selected++
+		}
+	}
+	if selected < 50 || selected > 150 {
+		t.Fatalf("10%% canary selected %d of 1000 outcomes", selected)
+	}
+	if rollout.ShadowSamples(3, meta.FromUint64(100)) {
+		t.Fatal("canary rollout must not produce shadow samples")
+	}
+}
+
+func TestRolloutShadowNeverRoutesUntilPromoted(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	rollout, err := NewRollout(StartRolloutInput{
+		ID: meta.FromUint64(2), TemplateID: "mbti", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Mode: RolloutModeShadow, Percent: 100, Reason: "compare", Actor: "user:1", At: at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcome := meta.FromUint64(5)
+	if rollout.RouteVersion(1, outcome) != policy.TemplateVersionV1 || !rollout.ShadowSamples(1, outcome) {
+		t.Fatal("active shadow rollout must commit stable and sample the candidate")
+	}
+	if err := rollout.Promote("user:2", "diff reviewed", at.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if rollout.Status() != RolloutStatusPromoted || rollout.Version() != 2 || rollout.ClosedBy() != "user:2" {
+		t.Fatalf("promote audit = status %s version %d by %q", rollout.Status(), rollout.Version(), rollout.ClosedBy())
+	}
+	if rollout.RouteVersion(1, outcome) != "custom-v2" || rollout.ShadowSamples(1, outcome) {
+		t.Fatal("promoted rollout must route every generation to the candidate without sampling")
+	}
+	if err := rollout.Promote("user:2", "again", at.Add(2*time.Hour)); err == nil {
+		t.Fatal("promoted rollout cannot be promoted twice")
+	}
+	if err := rollout.Rollback("user:3", "regression", at.Add(3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if rollout.Status().IsEffective() || rollout.RouteVersion(1, outcome) != policy.TemplateVersionV1 {
+		t.Fatal("rolled back rollout must stop routing to the candidate")
+	}
+}
+
+func TestNewRolloutRejectsInvalidSelection(t *testing.T) {
+	t.Parallel()
+
+	base := StartRolloutInput{
+		ID: meta.FromUint64(3), TemplateID: "mbti", StableVersion: policy.TemplateVersionV1, CandidateVersion: "custom-v2",
+		Reason: "pilot", Actor: "user:1", At: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	}
+	cases := map[string]func(*StartRolloutInput){
+		"canary without selection": func(input *StartRolloutInput) { input.Mode = RolloutModeCanary },
+		"shadow with orgs": func(input *StartRolloutInput) {
+			input.Mode, input.Percent, input.OrgIDs = RolloutModeShadow, 5, []int64{1}
+		},
+		"percent above 100": func(input *StartRolloutInput) { input.Mode, input.Percent = RolloutModeCanary, 101 },
+		"same version": func(input *StartRolloutInput) {
+			input.Mode, input.Percent, input.CandidateVersion = RolloutModeCanary, 5, input.StableVersion
+		},
+		"missing reason": func(input *StartRolloutInput) { input.Mode, input.Percent, input.Reason = RolloutModeCanary, 5, " " },
+	}
+	for name, mutate := range cases {
+		input := base
+		mutate(&input)
+		if _, err := NewRollout(input); err == nil {
+			t.Fatalf("%s: want error", name)
+		}
+	}
+}
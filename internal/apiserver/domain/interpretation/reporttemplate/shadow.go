@@ -0,0 +1,115 @@
+package reporttemplate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// ShadowComparison records one unpublished candidate draft rendered by a shadow
+// rollout next to the committed stable report. The candidate content is never
+// visible on any read path; it exists only for side-by-side review.
+type ShadowComparison struct {
+	id                       meta.ID
+	rolloutID                meta.ID
+	outcomeID                meta.ID
+	orgID                    int64
+	testeeID                 uint64
+	reportID                 meta.ID
+	stableVersion            policy.TemplateVersion
+	candidateVersion         policy.TemplateVersion
+	stableBuilderIdentity    string
+	candidateBuilderIdentity string
+	candidate                report.Content
+	changes                  []report.ContentChange
+	createdAt                time.Time
+}
+
+// ShadowComparisonInput constructs a comparison from a committed stable report.
+type ShadowComparisonInput struct {
+	ID                       meta.ID
+	RolloutID                meta.ID
+	Stable                   *report.InterpretReport
+	CandidateVersion         policy.TemplateVersion
+	CandidateBuilderIdentity string
+	Candidate                report.Content
+	CreatedAt                time.Time
+}
+
+// NewShadowComparison diffs the candidate draft against the committed report.
+func NewShadowComparison(input ShadowComparisonInput) (*ShadowComparison, error) {
+	if input.ID.IsZero() || input.RolloutID.IsZero() {
+		return nil, fmt.Errorf("shadow comparison and rollout ids are required")
+	}
+	if input.Stable == nil {
+		return nil, fmt.Errorf("shadow comparison requires the committed stable report")
+	}
+	if input.CandidateVersion.IsEmpty() || input.CandidateVersion == input.Stable.TemplateVersion() {
+		return nil, fmt.Errorf("shadow comparison candidate version is invalid")
+	}
+	if strings.TrimSpace(input.CandidateBuilderIdentity) == "" {
+		return nil, fmt.Errorf("shadow comparison candidate builder identity is required")
+	}
+	if input.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("shadow comparison created_at is required")
+	}
+	stable := input.Stable.Content()
+	return &ShadowComparison{
+		id: input.ID, rolloutID: input.RolloutID, outcomeID: input.Stable.OutcomeID(), orgID: input.Stable.Association().OrgID,
+		testeeID: input.Stable.Association().TesteeID,
+		reportID: input.Stable.ID(), stableVersion: input.Stable.TemplateVersion(), candidateVersion: input.CandidateVersion,
+		stableBuilderIdentity: input.Stable.BuilderIdentity(), candidateBuilderIdentity: input.CandidateBuilderIdentity,
+		candidate: input.Candidate, changes: report.DiffContent(stable, input.Candidate), createdAt: input.CreatedAt,
+	}, nil
+}
+
+// PersistedShadowComparison is the storage shape for ShadowComparison.
+type PersistedShadowComparison struct {
+	ID                       meta.ID
+	RolloutID                meta.ID
+	OutcomeID                meta.ID
+	OrgID                    int64
+	TesteeID                 uint64
+	ReportID                 meta.ID
+	StableVersion            policy.TemplateVersion
+	CandidateVersion         policy.TemplateVersion
+	StableBuilderIdentity    string
+	CandidateBuilderIdentity string
+	Candidate                report.Content
+	Changes                  []report.ContentChange
+	CreatedAt                time.Time
+}
+
+// RehydrateShadowComparison restores a stored comparison without re-diffing.
+func RehydrateShadowComparison(input PersistedShadowComparison) (*ShadowComparison, error) {
+	if input.ID.IsZero() || input.RolloutID.IsZero() || input.OutcomeID.IsZero() || input.ReportID.IsZero() {
+		return nil, fmt.Errorf("shadow comparison identity is incomplete")
+	}
+	return &ShadowComparison{
+		id: input.ID, rolloutID: input.RolloutID, outcomeID: input.OutcomeID, orgID: input.OrgID, testeeID: input.TesteeID, reportID: input.ReportID,
+		stableVersion: input.StableVersion, candidateVersion: input.CandidateVersion,
+		stableBuilderIdentity: input.StableBuilderIdentity, candidateBuilderIdentity: input.CandidateBuilderIdentity,
+		candidate: input.Candidate, changes: append([]report.ContentChange(nil), input.Changes...), createdAt: input.CreatedAt,
+	}, nil
+}
+
+func (c *ShadowComparison) ID() meta.ID                              { return c.id }
+func (c *ShadowComparison) RolloutID() meta.ID                       { return c.rolloutID }
+func (c *ShadowComparison) OutcomeID() meta.ID                       { return c.outcomeID }
+func (c *ShadowComparison) OrgID() int64                             { return c.orgID }
+func (c *ShadowComparison) TesteeID() uint64                         { return c.testeeID }
+func (c *ShadowComparison) ReportID() meta.ID                        { return c.reportID }
+func (c *ShadowComparison) StableVersion() policy.TemplateVersion    { return c.stableVersion }
+func (c *ShadowComparison) CandidateVersion() policy.TemplateVersion { return c.candidateVersion }
+func (c *ShadowComparison) StableBuilderIdentity() string            { return c.stableBuilderIdentity }
+func (c *ShadowComparison) CandidateBuilderIdentity() string         { return c.candidateBuilderIdentity }
+func (c *ShadowComparison) Candidate() report.Content                { return c.candidate }
+func (c *ShadowComparison) Changes() []report.ContentChange {
+	return append([]report.ContentChange(nil), c.changes...)
+}
+func (c *ShadowComparison) CreatedAt() time.Time { return c.createdAt }
+func (c *ShadowComparison) Identical() bool      { return len(c.changes) == 0 }
@@ -0,0 +1,80 @@
+package riskalert
+
+import "time"
+
+// AlertStatus 预警状态。
+type AlertStatus string
+
+const (
+	AlertStatusOpen         AlertStatus = "open"
+	AlertStatusAcknowledged AlertStatus = "acknowledged"
+)
+
+// AlertAction 预警历史中的动作。
+type AlertAction string
+
+const (
+	AlertActionRaised       AlertAction = "raised"
+	AlertActionEscalated    AlertAction = "escalated"
+	AlertActionAcknowledged AlertAction = "acknowledged"
+)
+
+// Actor 确认预警的后台操作者。
+type Actor struct {
+	UserID int64
+	Name   string
+}
+
+// Alert 一次规则命中产生的预警。Channel 与 EscalationLevel 为当前寻呼到的渠道与级数，
+// NextEscalationAt 为空表示已确认或升级链已耗尽。
+type Alert struct {
+	ID                 uint64
+	OrgID              int64
+	RuleID             uint64
+	RuleName           string
+	RuleKind           RuleKind
+	Severity           Severity
+	OutcomeID          uint64
+	AssessmentID       uint64
+	TesteeID           uint64
+	ModelCode          string
+	Status             AlertStatus
+	Channel            string
+	EscalationLevel    int
+	EscalationChannels []string
+	AckTimeout         time.Duration
+	Summary            string
+	ObservedValue      float64
+	BaselineValue      *float64
+	TriggeredAt        time.Time
+	NextEscalationAt   *time.Time
+	AcknowledgedBy     *Actor
+	AcknowledgedAt     *time.Time
+	AckNote            string
+	Version            int
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// AlertEvent 预警历史；Operator 为空表示系统动作（触发、升级）。
+type AlertEvent struct {
+	ID              uint64
+	OrgID           int64
+	AlertID         uint64
+	Action          AlertAction
+	Channel         string
+	EscalationLevel int
+	Operator        *Actor
+	Note            string
+	OccurredAt      time.Time
+}
+
+// AlertFilter 预警列表过滤条件。
+type AlertFilter struct {
+	OrgID    int64
+	Status   AlertStatus
+	TesteeID uint64
+	RuleID   uint64
+	Page     int
+	PageSize int
+}
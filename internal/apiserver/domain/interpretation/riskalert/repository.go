@@ -0,0 +1,32 @@
+package riskalert
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 预警规则、预警与评估进度仓储接口。
+type Repository interface {
+	SaveRule(ctx context.Context, rule *Rule) error
+	// FindRule 不存在或已删除时返回 nil, nil。
+	FindRule(ctx context.Context, orgID int64, ruleID uint64) (*Rule, error)
+	ListRules(ctx context.Context, orgID int64) ([]Rule, error)
+	// DeleteRule 软删除规则；不存在时返回 false。已触发的预警保留。
+	DeleteRule(ctx context.Context, orgID int64, ruleID uint64, at time.Time) (bool, error)
+	ListEnabledRules(ctx context.Context, orgID int64) ([]Rule, error)
+
+	// ListPendingOutcomes 返回 since 之后完成、所在机构配置了启用规则且尚未评估的测评结果。
+	ListPendingOutcomes(ctx context.Context, since time.Time, limit int) ([]OutcomeRef, error)
+	// FindPreviousOutcome 返回同一受试者同一模型在该结果之前最近一次的测评结果；不存在时返回 nil, nil。
+	FindPreviousOutcome(ctx context.Context, outcome OutcomeRef) (*OutcomeRef, error)
+	// RecordScan 写入评估进度与命中的预警及其历史；该结果已评估过时返回 false 且不写入。
+	RecordScan(ctx context.Context, outcome OutcomeRef, alerts []Alert, events []AlertEvent, at time.Time) (bool, error)
+
+	FindAlert(ctx context.Context, orgID int64, alertID uint64) (*Alert, error)
+	ListAlerts(ctx context.Context, filter AlertFilter) ([]Alert, int64, error)
+	ListAlertEvents(ctx context.Context, orgID int64, alertID uint64) ([]AlertEvent, error)
+	// ListDueEscalations 返回 next_escalation_at 不晚于 now 的未确认预警。
+	ListDueEscalations(ctx context.Context, now time.Time, limit int) ([]Alert, error)
+	// SaveAlert 按 expectedVersion 乐观锁保存预警并追加历史；已被并发修改时返回 false。
+	SaveAlert(ctx context.Context, alert *Alert, expectedVersion int, event *AlertEvent) (bool, error)
+}
@@ -0,0 +1,159 @@
+// Package riskalert 风险预警：机构配置的预警规则、规则命中产生的预警及其寻呼、升级与确认历史。
+// 规则只评估创建之后完成的测评；预警按乐观锁版本更新，历史只追加。
+package riskalert
+
+import "time"
+
+// RuleKind 规则类型。
+type RuleKind string
+
+const (
+	// RuleKindItemAnswer 单题作答得分满足条件。
+	RuleKindItemAnswer RuleKind = "item_answer"
+	// RuleKindFactorScore 因子原始分或 T 分满足条件。
+	RuleKindFactorScore RuleKind = "factor_score"
+	// RuleKindScoreChange 因子（或总分）较上次测评的升幅超过阈值。
+	RuleKindScoreChange RuleKind = "score_change"
+	// RuleKindRiskLevel 结果风险等级属于给定集合。
+	RuleKindRiskLevel RuleKind = "risk_level"
+)
+
+// Valid 是否为支持的规则类型。
+func (k RuleKind) Valid() bool {
+	switch k {
+	case RuleKindItemAnswer, RuleKindFactorScore, RuleKindScoreChange, RuleKindRiskLevel:
+		return true
+	default:
+		return false
+	}
+}
+
+// Operator 比较运算符。
+type Operator string
+
+const (
+	OperatorGTE Operator = "gte"
+	OperatorGT  Operator = "gt"
+	OperatorLTE Operator = "lte"
+	OperatorLT  Operator = "lt"
+	OperatorEQ  Operator = "eq"
+)
+
+// Valid 是否为支持的运算符。
+func (o Operator) Valid() bool {
+	switch o {
+	case OperatorGTE, OperatorGT, OperatorLTE, OperatorLT, OperatorEQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// Symbol 运算符在预警摘要中的写法。
+func (o Operator) Symbol() string {
+	switch o {
+	case OperatorGTE:
+		return ">="
+	case OperatorGT:
+		return ">"
+	case OperatorLTE:
+		return "<="
+	case OperatorLT:
+		return "<"
+	default:
+		return "="
+	}
+}
+
+// Compare 判断 value 与 threshold 是否满足运算符。
+func (o Operator) Compare(value, threshold float64) bool {
+	switch o {
+	case OperatorGTE:
+		return value >= threshold
+	case OperatorGT:
+		return value > threshold
+	case OperatorLTE:
+		return value <= threshold
+	case OperatorLT:
+		return value < threshold
+	case OperatorEQ:
+		return value == threshold
+	default:
+		return false
+	}
+}
+
+// Severity 预警级别，随寻呼一起下发给值班渠道。
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+)
+
+// Valid 是否为支持的预警级别。
+func (s Severity) Valid() bool {
+	return s == SeverityCritical || s == SeverityHigh || s == SeverityMedium
+}
+
+// ScoreKind factor_score 规则比较的分数类型，取值与测评结果事实的分数类型一致。
+type ScoreKind string
+
+const (
+	ScoreKindRawTotal ScoreKind = "raw_total"
+	ScoreKindTScore   ScoreKind = "t_score"
+)
+
+// Condition 规则条件，按 Kind 使用不同字段：
+//   - item_answer：QuestionCode、Operator、Threshold；
+//   - factor_score：FactorCode、ScoreKind（raw_total 或 t_score）、Operator、Threshold；
+//   - score_change：FactorCode（空表示主分数）、Threshold 为升幅，升幅大于阈值时命中；
+//   - risk_level：Levels 为风险等级编码集合。
+type Condition struct {
+	QuestionCode string    `json:"question_code,omitempty"`
+	FactorCode   string    `json:"factor_code,omitempty"`
+	ScoreKind    ScoreKind `json:"score_kind,omitempty"`
+	Operator     Operator  `json:"operator,omitempty"`
+	Threshold    float64   `json:"threshold"`
+	Levels       []string  `json:"levels,omitempty"`
+}
+
+// Rule 机构配置的预警规则。ModelCode 为空表示适用于全部测评模型。
+// PageChannel 为首次寻呼渠道，EscalationChannels 为无人确认时依次升级的渠道，
+// 每一级等待 AckTimeout 后升级到下一级。
+type Rule struct {
+	ID                 uint64
+	OrgID              int64
+	Name               string
+	Kind               RuleKind
+	ModelCode          string
+	Condition          Condition
+	Severity           Severity
+	PageChannel        string
+	EscalationChannels []string
+	AckTimeout         time.Duration
+	Enabled            bool
+	CreatedBy          int64
+	UpdatedBy          int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// AppliesTo 规则是否适用于该测评结果：规则已启用、模型匹配，且测评在规则创建之后完成。
+func (r Rule) AppliesTo(outcome OutcomeRef) bool {
+	if !r.Enabled || outcome.EvaluatedAt.Before(r.CreatedAt) {
+		return false
+	}
+	return r.ModelCode == "" || r.ModelCode == outcome.ModelCode
+}
+
+// OutcomeRef 待评估的测评结果索引。
+type OutcomeRef struct {
+	ID           uint64
+	OrgID        int64
+	AssessmentID uint64
+	TesteeID     uint64
+	ModelCode    string
+	EvaluatedAt  time.Time
+}
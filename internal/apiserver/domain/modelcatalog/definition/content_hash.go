@@ -7,18 +7,21 @@ import (
 )
 
 // CanonicalContentHash fingerprints the canonical authoring layers frozen in a
-// published DefinitionV2. Derived DecisionSpec / InterpretationAssets are excluded.
+// published DefinitionV2. Derived DecisionSpec / InterpretationAssets are excluded;
+// authored Translations are included and omitted when empty, so single-language
+// definitions keep their historical hash.
 func CanonicalContentHash(def *Definition) (string, error) {
 	if def == nil {
 		return "", nil
 	}
 	canonical := Definition{
-		Measure:     def.Measure,
-		Calibration: def.Calibration,
-		Execution:   def.Execution,
-		Conclusions: def.Conclusions,
-		Outcomes:    def.Outcomes,
-		ReportMap:   def.ReportMap,
+		Measure:      def.Measure,
+		Calibration:  def.Calibration,
+		Execution:    def.Execution,
+		Conclusions:  def.Conclusions,
+		Outcomes:     def.Outcomes,
+		ReportMap:    def.ReportMap,
+		Translations: def.Translations,
 	}
 	data, err := json.Marshal(canonical)
 	if err != nil {
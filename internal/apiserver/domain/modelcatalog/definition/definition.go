@@ -18,6 +18,8 @@ type Definition struct {
 	ReportMap            ReportMap
 	DecisionSpec         decision.Spec               `json:"DecisionSpec,omitempty"`
 	InterpretationAssets interpretationassets.Assets `json:"InterpretationAssets,omitempty"`
+	// Translations 是结论、因子名与类型画像的非默认语言文案；基础文案以 locale.Default 撰写。
+	Translations []interpretationassets.LocalizedAssets `json:"Translations,omitempty"`
 }
 
 // ExecutionSpec carries algorithm-specific semantics that cannot be expressed
@@ -26,6 +26,7 @@ func (d Definition) MarshalJSON() ([]byte, error) {
 		ReportMap:            d.ReportMap,
 		DecisionSpec:         d.DecisionSpec,
 		InterpretationAssets: d.InterpretationAssets,
+		Translations:         d.Translations,
 	})
 }
 
@@ -50,19 +51,21 @@ func (d *Definition) UnmarshalJSON(data []byte) error {
 		ReportMap:            raw.ReportMap,
 		DecisionSpec:         raw.DecisionSpec,
 		InterpretationAssets: raw.InterpretationAssets,
+		Translations:         raw.Translations,
 	}
 	return nil
 }
 
 type definitionJSON struct {
-	Measure              MeasureSpec                 `json:"Measure"`
-	Calibration          Calibration                 `json:"Calibration"`
-	Execution            ExecutionSpec               `json:"Execution"`
-	Conclusions          []conclusionJSON            `json:"Conclusions"`
-	Outcomes             []conclusion.Outcome        `json:"Outcomes"`
-	ReportMap            ReportMap                   `json:"ReportMap"`
-	DecisionSpec         decision.Spec               `json:"DecisionSpec,omitempty"`
-	InterpretationAssets interpretationassets.Assets `json:"InterpretationAssets,omitempty"`
+	Measure              MeasureSpec                            `json:"Measure"`
+	Calibration          Calibration                            `json:"Calibration"`
+	Execution            ExecutionSpec                          `json:"Execution"`
+	Conclusions          []conclusionJSON                       `json:"Conclusions"`
+	Outcomes             []conclusion.Outcome                   `json:"Outcomes"`
+	ReportMap            ReportMap                              `json:"ReportMap"`
+	DecisionSpec         decision.Spec                          `json:"DecisionSpec,omitempty"`
+	InterpretationAssets interpretationassets.Assets            `json:"InterpretationAssets,omitempty"`
+	Translations         []interpretationassets.LocalizedAssets `json:"Translations,omitempty"`
 }
 
 type conclusionJSON struct {
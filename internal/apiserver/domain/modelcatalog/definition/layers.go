@@ -137,9 +137,34 @@ func InterpretationAssetsFrom(def *Definition) interpretationassets.Assets {
 		}
 	}
 	assets.ReportSpec = reportSpecFrom(def.ReportMap)
+	assets.Translations = cloneTranslations(def.Translations)
 	return assets
 }
 
+func cloneTranslations(items []interpretationassets.LocalizedAssets) []interpretationassets.LocalizedAssets {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]interpretationassets.LocalizedAssets, 0, len(items))
+	for _, item := range items {
+		cloned := interpretationassets.LocalizedAssets{
+			Locale:     item.Locale,
+			ModelTitle: item.ModelTitle,
+			Factors:    append([]interpretationassets.FactorTitle(nil), item.Factors...),
+			Outcomes:   append([]interpretationassets.OutcomePresentation(nil), item.Outcomes...),
+		}
+		for _, profile := range item.Profiles {
+			profile.Traits = append([]string(nil), profile.Traits...)
+			profile.Strengths = append([]string(nil), profile.Strengths...)
+			profile.Weaknesses = append([]string(nil), profile.Weaknesses...)
+			profile.Suggestions = append([]string(nil), profile.Suggestions...)
+			cloned.Profiles = append(cloned.Profiles, profile)
+		}
+		out = append(out, cloned)
+	}
+	return out
+}
+
 // MaterializeLayers projects Conclusions/Outcomes/ReportMap into DecisionSpec and
 // InterpretationAssets fields (MC-R016 batch 3). Authoring/publish should call this
 // before persistence; historical definitions without stored layers still project on read.
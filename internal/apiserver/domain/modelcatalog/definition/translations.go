@@ -0,0 +1,83 @@
+package definition
+
+import (
+	"fmt"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+)
+
+// validateTranslations checks that every translation names a normalized,
+// non-default locale once and only translates copy the base layers define.
+// Coverage gaps are not invariants; see UntranslatedKeys.
+func validateTranslations(def Definition, factorCodes map[string]struct{}) []ValidationIssue {
+	issues := make([]ValidationIssue, 0)
+	if len(def.Translations) == 0 {
+		return issues
+	}
+	base := InterpretationAssetsFrom(&def)
+	outcomeCodes := makeStringSet()
+	for _, item := range base.Outcomes {
+		outcomeCodes[item.OutcomeCode] = struct{}{}
+	}
+	profileCodes := makeStringSet()
+	for _, item := range base.Profiles {
+		profileCodes[item.OutcomeCode] = struct{}{}
+	}
+	seen := makeStringSet()
+	for index, item := range def.Translations {
+		field := fmt.Sprintf("translations[%d]", index)
+		normalized, err := locale.Normalize(item.Locale)
+		switch {
+		case err != nil || normalized == "" || normalized != item.Locale:
+			issues = append(issues, ValidationIssue{Field: field + ".locale", Code: "translation.locale.invalid", Message: fmt.Sprintf("translation locale %q must be a normalized tag such as en-US", item.Locale)})
+			continue
+		case normalized == locale.Default:
+			issues = append(issues, ValidationIssue{Field: field + ".locale", Code: "translation.locale.default", Message: fmt.Sprintf("base copy is authored in %s and cannot be translated into it", locale.Default)})
+			continue
+		}
+		if _, duplicate := seen[item.Locale]; duplicate {
+			issues = append(issues, ValidationIssue{Field: field + ".locale", Code: "translation.locale.duplicate", Message: fmt.Sprintf("translation locale %s is duplicated", item.Locale)})
+		}
+		seen[item.Locale] = struct{}{}
+		field = "translations." + item.Locale
+		for _, factor := range item.Factors {
+			if _, ok := factorCodes[factor.FactorCode]; !ok {
+				issues = append(issues, ValidationIssue{Field: field + ".factors", Code: "translation.factor.not_found", Message: fmt.Sprintf("translated factor %s is not defined", factor.FactorCode)})
+			}
+		}
+		for _, outcome := range item.Outcomes {
+			if _, ok := outcomeCodes[outcome.OutcomeCode]; !ok {
+				issues = append(issues, ValidationIssue{Field: field + ".outcomes", Code: "translation.outcome.not_found", Message: fmt.Sprintf("translated outcome %s is not defined", outcome.OutcomeCode)})
+			}
+		}
+		for _, profile := range item.Profiles {
+			if _, ok := profileCodes[profile.OutcomeCode]; !ok {
+				issues = append(issues, ValidationIssue{Field: field + ".profiles", Code: "translation.profile.not_found", Message: fmt.Sprintf("translated profile %s is not defined", profile.OutcomeCode)})
+			}
+		}
+	}
+	return issues
+}
+
+// UntranslatedKeys lists, per translated locale, the report copy that still
+// falls back to the base locale. Factor titles are checked for every titled
+// factor because any of them can appear in a report.
+func UntranslatedKeys(def Definition) map[string][]string {
+	if len(def.Translations) == 0 {
+		return nil
+	}
+	assets := InterpretationAssetsFrom(&def)
+	factorCodes := make([]string, 0, len(def.Measure.Factors))
+	for _, item := range def.Measure.Factors {
+		if item.Code != "" && item.Title != "" {
+			factorCodes = append(factorCodes, item.Code)
+		}
+	}
+	out := make(map[string][]string, len(def.Translations))
+	for _, item := range def.Translations {
+		if keys := assets.UntranslatedKeys(item.Locale, factorCodes); len(keys) > 0 {
+			out[item.Locale] = keys
+		}
+	}
+	return out
+}
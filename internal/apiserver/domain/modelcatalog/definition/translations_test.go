@@ -0,0 +1,69 @@
+package definition_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/conclusion"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/definition"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/factor"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/modelcatalog/interpretationassets"
+)
+
+func translatedDefinition(translations ...interpretationassets.LocalizedAssets) definition.Definition {
+	return definition.Definition{
+		Measure: definition.MeasureSpec{Factors: []factor.Factor{{Code: "total", Title: "总分", Role: factor.FactorRoleTotal}}},
+		Outcomes: []conclusion.Outcome{
+			{Code: "low", Title: "低", Summary: "状态良好"},
+			{Code: "high", Title: "高", Summary: "需要关注", Description: "建议复评"},
+		},
+		Translations: translations,
+	}
+}
+
+func TestValidateRejectsMalformedTranslations(t *testing.T) {
+	t.Parallel()
+
+	def := translatedDefinition(
+		interpretationassets.LocalizedAssets{Locale: "en_us"},
+		interpretationassets.LocalizedAssets{Locale: "zh-CN"},
+		interpretationassets.LocalizedAssets{Locale: "en-US", Factors: []interpretationassets.FactorTitle{{FactorCode: "missing", Title: "Missing"}}},
+		interpretationassets.LocalizedAssets{Locale: "en-US", Outcomes: []interpretationassets.OutcomePresentation{{OutcomeCode: "extreme", Title: "Extreme"}}},
+	)
+	issues := definition.Validate(def)
+	for _, code := range []string{"translation.locale.invalid", "translation.locale.default", "translation.locale.duplicate", "translation.factor.not_found", "translation.outcome.not_found"} {
+		if !hasValidationCode(issues, code) {
+			t.Fatalf("Validate() missing %s in %#v", code, issues)
+		}
+	}
+}
+
+func TestUntranslatedKeysReportsCopyFallingBackToBase(t *testing.T) {
+	t.Parallel()
+
+	def := translatedDefinition(interpretationassets.LocalizedAssets{
+		Locale:     "en-US",
+		ModelTitle: "Anxiety",
+		Factors:    []interpretationassets.FactorTitle{{FactorCode: "total", Title: "Total"}},
+		Outcomes: []interpretationassets.OutcomePresentation{
+			{OutcomeCode: "low", Title: "Low", Summary: "Doing well"},
+			{OutcomeCode: "high", Title: "High", Summary: "Needs attention"},
+		},
+	}, interpretationassets.LocalizedAssets{
+		Locale:     "ja",
+		ModelTitle: "不安",
+		Factors:    []interpretationassets.FactorTitle{{FactorCode: "total", Title: "合計"}},
+		Outcomes: []interpretationassets.OutcomePresentation{
+			{OutcomeCode: "low", Title: "低", Summary: "良好"},
+			{OutcomeCode: "high", Title: "高", Summary: "注意", Description: "再評価"},
+		},
+	})
+	if issues := definition.Validate(def); hasValidationCode(issues, "translation.outcome.not_found") {
+		t.Fatalf("Validate() issues = %#v", issues)
+	}
+	got := definition.UntranslatedKeys(def)
+	want := map[string][]string{"en-US": {"outcomes.high.description"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("UntranslatedKeys() = %#v, want %#v", got, want)
+	}
+}
@@ -34,6 +34,7 @@ func Validate(def Definition) []ValidationIssue {
 	issues = append(issues, validateConclusions(def.Conclusions, factorCodes, outcomeCodes)...)
 	issues = append(issues, validateReportMap(def.ReportMap, factorCodes)...)
 	issues = append(issues, validateReportMapAgainstDecision(def)...)
+	issues = append(issues, validateTranslations(def, factorCodes)...)
 	return issues
 }
 
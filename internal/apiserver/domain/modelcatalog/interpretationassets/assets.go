@@ -51,10 +51,13 @@ type ReportSpec struct {
 }
 
 // Assets is the logical InterpretationAssets projected from DefinitionV2.
+// Outcomes/Profiles are authored in locale.Default; Translations carry the
+// other locales and are omitted from encodings of single-language assets.
 type Assets struct {
-	Outcomes   []OutcomePresentation
-	Profiles   []TypeProfilePresentation
-	ReportSpec ReportSpec
+	Outcomes     []OutcomePresentation
+	Profiles     []TypeProfilePresentation
+	ReportSpec   ReportSpec
+	Translations []LocalizedAssets `json:",omitempty"`
 }
 
 // IsMaterialized reports whether presentation assets are present (MC-R016).
@@ -0,0 +1,224 @@
+package interpretationassets
+
+import (
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
+)
+
+// LocalizedAssets is the display copy of one non-default locale. Entries are
+// keyed like the base assets; an empty field falls back to the base copy.
+type LocalizedAssets struct {
+	Locale     string
+	ModelTitle string                    `json:",omitempty"`
+	Factors    []FactorTitle             `json:",omitempty"`
+	Outcomes   []OutcomePresentation     `json:",omitempty"`
+	Profiles   []TypeProfilePresentation `json:",omitempty"`
+}
+
+// FactorTitle is the translated display name of one factor.
+type FactorTitle struct {
+	FactorCode string
+	Title      string
+}
+
+// Locales returns locale.Default followed by every translated locale.
+func (a Assets) Locales() []string {
+	out := make([]string, 0, len(a.Translations)+1)
+	out = append(out, locale.Default)
+	for _, item := range a.Translations {
+		if item.Locale != "" && item.Locale != locale.Default {
+			out = append(out, item.Locale)
+		}
+	}
+	return out
+}
+
+// Translation returns the copy authored for locale; the default locale has none.
+func (a Assets) Translation(tag string) (LocalizedAssets, bool) {
+	if tag == "" || tag == locale.Default {
+		return LocalizedAssets{}, false
+	}
+	for _, item := range a.Translations {
+		if item.Locale == tag {
+			return item, true
+		}
+	}
+	return LocalizedAssets{}, false
+}
+
+// Localize overlays the translation for locale onto the base copy. Untranslated
+// fields keep the base copy, so a partially translated locale still renders.
+// The result carries no Translations; the default or an unknown locale returns
+// the base copy unchanged.
+func (a Assets) Localize(tag string) Assets {
+	out := Assets{
+		Outcomes:   append([]OutcomePresentation(nil), a.Outcomes...),
+		Profiles:   cloneProfiles(a.Profiles),
+		ReportSpec: a.ReportSpec,
+	}
+	translation, ok := a.Translation(tag)
+	if !ok {
+		return out
+	}
+	for index, item := range out.Outcomes {
+		if localized, found := translation.findOutcome(item.OutcomeCode); found {
+			out.Outcomes[index] = overlayOutcome(item, localized)
+		}
+	}
+	for index, item := range out.Profiles {
+		if localized, found := translation.findProfile(item.OutcomeCode); found {
+			out.Profiles[index] = overlayProfile(item, localized)
+		}
+	}
+	return out
+}
+
+// ModelTitle returns the translated model title, or fallback when locale has none.
+func (a Assets) ModelTitle(tag, fallback string) string {
+	if translation, ok := a.Translation(tag); ok && translation.ModelTitle != "" {
+		return translation.ModelTitle
+	}
+	return fallback
+}
+
+// FactorTitle returns the translated factor title, or fallback when locale has none.
+func (a Assets) FactorTitle(tag, factorCode, fallback string) string {
+	translation, ok := a.Translation(tag)
+	if !ok {
+		return fallback
+	}
+	for _, item := range translation.Factors {
+		if item.FactorCode == factorCode && item.Title != "" {
+			return item.Title
+		}
+	}
+	return fallback
+}
+
+// OutcomeTitle returns the translated outcome title, or fallback when locale has none.
+func (a Assets) OutcomeTitle(tag, outcomeCode, fallback string) string {
+	translation, ok := a.Translation(tag)
+	if !ok {
+		return fallback
+	}
+	if item, found := translation.findOutcome(outcomeCode); found && item.Title != "" {
+		return item.Title
+	}
+	return fallback
+}
+
+// UntranslatedKeys lists base copy that locale does not translate, as stable
+// keys such as "model.title", "factors.A.title" or "outcomes.low.summary".
+// Only fields with base copy are reported; factorCodes names the factors whose
+// titles are shown in reports.
+func (a Assets) UntranslatedKeys(tag string, factorCodes []string) []string {
+	translation, _ := a.Translation(tag)
+	var keys []string
+	if translation.ModelTitle == "" {
+		keys = append(keys, "model.title")
+	}
+	for _, code := range factorCodes {
+		if a.FactorTitle(tag, code, "") == "" {
+			keys = append(keys, "factors."+code+".title")
+		}
+	}
+	for _, item := range a.Outcomes {
+		localized, _ := translation.findOutcome(item.OutcomeCode)
+		prefix := "outcomes." + item.OutcomeCode + "."
+		keys = appendMissing(keys, prefix+"title", item.Title, localized.Title)
+		keys = appendMissing(keys, prefix+"summary", item.Summary, localized.Summary)
+		keys = appendMissing(keys, prefix+"description", item.Description, localized.Description)
+	}
+	for _, item := range a.Profiles {
+		localized, _ := translation.findProfile(item.OutcomeCode)
+		prefix := "profiles." + item.OutcomeCode + "."
+		keys = appendMissing(keys, prefix+"pattern", item.Pattern, localized.Pattern)
+		keys = appendMissingList(keys, prefix+"traits", item.Traits, localized.Traits)
+		keys = appendMissingList(keys, prefix+"strengths", item.Strengths, localized.Strengths)
+		keys = appendMissingList(keys, prefix+"weaknesses", item.Weaknesses, localized.Weaknesses)
+		keys = appendMissingList(keys, prefix+"suggestions", item.Suggestions, localized.Suggestions)
+		keys = appendMissing(keys, prefix+"rarity.label", item.Rarity.Label, localized.Rarity.Label)
+		keys = appendMissing(keys, prefix+"trigger", item.Trigger, localized.Trigger)
+		keys = appendMissing(keys, prefix+"commentary", item.Commentary, localized.Commentary)
+	}
+	return keys
+}
+
+func (t LocalizedAssets) findOutcome(code string) (OutcomePresentation, bool) {
+	for _, item := range t.Outcomes {
+		if item.OutcomeCode == code {
+			return item, true
+		}
+	}
+	return OutcomePresentation{}, false
+}
+
+func (t LocalizedAssets) findProfile(code string) (TypeProfilePresentation, bool) {
+	for _, item := range t.Profiles {
+		if item.OutcomeCode == code {
+			return item, true
+		}
+	}
+	return TypeProfilePresentation{}, false
+}
+
+func overlayOutcome(base, localized OutcomePresentation) OutcomePresentation {
+	base.Title = pick(localized.Title, base.Title)
+	base.Summary = pick(localized.Summary, base.Summary)
+	base.Description = pick(localized.Description, base.Description)
+	return base
+}
+
+func overlayProfile(base, localized TypeProfilePresentation) TypeProfilePresentation {
+	base.Pattern = pick(localized.Pattern, base.Pattern)
+	base.Traits = pickList(localized.Traits, base.Traits)
+	base.Strengths = pickList(localized.Strengths, base.Strengths)
+	base.Weaknesses = pickList(localized.Weaknesses, base.Weaknesses)
+	base.Suggestions = pickList(localized.Suggestions, base.Suggestions)
+	base.Rarity.Label = pick(localized.Rarity.Label, base.Rarity.Label)
+	base.Trigger = pick(localized.Trigger, base.Trigger)
+	base.Commentary = pick(localized.Commentary, base.Commentary)
+	return base
+}
+
+func cloneProfiles(items []TypeProfilePresentation) []TypeProfilePresentation {
+	if items == nil {
+		return nil
+	}
+	out := make([]TypeProfilePresentation, len(items))
+	for index, item := range items {
+		item.Traits = append([]string(nil), item.Traits...)
+		item.Strengths = append([]string(nil), item.Strengths...)
+		item.Weaknesses = append([]string(nil), item.Weaknesses...)
+		item.Suggestions = append([]string(nil), item.Suggestions...)
+		out[index] = item
+	}
+	return out
+}
+
+func pick(localized, base string) string {
+	if localized != "" {
+		return localized
+	}
+	return base
+}
+
+func pickList(localized, base []string) []string {
+	if len(localized) > 0 {
+		return append([]string(nil), localized...)
+	}
+	return base
+}
+
+func appendMissing(keys []string, key, base, localized string) []string {
+	if base != "" && localized == "" {
+		return append(keys, key)
+	}
+	return keys
+}
+
+func appendMissingList(keys []string, key string, base, localized []string) []string {
+	if len(base) > 0 && len(localized) == 0 {
+		return append(keys, key)
+	}
+	return keys
+}
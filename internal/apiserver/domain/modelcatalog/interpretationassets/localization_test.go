@@ -0,0 +1,84 @@
+package interpretationassets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func localizedFixture() Assets {
+	return Assets{
+		Outcomes: []OutcomePresentation{
+			{OutcomeCode: "low", Title: "低", Summary: "状态良好", Description: "保持作息"},
+			{OutcomeCode: "high", Title: "高", Summary: "需要关注", Description: "寻求支持"},
+		},
+		Profiles: []TypeProfilePresentation{{OutcomeCode: "INTJ", Pattern: "建筑师", Traits: []string{"独立"}}},
+		Translations: []LocalizedAssets{{
+			Locale:     "en-US",
+			ModelTitle: "Anxiety Scale",
+			Factors:    []FactorTitle{{FactorCode: "total", Title: "Total"}},
+			Outcomes:   []OutcomePresentation{{OutcomeCode: "low", Title: "Low", Summary: "Doing well"}},
+			Profiles:   []TypeProfilePresentation{{OutcomeCode: "INTJ", Pattern: "Architect"}},
+		}},
+	}
+}
+
+func TestLocalizeOverlaysTranslatedFieldsAndKeepsBaseFallback(t *testing.T) {
+	assets := localizedFixture()
+	localized := assets.Localize("en-US")
+	low, _ := localized.FindOutcome("low")
+	if low.Title != "Low" || low.Summary != "Doing well" || low.Description != "保持作息" {
+		t.Fatalf("low = %+v", low)
+	}
+	high, _ := localized.FindOutcome("high")
+	if high.Summary != "需要关注" {
+		t.Fatalf("untranslated outcome = %+v", high)
+	}
+	profile, _ := localized.FindProfile("INTJ")
+	if profile.Pattern != "Architect" || !reflect.DeepEqual(profile.Traits, []string{"独立"}) {
+		t.Fatalf("profile = %+v", profile)
+	}
+	if localized.Translations != nil {
+		t.Fatalf("localized assets must not carry translations")
+	}
+	if base, _ := assets.FindOutcome("low"); base.Title != "低" {
+		t.Fatalf("Localize mutated base copy: %+v", base)
+	}
+	if same := assets.Localize("zh-CN"); !reflect.DeepEqual(same.Outcomes, assets.Outcomes) {
+		t.Fatalf("default locale changed copy: %+v", same.Outcomes)
+	}
+}
+
+func TestTitlesFallBackWhenUntranslated(t *testing.T) {
+	assets := localizedFixture()
+	if got := assets.ModelTitle("en-US", "焦虑量表"); got != "Anxiety Scale" {
+		t.Fatalf("model title = %q", got)
+	}
+	if got := assets.ModelTitle("ja", "焦虑量表"); got != "焦虑量表" {
+		t.Fatalf("unknown locale model title = %q", got)
+	}
+	if got := assets.FactorTitle("en-US", "anxiety", "焦虑"); got != "焦虑" {
+		t.Fatalf("untranslated factor title = %q", got)
+	}
+	if got := assets.OutcomeTitle("en-US", "low", "低"); got != "Low" {
+		t.Fatalf("outcome title = %q", got)
+	}
+	if got := assets.OutcomeTitle("en-US", "high", "高"); got != "高" {
+		t.Fatalf("untranslated outcome title = %q", got)
+	}
+	if got := assets.Locales(); !reflect.DeepEqual(got, []string{"zh-CN", "en-US"}) {
+		t.Fatalf("locales = %v", got)
+	}
+}
+
+func TestUntranslatedKeysListsOnlyBaseCopyMissingInLocale(t *testing.T) {
+	got := localizedFixture().UntranslatedKeys("en-US", []string{"total", "anxiety"})
+	want := []string{
+		"factors.anxiety.title",
+		"outcomes.low.description",
+		"outcomes.high.title", "outcomes.high.summary", "outcomes.high.description",
+		"profiles.INTJ.traits",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+}
@@ -36,6 +36,12 @@ type AssessmentPlan struct {
 	fixedDates    []time.Time // 固定日期列表（用于 fixed_date，特殊场景使用绝对日期）
 	relativeWeeks []int       // 相对周次列表（用于 custom，如 [2,4,8,12,18]）
 
+	// === 日历规则与时区 ===
+	timeZone       string          // IANA 时区（如 Asia/Shanghai）；为空时沿用服务器本地时区解释触发时间
+	recurrenceRule string          // RRULE 文本（用于 rrule，如 FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=12）
+	exceptionDates []time.Time     // EXDATE 排除日期（用于 rrule，如节假日）
+	recurrence     *RecurrenceRule // 解析后的 RRULE，构造时生成
+
 	// === 状态 ===
 	status PlanStatus
 
@@ -100,6 +106,24 @@ func NewAssessmentPlan(
 	}
 	plan.triggerTime = normalizedTriggerTime
 
+	normalizedTimeZone, err := NormalizePlanTimeZone(plan.timeZone)
+	if err != nil {
+		return nil, err
+	}
+	plan.timeZone = normalizedTimeZone
+
+	if scheduleType == PlanScheduleRRule {
+		recurrence, err := ParseRecurrenceRule(plan.recurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+		plan.recurrence = recurrence
+		plan.recurrenceRule = recurrence.String()
+		if plan.totalTimes <= 0 && recurrence.Count > 0 {
+			plan.totalTimes = recurrence.Count
+		}
+	}
+
 	return plan, nil
 }
 
@@ -128,6 +152,22 @@ func WithRelativeWeeks(weeks []int) PlanOption {
 	}
 }
 
+// WithTimeZone 设置计划时区（IANA 名称，如 Asia/Shanghai、America/New_York）。
+// 触发时间、开放窗口和入口有效期均按该时区的自然日计算。
+func WithTimeZone(timeZone string) PlanOption {
+	return func(p *AssessmentPlan) {
+		p.timeZone = timeZone
+	}
+}
+
+// WithRecurrenceRule 设置 RRULE 规则与 EXDATE 排除日期（用于 rrule）
+func WithRecurrenceRule(rule string, exceptionDates []time.Time) PlanOption {
+	return func(p *AssessmentPlan) {
+		p.recurrenceRule = rule
+		p.exceptionDates = exceptionDates
+	}
+}
+
 // ==================== Getter 方法 ====================
 
 // GetID 获取计划ID
@@ -186,6 +226,31 @@ func (p *AssessmentPlan) GetRelativeWeeks() []int {
 	return weeks
 }
 
+// GetTimeZone 获取计划时区（为空表示未指定）
+func (p *AssessmentPlan) GetTimeZone() string {
+	return p.timeZone
+}
+
+// GetLocation 获取计划时区对应的 Location；未指定时区时返回 nil。
+func (p *AssessmentPlan) GetLocation() *time.Location {
+	return LoadPlanLocation(p.timeZone)
+}
+
+// GetRecurrenceRule 获取 RRULE 文本
+func (p *AssessmentPlan) GetRecurrenceRule() string {
+	return p.recurrenceRule
+}
+
+// GetExceptionDates 获取 EXDATE 排除日期列表（返回副本）
+func (p *AssessmentPlan) GetExceptionDates() []time.Time {
+	if p.exceptionDates == nil {
+		return nil
+	}
+	dates := make([]time.Time, len(p.exceptionDates))
+	copy(dates, p.exceptionDates)
+	return dates
+}
+
 // GetStatus 获取状态
 func (p *AssessmentPlan) GetStatus() PlanStatus {
 	return p.status
@@ -42,6 +42,7 @@ type AssessmentTask struct {
 	completedAt       *time.Time // 完成时间
 	expiredAt         *time.Time // 实际过期状态迁移时间
 	canceledAt        *time.Time // 实际取消状态迁移时间
+	timeZone          string     // 继承自计划的 IANA 时区；为空时按上海自然日计算截止与入口有效期
 
 	// === 状态与关联 ===
 	status           TaskStatus
@@ -145,6 +146,17 @@ func (t *AssessmentTask) GetDueAt() time.Time { return t.dueAt }
 
 func (t *AssessmentTask) GetScheduleRevision() uint32 { return t.scheduleRevision }
 
+// GetTimeZone 返回任务继承的计划时区，为空表示未指定。
+func (t *AssessmentTask) GetTimeZone() string { return t.timeZone }
+
+// BusinessLocation 返回计算截止时间和入口有效期所用的时区。
+func (t *AssessmentTask) BusinessLocation() *time.Location {
+	if loc := LoadPlanLocation(t.timeZone); loc != nil {
+		return loc
+	}
+	return taskBusinessLocation
+}
+
 func (t *AssessmentTask) GetScheduleDefinedAt() time.Time { return t.scheduleDefinedAt }
 
 func (t *AssessmentTask) GetBusinessCreatedAt() *time.Time { return t.businessCreatedAt }
@@ -334,7 +346,7 @@ func (t *AssessmentTask) reschedule(plannedAt, actionAt time.Time) error {
 	}
 
 	t.plannedAt = plannedAt
-	t.dueAt = TaskDueAt(plannedAt)
+	t.dueAt = TaskDueAtIn(plannedAt, t.BusinessLocation())
 	t.scheduleRevision++
 	if t.scheduleRevision == 0 {
 		t.scheduleRevision = 1
@@ -357,13 +369,25 @@ func (t *AssessmentTask) reschedule(plannedAt, actionAt time.Time) error {
 // schema. A nil dueAt is a legacy row and is derived without mutating storage.
 func (t *AssessmentTask) RestoreTimeSemantics(dueAt *time.Time, reason TaskExpirationReason) {
 	if dueAt == nil || dueAt.IsZero() {
-		t.dueAt = TaskDueAt(t.plannedAt)
+		t.dueAt = TaskDueAtIn(t.plannedAt, t.BusinessLocation())
 	} else {
 		t.dueAt = *dueAt
 	}
 	t.expirationReason = reason
 }
 
+// RestoreTimeZone restores the plan time zone copied onto the task. Repositories
+// call it before RestoreTimeSemantics so legacy due dates derive in that zone.
+func (t *AssessmentTask) RestoreTimeZone(timeZone string) {
+	t.timeZone = timeZone
+}
+
+// applyTimeZone 绑定计划时区并按该时区重算截止时间（包内方法，供任务生成器调用）。
+func (t *AssessmentTask) applyTimeZone(timeZone string) {
+	t.timeZone = timeZone
+	t.dueAt = TaskDueAtIn(t.plannedAt, t.BusinessLocation())
+}
+
 // RestoreScheduleSemantics restores the current scheduling epoch. Legacy rows
 // use revision 1 and their immutable business creation time as the definition
 // timestamp until the one-off repair persists the explicit columns.
@@ -55,5 +55,11 @@ var (
 	// ErrInvalidTriggerTime 无效的计划触发时间
 	ErrInvalidTriggerTime = errors.New("invalid trigger time")
 
+	// ErrInvalidRecurrenceRule 无效的 RRULE/EXDATE 周期规则
+	ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+	// ErrInvalidTimeZone 无效的 IANA 时区
+	ErrInvalidTimeZone = errors.New("invalid time zone")
+
 	ErrActiveEnrollmentExists = errors.New("active enrollment already exists")
 )
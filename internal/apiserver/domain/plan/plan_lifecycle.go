@@ -318,6 +318,10 @@ func (l *PlanLifecycle) prepareResumeTasksForTestee(
 // 根据计划的周期类型和任务的序号、计划时间点，反推 startDate
 func inferStartDateFromTask(plan *AssessmentPlan, task *AssessmentTask) time.Time {
 	plannedAt := task.GetPlannedAt()
+	if loc := plan.GetLocation(); loc != nil {
+		// 仓储恢复的时间点不带计划时区，先换算回计划时区才能得到正确的日历日期
+		plannedAt = plannedAt.In(loc)
+	}
 	seq := task.GetSeq()
 
 	switch plan.GetScheduleType() {
@@ -348,6 +352,10 @@ func inferStartDateFromTask(plan *AssessmentPlan, task *AssessmentTask) time.Tim
 		// 固定日期类型，第一个任务的 plannedAt 就是 startDate
 		return plannedAt
 
+	case PlanScheduleRRule:
+		// 仅作兜底：EXDATE 剔除首个发生日时首个任务并非 DTSTART，调用方应优先传入参与轮次的开始日期
+		return plannedAt
+
 	default:
 		return plannedAt
 	}
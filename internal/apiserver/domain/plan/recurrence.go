@@ -0,0 +1,463 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRecurrenceOccurrences 单次展开 RRULE 允许生成的最大任务数，与 by_week/by_day 的总次数上限保持一致。
+const MaxRecurrenceOccurrences = 100
+
+// maxRecurrencePeriods 展开时最多遍历的周期数，防止过滤条件永远不命中时死循环。
+const maxRecurrencePeriods = 5000
+
+const (
+	recurrenceDateLayout      = "20060102"
+	recurrenceDateTimeLayout  = "20060102T150405"
+	recurrenceUTCLayout       = "20060102T150405Z"
+	recurrenceExDateISOLayout = "2006-01-02"
+)
+
+// RecurrenceFrequency RRULE 的 FREQ 取值（仅支持日/周/月三种粒度）。
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// RecurrenceWeekday BYDAY 中的单个取值，Ordinal 仅在 MONTHLY 下有意义（1MO=第一个周一，-1FR=最后一个周五）。
+type RecurrenceWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule iCalendar RRULE（RFC 5545）的受限子集。
+//
+// 支持：FREQ=DAILY|WEEKLY|MONTHLY、INTERVAL、COUNT、UNTIL、BYDAY、BYMONTHDAY、WKST。
+// 规则按"日期"展开，具体时刻由计划的触发时间和时区决定，因此 DTSTART 取受试者加入计划的开始日期。
+// 为保证一次性生成任务，规则必须通过 COUNT 或 UNTIL 收敛。
+type RecurrenceRule struct {
+	Freq       RecurrenceFrequency
+	Interval   int
+	Count      int
+	Until      *time.Time // 仅保留日期部分，含当日
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+	WeekStart  time.Weekday
+}
+
+var recurrenceWeekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRecurrenceRule 解析 RRULE 字符串，允许带 "RRULE:" 前缀。
+func ParseRecurrenceRule(raw string) (*RecurrenceRule, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= len("RRULE:") && strings.EqualFold(raw[:len("RRULE:")], "RRULE:") {
+		raw = raw[len("RRULE:"):]
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRecurrenceRule)
+	}
+
+	rule := &RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrenceRule, part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicated %s", ErrInvalidRecurrenceRule, key)
+		}
+		seen[key] = true
+
+		if err := rule.applyPart(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RecurrenceRule) applyPart(key, value string) error {
+	switch key {
+	case "FREQ":
+		freq := RecurrenceFrequency(value)
+		switch freq {
+		case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+			r.Freq = freq
+		default:
+			return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRecurrenceRule, value)
+		}
+	case "INTERVAL":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrenceRule)
+		}
+		r.Interval = n
+	case "COUNT":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrenceRule)
+		}
+		r.Count = n
+	case "UNTIL":
+		until, err := parseRecurrenceDate(value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid UNTIL %s", ErrInvalidRecurrenceRule, value)
+		}
+		r.Until = &until
+	case "BYDAY":
+		for _, item := range strings.Split(value, ",") {
+			day, err := parseRecurrenceWeekday(strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+			r.ByDay = append(r.ByDay, day)
+		}
+	case "BYMONTHDAY":
+		for _, item := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(item))
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return fmt.Errorf("%w: invalid BYMONTHDAY %s", ErrInvalidRecurrenceRule, item)
+			}
+			r.ByMonthDay = append(r.ByMonthDay, n)
+		}
+	case "WKST":
+		weekday, ok := recurrenceWeekdayCodes[value]
+		if !ok {
+			return fmt.Errorf("%w: invalid WKST %s", ErrInvalidRecurrenceRule, value)
+		}
+		r.WeekStart = weekday
+	default:
+		return fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrenceRule, key)
+	}
+	return nil
+}
+
+func (r *RecurrenceRule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrenceRule)
+	}
+	if r.Count == 0 && r.Until == nil {
+		return fmt.Errorf("%w: COUNT or UNTIL is required", ErrInvalidRecurrenceRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrenceRule)
+	}
+	if r.Count > MaxRecurrenceOccurrences {
+		return fmt.Errorf("%w: COUNT must not exceed %d", ErrInvalidRecurrenceRule, MaxRecurrenceOccurrences)
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != RecurrenceMonthly {
+		return fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrInvalidRecurrenceRule)
+	}
+	if r.Freq != RecurrenceMonthly {
+		for _, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return fmt.Errorf("%w: ordinal BYDAY is only supported with FREQ=MONTHLY", ErrInvalidRecurrenceRule)
+			}
+		}
+	}
+	return nil
+}
+
+// Occurrences 从 start 所在日期开始展开规则，返回不早于 start 的日期（零点，位于 start 的时区）。
+//
+// EXDATE 命中的日期会被剔除，但按 RFC 5545 仍计入 COUNT。
+// 返回结果不超过 MaxRecurrenceOccurrences 条。
+func (r *RecurrenceRule) Occurrences(start time.Time, exceptionDates []time.Time) []time.Time {
+	if r == nil || start.IsZero() {
+		return nil
+	}
+	start = startOfDay(start)
+	excluded := make(map[string]struct{}, len(exceptionDates))
+	for _, date := range exceptionDates {
+		excluded[date.Format(recurrenceDateLayout)] = struct{}{}
+	}
+
+	var result []time.Time
+	generated := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(start, period)
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if r.Until != nil && dateAfter(candidate, *r.Until) {
+				return result
+			}
+			generated++
+			if _, skip := excluded[candidate.Format(recurrenceDateLayout)]; !skip {
+				result = append(result, candidate)
+				if len(result) >= MaxRecurrenceOccurrences {
+					return result
+				}
+			}
+			if r.Count > 0 && generated >= r.Count {
+				return result
+			}
+		}
+		if r.Until != nil && dateAfter(r.periodStart(start, period), *r.Until) {
+			return result
+		}
+	}
+	return result
+}
+
+// String 将规则序列化为规范化的 RRULE 文本（不含前缀）。
+func (r *RecurrenceRule) String() string {
+	if r == nil {
+		return ""
+	}
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format(recurrenceDateLayout))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+// String 返回 BYDAY 取值的 RRULE 文本，如 MO、1MO、-1FR。
+func (d RecurrenceWeekday) String() string {
+	if d.Ordinal == 0 {
+		return weekdayCode(d.Weekday)
+	}
+	return strconv.Itoa(d.Ordinal) + weekdayCode(d.Weekday)
+}
+
+func (r *RecurrenceRule) periodStart(start time.Time, period int) time.Time {
+	switch r.Freq {
+	case RecurrenceWeekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		return start.AddDate(0, 0, -offset+period*r.Interval*7)
+	case RecurrenceMonthly:
+		return time.Date(start.Year(), start.Month()+time.Month(period*r.Interval), 1, 0, 0, 0, 0, start.Location())
+	default:
+		return start.AddDate(0, 0, period*r.Interval)
+	}
+}
+
+func (r *RecurrenceRule) periodCandidates(start time.Time, period int) []time.Time {
+	base := r.periodStart(start, period)
+	switch r.Freq {
+	case RecurrenceWeekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{base.AddDate(0, 0, (int(start.Weekday())-int(r.WeekStart)+7)%7)}
+		}
+		candidates := make([]time.Time, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			candidates = append(candidates, base.AddDate(0, 0, (int(day.Weekday)-int(r.WeekStart)+7)%7))
+		}
+		return sortUniqueDates(candidates)
+	case RecurrenceMonthly:
+		return r.monthlyCandidates(base, start.Day())
+	default:
+		if len(r.ByDay) == 0 || r.matchesWeekday(base.Weekday()) {
+			return []time.Time{base}
+		}
+		return nil
+	}
+}
+
+func (r *RecurrenceRule) monthlyCandidates(monthStart time.Time, fallbackDay int) []time.Time {
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	var candidates []time.Time
+
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		// 未指定 BYDAY/BYMONTHDAY 时沿用开始日期的"日"，当月没有该日则跳过（RFC 5545 语义）。
+		if fallbackDay <= daysInMonth {
+			candidates = append(candidates, monthStart.AddDate(0, 0, fallbackDay-1))
+		}
+		return candidates
+	}
+
+	var byMonthDay []time.Time
+	for _, day := range r.ByMonthDay {
+		if day < 0 {
+			day = daysInMonth + day + 1
+		}
+		if day >= 1 && day <= daysInMonth {
+			byMonthDay = append(byMonthDay, monthStart.AddDate(0, 0, day-1))
+		}
+	}
+
+	var byDay []time.Time
+	for _, weekday := range r.ByDay {
+		var matches []time.Time
+		for d := 0; d < daysInMonth; d++ {
+			date := monthStart.AddDate(0, 0, d)
+			if date.Weekday() == weekday.Weekday {
+				matches = append(matches, date)
+			}
+		}
+		switch {
+		case weekday.Ordinal == 0:
+			byDay = append(byDay, matches...)
+		case weekday.Ordinal > 0 && weekday.Ordinal <= len(matches):
+			byDay = append(byDay, matches[weekday.Ordinal-1])
+		case weekday.Ordinal < 0 && -weekday.Ordinal <= len(matches):
+			byDay = append(byDay, matches[len(matches)+weekday.Ordinal])
+		}
+	}
+
+	switch {
+	case len(r.ByMonthDay) == 0:
+		candidates = byDay
+	case len(r.ByDay) == 0:
+		candidates = byMonthDay
+	default:
+		// 同时指定时按 RFC 5545，BYDAY 对 BYMONTHDAY 起限定作用（取交集），如 BYDAY=FR;BYMONTHDAY=13 仅命中"13 号星期五"。
+		allowed := make(map[string]struct{}, len(byDay))
+		for _, date := range byDay {
+			allowed[date.Format(recurrenceDateLayout)] = struct{}{}
+		}
+		for _, date := range byMonthDay {
+			if _, ok := allowed[date.Format(recurrenceDateLayout)]; ok {
+				candidates = append(candidates, date)
+			}
+		}
+	}
+	return sortUniqueDates(candidates)
+}
+
+func (r *RecurrenceRule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRecurrenceExceptionDates 解析 EXDATE 列表，支持 YYYY-MM-DD、YYYYMMDD、YYYYMMDDTHHMMSS(Z)，
+// 允许带 "EXDATE:" 前缀或逗号分隔的多个值。返回值为 loc 时区下的零点日期。
+func ParseRecurrenceExceptionDates(raw []string, loc *time.Location) ([]time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	var dates []time.Time
+	for _, item := range raw {
+		item = strings.TrimSpace(item)
+		if len(item) >= len("EXDATE") && strings.EqualFold(item[:len("EXDATE")], "EXDATE") {
+			if _, value, ok := strings.Cut(item, ":"); ok {
+				item = value
+			}
+		}
+		for _, value := range strings.Split(item, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			date, err := parseRecurrenceDate(strings.ToUpper(value))
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid EXDATE %s", ErrInvalidRecurrenceRule, value)
+			}
+			dates = append(dates, time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc))
+		}
+	}
+	return sortUniqueDates(dates), nil
+}
+
+func parseRecurrenceDate(value string) (time.Time, error) {
+	for _, layout := range []string{recurrenceUTCLayout, recurrenceDateTimeLayout, recurrenceDateLayout, recurrenceExDateISOLayout} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
+
+func parseRecurrenceWeekday(value string) (RecurrenceWeekday, error) {
+	if len(value) < 2 {
+		return RecurrenceWeekday{}, fmt.Errorf("%w: invalid BYDAY %s", ErrInvalidRecurrenceRule, value)
+	}
+	code := value[len(value)-2:]
+	weekday, ok := recurrenceWeekdayCodes[code]
+	if !ok {
+		return RecurrenceWeekday{}, fmt.Errorf("%w: invalid BYDAY %s", ErrInvalidRecurrenceRule, value)
+	}
+	day := RecurrenceWeekday{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceWeekday{}, fmt.Errorf("%w: invalid BYDAY ordinal %s", ErrInvalidRecurrenceRule, value)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range recurrenceWeekdayCodes {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+// dateAfter 按日历日期比较，忽略时区差异（UNTIL/EXDATE 均为日期语义）。
+func dateAfter(a, b time.Time) bool {
+	return a.Format(recurrenceDateLayout) > b.Format(recurrenceDateLayout)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func sortUniqueDates(dates []time.Time) []time.Time {
+	if len(dates) == 0 {
+		return dates
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	unique := dates[:1]
+	for _, date := range dates[1:] {
+		if !date.Equal(unique[len(unique)-1]) {
+			unique = append(unique, date)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,214 @@
+package plan
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testee"
+)
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, 0, len(dates))
+	for _, date := range dates {
+		out = append(out, date.Format("2006-01-02"))
+	}
+	return out
+}
+
+func assertDates(t *testing.T, got []time.Time, want ...string) {
+	t.Helper()
+	formatted := formatDates(got)
+	if len(formatted) != len(want) {
+		t.Fatalf("dates=%v want=%v", formatted, want)
+	}
+	for i := range want {
+		if formatted[i] != want[i] {
+			t.Fatalf("dates=%v want=%v", formatted, want)
+		}
+	}
+}
+
+func TestRecurrenceRuleEveryOtherTuesdayAndThursday(t *testing.T) {
+	rule, err := ParseRecurrenceRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-03-04 是周三：本周二已过，本周四为首次发生
+	start := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, nil),
+		"2026-03-05", "2026-03-17", "2026-03-19", "2026-03-31", "2026-04-02")
+}
+
+func TestRecurrenceRuleFirstMondayOfMonthWithHolidayExclusion(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYDAY=1MO;UNTIL=20260630")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exdates, err := ParseRecurrenceExceptionDates([]string{"EXDATE;VALUE=DATE:20260406"}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, exdates),
+		"2026-02-02", "2026-03-02", "2026-05-04", "2026-06-01")
+}
+
+func TestRecurrenceRuleExceptionDatesStillConsumeCount(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exdates, err := ParseRecurrenceExceptionDates([]string{"2026-05-02"}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, exdates), "2026-05-01", "2026-05-03")
+}
+
+func TestRecurrenceRuleMonthlyLastDayAndSkippedMonths(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, nil), "2026-01-31", "2026-02-28", "2026-03-31")
+
+	rule, err = ParseRecurrenceRule("FREQ=MONTHLY;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, nil), "2026-01-31", "2026-03-31", "2026-05-31")
+}
+
+func TestRecurrenceRuleMonthlyByDayLimitsByMonthDay(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 同时指定 BYDAY 与 BYMONTHDAY 时只保留"13 号星期五"，不是两者的并集
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assertDates(t, rule.Occurrences(start, nil), "2026-02-13", "2026-03-13", "2026-11-13", "2027-08-13")
+}
+
+func TestParseRecurrenceRuleRejectsUnsupportedOrUnbounded(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"FREQ=WEEKLY",
+		"FREQ=YEARLY;COUNT=2",
+		"FREQ=WEEKLY;COUNT=2;UNTIL=20260101",
+		"FREQ=WEEKLY;BYDAY=1MO;COUNT=2",
+		"FREQ=DAILY;BYMONTHDAY=1;COUNT=2",
+		"FREQ=DAILY;BYHOUR=9;COUNT=2",
+		"FREQ=DAILY;COUNT=101",
+		"FREQ=DAILY;INTERVAL=0;COUNT=2",
+	} {
+		if _, err := ParseRecurrenceRule(raw); !errors.Is(err, ErrInvalidRecurrenceRule) {
+			t.Fatalf("ParseRecurrenceRule(%q) err=%v, want ErrInvalidRecurrenceRule", raw, err)
+		}
+	}
+}
+
+func TestRecurrenceRuleStringIsCanonical(t *testing.T) {
+	rule, err := ParseRecurrenceRule("rrule:byday=tu,th;freq=weekly;interval=2;count=4;wkst=su")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rule.String(), "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,TH;WKST=SU"; got != want {
+		t.Fatalf("String()=%s want=%s", got, want)
+	}
+}
+
+func TestTaskGeneratorExpandsRRulePlanInPlanTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exdates, err := ParseRecurrenceExceptionDates([]string{"20260310"}, newYork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewAssessmentPlan(1, "scale", PlanScheduleRRule, 0, 0,
+		WithTriggerTime("08:30"),
+		WithTimeZone("America/New_York"),
+		WithRecurrenceRule("FREQ=WEEKLY;BYDAY=TU;COUNT=3", exdates),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.GetTotalTimes() != 3 {
+		t.Fatalf("total_times=%d want=3", p.GetTotalTimes())
+	}
+
+	// 服务器时区下的开始日期只取其日历日期
+	startDate := time.Date(2026, 3, 2, 0, 0, 0, 0, taskBusinessLocation)
+	tasks := NewTaskGenerator().GenerateTasks(p, testee.NewID(1), startDate)
+	if len(tasks) != 2 {
+		t.Fatalf("tasks=%d want=2", len(tasks))
+	}
+	first := tasks[0]
+	if want := time.Date(2026, 3, 3, 8, 30, 0, 0, newYork); !first.GetPlannedAt().Equal(want) {
+		t.Fatalf("first planned_at=%s want=%s", first.GetPlannedAt(), want)
+	}
+	if first.GetTimeZone() != "America/New_York" {
+		t.Fatalf("task time zone=%q", first.GetTimeZone())
+	}
+	// 2026-03-08 美东进入夏令时，截止时间仍为当地 08:30
+	if want := time.Date(2026, 3, 10, 8, 30, 0, 0, newYork); !first.GetDueAt().Equal(want) {
+		t.Fatalf("due_at=%s want=%s", first.GetDueAt(), want)
+	}
+	if want := time.Date(2026, 3, 17, 8, 30, 0, 0, newYork); !tasks[1].GetPlannedAt().Equal(want) || tasks[1].GetSeq() != 2 {
+		t.Fatalf("second task seq=%d planned_at=%s want=%s", tasks[1].GetSeq(), tasks[1].GetPlannedAt(), want)
+	}
+
+	openAt := time.Date(2026, 3, 3, 9, 0, 0, 0, newYork)
+	if err := NewTaskLifecycle().OpenAt(t.Context(), first, "token", "url", openAt); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 3, 10, 9, 0, 0, 0, newYork); first.GetExpireAt() == nil || !first.GetExpireAt().Equal(want) {
+		t.Fatalf("expire_at=%v want=%s", first.GetExpireAt(), want)
+	}
+}
+
+func TestTaskGeneratorUntilStopsRRuleAtEndDate(t *testing.T) {
+	p, err := NewAssessmentPlan(1, "scale", PlanScheduleRRule, 0, 0,
+		WithTimeZone("Asia/Shanghai"),
+		WithRecurrenceRule("FREQ=DAILY;INTERVAL=2;COUNT=10", nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, taskBusinessLocation)
+	end := time.Date(2026, 6, 5, 23, 59, 59, 0, taskBusinessLocation)
+	tasks := NewTaskGenerator().GenerateTasksUntil(p, testee.NewID(1), start, end)
+	if len(tasks) != 3 {
+		t.Fatalf("tasks=%d want=3", len(tasks))
+	}
+}
+
+func TestNewAssessmentPlanRejectsInvalidTimeZoneAndRule(t *testing.T) {
+	if _, err := NewAssessmentPlan(1, "scale", PlanScheduleByDay, 1, 1, WithTimeZone("Mars/Olympus")); !errors.Is(err, ErrInvalidTimeZone) {
+		t.Fatalf("invalid time zone err=%v", err)
+	}
+	if _, err := NewAssessmentPlan(1, "scale", PlanScheduleByDay, 1, 1, WithTimeZone("Local")); !errors.Is(err, ErrInvalidTimeZone) {
+		t.Fatalf("Local time zone err=%v", err)
+	}
+	if _, err := NewAssessmentPlan(1, "scale", PlanScheduleRRule, 0, 0); !errors.Is(err, ErrInvalidRecurrenceRule) {
+		t.Fatalf("missing rule err=%v", err)
+	}
+}
+
+func TestPlanValidatorValidateRecurrence(t *testing.T) {
+	v := NewPlanValidator()
+	if errs := v.ValidateRecurrence(PlanScheduleRRule, "FREQ=WEEKLY;COUNT=4", "Europe/London"); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs := v.ValidateRecurrence(PlanScheduleRRule, "", ""); len(errs) != 1 || errs[0].Field != "recurrenceRule" {
+		t.Fatalf("errors=%v", errs)
+	}
+	if errs := v.ValidateRecurrence(PlanScheduleByWeek, "FREQ=WEEKLY;COUNT=4", "Nowhere/City"); len(errs) != 2 {
+		t.Fatalf("errors=%v", errs)
+	}
+}
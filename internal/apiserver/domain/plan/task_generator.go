@@ -100,9 +100,24 @@ func (g *TaskGenerator) GenerateTasksAt(plan *AssessmentPlan, testeeID testee.ID
 			)
 			tasks = append(tasks, task)
 		}
+
+	case PlanScheduleRRule:
+		// RRULE/EXDATE 日历规则，以 startDate 作为 DTSTART 展开
+		for i, date := range recurrenceDates(plan, startDate) {
+			task := NewAssessmentTaskAt(
+				plan.GetID(),
+				i+1,
+				plan.GetOrgID(),
+				testeeID,
+				plan.GetScaleCode(),
+				normalizeTaskPlannedAt(plan, date),
+				scheduleDefinedAt,
+			)
+			tasks = append(tasks, task)
+		}
 	}
 
-	return tasks
+	return bindPlanTimeZone(plan, tasks)
 }
 
 // GenerateTasksUntil 生成直到指定日期之前的任务（用于定时生成场景）
@@ -195,23 +210,67 @@ func (g *TaskGenerator) GenerateTasksUntil(plan *AssessmentPlan, testeeID testee
 				seq++
 			}
 		}
+
+	case PlanScheduleRRule:
+		// RRULE/EXDATE 日历规则，只保留截止日期之前的发生日
+		for _, date := range recurrenceDates(plan, startDate) {
+			plannedAt := normalizeTaskPlannedAt(plan, date)
+			if plannedAt.After(endDate) {
+				break
+			}
+			task := NewAssessmentTask(
+				plan.GetID(),
+				seq,
+				plan.GetOrgID(),
+				testeeID,
+				plan.GetScaleCode(),
+				plannedAt,
+			)
+			tasks = append(tasks, task)
+			seq++
+		}
 	}
 
+	return bindPlanTimeZone(plan, tasks)
+}
+
+// recurrenceDates 在计划时区内以 startDate 的日历日期为 DTSTART 展开 RRULE。
+func recurrenceDates(plan *AssessmentPlan, startDate time.Time) []time.Time {
+	if plan.recurrence == nil {
+		return nil
+	}
+	loc := plan.GetLocation()
+	if loc == nil {
+		loc = startDate.Location()
+	}
+	dtStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	return plan.recurrence.Occurrences(dtStart, plan.GetExceptionDates())
+}
+
+// bindPlanTimeZone 让任务继承计划时区，截止时间与入口有效期按该时区的自然日计算。
+func bindPlanTimeZone(plan *AssessmentPlan, tasks []*AssessmentTask) []*AssessmentTask {
+	if plan == nil || plan.GetTimeZone() == "" {
+		return tasks
+	}
+	for _, task := range tasks {
+		task.applyTimeZone(plan.GetTimeZone())
+	}
 	return tasks
 }
 
 func normalizeTaskPlannedAt(plan *AssessmentPlan, t time.Time) time.Time {
 	if plan == nil {
-		return normalizeTaskPlannedAtWithTriggerTime(DefaultPlanTriggerTime, t)
+		return normalizeTaskPlannedAtIn(DefaultPlanTriggerTime, t, nil)
 	}
-	return normalizeTaskPlannedAtWithTriggerTime(plan.GetTriggerTime(), t)
+	return normalizeTaskPlannedAtIn(plan.GetTriggerTime(), t, plan.GetLocation())
 }
 
-func normalizeTaskPlannedAtWithTriggerTime(triggerTime string, t time.Time) time.Time {
-	plannedAt, err := ApplyPlanTriggerTime(t, triggerTime)
+// normalizeTaskPlannedAtIn 在 loc 时区内套用触发时间；loc 为空时沿用 t 自身的时区。
+func normalizeTaskPlannedAtIn(triggerTime string, t time.Time, loc *time.Location) time.Time {
+	plannedAt, err := ApplyPlanTriggerTimeIn(t, triggerTime, loc)
 	if err == nil {
 		return plannedAt
 	}
-	fallback, _ := ApplyPlanTriggerTime(t, DefaultPlanTriggerTime)
+	fallback, _ := ApplyPlanTriggerTimeIn(t, DefaultPlanTriggerTime, loc)
 	return fallback
 }
@@ -26,7 +26,7 @@ func (l *TaskLifecycle) Open(ctx context.Context, task *AssessmentTask, entryTok
 }
 
 func (l *TaskLifecycle) OpenAt(ctx context.Context, task *AssessmentTask, entryToken string, entryURL string, actionAt time.Time) error {
-	expireAt := TaskEntryExpiresAt(actionAt)
+	expireAt := TaskEntryExpiresAtIn(actionAt, task.BusinessLocation())
 	taskID := task.GetID().String()
 	logger.L(ctx).Infow("Opening task in domain service",
 		"domain_action", "open_task",
@@ -31,8 +31,44 @@ func NormalizePlanTriggerTime(raw string) (string, error) {
 	return "", ErrInvalidTriggerTime
 }
 
+// NormalizePlanTimeZone 校验并规范 IANA 时区名；空值表示沿用历史行为（不指定时区）。
+// "Local" 依赖服务器配置，不允许作为计划时区持久化。
+func NormalizePlanTimeZone(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	if strings.EqualFold(raw, "Local") {
+		return "", ErrInvalidTimeZone
+	}
+	loc, err := time.LoadLocation(raw)
+	if err != nil {
+		return "", ErrInvalidTimeZone
+	}
+	return loc.String(), nil
+}
+
+// LoadPlanLocation 返回计划时区对应的 Location；未指定或无法加载时返回 nil。
+func LoadPlanLocation(timeZone string) *time.Location {
+	normalized, err := NormalizePlanTimeZone(timeZone)
+	if err != nil || normalized == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(normalized)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
 // ApplyPlanTriggerTime 将日期部分保留，并把时间部分替换为 plan 的触发时间。
 func ApplyPlanTriggerTime(base time.Time, triggerTime string) (time.Time, error) {
+	return ApplyPlanTriggerTimeIn(base, triggerTime, base.Location())
+}
+
+// ApplyPlanTriggerTimeIn 取 base 的日历日期，在 loc 时区内按触发时间构造计划时间点。
+// 例如 base=2026-04-03、triggerTime=08:30、loc=America/New_York，得到纽约当地 08:30。
+func ApplyPlanTriggerTimeIn(base time.Time, triggerTime string, loc *time.Location) (time.Time, error) {
 	normalized, err := NormalizePlanTriggerTime(triggerTime)
 	if err != nil {
 		return time.Time{}, err
@@ -43,7 +79,9 @@ func ApplyPlanTriggerTime(base time.Time, triggerTime string) (time.Time, error)
 		return time.Time{}, err
 	}
 
-	loc := base.Location()
+	if loc == nil {
+		loc = base.Location()
+	}
 	if loc == nil {
 		loc = time.Local
 	}
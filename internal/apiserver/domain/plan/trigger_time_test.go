@@ -50,3 +50,36 @@ func TestApplyPlanTriggerTime(t *testing.T) {
 		t.Fatalf("expected %s, got %s", want, got)
 	}
 }
+
+func TestApplyPlanTriggerTimeInKeepsCalendarDateOfBase(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 25, 0, 0, 0, 0, shanghai)
+
+	got, err := ApplyPlanTriggerTimeIn(base, "19:00", london)
+	if err != nil {
+		t.Fatalf("ApplyPlanTriggerTimeIn returned error: %v", err)
+	}
+	want := time.Date(2026, 10, 25, 19, 0, 0, 0, london)
+	if !got.Equal(want) || got.Location() != london {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestNormalizePlanTimeZone(t *testing.T) {
+	if got, err := NormalizePlanTimeZone(" America/Chicago "); err != nil || got != "America/Chicago" {
+		t.Fatalf("NormalizePlanTimeZone() = %q, %v", got, err)
+	}
+	if got, err := NormalizePlanTimeZone(""); err != nil || got != "" {
+		t.Fatalf("empty time zone = %q, %v", got, err)
+	}
+	if _, err := NormalizePlanTimeZone("Not/AZone"); err == nil {
+		t.Fatal("expected error for unknown time zone")
+	}
+}
@@ -120,6 +120,8 @@ const (
 	PlanScheduleFixedDate PlanScheduleType = "fixed_date"
 	// PlanScheduleCustom 自定义周次（如 2,4,8,12）
 	PlanScheduleCustom PlanScheduleType = "custom"
+	// PlanScheduleRRule iCalendar RRULE/EXDATE 规则（如隔周二、四，每月第一个周一）
+	PlanScheduleRRule PlanScheduleType = "rrule"
 )
 
 // String 返回周期类型的字符串表示
@@ -138,6 +140,8 @@ func (t PlanScheduleType) DisplayName() string {
 		return "固定日期"
 	case PlanScheduleCustom:
 		return "自定义周次"
+	case PlanScheduleRRule:
+		return "日历规则"
 	default:
 		return string(t)
 	}
@@ -146,7 +150,7 @@ func (t PlanScheduleType) DisplayName() string {
 // IsValid 检查周期类型是否有效
 func (t PlanScheduleType) IsValid() bool {
 	switch t {
-	case PlanScheduleByWeek, PlanScheduleByDay, PlanScheduleFixedDate, PlanScheduleCustom:
+	case PlanScheduleByWeek, PlanScheduleByDay, PlanScheduleFixedDate, PlanScheduleCustom, PlanScheduleRRule:
 		return true
 	default:
 		return false
@@ -195,7 +199,16 @@ func (r TaskExpirationReason) IsValid() bool {
 // TaskDueAt is the stable fulfillment deadline. The conversion is explicit so
 // callers holding UTC timestamps still receive seven Shanghai calendar days.
 func TaskDueAt(plannedAt time.Time) time.Time {
-	return plannedAt.In(taskBusinessLocation).AddDate(0, 0, TaskFulfillmentDays)
+	return TaskDueAtIn(plannedAt, taskBusinessLocation)
+}
+
+// TaskDueAtIn counts the fulfillment calendar days in the plan's time zone so
+// a DST transition inside the window does not shift the deadline clock time.
+func TaskDueAtIn(plannedAt time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = taskBusinessLocation
+	}
+	return plannedAt.In(loc).AddDate(0, 0, TaskFulfillmentDays)
 }
 
 func TaskOpenWindowEndsAt(plannedAt time.Time) time.Time {
@@ -203,7 +216,15 @@ func TaskOpenWindowEndsAt(plannedAt time.Time) time.Time {
 }
 
 func TaskEntryExpiresAt(openAt time.Time) time.Time {
-	return openAt.In(taskBusinessLocation).AddDate(0, 0, TaskEntryValidityDays)
+	return TaskEntryExpiresAtIn(openAt, taskBusinessLocation)
+}
+
+// TaskEntryExpiresAtIn counts the entry validity calendar days in loc.
+func TaskEntryExpiresAtIn(openAt time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = taskBusinessLocation
+	}
+	return openAt.In(loc).AddDate(0, 0, TaskEntryValidityDays)
 }
 
 const (
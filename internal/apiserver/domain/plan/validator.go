@@ -138,6 +138,11 @@ func (v *PlanValidator) ValidateScheduleStrategy(
 				}
 			}
 		}
+	case PlanScheduleRRule:
+		// RRULE 本身由 ValidateRecurrence 校验，这里只约束 totalTimes 上限
+		if totalTimes > MaxRecurrenceOccurrences {
+			errs = append(errs, ValidationError{Field: "totalTimes", Message: "总次数不能超过100次"})
+		}
 	default:
 		errs = append(errs, ValidationError{
 			Field:   "scheduleType",
@@ -147,6 +152,33 @@ func (v *PlanValidator) ValidateScheduleStrategy(
 	return errs
 }
 
+// ValidateRecurrence 验证日历规则与计划时区
+// rrule 类型必须提供可解析且有界（COUNT/UNTIL）的 RRULE；其他类型不允许携带 RRULE。
+func (v *PlanValidator) ValidateRecurrence(
+	scheduleType PlanScheduleType,
+	recurrenceRule string,
+	timeZone string,
+) []ValidationError {
+	var errs []ValidationError
+
+	if _, err := NormalizePlanTimeZone(timeZone); err != nil {
+		errs = append(errs, ValidationError{Field: "timeZone", Message: fmt.Sprintf("无效的 IANA 时区: %s", timeZone)})
+	}
+
+	hasRule := strings.TrimSpace(recurrenceRule) != ""
+	switch {
+	case scheduleType == PlanScheduleRRule && !hasRule:
+		errs = append(errs, ValidationError{Field: "recurrenceRule", Message: "日历规则不能为空"})
+	case scheduleType == PlanScheduleRRule:
+		if _, err := ParseRecurrenceRule(recurrenceRule); err != nil {
+			errs = append(errs, ValidationError{Field: "recurrenceRule", Message: err.Error()})
+		}
+	case hasRule:
+		errs = append(errs, ValidationError{Field: "recurrenceRule", Message: "仅 rrule 周期类型支持日历规则"})
+	}
+	return errs
+}
+
 // ToError 将验证错误列表转换为单个 error
 func ToError(errs []ValidationError) error {
 	if len(errs) == 0 {
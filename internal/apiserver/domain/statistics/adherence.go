@@ -0,0 +1,123 @@
+package statistics
+
+import "time"
+
+// AdherenceOutcome classifies one AssessmentTask lifecycle for adherence
+// analytics. Canceled tasks are kept in the projection but excluded from every
+// denominator so that plan edits do not look like non-adherence.
+type AdherenceOutcome string
+
+const (
+	AdherenceOnTime   AdherenceOutcome = "on_time"
+	AdherenceLate     AdherenceOutcome = "late"
+	AdherenceExpired  AdherenceOutcome = "expired"
+	AdherencePending  AdherenceOutcome = "pending"
+	AdherenceCanceled AdherenceOutcome = "canceled"
+)
+
+// AdherenceTask is the lifecycle input mirrored by PlanAdherenceProjection's SQL.
+type AdherenceTask struct {
+	DueAt       *time.Time
+	CompletedAt *time.Time
+	ExpiredAt   *time.Time
+	Canceled    bool
+}
+
+// ClassifyAdherenceOutcome is the executable outcome contract. A completion at
+// exactly due_at is on time; an uncompleted task counts as expired once it has
+// an expiry fact or its due_at is before the projection cutoff.
+func ClassifyAdherenceOutcome(task AdherenceTask, cutoff time.Time) AdherenceOutcome {
+	switch {
+	case task.Canceled:
+		return AdherenceCanceled
+	case task.CompletedAt != nil:
+		if task.DueAt != nil && task.CompletedAt.After(*task.DueAt) {
+			return AdherenceLate
+		}
+		return AdherenceOnTime
+	case task.ExpiredAt != nil, task.DueAt != nil && task.DueAt.Before(cutoff):
+		return AdherenceExpired
+	default:
+		return AdherencePending
+	}
+}
+
+// AdherenceCounts 任务履约计数；比例均为百分数。
+type AdherenceCounts struct {
+	TaskCount    int64 `json:"task_count"`
+	OnTimeCount  int64 `json:"on_time_count"`
+	LateCount    int64 `json:"late_count"`
+	ExpiredCount int64 `json:"expired_count"`
+	PendingCount int64 `json:"pending_count"`
+}
+
+// ResolvedCount returns tasks whose outcome is final: completed or expired.
+func (c AdherenceCounts) ResolvedCount() int64 {
+	return c.OnTimeCount + c.LateCount + c.ExpiredCount
+}
+
+// CompletionRate uses resolved tasks as the denominator so that occurrences
+// which are not yet due do not depress the rate of a young cohort.
+func (c AdherenceCounts) CompletionRate() float64 {
+	return percent(c.OnTimeCount+c.LateCount, c.ResolvedCount())
+}
+
+func (c AdherenceCounts) OnTimeRate() float64 {
+	return percent(c.OnTimeCount, c.ResolvedCount())
+}
+
+func percent(numerator, denominator int64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return float64(numerator) * 100 / float64(denominator)
+}
+
+// DropoutCohort groups enrollments by the last enrollment week in which they
+// completed a task (-1 when they never completed one) and by how many whole
+// weeks have elapsed since enrollment at the snapshot date.
+type DropoutCohort struct {
+	LastCompletedWeek int   `json:"last_completed_week"`
+	ObservedWeeks     int   `json:"observed_weeks"`
+	EnrollmentCount   int64 `json:"enrollment_count"`
+}
+
+// DropoutPoint 入组后第 Week 周的留存：仍在观察期内的入组数与其中在该周及之后仍有完成记录的入组数。
+type DropoutPoint struct {
+	Week          int     `json:"week"`
+	EligibleCount int64   `json:"eligible_count"`
+	RetainedCount int64   `json:"retained_count"`
+	RetentionRate float64 `json:"retention_rate"`
+	DropoutRate   float64 `json:"dropout_rate"`
+}
+
+// BuildDropoutCurve computes a right-censored retention curve: an enrollment
+// only contributes to week w once w whole weeks have elapsed, so recent
+// enrollments are not reported as dropouts for weeks they could not reach.
+func BuildDropoutCurve(cohorts []DropoutCohort) []DropoutPoint {
+	maxWeek := -1
+	for _, cohort := range cohorts {
+		if cohort.EnrollmentCount > 0 && cohort.ObservedWeeks > maxWeek {
+			maxWeek = cohort.ObservedWeeks
+		}
+	}
+	points := make([]DropoutPoint, 0, maxWeek+1)
+	for week := 0; week <= maxWeek; week++ {
+		point := DropoutPoint{Week: week}
+		for _, cohort := range cohorts {
+			if cohort.ObservedWeeks < week {
+				continue
+			}
+			point.EligibleCount += cohort.EnrollmentCount
+			if cohort.LastCompletedWeek >= week {
+				point.RetainedCount += cohort.EnrollmentCount
+			}
+		}
+		point.RetentionRate = percent(point.RetainedCount, point.EligibleCount)
+		if point.EligibleCount > 0 {
+			point.DropoutRate = 100 - point.RetentionRate
+		}
+		points = append(points, point)
+	}
+	return points
+}
@@ -0,0 +1,65 @@
+package statistics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClassifyAdherenceOutcomeBoundaries(t *testing.T) {
+	due := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+	atDue, afterDue := due, due.Add(time.Nanosecond)
+	cutoff := due.Add(24 * time.Hour)
+	cases := []struct {
+		name string
+		task AdherenceTask
+		want AdherenceOutcome
+	}{
+		{"completed at due", AdherenceTask{DueAt: &due, CompletedAt: &atDue}, AdherenceOnTime},
+		{"completed after due", AdherenceTask{DueAt: &due, CompletedAt: &afterDue}, AdherenceLate},
+		{"completed without due", AdherenceTask{CompletedAt: &afterDue}, AdherenceOnTime},
+		{"expired fact", AdherenceTask{ExpiredAt: &afterDue}, AdherenceExpired},
+		{"due before cutoff", AdherenceTask{DueAt: &due}, AdherenceExpired},
+		{"due after cutoff", AdherenceTask{DueAt: &cutoff}, AdherencePending},
+		{"canceled wins", AdherenceTask{DueAt: &due, CompletedAt: &atDue, Canceled: true}, AdherenceCanceled},
+	}
+	for _, tc := range cases {
+		if got := ClassifyAdherenceOutcome(tc.task, cutoff); got != tc.want {
+			t.Fatalf("%s: outcome=%s want=%s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAdherenceCountsRatesIgnorePendingTasks(t *testing.T) {
+	counts := AdherenceCounts{TaskCount: 10, OnTimeCount: 3, LateCount: 1, ExpiredCount: 4, PendingCount: 2}
+	if got := counts.CompletionRate(); got != 50 {
+		t.Fatalf("completion_rate=%v", got)
+	}
+	if got := counts.OnTimeRate(); got != 37.5 {
+		t.Fatalf("on_time_rate=%v", got)
+	}
+	if got := (AdherenceCounts{PendingCount: 3}).CompletionRate(); got != 0 {
+		t.Fatalf("empty completion_rate=%v", got)
+	}
+}
+
+func TestBuildDropoutCurveIsRightCensored(t *testing.T) {
+	got := BuildDropoutCurve([]DropoutCohort{
+		{LastCompletedWeek: -1, ObservedWeeks: 3, EnrollmentCount: 1}, // 从未完成
+		{LastCompletedWeek: 1, ObservedWeeks: 3, EnrollmentCount: 2},  // 第 2 周流失
+		{LastCompletedWeek: 3, ObservedWeeks: 3, EnrollmentCount: 1},  // 全程坚持
+		{LastCompletedWeek: 0, ObservedWeeks: 0, EnrollmentCount: 4},  // 本周刚入组
+	})
+	want := []DropoutPoint{
+		{Week: 0, EligibleCount: 8, RetainedCount: 7, RetentionRate: 87.5, DropoutRate: 12.5},
+		{Week: 1, EligibleCount: 4, RetainedCount: 3, RetentionRate: 75, DropoutRate: 25},
+		{Week: 2, EligibleCount: 4, RetainedCount: 1, RetentionRate: 25, DropoutRate: 75},
+		{Week: 3, EligibleCount: 4, RetainedCount: 1, RetentionRate: 25, DropoutRate: 75},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("curve=%+v\nwant=%+v", got, want)
+	}
+	if curve := BuildDropoutCurve(nil); len(curve) != 0 {
+		t.Fatalf("empty curve=%+v", curve)
+	}
+}
@@ -0,0 +1,53 @@
+package answersheet
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+)
+
+// CriticalItem 命中关键条目规则的答案
+type CriticalItem struct {
+	QuestionCode string
+	Category     questionnaire.CriticalCategory
+	// Trigger 命中的触发条件：选项编码、数值或关键词，不含答案原文
+	Trigger string
+}
+
+// DetectCriticalItems 按问卷题目上的关键条目规则检查答案，按答案顺序返回命中项。
+// 只依赖问卷与答案本身，提交时同步执行，不依赖测评模型评估。
+func DetectCriticalItems(questions []questionnaire.Question, answers []Answer) []CriticalItem {
+	rules := make(map[string]*questionnaire.CriticalRule)
+	for _, question := range questions {
+		if rule := question.GetCriticalRule(); !rule.IsEmpty() {
+			rules[question.GetCode().Value()] = rule
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	var items []CriticalItem
+	for _, answer := range answers {
+		rule, ok := rules[answer.QuestionCode()]
+		if !ok || answer.Value() == nil {
+			continue
+		}
+		if trigger, matched := rule.Match(answer.Value().Raw()); matched {
+			items = append(items, CriticalItem{
+				QuestionCode: answer.QuestionCode(),
+				Category:     rule.Category,
+				Trigger:      trigger,
+			})
+		}
+	}
+	return items
+}
+
+// FlagCriticalItems 记录提交时命中的关键条目并产生 AnswerSheetCriticalItemFlaggedEvent。
+// 事件与 AnswerSheetSubmittedEvent 一起由提交事务暂存到 outbox；没有命中项时不产生事件。
+func (a *AnswerSheet) FlagCriticalItems(items []CriticalItem, flaggedAt time.Time) {
+	if len(items) == 0 {
+		return
+	}
+	a.addEvent(NewAnswerSheetCriticalItemFlaggedEvent(a, items, flaggedAt))
+}
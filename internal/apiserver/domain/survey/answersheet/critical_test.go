@@ -0,0 +1,109 @@
+package answersheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/survey/questionnaire"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func TestDetectCriticalItemsMatchesOptionNumberAndKeywordRules(t *testing.T) {
+	t.Parallel()
+
+	threshold := 8.0
+	questions := []questionnaire.Question{
+		mustQuestion(t, questionnaire.WithCode(meta.NewCode("Q1")), questionnaire.WithStem("近两周是否有伤害自己的想法"),
+			questionnaire.WithQuestionType(questionnaire.TypeRadio), questionnaire.WithOption("A", "没有", 0), questionnaire.WithOption("B", "有", 3),
+			questionnaire.WithCriticalRule(questionnaire.NewCriticalRule(questionnaire.CriticalCategorySelfHarm, []meta.Code{"B"}, nil, nil))),
+		mustQuestion(t, questionnaire.WithCode(meta.NewCode("Q2")), questionnaire.WithStem("痛苦程度"),
+			questionnaire.WithQuestionType(questionnaire.TypeNumber),
+			questionnaire.WithCriticalRule(questionnaire.NewCriticalRule(questionnaire.CriticalCategoryOther, nil, &threshold, nil))),
+		mustQuestion(t, questionnaire.WithCode(meta.NewCode("Q3")), questionnaire.WithStem("还有什么想告诉我们"),
+			questionnaire.WithQuestionType(questionnaire.TypeTextarea),
+			questionnaire.WithCriticalRule(questionnaire.NewCriticalRule(questionnaire.CriticalCategoryAbuse, nil, nil, []string{"打我"}))),
+		mustQuestion(t, questionnaire.WithCode(meta.NewCode("Q4")), questionnaire.WithStem("睡眠"),
+			questionnaire.WithQuestionType(questionnaire.TypeRadio), questionnaire.WithOption("A", "好", 0)),
+	}
+	answers := []Answer{
+		mustAnswerOf(t, "Q1", questionnaire.TypeRadio, NewOptionValue("B")),
+		mustAnswerOf(t, "Q2", questionnaire.TypeNumber, NewNumberValue(6)),
+		mustAnswerOf(t, "Q3", questionnaire.TypeTextarea, NewStringValue("爸爸喝醉了会打我")),
+		mustAnswerOf(t, "Q4", questionnaire.TypeRadio, NewOptionValue("A")),
+	}
+
+	items := DetectCriticalItems(questions, answers)
+	if len(items) != 2 {
+		t.Fatalf("items = %+v, want Q1 and Q3", items)
+	}
+	if items[0].QuestionCode != "Q1" || items[0].Category != questionnaire.CriticalCategorySelfHarm || items[0].Trigger != "B" {
+		t.Fatalf("option item = %+v", items[0])
+	}
+	if items[1].QuestionCode != "Q3" || items[1].Category != questionnaire.CriticalCategoryAbuse || items[1].Trigger != "打我" {
+		t.Fatalf("keyword item = %+v; trigger must be the keyword, not the answer text", items[1])
+	}
+}
+
+func TestFlagCriticalItemsRaisesEventAfterSubmitted(t *testing.T) {
+	t.Parallel()
+
+	flaggedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	sheet, err := Submit(meta.FromUint64(1001), mustQuestionnaireRef(t), mustSubmissionContext(t), []Answer{mustAnswer(t)}, flaggedAt)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	sheet.FlagCriticalItems(nil, flaggedAt)
+	if got := len(sheet.Events()); got != 1 {
+		t.Fatalf("event count without critical items = %d, want 1", got)
+	}
+
+	sheet.FlagCriticalItems([]CriticalItem{{QuestionCode: "Q1", Category: questionnaire.CriticalCategorySelfHarm, Trigger: "A"}}, flaggedAt)
+	events := sheet.Events()
+	if len(events) != 2 || events[0].EventType() != EventTypeSubmitted {
+		t.Fatalf("events = %+v", events)
+	}
+	evt, ok := events[1].(AnswerSheetCriticalItemFlaggedEvent)
+	if !ok {
+		t.Fatalf("event type = %T, want AnswerSheetCriticalItemFlaggedEvent", events[1])
+	}
+	payload := evt.Payload()
+	if payload.AnswerSheetID != "1001" || payload.OrgID != 501 || payload.TesteeID != 401 || payload.FillerID != 301 ||
+		len(payload.Items) != 1 || payload.Items[0].Category != "self_harm" || !payload.FlaggedAt.Equal(flaggedAt) {
+		t.Fatalf("critical payload = %+v", payload)
+	}
+}
+
+func TestCriticalRuleRejectsConditionForOtherQuestionType(t *testing.T) {
+	t.Parallel()
+
+	_, err := questionnaire.NewQuestion(questionnaire.WithCode(meta.NewCode("Q1")), questionnaire.WithStem("stem"),
+		questionnaire.WithQuestionType(questionnaire.TypeRadio), questionnaire.WithOption("A", "a", 0),
+		questionnaire.WithCriticalRule(questionnaire.NewCriticalRule(questionnaire.CriticalCategorySelfHarm, []meta.Code{"Z"}, nil, nil)))
+	if err == nil {
+		t.Fatal("NewQuestion() error = nil, want unknown critical option error")
+	}
+	_, err = questionnaire.NewQuestion(questionnaire.WithCode(meta.NewCode("Q2")), questionnaire.WithStem("stem"),
+		questionnaire.WithQuestionType(questionnaire.TypeText),
+		questionnaire.WithCriticalRule(questionnaire.NewCriticalRule(questionnaire.CriticalCategory("unknown"), nil, nil, []string{"x"})))
+	if err == nil {
+		t.Fatal("NewQuestion() error = nil, want unsupported category error")
+	}
+}
+
+func mustQuestion(t *testing.T, opts ...questionnaire.QuestionParamsOption) questionnaire.Question {
+	t.Helper()
+	question, err := questionnaire.NewQuestion(opts...)
+	if err != nil {
+		t.Fatalf("NewQuestion() error = %v", err)
+	}
+	return question
+}
+
+func mustAnswerOf(t *testing.T, code string, typ questionnaire.QuestionType, value AnswerValue) Answer {
+	t.Helper()
+	answer, err := NewAnswer(meta.NewCode(code), typ, value, 0)
+	if err != nil {
+		t.Fatalf("NewAnswer() error = %v", err)
+	}
+	return answer
+}
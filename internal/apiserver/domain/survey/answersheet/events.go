@@ -2,6 +2,7 @@ package answersheet
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/FangcunMount/component-base/pkg/event"
 	"github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
@@ -15,6 +16,8 @@ import (
 const (
 	// EventTypeSubmitted 答卷已提交
 	EventTypeSubmitted = eventcatalog.AnswerSheetSubmitted
+	// EventTypeCriticalItemFlagged 答卷命中关键条目
+	EventTypeCriticalItemFlagged = eventcatalog.AnswerSheetCriticalItemFlagged
 )
 
 // AggregateType 聚合根类型
@@ -25,11 +28,17 @@ const AggregateType = "AnswerSheet"
 // AnswerSheetSubmittedData 答卷已提交事件数据
 type AnswerSheetSubmittedData = eventpayload.AnswerSheetSubmittedData
 
+// AnswerSheetCriticalItemFlaggedData 答卷命中关键条目事件数据
+type AnswerSheetCriticalItemFlaggedData = eventpayload.AnswerSheetCriticalItemFlaggedData
+
 // ==================== 事件类型别名 ====================
 
 // AnswerSheetSubmittedEvent 答卷已提交事件
 type AnswerSheetSubmittedEvent = event.Event[AnswerSheetSubmittedData]
 
+// AnswerSheetCriticalItemFlaggedEvent 答卷命中关键条目事件
+type AnswerSheetCriticalItemFlaggedEvent = event.Event[AnswerSheetCriticalItemFlaggedData]
+
 // ==================== 事件构造函数 ====================
 
 // NewAnswerSheetSubmittedEvent 构造答卷提交事件。
@@ -75,3 +84,42 @@ func NewAnswerSheetSubmittedEvent(sheet *AnswerSheet) AnswerSheetSubmittedEvent
 		},
 	)
 }
+
+// NewAnswerSheetCriticalItemFlaggedEvent 构造答卷命中关键条目事件。
+func NewAnswerSheetCriticalItemFlaggedEvent(sheet *AnswerSheet, items []CriticalItem, flaggedAt time.Time) AnswerSheetCriticalItemFlaggedEvent {
+	code, ver, _ := sheet.QuestionnaireInfo()
+	fillerID, err := safeconv.Int64ToUint64(sheet.Filler().UserID())
+	if err != nil {
+		panic(fmt.Errorf("answersheet filler id: %w", err))
+	}
+	submissionContext := sheet.SubmissionContext()
+	testeeID, err := safeconv.MetaIDToUint64(submissionContext.TesteeID())
+	if err != nil {
+		panic(fmt.Errorf("answersheet testee id: %w", err))
+	}
+	orgID, err := safeconv.MetaIDToUint64(submissionContext.OrgID())
+	if err != nil {
+		panic(fmt.Errorf("answersheet org id: %w", err))
+	}
+	payloadItems := make([]eventpayload.CriticalItemData, 0, len(items))
+	for _, item := range items {
+		payloadItems = append(payloadItems, eventpayload.CriticalItemData{
+			QuestionCode: item.QuestionCode,
+			Category:     string(item.Category),
+			Trigger:      item.Trigger,
+		})
+	}
+
+	return event.New(EventTypeCriticalItemFlagged, AggregateType, sheet.ID().String(),
+		AnswerSheetCriticalItemFlaggedData{
+			AnswerSheetID:        sheet.ID().String(),
+			QuestionnaireCode:    code,
+			QuestionnaireVersion: ver,
+			OrgID:                orgID,
+			TesteeID:             testeeID,
+			FillerID:             fillerID,
+			Items:                payloadItems,
+			FlaggedAt:            flaggedAt,
+		},
+	)
+}
@@ -0,0 +1,142 @@
+package questionnaire
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+// CriticalCategory 关键条目类别
+type CriticalCategory string
+
+const (
+	// CriticalCategorySelfHarm 自伤、自杀意念
+	CriticalCategorySelfHarm CriticalCategory = "self_harm"
+	// CriticalCategoryAbuse 受虐、受暴力披露
+	CriticalCategoryAbuse CriticalCategory = "abuse"
+	// CriticalCategoryOther 其他需要立即关注的条目
+	CriticalCategoryOther CriticalCategory = "other"
+)
+
+// IsValid 是否为支持的类别
+func (c CriticalCategory) IsValid() bool {
+	switch c {
+	case CriticalCategorySelfHarm, CriticalCategoryAbuse, CriticalCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// CriticalRule 关键条目规则
+// 答案命中规则时，答卷提交即标记关键条目，不依赖测评模型评估是否成功或排队。
+// 三类条件按题型使用，命中任一即视为命中。
+type CriticalRule struct {
+	// Category 关键条目类别
+	Category CriticalCategory `json:"category"`
+
+	// OptionCodes 选中其中任一选项即命中（单选题、多选题）
+	OptionCodes []meta.Code `json:"option_codes,omitempty"`
+
+	// MinValue 答案数值不小于该值即命中（数字题）
+	MinValue *float64 `json:"min_value,omitempty"`
+
+	// Keywords 答案文本包含其中任一关键词即命中（文本题、文本域题）
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// NewCriticalRule 创建关键条目规则
+func NewCriticalRule(category CriticalCategory, optionCodes []meta.Code, minValue *float64, keywords []string) *CriticalRule {
+	return &CriticalRule{
+		Category:    category,
+		OptionCodes: optionCodes,
+		MinValue:    minValue,
+		Keywords:    keywords,
+	}
+}
+
+// IsEmpty 判断规则是否未配置任何条件
+func (r *CriticalRule) IsEmpty() bool {
+	return r == nil || (len(r.OptionCodes) == 0 && r.MinValue == nil && len(r.Keywords) == 0)
+}
+
+// Match 判断答案原始值是否命中规则。
+// 返回命中的触发条件（选项编码、数值或关键词），不返回答案原文，避免自由文本随事件扩散。
+func (r *CriticalRule) Match(raw any) (string, bool) {
+	if r.IsEmpty() || raw == nil {
+		return "", false
+	}
+	switch value := raw.(type) {
+	case string:
+		if r.hasOption(value) {
+			return value, true
+		}
+		for _, keyword := range r.Keywords {
+			if keyword != "" && strings.Contains(value, keyword) {
+				return keyword, true
+			}
+		}
+	case []string:
+		for _, selected := range value {
+			if r.hasOption(selected) {
+				return selected, true
+			}
+		}
+	case float64:
+		if r.MinValue != nil && value >= *r.MinValue {
+			return strconv.FormatFloat(value, 'f', -1, 64), true
+		}
+	}
+	return "", false
+}
+
+func (r *CriticalRule) hasOption(code string) bool {
+	for _, option := range r.OptionCodes {
+		if option.Value() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// validate 按题型校验规则条件：选项条件只用于选择题且选项必须存在，数值条件只用于数字题，关键词只用于文本题。
+func (r *CriticalRule) validate(typ QuestionType, options []Option) error {
+	if !r.Category.IsValid() {
+		return newError(ErrorKindInvalidQuestion, "unsupported critical category: %s", string(r.Category))
+	}
+	if r.IsEmpty() {
+		return newError(ErrorKindInvalidQuestion, "critical rule requires at least one condition")
+	}
+	switch typ {
+	case TypeRadio, TypeCheckbox:
+		if r.MinValue != nil || len(r.Keywords) > 0 || len(r.OptionCodes) == 0 {
+			return newError(ErrorKindInvalidQuestion, "critical rule of %s question only supports option codes", string(typ))
+		}
+		for _, code := range r.OptionCodes {
+			if !containsOption(options, code) {
+				return newError(ErrorKindInvalidQuestion, "critical option %s not found", code.Value())
+			}
+		}
+	case TypeNumber:
+		if r.MinValue == nil || len(r.OptionCodes) > 0 || len(r.Keywords) > 0 {
+			return newError(ErrorKindInvalidQuestion, "critical rule of number question only supports min value")
+		}
+	case TypeText, TypeTextarea:
+		if len(r.Keywords) == 0 || len(r.OptionCodes) > 0 || r.MinValue != nil {
+			return newError(ErrorKindInvalidQuestion, "critical rule of %s question only supports keywords", string(typ))
+		}
+	default:
+		return newError(ErrorKindInvalidQuestion, "%s question does not support critical rule", string(typ))
+	}
+	return nil
+}
+
+func containsOption(options []Option, code meta.Code) bool {
+	for _, option := range options {
+		if option.GetCode() == code {
+			return true
+		}
+	}
+	return false
+}
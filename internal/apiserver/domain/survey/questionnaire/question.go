@@ -30,6 +30,9 @@ type Question interface {
 
 	// 显示控制相关
 	GetShowController() *ShowController
+
+	// 关键条目规则（未配置时返回 nil）
+	GetCriticalRule() *CriticalRule
 }
 
 // HasOptions 带选项的问题接口
@@ -59,6 +62,7 @@ type QuestionCore struct {
 	stem           string
 	tips           string
 	showController *ShowController
+	criticalRule   *CriticalRule
 }
 
 // Get*** 方法实现
@@ -77,6 +81,9 @@ func (q *QuestionCore) GetCalculationRule() *calculation.CalculationRule {
 func (q *QuestionCore) GetShowController() *ShowController {
 	return q.showController
 }
+func (q *QuestionCore) GetCriticalRule() *CriticalRule {
+	return q.criticalRule
+}
 
 // ============ 具体题型实现 ============
 
@@ -335,6 +342,11 @@ func (b *QuestionParams) Validate() error {
 	if b.core.typ == "" {
 		return newError(ErrorKindInvalidQuestion, "question type is required")
 	}
+	if b.core.criticalRule != nil {
+		if err := b.core.criticalRule.validate(b.core.typ, b.options); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -405,6 +417,11 @@ func WithShowController(showController *ShowController) QuestionParamsOption {
 		b.core.showController = showController
 	}
 }
+func WithCriticalRule(criticalRule *CriticalRule) QuestionParamsOption {
+	return func(b *QuestionParams) {
+		b.core.criticalRule = criticalRule
+	}
+}
 
 // 便捷的校验规则选项
 func WithRequired() QuestionParamsOption {
@@ -0,0 +1,41 @@
+// Package criticalitem 工作台关键条目队列：答卷提交时命中问卷关键条目规则的投影，以答卷 ID 为条目主体。
+// 投影只记录命中的题目编码、类别与触发条件，不含答案原文。
+package criticalitem
+
+import (
+	"sort"
+	"time"
+)
+
+// Hit 命中的关键条目；Trigger 为命中的选项编码、数值或关键词，不含答案原文。
+type Hit struct {
+	QuestionCode string
+	Category     string
+	Trigger      string
+}
+
+// Flag 答卷命中关键条目的工作台投影。
+type Flag struct {
+	AnswerSheetID        uint64
+	OrgID                int64
+	TesteeID             uint64
+	QuestionnaireCode    string
+	QuestionnaireVersion string
+	Items                []Hit
+	FlaggedAt            time.Time
+}
+
+// Categories 命中条目的类别，去重并按字典序排列。
+func (f Flag) Categories() []string {
+	seen := make(map[string]struct{}, len(f.Items))
+	categories := make([]string, 0, len(f.Items))
+	for _, item := range f.Items {
+		if _, ok := seen[item.Category]; ok || item.Category == "" {
+			continue
+		}
+		seen[item.Category] = struct{}{}
+		categories = append(categories, item.Category)
+	}
+	sort.Strings(categories)
+	return categories
+}
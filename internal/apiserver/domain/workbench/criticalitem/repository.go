@@ -0,0 +1,9 @@
+package criticalitem
+
+import "context"
+
+// Repository 关键条目投影仓储接口。
+type Repository interface {
+	// RecordCriticalItemFlag 记录关键条目投影；同一答卷重复投递时必须幂等。
+	RecordCriticalItemFlag(ctx context.Context, flag Flag) error
+}
@@ -0,0 +1,129 @@
+// Package triage 工作台队列条目的分诊：认领、暂缓、解决、重新打开与高风险条目的认领超时升级。
+// 每个条目的分诊状态带乐观锁版本，每次状态变化都追加一条分诊历史。
+package triage
+
+import "time"
+
+// QueueType 工作台队列类型。
+type QueueType string
+
+const (
+	QueueTypeHighRisk QueueType = "high_risk"
+	QueueTypeFollowUp QueueType = "follow_up"
+	QueueTypeKeyFocus QueueType = "key_focus"
+	// QueueTypeAwaitingReview 已生成报告、尚未签署临床复核的测评。
+	QueueTypeAwaitingReview QueueType = "awaiting_review"
+	// QueueTypeCriticalItem 提交时命中问卷关键条目的答卷，不依赖测评模型评估。
+	QueueTypeCriticalItem QueueType = "critical_item"
+)
+
+// Status 队列条目的分诊状态。没有分诊记录的条目视为 open。
+type Status string
+
+const (
+	StatusOpen     Status = "open"
+	StatusClaimed  Status = "claimed"
+	StatusSnoozed  Status = "snoozed"
+	StatusResolved Status = "resolved"
+)
+
+// Action 分诊历史中的动作。
+type Action string
+
+const (
+	ActionClaim   Action = "claim"
+	ActionSnooze  Action = "snooze"
+	ActionResolve Action = "resolve"
+	ActionReopen  Action = "reopen"
+	// ActionEscalate 高风险条目超过认领时限仍未认领，由系统升级。
+	ActionEscalate Action = "escalate"
+)
+
+// ResolutionCode 解决条目时的结论代码。
+type ResolutionCode string
+
+const (
+	ResolutionContacted            ResolutionCode = "contacted"
+	ResolutionAppointmentScheduled ResolutionCode = "appointment_scheduled"
+	ResolutionReferred             ResolutionCode = "referred"
+	ResolutionFalsePositive        ResolutionCode = "false_positive"
+	ResolutionNoActionNeeded       ResolutionCode = "no_action_needed"
+	// ResolutionOther 其他结论，必须填写说明。
+	ResolutionOther ResolutionCode = "other"
+)
+
+// Valid 是否为支持的结论代码。
+func (c ResolutionCode) Valid() bool {
+	switch c {
+	case ResolutionContacted, ResolutionAppointmentScheduled, ResolutionReferred,
+		ResolutionFalsePositive, ResolutionNoActionNeeded, ResolutionOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actor 分诊操作人；机构管理员视角下操作人可能未绑定从业者，ClinicianID 为 0。
+type Actor struct {
+	UserID      int64
+	ClinicianID uint64
+	Name        string
+}
+
+// Item 队列条目的分诊状态。SubjectID 为条目主体：high_risk、awaiting_review 为测评 ID，
+// follow_up 为任务 ID，key_focus 为受试者 ID。
+type Item struct {
+	ID             uint64
+	OrgID          int64
+	QueueType      QueueType
+	SubjectID      uint64
+	TesteeID       uint64
+	Status         Status
+	ClaimedBy      *Actor
+	ClaimedAt      *time.Time
+	SnoozedUntil   *time.Time
+	ResolutionCode ResolutionCode
+	ResolutionNote string
+	ResolvedBy     *Actor
+	ResolvedAt     *time.Time
+	// EscalatedAt 超过认领时限被升级的时间；重新打开后保留，历史中可见。
+	EscalatedAt *time.Time
+	// Version 乐观锁版本；0 表示尚无分诊记录。
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EffectiveStatus 暂缓到期后视为 open。
+func (i Item) EffectiveStatus(now time.Time) Status {
+	if i.Status == StatusSnoozed && (i.SnoozedUntil == nil || !i.SnoozedUntil.After(now)) {
+		return StatusOpen
+	}
+	if i.Status == "" {
+		return StatusOpen
+	}
+	return i.Status
+}
+
+// Event 分诊历史；Operator 为空表示系统动作（SLA 升级）。
+type Event struct {
+	ID           uint64
+	OrgID        int64
+	ItemID       uint64
+	Action       Action
+	FromStatus   Status
+	ToStatus     Status
+	Operator     *Actor
+	ReasonCode   string
+	Note         string
+	SnoozedUntil *time.Time
+	OccurredAt   time.Time
+}
+
+// EscalationCandidate 超过认领时限仍未认领、尚未升级的高风险条目。
+type EscalationCandidate struct {
+	OrgID        int64
+	AssessmentID uint64
+	TesteeID     uint64
+	OccurredAt   time.Time
+}
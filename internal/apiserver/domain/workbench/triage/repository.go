@@ -0,0 +1,21 @@
+package triage
+
+import (
+	"context"
+	"time"
+)
+
+// Repository 分诊状态与历史仓储接口。
+type Repository interface {
+	FindTriageItem(ctx context.Context, orgID int64, queueType QueueType, subjectID uint64) (*Item, error)
+	ListTriageItems(ctx context.Context, orgID int64, queueType QueueType, subjectIDs []uint64) ([]Item, error)
+	ListTriageEvents(ctx context.Context, orgID int64, itemID uint64) ([]Event, error)
+	// SaveTriageItem 按 expectedVersion 乐观锁保存条目并在同一事务中追加历史；expectedVersion 为 0 表示新建。
+	// 返回 false 表示条目已被并发修改。
+	SaveTriageItem(ctx context.Context, item *Item, expectedVersion int, event *Event) (bool, error)
+	// FindSubjectTesteeID 校验条目主体属于该机构并返回所属受试者 ID；不存在时返回 0。
+	FindSubjectTesteeID(ctx context.Context, orgID int64, queueType QueueType, subjectID uint64) (uint64, error)
+	// ListEscalationCandidates 跨机构列出 dueBefore 之前进入高风险队列、仍未认领且未升级的条目；
+	// 暂缓中（snoozed_until 晚于 now）或已解决的条目不在其中。
+	ListEscalationCandidates(ctx context.Context, dueBefore, now time.Time, limit int) ([]EscalationCandidate, error)
+}
@@ -13,7 +13,15 @@ import (
 	"github.com/FangcunMount/qs-server/internal/pkg/eventing/runtime"
 )
 
-const hotRankConsumerID = "modelcatalog.hot_rank_projection"
+const (
+	hotRankConsumerID      = "modelcatalog.hot_rank_projection"
+	criticalItemConsumerID = "workbench.critical_item_projection"
+	reportPDFConsumerID    = "interpretation.report_pdf_render"
+
+	planReportCompletedConsumerID = "interpretation.plan_report_on_task_completed"
+	planReportExpiredConsumerID   = "interpretation.plan_report_on_task_expired"
+	planReportCanceledConsumerID  = "interpretation.plan_report_on_task_canceled"
+)
 
 type fakePublisher struct{}
 
@@ -113,6 +121,8 @@ func TestSubsystemStartCloseAreIdempotentAndSettleProjectionMessages(t *testing.
 	s, err := New(Options{
 		Catalog: loadCatalog(t), PublisherMode: eventruntime.PublishModeMQ, MQPublisher: fakePublisher{},
 		SubscriberFactory: func() (messaging.Subscriber, error) { return subscriber, nil },
+		Consumers: map[string]ConsumerOptions{criticalItemConsumerID: {Enabled: false}, reportPDFConsumerID: {Enabled: false},
+			planReportCompletedConsumerID: {Enabled: false}, planReportExpiredConsumerID: {Enabled: false}, planReportCanceledConsumerID: {Enabled: false}},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -160,6 +170,8 @@ func TestProjectionHandlerFailureNacksOnlyItsMessage(t *testing.T) {
 	s, err := New(Options{
 		Catalog: loadCatalog(t), PublisherMode: eventruntime.PublishModeMQ, MQPublisher: fakePublisher{},
 		SubscriberFactory: func() (messaging.Subscriber, error) { return subscriber, nil },
+		Consumers: map[string]ConsumerOptions{criticalItemConsumerID: {Enabled: false}, reportPDFConsumerID: {Enabled: false},
+			planReportCompletedConsumerID: {Enabled: false}, planReportExpiredConsumerID: {Enabled: false}, planReportCanceledConsumerID: {Enabled: false}},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -190,6 +202,8 @@ func TestProjectionDecodeFailureNacksMessage(t *testing.T) {
 	s, err := New(Options{
 		Catalog: loadCatalog(t), PublisherMode: eventruntime.PublishModeMQ, MQPublisher: fakePublisher{},
 		SubscriberFactory: func() (messaging.Subscriber, error) { return subscriber, nil },
+		Consumers: map[string]ConsumerOptions{criticalItemConsumerID: {Enabled: false}, reportPDFConsumerID: {Enabled: false},
+			planReportCompletedConsumerID: {Enabled: false}, planReportExpiredConsumerID: {Enabled: false}, planReportCanceledConsumerID: {Enabled: false}},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -222,6 +236,8 @@ func TestProjectionDecodeFailureReturnsNackError(t *testing.T) {
 	s, err := New(Options{
 		Catalog: loadCatalog(t), PublisherMode: eventruntime.PublishModeMQ, MQPublisher: fakePublisher{},
 		SubscriberFactory: func() (messaging.Subscriber, error) { return subscriber, nil },
+		Consumers: map[string]ConsumerOptions{criticalItemConsumerID: {Enabled: false}, reportPDFConsumerID: {Enabled: false},
+			planReportCompletedConsumerID: {Enabled: false}, planReportExpiredConsumerID: {Enabled: false}, planReportCanceledConsumerID: {Enabled: false}},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -269,8 +285,13 @@ func TestLoggingModeReportsProjectionConsumerDisabled(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(status.Consumers) != 1 || status.Consumers[0].Enabled {
-		t.Fatalf("logging consumer status = %#v, want disabled", status.Consumers)
+	if len(status.Consumers) != 6 {
+		t.Fatalf("logging consumer status = %#v, want hot-rank, critical-item, report-pdf and plan-report consumers", status.Consumers)
+	}
+	for _, consumer := range status.Consumers {
+		if consumer.Enabled {
+			t.Fatalf("logging consumer status = %#v, want disabled", status.Consumers)
+		}
 	}
 	for _, profile := range status.Profiles {
 		if profile.Name == eventcatalog.OutboxProfileMongoDomain && (profile.RelayEnabled || profile.ImmediateEnabled) {
@@ -285,7 +306,8 @@ func TestSubsystemStartsLifecycleInPhasesAndClosesProfilesInReverseOrder(t *test
 	assessmentRelay := &fakeRelay{name: "assessment", recorder: recorder, started: make(chan struct{})}
 	s, err := New(Options{
 		Catalog: loadCatalog(t), PublisherMode: eventruntime.PublishModeMQ, MQPublisher: fakePublisher{},
-		Consumers: map[string]ConsumerOptions{hotRankConsumerID: {Enabled: false}},
+		Consumers: map[string]ConsumerOptions{hotRankConsumerID: {Enabled: false}, criticalItemConsumerID: {Enabled: false}, reportPDFConsumerID: {Enabled: false},
+			planReportCompletedConsumerID: {Enabled: false}, planReportExpiredConsumerID: {Enabled: false}, planReportCanceledConsumerID: {Enabled: false}},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -394,5 +394,8 @@ func interpretReportPOToReadRow(po *InterpretReportPO) readmodel.ReportRow {
 		return readmodel.ReportRow{}
 	}
 	archived := &ArchivedReportPO{BaseDocument: base.BaseDocument{DomainID: meta.FromUint64(po.AssessmentID), CreatedAt: po.GeneratedAt}, ScaleName: po.ScaleName, ScaleCode: po.ScaleCode, Model: po.Model, PrimaryScore: po.PrimaryScore, Level: po.Level, TotalScore: po.TotalScore, RiskLevel: po.RiskLevel, Conclusion: po.Conclusion, Dimensions: po.Dimensions, Suggestions: po.Suggestions, ModelExtra: po.ModelExtra, PresentationProfile: po.PresentationProfile}
-	return projectArchivedReportRow(archived)
+	row := projectArchivedReportRow(archived)
+	row.ReportID = po.DomainID.Uint64()
+	row.Locale = po.Locale
+	return row
 }
@@ -148,6 +148,7 @@ func (m *LifecycleMapper) ReportToPO(domain *domainreport.InterpretReport) *Inte
 		BuilderIdentity:      domain.BuilderIdentity(),
 		ContentSchemaVersion: domain.ContentSchemaVersion(),
 		GeneratedAt:          domain.GeneratedAt(),
+		Locale:               domain.Locale(),
 		OrgID:                association.OrgID,
 		AssessmentID:         association.AssessmentID.Uint64(),
 		TesteeID:             association.TesteeID,
@@ -171,6 +172,93 @@ func (m *LifecycleMapper) ReportToPO(domain *domainreport.InterpretReport) *Inte
 	return po
 }
 
+// VariantToPO stores a variant in the artifact layout keyed by its canonical report.
+func (m *LifecycleMapper) VariantToPO(variant *domainreport.AudienceVariant) *ReportVariantPO {
+	if variant == nil {
+		return nil
+	}
+	content := variant.Content()
+	association := variant.Association()
+	po := &ReportVariantPO{
+		InterpretReportPO: InterpretReportPO{
+			BaseDocument:         base.BaseDocument{DomainID: variant.ReportID(), CreatedAt: variant.GeneratedAt(), UpdatedAt: variant.GeneratedAt()},
+			GenerationID:         variant.GenerationID().Uint64(),
+			OutcomeID:            variant.OutcomeID().Uint64(),
+			ReportType:           variant.ReportType().String(),
+			TemplateVersion:      variant.TemplateVersion().String(),
+			BuilderIdentity:      variant.BuilderIdentity(),
+			ContentSchemaVersion: variant.ContentSchemaVersion(),
+			GeneratedAt:          variant.GeneratedAt(),
+			Locale:               variant.Locale(),
+			OrgID:                association.OrgID,
+			AssessmentID:         association.AssessmentID.Uint64(),
+			TesteeID:             association.TesteeID,
+			ScaleName:            content.Model.Title,
+			ScaleCode:            content.Model.Code,
+			Model:                modelIdentityToPO(content.Model),
+			PrimaryScore:         scoreValueToPO(content.PrimaryScore),
+			Level:                resultLevelToPO(content.Level),
+			Conclusion:           content.Conclusion,
+			Dimensions:           dimensionsToPO(content.Dimensions),
+			Suggestions:          toSuggestionPOs(content.Suggestions),
+			ModelExtra:           toModelExtraPO(content.ModelExtra),
+			PresentationProfile:  presentationProfileToPO(content.PresentationProfile),
+		},
+		Audience: string(variant.Audience()),
+	}
+	if content.PrimaryScore != nil {
+		po.TotalScore = content.PrimaryScore.Value
+	}
+	if content.Level != nil && isArtifactRiskLevelCode(content.Level.Code) {
+		po.RiskLevel = content.Level.Code
+	}
+	return po
+}
+
+// LocalizedToPO stores an on-demand locale rendition in the artifact layout
+// keyed by its canonical report.
+func (m *LifecycleMapper) LocalizedToPO(localized *domainreport.LocalizedReport) *ReportLocalePO {
+	if localized == nil {
+		return nil
+	}
+	content := localized.Content()
+	association := localized.Association()
+	po := &ReportLocalePO{
+		InterpretReportPO: InterpretReportPO{
+			BaseDocument:         base.BaseDocument{DomainID: localized.ReportID(), CreatedAt: localized.RenderedAt(), UpdatedAt: localized.RenderedAt()},
+			GenerationID:         localized.GenerationID().Uint64(),
+			OutcomeID:            localized.OutcomeID().Uint64(),
+			ReportType:           localized.ReportType().String(),
+			TemplateVersion:      localized.TemplateVersion().String(),
+			BuilderIdentity:      localized.BuilderIdentity(),
+			ContentSchemaVersion: localized.ContentSchemaVersion(),
+			GeneratedAt:          localized.RenderedAt(),
+			Locale:               localized.Locale(),
+			OrgID:                association.OrgID,
+			AssessmentID:         association.AssessmentID.Uint64(),
+			TesteeID:             association.TesteeID,
+			ScaleName:            content.Model.Title,
+			ScaleCode:            content.Model.Code,
+			Model:                modelIdentityToPO(content.Model),
+			PrimaryScore:         scoreValueToPO(content.PrimaryScore),
+			Level:                resultLevelToPO(content.Level),
+			Conclusion:           content.Conclusion,
+			Dimensions:           dimensionsToPO(content.Dimensions),
+			Suggestions:          toSuggestionPOs(content.Suggestions),
+			ModelExtra:           toModelExtraPO(content.ModelExtra),
+			PresentationProfile:  presentationProfileToPO(content.PresentationProfile),
+		},
+		Audience: string(localized.Audience()),
+	}
+	if content.PrimaryScore != nil {
+		po.TotalScore = content.PrimaryScore.Value
+	}
+	if content.Level != nil && isArtifactRiskLevelCode(content.Level.Code) {
+		po.RiskLevel = content.Level.Code
+	}
+	return po
+}
+
 func isArtifactRiskLevelCode(code string) bool {
 	switch code {
 	case "none", "low", "medium", "high", "severe":
@@ -204,6 +292,7 @@ func (m *LifecycleMapper) ReportToDomain(po *InterpretReportPO) (*domainreport.I
 			ModelExtra:          toDomainModelExtra(po.ModelExtra),
 			PresentationProfile: presentationProfileToDomain(po.PresentationProfile),
 		},
+		Locale:      po.Locale,
 		GeneratedAt: po.GeneratedAt,
 	})
 	if err != nil {
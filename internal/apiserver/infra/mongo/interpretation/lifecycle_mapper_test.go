@@ -85,6 +85,36 @@ func TestLifecycleMapperRoundTripsThreeInterpretationObjects(t *testing.T) {
 	if restoredArtifact.BuilderIdentity() != domainreport.BuilderIdentityFactorScoring || restoredArtifact.ContentSchemaVersion() != domainreport.ContentSchemaVersionV1 {
 		t.Fatalf("artifact provenance round trip = %q/%q", restoredArtifact.BuilderIdentity(), restoredArtifact.ContentSchemaVersion())
 	}
+
+	familyContent, err := domainreport.AdaptContentForAudience(artifact.Content(), policy.ReportAudienceFamily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	variant, err := domainreport.NewAudienceVariant(artifact, policy.ReportAudienceFamily, familyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	variantPO := mapper.VariantToPO(variant)
+	row := interpretReportPOToReadRow(&variantPO.InterpretReportPO)
+	if variantPO.DomainID != artifact.ID() || variantPO.Audience != "family" || row.ReportID != 3 || row.AssessmentID != 7 {
+		t.Fatalf("variant po = %#v row = %#v", variantPO, row)
+	}
+	if len(row.Dimensions) != 1 || len(row.Dimensions[0].DerivedScores) != 0 || row.Dimensions[0].NormReference != nil {
+		t.Fatalf("family variant row carries clinical scores: %#v", row.Dimensions)
+	}
+	if restoredArtifact.Locale() != "zh-CN" || row.Locale != "zh-CN" {
+		t.Fatalf("artifact locale = %q, variant row locale = %q", restoredArtifact.Locale(), row.Locale)
+	}
+
+	localized, err := domainreport.NewLocalizedReport(artifact, policy.ReportAudienceFamily, "en-US", familyContent, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	localePO := mapper.LocalizedToPO(localized)
+	localeRow := interpretReportPOToReadRow(&localePO.InterpretReportPO)
+	if localePO.DomainID != artifact.ID() || localePO.Audience != "family" || localeRow.Locale != "en-US" || localeRow.ReportID != 3 {
+		t.Fatalf("locale po = %#v row = %#v", localePO, localeRow)
+	}
 }
 
 func TestLifecycleMapperRestoresLegacyArtifactProvenance(t *testing.T) {
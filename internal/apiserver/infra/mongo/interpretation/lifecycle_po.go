@@ -70,6 +70,8 @@ type InterpretReportPO struct {
 	BuilderIdentity      string    `bson:"builder_identity,omitempty"`
 	ContentSchemaVersion string    `bson:"content_schema_version,omitempty"`
 	GeneratedAt          time.Time `bson:"generated_at"`
+	// Locale 为空的是语言上线前生成的报告，视为 locale.Default。
+	Locale string `bson:"locale,omitempty"`
 
 	// Frozen Outcome correlation doubles as the query envelope. It is a value
 	// snapshot, not an Assessment aggregate reference.
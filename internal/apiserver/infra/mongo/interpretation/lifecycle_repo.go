@@ -363,6 +363,7 @@ func reportIndexModels() []mongo.IndexModel {
 		{Keys: bson.D{{Key: "outcome_id", Value: 1}, {Key: "report_type", Value: 1}, {Key: "template_version", Value: 1}}, Options: options.Index().SetName("idx_artifact_outcome_type_version")},
 		{Keys: bson.D{{Key: "assessment_id", Value: 1}, {Key: "generated_at", Value: -1}}, Options: options.Index().SetName("idx_artifact_assessment_generated")},
 		{Keys: bson.D{{Key: "testee_id", Value: 1}, {Key: "generated_at", Value: -1}}, Options: options.Index().SetName("idx_artifact_testee_generated")},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "model.code", Value: 1}, {Key: "outcome_id", Value: 1}}, Options: options.Index().SetName("idx_artifact_org_model_outcome")},
 	}
 }
 
@@ -0,0 +1,80 @@
+package interpretation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	base "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo"
+	readmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportLocalePO stores one on-demand locale rendition of an immutable report.
+// It reuses the artifact layout; DomainID is the canonical report id and
+// (domain_id, audience, locale) is unique. Audience is empty for the canonical
+// content.
+type ReportLocalePO struct {
+	InterpretReportPO `bson:",inline"`
+
+	Audience string `bson:"audience"`
+}
+
+func (ReportLocalePO) CollectionName() string { return "interpret_report_locales" }
+
+// ReportLocaleRepository 写入并读取按需渲染的其他语言正文；正文渲染一次后不再修改。
+type ReportLocaleRepository struct {
+	base.BaseRepository
+	mapper *LifecycleMapper
+}
+
+func NewReportLocaleRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportLocaleRepository, error) {
+	repo := &ReportLocaleRepository{BaseRepository: base.NewBaseRepository(db, (ReportLocalePO{}).CollectionName(), opts...), mapper: NewLifecycleMapper()}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportLocaleIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report locale indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportLocaleIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain_id", Value: 1}, {Key: "audience", Value: 1}, {Key: "locale", Value: 1}}, Options: options.Index().SetName("uk_locale_report_audience_locale").SetUnique(true)},
+		{Keys: bson.D{{Key: "testee_id", Value: 1}}, Options: options.Index().SetName("idx_locale_testee")},
+	}
+}
+
+var (
+	_ domainreport.LocalizedReportRepository = (*ReportLocaleRepository)(nil)
+	_ readmodel.LocalizedReportReader        = (*ReportLocaleRepository)(nil)
+)
+
+func (r *ReportLocaleRepository) Insert(ctx context.Context, localized *domainreport.LocalizedReport) error {
+	po := r.mapper.LocalizedToPO(localized)
+	if po == nil {
+		return fmt.Errorf("interpretation report locale is required")
+	}
+	if _, err := r.InsertOne(ctx, po); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("insert interpretation report locale: %w", domainreport.ErrInterpretReportAlreadyExists)
+		}
+		return fmt.Errorf("insert interpretation report locale: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportLocaleRepository) FindLocalizedReport(ctx context.Context, reportID uint64, audience, locale string) (*readmodel.ReportRow, error) {
+	var po ReportLocalePO
+	err := r.FindOne(ctx, bson.M{"domain_id": reportID, "audience": audience, "locale": locale, "deleted_at": nil}, &po)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find interpretation report locale: %w", err)
+	}
+	row := interpretReportPOToReadRow(&po.InterpretReportPO)
+	row.Audience = po.Audience
+	return &row, nil
+}
@@ -0,0 +1,463 @@
+package interpretation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
+	base "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	reportRegenerationBatchCollection = "interpretation_report_regeneration_batches"
+	reportRegenerationItemCollection  = "interpretation_report_regeneration_items"
+)
+
+// ReportRegenerationBatchPO is the Mongo document for one regeneration batch.
+// RequestID is omitted for batches started outside a governed action, so the
+// partial unique index only guards action replays.
+type ReportRegenerationBatchPO struct {
+	DomainID      uint64     `bson:"domain_id"`
+	RequestID     string     `bson:"request_id,omitempty"`
+	OrgID         int64      `bson:"org_id"`
+	ModelCode     string     `bson:"model_code"`
+	ModelVersion  string     `bson:"model_version,omitempty"`
+	GeneratedFrom *time.Time `bson:"generated_from,omitempty"`
+	GeneratedTo   *time.Time `bson:"generated_to,omitempty"`
+	TemplateID    string     `bson:"template_id"`
+	TargetVersion string     `bson:"target_version"`
+	Estimated     int64      `bson:"estimated"`
+	Status        string     `bson:"status"`
+	Selected      int        `bson:"selected"`
+	Generated     int        `bson:"generated"`
+	Skipped       int        `bson:"skipped"`
+	Failed        int        `bson:"failed"`
+	Pending       int        `bson:"pending"`
+	Cursor        uint64     `bson:"cursor"`
+	Version       uint64     `bson:"version"`
+	Reason        string     `bson:"reason"`
+	RequestedBy   string     `bson:"requested_by"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at"`
+	ClosedAt      *time.Time `bson:"closed_at,omitempty"`
+	ClosedBy      string     `bson:"closed_by,omitempty"`
+	CloseReason   string     `bson:"close_reason,omitempty"`
+}
+
+func (ReportRegenerationBatchPO) CollectionName() string { return reportRegenerationBatchCollection }
+
+// ReportRegenerationBatchRepository persists regeneration batches.
+type ReportRegenerationBatchRepository struct {
+	base.BaseRepository
+}
+
+func NewReportRegenerationBatchRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportRegenerationBatchRepository, error) {
+	repo := &ReportRegenerationBatchRepository{BaseRepository: base.NewBaseRepository(db, reportRegenerationBatchCollection, opts...)}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportRegenerationBatchIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report regeneration batch indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportRegenerationBatchIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain_id", Value: 1}}, Options: options.Index().SetName("uk_report_regeneration_batch_domain_id").SetUnique(true)},
+		{Keys: bson.D{{Key: "request_id", Value: 1}}, Options: options.Index().SetName("uk_report_regeneration_batch_request").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"request_id": bson.M{"$exists": true}})},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("idx_report_regeneration_batch_org")},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}, Options: options.Index().SetName("idx_report_regeneration_batch_status")},
+	}
+}
+
+var _ domainregeneration.BatchRepository = (*ReportRegenerationBatchRepository)(nil)
+
+func (r *ReportRegenerationBatchRepository) Create(ctx context.Context, batch *domainregeneration.Batch) error {
+	if batch == nil {
+		return fmt.Errorf("report regeneration batch is required")
+	}
+	if _, err := r.InsertOne(ctx, regenerationBatchToPO(batch)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domainregeneration.ErrBatchConflict
+		}
+		return fmt.Errorf("create report regeneration batch: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportRegenerationBatchRepository) Save(ctx context.Context, batch *domainregeneration.Batch, expectedVersion uint64) error {
+	if batch == nil || expectedVersion == 0 || batch.Version() <= expectedVersion {
+		return domainregeneration.ErrBatchConflict
+	}
+	po := regenerationBatchToPO(batch)
+	result, err := r.UpdateOne(ctx, bson.M{"domain_id": po.DomainID, "version": expectedVersion}, bson.M{"$set": bson.M{
+		"status": po.Status, "selected": po.Selected, "generated": po.Generated, "skipped": po.Skipped, "failed": po.Failed,
+		"pending": po.Pending, "cursor": po.Cursor, "version": po.Version, "updated_at": po.UpdatedAt,
+		"closed_at": po.ClosedAt, "closed_by": po.ClosedBy, "close_reason": po.CloseReason,
+	}})
+	if err != nil {
+		return fmt.Errorf("save report regeneration batch: %w", err)
+	}
+	if result.MatchedCount != 1 {
+		return domainregeneration.ErrBatchConflict
+	}
+	return nil
+}
+
+func (r *ReportRegenerationBatchRepository) FindByID(ctx context.Context, id meta.ID) (*domainregeneration.Batch, error) {
+	return r.findOne(ctx, bson.M{"domain_id": id.Uint64()})
+}
+
+func (r *ReportRegenerationBatchRepository) FindByRequestID(ctx context.Context, requestID string) (*domainregeneration.Batch, error) {
+	if requestID == "" {
+		return nil, domainregeneration.ErrBatchNotFound
+	}
+	return r.findOne(ctx, bson.M{"request_id": requestID})
+}
+
+func (r *ReportRegenerationBatchRepository) ListByOrg(ctx context.Context, orgID int64, limit int) ([]*domainregeneration.Batch, error) {
+	return r.list(ctx, bson.M{"org_id": orgID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "domain_id", Value: -1}}).SetLimit(int64(limit)))
+}
+
+// ListRunning returns running batches oldest first, so one large batch cannot
+// starve the ones started after it indefinitely once it drains.
+func (r *ReportRegenerationBatchRepository) ListRunning(ctx context.Context, limit int) ([]*domainregeneration.Batch, error) {
+	return r.list(ctx, bson.M{"status": string(domainregeneration.BatchStatusRunning)},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "domain_id", Value: 1}}).SetLimit(int64(limit)))
+}
+
+func (r *ReportRegenerationBatchRepository) list(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domainregeneration.Batch, error) {
+	cur, err := r.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list report regeneration batches: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	items := make([]*domainregeneration.Batch, 0)
+	for cur.Next(ctx) {
+		var po ReportRegenerationBatchPO
+		if err := cur.Decode(&po); err != nil {
+			return nil, err
+		}
+		item, err := regenerationBatchToDomain(&po)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, cur.Err()
+}
+
+func (r *ReportRegenerationBatchRepository) findOne(ctx context.Context, filter bson.M) (*domainregeneration.Batch, error) {
+	var po ReportRegenerationBatchPO
+	if err := r.FindOne(ctx, filter, &po); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainregeneration.ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("find report regeneration batch: %w", err)
+	}
+	return regenerationBatchToDomain(&po)
+}
+
+func regenerationBatchToPO(batch *domainregeneration.Batch) *ReportRegenerationBatchPO {
+	selector, progress := batch.Selector(), batch.Progress()
+	return &ReportRegenerationBatchPO{
+		DomainID: batch.ID().Uint64(), RequestID: batch.RequestID(), OrgID: selector.OrgID,
+		ModelCode: selector.ModelCode, ModelVersion: selector.ModelVersion,
+		GeneratedFrom: selector.GeneratedFrom, GeneratedTo: selector.GeneratedTo,
+		TemplateID: batch.TemplateID(), TargetVersion: batch.TargetVersion().String(), Estimated: batch.Estimated(),
+		Status: string(batch.Status()), Selected: progress.Selected, Generated: progress.Generated, Skipped: progress.Skipped,
+		Failed: progress.Failed, Pending: progress.Pending, Cursor: batch.Cursor().Uint64(), Version: batch.Version(),
+		Reason: batch.Reason(), RequestedBy: batch.RequestedBy(), CreatedAt: batch.CreatedAt(), UpdatedAt: batch.UpdatedAt(),
+		ClosedAt: batch.ClosedAt(), ClosedBy: batch.ClosedBy(), CloseReason: batch.CloseReason(),
+	}
+}
+
+func regenerationBatchToDomain(po *ReportRegenerationBatchPO) (*domainregeneration.Batch, error) {
+	batch, err := domainregeneration.RehydrateBatch(domainregeneration.PersistedBatch{
+		StartBatchInput: domainregeneration.StartBatchInput{
+			ID: meta.FromUint64(po.DomainID), RequestID: po.RequestID,
+			Selector: domainregeneration.Selector{
+				OrgID: po.OrgID, ModelCode: po.ModelCode, ModelVersion: po.ModelVersion,
+				GeneratedFrom: po.GeneratedFrom, GeneratedTo: po.GeneratedTo,
+			},
+			TemplateID: po.TemplateID, TargetVersion: policy.TemplateVersion(po.TargetVersion), Estimated: po.Estimated,
+			Reason: po.Reason, Actor: po.RequestedBy, At: po.CreatedAt,
+		},
+		Status: domainregeneration.BatchStatus(po.Status),
+		Progress: domainregeneration.Progress{
+			Selected: po.Selected, Generated: po.Generated, Skipped: po.Skipped, Failed: po.Failed, Pending: po.Pending,
+		},
+		Cursor: meta.FromUint64(po.Cursor), Version: po.Version, UpdatedAt: po.UpdatedAt,
+		ClosedAt: po.ClosedAt, ClosedBy: po.ClosedBy, CloseReason: po.CloseReason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("restore report regeneration batch: %w", err)
+	}
+	return batch, nil
+}
+
+// ReportRegenerationItemPO records one selected Outcome. It references reports
+// by id only and carries testee_id for subject-rights erasure.
+type ReportRegenerationItemPO struct {
+	BatchID          uint64     `bson:"batch_id"`
+	OutcomeID        uint64     `bson:"outcome_id"`
+	OrgID            int64      `bson:"org_id"`
+	AssessmentID     uint64     `bson:"assessment_id"`
+	TesteeID         uint64     `bson:"testee_id"`
+	PreviousReportID uint64     `bson:"previous_report_id"`
+	PreviousVersion  string     `bson:"previous_version"`
+	Status           string     `bson:"status"`
+	GenerationID     uint64     `bson:"generation_id,omitempty"`
+	ReportID         uint64     `bson:"report_id,omitempty"`
+	ChangedFields    int        `bson:"changed_fields"`
+	ChangedPaths     []string   `bson:"changed_paths,omitempty"`
+	Message          string     `bson:"message,omitempty"`
+	RetryAt          *time.Time `bson:"retry_at,omitempty"`
+	CreatedAt        time.Time  `bson:"created_at"`
+	UpdatedAt        time.Time  `bson:"updated_at"`
+}
+
+func (ReportRegenerationItemPO) CollectionName() string { return reportRegenerationItemCollection }
+
+// ReportRegenerationItemRepository persists regeneration items.
+type ReportRegenerationItemRepository struct {
+	base.BaseRepository
+}
+
+func NewReportRegenerationItemRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportRegenerationItemRepository, error) {
+	repo := &ReportRegenerationItemRepository{BaseRepository: base.NewBaseRepository(db, reportRegenerationItemCollection, opts...)}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportRegenerationItemIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report regeneration item indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportRegenerationItemIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "batch_id", Value: 1}, {Key: "outcome_id", Value: 1}}, Options: options.Index().SetName("uk_report_regeneration_item_outcome").SetUnique(true)},
+		{Keys: bson.D{{Key: "batch_id", Value: 1}, {Key: "status", Value: 1}, {Key: "retry_at", Value: 1}}, Options: options.Index().SetName("idx_report_regeneration_item_status")},
+		{Keys: bson.D{{Key: "testee_id", Value: 1}}, Options: options.Index().SetName("idx_report_regeneration_item_testee")},
+	}
+}
+
+var _ domainregeneration.ItemRepository = (*ReportRegenerationItemRepository)(nil)
+
+func (r *ReportRegenerationItemRepository) Insert(ctx context.Context, item *domainregeneration.Item) error {
+	if item == nil {
+		return fmt.Errorf("report regeneration item is required")
+	}
+	if _, err := r.InsertOne(ctx, regenerationItemToPO(item)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domainregeneration.ErrItemConflict
+		}
+		return fmt.Errorf("insert report regeneration item: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportRegenerationItemRepository) Save(ctx context.Context, item *domainregeneration.Item) error {
+	if item == nil {
+		return fmt.Errorf("report regeneration item is required")
+	}
+	po := regenerationItemToPO(item)
+	update := bson.M{"$set": bson.M{
+		"status": po.Status, "generation_id": po.GenerationID, "report_id": po.ReportID,
+		"changed_fields": po.ChangedFields, "changed_paths": po.ChangedPaths, "message": po.Message, "updated_at": po.UpdatedAt,
+	}}
+	if po.RetryAt != nil {
+		update["$set"].(bson.M)["retry_at"] = po.RetryAt
+	} else {
+		update["$unset"] = bson.M{"retry_at": ""}
+	}
+	result, err := r.UpdateOne(ctx, bson.M{"batch_id": po.BatchID, "outcome_id": po.OutcomeID}, update)
+	if err != nil {
+		return fmt.Errorf("save report regeneration item: %w", err)
+	}
+	if result.MatchedCount != 1 {
+		return fmt.Errorf("report regeneration item not found")
+	}
+	return nil
+}
+
+func (r *ReportRegenerationItemRepository) ListPending(ctx context.Context, batchID meta.ID, dueAt time.Time, limit int) ([]*domainregeneration.Item, error) {
+	return r.list(ctx, bson.M{
+		"batch_id": batchID.Uint64(), "status": string(domainregeneration.ItemStatusPending), "retry_at": bson.M{"$lte": dueAt},
+	}, options.Find().SetSort(bson.D{{Key: "retry_at", Value: 1}, {Key: "outcome_id", Value: 1}}).SetLimit(int64(limit)))
+}
+
+func (r *ReportRegenerationItemRepository) ListByBatch(ctx context.Context, batchID meta.ID, status domainregeneration.ItemStatus, limit int) ([]*domainregeneration.Item, error) {
+	filter := bson.M{"batch_id": batchID.Uint64()}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+	return r.list(ctx, filter, options.Find().SetSort(bson.D{{Key: "outcome_id", Value: 1}}).SetLimit(int64(limit)))
+}
+
+func (r *ReportRegenerationItemRepository) list(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domainregeneration.Item, error) {
+	cur, err := r.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list report regeneration items: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	items := make([]*domainregeneration.Item, 0)
+	for cur.Next(ctx) {
+		var po ReportRegenerationItemPO
+		if err := cur.Decode(&po); err != nil {
+			return nil, err
+		}
+		item, err := domainregeneration.RehydrateItem(domainregeneration.PersistedItem{
+			BatchID: meta.FromUint64(po.BatchID),
+			Candidate: domainregeneration.Candidate{
+				OutcomeID: meta.FromUint64(po.OutcomeID), OrgID: po.OrgID, AssessmentID: meta.FromUint64(po.AssessmentID),
+				TesteeID: po.TesteeID, PreviousReportID: meta.FromUint64(po.PreviousReportID),
+				PreviousVersion: policy.TemplateVersion(po.PreviousVersion),
+			},
+			Status: domainregeneration.ItemStatus(po.Status), GenerationID: meta.FromUint64(po.GenerationID),
+			ReportID: meta.FromUint64(po.ReportID), Diff: domainregeneration.DiffSummary{ChangedFields: po.ChangedFields, Paths: po.ChangedPaths},
+			Message: po.Message, RetryAt: po.RetryAt, CreatedAt: po.CreatedAt, UpdatedAt: po.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("restore report regeneration item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, cur.Err()
+}
+
+func regenerationItemToPO(item *domainregeneration.Item) *ReportRegenerationItemPO {
+	diff := item.Diff()
+	return &ReportRegenerationItemPO{
+		BatchID: item.BatchID().Uint64(), OutcomeID: item.OutcomeID().Uint64(), OrgID: item.OrgID(),
+		AssessmentID: item.AssessmentID().Uint64(), TesteeID: item.TesteeID(), PreviousReportID: item.PreviousReportID().Uint64(),
+		PreviousVersion: item.PreviousVersion().String(), Status: string(item.Status()), GenerationID: item.GenerationID().Uint64(),
+		ReportID: item.ReportID().Uint64(), ChangedFields: diff.ChangedFields, ChangedPaths: diff.Paths, Message: item.Message(),
+		RetryAt: item.RetryAt(), CreatedAt: item.CreatedAt(), UpdatedAt: item.UpdatedAt(),
+	}
+}
+
+// ReportRegenerationCandidateReader selects Outcomes from committed report
+// artifacts. Each Outcome is represented by its latest report; the date range
+// applies to its first report and Outcomes with any report under the target
+// release are excluded.
+type ReportRegenerationCandidateReader struct {
+	db *mongo.Database
+}
+
+func NewReportRegenerationCandidateReader(db *mongo.Database) *ReportRegenerationCandidateReader {
+	return &ReportRegenerationCandidateReader{db: db}
+}
+
+var _ domainregeneration.CandidateReader = (*ReportRegenerationCandidateReader)(nil)
+
+type regenerationCandidateRow struct {
+	OutcomeID       uint64 `bson:"_id"`
+	ReportID        uint64 `bson:"report_id"`
+	OrgID           int64  `bson:"org_id"`
+	AssessmentID    uint64 `bson:"assessment_id"`
+	TesteeID        uint64 `bson:"testee_id"`
+	TemplateVersion string `bson:"template_version"`
+}
+
+func (r *ReportRegenerationCandidateReader) Count(ctx context.Context, selector domainregeneration.Selector, target policy.TemplateVersion) (domainregeneration.Counts, error) {
+	if r == nil || r.db == nil {
+		return domainregeneration.Counts{}, fmt.Errorf("report regeneration candidate reader is not configured")
+	}
+	pipeline := append(regenerationOutcomePipeline(selector, 0), bson.D{{Key: "$group", Value: bson.M{
+		"_id":       nil,
+		"matched":   bson.M{"$sum": 1},
+		"on_target": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$in": bson.A{target.String(), "$versions"}}, 1, 0}}},
+	}}})
+	cur, err := r.db.Collection((InterpretReportPO{}).CollectionName()).Aggregate(ctx, pipeline)
+	if err != nil {
+		return domainregeneration.Counts{}, fmt.Errorf("count report regeneration candidates: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	var counts struct {
+		Matched  int64 `bson:"matched"`
+		OnTarget int64 `bson:"on_target"`
+	}
+	if cur.Next(ctx) {
+		if err := cur.Decode(&counts); err != nil {
+			return domainregeneration.Counts{}, err
+		}
+	}
+	return domainregeneration.Counts{Matched: counts.Matched, OnTarget: counts.OnTarget}, cur.Err()
+}
+
+func (r *ReportRegenerationCandidateReader) ListAfter(ctx context.Context, selector domainregeneration.Selector, target policy.TemplateVersion, after meta.ID, limit int) ([]domainregeneration.Candidate, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("report regeneration candidate reader is not configured")
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+	pipeline := append(regenerationOutcomePipeline(selector, after.Uint64()),
+		bson.D{{Key: "$match", Value: bson.M{"versions": bson.M{"$ne": target.String()}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+	cur, err := r.db.Collection((InterpretReportPO{}).CollectionName()).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("list report regeneration candidates: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	items := make([]domainregeneration.Candidate, 0, limit)
+	for cur.Next(ctx) {
+		var row regenerationCandidateRow
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		items = append(items, domainregeneration.Candidate{
+			OutcomeID: meta.FromUint64(row.OutcomeID), OrgID: row.OrgID, AssessmentID: meta.FromUint64(row.AssessmentID),
+			TesteeID: row.TesteeID, PreviousReportID: meta.FromUint64(row.ReportID),
+			PreviousVersion: policy.TemplateVersion(row.TemplateVersion),
+		})
+	}
+	return items, cur.Err()
+}
+
+// regenerationOutcomePipeline groups the selected artifacts per Outcome,
+// keeping the latest report, the first generation time and every release the
+// Outcome already has a report under.
+func regenerationOutcomePipeline(selector domainregeneration.Selector, after uint64) mongo.Pipeline {
+	match := bson.M{"org_id": selector.OrgID, "model.code": selector.ModelCode, "deleted_at": nil}
+	if selector.ModelVersion != "" {
+		match["model.version"] = selector.ModelVersion
+	}
+	if after != 0 {
+		match["outcome_id"] = bson.M{"$gt": after}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "outcome_id", Value: 1}, {Key: "generated_at", Value: -1}, {Key: "domain_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                "$outcome_id",
+			"report_id":          bson.M{"$first": "$domain_id"},
+			"org_id":             bson.M{"$first": "$org_id"},
+			"assessment_id":      bson.M{"$first": "$assessment_id"},
+			"testee_id":          bson.M{"$first": "$testee_id"},
+			"template_version":   bson.M{"$first": "$template_version"},
+			"first_generated_at": bson.M{"$min": "$generated_at"},
+			"versions":           bson.M{"$addToSet": "$template_version"},
+		}}},
+	}
+	generated := bson.M{}
+	if selector.GeneratedFrom != nil {
+		generated["$gte"] = *selector.GeneratedFrom
+	}
+	if selector.GeneratedTo != nil {
+		generated["$lt"] = *selector.GeneratedTo
+	}
+	if len(generated) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"first_generated_at": generated}}})
+	}
+	return pipeline
+}
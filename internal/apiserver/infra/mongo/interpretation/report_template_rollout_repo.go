@@ -0,0 +1,339 @@
+package interpretation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
+	base "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+const (
+	reportTemplateRolloutCollection   = "interpretation_report_template_rollouts"
+	reportTemplateShadowCollection    = "interpretation_report_template_shadow_comparisons"
+	reportTemplateShadowDefaultLimit  = 50
+	reportTemplateRolloutDefaultLimit = 100
+)
+
+// ReportTemplateRolloutPO is the Mongo document for one staged rollout.
+// EffectiveKey is set only while the rollout routes generations, so a partial
+// unique index keeps one effective rollout per stable release.
+type ReportTemplateRolloutPO struct {
+	DomainID         uint64     `bson:"domain_id"`
+	TemplateID       string     `bson:"template_id"`
+	StableVersion    string     `bson:"stable_version"`
+	CandidateVersion string     `bson:"candidate_version"`
+	Mode             string     `bson:"mode"`
+	Percent          int        `bson:"percent"`
+	OrgIDs           []int64    `bson:"org_ids,omitempty"`
+	Status           string     `bson:"status"`
+	EffectiveKey     string     `bson:"effective_key,omitempty"`
+	Version          uint64     `bson:"version"`
+	Reason           string     `bson:"reason"`
+	StartedAt        time.Time  `bson:"started_at"`
+	StartedBy        string     `bson:"started_by"`
+	ClosedAt         *time.Time `bson:"closed_at,omitempty"`
+	ClosedBy         string     `bson:"closed_by,omitempty"`
+	CloseReason      string     `bson:"close_reason,omitempty"`
+}
+
+func (ReportTemplateRolloutPO) CollectionName() string { return reportTemplateRolloutCollection }
+
+// ReportTemplateRolloutRepository persists staged template rollouts.
+type ReportTemplateRolloutRepository struct {
+	base.BaseRepository
+}
+
+func NewReportTemplateRolloutRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportTemplateRolloutRepository, error) {
+	repo := &ReportTemplateRolloutRepository{BaseRepository: base.NewBaseRepository(db, reportTemplateRolloutCollection, opts...)}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportTemplateRolloutIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report template rollout indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportTemplateRolloutIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain_id", Value: 1}}, Options: options.Index().SetName("uk_report_template_rollout_domain_id").SetUnique(true)},
+		{Keys: bson.D{{Key: "effective_key", Value: 1}}, Options: options.Index().SetName("uk_report_template_rollout_effective").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"effective_key": bson.M{"$exists": true}})},
+		{Keys: bson.D{{Key: "template_id", Value: 1}, {Key: "started_at", Value: -1}}, Options: options.Index().SetName("idx_report_template_rollout_template")},
+	}
+}
+
+var _ domainreporttemplate.RolloutRepository = (*ReportTemplateRolloutRepository)(nil)
+
+func (r *ReportTemplateRolloutRepository) Create(ctx context.Context, rollout *domainreporttemplate.Rollout) error {
+	if rollout == nil {
+		return fmt.Errorf("report template rollout is required")
+	}
+	if _, err := r.InsertOne(ctx, rolloutToPO(rollout)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domainreporttemplate.ErrRolloutConflict
+		}
+		return fmt.Errorf("create report template rollout: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportTemplateRolloutRepository) Save(ctx context.Context, rollout *domainreporttemplate.Rollout, expectedVersion uint64) error {
+	if rollout == nil || expectedVersion == 0 || rollout.Version() <= expectedVersion {
+		return domainreporttemplate.ErrRolloutConflict
+	}
+	po := rolloutToPO(rollout)
+	update := bson.M{"$set": bson.M{
+		"status": po.Status, "version": po.Version, "closed_at": po.ClosedAt, "closed_by": po.ClosedBy, "close_reason": po.CloseReason,
+	}}
+	if po.EffectiveKey == "" {
+		update["$unset"] = bson.M{"effective_key": ""}
+	}
+	result, err := r.UpdateOne(ctx, bson.M{"domain_id": po.DomainID, "version": expectedVersion}, update)
+	if err != nil {
+		return fmt.Errorf("save report template rollout: %w", err)
+	}
+	if result.MatchedCount != 1 {
+		return domainreporttemplate.ErrRolloutConflict
+	}
+	return nil
+}
+
+func (r *ReportTemplateRolloutRepository) FindByID(ctx context.Context, id meta.ID) (*domainreporttemplate.Rollout, error) {
+	return r.findOne(ctx, bson.M{"domain_id": id.Uint64()})
+}
+
+func (r *ReportTemplateRolloutRepository) FindEffective(ctx context.Context, templateID string, stable policy.TemplateVersion) (*domainreporttemplate.Rollout, error) {
+	return r.findOne(ctx, bson.M{"effective_key": rolloutEffectiveKey(templateID, stable)})
+}
+
+func (r *ReportTemplateRolloutRepository) ListByTemplateID(ctx context.Context, templateID string, limit int) ([]*domainreporttemplate.Rollout, error) {
+	if limit <= 0 || limit > reportTemplateRolloutDefaultLimit {
+		limit = reportTemplateRolloutDefaultLimit
+	}
+	cur, err := r.Find(ctx, bson.M{"template_id": templateID},
+		options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}, {Key: "domain_id", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list report template rollouts: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	items := make([]*domainreporttemplate.Rollout, 0)
+	for cur.Next(ctx) {
+		var po ReportTemplateRolloutPO
+		if err := cur.Decode(&po); err != nil {
+			return nil, err
+		}
+		item, err := rolloutToDomain(&po)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, cur.Err()
+}
+
+func (r *ReportTemplateRolloutRepository) findOne(ctx context.Context, filter bson.M) (*domainreporttemplate.Rollout, error) {
+	var po ReportTemplateRolloutPO
+	if err := r.FindOne(ctx, filter, &po); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainreporttemplate.ErrRolloutNotFound
+		}
+		return nil, fmt.Errorf("find report template rollout: %w", err)
+	}
+	return rolloutToDomain(&po)
+}
+
+func rolloutEffectiveKey(templateID string, stable policy.TemplateVersion) string {
+	return templateID + "@" + stable.String()
+}
+
+func rolloutToPO(rollout *domainreporttemplate.Rollout) *ReportTemplateRolloutPO {
+	po := &ReportTemplateRolloutPO{
+		DomainID: rollout.ID().Uint64(), TemplateID: rollout.TemplateID(),
+		StableVersion: rollout.StableVersion().String(), CandidateVersion: rollout.CandidateVersion().String(),
+		Mode: string(rollout.Mode()), Percent: rollout.Percent(), OrgIDs: rollout.OrgIDs(), Status: string(rollout.Status()),
+		Version: rollout.Version(), Reason: rollout.Reason(), StartedAt: rollout.StartedAt(), StartedBy: rollout.StartedBy(),
+		ClosedAt: rollout.ClosedAt(), ClosedBy: rollout.ClosedBy(), CloseReason: rollout.CloseReason(),
+	}
+	if rollout.Status().IsEffective() {
+		po.EffectiveKey = rolloutEffectiveKey(rollout.TemplateID(), rollout.StableVersion())
+	}
+	return po
+}
+
+func rolloutToDomain(po *ReportTemplateRolloutPO) (*domainreporttemplate.Rollout, error) {
+	rollout, err := domainreporttemplate.RehydrateRollout(domainreporttemplate.PersistedRollout{
+		StartRolloutInput: domainreporttemplate.StartRolloutInput{
+			ID: meta.FromUint64(po.DomainID), TemplateID: po.TemplateID,
+			StableVersion: policy.TemplateVersion(po.StableVersion), CandidateVersion: policy.TemplateVersion(po.CandidateVersion),
+			Mode: domainreporttemplate.RolloutMode(po.Mode), Percent: po.Percent, OrgIDs: po.OrgIDs,
+			Reason: po.Reason, Actor: po.StartedBy, At: po.StartedAt,
+		},
+		Status: domainreporttemplate.RolloutStatus(po.Status), Version: po.Version,
+		ClosedAt: po.ClosedAt, ClosedBy: po.ClosedBy, CloseReason: po.CloseReason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("restore report template rollout: %w", err)
+	}
+	return rollout, nil
+}
+
+// ReportTemplateShadowComparisonPO stores one candidate draft and its diff. The
+// candidate content keeps the artifact layout but lives outside the report
+// collections, so no read path can serve it.
+type ReportTemplateShadowComparisonPO struct {
+	DomainID                 uint64                  `bson:"domain_id"`
+	RolloutID                uint64                  `bson:"rollout_id"`
+	OutcomeID                uint64                  `bson:"outcome_id"`
+	OrgID                    int64                   `bson:"org_id"`
+	TesteeID                 uint64                  `bson:"testee_id"`
+	ReportID                 uint64                  `bson:"report_id"`
+	StableVersion            string                  `bson:"stable_version"`
+	CandidateVersion         string                  `bson:"candidate_version"`
+	StableBuilderIdentity    string                  `bson:"stable_builder_identity"`
+	CandidateBuilderIdentity string                  `bson:"candidate_builder_identity"`
+	Candidate                ShadowContentPO         `bson:"candidate"`
+	Changes                  []ShadowContentChangePO `bson:"changes,omitempty"`
+	CreatedAt                time.Time               `bson:"created_at"`
+}
+
+func (ReportTemplateShadowComparisonPO) CollectionName() string {
+	return reportTemplateShadowCollection
+}
+
+// ShadowContentPO is the artifact content layout without report provenance.
+type ShadowContentPO struct {
+	Model               *ModelIdentityPO       `bson:"model,omitempty"`
+	PrimaryScore        *ScoreValuePO          `bson:"primary_score,omitempty"`
+	Level               *ResultLevelPO         `bson:"level,omitempty"`
+	Conclusion          string                 `bson:"conclusion,omitempty"`
+	Dimensions          []DimensionInterpretPO `bson:"dimensions,omitempty"`
+	Suggestions         []SuggestionPO         `bson:"suggestions,omitempty"`
+	ModelExtra          *ModelExtraPO          `bson:"model_extra,omitempty"`
+	PresentationProfile *PresentationProfilePO `bson:"presentation_profile,omitempty"`
+}
+
+type ShadowContentChangePO struct {
+	Path      string `bson:"path"`
+	Current   string `bson:"current,omitempty"`
+	Candidate string `bson:"candidate,omitempty"`
+}
+
+// ReportTemplateShadowComparisonRepository stores shadow rollout comparisons.
+type ReportTemplateShadowComparisonRepository struct {
+	base.BaseRepository
+}
+
+func NewReportTemplateShadowComparisonRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportTemplateShadowComparisonRepository, error) {
+	repo := &ReportTemplateShadowComparisonRepository{BaseRepository: base.NewBaseRepository(db, reportTemplateShadowCollection, opts...)}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportTemplateShadowIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report template shadow comparison indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportTemplateShadowIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "rollout_id", Value: 1}, {Key: "outcome_id", Value: 1}}, Options: options.Index().SetName("uk_report_template_shadow_outcome").SetUnique(true)},
+		{Keys: bson.D{{Key: "rollout_id", Value: 1}, {Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("idx_report_template_shadow_rollout")},
+		{Keys: bson.D{{Key: "testee_id", Value: 1}}, Options: options.Index().SetName("idx_report_template_shadow_testee")},
+	}
+}
+
+var _ domainreporttemplate.ShadowComparisonRepository = (*ReportTemplateShadowComparisonRepository)(nil)
+
+// Insert is idempotent per rollout and Outcome: a redelivered generation keeps
+// the first comparison.
+func (r *ReportTemplateShadowComparisonRepository) Insert(ctx context.Context, comparison *domainreporttemplate.ShadowComparison) error {
+	if comparison == nil {
+		return fmt.Errorf("shadow comparison is required")
+	}
+	if _, err := r.InsertOne(ctx, shadowComparisonToPO(comparison)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return fmt.Errorf("insert report template shadow comparison: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportTemplateShadowComparisonRepository) ListByRollout(ctx context.Context, rolloutID meta.ID, orgID int64, limit int) ([]*domainreporttemplate.ShadowComparison, error) {
+	if limit <= 0 || limit > reportTemplateShadowDefaultLimit {
+		limit = reportTemplateShadowDefaultLimit
+	}
+	cur, err := r.Find(ctx, bson.M{"rollout_id": rolloutID.Uint64(), "org_id": orgID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "domain_id", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list report template shadow comparisons: %w", err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	items := make([]*domainreporttemplate.ShadowComparison, 0)
+	for cur.Next(ctx) {
+		var po ReportTemplateShadowComparisonPO
+		if err := cur.Decode(&po); err != nil {
+			return nil, err
+		}
+		item, err := shadowComparisonToDomain(&po)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, cur.Err()
+}
+
+func shadowComparisonToPO(comparison *domainreporttemplate.ShadowComparison) *ReportTemplateShadowComparisonPO {
+	candidate := comparison.Candidate()
+	po := &ReportTemplateShadowComparisonPO{
+		DomainID: comparison.ID().Uint64(), RolloutID: comparison.RolloutID().Uint64(), OutcomeID: comparison.OutcomeID().Uint64(),
+		OrgID: comparison.OrgID(), TesteeID: comparison.TesteeID(), ReportID: comparison.ReportID().Uint64(),
+		StableVersion: comparison.StableVersion().String(), CandidateVersion: comparison.CandidateVersion().String(),
+		StableBuilderIdentity: comparison.StableBuilderIdentity(), CandidateBuilderIdentity: comparison.CandidateBuilderIdentity(),
+		Candidate: ShadowContentPO{
+			Model:               modelIdentityToPO(candidate.Model),
+			PrimaryScore:        scoreValueToPO(candidate.PrimaryScore),
+			Level:               resultLevelToPO(candidate.Level),
+			Conclusion:          candidate.Conclusion,
+			Dimensions:          dimensionsToPO(candidate.Dimensions),
+			Suggestions:         toSuggestionPOs(candidate.Suggestions),
+			ModelExtra:          toModelExtraPO(candidate.ModelExtra),
+			PresentationProfile: presentationProfileToPO(candidate.PresentationProfile),
+		},
+		CreatedAt: comparison.CreatedAt(),
+	}
+	for _, change := range comparison.Changes() {
+		po.Changes = append(po.Changes, ShadowContentChangePO{Path: change.Path, Current: change.Current, Candidate: change.Candidate})
+	}
+	return po
+}
+
+func shadowComparisonToDomain(po *ReportTemplateShadowComparisonPO) (*domainreporttemplate.ShadowComparison, error) {
+	changes := make([]domainreport.ContentChange, 0, len(po.Changes))
+	for _, change := range po.Changes {
+		changes = append(changes, domainreport.ContentChange{Path: change.Path, Current: change.Current, Candidate: change.Candidate})
+	}
+	return domainreporttemplate.RehydrateShadowComparison(domainreporttemplate.PersistedShadowComparison{
+		ID: meta.FromUint64(po.DomainID), RolloutID: meta.FromUint64(po.RolloutID), OutcomeID: meta.FromUint64(po.OutcomeID),
+		OrgID: po.OrgID, TesteeID: po.TesteeID, ReportID: meta.FromUint64(po.ReportID),
+		StableVersion: policy.TemplateVersion(po.StableVersion), CandidateVersion: policy.TemplateVersion(po.CandidateVersion),
+		StableBuilderIdentity: po.StableBuilderIdentity, CandidateBuilderIdentity: po.CandidateBuilderIdentity,
+		Candidate: domainreport.Content{
+			Model:               modelIdentityToDomain(po.Candidate.Model),
+			PrimaryScore:        scoreValueToDomain(po.Candidate.PrimaryScore),
+			Level:               resultLevelToDomain(po.Candidate.Level),
+			Conclusion:          po.Candidate.Conclusion,
+			Dimensions:          dimensionsToDomain(po.Candidate.Dimensions),
+			Suggestions:         toDomainSuggestions(po.Candidate.Suggestions),
+			ModelExtra:          toDomainModelExtra(po.Candidate.ModelExtra),
+			PresentationProfile: presentationProfileToDomain(po.Candidate.PresentationProfile),
+		},
+		Changes: changes, CreatedAt: po.CreatedAt,
+	})
+}
@@ -0,0 +1,88 @@
+package interpretation
+
+import (
+	"context"
+	"fmt"
+
+	domainreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/report"
+	base "github.com/FangcunMount/qs-server/internal/apiserver/infra/mongo"
+	readmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/interpretationreadmodel"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportVariantPO stores one audience variant of an immutable report. It reuses
+// the artifact layout; DomainID is the canonical report id and (domain_id,
+// audience) is unique.
+type ReportVariantPO struct {
+	InterpretReportPO `bson:",inline"`
+
+	Audience string `bson:"audience"`
+}
+
+func (ReportVariantPO) CollectionName() string { return "interpret_report_variants" }
+
+// ReportVariantRepository 写入并读取受众变体；变体与规范报告同事务提交，之后不再修改。
+type ReportVariantRepository struct {
+	base.BaseRepository
+	mapper *LifecycleMapper
+}
+
+func NewReportVariantRepository(db *mongo.Database, opts ...base.BaseRepositoryOptions) (*ReportVariantRepository, error) {
+	repo := &ReportVariantRepository{BaseRepository: base.NewBaseRepository(db, (ReportVariantPO{}).CollectionName(), opts...), mapper: NewLifecycleMapper()}
+	if _, err := repo.Collection().Indexes().CreateMany(context.Background(), reportVariantIndexModels()); err != nil {
+		return nil, fmt.Errorf("create interpretation report variant indexes: %w", err)
+	}
+	return repo, nil
+}
+
+func reportVariantIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain_id", Value: 1}, {Key: "audience", Value: 1}}, Options: options.Index().SetName("uk_variant_report_audience").SetUnique(true)},
+		{Keys: bson.D{{Key: "testee_id", Value: 1}}, Options: options.Index().SetName("idx_variant_testee")},
+	}
+}
+
+var (
+	_ domainreport.AudienceVariantRepository = (*ReportVariantRepository)(nil)
+	_ readmodel.AudienceVariantReader        = (*ReportVariantRepository)(nil)
+)
+
+func (r *ReportVariantRepository) Insert(ctx context.Context, variants []*domainreport.AudienceVariant) error {
+	for _, variant := range variants {
+		po := r.mapper.VariantToPO(variant)
+		if po == nil {
+			return fmt.Errorf("interpretation report variant is required")
+		}
+		if _, err := r.InsertOne(ctx, po); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return fmt.Errorf("insert interpretation report variant: %w", domainreport.ErrInterpretReportAlreadyExists)
+			}
+			return fmt.Errorf("insert interpretation report variant: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ReportVariantRepository) FindAudienceVariants(ctx context.Context, reportIDs []uint64, audience string) (map[uint64]readmodel.ReportRow, error) {
+	rows := make(map[uint64]readmodel.ReportRow, len(reportIDs))
+	if len(reportIDs) == 0 || audience == "" {
+		return rows, nil
+	}
+	cursor, err := r.Find(ctx, bson.M{"domain_id": bson.M{"$in": reportIDs}, "audience": audience, "deleted_at": nil})
+	if err != nil {
+		return nil, fmt.Errorf("find interpretation report variants: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+	for cursor.Next(ctx) {
+		var po ReportVariantPO
+		if err := cursor.Decode(&po); err != nil {
+			return nil, err
+		}
+		row := interpretReportPOToReadRow(&po.InterpretReportPO)
+		row.Audience = po.Audience
+		rows[row.ReportID] = row
+	}
+	return rows, cursor.Err()
+}
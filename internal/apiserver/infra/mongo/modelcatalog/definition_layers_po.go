@@ -41,9 +41,23 @@ type OutcomeRefPO struct {
 }
 
 type InterpretationAssetsPO struct {
+	Outcomes     []OutcomePresentationPO `bson:"outcomes,omitempty"`
+	Profiles     []TypeProfilePO         `bson:"profiles,omitempty"`
+	ReportSpec   InterpretationReportPO  `bson:"report_spec,omitempty"`
+	Translations []LocalizedAssetsPO     `bson:"translations,omitempty"`
+}
+
+type LocalizedAssetsPO struct {
+	Locale     string                  `bson:"locale"`
+	ModelTitle string                  `bson:"model_title,omitempty"`
+	Factors    []FactorTitlePO         `bson:"factors,omitempty"`
 	Outcomes   []OutcomePresentationPO `bson:"outcomes,omitempty"`
 	Profiles   []TypeProfilePO         `bson:"profiles,omitempty"`
-	ReportSpec InterpretationReportPO  `bson:"report_spec,omitempty"`
+}
+
+type FactorTitlePO struct {
+	FactorCode string `bson:"factor_code"`
+	Title      string `bson:"title,omitempty"`
 }
 
 type OutcomePresentationPO struct {
@@ -149,25 +163,10 @@ func decisionSpecFromPO(po DecisionSpecPO) decision.Spec {
 }
 
 func interpretationAssetsToPO(assets interpretationassets.Assets) InterpretationAssetsPO {
-	out := InterpretationAssetsPO{}
-	if len(assets.Outcomes) > 0 {
-		out.Outcomes = make([]OutcomePresentationPO, 0, len(assets.Outcomes))
-		for _, item := range assets.Outcomes {
-			out.Outcomes = append(out.Outcomes, OutcomePresentationPO{
-				OutcomeCode: item.OutcomeCode, Title: item.Title, Summary: item.Summary, Description: item.Description,
-			})
-		}
-	}
-	if len(assets.Profiles) > 0 {
-		out.Profiles = make([]TypeProfilePO, 0, len(assets.Profiles))
-		for _, item := range assets.Profiles {
-			out.Profiles = append(out.Profiles, TypeProfilePO{
-				OutcomeCode: item.OutcomeCode, Pattern: item.Pattern,
-				Traits: append([]string(nil), item.Traits...), Strengths: append([]string(nil), item.Strengths...),
-				Weaknesses: append([]string(nil), item.Weaknesses...), Suggestions: append([]string(nil), item.Suggestions...),
-				ImageURL: item.ImageURL, Image: item.Image, IsSpecial: item.IsSpecial, Trigger: item.Trigger, Commentary: item.Commentary,
-			})
-		}
+	out := InterpretationAssetsPO{
+		Outcomes:     outcomePresentationsToPO(assets.Outcomes),
+		Profiles:     typeProfilesToPO(assets.Profiles),
+		Translations: translationsToPO(assets.Translations),
 	}
 	if len(assets.ReportSpec.Sections) > 0 {
 		sections := make([]InterpretationReportSectionPO, 0, len(assets.ReportSpec.Sections))
@@ -183,26 +182,58 @@ func interpretationAssetsToPO(assets interpretationassets.Assets) Interpretation
 	return out
 }
 
-func interpretationAssetsFromPO(po InterpretationAssetsPO) interpretationassets.Assets {
-	assets := interpretationassets.Assets{}
-	if len(po.Outcomes) > 0 {
-		assets.Outcomes = make([]interpretationassets.OutcomePresentation, 0, len(po.Outcomes))
-		for _, item := range po.Outcomes {
-			assets.Outcomes = append(assets.Outcomes, interpretationassets.OutcomePresentation{
-				OutcomeCode: item.OutcomeCode, Title: item.Title, Summary: item.Summary, Description: item.Description,
-			})
-		}
+func outcomePresentationsToPO(items []interpretationassets.OutcomePresentation) []OutcomePresentationPO {
+	if len(items) == 0 {
+		return nil
 	}
-	if len(po.Profiles) > 0 {
-		assets.Profiles = make([]interpretationassets.TypeProfilePresentation, 0, len(po.Profiles))
-		for _, item := range po.Profiles {
-			assets.Profiles = append(assets.Profiles, interpretationassets.TypeProfilePresentation{
-				OutcomeCode: item.OutcomeCode, Pattern: item.Pattern,
-				Traits: append([]string(nil), item.Traits...), Strengths: append([]string(nil), item.Strengths...),
-				Weaknesses: append([]string(nil), item.Weaknesses...), Suggestions: append([]string(nil), item.Suggestions...),
-				ImageURL: item.ImageURL, Image: item.Image, IsSpecial: item.IsSpecial, Trigger: item.Trigger, Commentary: item.Commentary,
-			})
+	out := make([]OutcomePresentationPO, 0, len(items))
+	for _, item := range items {
+		out = append(out, OutcomePresentationPO{
+			OutcomeCode: item.OutcomeCode, Title: item.Title, Summary: item.Summary, Description: item.Description,
+		})
+	}
+	return out
+}
+
+func typeProfilesToPO(items []interpretationassets.TypeProfilePresentation) []TypeProfilePO {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]TypeProfilePO, 0, len(items))
+	for _, item := range items {
+		out = append(out, TypeProfilePO{
+			OutcomeCode: item.OutcomeCode, Pattern: item.Pattern,
+			Traits: append([]string(nil), item.Traits...), Strengths: append([]string(nil), item.Strengths...),
+			Weaknesses: append([]string(nil), item.Weaknesses...), Suggestions: append([]string(nil), item.Suggestions...),
+			ImageURL: item.ImageURL, Image: item.Image, IsSpecial: item.IsSpecial, Trigger: item.Trigger, Commentary: item.Commentary,
+		})
+	}
+	return out
+}
+
+func translationsToPO(items []interpretationassets.LocalizedAssets) []LocalizedAssetsPO {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]LocalizedAssetsPO, 0, len(items))
+	for _, item := range items {
+		po := LocalizedAssetsPO{
+			Locale: item.Locale, ModelTitle: item.ModelTitle,
+			Outcomes: outcomePresentationsToPO(item.Outcomes), Profiles: typeProfilesToPO(item.Profiles),
 		}
+		for _, factor := range item.Factors {
+			po.Factors = append(po.Factors, FactorTitlePO{FactorCode: factor.FactorCode, Title: factor.Title})
+		}
+		out = append(out, po)
+	}
+	return out
+}
+
+func interpretationAssetsFromPO(po InterpretationAssetsPO) interpretationassets.Assets {
+	assets := interpretationassets.Assets{
+		Outcomes:     outcomePresentationsFromPO(po.Outcomes),
+		Profiles:     typeProfilesFromPO(po.Profiles),
+		Translations: translationsFromPO(po.Translations),
 	}
 	if len(po.ReportSpec.Sections) > 0 {
 		sections := make([]interpretationassets.ReportSection, 0, len(po.ReportSpec.Sections))
@@ -217,3 +248,50 @@ func interpretationAssetsFromPO(po InterpretationAssetsPO) interpretationassets.
 	}
 	return assets
 }
+
+func outcomePresentationsFromPO(items []OutcomePresentationPO) []interpretationassets.OutcomePresentation {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]interpretationassets.OutcomePresentation, 0, len(items))
+	for _, item := range items {
+		out = append(out, interpretationassets.OutcomePresentation{
+			OutcomeCode: item.OutcomeCode, Title: item.Title, Summary: item.Summary, Description: item.Description,
+		})
+	}
+	return out
+}
+
+func typeProfilesFromPO(items []TypeProfilePO) []interpretationassets.TypeProfilePresentation {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]interpretationassets.TypeProfilePresentation, 0, len(items))
+	for _, item := range items {
+		out = append(out, interpretationassets.TypeProfilePresentation{
+			OutcomeCode: item.OutcomeCode, Pattern: item.Pattern,
+			Traits: append([]string(nil), item.Traits...), Strengths: append([]string(nil), item.Strengths...),
+			Weaknesses: append([]string(nil), item.Weaknesses...), Suggestions: append([]string(nil), item.Suggestions...),
+			ImageURL: item.ImageURL, Image: item.Image, IsSpecial: item.IsSpecial, Trigger: item.Trigger, Commentary: item.Commentary,
+		})
+	}
+	return out
+}
+
+func translationsFromPO(items []LocalizedAssetsPO) []interpretationassets.LocalizedAssets {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]interpretationassets.LocalizedAssets, 0, len(items))
+	for _, item := range items {
+		localized := interpretationassets.LocalizedAssets{
+			Locale: item.Locale, ModelTitle: item.ModelTitle,
+			Outcomes: outcomePresentationsFromPO(item.Outcomes), Profiles: typeProfilesFromPO(item.Profiles),
+		}
+		for _, factor := range item.Factors {
+			localized.Factors = append(localized.Factors, interpretationassets.FactorTitle{FactorCode: factor.FactorCode, Title: factor.Title})
+		}
+		out = append(out, localized)
+	}
+	return out
+}
@@ -16,6 +16,7 @@ type DefinitionPO struct {
 	ReportMap            ReportMapPO            `bson:"report_map,omitempty"`
 	DecisionSpec         DecisionSpecPO         `bson:"decision_spec,omitempty"`
 	InterpretationAssets InterpretationAssetsPO `bson:"interpretation_assets,omitempty"`
+	Translations         []LocalizedAssetsPO    `bson:"translations,omitempty"`
 }
 
 type ExecutionSpecPO struct {
@@ -220,6 +221,7 @@ func definitionToPO(def *domain.Definition) *DefinitionPO {
 		ReportMap:            reportMapToPO(def.ReportMap),
 		DecisionSpec:         decisionSpecToPO(def.DecisionSpec),
 		InterpretationAssets: interpretationAssetsToPO(def.InterpretationAssets),
+		Translations:         translationsToPO(def.Translations),
 	}
 }
 
@@ -243,6 +245,7 @@ func definitionFromPO(po *DefinitionPO) *domain.Definition {
 		ReportMap:            reportMapFromPO(po.ReportMap),
 		DecisionSpec:         decisionSpecFromPO(po.DecisionSpec),
 		InterpretationAssets: interpretationAssetsFromPO(po.InterpretationAssets),
+		Translations:         translationsFromPO(po.Translations),
 	}
 }
 
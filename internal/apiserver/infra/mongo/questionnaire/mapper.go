@@ -48,6 +48,7 @@ func (m *QuestionnaireMapper) ToPO(bo *questionnaire.Questionnaire) *Questionnai
 			ValidationRules: m.mapValidationRules(questionBO.GetValidationRules()),
 			CalculationRule: m.mapCalculationRule(questionBO.GetCalculationRule()),
 			ShowController:  m.mapShowController(questionBO.GetShowController()),
+			CriticalRule:    m.mapCriticalRule(questionBO.GetCriticalRule()),
 		}
 
 		po.Questions = append(po.Questions, questionPO)
@@ -163,6 +164,11 @@ func (m *QuestionnaireMapper) mapQuestions(questionsPO []QuestionPO) []questionn
 			opts = append(opts, questionnaire.WithShowController(showController))
 		}
 
+		// 添加关键条目规则（如果有的话）
+		if criticalRule := m.mapCriticalRulePOToBO(questionPO.CriticalRule); criticalRule != nil {
+			opts = append(opts, questionnaire.WithCriticalRule(criticalRule))
+		}
+
 		questionBO, err := questionnaire.NewQuestion(opts...)
 		if err != nil {
 			// 跳过不符合条件的问题
@@ -248,3 +254,32 @@ func (m *QuestionnaireMapper) mapShowControllerPOToBO(scPO *ShowControllerPO) *q
 
 	return questionnaire.NewShowController(scPO.Rule, conditions)
 }
+
+// mapCriticalRule 将关键条目规则BO转换为PO
+func (m *QuestionnaireMapper) mapCriticalRule(rule *questionnaire.CriticalRule) *CriticalRulePO {
+	if rule.IsEmpty() {
+		return nil
+	}
+	optionCodes := make([]string, 0, len(rule.OptionCodes))
+	for _, code := range rule.OptionCodes {
+		optionCodes = append(optionCodes, code.Value())
+	}
+	return &CriticalRulePO{
+		Category:    string(rule.Category),
+		OptionCodes: optionCodes,
+		MinValue:    rule.MinValue,
+		Keywords:    rule.Keywords,
+	}
+}
+
+// mapCriticalRulePOToBO 将关键条目规则PO转换为BO
+func (m *QuestionnaireMapper) mapCriticalRulePOToBO(rulePO *CriticalRulePO) *questionnaire.CriticalRule {
+	if rulePO == nil {
+		return nil
+	}
+	optionCodes := make([]meta.Code, 0, len(rulePO.OptionCodes))
+	for _, code := range rulePO.OptionCodes {
+		optionCodes = append(optionCodes, meta.NewCode(code))
+	}
+	return questionnaire.NewCriticalRule(questionnaire.CriticalCategory(rulePO.Category), optionCodes, rulePO.MinValue, rulePO.Keywords)
+}
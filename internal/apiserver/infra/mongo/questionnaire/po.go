@@ -127,6 +127,7 @@ type QuestionPO struct {
 	ValidationRules []ValidationRulePO `bson:"validation_rules" json:"validation_rules"`
 	CalculationRule CalculationRulePO  `bson:"calculation_rule" json:"calculation_rule"`
 	ShowController  *ShowControllerPO  `bson:"show_controller,omitempty" json:"show_controller,omitempty"`
+	CriticalRule    *CriticalRulePO    `bson:"critical_rule,omitempty" json:"critical_rule,omitempty"`
 }
 
 // ShowControllerPO 显示控制器持久化对象
@@ -141,6 +142,14 @@ type ShowControllerConditionPO struct {
 	SelectOptionCodes []string `bson:"select_option_codes" json:"select_option_codes"`
 }
 
+// CriticalRulePO 关键条目规则持久化对象
+type CriticalRulePO struct {
+	Category    string   `bson:"category" json:"category"`
+	OptionCodes []string `bson:"option_codes,omitempty" json:"option_codes,omitempty"`
+	MinValue    *float64 `bson:"min_value,omitempty" json:"min_value,omitempty"`
+	Keywords    []string `bson:"keywords,omitempty" json:"keywords,omitempty"`
+}
+
 // ToBsonM 将 QuestionPO 转换为 bson.M
 func (p *QuestionPO) ToBsonM() (bson.M, error) {
 	data, err := bson.Marshal(p)
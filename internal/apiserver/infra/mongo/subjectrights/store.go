@@ -0,0 +1,129 @@
+// Package subjectrights collects and erases a testee's Mongo documents for
+// data-subject export and erasure requests.
+package subjectrights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	subjectApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/pkg/attentionprojection"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	answerSheetCollection = "answersheets"
+	idempotencyCollection = "answersheet_submit_idempotency"
+)
+
+// reportCollections 解读报告、受众变体、模板影子对比、批量重生成条目、归档报告与报告查询目录。
+var reportCollections = []string{
+	"interpret_report_artifacts",
+	"interpret_report_variants",
+	"interpretation_report_template_shadow_comparisons",
+	"interpretation_report_regeneration_items",
+	"archived_reports",
+	"report_query_catalog",
+}
+
+// DocumentStore 数据主体请求的 Mongo 文档存储。
+//
+// 各步骤只按 testee_id 过滤，重复执行时已处理的文档不再命中或保持不变。
+type DocumentStore struct {
+	db *mongo.Database
+}
+
+var _ subjectApp.DocumentStore = (*DocumentStore)(nil)
+
+func NewDocumentStore(db *mongo.Database) *DocumentStore {
+	return &DocumentStore{db: db}
+}
+
+func (s *DocumentStore) CollectDocuments(ctx context.Context, testeeID uint64) ([]subjectApp.Section, error) {
+	names := append([]string{answerSheetCollection}, reportCollections...)
+	sections := make([]subjectApp.Section, 0, len(names))
+	for _, name := range names {
+		records, err := s.collect(ctx, name, testeeID)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, subjectApp.Section{Store: subjectApp.StoreMongo, Name: name, Records: records})
+	}
+	return sections, nil
+}
+
+func (s *DocumentStore) EraseDocuments(ctx context.Context, testeeID uint64, step subjectApp.StepName, action subjectApp.StepAction) (int64, error) {
+	filter := bson.M{"testee_id": testeeID}
+	switch {
+	case step == subjectApp.StepAnswerSheets && action == subjectApp.ActionPseudonymize:
+		// 答卷内容保留用于测评溯源，只清除填写人关联与提交请求标识；提交幂等记录不再需要。
+		result, err := s.db.Collection(answerSheetCollection).UpdateMany(ctx,
+			bson.M{"testee_id": testeeID, "$or": bson.A{
+				bson.M{"filler_id": bson.M{"$ne": 0}},
+				bson.M{"submit_meta.request_id": bson.M{"$exists": true}},
+			}},
+			bson.M{"$set": bson.M{"filler_id": 0}, "$unset": bson.M{"submit_meta.request_id": ""}},
+		)
+		if err != nil {
+			return 0, fmt.Errorf("pseudonymize answersheets: %w", err)
+		}
+		deleted, err := s.deleteMany(ctx, idempotencyCollection, filter)
+		return result.ModifiedCount + deleted, err
+	case step == subjectApp.StepAnswerSheets && action == subjectApp.ActionDelete:
+		var affected int64
+		for _, name := range []string{answerSheetCollection, idempotencyCollection} {
+			deleted, err := s.deleteMany(ctx, name, filter)
+			affected += deleted
+			if err != nil {
+				return affected, err
+			}
+		}
+		return affected, nil
+	case step == subjectApp.StepReports && action == subjectApp.ActionDelete:
+		var affected int64
+		for _, name := range reportCollections {
+			deleted, err := s.deleteMany(ctx, name, filter)
+			affected += deleted
+			if err != nil {
+				return affected, err
+			}
+		}
+		return affected, nil
+	case step == subjectApp.StepAttention && action == subjectApp.ActionDelete:
+		// 报告关注投影可由报告事实重建，匿名化与删除都直接删除。
+		return s.deleteMany(ctx, attentionprojection.CollectionName, filter)
+	default:
+		return 0, fmt.Errorf("unsupported erasure step %q with action %q", step, action)
+	}
+}
+
+func (s *DocumentStore) deleteMany(ctx context.Context, name string, filter bson.M) (int64, error) {
+	result, err := s.db.Collection(name).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("delete %s documents: %w", name, err)
+	}
+	return result.DeletedCount, nil
+}
+
+// collect 以 relaxed Extended JSON 导出文档，ObjectID 与时间保留类型标注。
+func (s *DocumentStore) collect(ctx context.Context, name string, testeeID uint64) ([]json.RawMessage, error) {
+	cursor, err := s.db.Collection(name).Find(ctx, bson.M{"testee_id": testeeID})
+	if err != nil {
+		return nil, fmt.Errorf("find %s documents: %w", name, err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+	records := make([]json.RawMessage, 0)
+	for cursor.Next(ctx) {
+		record, err := bson.MarshalExtJSON(cursor.Current, false, false)
+		if err != nil {
+			return nil, fmt.Errorf("encode %s document: %w", name, err)
+		}
+		records = append(records, record)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("read %s documents: %w", name, err)
+	}
+	return records, nil
+}
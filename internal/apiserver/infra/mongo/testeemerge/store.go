@@ -0,0 +1,123 @@
+// Package testeemerge moves Mongo documents between testees for the testee
+// merge journey.
+package testeemerge
+
+import (
+	"context"
+	"fmt"
+
+	mergeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collections 合并时迁移 testee_id 的集合：答卷、解读报告、受众变体、模板影子对比、批量重生成条目、归档报告与报告查询目录。
+var collections = []string{
+	"answersheets",
+	"interpret_report_artifacts",
+	"interpret_report_variants",
+	"interpretation_report_template_shadow_comparisons",
+	"interpretation_report_regeneration_items",
+	"archived_reports",
+	"report_query_catalog",
+}
+
+var movableCollections = func() map[string]struct{} {
+	names := make(map[string]struct{}, len(collections))
+	for _, name := range collections {
+		names[name] = struct{}{}
+	}
+	return names
+}()
+
+// DocumentStore 受试者合并的 Mongo 文档迁移。
+//
+// Mongo 与 MySQL 不共享事务：先迁移文档并记录 _id，MySQL 事务失败时由应用层按记录迁回。
+type DocumentStore struct {
+	db *mongo.Database
+}
+
+var _ mergeApp.DocumentStore = (*DocumentStore)(nil)
+
+func NewDocumentStore(db *mongo.Database) *DocumentStore {
+	return &DocumentStore{db: db}
+}
+
+func (s *DocumentStore) Repoint(ctx context.Context, from, to uint64) ([]mergeApp.MovedRecords, error) {
+	var moved []mergeApp.MovedRecords
+	for _, name := range collections {
+		ids, err := s.findIDs(ctx, name, from)
+		if err != nil {
+			return moved, err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if err := s.move(ctx, name, ids, from, to); err != nil {
+			return moved, err
+		}
+		hexIDs := make([]string, 0, len(ids))
+		for _, id := range ids {
+			hexIDs = append(hexIDs, id.Hex())
+		}
+		moved = append(moved, mergeApp.MovedRecords{Store: mergeApp.RecordStoreMongo, Table: name, IDs: hexIDs})
+	}
+	return moved, nil
+}
+
+func (s *DocumentStore) Move(ctx context.Context, records []mergeApp.MovedRecords, from, to uint64) error {
+	for _, record := range records {
+		if record.Store != mergeApp.RecordStoreMongo {
+			continue
+		}
+		if _, ok := movableCollections[record.Table]; !ok {
+			return fmt.Errorf("unsupported merge collection %q", record.Table)
+		}
+		ids := make([]primitive.ObjectID, 0, len(record.IDs))
+		for _, hex := range record.IDs {
+			id, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return fmt.Errorf("invalid moved document id %q: %w", hex, err)
+			}
+			ids = append(ids, id)
+		}
+		if err := s.move(ctx, record.Table, ids, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DocumentStore) findIDs(ctx context.Context, name string, testeeID uint64) ([]primitive.ObjectID, error) {
+	cursor, err := s.db.Collection(name).Find(ctx, bson.M{"testee_id": testeeID}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("find %s documents: %w", name, err)
+	}
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode %s documents: %w", name, err)
+	}
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// move 只迁移仍引用 from 的文档；重复执行或部分执行后再次执行都是安全的。
+func (s *DocumentStore) move(ctx context.Context, name string, ids []primitive.ObjectID, from, to uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := s.db.Collection(name).UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "testee_id": from},
+		bson.M{"$set": bson.M{"testee_id": to}},
+	); err != nil {
+		return fmt.Errorf("repoint %s documents: %w", name, err)
+	}
+	return nil
+}
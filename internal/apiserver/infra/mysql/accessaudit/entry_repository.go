@@ -0,0 +1,110 @@
+package accessaudit
+
+import (
+	"context"
+
+	domainaudit "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/accessaudit"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// entryRepository 访问审计仓储。
+// 每个机构一行链尾记录，追加时行锁串行化同一机构的写入；记录表只插入不更新，
+// 读取与链校验不按 deleted_at 过滤，任何被标记删除的记录都必须仍能参与校验。
+type entryRepository struct {
+	mysql.BaseRepository[*EntryPO]
+}
+
+// NewEntryRepository 创建访问审计仓储
+func NewEntryRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainaudit.Repository {
+	return &entryRepository{BaseRepository: mysql.NewBaseRepository[*EntryPO](db, opts...)}
+}
+
+// Append 使用独立事务写入，不加入调用方上下文中的业务事务：读取失败或回滚不能抹掉审计记录。
+func (r *entryRepository) Append(ctx context.Context, entry *domainaudit.Entry) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.appendLocked(ctx, tx, entry)
+	})
+}
+
+// AppendInTx 链尾行锁持有到调用方事务结束；调用方事务回滚时记录与链尾一并回滚，链保持连续。
+func (r *entryRepository) AppendInTx(ctx context.Context, entry *domainaudit.Entry) error {
+	tx, ok := mysql.TxFromContext(ctx)
+	if !ok {
+		return r.Append(ctx, entry)
+	}
+	return r.appendLocked(ctx, tx.WithContext(ctx), entry)
+}
+
+func (r *entryRepository) appendLocked(ctx context.Context, tx *gorm.DB, entry *domainaudit.Entry) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ChainHeadPO{OrgID: entry.OrgID}).Error; err != nil {
+		return err
+	}
+	var head ChainHeadPO
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("org_id=?", entry.OrgID).Take(&head).Error; err != nil {
+		return err
+	}
+	entry.Seal(domainaudit.ChainHead{Seq: head.LastSeq, Hash: head.LastHash})
+	if err := r.CreateAndSync(mysql.WithTx(ctx, tx), entryToPO(entry), nil); err != nil {
+		return err
+	}
+	return tx.Model(&ChainHeadPO{}).Where("org_id=? AND last_seq=?", entry.OrgID, head.LastSeq).
+		Updates(map[string]interface{}{"last_seq": entry.Seq, "last_hash": entry.Hash}).Error
+}
+
+func (r *entryRepository) List(ctx context.Context, filter domainaudit.Filter, offset, limit int) ([]domainaudit.Entry, int64, error) {
+	query := func() *gorm.DB {
+		db := r.DB().WithContext(ctx).Model(&EntryPO{}).Where("org_id=?", filter.OrgID)
+		if filter.ActorUserID != 0 {
+			db = db.Where("actor_user_id=?", filter.ActorUserID)
+		}
+		if filter.TesteeID != 0 {
+			db = db.Where("testee_id=?", filter.TesteeID)
+		}
+		if filter.ResourceType != "" {
+			db = db.Where("resource_type=?", string(filter.ResourceType))
+		}
+		if filter.Result != "" {
+			db = db.Where("result=?", string(filter.Result))
+		}
+		if !filter.From.IsZero() {
+			db = db.Where("occurred_at>=?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			db = db.Where("occurred_at<?", filter.To)
+		}
+		return db
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []EntryPO
+	if err := query().Order("seq DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return entriesToDomain(pos), total, nil
+}
+
+func (r *entryRepository) ListChain(ctx context.Context, orgID int64, afterSeq uint64, limit int) ([]domainaudit.Entry, error) {
+	var pos []EntryPO
+	if err := r.DB().WithContext(ctx).Where("org_id=? AND seq>?", orgID, afterSeq).
+		Order("seq ASC").Limit(limit).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return entriesToDomain(pos), nil
+}
+
+func (r *entryRepository) Head(ctx context.Context, orgID int64) (domainaudit.ChainHead, error) {
+	var rows []ChainHeadPO
+	if err := r.DB().WithContext(ctx).Where("org_id=?", orgID).Limit(1).Find(&rows).Error; err != nil {
+		return domainaudit.ChainHead{}, err
+	}
+	if len(rows) == 0 {
+		return domainaudit.ChainHead{}, nil
+	}
+	return domainaudit.ChainHead{Seq: rows[0].LastSeq, Hash: rows[0].LastHash}, nil
+}
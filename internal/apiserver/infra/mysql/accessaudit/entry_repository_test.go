@@ -0,0 +1,121 @@
+package accessaudit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainaudit "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/accessaudit"
+	mysqlpkg "github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newEntryRepositoryTestDB(t *testing.T) (*entryRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEntryRepository(db).(*entryRepository), mock
+}
+
+func TestAppendLocksChainHeadAndAdvancesIt(t *testing.T) {
+	repo, mock := newEntryRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `access_audit_chain_head`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `access_audit_chain_head` WHERE org_id=? LIMIT ? FOR UPDATE")).
+		WithArgs(int64(7), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"org_id", "last_seq", "last_hash"}).AddRow(7, 4, "prev"))
+	occurredAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `access_audit_log` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,`seq`")).
+		WithArgs(occurredAt, sqlmock.AnyArg(), nil, uint64(11), uint64(0), uint64(0), uint32(1), int64(7), uint64(5),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), uint64(1)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `access_audit_chain_head` SET `last_hash`=?,`last_seq`=?,`updated_at`=? WHERE org_id=? AND last_seq=?")).
+		WithArgs(sqlmock.AnyArg(), uint64(5), sqlmock.AnyArg(), int64(7), uint64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	entry := &domainaudit.Entry{ID: 1, OrgID: 7, ActorUserID: 11, ResourceType: domainaudit.ResourceTestee, Result: domainaudit.ResultAllowed,
+		OccurredAt: occurredAt}
+	if err := repo.Append(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Seq != 5 || entry.PrevHash != "prev" || len(entry.Hash) != 64 {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAppendInTxJoinsCallerTransaction(t *testing.T) {
+	repo, mock := newEntryRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `access_audit_chain_head`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `access_audit_chain_head` WHERE org_id=? LIMIT ? FOR UPDATE")).
+		WithArgs(int64(7), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"org_id", "last_seq", "last_hash"}).AddRow(7, 0, ""))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `access_audit_log`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `access_audit_chain_head`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	// 调用方事务回滚时，审计记录与链尾推进一并回滚。
+	err := repo.DB().Transaction(func(tx *gorm.DB) error {
+		entry := &domainaudit.Entry{ID: 1, OrgID: 7, ActorUserID: 11, ResourceType: domainaudit.ResourceBreakGlassGrant,
+			Result: domainaudit.ResultAllowed, OccurredAt: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)}
+		if err := repo.AppendInTx(mysqlpkg.WithTx(context.Background(), tx), entry); err != nil {
+			return err
+		}
+		return errors.New("grant insert failed")
+	})
+	if err == nil {
+		t.Fatal("expected caller transaction error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHeadOfEmptyChain(t *testing.T) {
+	repo, mock := newEntryRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `access_audit_chain_head` WHERE org_id=?")).
+		WillReturnRows(sqlmock.NewRows([]string{"org_id", "last_seq", "last_hash"}))
+	head, err := repo.Head(context.Background(), 7)
+	if err != nil || head.Seq != 0 || head.Hash != "" {
+		t.Fatalf("Head() = %+v, %v", head, err)
+	}
+}
+
+func TestAccessAuditMigrationAddsAuditFieldsOutsideHash(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000089_add_access_audit_log_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ADD COLUMN `created_at`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `version`",
+		"`created_at` = `occurred_at`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
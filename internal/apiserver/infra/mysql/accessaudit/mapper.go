@@ -0,0 +1,38 @@
+package accessaudit
+
+import (
+	domainaudit "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/accessaudit"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func entryToPO(entry *domainaudit.Entry) *EntryPO {
+	var actor meta.ID
+	if entry.ActorUserID > 0 {
+		actor = meta.FromUint64(uint64(entry.ActorUserID))
+	}
+	return &EntryPO{
+		AuditFields: mysql.AuditFields{ID: meta.FromUint64(entry.ID), CreatedAt: entry.OccurredAt, CreatedBy: actor},
+		OrgID:       entry.OrgID, Seq: entry.Seq,
+		ActorUserID: entry.ActorUserID, ActorRole: entry.ActorRole, Relation: entry.Relation,
+		ResourceType: string(entry.ResourceType), ResourceID: entry.ResourceID, TesteeID: entry.TesteeID,
+		Purpose: entry.Purpose, Result: string(entry.Result), StatusCode: entry.StatusCode,
+		Route: entry.Route, RequestID: entry.RequestID, ClientIP: entry.ClientIP,
+		OccurredAt: entry.OccurredAt, PrevHash: entry.PrevHash, Hash: entry.Hash,
+	}
+}
+
+func entriesToDomain(pos []EntryPO) []domainaudit.Entry {
+	entries := make([]domainaudit.Entry, 0, len(pos))
+	for _, po := range pos {
+		entries = append(entries, domainaudit.Entry{
+			ID: po.ID.Uint64(), OrgID: po.OrgID, Seq: po.Seq,
+			ActorUserID: po.ActorUserID, ActorRole: po.ActorRole, Relation: po.Relation,
+			ResourceType: domainaudit.ResourceType(po.ResourceType), ResourceID: po.ResourceID, TesteeID: po.TesteeID,
+			Purpose: po.Purpose, Result: domainaudit.Result(po.Result), StatusCode: po.StatusCode,
+			Route: po.Route, RequestID: po.RequestID, ClientIP: po.ClientIP,
+			OccurredAt: po.OccurredAt, PrevHash: po.PrevHash, Hash: po.Hash,
+		})
+	}
+	return entries
+}
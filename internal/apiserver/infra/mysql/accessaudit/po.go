@@ -0,0 +1,57 @@
+package accessaudit
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// EntryPO 访问审计记录持久化对象；记录只插入不更新。
+type EntryPO struct {
+	mysql.AuditFields
+
+	OrgID        int64     `gorm:"column:org_id;not null"`
+	Seq          uint64    `gorm:"column:seq;not null"`
+	ActorUserID  int64     `gorm:"column:actor_user_id;not null"`
+	ActorRole    string    `gorm:"column:actor_role;size:32;not null"`
+	Relation     string    `gorm:"column:relation;size:128;not null"`
+	ResourceType string    `gorm:"column:resource_type;size:32;not null"`
+	ResourceID   string    `gorm:"column:resource_id;size:64;not null;default:''"`
+	TesteeID     uint64    `gorm:"column:testee_id;not null;default:0"`
+	Purpose      string    `gorm:"column:purpose;size:64;not null"`
+	Result       string    `gorm:"column:result;size:16;not null"`
+	StatusCode   int       `gorm:"column:status_code;not null"`
+	Route        string    `gorm:"column:route;size:200;not null;default:''"`
+	RequestID    string    `gorm:"column:request_id;size:64;not null;default:''"`
+	ClientIP     string    `gorm:"column:client_ip;size:64;not null;default:''"`
+	OccurredAt   time.Time `gorm:"column:occurred_at;not null"`
+	PrevHash     string    `gorm:"column:prev_hash;size:64;not null;default:''"`
+	Hash         string    `gorm:"column:hash;size:64;not null"`
+}
+
+// TableName 指定表名
+func (EntryPO) TableName() string { return "access_audit_log" }
+
+// BeforeCreate GORM hook：记录的创建人与创建时间即访问者与访问时间。
+func (p *EntryPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// ChainHeadPO 机构审计链尾；以 org_id 为主键，追加时行锁串行化同一机构的写入。
+type ChainHeadPO struct {
+	OrgID     int64     `gorm:"column:org_id;primaryKey;autoIncrement:false"`
+	LastSeq   uint64    `gorm:"column:last_seq;not null;default:0"`
+	LastHash  string    `gorm:"column:last_hash;size:64;not null;default:''"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime:milli"`
+}
+
+// TableName 指定表名
+func (ChainHeadPO) TableName() string { return "access_audit_chain_head" }
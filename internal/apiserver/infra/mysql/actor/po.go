@@ -23,6 +23,8 @@ type TesteePO struct {
 	Tags       StringSliceCol `gorm:"column:tags;type:json"`
 	Source     string         `gorm:"column:source;size:50;not null;default:unknown"`
 	IsKeyFocus bool           `gorm:"column:is_key_focus;not null;default:false"`
+	// PreferredLocale 用指针保证清除偏好（空串）时也会随 Updates 写回
+	PreferredLocale *string `gorm:"column:preferred_locale;size:16;not null;default:''"`
 }
 
 // TableName 指定表名
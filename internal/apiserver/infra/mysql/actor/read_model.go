@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
 	actorreadmodel "github.com/FangcunMount/qs-server/internal/apiserver/port/actorreadmodel"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
 	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
@@ -142,6 +143,9 @@ func (r *readModel) applyTesteeFilter(query *gorm.DB, filter actorreadmodel.Test
 	if filter.CreatedAtEnd != nil {
 		query = query.Where("created_at < ?", *filter.CreatedAtEnd)
 	}
+	if triageSQL, triageArgs := workbenchtriage.QueuePredicate(filter.Triage, filter.OrgID, "testee.id"); triageSQL != "" {
+		query = query.Where(triageSQL, triageArgs...)
+	}
 	return query
 }
 
@@ -552,6 +556,9 @@ func testeeRowFromPO(po *TesteePO) actorreadmodel.TesteeRow {
 		Source:     po.Source,
 		IsKeyFocus: po.IsKeyFocus,
 	}
+	if po.PreferredLocale != nil {
+		row.PreferredLocale = *po.PreferredLocale
+	}
 	return row
 }
 
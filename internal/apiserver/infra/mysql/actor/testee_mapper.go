@@ -19,14 +19,16 @@ func (m *TesteeMapper) ToPO(domain *testee.Testee) *TesteePO {
 		return nil
 	}
 
+	preferredLocale := domain.PreferredLocale()
 	po := &TesteePO{
-		OrgID:      domain.OrgID(),
-		Name:       domain.Name(),
-		Gender:     int8(domain.Gender()),
-		Birthday:   domain.Birthday(),
-		Tags:       domain.TagsAsStrings(),
-		Source:     domain.Source(),
-		IsKeyFocus: domain.IsKeyFocus(),
+		OrgID:           domain.OrgID(),
+		Name:            domain.Name(),
+		Gender:          int8(domain.Gender()),
+		Birthday:        domain.Birthday(),
+		Tags:            domain.TagsAsStrings(),
+		Source:          domain.Source(),
+		IsKeyFocus:      domain.IsKeyFocus(),
+		PreferredLocale: &preferredLocale,
 	}
 
 	// 处理 ProfileID
@@ -64,6 +66,9 @@ func (m *TesteeMapper) ToDomain(po *TesteePO) *testee.Testee {
 		po.Tags,
 		po.IsKeyFocus,
 	)
+	if po.PreferredLocale != nil {
+		domain.SetPreferredLocale(*po.PreferredLocale)
+	}
 	domain.SetCreatedAt(po.CreatedAt)
 	domain.SetUpdatedAt(po.UpdatedAt)
 
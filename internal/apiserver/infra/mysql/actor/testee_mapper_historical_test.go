@@ -34,3 +34,17 @@ func TestTesteeMapperLeavesOrdinaryNewTesteeCreatedAtUnset(t *testing.T) {
 		t.Fatalf("ordinary PO created_at=%s, want zero for GORM autoCreateTime", got)
 	}
 }
+
+func TestTesteeMapperWritesClearedPreferredLocale(t *testing.T) {
+	testee := domain.NewTestee(1, "testee", domain.GenderMale, nil)
+	po := NewTesteeMapper().ToPO(testee)
+	if po.PreferredLocale == nil || *po.PreferredLocale != "" {
+		t.Fatalf("PO preferred_locale=%v, want non-nil empty string so Updates clears it", po.PreferredLocale)
+	}
+
+	english := "en-US"
+	po.PreferredLocale = &english
+	if got := NewTesteeMapper().ToDomain(po).PreferredLocale(); got != english {
+		t.Fatalf("domain preferred locale=%q, want %q", got, english)
+	}
+}
@@ -0,0 +1,114 @@
+package breakglass
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainbreakglass "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+// grantRepository 紧急访问授权仓储。生效条件为 revoked_at 为空且 expires_at 晚于查询时刻。
+type grantRepository struct {
+	mysql.BaseRepository[*GrantPO]
+}
+
+// NewGrantRepository 创建紧急访问授权仓储
+func NewGrantRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainbreakglass.Repository {
+	return &grantRepository{BaseRepository: mysql.NewBaseRepository[*GrantPO](db, opts...)}
+}
+
+func (r *grantRepository) HasActiveGrant(ctx context.Context, orgID int64, clinicianID, testeeID uint64, now time.Time) (bool, error) {
+	var count int64
+	err := r.active(ctx, orgID, now).
+		Where("clinician_id=? AND testee_id=?", clinicianID, testeeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *grantRepository) ListActiveGrants(ctx context.Context, query domainbreakglass.ActiveGrantQuery, now time.Time) ([]domainbreakglass.Grant, error) {
+	db := r.active(ctx, query.OrgID, now)
+	if query.ClinicianID != 0 {
+		db = db.Where("clinician_id=?", query.ClinicianID)
+	}
+	if len(query.TesteeIDs) > 0 {
+		db = db.Where("testee_id IN ?", query.TesteeIDs)
+	}
+	var pos []GrantPO
+	if err := db.Order("expires_at DESC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return grantsToDomain(pos), nil
+}
+
+func (r *grantRepository) Create(ctx context.Context, grant *domainbreakglass.Grant) error {
+	return r.CreateAndSync(ctx, grantToPO(grant), nil)
+}
+
+func (r *grantRepository) Find(ctx context.Context, orgID int64, id uint64) (*domainbreakglass.Grant, error) {
+	var po GrantPO
+	err := r.WithContext(ctx).Where("org_id=? AND id=? AND deleted_at IS NULL", orgID, id).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	grant := grantToDomain(&po)
+	return &grant, nil
+}
+
+func (r *grantRepository) List(ctx context.Context, filter domainbreakglass.Filter, now time.Time, offset, limit int) ([]domainbreakglass.Grant, int64, error) {
+	query := func() *gorm.DB {
+		db := r.WithContext(ctx).Model(&GrantPO{}).Where("org_id=? AND deleted_at IS NULL", filter.OrgID)
+		if filter.ActiveOnly {
+			db = db.Where("revoked_at IS NULL AND expires_at>?", now)
+		}
+		if filter.ClinicianID != 0 {
+			db = db.Where("clinician_id=?", filter.ClinicianID)
+		}
+		if filter.OperatorUserID != 0 {
+			db = db.Where("operator_user_id=?", filter.OperatorUserID)
+		}
+		if filter.TesteeID != 0 {
+			db = db.Where("testee_id=?", filter.TesteeID)
+		}
+		if filter.ReviewStatus != "" {
+			db = db.Where("review_status=?", string(filter.ReviewStatus))
+		}
+		return db
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []GrantPO
+	if err := query().Order("granted_at DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return grantsToDomain(pos), total, nil
+}
+
+func (r *grantRepository) Revoke(ctx context.Context, orgID int64, id uint64, revokedBy int64, at time.Time) (bool, error) {
+	result := r.active(ctx, orgID, at).Where("id=?", id).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_by": revokedBy, "updated_by": revokedBy})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *grantRepository) UpdateReview(ctx context.Context, grant *domainbreakglass.Grant) error {
+	return r.WithContext(ctx).Model(&GrantPO{}).Where("org_id=? AND id=? AND deleted_at IS NULL", grant.OrgID, grant.ID).
+		Updates(map[string]interface{}{
+			"review_status": string(grant.ReviewStatus),
+			"reviewed_by":   grant.ReviewedBy,
+			"reviewed_at":   grant.ReviewedAt,
+			"review_note":   grant.ReviewNote,
+			"updated_by":    grant.ReviewedBy,
+		}).Error
+}
+
+func (r *grantRepository) active(ctx context.Context, orgID int64, now time.Time) *gorm.DB {
+	return r.WithContext(ctx).Model(&GrantPO{}).
+		Where("org_id=? AND revoked_at IS NULL AND expires_at>? AND deleted_at IS NULL", orgID, now)
+}
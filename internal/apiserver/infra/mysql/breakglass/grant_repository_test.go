@@ -0,0 +1,75 @@
+package breakglass
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newGrantRepositoryTestDB(t *testing.T) (*grantRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGrantRepository(db).(*grantRepository), mock
+}
+
+func TestHasActiveGrantFiltersRevokedAndExpired(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `break_glass_grant` WHERE (org_id=? AND revoked_at IS NULL AND expires_at>? AND deleted_at IS NULL) AND (clinician_id=? AND testee_id=?)")).
+		WithArgs(int64(7), now, uint64(301), uint64(401)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	ok, err := repo.HasActiveGrant(context.Background(), 7, 301, 401, now)
+	if err != nil || !ok {
+		t.Fatalf("HasActiveGrant() = %v, %v", ok, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRevokeOnlyUpdatesActiveGrant(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `break_glass_grant` SET `revoked_at`=?,`revoked_by`=?,`updated_by`=?,`updated_at`=? WHERE (org_id=? AND revoked_at IS NULL AND expires_at>? AND deleted_at IS NULL) AND id=?")).
+		WithArgs(now, int64(900), int64(900), sqlmock.AnyArg(), int64(7), now, uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	updated, err := repo.Revoke(context.Background(), 7, 11, 900, now)
+	if err != nil || updated {
+		t.Fatalf("Revoke() = %v, %v", updated, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindMissingGrantReturnsNil(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `break_glass_grant` WHERE org_id=? AND id=? AND deleted_at IS NULL LIMIT ?")).
+		WithArgs(int64(7), uint64(11), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	grant, err := repo.Find(context.Background(), 7, 11)
+	if err != nil || grant != nil {
+		t.Fatalf("Find() = %+v, %v", grant, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package breakglass
+
+import (
+	domainbreakglass "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func grantToPO(grant *domainbreakglass.Grant) *GrantPO {
+	var operator meta.ID
+	if grant.OperatorUserID > 0 {
+		operator = meta.FromUint64(uint64(grant.OperatorUserID))
+	}
+	return &GrantPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(grant.ID), CreatedAt: grant.GrantedAt, UpdatedAt: grant.GrantedAt,
+			CreatedBy: operator, UpdatedBy: operator,
+		},
+		OrgID: grant.OrgID, ClinicianID: grant.ClinicianID,
+		OperatorUserID: grant.OperatorUserID, TesteeID: grant.TesteeID, Justification: grant.Justification,
+		GrantedAt: grant.GrantedAt, ExpiresAt: grant.ExpiresAt, RevokedAt: grant.RevokedAt, RevokedBy: grant.RevokedBy,
+		ReviewStatus: string(grant.ReviewStatus), ReviewedBy: grant.ReviewedBy, ReviewedAt: grant.ReviewedAt,
+		ReviewNote: grant.ReviewNote,
+	}
+}
+
+func grantToDomain(po *GrantPO) domainbreakglass.Grant {
+	return domainbreakglass.Grant{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, ClinicianID: po.ClinicianID,
+		OperatorUserID: po.OperatorUserID, TesteeID: po.TesteeID, Justification: po.Justification,
+		GrantedAt: po.GrantedAt, ExpiresAt: po.ExpiresAt, RevokedAt: po.RevokedAt, RevokedBy: po.RevokedBy,
+		ReviewStatus: domainbreakglass.ReviewStatus(po.ReviewStatus), ReviewedBy: po.ReviewedBy, ReviewedAt: po.ReviewedAt,
+		ReviewNote: po.ReviewNote,
+	}
+}
+
+func grantsToDomain(pos []GrantPO) []domainbreakglass.Grant {
+	grants := make([]domainbreakglass.Grant, 0, len(pos))
+	for i := range pos {
+		grants = append(grants, grantToDomain(&pos[i]))
+	}
+	return grants
+}
@@ -0,0 +1,42 @@
+package breakglass
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// GrantPO 紧急访问授权持久化对象
+type GrantPO struct {
+	mysql.AuditFields
+
+	OrgID          int64      `gorm:"column:org_id;not null"`
+	ClinicianID    uint64     `gorm:"column:clinician_id;not null"`
+	OperatorUserID int64      `gorm:"column:operator_user_id;not null"`
+	TesteeID       uint64     `gorm:"column:testee_id;not null"`
+	Justification  string     `gorm:"column:justification;size:500;not null"`
+	GrantedAt      time.Time  `gorm:"column:granted_at;not null"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at"`
+	RevokedBy      int64      `gorm:"column:revoked_by;not null;default:0"`
+	ReviewStatus   string     `gorm:"column:review_status;size:16;not null;default:'pending'"`
+	ReviewedBy     int64      `gorm:"column:reviewed_by;not null;default:0"`
+	ReviewedAt     *time.Time `gorm:"column:reviewed_at"`
+	ReviewNote     string     `gorm:"column:review_note;size:500;not null;default:''"`
+}
+
+// TableName 指定表名
+func (GrantPO) TableName() string { return "break_glass_grant" }
+
+// BeforeCreate GORM hook：授权的创建人与创建时间即申请人与授予时间。
+func (p *GrantPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package careteam
+
+import (
+	domaincareteam "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/careteam"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func teamToPO(team *domaincareteam.Team) *TeamPO {
+	return &TeamPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(team.ID), CreatedAt: team.CreatedAt, UpdatedAt: team.UpdatedAt,
+			CreatedBy: meta.ID(team.CreatedBy), UpdatedBy: meta.ID(team.UpdatedBy),
+		},
+		OrgID: team.OrgID, Name: team.Name, Department: team.Department, Description: team.Description,
+	}
+}
+
+func teamToDomain(row *teamRow) domaincareteam.Team {
+	return domaincareteam.Team{
+		ID: row.ID.Uint64(), OrgID: row.OrgID, Name: row.Name, Department: row.Department, Description: row.Description,
+		CreatedBy: int64(row.CreatedBy), UpdatedBy: int64(row.UpdatedBy), CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+		MemberCount: row.MemberCount, TesteeCount: row.TesteeCount,
+	}
+}
+
+func memberToPO(member *domaincareteam.Member) *MemberPO {
+	return &MemberPO{
+		TeamID: member.TeamID, OrgID: member.OrgID, ClinicianID: member.ClinicianID,
+		Role: string(member.Role), AddedBy: member.AddedBy, AddedAt: member.AddedAt,
+	}
+}
+
+func memberToDomain(row *memberRow) domaincareteam.Member {
+	return domaincareteam.Member{
+		TeamID: row.TeamID, OrgID: row.OrgID, ClinicianID: row.ClinicianID, ClinicianName: row.ClinicianName,
+		Department: row.Department, Role: domaincareteam.MemberRole(row.Role), AddedBy: row.AddedBy, AddedAt: row.AddedAt,
+	}
+}
+
+func testeeToPO(assignment *domaincareteam.TesteeAssignment) *TesteePO {
+	return &TesteePO{
+		TeamID: assignment.TeamID, OrgID: assignment.OrgID, TesteeID: assignment.TesteeID,
+		AssignedBy: assignment.AssignedBy, AssignedAt: assignment.AssignedAt,
+	}
+}
+
+func testeeToDomain(row *testeeRow) domaincareteam.TesteeAssignment {
+	return domaincareteam.TesteeAssignment{
+		TeamID: row.TeamID, OrgID: row.OrgID, TesteeID: row.TesteeID, TesteeName: row.TesteeName,
+		AssignedBy: row.AssignedBy, AssignedAt: row.AssignedAt,
+	}
+}
+
+func eventToPO(event *domaincareteam.Event) *EventPO {
+	return &EventPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(event.ID), CreatedAt: event.OccurredAt, UpdatedAt: event.OccurredAt,
+			CreatedBy: meta.ID(event.OperatorUserID), UpdatedBy: meta.ID(event.OperatorUserID),
+		},
+		OrgID: event.OrgID, TeamID: event.TeamID, Action: string(event.Action),
+		ClinicianID: event.ClinicianID, TesteeID: event.TesteeID,
+		FromRole: string(event.FromRole), ToRole: string(event.ToRole),
+		OperatorUserID: event.OperatorUserID, OccurredAt: event.OccurredAt,
+	}
+}
+
+func eventToDomain(po *EventPO) domaincareteam.Event {
+	return domaincareteam.Event{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, TeamID: po.TeamID, Action: domaincareteam.Action(po.Action),
+		ClinicianID: po.ClinicianID, TesteeID: po.TesteeID,
+		FromRole: domaincareteam.MemberRole(po.FromRole), ToRole: domaincareteam.MemberRole(po.ToRole),
+		OperatorUserID: po.OperatorUserID, OccurredAt: po.OccurredAt,
+	}
+}
+
+func accessRoles() []string {
+	roles := domaincareteam.AccessRoles()
+	items := make([]string, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, string(role))
+	}
+	return items
+}
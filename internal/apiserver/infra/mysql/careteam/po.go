@@ -0,0 +1,107 @@
+package careteam
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// TeamPO 照护团队持久化对象
+type TeamPO struct {
+	mysql.AuditFields
+
+	OrgID       int64  `gorm:"column:org_id;not null"`
+	Name        string `gorm:"column:name;size:100;not null"`
+	Department  string `gorm:"column:department;size:50;not null;default:''"`
+	Description string `gorm:"column:description;size:500;not null;default:''"`
+}
+
+// TableName 指定表名
+func (TeamPO) TableName() string { return "care_team" }
+
+// BeforeCreate GORM hook：团队的创建与更新时间由应用层给出。
+func (p *TeamPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// teamRow 团队连同成员数与受试者数的投影。
+type teamRow struct {
+	TeamPO
+	MemberCount int64
+	TesteeCount int64
+}
+
+// MemberPO 团队成员持久化对象。成员以团队与从业者组合主键标识，加入人与加入时间即其审计信息。
+type MemberPO struct {
+	TeamID      uint64    `gorm:"column:team_id;primaryKey"`
+	OrgID       int64     `gorm:"column:org_id;not null"`
+	ClinicianID uint64    `gorm:"column:clinician_id;primaryKey"`
+	Role        string    `gorm:"column:role;size:16;not null"`
+	AddedBy     int64     `gorm:"column:added_by;not null;default:0"`
+	AddedAt     time.Time `gorm:"column:added_at;not null"`
+}
+
+// TableName 指定表名
+func (MemberPO) TableName() string { return "care_team_member" }
+
+// memberRow 成员连同从业者姓名与科室的投影。
+type memberRow struct {
+	MemberPO
+	ClinicianName string
+	Department    string
+}
+
+// TesteePO 团队受试者分配持久化对象。分配以团队与受试者组合主键标识，分配人与分配时间即其审计信息。
+type TesteePO struct {
+	TeamID     uint64    `gorm:"column:team_id;primaryKey"`
+	OrgID      int64     `gorm:"column:org_id;not null"`
+	TesteeID   uint64    `gorm:"column:testee_id;primaryKey"`
+	AssignedBy int64     `gorm:"column:assigned_by;not null;default:0"`
+	AssignedAt time.Time `gorm:"column:assigned_at;not null"`
+}
+
+// TableName 指定表名
+func (TesteePO) TableName() string { return "care_team_testee" }
+
+// testeeRow 受试者分配连同受试者姓名的投影。
+type testeeRow struct {
+	TesteePO
+	TesteeName string
+}
+
+// EventPO 团队变更事件持久化对象；事件只追加。
+type EventPO struct {
+	mysql.AuditFields
+
+	OrgID          int64     `gorm:"column:org_id;not null"`
+	TeamID         uint64    `gorm:"column:team_id;not null"`
+	Action         string    `gorm:"column:action;size:32;not null"`
+	ClinicianID    uint64    `gorm:"column:clinician_id;not null;default:0"`
+	TesteeID       uint64    `gorm:"column:testee_id;not null;default:0"`
+	FromRole       string    `gorm:"column:from_role;size:16;not null;default:''"`
+	ToRole         string    `gorm:"column:to_role;size:16;not null;default:''"`
+	OperatorUserID int64     `gorm:"column:operator_user_id;not null;default:0"`
+	OccurredAt     time.Time `gorm:"column:occurred_at;not null"`
+}
+
+// TableName 指定表名
+func (EventPO) TableName() string { return "care_team_event" }
+
+// BeforeCreate GORM hook：事件的创建人与创建时间即操作人与发生时间。
+func (p *EventPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package careteam
+
+import (
+	"context"
+
+	domaincareteam "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/careteam"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// teamRepository 照护团队仓储。团队、成员与受试者分配按物理删除，删除后同一名称可重新创建；
+// 团队事件只追加，作为审计轨迹保留。
+type teamRepository struct {
+	mysql.BaseRepository[*TeamPO]
+}
+
+// NewTeamRepository 创建照护团队仓储
+func NewTeamRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domaincareteam.Repository {
+	return &teamRepository{BaseRepository: mysql.NewBaseRepository[*TeamPO](db, opts...)}
+}
+
+func (r *teamRepository) HasTeamAccess(ctx context.Context, orgID int64, clinicianID, testeeID uint64) (bool, error) {
+	var count int64
+	err := r.WithContext(ctx).Table("care_team_member AS m").
+		Joins("JOIN care_team_testee AS t ON t.team_id=m.team_id").
+		Where("m.org_id=? AND m.clinician_id=? AND m.role IN ? AND t.testee_id=?", orgID, clinicianID, accessRoles(), testeeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *teamRepository) ListAccessibleTesteeIDs(ctx context.Context, orgID int64, clinicianID uint64) ([]uint64, error) {
+	var ids []uint64
+	err := r.WithContext(ctx).Table("care_team_member AS m").
+		Distinct("t.testee_id").
+		Joins("JOIN care_team_testee AS t ON t.team_id=m.team_id").
+		Where("m.org_id=? AND m.clinician_id=? AND m.role IN ?", orgID, clinicianID, accessRoles()).
+		Pluck("t.testee_id", &ids).Error
+	return ids, err
+}
+
+func (r *teamRepository) ListTeamTesteeIDs(ctx context.Context, orgID int64, teamID uint64) ([]uint64, error) {
+	var ids []uint64
+	err := r.WithContext(ctx).Model(&TesteePO{}).
+		Where("org_id=? AND team_id=?", orgID, teamID).
+		Pluck("testee_id", &ids).Error
+	return ids, err
+}
+
+func (r *teamRepository) FindMember(ctx context.Context, orgID int64, teamID, clinicianID uint64) (*domaincareteam.Member, error) {
+	var pos []MemberPO
+	if err := r.WithContext(ctx).Where("org_id=? AND team_id=? AND clinician_id=?", orgID, teamID, clinicianID).Limit(1).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	member := memberToDomain(&memberRow{MemberPO: pos[0]})
+	return &member, nil
+}
+
+func (r *teamRepository) CreateTeam(ctx context.Context, team *domaincareteam.Team, event *domaincareteam.Event) error {
+	return r.withEvent(ctx, event, func(tx *gorm.DB) error {
+		return tx.Create(teamToPO(team)).Error
+	})
+}
+
+func (r *teamRepository) UpdateTeam(ctx context.Context, team *domaincareteam.Team, event *domaincareteam.Event) error {
+	return r.withEvent(ctx, event, func(tx *gorm.DB) error {
+		return tx.Model(&TeamPO{}).Where("org_id=? AND id=? AND deleted_at IS NULL", team.OrgID, team.ID).
+			Updates(map[string]interface{}{
+				"name":        team.Name,
+				"department":  team.Department,
+				"description": team.Description,
+				"updated_by":  team.UpdatedBy,
+				"updated_at":  team.UpdatedAt,
+			}).Error
+	})
+}
+
+func (r *teamRepository) DeleteTeam(ctx context.Context, orgID int64, teamID uint64, event *domaincareteam.Event) error {
+	return r.withEvent(ctx, event, func(tx *gorm.DB) error {
+		if err := tx.Where("org_id=? AND team_id=?", orgID, teamID).Delete(&MemberPO{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("org_id=? AND team_id=?", orgID, teamID).Delete(&TesteePO{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("org_id=? AND id=?", orgID, teamID).Delete(&TeamPO{}).Error
+	})
+}
+
+func (r *teamRepository) FindTeam(ctx context.Context, orgID int64, teamID uint64) (*domaincareteam.Team, error) {
+	return r.findTeam(ctx, "t.org_id=? AND t.id=?", orgID, teamID)
+}
+
+func (r *teamRepository) FindTeamByName(ctx context.Context, orgID int64, name string) (*domaincareteam.Team, error) {
+	return r.findTeam(ctx, "t.org_id=? AND t.name=?", orgID, name)
+}
+
+func (r *teamRepository) ListTeams(ctx context.Context, filter domaincareteam.TeamFilter) ([]domaincareteam.Team, error) {
+	query := r.teamQuery(ctx).Where("t.org_id=?", filter.OrgID)
+	if filter.Department != "" {
+		query = query.Where("t.department=?", filter.Department)
+	}
+	if filter.ClinicianID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM care_team_member AS cm WHERE cm.team_id=t.id AND cm.clinician_id=?)", filter.ClinicianID)
+	}
+	var rows []teamRow
+	if err := query.Order("t.name ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	teams := make([]domaincareteam.Team, 0, len(rows))
+	for i := range rows {
+		teams = append(teams, teamToDomain(&rows[i]))
+	}
+	return teams, nil
+}
+
+func (r *teamRepository) CreateMember(ctx context.Context, member *domaincareteam.Member, event *domaincareteam.Event) error {
+	return r.withEvent(ctx, event, func(tx *gorm.DB) error {
+		return tx.Create(memberToPO(member)).Error
+	})
+}
+
+func (r *teamRepository) UpdateMemberRole(ctx context.Context, member *domaincareteam.Member, event *domaincareteam.Event) error {
+	return r.withEvent(ctx, event, func(tx *gorm.DB) error {
+		return tx.Model(&MemberPO{}).
+			Where("org_id=? AND team_id=? AND clinician_id=?", member.OrgID, member.TeamID, member.ClinicianID).
+			Update("role", string(member.Role)).Error
+	})
+}
+
+func (r *teamRepository) DeleteMember(ctx context.Context, orgID int64, teamID, clinicianID uint64, event *domaincareteam.Event) (bool, error) {
+	return r.changeWithEvent(ctx, event, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("org_id=? AND team_id=? AND clinician_id=?", orgID, teamID, clinicianID).Delete(&MemberPO{})
+	})
+}
+
+func (r *teamRepository) ListMembers(ctx context.Context, orgID int64, teamID uint64) ([]domaincareteam.Member, error) {
+	var rows []memberRow
+	err := r.WithContext(ctx).Table("care_team_member AS m").
+		Select("m.*, c.name AS clinician_name, c.department").
+		Joins("LEFT JOIN clinician AS c ON c.id=m.clinician_id").
+		Where("m.org_id=? AND m.team_id=?", orgID, teamID).
+		Order("m.added_at ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	members := make([]domaincareteam.Member, 0, len(rows))
+	for i := range rows {
+		members = append(members, memberToDomain(&rows[i]))
+	}
+	return members, nil
+}
+
+func (r *teamRepository) AssignTestee(ctx context.Context, assignment *domaincareteam.TesteeAssignment, event *domaincareteam.Event) (bool, error) {
+	po := testeeToPO(assignment)
+	return r.changeWithEvent(ctx, event, func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(po)
+	})
+}
+
+func (r *teamRepository) UnassignTestee(ctx context.Context, orgID int64, teamID, testeeID uint64, event *domaincareteam.Event) (bool, error) {
+	return r.changeWithEvent(ctx, event, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("org_id=? AND team_id=? AND testee_id=?", orgID, teamID, testeeID).Delete(&TesteePO{})
+	})
+}
+
+func (r *teamRepository) ListTestees(ctx context.Context, orgID int64, teamID uint64, offset, limit int) ([]domaincareteam.TesteeAssignment, int64, error) {
+	var total int64
+	if err := r.WithContext(ctx).Model(&TesteePO{}).Where("org_id=? AND team_id=?", orgID, teamID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []testeeRow
+	err := r.WithContext(ctx).Table("care_team_testee AS a").
+		Select("a.*, t.name AS testee_name").
+		Joins("LEFT JOIN testee AS t ON t.id=a.testee_id").
+		Where("a.org_id=? AND a.team_id=?", orgID, teamID).
+		Order("a.assigned_at DESC").
+		Offset(offset).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]domaincareteam.TesteeAssignment, 0, len(rows))
+	for i := range rows {
+		items = append(items, testeeToDomain(&rows[i]))
+	}
+	return items, total, nil
+}
+
+func (r *teamRepository) ListEvents(ctx context.Context, orgID int64, teamID uint64, offset, limit int) ([]domaincareteam.Event, int64, error) {
+	query := r.WithContext(ctx).Model(&EventPO{}).Where("org_id=? AND team_id=? AND deleted_at IS NULL", orgID, teamID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []EventPO
+	if err := query.Order("occurred_at DESC, id DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	events := make([]domaincareteam.Event, 0, len(pos))
+	for i := range pos {
+		events = append(events, eventToDomain(&pos[i]))
+	}
+	return events, total, nil
+}
+
+// withEvent 在同一事务内执行变更并写入团队事件。
+func (r *teamRepository) withEvent(ctx context.Context, event *domaincareteam.Event, change func(tx *gorm.DB) error) error {
+	return r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := change(tx); err != nil {
+			return err
+		}
+		return tx.Create(eventToPO(event)).Error
+	})
+}
+
+// changeWithEvent 与 withEvent 相同，但只在变更实际影响了记录时写入事件，并返回是否有记录受影响。
+func (r *teamRepository) changeWithEvent(ctx context.Context, event *domaincareteam.Event, change func(tx *gorm.DB) *gorm.DB) (bool, error) {
+	affected := false
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := change(tx)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		affected = true
+		return tx.Create(eventToPO(event)).Error
+	})
+	return affected, err
+}
+
+func (r *teamRepository) teamQuery(ctx context.Context) *gorm.DB {
+	return r.WithContext(ctx).Table("care_team AS t").
+		Select("t.*, " +
+			"(SELECT COUNT(*) FROM care_team_member AS m WHERE m.team_id=t.id) AS member_count, " +
+			"(SELECT COUNT(*) FROM care_team_testee AS a WHERE a.team_id=t.id) AS testee_count").
+		Where("t.deleted_at IS NULL")
+}
+
+func (r *teamRepository) findTeam(ctx context.Context, query string, args ...interface{}) (*domaincareteam.Team, error) {
+	var rows []teamRow
+	if err := r.teamQuery(ctx).Where(query, args...).Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	team := teamToDomain(&rows[0])
+	return &team, nil
+}
@@ -0,0 +1,144 @@
+package careteam
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domaincareteam "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/careteam"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newTeamRepositoryTestDB(t *testing.T) (domaincareteam.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTeamRepository(db), mock
+}
+
+func TestHasTeamAccessOnlyCountsInheritingRoles(t *testing.T) {
+	repo, mock := newTeamRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM care_team_member AS m JOIN care_team_testee AS t ON t.team_id=m.team_id WHERE m.org_id=? AND m.clinician_id=? AND m.role IN (?,?) AND t.testee_id=?")).
+		WithArgs(int64(7), uint64(301), "lead", "member", uint64(401)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	allowed, err := repo.HasTeamAccess(context.Background(), 7, 301, 401)
+	if err != nil || !allowed {
+		t.Fatalf("HasTeamAccess() = %v, %v", allowed, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateMemberRoleWritesEventInSameTransaction(t *testing.T) {
+	repo, mock := newTeamRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `care_team_member` SET `role`=? WHERE org_id=? AND team_id=? AND clinician_id=?")).
+		WithArgs("lead", int64(7), uint64(11), uint64(301)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `care_team_event`")).
+		WithArgs(at, at, nil, int64(900), int64(900), int64(0), uint32(1),
+			int64(7), uint64(11), "member_role_changed", uint64(301), uint64(0), "member", "lead", int64(900), at, int64(21)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateMemberRole(context.Background(),
+		&domaincareteam.Member{TeamID: 11, OrgID: 7, ClinicianID: 301, Role: domaincareteam.RoleLead},
+		&domaincareteam.Event{ID: 21, OrgID: 7, TeamID: 11, Action: domaincareteam.ActionMemberRoleChanged, ClinicianID: 301,
+			FromRole: domaincareteam.RoleMember, ToRole: domaincareteam.RoleLead, OperatorUserID: 900, OccurredAt: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAssignTesteeSkipsEventWhenAlreadyAssigned(t *testing.T) {
+	repo, mock := newTeamRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `care_team_testee` (`team_id`,`org_id`,`testee_id`,`assigned_by`,`assigned_at`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `team_id`=`team_id`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	created, err := repo.AssignTestee(context.Background(),
+		&domaincareteam.TesteeAssignment{TeamID: 11, OrgID: 7, TesteeID: 401, AssignedAt: time.Now()},
+		&domaincareteam.Event{ID: 22, OrgID: 7, TeamID: 11, Action: domaincareteam.ActionTesteeAssigned, TesteeID: 401})
+	if err != nil || created {
+		t.Fatalf("AssignTestee() = %v, %v", created, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteTeamRemovesMembershipBeforeTeam(t *testing.T) {
+	repo, mock := newTeamRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `care_team_member` WHERE org_id=? AND team_id=?")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `care_team_testee` WHERE org_id=? AND team_id=?")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `care_team` WHERE org_id=? AND id=?")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `care_team_event`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.DeleteTeam(context.Background(), 7, 11, &domaincareteam.Event{ID: 23, OrgID: 7, TeamID: 11, Action: domaincareteam.ActionTeamDeleted}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindTeamSkipsDeletedTeams(t *testing.T) {
+	repo, mock := newTeamRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM care_team AS t WHERE t.deleted_at IS NULL AND (t.org_id=? AND t.id=?) LIMIT ?")).
+		WithArgs(int64(7), uint64(11), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	team, err := repo.FindTeam(context.Background(), 7, 11)
+	if err != nil || team != nil {
+		t.Fatalf("FindTeam() = %#v, %v", team, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCareTeamMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000093_add_care_team_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `care_team`",
+		"ALTER TABLE `care_team_event`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `version`",
+		"`created_by` = `operator_user_id`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package clinicalreview
+
+import (
+	domainreview "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func reviewToPO(review *domainreview.Review) *ReviewPO {
+	po := &ReviewPO{
+		AssessmentID: review.AssessmentID, OrgID: review.OrgID, TesteeID: review.TesteeID,
+		Status: string(review.Status), NoteVersion: review.NoteVersion,
+		ReviewedAt: review.ReviewedAt, UpdatedAt: review.UpdatedAt,
+	}
+	if review.Reviewer != nil {
+		po.ReviewerUserID = review.Reviewer.UserID
+		po.ReviewerClinicianID = review.Reviewer.ClinicianID
+		po.ReviewerName = review.Reviewer.Name
+	}
+	return po
+}
+
+func reviewToDomain(po *ReviewPO) *domainreview.Review {
+	review := &domainreview.Review{
+		OrgID: po.OrgID, AssessmentID: po.AssessmentID, TesteeID: po.TesteeID,
+		Status: domainreview.Status(po.Status), NoteVersion: po.NoteVersion,
+		ReviewedAt: po.ReviewedAt, UpdatedAt: po.UpdatedAt,
+	}
+	if po.ReviewerClinicianID != 0 {
+		review.Reviewer = &domainreview.Reviewer{UserID: po.ReviewerUserID, ClinicianID: po.ReviewerClinicianID, Name: po.ReviewerName}
+	}
+	return review
+}
+
+func noteToPO(note *domainreview.Note) *NotePO {
+	return &NotePO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(note.ID), CreatedAt: note.CreatedAt, UpdatedAt: note.CreatedAt,
+			CreatedBy: meta.ID(note.Author.UserID), UpdatedBy: meta.ID(note.Author.UserID),
+		},
+		OrgID: note.OrgID, AssessmentID: note.AssessmentID, TesteeID: note.TesteeID, NoteVersion: note.Version,
+		Content: note.Content, Addendum: note.Addendum,
+		AuthorUserID: note.Author.UserID, AuthorClinicianID: note.Author.ClinicianID, AuthorName: note.Author.Name,
+	}
+}
+
+func noteToDomain(po *NotePO) domainreview.Note {
+	return domainreview.Note{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, AssessmentID: po.AssessmentID, TesteeID: po.TesteeID, Version: po.NoteVersion,
+		Content: po.Content, Addendum: po.Addendum,
+		Author:    domainreview.Reviewer{UserID: po.AuthorUserID, ClinicianID: po.AuthorClinicianID, Name: po.AuthorName},
+		CreatedAt: po.CreatedAt,
+	}
+}
+
+func signedStatuses() []string {
+	statuses := domainreview.SignedStatuses()
+	items := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		items = append(items, string(status))
+	}
+	return items
+}
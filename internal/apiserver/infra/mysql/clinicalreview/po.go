@@ -0,0 +1,73 @@
+package clinicalreview
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// ReviewPO 报告复核状态持久化对象。每个测评至多一条复核状态，以测评 ID 为主键，复核人与复核时间即其审计信息。
+type ReviewPO struct {
+	AssessmentID        uint64     `gorm:"column:assessment_id;primaryKey"`
+	OrgID               int64      `gorm:"column:org_id;not null"`
+	TesteeID            uint64     `gorm:"column:testee_id;not null"`
+	Status              string     `gorm:"column:status;size:16;not null"`
+	NoteVersion         int        `gorm:"column:note_version;not null;default:0"`
+	ReviewerUserID      int64      `gorm:"column:reviewer_user_id;not null;default:0"`
+	ReviewerClinicianID uint64     `gorm:"column:reviewer_clinician_id;not null;default:0"`
+	ReviewerName        string     `gorm:"column:reviewer_name;size:100;not null;default:''"`
+	ReviewedAt          *time.Time `gorm:"column:reviewed_at"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName 指定表名
+func (ReviewPO) TableName() string { return "report_review" }
+
+// NotePO 临床备注版本持久化对象；备注只追加。备注版本存于 note_version，version 为通用乐观锁版本。
+type NotePO struct {
+	mysql.AuditFields
+
+	OrgID             int64  `gorm:"column:org_id;not null"`
+	AssessmentID      uint64 `gorm:"column:assessment_id;not null"`
+	TesteeID          uint64 `gorm:"column:testee_id;not null"`
+	NoteVersion       int    `gorm:"column:note_version;not null"`
+	Content           string `gorm:"column:content;type:text;not null"`
+	Addendum          string `gorm:"column:addendum;size:2000;not null;default:''"`
+	AuthorUserID      int64  `gorm:"column:author_user_id;not null;default:0"`
+	AuthorClinicianID uint64 `gorm:"column:author_clinician_id;not null;default:0"`
+	AuthorName        string `gorm:"column:author_name;size:100;not null;default:''"`
+}
+
+// TableName 指定表名
+func (NotePO) TableName() string { return "report_clinical_note" }
+
+// BeforeCreate GORM hook：备注的创建人与创建时间即作者与写入时间。
+func (p *NotePO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// addendumRow 已签署复核当前备注版本中补充说明的投影。
+type addendumRow struct {
+	Addendum     string
+	ReviewerName string
+	Status       string
+	ReviewedAt   *time.Time
+}
+
+// awaitingReviewRow 待复核队列的投影。
+type awaitingReviewRow struct {
+	AssessmentID uint64
+	OrgID        int64
+	TesteeID     uint64
+	RiskLevel    string
+	NoteVersion  int
+	EvaluatedAt  time.Time
+}
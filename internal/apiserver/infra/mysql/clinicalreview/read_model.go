@@ -0,0 +1,96 @@
+package clinicalreview
+
+import (
+	"context"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	domainreview "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+// ReadModel 复核读模型：为报告投影提供已签署的补充说明、为工作台提供待复核队列。
+type ReadModel interface {
+	reportprojection.AddendumReader
+	workbenchreadmodel.AwaitingReviewReader
+}
+
+type readModel struct {
+	mysql.BaseRepository[*NotePO]
+}
+
+// NewReadModel 创建复核读模型
+func NewReadModel(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) ReadModel {
+	return &readModel{BaseRepository: mysql.NewBaseRepository[*NotePO](db, opts...)}
+}
+
+// FindSignedAddendum 读取已签署复核当前备注版本中的补充说明。
+func (r *readModel) FindSignedAddendum(ctx context.Context, assessmentID uint64) (*reportprojection.ClinicianAddendum, error) {
+	var rows []addendumRow
+	err := r.WithContext(ctx).Table("report_review AS r").
+		Select("n.addendum, r.reviewer_name, r.status, r.reviewed_at").
+		Joins("JOIN report_clinical_note AS n ON n.assessment_id=r.assessment_id AND n.note_version=r.note_version AND n.deleted_at IS NULL").
+		Where("r.assessment_id=? AND r.status IN ? AND n.addendum<>''", assessmentID, signedStatuses()).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	addendum := &reportprojection.ClinicianAddendum{Content: rows[0].Addendum, ReviewerName: rows[0].ReviewerName, ReviewStatus: rows[0].Status}
+	if rows[0].ReviewedAt != nil {
+		addendum.ReviewedAt = *rows[0].ReviewedAt
+	}
+	return addendum, nil
+}
+
+// ListAwaitingReviewQueue 已完成评估但尚未签署复核的测评，等待最久的排在前面。
+func (r *readModel) ListAwaitingReviewQueue(
+	ctx context.Context,
+	filter workbenchreadmodel.AwaitingReviewQueueFilter,
+	page workbenchreadmodel.PageRequest,
+) (workbenchreadmodel.AwaitingReviewPage, error) {
+	result := workbenchreadmodel.AwaitingReviewPage{
+		Items:    []workbenchreadmodel.AwaitingReviewRow{},
+		Page:     max(page.Page, 1),
+		PageSize: page.Limit(),
+	}
+	if filter.RestrictToTesteeIDs && len(filter.TesteeIDs) == 0 {
+		return result, nil
+	}
+	query := func() *gorm.DB {
+		query := r.WithContext(ctx).Table("assessment AS a").
+			Joins("LEFT JOIN report_review AS r ON r.assessment_id=a.id").
+			Where("a.org_id=? AND a.status=? AND a.deleted_at IS NULL", filter.OrgID, "evaluated").
+			Where("r.assessment_id IS NULL OR r.status=?", string(domainreview.StatusPendingReview))
+		if filter.RestrictToTesteeIDs {
+			query = query.Where("a.testee_id IN ?", filter.TesteeIDs)
+		}
+		if triageSQL, triageArgs := workbenchtriage.QueuePredicate(filter.Triage, filter.OrgID, "a.id"); triageSQL != "" {
+			query = query.Where(triageSQL, triageArgs...)
+		}
+		return query
+	}
+	if err := query().Count(&result.Total).Error; err != nil {
+		return workbenchreadmodel.AwaitingReviewPage{}, err
+	}
+	var rows []awaitingReviewRow
+	err := query().
+		Select("a.id AS assessment_id, a.org_id, a.testee_id, a.risk_level, " +
+			"COALESCE(r.note_version, 0) AS note_version, " +
+			"COALESCE(a.evaluated_at, a.updated_at, a.created_at) AS evaluated_at").
+		Order("evaluated_at ASC, a.id ASC").
+		Offset(page.Offset()).Limit(page.Limit()).
+		Scan(&rows).Error
+	if err != nil {
+		return workbenchreadmodel.AwaitingReviewPage{}, err
+	}
+	for _, row := range rows {
+		result.Items = append(result.Items, workbenchreadmodel.AwaitingReviewRow{
+			AssessmentID: row.AssessmentID, OrgID: row.OrgID, TesteeID: row.TesteeID, RiskLevel: row.RiskLevel,
+			NoteVersion: row.NoteVersion, EvaluatedAt: row.EvaluatedAt,
+		})
+	}
+	return result, nil
+}
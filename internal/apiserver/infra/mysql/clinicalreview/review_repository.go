@@ -0,0 +1,94 @@
+package clinicalreview
+
+import (
+	"context"
+
+	domainreview "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reviewRepository 报告临床复核仓储。备注只追加，复核状态随备注或签署在同一事务内保存。
+type reviewRepository struct {
+	mysql.BaseRepository[*NotePO]
+}
+
+// NewReviewRepository 创建报告临床复核仓储
+func NewReviewRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainreview.Repository {
+	return &reviewRepository{BaseRepository: mysql.NewBaseRepository[*NotePO](db, opts...)}
+}
+
+func (r *reviewRepository) FindReview(ctx context.Context, orgID int64, assessmentID uint64) (*domainreview.Review, error) {
+	var pos []ReviewPO
+	if err := r.WithContext(ctx).Where("org_id=? AND assessment_id=?", orgID, assessmentID).Limit(1).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	return reviewToDomain(&pos[0]), nil
+}
+
+func (r *reviewRepository) ListNotes(ctx context.Context, orgID int64, assessmentID uint64) ([]domainreview.Note, error) {
+	var pos []NotePO
+	if err := r.WithContext(ctx).Where("org_id=? AND assessment_id=? AND deleted_at IS NULL", orgID, assessmentID).
+		Order("note_version ASC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	notes := make([]domainreview.Note, 0, len(pos))
+	for i := range pos {
+		notes = append(notes, noteToDomain(&pos[i]))
+	}
+	return notes, nil
+}
+
+func (r *reviewRepository) AppendNote(ctx context.Context, review *domainreview.Review, note *domainreview.Note) (bool, error) {
+	notePO := noteToPO(note)
+	reviewPO := reviewToPO(review)
+	saved := false
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(notePO)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		saved = true
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "assessment_id"}},
+			DoUpdates: clause.AssignmentColumns(reviewUpdateColumns()),
+		}).Create(reviewPO).Error
+	})
+	return saved, err
+}
+
+func (r *reviewRepository) SignOff(ctx context.Context, review *domainreview.Review) (bool, error) {
+	po := reviewToPO(review)
+	saved := false
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(po)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			saved = true
+			return nil
+		}
+		result = tx.Model(&ReviewPO{}).
+			Where("org_id=? AND assessment_id=? AND status=?", po.OrgID, po.AssessmentID, string(domainreview.StatusPendingReview)).
+			Updates(map[string]interface{}{
+				"status":                po.Status,
+				"reviewer_user_id":      po.ReviewerUserID,
+				"reviewer_clinician_id": po.ReviewerClinicianID,
+				"reviewer_name":         po.ReviewerName,
+				"reviewed_at":           po.ReviewedAt,
+				"updated_at":            po.UpdatedAt,
+			})
+		saved = result.RowsAffected > 0
+		return result.Error
+	})
+	return saved, err
+}
+
+func reviewUpdateColumns() []string {
+	return []string{"status", "note_version", "reviewer_user_id", "reviewer_clinician_id", "reviewer_name", "reviewed_at", "updated_at"}
+}
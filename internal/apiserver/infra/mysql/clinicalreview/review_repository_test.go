@@ -0,0 +1,138 @@
+package clinicalreview
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainreview "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newReviewRepositoryTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func TestSignOffOnlyUpdatesPendingReview(t *testing.T) {
+	db, mock := newReviewRepositoryTestDB(t)
+	repo := NewReviewRepository(db)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `report_review`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `report_review` SET `reviewed_at`=?,`reviewer_clinician_id`=?,`reviewer_name`=?,`reviewer_user_id`=?,`status`=?,`updated_at`=? WHERE org_id=? AND assessment_id=? AND status=?")).
+		WithArgs(&at, uint64(301), "王医生", int64(900), "reviewed", at, int64(7), uint64(5001), "pending_review").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	saved, err := repo.SignOff(context.Background(), &domainreview.Review{
+		OrgID: 7, AssessmentID: 5001, TesteeID: 401, Status: domainreview.StatusReviewed,
+		Reviewer:   &domainreview.Reviewer{UserID: 900, ClinicianID: 301, Name: "王医生"},
+		ReviewedAt: &at, UpdatedAt: at,
+	})
+	if err != nil || saved {
+		t.Fatalf("SignOff() = %v, %v; want already signed", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAppendNoteSkipsReviewWhenVersionTaken(t *testing.T) {
+	db, mock := newReviewRepositoryTestDB(t)
+	repo := NewReviewRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `report_clinical_note`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	saved, err := repo.AppendNote(context.Background(),
+		&domainreview.Review{OrgID: 7, AssessmentID: 5001, Status: domainreview.StatusPendingReview, NoteVersion: 2},
+		&domainreview.Note{ID: 31, OrgID: 7, AssessmentID: 5001, Version: 2, Content: "复核意见"})
+	if err != nil || saved {
+		t.Fatalf("AppendNote() = %v, %v; want version conflict", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAwaitingReviewQueueExcludesSignedReports(t *testing.T) {
+	db, mock := newReviewRepositoryTestDB(t)
+	reads := NewReadModel(db)
+	evaluatedAt := time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM assessment AS a LEFT JOIN report_review AS r ON r.assessment_id=a.id WHERE (a.org_id=? AND a.status=? AND a.deleted_at IS NULL) AND (r.assessment_id IS NULL OR r.status=?) AND a.testee_id IN (?,?)")).
+		WithArgs(int64(7), "evaluated", "pending_review", uint64(401), uint64(402)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY evaluated_at ASC, a.id ASC LIMIT ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"assessment_id", "org_id", "testee_id", "risk_level", "note_version", "evaluated_at"}).
+			AddRow(5001, 7, 401, "high", 1, evaluatedAt))
+
+	page, err := reads.ListAwaitingReviewQueue(context.Background(),
+		workbenchreadmodel.AwaitingReviewQueueFilter{OrgID: 7, TesteeIDs: []uint64{401, 402}, RestrictToTesteeIDs: true},
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].AssessmentID != 5001 || page.Items[0].NoteVersion != 1 {
+		t.Fatalf("page = %#v", page)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindSignedAddendumJoinsCurrentNoteVersion(t *testing.T) {
+	db, mock := newReviewRepositoryTestDB(t)
+	reads := NewReadModel(db)
+	reviewedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT n.addendum, r.reviewer_name, r.status, r.reviewed_at FROM report_review AS r JOIN report_clinical_note AS n ON n.assessment_id=r.assessment_id AND n.note_version=r.note_version AND n.deleted_at IS NULL WHERE r.assessment_id=? AND r.status IN (?,?) AND n.addendum<>'' LIMIT ?")).
+		WithArgs(uint64(5001), "reviewed", "amended", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"addendum", "reviewer_name", "status", "reviewed_at"}).
+			AddRow("请按时复诊", "王医生", "reviewed", reviewedAt))
+
+	addendum, err := reads.FindSignedAddendum(context.Background(), 5001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addendum == nil || addendum.Content != "请按时复诊" || addendum.ReviewerName != "王医生" || !addendum.ReviewedAt.Equal(reviewedAt) {
+		t.Fatalf("addendum = %#v", addendum)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClinicalNoteMigrationMovesNoteVersion(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000094_add_clinical_note_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `report_clinical_note`",
+		"CHANGE COLUMN `version` `note_version`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `version` INT UNSIGNED",
+		"`created_by` = `author_user_id`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
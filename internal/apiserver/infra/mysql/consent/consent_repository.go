@@ -0,0 +1,189 @@
+// Package consent 知情同意书与签署记录的 MySQL 仓储。
+package consent
+
+import (
+	"context"
+
+	domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/middleware"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// consentRepository 知情同意仓储。发布与签署在外层事务内读取同意书时加行锁，
+// 同一范围的发布与签署据此串行化。
+type consentRepository struct {
+	documents   mysql.BaseRepository[*DocumentPO]
+	acceptances mysql.BaseRepository[*AcceptancePO]
+}
+
+// NewConsentRepository 创建知情同意仓储
+func NewConsentRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainConsent.Repository {
+	return &consentRepository{
+		documents:   mysql.NewBaseRepository[*DocumentPO](db, opts...),
+		acceptances: mysql.NewBaseRepository[*AcceptancePO](db, opts...),
+	}
+}
+
+// NextVersion 版本号唯一键覆盖全部同意书，因此不排除已软删除的记录。
+func (r *consentRepository) NextVersion(ctx context.Context, orgID int64, scope domainConsent.Scope) (int, error) {
+	var current *int
+	if err := r.documents.WithContext(ctx).Model(&DocumentPO{}).Select("MAX(document_version)").
+		Where("org_id=? AND scope_kind=? AND scope_ref=?", orgID, string(scope.Kind), scope.Ref).
+		Scan(&current).Error; err != nil {
+		return 0, err
+	}
+	if current == nil {
+		return 1, nil
+	}
+	return *current + 1, nil
+}
+
+func (r *consentRepository) SaveDocument(ctx context.Context, doc *domainConsent.Document) error {
+	return r.documents.CreateAndSync(ctx, documentToPO(doc), nil)
+}
+
+func (r *consentRepository) UpdateDocument(ctx context.Context, doc *domainConsent.Document) error {
+	return r.documents.WithContext(ctx).Model(&DocumentPO{}).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", doc.ID().Uint64(), doc.OrgID()).
+		Updates(withOperator(ctx, map[string]interface{}{
+			"status":       string(doc.Status()),
+			"published_by": doc.PublishedBy(),
+			"published_at": doc.PublishedAt(),
+			"retired_at":   doc.RetiredAt(),
+		})).Error
+}
+
+func (r *consentRepository) FindDocument(ctx context.Context, orgID int64, id domainConsent.ID) (*domainConsent.Document, error) {
+	var pos []DocumentPO
+	if err := r.lockInTx(ctx, r.documents.WithContext(ctx)).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", id.Uint64(), orgID).Limit(1).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	return documentToDomain(&pos[0]), nil
+}
+
+func (r *consentRepository) ListDocuments(ctx context.Context, orgID int64, filter domainConsent.DocumentFilter, offset, limit int) ([]*domainConsent.Document, int64, error) {
+	query := r.documents.WithContext(ctx).Model(&DocumentPO{}).Where("org_id=? AND deleted_at IS NULL", orgID)
+	if filter.ScopeKind != "" {
+		query = query.Where("scope_kind=?", string(filter.ScopeKind))
+	}
+	if filter.ScopeRef != "" {
+		query = query.Where("scope_ref=?", filter.ScopeRef)
+	}
+	if filter.Status != "" {
+		query = query.Where("status=?", string(filter.Status))
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+	var pos []DocumentPO
+	if err := query.Order("scope_kind, scope_ref, document_version DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return documentsToDomain(pos), total, nil
+}
+
+func (r *consentRepository) FindPublished(ctx context.Context, orgID int64, scopes []domainConsent.Scope) ([]*domainConsent.Document, error) {
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+	scopeCond := r.documents.DB().Where("scope_kind=? AND scope_ref=?", string(scopes[0].Kind), scopes[0].Ref)
+	for _, scope := range scopes[1:] {
+		scopeCond = scopeCond.Or("scope_kind=? AND scope_ref=?", string(scope.Kind), scope.Ref)
+	}
+	var pos []DocumentPO
+	if err := r.lockInTx(ctx, r.documents.WithContext(ctx)).
+		Where("org_id=? AND status=? AND deleted_at IS NULL", orgID, string(domainConsent.DocumentStatusPublished)).
+		Where(scopeCond).Order("id").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return documentsToDomain(pos), nil
+}
+
+func (r *consentRepository) SaveAcceptance(ctx context.Context, acceptance *domainConsent.Acceptance) error {
+	return r.acceptances.CreateAndSync(ctx, acceptanceToPO(acceptance), nil)
+}
+
+func (r *consentRepository) UpdateAcceptance(ctx context.Context, acceptance *domainConsent.Acceptance) error {
+	return r.acceptances.WithContext(ctx).Model(&AcceptancePO{}).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", acceptance.ID().Uint64(), acceptance.OrgID()).
+		Updates(map[string]interface{}{
+			"withdrawn_by":    acceptance.WithdrawnBy(),
+			"withdrawn_at":    acceptance.WithdrawnAt(),
+			"withdraw_reason": acceptance.WithdrawReason(),
+			"updated_by":      acceptance.WithdrawnBy(),
+		}).Error
+}
+
+func (r *consentRepository) FindAcceptance(ctx context.Context, orgID int64, id domainConsent.ID) (*domainConsent.Acceptance, error) {
+	var pos []AcceptancePO
+	if err := r.acceptances.WithContext(ctx).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", id.Uint64(), orgID).Limit(1).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	return acceptanceToDomain(&pos[0]), nil
+}
+
+func (r *consentRepository) ListActiveAcceptances(ctx context.Context, orgID int64, testeeID uint64, documentIDs []domainConsent.ID) ([]*domainConsent.Acceptance, error) {
+	if len(documentIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, len(documentIDs))
+	for _, id := range documentIDs {
+		ids = append(ids, id.Uint64())
+	}
+	var pos []AcceptancePO
+	if err := r.acceptances.WithContext(ctx).
+		Where("org_id=? AND testee_id=? AND document_id IN ? AND withdrawn_at IS NULL AND deleted_at IS NULL", orgID, testeeID, ids).
+		Order("accepted_at DESC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return acceptancesToDomain(pos), nil
+}
+
+func (r *consentRepository) ListAcceptances(ctx context.Context, orgID int64, filter domainConsent.AcceptanceFilter, offset, limit int) ([]*domainConsent.Acceptance, int64, error) {
+	query := r.acceptances.WithContext(ctx).Model(&AcceptancePO{}).Where("org_id=? AND deleted_at IS NULL", orgID)
+	if filter.TesteeID != 0 {
+		query = query.Where("testee_id=?", filter.TesteeID)
+	}
+	if filter.DocumentID != 0 {
+		query = query.Where("document_id=?", filter.DocumentID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("withdrawn_at IS NULL")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+	var pos []AcceptancePO
+	if err := query.Order("accepted_at DESC, id DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return acceptancesToDomain(pos), total, nil
+}
+
+// lockInTx 仅在外层事务内加行锁；事务外的读取不持有锁。
+func (r *consentRepository) lockInTx(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if _, ok := mysql.TxFromContext(ctx); ok {
+		return db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return db
+}
+
+// withOperator 同意书的替代与停用不记录操作人，更新人取自请求上下文。
+func withOperator(ctx context.Context, updates map[string]interface{}) map[string]interface{} {
+	if userID := middleware.GetUserIDFromContext(ctx); userID > 0 {
+		updates["updated_by"] = userID
+	}
+	return updates
+}
@@ -0,0 +1,167 @@
+package consent
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newConsentRepositoryTestDB(t *testing.T) (domainConsent.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewConsentRepository(db), mock
+}
+
+func TestNextVersionStartsAtOne(t *testing.T) {
+	repo, mock := newConsentRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(document_version) FROM `consent_document` WHERE org_id=? AND scope_kind=? AND scope_ref=?")).
+		WithArgs(int64(7), "model", "SDS").
+		WillReturnRows(sqlmock.NewRows([]string{"MAX(document_version)"}).AddRow(nil))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(document_version) FROM `consent_document`")).
+		WillReturnRows(sqlmock.NewRows([]string{"MAX(document_version)"}).AddRow(3))
+
+	scope := domainConsent.Scope{Kind: domainConsent.ScopeKindModel, Ref: "SDS"}
+	if version, err := repo.NextVersion(context.Background(), 7, scope); err != nil || version != 1 {
+		t.Fatalf("version = %d, err = %v", version, err)
+	}
+	if version, err := repo.NextVersion(context.Background(), 7, scope); err != nil || version != 4 {
+		t.Fatalf("version = %d, err = %v", version, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindPublishedMatchesAnyScope(t *testing.T) {
+	repo, mock := newConsentRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE (org_id=? AND status=? AND deleted_at IS NULL) AND ((scope_kind=? AND scope_ref=?) OR (scope_kind=? AND scope_ref=?))")).
+		WithArgs(int64(7), "published", "org", "", "model", "SDS").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "scope_kind", "scope_ref", "document_version", "version", "status"}).
+			AddRow(1, 7, "org", "", 2, 5, "published"))
+
+	docs, err := repo.FindPublished(context.Background(), 7, domainConsent.SubmissionScopes("SDS", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].Version() != 2 || !docs[0].IsPublished() {
+		t.Fatalf("docs = %+v", docs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcceptancePORoundTrip(t *testing.T) {
+	at := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	doc, _ := domainConsent.NewDocument(domainConsent.NewID(1), 7, domainConsent.Scope{Kind: domainConsent.ScopeKindOrg}, 1, "同意书", "正文", 1, at)
+	_ = doc.Publish(1, at)
+	acceptance, err := domainConsent.Accept(domainConsent.NewID(2), doc, domainConsent.Subject{TesteeID: 3, OrgID: 7},
+		actor.NewFillerRef(41, actor.FillerTypeGuardian), domainConsent.GuardianRelationLegalGuardian,
+		domainConsent.Evidence{IP: "10.0.0.1", RequestID: "req-1"}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	po := acceptanceToPO(acceptance)
+	if po.CreatedBy.Uint64() != 41 || !po.CreatedAt.Equal(at) {
+		t.Fatalf("audit fields = %+v", po.AuditFields)
+	}
+	decoded := acceptanceToDomain(po)
+	if !decoded.Satisfies(doc) || decoded.Filler().UserID() != 41 || !decoded.Filler().IsGuardian() ||
+		decoded.GuardianRelation() != domainConsent.GuardianRelationLegalGuardian || decoded.Evidence().RequestID != "req-1" {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+}
+
+func TestSaveDocumentKeepsDocumentVersionApartFromRowVersion(t *testing.T) {
+	repo, mock := newConsentRepositoryTestDB(t)
+	at := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	doc, err := domainConsent.NewDocument(domainConsent.NewID(11), 7, domainConsent.Scope{Kind: domainConsent.ScopeKindModel, Ref: "SDS"}, 3, "同意书", "正文", 21, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `consent_document` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,`scope_kind`,`scope_ref`,`document_version`,")).
+		WithArgs(at, at, nil, int64(21), int64(21), int64(0), uint32(1),
+			int64(7), "model", "SDS", 3, "同意书", "正文", doc.ContentHash(), "draft", uint64(0), nil, nil, uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SaveDocument(context.Background(), doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConsentMigrationDefinesVersionedDocumentsAndAcceptances(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000072_add_consent.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"CREATE TABLE `consent_document`",
+		"UNIQUE KEY `uk_consent_document_scope_version` (`org_id`,`scope_kind`,`scope_ref`,`version`)",
+		"CREATE TABLE `consent_acceptance`",
+		"`content_hash` CHAR(64) NOT NULL",
+		"`guardian_relation`",
+		"`withdrawn_at` DATETIME(3) NULL",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+	down, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000072_add_consent.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{"DROP TABLE IF EXISTS `consent_acceptance`", "DROP TABLE IF EXISTS `consent_document`"} {
+		if !strings.Contains(string(down), token) {
+			t.Fatalf("down migration does not contain %q", token)
+		}
+	}
+}
+
+func TestConsentAuditFieldsMigrationMovesDocumentVersion(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000102_add_consent_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `consent_document`",
+		"CHANGE COLUMN `version` `document_version`",
+		"ALTER TABLE `consent_acceptance`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `version` INT UNSIGNED",
+		"`created_at` = `accepted_at`, `created_by` = `filler_user_id`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+	down, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000102_add_consent_audit_fields.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(down), "CHANGE COLUMN `document_version` `version`") {
+		t.Fatal("down migration does not restore the document version column")
+	}
+}
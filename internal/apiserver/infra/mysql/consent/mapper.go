@@ -0,0 +1,80 @@
+package consent
+
+import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/actor"
+	domainConsent "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func documentToPO(doc *domainConsent.Document) *DocumentPO {
+	return &DocumentPO{
+		AuditFields: mysql.AuditFields{
+			ID: doc.ID(), CreatedAt: doc.CreatedAt(), UpdatedAt: doc.CreatedAt(),
+			CreatedBy: meta.FromUint64(doc.CreatedBy()), UpdatedBy: meta.FromUint64(doc.CreatedBy()),
+		},
+		OrgID: doc.OrgID(), ScopeKind: string(doc.Scope().Kind), ScopeRef: doc.Scope().Ref,
+		DocumentVersion: doc.Version(), Title: doc.Title(), Body: doc.Body(), ContentHash: doc.ContentHash(),
+		Status: string(doc.Status()), PublishedBy: doc.PublishedBy(), PublishedAt: doc.PublishedAt(), RetiredAt: doc.RetiredAt(),
+	}
+}
+
+func documentToDomain(po *DocumentPO) *domainConsent.Document {
+	return domainConsent.RestoreDocument(
+		po.ID, po.OrgID,
+		domainConsent.Scope{Kind: domainConsent.ScopeKind(po.ScopeKind), Ref: po.ScopeRef},
+		po.DocumentVersion, po.Title, po.Body, po.ContentHash, domainConsent.DocumentStatus(po.Status),
+		po.CreatedBy.Uint64(), po.CreatedAt, po.PublishedBy, po.PublishedAt, po.RetiredAt,
+	)
+}
+
+func documentsToDomain(pos []DocumentPO) []*domainConsent.Document {
+	docs := make([]*domainConsent.Document, 0, len(pos))
+	for i := range pos {
+		docs = append(docs, documentToDomain(&pos[i]))
+	}
+	return docs
+}
+
+func acceptanceToPO(acceptance *domainConsent.Acceptance) *AcceptancePO {
+	evidence := acceptance.Evidence()
+	po := &AcceptancePO{
+		AuditFields: mysql.AuditFields{
+			ID: acceptance.ID(), CreatedAt: acceptance.AcceptedAt(), UpdatedAt: acceptance.AcceptedAt(),
+		},
+		OrgID: acceptance.OrgID(), DocumentID: acceptance.DocumentID().Uint64(),
+		DocumentVersion: acceptance.DocumentVersion(), ScopeKind: string(acceptance.Scope().Kind),
+		ScopeRef: acceptance.Scope().Ref, ContentHash: acceptance.ContentHash(), TesteeID: acceptance.TesteeID(),
+		GuardianRelation: string(acceptance.GuardianRelation()), IP: evidence.IP, UserAgent: evidence.UserAgent,
+		RequestID: evidence.RequestID, AcceptedAt: acceptance.AcceptedAt(), WithdrawnBy: acceptance.WithdrawnBy(),
+		WithdrawnAt: acceptance.WithdrawnAt(), WithdrawReason: acceptance.WithdrawReason(),
+	}
+	if filler := acceptance.Filler(); filler != nil {
+		po.FillerUserID = filler.UserID()
+		po.FillerType = filler.FillerType().String()
+		if filler.UserID() > 0 {
+			po.CreatedBy = meta.FromUint64(uint64(filler.UserID()))
+			po.UpdatedBy = po.CreatedBy
+		}
+	}
+	return po
+}
+
+func acceptanceToDomain(po *AcceptancePO) *domainConsent.Acceptance {
+	return domainConsent.RestoreAcceptance(
+		po.ID, po.OrgID, domainConsent.NewID(po.DocumentID), po.DocumentVersion,
+		domainConsent.Scope{Kind: domainConsent.ScopeKind(po.ScopeKind), Ref: po.ScopeRef}, po.ContentHash,
+		po.TesteeID, actor.NewFillerRef(po.FillerUserID, actor.FillerType(po.FillerType)),
+		domainConsent.GuardianRelation(po.GuardianRelation),
+		domainConsent.Evidence{IP: po.IP, UserAgent: po.UserAgent, RequestID: po.RequestID},
+		po.AcceptedAt, po.WithdrawnBy, po.WithdrawnAt, po.WithdrawReason,
+	)
+}
+
+func acceptancesToDomain(pos []AcceptancePO) []*domainConsent.Acceptance {
+	acceptances := make([]*domainConsent.Acceptance, 0, len(pos))
+	for i := range pos {
+		acceptances = append(acceptances, acceptanceToDomain(&pos[i]))
+	}
+	return acceptances
+}
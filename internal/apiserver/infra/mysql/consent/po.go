@@ -0,0 +1,77 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// DocumentPO 知情同意书持久化对象。同意书版本存于 document_version，version 为通用乐观锁版本。
+type DocumentPO struct {
+	mysql.AuditFields
+
+	OrgID           int64      `gorm:"column:org_id;not null"`
+	ScopeKind       string     `gorm:"column:scope_kind;size:16;not null"`
+	ScopeRef        string     `gorm:"column:scope_ref;size:100;not null;default:''"`
+	DocumentVersion int        `gorm:"column:document_version;not null"`
+	Title           string     `gorm:"column:title;size:200;not null"`
+	Body            string     `gorm:"column:body;type:mediumtext;not null"`
+	ContentHash     string     `gorm:"column:content_hash;size:64;not null"`
+	Status          string     `gorm:"column:status;size:16;not null"`
+	PublishedBy     uint64     `gorm:"column:published_by;not null;default:0"`
+	PublishedAt     *time.Time `gorm:"column:published_at"`
+	RetiredAt       *time.Time `gorm:"column:retired_at"`
+}
+
+// TableName 指定表名
+func (DocumentPO) TableName() string { return "consent_document" }
+
+// BeforeCreate GORM hook：同意书的创建人与创建时间即起草人与起草时间。
+func (p *DocumentPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// AcceptancePO 知情同意签署记录持久化对象；签署记录只追加，撤回只写撤回字段。
+type AcceptancePO struct {
+	mysql.AuditFields
+
+	OrgID            int64      `gorm:"column:org_id;not null"`
+	DocumentID       uint64     `gorm:"column:document_id;not null"`
+	DocumentVersion  int        `gorm:"column:document_version;not null"`
+	ScopeKind        string     `gorm:"column:scope_kind;size:16;not null"`
+	ScopeRef         string     `gorm:"column:scope_ref;size:100;not null;default:''"`
+	ContentHash      string     `gorm:"column:content_hash;size:64;not null"`
+	TesteeID         uint64     `gorm:"column:testee_id;not null"`
+	FillerUserID     int64      `gorm:"column:filler_user_id;not null"`
+	FillerType       string     `gorm:"column:filler_type;size:16;not null"`
+	GuardianRelation string     `gorm:"column:guardian_relation;size:32;not null;default:''"`
+	IP               string     `gorm:"column:ip;size:64;not null;default:''"`
+	UserAgent        string     `gorm:"column:user_agent;size:512;not null;default:''"`
+	RequestID        string     `gorm:"column:request_id;size:64;not null;default:''"`
+	AcceptedAt       time.Time  `gorm:"column:accepted_at;not null"`
+	WithdrawnBy      uint64     `gorm:"column:withdrawn_by;not null;default:0"`
+	WithdrawnAt      *time.Time `gorm:"column:withdrawn_at"`
+	WithdrawReason   string     `gorm:"column:withdraw_reason;size:500;not null;default:''"`
+}
+
+// TableName 指定表名
+func (AcceptancePO) TableName() string { return "consent_acceptance" }
+
+// BeforeCreate GORM hook：签署记录的创建人与创建时间即填写人与签署时间。
+func (p *AcceptancePO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Package criticalitem 关键条目投影的 MySQL 仓储与工作台关键条目队列读模型。
+package criticalitem
+
+import (
+	"context"
+
+	domaincriticalitem "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/criticalitem"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// flagRepository 关键条目投影仓储：消费答卷关键条目事件写入。
+type flagRepository struct {
+	mysql.BaseRepository[*FlagPO]
+}
+
+// NewFlagRepository 创建关键条目投影仓储
+func NewFlagRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domaincriticalitem.Repository {
+	return &flagRepository{BaseRepository: mysql.NewBaseRepository[*FlagPO](db, opts...)}
+}
+
+// RecordCriticalItemFlag 以答卷 ID 为主键写入，事件重复投递时保持首次写入的记录。
+func (r *flagRepository) RecordCriticalItemFlag(ctx context.Context, flag domaincriticalitem.Flag) error {
+	po, err := flagToPO(&flag)
+	if err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(po).Error
+}
@@ -0,0 +1,132 @@
+package criticalitem
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domaincriticalitem "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/criticalitem"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newFlagRepositoryTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func TestRecordCriticalItemFlagIgnoresRedelivery(t *testing.T) {
+	db, mock := newFlagRepositoryTestDB(t)
+	repo := NewFlagRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `critical_item_flag`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.RecordCriticalItemFlag(context.Background(), domaincriticalitem.Flag{
+		AnswerSheetID: 42, OrgID: 7, TesteeID: 9, QuestionnaireCode: "PHQ9",
+		Items: []domaincriticalitem.Hit{
+			{QuestionCode: "Q9", Category: "self_harm", Trigger: "opt-3"},
+			{QuestionCode: "Q10", Category: "self_harm", Trigger: "opt-2"},
+		},
+		FlaggedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCriticalItemQueueDecodesItems(t *testing.T) {
+	db, mock := newFlagRepositoryTestDB(t)
+	readModel := NewQueueReadModel(db)
+	flaggedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `critical_item_flag`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `critical_item_flag`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "testee_id", "questionnaire_code", "questionnaire_version", "categories", "items_json", "flagged_at"}).
+			AddRow(42, 7, 9, "PHQ9", "1.0.0", "abuse,self_harm", `[{"question_code":"Q9","category":"self_harm","trigger":"opt-3"}]`, flaggedAt))
+
+	page, err := readModel.ListCriticalItemQueue(context.Background(),
+		workbenchreadmodel.CriticalItemQueueFilter{OrgID: 7, TesteeIDs: []uint64{9}, RestrictToTesteeIDs: true},
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 {
+		t.Fatalf("page = %#v", page)
+	}
+	row := page.Items[0]
+	if row.AnswerSheetID != 42 || len(row.Categories) != 2 || len(row.Items) != 1 || row.Items[0].Trigger != "opt-3" {
+		t.Fatalf("row = %#v", row)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCriticalItemQueueSkipsEmptyScope(t *testing.T) {
+	db, mock := newFlagRepositoryTestDB(t)
+	readModel := NewQueueReadModel(db)
+	page, err := readModel.ListCriticalItemQueue(context.Background(),
+		workbenchreadmodel.CriticalItemQueueFilter{OrgID: 7, RestrictToTesteeIDs: true},
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 20})
+	if err != nil || page.Total != 0 || len(page.Items) != 0 {
+		t.Fatalf("page = %#v, err = %v", page, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCriticalItemQueueSkipsDeletedFlags(t *testing.T) {
+	db, mock := newFlagRepositoryTestDB(t)
+	readModel := NewQueueReadModel(db)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `critical_item_flag` WHERE org_id=? AND deleted_at IS NULL")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `critical_item_flag` WHERE org_id=? AND deleted_at IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	page, err := readModel.ListCriticalItemQueue(context.Background(),
+		workbenchreadmodel.CriticalItemQueueFilter{OrgID: 7},
+		workbenchreadmodel.PageRequest{Page: 1, PageSize: 20})
+	if err != nil || page.Total != 0 {
+		t.Fatalf("page = %#v, err = %v", page, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCriticalItemFlagMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000097_add_critical_item_flag_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `critical_item_flag`",
+		"ADD COLUMN `created_by`",
+		"ADD COLUMN `version` INT UNSIGNED",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
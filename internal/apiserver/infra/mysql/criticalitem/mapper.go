@@ -0,0 +1,59 @@
+package criticalitem
+
+import (
+	"encoding/json"
+	"strings"
+
+	domaincriticalitem "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/criticalitem"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func flagToPO(flag *domaincriticalitem.Flag) (*FlagPO, error) {
+	items := make([]itemJSON, 0, len(flag.Items))
+	for _, item := range flag.Items {
+		items = append(items, itemJSON{QuestionCode: item.QuestionCode, Category: item.Category, Trigger: item.Trigger})
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return &FlagPO{
+		AuditFields:          mysql.AuditFields{ID: meta.FromUint64(flag.AnswerSheetID)},
+		OrgID:                flag.OrgID,
+		TesteeID:             flag.TesteeID,
+		QuestionnaireCode:    flag.QuestionnaireCode,
+		QuestionnaireVersion: flag.QuestionnaireVersion,
+		Categories:           strings.Join(flag.Categories(), ","),
+		ItemsJSON:            string(raw),
+		FlaggedAt:            flag.FlaggedAt,
+	}, nil
+}
+
+func flagToRow(po *FlagPO) (workbenchreadmodel.CriticalItemRow, error) {
+	var items []itemJSON
+	if po.ItemsJSON != "" {
+		if err := json.Unmarshal([]byte(po.ItemsJSON), &items); err != nil {
+			return workbenchreadmodel.CriticalItemRow{}, err
+		}
+	}
+	hits := make([]workbenchreadmodel.CriticalItemHit, 0, len(items))
+	for _, item := range items {
+		hits = append(hits, workbenchreadmodel.CriticalItemHit{QuestionCode: item.QuestionCode, Category: item.Category, Trigger: item.Trigger})
+	}
+	var categories []string
+	if po.Categories != "" {
+		categories = strings.Split(po.Categories, ",")
+	}
+	return workbenchreadmodel.CriticalItemRow{
+		AnswerSheetID:        po.ID.Uint64(),
+		OrgID:                po.OrgID,
+		TesteeID:             po.TesteeID,
+		QuestionnaireCode:    po.QuestionnaireCode,
+		QuestionnaireVersion: po.QuestionnaireVersion,
+		Categories:           categories,
+		Items:                hits,
+		FlaggedAt:            po.FlaggedAt,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package criticalitem
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+)
+
+// FlagPO 关键条目投影持久化对象；ID 即答卷 ID，每份答卷最多一条。
+type FlagPO struct {
+	mysql.AuditFields
+
+	OrgID                int64     `gorm:"column:org_id;not null"`
+	TesteeID             uint64    `gorm:"column:testee_id;not null"`
+	QuestionnaireCode    string    `gorm:"column:questionnaire_code;size:100;not null"`
+	QuestionnaireVersion string    `gorm:"column:questionnaire_version;size:50;not null;default:''"`
+	Categories           string    `gorm:"column:categories;size:255;not null"`
+	ItemsJSON            string    `gorm:"column:items_json;type:json;not null"`
+	FlaggedAt            time.Time `gorm:"column:flagged_at;not null"`
+}
+
+// TableName 指定表名
+func (FlagPO) TableName() string { return "critical_item_flag" }
+
+// itemJSON 命中条目在 items_json 中的存储格式。
+type itemJSON struct {
+	QuestionCode string `json:"question_code"`
+	Category     string `json:"category"`
+	Trigger      string `json:"trigger"`
+}
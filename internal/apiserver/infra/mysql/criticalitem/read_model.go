@@ -0,0 +1,60 @@
+package criticalitem
+
+import (
+	"context"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+type queueReadModel struct {
+	mysql.BaseRepository[*FlagPO]
+}
+
+// NewQueueReadModel 创建工作台关键条目队列读模型
+func NewQueueReadModel(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) workbenchreadmodel.CriticalItemReader {
+	return &queueReadModel{BaseRepository: mysql.NewBaseRepository[*FlagPO](db, opts...)}
+}
+
+// ListCriticalItemQueue 命中关键条目的答卷，最近提交的排在前面。
+func (r *queueReadModel) ListCriticalItemQueue(
+	ctx context.Context,
+	filter workbenchreadmodel.CriticalItemQueueFilter,
+	page workbenchreadmodel.PageRequest,
+) (workbenchreadmodel.CriticalItemPage, error) {
+	result := workbenchreadmodel.CriticalItemPage{
+		Items:    []workbenchreadmodel.CriticalItemRow{},
+		Page:     max(page.Page, 1),
+		PageSize: page.Limit(),
+	}
+	if filter.RestrictToTesteeIDs && len(filter.TesteeIDs) == 0 {
+		return result, nil
+	}
+	query := func() *gorm.DB {
+		query := r.WithContext(ctx).Model(&FlagPO{}).Where("org_id=? AND deleted_at IS NULL", filter.OrgID)
+		if filter.RestrictToTesteeIDs {
+			query = query.Where("testee_id IN ?", filter.TesteeIDs)
+		}
+		if triageSQL, triageArgs := workbenchtriage.QueuePredicate(filter.Triage, filter.OrgID, "critical_item_flag.id"); triageSQL != "" {
+			query = query.Where(triageSQL, triageArgs...)
+		}
+		return query
+	}
+	if err := query().Count(&result.Total).Error; err != nil {
+		return workbenchreadmodel.CriticalItemPage{}, err
+	}
+	var pos []FlagPO
+	if err := query().Order("flagged_at DESC, id DESC").Offset(page.Offset()).Limit(page.Limit()).Find(&pos).Error; err != nil {
+		return workbenchreadmodel.CriticalItemPage{}, err
+	}
+	for i := range pos {
+		row, err := flagToRow(&pos[i])
+		if err != nil {
+			return workbenchreadmodel.CriticalItemPage{}, err
+		}
+		result.Items = append(result.Items, row)
+	}
+	return result, nil
+}
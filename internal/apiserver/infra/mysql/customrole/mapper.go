@@ -0,0 +1,103 @@
+package customrole
+
+import (
+	"encoding/json"
+
+	domaincustomrole "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/customrole"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func roleToPO(role *domaincustomrole.Role) (*RolePO, error) {
+	capabilities, err := json.Marshal(nonNil(role.Capabilities))
+	if err != nil {
+		return nil, err
+	}
+	scope, err := json.Marshal(scopeJSON{
+		ModelCodes:  nonNil(role.Scope.ModelCodes),
+		PlanIDs:     append([]uint64{}, role.Scope.PlanIDs...),
+		Departments: nonNil(role.Scope.Departments),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RolePO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(role.ID), CreatedAt: role.CreatedAt, UpdatedAt: role.UpdatedAt,
+			CreatedBy: meta.ID(role.CreatedBy), UpdatedBy: meta.ID(role.UpdatedBy),
+		},
+		OrgID: role.OrgID, Code: role.Code, Name: role.Name, Description: role.Description,
+		Capabilities: capabilities, Scope: scope, Sensitivity: role.Sensitivity,
+	}, nil
+}
+
+func roleToDomain(po *RolePO) (domaincustomrole.Role, error) {
+	var capabilities []string
+	if err := json.Unmarshal(po.Capabilities, &capabilities); err != nil {
+		return domaincustomrole.Role{}, err
+	}
+	var scope scopeJSON
+	if len(po.Scope) > 0 {
+		if err := json.Unmarshal(po.Scope, &scope); err != nil {
+			return domaincustomrole.Role{}, err
+		}
+	}
+	return domaincustomrole.Role{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, Code: po.Code, Name: po.Name, Description: po.Description,
+		Capabilities: capabilities,
+		Scope: domaincustomrole.Scope{
+			ModelCodes: emptyToNil(scope.ModelCodes), PlanIDs: emptyIDsToNil(scope.PlanIDs), Departments: emptyToNil(scope.Departments),
+		},
+		Sensitivity: po.Sensitivity,
+		CreatedBy:   int64(po.CreatedBy), UpdatedBy: int64(po.UpdatedBy),
+		CreatedAt: po.CreatedAt, UpdatedAt: po.UpdatedAt,
+	}, nil
+}
+
+func rolesToDomain(pos []RolePO) ([]domaincustomrole.Role, error) {
+	roles := make([]domaincustomrole.Role, 0, len(pos))
+	for i := range pos {
+		role, err := roleToDomain(&pos[i])
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func assignmentToPO(assignment *domaincustomrole.Assignment) *AssignmentPO {
+	return &AssignmentPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(assignment.ID), CreatedAt: assignment.AssignedAt, UpdatedAt: assignment.AssignedAt,
+			CreatedBy: meta.ID(assignment.AssignedBy), UpdatedBy: meta.ID(assignment.AssignedBy),
+		},
+		OrgID: assignment.OrgID, RoleID: assignment.RoleID, OperatorID: assignment.OperatorID,
+		UserID: assignment.UserID, AssignedBy: assignment.AssignedBy, AssignedAt: assignment.AssignedAt,
+	}
+}
+
+func assignmentToDomain(row *assignmentRow) domaincustomrole.Assignment {
+	return domaincustomrole.Assignment{
+		ID: row.ID.Uint64(), OrgID: row.OrgID, RoleID: row.RoleID, OperatorID: row.OperatorID, UserID: row.UserID,
+		OperatorName: row.OperatorName, AssignedBy: row.AssignedBy, AssignedAt: row.AssignedAt,
+	}
+}
+
+func nonNil(items []string) []string {
+	return append([]string{}, items...)
+}
+
+func emptyToNil(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
+func emptyIDsToNil(items []uint64) []uint64 {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
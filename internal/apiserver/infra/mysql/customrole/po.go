@@ -0,0 +1,75 @@
+package customrole
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// RolePO 自定义角色持久化对象
+type RolePO struct {
+	mysql.AuditFields
+
+	OrgID        int64  `gorm:"column:org_id;not null"`
+	Code         string `gorm:"column:code;size:64;not null"`
+	Name         string `gorm:"column:name;size:100;not null"`
+	Description  string `gorm:"column:description;size:500;not null;default:''"`
+	Capabilities []byte `gorm:"column:capabilities;type:json;not null"`
+	Scope        []byte `gorm:"column:scope;type:json;not null"`
+	Sensitivity  string `gorm:"column:sensitivity;size:16;not null"`
+}
+
+// TableName 指定表名
+func (RolePO) TableName() string { return "custom_role" }
+
+// BeforeCreate GORM hook：角色的创建与更新时间由应用层给出。
+func (p *RolePO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// AssignmentPO 自定义角色分配持久化对象
+type AssignmentPO struct {
+	mysql.AuditFields
+
+	OrgID      int64     `gorm:"column:org_id;not null"`
+	RoleID     uint64    `gorm:"column:role_id;not null"`
+	OperatorID uint64    `gorm:"column:operator_id;not null"`
+	UserID     int64     `gorm:"column:user_id;not null"`
+	AssignedBy int64     `gorm:"column:assigned_by;not null;default:0"`
+	AssignedAt time.Time `gorm:"column:assigned_at;not null"`
+}
+
+// TableName 指定表名
+func (AssignmentPO) TableName() string { return "custom_role_assignment" }
+
+// BeforeCreate GORM hook：分配的创建人与创建时间即分配人与分配时间。
+func (p *AssignmentPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// assignmentRow 分配列表连同操作者姓名的投影。
+type assignmentRow struct {
+	AssignmentPO
+	OperatorName string
+}
+
+// scopeJSON 范围的存储形态，空维度存为空数组。
+type scopeJSON struct {
+	ModelCodes  []string `json:"model_codes"`
+	PlanIDs     []uint64 `json:"plan_ids"`
+	Departments []string `json:"departments"`
+}
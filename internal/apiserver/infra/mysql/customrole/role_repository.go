@@ -0,0 +1,130 @@
+package customrole
+
+import (
+	"context"
+	"errors"
+
+	domaincustomrole "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/customrole"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// roleRepository 自定义角色仓储。角色与分配按物理删除，删除后同一编码、同一操作者可重新创建。
+type roleRepository struct {
+	mysql.BaseRepository[*RolePO]
+}
+
+// NewRoleRepository 创建自定义角色仓储
+func NewRoleRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domaincustomrole.Repository {
+	return &roleRepository{BaseRepository: mysql.NewBaseRepository[*RolePO](db, opts...)}
+}
+
+func (r *roleRepository) CreateRole(ctx context.Context, role *domaincustomrole.Role) error {
+	po, err := roleToPO(role)
+	if err != nil {
+		return err
+	}
+	return r.CreateAndSync(ctx, po, nil)
+}
+
+func (r *roleRepository) UpdateRole(ctx context.Context, role *domaincustomrole.Role) error {
+	po, err := roleToPO(role)
+	if err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Model(&RolePO{}).Where("org_id=? AND id=? AND deleted_at IS NULL", role.OrgID, role.ID).
+		Updates(map[string]interface{}{
+			"name":         po.Name,
+			"description":  po.Description,
+			"capabilities": po.Capabilities,
+			"scope":        po.Scope,
+			"sensitivity":  po.Sensitivity,
+			"updated_by":   po.UpdatedBy,
+			"updated_at":   po.UpdatedAt,
+		}).Error
+}
+
+func (r *roleRepository) DeleteRole(ctx context.Context, orgID int64, id uint64) error {
+	return r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("org_id=? AND role_id=?", orgID, id).Delete(&AssignmentPO{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("org_id=? AND id=?", orgID, id).Delete(&RolePO{}).Error
+	})
+}
+
+func (r *roleRepository) FindRole(ctx context.Context, orgID int64, id uint64) (*domaincustomrole.Role, error) {
+	return r.findRole(ctx, "org_id=? AND id=? AND deleted_at IS NULL", orgID, id)
+}
+
+func (r *roleRepository) FindRoleByCode(ctx context.Context, orgID int64, code string) (*domaincustomrole.Role, error) {
+	return r.findRole(ctx, "org_id=? AND code=? AND deleted_at IS NULL", orgID, code)
+}
+
+func (r *roleRepository) ListRoles(ctx context.Context, orgID int64) ([]domaincustomrole.Role, error) {
+	var pos []RolePO
+	if err := r.WithContext(ctx).Where("org_id=? AND deleted_at IS NULL", orgID).Order("code ASC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return rolesToDomain(pos)
+}
+
+func (r *roleRepository) CreateAssignment(ctx context.Context, assignment *domaincustomrole.Assignment) (bool, error) {
+	result := r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(assignmentToPO(assignment))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *roleRepository) DeleteAssignment(ctx context.Context, orgID int64, roleID, operatorID uint64) (bool, error) {
+	result := r.WithContext(ctx).Where("org_id=? AND role_id=? AND operator_id=?", orgID, roleID, operatorID).
+		Delete(&AssignmentPO{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *roleRepository) ListAssignments(ctx context.Context, orgID int64, roleID uint64) ([]domaincustomrole.Assignment, error) {
+	var rows []assignmentRow
+	err := r.WithContext(ctx).Table("custom_role_assignment AS a").
+		Select("a.*, s.name AS operator_name").
+		Joins("LEFT JOIN staff AS s ON s.id=a.operator_id").
+		Where("a.org_id=? AND a.role_id=? AND a.deleted_at IS NULL", orgID, roleID).
+		Order("a.assigned_at ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	assignments := make([]domaincustomrole.Assignment, 0, len(rows))
+	for i := range rows {
+		assignments = append(assignments, assignmentToDomain(&rows[i]))
+	}
+	return assignments, nil
+}
+
+func (r *roleRepository) ListRolesForUser(ctx context.Context, orgID, userID int64) ([]domaincustomrole.Role, error) {
+	var pos []RolePO
+	err := r.WithContext(ctx).Table("custom_role AS r").
+		Select("r.*").
+		Joins("JOIN custom_role_assignment AS a ON a.role_id=r.id AND a.org_id=r.org_id").
+		Where("r.org_id=? AND a.user_id=? AND r.deleted_at IS NULL AND a.deleted_at IS NULL", orgID, userID).
+		Order("r.code ASC").
+		Scan(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	return rolesToDomain(pos)
+}
+
+func (r *roleRepository) findRole(ctx context.Context, query string, args ...interface{}) (*domaincustomrole.Role, error) {
+	var po RolePO
+	err := r.WithContext(ctx).Where(query, args...).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	role, err := roleToDomain(&po)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
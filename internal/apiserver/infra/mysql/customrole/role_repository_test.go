@@ -0,0 +1,151 @@
+package customrole
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domaincustomrole "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/customrole"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newRoleRepositoryTestDB(t *testing.T) (*roleRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRoleRepository(db).(*roleRepository), mock
+}
+
+func TestCreateRoleStoresEmptyScopeDimensionsAsArrays(t *testing.T) {
+	repo, mock := newRoleRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `custom_role`")).
+		WithArgs(at, at, nil, int64(900), int64(900), int64(0), uint32(1),
+			int64(7), "research_assistant", "科研助理", "", []byte(`["read_answersheets"]`),
+			[]byte(`{"model_codes":["SDS"],"plan_ids":[],"departments":[]}`), "deidentified", int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateRole(context.Background(), &domaincustomrole.Role{
+		ID: 11, OrgID: 7, Code: "research_assistant", Name: "科研助理",
+		Capabilities: []string{"read_answersheets"},
+		Scope:        domaincustomrole.Scope{ModelCodes: []string{"SDS"}},
+		Sensitivity:  "deidentified",
+		CreatedBy:    900, UpdatedBy: 900, CreatedAt: at, UpdatedAt: at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListRolesForUserJoinsAssignments(t *testing.T) {
+	repo, mock := newRoleRepositoryTestDB(t)
+	rows := sqlmock.NewRows([]string{"id", "org_id", "code", "name", "description", "capabilities", "scope", "sensitivity"}).
+		AddRow(11, 7, "research_assistant", "科研助理", "", []byte(`["read_answersheets"]`),
+			[]byte(`{"model_codes":["SDS"],"plan_ids":[],"departments":[]}`), "deidentified")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT r.* FROM custom_role AS r JOIN custom_role_assignment AS a ON a.role_id=r.id AND a.org_id=r.org_id WHERE r.org_id=? AND a.user_id=? AND r.deleted_at IS NULL AND a.deleted_at IS NULL ORDER BY r.code ASC")).
+		WithArgs(int64(7), int64(3001)).
+		WillReturnRows(rows)
+
+	roles, err := repo.ListRolesForUser(context.Background(), 7, 3001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0].Scope.ModelCodes[0] != "SDS" || roles[0].Scope.PlanIDs != nil || roles[0].Sensitivity != "deidentified" {
+		t.Fatalf("roles = %#v", roles)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateAssignmentReportsExistingAssignment(t *testing.T) {
+	repo, mock := newRoleRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `custom_role_assignment` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,`role_id`,`operator_id`,`user_id`,`assigned_by`,`assigned_at`,`id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	created, err := repo.CreateAssignment(context.Background(), &domaincustomrole.Assignment{ID: 21, OrgID: 7, RoleID: 11, OperatorID: 31, UserID: 3001, AssignedAt: time.Now()})
+	if err != nil || created {
+		t.Fatalf("CreateAssignment() = %v, %v", created, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteRoleRemovesAssignmentsFirst(t *testing.T) {
+	repo, mock := newRoleRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `custom_role_assignment` WHERE org_id=? AND role_id=?")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `custom_role` WHERE org_id=? AND id=?")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.DeleteRole(context.Background(), 7, 11); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAssignmentsScansOperatorName(t *testing.T) {
+	repo, mock := newRoleRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "org_id", "role_id", "operator_id", "user_id", "assigned_by", "assigned_at", "operator_name"}).
+		AddRow(21, 7, 11, 31, 3001, 900, at, "王医生")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT a.*, s.name AS operator_name FROM custom_role_assignment AS a LEFT JOIN staff AS s ON s.id=a.operator_id WHERE a.org_id=? AND a.role_id=? AND a.deleted_at IS NULL ORDER BY a.assigned_at ASC")).
+		WithArgs(int64(7), uint64(11)).
+		WillReturnRows(rows)
+
+	assignments, err := repo.ListAssignments(context.Background(), 7, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assignments) != 1 || assignments[0].ID != 21 || assignments[0].OperatorName != "王医生" || !assignments[0].AssignedAt.Equal(at) {
+		t.Fatalf("assignments = %#v", assignments)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCustomRoleMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000092_add_custom_role_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `custom_role`",
+		"ALTER TABLE `custom_role_assignment`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `deleted_by`",
+		"ADD COLUMN `version`",
+		"`created_by` = `assigned_by`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
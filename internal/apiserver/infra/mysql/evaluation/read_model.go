@@ -7,6 +7,7 @@ import (
 	"time"
 
 	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/evaluationreadmodel"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -78,6 +79,11 @@ const latestRiskQueueCountSQL = `
 SELECT COUNT(*)
 ` + latestRiskQueueCoreSQL
 
+const latestRiskQueueOrderSQL = `
+ORDER BY occurred_at DESC, assessment_id DESC
+LIMIT ? OFFSET ?
+`
+
 const latestRiskQueueRestrictedTesteePredicate = `
 		AND assessment.testee_id IN ?`
 
@@ -210,9 +216,16 @@ func (r *assessmentReadModel) ListLatestRiskQueue(
 	}
 
 	args := latestRiskQueueArgs(filter)
+	countSQL := latestRiskQueueCountQuery(filter.RestrictToTesteeIDs)
+	selectSQL := latestRiskQueueSelect(filter.RestrictToTesteeIDs)
+	if triageSQL, triageArgs := workbenchtriage.QueuePredicate(filter.Triage, filter.OrgID, "a.id"); triageSQL != "" {
+		countSQL += "AND " + triageSQL
+		selectSQL += "AND " + triageSQL
+		args = append(args, triageArgs...)
+	}
 	var total int64
 	if err := r.WithContext(ctx).
-		Raw(latestRiskQueueCountQuery(filter.RestrictToTesteeIDs), args...).
+		Raw(countSQL, args...).
 		Scan(&total).Error; err != nil {
 		return workbenchreadmodel.LatestRiskPage{}, err
 	}
@@ -220,7 +233,7 @@ func (r *assessmentReadModel) ListLatestRiskQueue(
 	rowArgs := append(args, page.Limit(), page.Offset())
 	var rows []latestRiskPO
 	if err := r.WithContext(ctx).
-		Raw(latestRiskQueueRowsQuery(filter.RestrictToTesteeIDs), rowArgs...).
+		Raw(selectSQL+latestRiskQueueOrderSQL, rowArgs...).
 		Scan(&rows).Error; err != nil {
 		return workbenchreadmodel.LatestRiskPage{}, err
 	}
@@ -282,10 +295,7 @@ type latestRiskPO struct {
 }
 
 func latestRiskQueueRowsQuery(restrictToTesteeIDs bool) string {
-	return latestRiskQueueSelect(restrictToTesteeIDs) + `
-ORDER BY occurred_at DESC, assessment_id DESC
-LIMIT ? OFFSET ?
-`
+	return latestRiskQueueSelect(restrictToTesteeIDs) + latestRiskQueueOrderSQL
 }
 
 func latestRiskQueueCountQuery(restrictToTesteeIDs bool) string {
@@ -0,0 +1,50 @@
+package groupreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	"gorm.io/gorm"
+)
+
+// CohortResolver 从入口登记、计划参与、受试者标签解析群体成员；只返回本机构未删除的受试者。
+type CohortResolver struct{ db *gorm.DB }
+
+var _ groupReportApp.CohortResolver = (*CohortResolver)(nil)
+
+func NewCohortResolver(db *gorm.DB) *CohortResolver { return &CohortResolver{db} }
+
+func (r *CohortResolver) ResolveTestees(ctx context.Context, orgID int64, cohort groupReportApp.Cohort, limit int) ([]uint64, error) {
+	query := r.db.WithContext(ctx).Table("testee").
+		Where("testee.org_id=? AND testee.deleted_at IS NULL", orgID)
+	switch cohort.Kind {
+	case groupReportApp.CohortEntry:
+		query = query.Where("testee.id IN (?)", r.db.Table("assessment_entry_intake_log").
+			Select("testee_id").
+			Where("org_id=? AND entry_id=? AND deleted_at IS NULL", orgID, cohort.EntryID))
+	case groupReportApp.CohortPlan:
+		query = query.Where("testee.id IN (?)", r.db.Table("plan_enrollment").
+			Select("testee_id").
+			Where("org_id=? AND plan_id=? AND deleted_at IS NULL", orgID, cohort.PlanID))
+	case groupReportApp.CohortTag:
+		tag, err := json.Marshal(cohort.Tag)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("JSON_CONTAINS(testee.tags, ?)", string(tag))
+	case groupReportApp.CohortTestees:
+		if len(cohort.TesteeIDs) == 0 {
+			return nil, nil
+		}
+		query = query.Where("testee.id IN ?", cohort.TesteeIDs)
+	default:
+		return nil, fmt.Errorf("unsupported cohort kind %q", cohort.Kind)
+	}
+	var ids []uint64
+	if err := query.Order("testee.id").Limit(limit).Pluck("testee.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,61 @@
+package groupreport
+
+import (
+	"encoding/json"
+
+	domaingroup "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/groupreport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func reportToPO(report *domaingroup.Report) (*ReportPO, error) {
+	input, err := json.Marshal(report.Input)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(report.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportPO{
+		AuditFields: mysql.AuditFields{
+			CreatedAt: report.GeneratedAt, UpdatedAt: report.GeneratedAt,
+			CreatedBy: meta.ID(report.GeneratedBy), UpdatedBy: meta.ID(report.GeneratedBy),
+		},
+		OrgID:            report.OrgID,
+		CohortKind:       string(report.CohortKind),
+		CohortRef:        report.CohortRef,
+		ModelCode:        report.ModelCode,
+		TermFrom:         report.TermFrom,
+		TermTo:           report.TermTo,
+		MinCellSize:      report.MinCellSize,
+		InputFingerprint: report.InputFingerprint,
+		InputSnapshot:    string(input),
+		Content:          string(content),
+		GeneratedBy:      report.GeneratedBy,
+		GeneratedAt:      report.GeneratedAt,
+	}, nil
+}
+
+func reportToDomain(po *ReportPO) (*domaingroup.Report, error) {
+	report := &domaingroup.Report{
+		ID:               po.ID.Uint64(),
+		OrgID:            po.OrgID,
+		CohortKind:       domaingroup.CohortKind(po.CohortKind),
+		CohortRef:        po.CohortRef,
+		ModelCode:        po.ModelCode,
+		TermFrom:         po.TermFrom,
+		TermTo:           po.TermTo,
+		MinCellSize:      po.MinCellSize,
+		InputFingerprint: po.InputFingerprint,
+		GeneratedBy:      po.GeneratedBy,
+		GeneratedAt:      po.GeneratedAt,
+	}
+	if err := json.Unmarshal([]byte(po.InputSnapshot), &report.Input); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(po.Content), &report.Content); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
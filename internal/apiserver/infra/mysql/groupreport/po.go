@@ -0,0 +1,41 @@
+package groupreport
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// ReportPO 群体报告持久化对象；(org_id, input_fingerprint) 唯一，同一输入只保存一份。
+type ReportPO struct {
+	mysql.AuditFields
+
+	OrgID            int64     `gorm:"column:org_id;not null;uniqueIndex:uk_interpretation_group_report_input,priority:1"`
+	CohortKind       string    `gorm:"column:cohort_kind;size:16;not null"`
+	CohortRef        string    `gorm:"column:cohort_ref;size:255;not null;default:''"`
+	ModelCode        string    `gorm:"column:model_code;size:100;not null"`
+	TermFrom         time.Time `gorm:"column:term_from;not null"`
+	TermTo           time.Time `gorm:"column:term_to;not null"`
+	MinCellSize      int       `gorm:"column:min_cell_size;not null"`
+	InputFingerprint string    `gorm:"column:input_fingerprint;size:64;not null;uniqueIndex:uk_interpretation_group_report_input,priority:2"`
+	InputSnapshot    string    `gorm:"column:input_snapshot;type:json;not null"`
+	Content          string    `gorm:"column:content;type:json;not null"`
+	GeneratedBy      int64     `gorm:"column:generated_by;not null;default:0"`
+	GeneratedAt      time.Time `gorm:"column:generated_at;not null"`
+}
+
+// TableName 指定表名
+func (ReportPO) TableName() string { return "interpretation_group_report" }
+
+// BeforeCreate GORM hook：报告的创建人与创建时间即生成人与生成时间。
+func (p *ReportPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
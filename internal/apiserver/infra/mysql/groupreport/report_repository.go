@@ -0,0 +1,60 @@
+// Package groupreport 群体报告的 MySQL 仓储与群体解析。
+package groupreport
+
+import (
+	"context"
+	"errors"
+
+	domaingroup "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/groupreport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reportRepository 群体报告仓储。
+type reportRepository struct {
+	mysql.BaseRepository[*ReportPO]
+}
+
+// NewReportRepository 创建群体报告仓储
+func NewReportRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domaingroup.Repository {
+	return &reportRepository{BaseRepository: mysql.NewBaseRepository[*ReportPO](db, opts...)}
+}
+
+// Save 以 (org_id, input_fingerprint) 唯一键写入；并发生成同一份报告时返回先写入的记录。
+func (r *reportRepository) Save(ctx context.Context, report *domaingroup.Report) (*domaingroup.Report, error) {
+	po, err := reportToPO(report)
+	if err != nil {
+		return nil, err
+	}
+	result := r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(po)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.FindByFingerprint(ctx, report.OrgID, report.InputFingerprint)
+	}
+	saved := *report
+	saved.ID = po.ID.Uint64()
+	return &saved, nil
+}
+
+func (r *reportRepository) FindByFingerprint(ctx context.Context, orgID int64, fingerprint string) (*domaingroup.Report, error) {
+	return r.take(r.WithContext(ctx).Where("org_id=? AND input_fingerprint=? AND deleted_at IS NULL", orgID, fingerprint))
+}
+
+func (r *reportRepository) FindByID(ctx context.Context, id uint64) (*domaingroup.Report, error) {
+	return r.take(r.WithContext(ctx).Where("id=? AND deleted_at IS NULL", id))
+}
+
+func (r *reportRepository) take(query *gorm.DB) (*domaingroup.Report, error) {
+	var po ReportPO
+	err := query.Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reportToDomain(&po)
+}
@@ -0,0 +1,151 @@
+package groupreport
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	domaingroup "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/groupreport"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newReportRepositoryTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func TestSaveReturnsExistingReportWhenFingerprintAlreadyStored(t *testing.T) {
+	db, mock := newReportRepositoryTestDB(t)
+	repo := NewReportRepository(db)
+	generatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_group_report`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_group_report` WHERE org_id=? AND input_fingerprint=? AND deleted_at IS NULL LIMIT ?")).
+		WithArgs(int64(7), "fp", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "cohort_kind", "cohort_ref", "model_code", "min_cell_size", "input_fingerprint", "input_snapshot", "content", "generated_by", "generated_at"}).
+			AddRow(uint64(3), int64(7), "plan", "55", "MHT", 5, "fp", `{"cohort_size":12,"current":[{"assessment_id":"901","outcome_id":"1901","version_token":"v1"}],"previous":[]}`, `{"model_code":"MHT","cohort":{"kind":"plan","size":12,"assessed":10,"coverage":0.8333}}`, int64(42), generatedAt))
+
+	saved, err := repo.Save(context.Background(), &domaingroup.Report{
+		OrgID: 7, CohortKind: domaingroup.CohortPlan, CohortRef: "55", ModelCode: "MHT", InputFingerprint: "fp", GeneratedAt: generatedAt.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID != 3 || saved.GeneratedBy != 42 || saved.Input.CohortSize != 12 || len(saved.Input.Current) != 1 ||
+		saved.Content.Cohort.Assessed != 10 || saved.CohortKind != domaingroup.CohortPlan {
+		t.Fatalf("saved = %#v, want the first stored report", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindByIDReturnsNilWhenMissing(t *testing.T) {
+	db, mock := newReportRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_group_report` WHERE id=? AND deleted_at IS NULL LIMIT ?")).
+		WithArgs(uint64(9), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	report, err := NewReportRepository(db).FindByID(context.Background(), 9)
+	if err != nil || report != nil {
+		t.Fatalf("FindByID() = %#v, %v; want nil, nil", report, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveTagCohortFiltersOrgAndDeletedTestees(t *testing.T) {
+	db, mock := newReportRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `testee`.`id` FROM `testee` WHERE (testee.org_id=? AND testee.deleted_at IS NULL) AND JSON_CONTAINS(testee.tags, ?) ORDER BY testee.id LIMIT ?")).
+		WithArgs(int64(7), `"初二(3)班"`, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(2)).AddRow(uint64(5)))
+
+	ids, err := NewCohortResolver(db).ResolveTestees(context.Background(), 7, groupReportApp.Cohort{Kind: groupReportApp.CohortTag, Tag: "初二(3)班"}, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 5 {
+		t.Fatalf("ids = %v, want [2 5]", ids)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolvePlanCohortUsesEnrollmentSubquery(t *testing.T) {
+	db, mock := newReportRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `testee`.`id` FROM `testee` WHERE (testee.org_id=? AND testee.deleted_at IS NULL) AND testee.id IN (SELECT testee_id FROM `plan_enrollment` WHERE org_id=? AND plan_id=? AND deleted_at IS NULL) ORDER BY testee.id LIMIT ?")).
+		WithArgs(int64(7), int64(7), uint64(55), 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(2)))
+
+	ids, err := NewCohortResolver(db).ResolveTestees(context.Background(), 7, groupReportApp.Cohort{Kind: groupReportApp.CohortPlan, PlanID: 55}, 11)
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("ResolveTestees() = %v, %v", ids, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveRecordsGeneratorAsCreator(t *testing.T) {
+	db, mock := newReportRepositoryTestDB(t)
+	generatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	termFrom := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	termTo := termFrom.AddDate(0, 4, 0)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_group_report` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,")).
+		WithArgs(generatedAt, generatedAt, nil, int64(42), int64(42), int64(0), uint32(1),
+			int64(7), "plan", "55", "MHT", termFrom, termTo, 5, "fp", sqlmock.AnyArg(), sqlmock.AnyArg(), int64(42), generatedAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := NewReportRepository(db).Save(context.Background(), &domaingroup.Report{
+		OrgID: 7, CohortKind: domaingroup.CohortPlan, CohortRef: "55", ModelCode: "MHT", TermFrom: termFrom, TermTo: termTo,
+		MinCellSize: 5, InputFingerprint: "fp", GeneratedBy: 42, GeneratedAt: generatedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID == 0 {
+		t.Fatalf("saved = %#v, want generated ID", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGroupReportMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000101_add_interpretation_group_report_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `interpretation_group_report`",
+		"ADD COLUMN `deleted_at` DATETIME(3) NULL",
+		"ADD KEY `idx_interpretation_group_report_deleted_at` (`deleted_at`)",
+		"`created_at` = `generated_at`",
+		"`created_by` = `generated_by`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
@@ -51,6 +51,9 @@ func NewEnrollmentRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) d
 
 func (r *enrollmentRepository) FindByID(ctx context.Context, id domainplan.PlanEnrollmentID) (*domainplan.Enrollment, error) {
 	po, err := r.BaseRepository.FindByID(ctx, id.Uint64())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +33,9 @@ func (m *PlanMapper) ToPO(domain *domainPlan.AssessmentPlan) *AssessmentPlanPO {
 		Interval:     domain.GetInterval(),
 		TotalTimes:   domain.GetTotalTimes(),
 		Status:       string(domain.GetStatus()),
+
+		TimeZone:       domain.GetTimeZone(),
+		RecurrenceRule: domain.GetRecurrenceRule(),
 	}
 
 	// 设置ID（如果已存在）
@@ -56,6 +59,16 @@ func (m *PlanMapper) ToPO(domain *domainPlan.AssessmentPlan) *AssessmentPlanPO {
 		po.RelativeWeeks = IntSlice(relativeWeeks)
 	}
 
+	// 转换 EXDATE 排除日期列表（time.Time -> string）
+	exceptionDates := domain.GetExceptionDates()
+	if len(exceptionDates) > 0 {
+		dateStrings := make([]string, len(exceptionDates))
+		for i, date := range exceptionDates {
+			dateStrings[i] = date.Format("2006-01-02")
+		}
+		po.ExceptionDates = StringSlice(dateStrings)
+	}
+
 	return po
 }
 
@@ -82,11 +95,32 @@ func (m *PlanMapper) ToDomain(po *AssessmentPlanPO) *domainPlan.AssessmentPlan {
 		relativeWeeks = []int(po.RelativeWeeks)
 	}
 
+	// 转换 EXDATE 排除日期列表（string -> time.Time，按计划时区的日历日期）
+	var exceptionDates []time.Time
+	if len(po.ExceptionDates) > 0 {
+		loc := domainPlan.LoadPlanLocation(po.TimeZone)
+		if loc == nil {
+			loc = time.Local
+		}
+		exceptionDates = make([]time.Time, 0, len(po.ExceptionDates))
+		for _, dateStr := range po.ExceptionDates {
+			if date, err := time.ParseInLocation("2006-01-02", dateStr, loc); err == nil {
+				exceptionDates = append(exceptionDates, date)
+			}
+		}
+	}
+
 	// 构建选项
 	var opts []domainPlan.PlanOption
 	if po.TriggerTime != "" {
 		opts = append(opts, domainPlan.WithTriggerTime(po.TriggerTime))
 	}
+	if po.TimeZone != "" {
+		opts = append(opts, domainPlan.WithTimeZone(po.TimeZone))
+	}
+	if po.RecurrenceRule != "" {
+		opts = append(opts, domainPlan.WithRecurrenceRule(po.RecurrenceRule, exceptionDates))
+	}
 	if len(fixedDates) > 0 {
 		opts = append(opts, domainPlan.WithFixedDates(fixedDates))
 	}
@@ -175,6 +209,7 @@ func (m *TaskMapper) ToPO(domain *domainPlan.AssessmentTask) *AssessmentTaskPO {
 		PlannedAt:         domain.GetPlannedAt(),
 		BusinessCreatedAt: domain.GetBusinessCreatedAt(),
 		ScheduleRevision:  domain.GetScheduleRevision(),
+		TimeZone:          domain.GetTimeZone(),
 		Status:            string(domain.GetStatus()),
 		EntryToken:        domain.GetEntryToken(),
 		EntryURL:          domain.GetEntryURL(),
@@ -251,6 +286,7 @@ func (m *TaskMapper) ToDomain(po *AssessmentTaskPO) *domainPlan.AssessmentTask {
 		po.EntryToken,
 		po.EntryURL,
 	)
+	task.RestoreTimeZone(po.TimeZone)
 	task.RestoreTimeSemantics(po.DueAt, domainPlan.TaskExpirationReason(stringValue(po.ExpirationReason)))
 	fallbackScheduleAt := po.CreatedAt
 	if po.BusinessCreatedAt != nil {
@@ -30,6 +30,11 @@ type AssessmentPlanPO struct {
 	FixedDates    StringSlice `gorm:"column:fixed_dates;type:json"`    // 固定日期列表（JSON）
 	RelativeWeeks IntSlice    `gorm:"column:relative_weeks;type:json"` // 相对周次列表（JSON）
 
+	// 日历规则与时区
+	TimeZone       string      `gorm:"column:time_zone;size:64;not null;default:''"`        // IANA 时区，空值沿用服务器本地时区
+	RecurrenceRule string      `gorm:"column:recurrence_rule;size:512;not null;default:''"` // RRULE 文本（用于 rrule）
+	ExceptionDates StringSlice `gorm:"column:exception_dates;type:json"`                    // EXDATE 排除日期列表（JSON）
+
 	// 状态
 	Status string `gorm:"column:status;size:50;not null;default:'active';index:idx_status"`
 }
@@ -86,6 +91,7 @@ type AssessmentTaskPO struct {
 	CompletedAt       *time.Time `gorm:"column:completed_at"`
 	ExpiredAt         *time.Time `gorm:"column:expired_at"`
 	CanceledAt        *time.Time `gorm:"column:canceled_at"`
+	TimeZone          string     `gorm:"column:time_zone;size:64;not null;default:''"`
 
 	// 状态与关联
 	Status           string  `gorm:"column:status;size:50;not null;default:'pending'"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/FangcunMount/qs-server/internal/apiserver/infra/mysql/workbenchtriage"
 	"github.com/FangcunMount/qs-server/internal/apiserver/port/planreadmodel"
 	"gorm.io/gorm"
 )
@@ -13,13 +14,8 @@ type readModel struct {
 	db *gorm.DB
 }
 
-const followUpQueueTasksQuery = `
-SELECT picked_task.*
-FROM (
-	SELECT
-		ranked.id,
-		ranked.expire_at,
-		ranked.planned_at
+// followUpQueueRankedSQL 每个受试者取一条最早到期的待完成任务；两个占位符依次为受试者条件与分诊条件。
+const followUpQueueRankedSQL = `
 	FROM (
 		SELECT
 			assessment_task.id,
@@ -41,7 +37,16 @@ FROM (
 			AND assessment_task.deleted_at IS NULL
 	) ranked
 	WHERE ranked.row_num = 1
-	ORDER BY
+		%s
+`
+
+const followUpQueueTasksQuery = `
+SELECT picked_task.*
+FROM (
+	SELECT
+		ranked.id,
+		ranked.expire_at,
+		ranked.planned_at` + followUpQueueRankedSQL + `	ORDER BY
 		CASE WHEN ranked.expire_at IS NULL THEN 1 ELSE 0 END ASC,
 		ranked.expire_at ASC,
 		ranked.planned_at ASC,
@@ -56,6 +61,9 @@ FROM (
 		picked.id ASC
 		`
 
+const followUpQueueTriageCountQuery = `
+SELECT COUNT(*)` + followUpQueueRankedSQL
+
 // NewReadModel creates the MySQL-backed plan read model adapter.
 func NewReadModel(db *gorm.DB) interface {
 	planreadmodel.PlanReader
@@ -195,22 +203,37 @@ func (m *readModel) ListFollowUpQueueTasks(ctx context.Context, filter planreadm
 	testeeIDs := uniqueUint64(filter.TesteeIDs)
 	statuses := followUpQueueStatuses()
 
-	var total int64
-	countQuery := m.db.WithContext(ctx).
-		Model(&AssessmentTaskPO{}).
-		Where("org_id = ? AND status IN ? AND deleted_at IS NULL", filter.OrgID, statuses)
-	if filter.RestrictToTesteeIDs {
-		countQuery = countQuery.Where("testee_id IN ?", testeeIDs)
+	args := followUpQueueArgs(filter, statuses)
+	triageSQL, triageArgs := workbenchtriage.QueuePredicate(filter.Triage, filter.OrgID, "ranked.id")
+	if triageSQL != "" {
+		triageSQL = "AND " + triageSQL
+		args = append(args, triageArgs...)
 	}
-	if err := countQuery.Distinct("testee_id").Count(&total).Error; err != nil {
-		return planreadmodel.TaskPage{}, err
+
+	var total int64
+	if triageSQL != "" {
+		// 分诊过滤作用在每个受试者选出的任务上，只能在排序后的子查询上计数。
+		if err := m.db.WithContext(ctx).
+			Raw(fmt.Sprintf(followUpQueueTriageCountQuery, followUpQueueTesteePredicate(filter.RestrictToTesteeIDs), triageSQL), args...).
+			Scan(&total).Error; err != nil {
+			return planreadmodel.TaskPage{}, err
+		}
+	} else {
+		countQuery := m.db.WithContext(ctx).
+			Model(&AssessmentTaskPO{}).
+			Where("org_id = ? AND status IN ? AND deleted_at IS NULL", filter.OrgID, statuses)
+		if filter.RestrictToTesteeIDs {
+			countQuery = countQuery.Where("testee_id IN ?", testeeIDs)
+		}
+		if err := countQuery.Distinct("testee_id").Count(&total).Error; err != nil {
+			return planreadmodel.TaskPage{}, err
+		}
 	}
 
-	args := followUpQueueArgs(filter, statuses)
 	args = append(args, page.Limit(), page.Offset())
 	var pos []AssessmentTaskPO
 	err := m.db.WithContext(ctx).
-		Raw(followUpQueueTasksSQL(filter.RestrictToTesteeIDs), args...).
+		Raw(followUpQueueTasksSQLWithTriage(filter.RestrictToTesteeIDs, triageSQL), args...).
 		Scan(&pos).Error
 	if err != nil {
 		return planreadmodel.TaskPage{}, err
@@ -225,11 +248,18 @@ func (m *readModel) ListFollowUpQueueTasks(ctx context.Context, filter planreadm
 }
 
 func followUpQueueTasksSQL(restrictToTesteeIDs bool) string {
-	testeePredicate := ""
+	return followUpQueueTasksSQLWithTriage(restrictToTesteeIDs, "")
+}
+
+func followUpQueueTasksSQLWithTriage(restrictToTesteeIDs bool, triagePredicate string) string {
+	return fmt.Sprintf(followUpQueueTasksQuery, followUpQueueTesteePredicate(restrictToTesteeIDs), triagePredicate)
+}
+
+func followUpQueueTesteePredicate(restrictToTesteeIDs bool) string {
 	if restrictToTesteeIDs {
-		testeePredicate = "AND assessment_task.testee_id IN ?"
+		return "AND assessment_task.testee_id IN ?"
 	}
-	return fmt.Sprintf(followUpQueueTasksQuery, testeePredicate)
+	return ""
 }
 
 func followUpQueueArgs(filter planreadmodel.FollowUpQueueFilter, statuses []string) []interface{} {
@@ -376,6 +406,10 @@ func planRowFromPO(po *AssessmentPlanPO) planreadmodel.PlanRow {
 		FixedDates:    append([]string(nil), po.FixedDates...),
 		RelativeWeeks: append([]int(nil), po.RelativeWeeks...),
 		Status:        po.Status,
+
+		TimeZone:       po.TimeZone,
+		RecurrenceRule: po.RecurrenceRule,
+		ExceptionDates: append([]string(nil), po.ExceptionDates...),
 	}
 }
 
@@ -411,6 +445,7 @@ func taskRowFromPO(po *AssessmentTaskPO) planreadmodel.TaskRow {
 		AssessmentID:     po.AssessmentID,
 		EntryToken:       po.EntryToken,
 		EntryURL:         po.EntryURL,
+		TimeZone:         po.TimeZone,
 	}
 }
 
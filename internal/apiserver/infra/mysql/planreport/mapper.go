@@ -0,0 +1,59 @@
+package planreport
+
+import (
+	"encoding/json"
+
+	domainplanreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/planreport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+)
+
+func reportToPO(report *domainplanreport.Report) (*ReportPO, error) {
+	input, err := json.Marshal(report.Input)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(report.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportPO{
+		AuditFields:          mysql.AuditFields{CreatedAt: report.GeneratedAt, UpdatedAt: report.GeneratedAt},
+		OrgID:                report.OrgID,
+		EnrollmentID:         report.EnrollmentID,
+		PlanID:               report.PlanID,
+		TesteeID:             report.TesteeID,
+		Round:                report.Round,
+		Trigger:              string(report.Trigger),
+		TemplateVersion:      report.TemplateVersion,
+		BuilderIdentity:      report.BuilderIdentity,
+		ContentSchemaVersion: report.ContentSchemaVersion,
+		InputFingerprint:     report.InputFingerprint,
+		InputSnapshot:        string(input),
+		Content:              string(content),
+		GeneratedAt:          report.GeneratedAt,
+	}, nil
+}
+
+func reportToDomain(po *ReportPO) (*domainplanreport.Report, error) {
+	report := &domainplanreport.Report{
+		ID:                   po.ID.Uint64(),
+		OrgID:                po.OrgID,
+		EnrollmentID:         po.EnrollmentID,
+		PlanID:               po.PlanID,
+		TesteeID:             po.TesteeID,
+		Round:                po.Round,
+		Trigger:              domainplanreport.Trigger(po.Trigger),
+		TemplateVersion:      po.TemplateVersion,
+		BuilderIdentity:      po.BuilderIdentity,
+		ContentSchemaVersion: po.ContentSchemaVersion,
+		InputFingerprint:     po.InputFingerprint,
+		GeneratedAt:          po.GeneratedAt,
+	}
+	if err := json.Unmarshal([]byte(po.InputSnapshot), &report.Input); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(po.Content), &report.Content); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,42 @@
+package planreport
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// ReportPO 纵向计划报告持久化对象；(enrollment_id, input_fingerprint) 唯一，同一输入只保存一份。
+type ReportPO struct {
+	mysql.AuditFields
+
+	OrgID                int64     `gorm:"column:org_id;not null"`
+	EnrollmentID         uint64    `gorm:"column:enrollment_id;not null;uniqueIndex:uk_interpretation_plan_report_input,priority:1"`
+	PlanID               uint64    `gorm:"column:plan_id;not null"`
+	TesteeID             uint64    `gorm:"column:testee_id;not null"`
+	Round                uint32    `gorm:"column:round;not null"`
+	Trigger              string    `gorm:"column:trigger;size:32;not null"`
+	TemplateVersion      string    `gorm:"column:template_version;size:100;not null;default:''"`
+	BuilderIdentity      string    `gorm:"column:builder_identity;size:100;not null"`
+	ContentSchemaVersion string    `gorm:"column:content_schema_version;size:50;not null"`
+	InputFingerprint     string    `gorm:"column:input_fingerprint;size:64;not null;uniqueIndex:uk_interpretation_plan_report_input,priority:2"`
+	InputSnapshot        string    `gorm:"column:input_snapshot;type:json;not null"`
+	Content              string    `gorm:"column:content;type:json;not null"`
+	GeneratedAt          time.Time `gorm:"column:generated_at;not null"`
+}
+
+// TableName 指定表名
+func (ReportPO) TableName() string { return "interpretation_plan_report" }
+
+// BeforeCreate GORM hook：报告的创建时间即生成时间。
+func (p *ReportPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package planreport 纵向计划报告的 MySQL 仓储。
+package planreport
+
+import (
+	"context"
+	"errors"
+
+	domainplanreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/planreport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reportRepository 纵向计划报告仓储。
+type reportRepository struct {
+	mysql.BaseRepository[*ReportPO]
+}
+
+// NewReportRepository 创建纵向计划报告仓储
+func NewReportRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainplanreport.Repository {
+	return &reportRepository{BaseRepository: mysql.NewBaseRepository[*ReportPO](db, opts...)}
+}
+
+// Save 以 (enrollment_id, input_fingerprint) 唯一键写入；并发生成同一份报告时返回先写入的记录。
+func (r *reportRepository) Save(ctx context.Context, report *domainplanreport.Report) (*domainplanreport.Report, error) {
+	po, err := reportToPO(report)
+	if err != nil {
+		return nil, err
+	}
+	result := r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(po)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.FindByFingerprint(ctx, report.EnrollmentID, report.InputFingerprint)
+	}
+	saved := *report
+	saved.ID = po.ID.Uint64()
+	return &saved, nil
+}
+
+func (r *reportRepository) FindByFingerprint(ctx context.Context, enrollmentID uint64, fingerprint string) (*domainplanreport.Report, error) {
+	return r.take(r.WithContext(ctx).Where("enrollment_id=? AND input_fingerprint=? AND deleted_at IS NULL", enrollmentID, fingerprint))
+}
+
+func (r *reportRepository) FindLatestByEnrollment(ctx context.Context, enrollmentID uint64) (*domainplanreport.Report, error) {
+	return r.take(r.WithContext(ctx).
+		Where("enrollment_id=? AND deleted_at IS NULL", enrollmentID).
+		Order("generated_at DESC, id DESC"))
+}
+
+func (r *reportRepository) take(query *gorm.DB) (*domainplanreport.Report, error) {
+	var po ReportPO
+	err := query.Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reportToDomain(&po)
+}
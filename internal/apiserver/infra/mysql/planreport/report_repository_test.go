@@ -0,0 +1,115 @@
+package planreport
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainplanreport "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/planreport"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newReportRepositoryTestDB(t *testing.T) (domainplanreport.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewReportRepository(db), mock
+}
+
+func TestSaveReturnsExistingReportWhenFingerprintAlreadyStored(t *testing.T) {
+	repo, mock := newReportRepositoryTestDB(t)
+	generatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_plan_report`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_plan_report` WHERE enrollment_id=? AND input_fingerprint=? AND deleted_at IS NULL LIMIT ?")).
+		WithArgs(uint64(77), "fp", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "enrollment_id", "trigger", "input_fingerprint", "input_snapshot", "content", "generated_at"}).
+			AddRow(uint64(3), int64(7), uint64(77), "enrollment_closed", "fp", `{"outcomes":[{"seq":1,"outcome_id":"901"}],"planned_tasks":4}`, `{"conclusion":"轻度","factors":[]}`, generatedAt))
+
+	saved, err := repo.Save(context.Background(), &domainplanreport.Report{
+		OrgID: 7, EnrollmentID: 77, Trigger: domainplanreport.TriggerOnDemand, InputFingerprint: "fp", GeneratedAt: generatedAt.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID != 3 || saved.Trigger != domainplanreport.TriggerEnrollmentClosed || saved.Content.Conclusion != "轻度" ||
+		saved.Input.PlannedTasks != 4 || len(saved.Input.Outcomes) != 1 || saved.Input.Outcomes[0].OutcomeID != "901" {
+		t.Fatalf("saved = %#v, want the first stored report", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindLatestByEnrollmentReturnsNilWhenMissing(t *testing.T) {
+	repo, mock := newReportRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_plan_report` WHERE enrollment_id=? AND deleted_at IS NULL ORDER BY generated_at DESC, id DESC LIMIT ?")).
+		WithArgs(uint64(77), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	report, err := repo.FindLatestByEnrollment(context.Background(), 77)
+	if err != nil || report != nil {
+		t.Fatalf("FindLatestByEnrollment() = %#v, %v; want nil, nil", report, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveKeepsGenerationTimeAsCreationTime(t *testing.T) {
+	repo, mock := newReportRepositoryTestDB(t)
+	generatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_plan_report` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,")).
+		WithArgs(generatedAt, generatedAt, nil, int64(0), int64(0), int64(0), uint32(1),
+			int64(7), uint64(77), uint64(5), uint64(401), uint32(1), "on_demand", "v1", "longitudinal", "1", "fp",
+			sqlmock.AnyArg(), sqlmock.AnyArg(), generatedAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.Save(context.Background(), &domainplanreport.Report{
+		OrgID: 7, EnrollmentID: 77, PlanID: 5, TesteeID: 401, Round: 1, Trigger: domainplanreport.TriggerOnDemand,
+		TemplateVersion: "v1", BuilderIdentity: "longitudinal", ContentSchemaVersion: "1", InputFingerprint: "fp", GeneratedAt: generatedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID == 0 {
+		t.Fatalf("saved = %#v, want generated ID", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlanReportMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000099_add_interpretation_plan_report_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `interpretation_plan_report`",
+		"ADD COLUMN `deleted_at` DATETIME(3) NULL",
+		"ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1",
+		"ADD KEY `idx_interpretation_plan_report_deleted_at` (`deleted_at`)",
+		"`created_at` = `generated_at`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
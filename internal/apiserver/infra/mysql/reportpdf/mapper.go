@@ -0,0 +1,41 @@
+package reportpdf
+
+import (
+	domainpdf "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+)
+
+func renditionToPO(rendition *domainpdf.Rendition) *RenditionPO {
+	return &RenditionPO{
+		AuditFields:     mysql.AuditFields{CreatedAt: rendition.RenderedAt, UpdatedAt: rendition.RenderedAt},
+		ReportID:        rendition.ReportID,
+		OrgID:           rendition.OrgID,
+		AssessmentID:    rendition.AssessmentID,
+		TesteeID:        rendition.TesteeID,
+		ObjectKey:       rendition.ObjectKey,
+		ContentHash:     rendition.ContentHash,
+		TemplateVersion: rendition.TemplateVersion,
+		ModelCode:       rendition.ModelCode,
+		ModelVersion:    rendition.ModelVersion,
+		SizeBytes:       rendition.SizeBytes,
+		ReportCreatedAt: rendition.ReportCreatedAt,
+		RenderedAt:      rendition.RenderedAt,
+	}
+}
+
+func renditionToDomain(po *RenditionPO) *domainpdf.Rendition {
+	return &domainpdf.Rendition{
+		ReportID:        po.ReportID,
+		OrgID:           po.OrgID,
+		AssessmentID:    po.AssessmentID,
+		TesteeID:        po.TesteeID,
+		ObjectKey:       po.ObjectKey,
+		ContentHash:     po.ContentHash,
+		TemplateVersion: po.TemplateVersion,
+		ModelCode:       po.ModelCode,
+		ModelVersion:    po.ModelVersion,
+		SizeBytes:       po.SizeBytes,
+		ReportCreatedAt: po.ReportCreatedAt,
+		RenderedAt:      po.RenderedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package reportpdf
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// RenditionPO 报告 PDF 渲染记录持久化对象；report_id 唯一，每份报告最多一条。
+type RenditionPO struct {
+	mysql.AuditFields
+
+	ReportID        string    `gorm:"column:report_id;size:64;not null;uniqueIndex:uk_interpretation_report_pdf_report"`
+	OrgID           int64     `gorm:"column:org_id;not null"`
+	AssessmentID    uint64    `gorm:"column:assessment_id;not null"`
+	TesteeID        uint64    `gorm:"column:testee_id;not null"`
+	ObjectKey       string    `gorm:"column:object_key;size:255;not null"`
+	ContentHash     string    `gorm:"column:content_hash;size:64;not null"`
+	TemplateVersion string    `gorm:"column:template_version;size:100;not null;default:''"`
+	ModelCode       string    `gorm:"column:model_code;size:100;not null;default:''"`
+	ModelVersion    string    `gorm:"column:model_version;size:50;not null;default:''"`
+	SizeBytes       int64     `gorm:"column:size_bytes;not null"`
+	ReportCreatedAt time.Time `gorm:"column:report_created_at;not null"`
+	RenderedAt      time.Time `gorm:"column:rendered_at;not null"`
+}
+
+// TableName 指定表名
+func (RenditionPO) TableName() string { return "interpretation_report_pdf" }
+
+// BeforeCreate GORM hook：渲染记录的创建时间即渲染时间。
+func (p *RenditionPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
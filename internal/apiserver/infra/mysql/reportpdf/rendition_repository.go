@@ -0,0 +1,49 @@
+// Package reportpdf 报告 PDF 渲染记录的 MySQL 仓储。
+package reportpdf
+
+import (
+	"context"
+	"errors"
+
+	domainpdf "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// renditionRepository 报告 PDF 渲染记录仓储。
+type renditionRepository struct {
+	mysql.BaseRepository[*RenditionPO]
+}
+
+// NewRenditionRepository 创建渲染记录仓储
+func NewRenditionRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainpdf.Repository {
+	return &renditionRepository{BaseRepository: mysql.NewBaseRepository[*RenditionPO](db, opts...)}
+}
+
+// Save 以报告 ID 唯一键写入，事件重复投递时保持首次写入的记录。
+func (r *renditionRepository) Save(ctx context.Context, rendition *domainpdf.Rendition) error {
+	return r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(renditionToPO(rendition)).Error
+}
+
+func (r *renditionRepository) FindByReportID(ctx context.Context, reportID string) (*domainpdf.Rendition, error) {
+	return r.take(r.WithContext(ctx).Where("report_id=? AND deleted_at IS NULL", reportID))
+}
+
+func (r *renditionRepository) FindLatestByAssessment(ctx context.Context, assessmentID uint64) (*domainpdf.Rendition, error) {
+	return r.take(r.WithContext(ctx).
+		Where("assessment_id=? AND deleted_at IS NULL", assessmentID).
+		Order("report_created_at DESC, rendered_at DESC"))
+}
+
+func (r *renditionRepository) take(query *gorm.DB) (*domainpdf.Rendition, error) {
+	var po RenditionPO
+	err := query.Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return renditionToDomain(&po), nil
+}
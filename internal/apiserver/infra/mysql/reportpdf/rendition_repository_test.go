@@ -0,0 +1,107 @@
+package reportpdf
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainpdf "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportpdf"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newRenditionRepositoryTestDB(t *testing.T) (domainpdf.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRenditionRepository(db), mock
+}
+
+func TestFindLatestByAssessmentOrdersByReportCreation(t *testing.T) {
+	repo, mock := newRenditionRepositoryTestDB(t)
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_report_pdf` WHERE assessment_id=? AND deleted_at IS NULL ORDER BY report_created_at DESC, rendered_at DESC LIMIT ?")).
+		WithArgs(uint64(5001), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id", "org_id", "assessment_id", "testee_id", "object_key", "content_hash", "size_bytes", "report_created_at"}).
+			AddRow("rpt-2", int64(7), uint64(5001), uint64(401), "report-pdf/7/401/5001/rpt-2.pdf", "abc", int64(2048), createdAt))
+
+	rendition, err := repo.FindLatestByAssessment(context.Background(), 5001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendition == nil || rendition.ReportID != "rpt-2" || rendition.TesteeID != 401 || !rendition.ReportCreatedAt.Equal(createdAt) {
+		t.Fatalf("rendition = %#v", rendition)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindByReportIDReturnsNilWhenMissing(t *testing.T) {
+	repo, mock := newRenditionRepositoryTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interpretation_report_pdf` WHERE report_id=? AND deleted_at IS NULL LIMIT ?")).
+		WithArgs("rpt-missing", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+
+	rendition, err := repo.FindByReportID(context.Background(), "rpt-missing")
+	if err != nil || rendition != nil {
+		t.Fatalf("FindByReportID() = %#v, %v; want nil, nil", rendition, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveKeepsRenderTimeAsCreationTime(t *testing.T) {
+	repo, mock := newRenditionRepositoryTestDB(t)
+	renderedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	reportCreatedAt := renderedAt.Add(-time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_report_pdf` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`report_id`,")).
+		WithArgs(renderedAt, renderedAt, nil, int64(0), int64(0), int64(0), uint32(1),
+			"rpt-2", int64(7), uint64(5001), uint64(401), "report-pdf/7/401/5001/rpt-2.pdf", "abc", "v1", "SCL90", "1.0.0", int64(2048),
+			reportCreatedAt, renderedAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Save(context.Background(), &domainpdf.Rendition{
+		ReportID: "rpt-2", OrgID: 7, AssessmentID: 5001, TesteeID: 401, ObjectKey: "report-pdf/7/401/5001/rpt-2.pdf",
+		ContentHash: "abc", TemplateVersion: "v1", ModelCode: "SCL90", ModelVersion: "1.0.0", SizeBytes: 2048,
+		ReportCreatedAt: reportCreatedAt, RenderedAt: renderedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReportPDFMigrationAddsSurrogateKey(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000098_add_interpretation_report_pdf_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `interpretation_report_pdf`",
+		"ADD COLUMN `id` BIGINT UNSIGNED",
+		"ADD PRIMARY KEY (`id`)",
+		"ADD UNIQUE KEY `uk_interpretation_report_pdf_report` (`report_id`)",
+		"`created_at` = `rendered_at`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package reportshare 报告分享授权与访问记录的 MySQL 仓储。
+package reportshare
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainshare "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportshare"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+// grantRepository 报告分享授权仓储。
+// 浏览计数与 PIN 错误计数都用条件 UPDATE 累加，并发访问不会超出授权上限。
+type grantRepository struct {
+	mysql.BaseRepository[*GrantPO]
+}
+
+// NewGrantRepository 创建报告分享授权仓储
+func NewGrantRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainshare.Repository {
+	return &grantRepository{BaseRepository: mysql.NewBaseRepository[*GrantPO](db, opts...)}
+}
+
+func (r *grantRepository) Create(ctx context.Context, grant *domainshare.Grant) error {
+	return r.WithContext(ctx).Create(grantToPO(grant)).Error
+}
+
+func (r *grantRepository) FindByID(ctx context.Context, id uint64) (*domainshare.Grant, error) {
+	return r.take(r.WithContext(ctx).Where("id=? AND deleted_at IS NULL", id))
+}
+
+func (r *grantRepository) FindBySecretHash(ctx context.Context, hash string) (*domainshare.Grant, error) {
+	return r.take(r.WithContext(ctx).Where("secret_hash=? AND deleted_at IS NULL", hash))
+}
+
+func (r *grantRepository) ListByAssessment(ctx context.Context, testeeID, assessmentID uint64) ([]*domainshare.Grant, error) {
+	var pos []GrantPO
+	if err := r.WithContext(ctx).Where("testee_id=? AND assessment_id=? AND deleted_at IS NULL", testeeID, assessmentID).
+		Order("created_at DESC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	grants := make([]*domainshare.Grant, 0, len(pos))
+	for index := range pos {
+		grants = append(grants, grantToDomain(&pos[index]))
+	}
+	return grants, nil
+}
+
+func (r *grantRepository) CountActive(ctx context.Context, assessmentID uint64, now time.Time) (int, error) {
+	var count int64
+	err := r.active(r.WithContext(ctx).Model(&GrantPO{}).Where("assessment_id=?", assessmentID), now).Count(&count).Error
+	return int(count), err
+}
+
+func (r *grantRepository) Revoke(ctx context.Context, id uint64, at time.Time) error {
+	return r.WithContext(ctx).Model(&GrantPO{}).Where("id=? AND revoked_at IS NULL AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": at, "updated_at": at}).Error
+}
+
+func (r *grantRepository) RecordFailedPIN(ctx context.Context, id uint64) error {
+	return r.WithContext(ctx).Model(&GrantPO{}).Where("id=? AND deleted_at IS NULL", id).
+		Update("failed_pin_attempts", gorm.Expr("failed_pin_attempts + 1")).Error
+}
+
+// ConsumeView 在同一事务中累加浏览次数、清零 PIN 错误计数并写入访问记录；条件不满足时不写入。
+func (r *grantRepository) ConsumeView(ctx context.Context, access *domainshare.Access) (bool, error) {
+	consumed := false
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := r.active(tx.Model(&GrantPO{}).Where("id=?", access.GrantID), access.AccessedAt).
+			Updates(map[string]interface{}{
+				"view_count":          gorm.Expr("view_count + 1"),
+				"failed_pin_attempts": 0,
+				"last_viewed_at":      access.AccessedAt,
+				"updated_at":          access.AccessedAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		consumed = true
+		return tx.Create(accessToPO(access)).Error
+	})
+	return consumed, err
+}
+
+func (r *grantRepository) AppendAccess(ctx context.Context, access *domainshare.Access) error {
+	return r.WithContext(ctx).Create(accessToPO(access)).Error
+}
+
+// active 与 Grant.StatusAt 判定为 active 的条件一致；已软删除的授权不再可用。
+func (r *grantRepository) active(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("revoked_at IS NULL AND expires_at > ? AND view_count < max_views AND failed_pin_attempts < ? AND deleted_at IS NULL",
+		now, domainshare.MaxPINAttempts)
+}
+
+func (r *grantRepository) take(query *gorm.DB) (*domainshare.Grant, error) {
+	var po GrantPO
+	err := query.Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return grantToDomain(&po), nil
+}
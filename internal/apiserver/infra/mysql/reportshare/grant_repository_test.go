@@ -0,0 +1,114 @@
+package reportshare
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainshare "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportshare"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newGrantRepositoryTestDB(t *testing.T) (domainshare.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGrantRepository(db), mock
+}
+
+const consumeViewSQL = "UPDATE `interpretation_report_share` SET `failed_pin_attempts`=?,`last_viewed_at`=?,`updated_at`=?,`view_count`=view_count + 1 " +
+	"WHERE id=? AND (revoked_at IS NULL AND expires_at > ? AND view_count < max_views AND failed_pin_attempts < ? AND deleted_at IS NULL)"
+
+func TestConsumeViewCountsAndRecordsAccessInOneTransaction(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	at := time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)
+	access := &domainshare.Access{GrantID: 77, TesteeID: 401, AssessmentID: 5001, ReportID: 9001, Outcome: domainshare.OutcomeViewed, ClientIP: "203.0.113.9", AccessedAt: at}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(consumeViewSQL)).
+		WithArgs(0, at, at, uint64(77), at, domainshare.MaxPINAttempts).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_report_share_access` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`share_id`,")).
+		WithArgs(at, at, nil, int64(0), int64(0), int64(0), uint32(1),
+			uint64(77), uint64(401), uint64(5001), uint64(9001), "viewed", "203.0.113.9", "", at, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	consumed, err := repo.ConsumeView(context.Background(), access)
+	if err != nil || !consumed {
+		t.Fatalf("ConsumeView() = %v, %v", consumed, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConsumeViewSkipsAccessRecordWhenGrantNoLongerActive(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	at := time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(consumeViewSQL)).
+		WithArgs(0, at, at, uint64(77), at, domainshare.MaxPINAttempts).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	consumed, err := repo.ConsumeView(context.Background(), &domainshare.Access{GrantID: 77, AccessedAt: at})
+	if err != nil || consumed {
+		t.Fatalf("ConsumeView() = %v, %v; want false, nil", consumed, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateKeepsGrantIDAndCreationTime(t *testing.T) {
+	repo, mock := newGrantRepositoryTestDB(t)
+	createdAt := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(72 * time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `interpretation_report_share` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`testee_id`,")).
+		WithArgs(createdAt, createdAt, nil, int64(0), int64(0), int64(0), uint32(1),
+			uint64(401), uint64(5001), "link", "school", "", "hash", "", "", 10, 0, 0, expiresAt, nil, nil, int64(77)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), &domainshare.Grant{
+		ID: 77, TesteeID: 401, AssessmentID: 5001, Kind: domainshare.KindLink, Audience: "school",
+		SecretHash: "hash", MaxViews: 10, ExpiresAt: expiresAt, CreatedAt: createdAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReportShareMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000100_add_interpretation_report_share_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `interpretation_report_share`",
+		"ALTER TABLE `interpretation_report_share_access`",
+		"ADD KEY `idx_interpretation_report_share_deleted_at` (`deleted_at`)",
+		"`updated_at` = COALESCE(`revoked_at`, `last_viewed_at`, `created_at`)",
+		"`created_at` = `accessed_at`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
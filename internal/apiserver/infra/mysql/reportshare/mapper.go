@@ -0,0 +1,63 @@
+package reportshare
+
+import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainshare "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reportshare"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func grantToPO(grant *domainshare.Grant) *GrantPO {
+	return &GrantPO{
+		AuditFields:       mysql.AuditFields{ID: meta.FromUint64(grant.ID), CreatedAt: grant.CreatedAt, UpdatedAt: grant.CreatedAt},
+		TesteeID:          grant.TesteeID,
+		AssessmentID:      grant.AssessmentID,
+		Kind:              string(grant.Kind),
+		Audience:          string(grant.Audience),
+		Label:             grant.Label,
+		SecretHash:        grant.SecretHash,
+		PINSalt:           grant.PINSalt,
+		PINHash:           grant.PINHash,
+		MaxViews:          grant.MaxViews,
+		ViewCount:         grant.ViewCount,
+		FailedPINAttempts: grant.FailedPINAttempts,
+		ExpiresAt:         grant.ExpiresAt,
+		LastViewedAt:      grant.LastViewedAt,
+		RevokedAt:         grant.RevokedAt,
+	}
+}
+
+func grantToDomain(po *GrantPO) *domainshare.Grant {
+	return &domainshare.Grant{
+		ID:                po.ID.Uint64(),
+		TesteeID:          po.TesteeID,
+		AssessmentID:      po.AssessmentID,
+		Kind:              domainshare.Kind(po.Kind),
+		Audience:          policy.ReportAudience(po.Audience),
+		Label:             po.Label,
+		SecretHash:        po.SecretHash,
+		PINSalt:           po.PINSalt,
+		PINHash:           po.PINHash,
+		MaxViews:          po.MaxViews,
+		ViewCount:         po.ViewCount,
+		FailedPINAttempts: po.FailedPINAttempts,
+		ExpiresAt:         po.ExpiresAt,
+		CreatedAt:         po.CreatedAt,
+		LastViewedAt:      po.LastViewedAt,
+		RevokedAt:         po.RevokedAt,
+	}
+}
+
+func accessToPO(access *domainshare.Access) *AccessPO {
+	return &AccessPO{
+		AuditFields:  mysql.AuditFields{CreatedAt: access.AccessedAt, UpdatedAt: access.AccessedAt},
+		ShareID:      access.GrantID,
+		TesteeID:     access.TesteeID,
+		AssessmentID: access.AssessmentID,
+		ReportID:     access.ReportID,
+		Outcome:      string(access.Outcome),
+		ClientIP:     access.ClientIP,
+		UserAgent:    access.UserAgent,
+		AccessedAt:   access.AccessedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package reportshare
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// GrantPO 分享授权持久化对象；secret_hash 唯一，明文令牌与访问码不落库。
+type GrantPO struct {
+	mysql.AuditFields
+
+	TesteeID          uint64     `gorm:"column:testee_id;not null"`
+	AssessmentID      uint64     `gorm:"column:assessment_id;not null"`
+	Kind              string     `gorm:"column:kind;size:16;not null"`
+	Audience          string     `gorm:"column:audience;size:32;not null"`
+	Label             string     `gorm:"column:label;size:64;not null;default:''"`
+	SecretHash        string     `gorm:"column:secret_hash;size:64;not null;uniqueIndex:uk_interpretation_report_share_secret"`
+	PINSalt           string     `gorm:"column:pin_salt;size:64;not null;default:''"`
+	PINHash           string     `gorm:"column:pin_hash;size:64;not null;default:''"`
+	MaxViews          int        `gorm:"column:max_views;not null"`
+	ViewCount         int        `gorm:"column:view_count;not null;default:0"`
+	FailedPINAttempts int        `gorm:"column:failed_pin_attempts;not null;default:0"`
+	ExpiresAt         time.Time  `gorm:"column:expires_at;not null"`
+	LastViewedAt      *time.Time `gorm:"column:last_viewed_at"`
+	RevokedAt         *time.Time `gorm:"column:revoked_at"`
+}
+
+// TableName 指定表名
+func (GrantPO) TableName() string { return "interpretation_report_share" }
+
+// BeforeCreate GORM hook：授权 ID 与创建时间由应用层给出。
+func (p *GrantPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// AccessPO 分享访问记录持久化对象；只追加。
+type AccessPO struct {
+	mysql.AuditFields
+
+	ShareID      uint64    `gorm:"column:share_id;not null"`
+	TesteeID     uint64    `gorm:"column:testee_id;not null"`
+	AssessmentID uint64    `gorm:"column:assessment_id;not null"`
+	ReportID     uint64    `gorm:"column:report_id;not null;default:0"`
+	Outcome      string    `gorm:"column:outcome;size:32;not null"`
+	ClientIP     string    `gorm:"column:client_ip;size:64;not null;default:''"`
+	UserAgent    string    `gorm:"column:user_agent;size:255;not null;default:''"`
+	AccessedAt   time.Time `gorm:"column:accessed_at;not null"`
+}
+
+// TableName 指定表名
+func (AccessPO) TableName() string { return "interpretation_report_share_access" }
+
+// BeforeCreate GORM hook：访问记录的创建时间即访问时间。
+func (p *AccessPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
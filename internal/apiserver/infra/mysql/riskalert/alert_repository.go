@@ -0,0 +1,254 @@
+// Package riskalert 风险预警规则、预警与评估进度的 MySQL 仓储。
+package riskalert
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainriskalert "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/riskalert"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pendingOutcomesSQL 回看窗口内完成、所在机构有在其完成前创建的启用规则，且尚未评估的测评结果。
+const pendingOutcomesSQL = `
+SELECT o.id, o.org_id, o.assessment_id, o.testee_id, o.model_code, o.evaluated_at
+FROM evaluation_outcome o
+WHERE o.evaluated_at >= ?
+	AND EXISTS (
+		SELECT 1 FROM risk_alert_rule r
+		WHERE r.org_id = o.org_id
+			AND r.enabled = 1
+			AND r.deleted_at IS NULL
+			AND r.created_at <= o.evaluated_at
+	)
+	AND NOT EXISTS (SELECT 1 FROM risk_alert_scan s WHERE s.outcome_id = o.id)
+ORDER BY o.evaluated_at ASC, o.id ASC
+LIMIT ?
+`
+
+// alertRepository 风险预警仓储。预警按 version 乐观锁更新，历史只追加。
+type alertRepository struct {
+	mysql.BaseRepository[*AlertPO]
+}
+
+// NewAlertRepository 创建风险预警仓储
+func NewAlertRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainriskalert.Repository {
+	return &alertRepository{BaseRepository: mysql.NewBaseRepository[*AlertPO](db, opts...)}
+}
+
+// transaction 已处于外层事务时直接复用，否则开启新事务。
+func (r *alertRepository) transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	if tx, ok := mysql.TxFromContext(ctx); ok {
+		return fn(tx.WithContext(ctx))
+	}
+	return r.WithContext(ctx).Transaction(fn)
+}
+
+func (r *alertRepository) SaveRule(ctx context.Context, rule *domainriskalert.Rule) error {
+	po, err := ruleToPO(rule)
+	if err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(po).Error
+}
+
+func (r *alertRepository) FindRule(ctx context.Context, orgID int64, ruleID uint64) (*domainriskalert.Rule, error) {
+	var po RulePO
+	err := r.WithContext(ctx).Where("id=? AND org_id=? AND deleted_at IS NULL", ruleID, orgID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ruleToDomain(&po)
+}
+
+func (r *alertRepository) ListRules(ctx context.Context, orgID int64) ([]domainriskalert.Rule, error) {
+	return r.listRules(r.WithContext(ctx).Where("org_id=? AND deleted_at IS NULL", orgID))
+}
+
+func (r *alertRepository) ListEnabledRules(ctx context.Context, orgID int64) ([]domainriskalert.Rule, error) {
+	return r.listRules(r.WithContext(ctx).Where("org_id=? AND enabled=? AND deleted_at IS NULL", orgID, true))
+}
+
+func (r *alertRepository) listRules(query *gorm.DB) ([]domainriskalert.Rule, error) {
+	var pos []RulePO
+	if err := query.Order("created_at ASC, id ASC").Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	rules := make([]domainriskalert.Rule, 0, len(pos))
+	for i := range pos {
+		rule, err := ruleToDomain(&pos[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+func (r *alertRepository) DeleteRule(ctx context.Context, orgID int64, ruleID uint64, at time.Time) (bool, error) {
+	result := r.WithContext(ctx).Model(&RulePO{}).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", ruleID, orgID).
+		Updates(map[string]interface{}{"deleted_at": at, "updated_at": at})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListPendingOutcomes 跨机构扫描待评估的测评结果，按完成时间顺序分批返回。
+func (r *alertRepository) ListPendingOutcomes(ctx context.Context, since time.Time, limit int) ([]domainriskalert.OutcomeRef, error) {
+	var rows []outcomeRow
+	if err := r.WithContext(ctx).Raw(pendingOutcomesSQL, since, limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	outcomes := make([]domainriskalert.OutcomeRef, 0, len(rows))
+	for _, row := range rows {
+		outcomes = append(outcomes, domainriskalert.OutcomeRef(row))
+	}
+	return outcomes, nil
+}
+
+func (r *alertRepository) FindPreviousOutcome(ctx context.Context, outcome domainriskalert.OutcomeRef) (*domainriskalert.OutcomeRef, error) {
+	var rows []outcomeRow
+	err := r.WithContext(ctx).Table("evaluation_outcome").
+		Select("id, org_id, assessment_id, testee_id, model_code, evaluated_at").
+		Where("org_id=? AND testee_id=? AND model_code=?", outcome.OrgID, outcome.TesteeID, outcome.ModelCode).
+		Where("evaluated_at < ? OR (evaluated_at = ? AND id < ?)", outcome.EvaluatedAt, outcome.EvaluatedAt, outcome.ID).
+		Order("evaluated_at DESC, id DESC").
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	previous := domainriskalert.OutcomeRef(rows[0])
+	return &previous, nil
+}
+
+func (r *alertRepository) RecordScan(ctx context.Context, outcome domainriskalert.OutcomeRef, alerts []domainriskalert.Alert, events []domainriskalert.AlertEvent, at time.Time) (bool, error) {
+	alertRows := make([]*AlertPO, 0, len(alerts))
+	for i := range alerts {
+		po, err := alertToPO(&alerts[i])
+		if err != nil {
+			return false, err
+		}
+		alertRows = append(alertRows, po)
+	}
+	eventRows := make([]*EventPO, 0, len(events))
+	for i := range events {
+		eventRows = append(eventRows, eventToPO(&events[i]))
+	}
+	recorded := false
+	err := r.transaction(ctx, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ScanPO{
+			OutcomeID: outcome.ID, OrgID: outcome.OrgID, AssessmentID: outcome.AssessmentID, Alerts: len(alerts), ScannedAt: at,
+		})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		recorded = true
+		if len(alertRows) == 0 {
+			return nil
+		}
+		if err := tx.Create(&alertRows).Error; err != nil {
+			return err
+		}
+		return tx.Create(&eventRows).Error
+	})
+	return recorded, err
+}
+
+func (r *alertRepository) FindAlert(ctx context.Context, orgID int64, alertID uint64) (*domainriskalert.Alert, error) {
+	var po AlertPO
+	err := r.WithContext(ctx).Where("id=? AND org_id=? AND deleted_at IS NULL", alertID, orgID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return alertToDomain(&po)
+}
+
+func (r *alertRepository) ListAlerts(ctx context.Context, filter domainriskalert.AlertFilter) ([]domainriskalert.Alert, int64, error) {
+	query := r.WithContext(ctx).Model(&AlertPO{}).Where("org_id=? AND deleted_at IS NULL", filter.OrgID)
+	if filter.Status != "" {
+		query = query.Where("status=?", string(filter.Status))
+	}
+	if filter.TesteeID != 0 {
+		query = query.Where("testee_id=?", filter.TesteeID)
+	}
+	if filter.RuleID != 0 {
+		query = query.Where("rule_id=?", filter.RuleID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []AlertPO
+	if err := query.Order("triggered_at DESC, id DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	alerts, err := alertsToDomain(pos)
+	if err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+func (r *alertRepository) ListAlertEvents(ctx context.Context, orgID int64, alertID uint64) ([]domainriskalert.AlertEvent, error) {
+	var pos []EventPO
+	if err := r.WithContext(ctx).
+		Where("org_id=? AND alert_id=? AND deleted_at IS NULL", orgID, alertID).
+		Order("occurred_at ASC, id ASC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	events := make([]domainriskalert.AlertEvent, 0, len(pos))
+	for i := range pos {
+		events = append(events, eventToDomain(&pos[i]))
+	}
+	return events, nil
+}
+
+// ListDueEscalations 跨机构扫描到达升级时间的未确认预警。
+func (r *alertRepository) ListDueEscalations(ctx context.Context, now time.Time, limit int) ([]domainriskalert.Alert, error) {
+	var pos []AlertPO
+	if err := r.WithContext(ctx).
+		Where("status=? AND next_escalation_at IS NOT NULL AND next_escalation_at <= ? AND deleted_at IS NULL", string(domainriskalert.AlertStatusOpen), now).
+		Order("next_escalation_at ASC, id ASC").
+		Limit(limit).
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	return alertsToDomain(pos)
+}
+
+func (r *alertRepository) SaveAlert(ctx context.Context, alert *domainriskalert.Alert, expectedVersion int, event *domainriskalert.AlertEvent) (bool, error) {
+	po, err := alertToPO(alert)
+	if err != nil {
+		return false, err
+	}
+	if event.Operator != nil {
+		po.UpdatedBy = meta.ID(event.Operator.UserID)
+	}
+	eventRow := eventToPO(event)
+	saved := false
+	err = r.transaction(ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&AlertPO{}).
+			Where("id=? AND version=?", po.ID, expectedVersion).
+			Updates(alertUpdates(po))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		saved = true
+		return tx.Create(eventRow).Error
+	})
+	return saved, err
+}
@@ -0,0 +1,134 @@
+package riskalert
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainriskalert "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/riskalert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newAlertRepositoryTestDB(t *testing.T) (domainriskalert.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewAlertRepository(db), mock
+}
+
+func TestRecordScanSkipsAlertsForScannedOutcome(t *testing.T) {
+	repo, mock := newAlertRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `risk_alert_scan`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	recorded, err := repo.RecordScan(context.Background(),
+		domainriskalert.OutcomeRef{ID: 11, OrgID: 7, AssessmentID: 111},
+		[]domainriskalert.Alert{{ID: 31, OrgID: 7, RuleID: 1, OutcomeID: 11, Status: domainriskalert.AlertStatusOpen, Version: 1}},
+		[]domainriskalert.AlertEvent{{ID: 32, OrgID: 7, AlertID: 31, Action: domainriskalert.AlertActionRaised, OccurredAt: at}},
+		at)
+	if err != nil || recorded {
+		t.Fatalf("RecordScan() = %v, %v; want already scanned", recorded, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveAlertRejectsStaleVersion(t *testing.T) {
+	repo, mock := newAlertRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `risk_alert` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	saved, err := repo.SaveAlert(context.Background(),
+		&domainriskalert.Alert{ID: 31, OrgID: 7, Status: domainriskalert.AlertStatusAcknowledged, Version: 3, UpdatedAt: at},
+		2,
+		&domainriskalert.AlertEvent{ID: 33, OrgID: 7, AlertID: 31, Action: domainriskalert.AlertActionAcknowledged, OccurredAt: at})
+	if err != nil || saved {
+		t.Fatalf("SaveAlert() = %v, %v; want version conflict", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListPendingOutcomesScansOnlyUnscannedOutcomesWithRules(t *testing.T) {
+	repo, mock := newAlertRepositoryTestDB(t)
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("NOT EXISTS (SELECT 1 FROM risk_alert_scan s WHERE s.outcome_id = o.id)")).
+		WithArgs(since, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "assessment_id", "testee_id", "model_code", "evaluated_at"}).
+			AddRow(11, 7, 111, 9, "SCL90", since.Add(time.Hour)))
+
+	outcomes, err := repo.ListPendingOutcomes(context.Background(), since, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 || outcomes[0].ID != 11 || outcomes[0].ModelCode != "SCL90" || outcomes[0].TesteeID != 9 {
+		t.Fatalf("outcomes = %#v", outcomes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveAlertRecordsAcknowledgingOperator(t *testing.T) {
+	repo, mock := newAlertRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `risk_alert` SET")).
+		WithArgs("已联系家属", &at, "李医生", int64(900), "oncall-1", 0, nil, "acknowledged", at, int64(900), uint32(3), int64(31), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `risk_alert_event` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	operator := &domainriskalert.Actor{UserID: 900, Name: "李医生"}
+	saved, err := repo.SaveAlert(context.Background(),
+		&domainriskalert.Alert{ID: 31, OrgID: 7, Status: domainriskalert.AlertStatusAcknowledged, Channel: "oncall-1",
+			AcknowledgedBy: operator, AcknowledgedAt: &at, AckNote: "已联系家属", Version: 3, UpdatedAt: at},
+		2,
+		&domainriskalert.AlertEvent{ID: 33, OrgID: 7, AlertID: 31, Action: domainriskalert.AlertActionAcknowledged, Operator: operator, OccurredAt: at})
+	if err != nil || !saved {
+		t.Fatalf("SaveAlert() = %v, %v", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRiskAlertMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000096_add_risk_alert_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `risk_alert_rule`",
+		"ALTER TABLE `risk_alert`",
+		"ALTER TABLE `risk_alert_event`",
+		"MODIFY COLUMN `version` INT UNSIGNED",
+		"ADD COLUMN `deleted_at`",
+		"`created_by` = `operator_user_id`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package riskalert
+
+import (
+	"encoding/json"
+	"time"
+
+	domainriskalert "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/riskalert"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func ruleToPO(rule *domainriskalert.Rule) (*RulePO, error) {
+	condition, err := json.Marshal(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := marshalChannels(rule.EscalationChannels)
+	if err != nil {
+		return nil, err
+	}
+	return &RulePO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(rule.ID), CreatedAt: rule.CreatedAt, UpdatedAt: rule.UpdatedAt,
+			CreatedBy: meta.ID(rule.CreatedBy), UpdatedBy: meta.ID(rule.UpdatedBy),
+		},
+		OrgID: rule.OrgID, Name: rule.Name, Kind: string(rule.Kind), ModelCode: rule.ModelCode,
+		ConditionJSON: string(condition), Severity: string(rule.Severity), PageChannel: rule.PageChannel,
+		EscalationChannelsJSON: chain, AckTimeoutSeconds: int(rule.AckTimeout / time.Second), Enabled: rule.Enabled,
+	}, nil
+}
+
+func ruleToDomain(po *RulePO) (*domainriskalert.Rule, error) {
+	rule := &domainriskalert.Rule{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, Name: po.Name, Kind: domainriskalert.RuleKind(po.Kind), ModelCode: po.ModelCode,
+		Severity: domainriskalert.Severity(po.Severity), PageChannel: po.PageChannel,
+		AckTimeout: time.Duration(po.AckTimeoutSeconds) * time.Second, Enabled: po.Enabled,
+		CreatedBy: int64(po.CreatedBy), UpdatedBy: int64(po.UpdatedBy), CreatedAt: po.CreatedAt, UpdatedAt: po.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(po.ConditionJSON), &rule.Condition); err != nil {
+		return nil, err
+	}
+	chain, err := unmarshalChannels(po.EscalationChannelsJSON)
+	if err != nil {
+		return nil, err
+	}
+	rule.EscalationChannels = chain
+	return rule, nil
+}
+
+func alertToPO(alert *domainriskalert.Alert) (*AlertPO, error) {
+	chain, err := marshalChannels(alert.EscalationChannels)
+	if err != nil {
+		return nil, err
+	}
+	po := &AlertPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(alert.ID), CreatedAt: alert.CreatedAt, UpdatedAt: alert.UpdatedAt, Version: uint32(alert.Version),
+		},
+		OrgID: alert.OrgID, RuleID: alert.RuleID, RuleName: alert.RuleName, RuleKind: string(alert.RuleKind),
+		Severity: string(alert.Severity), OutcomeID: alert.OutcomeID, AssessmentID: alert.AssessmentID, TesteeID: alert.TesteeID,
+		ModelCode: alert.ModelCode, Status: string(alert.Status), Channel: alert.Channel, EscalationLevel: alert.EscalationLevel,
+		EscalationChannelsJSON: chain, AckTimeoutSeconds: int(alert.AckTimeout / time.Second), Summary: alert.Summary,
+		ObservedValue: alert.ObservedValue, BaselineValue: alert.BaselineValue, TriggeredAt: alert.TriggeredAt,
+		NextEscalationAt: alert.NextEscalationAt, AcknowledgedAt: alert.AcknowledgedAt, AckNote: alert.AckNote,
+	}
+	if alert.AcknowledgedBy != nil {
+		po.AcknowledgedByUserID = alert.AcknowledgedBy.UserID
+		po.AcknowledgedByName = alert.AcknowledgedBy.Name
+	}
+	return po, nil
+}
+
+func alertToDomain(po *AlertPO) (*domainriskalert.Alert, error) {
+	chain, err := unmarshalChannels(po.EscalationChannelsJSON)
+	if err != nil {
+		return nil, err
+	}
+	alert := &domainriskalert.Alert{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, RuleID: po.RuleID, RuleName: po.RuleName, RuleKind: domainriskalert.RuleKind(po.RuleKind),
+		Severity: domainriskalert.Severity(po.Severity), OutcomeID: po.OutcomeID, AssessmentID: po.AssessmentID, TesteeID: po.TesteeID,
+		ModelCode: po.ModelCode, Status: domainriskalert.AlertStatus(po.Status), Channel: po.Channel, EscalationLevel: po.EscalationLevel,
+		EscalationChannels: chain, AckTimeout: time.Duration(po.AckTimeoutSeconds) * time.Second, Summary: po.Summary,
+		ObservedValue: po.ObservedValue, BaselineValue: po.BaselineValue, TriggeredAt: po.TriggeredAt,
+		NextEscalationAt: po.NextEscalationAt, AcknowledgedAt: po.AcknowledgedAt, AckNote: po.AckNote,
+		Version: int(po.Version), CreatedAt: po.CreatedAt, UpdatedAt: po.UpdatedAt,
+	}
+	if po.AcknowledgedByUserID != 0 {
+		alert.AcknowledgedBy = &domainriskalert.Actor{UserID: po.AcknowledgedByUserID, Name: po.AcknowledgedByName}
+	}
+	return alert, nil
+}
+
+func alertsToDomain(pos []AlertPO) ([]domainriskalert.Alert, error) {
+	alerts := make([]domainriskalert.Alert, 0, len(pos))
+	for i := range pos {
+		alert, err := alertToDomain(&pos[i])
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, *alert)
+	}
+	return alerts, nil
+}
+
+// alertUpdates 乐观锁更新时写入的列。
+func alertUpdates(po *AlertPO) map[string]interface{} {
+	return map[string]interface{}{
+		"status":                  po.Status,
+		"channel":                 po.Channel,
+		"escalation_level":        po.EscalationLevel,
+		"next_escalation_at":      po.NextEscalationAt,
+		"acknowledged_by_user_id": po.AcknowledgedByUserID,
+		"acknowledged_by_name":    po.AcknowledgedByName,
+		"acknowledged_at":         po.AcknowledgedAt,
+		"ack_note":                po.AckNote,
+		"version":                 po.Version,
+		"updated_by":              po.UpdatedBy,
+		"updated_at":              po.UpdatedAt,
+	}
+}
+
+func eventToPO(event *domainriskalert.AlertEvent) *EventPO {
+	po := &EventPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(event.ID), CreatedAt: event.OccurredAt, UpdatedAt: event.OccurredAt,
+		},
+		OrgID: event.OrgID, AlertID: event.AlertID, Action: string(event.Action),
+		Channel: event.Channel, EscalationLevel: event.EscalationLevel, Note: event.Note, OccurredAt: event.OccurredAt,
+	}
+	if event.Operator != nil {
+		po.OperatorUserID = event.Operator.UserID
+		po.OperatorName = event.Operator.Name
+		po.CreatedBy = meta.ID(event.Operator.UserID)
+		po.UpdatedBy = meta.ID(event.Operator.UserID)
+	}
+	return po
+}
+
+func eventToDomain(po *EventPO) domainriskalert.AlertEvent {
+	event := domainriskalert.AlertEvent{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, AlertID: po.AlertID, Action: domainriskalert.AlertAction(po.Action),
+		Channel: po.Channel, EscalationLevel: po.EscalationLevel, Note: po.Note, OccurredAt: po.OccurredAt,
+	}
+	if po.OperatorUserID != 0 {
+		event.Operator = &domainriskalert.Actor{UserID: po.OperatorUserID, Name: po.OperatorName}
+	}
+	return event
+}
+
+func marshalChannels(channels []string) (string, error) {
+	if channels == nil {
+		channels = []string{}
+	}
+	raw, err := json.Marshal(channels)
+	return string(raw), err
+}
+
+func unmarshalChannels(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var channels []string
+	if err := json.Unmarshal([]byte(raw), &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
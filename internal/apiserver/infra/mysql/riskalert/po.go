@@ -0,0 +1,133 @@
+package riskalert
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// RulePO 预警规则持久化对象；created_at 同时是规则开始评估测评的时间。
+type RulePO struct {
+	mysql.AuditFields
+
+	OrgID                  int64  `gorm:"column:org_id;not null"`
+	Name                   string `gorm:"column:name;size:100;not null"`
+	Kind                   string `gorm:"column:kind;size:32;not null"`
+	ModelCode              string `gorm:"column:model_code;size:100;not null;default:''"`
+	ConditionJSON          string `gorm:"column:condition_json;type:json;not null"`
+	Severity               string `gorm:"column:severity;size:16;not null"`
+	PageChannel            string `gorm:"column:page_channel;size:100;not null"`
+	EscalationChannelsJSON string `gorm:"column:escalation_channels_json;type:json;not null"`
+	AckTimeoutSeconds      int    `gorm:"column:ack_timeout_seconds;not null"`
+	Enabled                bool   `gorm:"column:enabled;not null;default:1"`
+}
+
+// TableName 指定表名
+func (RulePO) TableName() string { return "risk_alert_rule" }
+
+// BeforeCreate GORM hook：规则的创建与更新时间、操作人由应用层给出。
+func (p *RulePO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// AlertPO 预警持久化对象；通用审计列中的 version 即预警乐观锁版本。
+type AlertPO struct {
+	mysql.AuditFields
+
+	OrgID                  int64      `gorm:"column:org_id;not null"`
+	RuleID                 uint64     `gorm:"column:rule_id;not null"`
+	RuleName               string     `gorm:"column:rule_name;size:100;not null"`
+	RuleKind               string     `gorm:"column:rule_kind;size:32;not null"`
+	Severity               string     `gorm:"column:severity;size:16;not null"`
+	OutcomeID              uint64     `gorm:"column:outcome_id;not null"`
+	AssessmentID           uint64     `gorm:"column:assessment_id;not null"`
+	TesteeID               uint64     `gorm:"column:testee_id;not null"`
+	ModelCode              string     `gorm:"column:model_code;size:100;not null;default:''"`
+	Status                 string     `gorm:"column:status;size:16;not null"`
+	Channel                string     `gorm:"column:channel;size:100;not null"`
+	EscalationLevel        int        `gorm:"column:escalation_level;not null;default:0"`
+	EscalationChannelsJSON string     `gorm:"column:escalation_channels_json;type:json;not null"`
+	AckTimeoutSeconds      int        `gorm:"column:ack_timeout_seconds;not null"`
+	Summary                string     `gorm:"column:summary;size:255;not null"`
+	ObservedValue          float64    `gorm:"column:observed_value;not null"`
+	BaselineValue          *float64   `gorm:"column:baseline_value"`
+	TriggeredAt            time.Time  `gorm:"column:triggered_at;not null"`
+	NextEscalationAt       *time.Time `gorm:"column:next_escalation_at"`
+	AcknowledgedByUserID   int64      `gorm:"column:acknowledged_by_user_id;not null;default:0"`
+	AcknowledgedByName     string     `gorm:"column:acknowledged_by_name;size:100;not null;default:''"`
+	AcknowledgedAt         *time.Time `gorm:"column:acknowledged_at"`
+	AckNote                string     `gorm:"column:ack_note;size:1000;not null;default:''"`
+}
+
+// TableName 指定表名
+func (AlertPO) TableName() string { return "risk_alert" }
+
+// BeforeCreate GORM hook：预警的创建与更新时间、版本由应用层给出。
+func (p *AlertPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// EventPO 预警历史持久化对象；历史只追加。
+type EventPO struct {
+	mysql.AuditFields
+
+	OrgID           int64     `gorm:"column:org_id;not null"`
+	AlertID         uint64    `gorm:"column:alert_id;not null"`
+	Action          string    `gorm:"column:action;size:16;not null"`
+	Channel         string    `gorm:"column:channel;size:100;not null;default:''"`
+	EscalationLevel int       `gorm:"column:escalation_level;not null;default:0"`
+	OperatorUserID  int64     `gorm:"column:operator_user_id;not null;default:0"`
+	OperatorName    string    `gorm:"column:operator_name;size:100;not null;default:''"`
+	Note            string    `gorm:"column:note;size:1000;not null;default:''"`
+	OccurredAt      time.Time `gorm:"column:occurred_at;not null"`
+}
+
+// TableName 指定表名
+func (EventPO) TableName() string { return "risk_alert_event" }
+
+// BeforeCreate GORM hook：历史的创建人与创建时间即操作人与发生时间。
+func (p *EventPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// ScanPO 评估进度持久化对象。以测评结果 ID 为主键、只写一次，不带通用审计列。
+type ScanPO struct {
+	OutcomeID    uint64    `gorm:"column:outcome_id;primaryKey"`
+	OrgID        int64     `gorm:"column:org_id;not null"`
+	AssessmentID uint64    `gorm:"column:assessment_id;not null"`
+	Alerts       int       `gorm:"column:alerts;not null;default:0"`
+	ScannedAt    time.Time `gorm:"column:scanned_at;not null"`
+}
+
+// TableName 指定表名
+func (ScanPO) TableName() string { return "risk_alert_scan" }
+
+// outcomeRow 待评估测评结果的投影。
+type outcomeRow struct {
+	ID           uint64
+	OrgID        int64
+	AssessmentID uint64
+	TesteeID     uint64
+	ModelCode    string
+	EvaluatedAt  time.Time
+}
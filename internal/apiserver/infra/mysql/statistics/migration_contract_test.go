@@ -141,3 +141,31 @@ func TestStatisticsCollectorIndexMigrationMatchesStableSourceScans(t *testing.T)
 		}
 	}
 }
+
+func TestStatisticsPlanAdherenceMigrationDefinesTaskProjection(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000069_add_statistics_plan_adherence.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(up)
+	for _, token := range []string{
+		"CREATE TABLE `statistics_plan_adherence_task`", "uk_statistics_plan_adherence_task", "(`org_id`,`task_id`)",
+		"`enrollment_week`", "`outcome`", "`open_to_complete_seconds`",
+		"idx_statistics_plan_adherence_cohort", "idx_statistics_plan_adherence_clinician", "idx_statistics_plan_adherence_entry",
+	} {
+		if !strings.Contains(text, token) {
+			t.Fatalf("adherence migration does not contain %q", token)
+		}
+	}
+	if strings.Contains(text, "ALTER TABLE `statistics_") {
+		t.Fatal("adherence migration must not alter existing statistics tables")
+	}
+
+	down, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000069_add_statistics_plan_adherence.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(down), "DROP TABLE IF EXISTS `statistics_plan_adherence_task`") {
+		t.Fatal("adherence down migration does not drop the projection table")
+	}
+}
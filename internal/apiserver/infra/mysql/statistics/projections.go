@@ -14,6 +14,7 @@ type AccessDailyProjection struct{ db *gorm.DB }
 type AssessmentDailyProjection struct{ db *gorm.DB }
 type PlanActivityProjection struct{ db *gorm.DB }
 type PlanFulfillmentProjection struct{ db *gorm.DB }
+type PlanAdherenceProjection struct{ db *gorm.DB }
 type OrganizationSnapshotProjection struct{ db *gorm.DB }
 
 type fulfillmentContractTask struct {
@@ -65,7 +66,7 @@ func NewDailyProjections(db *gorm.DB) []statisticsDomain.Projection {
 }
 
 func NewGlobalProjections(db *gorm.DB) []statisticsDomain.Projection {
-	return []statisticsDomain.Projection{&PlanFulfillmentProjection{db}, &OrganizationSnapshotProjection{db}}
+	return []statisticsDomain.Projection{&PlanFulfillmentProjection{db}, &PlanAdherenceProjection{db}, &OrganizationSnapshotProjection{db}}
 }
 
 func projectionDB(ctx context.Context, db *gorm.DB) *gorm.DB {
@@ -171,6 +172,80 @@ func (p *PlanFulfillmentProjection) Project(ctx context.Context, r statisticsDom
 	return statisticsDomain.ProjectionResult{Name: p.Name(), Rows: result.RowsAffected}, result.Error
 }
 
+func (*PlanAdherenceProjection) Name() string { return "plan_adherence" }
+
+// Project rebuilds one row per task for the organization. Schedule facts win
+// over legacy lifecycle values, the outcome CASE mirrors
+// statisticsDomain.ClassifyAdherenceOutcome, and attribution uses the testee's
+// current primary clinician (falling back to the intake clinician) plus the
+// entry of the first confirmed intake.
+func (p *PlanAdherenceProjection) Project(ctx context.Context, r statisticsDomain.ProjectionRequest) (statisticsDomain.ProjectionResult, error) {
+	db := projectionDB(ctx, p.db)
+	if err := db.Exec("DELETE FROM statistics_plan_adherence_task WHERE org_id=?", r.OrgID).Error; err != nil {
+		return statisticsDomain.ProjectionResult{Name: p.Name()}, err
+	}
+	result := db.Exec(`
+		INSERT INTO statistics_plan_adherence_task (org_id,plan_id,enrollment_id,testee_id,task_id,task_seq,scale_code,clinician_id,entry_id,enrolled_at,enrollment_week,planned_at,due_at,opened_at,completed_at,expired_at,outcome,open_to_complete_seconds)
+		WITH schedule_ranked AS (
+		 SELECT task_id,schedule_planned_at,schedule_due_at,
+		        ROW_NUMBER() OVER (PARTITION BY org_id,task_id ORDER BY schedule_revision DESC,id DESC) schedule_rank
+		 FROM statistics_plan_fact
+		 WHERE org_id=? AND fact_type='task_schedule_defined'
+		), latest_schedule AS (
+		 SELECT task_id,schedule_planned_at,schedule_due_at FROM schedule_ranked WHERE schedule_rank=1
+		), lifecycle AS (
+		 SELECT org_id,task_id,MAX(plan_id) plan_id,MAX(enrollment_id) enrollment_id,MAX(testee_id) testee_id,MAX(task_seq) task_seq,MAX(scale_code) scale_code,
+		        MAX(CASE WHEN fact_type='task_created' THEN planned_at END) planned_at,
+		        COALESCE(MAX(CASE WHEN fact_type='task_due_defined' THEN due_at END),MAX(CASE WHEN fact_type<>'task_due_defined' THEN due_at END)) due_at,
+		        MIN(CASE WHEN fact_type='task_opened' THEN occurred_at END) opened_at,
+		        MAX(CASE WHEN fact_type='task_completed' THEN COALESCE(completed_at,occurred_at) END) completed_at,
+		        MAX(CASE WHEN fact_type='task_expired' THEN occurred_at END) expired_at,
+		        MAX(CASE WHEN fact_type='task_canceled' THEN 1 ELSE 0 END) canceled
+		 FROM statistics_plan_fact
+		 WHERE org_id=? AND task_id IS NOT NULL
+		   AND fact_type IN ('task_created','task_opened','task_completed','task_expired','task_canceled','task_due_defined')
+		 GROUP BY org_id,task_id
+		), tasks AS (
+		 SELECT l.org_id,l.plan_id,COALESCE(l.enrollment_id,0) enrollment_id,l.testee_id,l.task_id,COALESCE(l.task_seq,0) task_seq,COALESCE(l.scale_code,'') scale_code,
+		        COALESCE(s.schedule_planned_at,l.planned_at) planned_at,COALESCE(s.schedule_due_at,l.due_at) due_at,
+		        l.opened_at,l.completed_at,l.expired_at,l.canceled
+		 FROM lifecycle l LEFT JOIN latest_schedule s ON s.task_id=l.task_id
+		 WHERE l.testee_id IS NOT NULL AND COALESCE(s.schedule_planned_at,l.planned_at) IS NOT NULL
+		), enrollment_start AS (
+		 SELECT enrollment_id,MIN(occurred_at) joined_at FROM statistics_plan_fact
+		 WHERE org_id=? AND fact_type='enrollment_joined' AND enrollment_id IS NOT NULL GROUP BY enrollment_id
+		), first_task AS (
+		 SELECT plan_id,testee_id,enrollment_id,MIN(planned_at) first_planned_at FROM tasks GROUP BY plan_id,testee_id,enrollment_id
+		), intake_ranked AS (
+		 SELECT testee_id,clinician_id,entry_id,ROW_NUMBER() OVER (PARTITION BY testee_id ORDER BY occurred_at,id) intake_rank
+		 FROM statistics_access_fact WHERE org_id=? AND fact_type='intake_confirmed' AND testee_id IS NOT NULL
+		), first_intake AS (
+		 SELECT testee_id,clinician_id,entry_id FROM intake_ranked WHERE intake_rank=1
+		), primary_clinician AS (
+		 SELECT testee_id,MIN(clinician_id) clinician_id FROM clinician_relation
+		 WHERE org_id=? AND relation_type='primary' AND is_active=1 AND deleted_at IS NULL GROUP BY testee_id
+		), attributed AS (
+		 SELECT t.*,COALESCE(pc.clinician_id,fi.clinician_id,0) clinician_id,COALESCE(fi.entry_id,0) entry_id,
+		        COALESCE(es.joined_at,ft.first_planned_at) enrolled_at
+		 FROM tasks t
+		 JOIN first_task ft ON ft.plan_id=t.plan_id AND ft.testee_id=t.testee_id AND ft.enrollment_id=t.enrollment_id
+		 LEFT JOIN enrollment_start es ON es.enrollment_id=t.enrollment_id
+		 LEFT JOIN primary_clinician pc ON pc.testee_id=t.testee_id
+		 LEFT JOIN first_intake fi ON fi.testee_id=t.testee_id
+		)
+		SELECT org_id,plan_id,enrollment_id,testee_id,task_id,task_seq,scale_code,clinician_id,entry_id,enrolled_at,
+		 GREATEST(FLOOR(DATEDIFF(planned_at,enrolled_at)/7),0) enrollment_week,
+		 planned_at,due_at,opened_at,completed_at,expired_at,
+		 CASE WHEN canceled=1 THEN 'canceled'
+		      WHEN completed_at IS NOT NULL AND due_at IS NOT NULL AND completed_at>due_at THEN 'late'
+		      WHEN completed_at IS NOT NULL THEN 'on_time'
+		      WHEN expired_at IS NOT NULL OR due_at<? THEN 'expired'
+		      ELSE 'pending' END outcome,
+		 CASE WHEN completed_at IS NOT NULL AND opened_at IS NOT NULL AND completed_at>=opened_at THEN TIMESTAMPDIFF(SECOND,opened_at,completed_at) END open_to_complete_seconds
+		FROM attributed`, r.OrgID, r.OrgID, r.OrgID, r.OrgID, r.OrgID, r.CutoffAt)
+	return statisticsDomain.ProjectionResult{Name: p.Name(), Rows: result.RowsAffected}, result.Error
+}
+
 func (*OrganizationSnapshotProjection) Name() string { return "organization_snapshot" }
 func (p *OrganizationSnapshotProjection) Project(ctx context.Context, r statisticsDomain.ProjectionRequest) (statisticsDomain.ProjectionResult, error) {
 	result := projectionDB(ctx, p.db).Exec(`
@@ -17,7 +17,7 @@ func TestProjectionRegistriesSeparateWindowRepairFromGlobalPublication(t *testin
 	if got := projectionNames(daily); !reflect.DeepEqual(got, []string{"access_daily", "assessment_daily", "plan_activity_daily"}) {
 		t.Fatalf("daily=%v", got)
 	}
-	if got := projectionNames(global); !reflect.DeepEqual(got, []string{"plan_fulfillment", "organization_snapshot"}) {
+	if got := projectionNames(global); !reflect.DeepEqual(got, []string{"plan_fulfillment", "plan_adherence", "organization_snapshot"}) {
 		t.Fatalf("global=%v", got)
 	}
 }
@@ -57,6 +57,26 @@ func TestFulfillmentProjectionSelectsLatestScheduleRevisionAndKeepsLegacyFallbac
 	}
 }
 
+func TestPlanAdherenceProjectionRebuildsOrganizationTasks(t *testing.T) {
+	writer, mock := newFactWriterTestDB(t)
+	cutoff := time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM statistics_plan_adherence_task WHERE org_id=?")).
+		WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("(?s)INSERT INTO statistics_plan_adherence_task.*ORDER BY schedule_revision DESC,id DESC.*task_due_defined.*fact_type='enrollment_joined'.*fact_type='intake_confirmed'.*relation_type='primary'.*"+regexp.QuoteMeta("WHEN expired_at IS NOT NULL OR due_at<? THEN 'expired'")).
+		WithArgs(int64(1), int64(1), int64(1), int64(1), int64(1), cutoff).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	result, err := (&PlanAdherenceProjection{db: writer.db}).Project(context.Background(), statisticsDomain.ProjectionRequest{OrgID: 1, CutoffAt: cutoff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "plan_adherence" || result.Rows != 4 {
+		t.Fatalf("result=%+v", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func projectionNames(items []statisticsDomain.Projection) []string {
 	names := make([]string, 0, len(items))
 	for _, item := range items {
@@ -0,0 +1,115 @@
+package statistics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	statisticsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/statistics"
+	domainstats "github.com/FangcunMount/qs-server/internal/apiserver/domain/statistics"
+)
+
+const adherenceCountColumns = `SUM(a.outcome<>'canceled') task_count,SUM(a.outcome='on_time') on_time_count,SUM(a.outcome='late') late_count,
+	SUM(a.outcome='expired') expired_count,SUM(a.outcome='pending') pending_count,m.median_open_to_complete_seconds`
+
+// adherenceMedianJoin computes an exact median per group with window functions:
+// the middle row for odd counts and the mean of the two middle rows otherwise.
+func adherenceMedianJoin(groupColumn, where string) string {
+	return `LEFT JOIN (SELECT group_key,AVG(open_to_complete_seconds) median_open_to_complete_seconds FROM (
+		 SELECT ` + groupColumn + ` group_key,open_to_complete_seconds,
+		        ROW_NUMBER() OVER (PARTITION BY ` + groupColumn + ` ORDER BY open_to_complete_seconds) median_rank,
+		        COUNT(*) OVER (PARTITION BY ` + groupColumn + `) median_count
+		 FROM statistics_plan_adherence_task a WHERE ` + where + ` AND a.open_to_complete_seconds IS NOT NULL AND a.outcome<>'canceled'
+		) ranked WHERE median_rank IN (FLOOR((median_count+1)/2),FLOOR((median_count+2)/2)) GROUP BY group_key) m ON m.group_key=` + groupColumn
+}
+
+func adherenceCohortWhere(orgID int64, planID *uint64, from, to time.Time) (string, []any) {
+	where := []string{"a.org_id=?", "a.enrolled_at>=?", "a.enrolled_at<?"}
+	args := []any{orgID, from, to}
+	if planID != nil {
+		where = append(where, "a.plan_id=?")
+		args = append(args, *planID)
+	}
+	return strings.Join(where, " AND "), args
+}
+
+func (s *ReadStore) ListPlanAdherence(ctx context.Context, orgID int64, planID *uint64, from, to time.Time, page, size int) ([]statisticsApp.PlanAdherenceItem, int64, error) {
+	ctx, release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	whereSQL, args := adherenceCohortWhere(orgID, planID, from, to)
+	var total int64
+	if err := s.db.WithContext(ctx).Raw("SELECT COUNT(DISTINCT a.plan_id) FROM statistics_plan_adherence_task a WHERE "+whereSQL, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	queryArgs := append(append([]any{}, args...), args...)
+	queryArgs = append(queryArgs, size, (page-1)*size)
+	var items []statisticsApp.PlanAdherenceItem
+	err = s.db.WithContext(ctx).Raw(`SELECT a.plan_id,COALESCE(p.scale_code,'') scale_code,COALESCE(p.status,'') plan_status,
+		COUNT(DISTINCT a.testee_id,a.enrollment_id) enrollment_count,`+adherenceCountColumns+`
+		FROM statistics_plan_adherence_task a LEFT JOIN assessment_plan p ON p.id=a.plan_id
+		`+adherenceMedianJoin("a.plan_id", whereSQL)+`
+		WHERE `+whereSQL+` GROUP BY a.plan_id,p.scale_code,p.status,m.median_open_to_complete_seconds ORDER BY a.plan_id LIMIT ? OFFSET ?`, queryArgs...).Scan(&items).Error
+	return items, total, err
+}
+
+func (s *ReadStore) PlanOccurrenceAdherence(ctx context.Context, orgID int64, planID uint64, from, to time.Time) ([]statisticsApp.OccurrenceAdherenceItem, error) {
+	ctx, release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	whereSQL, args := adherenceCohortWhere(orgID, &planID, from, to)
+	var items []statisticsApp.OccurrenceAdherenceItem
+	err = s.db.WithContext(ctx).Raw(`SELECT a.task_seq seq,`+adherenceCountColumns+`
+		FROM statistics_plan_adherence_task a
+		`+adherenceMedianJoin("a.task_seq", whereSQL)+`
+		WHERE `+whereSQL+` GROUP BY a.task_seq,m.median_open_to_complete_seconds ORDER BY a.task_seq`, append(append([]any{}, args...), args...)...).Scan(&items).Error
+	return items, err
+}
+
+// PlanDropoutCohorts observes each enrollment until the exclusive window end,
+// capped at the last enrollment week that still has a scheduled task.
+func (s *ReadStore) PlanDropoutCohorts(ctx context.Context, orgID int64, planID uint64, from, to time.Time) ([]domainstats.DropoutCohort, error) {
+	ctx, release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	whereSQL, args := adherenceCohortWhere(orgID, &planID, from, to)
+	var cohorts []domainstats.DropoutCohort
+	err = s.db.WithContext(ctx).Raw(`SELECT last_completed_week,observed_weeks,COUNT(*) enrollment_count FROM (
+		 SELECT COALESCE(MAX(CASE WHEN a.outcome IN ('on_time','late') THEN a.enrollment_week END),-1) last_completed_week,
+		        LEAST(GREATEST(FLOOR(DATEDIFF(?,MIN(a.enrolled_at))/7),0),MAX(a.enrollment_week)) observed_weeks
+		 FROM statistics_plan_adherence_task a
+		 WHERE `+whereSQL+` AND a.outcome<>'canceled' GROUP BY a.testee_id,a.enrollment_id
+		) enrollments GROUP BY last_completed_week,observed_weeks ORDER BY last_completed_week,observed_weeks`, append([]any{to}, args...)...).Scan(&cohorts).Error
+	return cohorts, err
+}
+
+func (s *ReadStore) PlanAdherenceBreakdown(ctx context.Context, orgID int64, planID uint64, dimension statisticsApp.AdherenceDimension, from, to time.Time, page, size int) ([]statisticsApp.AdherenceBreakdownItem, int64, error) {
+	ctx, release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	column, nameJoin, nameColumn := "a.clinician_id", "LEFT JOIN clinician d ON d.id=a.clinician_id", "d.name"
+	if dimension == statisticsApp.AdherenceByEntry {
+		column, nameJoin, nameColumn = "a.entry_id", "LEFT JOIN assessment_entry d ON d.id=a.entry_id", "d.token"
+	}
+	whereSQL, args := adherenceCohortWhere(orgID, &planID, from, to)
+	var total int64
+	if err := s.db.WithContext(ctx).Raw("SELECT COUNT(DISTINCT "+column+") FROM statistics_plan_adherence_task a WHERE "+whereSQL, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	queryArgs := append(append([]any{}, args...), args...)
+	queryArgs = append(queryArgs, size, (page-1)*size)
+	var items []statisticsApp.AdherenceBreakdownItem
+	err = s.db.WithContext(ctx).Raw(`SELECT `+column+` id,COALESCE(`+nameColumn+`,'') name,`+adherenceCountColumns+`
+		FROM statistics_plan_adherence_task a `+nameJoin+`
+		`+adherenceMedianJoin(column, whereSQL)+`
+		WHERE `+whereSQL+` GROUP BY `+column+`,`+nameColumn+`,m.median_open_to_complete_seconds ORDER BY `+column+` LIMIT ? OFFSET ?`, queryArgs...).Scan(&items).Error
+	return items, total, err
+}
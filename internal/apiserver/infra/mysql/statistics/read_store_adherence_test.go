@@ -0,0 +1,83 @@
+package statistics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	statisticsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/statistics"
+)
+
+func TestListPlanAdherenceScansEmbeddedCountsAndMedian(t *testing.T) {
+	store, mock := newReadStoreTestDB(t)
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	planID := uint64(42)
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT a.plan_id\\) FROM statistics_plan_adherence_task a WHERE a.org_id=\\? AND a.enrolled_at>=\\? AND a.enrolled_at<\\? AND a.plan_id=\\?").
+		WithArgs(int64(7), from, to, planID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("(?s)COUNT\\(DISTINCT a.testee_id,a.enrollment_id\\) enrollment_count.*ROW_NUMBER\\(\\) OVER \\(PARTITION BY a.plan_id ORDER BY open_to_complete_seconds\\).*FLOOR\\(\\(median_count\\+1\\)/2\\).*GROUP BY a.plan_id").
+		WithArgs(int64(7), from, to, planID, int64(7), from, to, planID, 1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"plan_id", "scale_code", "plan_status", "enrollment_count", "task_count", "on_time_count", "late_count", "expired_count", "pending_count", "median_open_to_complete_seconds"}).
+			AddRow(planID, "PHQ-9", "active", 5, 20, 8, 2, 6, 4, 3600.5))
+
+	items, total, err := store.ListPlanAdherence(context.Background(), 7, &planID, from, to, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(items) != 1 {
+		t.Fatalf("total=%d items=%+v", total, items)
+	}
+	item := items[0]
+	if item.EnrollmentCount != 5 || item.TaskCount != 20 || item.OnTimeCount != 8 || item.LateCount != 2 || item.ExpiredCount != 6 || item.PendingCount != 4 {
+		t.Fatalf("item=%+v", item)
+	}
+	if item.MedianOpenToCompleteSeconds == nil || *item.MedianOpenToCompleteSeconds != 3600.5 {
+		t.Fatalf("median=%v", item.MedianOpenToCompleteSeconds)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlanDropoutCohortsObserveUntilWindowEnd(t *testing.T) {
+	store, mock := newReadStoreTestDB(t)
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	mock.ExpectQuery("(?s)LEAST\\(GREATEST\\(FLOOR\\(DATEDIFF\\(\\?,MIN\\(a.enrolled_at\\)\\)/7\\),0\\),MAX\\(a.enrollment_week\\)\\) observed_weeks.*GROUP BY a.testee_id,a.enrollment_id").
+		WithArgs(to, int64(7), from, to, uint64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"last_completed_week", "observed_weeks", "enrollment_count"}).AddRow(-1, 3, 2).AddRow(3, 3, 1))
+
+	cohorts, err := store.PlanDropoutCohorts(context.Background(), 7, 42, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cohorts) != 2 || cohorts[0].LastCompletedWeek != -1 || cohorts[0].EnrollmentCount != 2 || cohorts[1].ObservedWeeks != 3 {
+		t.Fatalf("cohorts=%+v", cohorts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlanAdherenceBreakdownByEntryJoinsEntryToken(t *testing.T) {
+	store, mock := newReadStoreTestDB(t)
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT a.entry_id\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("(?s)SELECT a.entry_id id,COALESCE\\(d.token,''\\) name.*LEFT JOIN assessment_entry d ON d.id=a.entry_id.*PARTITION BY a.entry_id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "task_count", "on_time_count"}).AddRow(0, "", 3, 1).AddRow(9, "tok9", 4, 4))
+
+	items, total, err := store.PlanAdherenceBreakdown(context.Background(), 7, 42, statisticsApp.AdherenceByEntry, from, to, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(items) != 2 || items[1].ID != 9 || items[1].Name != "tok9" || items[1].OnTimeCount != 4 {
+		t.Fatalf("total=%d items=%+v", total, items)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package statistics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	statisticsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/statistics"
+)
+
+// careTeamAccessRoles 与 careteam.AccessRoles 保持一致：只有继承访问的成员计入团队活动量。
+const careTeamAccessRoles = "'lead','member'"
+
+// ListCareTeams 照护团队汇总。成员活动量来自成员从业者的日统计；同一从业者属于多个团队时分别计入各团队。
+func (s *ReadStore) ListCareTeams(ctx context.Context, orgID int64, teamID *uint64, department string, from, to time.Time, page, size int) ([]statisticsApp.CareTeamItem, int64, error) {
+	ctx, release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	where := []string{"t.org_id=?"}
+	args := []any{orgID}
+	if teamID != nil {
+		where = append(where, "t.id=?")
+		args = append(args, *teamID)
+	}
+	if department != "" {
+		where = append(where, "t.department=?")
+		args = append(args, department)
+	}
+	whereSQL := strings.Join(where, " AND ")
+	var total int64
+	if err := s.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM care_team t WHERE "+whereSQL, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	queryArgs := []any{orgID, orgID, from, to, orgID, orgID, from, to, orgID, from, to}
+	queryArgs = append(queryArgs, args...)
+	queryArgs = append(queryArgs, size, (page-1)*size)
+	var items []statisticsApp.CareTeamItem
+	err = s.db.WithContext(ctx).Raw(`SELECT t.id,t.name,t.department,
+		COALESCE(m.member_count,0) member_count,COALESCE(m.access_member_count,0) access_member_count,
+		COALESCE(tt.testee_count,0) testee_count,COALESCE(tt.key_focus_testee_count,0) key_focus_testee_count,COALESCE(tt.assessed_in_window_count,0) assessed_in_window_count,
+		COALESCE(a.entry_opened_count,0) entry_opened_count,COALESCE(a.intake_confirmed_count,0) intake_confirmed_count,
+		COALESCE(e.assessment_created_count,0) assessment_created_count,COALESCE(e.outcome_committed_count,0) outcome_committed_count,COALESCE(e.report_generated_count,0) report_generated_count
+		FROM care_team t
+		LEFT JOIN (SELECT team_id,COUNT(*) member_count,SUM(CASE WHEN role IN (`+careTeamAccessRoles+`) THEN 1 ELSE 0 END) access_member_count FROM care_team_member WHERE org_id=? GROUP BY team_id) m ON m.team_id=t.id
+		LEFT JOIN (SELECT ct.team_id,COUNT(*) testee_count,COUNT(CASE WHEN te.is_key_focus=1 THEN 1 END) key_focus_testee_count,COUNT(f.testee_id) assessed_in_window_count
+			FROM care_team_testee ct JOIN testee te ON te.id=ct.testee_id AND te.deleted_at IS NULL
+			LEFT JOIN (SELECT DISTINCT testee_id FROM statistics_assessment_fact WHERE org_id=? AND fact_type='outcome_committed' AND stat_date>=? AND stat_date<?) f ON f.testee_id=ct.testee_id
+			WHERE ct.org_id=? GROUP BY ct.team_id) tt ON tt.team_id=t.id
+		LEFT JOIN (SELECT cm.team_id,SUM(d.entry_opened_count) entry_opened_count,SUM(d.intake_confirmed_count) intake_confirmed_count
+			FROM care_team_member cm JOIN statistics_access_daily d ON d.org_id=cm.org_id AND d.clinician_id=cm.clinician_id
+			WHERE cm.org_id=? AND cm.role IN (`+careTeamAccessRoles+`) AND d.stat_date>=? AND d.stat_date<? GROUP BY cm.team_id) a ON a.team_id=t.id
+		LEFT JOIN (SELECT cm.team_id,SUM(d.assessment_created_count) assessment_created_count,SUM(d.outcome_committed_count) outcome_committed_count,SUM(d.report_generated_count) report_generated_count
+			FROM care_team_member cm JOIN statistics_assessment_daily d ON d.org_id=cm.org_id AND d.clinician_id=cm.clinician_id
+			WHERE cm.org_id=? AND cm.role IN (`+careTeamAccessRoles+`) AND d.stat_date>=? AND d.stat_date<? GROUP BY cm.team_id) e ON e.team_id=t.id
+		WHERE `+whereSQL+` ORDER BY t.id LIMIT ? OFFSET ?`, queryArgs...).Scan(&items).Error
+	return items, total, err
+}
@@ -0,0 +1,39 @@
+package statistics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestListCareTeamsOnlySumsAccessInheritingMembers(t *testing.T) {
+	store, mock := newReadStoreTestDB(t)
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	teamID := uint64(11)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM care_team t WHERE t.org_id=\\? AND t.id=\\? AND t.department=\\?").
+		WithArgs(int64(7), teamID, "心理科").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("(?s)FROM care_team_member cm JOIN statistics_access_daily d .*cm.role IN \\('lead','member'\\).*FROM care_team_member cm JOIN statistics_assessment_daily d .*cm.role IN \\('lead','member'\\).*ORDER BY t.id LIMIT \\? OFFSET \\?").
+		WithArgs(int64(7), int64(7), from, to, int64(7), int64(7), from, to, int64(7), from, to, int64(7), teamID, "心理科", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "department", "member_count", "access_member_count", "testee_count", "key_focus_testee_count", "assessed_in_window_count",
+			"entry_opened_count", "intake_confirmed_count", "assessment_created_count", "outcome_committed_count", "report_generated_count"}).
+			AddRow(teamID, "青少年组", "心理科", 4, 3, 12, 2, 5, 30, 18, 16, 15, 14))
+
+	items, total, err := store.ListCareTeams(context.Background(), 7, &teamID, "心理科", from, to, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(items) != 1 {
+		t.Fatalf("total=%d items=%+v", total, items)
+	}
+	item := items[0]
+	if item.ID != teamID || item.MemberCount != 4 || item.AccessMemberCount != 3 || item.TesteeCount != 12 || item.AssessedInWindowCount != 5 || item.OutcomeCommittedCount != 15 {
+		t.Fatalf("item=%+v", item)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+package subjectrights
+
+import (
+	"encoding/json"
+	"fmt"
+
+	domainsubject "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func requestToPO(req *domainsubject.Request) (*RequestPO, error) {
+	steps, err := marshalSteps(req.Steps)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(req.ID), CreatedAt: req.RequestedAt, UpdatedAt: req.RequestedAt,
+			CreatedBy: meta.FromUint64(req.RequestedBy), UpdatedBy: meta.FromUint64(req.RequestedBy),
+		},
+		OrgID: req.OrgID, TesteeID: req.TesteeID, Kind: string(req.Kind), Mode: string(req.Mode),
+		Status: string(req.Status), Reason: req.Reason, Steps: steps,
+		BundleKey: req.BundleKey, BundleSize: req.BundleSize, Attempts: req.Attempts,
+		LastError:   truncate(req.LastError, maxLastErrorRunes),
+		RequestedBy: req.RequestedBy, RequestedAt: req.RequestedAt, StartedAt: req.StartedAt, CompletedAt: req.CompletedAt,
+	}, nil
+}
+
+func requestToDomain(po *RequestPO) (*domainsubject.Request, error) {
+	var steps []domainsubject.StepResult
+	if len(po.Steps) > 0 {
+		if err := json.Unmarshal(po.Steps, &steps); err != nil {
+			return nil, fmt.Errorf("decode request steps: %w", err)
+		}
+	}
+	return &domainsubject.Request{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, TesteeID: po.TesteeID,
+		Kind: domainsubject.Kind(po.Kind), Mode: domainsubject.ErasureMode(po.Mode), Status: domainsubject.Status(po.Status),
+		Reason: po.Reason, Steps: steps, BundleKey: po.BundleKey, BundleSize: po.BundleSize,
+		Attempts: po.Attempts, LastError: po.LastError,
+		RequestedBy: po.RequestedBy, RequestedAt: po.RequestedAt, StartedAt: po.StartedAt, CompletedAt: po.CompletedAt,
+	}, nil
+}
+
+func certificateToPO(cert *domainsubject.Certificate) (*CertificatePO, error) {
+	steps, err := marshalSteps(cert.Steps)
+	if err != nil {
+		return nil, err
+	}
+	return &CertificatePO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(cert.ID), CreatedAt: cert.IssuedAt, UpdatedAt: cert.IssuedAt,
+			CreatedBy: meta.FromUint64(cert.IssuedBy), UpdatedBy: meta.FromUint64(cert.IssuedBy),
+		},
+		RequestID: cert.RequestID, OrgID: cert.OrgID, TesteeID: cert.TesteeID, SubjectRef: cert.SubjectRef,
+		Mode: string(cert.Mode), Steps: steps, Digest: cert.Digest, IssuedBy: cert.IssuedBy, IssuedAt: cert.IssuedAt,
+	}, nil
+}
+
+func certificateToDomain(po *CertificatePO) (*domainsubject.Certificate, error) {
+	var steps []domainsubject.StepResult
+	if err := json.Unmarshal(po.Steps, &steps); err != nil {
+		return nil, fmt.Errorf("decode certificate steps: %w", err)
+	}
+	return &domainsubject.Certificate{
+		ID: po.ID.Uint64(), RequestID: po.RequestID, OrgID: po.OrgID, TesteeID: po.TesteeID,
+		SubjectRef: po.SubjectRef, Mode: domainsubject.ErasureMode(po.Mode), Steps: steps,
+		Digest: po.Digest, IssuedBy: po.IssuedBy, IssuedAt: po.IssuedAt,
+	}, nil
+}
+
+func testeeToSnapshot(row testeeRow) *domainsubject.TesteeSnapshot {
+	return &domainsubject.TesteeSnapshot{ID: row.ID, OrgID: row.OrgID, Name: row.Name, Deleted: row.DeletedAt != nil}
+}
+
+func marshalSteps(steps []domainsubject.StepResult) ([]byte, error) {
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(steps)
+}
+
+func truncate(value string, maxRunes int) string {
+	runes := []rune(value)
+	if len(runes) <= maxRunes {
+		return value
+	}
+	return string(runes[:maxRunes])
+}
@@ -0,0 +1,81 @@
+package subjectrights
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// RequestPO 数据主体请求持久化对象
+type RequestPO struct {
+	mysql.AuditFields
+
+	OrgID       int64      `gorm:"column:org_id;not null"`
+	TesteeID    uint64     `gorm:"column:testee_id;not null"`
+	Kind        string     `gorm:"column:kind;size:16;not null"`
+	Mode        string     `gorm:"column:mode;size:16;not null;default:''"`
+	Status      string     `gorm:"column:status;size:16;not null"`
+	Reason      string     `gorm:"column:reason;size:500;not null;default:''"`
+	Steps       []byte     `gorm:"column:steps;type:json"`
+	BundleKey   string     `gorm:"column:bundle_key;size:255;not null;default:''"`
+	BundleSize  int64      `gorm:"column:bundle_size;not null;default:0"`
+	Attempts    int        `gorm:"column:attempts;not null;default:0"`
+	LastError   string     `gorm:"column:last_error;size:1000;not null;default:''"`
+	RequestedBy uint64     `gorm:"column:requested_by;not null;default:0"`
+	RequestedAt time.Time  `gorm:"column:requested_at;not null"`
+	StartedAt   *time.Time `gorm:"column:started_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+}
+
+// TableName 指定表名
+func (RequestPO) TableName() string { return "data_subject_request" }
+
+// BeforeCreate GORM hook：请求的创建人与创建时间即受理人与受理时间。
+func (p *RequestPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// CertificatePO 擦除证书持久化对象；证书写入后不再修改。
+type CertificatePO struct {
+	mysql.AuditFields
+
+	RequestID  uint64    `gorm:"column:request_id;not null"`
+	OrgID      int64     `gorm:"column:org_id;not null"`
+	TesteeID   uint64    `gorm:"column:testee_id;not null"`
+	SubjectRef string    `gorm:"column:subject_ref;size:64;not null"`
+	Mode       string    `gorm:"column:mode;size:16;not null"`
+	Steps      []byte    `gorm:"column:steps;type:json;not null"`
+	Digest     string    `gorm:"column:digest;size:64;not null"`
+	IssuedBy   uint64    `gorm:"column:issued_by;not null;default:0"`
+	IssuedAt   time.Time `gorm:"column:issued_at;not null"`
+}
+
+// TableName 指定表名
+func (CertificatePO) TableName() string { return "data_subject_certificate" }
+
+// BeforeCreate GORM hook：证书的创建人与创建时间即签发人与签发时间。
+func (p *CertificatePO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// testeeRow 受理请求读取的受试者列；受试者由 actor 仓储持久化，这里只做投影。
+type testeeRow struct {
+	ID        uint64
+	OrgID     int64
+	Name      string
+	DeletedAt *time.Time
+}
@@ -0,0 +1,339 @@
+package subjectrights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domainsubject "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/safeconv"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxLastErrorRunes = 1000
+	terminatedReason  = "data subject erasure"
+)
+
+// exportTables 导出包中按 testee_id 采集的表；受试者档案单独按主键采集。
+// 访问审计一并导出，受试者可据此了解其数据被谁查看过。
+var exportTables = []string{
+	"assessment",
+	"assessment_score",
+	"evaluation_outcome",
+	"report_review",
+	"report_clinical_note",
+	"workbench_triage_item",
+	"workbench_triage_event",
+	"risk_alert",
+	"risk_alert_event",
+	"critical_item_flag",
+	"interpretation_report_pdf",
+	"interpretation_plan_report",
+	"interpretation_report_share",
+	"interpretation_report_share_access",
+	"assessment_task",
+	"plan_enrollment",
+	"assessment_entry_intake_log",
+	"clinician_relation",
+	"consent_acceptance",
+	"break_glass_grant",
+	"care_team_testee",
+	"care_team_event",
+	"testee_import_row",
+	"statistics_access_fact",
+	"statistics_assessment_fact",
+	"statistics_plan_fact",
+	"statistics_plan_adherence_task",
+	"access_audit_log",
+}
+
+// deleteTables 删除方式下各步骤按 testee_id 删除的表，子表在前。
+var deleteTables = map[domainsubject.StepName][]string{
+	domainsubject.StepCareRelations:   {"clinician_relation", "break_glass_grant", "care_team_testee", "care_team_event"},
+	domainsubject.StepPlanSchedule:    {"assessment_task", "plan_enrollment"},
+	domainsubject.StepClinicalRecords: {"assessment_score", "evaluation_outcome", "report_clinical_note", "report_review", "workbench_triage_event", "workbench_triage_item", "risk_alert_event", "risk_alert", "critical_item_flag", "assessment_entry_intake_log", "interpretation_report_pdf", "interpretation_plan_report", "interpretation_report_share_access", "interpretation_report_share", "assessment"},
+	domainsubject.StepStatistics:      {"statistics_access_fact", "statistics_assessment_fact", "statistics_plan_fact", "statistics_plan_adherence_task"},
+}
+
+// childTables 没有 testee_id 列、经父表主键归属受试者的子表；删除时须排在父表之前。
+var childTables = map[string]parentKey{
+	"workbench_triage_event": {table: "workbench_triage_item", column: "item_id"},
+	"risk_alert_event":       {table: "risk_alert", column: "alert_id"},
+}
+
+type parentKey struct{ table, column string }
+
+// testeeScope 按受试者筛选表记录的条件，参数为受试者 ID。
+func testeeScope(table string) string {
+	if parent, ok := childTables[table]; ok {
+		return fmt.Sprintf("%s IN (SELECT id FROM `%s` WHERE testee_id=?)", parent.column, parent.table)
+	}
+	return "testee_id=?"
+}
+
+// requestRepository 数据主体请求仓储：请求与证书，以及按受试者采集与擦除各表记录。
+type requestRepository struct {
+	mysql.BaseRepository[*RequestPO]
+}
+
+// NewRequestRepository 创建数据主体请求仓储
+func NewRequestRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainsubject.Repository {
+	return &requestRepository{BaseRepository: mysql.NewBaseRepository[*RequestPO](db, opts...)}
+}
+
+func (r *requestRepository) GetTestee(ctx context.Context, orgID int64, testeeID uint64) (*domainsubject.TesteeSnapshot, error) {
+	var rows []testeeRow
+	if err := r.WithContext(ctx).Table("testee").Select("id, org_id, name, deleted_at").
+		Where("id=? AND org_id=?", testeeID, orgID).Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return testeeToSnapshot(rows[0]), nil
+}
+
+func (r *requestRepository) CreateRequest(ctx context.Context, req *domainsubject.Request) error {
+	po, err := requestToPO(req)
+	if err != nil {
+		return err
+	}
+	return r.CreateAndSync(ctx, po, nil)
+}
+
+func (r *requestRepository) GetRequest(ctx context.Context, orgID int64, requestID uint64) (*domainsubject.Request, error) {
+	var po RequestPO
+	err := r.WithContext(ctx).Where("org_id=? AND id=? AND deleted_at IS NULL", orgID, requestID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return requestToDomain(&po)
+}
+
+func (r *requestRepository) FindOpenRequest(ctx context.Context, orgID int64, testeeID uint64, kind domainsubject.Kind) (*domainsubject.Request, error) {
+	var pos []RequestPO
+	if err := r.WithContext(ctx).
+		Where("org_id=? AND testee_id=? AND kind=? AND status<>? AND deleted_at IS NULL", orgID, testeeID, string(kind), string(domainsubject.StatusCompleted)).
+		Order("requested_at DESC").Limit(1).Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	return requestToDomain(&pos[0])
+}
+
+func (r *requestRepository) ListRequests(ctx context.Context, orgID int64, filter domainsubject.Filter, offset, limit int) ([]domainsubject.Request, int64, error) {
+	query := func() *gorm.DB {
+		db := r.WithContext(ctx).Model(&RequestPO{}).Where("org_id=? AND deleted_at IS NULL", orgID)
+		if filter.TesteeID != 0 {
+			db = db.Where("testee_id=?", filter.TesteeID)
+		}
+		if filter.Kind != "" {
+			db = db.Where("kind=?", string(filter.Kind))
+		}
+		if filter.Status != "" {
+			db = db.Where("status=?", string(filter.Status))
+		}
+		return db
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []RequestPO
+	if err := query().Order("requested_at DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	items := make([]domainsubject.Request, 0, len(pos))
+	for i := range pos {
+		req, err := requestToDomain(&pos[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *req)
+	}
+	return items, total, nil
+}
+
+func (r *requestRepository) ClaimRequest(ctx context.Context, orgID int64, requestID uint64, now, staleBefore time.Time) (bool, error) {
+	result := r.WithContext(ctx).Model(&RequestPO{}).
+		Where("org_id=? AND id=? AND deleted_at IS NULL", orgID, requestID).
+		Where("status IN ? OR (status=? AND started_at<?)",
+			[]string{string(domainsubject.StatusPending), string(domainsubject.StatusFailed)}, string(domainsubject.StatusRunning), staleBefore).
+		Updates(map[string]any{
+			"status":     string(domainsubject.StatusRunning),
+			"started_at": now,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": "",
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *requestRepository) SaveProgress(ctx context.Context, req *domainsubject.Request) error {
+	steps, err := marshalSteps(req.Steps)
+	if err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Model(&RequestPO{}).Where("org_id=? AND id=? AND deleted_at IS NULL", req.OrgID, req.ID).
+		Updates(map[string]any{
+			"status":       string(req.Status),
+			"steps":        steps,
+			"bundle_key":   req.BundleKey,
+			"bundle_size":  req.BundleSize,
+			"last_error":   truncate(req.LastError, maxLastErrorRunes),
+			"completed_at": req.CompletedAt,
+		}).Error
+}
+
+func (r *requestRepository) CollectRecords(ctx context.Context, orgID int64, testeeID uint64) ([]domainsubject.Section, error) {
+	db := r.WithContext(ctx)
+	sections := make([]domainsubject.Section, 0, len(exportTables)+1)
+	testee, err := collect(db.Table("testee").Where("id=? AND org_id=?", testeeID, orgID))
+	if err != nil {
+		return nil, fmt.Errorf("collect testee: %w", err)
+	}
+	sections = append(sections, domainsubject.Section{Store: domainsubject.StoreMySQL, Name: "testee", Records: testee})
+	for _, table := range exportTables {
+		records, err := collect(db.Table(table).Where(testeeScope(table), testeeID))
+		if err != nil {
+			return nil, fmt.Errorf("collect %s: %w", table, err)
+		}
+		sections = append(sections, domainsubject.Section{Store: domainsubject.StoreMySQL, Name: table, Records: records})
+	}
+	return sections, nil
+}
+
+// collect 以列名为键导出整行，二进制列按 UTF-8 文本输出（JSON 对象与数组列保持原样嵌入）。
+func collect(db *gorm.DB) ([]json.RawMessage, error) {
+	var rows []map[string]any
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	records := make([]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		for key, value := range row {
+			if raw, ok := value.([]byte); ok {
+				if len(raw) > 0 && (raw[0] == '{' || raw[0] == '[') && json.Valid(raw) {
+					row[key] = json.RawMessage(raw)
+				} else {
+					row[key] = string(raw)
+				}
+			}
+		}
+		record, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r *requestRepository) EraseRecords(ctx context.Context, subject domainsubject.Subject, step domainsubject.StepName, action domainsubject.StepAction) (int64, error) {
+	var affected int64
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		exec := func(db *gorm.DB) error {
+			affected += db.RowsAffected
+			return db.Error
+		}
+		if action == domainsubject.ActionDelete {
+			if step == domainsubject.StepTesteeProfile {
+				if err := exec(scrubImportRows(tx, subject)); err != nil {
+					return err
+				}
+				return exec(tx.Exec("DELETE FROM testee WHERE id=? AND org_id=?", subject.TesteeID, subject.OrgID))
+			}
+			tables, ok := deleteTables[step]
+			if !ok {
+				return fmt.Errorf("unsupported erasure step %q", step)
+			}
+			for _, table := range tables {
+				if err := exec(tx.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE %s", table, testeeScope(table)), subject.TesteeID)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		switch {
+		case step == domainsubject.StepCareRelations && action == domainsubject.ActionRevoke:
+			if err := exec(tx.Table("clinician_relation").Where("testee_id=? AND deleted_at IS NULL", subject.TesteeID).
+				Updates(map[string]any{
+					"unbound_at": gorm.Expr("COALESCE(unbound_at, ?)", subject.At),
+					"deleted_at": subject.At,
+					"deleted_by": subject.OperatorID,
+				})); err != nil {
+				return err
+			}
+			revokedBy, _ := safeconv.Uint64ToInt64(subject.OperatorID)
+			if err := exec(tx.Table("break_glass_grant").Where("testee_id=? AND revoked_at IS NULL", subject.TesteeID).
+				Updates(map[string]any{"revoked_at": subject.At, "revoked_by": revokedBy, "updated_by": subject.OperatorID})); err != nil {
+				return err
+			}
+			// 团队成员经团队分配继承访问，撤销关系时一并移出所有照护团队；变更历史保留。
+			return exec(tx.Exec("DELETE FROM `care_team_testee` WHERE testee_id=?", subject.TesteeID))
+		case step == domainsubject.StepPlanSchedule && action == domainsubject.ActionRevoke:
+			if err := exec(tx.Table("assessment_task").
+				Where("testee_id=? AND status IN ? AND deleted_at IS NULL", subject.TesteeID, []string{"pending", "opened"}).
+				Updates(map[string]any{"status": "canceled", "canceled_at": subject.At})); err != nil {
+				return err
+			}
+			return exec(tx.Table("plan_enrollment").Where("testee_id=? AND status=?", subject.TesteeID, "active").
+				Updates(map[string]any{"status": "terminated", "terminated_at": subject.At, "terminated_reason": terminatedReason}))
+		case step == domainsubject.StepTesteeProfile && action == domainsubject.ActionPseudonymize:
+			if err := exec(scrubImportRows(tx, subject)); err != nil {
+				return err
+			}
+			return exec(tx.Table("testee").Where("id=? AND org_id=?", subject.TesteeID, subject.OrgID).
+				Updates(map[string]any{
+					"name":         subject.Pseudonym,
+					"profile_id":   nil,
+					"birthday":     nil,
+					"tags":         nil,
+					"is_key_focus": false,
+					"deleted_at":   gorm.Expr("COALESCE(deleted_at, ?)", subject.At),
+					"deleted_by":   subject.OperatorID,
+					"updated_by":   subject.OperatorID,
+					"version":      gorm.Expr("version + 1"),
+				}))
+		default:
+			return fmt.Errorf("unsupported erasure step %q with action %q", step, action)
+		}
+	})
+	return affected, err
+}
+
+// scrubImportRows 清除批量导入明细中的受试者标识；导入任务的计数保持不变。
+func scrubImportRows(tx *gorm.DB, subject domainsubject.Subject) *gorm.DB {
+	return tx.Table("testee_import_row").Where("testee_id=?", subject.TesteeID).
+		Updates(map[string]any{"name": subject.Pseudonym, "birthday": nil, "profile_id": nil})
+}
+
+func (r *requestRepository) IssueCertificate(ctx context.Context, cert *domainsubject.Certificate) error {
+	po, err := certificateToPO(cert)
+	if err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(po).Error
+}
+
+func (r *requestRepository) GetCertificate(ctx context.Context, orgID int64, requestID uint64) (*domainsubject.Certificate, error) {
+	var po CertificatePO
+	err := r.WithContext(ctx).Where("org_id=? AND request_id=? AND deleted_at IS NULL", orgID, requestID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return certificateToDomain(&po)
+}
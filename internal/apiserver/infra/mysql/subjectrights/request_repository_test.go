@@ -0,0 +1,223 @@
+package subjectrights
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainsubject "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/pkg/migration"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newRequestRepositoryTestDB(t *testing.T) (*requestRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRequestRepository(db).(*requestRepository), mock
+}
+
+func TestClaimRequestAcceptsPendingFailedOrStaleRunning(t *testing.T) {
+	repo, mock := newRequestRepositoryTestDB(t)
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	stale := now.Add(-15 * time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `data_subject_request` SET `attempts`=attempts + 1,`last_error`=?,`started_at`=?,`status`=?,`updated_at`=? WHERE (org_id=? AND id=? AND deleted_at IS NULL) AND (status IN (?,?) OR (status=? AND started_at<?))")).
+		WithArgs("", now, "running", sqlmock.AnyArg(), int64(7), uint64(11), "pending", "failed", "running", stale).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	claimed, err := repo.ClaimRequest(context.Background(), 7, 11, now, stale)
+	if err != nil || !claimed {
+		t.Fatalf("ClaimRequest() = %v, %v", claimed, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErasePseudonymizesTesteeAndImportRows(t *testing.T) {
+	repo, mock := newRequestRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	subject := domainsubject.Subject{OrgID: 7, TesteeID: 401, Pseudonym: "testee_abc", OperatorID: 900, At: at}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `testee_import_row` SET `birthday`=?,`name`=?,`profile_id`=? WHERE testee_id=?")).
+		WithArgs(nil, "testee_abc", nil, uint64(401)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `testee` SET `birthday`=?,`deleted_at`=COALESCE(deleted_at, ?),`deleted_by`=?,`is_key_focus`=?,`name`=?,`profile_id`=?,`tags`=?,`updated_by`=?,`version`=version + 1 WHERE id=? AND org_id=?")).
+		WithArgs(nil, at, uint64(900), false, "testee_abc", nil, nil, uint64(900), uint64(401), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	affected, err := repo.EraseRecords(context.Background(), subject, domainsubject.StepTesteeProfile, domainsubject.ActionPseudonymize)
+	if err != nil || affected != 2 {
+		t.Fatalf("EraseRecords() = %d, %v", affected, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEraseDeletesClinicalRecordsChildTablesFirst(t *testing.T) {
+	repo, mock := newRequestRepositoryTestDB(t)
+	subject := domainsubject.Subject{OrgID: 7, TesteeID: 401}
+	mock.ExpectBegin()
+	for _, table := range []string{"assessment_score", "evaluation_outcome", "report_clinical_note", "report_review", "workbench_triage_event", "workbench_triage_item", "risk_alert_event", "risk_alert", "critical_item_flag", "assessment_entry_intake_log", "interpretation_report_pdf", "interpretation_plan_report", "interpretation_report_share_access", "interpretation_report_share", "assessment"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `" + table + "` WHERE " + testeeScope(table))).
+			WithArgs(uint64(401)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	mock.ExpectCommit()
+
+	affected, err := repo.EraseRecords(context.Background(), subject, domainsubject.StepClinicalRecords, domainsubject.ActionDelete)
+	if err != nil || affected != 30 {
+		t.Fatalf("EraseRecords() = %d, %v", affected, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEraseRejectsUnsupportedAction(t *testing.T) {
+	repo, mock := newRequestRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	if _, err := repo.EraseRecords(context.Background(), domainsubject.Subject{OrgID: 7, TesteeID: 401}, domainsubject.StepClinicalRecords, domainsubject.ActionPseudonymize); err == nil {
+		t.Fatal("expected unsupported action to fail")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIssueCertificateKeepsExistingCertificate(t *testing.T) {
+	repo, mock := newRequestRepositoryTestDB(t)
+	issuedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `data_subject_certificate` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`request_id`,`org_id`,`testee_id`,`subject_ref`,`mode`,`steps`,`digest`,`issued_by`,`issued_at`,`id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")).
+		WithArgs(issuedAt, issuedAt, nil, uint64(900), uint64(900), uint64(0), uint32(1), uint64(11), int64(7), uint64(401), "testee_abc", "anonymize", sqlmock.AnyArg(), "d", uint64(900), issuedAt, uint64(21)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.IssueCertificate(context.Background(), &domainsubject.Certificate{
+		ID: 21, RequestID: 11, OrgID: 7, TesteeID: 401, SubjectRef: "testee_abc", Mode: domainsubject.ErasureModeAnonymize,
+		Steps:  []domainsubject.StepResult{{Name: domainsubject.StepTesteeProfile, Store: domainsubject.StoreMySQL, Action: domainsubject.ActionPseudonymize, Status: domainsubject.StepStatusCompleted, Affected: 1}},
+		Digest: "d", IssuedBy: 900, IssuedAt: issuedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDataSubjectMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000091_add_data_subject_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `data_subject_request`",
+		"ALTER TABLE `data_subject_certificate`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `updated_by`",
+		"ADD COLUMN `deleted_by`",
+		"ADD COLUMN `version`",
+		"`created_by` = `requested_by`",
+		"`created_by` = `issued_by`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
+
+// retainedTables 带 testee_id 但删除方式下不按受试者删除的表及原因。
+var retainedTables = map[string]string{
+	"access_audit_log":         "防篡改审计链，删除会破坏链校验",
+	"consent_acceptance":       "知情同意是合规凭证",
+	"data_subject_request":     "擦除请求本身是处理凭证",
+	"data_subject_certificate": "擦除证书是处理凭证",
+	"testee_import_row":        "受试者档案步骤中清除标识，保留导入计数",
+}
+
+// unexportedTables 带 testee_id 但不进入导出包的表及原因。
+var unexportedTables = map[string]string{
+	"data_subject_request":     "请求记录经数据主体请求接口查看",
+	"data_subject_certificate": "证书经数据主体请求接口查看",
+}
+
+// 新增带 testee_id 的表必须同时决定导出与删除方式，否则擦除证书会在数据仍然存在时签发。
+func TestEveryTesteeKeyedTableIsExportedAndErased(t *testing.T) {
+	tables, err := migration.MySQLTablesWithColumn("testee_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := map[string]bool{}
+	for step, names := range deleteTables {
+		position := map[string]int{}
+		for index, table := range names {
+			deleted[table] = true
+			position[table] = index
+		}
+		for child, parent := range childTables {
+			index, ok := position[child]
+			if !ok {
+				continue
+			}
+			if parentIndex, found := position[parent.table]; !found || parentIndex <= index {
+				t.Errorf("step %s deletes %s without deleting its parent %s afterwards", step, child, parent.table)
+			}
+		}
+	}
+	exported := map[string]bool{}
+	for _, table := range exportTables {
+		exported[table] = true
+	}
+	requireRegistry(t, tables, "erased", deleted, retainedTables)
+	requireRegistry(t, tables, "exported", exported, unexportedTables)
+}
+
+func requireRegistry(t *testing.T, schema []string, verb string, registered map[string]bool, exempt map[string]string) {
+	t.Helper()
+	current := map[string]bool{}
+	for _, table := range schema {
+		current[table] = true
+		if _, ok := exempt[table]; !ok && !registered[table] {
+			t.Errorf("table %s has testee_id but is not %s; register it or list it as exempt with a reason", table, verb)
+		}
+	}
+	for table := range registered {
+		if parent, ok := childTables[table]; ok {
+			if !registered[parent.table] {
+				t.Errorf("child table %s is %s but its parent %s is not", table, verb, parent.table)
+			}
+			continue
+		}
+		if !current[table] {
+			t.Errorf("%s table %s has no testee_id column in the current schema", verb, table)
+		}
+		if _, ok := exempt[table]; ok {
+			t.Errorf("table %s is both %s and exempt", table, verb)
+		}
+	}
+	for table := range exempt {
+		if !current[table] {
+			t.Errorf("exempt table %s has no testee_id column in the current schema", table)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package testeeimport
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainimport "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeeimport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+// rowInsertBatchSize 导入行分批写入，单条 INSERT 的占位符数量保持在 MySQL 限制之内。
+const rowInsertBatchSize = 500
+
+type jobRepository struct {
+	mysql.BaseRepository[*ImportJobPO]
+}
+
+// NewJobRepository 创建导入任务仓储
+func NewJobRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainimport.Repository {
+	return &jobRepository{BaseRepository: mysql.NewBaseRepository[*ImportJobPO](db, opts...)}
+}
+
+func (r *jobRepository) Create(ctx context.Context, job *domainimport.Job, rows []domainimport.Row) error {
+	po := jobToPO(job)
+	pos := make([]ImportRowPO, 0, len(rows))
+	for _, row := range rows {
+		pos = append(pos, rowToPO(row))
+	}
+	return r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := mysql.WithTx(ctx, tx)
+		if err := r.CreateAndSync(txCtx, po, nil); err != nil {
+			return err
+		}
+		if len(pos) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&pos, rowInsertBatchSize).Error
+	})
+}
+
+func (r *jobRepository) Get(ctx context.Context, orgID int64, jobID uint64) (*domainimport.Job, error) {
+	var po ImportJobPO
+	err := r.WithContext(ctx).Where("id=? AND org_id=? AND deleted_at IS NULL", jobID, orgID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jobToDomain(&po), nil
+}
+
+func (r *jobRepository) List(ctx context.Context, orgID int64, offset, limit int) ([]domainimport.Job, int64, error) {
+	var total int64
+	if err := r.WithContext(ctx).Model(&ImportJobPO{}).Where("org_id=? AND deleted_at IS NULL", orgID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []ImportJobPO
+	if err := r.WithContext(ctx).Where("org_id=? AND deleted_at IS NULL", orgID).Order("created_at DESC,id DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	jobs := make([]domainimport.Job, 0, len(pos))
+	for i := range pos {
+		jobs = append(jobs, *jobToDomain(&pos[i]))
+	}
+	return jobs, total, nil
+}
+
+func (r *jobRepository) CountRows(ctx context.Context, jobID uint64) (domainimport.RowCounts, error) {
+	var counts domainimport.RowCounts
+	err := r.WithContext(ctx).Raw(`SELECT
+		COALESCE(SUM(status='pending'),0) pending,COALESCE(SUM(status='invalid'),0) invalid,
+		COALESCE(SUM(status='duplicate'),0) duplicate,COALESCE(SUM(status='succeeded'),0) succeeded,
+		COALESCE(SUM(status='failed'),0) failed,
+		COALESCE(SUM(status='succeeded' AND testee_action='created'),0) created,
+		COALESCE(SUM(status='succeeded' AND testee_action='matched'),0) matched,
+		COALESCE(SUM(status='succeeded' AND enrollment_id<>0),0) enrolled
+		FROM testee_import_row WHERE job_id=?`, jobID).Scan(&counts).Error
+	return counts, err
+}
+
+func (r *jobRepository) ListRows(ctx context.Context, jobID uint64, status domainimport.RowStatus, offset, limit int) ([]domainimport.Row, int64, error) {
+	query := func() *gorm.DB {
+		db := r.WithContext(ctx).Model(&ImportRowPO{}).Where("job_id=?", jobID)
+		if status != "" {
+			db = db.Where("status=?", string(status))
+		}
+		return db
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []ImportRowPO
+	if err := query().Order("row_no").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return rowsToDomain(pos), total, nil
+}
+
+func (r *jobRepository) NextRunnable(ctx context.Context) (*domainimport.Job, error) {
+	var po ImportJobPO
+	err := r.WithContext(ctx).Where("status IN ? AND deleted_at IS NULL", []string{string(domainimport.JobStatusPending), string(domainimport.JobStatusRunning)}).
+		Order("created_at,id").Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jobToDomain(&po), nil
+}
+
+func (r *jobRepository) ListPendingRows(ctx context.Context, jobID uint64, limit int) ([]domainimport.Row, error) {
+	var pos []ImportRowPO
+	err := r.WithContext(ctx).Where("job_id=? AND status=?", jobID, string(domainimport.RowStatusPending)).
+		Order("row_no").Limit(limit).Find(&pos).Error
+	return rowsToDomain(pos), err
+}
+
+func (r *jobRepository) SaveRow(ctx context.Context, row domainimport.Row) error {
+	po := rowToPO(row)
+	return r.WithContext(ctx).Model(&ImportRowPO{}).Where("job_id=? AND row_no=?", row.JobID, row.RowNo).Updates(map[string]any{
+		"status":        po.Status,
+		"testee_id":     po.TesteeID,
+		"testee_action": po.TesteeAction,
+		"relation_id":   po.RelationID,
+		"enrollment_id": po.EnrollmentID,
+		"attempts":      po.Attempts,
+		"error":         po.Error,
+		"processed_at":  po.ProcessedAt,
+	}).Error
+}
+
+func (r *jobRepository) MarkRunning(ctx context.Context, jobID uint64, at time.Time) error {
+	return r.updateJob(ctx, jobID, []domainimport.JobStatus{domainimport.JobStatusPending},
+		map[string]any{"status": string(domainimport.JobStatusRunning), "started_at": gorm.Expr("COALESCE(started_at,?)", at)})
+}
+
+func (r *jobRepository) MarkCompleted(ctx context.Context, jobID uint64, at time.Time) error {
+	return r.updateJob(ctx, jobID, []domainimport.JobStatus{domainimport.JobStatusRunning},
+		map[string]any{"status": string(domainimport.JobStatusCompleted), "finished_at": at, "last_error": ""})
+}
+
+func (r *jobRepository) MarkCanceled(ctx context.Context, jobID uint64, at time.Time) error {
+	return r.updateJob(ctx, jobID, []domainimport.JobStatus{domainimport.JobStatusPending, domainimport.JobStatusRunning},
+		map[string]any{"status": string(domainimport.JobStatusCanceled), "finished_at": at})
+}
+
+func (r *jobRepository) RecordError(ctx context.Context, jobID uint64, message string) error {
+	return r.WithContext(ctx).Model(&ImportJobPO{}).Where("id=?", jobID).Update("last_error", message).Error
+}
+
+func (r *jobRepository) RequeueFailedRows(ctx context.Context, jobID uint64) (int64, error) {
+	var requeued int64
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ImportRowPO{}).Where("job_id=? AND status=?", jobID, string(domainimport.RowStatusFailed)).
+			Updates(map[string]any{"status": string(domainimport.RowStatusPending), "error": ""})
+		if result.Error != nil {
+			return result.Error
+		}
+		requeued = result.RowsAffected
+		if requeued == 0 {
+			return nil
+		}
+		return tx.Model(&ImportJobPO{}).Where("id=? AND status<>?", jobID, string(domainimport.JobStatusCanceled)).
+			Updates(map[string]any{"status": string(domainimport.JobStatusPending), "finished_at": nil, "last_error": ""}).Error
+	})
+	return requeued, err
+}
+
+// updateJob 只在任务处于 from 状态之一时推进状态，重复调用不会回退已结束的任务。
+func (r *jobRepository) updateJob(ctx context.Context, jobID uint64, from []domainimport.JobStatus, values map[string]any) error {
+	statuses := make([]string, 0, len(from))
+	for _, status := range from {
+		statuses = append(statuses, string(status))
+	}
+	return r.WithContext(ctx).Model(&ImportJobPO{}).Where("id=? AND status IN ?", jobID, statuses).Updates(values).Error
+}
@@ -0,0 +1,134 @@
+package testeeimport
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domainimport "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeeimport"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newJobRepositoryTestDB(t *testing.T) (*jobRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewJobRepository(db).(*jobRepository), mock
+}
+
+func TestCountRowsAggregatesOutcomes(t *testing.T) {
+	repo, mock := newJobRepositoryTestDB(t)
+	mock.ExpectQuery("(?s)SUM\\(status='succeeded' AND testee_action='created'\\).*FROM testee_import_row WHERE job_id=\\?").
+		WithArgs(uint64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"pending", "invalid", "duplicate", "succeeded", "failed", "created", "matched", "enrolled"}).
+			AddRow(1, 2, 3, 4, 5, 3, 1, 4))
+	counts, err := repo.CountRows(context.Background(), 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domainimport.RowCounts{Pending: 1, Invalid: 2, Duplicate: 3, Succeeded: 4, Failed: 5, Created: 3, Matched: 1, Enrolled: 4}
+	if counts != want {
+		t.Fatalf("counts=%+v", counts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequeueFailedRowsReopensJobOnlyWhenRowsChanged(t *testing.T) {
+	repo, mock := newJobRepositoryTestDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `testee_import_row` SET `error`=?,`status`=? WHERE job_id=? AND status=?")).
+		WithArgs("", "pending", uint64(9), "failed").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `testee_import_job` SET `finished_at`=?,`last_error`=?,`status`=?,`updated_at`=? WHERE id=? AND status<>?")).
+		WithArgs(nil, "", "pending", sqlmock.AnyArg(), uint64(9), "canceled").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	requeued, err := repo.RequeueFailedRows(context.Background(), 9)
+	if err != nil || requeued != 2 {
+		t.Fatalf("requeued=%d err=%v", requeued, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `testee_import_row`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	if requeued, err = repo.RequeueFailedRows(context.Background(), 9); err != nil || requeued != 0 {
+		t.Fatalf("requeued=%d err=%v", requeued, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateWritesJobWithAuditFieldsAndRowsInOneTransaction(t *testing.T) {
+	repo, mock := newJobRepositoryTestDB(t)
+	createdAt := time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `testee_import_job` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`")).
+		WithArgs(createdAt, sqlmock.AnyArg(), nil, uint64(3), uint64(0), uint64(0), uint32(1), int64(7),
+			"roster.csv", "csv", uint64(5), "primary", uint64(0), "", "pending", 1, "", nil, nil, uint64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `testee_import_row`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	job := &domainimport.Job{ID: 9, OrgID: 7, FileName: "roster.csv", FileFormat: domainimport.FileFormatCSV, ClinicianID: 5,
+		RelationType: "primary", Status: domainimport.JobStatusPending, TotalRows: 1, CreatedBy: 3, CreatedAt: createdAt}
+	rows := []domainimport.Row{{JobID: 9, RowNo: 2, Name: "张三", Status: domainimport.RowStatusPending}}
+	if err := repo.Create(context.Background(), job, rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTesteeImportMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000087_add_testee_import_job_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, column := range []string{"`deleted_at`", "`updated_by`", "`deleted_by`", "`version`"} {
+		if !strings.Contains(string(up), "ADD COLUMN "+column) {
+			t.Fatalf("audit migration does not add %s", column)
+		}
+	}
+}
+
+func TestTesteeImportMigrationDefinesResumableRows(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000070_add_testee_import_job.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(up)
+	for _, token := range []string{
+		"CREATE TABLE `testee_import_job`", "CREATE TABLE `testee_import_row`",
+		"PRIMARY KEY (`job_id`,`row_no`)", "idx_testee_import_job_runnable", "idx_testee_import_row_status",
+		"`relation_id`", "`enrollment_id`", "`attempts`",
+	} {
+		if !strings.Contains(text, token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+	down, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000070_add_testee_import_job.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"testee_import_job", "testee_import_row"} {
+		if !strings.Contains(string(down), "DROP TABLE IF EXISTS `"+table+"`") {
+			t.Fatalf("down migration does not drop %s", table)
+		}
+	}
+}
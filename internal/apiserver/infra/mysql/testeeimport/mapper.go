@@ -0,0 +1,46 @@
+package testeeimport
+
+import (
+	domainimport "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeeimport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func jobToPO(job *domainimport.Job) *ImportJobPO {
+	return &ImportJobPO{
+		AuditFields: mysql.AuditFields{ID: meta.FromUint64(job.ID), CreatedBy: meta.FromUint64(job.CreatedBy), CreatedAt: job.CreatedAt},
+		OrgID:       job.OrgID, FileName: job.FileName, FileFormat: string(job.FileFormat),
+		ClinicianID: job.ClinicianID, RelationType: job.RelationType, PlanID: job.PlanID, PlanStartDate: job.PlanStartDate,
+		Status: string(job.Status), TotalRows: job.TotalRows, LastError: job.LastError,
+		StartedAt: job.StartedAt, FinishedAt: job.FinishedAt,
+	}
+}
+
+func jobToDomain(po *ImportJobPO) *domainimport.Job {
+	return &domainimport.Job{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, FileName: po.FileName, FileFormat: domainimport.FileFormat(po.FileFormat),
+		ClinicianID: po.ClinicianID, RelationType: po.RelationType, PlanID: po.PlanID, PlanStartDate: po.PlanStartDate,
+		Status: domainimport.JobStatus(po.Status), TotalRows: po.TotalRows, CreatedBy: po.CreatedBy.Uint64(), LastError: po.LastError,
+		CreatedAt: po.CreatedAt, StartedAt: po.StartedAt, FinishedAt: po.FinishedAt,
+	}
+}
+
+func rowToPO(row domainimport.Row) ImportRowPO {
+	return ImportRowPO{
+		JobID: row.JobID, RowNo: row.RowNo, Name: row.Name, Gender: row.Gender, Birthday: row.Birthday, ProfileID: row.ProfileID,
+		Status: string(row.Status), TesteeID: row.TesteeID, TesteeAction: string(row.TesteeAction), RelationID: row.RelationID,
+		EnrollmentID: row.EnrollmentID, Attempts: row.Attempts, Error: row.Error, ProcessedAt: row.ProcessedAt,
+	}
+}
+
+func rowsToDomain(pos []ImportRowPO) []domainimport.Row {
+	rows := make([]domainimport.Row, 0, len(pos))
+	for _, po := range pos {
+		rows = append(rows, domainimport.Row{
+			JobID: po.JobID, RowNo: po.RowNo, Name: po.Name, Gender: po.Gender, Birthday: po.Birthday, ProfileID: po.ProfileID,
+			Status: domainimport.RowStatus(po.Status), TesteeID: po.TesteeID, TesteeAction: domainimport.TesteeAction(po.TesteeAction),
+			RelationID: po.RelationID, EnrollmentID: po.EnrollmentID, Attempts: po.Attempts, Error: po.Error, ProcessedAt: po.ProcessedAt,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,62 @@
+package testeeimport
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// ImportJobPO 导入任务持久化对象
+type ImportJobPO struct {
+	mysql.AuditFields
+
+	OrgID         int64      `gorm:"column:org_id;not null"`
+	FileName      string     `gorm:"column:file_name;size:255;not null;default:''"`
+	FileFormat    string     `gorm:"column:file_format;size:16;not null"`
+	ClinicianID   uint64     `gorm:"column:clinician_id;not null"`
+	RelationType  string     `gorm:"column:relation_type;size:32;not null"`
+	PlanID        uint64     `gorm:"column:plan_id;not null;default:0"`
+	PlanStartDate string     `gorm:"column:plan_start_date;size:10;not null;default:''"`
+	Status        string     `gorm:"column:status;size:16;not null"`
+	TotalRows     int        `gorm:"column:total_rows;not null;default:0"`
+	LastError     string     `gorm:"column:last_error;size:512;not null;default:''"`
+	StartedAt     *time.Time `gorm:"column:started_at"`
+	FinishedAt    *time.Time `gorm:"column:finished_at"`
+}
+
+// TableName 指定表名
+func (ImportJobPO) TableName() string { return "testee_import_job" }
+
+// BeforeCreate GORM hook：保留领域层给出的创建人与创建时间。
+func (p *ImportJobPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// ImportRowPO 导入行持久化对象；以 (job_id,row_no) 为主键，随任务写入。
+type ImportRowPO struct {
+	JobID        uint64     `gorm:"column:job_id;primaryKey;autoIncrement:false"`
+	RowNo        int        `gorm:"column:row_no;primaryKey;autoIncrement:false"`
+	Name         string     `gorm:"column:name;size:100;not null;default:''"`
+	Gender       int8       `gorm:"column:gender;not null;default:0"`
+	Birthday     *time.Time `gorm:"column:birthday;type:date"`
+	ProfileID    *uint64    `gorm:"column:profile_id"`
+	Status       string     `gorm:"column:status;size:16;not null"`
+	TesteeID     uint64     `gorm:"column:testee_id;not null;default:0"`
+	TesteeAction string     `gorm:"column:testee_action;size:16;not null;default:''"`
+	RelationID   uint64     `gorm:"column:relation_id;not null;default:0"`
+	EnrollmentID uint64     `gorm:"column:enrollment_id;not null;default:0"`
+	Attempts     int        `gorm:"column:attempts;not null;default:0"`
+	Error        string     `gorm:"column:error;size:1024;not null;default:''"`
+	ProcessedAt  *time.Time `gorm:"column:processed_at"`
+}
+
+// TableName 指定表名
+func (ImportRowPO) TableName() string { return "testee_import_row" }
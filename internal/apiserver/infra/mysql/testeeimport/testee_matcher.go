@@ -0,0 +1,44 @@
+package testeeimport
+
+import (
+	"context"
+	"time"
+
+	importApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+)
+
+// TesteeMatcher 在机构内按档案或身份信息查找可复用的受试者（只读）。
+type TesteeMatcher struct{ db *gorm.DB }
+
+var _ importApp.TesteeMatcher = (*TesteeMatcher)(nil)
+
+// NewTesteeMatcher 创建受试者匹配读模型
+func NewTesteeMatcher(db *gorm.DB) *TesteeMatcher { return &TesteeMatcher{db: db} }
+
+func (m *TesteeMatcher) FindByProfile(ctx context.Context, orgID int64, profileID uint64) ([]uint64, error) {
+	var ids []uint64
+	err := m.dbFor(ctx).Raw("SELECT id FROM testee WHERE org_id=? AND profile_id=? AND deleted_at IS NULL ORDER BY id LIMIT 2", orgID, profileID).Scan(&ids).Error
+	return ids, err
+}
+
+// FindByIdentity 按姓名、性别与出生日期精确匹配；文件未提供出生日期时只匹配同样未登记出生日期的受试者。
+func (m *TesteeMatcher) FindByIdentity(ctx context.Context, orgID int64, name string, gender int8, birthday *time.Time) ([]uint64, error) {
+	db := m.dbFor(ctx).Table("testee").Select("id").Where("org_id=? AND name=? AND gender=? AND deleted_at IS NULL", orgID, name, gender)
+	if birthday != nil {
+		db = db.Where("DATE(birthday)=?", birthday.Format("2006-01-02"))
+	} else {
+		db = db.Where("birthday IS NULL")
+	}
+	var ids []uint64
+	err := db.Order("id").Limit(5).Scan(&ids).Error
+	return ids, err
+}
+
+func (m *TesteeMatcher) dbFor(ctx context.Context) *gorm.DB {
+	if tx, ok := mysql.TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return m.db.WithContext(ctx)
+}
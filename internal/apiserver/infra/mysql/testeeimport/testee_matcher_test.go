@@ -0,0 +1,51 @@
+package testeeimport
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newTesteeMatcherTestDB(t *testing.T) (*TesteeMatcher, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTesteeMatcher(db), mock
+}
+
+func TestFindByIdentityMatchesMissingBirthdayExactly(t *testing.T) {
+	matcher, mock := newTesteeMatcherTestDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM `testee` WHERE (org_id=? AND name=? AND gender=? AND deleted_at IS NULL) AND birthday IS NULL ORDER BY id LIMIT ?")).
+		WithArgs(int64(7), "张三", int8(1), 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(12))
+	ids, err := matcher.FindByIdentity(context.Background(), 7, "张三", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 11 {
+		t.Fatalf("ids=%v", ids)
+	}
+
+	birthday := time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("AND DATE(birthday)=? ORDER BY id")).
+		WithArgs(int64(7), "张三", int8(1), "2015-03-04", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	if ids, err = matcher.FindByIdentity(context.Background(), 7, "张三", 1, &birthday); err != nil || len(ids) != 0 {
+		t.Fatalf("ids=%v err=%v", ids, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
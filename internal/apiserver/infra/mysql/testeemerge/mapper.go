@@ -0,0 +1,78 @@
+package testeemerge
+
+import (
+	"encoding/json"
+	"fmt"
+
+	domainmerge "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeemerge"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func mergeLogToPO(log *domainmerge.MergeLog) (*MergeLogPO, error) {
+	reasons, err := json.Marshal(log.MatchReasons)
+	if err != nil {
+		return nil, err
+	}
+	moved := log.Moved
+	if moved == nil {
+		moved = []domainmerge.MovedRecords{}
+	}
+	movedJSON, err := json.Marshal(moved)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := json.Marshal(log.SkippedRelationIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &MergeLogPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(log.ID), CreatedAt: log.MergedAt, CreatedBy: meta.FromUint64(log.MergedBy), UpdatedBy: meta.FromUint64(log.MergedBy),
+		},
+		OrgID: log.OrgID, SurvivorID: log.SurvivorID, DuplicateID: log.DuplicateID,
+		Status: string(log.Status), MatchReasons: reasons, Reason: log.Reason,
+		MovedRecords: movedJSON, SkippedRelationIDs: skipped, TransferredProfileID: log.TransferredProfile,
+		MergedBy: log.MergedBy, MergedAt: log.MergedAt,
+		RevertedBy: log.RevertedBy, RevertedAt: log.RevertedAt, RevertReason: log.RevertReason,
+	}, nil
+}
+
+func mergeLogToDomain(po *MergeLogPO) (*domainmerge.MergeLog, error) {
+	log := &domainmerge.MergeLog{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, SurvivorID: po.SurvivorID, DuplicateID: po.DuplicateID,
+		Status: domainmerge.MergeStatus(po.Status), Reason: po.Reason, TransferredProfile: po.TransferredProfileID,
+		MergedBy: po.MergedBy, MergedAt: po.MergedAt,
+		RevertedBy: po.RevertedBy, RevertedAt: po.RevertedAt, RevertReason: po.RevertReason,
+	}
+	for field, raw := range map[string]struct {
+		data   []byte
+		target any
+	}{
+		"match_reasons":        {po.MatchReasons, &log.MatchReasons},
+		"moved_records":        {po.MovedRecords, &log.Moved},
+		"skipped_relation_ids": {po.SkippedRelationIDs, &log.SkippedRelationIDs},
+	} {
+		if len(raw.data) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(raw.data, raw.target); err != nil {
+			return nil, fmt.Errorf("decode merge log %d %s: %w", log.ID, field, err)
+		}
+	}
+	return log, nil
+}
+
+func testeeToSnapshot(row testeeRow) domainmerge.TesteeSnapshot {
+	return domainmerge.TesteeSnapshot{
+		ID: row.ID, OrgID: row.OrgID, ProfileID: row.ProfileID, Name: row.Name, Gender: row.Gender,
+		Birthday: row.Birthday, Source: row.Source, CreatedAt: row.CreatedAt, Deleted: row.DeletedAt != nil,
+	}
+}
+
+func pairToDomain(orgID int64, row pairRow) domainmerge.CandidatePair {
+	return domainmerge.CandidatePair{
+		A: domainmerge.TesteeSnapshot{ID: row.AID, OrgID: orgID, ProfileID: row.AProfileID, Name: row.AName, Gender: row.AGender, Birthday: row.ABirthday, Source: row.ASource, CreatedAt: row.ACreatedAt},
+		B: domainmerge.TesteeSnapshot{ID: row.BID, OrgID: orgID, ProfileID: row.BProfileID, Name: row.BName, Gender: row.BGender, Birthday: row.BBirthday, Source: row.BSource, CreatedAt: row.BCreatedAt},
+	}
+}
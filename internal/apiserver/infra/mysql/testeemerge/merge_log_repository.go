@@ -0,0 +1,432 @@
+package testeemerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	domainRelation "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/relation"
+	domainmerge "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeemerge"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	relationTable = "clinician_relation"
+	careTeamTable = "care_team_testee"
+	// updateChunkSize 按主键分批迁移，单条 UPDATE 的 IN 列表保持在合理长度。
+	updateChunkSize = 1000
+	conflictLimit   = 10
+)
+
+// repointTables 合并时整体迁移 testee_id 的表；从业者关系与照护团队分配单独处理唯一键冲突。
+// 没有 testee_id 列的子表（分诊历史、预警寻呼历史）经父表主键归属受试者，随父表迁移，无需登记。
+// 回滚只接受该白名单内的表名，表名不会来自请求参数。
+var repointTables = []string{
+	"assessment",
+	"assessment_score",
+	"evaluation_outcome",
+	"report_review",
+	"report_clinical_note",
+	"workbench_triage_item",
+	"risk_alert",
+	"critical_item_flag",
+	"interpretation_report_pdf",
+	"interpretation_plan_report",
+	"assessment_task",
+	"plan_enrollment",
+	"assessment_entry_intake_log",
+	"interpretation_report_share",
+	"interpretation_report_share_access",
+	"statistics_access_fact",
+	"statistics_assessment_fact",
+	"statistics_plan_fact",
+	"statistics_plan_adherence_task",
+	"care_team_event",
+	"consent_acceptance",
+	"break_glass_grant",
+}
+
+// tableKeys 没有数值 id 主键的表按此列分批迁移：报告复核与报告 PDF 按测评迁移，
+// 照护团队分配的主键是 (team_id, testee_id)。
+var tableKeys = map[string]string{
+	"report_review":             "assessment_id",
+	"interpretation_report_pdf": "assessment_id",
+	careTeamTable:               "team_id",
+}
+
+var revertibleTables = func() map[string]struct{} {
+	tables := map[string]struct{}{relationTable: {}, careTeamTable: {}}
+	for _, table := range repointTables {
+		tables[table] = struct{}{}
+	}
+	return tables
+}()
+
+type mergeLogRepository struct {
+	mysql.BaseRepository[*MergeLogPO]
+}
+
+// NewMergeLogRepository 创建受试者合并仓储
+func NewMergeLogRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domainmerge.Repository {
+	return &mergeLogRepository{BaseRepository: mysql.NewBaseRepository[*MergeLogPO](db, opts...)}
+}
+
+const testeeColumns = "id, org_id, profile_id, name, gender, birthday, source, created_at, deleted_at"
+
+func (r *mergeLogRepository) GetTestee(ctx context.Context, orgID int64, testeeID uint64) (*domainmerge.TesteeSnapshot, error) {
+	var rows []testeeRow
+	if err := r.WithContext(ctx).Table("testee").Select(testeeColumns).
+		Where("id=? AND org_id=?", testeeID, orgID).Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	snapshot := testeeToSnapshot(rows[0])
+	return &snapshot, nil
+}
+
+// candidateJoin 召回条件与应用层判定（classify）保持一致：同一档案，或姓名相同且
+// 出生日期一致（性别一致或一方未知）/ 出生日期缺失（性别一致且已知）。
+const candidateJoin = `FROM testee a JOIN testee b ON b.org_id = a.org_id AND b.id > a.id AND b.deleted_at IS NULL AND (
+  (a.profile_id IS NOT NULL AND b.profile_id = a.profile_id)
+  OR (b.name = a.name AND (
+    (a.birthday IS NOT NULL AND b.birthday = a.birthday AND (a.gender = b.gender OR a.gender = 0 OR b.gender = 0))
+    OR ((a.birthday IS NULL OR b.birthday IS NULL) AND a.gender = b.gender AND a.gender <> 0))))
+WHERE a.org_id = ? AND a.deleted_at IS NULL`
+
+func (r *mergeLogRepository) ListCandidatePairs(ctx context.Context, orgID int64, testeeID uint64, offset, limit int) ([]domainmerge.CandidatePair, int64, error) {
+	where, args := candidateJoin, []any{orgID}
+	if testeeID != 0 {
+		where += " AND (a.id = ? OR b.id = ?)"
+		args = append(args, testeeID, testeeID)
+	}
+	var total int64
+	if err := r.WithContext(ctx).Raw("SELECT COUNT(*) "+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []domainmerge.CandidatePair{}, 0, nil
+	}
+	var rows []pairRow
+	query := `SELECT a.id AS a_id, a.profile_id AS a_profile_id, a.name AS a_name, a.gender AS a_gender, a.birthday AS a_birthday, a.source AS a_source, a.created_at AS a_created_at,
+  b.id AS b_id, b.profile_id AS b_profile_id, b.name AS b_name, b.gender AS b_gender, b.birthday AS b_birthday, b.source AS b_source, b.created_at AS b_created_at ` +
+		where + " ORDER BY a.id, b.id LIMIT ? OFFSET ?"
+	if err := r.WithContext(ctx).Raw(query, append(args, limit, offset)...).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	pairs := make([]domainmerge.CandidatePair, 0, len(rows))
+	for _, row := range rows {
+		pairs = append(pairs, pairToDomain(orgID, row))
+	}
+	return pairs, total, nil
+}
+
+func (r *mergeLogRepository) FindConflicts(ctx context.Context, orgID int64, survivorID, duplicateID uint64) ([]domainmerge.Conflict, error) {
+	db := r.WithContext(ctx)
+	var conflicts []domainmerge.Conflict
+
+	var profiles []testeeRow
+	if err := db.Table("testee").Select("id, profile_id").Where("id IN ? AND org_id=?", []uint64{survivorID, duplicateID}, orgID).Scan(&profiles).Error; err != nil {
+		return nil, err
+	}
+	if len(profiles) == 2 && profiles[0].ProfileID != nil && profiles[1].ProfileID != nil && *profiles[0].ProfileID != *profiles[1].ProfileID {
+		conflicts = append(conflicts, domainmerge.Conflict{Kind: domainmerge.ConflictProfile, Detail: "两者绑定了不同的用户档案"})
+	}
+
+	// 入组轮次与任务序号的唯一键都包含 plan_id + testee_id，参与过同一计划的两者无法直接合并。
+	var planIDs []uint64
+	if err := db.Raw(`SELECT plan_id FROM (
+  SELECT s.plan_id FROM plan_enrollment s JOIN plan_enrollment d ON d.org_id = s.org_id AND d.plan_id = s.plan_id AND d.testee_id = ?
+  WHERE s.org_id = ? AND s.testee_id = ?
+  UNION
+  SELECT s.plan_id FROM assessment_task s JOIN assessment_task d ON d.plan_id = s.plan_id AND d.seq = s.seq AND d.testee_id = ?
+  WHERE s.org_id = ? AND s.testee_id = ?
+) shared ORDER BY plan_id LIMIT ?`, duplicateID, orgID, survivorID, duplicateID, orgID, survivorID, conflictLimit).Scan(&planIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, planID := range planIDs {
+		conflicts = append(conflicts, domainmerge.Conflict{Kind: domainmerge.ConflictSharedPlan, Detail: fmt.Sprintf("两者均参与计划 %d，请先终止其中一方的参与", planID)})
+	}
+
+	var primaries []struct {
+		TesteeID    uint64
+		ClinicianID uint64
+	}
+	if err := db.Table(relationTable).Select("testee_id, clinician_id").
+		Where("org_id=? AND testee_id IN ? AND relation_type=? AND is_active=1 AND deleted_at IS NULL", orgID, []uint64{survivorID, duplicateID}, string(domainRelation.RelationTypePrimary)).
+		Scan(&primaries).Error; err != nil {
+		return nil, err
+	}
+	byTestee := map[uint64]uint64{}
+	for _, row := range primaries {
+		byTestee[row.TesteeID] = row.ClinicianID
+	}
+	if survivorPrimary, ok := byTestee[survivorID]; ok {
+		if duplicatePrimary, ok := byTestee[duplicateID]; ok && survivorPrimary != duplicatePrimary {
+			conflicts = append(conflicts, domainmerge.Conflict{Kind: domainmerge.ConflictPrimaryClinician, Detail: "两者的主责从业者不同，请先转移主责关系"})
+		}
+	}
+	return conflicts, nil
+}
+
+func (r *mergeLogRepository) ApplyMerge(ctx context.Context, log *domainmerge.MergeLog) error {
+	return r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		survivor, duplicate, err := lockTestees(tx, log.OrgID, log.SurvivorID, log.DuplicateID)
+		if err != nil {
+			return err
+		}
+		if survivor.DeletedAt != nil || duplicate.DeletedAt != nil {
+			return cberrors.WithCode(code.ErrConflict, "受试者状态已变化，请刷新后重试")
+		}
+
+		for _, table := range repointTables {
+			var ids []uint64
+			key := keyColumn(table)
+			if err := tx.Table(table).Where("testee_id=?", log.DuplicateID).Distinct(key).Order(key).Pluck(key, &ids).Error; err != nil {
+				return err
+			}
+			if err := moveRows(tx, table, ids, log.DuplicateID, log.SurvivorID); err != nil {
+				return err
+			}
+			log.Moved = appendMoved(log.Moved, table, ids)
+		}
+
+		var relationIDs []uint64
+		if err := tx.Raw(`SELECT d.id FROM clinician_relation d WHERE d.testee_id = ? AND NOT EXISTS (
+  SELECT 1 FROM clinician_relation s WHERE s.testee_id = ? AND s.org_id = d.org_id AND s.clinician_id = d.clinician_id
+    AND s.relation_type = d.relation_type AND s.is_active = d.is_active) ORDER BY d.id`, log.DuplicateID, log.SurvivorID).
+			Scan(&relationIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Table(relationTable).Where("testee_id=? AND id NOT IN ?", log.DuplicateID, append(relationIDs, 0)).
+			Order("id").Pluck("id", &log.SkippedRelationIDs).Error; err != nil {
+			return err
+		}
+		if err := moveRows(tx, relationTable, relationIDs, log.DuplicateID, log.SurvivorID); err != nil {
+			return err
+		}
+		log.Moved = appendMoved(log.Moved, relationTable, relationIDs)
+
+		// 保留受试者已在同一团队时，重复受试者的分配留在原处，团队访问不受影响。
+		var teamIDs []uint64
+		if err := tx.Raw(`SELECT d.team_id FROM care_team_testee d WHERE d.testee_id = ? AND NOT EXISTS (
+  SELECT 1 FROM care_team_testee s WHERE s.team_id = d.team_id AND s.testee_id = ?) ORDER BY d.team_id`, log.DuplicateID, log.SurvivorID).
+			Scan(&teamIDs).Error; err != nil {
+			return err
+		}
+		if err := moveRows(tx, careTeamTable, teamIDs, log.DuplicateID, log.SurvivorID); err != nil {
+			return err
+		}
+		log.Moved = appendMoved(log.Moved, careTeamTable, teamIDs)
+
+		duplicateUpdates := map[string]any{
+			"deleted_at": log.MergedAt,
+			"deleted_by": log.MergedBy,
+			"updated_by": log.MergedBy,
+			"version":    gorm.Expr("version + 1"),
+		}
+		if log.TransferredProfile != nil {
+			duplicateUpdates["profile_id"] = nil
+		}
+		if err := updateOne(tx.Table("testee").Where("id=? AND deleted_at IS NULL", log.DuplicateID), duplicateUpdates); err != nil {
+			return err
+		}
+		if log.TransferredProfile != nil {
+			if err := updateOne(tx.Table("testee").Where("id=? AND profile_id IS NULL", log.SurvivorID), map[string]any{
+				"profile_id": *log.TransferredProfile,
+				"updated_by": log.MergedBy,
+				"version":    gorm.Expr("version + 1"),
+			}); err != nil {
+				return err
+			}
+		}
+
+		po, err := mergeLogToPO(log)
+		if err != nil {
+			return err
+		}
+		return r.CreateAndSync(mysql.WithTx(ctx, tx), po, nil)
+	})
+}
+
+func (r *mergeLogRepository) ApplyRevert(ctx context.Context, log *domainmerge.MergeLog) error {
+	return r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		survivor, duplicate, err := lockTestees(tx, log.OrgID, log.SurvivorID, log.DuplicateID)
+		if err != nil {
+			return err
+		}
+		if survivor.DeletedAt != nil {
+			return cberrors.WithCode(code.ErrConflict, "保留受试者已被合并或删除，请先回滚后续合并")
+		}
+		if duplicate.DeletedAt == nil {
+			return cberrors.WithCode(code.ErrConflict, "重复受试者已恢复，无法再次回滚")
+		}
+
+		for _, record := range log.Moved {
+			if record.Store != domainmerge.RecordStoreMySQL {
+				continue
+			}
+			if _, ok := revertibleTables[record.Table]; !ok {
+				return fmt.Errorf("merge log %d references unsupported table %q", log.ID, record.Table)
+			}
+			ids, err := parseIDs(record.IDs)
+			if err != nil {
+				return err
+			}
+			if err := moveRows(tx, record.Table, ids, log.SurvivorID, log.DuplicateID); err != nil {
+				return err
+			}
+		}
+
+		duplicateUpdates := map[string]any{
+			"deleted_at": nil,
+			"deleted_by": 0,
+			"updated_by": log.RevertedBy,
+			"version":    gorm.Expr("version + 1"),
+		}
+		if log.TransferredProfile != nil {
+			duplicateUpdates["profile_id"] = *log.TransferredProfile
+			if err := tx.Table("testee").Where("id=? AND profile_id=?", log.SurvivorID, *log.TransferredProfile).Updates(map[string]any{
+				"profile_id": nil,
+				"updated_by": log.RevertedBy,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if err := updateOne(tx.Table("testee").Where("id=? AND deleted_at IS NOT NULL", log.DuplicateID), duplicateUpdates); err != nil {
+			return err
+		}
+		return updateOne(tx.Model(&MergeLogPO{}).Where("id=? AND org_id=? AND status=? AND deleted_at IS NULL", log.ID, log.OrgID, string(domainmerge.MergeStatusMerged)), map[string]any{
+			"status":        string(log.Status),
+			"updated_by":    log.RevertedBy,
+			"version":       gorm.Expr("version + 1"),
+			"reverted_by":   log.RevertedBy,
+			"reverted_at":   log.RevertedAt,
+			"revert_reason": log.RevertReason,
+		})
+	})
+}
+
+func (r *mergeLogRepository) GetLog(ctx context.Context, orgID int64, mergeID uint64) (*domainmerge.MergeLog, error) {
+	var po MergeLogPO
+	err := r.WithContext(ctx).Where("id=? AND org_id=? AND deleted_at IS NULL", mergeID, orgID).Take(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mergeLogToDomain(&po)
+}
+
+func (r *mergeLogRepository) ListLogs(ctx context.Context, orgID int64, testeeID uint64, offset, limit int) ([]domainmerge.MergeLog, int64, error) {
+	query := func() *gorm.DB {
+		db := r.WithContext(ctx).Model(&MergeLogPO{}).Where("org_id=? AND deleted_at IS NULL", orgID)
+		if testeeID != 0 {
+			db = db.Where("survivor_id=? OR duplicate_id=?", testeeID, testeeID)
+		}
+		return db
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var pos []MergeLogPO
+	if err := query().Order("merged_at DESC, id DESC").Offset(offset).Limit(limit).Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	logs := make([]domainmerge.MergeLog, 0, len(pos))
+	for i := range pos {
+		log, err := mergeLogToDomain(&pos[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, *log)
+	}
+	return logs, total, nil
+}
+
+func lockTestees(tx *gorm.DB, orgID int64, survivorID, duplicateID uint64) (*testeeRow, *testeeRow, error) {
+	var rows []testeeRow
+	if err := tx.Table("testee").Select(testeeColumns).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ? AND org_id=?", []uint64{survivorID, duplicateID}, orgID).Order("id").Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	var survivor, duplicate *testeeRow
+	for i := range rows {
+		switch rows[i].ID {
+		case survivorID:
+			survivor = &rows[i]
+		case duplicateID:
+			duplicate = &rows[i]
+		}
+	}
+	if survivor == nil || duplicate == nil {
+		return nil, nil, cberrors.WithCode(code.ErrUserNotFound, "testee not found")
+	}
+	return survivor, duplicate, nil
+}
+
+// moveRows 只迁移仍指向 from 的记录，回滚时已被后续操作改动的记录保持不变。
+func moveRows(tx *gorm.DB, table string, ids []uint64, from, to uint64) error {
+	key := keyColumn(table)
+	for start := 0; start < len(ids); start += updateChunkSize {
+		end := start + updateChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := tx.Table(table).Where(key+" IN ? AND testee_id=?", ids[start:end], from).
+			Update("testee_id", to).Error; err != nil {
+			return fmt.Errorf("repoint %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func keyColumn(table string) string {
+	if key, ok := tableKeys[table]; ok {
+		return key
+	}
+	return "id"
+}
+
+func updateOne(db *gorm.DB, updates map[string]any) error {
+	result := db.Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return cberrors.WithCode(code.ErrConflict, "受试者状态已变化，请刷新后重试")
+	}
+	return nil
+}
+
+func appendMoved(records []domainmerge.MovedRecords, table string, ids []uint64) []domainmerge.MovedRecords {
+	if len(ids) == 0 {
+		return records
+	}
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatUint(id, 10))
+	}
+	return append(records, domainmerge.MovedRecords{Store: domainmerge.RecordStoreMySQL, Table: table, IDs: values})
+}
+
+func parseIDs(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, value := range values {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moved record id %q: %w", value, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,278 @@
+package testeemerge
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	cberrors "github.com/FangcunMount/component-base/pkg/errors"
+	domainmerge "github.com/FangcunMount/qs-server/internal/apiserver/domain/actor/testeemerge"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/migration"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newMergeLogRepositoryTestDB(t *testing.T) (*mergeLogRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewMergeLogRepository(db).(*mergeLogRepository), mock
+}
+
+var lockedTesteeColumns = []string{"id", "org_id", "profile_id", "name", "gender", "birthday", "source", "created_at", "deleted_at"}
+
+func TestApplyRevertRequiresActiveSurvivor(t *testing.T) {
+	repo, mock := newMergeLogRepositoryTestDB(t)
+	deletedAt := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM `testee` WHERE id IN (?,?) AND org_id=? ORDER BY id FOR UPDATE")).
+		WithArgs(uint64(1), uint64(2), int64(7)).
+		WillReturnRows(sqlmock.NewRows(lockedTesteeColumns).
+			AddRow(1, 7, nil, "张三", 1, nil, "manual", deletedAt, deletedAt).
+			AddRow(2, 7, nil, "张三", 1, nil, "manual", deletedAt, deletedAt))
+	mock.ExpectRollback()
+
+	err := repo.ApplyRevert(context.Background(), &domainmerge.MergeLog{ID: 5, OrgID: 7, SurvivorID: 1, DuplicateID: 2})
+	if !cberrors.IsCode(err, code.ErrConflict) {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyRevertRejectsUnknownTable(t *testing.T) {
+	repo, mock := newMergeLogRepositoryTestDB(t)
+	deletedAt := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows(lockedTesteeColumns).
+			AddRow(1, 7, nil, "张三", 1, nil, "manual", deletedAt, nil).
+			AddRow(2, 7, nil, "张三", 1, nil, "manual", deletedAt, deletedAt))
+	mock.ExpectRollback()
+
+	err := repo.ApplyRevert(context.Background(), &domainmerge.MergeLog{
+		ID: 5, OrgID: 7, SurvivorID: 1, DuplicateID: 2,
+		Moved: []domainmerge.MovedRecords{{Store: domainmerge.RecordStoreMySQL, Table: "testee; DROP TABLE x", IDs: []string{"1"}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported table") {
+		t.Fatalf("err = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyRevertMovesReportReviewByAssessmentKey(t *testing.T) {
+	repo, mock := newMergeLogRepositoryTestDB(t)
+	deletedAt := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows(lockedTesteeColumns).
+			AddRow(1, 7, nil, "张三", 1, nil, "manual", deletedAt, nil).
+			AddRow(2, 7, nil, "张三", 1, nil, "manual", deletedAt, deletedAt))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `report_review` SET `testee_id`=? WHERE assessment_id IN (?) AND testee_id=?")).
+		WithArgs(uint64(2), uint64(501), uint64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `testee` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("`updated_by`=?,`version`=version + 1,`updated_at`=? WHERE id=? AND org_id=? AND status=? AND deleted_at IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.ApplyRevert(context.Background(), &domainmerge.MergeLog{
+		ID: 5, OrgID: 7, SurvivorID: 1, DuplicateID: 2, Status: domainmerge.MergeStatusReverted,
+		Moved: []domainmerge.MovedRecords{{Store: domainmerge.RecordStoreMySQL, Table: "report_review", IDs: []string{"501"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyMergeRepointsConsentAcceptanceAndBreakGlassGrant(t *testing.T) {
+	repo, mock := newMergeLogRepositoryTestDB(t)
+	createdAt := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows(lockedTesteeColumns).
+			AddRow(1, 7, nil, "张三", 1, nil, "manual", createdAt, nil).
+			AddRow(2, 7, nil, "张三", 1, nil, "manual", createdAt, nil))
+	moved := map[string]uint64{"consent_acceptance": 801, "break_glass_grant": 901}
+	for _, table := range repointTables {
+		rows := sqlmock.NewRows([]string{keyColumn(table)})
+		id, ok := moved[table]
+		if ok {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("FROM `" + table + "` WHERE testee_id=?")).
+			WithArgs(uint64(2)).WillReturnRows(rows)
+		if ok {
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE `"+table+"` SET `testee_id`=? WHERE id IN (?) AND testee_id=?")).
+				WithArgs(uint64(1), id, uint64(2)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+	}
+	// 关系迁移之后的步骤与本用例无关，在此中止并回滚
+	mock.ExpectQuery(regexp.QuoteMeta("FROM clinician_relation d")).WillReturnError(gorm.ErrInvalidDB)
+	mock.ExpectRollback()
+
+	log := &domainmerge.MergeLog{OrgID: 7, SurvivorID: 1, DuplicateID: 2}
+	if err := repo.ApplyMerge(context.Background(), log); err != gorm.ErrInvalidDB {
+		t.Fatalf("err = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	for _, record := range log.Moved {
+		got[record.Table] = record.IDs
+	}
+	if len(got) != 2 || got["consent_acceptance"][0] != "801" || got["break_glass_grant"][0] != "901" {
+		t.Fatalf("moved = %+v", log.Moved)
+	}
+}
+
+func TestListCandidatePairsSkipsPageQueryWhenEmpty(t *testing.T) {
+	repo, mock := newMergeLogRepositoryTestDB(t)
+	mock.ExpectQuery("(?s)SELECT COUNT\\(\\*\\) FROM testee a JOIN testee b.*AND \\(a.id = \\? OR b.id = \\?\\)").
+		WithArgs(int64(7), uint64(3), uint64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	pairs, total, err := repo.ListCandidatePairs(context.Background(), 7, 3, 0, 20)
+	if err != nil || total != 0 || len(pairs) != 0 {
+		t.Fatalf("pairs=%v total=%d err=%v", pairs, total, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeLogRoundTripsJSONColumns(t *testing.T) {
+	profile := uint64(900)
+	mergedAt := time.Date(2026, 5, 2, 8, 0, 0, 0, time.UTC)
+	log := &domainmerge.MergeLog{
+		ID: 5, OrgID: 7, SurvivorID: 1, DuplicateID: 2, Status: domainmerge.MergeStatusMerged, MergedBy: 42, MergedAt: mergedAt,
+		MatchReasons:       []domainmerge.MatchReason{domainmerge.MatchReasonIdentity},
+		Moved:              []domainmerge.MovedRecords{{Store: domainmerge.RecordStoreMySQL, Table: "assessment", IDs: []string{"11"}}},
+		SkippedRelationIDs: []uint64{31},
+		TransferredProfile: &profile,
+	}
+	po, err := mergeLogToPO(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if po.CreatedBy.Uint64() != 42 || !po.CreatedAt.Equal(mergedAt) {
+		t.Fatalf("audit fields = %+v, want merge operator and time", po.AuditFields)
+	}
+	decoded, err := mergeLogToDomain(po)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Moved) != 1 || decoded.Moved[0].IDs[0] != "11" || decoded.SkippedRelationIDs[0] != 31 ||
+		decoded.MatchReasons[0] != domainmerge.MatchReasonIdentity || *decoded.TransferredProfile != 900 {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+}
+
+func TestTesteeMergeMigrationDefinesRevertibleLog(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000071_add_testee_merge_log.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"CREATE TABLE `testee_merge_log`",
+		"`moved_records` JSON NOT NULL",
+		"`skipped_relation_ids` JSON",
+		"`transferred_profile_id`",
+		"idx_testee_org_name_deleted",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+	down, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000071_add_testee_merge_log.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{"DROP TABLE IF EXISTS `testee_merge_log`", "DROP INDEX `idx_testee_org_name_deleted`"} {
+		if !strings.Contains(string(down), token) {
+			t.Fatalf("down migration does not contain %q", token)
+		}
+	}
+}
+
+func TestTesteeMergeLogMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000088_add_testee_merge_log_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ADD COLUMN `created_at`",
+		"ADD COLUMN `deleted_at`",
+		"ADD COLUMN `created_by`",
+		"ADD COLUMN `updated_by`",
+		"ADD COLUMN `deleted_by`",
+		"ADD COLUMN `version`",
+		"`created_by` = `merged_by`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
+
+// notRepointedTables 带 testee_id 但合并时有意留在重复受试者上的表及原因。
+var notRepointedTables = map[string]string{
+	"access_audit_log":         "审计链记录访问当时的对象，不可改写",
+	"data_subject_request":     "数据主体请求针对原受试者",
+	"data_subject_certificate": "擦除证书针对原受试者",
+	"testee_import_row":        "导入明细保留原始来源行",
+}
+
+// 新增带 testee_id 的表必须决定合并时是否迁移，否则合并会把受试者的历史拆在两个档案上。
+func TestEveryTesteeKeyedTableIsRepointedOrExempt(t *testing.T) {
+	tables, err := migration.MySQLTablesWithColumn("testee_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := map[string]bool{}
+	for _, table := range tables {
+		schema[table] = true
+	}
+	registered := map[string]bool{}
+	for table := range revertibleTables {
+		registered[table] = true
+	}
+	for table := range registered {
+		if !schema[table] {
+			t.Errorf("repointed table %s has no testee_id column in the current schema", table)
+		}
+		if _, ok := notRepointedTables[table]; ok {
+			t.Errorf("table %s is both repointed and exempt", table)
+		}
+	}
+	for table := range notRepointedTables {
+		if !schema[table] {
+			t.Errorf("exempt table %s has no testee_id column in the current schema", table)
+		}
+	}
+	for _, table := range tables {
+		if _, ok := notRepointedTables[table]; !ok && !registered[table] {
+			t.Errorf("table %s has testee_id but is not repointed on merge; add it to repointTables or notRepointedTables", table)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package testeemerge
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// MergeLogPO 合并记录持久化对象
+type MergeLogPO struct {
+	mysql.AuditFields
+
+	OrgID                int64      `gorm:"column:org_id;not null"`
+	SurvivorID           uint64     `gorm:"column:survivor_id;not null"`
+	DuplicateID          uint64     `gorm:"column:duplicate_id;not null"`
+	Status               string     `gorm:"column:status;size:16;not null"`
+	MatchReasons         []byte     `gorm:"column:match_reasons;type:json"`
+	Reason               string     `gorm:"column:reason;size:500;not null;default:''"`
+	MovedRecords         []byte     `gorm:"column:moved_records;type:json;not null"`
+	SkippedRelationIDs   []byte     `gorm:"column:skipped_relation_ids;type:json"`
+	TransferredProfileID *uint64    `gorm:"column:transferred_profile_id"`
+	MergedBy             uint64     `gorm:"column:merged_by;not null;default:0"`
+	MergedAt             time.Time  `gorm:"column:merged_at;not null"`
+	RevertedBy           uint64     `gorm:"column:reverted_by;not null;default:0"`
+	RevertedAt           *time.Time `gorm:"column:reverted_at"`
+	RevertReason         string     `gorm:"column:revert_reason;size:500;not null;default:''"`
+}
+
+// TableName 指定表名
+func (MergeLogPO) TableName() string { return "testee_merge_log" }
+
+// BeforeCreate GORM hook：合并记录的创建人与创建时间即合并操作人与合并时间。
+func (p *MergeLogPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// testeeRow 合并读取与加锁的受试者列；受试者由 actor 仓储持久化，这里只做投影。
+type testeeRow struct {
+	ID        uint64
+	OrgID     int64
+	ProfileID *uint64
+	Name      string
+	Gender    int8
+	Birthday  *time.Time
+	Source    string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// pairRow 候选对查询的扁平投影。
+type pairRow struct {
+	AID        uint64
+	AProfileID *uint64
+	AName      string
+	AGender    int8
+	ABirthday  *time.Time
+	ASource    string
+	ACreatedAt time.Time
+	BID        uint64
+	BProfileID *uint64
+	BName      string
+	BGender    int8
+	BBirthday  *time.Time
+	BSource    string
+	BCreatedAt time.Time
+}
@@ -0,0 +1,176 @@
+// Package workbenchtriage 工作台队列分诊状态的 MySQL 仓储，以及各队列读模型共用的分诊过滤条件。
+package workbenchtriage
+
+import (
+	"context"
+	"time"
+
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// escalationCandidatesSQL 每个受试者最新一次已完成测评为高/严重风险、进入队列已超过认领时限，
+// 且没有认领、解决、生效中的暂缓或升级记录。
+const escalationCandidatesSQL = `
+SELECT
+	a.id AS assessment_id,
+	a.org_id,
+	a.testee_id,
+	COALESCE(a.evaluated_at, a.updated_at, a.created_at) AS occurred_at
+FROM assessment a
+WHERE a.status = 'evaluated'
+	AND a.deleted_at IS NULL
+	AND a.risk_level IN ('high', 'severe')
+	AND COALESCE(a.evaluated_at, a.updated_at, a.created_at) <= ?
+	AND a.id = (
+		SELECT MAX(latest.id)
+		FROM assessment latest
+		WHERE latest.org_id = a.org_id
+			AND latest.testee_id = a.testee_id
+			AND latest.status = 'evaluated'
+			AND latest.deleted_at IS NULL
+			AND latest.risk_level IS NOT NULL
+			AND latest.risk_level <> ''
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM workbench_triage_item wt
+		WHERE wt.org_id = a.org_id
+			AND wt.queue_type = 'high_risk'
+			AND wt.subject_id = a.id
+			AND (wt.escalated_at IS NOT NULL
+				OR wt.status IN ('claimed', 'resolved')
+				OR (wt.status = 'snoozed' AND wt.snoozed_until > ?))
+	)
+ORDER BY a.id ASC
+LIMIT ?
+`
+
+// itemRepository 分诊状态与历史仓储。条目按 version 乐观锁更新，历史只追加。
+type itemRepository struct {
+	mysql.BaseRepository[*ItemPO]
+}
+
+// NewItemRepository 创建分诊仓储
+func NewItemRepository(db *gorm.DB, opts ...mysql.BaseRepositoryOptions) domaintriage.Repository {
+	return &itemRepository{BaseRepository: mysql.NewBaseRepository[*ItemPO](db, opts...)}
+}
+
+func (r *itemRepository) FindTriageItem(ctx context.Context, orgID int64, queueType domaintriage.QueueType, subjectID uint64) (*domaintriage.Item, error) {
+	var pos []ItemPO
+	if err := r.WithContext(ctx).
+		Where("org_id=? AND queue_type=? AND subject_id=? AND deleted_at IS NULL", orgID, string(queueType), subjectID).
+		Limit(1).
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	if len(pos) == 0 {
+		return nil, nil
+	}
+	return itemToDomain(&pos[0]), nil
+}
+
+func (r *itemRepository) ListTriageItems(ctx context.Context, orgID int64, queueType domaintriage.QueueType, subjectIDs []uint64) ([]domaintriage.Item, error) {
+	if len(subjectIDs) == 0 {
+		return []domaintriage.Item{}, nil
+	}
+	var pos []ItemPO
+	if err := r.WithContext(ctx).
+		Where("org_id=? AND queue_type=? AND subject_id IN ? AND deleted_at IS NULL", orgID, string(queueType), subjectIDs).
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	items := make([]domaintriage.Item, 0, len(pos))
+	for i := range pos {
+		items = append(items, *itemToDomain(&pos[i]))
+	}
+	return items, nil
+}
+
+func (r *itemRepository) ListTriageEvents(ctx context.Context, orgID int64, itemID uint64) ([]domaintriage.Event, error) {
+	var pos []EventPO
+	if err := r.WithContext(ctx).
+		Where("org_id=? AND item_id=? AND deleted_at IS NULL", orgID, itemID).
+		Order("occurred_at ASC, id ASC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+	events := make([]domaintriage.Event, 0, len(pos))
+	for i := range pos {
+		events = append(events, eventToDomain(&pos[i]))
+	}
+	return events, nil
+}
+
+func (r *itemRepository) SaveTriageItem(ctx context.Context, item *domaintriage.Item, expectedVersion int, event *domaintriage.Event) (bool, error) {
+	po := itemToPO(item)
+	if event.Operator != nil {
+		po.UpdatedBy = meta.ID(event.Operator.UserID)
+		if expectedVersion == 0 {
+			po.CreatedBy = po.UpdatedBy
+		}
+	}
+	eventRow := eventToPO(event)
+	saved := false
+	err := r.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		if expectedVersion == 0 {
+			result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(po)
+		} else {
+			result = tx.Model(&ItemPO{}).
+				Where("id=? AND version=?", po.ID, expectedVersion).
+				Updates(itemUpdates(po))
+		}
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		saved = true
+		return tx.Create(eventRow).Error
+	})
+	return saved, err
+}
+
+func (r *itemRepository) FindSubjectTesteeID(ctx context.Context, orgID int64, queueType domaintriage.QueueType, subjectID uint64) (uint64, error) {
+	var (
+		table  string
+		column = "testee_id"
+	)
+	switch queueType {
+	case domaintriage.QueueTypeHighRisk, domaintriage.QueueTypeAwaitingReview:
+		table = "assessment"
+	case domaintriage.QueueTypeFollowUp:
+		table = "assessment_task"
+	case domaintriage.QueueTypeKeyFocus:
+		table, column = "testee", "id"
+	case domaintriage.QueueTypeCriticalItem:
+		table = "critical_item_flag"
+	default:
+		return 0, nil
+	}
+	var testeeIDs []uint64
+	err := r.WithContext(ctx).Table(table).
+		Where("id=? AND org_id=? AND deleted_at IS NULL", subjectID, orgID).
+		Limit(1).
+		Pluck(column, &testeeIDs).Error
+	if err != nil || len(testeeIDs) == 0 {
+		return 0, err
+	}
+	return testeeIDs[0], nil
+}
+
+// ListEscalationCandidates 跨机构扫描超过认领时限的高风险条目，按测评 ID 顺序分批返回。
+func (r *itemRepository) ListEscalationCandidates(ctx context.Context, dueBefore, now time.Time, limit int) ([]domaintriage.EscalationCandidate, error) {
+	var rows []candidateRow
+	if err := r.WithContext(ctx).Raw(escalationCandidatesSQL, dueBefore, now, limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	candidates := make([]domaintriage.EscalationCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, domaintriage.EscalationCandidate{
+			OrgID: row.OrgID, AssessmentID: row.AssessmentID, TesteeID: row.TesteeID, OccurredAt: row.OccurredAt,
+		})
+	}
+	return candidates, nil
+}
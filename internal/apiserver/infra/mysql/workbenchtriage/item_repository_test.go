@@ -0,0 +1,141 @@
+package workbenchtriage
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newItemRepositoryTestDB(t *testing.T) (domaintriage.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewItemRepository(db), mock
+}
+
+func TestQueuePredicateByView(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	if sql, args := QueuePredicate(workbenchreadmodel.TriageFilter{}, 9, "a.id"); sql != "" || args != nil {
+		t.Fatalf("disabled filter = %q, %v", sql, args)
+	}
+	cases := map[workbenchreadmodel.TriageView]string{
+		workbenchreadmodel.TriageViewActive:    "NOT EXISTS (SELECT 1 FROM workbench_triage_item wt WHERE wt.org_id = ? AND wt.queue_type = ? AND wt.subject_id = a.id AND (wt.status = 'resolved' OR (wt.status = 'snoozed' AND wt.snoozed_until > ?)))",
+		workbenchreadmodel.TriageViewOpen:      "NOT EXISTS (SELECT 1 FROM workbench_triage_item wt WHERE wt.org_id = ? AND wt.queue_type = ? AND wt.subject_id = a.id AND (wt.status IN ('claimed', 'resolved') OR (wt.status = 'snoozed' AND wt.snoozed_until > ?)))",
+		workbenchreadmodel.TriageViewClaimed:   "EXISTS (SELECT 1 FROM workbench_triage_item wt WHERE wt.org_id = ? AND wt.queue_type = ? AND wt.subject_id = a.id AND wt.status = 'claimed')",
+		workbenchreadmodel.TriageViewEscalated: "EXISTS (SELECT 1 FROM workbench_triage_item wt WHERE wt.org_id = ? AND wt.queue_type = ? AND wt.subject_id = a.id AND wt.escalated_at IS NOT NULL AND (wt.status = 'open' OR (wt.status = 'snoozed' AND wt.snoozed_until <= ?)))",
+	}
+	for view, want := range cases {
+		sql, args := QueuePredicate(workbenchreadmodel.TriageFilter{QueueType: "high_risk", View: view, Now: now}, 9, "a.id")
+		if sql != want {
+			t.Fatalf("%s predicate = %q, want %q", view, sql, want)
+		}
+		if args[0] != int64(9) || args[1] != "high_risk" {
+			t.Fatalf("%s args = %#v", view, args)
+		}
+		if strings.Contains(want, "?)") && args[len(args)-1] != now {
+			t.Fatalf("%s args = %#v, want snooze cutoff", view, args)
+		}
+	}
+}
+
+func TestSaveTriageItemRejectsStaleVersion(t *testing.T) {
+	repo, mock := newItemRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `workbench_triage_item` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	saved, err := repo.SaveTriageItem(context.Background(),
+		&domaintriage.Item{ID: 11, OrgID: 9, QueueType: domaintriage.QueueTypeHighRisk, SubjectID: 101, Status: domaintriage.StatusClaimed, Version: 3, UpdatedAt: at},
+		2,
+		&domaintriage.Event{ID: 12, OrgID: 9, ItemID: 11, Action: domaintriage.ActionClaim, OccurredAt: at})
+	if err != nil || saved {
+		t.Fatalf("SaveTriageItem() = %v, %v; want version conflict", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListEscalationCandidatesSkipsHandledItems(t *testing.T) {
+	repo, mock := newItemRepositoryTestDB(t)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	dueBefore := now.Add(-4 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("wt.escalated_at IS NOT NULL")).
+		WithArgs(dueBefore, now, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"assessment_id", "org_id", "testee_id", "occurred_at"}).
+			AddRow(101, 9, 1, dueBefore.Add(-time.Hour)))
+
+	candidates, err := repo.ListEscalationCandidates(context.Background(), dueBefore, now, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 1 || candidates[0].AssessmentID != 101 || candidates[0].OrgID != 9 {
+		t.Fatalf("candidates = %#v", candidates)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveTriageItemCreatesItemWithOperatorAudit(t *testing.T) {
+	repo, mock := newItemRepositoryTestDB(t)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `workbench_triage_item` (`created_at`,`updated_at`,`deleted_at`,`created_by`,`updated_by`,`deleted_by`,`version`,`org_id`,`queue_type`,`subject_id`,")).
+		WithArgs(at, at, nil, int64(900), int64(900), int64(0), uint32(1),
+			int64(9), "high_risk", uint64(101), uint64(1), "claimed", int64(900), uint64(301), "王医生", &at, nil,
+			"", "", int64(0), uint64(0), "", nil, nil, int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `workbench_triage_event`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	operator := &domaintriage.Actor{UserID: 900, ClinicianID: 301, Name: "王医生"}
+	saved, err := repo.SaveTriageItem(context.Background(),
+		&domaintriage.Item{ID: 11, OrgID: 9, QueueType: domaintriage.QueueTypeHighRisk, SubjectID: 101, TesteeID: 1,
+			Status: domaintriage.StatusClaimed, ClaimedBy: operator, ClaimedAt: &at, Version: 1, CreatedAt: at, UpdatedAt: at},
+		0,
+		&domaintriage.Event{ID: 12, OrgID: 9, ItemID: 11, Action: domaintriage.ActionClaim, Operator: operator, OccurredAt: at})
+	if err != nil || !saved {
+		t.Fatalf("SaveTriageItem() = %v, %v", saved, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkbenchTriageMigrationAddsAuditFields(t *testing.T) {
+	up, err := os.ReadFile("../../../../pkg/migration/migrations/mysql/000095_add_workbench_triage_audit_fields.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{
+		"ALTER TABLE `workbench_triage_item`",
+		"ALTER TABLE `workbench_triage_event`",
+		"MODIFY COLUMN `version` INT UNSIGNED",
+		"ADD COLUMN `deleted_at`",
+		"`created_by` = `operator_user_id`",
+	} {
+		if !strings.Contains(string(up), token) {
+			t.Fatalf("migration does not contain %q", token)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package workbenchtriage
+
+import (
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+)
+
+func itemToPO(item *domaintriage.Item) *ItemPO {
+	po := &ItemPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(item.ID), CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, Version: uint32(item.Version),
+		},
+		OrgID: item.OrgID, QueueType: string(item.QueueType), SubjectID: item.SubjectID, TesteeID: item.TesteeID,
+		Status: string(item.Status), ClaimedAt: item.ClaimedAt, SnoozedUntil: item.SnoozedUntil,
+		ResolutionCode: string(item.ResolutionCode), ResolutionNote: item.ResolutionNote, ResolvedAt: item.ResolvedAt,
+		EscalatedAt: item.EscalatedAt,
+	}
+	if item.ClaimedBy != nil {
+		po.ClaimedByUserID = item.ClaimedBy.UserID
+		po.ClaimedByClinicianID = item.ClaimedBy.ClinicianID
+		po.ClaimedByName = item.ClaimedBy.Name
+	}
+	if item.ResolvedBy != nil {
+		po.ResolvedByUserID = item.ResolvedBy.UserID
+		po.ResolvedByClinicianID = item.ResolvedBy.ClinicianID
+		po.ResolvedByName = item.ResolvedBy.Name
+	}
+	return po
+}
+
+func itemToDomain(po *ItemPO) *domaintriage.Item {
+	item := &domaintriage.Item{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, QueueType: domaintriage.QueueType(po.QueueType), SubjectID: po.SubjectID, TesteeID: po.TesteeID,
+		Status: domaintriage.Status(po.Status), ClaimedAt: po.ClaimedAt, SnoozedUntil: po.SnoozedUntil,
+		ResolutionCode: domaintriage.ResolutionCode(po.ResolutionCode), ResolutionNote: po.ResolutionNote, ResolvedAt: po.ResolvedAt,
+		EscalatedAt: po.EscalatedAt, Version: int(po.Version), CreatedAt: po.CreatedAt, UpdatedAt: po.UpdatedAt,
+	}
+	if po.ClaimedByUserID != 0 {
+		item.ClaimedBy = &domaintriage.Actor{UserID: po.ClaimedByUserID, ClinicianID: po.ClaimedByClinicianID, Name: po.ClaimedByName}
+	}
+	if po.ResolvedByUserID != 0 {
+		item.ResolvedBy = &domaintriage.Actor{UserID: po.ResolvedByUserID, ClinicianID: po.ResolvedByClinicianID, Name: po.ResolvedByName}
+	}
+	return item
+}
+
+// itemUpdates 乐观锁更新时写入的列。
+func itemUpdates(po *ItemPO) map[string]interface{} {
+	return map[string]interface{}{
+		"status":                   po.Status,
+		"claimed_by_user_id":       po.ClaimedByUserID,
+		"claimed_by_clinician_id":  po.ClaimedByClinicianID,
+		"claimed_by_name":          po.ClaimedByName,
+		"claimed_at":               po.ClaimedAt,
+		"snoozed_until":            po.SnoozedUntil,
+		"resolution_code":          po.ResolutionCode,
+		"resolution_note":          po.ResolutionNote,
+		"resolved_by_user_id":      po.ResolvedByUserID,
+		"resolved_by_clinician_id": po.ResolvedByClinicianID,
+		"resolved_by_name":         po.ResolvedByName,
+		"resolved_at":              po.ResolvedAt,
+		"escalated_at":             po.EscalatedAt,
+		"version":                  po.Version,
+		"updated_by":               po.UpdatedBy,
+		"updated_at":               po.UpdatedAt,
+	}
+}
+
+func eventToPO(event *domaintriage.Event) *EventPO {
+	po := &EventPO{
+		AuditFields: mysql.AuditFields{
+			ID: meta.FromUint64(event.ID), CreatedAt: event.OccurredAt, UpdatedAt: event.OccurredAt,
+		},
+		OrgID: event.OrgID, ItemID: event.ItemID, Action: string(event.Action),
+		FromStatus: string(event.FromStatus), ToStatus: string(event.ToStatus),
+		ReasonCode: event.ReasonCode, Note: event.Note, SnoozedUntil: event.SnoozedUntil, OccurredAt: event.OccurredAt,
+	}
+	if event.Operator != nil {
+		po.OperatorUserID = event.Operator.UserID
+		po.OperatorClinicianID = event.Operator.ClinicianID
+		po.OperatorName = event.Operator.Name
+		po.CreatedBy = meta.ID(event.Operator.UserID)
+		po.UpdatedBy = meta.ID(event.Operator.UserID)
+	}
+	return po
+}
+
+func eventToDomain(po *EventPO) domaintriage.Event {
+	event := domaintriage.Event{
+		ID: po.ID.Uint64(), OrgID: po.OrgID, ItemID: po.ItemID, Action: domaintriage.Action(po.Action),
+		FromStatus: domaintriage.Status(po.FromStatus), ToStatus: domaintriage.Status(po.ToStatus),
+		ReasonCode: po.ReasonCode, Note: po.Note, SnoozedUntil: po.SnoozedUntil, OccurredAt: po.OccurredAt,
+	}
+	if po.OperatorUserID != 0 {
+		event.Operator = &domaintriage.Actor{UserID: po.OperatorUserID, ClinicianID: po.OperatorClinicianID, Name: po.OperatorName}
+	}
+	return event
+}
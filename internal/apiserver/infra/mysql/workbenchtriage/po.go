@@ -0,0 +1,86 @@
+package workbenchtriage
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/database/mysql"
+	"github.com/FangcunMount/qs-server/internal/pkg/meta"
+	"gorm.io/gorm"
+)
+
+// ItemPO 队列条目分诊状态持久化对象；通用审计列中的 version 即分诊乐观锁版本。
+type ItemPO struct {
+	mysql.AuditFields
+
+	OrgID                 int64      `gorm:"column:org_id;not null"`
+	QueueType             string     `gorm:"column:queue_type;size:32;not null"`
+	SubjectID             uint64     `gorm:"column:subject_id;not null"`
+	TesteeID              uint64     `gorm:"column:testee_id;not null"`
+	Status                string     `gorm:"column:status;size:16;not null"`
+	ClaimedByUserID       int64      `gorm:"column:claimed_by_user_id;not null;default:0"`
+	ClaimedByClinicianID  uint64     `gorm:"column:claimed_by_clinician_id;not null;default:0"`
+	ClaimedByName         string     `gorm:"column:claimed_by_name;size:100;not null;default:''"`
+	ClaimedAt             *time.Time `gorm:"column:claimed_at"`
+	SnoozedUntil          *time.Time `gorm:"column:snoozed_until"`
+	ResolutionCode        string     `gorm:"column:resolution_code;size:32;not null;default:''"`
+	ResolutionNote        string     `gorm:"column:resolution_note;size:1000;not null;default:''"`
+	ResolvedByUserID      int64      `gorm:"column:resolved_by_user_id;not null;default:0"`
+	ResolvedByClinicianID uint64     `gorm:"column:resolved_by_clinician_id;not null;default:0"`
+	ResolvedByName        string     `gorm:"column:resolved_by_name;size:100;not null;default:''"`
+	ResolvedAt            *time.Time `gorm:"column:resolved_at"`
+	EscalatedAt           *time.Time `gorm:"column:escalated_at"`
+}
+
+// TableName 指定表名
+func (ItemPO) TableName() string { return "workbench_triage_item" }
+
+// BeforeCreate GORM hook：条目的创建与更新时间、版本由应用层给出。
+func (p *ItemPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// EventPO 分诊历史持久化对象；历史只追加。
+type EventPO struct {
+	mysql.AuditFields
+
+	OrgID               int64      `gorm:"column:org_id;not null"`
+	ItemID              uint64     `gorm:"column:item_id;not null"`
+	Action              string     `gorm:"column:action;size:16;not null"`
+	FromStatus          string     `gorm:"column:from_status;size:16;not null"`
+	ToStatus            string     `gorm:"column:to_status;size:16;not null"`
+	OperatorUserID      int64      `gorm:"column:operator_user_id;not null;default:0"`
+	OperatorClinicianID uint64     `gorm:"column:operator_clinician_id;not null;default:0"`
+	OperatorName        string     `gorm:"column:operator_name;size:100;not null;default:''"`
+	ReasonCode          string     `gorm:"column:reason_code;size:32;not null;default:''"`
+	Note                string     `gorm:"column:note;size:1000;not null;default:''"`
+	SnoozedUntil        *time.Time `gorm:"column:snoozed_until"`
+	OccurredAt          time.Time  `gorm:"column:occurred_at;not null"`
+}
+
+// TableName 指定表名
+func (EventPO) TableName() string { return "workbench_triage_event" }
+
+// BeforeCreate GORM hook：历史的创建人与创建时间即操作人与发生时间。
+func (p *EventPO) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == 0 {
+		p.ID = meta.New()
+	}
+	if p.Version == 0 {
+		p.Version = mysql.InitialVersion
+	}
+	return nil
+}
+
+// candidateRow 升级候选条目的投影。
+type candidateRow struct {
+	AssessmentID uint64
+	OrgID        int64
+	TesteeID     uint64
+	OccurredAt   time.Time
+}
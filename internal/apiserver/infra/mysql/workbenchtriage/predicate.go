@@ -0,0 +1,64 @@
+package workbenchtriage
+
+import (
+	domaintriage "github.com/FangcunMount/qs-server/internal/apiserver/domain/workbench/triage"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
+)
+
+// 分诊条件里的状态取值。
+const (
+	statusOpen     = string(domaintriage.StatusOpen)
+	statusClaimed  = string(domaintriage.StatusClaimed)
+	statusSnoozed  = string(domaintriage.StatusSnoozed)
+	statusResolved = string(domaintriage.StatusResolved)
+)
+
+const (
+	activeSnoozeCondition = "(wt.status = '" + statusSnoozed + "' AND wt.snoozed_until > ?)"
+	wokenSnoozeCondition  = "(wt.status = '" + statusSnoozed + "' AND wt.snoozed_until <= ?)"
+)
+
+// QueuePredicate 返回队列查询 WHERE 子句中的分诊状态条件（EXISTS 子查询）及其参数。
+// subjectColumn 为外层查询中队列条目主体 ID 的列；过滤未启用时返回空串。
+func QueuePredicate(filter workbenchreadmodel.TriageFilter, orgID int64, subjectColumn string) (string, []interface{}) {
+	if !filter.Enabled() {
+		return "", nil
+	}
+	var (
+		exists    = true
+		condition string
+		args      []interface{}
+	)
+	switch filter.View {
+	case workbenchreadmodel.TriageViewActive:
+		exists = false
+		condition = "(wt.status = '" + statusResolved + "' OR " + activeSnoozeCondition + ")"
+		args = []interface{}{filter.Now}
+	case workbenchreadmodel.TriageViewOpen:
+		exists = false
+		condition = "(wt.status IN ('" + statusClaimed + "', '" + statusResolved + "') OR " + activeSnoozeCondition + ")"
+		args = []interface{}{filter.Now}
+	case workbenchreadmodel.TriageViewClaimed:
+		condition = "wt.status = '" + statusClaimed + "'"
+	case workbenchreadmodel.TriageViewSnoozed:
+		condition = activeSnoozeCondition
+		args = []interface{}{filter.Now}
+	case workbenchreadmodel.TriageViewResolved:
+		condition = "wt.status = '" + statusResolved + "'"
+	case workbenchreadmodel.TriageViewHandled:
+		condition = "(wt.status = '" + statusResolved + "' OR " + activeSnoozeCondition + ")"
+		args = []interface{}{filter.Now}
+	case workbenchreadmodel.TriageViewEscalated:
+		condition = "wt.escalated_at IS NOT NULL AND (wt.status = '" + statusOpen + "' OR " + wokenSnoozeCondition + ")"
+		args = []interface{}{filter.Now}
+	default:
+		return "", nil
+	}
+	operator := "EXISTS"
+	if !exists {
+		operator = "NOT EXISTS"
+	}
+	sql := operator + " (SELECT 1 FROM workbench_triage_item wt WHERE wt.org_id = ? AND wt.queue_type = ? AND wt.subject_id = " +
+		subjectColumn + " AND " + condition + ")"
+	return sql, append([]interface{}{orgID, filter.QueueType}, args...)
+}
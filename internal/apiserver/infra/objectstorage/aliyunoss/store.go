@@ -20,7 +20,10 @@ type publicObjectStore struct {
 	cacheControl string
 }
 
-var _ objectstorageport.ObjectStore = (*publicObjectStore)(nil)
+var (
+	_ objectstorageport.ObjectStore   = (*publicObjectStore)(nil)
+	_ objectstorageport.PrefixDeleter = (*publicObjectStore)(nil)
+)
 
 // NewObjectStore creates an OSS-backed object store. Object visibility is
 // controlled by the HTTP proxy that consumes the store, rather than OSS ACLs.
@@ -150,6 +153,51 @@ func (s *publicObjectStore) Get(ctx context.Context, key string) (*objectstorage
 	}, nil
 }
 
+// DeletePrefix lists and deletes every object under prefix. Objects already
+// removed by a previous attempt simply no longer appear in the listing.
+func (s *publicObjectStore) DeletePrefix(ctx context.Context, prefix string) (int64, error) {
+	objectPrefix, err := normalizeObjectKey(prefix)
+	if err != nil {
+		return 0, err
+	}
+	objectPrefix += "/"
+
+	var deleted int64
+	var token *string
+	for {
+		result, err := s.client.ListObjectsV2(ctx, &alioss.ListObjectsV2Request{
+			Bucket:            alioss.Ptr(s.bucket),
+			Prefix:            alioss.Ptr(objectPrefix),
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("list objects under %q: %w", objectPrefix, err)
+		}
+		for _, object := range result.Contents {
+			if object.Key == nil {
+				continue
+			}
+			if _, err := s.client.DeleteObject(ctx, &alioss.DeleteObjectRequest{
+				Bucket: alioss.Ptr(s.bucket),
+				Key:    object.Key,
+			}); err != nil && !isObjectNotFound(err) {
+				logger.L(ctx).Errorw("delete object from oss failed",
+					"action", "delete_object_oss",
+					"bucket", s.bucket,
+					"object_key", *object.Key,
+					"error", err.Error(),
+				)
+				return deleted, fmt.Errorf("delete object %q from oss: %w", *object.Key, err)
+			}
+			deleted++
+		}
+		if !result.IsTruncated || result.NextContinuationToken == nil {
+			return deleted, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
 func isObjectNotFound(err error) bool {
 	var serviceErr *alioss.ServiceError
 	if errors.As(err, &serviceErr) {
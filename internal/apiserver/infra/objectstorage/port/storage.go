@@ -22,3 +22,9 @@ type ObjectStore interface {
 	Put(ctx context.Context, key string, contentType string, body []byte) error
 	Get(ctx context.Context, key string) (*ObjectReader, error)
 }
+
+// PrefixDeleter removes every object under a key prefix. It is optional so
+// that read/write-only stores keep satisfying ObjectStore.
+type PrefixDeleter interface {
+	DeletePrefix(ctx context.Context, prefix string) (int64, error)
+}
@@ -0,0 +1,40 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	reportPDFApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	objectstorageport "github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage/port"
+)
+
+// ReportPDFStore adapts the shared OSS ObjectStore to the report PDF port.
+type ReportPDFStore struct {
+	store objectstorageport.ObjectStore
+}
+
+var _ reportPDFApp.ObjectStore = (*ReportPDFStore)(nil)
+
+// NewReportPDFStore returns nil when store is nil; report PDFs are then not rendered.
+func NewReportPDFStore(store objectstorageport.ObjectStore) reportPDFApp.ObjectStore {
+	if store == nil {
+		return nil
+	}
+	return &ReportPDFStore{store: store}
+}
+
+func (s *ReportPDFStore) Put(ctx context.Context, key, contentType string, body []byte) error {
+	return s.store.Put(ctx, key, contentType, body)
+}
+
+func (s *ReportPDFStore) Open(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	reader, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if reader == nil || reader.Body == nil {
+		return nil, 0, fmt.Errorf("object %q: %w", key, objectstorageport.ErrObjectNotFound)
+	}
+	return reader.Body, reader.ContentLength, nil
+}
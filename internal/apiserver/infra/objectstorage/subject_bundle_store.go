@@ -0,0 +1,52 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	subjectApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	objectstorageport "github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage/port"
+)
+
+// SubjectBundleStore adapts the shared OSS ObjectStore to the data-subject
+// bundle port. Erasure needs prefix deletion, so the store must also
+// implement PrefixDeleter.
+type SubjectBundleStore struct {
+	store   objectstorageport.ObjectStore
+	deleter objectstorageport.PrefixDeleter
+}
+
+var _ subjectApp.BundleStore = (*SubjectBundleStore)(nil)
+
+// NewSubjectBundleStore returns nil when store is nil or cannot delete by
+// prefix; the service then builds bundles on download instead of storing them.
+func NewSubjectBundleStore(store objectstorageport.ObjectStore) subjectApp.BundleStore {
+	if store == nil {
+		return nil
+	}
+	deleter, ok := store.(objectstorageport.PrefixDeleter)
+	if !ok {
+		return nil
+	}
+	return &SubjectBundleStore{store: store, deleter: deleter}
+}
+
+func (s *SubjectBundleStore) Put(ctx context.Context, key, contentType string, body []byte) error {
+	return s.store.Put(ctx, key, contentType, body)
+}
+
+func (s *SubjectBundleStore) Open(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	reader, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if reader == nil || reader.Body == nil {
+		return nil, 0, fmt.Errorf("object %q: %w", key, objectstorageport.ErrObjectNotFound)
+	}
+	return reader.Body, reader.ContentLength, nil
+}
+
+func (s *SubjectBundleStore) DeletePrefix(ctx context.Context, prefix string) (int64, error) {
+	return s.deleter.DeletePrefix(ctx, prefix)
+}
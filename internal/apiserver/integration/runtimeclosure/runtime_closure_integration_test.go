@@ -131,7 +131,14 @@ func TestCurrentRuntimeClosure(t *testing.T) {
 		Catalog: eventcatalog.NewCatalog(events), MQPublisher: capture, PublisherMode: eventruntime.PublishModeMQ,
 		Mongo:      eventsubsystem.ProfileOptions{BatchSize: 20, PublishWorkers: 1, ImmediateMaxConcurrent: 1},
 		Assessment: eventsubsystem.ProfileOptions{BatchSize: 20, PublishWorkers: 1, ImmediateMaxConcurrent: 1},
-		Consumers:  map[string]eventsubsystem.ConsumerOptions{"modelcatalog.hot_rank_projection": {Enabled: false}},
+		Consumers: map[string]eventsubsystem.ConsumerOptions{
+			"modelcatalog.hot_rank_projection":             {Enabled: false},
+			"workbench.critical_item_projection":           {Enabled: false},
+			"interpretation.report_pdf_render":             {Enabled: false},
+			"interpretation.plan_report_on_task_completed": {Enabled: false},
+			"interpretation.plan_report_on_task_expired":   {Enabled: false},
+			"interpretation.plan_report_on_task_canceled":  {Enabled: false},
+		},
 	})
 	if err != nil {
 		t.Fatalf("build event subsystem: %v", err)
@@ -259,6 +266,7 @@ func createRuntimeActorAndEntry(t *testing.T, c *container.Container, grpcDeps g
 		grpcDeps.Actor.TesteeManagementService,
 		grpcDeps.Actor.TesteeQueryService,
 		grpcDeps.Actor.ClinicianRelationshipService,
+		grpcDeps.Plan.QueryService,
 	)
 	testee, err := actorService.CreateTestee(t.Context(), &actorpb.CreateTesteeRequest{
 		OrgId: orgID, IamProfileId: profileID, Name: "runtime-closure-testee", Source: "online_form",
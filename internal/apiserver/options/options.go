@@ -36,6 +36,15 @@ type Options struct {
 	PlanScheduler                  *PlanSchedulerOptions                   `json:"plan_scheduler" mapstructure:"plan_scheduler"`
 	EvaluationConsistencyReconcile *EvaluationConsistencyReconcileOptions  `json:"evaluation_consistency_reconcile" mapstructure:"evaluation_consistency_reconcile"`
 	ReportCatalogAudit             *ReportCatalogAuditOptions              `json:"report_catalog_audit" mapstructure:"report_catalog_audit"`
+	TesteeImport                   *TesteeImportOptions                    `json:"testee_import" mapstructure:"testee_import"`
+	WorkbenchTriage                *WorkbenchTriageOptions                 `json:"workbench_triage" mapstructure:"workbench_triage"`
+	RiskAlert                      *RiskAlertOptions                       `json:"risk_alert" mapstructure:"risk_alert"`
+	ReportRegeneration             *ReportRegenerationOptions              `json:"report_regeneration" mapstructure:"report_regeneration"`
+	Redaction                      *RedactionOptions                       `json:"redaction" mapstructure:"redaction"`
+	SafeMessaging                  *SafeMessagingOptions                   `json:"safe_messaging" mapstructure:"safe_messaging"`
+	ReportPDF                      *ReportPDFOptions                       `json:"report_pdf" mapstructure:"report_pdf"`
+	ReportShare                    *ReportShareOptions                     `json:"report_share" mapstructure:"report_share"`
+	GroupReport                    *GroupReportOptions                     `json:"group_report" mapstructure:"group_report"`
 	OutboxRelay                    *OutboxRelayOptions                     `json:"outbox_relay" mapstructure:"outbox_relay"`
 	Eventing                       *EventingOptions                        `json:"eventing" mapstructure:"eventing"`
 	RateLimit                      *RateLimitOptions                       `json:"rate_limit" mapstructure:"rate_limit"`
@@ -85,6 +94,15 @@ func NewOptions() *Options {
 		PlanScheduler:                  NewPlanSchedulerOptions(),
 		EvaluationConsistencyReconcile: NewEvaluationConsistencyReconcileOptions(),
 		ReportCatalogAudit:             NewReportCatalogAuditOptions(),
+		TesteeImport:                   NewTesteeImportOptions(),
+		WorkbenchTriage:                NewWorkbenchTriageOptions(),
+		RiskAlert:                      NewRiskAlertOptions(),
+		ReportRegeneration:             NewReportRegenerationOptions(),
+		Redaction:                      NewRedactionOptions(),
+		SafeMessaging:                  NewSafeMessagingOptions(),
+		ReportPDF:                      NewReportPDFOptions(),
+		ReportShare:                    NewReportShareOptions(),
+		GroupReport:                    NewGroupReportOptions(),
 		OutboxRelay:                    NewOutboxRelayOptions(),
 		Eventing:                       NewEventingOptions(),
 		RateLimit:                      NewRateLimitOptions(),
@@ -283,6 +301,285 @@ func (e *EvaluationConsistencyReconcileOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&e.LockTTL, "evaluation_consistency_reconcile.lock-ttl", e.LockTTL, "Redis distributed lock TTL used by the evaluation consistency reconcile scheduler.")
 }
 
+// TesteeImportOptions 控制受试者批量导入任务的后台处理。
+type TesteeImportOptions struct {
+	Enable     bool          `json:"enable" mapstructure:"enable"`
+	Interval   time.Duration `json:"interval" mapstructure:"interval"`
+	BatchLimit int           `json:"batch_limit" mapstructure:"batch_limit"`
+	LockKey    string        `json:"lock_key" mapstructure:"lock_key"`
+	LockTTL    time.Duration `json:"lock_ttl" mapstructure:"lock_ttl"`
+}
+
+// NewTesteeImportOptions 创建默认 testee import 配置。
+func NewTesteeImportOptions() *TesteeImportOptions {
+	return &TesteeImportOptions{
+		Enable:     true,
+		Interval:   5 * time.Second,
+		BatchLimit: 50,
+		LockKey:    "qs:testee-import:leader",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+// AddFlags 注册 testee import 相关参数。
+func (t *TesteeImportOptions) AddFlags(fs *pflag.FlagSet) {
+	if t == nil {
+		return
+	}
+	fs.BoolVar(&t.Enable, "testee_import.enable", t.Enable, "Enable background processing of bulk testee import jobs.")
+	fs.DurationVar(&t.Interval, "testee_import.interval", t.Interval, "Interval for polling runnable testee import jobs.")
+	fs.IntVar(&t.BatchLimit, "testee_import.batch-limit", t.BatchLimit, "Maximum import rows to process in one testee import tick.")
+	fs.StringVar(&t.LockKey, "testee_import.lock-key", t.LockKey, "Redis distributed lock key used by the testee import worker.")
+	fs.DurationVar(&t.LockTTL, "testee_import.lock-ttl", t.LockTTL, "Redis distributed lock TTL used by the testee import worker.")
+}
+
+// WorkbenchTriageOptions 控制工作台高风险条目的认领时限与超时升级扫描。
+type WorkbenchTriageOptions struct {
+	// Enable 是否启用超时升级扫描；认领时限对队列 SLA 展示始终生效。
+	Enable bool `json:"enable" mapstructure:"enable"`
+	// HighRiskClaimSLA 高风险条目进入队列后的认领时限。
+	HighRiskClaimSLA time.Duration `json:"high_risk_claim_sla" mapstructure:"high_risk_claim_sla"`
+	Interval         time.Duration `json:"interval" mapstructure:"interval"`
+	BatchLimit       int           `json:"batch_limit" mapstructure:"batch_limit"`
+	LockKey          string        `json:"lock_key" mapstructure:"lock_key"`
+	LockTTL          time.Duration `json:"lock_ttl" mapstructure:"lock_ttl"`
+}
+
+// NewWorkbenchTriageOptions 创建默认 workbench triage 配置。
+func NewWorkbenchTriageOptions() *WorkbenchTriageOptions {
+	return &WorkbenchTriageOptions{
+		Enable:           true,
+		HighRiskClaimSLA: 4 * time.Hour,
+		Interval:         time.Minute,
+		BatchLimit:       100,
+		LockKey:          "qs:workbench-triage-escalation:leader",
+		LockTTL:          30 * time.Second,
+	}
+}
+
+// AddFlags 注册 workbench triage 相关参数。
+func (w *WorkbenchTriageOptions) AddFlags(fs *pflag.FlagSet) {
+	if w == nil {
+		return
+	}
+	fs.BoolVar(&w.Enable, "workbench_triage.enable", w.Enable, "Enable escalation of high-risk workbench items left unclaimed past the claim SLA.")
+	fs.DurationVar(&w.HighRiskClaimSLA, "workbench_triage.high-risk-claim-sla", w.HighRiskClaimSLA, "Time allowed to claim a high-risk workbench item before it is escalated.")
+	fs.DurationVar(&w.Interval, "workbench_triage.interval", w.Interval, "Interval for scanning unclaimed high-risk workbench items.")
+	fs.IntVar(&w.BatchLimit, "workbench_triage.batch-limit", w.BatchLimit, "Maximum workbench items to escalate in one tick.")
+	fs.StringVar(&w.LockKey, "workbench_triage.lock-key", w.LockKey, "Redis distributed lock key used by the workbench triage escalation scheduler.")
+	fs.DurationVar(&w.LockTTL, "workbench_triage.lock-ttl", w.LockTTL, "Redis distributed lock TTL used by the workbench triage escalation scheduler.")
+}
+
+// RiskAlertOptions 控制风险预警规则评估与未确认预警升级的扫描。
+type RiskAlertOptions struct {
+	Enable     bool          `json:"enable" mapstructure:"enable"`
+	Interval   time.Duration `json:"interval" mapstructure:"interval"`
+	BatchLimit int           `json:"batch_limit" mapstructure:"batch_limit"`
+	// Lookback 只评估该窗口内完成的测评结果，避免停机恢复后对过旧的结果寻呼。
+	Lookback time.Duration `json:"lookback" mapstructure:"lookback"`
+	LockKey  string        `json:"lock_key" mapstructure:"lock_key"`
+	LockTTL  time.Duration `json:"lock_ttl" mapstructure:"lock_ttl"`
+}
+
+// NewRiskAlertOptions 创建默认 risk alert 配置。
+func NewRiskAlertOptions() *RiskAlertOptions {
+	return &RiskAlertOptions{
+		Enable:     true,
+		Interval:   15 * time.Second,
+		BatchLimit: 200,
+		Lookback:   24 * time.Hour,
+		LockKey:    "qs:risk-alert:leader",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+// AddFlags 注册 risk alert 相关参数。
+func (r *RiskAlertOptions) AddFlags(fs *pflag.FlagSet) {
+	if r == nil {
+		return
+	}
+	fs.BoolVar(&r.Enable, "risk_alert.enable", r.Enable, "Enable risk alert rule evaluation and escalation of unacknowledged alerts.")
+	fs.DurationVar(&r.Interval, "risk_alert.interval", r.Interval, "Interval for evaluating new outcomes against risk alert rules and escalating overdue alerts.")
+	fs.IntVar(&r.BatchLimit, "risk_alert.batch-limit", r.BatchLimit, "Maximum outcomes to evaluate and alerts to escalate in one tick.")
+	fs.DurationVar(&r.Lookback, "risk_alert.lookback", r.Lookback, "Only outcomes evaluated within this window are checked against risk alert rules.")
+	fs.StringVar(&r.LockKey, "risk_alert.lock-key", r.LockKey, "Redis distributed lock key used by the risk alert scheduler.")
+	fs.DurationVar(&r.LockTTL, "risk_alert.lock-ttl", r.LockTTL, "Redis distributed lock TTL used by the risk alert scheduler.")
+}
+
+// ReportRegenerationOptions 控制报告批量重生成批次的后台推进与节流。
+type ReportRegenerationOptions struct {
+	Enable   bool          `json:"enable" mapstructure:"enable"`
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+	// BatchLimit 每轮最多发起的生成调用数；与 Interval 共同决定重生成速率。
+	BatchLimit int           `json:"batch_limit" mapstructure:"batch_limit"`
+	LockKey    string        `json:"lock_key" mapstructure:"lock_key"`
+	LockTTL    time.Duration `json:"lock_ttl" mapstructure:"lock_ttl"`
+}
+
+// NewReportRegenerationOptions 创建默认 report regeneration 配置。
+func NewReportRegenerationOptions() *ReportRegenerationOptions {
+	return &ReportRegenerationOptions{
+		Enable:     true,
+		Interval:   10 * time.Second,
+		BatchLimit: 20,
+		LockKey:    "qs:report-regeneration:leader",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+// AddFlags 注册 report regeneration 相关参数。
+func (r *ReportRegenerationOptions) AddFlags(fs *pflag.FlagSet) {
+	if r == nil {
+		return
+	}
+	fs.BoolVar(&r.Enable, "report_regeneration.enable", r.Enable, "Enable background processing of report regeneration batches.")
+	fs.DurationVar(&r.Interval, "report_regeneration.interval", r.Interval, "Interval between report regeneration ticks.")
+	fs.IntVar(&r.BatchLimit, "report_regeneration.batch-limit", r.BatchLimit, "Maximum report generations to request in one report regeneration tick.")
+	fs.StringVar(&r.LockKey, "report_regeneration.lock-key", r.LockKey, "Redis distributed lock key used by the report regeneration worker.")
+	fs.DurationVar(&r.LockTTL, "report_regeneration.lock-ttl", r.LockTTL, "Redis distributed lock TTL used by the report regeneration worker.")
+}
+
+// RedactionOptions 受试者个人信息脱敏配置。
+type RedactionOptions struct {
+	// PseudonymSecret 导出与去标识视图中受试者假名的 HMAC 密钥；为空时使用进程级随机密钥，
+	// 假名在重启或多实例之间不一致。server.mode 为 release 时必填，经环境变量注入，不写入配置文件。
+	PseudonymSecret string `json:"-" mapstructure:"pseudonym_secret"`
+}
+
+// NewRedactionOptions 创建默认脱敏配置。
+func NewRedactionOptions() *RedactionOptions {
+	return &RedactionOptions{}
+}
+
+// AddFlags 注册脱敏相关参数。
+func (r *RedactionOptions) AddFlags(fs *pflag.FlagSet) {
+	if r == nil {
+		return
+	}
+	fs.StringVar(&r.PseudonymSecret, "redaction.pseudonym-secret", r.PseudonymSecret, "HMAC secret for stable testee pseudonyms in de-identified exports.")
+}
+
+// SafeMessagingOptions 答卷命中关键条目时随提交结果返回给作答端的安全提示。
+type SafeMessagingOptions struct {
+	Title    string                  `json:"title" mapstructure:"title"`
+	Message  string                  `json:"message" mapstructure:"message"`
+	Hotlines []*SafeMessagingHotline `json:"hotlines" mapstructure:"hotlines"`
+}
+
+// SafeMessagingHotline 安全提示中的求助热线。
+type SafeMessagingHotline struct {
+	Name   string `json:"name" mapstructure:"name"`
+	Number string `json:"number" mapstructure:"number"`
+	Hours  string `json:"hours" mapstructure:"hours"`
+}
+
+// NewSafeMessagingOptions 创建默认安全提示：全国心理援助热线与紧急求助电话。
+func NewSafeMessagingOptions() *SafeMessagingOptions {
+	return &SafeMessagingOptions{
+		Title:   "你并不孤单",
+		Message: "如果你正在经历痛苦或有伤害自己的想法，请立即联系下面的求助热线，或告诉身边信任的人。",
+		Hotlines: []*SafeMessagingHotline{
+			{Name: "全国心理援助热线", Number: "12356", Hours: "24小时"},
+			{Name: "急救电话", Number: "120", Hours: "24小时"},
+			{Name: "报警电话", Number: "110", Hours: "24小时"},
+		},
+	}
+}
+
+// ReportPDFOptions 解读报告 PDF 渲染与签名下载配置。
+type ReportPDFOptions struct {
+	// SigningSecret 下载链接的 HMAC 密钥；为空时使用进程级随机密钥，已签发的链接在重启或多实例之间失效，生产环境必须配置。
+	SigningSecret string        `json:"-" mapstructure:"signing_secret"`
+	LinkTTL       time.Duration `json:"link_ttl" mapstructure:"link_ttl"`
+	// Branding 默认页眉品牌；OrgBrandings 按机构覆盖。
+	Branding     *ReportPDFBranding   `json:"branding" mapstructure:"branding"`
+	OrgBrandings []*ReportPDFBranding `json:"org_brandings" mapstructure:"org_brandings"`
+}
+
+// ReportPDFBranding 报告 PDF 的机构品牌；OrgID 仅在机构覆盖中使用。
+type ReportPDFBranding struct {
+	OrgID        int64  `json:"org_id" mapstructure:"org_id"`
+	OrgName      string `json:"org_name" mapstructure:"org_name"`
+	PrimaryColor string `json:"primary_color" mapstructure:"primary_color"`
+	Footnote     string `json:"footnote" mapstructure:"footnote"`
+}
+
+// NewReportPDFOptions 创建默认报告 PDF 配置。
+func NewReportPDFOptions() *ReportPDFOptions {
+	return &ReportPDFOptions{
+		LinkTTL: 15 * time.Minute,
+		Branding: &ReportPDFBranding{
+			PrimaryColor: "#1F6FEB",
+			Footnote:     "本报告由系统根据作答自动生成，仅供专业人员参考，不作为诊断依据。",
+		},
+	}
+}
+
+// AddFlags 注册报告 PDF 相关参数。
+func (r *ReportPDFOptions) AddFlags(fs *pflag.FlagSet) {
+	if r == nil {
+		return
+	}
+	fs.StringVar(&r.SigningSecret, "report_pdf.signing-secret", r.SigningSecret, "HMAC secret for signed report PDF download links.")
+	fs.DurationVar(&r.LinkTTL, "report_pdf.link-ttl", r.LinkTTL, "Validity of a signed report PDF download link.")
+}
+
+// ReportShareOptions 解读报告对外分享的有效期与浏览次数配置。
+type ReportShareOptions struct {
+	// DefaultTTL 分享者未指定有效期时使用；MaxTTL 为可设置的最长有效期。
+	DefaultTTL time.Duration `json:"default_ttl" mapstructure:"default_ttl"`
+	MaxTTL     time.Duration `json:"max_ttl" mapstructure:"max_ttl"`
+	// DefaultMaxViews 分享者未指定浏览次数时使用；ViewLimit 为可设置的最大浏览次数。
+	DefaultMaxViews int `json:"default_max_views" mapstructure:"default_max_views"`
+	ViewLimit       int `json:"view_limit" mapstructure:"view_limit"`
+}
+
+// NewReportShareOptions 创建默认报告分享配置。
+func NewReportShareOptions() *ReportShareOptions {
+	return &ReportShareOptions{
+		DefaultTTL:      72 * time.Hour,
+		MaxTTL:          30 * 24 * time.Hour,
+		DefaultMaxViews: 10,
+		ViewLimit:       100,
+	}
+}
+
+// AddFlags 注册报告分享相关参数。
+func (r *ReportShareOptions) AddFlags(fs *pflag.FlagSet) {
+	if r == nil {
+		return
+	}
+	fs.DurationVar(&r.DefaultTTL, "report_share.default-ttl", r.DefaultTTL, "Validity of a report share when the sharer does not choose one.")
+	fs.DurationVar(&r.MaxTTL, "report_share.max-ttl", r.MaxTTL, "Longest validity a report share may be given.")
+	fs.IntVar(&r.DefaultMaxViews, "report_share.default-max-views", r.DefaultMaxViews, "View limit of a report share when the sharer does not choose one.")
+	fs.IntVar(&r.ViewLimit, "report_share.view-limit", r.ViewLimit, "Largest view limit a report share may be given.")
+}
+
+// GroupReportOptions 群体报告的最小单元格与群体规模配置。
+type GroupReportOptions struct {
+	// MinCellSize 小于该人数的计数不公布，本学期参测人数不足时拒绝生成。
+	MinCellSize int `json:"min_cell_size" mapstructure:"min_cell_size"`
+	// MaxCohortSize 单份报告可汇总的群体人数上限。
+	MaxCohortSize int `json:"max_cohort_size" mapstructure:"max_cohort_size"`
+}
+
+// NewGroupReportOptions 创建默认群体报告配置。
+func NewGroupReportOptions() *GroupReportOptions {
+	return &GroupReportOptions{
+		MinCellSize:   5,
+		MaxCohortSize: 5000,
+	}
+}
+
+// AddFlags 注册群体报告相关参数。
+func (g *GroupReportOptions) AddFlags(fs *pflag.FlagSet) {
+	if g == nil {
+		return
+	}
+	fs.IntVar(&g.MinCellSize, "group_report.min-cell-size", g.MinCellSize, "Smallest count a group report may publish; smaller cohorts are refused.")
+	fs.IntVar(&g.MaxCohortSize, "group_report.max-cohort-size", g.MaxCohortSize, "Largest cohort a single group report may aggregate.")
+}
+
 type ReportCatalogAuditOptions struct {
 	Enable        bool          `json:"enable" mapstructure:"enable"`
 	InitialDelay  time.Duration `json:"initial_delay" mapstructure:"initial_delay"`
@@ -334,7 +631,12 @@ type EventingOptions struct {
 }
 
 type EventConsumerOptions struct {
-	ModelCatalogHotRank *EventConsumerBindingOptions `json:"modelcatalog-hot-rank" mapstructure:"modelcatalog-hot-rank"`
+	ModelCatalogHotRank   *EventConsumerBindingOptions `json:"modelcatalog-hot-rank" mapstructure:"modelcatalog-hot-rank"`
+	WorkbenchCriticalItem *EventConsumerBindingOptions `json:"workbench-critical-item" mapstructure:"workbench-critical-item"`
+	ReportPDF             *EventConsumerBindingOptions `json:"interpretation-report-pdf" mapstructure:"interpretation-report-pdf"`
+	PlanReportCompleted   *EventConsumerBindingOptions `json:"interpretation-plan-report-completed" mapstructure:"interpretation-plan-report-completed"`
+	PlanReportExpired     *EventConsumerBindingOptions `json:"interpretation-plan-report-expired" mapstructure:"interpretation-plan-report-expired"`
+	PlanReportCanceled    *EventConsumerBindingOptions `json:"interpretation-plan-report-canceled" mapstructure:"interpretation-plan-report-canceled"`
 }
 
 type EventConsumerBindingOptions struct {
@@ -343,18 +645,56 @@ type EventConsumerBindingOptions struct {
 }
 
 func NewEventingOptions() *EventingOptions {
-	return &EventingOptions{Consumers: &EventConsumerOptions{ModelCatalogHotRank: &EventConsumerBindingOptions{
-		Enabled: true, Channel: "qs-apiserver-modelcatalog-hot-rank-v1",
-	}}}
+	return &EventingOptions{Consumers: &EventConsumerOptions{
+		ModelCatalogHotRank: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-modelcatalog-hot-rank-v1",
+		},
+		WorkbenchCriticalItem: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-workbench-critical-item-v1",
+		},
+		ReportPDF: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-interpretation-report-pdf-v1",
+		},
+		PlanReportCompleted: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-interpretation-plan-report-completed-v1",
+		},
+		PlanReportExpired: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-interpretation-plan-report-expired-v1",
+		},
+		PlanReportCanceled: &EventConsumerBindingOptions{
+			Enabled: true, Channel: "qs-apiserver-interpretation-plan-report-canceled-v1",
+		},
+	}}
 }
 
 func (o *EventingOptions) AddFlags(fs *pflag.FlagSet) {
-	if o == nil || o.Consumers == nil || o.Consumers.ModelCatalogHotRank == nil {
+	if o == nil || o.Consumers == nil {
 		return
 	}
-	hotRank := o.Consumers.ModelCatalogHotRank
-	fs.BoolVar(&hotRank.Enabled, "eventing.consumer.modelcatalog-hot-rank.enabled", hotRank.Enabled, "Enable the independent modelcatalog hot-rank event consumer.")
-	fs.StringVar(&hotRank.Channel, "eventing.consumer.modelcatalog-hot-rank.channel", hotRank.Channel, "Stable MQ channel for the modelcatalog hot-rank projection.")
+	if hotRank := o.Consumers.ModelCatalogHotRank; hotRank != nil {
+		fs.BoolVar(&hotRank.Enabled, "eventing.consumer.modelcatalog-hot-rank.enabled", hotRank.Enabled, "Enable the independent modelcatalog hot-rank event consumer.")
+		fs.StringVar(&hotRank.Channel, "eventing.consumer.modelcatalog-hot-rank.channel", hotRank.Channel, "Stable MQ channel for the modelcatalog hot-rank projection.")
+	}
+	if critical := o.Consumers.WorkbenchCriticalItem; critical != nil {
+		fs.BoolVar(&critical.Enabled, "eventing.consumer.workbench-critical-item.enabled", critical.Enabled, "Enable the workbench critical-item queue projection consumer.")
+		fs.StringVar(&critical.Channel, "eventing.consumer.workbench-critical-item.channel", critical.Channel, "Stable MQ channel for the workbench critical-item projection.")
+	}
+	if reportPDF := o.Consumers.ReportPDF; reportPDF != nil {
+		fs.BoolVar(&reportPDF.Enabled, "eventing.consumer.interpretation-report-pdf.enabled", reportPDF.Enabled, "Enable the interpretation report PDF render consumer.")
+		fs.StringVar(&reportPDF.Channel, "eventing.consumer.interpretation-report-pdf.channel", reportPDF.Channel, "Stable MQ channel for the interpretation report PDF render consumer.")
+	}
+	if planReport := o.Consumers.PlanReportCompleted; planReport != nil {
+		fs.BoolVar(&planReport.Enabled, "eventing.consumer.interpretation-plan-report-completed.enabled", planReport.Enabled, "Enable the longitudinal plan report consumer for task.completed.")
+		fs.StringVar(&planReport.Channel, "eventing.consumer.interpretation-plan-report-completed.channel", planReport.Channel, "Stable MQ channel for the longitudinal plan report consumer on task.completed.")
+	}
+	if planReport := o.Consumers.PlanReportExpired; planReport != nil {
+		fs.BoolVar(&planReport.Enabled, "eventing.consumer.interpretation-plan-report-expired.enabled", planReport.Enabled, "Enable the longitudinal plan report consumer for task.expired.")
+		fs.StringVar(&planReport.Channel, "eventing.consumer.interpretation-plan-report-expired.channel", planReport.Channel, "Stable MQ channel for the longitudinal plan report consumer on task.expired.")
+	}
+	if planReport := o.Consumers.PlanReportCanceled; planReport != nil {
+		fs.BoolVar(&planReport.Enabled, "eventing.consumer.interpretation-plan-report-canceled.enabled", planReport.Enabled, "Enable the longitudinal plan report consumer for task.canceled.")
+		fs.StringVar(&planReport.Channel, "eventing.consumer.interpretation-plan-report-canceled.channel", planReport.Channel, "Stable MQ channel for the longitudinal plan report consumer on task.canceled.")
+	}
 }
 
 func NewOutboxRelayOptions() *OutboxRelayOptions {
@@ -456,6 +796,14 @@ func (o *Options) Flags() (fss cliflag.NamedFlagSets) {
 	o.PlanScheduler.AddFlags(fss.FlagSet("plan_scheduler"))
 	o.EvaluationConsistencyReconcile.AddFlags(fss.FlagSet("evaluation_consistency_reconcile"))
 	o.ReportCatalogAudit.AddFlags(fss.FlagSet("report_catalog_audit"))
+	o.TesteeImport.AddFlags(fss.FlagSet("testee_import"))
+	o.WorkbenchTriage.AddFlags(fss.FlagSet("workbench_triage"))
+	o.RiskAlert.AddFlags(fss.FlagSet("risk_alert"))
+	o.ReportRegeneration.AddFlags(fss.FlagSet("report_regeneration"))
+	o.Redaction.AddFlags(fss.FlagSet("redaction"))
+	o.ReportPDF.AddFlags(fss.FlagSet("report_pdf"))
+	o.ReportShare.AddFlags(fss.FlagSet("report_share"))
+	o.GroupReport.AddFlags(fss.FlagSet("group_report"))
 	o.OutboxRelay.AddFlags(fss.FlagSet("outbox_relay"))
 	o.Eventing.AddFlags(fss.FlagSet("eventing"))
 	o.RateLimit.AddFlags(fss.FlagSet("rate_limit"))
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	genericoptions "github.com/FangcunMount/qs-server/internal/pkg/options"
 	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime"
 	"github.com/FangcunMount/qs-server/internal/pkg/retrygovernance"
 )
@@ -41,6 +42,13 @@ func (o *Options) Validate() []error {
 	errs = append(errs, validatePlanScheduler(o.PlanScheduler)...)
 	errs = append(errs, validateEvaluationConsistencyReconcile(o.EvaluationConsistencyReconcile)...)
 	errs = append(errs, validateReportCatalogAudit(o.ReportCatalogAudit)...)
+	errs = append(errs, validateTesteeImport(o.TesteeImport)...)
+	errs = append(errs, validateWorkbenchTriage(o.WorkbenchTriage)...)
+	errs = append(errs, validateRiskAlert(o.RiskAlert)...)
+	errs = append(errs, validateReportRegeneration(o.ReportRegeneration)...)
+	errs = append(errs, validateReportPDF(o.ReportPDF, o.GenericServerRunOptions)...)
+	errs = append(errs, validateReportShare(o.ReportShare)...)
+	errs = append(errs, validateRedaction(o.Redaction, o.GenericServerRunOptions)...)
 	errs = append(errs, validateOutboxRelay(o.OutboxRelay, o.MySQLOptions.MaxOpenConnections, o.Backpressure)...)
 	errs = append(errs, validateStatisticsSync(o.StatisticsSync)...)
 	errs = append(errs, validateCacheOptions(o.Cache)...)
@@ -274,6 +282,162 @@ func validateEvaluationConsistencyReconcile(opts *EvaluationConsistencyReconcile
 	return errs
 }
 
+func validateTesteeImport(opts *TesteeImportOptions) []error {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+
+	var errs []error
+	if opts.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("testee_import.interval must be greater than 0"))
+	}
+	if opts.BatchLimit <= 0 {
+		errs = append(errs, fmt.Errorf("testee_import.batch_limit must be greater than 0"))
+	}
+	if opts.LockKey == "" {
+		errs = append(errs, fmt.Errorf("testee_import.lock_key cannot be empty when enabled"))
+	}
+	if opts.LockTTL <= 0 {
+		errs = append(errs, fmt.Errorf("testee_import.lock_ttl must be greater than 0"))
+	}
+	return errs
+}
+
+func validateWorkbenchTriage(opts *WorkbenchTriageOptions) []error {
+	if opts == nil {
+		return nil
+	}
+
+	var errs []error
+	if opts.HighRiskClaimSLA < 0 {
+		errs = append(errs, fmt.Errorf("workbench_triage.high_risk_claim_sla cannot be negative"))
+	}
+	if !opts.Enable {
+		return errs
+	}
+	if opts.HighRiskClaimSLA == 0 {
+		errs = append(errs, fmt.Errorf("workbench_triage.high_risk_claim_sla must be greater than 0 when escalation is enabled"))
+	}
+	if opts.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("workbench_triage.interval must be greater than 0"))
+	}
+	if opts.BatchLimit <= 0 {
+		errs = append(errs, fmt.Errorf("workbench_triage.batch_limit must be greater than 0"))
+	}
+	if opts.LockKey == "" {
+		errs = append(errs, fmt.Errorf("workbench_triage.lock_key cannot be empty when enabled"))
+	}
+	if opts.LockTTL <= 0 {
+		errs = append(errs, fmt.Errorf("workbench_triage.lock_ttl must be greater than 0"))
+	}
+	return errs
+}
+
+// validateReportPDF 生产环境必须配置下载链接签名密钥：进程级随机密钥使已签发的链接在重启与多实例之间失效。
+func validateReportPDF(opts *ReportPDFOptions, server *genericoptions.ServerRunOptions) []error {
+	if opts == nil {
+		return nil
+	}
+	var errs []error
+	if server != nil && server.Mode == releaseServerMode && strings.TrimSpace(opts.SigningSecret) == "" {
+		errs = append(errs, fmt.Errorf("report_pdf.signing_secret is required when server.mode is release"))
+	}
+	if opts.LinkTTL <= 0 || opts.LinkTTL > 24*time.Hour {
+		errs = append(errs, fmt.Errorf("report_pdf.link_ttl must be within (0, 24h]"))
+	}
+	seen := map[int64]bool{}
+	for _, branding := range opts.OrgBrandings {
+		if branding == nil || branding.OrgID <= 0 {
+			errs = append(errs, fmt.Errorf("report_pdf.org_brandings[].org_id must be greater than 0"))
+			continue
+		}
+		if seen[branding.OrgID] {
+			errs = append(errs, fmt.Errorf("report_pdf.org_brandings has duplicate org_id %d", branding.OrgID))
+		}
+		seen[branding.OrgID] = true
+	}
+	return errs
+}
+
+// releaseServerMode 生产环境的 server.mode。
+const releaseServerMode = "release"
+
+// validateRedaction 生产环境必须配置假名密钥：进程级随机密钥使去标识导出的假名在重启与多实例之间不一致。
+func validateRedaction(opts *RedactionOptions, server *genericoptions.ServerRunOptions) []error {
+	if server == nil || server.Mode != releaseServerMode {
+		return nil
+	}
+	if opts == nil || strings.TrimSpace(opts.PseudonymSecret) == "" {
+		return []error{fmt.Errorf("redaction.pseudonym_secret is required when server.mode is release")}
+	}
+	return nil
+}
+
+func validateReportShare(opts *ReportShareOptions) []error {
+	if opts == nil {
+		return nil
+	}
+	var errs []error
+	if opts.MaxTTL < time.Minute {
+		errs = append(errs, fmt.Errorf("report_share.max_ttl must be at least 1m"))
+	}
+	if opts.DefaultTTL < time.Minute || opts.DefaultTTL > opts.MaxTTL {
+		errs = append(errs, fmt.Errorf("report_share.default_ttl must be within [1m, max_ttl]"))
+	}
+	if opts.ViewLimit <= 0 {
+		errs = append(errs, fmt.Errorf("report_share.view_limit must be greater than 0"))
+	}
+	if opts.DefaultMaxViews <= 0 || opts.DefaultMaxViews > opts.ViewLimit {
+		errs = append(errs, fmt.Errorf("report_share.default_max_views must be within [1, view_limit]"))
+	}
+	return errs
+}
+
+func validateRiskAlert(opts *RiskAlertOptions) []error {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+
+	var errs []error
+	if opts.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("risk_alert.interval must be greater than 0"))
+	}
+	if opts.BatchLimit <= 0 {
+		errs = append(errs, fmt.Errorf("risk_alert.batch_limit must be greater than 0"))
+	}
+	if opts.Lookback <= 0 {
+		errs = append(errs, fmt.Errorf("risk_alert.lookback must be greater than 0"))
+	}
+	if opts.LockKey == "" {
+		errs = append(errs, fmt.Errorf("risk_alert.lock_key cannot be empty when enabled"))
+	}
+	if opts.LockTTL <= 0 {
+		errs = append(errs, fmt.Errorf("risk_alert.lock_ttl must be greater than 0"))
+	}
+	return errs
+}
+
+func validateReportRegeneration(opts *ReportRegenerationOptions) []error {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+
+	var errs []error
+	if opts.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("report_regeneration.interval must be greater than 0"))
+	}
+	if opts.BatchLimit <= 0 {
+		errs = append(errs, fmt.Errorf("report_regeneration.batch_limit must be greater than 0"))
+	}
+	if opts.LockKey == "" {
+		errs = append(errs, fmt.Errorf("report_regeneration.lock_key cannot be empty when enabled"))
+	}
+	if opts.LockTTL <= 0 {
+		errs = append(errs, fmt.Errorf("report_regeneration.lock_ttl must be greater than 0"))
+	}
+	return errs
+}
+
 func validateReportCatalogAudit(opts *ReportCatalogAuditOptions) []error {
 	if opts == nil || !opts.Enable {
 		return nil
@@ -161,6 +161,179 @@ func TestOptionsValidateEvaluationConsistencyReconcile(t *testing.T) {
 	}
 }
 
+func TestOptionsValidateTesteeImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Options)
+		wantErr string
+	}{
+		{
+			name: "disabled worker skips validation",
+			mutate: func(opts *Options) {
+				opts.TesteeImport.Enable = false
+				opts.TesteeImport.Interval = 0
+				opts.TesteeImport.BatchLimit = 0
+				opts.TesteeImport.LockKey = ""
+				opts.TesteeImport.LockTTL = 0
+			},
+		},
+		{
+			name:    "enabled worker requires positive interval",
+			mutate:  func(opts *Options) { opts.TesteeImport.Interval = 0 },
+			wantErr: "testee_import.interval must be greater than 0",
+		},
+		{
+			name:    "enabled worker requires positive batch limit",
+			mutate:  func(opts *Options) { opts.TesteeImport.BatchLimit = 0 },
+			wantErr: "testee_import.batch_limit must be greater than 0",
+		},
+		{
+			name:    "enabled worker requires lock key",
+			mutate:  func(opts *Options) { opts.TesteeImport.LockKey = "" },
+			wantErr: "testee_import.lock_key cannot be empty when enabled",
+		},
+		{
+			name:    "enabled worker requires positive lock ttl",
+			mutate:  func(opts *Options) { opts.TesteeImport.LockTTL = 0 },
+			wantErr: "testee_import.lock_ttl must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			tt.mutate(opts)
+
+			errs := opts.Validate()
+			if tt.wantErr == "" {
+				for _, err := range errs {
+					if strings.Contains(err.Error(), "testee_import.") {
+						t.Fatalf("unexpected testee import validation error: %v", err)
+					}
+				}
+				return
+			}
+
+			for _, err := range errs {
+				if strings.Contains(err.Error(), tt.wantErr) {
+					return
+				}
+			}
+			t.Fatalf("expected validation error containing %q, got %v", tt.wantErr, errs)
+		})
+	}
+}
+
+func TestOptionsValidateRedactionPseudonymSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Options)
+		wantErr string
+	}{
+		{
+			name: "debug mode allows the random fallback",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "debug"
+				opts.Redaction.PseudonymSecret = ""
+			},
+		},
+		{
+			name: "release mode accepts a configured secret",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "release"
+				opts.Redaction.PseudonymSecret = "pseudonym-secret"
+			},
+		},
+		{
+			name: "release mode requires a secret",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "release"
+				opts.Redaction.PseudonymSecret = "  "
+			},
+			wantErr: "redaction.pseudonym_secret is required when server.mode is release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			tt.mutate(opts)
+
+			errs := opts.Validate()
+			if tt.wantErr == "" {
+				for _, err := range errs {
+					if strings.Contains(err.Error(), "redaction.") {
+						t.Fatalf("unexpected redaction validation error: %v", err)
+					}
+				}
+				return
+			}
+
+			for _, err := range errs {
+				if strings.Contains(err.Error(), tt.wantErr) {
+					return
+				}
+			}
+			t.Fatalf("expected validation error containing %q, got %v", tt.wantErr, errs)
+		})
+	}
+}
+
+func TestOptionsValidateReportPDFSigningSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Options)
+		wantErr string
+	}{
+		{
+			name: "debug mode allows the random fallback",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "debug"
+				opts.ReportPDF.SigningSecret = ""
+			},
+		},
+		{
+			name: "release mode accepts a configured secret",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "release"
+				opts.ReportPDF.SigningSecret = "signing-secret"
+			},
+		},
+		{
+			name: "release mode requires a secret",
+			mutate: func(opts *Options) {
+				opts.GenericServerRunOptions.Mode = "release"
+				opts.ReportPDF.SigningSecret = "  "
+			},
+			wantErr: "report_pdf.signing_secret is required when server.mode is release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			tt.mutate(opts)
+
+			errs := opts.Validate()
+			if tt.wantErr == "" {
+				for _, err := range errs {
+					if strings.Contains(err.Error(), "report_pdf.") {
+						t.Fatalf("unexpected report_pdf validation error: %v", err)
+					}
+				}
+				return
+			}
+
+			for _, err := range errs {
+				if strings.Contains(err.Error(), tt.wantErr) {
+					return
+				}
+			}
+			t.Fatalf("expected validation error containing %q, got %v", tt.wantErr, errs)
+		})
+	}
+}
+
 func TestOptionsValidateOutboxRelay(t *testing.T) {
 	tests := []struct {
 		name    string
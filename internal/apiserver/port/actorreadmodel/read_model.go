@@ -3,6 +3,8 @@ package actorreadmodel
 import (
 	"context"
 	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
 )
 
 type TesteeFilter struct {
@@ -15,6 +17,8 @@ type TesteeFilter struct {
 	RestrictToAccessScope bool
 	Offset                int
 	Limit                 int
+	// Triage 按工作台分诊状态过滤，条目主体为受试者 ID。
+	Triage workbenchreadmodel.TriageFilter
 }
 
 type TesteeRow struct {
@@ -28,6 +32,7 @@ type TesteeRow struct {
 	UpdatedAt        time.Time
 	Source           string
 	IsKeyFocus       bool
+	PreferredLocale  string
 	LastAssessmentAt *time.Time
 	TotalAssessments int
 	LastRiskLevel    string
@@ -0,0 +1,16 @@
+package consentgate
+
+import "context"
+
+// CheckRequest 一次答卷提交需要校验的同意范围。
+type CheckRequest struct {
+	OrgID             int64
+	TesteeID          uint64
+	QuestionnaireCode string
+	EntryID           string // 经测评入口作答时的入口ID
+}
+
+// Checker 校验受试者已签署提交所需的全部同意书；缺少时返回 code.ErrConsentRequired。
+type Checker interface {
+	CheckSubmission(ctx context.Context, request CheckRequest) error
+}
@@ -99,7 +99,13 @@ type ReportSuggestionRow struct {
 }
 
 type ReportRow struct {
-	AssessmentID        uint64
+	AssessmentID uint64
+	// ReportID 是规范报告 artifact 的 ID；归档报告没有 artifact，为 0。
+	ReportID uint64
+	// Audience 是正文所属的受众版本，空值为 canonical。
+	Audience string
+	// Locale 是正文的内容语言；归档报告与语言上线前的报告为空，视为 locale.Default。
+	Locale              string
 	ModelName           string
 	ModelCode           string
 	Model               ModelIdentityRow
@@ -196,6 +202,17 @@ type ReportModelRarityRow struct {
 	OneInX  int
 }
 
+// AudienceVariantReader 按规范报告 ID 批量读取指定受众的变体正文；
+// 未发布该受众的报告不出现在结果中，由调用方按回落顺序处理。
+type AudienceVariantReader interface {
+	FindAudienceVariants(ctx context.Context, reportIDs []uint64, audience string) (map[uint64]ReportRow, error)
+}
+
+// LocalizedReportReader 读取已按需渲染的其他语言正文；尚未渲染时返回 nil, nil。
+type LocalizedReportReader interface {
+	FindLocalizedReport(ctx context.Context, reportID uint64, audience, locale string) (*ReportRow, error)
+}
+
 type ReportReader interface {
 	GetReportByAssessmentID(ctx context.Context, assessmentID uint64) (*ReportRow, error)
 	ListReports(ctx context.Context, filter ReportFilter, page PageRequest) ([]ReportRow, int64, error)
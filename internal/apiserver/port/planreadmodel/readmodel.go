@@ -3,6 +3,8 @@ package planreadmodel
 import (
 	"context"
 	"time"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/workbenchreadmodel"
 )
 
 // PageRequest carries normalized pagination inputs for plan read queries.
@@ -61,6 +63,8 @@ type FollowUpQueueFilter struct {
 	OrgID               int64
 	TesteeIDs           []uint64
 	RestrictToTesteeIDs bool
+	// Triage 按工作台分诊状态过滤，条目主体为任务 ID。
+	Triage workbenchreadmodel.TriageFilter
 }
 
 // PlanRow is the read-side projection of an assessment plan.
@@ -75,6 +79,10 @@ type PlanRow struct {
 	FixedDates    []string
 	RelativeWeeks []int
 	Status        string
+
+	TimeZone       string
+	RecurrenceRule string
+	ExceptionDates []string
 }
 
 // TaskRow is the read-side projection of an assessment task.
@@ -95,6 +103,7 @@ type TaskRow struct {
 	AssessmentID     *uint64
 	EntryToken       string
 	EntryURL         string
+	TimeZone         string
 }
 
 // PlanPage carries paged plan rows.
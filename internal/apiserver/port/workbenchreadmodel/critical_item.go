@@ -0,0 +1,43 @@
+package workbenchreadmodel
+
+import (
+	"context"
+	"time"
+)
+
+// CriticalItemQueueFilter 关键条目队列：答卷提交时命中问卷关键条目规则的答卷。
+type CriticalItemQueueFilter struct {
+	OrgID               int64
+	TesteeIDs           []uint64
+	RestrictToTesteeIDs bool
+	Triage              TriageFilter
+}
+
+// CriticalItemHit 答卷命中的单个关键条目；Trigger 为命中的选项编码、数值或关键词，不含答案原文。
+type CriticalItemHit struct {
+	QuestionCode string
+	Category     string
+	Trigger      string
+}
+
+type CriticalItemRow struct {
+	AnswerSheetID        uint64
+	OrgID                int64
+	TesteeID             uint64
+	QuestionnaireCode    string
+	QuestionnaireVersion string
+	Categories           []string
+	Items                []CriticalItemHit
+	FlaggedAt            time.Time
+}
+
+type CriticalItemPage struct {
+	Items    []CriticalItemRow
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type CriticalItemReader interface {
+	ListCriticalItemQueue(context.Context, CriticalItemQueueFilter, PageRequest) (CriticalItemPage, error)
+}
@@ -0,0 +1,35 @@
+package workbenchreadmodel
+
+import (
+	"context"
+	"time"
+)
+
+// AwaitingReviewQueueFilter 待复核报告队列：已完成评估、尚未签署临床复核的测评。
+type AwaitingReviewQueueFilter struct {
+	OrgID               int64
+	TesteeIDs           []uint64
+	RestrictToTesteeIDs bool
+	Triage              TriageFilter
+}
+
+type AwaitingReviewRow struct {
+	AssessmentID uint64
+	OrgID        int64
+	TesteeID     uint64
+	RiskLevel    string
+	// NoteVersion 已追加但尚未签署的备注版本，0 表示尚无备注。
+	NoteVersion int
+	EvaluatedAt time.Time
+}
+
+type AwaitingReviewPage struct {
+	Items    []AwaitingReviewRow
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type AwaitingReviewReader interface {
+	ListAwaitingReviewQueue(context.Context, AwaitingReviewQueueFilter, PageRequest) (AwaitingReviewPage, error)
+}
@@ -39,6 +39,7 @@ type LatestRiskQueueFilter struct {
 	TesteeIDs           []uint64
 	RestrictToTesteeIDs bool
 	RiskLevels          []string
+	Triage              TriageFilter
 }
 
 type LatestRiskRow struct {
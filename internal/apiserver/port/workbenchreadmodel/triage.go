@@ -0,0 +1,34 @@
+package workbenchreadmodel
+
+import "time"
+
+// TriageView 按分诊状态筛选工作台队列条目。队列条目没有分诊记录时视为 open，暂缓到期后也视为 open。
+type TriageView string
+
+const (
+	// TriageViewAll 不按分诊状态过滤。
+	TriageViewAll TriageView = ""
+	// TriageViewActive 待处理条目：未认领或已认领，排除已解决与暂缓中的条目。
+	TriageViewActive TriageView = "active"
+	// TriageViewOpen 未认领、未暂缓、未解决的条目。
+	TriageViewOpen     TriageView = "open"
+	TriageViewClaimed  TriageView = "claimed"
+	TriageViewSnoozed  TriageView = "snoozed"
+	TriageViewResolved TriageView = "resolved"
+	// TriageViewHandled 已处理条目：已解决或暂缓中。
+	TriageViewHandled TriageView = "handled"
+	// TriageViewEscalated 已因超过认领时限升级、且仍未认领的条目。
+	TriageViewEscalated TriageView = "escalated"
+)
+
+// TriageFilter 队列读模型的分诊状态条件。View 为空时不追加条件；Now 用于判断暂缓是否到期。
+type TriageFilter struct {
+	QueueType string
+	View      TriageView
+	Now       time.Time
+}
+
+// Enabled 是否需要按分诊状态过滤。
+func (f TriageFilter) Enabled() bool {
+	return f.View != TriageViewAll && f.QueueType != ""
+}
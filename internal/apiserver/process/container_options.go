@@ -47,6 +47,13 @@ func (s *server) buildContainerOptions(input containerOptionsInput) container.Co
 		LockSubsystem:              locks,
 		Resilience:                 resilience,
 		PlanEntryBaseURL:           s.config.Plan.EntryBaseURL,
+		PseudonymSecret:            pseudonymSecret(s.config),
+		WorkbenchHighRiskClaimSLA:  workbenchHighRiskClaimSLA(s.config),
+		RiskAlertLookback:          riskAlertLookback(s.config),
+		SafeMessaging:              s.config.SafeMessaging,
+		ReportPDF:                  s.config.ReportPDF,
+		ReportShare:                s.config.ReportShare,
+		GroupReport:                s.config.GroupReport,
 		StatisticsRepairWindowDays: statisticsRepairWindowDays(s.config),
 		ReportStatus:               s.config.Cache.Capabilities.ReportStatus,
 		Signaling:                  s.config.Signaling,
@@ -90,6 +97,10 @@ func (s *server) buildResilienceSubsystem(runtime *cacheplanebootstrap.RuntimeBu
 			locklease.WorkloadStatisticsSyncLeader:           s.config.StatisticsSync != nil && s.config.StatisticsSync.Enable,
 			locklease.WorkloadStatisticsSync:                 true,
 			locklease.WorkloadEvaluationConsistencyReconcile: s.config.EvaluationConsistencyReconcile != nil && s.config.EvaluationConsistencyReconcile.Enable,
+			locklease.WorkloadTesteeImport:                   s.config.TesteeImport != nil && s.config.TesteeImport.Enable,
+			locklease.WorkloadWorkbenchTriageEscalation:      s.config.WorkbenchTriage != nil && s.config.WorkbenchTriage.Enable,
+			locklease.WorkloadRiskAlert:                      s.config.RiskAlert != nil && s.config.RiskAlert.Enable,
+			locklease.WorkloadReportRegeneration:             s.config.ReportRegeneration != nil && s.config.ReportRegeneration.Enable,
 		},
 	})
 	var stateStore *controlredis.Store
@@ -108,11 +119,27 @@ func (s *server) buildResilienceSubsystem(runtime *cacheplanebootstrap.RuntimeBu
 
 func buildEventConsumerOptions(cfg *config.Config) map[string]eventsubsystem.ConsumerOptions {
 	result := map[string]eventsubsystem.ConsumerOptions{}
-	if cfg == nil || cfg.Eventing == nil || cfg.Eventing.Consumers == nil || cfg.Eventing.Consumers.ModelCatalogHotRank == nil {
+	if cfg == nil || cfg.Eventing == nil || cfg.Eventing.Consumers == nil {
 		return result
 	}
-	hotRank := cfg.Eventing.Consumers.ModelCatalogHotRank
-	result["modelcatalog.hot_rank_projection"] = eventsubsystem.ConsumerOptions{Enabled: hotRank.Enabled, Channel: hotRank.Channel}
+	if hotRank := cfg.Eventing.Consumers.ModelCatalogHotRank; hotRank != nil {
+		result["modelcatalog.hot_rank_projection"] = eventsubsystem.ConsumerOptions{Enabled: hotRank.Enabled, Channel: hotRank.Channel}
+	}
+	if critical := cfg.Eventing.Consumers.WorkbenchCriticalItem; critical != nil {
+		result["workbench.critical_item_projection"] = eventsubsystem.ConsumerOptions{Enabled: critical.Enabled, Channel: critical.Channel}
+	}
+	if reportPDF := cfg.Eventing.Consumers.ReportPDF; reportPDF != nil {
+		result["interpretation.report_pdf_render"] = eventsubsystem.ConsumerOptions{Enabled: reportPDF.Enabled, Channel: reportPDF.Channel}
+	}
+	if planReport := cfg.Eventing.Consumers.PlanReportCompleted; planReport != nil {
+		result["interpretation.plan_report_on_task_completed"] = eventsubsystem.ConsumerOptions{Enabled: planReport.Enabled, Channel: planReport.Channel}
+	}
+	if planReport := cfg.Eventing.Consumers.PlanReportExpired; planReport != nil {
+		result["interpretation.plan_report_on_task_expired"] = eventsubsystem.ConsumerOptions{Enabled: planReport.Enabled, Channel: planReport.Channel}
+	}
+	if planReport := cfg.Eventing.Consumers.PlanReportCanceled; planReport != nil {
+		result["interpretation.plan_report_on_task_canceled"] = eventsubsystem.ConsumerOptions{Enabled: planReport.Enabled, Channel: planReport.Channel}
+	}
 	return result
 }
 
@@ -257,3 +284,24 @@ func statisticsRepairWindowDays(cfg *config.Config) int {
 	}
 	return cfg.StatisticsSync.RepairWindowDays
 }
+
+func workbenchHighRiskClaimSLA(cfg *config.Config) time.Duration {
+	if cfg.WorkbenchTriage == nil {
+		return 0
+	}
+	return cfg.WorkbenchTriage.HighRiskClaimSLA
+}
+
+func riskAlertLookback(cfg *config.Config) time.Duration {
+	if cfg.RiskAlert == nil {
+		return 0
+	}
+	return cfg.RiskAlert.Lookback
+}
+
+func pseudonymSecret(cfg *config.Config) string {
+	if cfg.Redaction == nil {
+		return ""
+	}
+	return cfg.Redaction.PseudonymSecret
+}
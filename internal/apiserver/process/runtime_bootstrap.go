@@ -104,6 +104,30 @@ func buildSchedulerManager(cfg *config.Config, deps container.ServerRuntimeDeps)
 			deps.LockManager,
 			deps.LockBuilder,
 		),
+		runtimescheduler.NewTesteeImportRunner(
+			cfg.TesteeImport,
+			deps.TesteeImportProcessor,
+			deps.LockManager,
+			deps.LockBuilder,
+		),
+		runtimescheduler.NewWorkbenchTriageEscalationRunner(
+			cfg.WorkbenchTriage,
+			deps.WorkbenchEscalator,
+			deps.LockManager,
+			deps.LockBuilder,
+		),
+		runtimescheduler.NewRiskAlertRunner(
+			cfg.RiskAlert,
+			deps.RiskAlertMonitor,
+			deps.LockManager,
+			deps.LockBuilder,
+		),
+		runtimescheduler.NewReportRegenerationRunner(
+			cfg.ReportRegeneration,
+			deps.ReportRegenerationProcessor,
+			deps.LockManager,
+			deps.LockBuilder,
+		),
 	)
 	if manager.Len() == 0 {
 		return nil
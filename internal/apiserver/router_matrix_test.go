@@ -9,11 +9,24 @@ import (
 	"strings"
 	"testing"
 
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	assessmentEntryApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/assessmententry"
+	breakGlassApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	careTeamApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
 	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	evaluationoperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
+	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
+	clinicalReviewApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportprojection"
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	fhirApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
+	subjectRightsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
 	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
 	statisticsApp "github.com/FangcunMount/qs-server/internal/apiserver/application/statistics"
 	answerSheetApp "github.com/FangcunMount/qs-server/internal/apiserver/application/survey/answersheet"
@@ -174,22 +187,93 @@ func TestRouterRegisterRoutesIncludesKeyPaths(t *testing.T) {
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/workbench/queues/:queue_type")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/workbench/queues/summary")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/workbench/queues/:queue_type")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/workbench/queues/:queue_type/items/:subject_id")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/workbench/queues/:queue_type/items/:subject_id/claim")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/workbench/queues/:queue_type/items/:subject_id/snooze")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/workbench/queues/:queue_type/items/:subject_id/resolve")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/workbench/queues/:queue_type/items/:subject_id/reopen")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/workbench/queues/:queue_type/items/:subject_id")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/workbench/queues/:queue_type/items/:subject_id/claim")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/workbench/queues/:queue_type/items/:subject_id/snooze")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/workbench/queues/:queue_type/items/:subject_id/resolve")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/workbench/queues/:queue_type/items/:subject_id/reopen")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/practitioners")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/practitioners/me/workbench/queues/summary")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/practitioners/me/workbench/queues/:queue_type")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/staff")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/testee-imports")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testee-imports/:id/rows")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/testee-imports/:id/resume")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testee-duplicates")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testees/:id/duplicates")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/testee-merges")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/testee-merges/:id/revert")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/access-audits")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/access-audits/export")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/access-audits/verify")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testees/export")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/testees/:id/unmask")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/break-glass")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/break-glass")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/break-glass/:id/revoke")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/break-glass-grants")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/break-glass-grants/:id/review")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/break-glass-grants/:id/revoke")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/data-subject-requests")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/data-subject-requests")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/data-subject-requests/:id")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/data-subject-requests/:id/resume")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/data-subject-requests/:id/bundle")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/data-subject-requests/:id/certificate")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/custom-roles/capabilities")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/custom-roles")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/custom-roles")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/custom-roles/:id")
+	assertRoutePresent(t, routes, http.MethodPut, "/api/v1/custom-roles/:id")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/custom-roles/:id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/custom-roles/:id/assignments")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/custom-roles/:id/assignments")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/custom-roles/:id/assignments/:operator_id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/authz/explain")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/care-teams")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/care-teams")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/care-teams/:id")
+	assertRoutePresent(t, routes, http.MethodPut, "/api/v1/care-teams/:id")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/care-teams/:id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/care-teams/:id/members")
+	assertRoutePresent(t, routes, http.MethodPut, "/api/v1/care-teams/:id/members/:clinician_id")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/care-teams/:id/members/:clinician_id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/care-teams/:id/testees")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/care-teams/:id/testees")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/care-teams/:id/testees/:testee_id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/care-teams/:id/events")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/care-teams")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/clinicians/me/testees/:testee_id/reports/:assessment_id/review")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/testees/:testee_id/reports/:assessment_id/notes")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/clinicians/me/testees/:testee_id/reports/:assessment_id/sign-off")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/risk-alert-rules")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/risk-alert-rules")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/risk-alert-rules/:id")
+	assertRoutePresent(t, routes, http.MethodPut, "/api/v1/risk-alert-rules/:id")
+	assertRoutePresent(t, routes, http.MethodDelete, "/api/v1/risk-alert-rules/:id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/risk-alerts")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/risk-alerts/:id")
+	assertRoutePresent(t, routes, http.MethodPost, "/api/v1/risk-alerts/:id/acknowledge")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/assessment-entries/:id")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/overview")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/clinicians")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/clinicians/:id")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/entries")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/entries/:id")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/plans/:id/adherence")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/plans/:id/adherence/export")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/clinicians/me/overview")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/clinicians/me/entries")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/statistics/clinicians/me/testees-summary")
 	assertRoutePresent(t, routes, http.MethodPost, "/api/v2/statistics/contents/batch")
 	assertRouteAbsent(t, routes, http.MethodGet, "/api/v1/statistics/overview")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testees/:id/plans")
+	assertRoutePresent(t, routes, http.MethodGet, "/api/v1/testees/:id/tasks/calendar.ics")
 	assertRoutePresent(t, routes, http.MethodGet, "/api/v2/plans/testees/:testee_id/enrollments")
 	assertRoutePresent(t, routes, http.MethodPost, "/internal/v1/plans/tasks/window")
 	assertRoutePresent(t, routes, http.MethodPost, "/internal/v2/statistics/runs")
@@ -379,6 +463,255 @@ func TestRouterAdminWorkbenchRouteRequiresOrgAdminCapability(t *testing.T) {
 	}
 }
 
+func TestRouterTesteeImportRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/testee-imports", nil)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestRouterTesteeMergeRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/testee-duplicates"},
+		{http.MethodGet, "/api/v1/testees/1/duplicates"},
+		{http.MethodPost, "/api/v1/testee-merges"},
+		{http.MethodPost, "/api/v1/testee-merges/1/revert"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterAccessAuditRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, path := range []string{"/api/v1/access-audits", "/api/v1/access-audits/export", "/api/v1/access-audits/verify"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("GET %s status = %d, want %d", path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterBreakGlassReviewRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/break-glass-grants"},
+		{http.MethodPost, "/api/v1/break-glass-grants/1/review"},
+		{http.MethodPost, "/api/v1/break-glass-grants/1/revoke"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterDataSubjectRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/data-subject-requests"},
+		{http.MethodGet, "/api/v1/data-subject-requests"},
+		{http.MethodPost, "/api/v1/data-subject-requests/1/resume"},
+		{http.MethodGet, "/api/v1/data-subject-requests/1/bundle"},
+		{http.MethodGet, "/api/v1/data-subject-requests/1/certificate"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterFHIRRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, path := range []string{
+		"/api/v1/fhir/Questionnaire/PHQ",
+		"/api/v1/fhir/Questionnaire/PHQ/_history/1.0.0",
+		"/api/v1/fhir/QuestionnaireResponse/1",
+		"/api/v1/fhir/Observation/1-result",
+		"/api/v1/fhir/DiagnosticReport/1",
+		"/api/v1/fhir/$export?_since=2026-01-01",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("GET %s status = %d, want %d", path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterGroupReportRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodPost, path: "/api/v1/group-reports"},
+		{method: http.MethodGet, path: "/api/v1/group-reports/1"},
+		{method: http.MethodGet, path: "/api/v1/group-reports/1/pdf"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterCustomRoleRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/custom-roles"},
+		{http.MethodPost, "/api/v1/custom-roles"},
+		{http.MethodPut, "/api/v1/custom-roles/1"},
+		{http.MethodDelete, "/api/v1/custom-roles/1"},
+		{http.MethodPost, "/api/v1/custom-roles/1/assignments"},
+		{http.MethodDelete, "/api/v1/custom-roles/1/assignments/2"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterCareTeamRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/care-teams"},
+		{http.MethodPost, "/api/v1/care-teams"},
+		{http.MethodDelete, "/api/v1/care-teams/1"},
+		{http.MethodPut, "/api/v1/care-teams/1/members/2"},
+		{http.MethodPost, "/api/v1/care-teams/1/testees"},
+		{http.MethodGet, "/api/v1/care-teams/1/events"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterRiskAlertRoutesRequireOrgAdminCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/risk-alert-rules"},
+		{http.MethodPost, "/api/v1/risk-alert-rules"},
+		{http.MethodPut, "/api/v1/risk-alert-rules/1"},
+		{http.MethodDelete, "/api/v1/risk-alert-rules/1"},
+		{http.MethodGet, "/api/v1/risk-alerts"},
+		{http.MethodPost, "/api/v1/risk-alerts/1/acknowledge"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestRouterTesteePrivacyRoutesRequireCapabilities(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := resttransport.NewRouter(newRouterTestDeps())
+	router.RegisterRoutes(engine)
+
+	for _, target := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/testees/export"},
+		{http.MethodPost, "/api/v1/testees/1/unmask"},
+	} {
+		req := httptest.NewRequest(target.method, target.path, nil)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status = %d, want %d", target.method, target.path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
 func TestTransportPlaneDoesNotUseLegacyInterfaceImplementation(t *testing.T) {
 	root, err := os.Getwd()
 	if err != nil {
@@ -508,6 +841,18 @@ func newRouterTestContainer() *container.Container {
 func newRouterTestDeps() resttransport.Deps {
 	deps := newRouterTestContainer().BuildRESTDeps(nil)
 	deps.Workbench.WorkbenchService = &routerWorkbenchServiceStub{}
+	deps.TesteeImport.Service = testeeImport.NewService(nil, nil, nil, nil, nil, nil, nil)
+	deps.TesteeMerge.Service = testeeMerge.NewService(nil, nil, nil)
+	deps.AccessAudit.Service = accessAuditApp.NewService(nil, nil)
+	deps.Actor.BreakGlassService = breakGlassApp.NewService(nil, nil, nil, nil, nil, nil, nil, appEventing.ProfileBinding{})
+	deps.SubjectRights.Service = subjectRightsApp.NewService(nil, nil, nil, nil, nil)
+	deps.FHIR.Service = fhirApp.NewService(nil, nil, nil, nil, nil, reportprojection.Mapper{})
+	deps.Actor.CustomRoleService = customRoleApp.NewService(nil, nil, nil)
+	deps.Actor.CareTeamService = careTeamApp.NewService(nil, nil, nil, nil)
+	deps.Interpretation.ClinicalReview = clinicalReviewApp.NewService(nil, nil, nil, nil, nil)
+	deps.Interpretation.RiskAlerts = riskAlertApp.NewService(nil, nil)
+	deps.Interpretation.GroupReports = groupReportApp.NewService(nil, nil, nil, nil, nil, nil, groupReportApp.Config{})
+	deps.Actor.TesteeBackendQueryService = testeeApp.NewBackendQueryService(&routerTesteeQueryStub{}, nil)
 	return deps
 }
 
@@ -521,6 +866,26 @@ func (*routerWorkbenchServiceStub) ListQueue(context.Context, workbenchApp.ListQ
 	return &workbenchApp.QueuePage{Items: []workbenchApp.QueueItem{}}, nil
 }
 
+func (*routerWorkbenchServiceStub) GetTriageItem(context.Context, workbenchApp.TriageDTO) (*workbenchApp.TriageItemView, error) {
+	return &workbenchApp.TriageItemView{}, nil
+}
+
+func (*routerWorkbenchServiceStub) Claim(context.Context, workbenchApp.TriageDTO) (*workbenchApp.TriageItemView, error) {
+	return &workbenchApp.TriageItemView{}, nil
+}
+
+func (*routerWorkbenchServiceStub) Snooze(context.Context, workbenchApp.TriageDTO) (*workbenchApp.TriageItemView, error) {
+	return &workbenchApp.TriageItemView{}, nil
+}
+
+func (*routerWorkbenchServiceStub) Resolve(context.Context, workbenchApp.TriageDTO) (*workbenchApp.TriageItemView, error) {
+	return &workbenchApp.TriageItemView{}, nil
+}
+
+func (*routerWorkbenchServiceStub) Reopen(context.Context, workbenchApp.TriageDTO) (*workbenchApp.TriageItemView, error) {
+	return &workbenchApp.TriageItemView{}, nil
+}
+
 func assertRoutePresent(t *testing.T, routes gin.RoutesInfo, method, path string) {
 	t.Helper()
 	for _, route := range routes {
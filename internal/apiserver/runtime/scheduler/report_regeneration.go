@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/log"
+	reportRegeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/observability"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+)
+
+// ReportRegenerationRunner
+// 报告批量重生成处理器，在 leader 锁内按批次游标推进重生成；BatchLimit 即每轮生成调用的节流上限。
+type ReportRegenerationRunner struct {
+	opts      *apiserveroptions.ReportRegenerationOptions
+	processor reportRegeneration.Processor
+	leader    leaderLeaseRunner
+}
+
+// NewReportRegenerationRunner 创建报告批量重生成处理器，当依赖项可用时创建处理器。
+func NewReportRegenerationRunner(
+	opts *apiserveroptions.ReportRegenerationOptions,
+	processor reportRegeneration.Processor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+) *ReportRegenerationRunner {
+	return newReportRegenerationRunnerWithHooks(
+		opts,
+		processor,
+		lockManager,
+		lockBuilder,
+		func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error) {
+			return lockManager.AcquireSpec(ctx, spec, key, ttl)
+		},
+		func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error {
+			return lockManager.ReleaseSpec(ctx, spec, key, lease)
+		},
+	)
+}
+
+func newReportRegenerationRunnerWithHooks(
+	opts *apiserveroptions.ReportRegenerationOptions,
+	processor reportRegeneration.Processor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+	acquireLock func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error),
+	releaseLock func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error,
+) *ReportRegenerationRunner {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+	if processor == nil {
+		log.Warnf("report regeneration worker not started (processor unavailable)")
+		return nil
+	}
+	if opts.Interval <= 0 {
+		log.Warnf("report regeneration worker not started (interval must be greater than 0)")
+		return nil
+	}
+	if opts.BatchLimit <= 0 {
+		log.Warnf("report regeneration worker not started (batch_limit must be greater than 0)")
+		return nil
+	}
+	if opts.LockKey == "" {
+		log.Warnf("report regeneration worker not started (lock_key is empty)")
+		return nil
+	}
+	if opts.LockTTL <= 0 {
+		log.Warnf("report regeneration worker not started (lock_ttl must be greater than 0)")
+		return nil
+	}
+	if lockManager == nil {
+		observability.ObserveLockDegraded("report_regeneration", "redis_unavailable")
+		log.Warnf("report regeneration worker not started (HA lock unavailable: redis client unavailable)")
+		return nil
+	}
+	if acquireLock == nil || releaseLock == nil {
+		log.Warnf("report regeneration worker not started (lock hooks unavailable)")
+		return nil
+	}
+
+	return &ReportRegenerationRunner{
+		opts:      opts,
+		processor: processor,
+		leader:    newLeaderLock(workloadSpec(locklease.WorkloadReportRegeneration), opts.LockKey, opts.LockTTL, lockBuilder, acquireLock, releaseLock, leaseRunner(lockManager)),
+	}
+}
+
+// Name 返回处理器名称。
+func (r *ReportRegenerationRunner) Name() string {
+	return "report_regeneration"
+}
+
+// Start 启动处理循环。
+func (r *ReportRegenerationRunner) Start(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	log.Infof("report regeneration worker started (interval=%s, batch_limit=%d, lock_key=%s, lock_ttl=%s)",
+		r.opts.Interval, r.opts.BatchLimit, r.leader.DisplayKey(), r.opts.LockTTL)
+
+	go func() {
+		r.executeTick(ctx)
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.executeTick(ctx)
+			}
+		}
+	}()
+}
+
+func (r *ReportRegenerationRunner) executeTick(ctx context.Context) {
+	if err := r.runOnce(ctx); err != nil {
+		log.Warnf("report regeneration tick failed: %v", err)
+	}
+}
+
+// runOnce 每轮只调用一次处理器：重生成是对历史数据的补做，
+// 以 BatchLimit/Interval 限定生成速率，不与实时生成争抢 Builder 与存储。
+func (r *ReportRegenerationRunner) runOnce(ctx context.Context) error {
+	return r.leader.Run(ctx, leaderLockRunOptions{
+		AcquireError: "failed to acquire report regeneration lock",
+		OnNotAcquired: func(lockKey string) {
+			log.Debugf("report regeneration tick skipped (lock_key=%s, reason=lock_not_acquired)", lockKey)
+		},
+		OnReleaseError: func(lockKey string, err error) {
+			log.Warnf("failed to release report regeneration lock (lock_key=%s): %v", lockKey, err)
+		},
+	}, func(ctx context.Context) error {
+		processed, err := r.processor.ProcessOnce(ctx, r.opts.BatchLimit)
+		if processed > 0 {
+			log.Infof("report regeneration worker processed %d outcomes", processed)
+		}
+		return err
+	})
+}
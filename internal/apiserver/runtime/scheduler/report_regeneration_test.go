@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	reportRegeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease/redisadapter"
+)
+
+type fakeReportRegenerationProcessor struct {
+	batches []int // 每次调用返回的生成调用数
+	limits  []int
+}
+
+func (f *fakeReportRegenerationProcessor) ProcessOnce(_ context.Context, limit int) (int, error) {
+	f.limits = append(f.limits, limit)
+	if len(f.batches) == 0 {
+		return 0, nil
+	}
+	processed := f.batches[0]
+	f.batches = f.batches[1:]
+	return processed, nil
+}
+
+var _ reportRegeneration.Processor = (*fakeReportRegenerationProcessor)(nil)
+
+func newTestReportRegenerationOptions() *apiserveroptions.ReportRegenerationOptions {
+	return &apiserveroptions.ReportRegenerationOptions{
+		Enable:     true,
+		Interval:   5 * time.Second,
+		BatchLimit: 50,
+		LockKey:    "qs:report-regeneration:test",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+func TestNewReportRegenerationRunnerRequiresDependencies(t *testing.T) {
+	acquire := func(context.Context, redisadapter.Spec, string, time.Duration) (*redisadapter.Lease, bool, error) {
+		return &redisadapter.Lease{Key: "k", Token: "t"}, true, nil
+	}
+	release := func(context.Context, redisadapter.Spec, string, *redisadapter.Lease) error { return nil }
+	processor := &fakeReportRegenerationProcessor{}
+
+	if runner := newReportRegenerationRunnerWithHooks(&apiserveroptions.ReportRegenerationOptions{Enable: false}, processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected disabled runner to return nil")
+	}
+	if runner := newReportRegenerationRunnerWithHooks(newTestReportRegenerationOptions(), nil, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected nil processor to return nil")
+	}
+	if runner := newReportRegenerationRunnerWithHooks(newTestReportRegenerationOptions(), processor, nil, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected nil lock manager to return nil")
+	}
+	invalid := newTestReportRegenerationOptions()
+	invalid.BatchLimit = 0
+	if runner := newReportRegenerationRunnerWithHooks(invalid, processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected invalid batch limit to return nil")
+	}
+}
+
+func TestReportRegenerationRunOnceSpendsOneBudgetPerTick(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	processor := &fakeReportRegenerationProcessor{batches: []int{50, 50, 12}}
+	var gotSpec redisadapter.Spec
+	runner := newReportRegenerationRunnerWithHooks(
+		newTestReportRegenerationOptions(),
+		processor,
+		&redisadapter.Manager{},
+		newTestEvaluationConsistencyLockBuilder(),
+		func(ctx context.Context, spec redisadapter.Spec, key string, ttl time.Duration) (*redisadapter.Lease, bool, error) {
+			gotSpec = spec
+			return lock.acquire(ctx, spec, key, ttl)
+		},
+		lock.release,
+	)
+
+	if err := runner.runOnce(context.Background()); err != nil {
+		t.Fatalf("runOnce returned error: %v", err)
+	}
+	if gotSpec.Name != workloadSpec(locklease.WorkloadReportRegeneration).Name {
+		t.Fatalf("spec.name = %q", gotSpec.Name)
+	}
+	if len(processor.limits) != 1 || processor.limits[0] != 50 {
+		t.Fatalf("limits = %v, want a single call per tick", processor.limits)
+	}
+	if lock.releases() != 1 {
+		t.Fatalf("expected lock release once, got %d", lock.releases())
+	}
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/log"
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/observability"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+)
+
+// RiskAlertRunner
+// 风险预警扫描，在 leader 锁内按机构规则评估新完成的测评结果，并升级超时未确认的预警。
+type RiskAlertRunner struct {
+	opts    *apiserveroptions.RiskAlertOptions
+	monitor riskAlertApp.Monitor
+	leader  leaderLeaseRunner
+}
+
+// NewRiskAlertRunner 创建风险预警扫描器，当依赖项可用时创建扫描器。
+func NewRiskAlertRunner(
+	opts *apiserveroptions.RiskAlertOptions,
+	monitor riskAlertApp.Monitor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+) *RiskAlertRunner {
+	return newRiskAlertRunnerWithHooks(
+		opts,
+		monitor,
+		lockManager,
+		lockBuilder,
+		func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error) {
+			return lockManager.AcquireSpec(ctx, spec, key, ttl)
+		},
+		func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error {
+			return lockManager.ReleaseSpec(ctx, spec, key, lease)
+		},
+	)
+}
+
+func newRiskAlertRunnerWithHooks(
+	opts *apiserveroptions.RiskAlertOptions,
+	monitor riskAlertApp.Monitor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+	acquireLock func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error),
+	releaseLock func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error,
+) *RiskAlertRunner {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+	if monitor == nil {
+		log.Warnf("risk alert scheduler not started (monitor unavailable)")
+		return nil
+	}
+	if opts.Interval <= 0 {
+		log.Warnf("risk alert scheduler not started (interval must be greater than 0)")
+		return nil
+	}
+	if opts.BatchLimit <= 0 {
+		log.Warnf("risk alert scheduler not started (batch_limit must be greater than 0)")
+		return nil
+	}
+	if opts.LockKey == "" {
+		log.Warnf("risk alert scheduler not started (lock_key is empty)")
+		return nil
+	}
+	if opts.LockTTL <= 0 {
+		log.Warnf("risk alert scheduler not started (lock_ttl must be greater than 0)")
+		return nil
+	}
+	if lockManager == nil {
+		observability.ObserveLockDegraded("risk_alert", "redis_unavailable")
+		log.Warnf("risk alert scheduler not started (HA lock unavailable: redis client unavailable)")
+		return nil
+	}
+	if acquireLock == nil || releaseLock == nil {
+		log.Warnf("risk alert scheduler not started (lock hooks unavailable)")
+		return nil
+	}
+
+	return &RiskAlertRunner{
+		opts:    opts,
+		monitor: monitor,
+		leader:  newLeaderLock(workloadSpec(locklease.WorkloadRiskAlert), opts.LockKey, opts.LockTTL, lockBuilder, acquireLock, releaseLock, leaseRunner(lockManager)),
+	}
+}
+
+// Name 返回扫描器名称。
+func (r *RiskAlertRunner) Name() string {
+	return "risk_alert"
+}
+
+// Start 启动扫描循环。
+func (r *RiskAlertRunner) Start(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	log.Infof("risk alert scheduler started (interval=%s, lookback=%s, batch_limit=%d, lock_key=%s, lock_ttl=%s)",
+		r.opts.Interval, r.opts.Lookback, r.opts.BatchLimit, r.leader.DisplayKey(), r.opts.LockTTL)
+
+	go func() {
+		r.executeTick(ctx)
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.executeTick(ctx)
+			}
+		}
+	}()
+}
+
+func (r *RiskAlertRunner) executeTick(ctx context.Context) {
+	if err := r.runOnce(ctx); err != nil {
+		log.Warnf("risk alert scheduler tick failed: %v", err)
+	}
+}
+
+func (r *RiskAlertRunner) runOnce(ctx context.Context) error {
+	return r.leader.Run(ctx, leaderLockRunOptions{
+		AcquireError: "failed to acquire risk alert lock",
+		OnNotAcquired: func(lockKey string) {
+			log.Debugf("risk alert scheduler tick skipped (lock_key=%s, reason=lock_not_acquired)", lockKey)
+		},
+		OnReleaseError: func(lockKey string, err error) {
+			log.Warnf("failed to release risk alert lock (lock_key=%s): %v", lockKey, err)
+		},
+	}, func(ctx context.Context) error {
+		// 评估失败不阻塞升级：已触发的预警仍须按时限寻呼下一级。
+		raised, evaluateErr := r.monitor.EvaluatePending(ctx, r.opts.BatchLimit)
+		if raised > 0 {
+			log.Infof("risk alert scheduler raised %d alerts", raised)
+		}
+		escalated, escalateErr := r.monitor.EscalateOverdue(ctx, r.opts.BatchLimit)
+		if escalated > 0 {
+			log.Infof("risk alert scheduler escalated %d unacknowledged alerts", escalated)
+		}
+		return errors.Join(evaluateErr, escalateErr)
+	})
+}
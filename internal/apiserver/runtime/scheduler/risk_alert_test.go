@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease/redisadapter"
+)
+
+type fakeRiskAlertMonitor struct {
+	calls       []string
+	evaluateErr error
+}
+
+func (f *fakeRiskAlertMonitor) EvaluatePending(_ context.Context, _ int) (int, error) {
+	f.calls = append(f.calls, "evaluate")
+	return 1, f.evaluateErr
+}
+
+func (f *fakeRiskAlertMonitor) EscalateOverdue(_ context.Context, _ int) (int, error) {
+	f.calls = append(f.calls, "escalate")
+	return 1, nil
+}
+
+func newTestRiskAlertOptions() *apiserveroptions.RiskAlertOptions {
+	return &apiserveroptions.RiskAlertOptions{
+		Enable:     true,
+		Interval:   15 * time.Second,
+		BatchLimit: 100,
+		Lookback:   24 * time.Hour,
+		LockKey:    "qs:risk-alert:test",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+func TestNewRiskAlertRunnerRequiresDependencies(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	monitor := &fakeRiskAlertMonitor{}
+	if runner := newRiskAlertRunnerWithHooks(&apiserveroptions.RiskAlertOptions{Enable: false}, monitor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected disabled runner to return nil")
+	}
+	if runner := newRiskAlertRunnerWithHooks(newTestRiskAlertOptions(), nil, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected nil monitor to return nil")
+	}
+	if runner := newRiskAlertRunnerWithHooks(newTestRiskAlertOptions(), monitor, nil, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected nil lock manager to return nil")
+	}
+}
+
+func TestRiskAlertRunOnceEvaluatesBeforeEscalatingUnderLeaderLock(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	monitor := &fakeRiskAlertMonitor{}
+	var gotSpec redisadapter.Spec
+	runner := newRiskAlertRunnerWithHooks(
+		newTestRiskAlertOptions(),
+		monitor,
+		&redisadapter.Manager{},
+		newTestEvaluationConsistencyLockBuilder(),
+		func(ctx context.Context, spec redisadapter.Spec, key string, ttl time.Duration) (*redisadapter.Lease, bool, error) {
+			gotSpec = spec
+			return lock.acquire(ctx, spec, key, ttl)
+		},
+		lock.release,
+	)
+
+	if err := runner.runOnce(context.Background()); err != nil {
+		t.Fatalf("runOnce returned error: %v", err)
+	}
+	if gotSpec.Name != workloadSpec(locklease.WorkloadRiskAlert).Name {
+		t.Fatalf("spec.name = %q", gotSpec.Name)
+	}
+	if len(monitor.calls) != 2 || monitor.calls[0] != "evaluate" || monitor.calls[1] != "escalate" {
+		t.Fatalf("calls = %v, want evaluate then escalate", monitor.calls)
+	}
+	if lock.releases() != 1 {
+		t.Fatalf("expected lock release once, got %d", lock.releases())
+	}
+}
+
+func TestRiskAlertRunOnceStillEscalatesWhenEvaluationFails(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	monitor := &fakeRiskAlertMonitor{evaluateErr: errors.New("db down")}
+	runner := newRiskAlertRunnerWithHooks(newTestRiskAlertOptions(), monitor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release)
+
+	if err := runner.runOnce(context.Background()); err == nil {
+		t.Fatal("expected evaluation error")
+	}
+	if len(monitor.calls) != 2 || monitor.calls[1] != "escalate" {
+		t.Fatalf("calls = %v, want escalation after failed evaluation", monitor.calls)
+	}
+	if lock.releases() != 1 {
+		t.Fatalf("expected lock release once, got %d", lock.releases())
+	}
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/log"
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/observability"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+)
+
+// TesteeImportRunner
+// 受试者批量导入处理器，在 leader 锁内逐批推进导入任务；进程中断后由下一轮继续。
+type TesteeImportRunner struct {
+	opts      *apiserveroptions.TesteeImportOptions
+	processor testeeImport.Processor
+	leader    leaderLeaseRunner
+	now       func() time.Time
+}
+
+// NewTesteeImportRunner 创建受试者批量导入处理器，当依赖项可用时创建处理器。
+func NewTesteeImportRunner(
+	opts *apiserveroptions.TesteeImportOptions,
+	processor testeeImport.Processor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+) *TesteeImportRunner {
+	return newTesteeImportRunnerWithHooks(
+		opts,
+		processor,
+		lockManager,
+		lockBuilder,
+		func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error) {
+			return lockManager.AcquireSpec(ctx, spec, key, ttl)
+		},
+		func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error {
+			return lockManager.ReleaseSpec(ctx, spec, key, lease)
+		},
+	)
+}
+
+func newTesteeImportRunnerWithHooks(
+	opts *apiserveroptions.TesteeImportOptions,
+	processor testeeImport.Processor,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+	acquireLock func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error),
+	releaseLock func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error,
+) *TesteeImportRunner {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+	if processor == nil {
+		log.Warnf("testee import worker not started (processor unavailable)")
+		return nil
+	}
+	if opts.Interval <= 0 {
+		log.Warnf("testee import worker not started (interval must be greater than 0)")
+		return nil
+	}
+	if opts.BatchLimit <= 0 {
+		log.Warnf("testee import worker not started (batch_limit must be greater than 0)")
+		return nil
+	}
+	if opts.LockKey == "" {
+		log.Warnf("testee import worker not started (lock_key is empty)")
+		return nil
+	}
+	if opts.LockTTL <= 0 {
+		log.Warnf("testee import worker not started (lock_ttl must be greater than 0)")
+		return nil
+	}
+	if lockManager == nil {
+		observability.ObserveLockDegraded("testee_import", "redis_unavailable")
+		log.Warnf("testee import worker not started (HA lock unavailable: redis client unavailable)")
+		return nil
+	}
+	if acquireLock == nil || releaseLock == nil {
+		log.Warnf("testee import worker not started (lock hooks unavailable)")
+		return nil
+	}
+
+	return &TesteeImportRunner{
+		opts:      opts,
+		processor: processor,
+		leader:    newLeaderLock(workloadSpec(locklease.WorkloadTesteeImport), opts.LockKey, opts.LockTTL, lockBuilder, acquireLock, releaseLock, leaseRunner(lockManager)),
+		now:       time.Now,
+	}
+}
+
+// Name 返回处理器名称。
+func (r *TesteeImportRunner) Name() string {
+	return "testee_import"
+}
+
+// Start 启动处理循环。
+func (r *TesteeImportRunner) Start(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	log.Infof("testee import worker started (interval=%s, batch_limit=%d, lock_key=%s, lock_ttl=%s)",
+		r.opts.Interval, r.opts.BatchLimit, r.leader.DisplayKey(), r.opts.LockTTL)
+
+	go func() {
+		r.executeTick(ctx)
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.executeTick(ctx)
+			}
+		}
+	}()
+}
+
+func (r *TesteeImportRunner) executeTick(ctx context.Context) {
+	if err := r.runOnce(ctx); err != nil {
+		log.Warnf("testee import tick failed: %v", err)
+	}
+}
+
+// runOnce 在一个调度间隔内连续处理多个批次，直到没有待处理行或时间片用完，
+// 避免大文件因每轮只处理一批而长时间停留在 running。
+func (r *TesteeImportRunner) runOnce(ctx context.Context) error {
+	return r.leader.Run(ctx, leaderLockRunOptions{
+		AcquireError: "failed to acquire testee import lock",
+		OnNotAcquired: func(lockKey string) {
+			log.Debugf("testee import tick skipped (lock_key=%s, reason=lock_not_acquired)", lockKey)
+		},
+		OnReleaseError: func(lockKey string, err error) {
+			log.Warnf("failed to release testee import lock (lock_key=%s): %v", lockKey, err)
+		},
+	}, func(ctx context.Context) error {
+		deadline := r.now().Add(r.opts.Interval)
+		for {
+			processed, err := r.processor.ProcessOnce(ctx, r.opts.BatchLimit)
+			if err != nil || processed == 0 || !r.now().Before(deadline) {
+				return err
+			}
+		}
+	})
+}
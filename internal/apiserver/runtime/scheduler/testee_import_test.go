@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease/redisadapter"
+)
+
+type fakeTesteeImportProcessor struct {
+	batches []int // 每次调用返回的处理行数
+	limits  []int
+	err     error
+}
+
+func (f *fakeTesteeImportProcessor) ProcessOnce(_ context.Context, limit int) (int, error) {
+	f.limits = append(f.limits, limit)
+	if f.err != nil {
+		return 0, f.err
+	}
+	if len(f.batches) == 0 {
+		return 0, nil
+	}
+	processed := f.batches[0]
+	f.batches = f.batches[1:]
+	return processed, nil
+}
+
+var _ testeeImport.Processor = (*fakeTesteeImportProcessor)(nil)
+
+func newTestTesteeImportOptions() *apiserveroptions.TesteeImportOptions {
+	return &apiserveroptions.TesteeImportOptions{
+		Enable:     true,
+		Interval:   5 * time.Second,
+		BatchLimit: 50,
+		LockKey:    "qs:testee-import:test",
+		LockTTL:    30 * time.Second,
+	}
+}
+
+func TestNewTesteeImportRunnerRequiresDependencies(t *testing.T) {
+	acquire := func(context.Context, redisadapter.Spec, string, time.Duration) (*redisadapter.Lease, bool, error) {
+		return &redisadapter.Lease{Key: "k", Token: "t"}, true, nil
+	}
+	release := func(context.Context, redisadapter.Spec, string, *redisadapter.Lease) error { return nil }
+	processor := &fakeTesteeImportProcessor{}
+
+	if runner := newTesteeImportRunnerWithHooks(&apiserveroptions.TesteeImportOptions{Enable: false}, processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected disabled runner to return nil")
+	}
+	if runner := newTesteeImportRunnerWithHooks(newTestTesteeImportOptions(), nil, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected nil processor to return nil")
+	}
+	if runner := newTesteeImportRunnerWithHooks(newTestTesteeImportOptions(), processor, nil, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected nil lock manager to return nil")
+	}
+	invalid := newTestTesteeImportOptions()
+	invalid.BatchLimit = 0
+	if runner := newTesteeImportRunnerWithHooks(invalid, processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), acquire, release); runner != nil {
+		t.Fatal("expected invalid batch limit to return nil")
+	}
+}
+
+func TestTesteeImportRunOnceDrainsBatchesUnderLeaderLock(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	processor := &fakeTesteeImportProcessor{batches: []int{50, 50, 12}}
+	var gotSpec redisadapter.Spec
+	runner := newTesteeImportRunnerWithHooks(
+		newTestTesteeImportOptions(),
+		processor,
+		&redisadapter.Manager{},
+		newTestEvaluationConsistencyLockBuilder(),
+		func(ctx context.Context, spec redisadapter.Spec, key string, ttl time.Duration) (*redisadapter.Lease, bool, error) {
+			gotSpec = spec
+			return lock.acquire(ctx, spec, key, ttl)
+		},
+		lock.release,
+	)
+
+	if err := runner.runOnce(context.Background()); err != nil {
+		t.Fatalf("runOnce returned error: %v", err)
+	}
+	if gotSpec.Name != workloadSpec(locklease.WorkloadTesteeImport).Name {
+		t.Fatalf("spec.name = %q", gotSpec.Name)
+	}
+	if len(processor.limits) != 4 || processor.limits[0] != 50 {
+		t.Fatalf("limits = %v, want four calls ending with an empty batch", processor.limits)
+	}
+	if lock.releases() != 1 {
+		t.Fatalf("expected lock release once, got %d", lock.releases())
+	}
+}
+
+func TestTesteeImportRunOnceStopsAtIntervalBudget(t *testing.T) {
+	processor := &fakeTesteeImportProcessor{batches: []int{50, 50, 50}}
+	lock := &fakeSchedulerLockManager{}
+	runner := newTesteeImportRunnerWithHooks(newTestTesteeImportOptions(), processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release)
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	runner.now = func() time.Time {
+		calls++
+		if calls == 1 {
+			return start
+		}
+		return start.Add(6 * time.Second)
+	}
+
+	if err := runner.runOnce(context.Background()); err != nil {
+		t.Fatalf("runOnce returned error: %v", err)
+	}
+	if len(processor.limits) != 1 {
+		t.Fatalf("limits = %v, want a single batch once the interval budget is spent", processor.limits)
+	}
+}
+
+func TestTesteeImportRunOnceReturnsProcessorError(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	processor := &fakeTesteeImportProcessor{err: errors.New("store unavailable")}
+	runner := newTesteeImportRunnerWithHooks(newTestTesteeImportOptions(), processor, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release)
+	if err := runner.runOnce(context.Background()); err == nil {
+		t.Fatal("expected processor error")
+	}
+}
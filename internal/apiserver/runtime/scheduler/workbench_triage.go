@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/log"
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/keyspace"
+	"github.com/FangcunMount/qs-server/internal/pkg/redisruntime/observability"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+)
+
+// WorkbenchTriageEscalationRunner
+// 工作台高风险条目升级扫描，在 leader 锁内把超过认领时限仍未认领的条目标记为已升级。
+type WorkbenchTriageEscalationRunner struct {
+	opts      *apiserveroptions.WorkbenchTriageOptions
+	escalator workbenchApp.Escalator
+	leader    leaderLeaseRunner
+}
+
+// NewWorkbenchTriageEscalationRunner 创建工作台升级扫描器，当依赖项可用时创建扫描器。
+func NewWorkbenchTriageEscalationRunner(
+	opts *apiserveroptions.WorkbenchTriageOptions,
+	escalator workbenchApp.Escalator,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+) *WorkbenchTriageEscalationRunner {
+	return newWorkbenchTriageEscalationRunnerWithHooks(
+		opts,
+		escalator,
+		lockManager,
+		lockBuilder,
+		func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error) {
+			return lockManager.AcquireSpec(ctx, spec, key, ttl)
+		},
+		func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error {
+			return lockManager.ReleaseSpec(ctx, spec, key, lease)
+		},
+	)
+}
+
+func newWorkbenchTriageEscalationRunnerWithHooks(
+	opts *apiserveroptions.WorkbenchTriageOptions,
+	escalator workbenchApp.Escalator,
+	lockManager locklease.Manager,
+	lockBuilder *keyspace.Builder,
+	acquireLock func(ctx context.Context, spec locklease.Spec, key string, ttl time.Duration) (*locklease.Lease, bool, error),
+	releaseLock func(ctx context.Context, spec locklease.Spec, key string, lease *locklease.Lease) error,
+) *WorkbenchTriageEscalationRunner {
+	if opts == nil || !opts.Enable {
+		return nil
+	}
+	if escalator == nil {
+		log.Warnf("workbench triage escalation not started (escalator unavailable)")
+		return nil
+	}
+	if opts.Interval <= 0 {
+		log.Warnf("workbench triage escalation not started (interval must be greater than 0)")
+		return nil
+	}
+	if opts.BatchLimit <= 0 {
+		log.Warnf("workbench triage escalation not started (batch_limit must be greater than 0)")
+		return nil
+	}
+	if opts.LockKey == "" {
+		log.Warnf("workbench triage escalation not started (lock_key is empty)")
+		return nil
+	}
+	if opts.LockTTL <= 0 {
+		log.Warnf("workbench triage escalation not started (lock_ttl must be greater than 0)")
+		return nil
+	}
+	if lockManager == nil {
+		observability.ObserveLockDegraded("workbench_triage_escalation", "redis_unavailable")
+		log.Warnf("workbench triage escalation not started (HA lock unavailable: redis client unavailable)")
+		return nil
+	}
+	if acquireLock == nil || releaseLock == nil {
+		log.Warnf("workbench triage escalation not started (lock hooks unavailable)")
+		return nil
+	}
+
+	return &WorkbenchTriageEscalationRunner{
+		opts:      opts,
+		escalator: escalator,
+		leader:    newLeaderLock(workloadSpec(locklease.WorkloadWorkbenchTriageEscalation), opts.LockKey, opts.LockTTL, lockBuilder, acquireLock, releaseLock, leaseRunner(lockManager)),
+	}
+}
+
+// Name 返回扫描器名称。
+func (r *WorkbenchTriageEscalationRunner) Name() string {
+	return "workbench_triage_escalation"
+}
+
+// Start 启动扫描循环。
+func (r *WorkbenchTriageEscalationRunner) Start(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	log.Infof("workbench triage escalation started (interval=%s, high_risk_claim_sla=%s, batch_limit=%d, lock_key=%s, lock_ttl=%s)",
+		r.opts.Interval, r.opts.HighRiskClaimSLA, r.opts.BatchLimit, r.leader.DisplayKey(), r.opts.LockTTL)
+
+	go func() {
+		r.executeTick(ctx)
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.executeTick(ctx)
+			}
+		}
+	}()
+}
+
+func (r *WorkbenchTriageEscalationRunner) executeTick(ctx context.Context) {
+	if err := r.runOnce(ctx); err != nil {
+		log.Warnf("workbench triage escalation tick failed: %v", err)
+	}
+}
+
+func (r *WorkbenchTriageEscalationRunner) runOnce(ctx context.Context) error {
+	return r.leader.Run(ctx, leaderLockRunOptions{
+		AcquireError: "failed to acquire workbench triage escalation lock",
+		OnNotAcquired: func(lockKey string) {
+			log.Debugf("workbench triage escalation tick skipped (lock_key=%s, reason=lock_not_acquired)", lockKey)
+		},
+		OnReleaseError: func(lockKey string, err error) {
+			log.Warnf("failed to release workbench triage escalation lock (lock_key=%s): %v", lockKey, err)
+		},
+	}, func(ctx context.Context) error {
+		escalated, err := r.escalator.EscalateOverdue(ctx, r.opts.BatchLimit)
+		if escalated > 0 {
+			log.Infof("workbench triage escalation escalated %d high risk items", escalated)
+		}
+		return err
+	})
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiserveroptions "github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease"
+	"github.com/FangcunMount/qs-server/internal/pkg/resilience/locklease/redisadapter"
+)
+
+type fakeWorkbenchEscalator struct {
+	limits []int
+}
+
+func (f *fakeWorkbenchEscalator) EscalateOverdue(_ context.Context, limit int) (int, error) {
+	f.limits = append(f.limits, limit)
+	return 2, nil
+}
+
+func newTestWorkbenchTriageOptions() *apiserveroptions.WorkbenchTriageOptions {
+	return &apiserveroptions.WorkbenchTriageOptions{
+		Enable:           true,
+		HighRiskClaimSLA: 4 * time.Hour,
+		Interval:         time.Minute,
+		BatchLimit:       100,
+		LockKey:          "qs:workbench-triage-escalation:test",
+		LockTTL:          30 * time.Second,
+	}
+}
+
+func TestNewWorkbenchTriageEscalationRunnerRequiresDependencies(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	escalator := &fakeWorkbenchEscalator{}
+	if runner := newWorkbenchTriageEscalationRunnerWithHooks(&apiserveroptions.WorkbenchTriageOptions{Enable: false}, escalator, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected disabled runner to return nil")
+	}
+	if runner := newWorkbenchTriageEscalationRunnerWithHooks(newTestWorkbenchTriageOptions(), nil, &redisadapter.Manager{}, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected nil escalator to return nil")
+	}
+	if runner := newWorkbenchTriageEscalationRunnerWithHooks(newTestWorkbenchTriageOptions(), escalator, nil, newTestEvaluationConsistencyLockBuilder(), lock.acquire, lock.release); runner != nil {
+		t.Fatal("expected nil lock manager to return nil")
+	}
+}
+
+func TestWorkbenchTriageEscalationRunOnceUsesLeaderLockAndBatchLimit(t *testing.T) {
+	lock := &fakeSchedulerLockManager{}
+	escalator := &fakeWorkbenchEscalator{}
+	var gotSpec redisadapter.Spec
+	runner := newWorkbenchTriageEscalationRunnerWithHooks(
+		newTestWorkbenchTriageOptions(),
+		escalator,
+		&redisadapter.Manager{},
+		newTestEvaluationConsistencyLockBuilder(),
+		func(ctx context.Context, spec redisadapter.Spec, key string, ttl time.Duration) (*redisadapter.Lease, bool, error) {
+			gotSpec = spec
+			return lock.acquire(ctx, spec, key, ttl)
+		},
+		lock.release,
+	)
+
+	if err := runner.runOnce(context.Background()); err != nil {
+		t.Fatalf("runOnce returned error: %v", err)
+	}
+	if gotSpec.Name != workloadSpec(locklease.WorkloadWorkbenchTriageEscalation).Name {
+		t.Fatalf("spec.name = %q", gotSpec.Name)
+	}
+	if len(escalator.limits) != 1 || escalator.limits[0] != 100 {
+		t.Fatalf("limits = %v, want one call with batch limit", escalator.limits)
+	}
+	if lock.releases() != 1 {
+		t.Fatalf("expected lock release once, got %d", lock.releases())
+	}
+}
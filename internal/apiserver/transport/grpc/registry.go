@@ -6,6 +6,7 @@ import (
 	"github.com/FangcunMount/component-base/pkg/log"
 	"github.com/FangcunMount/component-base/pkg/logger"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
 	cachegovernance "github.com/FangcunMount/qs-server/internal/apiserver/application/cachegovernance"
@@ -14,6 +15,8 @@ import (
 	evaluationworker "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/worker"
 	interpretationAutomation "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/automation"
 	interpretationParticipant "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/participant"
+	interpretationReportPDF "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	interpretationReportShare "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportshare"
 	assessmentintakejourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/assessmentintake"
 	modelcatalogApp "github.com/FangcunMount/qs-server/internal/apiserver/application/modelcatalog"
 	notificationApp "github.com/FangcunMount/qs-server/internal/apiserver/application/notification"
@@ -43,6 +46,7 @@ type Deps struct {
 	Interpretation         InterpretationDeps
 	AssessmentModelCatalog AssessmentModelCatalogDeps
 	Plan                   PlanDeps
+	Consent                ConsentDeps
 	IAM                    IAMDeps
 	PublishedModelCatalog  rulesetport.Catalog
 
@@ -84,6 +88,8 @@ type EvaluationDeps struct {
 type InterpretationDeps struct {
 	AutomationService        interpretationAutomation.Service
 	ParticipantService       interpretationParticipant.Service
+	ReportPDF                interpretationReportPDF.Service
+	ReportShare              interpretationReportShare.Service
 	ReportStatusReporter     *reportstatus.Reporter
 	DelegatedSubjectVerifier *delegatedsubject.Verifier
 }
@@ -94,9 +100,14 @@ type AssessmentModelCatalogDeps struct {
 
 type PlanDeps struct {
 	CommandService         planApp.PlanCommandService
+	QueryService           planApp.PlanQueryService
 	TaskAssessmentResolver planApp.TaskAssessmentResolver
 }
 
+type ConsentDeps struct {
+	Service consentApp.Service
+}
+
 type IAMDeps struct {
 	AuthzSnapshotLoader *iaminfra.AuthzSnapshotLoader
 }
@@ -136,6 +147,9 @@ func (r *Registry) RegisterServices() error {
 	if err := r.registerPlanCommandService(); err != nil {
 		return err
 	}
+	if err := r.registerConsentService(); err != nil {
+		return err
+	}
 
 	logger.L(context.Background()).Infow("All GRPC services registered successfully",
 		"component", "grpc",
@@ -185,6 +199,7 @@ func (r *Registry) registerActorService() error {
 		r.deps.Actor.TesteeManagementService,
 		r.deps.Actor.TesteeQueryService,
 		r.deps.Actor.ClinicianRelationshipService,
+		r.deps.Plan.QueryService,
 	)
 	r.server.RegisterService(actorService)
 	log.Info("   👥 Actor service registered")
@@ -211,7 +226,7 @@ func (r *Registry) registerEvaluationService() error {
 		r.deps.Interpretation.ReportStatusReporter,
 	)
 	r.server.RegisterService(service.NewTesteeEvaluationService(r.deps.Evaluation.TesteeService))
-	r.server.RegisterService(service.NewParticipantReportService(r.deps.Interpretation.ParticipantService, r.deps.Interpretation.DelegatedSubjectVerifier))
+	r.server.RegisterService(service.NewParticipantReportService(r.deps.Interpretation.ParticipantService, r.deps.Interpretation.ReportPDF, r.deps.Interpretation.ReportShare, r.deps.Interpretation.DelegatedSubjectVerifier))
 	assessmentIntakeService := service.NewAssessmentIntakeService(journey, r.deps.Evaluation.IntakeService, r.deps.Survey.AnswerSheetManagementService)
 	evaluationWorkerService := service.NewEvaluationWorkerService(r.deps.Evaluation.WorkerService)
 	interpretationAutomationService := service.NewInterpretationAutomationService(r.deps.Interpretation.AutomationService)
@@ -267,6 +282,18 @@ func (r *Registry) registerPlanCommandService() error {
 	return nil
 }
 
+func (r *Registry) registerConsentService() error {
+	if r.deps.Consent.Service == nil {
+		log.Warn("Consent service is not initialized, skipping consent service registration")
+		return nil
+	}
+
+	consentService := service.NewConsentService(r.deps.Consent.Service)
+	r.server.RegisterService(consentService)
+	log.Info("   📝 Consent service registered")
+	return nil
+}
+
 // GetRegisteredServices 获取已注册的服务列表。
 func (r *Registry) GetRegisteredServices() []string {
 	services := make([]string, 0)
@@ -295,6 +322,9 @@ func (r *Registry) GetRegisteredServices() []string {
 	if r.deps.Plan.CommandService != nil {
 		services = append(services, "PlanCommandService")
 	}
+	if r.deps.Consent.Service != nil {
+		services = append(services, "ConsentService")
+	}
 
 	return services
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
@@ -11,8 +12,12 @@ import (
 
 	"github.com/FangcunMount/component-base/pkg/logger"
 	pb "github.com/FangcunMount/qs-server/api/grpc/gen/actor"
+	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 )
 
 // ActorService Actor gRPC 服务 - C 端接口
@@ -23,6 +28,7 @@ type ActorService struct {
 	managementService            testeeApp.TesteeManagementService
 	queryService                 testeeApp.TesteeQueryService
 	clinicianRelationshipService clinicianApp.ClinicianRelationshipService
+	planQueryService             planApp.PlanQueryService
 }
 
 // NewActorService 创建 Actor gRPC 服务
@@ -31,12 +37,14 @@ func NewActorService(
 	managementService testeeApp.TesteeManagementService,
 	queryService testeeApp.TesteeQueryService,
 	clinicianRelationshipService clinicianApp.ClinicianRelationshipService,
+	planQueryService planApp.PlanQueryService,
 ) *ActorService {
 	return &ActorService{
 		registrationService:          registrationService,
 		managementService:            managementService,
 		queryService:                 queryService,
 		clinicianRelationshipService: clinicianRelationshipService,
+		planQueryService:             planQueryService,
 	}
 }
 
@@ -236,7 +244,8 @@ func (s *ActorService) TesteeExists(ctx context.Context, req *pb.TesteeExistsReq
 }
 
 // ListTesteesByOrg 根据机构查询受试者列表
-// @Description 查询指定机构下的受试者列表
+// @Description 查询指定机构下的受试者列表。机构级枚举不对应具体监护人，按调用方授权快照脱敏：
+// 机构管理员不脱敏、统计人员去标识，其余（含无快照的服务调用）按 Restricted 处理。
 func (s *ActorService) ListTesteesByOrg(ctx context.Context, req *pb.ListTesteesByOrgRequest) (*pb.TesteeListResponse, error) {
 	orgID, err := requestOrgIDInt64(ctx, req.OrgId)
 	if err != nil {
@@ -268,6 +277,12 @@ func (s *ActorService) ListTesteesByOrg(ctx context.Context, req *pb.ListTestees
 	if convErr != nil {
 		return nil, convErr
 	}
+	snapshot, _ := authzapp.FromContext(ctx)
+	policy := actorAccessApp.TesteeRedactionPolicy(snapshot)
+	now := time.Now()
+	for _, item := range resp.Items {
+		redactProtoTestee(item, policy, now)
+	}
 
 	return resp, nil
 }
@@ -349,6 +364,40 @@ func (s *ActorService) GetTesteeCareContext(ctx context.Context, req *pb.GetTest
 	}, nil
 }
 
+// ExportTesteeCalendar 导出受试者待完成计划任务的 ICS 日历
+// 与 apiserver REST 导出共用同一日历构建逻辑；受试者访问权限由 collection-server 路由层校验。
+func (s *ActorService) ExportTesteeCalendar(ctx context.Context, req *pb.ExportTesteeCalendarRequest) (*pb.TesteeCalendarResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "受试者ID不能为空")
+	}
+	if s.planQueryService == nil {
+		return nil, status.Error(codes.Unavailable, "plan query service unavailable")
+	}
+	if _, err := s.queryService.GetByID(ctx, req.Id); err != nil {
+		logger.L(ctx).Errorw("Failed to get testee for calendar export",
+			"action", "export_testee_calendar",
+			"testee_id", req.Id,
+			"error", err.Error(),
+		)
+		return nil, status.Error(codes.NotFound, "受试者不存在")
+	}
+
+	result, err := s.planQueryService.ExportTesteeCalendar(ctx, strconv.FormatUint(req.Id, 10))
+	if err != nil {
+		logger.L(ctx).Errorw("Failed to export testee calendar",
+			"action", "export_testee_calendar",
+			"testee_id", req.Id,
+			"error", err.Error(),
+		)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.TesteeCalendarResponse{
+		FileName:   result.FileName,
+		Content:    result.Content,
+		EventCount: int32(result.EventCount),
+	}, nil
+}
+
 // toProtoTesteeResponse 转换为 proto TesteeResponse
 func (s *ActorService) toProtoTesteeResponse(result *testeeApp.TesteeResult) (*pb.TesteeResponse, error) {
 	if result == nil {
@@ -397,6 +446,25 @@ func (s *ActorService) toProtoTesteeResponse(result *testeeApp.TesteeResult) (*p
 	return resp, nil
 }
 
+// redactProtoTestee 按脱敏策略就地改写受试者响应。
+func redactProtoTestee(resp *pb.TesteeResponse, policy redaction.Policy, now time.Time) {
+	if resp == nil || policy.IsFull() {
+		return
+	}
+	resp.Name = policy.RedactName(resp.Name)
+	if resp.Birthday != nil {
+		birthday := resp.Birthday.AsTime()
+		resp.AgeBand = policy.AgeBandOf(&birthday, now)
+		if policy.RedactBirthday(&birthday) == nil {
+			resp.Birthday = nil
+		}
+	}
+	if policy.RedactProfileID(&resp.IamProfileId) == nil {
+		resp.IamProfileId = 0
+	}
+	resp.Redacted = true
+}
+
 // toProtoTesteeListResponse 转换为 proto TesteeListResponse
 func (s *ActorService) toProtoTesteeListResponse(result *testeeApp.TesteeListResult) (*pb.TesteeListResponse, error) {
 	if result == nil {
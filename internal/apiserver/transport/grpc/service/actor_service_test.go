@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/FangcunMount/qs-server/api/grpc/gen/actor"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRedactProtoTesteeRestrictedHidesIdentity(t *testing.T) {
+	resp := &pb.TesteeResponse{
+		Id:           7,
+		Name:         "李四",
+		IamProfileId: 9001,
+		Birthday:     timestamppb.New(time.Date(2010, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	redactProtoTestee(resp, redaction.Restricted, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	if resp.Id != 7 || resp.Name != "李*" || resp.IamProfileId != 0 || resp.Birthday != nil {
+		t.Fatalf("unexpected redacted testee: %+v", resp)
+	}
+	if resp.AgeBand != "12-17" || !resp.Redacted {
+		t.Fatalf("age_band/redacted = %q/%v", resp.AgeBand, resp.Redacted)
+	}
+}
@@ -104,8 +104,9 @@ func (s *AnswerSheetService) SaveAnswerSheet(ctx context.Context, req *pb.SaveAn
 
 	// 转换响应
 	return &pb.SaveAnswerSheetResponse{
-		Id:      result.ID,
-		Message: "答卷提交成功",
+		Id:            result.ID,
+		Message:       "答卷提交成功",
+		SafeMessaging: toSafeMessagingProto(result.SafeMessaging),
 	}, nil
 }
 
@@ -174,7 +175,7 @@ func (s *AnswerSheetService) LookupAnswerSheetSubmission(
 	if result == nil || result.ID == 0 {
 		return nil, status.Error(codes.Unavailable, "durable submission lookup returned no id")
 	}
-	return &pb.LookupAnswerSheetSubmissionResponse{Found: true, Id: result.ID}, nil
+	return &pb.LookupAnswerSheetSubmissionResponse{Found: true, Id: result.ID, SafeMessaging: toSafeMessagingProto(result.SafeMessaging)}, nil
 }
 
 // GetAnswerSheet 获取答卷详情（C端）。
@@ -301,6 +302,18 @@ func (s *AnswerSheetService) valueToString(value interface{}) string {
 	}
 }
 
+// toSafeMessagingProto 转换安全提示；答卷未命中关键条目时为 nil。
+func toSafeMessagingProto(messaging *answersheet.SafeMessaging) *pb.SafeMessaging {
+	if messaging == nil {
+		return nil
+	}
+	result := &pb.SafeMessaging{Title: messaging.Title, Message: messaging.Message}
+	for _, hotline := range messaging.Hotlines {
+		result.Hotlines = append(result.Hotlines, &pb.Hotline{Name: hotline.Name, Number: hotline.Number, Hours: hotline.Hours})
+	}
+	return result
+}
+
 func toAnswerSheetGRPCError(err error) error {
 	if err == nil {
 		return nil
@@ -314,6 +327,8 @@ func toAnswerSheetGRPCError(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case errorCode.ErrPermissionDenied:
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errorCode.ErrConsentRequired:
+		return status.Error(codes.FailedPrecondition, err.Error())
 	case errorCode.ErrConflict:
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errorCode.ErrDatabase, errorCode.ErrInternalServerError:
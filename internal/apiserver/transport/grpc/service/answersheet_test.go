@@ -149,6 +149,36 @@ func TestAnswerSheetServiceSaveAnswerSheetDecodesStructuredValues(t *testing.T)
 	}
 }
 
+func TestAnswerSheetServiceSaveAnswerSheetReturnsSafeMessaging(t *testing.T) {
+	t.Parallel()
+
+	svc := NewAnswerSheetService(&submissionServiceStub{
+		submitFunc: func(context.Context, appanswersheet.SubmitAnswerSheetDTO) (*appanswersheet.AnswerSheetResult, error) {
+			return &appanswersheet.AnswerSheetResult{
+				ID:            42,
+				CriticalItems: []appanswersheet.CriticalItemResult{{QuestionCode: "q9", Category: "self_harm", Trigger: "A"}},
+				SafeMessaging: &appanswersheet.SafeMessaging{
+					Title:    "你并不孤单",
+					Hotlines: []appanswersheet.Hotline{{Name: "全国心理援助热线", Number: "12356", Hours: "24小时"}},
+				},
+			}, nil
+		},
+	})
+
+	resp, err := svc.SaveAnswerSheet(context.Background(), &pb.SaveAnswerSheetRequest{
+		QuestionnaireCode: "QNR-001", QuestionnaireVersion: "1.0.0", IdempotencyKey: "submit-0002",
+		OrgId: 1, WriterId: 101, TesteeId: 202,
+		Answers: []*pb.Answer{{QuestionCode: "q9", QuestionType: "Radio", Value: "A"}},
+	})
+	if err != nil {
+		t.Fatalf("SaveAnswerSheet returned error: %v", err)
+	}
+	messaging := resp.GetSafeMessaging()
+	if messaging.GetTitle() != "你并不孤单" || len(messaging.GetHotlines()) != 1 || messaging.GetHotlines()[0].GetNumber() != "12356" {
+		t.Fatalf("safe messaging = %#v", messaging)
+	}
+}
+
 func TestAnswerSheetServiceSaveAnswerSheetMapsInvalidDomainError(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+
+	pkgerrors "github.com/FangcunMount/component-base/pkg/errors"
+	pb "github.com/FangcunMount/qs-server/api/grpc/gen/consent"
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/apiserver/port/consentgate"
+	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConsentService 知情同意 gRPC 服务，供 collection-server 的受试者侧签署与提交前校验使用。
+type ConsentService struct {
+	pb.UnimplementedConsentServiceServer
+	consentService consentApp.Service
+}
+
+func NewConsentService(consentService consentApp.Service) *ConsentService {
+	return &ConsentService{consentService: consentService}
+}
+
+func (s *ConsentService) RegisterService(server *grpc.Server) {
+	pb.RegisterConsentServiceServer(server, s)
+}
+
+func (s *ConsentService) ListRequiredConsents(ctx context.Context, req *pb.ListRequiredConsentsRequest) (*pb.ListRequiredConsentsResponse, error) {
+	if req.GetTesteeId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 不能为空")
+	}
+	result, err := s.consentService.ListRequirements(ctx, consentApp.RequirementQuery{
+		TesteeID:          req.GetTesteeId(),
+		QuestionnaireCode: req.GetQuestionnaireCode(),
+		EntryID:           req.GetEntryId(),
+	})
+	if err != nil {
+		return nil, toConsentGRPCError(err)
+	}
+
+	resp := &pb.ListRequiredConsentsResponse{
+		TesteeId:    result.TesteeID,
+		MinorTestee: result.MinorTestee,
+		Satisfied:   result.Satisfied,
+		Items:       make([]*pb.RequiredConsent, 0, len(result.Items)),
+	}
+	for _, item := range result.Items {
+		document, err := toPBConsentDocument(item.Document)
+		if err != nil {
+			return nil, err
+		}
+		acceptance, err := toPBConsentAcceptance(item.Acceptance)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, &pb.RequiredConsent{Document: document, Acceptance: acceptance})
+	}
+	return resp, nil
+}
+
+func (s *ConsentService) AcceptConsent(ctx context.Context, req *pb.AcceptConsentRequest) (*pb.ConsentAcceptance, error) {
+	if req.GetTesteeId() == 0 || req.GetDocumentId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 与 document_id 不能为空")
+	}
+	if req.GetFillerUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "filler_user_id 不能为空")
+	}
+	result, err := s.consentService.Accept(ctx, consentApp.AcceptDTO{
+		TesteeID:         req.GetTesteeId(),
+		DocumentID:       req.GetDocumentId(),
+		FillerUserID:     req.GetFillerUserId(),
+		FillerType:       req.GetFillerType(),
+		GuardianRelation: req.GetGuardianRelation(),
+		IP:               req.GetIp(),
+		UserAgent:        req.GetUserAgent(),
+		RequestID:        req.GetRequestId(),
+	})
+	if err != nil {
+		return nil, toConsentGRPCError(err)
+	}
+	return toPBConsentAcceptance(result)
+}
+
+func (s *ConsentService) WithdrawConsent(ctx context.Context, req *pb.WithdrawConsentRequest) (*pb.ConsentAcceptance, error) {
+	if req.GetTesteeId() == 0 || req.GetAcceptanceId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 与 acceptance_id 不能为空")
+	}
+	result, err := s.consentService.Withdraw(ctx, consentApp.WithdrawDTO{
+		TesteeID:     req.GetTesteeId(),
+		AcceptanceID: req.GetAcceptanceId(),
+		OperatorID:   req.GetOperatorUserId(),
+		Reason:       req.GetReason(),
+	})
+	if err != nil {
+		return nil, toConsentGRPCError(err)
+	}
+	return toPBConsentAcceptance(result)
+}
+
+func (s *ConsentService) CheckSubmissionConsent(ctx context.Context, req *pb.CheckSubmissionConsentRequest) (*pb.CheckSubmissionConsentResponse, error) {
+	if req.GetTesteeId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 不能为空")
+	}
+	orgID, err := requestInt64FromUint64("org_id", req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.consentService.CheckSubmission(ctx, consentgate.CheckRequest{
+		OrgID:             orgID,
+		TesteeID:          req.GetTesteeId(),
+		QuestionnaireCode: req.GetQuestionnaireCode(),
+		EntryID:           req.GetEntryId(),
+	}); err != nil {
+		return nil, toConsentGRPCError(err)
+	}
+	return &pb.CheckSubmissionConsentResponse{Satisfied: true}, nil
+}
+
+func toConsentGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	coder := pkgerrors.ParseCoder(err)
+	switch coder.Code() {
+	case errorCode.ErrInvalidArgument, errorCode.ErrValidation, errorCode.ErrBind, errorCode.ErrConsentInvalid:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errorCode.ErrConsentDocumentNotFound, errorCode.ErrConsentAcceptanceNotFound, errorCode.ErrUserNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case errorCode.ErrConsentRequired, errorCode.ErrConflict:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errorCode.ErrPermissionDenied:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errorCode.ErrDatabase:
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toPBConsentDocument(result *consentApp.DocumentResult) (*pb.ConsentDocument, error) {
+	if result == nil {
+		return nil, nil
+	}
+	version, err := protoInt32FromInt("version", result.Version)
+	if err != nil {
+		return nil, err
+	}
+	document := &pb.ConsentDocument{
+		Id:          result.ID,
+		ScopeKind:   result.ScopeKind,
+		ScopeRef:    result.ScopeRef,
+		Version:     version,
+		Title:       result.Title,
+		Body:        result.Body,
+		ContentHash: result.ContentHash,
+	}
+	if result.PublishedAt != nil {
+		document.PublishedAt = timestamppb.New(*result.PublishedAt)
+	}
+	return document, nil
+}
+
+func toPBConsentAcceptance(result *consentApp.AcceptanceResult) (*pb.ConsentAcceptance, error) {
+	if result == nil {
+		return nil, nil
+	}
+	version, err := protoInt32FromInt("document_version", result.DocumentVersion)
+	if err != nil {
+		return nil, err
+	}
+	acceptance := &pb.ConsentAcceptance{
+		Id:               result.ID,
+		DocumentId:       result.DocumentID,
+		DocumentVersion:  version,
+		TesteeId:         result.TesteeID,
+		FillerUserId:     result.FillerUserID,
+		FillerType:       result.FillerType,
+		GuardianRelation: result.GuardianRelation,
+		AcceptedAt:       timestamppb.New(result.AcceptedAt),
+		Active:           result.Active,
+		WithdrawReason:   result.WithdrawReason,
+	}
+	if result.WithdrawnAt != nil {
+		acceptance.WithdrawnAt = timestamppb.New(*result.WithdrawnAt)
+	}
+	return acceptance, nil
+}
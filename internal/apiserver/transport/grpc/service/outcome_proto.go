@@ -127,6 +127,9 @@ func toProtoParticipantReport(result *interpretationParticipant.Report) *interpr
 		}
 		report.Suggestions = append(report.Suggestions, item)
 	}
+	if result.ClinicianAddendum != nil {
+		report.ClinicianAddendum = &interpretationpb.ClinicianAddendum{Content: result.ClinicianAddendum.Content, ReviewerName: result.ClinicianAddendum.ReviewerName, ReviewStatus: result.ClinicianAddendum.ReviewStatus, ReviewedAt: result.ClinicianAddendum.ReviewedAt.Format("2006-01-02 15:04:05")}
+	}
 	if result.ModelExtra != nil {
 		report.ModelExtra = &interpretationpb.ModelExtra{Kind: result.ModelExtra.Kind, TypeCode: result.ModelExtra.TypeCode, TypeName: result.ModelExtra.TypeName, OneLiner: result.ModelExtra.OneLiner, ImageUrl: result.ModelExtra.ImageURL, MatchPercent: result.ModelExtra.MatchPercent, IsSpecial: result.ModelExtra.IsSpecial, SpecialTrigger: result.ModelExtra.SpecialTrigger, Commentary: result.ModelExtra.Commentary}
 		if result.ModelExtra.Rarity != nil {
@@ -2,24 +2,32 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pkgerrors "github.com/FangcunMount/component-base/pkg/errors"
 	pb "github.com/FangcunMount/qs-server/api/grpc/gen/interpretation"
 	participant "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/participant"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportshare"
+	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
 	"github.com/FangcunMount/qs-server/internal/pkg/delegatedsubject"
 )
 
 type ParticipantReportService struct {
 	pb.UnimplementedParticipantReportServiceServer
 	service           participant.Service
+	reportPDF         reportpdf.Service
+	reportShare       reportshare.Service
 	delegatedVerifier *delegatedsubject.Verifier
 }
 
-func NewParticipantReportService(service participant.Service, delegatedVerifier *delegatedsubject.Verifier) *ParticipantReportService {
-	return &ParticipantReportService{service: service, delegatedVerifier: delegatedVerifier}
+func NewParticipantReportService(service participant.Service, reportPDF reportpdf.Service, reportShare reportshare.Service, delegatedVerifier *delegatedsubject.Verifier) *ParticipantReportService {
+	return &ParticipantReportService{service: service, reportPDF: reportPDF, reportShare: reportShare, delegatedVerifier: delegatedVerifier}
 }
 
 func (s *ParticipantReportService) RegisterService(server *grpc.Server) {
@@ -83,3 +91,59 @@ func (s *ParticipantReportService) ListMyReports(ctx context.Context, req *pb.Li
 	}
 	return &pb.ListMyReportsResponse{Items: items, Total: total, Page: pageOut, PageSize: pageSizeOut, TotalPages: totalPages}, nil
 }
+
+// IssueReportPDFLink 为受试者本人签发报告 PDF 下载令牌。
+func (s *ParticipantReportService) IssueReportPDFLink(ctx context.Context, req *pb.IssueReportPDFLinkRequest) (*pb.IssueReportPDFLinkResponse, error) {
+	if req.TesteeId == 0 || req.AssessmentId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 和 assessment_id 不能为空")
+	}
+	if s.reportPDF == nil {
+		return nil, status.Error(codes.Unimplemented, "报告 PDF 未启用")
+	}
+	if err := s.authorizeDelegatedSubject(ctx, req.TesteeId, delegatedsubject.PurposeIssueReportPDFLink); err != nil {
+		return nil, err
+	}
+	link, err := s.reportPDF.IssueParticipantLink(ctx, req.TesteeId, req.AssessmentId)
+	if err != nil {
+		return nil, toReportPDFGRPCError(err)
+	}
+	return &pb.IssueReportPDFLinkResponse{
+		Token:       link.Token,
+		ExpiresAt:   link.ExpiresAt.Format(time.RFC3339),
+		FileName:    link.FileName,
+		ContentHash: link.ContentHash,
+	}, nil
+}
+
+// DownloadReportPDF 按签名令牌返回报告 PDF；令牌本身即凭证，不要求委托主体。
+func (s *ParticipantReportService) DownloadReportPDF(ctx context.Context, req *pb.DownloadReportPDFRequest) (*pb.DownloadReportPDFResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token 不能为空")
+	}
+	if s.reportPDF == nil {
+		return nil, status.Error(codes.Unimplemented, "报告 PDF 未启用")
+	}
+	download, err := s.reportPDF.Open(ctx, req.Token)
+	if err != nil {
+		return nil, toReportPDFGRPCError(err)
+	}
+	defer func() { _ = download.Body.Close() }()
+	content, err := io.ReadAll(download.Body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "读取报告 PDF 失败")
+	}
+	return &pb.DownloadReportPDFResponse{Content: content, FileName: download.FileName, ContentType: download.ContentType}, nil
+}
+
+func toReportPDFGRPCError(err error) error {
+	switch pkgerrors.ParseCoder(err).Code() {
+	case errorCode.ErrReportPDFNotReady:
+		return status.Error(codes.NotFound, err.Error())
+	case errorCode.ErrReportPDFLinkInvalid:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errorCode.ErrInvalidArgument:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return toAssessmentQueryGRPCError(err)
+	}
+}
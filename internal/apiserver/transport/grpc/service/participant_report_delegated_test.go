@@ -59,7 +59,7 @@ func withMTLSWorkload(ctx context.Context, serviceName string) context.Context {
 }
 
 func TestParticipantReportServiceRejectsMissingDelegatedSubject(t *testing.T) {
-	svc := NewParticipantReportService(&fakeParticipantReportService{}, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(&fakeParticipantReportService{}, nil, nil, testDelegatedVerifier(t))
 	_, err := svc.GetAssessmentReport(context.Background(), &pb.GetAssessmentReportRequest{TesteeId: 7, AssessmentId: 42})
 	if status.Code(err) != codes.Unauthenticated {
 		t.Fatalf("code = %v, want Unauthenticated", status.Code(err))
@@ -67,7 +67,7 @@ func TestParticipantReportServiceRejectsMissingDelegatedSubject(t *testing.T) {
 }
 
 func TestParticipantReportServiceRejectsTamperedTesteeInRequest(t *testing.T) {
-	svc := NewParticipantReportService(&fakeParticipantReportService{}, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(&fakeParticipantReportService{}, nil, nil, testDelegatedVerifier(t))
 	ctx := testDelegatedContext(t, 7)
 	_, err := svc.GetAssessmentReport(ctx, &pb.GetAssessmentReportRequest{TesteeId: 8, AssessmentId: 42})
 	if status.Code(err) != codes.PermissionDenied {
@@ -85,7 +85,7 @@ func TestParticipantReportServiceRejectsExpiredDelegatedSubject(t *testing.T) {
 		t.Fatalf("SignWithExpiryForTest() error = %v", err)
 	}
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(delegatedsubject.MetadataKey, raw))
-	svc := NewParticipantReportService(&fakeParticipantReportService{}, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(&fakeParticipantReportService{}, nil, nil, testDelegatedVerifier(t))
 	_, err = svc.GetAssessmentReport(ctx, &pb.GetAssessmentReportRequest{TesteeId: 7, AssessmentId: 42})
 	if status.Code(err) != codes.Unauthenticated {
 		t.Fatalf("code = %v, want Unauthenticated", status.Code(err))
@@ -94,7 +94,7 @@ func TestParticipantReportServiceRejectsExpiredDelegatedSubject(t *testing.T) {
 
 func TestParticipantReportServiceRejectsBadDelegatedSignature(t *testing.T) {
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(delegatedsubject.MetadataKey, "bad.token"))
-	svc := NewParticipantReportService(&fakeParticipantReportService{}, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(&fakeParticipantReportService{}, nil, nil, testDelegatedVerifier(t))
 	_, err := svc.GetAssessmentReport(ctx, &pb.GetAssessmentReportRequest{TesteeId: 7, AssessmentId: 42})
 	if status.Code(err) != codes.Unauthenticated {
 		t.Fatalf("code = %v, want Unauthenticated", status.Code(err))
@@ -104,6 +104,8 @@ func TestParticipantReportServiceRejectsBadDelegatedSignature(t *testing.T) {
 func TestParticipantReportServiceAllowsValidDelegationButRejectsWrongAssessment(t *testing.T) {
 	svc := NewParticipantReportService(
 		&fakeParticipantReportService{err: evalerrors.Forbidden("无权访问此测评")},
+		nil,
+		nil,
 		testDelegatedVerifier(t),
 	)
 	ctx := testDelegatedContext(t, 7)
@@ -115,7 +117,7 @@ func TestParticipantReportServiceAllowsValidDelegationButRejectsWrongAssessment(
 
 func TestParticipantReportServiceReturnsReportWithValidDelegation(t *testing.T) {
 	reportSvc := &fakeParticipantReportService{report: &interpretationParticipant.Report{AssessmentID: 42}}
-	svc := NewParticipantReportService(reportSvc, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(reportSvc, nil, nil, testDelegatedVerifier(t))
 	ctx := withMTLSWorkload(testDelegatedContext(t, 7), serviceidentity.CollectionServerCertificateCommonName)
 	resp, err := svc.GetAssessmentReport(ctx, &pb.GetAssessmentReportRequest{TesteeId: 7, AssessmentId: 42})
 	if err != nil {
@@ -127,7 +129,7 @@ func TestParticipantReportServiceReturnsReportWithValidDelegation(t *testing.T)
 }
 
 func TestParticipantReportServiceRejectsUntrustedWorkloadIdentity(t *testing.T) {
-	svc := NewParticipantReportService(&fakeParticipantReportService{}, testDelegatedVerifier(t))
+	svc := NewParticipantReportService(&fakeParticipantReportService{}, nil, nil, testDelegatedVerifier(t))
 	ctx := testDelegatedContext(t, 7)
 	ctx = withMTLSWorkload(ctx, "qs-worker.svc")
 	_, err := svc.GetAssessmentReport(ctx, &pb.GetAssessmentReportRequest{TesteeId: 7, AssessmentId: 42})
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pkgerrors "github.com/FangcunMount/component-base/pkg/errors"
+	pb "github.com/FangcunMount/qs-server/api/grpc/gen/interpretation"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportshare"
+	errorCode "github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/delegatedsubject"
+)
+
+// CreateReportShare 为受试者本人的测评报告创建对外分享授权；令牌或访问码只在本次响应中返回。
+func (s *ParticipantReportService) CreateReportShare(ctx context.Context, req *pb.CreateReportShareRequest) (*pb.CreateReportShareResponse, error) {
+	if req.TesteeId == 0 || req.AssessmentId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 和 assessment_id 不能为空")
+	}
+	if req.TtlSeconds < 0 || req.MaxViews < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds 和 max_views 不能为负数")
+	}
+	if s.reportShare == nil {
+		return nil, status.Error(codes.Unimplemented, "报告分享未启用")
+	}
+	if err := s.authorizeDelegatedSubject(ctx, req.TesteeId, delegatedsubject.PurposeManageReportShares); err != nil {
+		return nil, err
+	}
+	created, err := s.reportShare.Create(ctx, reportshare.CreateInput{
+		TesteeID:     req.TesteeId,
+		AssessmentID: req.AssessmentId,
+		Kind:         reportshare.Kind(req.Kind),
+		Audience:     req.Audience,
+		TTL:          time.Duration(req.TtlSeconds) * time.Second,
+		MaxViews:     int(req.MaxViews),
+		PIN:          req.Pin,
+		Label:        req.Label,
+	})
+	if err != nil {
+		return nil, toReportShareGRPCError(err)
+	}
+	share, err := toProtoReportShare(created.Share)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateReportShareResponse{Share: share, Token: created.Token, AccessCode: created.AccessCode}, nil
+}
+
+// ListReportShares 列出受试者在某次测评上的分享授权。
+func (s *ParticipantReportService) ListReportShares(ctx context.Context, req *pb.ListReportSharesRequest) (*pb.ListReportSharesResponse, error) {
+	if req.TesteeId == 0 || req.AssessmentId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 和 assessment_id 不能为空")
+	}
+	if s.reportShare == nil {
+		return nil, status.Error(codes.Unimplemented, "报告分享未启用")
+	}
+	if err := s.authorizeDelegatedSubject(ctx, req.TesteeId, delegatedsubject.PurposeManageReportShares); err != nil {
+		return nil, err
+	}
+	shares, err := s.reportShare.List(ctx, req.TesteeId, req.AssessmentId)
+	if err != nil {
+		return nil, toReportShareGRPCError(err)
+	}
+	items := make([]*pb.ReportShare, 0, len(shares))
+	for _, item := range shares {
+		share, err := toProtoReportShare(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, share)
+	}
+	return &pb.ListReportSharesResponse{Items: items}, nil
+}
+
+// RevokeReportShare 撤销受试者本人创建的分享授权。
+func (s *ParticipantReportService) RevokeReportShare(ctx context.Context, req *pb.RevokeReportShareRequest) (*pb.RevokeReportShareResponse, error) {
+	if req.TesteeId == 0 || req.ShareId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "testee_id 和 share_id 不能为空")
+	}
+	if s.reportShare == nil {
+		return nil, status.Error(codes.Unimplemented, "报告分享未启用")
+	}
+	if err := s.authorizeDelegatedSubject(ctx, req.TesteeId, delegatedsubject.PurposeManageReportShares); err != nil {
+		return nil, err
+	}
+	revoked, err := s.reportShare.Revoke(ctx, req.TesteeId, req.ShareId)
+	if err != nil {
+		return nil, toReportShareGRPCError(err)
+	}
+	share, err := toProtoReportShare(revoked)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RevokeReportShareResponse{Share: share}, nil
+}
+
+// OpenReportShare 按分享令牌或访问码返回只读报告；令牌或访问码本身即凭证，不要求委托主体。
+func (s *ParticipantReportService) OpenReportShare(ctx context.Context, req *pb.OpenReportShareRequest) (*pb.OpenReportShareResponse, error) {
+	if req.Token == "" && req.AccessCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "token 或 access_code 不能为空")
+	}
+	if s.reportShare == nil {
+		return nil, status.Error(codes.Unimplemented, "报告分享未启用")
+	}
+	view, err := s.reportShare.Open(ctx, reportshare.OpenInput{
+		Token:      req.Token,
+		AccessCode: req.AccessCode,
+		PIN:        req.Pin,
+		Client:     reportshare.Client{IP: req.ClientIp, UserAgent: req.UserAgent},
+	})
+	if err != nil {
+		return nil, toReportShareGRPCError(err)
+	}
+	remaining, err := protoInt32FromInt("remaining_views", view.RemainingViews)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OpenReportShareResponse{
+		Report:         toProtoParticipantReport(view.Report),
+		Audience:       view.Audience,
+		ExpiresAt:      view.ExpiresAt.Format(time.RFC3339),
+		RemainingViews: remaining,
+	}, nil
+}
+
+func toProtoReportShare(share *reportshare.Share) (*pb.ReportShare, error) {
+	maxViews, err := protoInt32FromInt("max_views", share.MaxViews)
+	if err != nil {
+		return nil, err
+	}
+	viewCount, err := protoInt32FromInt("view_count", share.ViewCount)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReportShare{
+		Id:           share.ID,
+		AssessmentId: share.AssessmentID,
+		Kind:         string(share.Kind),
+		Audience:     share.Audience.String(),
+		Label:        share.Label,
+		PinProtected: share.PINProtected,
+		MaxViews:     maxViews,
+		ViewCount:    viewCount,
+		Status:       string(share.Status),
+		ExpiresAt:    share.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:    share.CreatedAt.Format(time.RFC3339),
+		LastViewedAt: formatOptionalRFC3339(share.LastViewedAt),
+		RevokedAt:    formatOptionalRFC3339(share.RevokedAt),
+	}, nil
+}
+
+func formatOptionalRFC3339(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func toReportShareGRPCError(err error) error {
+	switch pkgerrors.ParseCoder(err).Code() {
+	case errorCode.ErrReportShareNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case errorCode.ErrReportShareUnavailable:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errorCode.ErrReportSharePINRequired:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errorCode.ErrReportShareLimitExceeded:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errorCode.ErrInvalidArgument:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return toAssessmentQueryGRPCError(err)
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// AccessAuditHandler 受试者数据访问审计查询处理器（机构管理员）。
+type AccessAuditHandler struct {
+	*BaseHandler
+	service accessAuditApp.Service
+}
+
+func NewAccessAuditHandler(service accessAuditApp.Service) *AccessAuditHandler {
+	return &AccessAuditHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// ListAccessAudits godoc
+// @Summary 查询受试者数据访问审计
+// @Description 按序号倒序返回机构内的访问记录。from/to 支持 RFC3339 或 YYYY-MM-DD（to 为日期时包含当天）。
+// @Tags access-audits
+// @Security BearerAuth
+// @Produce json
+// @Param actor_user_id query string false "操作者用户ID"
+// @Param testee_id query string false "受试者ID"
+// @Param resource_type query string false "资源类型"
+// @Param result query string false "访问结果"
+// @Param from query string false "开始时间"
+// @Param to query string false "结束时间"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页数量"
+// @Success 200 {object} response.AccessAuditListResponse
+// @Router /api/v1/access-audits [get]
+func (h *AccessAuditHandler) ListAccessAudits(c *gin.Context) {
+	filter, ok := h.accessAuditFilter(c)
+	if !ok {
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.List(c.Request.Context(), accessAuditApp.ListQuery{Filter: filter, Page: page, PageSize: pageSize})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewAccessAuditListResponse(result))
+}
+
+// ExportAccessAudits godoc
+// @Summary 导出受试者数据访问审计 CSV
+// @Description 必须指定 from 与 to；按序号升序导出，包含 prev_hash/hash 列以便离线复核。
+// @Tags access-audits
+// @Security BearerAuth
+// @Produce text/csv
+// @Param from query string true "开始时间"
+// @Param to query string true "结束时间"
+// @Success 200 {file} file
+// @Router /api/v1/access-audits/export [get]
+func (h *AccessAuditHandler) ExportAccessAudits(c *gin.Context) {
+	filter, ok := h.accessAuditFilter(c)
+	if !ok {
+		return
+	}
+	value, err := h.service.ExportCSV(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", value.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", value.Content)
+}
+
+// VerifyAccessAuditChain godoc
+// @Summary 校验访问审计哈希链
+// @Description 从第一条记录开始重算哈希，发现记录被修改、删除或断号时返回第一处断点。
+// @Tags access-audits
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.AccessAuditChainVerificationResponse
+// @Router /api/v1/access-audits/verify [get]
+func (h *AccessAuditHandler) VerifyAccessAuditChain(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.service.VerifyChain(c.Request.Context(), orgID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewAccessAuditChainVerificationResponse(result))
+}
+
+func (h *AccessAuditHandler) accessAuditFilter(c *gin.Context) (accessAuditApp.Filter, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return accessAuditApp.Filter{}, false
+	}
+	filter := accessAuditApp.Filter{
+		OrgID:        orgID,
+		ResourceType: accessAuditApp.ResourceType(strings.TrimSpace(c.Query("resource_type"))),
+		Result:       accessAuditApp.Result(strings.TrimSpace(c.Query("result"))),
+	}
+	if raw := strings.TrimSpace(c.Query("actor_user_id")); raw != "" {
+		if filter.ActorUserID, err = strconv.ParseInt(raw, 10, 64); err != nil || filter.ActorUserID <= 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid actor_user_id"))
+			return accessAuditApp.Filter{}, false
+		}
+	}
+	if raw := strings.TrimSpace(c.Query("testee_id")); raw != "" {
+		if filter.TesteeID, err = strconv.ParseUint(raw, 10, 64); err != nil || filter.TesteeID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+			return accessAuditApp.Filter{}, false
+		}
+	}
+	if filter.From, err = parseAccessAuditTime(c.Query("from"), false); err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "from 格式无效，必须为 RFC3339 或 YYYY-MM-DD"))
+		return accessAuditApp.Filter{}, false
+	}
+	if filter.To, err = parseAccessAuditTime(c.Query("to"), true); err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "to 格式无效，必须为 RFC3339 或 YYYY-MM-DD"))
+		return accessAuditApp.Filter{}, false
+	}
+	return filter, true
+}
+
+// parseAccessAuditTime 解析 RFC3339 或本地日期；inclusiveEnd 为 true 时日期取次日零点作为开区间上界。
+func parseAccessAuditTime(raw string, inclusiveEnd bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if inclusiveEnd {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return parsed, nil
+}
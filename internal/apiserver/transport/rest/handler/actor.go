@@ -86,7 +86,7 @@ func (h *TesteeHandler) GetTestee(c *gin.Context) {
 		return
 	}
 
-	orgID, _, err := h.validateProtectedTesteeAccess(c, id)
+	orgID, operatorUserID, err := h.validateProtectedTesteeAccess(c, id)
 	if err != nil {
 		h.Error(c, err)
 		return
@@ -107,7 +107,7 @@ func (h *TesteeHandler) GetTestee(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, toTesteeBackendResponse(backendResult))
+	h.successRedactedTestee(c, orgID, operatorUserID, toTesteeBackendResponse(backendResult))
 }
 
 // GetTesteeByProfileID 根据 profile_id 获取受试者详情。
@@ -163,11 +163,11 @@ func (h *TesteeHandler) GetTesteeByProfileID(c *gin.Context) {
 			h.Error(c, backendErr)
 			return
 		}
-		h.Success(c, toTesteeBackendResponse(backendResult))
+		h.successRedactedTestee(c, orgID, operatorUserID, toTesteeBackendResponse(backendResult))
 		return
 	}
 
-	h.Success(c, toTesteeResponse(testeeResult))
+	h.successRedactedTestee(c, orgID, operatorUserID, toTesteeResponse(testeeResult))
 }
 
 // GetScaleAnalysis 获取受试者量表分析结果。
@@ -223,7 +223,8 @@ func (h *TesteeHandler) UpdateTestee(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
-	if _, _, err := h.validateProtectedTesteeAccess(c, id); err != nil {
+	orgID, operatorUserID, err := h.validateProtectedTesteeAccess(c, id)
+	if err != nil {
 		h.Error(c, err)
 		return
 	}
@@ -273,6 +274,20 @@ func (h *TesteeHandler) UpdateTestee(c *gin.Context) {
 		}
 	}
 
+	if req.PreferredLocale != nil {
+		if err := h.testeeManagementService.ChangePreferredLocale(c.Request.Context(), id, *req.PreferredLocale); err != nil {
+			logger.L(c.Request.Context()).Errorw("Failed to update preferred locale",
+				"action", "update_testee",
+				"resource", "testee",
+				"testee_id", id,
+				"field", "preferred_locale",
+				"error", err.Error(),
+			)
+			h.Error(c, err)
+			return
+		}
+	}
+
 	result, err := h.testeeQueryService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		logger.L(c.Request.Context()).Errorw("Failed to get updated testee",
@@ -285,7 +300,12 @@ func (h *TesteeHandler) UpdateTestee(c *gin.Context) {
 		return
 	}
 
-	h.SuccessResponseWithMessage(c, "受试者更新成功", toTesteeResponse(result))
+	resp := toTesteeResponse(result)
+	if err := redactTesteeResponses(c, h.testeeAccessService, orgID, operatorUserID, resp); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.SuccessResponseWithMessage(c, "受试者更新成功", resp)
 }
 
 // ListTestees 查询受试者列表。
@@ -312,7 +332,7 @@ func (h *TesteeHandler) ListTestees(c *gin.Context) {
 			h.Error(c, err)
 			return
 		}
-		h.Success(c, result)
+		h.successRedactedTestees(c, query.OrgID, operatorUserID, result)
 		return
 	}
 
@@ -334,7 +354,25 @@ func (h *TesteeHandler) ListTestees(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, toTesteeListResponse(listResult.Items, listResult.TotalCount, query.Page, query.PageSize))
+	h.successRedactedTestees(c, query.OrgID, operatorUserID, toTesteeListResponse(listResult.Items, listResult.TotalCount, query.Page, query.PageSize))
+}
+
+// successRedactedTestee 按查看者与受试者的关系脱敏后输出受试者详情。
+func (h *TesteeHandler) successRedactedTestee(c *gin.Context, orgID, operatorUserID int64, resp *response.TesteeResponse) {
+	if err := redactTesteeResponses(c, h.testeeAccessService, orgID, operatorUserID, resp); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, resp)
+}
+
+// successRedactedTestees 按查看者与各受试者的关系脱敏后输出受试者列表。
+func (h *TesteeHandler) successRedactedTestees(c *gin.Context, orgID, operatorUserID int64, resp *response.TesteeListResponse) {
+	if err := redactTesteeResponses(c, h.testeeAccessService, orgID, operatorUserID, resp.Items...); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, resp)
 }
 
 func (h *TesteeHandler) fetchTesteeByProfile(c *gin.Context, orgID int64, profileIDStr string) (*testeeApp.TesteeResult, error) {
@@ -618,6 +656,7 @@ func toTesteeResponse(result *testeeApp.TesteeResult) *response.TesteeResponse {
 		SourceLabel:     response.LabelForTesteeSource(result.Source),
 		IsKeyFocus:      result.IsKeyFocus,
 		IsKeyFocusLabel: response.LabelForKeyFocus(result.IsKeyFocus),
+		PreferredLocale: result.PreferredLocale,
 		CreatedAt:       response.FormatDateTimeValue(result.CreatedAt),
 		UpdatedAt:       response.FormatDateTimeValue(result.UpdatedAt),
 	}
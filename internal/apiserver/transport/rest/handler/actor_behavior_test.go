@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
 	assessmentEntryApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/assessmententry"
@@ -14,6 +15,7 @@ import (
 	operatorApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
 	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 	"github.com/gin-gonic/gin"
 )
 
@@ -76,6 +78,8 @@ type stubActorTesteeAccessService struct {
 	accessibleTesteesErr  error
 	resolveScope          *actorAccessApp.TesteeAccessScope
 	resolveAccessScopeErr error
+	redaction             actorAccessApp.TesteeRedaction
+	lastRedactionIDs      []uint64
 }
 
 func (s *stubActorTesteeAccessService) ResolveAccessScope(_ context.Context, orgID int64, operatorUserID int64) (*actorAccessApp.TesteeAccessScope, error) {
@@ -98,6 +102,18 @@ func (s *stubActorTesteeAccessService) ListAccessibleTesteeIDs(context.Context,
 	return s.accessibleTesteeIDs, s.accessibleTesteesErr
 }
 
+func (s *stubActorTesteeAccessService) ResolveTesteeRedaction(_ context.Context, _ int64, _ int64, testeeIDs []uint64) (actorAccessApp.TesteeRedaction, error) {
+	s.lastRedactionIDs = append([]uint64(nil), testeeIDs...)
+	if s.redaction != nil {
+		return s.redaction, nil
+	}
+	result := make(actorAccessApp.TesteeRedaction, len(testeeIDs))
+	for _, testeeID := range testeeIDs {
+		result[testeeID] = redaction.Full
+	}
+	return result, nil
+}
+
 type stubActorClinicianQueryService struct {
 	getByIDResult      *clinicianApp.ClinicianResult
 	getByIDErr         error
@@ -391,6 +407,66 @@ func TestTesteeHandlerListTesteesDefaultsPaginationAndUsesProtectedScope(t *test
 	}
 }
 
+func TestTesteeHandlerListTesteesRedactsByViewerRelation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	birthday := time.Date(2015, 3, 1, 0, 0, 0, 0, time.Local)
+	profileID := uint64(9001)
+	query := &stubActorTesteeQueryService{
+		listResult: &testeeApp.TesteeListResult{
+			Items: []*testeeApp.TesteeResult{
+				{ID: 5, OrgID: 91, Name: "张小明", Birthday: &birthday, ProfileID: &profileID},
+				{ID: 6, OrgID: 91, Name: "李小红", Birthday: &birthday},
+			},
+			TotalCount: 2,
+		},
+	}
+	access := &stubActorTesteeAccessService{
+		redaction: actorAccessApp.TesteeRedaction{5: redaction.Restricted, 6: redaction.Full},
+	}
+	handler := newTesteeHandlerForTest()
+	handler.testeeQueryService = query
+	handler.testeeAccessService = access
+
+	c, rec := newActorTestContext(http.MethodGet, "/api/v1/testees?org_id=91", nil)
+	c.Set(restmiddleware.OrgIDKey, uint64(91))
+	c.Set(restmiddleware.UserIDKey, uint64(702))
+
+	handler.ListTestees(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if len(access.lastRedactionIDs) != 2 || access.lastRedactionIDs[0] != 5 || access.lastRedactionIDs[1] != 6 {
+		t.Fatalf("redaction lookup ids = %v, want [5 6]", access.lastRedactionIDs)
+	}
+	var payload struct {
+		Data struct {
+			Items []struct {
+				ID        string  `json:"id"`
+				Name      string  `json:"name"`
+				Birthday  *string `json:"birthday"`
+				AgeBand   string  `json:"age_band"`
+				ProfileID *string `json:"profile_id"`
+				Redacted  bool    `json:"redacted"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(payload.Data.Items) != 2 {
+		t.Fatalf("items = %+v", payload.Data.Items)
+	}
+	restricted, full := payload.Data.Items[0], payload.Data.Items[1]
+	if restricted.Name == "张小明" || restricted.Birthday != nil || restricted.ProfileID != nil || restricted.AgeBand == "" || !restricted.Redacted {
+		t.Fatalf("restricted item not redacted: %+v", restricted)
+	}
+	if full.Name != "李小红" || full.Birthday == nil || full.Redacted {
+		t.Fatalf("full item unexpectedly redacted: %+v", full)
+	}
+}
+
 func TestOperatorClinicianHandlerListStaffDefaultsPaginationAndUsesProtectedOrgScope(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	"github.com/FangcunMount/qs-server/internal/apiserver/application/survey/answersheet"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -28,6 +31,7 @@ type AnswerSheetHandler struct {
 	BaseHandler
 	managementService answersheet.AnswerSheetManagementService
 	submissionService answersheet.AnswerSheetSubmissionService
+	modelScope        customRoleApp.ModelScopeResolver
 }
 
 // NewAnswerSheetHandler 创建答卷处理器
@@ -41,11 +45,17 @@ func NewAnswerSheetHandler(
 	}
 }
 
+// SetModelScopeResolver 注入答卷到测评模型的解析，供有模型范围的自定义角色判定；未注入时答卷视为无模型。
+func (h *AnswerSheetHandler) SetModelScopeResolver(resolver customRoleApp.ModelScopeResolver) {
+	h.modelScope = resolver
+}
+
 // ============= Management API (B端管理) =============
 
 // GetByID 根据ID获取答卷详情
 // @Summary 获取答卷详情
 // @Description 管理员仅可查看当前组织范围内的答卷完整信息；跨组织 ID 按不存在处理。
+// @Description 仅经自定义角色授权时按答卷所属测评模型判定范围，去标识级别的角色看不到填写人。
 // @Tags AnswerSheet-Management
 // @Accept json
 // @Produce json
@@ -81,13 +91,25 @@ func (h *AnswerSheetHandler) GetByID(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
+	sensitivity, err := h.readSensitivity(c, result.QuestionnaireCode, result.QuestionnaireVer)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, result.TesteeID)
 
-	h.Success(c, response.NewAnswerSheetResponse(result))
+	resp := response.NewAnswerSheetResponse(result)
+	if sensitivity != authzapp.SensitivityIdentified {
+		resp.FillerID = 0
+		resp.FillerName = ""
+	}
+	h.Success(c, resp)
 }
 
 // List 查询答卷列表
 // @Summary 查询答卷列表
 // @Description 管理员查询当前组织范围内的答卷列表，支持多维度筛选。
+// @Description 仅经自定义角色授权时必须指定 questionnaire_code，并按其测评模型判定范围。
 // @Tags AnswerSheet-Management
 // @Accept json
 // @Produce json
@@ -121,6 +143,21 @@ func (h *AnswerSheetHandler) List(c *gin.Context) {
 		h.Error(c, errors.WithCode(code.ErrPermissionDenied, "org scope exceeds uint64"))
 		return
 	}
+	sensitivity := authzapp.SensitivityIdentified
+	if scopedAnswerSheetReader(middleware.GetAuthzSnapshot(c)) {
+		if dto.QuestionnaireCode == "" {
+			h.Error(c, errors.WithCode(code.ErrPermissionDenied, "questionnaire_code is required for scoped custom roles"))
+			return
+		}
+		if sensitivity, err = h.readSensitivity(c, dto.QuestionnaireCode, ""); err != nil {
+			h.Error(c, err)
+			return
+		}
+		if sensitivity != authzapp.SensitivityIdentified && dto.FillerID != nil {
+			h.Error(c, errors.WithCode(code.ErrPermissionDenied, "filler_id filter requires identified access"))
+			return
+		}
+	}
 
 	result, err := h.managementService.List(c.Request.Context(), dto)
 	if err != nil {
@@ -128,7 +165,41 @@ func (h *AnswerSheetHandler) List(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, response.NewAnswerSheetSummaryListResponse(result))
+	resp := response.NewAnswerSheetSummaryListResponse(result)
+	if sensitivity != authzapp.SensitivityIdentified {
+		for i := range resp.Items {
+			resp.Items[i].FillerID = 0
+		}
+	}
+	h.Success(c, resp)
+}
+
+// scopedAnswerSheetReader 当前操作者是否仅凭有范围的自定义角色进入答卷查询路由。
+// 快照缺失的请求已被路由层拒绝，这里不再重复判定。
+func scopedAnswerSheetReader(snapshot *authzapp.Snapshot) bool {
+	return snapshot != nil && !authzapp.DecideCapability(snapshot, authzapp.CapabilityReadAnswersheets).Allowed
+}
+
+// readSensitivity 判定当前操作者对某问卷答卷可见的敏感级别：IAM 授权或不限范围的角色为 identified，
+// 否则按问卷绑定的测评模型逐个判定自定义角色；汇总级别不足以查看单份答卷。
+func (h *AnswerSheetHandler) readSensitivity(c *gin.Context, questionnaireCode, questionnaireVer string) (authzapp.Sensitivity, error) {
+	snapshot := middleware.GetAuthzSnapshot(c)
+	if !scopedAnswerSheetReader(snapshot) {
+		return authzapp.SensitivityIdentified, nil
+	}
+	modelCode := ""
+	if h.modelScope != nil {
+		resolved, err := h.modelScope.ResolveModelCode(c.Request.Context(), questionnaireCode, questionnaireVer)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to resolve answersheet model scope")
+		}
+		modelCode = resolved
+	}
+	level, ok := authzapp.AllowedSensitivity(snapshot, authzapp.CapabilityReadAnswersheets, authzapp.Target{ModelCode: modelCode})
+	if !ok || level == authzapp.SensitivityAggregate {
+		return "", errors.WithCode(code.ErrPermissionDenied, "answersheet is outside custom role scope")
+	}
+	return level, nil
 }
 
 // AdminSubmit 管理员提交答卷
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	answersheetapp "github.com/FangcunMount/qs-server/internal/apiserver/application/survey/answersheet"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	pkgmiddleware "github.com/FangcunMount/qs-server/internal/pkg/middleware"
@@ -301,3 +302,65 @@ func TestAnswerSheetHandlerAdminSubmitSuccess(t *testing.T) {
 		t.Fatalf("unexpected payload: %+v", payload)
 	}
 }
+
+type stubModelScopeResolver map[string]string
+
+func (s stubModelScopeResolver) ResolveModelCode(_ context.Context, questionnaireCode, _ string) (string, error) {
+	return s[questionnaireCode], nil
+}
+
+func TestAnswerSheetHandlerNarrowsScopedCustomRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	management := &stubAnswerSheetManagementService{
+		getByIDResult: &answersheetapp.AnswerSheetResult{ID: 42, QuestionnaireCode: "QNR-SDS", FillerID: 101, FillerName: "Alice"},
+		listResult: &answersheetapp.AnswerSheetSummaryListResult{Total: 1, Items: []*answersheetapp.AnswerSheetSummaryResult{
+			{ID: 77, QuestionnaireCode: "QNR-SDS", FillerID: 303},
+		}},
+	}
+	handler := newAnswerSheetHandlerForTest(management, &stubAnswerSheetSubmissionService{})
+	handler.SetModelScopeResolver(stubModelScopeResolver{"QNR-SDS": "SDS", "QNR-SAS": "SAS"})
+	snapshot := (&authzapp.Snapshot{}).WithGrants([]authzapp.RoleGrant{{
+		RoleCode:     "research_assistant",
+		Capabilities: []authzapp.Capability{authzapp.CapabilityReadAnswersheets},
+		Scope:        authzapp.Scope{ModelCodes: []string{"SDS"}},
+		Sensitivity:  authzapp.SensitivityDeidentified,
+	}})
+	request := func(target string) (*gin.Context, *httptest.ResponseRecorder) {
+		c, rec := newHandlerTestContext(http.MethodGet, target, bytes.NewReader(nil))
+		c.Set(middleware.OrgIDKey, uint64(88))
+		c.Set(middleware.AuthzSnapshotKey, snapshot)
+		return c, rec
+	}
+
+	c, rec := request("/api/v1/answersheets/42")
+	c.Params = gin.Params{{Key: "id", Value: "42"}}
+	handler.GetByID(c)
+	if rec.Code != http.StatusOK || bytes.Contains(rec.Body.Bytes(), []byte("Alice")) || !bytes.Contains(rec.Body.Bytes(), []byte(`"filler_id":null`)) {
+		t.Fatalf("scoped detail = %d %s", rec.Code, rec.Body.String())
+	}
+
+	management.getByIDResult.QuestionnaireCode = "QNR-SAS"
+	c, rec = request("/api/v1/answersheets/42")
+	c.Params = gin.Params{{Key: "id", Value: "42"}}
+	handler.GetByID(c)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("out-of-scope detail status = %d, want 403", rec.Code)
+	}
+
+	for target, want := range map[string]int{
+		"/api/v1/answersheets":                                          http.StatusForbidden,
+		"/api/v1/answersheets?questionnaire_code=QNR-SAS":               http.StatusForbidden,
+		"/api/v1/answersheets?questionnaire_code=QNR-SDS&filler_id=303": http.StatusForbidden,
+		"/api/v1/answersheets?questionnaire_code=QNR-SDS":               http.StatusOK,
+	} {
+		c, rec = request(target)
+		handler.List(c)
+		if rec.Code != want {
+			t.Fatalf("%s status = %d, want %d", target, rec.Code, want)
+		}
+		if want == http.StatusOK && bytes.Contains(rec.Body.Bytes(), []byte(`"filler_id":"303"`)) {
+			t.Fatalf("scoped list leaked filler id: %s", rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	breakGlassApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// BreakGlassHandler 紧急访问处理器：从业者申请与结束授权，机构管理员复核。
+type BreakGlassHandler struct {
+	*BaseHandler
+	service breakGlassApp.Service
+}
+
+func NewBreakGlassHandler(service breakGlassApp.Service) *BreakGlassHandler {
+	return &BreakGlassHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// RequestBreakGlass godoc
+// @Summary 申请紧急访问
+// @Description 当前从业者与受试者之间没有授权关系时，填写理由即可立即获得短时访问。授权到期自动失效，并进入机构管理员的复核队列；已有生效授权时直接返回该授权。
+// @Tags break-glass
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateBreakGlassRequest true "紧急访问申请"
+// @Success 200 {object} response.BreakGlassGrantResponse
+// @Router /api/v1/clinicians/me/break-glass [post]
+func (h *BreakGlassHandler) RequestBreakGlass(c *gin.Context) {
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.CreateBreakGlassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid break-glass request: %v", err))
+		return
+	}
+	testeeID, err := strconv.ParseUint(strings.TrimSpace(req.TesteeID), 10, 64)
+	if err != nil || testeeID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+		return
+	}
+	result, err := h.service.Request(c.Request.Context(), breakGlassApp.RequestDTO{
+		OrgID:          orgID,
+		OperatorUserID: operatorUserID,
+		TesteeID:       testeeID,
+		Justification:  req.Justification,
+		Duration:       time.Duration(req.DurationMinutes) * time.Minute,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantResponse(result))
+}
+
+// ListMyBreakGlass godoc
+// @Summary 查询我的紧急访问授权
+// @Tags break-glass
+// @Security BearerAuth
+// @Produce json
+// @Param active query bool false "只返回生效中的授权"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.BreakGlassGrantListResponse
+// @Router /api/v1/clinicians/me/break-glass [get]
+func (h *BreakGlassHandler) ListMyBreakGlass(c *gin.Context) {
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	activeOnly, ok := h.activeOnly(c)
+	if !ok {
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListMine(c.Request.Context(), orgID, operatorUserID, activeOnly, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantListResponse(result))
+}
+
+// RevokeMyBreakGlass godoc
+// @Summary 提前结束我的紧急访问授权
+// @Tags break-glass
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "授权ID"
+// @Success 200 {object} response.BreakGlassGrantResponse
+// @Router /api/v1/clinicians/me/break-glass/{id}/revoke [post]
+func (h *BreakGlassHandler) RevokeMyBreakGlass(c *gin.Context) {
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	grantID, ok := h.grantID(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.RevokeMine(c.Request.Context(), orgID, operatorUserID, grantID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantResponse(result))
+}
+
+// ListBreakGlassGrants godoc
+// @Summary 查询紧急访问授权（复核队列）
+// @Description 按授予时间倒序返回机构内的紧急访问授权；review_status=pending 即待复核队列。
+// @Tags break-glass
+// @Security BearerAuth
+// @Produce json
+// @Param review_status query string false "复核状态：pending/approved/flagged"
+// @Param clinician_id query string false "从业者ID"
+// @Param testee_id query string false "受试者ID"
+// @Param active query bool false "只返回生效中的授权"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.BreakGlassGrantListResponse
+// @Router /api/v1/break-glass-grants [get]
+func (h *BreakGlassHandler) ListBreakGlassGrants(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	filter := breakGlassApp.Filter{
+		OrgID:        orgID,
+		ReviewStatus: breakGlassApp.ReviewStatus(strings.TrimSpace(c.Query("review_status"))),
+	}
+	if raw := strings.TrimSpace(c.Query("clinician_id")); raw != "" {
+		if filter.ClinicianID, err = strconv.ParseUint(raw, 10, 64); err != nil || filter.ClinicianID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid clinician_id"))
+			return
+		}
+	}
+	if raw := strings.TrimSpace(c.Query("testee_id")); raw != "" {
+		if filter.TesteeID, err = strconv.ParseUint(raw, 10, 64); err != nil || filter.TesteeID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+			return
+		}
+	}
+	activeOnly, ok := h.activeOnly(c)
+	if !ok {
+		return
+	}
+	filter.ActiveOnly = activeOnly
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.List(c.Request.Context(), breakGlassApp.ListQuery{Filter: filter, Page: page, PageSize: pageSize})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantListResponse(result))
+}
+
+// ReviewBreakGlassGrant godoc
+// @Summary 复核紧急访问授权
+// @Description decision 为 approved 或 flagged；flagged 时必须填写说明。申请人本人不能复核自己的授权。可重复复核，以最后一次为准。
+// @Tags break-glass
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "授权ID"
+// @Param request body request.ReviewBreakGlassRequest true "复核结论"
+// @Success 200 {object} response.BreakGlassGrantResponse
+// @Router /api/v1/break-glass-grants/{id}/review [post]
+func (h *BreakGlassHandler) ReviewBreakGlassGrant(c *gin.Context) {
+	orgID, reviewerID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	grantID, ok := h.grantID(c)
+	if !ok {
+		return
+	}
+	var req request.ReviewBreakGlassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid break-glass review request: %v", err))
+		return
+	}
+	result, err := h.service.Review(c.Request.Context(), breakGlassApp.ReviewDTO{
+		OrgID:      orgID,
+		GrantID:    grantID,
+		ReviewerID: reviewerID,
+		Decision:   breakGlassApp.ReviewStatus(strings.TrimSpace(req.Decision)),
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantResponse(result))
+}
+
+// RevokeBreakGlassGrant godoc
+// @Summary 提前结束紧急访问授权
+// @Tags break-glass
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "授权ID"
+// @Success 200 {object} response.BreakGlassGrantResponse
+// @Router /api/v1/break-glass-grants/{id}/revoke [post]
+func (h *BreakGlassHandler) RevokeBreakGlassGrant(c *gin.Context) {
+	orgID, adminUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	grantID, ok := h.grantID(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.Revoke(c.Request.Context(), orgID, adminUserID, grantID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewBreakGlassGrantResponse(result))
+}
+
+func (h *BreakGlassHandler) grantID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid break-glass grant id"))
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *BreakGlassHandler) activeOnly(c *gin.Context) (bool, bool) {
+	raw := strings.TrimSpace(c.Query("active"))
+	if raw == "" {
+		return false, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid active"))
+		return false, false
+	}
+	return value, true
+}
@@ -0,0 +1,449 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	careTeamApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// CareTeamHandler 照护团队处理器：团队、成员与受试者分配维护，以及团队变更审计。
+type CareTeamHandler struct {
+	*BaseHandler
+	service careTeamApp.Service
+}
+
+func NewCareTeamHandler(service careTeamApp.Service) *CareTeamHandler {
+	return &CareTeamHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// ListCareTeams godoc
+// @Summary 查询照护团队
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param department query string false "科室"
+// @Param clinician_id query string false "只返回该从业者所在的团队"
+// @Success 200 {object} response.CareTeamListResponse
+// @Router /api/v1/care-teams [get]
+func (h *CareTeamHandler) ListCareTeams(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	filter := careTeamApp.TeamFilter{OrgID: orgID, Department: strings.TrimSpace(c.Query("department"))}
+	if raw := strings.TrimSpace(c.Query("clinician_id")); raw != "" {
+		if filter.ClinicianID, err = strconv.ParseUint(raw, 10, 64); err != nil || filter.ClinicianID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid clinician_id"))
+			return
+		}
+	}
+	teams, err := h.service.ListTeams(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamListResponse(teams))
+}
+
+// ListMyCareTeams godoc
+// @Summary 查询我所在的照护团队
+// @Description 返回当前操作者绑定的从业者所在的团队；工作台可用 team_id 切换到团队范围。
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.CareTeamListResponse
+// @Router /api/v1/clinicians/me/care-teams [get]
+func (h *CareTeamHandler) ListMyCareTeams(c *gin.Context) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teams, err := h.service.ListMyTeams(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamListResponse(teams))
+}
+
+// CreateCareTeam godoc
+// @Summary 创建照护团队
+// @Description 指定科室时为科室团队，负责人与成员必须属于该科室；不指定时为跨科室团队。
+// @Tags care-teams
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CareTeamRequest true "照护团队"
+// @Success 200 {object} response.CareTeamResponse
+// @Router /api/v1/care-teams [post]
+func (h *CareTeamHandler) CreateCareTeam(c *gin.Context) {
+	dto, ok := h.bindTeam(c)
+	if !ok {
+		return
+	}
+	team, err := h.service.CreateTeam(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamResponse(team))
+}
+
+// GetCareTeam godoc
+// @Summary 查询照护团队详情
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Success 200 {object} response.CareTeamResponse
+// @Router /api/v1/care-teams/{id} [get]
+func (h *CareTeamHandler) GetCareTeam(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	team, err := h.service.GetTeam(c.Request.Context(), orgID, teamID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamResponse(team))
+}
+
+// UpdateCareTeam godoc
+// @Summary 更新照护团队
+// @Description 改为科室团队时，现有负责人与成员必须都属于新科室。
+// @Tags care-teams
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param request body request.CareTeamRequest true "照护团队"
+// @Success 200 {object} response.CareTeamResponse
+// @Router /api/v1/care-teams/{id} [put]
+func (h *CareTeamHandler) UpdateCareTeam(c *gin.Context) {
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	dto, ok := h.bindTeam(c)
+	if !ok {
+		return
+	}
+	team, err := h.service.UpdateTeam(c.Request.Context(), teamID, dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamResponse(team))
+}
+
+// DeleteCareTeam godoc
+// @Summary 删除照护团队
+// @Description 同时移除全部成员与受试者分配，成员经由该团队继承的访问立即失效；团队事件保留。
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/care-teams/{id} [delete]
+func (h *CareTeamHandler) DeleteCareTeam(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteTeam(c.Request.Context(), orgID, operatorID, teamID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ListCareTeamMembers godoc
+// @Summary 查询团队成员
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Success 200 {object} response.CareTeamMemberListResponse
+// @Router /api/v1/care-teams/{id}/members [get]
+func (h *CareTeamHandler) ListCareTeamMembers(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	members, err := h.service.ListMembers(c.Request.Context(), orgID, teamID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamMemberListResponse(members))
+}
+
+// SetCareTeamMember godoc
+// @Summary 添加团队成员或调整角色
+// @Description 负责人与成员继承团队受试者的访问，观察者不继承；变更即时生效并记入团队事件。
+// @Tags care-teams
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param clinician_id path string true "从业者ID"
+// @Param request body request.CareTeamMemberRequest true "团队角色"
+// @Success 200 {object} response.CareTeamMemberResponse
+// @Router /api/v1/care-teams/{id}/members/{clinician_id} [put]
+func (h *CareTeamHandler) SetCareTeamMember(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	clinicianID, ok := h.clinicianID(c)
+	if !ok {
+		return
+	}
+	var req request.CareTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid care team member request: %v", err))
+		return
+	}
+	member, err := h.service.SetMember(c.Request.Context(), careTeamApp.MemberDTO{
+		OrgID:       orgID,
+		OperatorID:  operatorID,
+		TeamID:      teamID,
+		ClinicianID: clinicianID,
+		Role:        careTeamApp.MemberRole(strings.TrimSpace(req.Role)),
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamMemberResponse(member))
+}
+
+// RemoveCareTeamMember godoc
+// @Summary 移除团队成员
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param clinician_id path string true "从业者ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/care-teams/{id}/members/{clinician_id} [delete]
+func (h *CareTeamHandler) RemoveCareTeamMember(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	clinicianID, ok := h.clinicianID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.RemoveMember(c.Request.Context(), orgID, operatorID, teamID, clinicianID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ListCareTeamTestees godoc
+// @Summary 查询团队受试者
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页数量"
+// @Success 200 {object} response.CareTeamTesteeListResponse
+// @Router /api/v1/care-teams/{id}/testees [get]
+func (h *CareTeamHandler) ListCareTeamTestees(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListTestees(c.Request.Context(), orgID, teamID, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamTesteeListResponse(result))
+}
+
+// AssignCareTeamTestee godoc
+// @Summary 分配受试者给团队
+// @Description 分配后团队负责人与成员立即可访问该受试者；重复分配返回已有分配。
+// @Tags care-teams
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param request body request.AssignCareTeamTesteeRequest true "受试者"
+// @Success 200 {object} response.CareTeamTesteeResponse
+// @Router /api/v1/care-teams/{id}/testees [post]
+func (h *CareTeamHandler) AssignCareTeamTestee(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	var req request.AssignCareTeamTesteeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid care team testee request: %v", err))
+		return
+	}
+	testeeID, err := strconv.ParseUint(strings.TrimSpace(req.TesteeID), 10, 64)
+	if err != nil || testeeID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+		return
+	}
+	assignment, err := h.service.AssignTestee(c.Request.Context(), orgID, operatorID, teamID, testeeID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamTesteeResponse(assignment))
+}
+
+// UnassignCareTeamTestee godoc
+// @Summary 取消受试者的团队分配
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param testee_id path string true "受试者ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/care-teams/{id}/testees/{testee_id} [delete]
+func (h *CareTeamHandler) UnassignCareTeamTestee(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	testeeID, err := strconv.ParseUint(c.Param("testee_id"), 10, 64)
+	if err != nil || testeeID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+		return
+	}
+	if err := h.service.UnassignTestee(c.Request.Context(), orgID, operatorID, teamID, testeeID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ListCareTeamEvents godoc
+// @Summary 查询团队变更审计
+// @Description 按时间倒序返回团队、成员与受试者分配的变更；团队删除后仍可查询。
+// @Tags care-teams
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "团队ID"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页数量"
+// @Success 200 {object} response.CareTeamEventListResponse
+// @Router /api/v1/care-teams/{id}/events [get]
+func (h *CareTeamHandler) ListCareTeamEvents(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	teamID, ok := h.teamID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListEvents(c.Request.Context(), orgID, teamID, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCareTeamEventListResponse(result))
+}
+
+func (h *CareTeamHandler) bindTeam(c *gin.Context) (careTeamApp.TeamDTO, bool) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return careTeamApp.TeamDTO{}, false
+	}
+	var req request.CareTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid care team request: %v", err))
+		return careTeamApp.TeamDTO{}, false
+	}
+	return careTeamApp.TeamDTO{
+		OrgID:       orgID,
+		OperatorID:  operatorID,
+		Name:        req.Name,
+		Department:  req.Department,
+		Description: req.Description,
+	}, true
+}
+
+func (h *CareTeamHandler) teamID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid care team id"))
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *CareTeamHandler) clinicianID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("clinician_id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid clinician_id"))
+		return 0, false
+	}
+	return id, true
+}
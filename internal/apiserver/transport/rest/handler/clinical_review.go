@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"github.com/FangcunMount/component-base/pkg/errors"
+	clinicalReviewApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// ClinicalReviewHandler 报告临床复核处理器：临床备注版本与报告签署。
+type ClinicalReviewHandler struct {
+	*BaseHandler
+	service clinicalReviewApp.Service
+}
+
+func NewClinicalReviewHandler(service clinicalReviewApp.Service) *ClinicalReviewHandler {
+	return &ClinicalReviewHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// GetReview godoc
+// @Summary 查询报告临床复核
+// @Description 返回复核状态（pending_review/reviewed/amended）、复核人与全部备注版本；尚未复核时状态为 pending_review。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param assessment_id path string true "测评ID"
+// @Success 200 {object} core.Response{data=response.ClinicalReviewResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/reports/{assessment_id}/review [get]
+func (h *ClinicalReviewHandler) GetReview(c *gin.Context) {
+	actor, testeeID, assessmentID, ok := h.target(c)
+	if !ok {
+		return
+	}
+	view, err := h.service.GetReview(c.Request.Context(), actor, testeeID, assessmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicalReviewResponse(view))
+}
+
+// AddClinicalNote godoc
+// @Summary 追加临床备注
+// @Description 每次追加生成新版本，历史版本保留；报告已签署时状态转为 amended。addendum 在报告签署后随受试者报告展示。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param assessment_id path string true "测评ID"
+// @Param request body request.ClinicalNoteRequest true "临床备注"
+// @Success 200 {object} core.Response{data=response.ClinicalReviewResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/reports/{assessment_id}/notes [post]
+func (h *ClinicalReviewHandler) AddClinicalNote(c *gin.Context) {
+	actor, testeeID, assessmentID, ok := h.target(c)
+	if !ok {
+		return
+	}
+	var req request.ClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid clinical note request: %v", err))
+		return
+	}
+	view, err := h.service.AddNote(c.Request.Context(), clinicalReviewApp.NoteDTO{
+		Actor:        actor,
+		TesteeID:     testeeID,
+		AssessmentID: assessmentID,
+		Content:      req.Content,
+		Addendum:     req.Addendum,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicalReviewResponse(view))
+}
+
+// SignOffReport godoc
+// @Summary 签署报告复核
+// @Description 报告需已生成；已签署的报告再次签署返回冲突，如需修改请追加备注。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param assessment_id path string true "测评ID"
+// @Success 200 {object} core.Response{data=response.ClinicalReviewStatusResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/reports/{assessment_id}/sign-off [post]
+func (h *ClinicalReviewHandler) SignOffReport(c *gin.Context) {
+	actor, testeeID, assessmentID, ok := h.target(c)
+	if !ok {
+		return
+	}
+	review, err := h.service.SignOff(c.Request.Context(), actor, testeeID, assessmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicalReviewStatusResponse(review))
+}
+
+func (h *ClinicalReviewHandler) target(c *gin.Context) (clinicalReviewApp.Actor, uint64, uint64, bool) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return clinicalReviewApp.Actor{}, 0, 0, false
+	}
+	testeeID, ok := parsePathUint(c, "testee_id", h.BaseHandler)
+	if !ok {
+		return clinicalReviewApp.Actor{}, 0, 0, false
+	}
+	assessmentID, ok := parsePathUint(c, "assessment_id", h.BaseHandler)
+	if !ok {
+		return clinicalReviewApp.Actor{}, 0, 0, false
+	}
+	return clinicalReviewApp.Actor{OrgID: orgID, OperatorUserID: userID}, testeeID, assessmentID, true
+}
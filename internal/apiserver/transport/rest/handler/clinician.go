@@ -622,7 +622,7 @@ func (h *OperatorClinicianHandler) ListClinicians(c *gin.Context) {
 // @Success 200 {object} core.Response
 // @Router /api/v1/clinicians/{id}/testees [get]
 func (h *OperatorClinicianHandler) ListClinicianTestees(c *gin.Context) {
-	orgID, err := h.RequireProtectedOrgID(c)
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
 	if err != nil {
 		h.Error(c, err)
 		return
@@ -653,6 +653,10 @@ func (h *OperatorClinicianHandler) ListClinicianTestees(c *gin.Context) {
 	for _, item := range result.Items {
 		items = append(items, toAssignedTesteeResponse(item))
 	}
+	if err := redactTesteeResponses(c, h.testeeAccessService, orgID, operatorUserID, items...); err != nil {
+		h.Error(c, err)
+		return
+	}
 	totalPages := 0
 	if pageSize > 0 {
 		totalPages = int((result.TotalCount + int64(pageSize) - 1) / int64(pageSize))
@@ -675,7 +679,7 @@ func (h *OperatorClinicianHandler) ListClinicianTestees(c *gin.Context) {
 // @Success 200 {object} core.Response
 // @Router /api/v1/clinicians/{id}/relations [get]
 func (h *OperatorClinicianHandler) ListClinicianRelations(c *gin.Context) {
-	orgID, err := h.RequireProtectedOrgID(c)
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
 	if err != nil {
 		h.Error(c, err)
 		return
@@ -690,7 +694,7 @@ func (h *OperatorClinicianHandler) ListClinicianRelations(c *gin.Context) {
 		return
 	}
 
-	h.listClinicianRelationsFor(c, orgID, clinicianID)
+	h.listClinicianRelationsFor(c, orgID, operatorUserID, clinicianID)
 }
 
 // GetMyClinician 获取当前从业者信息。
@@ -752,7 +756,12 @@ func (h *OperatorClinicianHandler) ListMyClinicianTestees(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, toTesteeListResponse(result.Items, result.TotalCount, page, pageSize))
+	resp := toTesteeListResponse(result.Items, result.TotalCount, page, pageSize)
+	if err := redactTesteeResponses(c, h.testeeAccessService, clinicianItem.OrgID, operatorUserID, resp.Items...); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, resp)
 }
 
 // ListMyClinicianRelations 查询当前从业者关系列表。
@@ -768,8 +777,13 @@ func (h *OperatorClinicianHandler) ListMyClinicianRelations(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
+	_, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
 
-	h.listClinicianRelationsFor(c, clinicianItem.OrgID, clinicianItem.ID)
+	h.listClinicianRelationsFor(c, clinicianItem.OrgID, operatorUserID, clinicianItem.ID)
 }
 
 // AssignClinicianTestee 分配受试者。
@@ -1128,7 +1142,8 @@ func (h *OperatorClinicianHandler) loadTesteeClinicianRelations(c *gin.Context,
 	return result, nil
 }
 
-func (h *OperatorClinicianHandler) listClinicianRelationsFor(c *gin.Context, orgID int64, clinicianID uint64) {
+// listClinicianRelationsFor 列出从业者关系；受试者 PII 按查看者与各受试者的关系脱敏。
+func (h *OperatorClinicianHandler) listClinicianRelationsFor(c *gin.Context, orgID, operatorUserID int64, clinicianID uint64) {
 	page, pageSize := paginationFromContext(c)
 	result, err := h.clinicianRelationshipService.ListClinicianRelations(c.Request.Context(), clinicianApp.ListClinicianRelationDTO{
 		OrgID:       orgID,
@@ -1143,8 +1158,17 @@ func (h *OperatorClinicianHandler) listClinicianRelationsFor(c *gin.Context, org
 	}
 
 	items := make([]*response.ClinicianRelationResponse, 0, len(result.Items))
+	testees := make([]*response.TesteeResponse, 0, len(result.Items))
 	for _, item := range result.Items {
-		items = append(items, toClinicianRelationResponse(item))
+		resp := toClinicianRelationResponse(item)
+		if resp != nil {
+			testees = append(testees, resp.Testee)
+		}
+		items = append(items, resp)
+	}
+	if err := redactTesteeResponses(c, h.testeeAccessService, orgID, operatorUserID, testees...); err != nil {
+		h.Error(c, err)
+		return
 	}
 
 	totalPages := 0
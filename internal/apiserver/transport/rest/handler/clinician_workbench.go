@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
+	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
 	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -13,21 +14,27 @@ import (
 type ClinicianWorkbenchHandler struct {
 	*BaseHandler
 	service workbenchApp.Service
+	access  actorAccessApp.TesteeAccessService
 }
 
-func NewClinicianWorkbenchHandler(service workbenchApp.Service) *ClinicianWorkbenchHandler {
+// NewClinicianWorkbenchHandler 创建工作台处理器；access 用于按查看者与受试者的关系脱敏队列中的受试者。
+func NewClinicianWorkbenchHandler(service workbenchApp.Service, access actorAccessApp.TesteeAccessService) *ClinicianWorkbenchHandler {
 	return &ClinicianWorkbenchHandler{
 		BaseHandler: NewBaseHandler(),
 		service:     service,
+		access:      access,
 	}
 }
 
 // GetMyClinicianWorkbenchQueueSummary godoc
 // @Summary 获取当前医生工作台队列统计
 // @Description 返回当前医生名下高风险、复诊、重点关注队列数量。队列由最新测评风险、开放任务、重点关注字段动态生成，不以用户标签为事实来源。
+// @Description 名下受试者包含授权关系、照护团队继承与紧急访问三种来源；team_id 存在时切换为该照护团队视角（需为团队负责人或成员）。
+// @Description counts 为待处理（open 与 claimed）条目数，open 为其中尚未认领的条目数，handled 为已解决或暂缓中的条目数，escalated_high_risk 为超过认领时限仍未认领的高风险条目数。
 // @Tags clinicians
 // @Security BearerAuth
 // @Produce json
+// @Param team_id query int false "照护团队 ID，可选"
 // @Success 200 {object} response.ClinicianWorkbenchQueueSummaryResponse
 // @Router /api/v1/clinicians/me/workbench/queues/summary [get]
 func (h *ClinicianWorkbenchHandler) GetMyClinicianWorkbenchQueueSummary(c *gin.Context) {
@@ -36,11 +43,12 @@ func (h *ClinicianWorkbenchHandler) GetMyClinicianWorkbenchQueueSummary(c *gin.C
 		h.Error(c, err)
 		return
 	}
-	result, err := h.service.GetSummary(c.Request.Context(), workbenchApp.Scope{
-		Kind:           workbenchApp.ScopeKindClinicianMe,
-		OrgID:          orgID,
-		OperatorUserID: operatorUserID,
-	})
+	scope, err := myWorkbenchScope(c, orgID, operatorUserID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.service.GetSummary(c.Request.Context(), scope)
 	if err != nil {
 		h.Error(c, err)
 		return
@@ -50,11 +58,13 @@ func (h *ClinicianWorkbenchHandler) GetMyClinicianWorkbenchQueueSummary(c *gin.C
 
 // ListMyClinicianWorkbenchQueue godoc
 // @Summary 获取当前医生工作台队列
-// @Description queue_type 取值：high_risk、follow_up、key_focus。high_risk 使用最近一次有效测评风险，follow_up 返回每名受试者最紧急的待开放或已开放任务，key_focus 使用重点关注字段。
+// @Description queue_type 取值：high_risk、follow_up、key_focus、awaiting_review、critical_item。high_risk 使用最近一次有效测评风险，follow_up 返回每名受试者最紧急的待开放或已开放任务，key_focus 使用重点关注字段，awaiting_review 返回已生成报告、尚未签署临床复核的测评（等待最久的在前），critical_item 返回提交时命中关键条目的答卷（最新在前）。
 // @Tags clinicians
 // @Security BearerAuth
 // @Produce json
-// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus"
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param triage_status query string false "分诊状态过滤：open/claimed/snoozed/resolved/handled/escalated/all，默认只返回待处理（open 与 claimed）条目"
 // @Param page query int false "页码，默认 1"
 // @Param page_size query int false "每页数量，默认 20，最大 100"
 // @Success 200 {object} response.ClinicianWorkbenchQueueResponse
@@ -65,31 +75,34 @@ func (h *ClinicianWorkbenchHandler) ListMyClinicianWorkbenchQueue(c *gin.Context
 		h.Error(c, err)
 		return
 	}
+	scope, err := myWorkbenchScope(c, orgID, operatorUserID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
 	page, pageSize := paginationFromContext(c)
 	result, err := h.service.ListQueue(c.Request.Context(), workbenchApp.ListQueueDTO{
-		Scope: workbenchApp.Scope{
-			Kind:           workbenchApp.ScopeKindClinicianMe,
-			OrgID:          orgID,
-			OperatorUserID: operatorUserID,
-		},
-		QueueType: workbenchApp.QueueType(c.Param("queue_type")),
-		Page:      page,
-		PageSize:  pageSize,
+		Scope:      scope,
+		QueueType:  workbenchApp.QueueType(c.Param("queue_type")),
+		TriageView: c.Query("triage_status"),
+		Page:       page,
+		PageSize:   pageSize,
 	})
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
-	h.Success(c, response.NewClinicianWorkbenchQueueResponse(result))
+	h.successQueue(c, orgID, operatorUserID, response.NewClinicianWorkbenchQueueResponse(result))
 }
 
 // GetOrgWorkbenchQueueSummary godoc
 // @Summary 获取管理员全院工作台队列统计
-// @Description 返回当前机构高风险、复诊、重点关注队列数量；仅 qs:admin 可访问。clinician_id 可选，存在时限制到该医生已分配受试者。
+// @Description 返回当前机构高风险、复诊、重点关注队列数量；仅 qs:admin 可访问。clinician_id 可选，存在时限制到该医生已分配受试者；team_id 可选，存在时限制到该照护团队的受试者，二者不可同时使用。
 // @Tags Workbench
 // @Security BearerAuth
 // @Produce json
 // @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
 // @Success 200 {object} response.ClinicianWorkbenchQueueSummaryResponse
 // @Router /api/v1/workbench/queues/summary [get]
 func (h *ClinicianWorkbenchHandler) GetOrgWorkbenchQueueSummary(c *gin.Context) {
@@ -98,16 +111,12 @@ func (h *ClinicianWorkbenchHandler) GetOrgWorkbenchQueueSummary(c *gin.Context)
 		h.Error(c, err)
 		return
 	}
-	clinicianID, err := optionalWorkbenchUint64Query(c, "clinician_id")
+	scope, err := orgWorkbenchScope(c, orgID)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
-	result, err := h.service.GetSummary(c.Request.Context(), workbenchApp.Scope{
-		Kind:        workbenchApp.ScopeKindOrgAdmin,
-		OrgID:       orgID,
-		ClinicianID: clinicianID,
-	})
+	result, err := h.service.GetSummary(c.Request.Context(), scope)
 	if err != nil {
 		h.Error(c, err)
 		return
@@ -117,43 +126,81 @@ func (h *ClinicianWorkbenchHandler) GetOrgWorkbenchQueueSummary(c *gin.Context)
 
 // ListOrgWorkbenchQueue godoc
 // @Summary 获取管理员全院工作台队列
-// @Description queue_type 取值：high_risk、follow_up、key_focus；follow_up 只包含待开放或已开放任务；仅 qs:admin 可访问。clinician_id 可选，存在时限制到该医生已分配受试者。
+// @Description queue_type 取值：high_risk、follow_up、key_focus、awaiting_review、critical_item；follow_up 只包含待开放或已开放任务；仅 qs:admin 可访问。clinician_id 可选，存在时限制到该医生已分配受试者；team_id 可选，存在时限制到该照护团队的受试者，二者不可同时使用。
 // @Tags Workbench
 // @Security BearerAuth
 // @Produce json
-// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus"
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
 // @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param triage_status query string false "分诊状态过滤：open/claimed/snoozed/resolved/handled/escalated/all，默认只返回待处理（open 与 claimed）条目"
 // @Param page query int false "页码，默认 1"
 // @Param page_size query int false "每页数量，默认 20，最大 100"
 // @Success 200 {object} response.ClinicianWorkbenchQueueResponse
 // @Router /api/v1/workbench/queues/{queue_type} [get]
 func (h *ClinicianWorkbenchHandler) ListOrgWorkbenchQueue(c *gin.Context) {
-	orgID, err := h.RequireProtectedOrgID(c)
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
-	clinicianID, err := optionalWorkbenchUint64Query(c, "clinician_id")
+	scope, err := orgWorkbenchScope(c, orgID)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
 	page, pageSize := paginationFromContext(c)
 	result, err := h.service.ListQueue(c.Request.Context(), workbenchApp.ListQueueDTO{
-		Scope: workbenchApp.Scope{
-			Kind:        workbenchApp.ScopeKindOrgAdmin,
-			OrgID:       orgID,
-			ClinicianID: clinicianID,
-		},
-		QueueType: workbenchApp.QueueType(c.Param("queue_type")),
-		Page:      page,
-		PageSize:  pageSize,
+		Scope:      scope,
+		QueueType:  workbenchApp.QueueType(c.Param("queue_type")),
+		TriageView: c.Query("triage_status"),
+		Page:       page,
+		PageSize:   pageSize,
 	})
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
-	h.Success(c, response.NewClinicianWorkbenchQueueResponse(result))
+	h.successQueue(c, orgID, operatorUserID, response.NewClinicianWorkbenchQueueResponse(result))
+}
+
+// successQueue 按查看者与各受试者的关系脱敏队列条目中的受试者后输出。
+func (h *ClinicianWorkbenchHandler) successQueue(c *gin.Context, orgID, operatorUserID int64, resp *response.ClinicianWorkbenchQueueResponse) {
+	testees := make([]*response.TesteeResponse, 0, len(resp.Items))
+	for i := range resp.Items {
+		testees = append(testees, resp.Items[i].Testee)
+	}
+	if err := redactTesteeResponses(c, h.access, orgID, operatorUserID, testees...); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, resp)
+}
+
+// myWorkbenchScope 当前医生视角；带 team_id 时切换为照护团队视角。
+func myWorkbenchScope(c *gin.Context, orgID, operatorUserID int64) (workbenchApp.Scope, error) {
+	teamID, err := optionalWorkbenchUint64Query(c, "team_id")
+	if err != nil {
+		return workbenchApp.Scope{}, err
+	}
+	scope := workbenchApp.Scope{Kind: workbenchApp.ScopeKindClinicianMe, OrgID: orgID, OperatorUserID: operatorUserID}
+	if teamID != nil {
+		scope.Kind = workbenchApp.ScopeKindCareTeam
+		scope.TeamID = teamID
+	}
+	return scope, nil
+}
+
+func orgWorkbenchScope(c *gin.Context, orgID int64) (workbenchApp.Scope, error) {
+	clinicianID, err := optionalWorkbenchUint64Query(c, "clinician_id")
+	if err != nil {
+		return workbenchApp.Scope{}, err
+	}
+	teamID, err := optionalWorkbenchUint64Query(c, "team_id")
+	if err != nil {
+		return workbenchApp.Scope{}, err
+	}
+	return workbenchApp.Scope{Kind: workbenchApp.ScopeKindOrgAdmin, OrgID: orgID, ClinicianID: clinicianID, TeamID: teamID}, nil
 }
 
 func optionalWorkbenchUint64Query(c *gin.Context, key string) (*uint64, error) {
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// ConsentHandler 知情同意管理处理器。
+type ConsentHandler struct {
+	*BaseHandler
+	service consentApp.Service
+}
+
+func NewConsentHandler(service consentApp.Service) *ConsentHandler {
+	return &ConsentHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// CreateConsentDocument godoc
+// @Summary 创建知情同意书草稿
+// @Description 同意书按范围版本化：org 适用于机构内所有作答，model 适用于指定问卷编码，entry 适用于经指定测评入口的作答。版本号在同一范围内自动递增，草稿发布后才生效。
+// @Tags consents
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateConsentDocumentRequest true "同意书"
+// @Success 200 {object} response.ConsentDocumentResponse
+// @Router /api/v1/consent-documents [post]
+func (h *ConsentHandler) CreateConsentDocument(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.CreateConsentDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid consent document request: %v", err))
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.CreateDocument(c.Request.Context(), consentApp.CreateDocumentDTO{
+		OrgID:      orgID,
+		ScopeKind:  req.ScopeKind,
+		ScopeRef:   req.ScopeRef,
+		Title:      req.Title,
+		Body:       req.Body,
+		OperatorID: operatorID,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentDocumentResponse(result))
+}
+
+// ListConsentDocuments godoc
+// @Summary 查询知情同意书
+// @Tags consents
+// @Security BearerAuth
+// @Produce json
+// @Param scope_kind query string false "适用范围：org/model/entry"
+// @Param scope_ref query string false "问卷编码或测评入口ID"
+// @Param status query string false "状态：draft/published/superseded/retired"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.ConsentDocumentListResponse
+// @Router /api/v1/consent-documents [get]
+func (h *ConsentHandler) ListConsentDocuments(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListDocuments(c.Request.Context(), consentApp.ListDocumentsDTO{
+		OrgID:     orgID,
+		ScopeKind: c.Query("scope_kind"),
+		ScopeRef:  c.Query("scope_ref"),
+		Status:    c.Query("status"),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentDocumentListResponse(result))
+}
+
+// GetConsentDocument godoc
+// @Summary 获取知情同意书
+// @Tags consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "同意书ID"
+// @Success 200 {object} response.ConsentDocumentResponse
+// @Router /api/v1/consent-documents/{id} [get]
+func (h *ConsentHandler) GetConsentDocument(c *gin.Context) {
+	orgID, documentID, ok := h.consentScope(c, "invalid consent document id")
+	if !ok {
+		return
+	}
+	result, err := h.service.GetDocument(c.Request.Context(), orgID, documentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentDocumentResponse(result))
+}
+
+// PublishConsentDocument godoc
+// @Summary 发布知情同意书
+// @Description 发布草稿并替代同一范围内的现行版本。已签署旧版本的受试者需重新签署后才能继续提交答卷。
+// @Tags consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "同意书ID"
+// @Success 200 {object} response.ConsentDocumentResponse
+// @Router /api/v1/consent-documents/{id}/publish [post]
+func (h *ConsentHandler) PublishConsentDocument(c *gin.Context) {
+	orgID, documentID, ok := h.consentScope(c, "invalid consent document id")
+	if !ok {
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.PublishDocument(c.Request.Context(), orgID, documentID, operatorID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentDocumentResponse(result))
+}
+
+// RetireConsentDocument godoc
+// @Summary 停用知情同意书
+// @Description 停用后该范围不再要求签署，直到发布新版本。
+// @Tags consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "同意书ID"
+// @Success 200 {object} response.ConsentDocumentResponse
+// @Router /api/v1/consent-documents/{id}/retire [post]
+func (h *ConsentHandler) RetireConsentDocument(c *gin.Context) {
+	orgID, documentID, ok := h.consentScope(c, "invalid consent document id")
+	if !ok {
+		return
+	}
+	result, err := h.service.RetireDocument(c.Request.Context(), orgID, documentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentDocumentResponse(result))
+}
+
+// ListConsentAcceptances godoc
+// @Summary 查询知情同意签署记录
+// @Tags consents
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id query string false "受试者ID"
+// @Param document_id query string false "同意书ID"
+// @Param active query bool false "仅返回未撤回的签署"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.ConsentAcceptanceListResponse
+// @Router /api/v1/consent-acceptances [get]
+func (h *ConsentHandler) ListConsentAcceptances(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	dto := consentApp.ListAcceptancesDTO{OrgID: orgID, ActiveOnly: c.Query("active") == "true"}
+	if raw := c.Query("testee_id"); raw != "" {
+		if dto.TesteeID, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+			return
+		}
+	}
+	if raw := c.Query("document_id"); raw != "" {
+		if dto.DocumentID, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid document_id"))
+			return
+		}
+	}
+	dto.Page, dto.PageSize = paginationFromContext(c)
+	result, err := h.service.ListAcceptances(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentAcceptanceListResponse(result))
+}
+
+// WithdrawConsentAcceptance godoc
+// @Summary 撤回知情同意
+// @Description 代受试者撤回签署（例如线下提出撤回）。撤回会发布 consent.withdrawn 事件，受试者需重新签署后才能继续提交答卷；已提交的答卷不受影响。
+// @Tags consents
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "签署记录ID"
+// @Param request body request.WithdrawConsentAcceptanceRequest true "撤回请求"
+// @Success 200 {object} response.ConsentAcceptanceResponse
+// @Router /api/v1/consent-acceptances/{id}/withdraw [post]
+func (h *ConsentHandler) WithdrawConsentAcceptance(c *gin.Context) {
+	orgID, acceptanceID, ok := h.consentScope(c, "invalid consent acceptance id")
+	if !ok {
+		return
+	}
+	var req request.WithdrawConsentAcceptanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid withdraw request: %v", err))
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.WithdrawAcceptance(c.Request.Context(), consentApp.WithdrawDTO{
+		OrgID:        orgID,
+		AcceptanceID: acceptanceID,
+		OperatorID:   operatorID,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewConsentAcceptanceResponse(result))
+}
+
+func (h *ConsentHandler) consentScope(c *gin.Context, invalidMessage string) (int64, uint64, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "%s", invalidMessage))
+		return 0, 0, false
+	}
+	return orgID, id, true
+}
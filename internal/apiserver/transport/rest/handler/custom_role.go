@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// CustomRoleHandler 机构自定义角色处理器：角色维护、分配，以及能力判定解释。
+type CustomRoleHandler struct {
+	*BaseHandler
+	service customRoleApp.Service
+}
+
+func NewCustomRoleHandler(service customRoleApp.Service) *CustomRoleHandler {
+	return &CustomRoleHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// ListGrantableCapabilities godoc
+// @Summary 查询可授予的能力
+// @Description 返回自定义角色可组合的能力与数据敏感级别；org_admin 与 unmask_testee_pii 只能由 IAM 授予。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.GrantableCapabilitiesResponse
+// @Router /api/v1/custom-roles/capabilities [get]
+func (h *CustomRoleHandler) ListGrantableCapabilities(c *gin.Context) {
+	h.Success(c, response.NewGrantableCapabilitiesResponse())
+}
+
+// ListCustomRoles godoc
+// @Summary 查询自定义角色
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.CustomRoleListResponse
+// @Router /api/v1/custom-roles [get]
+func (h *CustomRoleHandler) ListCustomRoles(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roles, err := h.service.ListRoles(c.Request.Context(), orgID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleListResponse(roles))
+}
+
+// CreateCustomRole godoc
+// @Summary 创建自定义角色
+// @Description 由能力组合成机构角色，可按测评模型、计划、科室限定范围，并限定可见的数据敏感级别。
+// @Description 有范围的角色只在会逐个资源判定的接口（目前为答卷查询）上生效。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CustomRoleRequest true "自定义角色"
+// @Success 200 {object} response.CustomRoleResponse
+// @Router /api/v1/custom-roles [post]
+func (h *CustomRoleHandler) CreateCustomRole(c *gin.Context) {
+	dto, ok := h.bindRole(c)
+	if !ok {
+		return
+	}
+	role, err := h.service.CreateRole(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleResponse(role))
+}
+
+// GetCustomRole godoc
+// @Summary 查询自定义角色详情
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} response.CustomRoleResponse
+// @Router /api/v1/custom-roles/{id} [get]
+func (h *CustomRoleHandler) GetCustomRole(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	role, err := h.service.GetRole(c.Request.Context(), orgID, roleID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleResponse(role))
+}
+
+// UpdateCustomRole godoc
+// @Summary 更新自定义角色
+// @Description 整体替换名称、能力、范围与敏感级别；角色编码不可修改。已分配的操作者在下一次请求时生效。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body request.CustomRoleRequest true "自定义角色"
+// @Success 200 {object} response.CustomRoleResponse
+// @Router /api/v1/custom-roles/{id} [put]
+func (h *CustomRoleHandler) UpdateCustomRole(c *gin.Context) {
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	dto, ok := h.bindRole(c)
+	if !ok {
+		return
+	}
+	role, err := h.service.UpdateRole(c.Request.Context(), roleID, dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleResponse(role))
+}
+
+// DeleteCustomRole godoc
+// @Summary 删除自定义角色
+// @Description 同时撤销该角色的全部分配。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/custom-roles/{id} [delete]
+func (h *CustomRoleHandler) DeleteCustomRole(c *gin.Context) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteRole(c.Request.Context(), orgID, operatorID, roleID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ListCustomRoleAssignments godoc
+// @Summary 查询自定义角色的分配
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} response.CustomRoleAssignmentListResponse
+// @Router /api/v1/custom-roles/{id}/assignments [get]
+func (h *CustomRoleHandler) ListCustomRoleAssignments(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	assignments, err := h.service.ListAssignments(c.Request.Context(), orgID, roleID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleAssignmentListResponse(assignments))
+}
+
+// AssignCustomRole godoc
+// @Summary 分配自定义角色
+// @Description 分配给本机构在职的后台操作者；重复分配返回已有分配。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body request.AssignCustomRoleRequest true "分配对象"
+// @Success 200 {object} response.CustomRoleAssignmentResponse
+// @Router /api/v1/custom-roles/{id}/assignments [post]
+func (h *CustomRoleHandler) AssignCustomRole(c *gin.Context) {
+	orgID, assignedBy, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	var req request.AssignCustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid custom role assignment request: %v", err))
+		return
+	}
+	operatorID, err := strconv.ParseUint(strings.TrimSpace(req.OperatorID), 10, 64)
+	if err != nil || operatorID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid operator_id"))
+		return
+	}
+	assignment, err := h.service.AssignRole(c.Request.Context(), orgID, assignedBy, roleID, operatorID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewCustomRoleAssignmentResponse(assignment))
+}
+
+// UnassignCustomRole godoc
+// @Summary 撤销自定义角色分配
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param operator_id path string true "后台操作者ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/custom-roles/{id}/assignments/{operator_id} [delete]
+func (h *CustomRoleHandler) UnassignCustomRole(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	roleID, ok := h.roleID(c)
+	if !ok {
+		return
+	}
+	operatorID, err := strconv.ParseUint(c.Param("operator_id"), 10, 64)
+	if err != nil || operatorID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid operator_id"))
+		return
+	}
+	if err := h.service.UnassignRole(c.Request.Context(), orgID, roleID, operatorID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ExplainCapability godoc
+// @Summary 解释能力判定
+// @Description 说明操作者能否对目标资源行使某项能力，并逐条列出 IAM 授权与各自定义角色的结论。
+// @Description 不传 user_id 时解释当前操作者；解释其他操作者需要机构管理员权限。
+// @Tags custom-roles
+// @Security BearerAuth
+// @Produce json
+// @Param capability query string true "能力"
+// @Param user_id query string false "操作者用户ID，默认当前用户"
+// @Param model_code query string false "目标测评模型编码"
+// @Param plan_id query string false "目标测评计划ID"
+// @Param department query string false "目标科室"
+// @Param sensitivity query string false "目标数据敏感级别，默认 identified"
+// @Success 200 {object} response.AuthzExplanationResponse
+// @Router /api/v1/authz/explain [get]
+func (h *CustomRoleHandler) ExplainCapability(c *gin.Context) {
+	orgID, currentUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	capability := authzapp.Capability(strings.TrimSpace(c.Query("capability")))
+	if capability == "" {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "capability is required"))
+		return
+	}
+	query := customRoleApp.ExplainQuery{
+		OrgID:      orgID,
+		UserID:     currentUserID,
+		Capability: capability,
+		Target: authzapp.Target{
+			ModelCode:   strings.TrimSpace(c.Query("model_code")),
+			Department:  strings.TrimSpace(c.Query("department")),
+			Sensitivity: authzapp.Sensitivity(strings.TrimSpace(c.Query("sensitivity"))),
+		},
+	}
+	if raw := strings.TrimSpace(c.Query("plan_id")); raw != "" {
+		if query.Target.PlanID, err = strconv.ParseUint(raw, 10, 64); err != nil || query.Target.PlanID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid plan_id"))
+			return
+		}
+	}
+	snapshot := middleware.GetAuthzSnapshot(c)
+	if raw := strings.TrimSpace(c.Query("user_id")); raw != "" {
+		if query.UserID, err = strconv.ParseInt(raw, 10, 64); err != nil || query.UserID <= 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid user_id"))
+			return
+		}
+	}
+	if query.UserID == currentUserID {
+		query.Snapshot = snapshot
+	} else if !authzapp.DecideCapability(snapshot, authzapp.CapabilityOrgAdmin).Allowed {
+		h.Error(c, errors.WithCode(code.ErrPermissionDenied, "explaining another operator requires org_admin"))
+		return
+	}
+	explanation, err := h.service.Explain(c.Request.Context(), query)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewAuthzExplanationResponse(explanation))
+}
+
+func (h *CustomRoleHandler) bindRole(c *gin.Context) (customRoleApp.RoleDTO, bool) {
+	orgID, operatorID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return customRoleApp.RoleDTO{}, false
+	}
+	var req request.CustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid custom role request: %v", err))
+		return customRoleApp.RoleDTO{}, false
+	}
+	planIDs := make([]uint64, 0, len(req.Scope.PlanIDs))
+	for _, raw := range req.Scope.PlanIDs {
+		id, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid plan_ids item %q", raw))
+			return customRoleApp.RoleDTO{}, false
+		}
+		planIDs = append(planIDs, id)
+	}
+	return customRoleApp.RoleDTO{
+		OrgID:        orgID,
+		OperatorID:   operatorID,
+		Code:         req.Code,
+		Name:         req.Name,
+		Description:  req.Description,
+		Capabilities: req.Capabilities,
+		Scope: authzapp.Scope{
+			ModelCodes:  req.Scope.ModelCodes,
+			PlanIDs:     planIDs,
+			Departments: req.Scope.Departments,
+		},
+		Sensitivity: req.Sensitivity,
+	}, true
+}
+
+func (h *CustomRoleHandler) roleID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid custom role id"))
+		return 0, false
+	}
+	return id, true
+}
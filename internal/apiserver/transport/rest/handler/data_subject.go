@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	subjectRights "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// DataSubjectHandler 数据主体请求处理器：受试者数据导出与擦除。
+type DataSubjectHandler struct {
+	*BaseHandler
+	service subjectRights.Service
+}
+
+func NewDataSubjectHandler(service subjectRights.Service) *DataSubjectHandler {
+	return &DataSubjectHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// CreateDataSubjectRequest godoc
+// @Summary 受理数据主体请求
+// @Description kind=export 生成受试者全部数据的导出包（subject.json 与渲染后的报告）；kind=erasure 按步骤擦除受试者在 MySQL、Mongo 与对象存储中的数据并签发证书。擦除默认 mode=anonymize：假名化受试者，保留测评、结果与报告的来源链路；mode=delete 连同测评与报告一并删除。执行失败时请求保留为 failed，可续做。
+// @Tags data-subject
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateDataSubjectRequest true "数据主体请求"
+// @Success 200 {object} response.DataSubjectRequestResponse
+// @Router /api/v1/data-subject-requests [post]
+func (h *DataSubjectHandler) CreateDataSubjectRequest(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.CreateDataSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid data subject request: %v", err))
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.Create(c.Request.Context(), subjectRights.CreateCommand{
+		OrgID:      orgID,
+		TesteeID:   req.TesteeID.Uint64(),
+		Kind:       subjectRights.Kind(strings.TrimSpace(req.Kind)),
+		Mode:       subjectRights.ErasureMode(strings.TrimSpace(req.Mode)),
+		Reason:     req.Reason,
+		OperatorID: operatorID,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewDataSubjectRequestResponse(result))
+}
+
+// ListDataSubjectRequests godoc
+// @Summary 查询数据主体请求
+// @Tags data-subject
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id query string false "受试者ID"
+// @Param kind query string false "请求类型：export/erasure"
+// @Param status query string false "状态：pending/running/failed/completed"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.DataSubjectRequestListResponse
+// @Router /api/v1/data-subject-requests [get]
+func (h *DataSubjectHandler) ListDataSubjectRequests(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	filter := subjectRights.Filter{
+		Kind:   subjectRights.Kind(strings.TrimSpace(c.Query("kind"))),
+		Status: subjectRights.Status(strings.TrimSpace(c.Query("status"))),
+	}
+	if raw := strings.TrimSpace(c.Query("testee_id")); raw != "" {
+		if filter.TesteeID, err = strconv.ParseUint(raw, 10, 64); err != nil || filter.TesteeID == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+			return
+		}
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.List(c.Request.Context(), orgID, filter, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewDataSubjectRequestListResponse(result))
+}
+
+// GetDataSubjectRequest godoc
+// @Summary 获取数据主体请求
+// @Tags data-subject
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "请求ID"
+// @Success 200 {object} response.DataSubjectRequestResponse
+// @Router /api/v1/data-subject-requests/{id} [get]
+func (h *DataSubjectHandler) GetDataSubjectRequest(c *gin.Context) {
+	orgID, requestID, ok := h.requestScope(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.Get(c.Request.Context(), orgID, requestID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewDataSubjectRequestResponse(result))
+}
+
+// ResumeDataSubjectRequest godoc
+// @Summary 续做数据主体请求
+// @Description 续做失败或执行中断的请求；已完成的擦除步骤不会重复执行，每个步骤本身也可安全重做。
+// @Tags data-subject
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "请求ID"
+// @Success 200 {object} response.DataSubjectRequestResponse
+// @Router /api/v1/data-subject-requests/{id}/resume [post]
+func (h *DataSubjectHandler) ResumeDataSubjectRequest(c *gin.Context) {
+	orgID, requestID, ok := h.requestScope(c)
+	if !ok {
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.Resume(c.Request.Context(), orgID, requestID, operatorID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewDataSubjectRequestResponse(result))
+}
+
+// DownloadDataSubjectBundle godoc
+// @Summary 下载受试者数据导出包
+// @Description 返回 zip：manifest.json、subject.json（按来源表/集合分组的全部记录）与 reports/ 下渲染为 Markdown 的报告。每次下载写入访问审计；受试者擦除后导出包随之删除。
+// @Tags data-subject
+// @Security BearerAuth
+// @Produce application/zip
+// @Param id path string true "导出请求ID"
+// @Success 200 {file} binary
+// @Router /api/v1/data-subject-requests/{id}/bundle [get]
+func (h *DataSubjectHandler) DownloadDataSubjectBundle(c *gin.Context) {
+	orgID, requestID, ok := h.requestScope(c)
+	if !ok {
+		return
+	}
+	if req, err := h.service.Get(c.Request.Context(), orgID, requestID); err == nil {
+		middleware.SetAccessAuditTestee(c, req.TesteeID)
+	}
+	bundle, err := h.service.OpenBundle(c.Request.Context(), orgID, requestID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	defer func() { _ = bundle.Body.Close() }()
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundle.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, bundle.Size, bundle.ContentType, bundle.Body, nil)
+}
+
+// GetDataSubjectCertificate godoc
+// @Summary 获取擦除证书
+// @Description 返回已完成擦除请求的证书：受试者假名、擦除方式、各步骤处理方式与影响记录数，以及证书内容的 SHA-256 摘要。
+// @Tags data-subject
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "擦除请求ID"
+// @Success 200 {object} response.DataSubjectCertificateResponse
+// @Router /api/v1/data-subject-requests/{id}/certificate [get]
+func (h *DataSubjectHandler) GetDataSubjectCertificate(c *gin.Context) {
+	orgID, requestID, ok := h.requestScope(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.GetCertificate(c.Request.Context(), orgID, requestID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewDataSubjectCertificateResponse(result))
+}
+
+func (h *DataSubjectHandler) requestScope(c *gin.Context) (int64, uint64, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid data subject request id"))
+		return 0, 0, false
+	}
+	return orgID, id, true
+}
@@ -12,9 +12,12 @@ import (
 	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
 	reportwaitjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportwait"
 	systemgov "github.com/FangcunMount/qs-server/internal/apiserver/application/systemgovernance"
+	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/locale"
 	pkgmiddleware "github.com/FangcunMount/qs-server/internal/pkg/middleware"
 )
 
@@ -215,6 +218,7 @@ func (h *EvaluationOperatorHandler) GetScores(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
+	middleware.SetAccessAuditTestee(c, result.TesteeID)
 
 	h.Success(c, response.NewScoreResponse(result))
 }
@@ -290,9 +294,13 @@ func (h *EvaluationOperatorHandler) GetHighRiskFactors(c *gin.Context) {
 // @Description 获取指定测评的解读报告。响应字段说明：
 // @Description - dimensions（维度列表）：每个维度包含 factor_code（因子编码）、factor_name（因子名称）、raw_score（原始分）、max_score（最大分，可选）、risk_level（风险等级）、description（解读描述）、suggestion（维度建议，字符串）字段
 // @Description - suggestions（建议列表）：报告级别的建议列表，每个建议包含 category（分类）、content（内容）、factor_code（关联因子编码，可选）字段
+// @Description - audience（受众版本）：默认返回 clinician 版本；机构管理员可指定 clinician/family/school，未发布的受众回落到 canonical
+// @Description - locale（内容语言）：默认返回报告生成时的语言；指定其他语言时按需渲染，模板或量表未提供该语言时返回生成时的语言
 // @Tags Evaluation-Report
 // @Produce json
 // @Param id path string true "测评ID"
+// @Param audience query string false "报告受众版本：clinician、family、school"
+// @Param locale query string false "报告内容语言，如 zh-CN、en-US"
 // @Success 200 {object} core.Response{data=response.ReportResponse}
 // @Failure 429 {object} core.ErrResponse
 // @Router /api/v1/evaluations/assessments/{id}/report [get]
@@ -307,15 +315,48 @@ func (h *AssessmentReportJourneyHandler) GetReport(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
-	result, err := h.reportQueryJourney.GetReport(c.Request.Context(), reportqueryjourney.Scope{OrgID: orgID, OperatorUserID: operatorUserID}, id)
+	audience, err := reportAudienceQuery(c)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
+	contentLocale, err := reportLocaleQuery(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.reportQueryJourney.GetReport(c.Request.Context(), reportqueryjourney.Scope{OrgID: orgID, OperatorUserID: operatorUserID}, reportqueryjourney.GetQuery{AssessmentID: id, Audience: audience, Locale: contentLocale})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, result.TesteeID)
 
 	h.Success(c, response.NewReportResponse(result))
 }
 
+// reportAudienceQuery 解析可选的 audience 查询参数；省略时由服务按访问决策选择默认受众。
+func reportAudienceQuery(c *gin.Context) (policy.ReportAudience, error) {
+	raw := c.Query("audience")
+	if raw == "" {
+		return policy.ReportAudienceCanonical, nil
+	}
+	audience, ok := policy.ParseReportAudience(raw)
+	if !ok {
+		return "", errors.WithCode(code.ErrInvalidArgument, "audience 仅支持 clinician、family、school")
+	}
+	return audience, nil
+}
+
+// reportLocaleQuery 解析可选的 locale 查询参数；省略时返回报告生成时的语言。
+func reportLocaleQuery(c *gin.Context) (string, error) {
+	tag, err := locale.Normalize(c.Query("locale"))
+	if err != nil {
+		return "", errors.WithCode(code.ErrInvalidArgument, "locale 需为 zh-CN、en-US 形式的语言标签")
+	}
+	return tag, nil
+}
+
 // ListReports 查询报告列表
 // @Summary 查询报告列表
 // @Description 查询当前机构或指定受试者的报告列表。每个报告包含 dimensions（维度列表）和 suggestions（建议列表）
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	evaluationoperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
 	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
 	reportwaitjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportwait"
@@ -26,6 +27,7 @@ type operatorQueryStub struct {
 	runList    *evaluationoperator.RunList
 	failedRuns *evaluationoperator.RetryableFailedRunList
 	latestRun  *evaluationoperator.Run
+	score      *evaluationoperator.Score
 }
 
 func (s *operatorQueryStub) GetAssessment(_ context.Context, actor evaluationoperator.Actor, id uint64) (*evaluationoperator.Assessment, error) {
@@ -47,8 +49,9 @@ func (*operatorQueryStub) GetAssessmentOutcome(context.Context, evaluationoperat
 func (*operatorQueryStub) ListAssessmentsOutcome(context.Context, evaluationoperator.Actor, evaluationoperator.ListQuery) (*evaluationoperator.OutcomeAssessmentList, error) {
 	return &evaluationoperator.OutcomeAssessmentList{}, nil
 }
-func (*operatorQueryStub) GetScores(context.Context, evaluationoperator.Actor, uint64) (*evaluationoperator.Score, error) {
-	return nil, nil
+func (s *operatorQueryStub) GetScores(_ context.Context, actor evaluationoperator.Actor, id uint64) (*evaluationoperator.Score, error) {
+	s.lastActor, s.lastID = actor, id
+	return s.score, s.err
 }
 func (*operatorQueryStub) GetHighRiskFactors(context.Context, evaluationoperator.Actor, uint64) (*evaluationoperator.HighRiskFactors, error) {
 	return nil, nil
@@ -158,6 +161,37 @@ func TestEvaluationHandlerGetAssessmentSuccess(t *testing.T) {
 	}
 }
 
+type accessAuditRecorderStub struct{ events []accessaudit.AccessEvent }
+
+func (r *accessAuditRecorderStub) Record(_ context.Context, event accessaudit.AccessEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestEvaluationHandlerGetScoresRecordsScoredTesteeInAccessAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	query := &operatorQueryStub{score: &evaluationoperator.Score{AssessmentID: 301, TesteeID: 4001, TotalScore: 12}}
+	h := NewEvaluationOperatorHandler(nil, query)
+	recorder := &accessAuditRecorderStub{}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(middleware.OrgIDKey, uint64(12))
+		c.Set(middleware.UserIDKey, uint64(34))
+	})
+	engine.GET("/api/v1/evaluations/assessments/:id/scores",
+		middleware.AccessAuditMiddleware(recorder, middleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentScores, ResourceParam: "id"}),
+		h.GetScores)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/evaluations/assessments/301/scores", nil))
+	if rec.Code != http.StatusOK || query.lastID != 301 {
+		t.Fatalf("response/query=%d %#v", rec.Code, query)
+	}
+	if len(recorder.events) != 1 || recorder.events[0].TesteeID != 4001 || recorder.events[0].ResourceID != "301" {
+		t.Fatalf("audit events = %+v, want scored testee 4001", recorder.events)
+	}
+}
+
 func TestEvaluationHandlerWaitReportReturnsTerminalSummaryImmediately(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	total, risk := 18.5, "medium"
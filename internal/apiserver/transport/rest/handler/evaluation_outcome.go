@@ -7,6 +7,7 @@ import (
 
 	evaluationoperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
 	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
 )
@@ -86,6 +87,7 @@ func (h *EvaluationOperatorHandler) ListAssessmentsOutcome(c *gin.Context) {
 // @Tags Evaluation-Report-Outcome
 // @Produce json
 // @Param id path string true "测评ID"
+// @Param locale query string false "报告内容语言，如 zh-CN、en-US"
 // @Success 200 {object} core.Response{data=response.ReportOutcomeResponse}
 // @Failure 429 {object} core.ErrResponse
 // @Router /api/v2/evaluations/assessments/{id}/report [get]
@@ -100,11 +102,22 @@ func (h *AssessmentReportJourneyHandler) GetReportOutcome(c *gin.Context) {
 		h.Error(c, err)
 		return
 	}
-	result, err := h.reportQueryJourney.GetReport(c.Request.Context(), reportqueryjourney.Scope{OrgID: orgID, OperatorUserID: operatorUserID}, id)
+	audience, err := reportAudienceQuery(c)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
+	contentLocale, err := reportLocaleQuery(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.reportQueryJourney.GetReport(c.Request.Context(), reportqueryjourney.Scope{OrgID: orgID, OperatorUserID: operatorUserID}, reportqueryjourney.GetQuery{AssessmentID: id, Audience: audience, Locale: contentLocale})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, result.TesteeID)
 	h.Success(c, response.NewReportOutcomeResponse(result))
 }
 
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	fhirJSONContentType   = "application/fhir+json; charset=utf-8"
+	fhirNDJSONContentType = "application/fhir+ndjson"
+	// fhirBasePath FHIR 接口挂载路径，同时作为问卷 canonical URL 与本地术语体系的前缀。
+	fhirBasePath = "/api/v1/fhir"
+)
+
+// FHIRHandler FHIR R4 只读接口：问卷、答卷、得分观察与解读报告，以及机构批量导出。
+// 响应直接是 FHIR 资源，不套用统一响应结构；错误以 OperationOutcome 返回。
+type FHIRHandler struct {
+	*BaseHandler
+	service fhir.Service
+}
+
+func NewFHIRHandler(service fhir.Service) *FHIRHandler {
+	return &FHIRHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// GetQuestionnaire godoc
+// @Summary 读取 FHIR Questionnaire
+// @Description id 为问卷编码，返回当前生效的发布版本；题目显示规则映射为 enableWhen，无法等价表达的组合条件使用 SDC enableWhenExpression。
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+json
+// @Param id path string true "问卷编码"
+// @Success 200 {object} fhir.Questionnaire
+// @Router /api/v1/fhir/Questionnaire/{id} [get]
+func (h *FHIRHandler) GetQuestionnaire(c *gin.Context) {
+	h.getQuestionnaire(c, "")
+}
+
+// GetQuestionnaireVersion godoc
+// @Summary 读取指定版本的 FHIR Questionnaire
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+json
+// @Param id path string true "问卷编码"
+// @Param vid path string true "问卷版本"
+// @Success 200 {object} fhir.Questionnaire
+// @Router /api/v1/fhir/Questionnaire/{id}/_history/{vid} [get]
+func (h *FHIRHandler) GetQuestionnaireVersion(c *gin.Context) {
+	version := strings.TrimSpace(c.Param("vid"))
+	if version == "" {
+		h.fhirError(c, errors.WithCode(code.ErrInvalidArgument, "version is required"))
+		return
+	}
+	h.getQuestionnaire(c, version)
+}
+
+func (h *FHIRHandler) getQuestionnaire(c *gin.Context, version string) {
+	scope, ok := h.scope(c)
+	if !ok {
+		return
+	}
+	resource, err := h.service.GetQuestionnaire(c.Request.Context(), scope, strings.TrimSpace(c.Param("id")), version)
+	if err != nil {
+		h.fhirError(c, err)
+		return
+	}
+	h.fhirResource(c, resource)
+}
+
+// GetQuestionnaireResponse godoc
+// @Summary 读取 FHIR QuestionnaireResponse
+// @Description id 为答卷 ID，仅限本机构；受试者以逻辑标识引用。
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+json
+// @Param id path string true "答卷ID"
+// @Success 200 {object} fhir.QuestionnaireResponse
+// @Router /api/v1/fhir/QuestionnaireResponse/{id} [get]
+func (h *FHIRHandler) GetQuestionnaireResponse(c *gin.Context) {
+	scope, id, ok := h.numericScope(c)
+	if !ok {
+		return
+	}
+	resource, err := h.service.GetQuestionnaireResponse(c.Request.Context(), scope, id)
+	if err != nil {
+		h.fhirError(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, fhir.SubjectTesteeID(resource.Subject))
+	h.fhirResource(c, resource)
+}
+
+// GetObservation godoc
+// @Summary 读取 FHIR Observation
+// @Description id 为 {测评ID}-{序号}（因子得分）或 {测评ID}-result（结论等级）。
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+json
+// @Param id path string true "Observation ID"
+// @Success 200 {object} fhir.Observation
+// @Router /api/v1/fhir/Observation/{id} [get]
+func (h *FHIRHandler) GetObservation(c *gin.Context) {
+	scope, ok := h.scope(c)
+	if !ok {
+		return
+	}
+	resource, err := h.service.GetObservation(c.Request.Context(), scope, strings.TrimSpace(c.Param("id")))
+	if err != nil {
+		h.fhirError(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, fhir.SubjectTesteeID(resource.Subject))
+	h.fhirResource(c, resource)
+}
+
+// GetDiagnosticReport godoc
+// @Summary 读取 FHIR DiagnosticReport
+// @Description id 为测评ID，返回临床受众版本的解读报告。
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+json
+// @Param id path string true "测评ID"
+// @Success 200 {object} fhir.DiagnosticReport
+// @Router /api/v1/fhir/DiagnosticReport/{id} [get]
+func (h *FHIRHandler) GetDiagnosticReport(c *gin.Context) {
+	scope, id, ok := h.numericScope(c)
+	if !ok {
+		return
+	}
+	resource, err := h.service.GetDiagnosticReport(c.Request.Context(), scope, id)
+	if err != nil {
+		h.fhirError(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, fhir.SubjectTesteeID(resource.Subject))
+	h.fhirResource(c, resource)
+}
+
+// Export godoc
+// @Summary 批量导出 FHIR 资源
+// @Description 以 NDJSON 同步流式导出本机构在时间范围内已完成评估的测评：问卷（每个版本一次）、答卷、得分 Observation 与 DiagnosticReport。时间跨度不超过 366 天、测评数不超过 5000；导出中途失败时最后一行为 OperationOutcome。
+// @Tags fhir
+// @Security BearerAuth
+// @Produce application/fhir+ndjson
+// @Param _since query string true "测评创建时间起点（RFC3339 或 YYYY-MM-DD）"
+// @Param _until query string false "测评创建时间终点，默认当前时间（RFC3339 或 YYYY-MM-DD，日期按整天计）"
+// @Param _type query string false "逗号分隔的资源类型：Questionnaire,QuestionnaireResponse,Observation,DiagnosticReport"
+// @Success 200 {string} string "NDJSON"
+// @Router /api/v1/fhir/$export [get]
+func (h *FHIRHandler) Export(c *gin.Context) {
+	scope, ok := h.scope(c)
+	if !ok {
+		return
+	}
+	since, err := parseAccessAuditTime(c.Query("_since"), false)
+	if err != nil {
+		h.fhirError(c, errors.WithCode(code.ErrInvalidArgument, "invalid _since"))
+		return
+	}
+	until, err := parseAccessAuditTime(c.Query("_until"), true)
+	if err != nil {
+		h.fhirError(c, errors.WithCode(code.ErrInvalidArgument, "invalid _until"))
+		return
+	}
+	query := fhir.ExportQuery{Since: since, Until: until}
+	for _, resourceType := range strings.Split(c.Query("_type"), ",") {
+		if resourceType = strings.TrimSpace(resourceType); resourceType != "" {
+			query.Types = append(query.Types, resourceType)
+		}
+	}
+
+	// 参数校验失败时服务不写出任何内容，响应头延迟到第一行写出时才发送，仍可返回错误状态。
+	c.Header("Content-Type", fhirNDJSONContentType)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("fhir-export-%d-%s.ndjson", scope.OrgID, time.Now().Format("20060102150405"))))
+	summary, err := h.service.Export(c.Request.Context(), scope, query, c.Writer)
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			h.fhirError(c, err)
+		}
+		return
+	}
+	if !c.Writer.Written() {
+		c.Status(http.StatusOK)
+	}
+	logger.L(c.Request.Context()).Infow("FHIR bulk export completed",
+		"action", "fhir_export",
+		"org_id", scope.OrgID,
+		"assessments", summary.Assessments,
+		"resources", summary.Resources,
+	)
+}
+
+func (h *FHIRHandler) scope(c *gin.Context) (fhir.Scope, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.fhirError(c, err)
+		return fhir.Scope{}, false
+	}
+	return fhir.Scope{OrgID: orgID, BaseURL: fhirBaseURL(c)}, true
+}
+
+func (h *FHIRHandler) numericScope(c *gin.Context) (fhir.Scope, uint64, bool) {
+	scope, ok := h.scope(c)
+	if !ok {
+		return fhir.Scope{}, 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(c.Param("id")), 10, 64)
+	if err != nil || id == 0 {
+		h.fhirError(c, errors.WithCode(code.ErrInvalidArgument, "invalid resource id"))
+		return fhir.Scope{}, 0, false
+	}
+	return scope, id, true
+}
+
+func (h *FHIRHandler) fhirResource(c *gin.Context, resource any) {
+	c.Header("Cache-Control", "no-store")
+	c.Render(http.StatusOK, fhirJSON{data: resource})
+}
+
+// fhirError 按错误码的 HTTP 状态返回 OperationOutcome；5xx 只返回通用描述。
+func (h *FHIRHandler) fhirError(c *gin.Context, err error) {
+	coder := errors.ParseCoder(err)
+	status := coder.HTTPStatus()
+	diagnostics := coder.String()
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		if cause := errors.Cause(err); cause != nil && strings.TrimSpace(cause.Error()) != "" {
+			diagnostics = cause.Error()
+		}
+	} else {
+		logger.L(c.Request.Context()).Errorw("FHIR request failed",
+			"action", "fhir_read",
+			"route", c.FullPath(),
+			"error", err.Error(),
+		)
+	}
+	c.Header("Content-Type", fhirJSONContentType)
+	c.Render(status, fhirJSON{data: fhir.NewOperationOutcome("error", fhirIssueCode(status), diagnostics)})
+}
+
+func fhirIssueCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid"
+	case http.StatusUnauthorized:
+		return "login"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not-found"
+	case http.StatusTooManyRequests:
+		return "throttled"
+	default:
+		return "exception"
+	}
+}
+
+// fhirBaseURL 按本次请求推导 FHIR base URL；经反向代理时以转发头为准。
+func fhirBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := strings.TrimSpace(strings.Split(c.GetHeader("X-Forwarded-Proto"), ",")[0]); forwarded == "http" || forwarded == "https" {
+		scheme = forwarded
+	}
+	host := c.Request.Host
+	if forwarded := strings.TrimSpace(strings.Split(c.GetHeader("X-Forwarded-Host"), ",")[0]); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host + fhirBasePath
+}
+
+// fhirJSON 以 application/fhir+json 渲染资源。
+type fhirJSON struct {
+	data any
+}
+
+func (r fhirJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.data)
+}
+
+func (r fhirJSON) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", fhirJSONContentType)
+}
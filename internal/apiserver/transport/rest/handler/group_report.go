@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// GroupReportHandler 群体报告处理器：机构管理员按群体与学期生成、查看和下载群体报告。
+type GroupReportHandler struct {
+	*BaseHandler
+	service groupReportApp.Service
+}
+
+func NewGroupReportHandler(service groupReportApp.Service) *GroupReportHandler {
+	return &GroupReportHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// GenerateGroupReport godoc
+// @Summary 生成群体报告
+// @Description 汇总群体内成员在学期内最近一次已完成测评的冻结结果，给出因子等级分布、风险占比、常模对照、与上一学期的比较以及按风险带的假名名单。
+// @Description 小于最小单元格的计数不公布；本学期参测人数不足最小单元格时返回 409。输入未变化时返回已有报告。
+// @Tags Interpretation-Group
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.GenerateGroupReportRequest true "群体与学期"
+// @Success 200 {object} core.Response{data=response.GroupReportResponse}
+// @Router /api/v1/group-reports [post]
+func (h *GroupReportHandler) GenerateGroupReport(c *gin.Context) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.GenerateGroupReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid group report request: %v", err))
+		return
+	}
+	dto, err := groupReportRequest(req)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	report, err := h.service.Generate(c.Request.Context(), groupReportApp.Actor{OrgID: orgID, OperatorUserID: userID}, dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewGroupReportResponse(report))
+}
+
+// GetGroupReport godoc
+// @Summary 查看群体报告
+// @Tags Interpretation-Group
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "群体报告ID"
+// @Success 200 {object} core.Response{data=response.GroupReportResponse}
+// @Router /api/v1/group-reports/{id} [get]
+func (h *GroupReportHandler) GetGroupReport(c *gin.Context) {
+	actor, id, ok := h.parseGroupReportPath(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.Get(c.Request.Context(), actor, id)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewGroupReportResponse(report))
+}
+
+// DownloadGroupReportPDF godoc
+// @Summary 下载群体报告 PDF
+// @Description 按机构品牌渲染群体报告 PDF；内容与 JSON 相同，被抑制的计数以 * 标记。
+// @Tags Interpretation-Group
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "群体报告ID"
+// @Success 200 {file} binary
+// @Router /api/v1/group-reports/{id}/pdf [get]
+func (h *GroupReportHandler) DownloadGroupReportPDF(c *gin.Context) {
+	actor, id, ok := h.parseGroupReportPath(c)
+	if !ok {
+		return
+	}
+	report, body, err := h.service.RenderPDF(c.Request.Context(), actor, id)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("group-report-%d.pdf", report.ID)))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/pdf", body)
+}
+
+func (h *GroupReportHandler) parseGroupReportPath(c *gin.Context) (groupReportApp.Actor, uint64, bool) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return groupReportApp.Actor{}, 0, false
+	}
+	id, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return groupReportApp.Actor{}, 0, false
+	}
+	return groupReportApp.Actor{OrgID: orgID, OperatorUserID: userID}, id, true
+}
+
+func groupReportRequest(req request.GenerateGroupReportRequest) (groupReportApp.Request, error) {
+	term, err := groupReportTerm(req.Term)
+	if err != nil {
+		return groupReportApp.Request{}, err
+	}
+	dto := groupReportApp.Request{
+		Cohort: groupReportApp.Cohort{
+			Kind:    groupReportApp.CohortKind(strings.TrimSpace(req.Cohort.Kind)),
+			EntryID: req.Cohort.EntryID.Uint64(),
+			PlanID:  req.Cohort.PlanID.Uint64(),
+			Tag:     req.Cohort.Tag,
+		},
+		ModelCode: req.ModelCode,
+		Term:      term,
+	}
+	for _, id := range req.Cohort.TesteeIDs {
+		dto.Cohort.TesteeIDs = append(dto.Cohort.TesteeIDs, id.Uint64())
+	}
+	if req.PreviousTerm != nil {
+		previous, err := groupReportTerm(*req.PreviousTerm)
+		if err != nil {
+			return groupReportApp.Request{}, err
+		}
+		dto.PreviousTerm = &previous
+	}
+	return dto, nil
+}
+
+func groupReportTerm(req request.GroupReportTermRequest) (groupReportApp.Term, error) {
+	from, err := parseAccessAuditTime(req.From, false)
+	if err != nil {
+		return groupReportApp.Term{}, errors.WithCode(code.ErrInvalidArgument, "term from 格式无效，必须为 RFC3339 或 YYYY-MM-DD")
+	}
+	to, err := parseAccessAuditTime(req.To, true)
+	if err != nil {
+		return groupReportApp.Term{}, errors.WithCode(code.ErrInvalidArgument, "term to 格式无效，必须为 RFC3339 或 YYYY-MM-DD")
+	}
+	return groupReportApp.Term{From: from, To: to}, nil
+}
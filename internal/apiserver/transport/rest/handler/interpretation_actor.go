@@ -10,9 +10,11 @@ import (
 	interpretationcatalog "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/catalogreconcile"
 	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
 	interpretationoperations "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/operations"
+	interpretationregeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
 	interpretationreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reporttemplate"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/admission"
 	"github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/policy"
+	domainregeneration "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/regeneration"
 	domainreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/domain/interpretation/reporttemplate"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
 	"github.com/FangcunMount/qs-server/internal/pkg/code"
@@ -112,6 +114,310 @@ func (h *InterpretationReportTemplateHandler) CreateDraft(c *gin.Context) {
 	h.Success(c, reportTemplateResponse(item))
 }
 
+type InterpretationReportTemplateRolloutHandler struct {
+	*BaseHandler
+	service interpretationreporttemplate.RolloutService
+}
+
+func NewInterpretationReportTemplateRolloutHandler(service interpretationreporttemplate.RolloutService) *InterpretationReportTemplateRolloutHandler {
+	return &InterpretationReportTemplateRolloutHandler{BaseHandler: &BaseHandler{}, service: service}
+}
+
+type reportTemplateRolloutWire struct {
+	RolloutID        string     `json:"rollout_id"`
+	TemplateID       string     `json:"template_id"`
+	StableVersion    string     `json:"stable_version"`
+	CandidateVersion string     `json:"candidate_version"`
+	Mode             string     `json:"mode"`
+	Percent          int        `json:"percent"`
+	OrgIDs           []int64    `json:"org_ids,omitempty"`
+	Status           string     `json:"status"`
+	Version          uint64     `json:"version"`
+	Reason           string     `json:"reason"`
+	StartedAt        time.Time  `json:"started_at"`
+	StartedBy        string     `json:"started_by"`
+	ClosedAt         *time.Time `json:"closed_at,omitempty"`
+	ClosedBy         string     `json:"closed_by,omitempty"`
+	CloseReason      string     `json:"close_reason,omitempty"`
+}
+
+func reportTemplateRolloutResponse(item *domainreporttemplate.Rollout) reportTemplateRolloutWire {
+	return reportTemplateRolloutWire{
+		RolloutID: item.ID().String(), TemplateID: item.TemplateID(),
+		StableVersion: item.StableVersion().String(), CandidateVersion: item.CandidateVersion().String(),
+		Mode: string(item.Mode()), Percent: item.Percent(), OrgIDs: item.OrgIDs(), Status: string(item.Status()),
+		Version: item.Version(), Reason: item.Reason(), StartedAt: item.StartedAt(), StartedBy: item.StartedBy(),
+		ClosedAt: item.ClosedAt(), ClosedBy: item.ClosedBy(), CloseReason: item.CloseReason(),
+	}
+}
+
+type reportTemplateShadowComparisonWire struct {
+	ComparisonID             string                            `json:"comparison_id"`
+	OutcomeID                string                            `json:"outcome_id"`
+	ReportID                 string                            `json:"report_id"`
+	StableVersion            string                            `json:"stable_version"`
+	CandidateVersion         string                            `json:"candidate_version"`
+	StableBuilderIdentity    string                            `json:"stable_builder_identity"`
+	CandidateBuilderIdentity string                            `json:"candidate_builder_identity"`
+	Identical                bool                              `json:"identical"`
+	Changes                  []reportTemplateContentChangeWire `json:"changes"`
+	CreatedAt                time.Time                         `json:"created_at"`
+}
+
+type reportTemplateContentChangeWire struct {
+	Path      string `json:"path"`
+	Current   string `json:"current"`
+	Candidate string `json:"candidate"`
+}
+
+// ListRollouts 列出模板的灰度发布；开始、提升与回滚只走治理动作。
+func (h *InterpretationReportTemplateRolloutHandler) ListRollouts(c *gin.Context) {
+	if _, _, err := h.RequireProtectedScope(c); err != nil {
+		h.Error(c, err)
+		return
+	}
+	items, err := h.service.ListRollouts(c.Request.Context(), c.Param("template_id"), 100)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result := make([]reportTemplateRolloutWire, 0, len(items))
+	for _, item := range items {
+		result = append(result, reportTemplateRolloutResponse(item))
+	}
+	h.Success(c, result)
+}
+
+func (h *InterpretationReportTemplateRolloutHandler) GetRollout(c *gin.Context) {
+	if _, _, err := h.RequireProtectedScope(c); err != nil {
+		h.Error(c, err)
+		return
+	}
+	rolloutID, ok := parseMetaPath(c, "rollout_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	item, err := h.service.GetRollout(c.Request.Context(), rolloutID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, reportTemplateRolloutResponse(item))
+}
+
+// ListComparisons 返回当前组织影子样本中候选草稿与已提交报告的逐字段差异，不包含候选正文。
+func (h *InterpretationReportTemplateRolloutHandler) ListComparisons(c *gin.Context) {
+	orgID, _, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	rolloutID, ok := parseMetaPath(c, "rollout_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	items, err := h.service.ListShadowComparisons(c.Request.Context(), rolloutID, orgID, limit)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result := make([]reportTemplateShadowComparisonWire, 0, len(items))
+	for _, item := range items {
+		changes := make([]reportTemplateContentChangeWire, 0, len(item.Changes()))
+		for _, change := range item.Changes() {
+			changes = append(changes, reportTemplateContentChangeWire{Path: change.Path, Current: change.Current, Candidate: change.Candidate})
+		}
+		result = append(result, reportTemplateShadowComparisonWire{
+			ComparisonID: item.ID().String(), OutcomeID: item.OutcomeID().String(), ReportID: item.ReportID().String(),
+			StableVersion: item.StableVersion().String(), CandidateVersion: item.CandidateVersion().String(),
+			StableBuilderIdentity: item.StableBuilderIdentity(), CandidateBuilderIdentity: item.CandidateBuilderIdentity(),
+			Identical: item.Identical(), Changes: changes, CreatedAt: item.CreatedAt(),
+		})
+	}
+	h.Success(c, result)
+}
+
+type InterpretationReportRegenerationHandler struct {
+	*BaseHandler
+	service interpretationregeneration.Service
+}
+
+func NewInterpretationReportRegenerationHandler(service interpretationregeneration.Service) *InterpretationReportRegenerationHandler {
+	return &InterpretationReportRegenerationHandler{BaseHandler: &BaseHandler{}, service: service}
+}
+
+type reportRegenerationSelectionRequest struct {
+	ModelCode     string     `json:"model_code"`
+	ModelVersion  string     `json:"model_version"`
+	GeneratedFrom *time.Time `json:"generated_from"`
+	GeneratedTo   *time.Time `json:"generated_to"`
+	TemplateID    string     `json:"template_id"`
+	TargetVersion string     `json:"target_version"`
+}
+
+type reportRegenerationDryRunWire struct {
+	Matched      int64 `json:"matched"`
+	OnTarget     int64 `json:"on_target"`
+	ToRegenerate int64 `json:"to_regenerate"`
+}
+
+type reportRegenerationBatchWire struct {
+	BatchID       string     `json:"batch_id"`
+	ModelCode     string     `json:"model_code"`
+	ModelVersion  string     `json:"model_version,omitempty"`
+	GeneratedFrom *time.Time `json:"generated_from,omitempty"`
+	GeneratedTo   *time.Time `json:"generated_to,omitempty"`
+	TemplateID    string     `json:"template_id"`
+	TargetVersion string     `json:"target_version"`
+	Estimated     int64      `json:"estimated"`
+	Selected      int        `json:"selected"`
+	Generated     int        `json:"generated"`
+	Skipped       int        `json:"skipped"`
+	Failed        int        `json:"failed"`
+	Pending       int        `json:"pending"`
+	Status        string     `json:"status"`
+	Version       uint64     `json:"version"`
+	Reason        string     `json:"reason"`
+	RequestedBy   string     `json:"requested_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	ClosedAt      *time.Time `json:"closed_at,omitempty"`
+	ClosedBy      string     `json:"closed_by,omitempty"`
+	CloseReason   string     `json:"close_reason,omitempty"`
+}
+
+type reportRegenerationItemWire struct {
+	OutcomeID        string     `json:"outcome_id"`
+	AssessmentID     string     `json:"assessment_id"`
+	PreviousReportID string     `json:"previous_report_id"`
+	PreviousVersion  string     `json:"previous_version"`
+	Status           string     `json:"status"`
+	GenerationID     string     `json:"generation_id,omitempty"`
+	ReportID         string     `json:"report_id,omitempty"`
+	ChangedFields    int        `json:"changed_fields"`
+	ChangedPaths     []string   `json:"changed_paths,omitempty"`
+	Message          string     `json:"message,omitempty"`
+	RetryAt          *time.Time `json:"retry_at,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func reportRegenerationBatchResponse(item *domainregeneration.Batch) reportRegenerationBatchWire {
+	selector, progress := item.Selector(), item.Progress()
+	return reportRegenerationBatchWire{
+		BatchID: item.ID().String(), ModelCode: selector.ModelCode, ModelVersion: selector.ModelVersion,
+		GeneratedFrom: selector.GeneratedFrom, GeneratedTo: selector.GeneratedTo,
+		TemplateID: item.TemplateID(), TargetVersion: item.TargetVersion().String(), Estimated: item.Estimated(),
+		Selected: progress.Selected, Generated: progress.Generated, Skipped: progress.Skipped,
+		Failed: progress.Failed, Pending: progress.Pending, Status: string(item.Status()), Version: item.Version(),
+		Reason: item.Reason(), RequestedBy: item.RequestedBy(), CreatedAt: item.CreatedAt(), UpdatedAt: item.UpdatedAt(),
+		ClosedAt: item.ClosedAt(), ClosedBy: item.ClosedBy(), CloseReason: item.CloseReason(),
+	}
+}
+
+func reportRegenerationItemResponse(item *domainregeneration.Item) reportRegenerationItemWire {
+	wire := reportRegenerationItemWire{
+		OutcomeID: item.OutcomeID().String(), AssessmentID: item.AssessmentID().String(),
+		PreviousReportID: item.PreviousReportID().String(), PreviousVersion: item.PreviousVersion().String(),
+		Status: string(item.Status()), ChangedFields: item.Diff().ChangedFields, ChangedPaths: item.Diff().Paths,
+		Message: item.Message(), RetryAt: item.RetryAt(), UpdatedAt: item.UpdatedAt(),
+	}
+	if !item.GenerationID().IsZero() {
+		wire.GenerationID = item.GenerationID().String()
+	}
+	if !item.ReportID().IsZero() {
+		wire.ReportID = item.ReportID().String()
+	}
+	return wire
+}
+
+// DryRun 统计当前组织按选择条件会重生成多少 Outcome；开始与取消只走治理动作。
+func (h *InterpretationReportRegenerationHandler) DryRun(c *gin.Context) {
+	orgID, _, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var request reportRegenerationSelectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.service.DryRun(c.Request.Context(), interpretationregeneration.DryRunCommand{
+		Selector: domainregeneration.Selector{
+			OrgID: orgID, ModelCode: request.ModelCode, ModelVersion: request.ModelVersion,
+			GeneratedFrom: request.GeneratedFrom, GeneratedTo: request.GeneratedTo,
+		},
+		TemplateID: request.TemplateID, TargetVersion: policy.TemplateVersion(request.TargetVersion),
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, reportRegenerationDryRunWire{Matched: result.Matched, OnTarget: result.OnTarget, ToRegenerate: result.ToRegenerate})
+}
+
+func (h *InterpretationReportRegenerationHandler) ListBatches(c *gin.Context) {
+	orgID, _, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	items, err := h.service.ListBatches(c.Request.Context(), orgID, limit)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result := make([]reportRegenerationBatchWire, 0, len(items))
+	for _, item := range items {
+		result = append(result, reportRegenerationBatchResponse(item))
+	}
+	h.Success(c, result)
+}
+
+func (h *InterpretationReportRegenerationHandler) GetBatch(c *gin.Context) {
+	orgID, _, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	batchID, ok := parseMetaPath(c, "batch_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	item, err := h.service.GetBatch(c.Request.Context(), orgID, batchID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, reportRegenerationBatchResponse(item))
+}
+
+// ListItems 返回批次内逐 Outcome 的处理结果与差异摘要（仅字段路径）。
+func (h *InterpretationReportRegenerationHandler) ListItems(c *gin.Context) {
+	orgID, _, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	batchID, ok := parseMetaPath(c, "batch_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	items, err := h.service.ListItems(c.Request.Context(), orgID, batchID, domainregeneration.ItemStatus(c.Query("status")), limit)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result := make([]reportRegenerationItemWire, 0, len(items))
+	for _, item := range items {
+		result = append(result, reportRegenerationItemResponse(item))
+	}
+	h.Success(c, result)
+}
+
 type InterpretationClinicianHandler struct {
 	*BaseHandler
 	service interpretationclinician.Service
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 
 	"github.com/FangcunMount/component-base/pkg/errors"
@@ -53,6 +54,8 @@ func (h *PlanHandler) SetTesteeAccessService(testeeAccessService actorAccessApp.
 // @Description - by_week/by_day: 需要 interval（间隔）和 total_times（总次数）
 // @Description - fixed_date: 需要 fixed_dates（固定日期列表）
 // @Description - custom: 需要 relative_weeks（相对周次列表）
+// @Description - rrule: 需要 recurrence_rule（RRULE，支持 FREQ=DAILY/WEEKLY/MONTHLY，须含 COUNT 或 UNTIL），可选 exception_dates（排除日期）
+// @Description 可选 time_zone 指定 IANA 时区，触发时间、截止时间与入口有效期均按该时区计算
 // @Tags Plan-Lifecycle
 // @Accept json
 // @Produce json
@@ -135,6 +138,8 @@ func (h *PlanHandler) parseCreatePlanInput(c *gin.Context) (createPlanInput, boo
 		"total_times", req.TotalTimes,
 		"fixed_dates", req.FixedDates,
 		"relative_weeks", req.RelativeWeeks,
+		"time_zone", req.TimeZone,
+		"recurrence_rule", req.RecurrenceRule,
 	)
 
 	return createPlanInput{
@@ -160,6 +165,10 @@ func buildCreatePlanDTO(input createPlanInput) planApp.CreatePlanDTO {
 		TotalTimes:    input.req.TotalTimes,
 		FixedDates:    input.req.FixedDates,
 		RelativeWeeks: input.req.RelativeWeeks,
+
+		TimeZone:       input.req.TimeZone,
+		RecurrenceRule: input.req.RecurrenceRule,
+		ExceptionDates: input.req.ExceptionDates,
 	}
 }
 
@@ -941,6 +950,38 @@ func (h *PlanHandler) ListTasksByTestee(c *gin.Context) {
 	h.Success(c, response.NewTaskListResponseFromSlice(tasks))
 }
 
+// ExportTesteeCalendar 导出受试者待完成任务日历
+// @Summary 导出受试者待完成任务的 ICS 日历
+// @Description 导出受试者 pending/opened 且未过截止时间的计划任务（iCalendar / RFC 5545），供家长导入手机或邮箱日历
+// @Tags Plan-Query
+// @Produce text/calendar
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path string true "受试者ID"
+// @Success 200 {string} string "ICS 文件内容"
+// @Failure 429 {object} core.ErrResponse
+// @Router /api/v1/testees/{id}/tasks/calendar.ics [get]
+func (h *PlanHandler) ExportTesteeCalendar(c *gin.Context) {
+	testeeID := c.Param("id")
+	if testeeID == "" {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "受试者ID不能为空"))
+		return
+	}
+	if _, _, err := h.validateProtectedTesteeID(c, testeeID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	result, err := h.queryService.ExportTesteeCalendar(c.Request.Context(), testeeID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+result.FileName+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", result.Content)
+}
+
 // ListPlansByTestee 查询受试者参与的所有计划
 // @Summary 查询受试者参与的所有计划
 // @Description 查看某个受试者参与的所有计划
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	planReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/planreport"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/gin-gonic/gin"
+)
+
+// PlanReportHandler 纵向计划报告处理器：按需生成与查看参与轮次的纵向报告。
+type PlanReportHandler struct {
+	*BaseHandler
+	service planReportApp.Service
+}
+
+func NewPlanReportHandler(service planReportApp.Service) *PlanReportHandler {
+	return &PlanReportHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// GenerateLongitudinalReport godoc
+// @Summary 生成纵向计划报告
+// @Description 以参与轮次当前全部已完成测评的冻结结果生成纵向报告；结果未变化时返回已有报告。存在尚未产出结果的已完成任务时返回 409。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param enrollment_id path string true "计划参与轮次ID"
+// @Success 200 {object} core.Response{data=response.PlanReportResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report [post]
+func (h *PlanReportHandler) GenerateLongitudinalReport(c *gin.Context) {
+	actor, testeeID, enrollmentID, ok := h.parsePlanReportRequest(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.Generate(c.Request.Context(), actor, testeeID, enrollmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewPlanReportResponse(report))
+}
+
+// GetLongitudinalReport godoc
+// @Summary 查看纵向计划报告
+// @Description 返回参与轮次最新一份纵向报告；尚未生成时返回 404。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param enrollment_id path string true "计划参与轮次ID"
+// @Success 200 {object} core.Response{data=response.PlanReportResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report [get]
+func (h *PlanReportHandler) GetLongitudinalReport(c *gin.Context) {
+	actor, testeeID, enrollmentID, ok := h.parsePlanReportRequest(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.GetLatest(c.Request.Context(), actor, testeeID, enrollmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewPlanReportResponse(report))
+}
+
+func (h *PlanReportHandler) parsePlanReportRequest(c *gin.Context) (planReportApp.Actor, uint64, uint64, bool) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return planReportApp.Actor{}, 0, 0, false
+	}
+	testeeID, ok := parsePathUint(c, "testee_id", h.BaseHandler)
+	if !ok {
+		return planReportApp.Actor{}, 0, 0, false
+	}
+	enrollmentID, ok := parsePathUint(c, "enrollment_id", h.BaseHandler)
+	if !ok {
+		return planReportApp.Actor{}, 0, 0, false
+	}
+	return planReportApp.Actor{OrgID: orgID, OperatorUserID: userID}, testeeID, enrollmentID, true
+}
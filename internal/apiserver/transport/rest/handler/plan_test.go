@@ -92,6 +92,9 @@ func (stubPlanQueryService) ListPlansByTestee(context.Context, string) ([]*planA
 func (stubPlanQueryService) ListTasksByTesteeAndPlan(context.Context, string, string) ([]*planApp.TaskResult, error) {
 	return nil, nil
 }
+func (stubPlanQueryService) ExportTesteeCalendar(context.Context, string) (*planApp.TesteeCalendarResult, error) {
+	return &planApp.TesteeCalendarResult{FileName: "testee_1_tasks.ics", Content: []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")}, nil
+}
 
 type planTesteeAccessService struct{}
 
@@ -104,6 +107,9 @@ func (*planTesteeAccessService) ValidateTesteeAccess(context.Context, int64, int
 func (*planTesteeAccessService) ListAccessibleTesteeIDs(context.Context, int64, int64) ([]uint64, error) {
 	return nil, nil
 }
+func (*planTesteeAccessService) ResolveTesteeRedaction(context.Context, int64, int64, []uint64) (actorAccessApp.TesteeRedaction, error) {
+	return actorAccessApp.TesteeRedaction{}, nil
+}
 
 func newPlanHandlerForTest(command planApp.PlanCommandService) *PlanHandler {
 	handler := NewPlanHandler(command, stubPlanQueryService{})
@@ -497,6 +497,17 @@ func (h *QuestionnaireHandler) BatchUpdateQuestions(c *gin.Context) {
 			}
 		}
 
+		// 转换 critical_rule
+		var criticalRule *questionnaire.CriticalRuleDTO
+		if q.CriticalRule != nil {
+			criticalRule = &questionnaire.CriticalRuleDTO{
+				Category:    q.CriticalRule.Category,
+				OptionCodes: append([]string(nil), q.CriticalRule.OptionCodes...),
+				MinValue:    q.CriticalRule.MinValue,
+				Keywords:    append([]string(nil), q.CriticalRule.Keywords...),
+			}
+		}
+
 		questions = append(questions, questionnaire.QuestionDTO{
 			Code:            q.Code,
 			Stem:            q.Stem,
@@ -507,6 +518,7 @@ func (h *QuestionnaireHandler) BatchUpdateQuestions(c *gin.Context) {
 			ValidationRules: validationRules,
 			CalculationRule: calculationRule,
 			ShowController:  showController,
+			CriticalRule:    criticalRule,
 		})
 	}
 
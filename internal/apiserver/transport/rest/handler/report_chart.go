@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/gin-gonic/gin"
+
+	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+)
+
+// GetReportChart 获取报告图表 SVG
+// @Summary 获取报告图表 SVG
+// @Description 按报告 charts 中的 id 返回服务端渲染的 SVG，版式与报告 PDF 中的图表一致。audience 与 locale 与获取报告接口相同，图表随所选正文派生。
+// @Tags Evaluation-Report
+// @Produce image/svg+xml
+// @Param id path string true "测评ID"
+// @Param chart_id path string true "图表ID，如 profile、radar、pole_bars"
+// @Param audience query string false "报告受众版本：clinician、family、school"
+// @Param locale query string false "报告内容语言，如 zh-CN、en-US"
+// @Success 200 {string} string "SVG 图像"
+// @Failure 404 {object} core.ErrResponse
+// @Failure 429 {object} core.ErrResponse
+// @Router /api/v1/evaluations/assessments/{id}/report/charts/{chart_id} [get]
+// @Router /api/v2/evaluations/assessments/{id}/report/charts/{chart_id} [get]
+func (h *AssessmentReportJourneyHandler) GetReportChart(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	audience, err := reportAudienceQuery(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	contentLocale, err := reportLocaleQuery(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.reportQueryJourney.GetReport(c.Request.Context(), reportqueryjourney.Scope{OrgID: orgID, OperatorUserID: operatorUserID}, reportqueryjourney.GetQuery{AssessmentID: id, Audience: audience, Locale: contentLocale})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	middleware.SetAccessAuditTestee(c, result.TesteeID)
+	writeReportChart(c, h.BaseHandler, result.Charts)
+}
+
+// GetLongitudinalReportChart godoc
+// @Summary 获取纵向计划报告图表 SVG
+// @Description 按纵向报告 charts 中的 id 返回服务端渲染的迷你折线 SVG：sparkline 为主分数趋势，sparkline.{factor_code} 为因子趋势。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce image/svg+xml
+// @Param testee_id path string true "受试者ID"
+// @Param enrollment_id path string true "计划参与轮次ID"
+// @Param chart_id path string true "图表ID"
+// @Success 200 {string} string "SVG 图像"
+// @Failure 404 {object} core.ErrResponse
+// @Router /api/v1/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report/charts/{chart_id} [get]
+func (h *PlanReportHandler) GetLongitudinalReportChart(c *gin.Context) {
+	actor, testeeID, enrollmentID, ok := h.parsePlanReportRequest(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.GetLatest(c.Request.Context(), actor, testeeID, enrollmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	writeReportChart(c, h.BaseHandler, report.Content.Charts())
+}
+
+// writeReportChart 渲染路径参数 chart_id 指定的图表；报告随访问者与正文版本而变，不允许缓存。
+func writeReportChart(c *gin.Context, h *BaseHandler, charts []reportchart.Spec) {
+	chartID := c.Param("chart_id")
+	spec, ok := reportchart.Find(charts, chartID)
+	if !ok {
+		h.Error(c, errors.WithCode(code.ErrReportChartNotFound, "report has no chart %q", chartID))
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, reportchart.ContentTypeSVG, reportchart.SVG(spec, reportchart.DefaultTheme()))
+}
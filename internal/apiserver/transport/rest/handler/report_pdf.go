@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	reportPDFApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ReportPDFHandler 解读报告 PDF 处理器：签发下载链接与按令牌下载。
+type ReportPDFHandler struct {
+	*BaseHandler
+	service reportPDFApp.Service
+}
+
+func NewReportPDFHandler(service reportPDFApp.Service) *ReportPDFHandler {
+	return &ReportPDFHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// IssueReportPDFLink godoc
+// @Summary 签发报告 PDF 下载链接
+// @Description 返回测评最新报告 PDF 的短期签名下载地址；PDF 在报告生成后异步渲染，尚未渲染完成时返回 404，可稍后重试。
+// @Tags Interpretation-Clinician
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id path string true "受试者ID"
+// @Param assessment_id path string true "测评ID"
+// @Success 200 {object} core.Response{data=response.ReportPDFLinkResponse}
+// @Router /api/v1/clinicians/me/testees/{testee_id}/reports/{assessment_id}/pdf-link [get]
+func (h *ReportPDFHandler) IssueReportPDFLink(c *gin.Context) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	testeeID, ok := parsePathUint(c, "testee_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	assessmentID, ok := parsePathUint(c, "assessment_id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	link, err := h.service.IssueClinicianLink(c.Request.Context(), reportPDFApp.Actor{OrgID: orgID, OperatorUserID: userID}, testeeID, assessmentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewReportPDFLinkResponse(link))
+}
+
+// DownloadReportPDF godoc
+// @Summary 下载报告 PDF
+// @Description 按签名令牌下载报告 PDF，无需登录；令牌过期或被篡改时返回 403。成功下载记入所属机构的访问审计。
+// @Tags Interpretation-Public
+// @Produce application/pdf
+// @Param token path string true "下载令牌"
+// @Success 200 {file} binary
+// @Router /api/v1/public/report-pdfs/{token} [get]
+func (h *ReportPDFHandler) DownloadReportPDF(c *gin.Context) {
+	download, err := h.service.Open(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	defer func() { _ = download.Body.Close() }()
+	middleware.SetAccessAuditSubject(c, download.OrgID, strconv.FormatUint(download.AssessmentID, 10), download.TesteeID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", download.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, download.SizeBytes, download.ContentType, download.Body, nil)
+}
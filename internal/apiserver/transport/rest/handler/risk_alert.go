@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// RiskAlertHandler 风险预警处理器：机构预警规则维护，以及预警查询与确认。
+type RiskAlertHandler struct {
+	*BaseHandler
+	service riskAlertApp.Service
+}
+
+func NewRiskAlertHandler(service riskAlertApp.Service) *RiskAlertHandler {
+	return &RiskAlertHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// ListRiskAlertRules godoc
+// @Summary 查询风险预警规则
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.RiskAlertRuleListResponse
+// @Router /api/v1/risk-alert-rules [get]
+func (h *RiskAlertHandler) ListRiskAlertRules(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	rules, err := h.service.ListRules(c.Request.Context(), orgID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertRuleListResponse(rules))
+}
+
+// CreateRiskAlertRule godoc
+// @Summary 创建风险预警规则
+// @Description 规则只评估创建之后完成的测评；命中后立即按 page_channel 寻呼，
+// @Description 每超过 ack_timeout_seconds 无人确认即依次升级到 escalation_channels 的下一级。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.RiskAlertRuleRequest true "预警规则"
+// @Success 200 {object} response.RiskAlertRuleResponse
+// @Router /api/v1/risk-alert-rules [post]
+func (h *RiskAlertHandler) CreateRiskAlertRule(c *gin.Context) {
+	dto, ok := h.bindRule(c)
+	if !ok {
+		return
+	}
+	rule, err := h.service.CreateRule(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertRuleResponse(rule))
+}
+
+// GetRiskAlertRule godoc
+// @Summary 查询风险预警规则详情
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "规则ID"
+// @Success 200 {object} response.RiskAlertRuleResponse
+// @Router /api/v1/risk-alert-rules/{id} [get]
+func (h *RiskAlertHandler) GetRiskAlertRule(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	ruleID, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	rule, err := h.service.GetRule(c.Request.Context(), orgID, ruleID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertRuleResponse(rule))
+}
+
+// UpdateRiskAlertRule godoc
+// @Summary 更新风险预警规则
+// @Description 整体替换规则配置；已触发的预警保留触发时的规则快照与升级链。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "规则ID"
+// @Param request body request.RiskAlertRuleRequest true "预警规则"
+// @Success 200 {object} response.RiskAlertRuleResponse
+// @Router /api/v1/risk-alert-rules/{id} [put]
+func (h *RiskAlertHandler) UpdateRiskAlertRule(c *gin.Context) {
+	ruleID, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	dto, ok := h.bindRule(c)
+	if !ok {
+		return
+	}
+	dto.RuleID = ruleID
+	rule, err := h.service.UpdateRule(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertRuleResponse(rule))
+}
+
+// DeleteRiskAlertRule godoc
+// @Summary 删除风险预警规则
+// @Description 已触发的预警保留，仍可确认并继续升级。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "规则ID"
+// @Success 200 {object} core.Response
+// @Router /api/v1/risk-alert-rules/{id} [delete]
+func (h *RiskAlertHandler) DeleteRiskAlertRule(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	ruleID, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteRule(c.Request.Context(), orgID, ruleID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, nil)
+}
+
+// ListRiskAlerts godoc
+// @Summary 查询风险预警
+// @Description 按触发时间倒序返回。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "预警状态：open/acknowledged"
+// @Param testee_id query string false "受试者ID"
+// @Param rule_id query string false "规则ID"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.RiskAlertListResponse
+// @Router /api/v1/risk-alerts [get]
+func (h *RiskAlertHandler) ListRiskAlerts(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	filter := riskAlertApp.AlertFilter{
+		OrgID:    orgID,
+		Status:   riskAlertApp.AlertStatus(strings.TrimSpace(c.Query("status"))),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if filter.TesteeID, err = optionalQueryUint(c, "testee_id"); err != nil {
+		h.Error(c, err)
+		return
+	}
+	if filter.RuleID, err = optionalQueryUint(c, "rule_id"); err != nil {
+		h.Error(c, err)
+		return
+	}
+	result, err := h.service.ListAlerts(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertListResponse(result))
+}
+
+// GetRiskAlert godoc
+// @Summary 查询风险预警详情
+// @Description 包含触发、逐级升级与确认的完整历史。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "预警ID"
+// @Success 200 {object} response.RiskAlertDetailResponse
+// @Router /api/v1/risk-alerts/{id} [get]
+func (h *RiskAlertHandler) GetRiskAlert(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	alertID, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	view, err := h.service.GetAlert(c.Request.Context(), orgID, alertID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertDetailResponse(view))
+}
+
+// AcknowledgeRiskAlert godoc
+// @Summary 确认风险预警
+// @Description 记录确认人、确认时间与说明并停止升级；每条预警只能确认一次，重复确认返回冲突。
+// @Tags risk-alerts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "预警ID"
+// @Param request body request.AcknowledgeRiskAlertRequest false "确认说明"
+// @Success 200 {object} response.RiskAlertDetailResponse
+// @Router /api/v1/risk-alerts/{id}/acknowledge [post]
+func (h *RiskAlertHandler) AcknowledgeRiskAlert(c *gin.Context) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	alertID, ok := parsePathUint(c, "id", h.BaseHandler)
+	if !ok {
+		return
+	}
+	var req request.AcknowledgeRiskAlertRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.Error(c, errors.WithCode(code.ErrBind, "invalid risk alert acknowledge request: %v", err))
+			return
+		}
+	}
+	view, err := h.service.Acknowledge(c.Request.Context(), riskAlertApp.AcknowledgeDTO{
+		OrgID:          orgID,
+		OperatorUserID: userID,
+		AlertID:        alertID,
+		Note:           req.Note,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewRiskAlertDetailResponse(view))
+}
+
+func (h *RiskAlertHandler) bindRule(c *gin.Context) (riskAlertApp.RuleDTO, bool) {
+	orgID, userID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return riskAlertApp.RuleDTO{}, false
+	}
+	var req request.RiskAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid risk alert rule request: %v", err))
+		return riskAlertApp.RuleDTO{}, false
+	}
+	return riskAlertApp.RuleDTO{
+		OrgID:          orgID,
+		OperatorUserID: userID,
+		Name:           req.Name,
+		Kind:           riskAlertApp.RuleKind(strings.TrimSpace(req.Kind)),
+		ModelCode:      req.ModelCode,
+		Condition: riskAlertApp.Condition{
+			QuestionCode: req.Condition.QuestionCode,
+			FactorCode:   req.Condition.FactorCode,
+			ScoreKind:    riskAlertApp.ScoreKind(strings.TrimSpace(req.Condition.ScoreKind)),
+			Operator:     riskAlertApp.Operator(strings.TrimSpace(req.Condition.Operator)),
+			Threshold:    req.Condition.Threshold,
+			Levels:       req.Condition.Levels,
+		},
+		Severity:           riskAlertApp.Severity(strings.TrimSpace(req.Severity)),
+		PageChannel:        req.PageChannel,
+		EscalationChannels: req.EscalationChannels,
+		AckTimeout:         time.Duration(req.AckTimeoutSeconds) * time.Second,
+		Enabled:            req.Enabled,
+	}, true
+}
+
+func optionalQueryUint(c *gin.Context, name string) (uint64, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || value == 0 {
+		return 0, errors.WithCode(code.ErrInvalidArgument, "invalid %s", name)
+	}
+	return value, nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -283,6 +284,174 @@ func (h *StatisticsHandler) CurrentClinicianTestees(c *gin.Context) {
 	h.Success(c, value)
 }
 
+func parseStatisticsPlanID(c *gin.Context) (uint64, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, errors.WithCode(code.ErrInvalidArgument, "invalid plan id")
+	}
+	return id, nil
+}
+
+// PlanAdherenceList godoc
+// @Summary 查询 Statistics 计划履约列表
+// @Description 按入组日期筛选队列，返回各计划的按时/逾期完成/过期/待完成计数、完成率与打开到完成的中位耗时
+// @Tags Statistics
+// @Param preset query string false "latest_complete_day/7d/30d/custom"
+// @Param from query string false "上海日期 YYYY-MM-DD"
+// @Param to query string false "上海日期 YYYY-MM-DD"
+// @Success 200 {object} core.Response{data=statisticsApp.Page[statisticsApp.PlanAdherenceItem]}
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v2/statistics/plans [get]
+func (h *StatisticsHandler) PlanAdherenceList(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, size, err := parseStatisticsPage(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	value, err := h.read.PlanAdherenceList(c.Request.Context(), orgID, statisticsFilter(c), page, size)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, value)
+}
+
+// CareTeams godoc
+// @Summary 查询 Statistics 照护团队汇总
+// @Description 按团队汇总成员数、团队受试者与窗口内完成测评的受试者数，以及继承访问成员（负责人与成员）的入口与测评活动量
+// @Tags Statistics
+// @Param team_id query uint64 false "团队 ID"
+// @Param department query string false "科室"
+// @Param preset query string false "latest_complete_day/7d/30d/custom"
+// @Param from query string false "上海日期 YYYY-MM-DD"
+// @Param to query string false "上海日期 YYYY-MM-DD"
+// @Success 200 {object} core.Response{data=statisticsApp.Page[statisticsApp.CareTeamItem]}
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v2/statistics/care-teams [get]
+func (h *StatisticsHandler) CareTeams(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, size, err := parseStatisticsPage(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var teamID *uint64
+	if raw := c.Query("team_id"); raw != "" {
+		id, parseErr := strconv.ParseUint(raw, 10, 64)
+		if parseErr != nil || id == 0 {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid team_id"))
+			return
+		}
+		teamID = &id
+	}
+	value, err := h.read.CareTeams(c.Request.Context(), orgID, teamID, strings.TrimSpace(c.Query("department")), statisticsFilter(c), page, size)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, value)
+}
+
+// PlanAdherence godoc
+// @Summary 查询 Statistics 计划履约详情
+// @Description 返回计划汇总、按测评次序的完成率以及按入组周的留存/流失曲线
+// @Tags Statistics
+// @Param id path uint64 true "计划 ID"
+// @Success 200 {object} core.Response{data=statisticsApp.PlanAdherence}
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v2/statistics/plans/{id}/adherence [get]
+func (h *StatisticsHandler) PlanAdherence(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	planID, err := parseStatisticsPlanID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	value, err := h.read.PlanAdherence(c.Request.Context(), orgID, planID, statisticsFilter(c))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, value)
+}
+
+// PlanAdherenceBreakdown godoc
+// @Summary 按医生或入口分解计划履约
+// @Tags Statistics
+// @Param id path uint64 true "计划 ID"
+// @Param dimension query string true "clinician/entry"
+// @Success 200 {object} core.Response{data=statisticsApp.Page[statisticsApp.AdherenceBreakdownItem]}
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v2/statistics/plans/{id}/adherence/breakdown [get]
+func (h *StatisticsHandler) PlanAdherenceBreakdown(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	planID, err := parseStatisticsPlanID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, size, err := parseStatisticsPage(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	dimension := statisticsApp.AdherenceDimension(strings.TrimSpace(c.Query("dimension")))
+	value, err := h.read.PlanAdherenceBreakdown(c.Request.Context(), orgID, planID, dimension, statisticsFilter(c), page, size)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, value)
+}
+
+// ExportPlanAdherence godoc
+// @Summary 导出计划履约 CSV
+// @Tags Statistics
+// @Produce text/csv
+// @Param id path uint64 true "计划 ID"
+// @Param view query string false "occurrences/dropout/clinicians/entries，默认 occurrences"
+// @Success 200 {file} file
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v2/statistics/plans/{id}/adherence/export [get]
+func (h *StatisticsHandler) ExportPlanAdherence(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	planID, err := parseStatisticsPlanID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	view := statisticsApp.AdherenceExportView(strings.TrimSpace(c.DefaultQuery("view", string(statisticsApp.AdherenceExportOccurrences))))
+	value, err := h.read.ExportPlanAdherenceCSV(c.Request.Context(), orgID, planID, view, statisticsFilter(c))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", value.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", value.Content)
+}
+
 type StatisticsContentRequest struct {
 	Items []statisticsApp.ContentRef `json:"items"`
 }
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// TesteeImportHandler 受试者批量导入处理器。
+type TesteeImportHandler struct {
+	*BaseHandler
+	service testeeImport.Service
+}
+
+func NewTesteeImportHandler(service testeeImport.Service) *TesteeImportHandler {
+	return &TesteeImportHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// CreateTesteeImport godoc
+// @Summary 上传受试者名单并创建批量导入任务
+// @Description 上传 CSV（UTF-8）或 XLSX 名单，必须包含姓名列，可选性别、出生日期、档案ID列。文件逐行校验并在文件内去重后立即返回任务，后台按行创建或复用受试者、分配给指定从业者，并可选加入计划；处理进度与每行结果通过任务与行查询接口获取。
+// @Tags testee-imports
+// @Security BearerAuth
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV 或 XLSX 文件，最大 5 MiB，最多 5000 行"
+// @Param clinician_id formData string true "接收受试者的从业者ID"
+// @Param relation_type formData string false "关系类型：attending/primary/collaborator，默认 attending"
+// @Param plan_id formData string false "加入的计划ID（可选，计划需为进行中）"
+// @Param start_date formData string false "入组开始日期 YYYY-MM-DD，填写 plan_id 时必填"
+// @Success 200 {object} response.TesteeImportJobResponse
+// @Router /api/v1/testee-imports [post]
+func (h *TesteeImportHandler) CreateTesteeImport(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, testeeImport.MaxFileBytes+64*1024)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "import file is required"))
+		return
+	}
+	if fileHeader.Size > testeeImport.MaxFileBytes {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "导入文件超过 %d 字节上限", testeeImport.MaxFileBytes))
+		return
+	}
+	clinicianID, err := strconv.ParseUint(c.PostForm("clinician_id"), 10, 64)
+	if err != nil || clinicianID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "clinician_id is required"))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "open import file: %v", err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+	content, err := io.ReadAll(io.LimitReader(file, testeeImport.MaxFileBytes+1))
+	if err != nil {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "read import file: %v", err))
+		return
+	}
+
+	result, err := h.service.CreateJob(c.Request.Context(), testeeImport.CreateJobCommand{
+		OrgID:         orgID,
+		OperatorID:    operatorID,
+		FileName:      fileHeader.Filename,
+		Content:       content,
+		ClinicianID:   clinicianID,
+		RelationType:  c.PostForm("relation_type"),
+		PlanID:        c.PostForm("plan_id"),
+		PlanStartDate: c.PostForm("start_date"),
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportJobResponse(result))
+}
+
+// ListTesteeImports godoc
+// @Summary 查询受试者批量导入任务
+// @Tags testee-imports
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.TesteeImportJobListResponse
+// @Router /api/v1/testee-imports [get]
+func (h *TesteeImportHandler) ListTesteeImports(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListJobs(c.Request.Context(), orgID, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportJobListResponse(result))
+}
+
+// GetTesteeImport godoc
+// @Summary 获取受试者批量导入任务
+// @Description 返回任务状态与按行结果实时汇总的计数。
+// @Tags testee-imports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "导入任务ID"
+// @Success 200 {object} response.TesteeImportJobResponse
+// @Router /api/v1/testee-imports/{id} [get]
+func (h *TesteeImportHandler) GetTesteeImport(c *gin.Context) {
+	orgID, jobID, ok := h.importScope(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.GetJob(c.Request.Context(), orgID, jobID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportJobResponse(result))
+}
+
+// ListTesteeImportRows godoc
+// @Summary 查询受试者批量导入行结果
+// @Tags testee-imports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "导入任务ID"
+// @Param status query string false "行状态：pending/invalid/duplicate/succeeded/failed"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.TesteeImportRowListResponse
+// @Router /api/v1/testee-imports/{id}/rows [get]
+func (h *TesteeImportHandler) ListTesteeImportRows(c *gin.Context) {
+	orgID, jobID, ok := h.importScope(c)
+	if !ok {
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListRows(c.Request.Context(), orgID, jobID, c.Query("status"), page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportRowListResponse(result))
+}
+
+// ResumeTesteeImport godoc
+// @Summary 重试受试者批量导入的失败行
+// @Description 将失败行重新排队；已完成的步骤（创建受试者、建立关系、入组）不会重复执行。
+// @Tags testee-imports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "导入任务ID"
+// @Success 200 {object} response.TesteeImportJobResponse
+// @Router /api/v1/testee-imports/{id}/resume [post]
+func (h *TesteeImportHandler) ResumeTesteeImport(c *gin.Context) {
+	orgID, jobID, ok := h.importScope(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.ResumeJob(c.Request.Context(), orgID, jobID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportJobResponse(result))
+}
+
+// CancelTesteeImport godoc
+// @Summary 取消受试者批量导入任务
+// @Description 剩余待处理行不再执行；已处理的行保持不变。
+// @Tags testee-imports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "导入任务ID"
+// @Success 200 {object} response.TesteeImportJobResponse
+// @Router /api/v1/testee-imports/{id}/cancel [post]
+func (h *TesteeImportHandler) CancelTesteeImport(c *gin.Context) {
+	orgID, jobID, ok := h.importScope(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.CancelJob(c.Request.Context(), orgID, jobID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeImportJobResponse(result))
+}
+
+func (h *TesteeImportHandler) importScope(c *gin.Context) (int64, uint64, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return 0, 0, false
+	}
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || jobID == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid import job id"))
+		return 0, 0, false
+	}
+	return orgID, jobID, true
+}
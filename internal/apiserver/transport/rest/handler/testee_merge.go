@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// TesteeMergeHandler 受试者判重与合并处理器。
+type TesteeMergeHandler struct {
+	*BaseHandler
+	service testeeMerge.Service
+}
+
+func NewTesteeMergeHandler(service testeeMerge.Service) *TesteeMergeHandler {
+	return &TesteeMergeHandler{
+		BaseHandler: NewBaseHandler(),
+		service:     service,
+	}
+}
+
+// ListTesteeDuplicates godoc
+// @Summary 查询机构内的重复受试者候选
+// @Description 按同一用户档案，或姓名相同且出生日期/性别一致召回候选对，返回命中依据、置信分与建议保留的受试者。
+// @Tags testee-merges
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.TesteeDuplicateCandidateListResponse
+// @Router /api/v1/testee-duplicates [get]
+func (h *TesteeMergeHandler) ListTesteeDuplicates(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListCandidates(c.Request.Context(), orgID, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeDuplicateCandidateListResponse(result))
+}
+
+// GetTesteeDuplicates godoc
+// @Summary 查询受试者的重复候选
+// @Description 返回与该受试者疑似重复的受试者，并附带阻止合并的冲突（共同参与的计划、不同的主责从业者、不同的用户档案）。
+// @Tags testee-merges
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "受试者ID"
+// @Success 200 {object} response.TesteeDuplicateCandidateListResponse
+// @Router /api/v1/testees/{id}/duplicates [get]
+func (h *TesteeMergeHandler) GetTesteeDuplicates(c *gin.Context) {
+	orgID, testeeID, ok := h.mergeScope(c, "invalid testee id")
+	if !ok {
+		return
+	}
+	result, err := h.service.FindDuplicates(c.Request.Context(), orgID, testeeID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeDuplicateCandidatesResponse(result))
+}
+
+// MergeTestees godoc
+// @Summary 合并重复受试者
+// @Description 将重复受试者的答卷、测评、结果、报告、计划入组与任务、从业者关系和统计事实迁移到保留受试者，并软删除重复受试者。存在冲突时拒绝合并；每条迁移记录都会写入合并记录以便回滚。
+// @Tags testee-merges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body request.MergeTesteesRequest true "合并请求"
+// @Success 200 {object} response.TesteeMergeResponse
+// @Router /api/v1/testee-merges [post]
+func (h *TesteeMergeHandler) MergeTestees(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.MergeTesteesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid merge request: %v", err))
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.Merge(c.Request.Context(), testeeMerge.MergeCommand{
+		OrgID:       orgID,
+		SurvivorID:  req.SurvivorID.Uint64(),
+		DuplicateID: req.DuplicateID.Uint64(),
+		OperatorID:  operatorID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeMergeResponse(result))
+}
+
+// ListTesteeMerges godoc
+// @Summary 查询受试者合并记录
+// @Tags testee-merges
+// @Security BearerAuth
+// @Produce json
+// @Param testee_id query string false "按保留或被合并的受试者过滤"
+// @Param page query int false "页码，默认 1"
+// @Param page_size query int false "每页数量，默认 20，最大 100"
+// @Success 200 {object} response.TesteeMergeListResponse
+// @Router /api/v1/testee-merges [get]
+func (h *TesteeMergeHandler) ListTesteeMerges(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var testeeID uint64
+	if raw := c.Query("testee_id"); raw != "" {
+		if testeeID, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee_id"))
+			return
+		}
+	}
+	page, pageSize := paginationFromContext(c)
+	result, err := h.service.ListMerges(c.Request.Context(), orgID, testeeID, page, pageSize)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeMergeListResponse(result))
+}
+
+// GetTesteeMerge godoc
+// @Summary 获取受试者合并记录
+// @Tags testee-merges
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "合并记录ID"
+// @Success 200 {object} response.TesteeMergeResponse
+// @Router /api/v1/testee-merges/{id} [get]
+func (h *TesteeMergeHandler) GetTesteeMerge(c *gin.Context) {
+	orgID, mergeID, ok := h.mergeScope(c, "invalid merge id")
+	if !ok {
+		return
+	}
+	result, err := h.service.GetMerge(c.Request.Context(), orgID, mergeID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeMergeResponse(result))
+}
+
+// RevertTesteeMerge godoc
+// @Summary 回滚受试者合并
+// @Description 按合并记录把迁移的记录迁回重复受试者并恢复该受试者；合并后新产生的记录保留在保留受试者上。保留受试者又被合并到其他受试者时，需先回滚后续合并。
+// @Tags testee-merges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "合并记录ID"
+// @Param request body request.RevertTesteeMergeRequest true "回滚请求"
+// @Success 200 {object} response.TesteeMergeResponse
+// @Router /api/v1/testee-merges/{id}/revert [post]
+func (h *TesteeMergeHandler) RevertTesteeMerge(c *gin.Context) {
+	orgID, mergeID, ok := h.mergeScope(c, "invalid merge id")
+	if !ok {
+		return
+	}
+	var req request.RevertTesteeMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid revert request: %v", err))
+		return
+	}
+	operatorID, _ := h.GetUserIDUint64(c)
+	result, err := h.service.Revert(c.Request.Context(), testeeMerge.RevertCommand{
+		OrgID:      orgID,
+		MergeID:    mergeID,
+		OperatorID: operatorID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewTesteeMergeResponse(result))
+}
+
+func (h *TesteeMergeHandler) mergeScope(c *gin.Context, invalidMessage string) (int64, uint64, bool) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "%s", invalidMessage))
+		return 0, 0, false
+	}
+	return orgID, id, true
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
+	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// TesteePrivacyHandler 受试者 PII 相关入口：去标识化导出与显式解除脱敏。
+type TesteePrivacyHandler struct {
+	*BaseHandler
+	exportService       testeeApp.TesteeExportService
+	backendQueryService testeeApp.TesteeBackendQueryService
+}
+
+func NewTesteePrivacyHandler(exportService testeeApp.TesteeExportService, backendQueryService testeeApp.TesteeBackendQueryService) *TesteePrivacyHandler {
+	return &TesteePrivacyHandler{
+		BaseHandler:         NewBaseHandler(),
+		exportService:       exportService,
+		backendQueryService: backendQueryService,
+	}
+}
+
+// ExportTestees godoc
+// @Summary 导出去标识化受试者 CSV
+// @Description 受试者ID以假名输出，不含姓名、出生日期（以年龄段代替）、档案ID与监护人联系方式。需要 read_statistics 能力。
+// @Tags 受试者
+// @Security BearerAuth
+// @Produce text/csv
+// @Param is_key_focus query bool false "是否重点关注"
+// @Param created_start_date query string false "报到开始日期（YYYY-MM-DD）"
+// @Param created_end_date query string false "报到结束日期（YYYY-MM-DD，包含当天）"
+// @Success 200 {file} file
+// @Router /api/v1/testees/export [get]
+func (h *TesteePrivacyHandler) ExportTestees(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	var req request.ExportTesteeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.Error(c, err)
+		return
+	}
+	createdStart, createdEnd, err := parseInclusiveLocalDateRange(req.CreatedStartDate, req.CreatedEndDate)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	value, err := h.exportService.ExportDeidentified(c.Request.Context(), testeeApp.ExportTesteeDTO{
+		OrgID:          orgID,
+		KeyFocus:       req.IsKeyFocus,
+		CreatedAtStart: createdStart,
+		CreatedAtEnd:   createdEnd,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", value.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", value.Content)
+}
+
+// UnmaskTestee godoc
+// @Summary 解除受试者 PII 脱敏
+// @Description 返回受试者完整档案（含监护人联系方式）。需要 unmask_testee_pii 能力，并通过 X-Access-Purpose 请求头或 purpose 参数声明访问目的；每次调用写入访问审计。
+// @Tags 受试者
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "受试者ID"
+// @Param X-Access-Purpose header string false "访问目的"
+// @Param purpose query string false "访问目的（请求头缺省时使用）"
+// @Success 200 {object} core.Response{data=response.TesteeResponse}
+// @Router /api/v1/testees/{id}/unmask [post]
+func (h *TesteePrivacyHandler) UnmaskTestee(c *gin.Context) {
+	orgID, err := h.RequireProtectedOrgID(c)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "invalid testee id"))
+		return
+	}
+	purpose := accessaudit.NormalizePurpose(unmaskPurpose(c))
+	if purpose == accessaudit.PurposeUnspecified {
+		h.Error(c, errors.WithCode(code.ErrInvalidArgument, "access purpose is required to unmask testee PII"))
+		return
+	}
+
+	result, err := h.backendQueryService.GetByIDWithGuardians(c.Request.Context(), id)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if result.OrgID != orgID {
+		h.Error(c, errors.WithCode(code.ErrPermissionDenied, "testee does not belong to current organization"))
+		return
+	}
+
+	logger.L(c.Request.Context()).Infow("Testee PII unmasked",
+		"action", "unmask_testee",
+		"org_id", orgID,
+		"testee_id", id,
+		"purpose", purpose,
+	)
+	h.Success(c, toTesteeBackendResponse(result))
+}
+
+func unmaskPurpose(c *gin.Context) string {
+	if purpose := strings.TrimSpace(c.GetHeader(restmiddleware.AccessPurposeHeader)); purpose != "" {
+		return purpose
+	}
+	return c.Query("purpose")
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/gin-gonic/gin"
+)
+
+// redactTesteeResponses 按查看者与各受试者之间的关系就地脱敏受试者响应。
+// ID 无法解析的条目与未配置访问控制服务时按 Restricted 处理。
+func redactTesteeResponses(c *gin.Context, access actorAccessApp.TesteeAccessService, orgID, operatorUserID int64, items ...*response.TesteeResponse) error {
+	testeeIDs := make([]uint64, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if testeeID, err := strconv.ParseUint(item.ID, 10, 64); err == nil {
+			testeeIDs = append(testeeIDs, testeeID)
+		}
+	}
+	policies := actorAccessApp.TesteeRedaction{}
+	if access != nil {
+		resolved, err := access.ResolveTesteeRedaction(c.Request.Context(), orgID, operatorUserID, testeeIDs)
+		if err != nil {
+			return err
+		}
+		policies = resolved
+	}
+	now := time.Now()
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		testeeID, _ := strconv.ParseUint(item.ID, 10, 64)
+		response.RedactTesteeResponse(item, policies.PolicyFor(testeeID), now)
+	}
+	return nil
+}
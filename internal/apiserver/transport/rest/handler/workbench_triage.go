@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/errors"
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/request"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/response"
+	"github.com/FangcunMount/qs-server/internal/pkg/code"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyWorkbenchTriageItem godoc
+// @Summary 获取工作台条目分诊详情
+// @Description 返回条目当前分诊状态与完整历史；尚无分诊记录时状态为 open、历史为空。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags clinicians
+// @Security BearerAuth
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/clinicians/me/workbench/queues/{queue_type}/items/{subject_id} [get]
+func (h *ClinicianWorkbenchHandler) GetMyWorkbenchTriageItem(c *gin.Context) {
+	dto, ok := h.myTriageDTO(c)
+	if !ok {
+		return
+	}
+	view, err := h.service.GetTriageItem(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ClaimMyWorkbenchItem godoc
+// @Summary 认领工作台条目
+// @Description 认领后条目由当前操作人负责；已被他人认领时返回冲突（机构管理员可接管），已解决的条目需先重新打开。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags clinicians
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageNoteRequest false "操作说明，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}/claim [post]
+func (h *ClinicianWorkbenchHandler) ClaimMyWorkbenchItem(c *gin.Context) {
+	dto, ok := h.myTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageNoteRequest
+	// 可选请求体
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+			return
+		}
+	}
+	dto.Note = req.Note
+	view, err := h.service.Claim(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// SnoozeMyWorkbenchItem godoc
+// @Summary 暂缓工作台条目
+// @Description 暂缓至 snoozed_until（不超过 30 天），到期后自动回到待处理；暂缓会释放认领。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags clinicians
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageSnoozeRequest true "分诊请求"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}/snooze [post]
+func (h *ClinicianWorkbenchHandler) SnoozeMyWorkbenchItem(c *gin.Context) {
+	dto, ok := h.myTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageSnoozeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+		return
+	}
+	dto.SnoozedUntil = flexibleTimePtrToTimePtr(req.SnoozedUntil)
+	dto.Note = req.Note
+	view, err := h.service.Snooze(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ResolveMyWorkbenchItem godoc
+// @Summary 解决工作台条目
+// @Description 按结论代码解决条目，resolution_code 为 other 时 note 必填；已解决的条目不再出现在待处理队列中。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags clinicians
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageResolveRequest true "分诊请求"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}/resolve [post]
+func (h *ClinicianWorkbenchHandler) ResolveMyWorkbenchItem(c *gin.Context) {
+	dto, ok := h.myTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+		return
+	}
+	dto.ResolutionCode = workbenchApp.ResolutionCode(strings.TrimSpace(req.ResolutionCode))
+	dto.Note = req.Note
+	view, err := h.service.Resolve(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ReopenMyWorkbenchItem godoc
+// @Summary 重新打开工作台条目
+// @Description 把已认领、暂缓或已解决的条目恢复为待认领；历史保留。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags clinicians
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageNoteRequest false "操作说明，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}/reopen [post]
+func (h *ClinicianWorkbenchHandler) ReopenMyWorkbenchItem(c *gin.Context) {
+	dto, ok := h.myTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageNoteRequest
+	// 可选请求体
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+			return
+		}
+	}
+	dto.Note = req.Note
+	view, err := h.service.Reopen(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// GetOrgWorkbenchTriageItem godoc
+// @Summary 获取全院工作台条目分诊详情
+// @Description 返回条目当前分诊状态与完整历史；尚无分诊记录时状态为 open、历史为空。仅 qs:admin 可访问。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags Workbench
+// @Security BearerAuth
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/workbench/queues/{queue_type}/items/{subject_id} [get]
+func (h *ClinicianWorkbenchHandler) GetOrgWorkbenchTriageItem(c *gin.Context) {
+	dto, ok := h.orgTriageDTO(c)
+	if !ok {
+		return
+	}
+	view, err := h.service.GetTriageItem(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ClaimOrgWorkbenchItem godoc
+// @Summary 认领全院工作台条目
+// @Description 认领后条目由当前操作人负责；已被他人认领时返回冲突（机构管理员可接管），已解决的条目需先重新打开。仅 qs:admin 可访问。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags Workbench
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageNoteRequest false "操作说明，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/workbench/queues/{queue_type}/items/{subject_id}/claim [post]
+func (h *ClinicianWorkbenchHandler) ClaimOrgWorkbenchItem(c *gin.Context) {
+	dto, ok := h.orgTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageNoteRequest
+	// 可选请求体
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+			return
+		}
+	}
+	dto.Note = req.Note
+	view, err := h.service.Claim(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// SnoozeOrgWorkbenchItem godoc
+// @Summary 暂缓全院工作台条目
+// @Description 暂缓至 snoozed_until（不超过 30 天），到期后自动回到待处理；暂缓会释放认领。仅 qs:admin 可访问。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags Workbench
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageSnoozeRequest true "分诊请求"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/workbench/queues/{queue_type}/items/{subject_id}/snooze [post]
+func (h *ClinicianWorkbenchHandler) SnoozeOrgWorkbenchItem(c *gin.Context) {
+	dto, ok := h.orgTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageSnoozeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+		return
+	}
+	dto.SnoozedUntil = flexibleTimePtrToTimePtr(req.SnoozedUntil)
+	dto.Note = req.Note
+	view, err := h.service.Snooze(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ResolveOrgWorkbenchItem godoc
+// @Summary 解决全院工作台条目
+// @Description 按结论代码解决条目，resolution_code 为 other 时 note 必填；已解决的条目不再出现在待处理队列中。仅 qs:admin 可访问。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags Workbench
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageResolveRequest true "分诊请求"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/workbench/queues/{queue_type}/items/{subject_id}/resolve [post]
+func (h *ClinicianWorkbenchHandler) ResolveOrgWorkbenchItem(c *gin.Context) {
+	dto, ok := h.orgTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+		return
+	}
+	dto.ResolutionCode = workbenchApp.ResolutionCode(strings.TrimSpace(req.ResolutionCode))
+	dto.Note = req.Note
+	view, err := h.service.Resolve(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// ReopenOrgWorkbenchItem godoc
+// @Summary 重新打开全院工作台条目
+// @Description 把已认领、暂缓或已解决的条目恢复为待认领；历史保留。仅 qs:admin 可访问。subject_id 取队列条目的 subject_id：high_risk、awaiting_review 为测评 ID，follow_up 为任务 ID，key_focus 为受试者 ID，critical_item 为答卷 ID。
+// @Tags Workbench
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param queue_type path string true "队列类型：high_risk/follow_up/key_focus/awaiting_review/critical_item"
+// @Param subject_id path string true "条目主体 ID"
+// @Param clinician_id query int false "从业者 ID，可选"
+// @Param team_id query int false "照护团队 ID，可选"
+// @Param request body request.WorkbenchTriageNoteRequest false "操作说明，可选"
+// @Success 200 {object} core.Response{data=response.ClinicianWorkbenchTriageItemResponse}
+// @Router /api/v1/workbench/queues/{queue_type}/items/{subject_id}/reopen [post]
+func (h *ClinicianWorkbenchHandler) ReopenOrgWorkbenchItem(c *gin.Context) {
+	dto, ok := h.orgTriageDTO(c)
+	if !ok {
+		return
+	}
+	var req request.WorkbenchTriageNoteRequest
+	// 可选请求体
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.Error(c, errors.WithCode(code.ErrBind, "invalid workbench triage request: %v", err))
+			return
+		}
+	}
+	dto.Note = req.Note
+	view, err := h.service.Reopen(c.Request.Context(), dto)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.Success(c, response.NewClinicianWorkbenchTriageItemResponse(view))
+}
+
+// myTriageDTO 当前医生视角的分诊目标；带 team_id 时按照护团队视角校验条目归属。
+func (h *ClinicianWorkbenchHandler) myTriageDTO(c *gin.Context) (workbenchApp.TriageDTO, bool) {
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return workbenchApp.TriageDTO{}, false
+	}
+	scope, err := myWorkbenchScope(c, orgID, operatorUserID)
+	if err != nil {
+		h.Error(c, err)
+		return workbenchApp.TriageDTO{}, false
+	}
+	return h.triageDTO(c, scope)
+}
+
+// orgTriageDTO 机构管理员视角的分诊目标；操作人取当前登录用户。
+func (h *ClinicianWorkbenchHandler) orgTriageDTO(c *gin.Context) (workbenchApp.TriageDTO, bool) {
+	orgID, operatorUserID, err := h.RequireProtectedScope(c)
+	if err != nil {
+		h.Error(c, err)
+		return workbenchApp.TriageDTO{}, false
+	}
+	scope, err := orgWorkbenchScope(c, orgID)
+	if err != nil {
+		h.Error(c, err)
+		return workbenchApp.TriageDTO{}, false
+	}
+	scope.OperatorUserID = operatorUserID
+	return h.triageDTO(c, scope)
+}
+
+func (h *ClinicianWorkbenchHandler) triageDTO(c *gin.Context, scope workbenchApp.Scope) (workbenchApp.TriageDTO, bool) {
+	subjectID, ok := parsePathUint(c, "subject_id", h.BaseHandler)
+	if !ok {
+		return workbenchApp.TriageDTO{}, false
+	}
+	return workbenchApp.TriageDTO{
+		Scope:     scope,
+		QueueType: workbenchApp.QueueType(c.Param("queue_type")),
+		SubjectID: subjectID,
+	}, true
+}
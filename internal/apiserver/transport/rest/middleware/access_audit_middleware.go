@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	pkgmiddleware "github.com/FangcunMount/qs-server/internal/pkg/middleware"
+	"github.com/FangcunMount/qs-server/internal/pkg/safeconv"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AccessPurposeHeader 调用方声明访问受试者数据目的的请求头；也可用 purpose 查询参数。
+	AccessPurposeHeader = "X-Access-Purpose"
+
+	accessAuditTesteeKey   = "access_audit_testee_id"
+	accessAuditOrgKey      = "access_audit_org_id"
+	accessAuditResourceKey = "access_audit_resource_id"
+)
+
+// AccessAuditRoute 读接口的审计描述：资源类型，以及资源 ID、受试者 ID 所在的路径参数。
+type AccessAuditRoute struct {
+	Resource accessaudit.ResourceType
+	// ResourceParam 为空表示资源 ID 由 handler 通过 SetAccessAuditSubject 提供（例如路径参数是下载令牌）。
+	ResourceParam string
+	// TesteeParam 为空表示受试者 ID 由 handler 加载资源后通过 SetAccessAuditTestee 提供。
+	TesteeParam string
+}
+
+// AccessAuditMiddleware 在读接口处理完成后追加一条访问审计，被拒绝与失败的读取同样记录。
+// 响应已写出后审计才落库，写入失败只记录错误日志，不改变响应。
+func AccessAuditMiddleware(recorder accessaudit.Recorder, route AccessAuditRoute) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if recorder == nil {
+			return
+		}
+		orgID, err := accessAuditOrgID(c)
+		if err != nil || orgID == 0 {
+			return
+		}
+		userID, _ := safeconv.Uint64ToInt64(GetUserID(c))
+		requestID := pkgmiddleware.GetRequestIDFromContext(c)
+		if requestID == "" {
+			requestID = pkgmiddleware.GetRequestIDFromHeaders(c)
+		}
+		purpose := c.GetHeader(AccessPurposeHeader)
+		if strings.TrimSpace(purpose) == "" {
+			purpose = c.Query("purpose")
+		}
+		status := c.Writer.Status()
+		event := accessaudit.AccessEvent{
+			OrgID:        orgID,
+			ActorUserID:  userID,
+			ResourceType: route.Resource,
+			ResourceID:   accessAuditResourceID(c, route.ResourceParam),
+			TesteeID:     accessAuditTesteeID(c, route.TesteeParam),
+			Purpose:      purpose,
+			Result:       accessaudit.ResultFromStatus(status),
+			StatusCode:   status,
+			Route:        c.FullPath(),
+			RequestID:    requestID,
+			ClientIP:     c.ClientIP(),
+		}
+		if err := recorder.Record(c.Request.Context(), event); err != nil {
+			logger.L(c.Request.Context()).Errorw("failed to record testee data access audit",
+				"org_id", orgID,
+				"user_id", userID,
+				"resource_type", string(route.Resource),
+				"resource_id", event.ResourceID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// SetAccessAuditTestee 由 handler 在加载资源后写入资源所属受试者，供访问审计记录。
+func SetAccessAuditTestee(c *gin.Context, testeeID uint64) {
+	if testeeID != 0 {
+		c.Set(accessAuditTesteeKey, testeeID)
+	}
+}
+
+// SetAccessAuditSubject 供不经过 IAM 认证的公开读接口使用：凭证校验通过后由 handler 写入资源所属机构、资源 ID 与受试者。
+// 凭证无效时无法确定机构，请求不记录审计。
+func SetAccessAuditSubject(c *gin.Context, orgID int64, resourceID string, testeeID uint64) {
+	if orgID > 0 {
+		c.Set(accessAuditOrgKey, orgID)
+	}
+	if resourceID != "" {
+		c.Set(accessAuditResourceKey, resourceID)
+	}
+	SetAccessAuditTestee(c, testeeID)
+}
+
+func accessAuditOrgID(c *gin.Context) (int64, error) {
+	if value, ok := c.Get(accessAuditOrgKey); ok {
+		if id, ok := value.(int64); ok {
+			return id, nil
+		}
+	}
+	return safeconv.Uint64ToInt64(GetOrgID(c))
+}
+
+func accessAuditResourceID(c *gin.Context, param string) string {
+	if param != "" {
+		return c.Param(param)
+	}
+	if value, ok := c.Get(accessAuditResourceKey); ok {
+		if id, ok := value.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func accessAuditTesteeID(c *gin.Context, param string) uint64 {
+	if param != "" {
+		if id, err := strconv.ParseUint(c.Param(param), 10, 64); err == nil {
+			return id
+		}
+	}
+	if value, ok := c.Get(accessAuditTesteeKey); ok {
+		if id, ok := value.(uint64); ok {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	"github.com/FangcunMount/qs-server/internal/pkg/httpauth"
+	"github.com/gin-gonic/gin"
+)
+
+type recorderStub struct{ events []accessaudit.AccessEvent }
+
+func (r *recorderStub) Record(_ context.Context, event accessaudit.AccessEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestAccessAuditMiddlewareRecordsReadsIncludingDenied(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &recorderStub{}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(httpauth.OrgIDKey, uint64(7))
+		c.Set(httpauth.UserIDKey, uint64(11))
+	})
+	audit := AccessAuditMiddleware(recorder, AccessAuditRoute{Resource: accessaudit.ResourceAnswerSheet, ResourceParam: "id"})
+	engine.GET("/answersheets/:id", audit, func(c *gin.Context) {
+		if c.Param("id") == "2" {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		SetAccessAuditTestee(c, 42)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/answersheets/1", nil)
+	req.Header.Set(AccessPurposeHeader, "treatment")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/answersheets/2?purpose=audit", nil))
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("events = %+v", recorder.events)
+	}
+	allowed, denied := recorder.events[0], recorder.events[1]
+	if allowed.OrgID != 7 || allowed.ActorUserID != 11 || allowed.ResourceID != "1" || allowed.TesteeID != 42 ||
+		allowed.Purpose != "treatment" || allowed.Result != accessaudit.ResultAllowed || allowed.Route != "/answersheets/:id" {
+		t.Fatalf("allowed event = %+v", allowed)
+	}
+	if denied.Result != accessaudit.ResultDenied || denied.StatusCode != http.StatusForbidden || denied.Purpose != "audit" || denied.TesteeID != 0 {
+		t.Fatalf("denied event = %+v", denied)
+	}
+}
+
+func TestAccessAuditMiddlewareSkipsRequestsWithoutOrgScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &recorderStub{}
+	engine := gin.New()
+	engine.GET("/testees/:id", AccessAuditMiddleware(recorder, AccessAuditRoute{Resource: accessaudit.ResourceTestee, ResourceParam: "id", TesteeParam: "id"}),
+		func(c *gin.Context) { c.Status(http.StatusUnauthorized) })
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/testees/9", nil))
+	if len(recorder.events) != 0 {
+		t.Fatalf("events = %+v", recorder.events)
+	}
+}
+
+func TestAccessAuditMiddlewareRecordsPublicReadsAttributedByHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &recorderStub{}
+	engine := gin.New()
+	engine.GET("/report-pdfs/:token", AccessAuditMiddleware(recorder, AccessAuditRoute{Resource: accessaudit.ResourceReportPDFDownload}),
+		func(c *gin.Context) {
+			if c.Param("token") == "forged" {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			SetAccessAuditSubject(c, 7, "301", 42)
+			c.Status(http.StatusOK)
+		})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report-pdfs/signed", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report-pdfs/forged", nil))
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("events = %+v", recorder.events)
+	}
+	event := recorder.events[0]
+	if event.OrgID != 7 || event.ActorUserID != 0 || event.ResourceID != "301" || event.TesteeID != 42 ||
+		event.ResourceType != accessaudit.ResourceReportPDFDownload || event.Result != accessaudit.ResultAllowed {
+		t.Fatalf("event = %+v", event)
+	}
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/FangcunMount/component-base/pkg/logger"
 	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/actorctx"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
 	operatorapp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	"github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
 	iamauth "github.com/FangcunMount/qs-server/internal/pkg/iamauth"
+	"github.com/FangcunMount/qs-server/internal/pkg/safeconv"
 	"github.com/gin-gonic/gin"
 )
 
@@ -83,6 +85,47 @@ func newAuthzSnapshotMiddleware(
 	}
 }
 
+// CustomRoleGrantsMiddleware 在 IAM 授权快照之上叠加当前操作者的机构自定义角色。
+// 必须位于 AuthzSnapshotMiddleware 之后；加载失败时只记录告警并按无自定义角色继续，不放宽授权。
+// 授权由 loader 按 (机构, 用户) 短时缓存，中间件不再另行缓存。
+func CustomRoleGrantsMiddleware(loader customRoleApp.GrantLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		snap := GetAuthzSnapshot(c)
+		if loader == nil || snap == nil {
+			c.Next()
+			return
+		}
+		orgID, err := safeconv.Uint64ToInt64(GetOrgID(c))
+		if err != nil {
+			c.Next()
+			return
+		}
+		userID, err := safeconv.Uint64ToInt64(GetUserID(c))
+		if err != nil {
+			c.Next()
+			return
+		}
+		grants, err := loader.LoadGrants(c.Request.Context(), orgID, userID)
+		if err != nil {
+			logger.L(c.Request.Context()).Warnw("failed to load custom role grants",
+				"org_id", orgID,
+				"user_id", userID,
+				"error", err.Error(),
+			)
+			c.Next()
+			return
+		}
+		if len(grants) == 0 {
+			c.Next()
+			return
+		}
+		snap = snap.WithGrants(grants)
+		c.Set(AuthzSnapshotKey, snap)
+		c.Request = c.Request.WithContext(authz.WithSnapshot(c.Request.Context(), snap))
+		c.Next()
+	}
+}
+
 // GetAuthzSnapshot 从 gin 读取 IAM 授权快照（可能为 nil）。
 func GetAuthzSnapshot(c *gin.Context) *authz.Snapshot {
 	v, ok := c.Get(AuthzSnapshotKey)
@@ -153,3 +153,86 @@ func TestAuthzSnapshotMiddlewareUpdaterFailureDoesNotAbortRequest(t *testing.T)
 		t.Fatalf("updater calls = %d, want 1", updater.calls)
 	}
 }
+
+type stubGrantLoader struct {
+	grants []authzapp.RoleGrant
+	err    error
+}
+
+func (s stubGrantLoader) LoadGrants(context.Context, int64, int64) ([]authzapp.RoleGrant, error) {
+	return s.grants, s.err
+}
+
+func TestCustomRoleGrantsMiddlewareOverlaysCopyOfSnapshot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cached := &authzapp.Snapshot{Roles: []string{"qs:evaluator"}}
+	grant := authzapp.RoleGrant{
+		RoleCode:     "research_assistant",
+		Capabilities: []authzapp.Capability{authzapp.CapabilityReadAnswersheets},
+		Scope:        authzapp.Scope{ModelCodes: []string{"SDS"}},
+		Sensitivity:  authzapp.SensitivityDeidentified,
+	}
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(OrgIDKey, uint64(88))
+		c.Set(UserIDKey, uint64(701))
+		c.Set(AuthzSnapshotKey, cached)
+		c.Next()
+	})
+	engine.Use(CustomRoleGrantsMiddleware(stubGrantLoader{grants: []authzapp.RoleGrant{grant}}))
+	engine.GET("/answersheets", RequireScopedCapabilityMiddleware(CapabilityReadAnswersheets), func(c *gin.Context) {
+		got := GetAuthzSnapshot(c)
+		if got == cached || len(got.Grants) != 1 {
+			t.Fatalf("snapshot = %#v", got)
+		}
+		if fromCtx, ok := authzapp.FromContext(c.Request.Context()); !ok || fromCtx != got {
+			t.Fatalf("request context snapshot = %#v", fromCtx)
+		}
+		c.Status(http.StatusNoContent)
+	})
+	engine.GET("/statistics", RequireCapabilityMiddleware(CapabilityReadAnswersheets), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/answersheets", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("scoped route status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/statistics", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("unscoped route status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if len(cached.Grants) != 0 {
+		t.Fatalf("cached snapshot mutated: %#v", cached)
+	}
+}
+
+func TestCustomRoleGrantsMiddlewareIgnoresLoadFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cached := &authzapp.Snapshot{Roles: []string{"qs:evaluator"}}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(OrgIDKey, uint64(88))
+		c.Set(UserIDKey, uint64(701))
+		c.Set(AuthzSnapshotKey, cached)
+		c.Next()
+	})
+	engine.Use(CustomRoleGrantsMiddleware(stubGrantLoader{err: errors.New("db down")}))
+	engine.GET("/check", func(c *gin.Context) {
+		if got := GetAuthzSnapshot(c); got != cached {
+			t.Fatalf("snapshot = %#v, want cached", got)
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/check", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", rec.Code)
+	}
+}
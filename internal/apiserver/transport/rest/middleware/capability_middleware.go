@@ -26,6 +26,8 @@ const (
 	CapabilityManageEvaluationPlans            = authzapp.CapabilityManageEvaluationPlans
 	CapabilityEvaluateAssessments              = authzapp.CapabilityEvaluateAssessments
 	CapabilityAuditInterpretation              = authzapp.CapabilityAuditInterpretation
+	CapabilityReadStatistics                   = authzapp.CapabilityReadStatistics
+	CapabilityUnmaskTesteePII                  = authzapp.CapabilityUnmaskTesteePII
 )
 
 // RequireCapabilityMiddleware 要求当前请求具备指定能力（基于 IAM 授权快照的 resource/action，不信任 JWT roles）。
@@ -48,6 +50,27 @@ func RequireCapabilityMiddleware(capability Capability) gin.HandlerFunc {
 	}
 }
 
+// RequireScopedCapabilityMiddleware 供会逐个资源收窄范围的路由使用：IAM 授权或任一包含该能力的自定义角色即可进入，
+// handler 必须再按 authz.DecideCapabilityFor 判定具体资源。
+func RequireScopedCapabilityMiddleware(capability Capability) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		snap := GetAuthzSnapshot(c)
+		if snap == nil {
+			abortPermissionDenied(c, errors.WithCode(code.ErrPermissionDenied, "authorization snapshot required"))
+			return
+		}
+		if decision := authzapp.DecideScopedCapability(snap, capability); !decision.Allowed {
+			abortPermissionDenied(c, errors.WithCode(
+				code.ErrPermissionDenied,
+				"capability %s denied by IAM authorization",
+				capability,
+			))
+			return
+		}
+		c.Next()
+	}
+}
+
 // RequireAnyCapabilityMiddleware 要求当前请求具备任一能力。
 func RequireAnyCapabilityMiddleware(capabilities ...Capability) gin.HandlerFunc {
 	return func(c *gin.Context) {
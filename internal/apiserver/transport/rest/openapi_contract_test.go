@@ -35,6 +35,7 @@ func TestApiserverOpenAPIContractCoversKeyPublicRoutes(t *testing.T) {
 	assertOpenAPIOperation(t, spec, "/answersheets/admin-submit", "post")
 	assertOpenAPIOperation(t, spec, "/evaluations/assessments", "get")
 	assertOpenAPIOperation(t, spec, "/plans/{id}/tasks", "get")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/tasks/calendar.ics", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/overview", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/clinicians", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/clinicians/{id}", "get")
@@ -43,10 +44,74 @@ func TestApiserverOpenAPIContractCoversKeyPublicRoutes(t *testing.T) {
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/clinicians/me/testees-summary", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/entries", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/entries/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/api/v2/statistics/plans", "get")
+	assertOpenAPIOperation(t, spec, "/api/v2/statistics/plans/{id}/adherence", "get")
+	assertOpenAPIOperation(t, spec, "/api/v2/statistics/plans/{id}/adherence/breakdown", "get")
+	assertOpenAPIOperation(t, spec, "/api/v2/statistics/plans/{id}/adherence/export", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/statistics/contents/batch", "post")
 	assertOpenAPIOperationAbsent(t, spec, "/api/v1/statistics/overview", "get")
 	assertOpenAPIOperation(t, spec, "/api/v2/plans/testees/{testee_id}/enrollments", "get")
 	assertOpenAPIOperation(t, spec, "/testees/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/testee-imports", "post")
+	assertOpenAPIOperation(t, spec, "/testee-imports/{id}/rows", "get")
+	assertOpenAPIOperation(t, spec, "/testee-imports/{id}/resume", "post")
+	assertOpenAPIOperation(t, spec, "/testee-duplicates", "get")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/duplicates", "get")
+	assertOpenAPIOperation(t, spec, "/testee-merges", "post")
+	assertOpenAPIOperation(t, spec, "/testee-merges/{id}/revert", "post")
+	assertOpenAPIOperation(t, spec, "/consent-documents", "post")
+	assertOpenAPIOperation(t, spec, "/consent-documents/{id}/publish", "post")
+	assertOpenAPIOperation(t, spec, "/consent-acceptances/{id}/withdraw", "post")
+	assertOpenAPIOperation(t, spec, "/access-audits", "get")
+	assertOpenAPIOperation(t, spec, "/access-audits/export", "get")
+	assertOpenAPIOperation(t, spec, "/access-audits/verify", "get")
+	assertOpenAPIOperation(t, spec, "/testees/export", "get")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/unmask", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/break-glass", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/break-glass/{id}/revoke", "post")
+	assertOpenAPIOperation(t, spec, "/break-glass-grants", "get")
+	assertOpenAPIOperation(t, spec, "/break-glass-grants/{id}/review", "post")
+	assertOpenAPIOperation(t, spec, "/data-subject-requests", "post")
+	assertOpenAPIOperation(t, spec, "/data-subject-requests/{id}/resume", "post")
+	assertOpenAPIOperation(t, spec, "/data-subject-requests/{id}/bundle", "get")
+	assertOpenAPIOperation(t, spec, "/data-subject-requests/{id}/certificate", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/Questionnaire/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/Questionnaire/{id}/_history/{vid}", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/QuestionnaireResponse/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/Observation/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/DiagnosticReport/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/fhir/$export", "get")
+	assertOpenAPIOperation(t, spec, "/custom-roles", "post")
+	assertOpenAPIOperation(t, spec, "/custom-roles/{id}", "put")
+	assertOpenAPIOperation(t, spec, "/custom-roles/{id}/assignments", "post")
+	assertOpenAPIOperation(t, spec, "/custom-roles/{id}/assignments/{operator_id}", "delete")
+	assertOpenAPIOperation(t, spec, "/authz/explain", "get")
+	assertOpenAPIOperation(t, spec, "/care-teams", "post")
+	assertOpenAPIOperation(t, spec, "/care-teams/{id}/members/{clinician_id}", "put")
+	assertOpenAPIOperation(t, spec, "/care-teams/{id}/testees", "post")
+	assertOpenAPIOperation(t, spec, "/care-teams/{id}/events", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/care-teams", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/reports/{assessment_id}/review", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/reports/{assessment_id}/notes", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/reports/{assessment_id}/sign-off", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/reports/{assessment_id}/pdf-link", "get")
+	assertOpenAPIOperation(t, spec, "/api/v1/public/report-pdfs/{token}", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/testees/{testee_id}/plan-enrollments/{enrollment_id}/longitudinal-report/charts/{chart_id}", "get")
+	assertOpenAPIOperation(t, spec, "/evaluations/assessments/{id}/report/charts/{chart_id}", "get")
+	assertOpenAPIOperation(t, spec, "/api/v2/evaluations/assessments/{id}/report/charts/{chart_id}", "get")
+	assertOpenAPIOperation(t, spec, "/group-reports", "post")
+	assertOpenAPIOperation(t, spec, "/group-reports/{id}", "get")
+	assertOpenAPIOperation(t, spec, "/group-reports/{id}/pdf", "get")
+	assertOpenAPIOperation(t, spec, "/risk-alert-rules", "post")
+	assertOpenAPIOperation(t, spec, "/risk-alert-rules/{id}", "put")
+	assertOpenAPIOperation(t, spec, "/risk-alerts", "get")
+	assertOpenAPIOperation(t, spec, "/risk-alerts/{id}/acknowledge", "post")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}", "get")
+	assertOpenAPIOperation(t, spec, "/clinicians/me/workbench/queues/{queue_type}/items/{subject_id}/claim", "post")
+	assertOpenAPIOperation(t, spec, "/workbench/queues/{queue_type}/items/{subject_id}/resolve", "post")
+	assertOpenAPIOperation(t, spec, "/api/v2/statistics/care-teams", "get")
 	assertOpenAPIOperation(t, spec, "/clinicians", "get")
 	assertOpenAPIOperation(t, spec, "/clinicians/me", "get")
 	assertOpenAPIOperationAbsent(t, spec, "/practitioners", "get")
@@ -59,11 +124,13 @@ func TestApiserverOpenAPIHasExplicitModelAndInterpretationWireSchemas(t *testing
 
 	schemas := loadOpenAPIComponents(t, "../../../../api/rest/apiserver.yaml")
 	for name, properties := range map[string][]string{
-		"response.DefinitionV2Wire":             {"Measure", "Calibration", "Conclusions", "Outcomes", "ReportMap"},
+		"response.DefinitionV2Wire":             {"Measure", "Calibration", "Conclusions", "Outcomes", "ReportMap", "Translations"},
+		"response.DefinitionTranslationWire":    {"Locale", "ModelTitle", "Factors", "Outcomes", "Profiles"},
 		"response.PreviewReportRequestWire":     {"answers", "sample_id"},
 		"response.PreviewReportWire":            {"outcome", "score_detail", "report_sections"},
 		"response.InterpretationGenerationWire": {"ID", "OutcomeID", "LatestRun", "Report"},
 		"response.InterpretationRunWire":        {"ID", "GenerationID", "Status", "Failure"},
+		"response.ReportChartResponse":          {"id", "kind", "min", "max", "reference", "bands", "points"},
 	} {
 		schema, ok := schemas[name].(map[string]any)
 		if !ok {
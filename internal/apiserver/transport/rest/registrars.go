@@ -54,6 +54,7 @@ func (registrar publicRouteRegistrar) register(engine *gin.Engine) {
 	{
 		publicAPI.GET("/info", codesHandler.PublicInfo)
 		r.registerActorPublicRoutes(publicAPI)
+		r.registerInterpretationPublicRoutes(publicAPI)
 	}
 
 	objectKeyPrefix := "qrcode"
@@ -79,6 +80,7 @@ func (registrar protectedRouteRegistrar) register(engine *gin.Engine) {
 	r.registerInterpretationProtectedRoutes(apiV1)
 	r.registerActorProtectedRoutes(apiV1)
 	r.registerPlanProtectedRoutes(apiV1)
+	r.registerFHIRProtectedRoutes(apiV1)
 	r.registerCodesRoutes(apiV1)
 
 	apiV2 := engine.Group("/api/v2")
@@ -121,6 +123,9 @@ func (composer protectedGroupMiddlewareComposer) apply(group *gin.RouterGroup, r
 			group.Use(restmiddleware.RequireOrgScopeMiddleware())
 			if loader := r.deps.IAM.SnapshotLoader; loader != nil {
 				group.Use(restmiddleware.AuthzSnapshotMiddleware(loader, r.deps.Actor.OperatorRoleProjectionUpdater))
+				if grants := r.deps.Actor.CustomRoleService; grants != nil {
+					group.Use(restmiddleware.CustomRoleGrantsMiddleware(grants))
+				}
 			} else {
 				fmt.Printf("⚠️  Warning: IAM AuthzSnapshotLoader unavailable (need gRPC); authorization snapshot disabled for %s\n", routePrefix)
 			}
@@ -23,6 +23,8 @@ type UpdateTesteeRequest struct {
 	Gender     *string    `json:"gender"`       // 性别
 	Birthday   *time.Time `json:"birthday"`     // 出生日期
 	IsKeyFocus *bool      `json:"is_key_focus"` // 是否重点关注
+	// PreferredLocale 报告内容偏好语言（如 en-US），空串恢复默认语言
+	PreferredLocale *string `json:"preferred_locale"`
 }
 
 // ListTesteeRequest 查询受试者列表请求
@@ -38,6 +40,13 @@ type ListTesteeRequest struct {
 	PageSize         int     `form:"page_size" binding:"omitempty,min=1,max=100"` // 每页数量
 }
 
+// ExportTesteeRequest 受试者去标识化导出请求
+type ExportTesteeRequest struct {
+	IsKeyFocus       *bool  `form:"is_key_focus"`       // 是否重点关注
+	CreatedStartDate string `form:"created_start_date"` // 报到开始日期（YYYY-MM-DD）
+	CreatedEndDate   string `form:"created_end_date"`   // 报到结束日期（YYYY-MM-DD）
+}
+
 // GetTesteeByProfileIDRequest 根据 profile_id 查询受试者请求
 type GetTesteeByProfileIDRequest struct {
 	OrgID     int64  `form:"org_id"`     // 兼容字段：机构ID
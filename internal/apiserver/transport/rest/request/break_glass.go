@@ -0,0 +1,14 @@
+package request
+
+// CreateBreakGlassRequest 从业者申请紧急访问请求。
+type CreateBreakGlassRequest struct {
+	TesteeID        string `json:"testee_id" binding:"required"`     // 受试者ID
+	Justification   string `json:"justification" binding:"required"` // 申请理由，10-500 字
+	DurationMinutes int    `json:"duration_minutes"`                 // 授权时长（分钟），15-240，默认 60
+}
+
+// ReviewBreakGlassRequest 机构管理员复核紧急访问请求。
+type ReviewBreakGlassRequest struct {
+	Decision string `json:"decision" binding:"required"` // 复核结论：approved/flagged
+	Note     string `json:"note"`                        // 复核说明，flagged 时必填
+}
@@ -0,0 +1,18 @@
+package request
+
+// CareTeamRequest 创建或更新照护团队请求。
+type CareTeamRequest struct {
+	Name        string `json:"name" binding:"required"` // 团队名称，机构内唯一
+	Department  string `json:"department"`              // 所属科室；为空表示跨科室团队
+	Description string `json:"description"`             // 团队说明
+}
+
+// CareTeamMemberRequest 添加成员或调整成员角色请求。
+type CareTeamMemberRequest struct {
+	Role string `json:"role" binding:"required"` // 团队角色：lead/member/observer
+}
+
+// AssignCareTeamTesteeRequest 把受试者分配给团队请求。
+type AssignCareTeamTesteeRequest struct {
+	TesteeID string `json:"testee_id" binding:"required"` // 受试者ID
+}
@@ -0,0 +1,7 @@
+package request
+
+// ClinicalNoteRequest 追加临床备注请求。
+type ClinicalNoteRequest struct {
+	Content  string `json:"content" binding:"required"` // 备注正文，仅临床人员可见，最多 5000 字
+	Addendum string `json:"addendum"`                   // 面向受试者的补充说明，报告签署后展示，最多 2000 字
+}
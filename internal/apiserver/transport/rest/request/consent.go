@@ -0,0 +1,14 @@
+package request
+
+// CreateConsentDocumentRequest 创建知情同意书草稿请求。
+type CreateConsentDocumentRequest struct {
+	ScopeKind string `json:"scope_kind" binding:"required"` // 适用范围：org/model/entry
+	ScopeRef  string `json:"scope_ref"`                     // 问卷编码（model）或测评入口ID（entry）
+	Title     string `json:"title" binding:"required"`      // 标题
+	Body      string `json:"body" binding:"required"`       // 正文
+}
+
+// WithdrawConsentAcceptanceRequest 撤回知情同意请求。
+type WithdrawConsentAcceptanceRequest struct {
+	Reason string `json:"reason" binding:"required"` // 撤回原因
+}
@@ -0,0 +1,23 @@
+package request
+
+// CustomRoleScopeRequest 自定义角色资源范围；各维度为空表示不限。
+type CustomRoleScopeRequest struct {
+	ModelCodes  []string `json:"model_codes"` // 测评模型编码
+	PlanIDs     []string `json:"plan_ids"`    // 测评计划ID
+	Departments []string `json:"departments"` // 从业者科室
+}
+
+// CustomRoleRequest 创建或更新自定义角色请求；更新时忽略 code。
+type CustomRoleRequest struct {
+	Code         string                 `json:"code"`                            // 角色编码，创建后不可修改
+	Name         string                 `json:"name" binding:"required"`         // 角色名称
+	Description  string                 `json:"description"`                     // 角色说明
+	Capabilities []string               `json:"capabilities" binding:"required"` // 能力列表，见 /custom-roles/capabilities
+	Scope        CustomRoleScopeRequest `json:"scope"`                           // 资源范围
+	Sensitivity  string                 `json:"sensitivity" binding:"required"`  // 数据敏感级别：aggregate/deidentified/identified
+}
+
+// AssignCustomRoleRequest 分配自定义角色请求。
+type AssignCustomRoleRequest struct {
+	OperatorID string `json:"operator_id" binding:"required"` // 后台操作者ID
+}
@@ -0,0 +1,11 @@
+package request
+
+import "github.com/FangcunMount/qs-server/internal/pkg/meta"
+
+// CreateDataSubjectRequest 受理数据主体请求。
+type CreateDataSubjectRequest struct {
+	TesteeID meta.ID `json:"testee_id" binding:"required"` // 受试者ID
+	Kind     string  `json:"kind" binding:"required"`      // export/erasure
+	Mode     string  `json:"mode,omitempty"`               // 擦除方式：anonymize（默认）/delete
+	Reason   string  `json:"reason" binding:"required"`    // 请求依据，例如家属撤回同意
+}
@@ -0,0 +1,27 @@
+package request
+
+import "github.com/FangcunMount/qs-server/internal/pkg/meta"
+
+// GroupReportCohortRequest 群体定义，按 kind 使用不同字段：
+// entry 使用 entry_id；plan 使用 plan_id；tag 使用 tag；testees 使用 testee_ids。
+type GroupReportCohortRequest struct {
+	Kind      string    `json:"kind" binding:"required"` // 群体来源：entry/plan/tag/testees
+	EntryID   meta.ID   `json:"entry_id"`                // 测评入口ID
+	PlanID    meta.ID   `json:"plan_id"`                 // 测评计划ID
+	Tag       string    `json:"tag"`                     // 受试者标签
+	TesteeIDs []meta.ID `json:"testee_ids"`              // 显式指定的受试者ID
+}
+
+// GroupReportTermRequest 学期时间窗；from/to 支持 RFC3339 或 YYYY-MM-DD（to 为日期时包含当天）。
+type GroupReportTermRequest struct {
+	From string `json:"from" binding:"required"` // 起点
+	To   string `json:"to" binding:"required"`   // 终点
+}
+
+// GenerateGroupReportRequest 生成群体报告请求。
+type GenerateGroupReportRequest struct {
+	Cohort       GroupReportCohortRequest `json:"cohort" binding:"required"`     // 群体
+	ModelCode    string                   `json:"model_code" binding:"required"` // 测评模型编码
+	Term         GroupReportTermRequest   `json:"term" binding:"required"`       // 本学期
+	PreviousTerm *GroupReportTermRequest  `json:"previous_term"`                 // 对比学期，空表示紧邻本学期之前的等长时间窗
+}
@@ -7,6 +7,9 @@ package request
 //   - by_week/by_day: 需要 interval 和 total_times
 //   - fixed_date: 需要 fixed_dates（不需要 interval 和 total_times）
 //   - custom: 需要 relative_weeks（不需要 interval 和 total_times）
+//   - rrule: 需要 recurrence_rule（RRULE，须含 COUNT 或 UNTIL），可选 exception_dates 排除节假日
+//
+// time_zone 对所有周期类型生效，触发时间、截止时间和入口有效期均按该 IANA 时区的自然日计算。
 type CreatePlanRequest struct {
 	ScaleCode     string   `json:"scale_code" valid:"required~量表编码不能为空"`
 	ScheduleType  string   `json:"schedule_type" valid:"required~周期类型不能为空"`
@@ -15,6 +18,10 @@ type CreatePlanRequest struct {
 	TotalTimes    int      `json:"total_times,omitempty"`    // 总次数（用于 by_week/by_day）
 	FixedDates    []string `json:"fixed_dates,omitempty"`    // 固定日期列表（用于 fixed_date，格式：YYYY-MM-DD）
 	RelativeWeeks []int    `json:"relative_weeks,omitempty"` // 相对周次列表（用于 custom，如 [2,4,8,12]）
+
+	TimeZone       string   `json:"time_zone,omitempty"`       // IANA 时区（如 Asia/Shanghai、America/New_York）
+	RecurrenceRule string   `json:"recurrence_rule,omitempty"` // RRULE（用于 rrule，如 FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=12）
+	ExceptionDates []string `json:"exception_dates,omitempty"` // EXDATE 排除日期（用于 rrule，格式：YYYY-MM-DD）
 }
 
 // PausePlanRequest 暂停计划请求（无请求体，使用路径参数）
@@ -0,0 +1,31 @@
+package request
+
+// RiskAlertConditionRequest 预警规则条件，按 kind 使用不同字段：
+// item_answer 使用 question_code/operator/threshold；factor_score 使用 factor_code/score_kind/operator/threshold；
+// score_change 使用 factor_code（空表示主分数）与 threshold（升幅）；risk_level 使用 levels。
+type RiskAlertConditionRequest struct {
+	QuestionCode string   `json:"question_code"` // 题目编码
+	FactorCode   string   `json:"factor_code"`   // 因子编码
+	ScoreKind    string   `json:"score_kind"`    // 分数类型：raw_total/t_score，默认 raw_total
+	Operator     string   `json:"operator"`      // 比较运算：gte/gt/lte/lt/eq
+	Threshold    float64  `json:"threshold"`     // 阈值
+	Levels       []string `json:"levels"`        // 风险等级编码
+}
+
+// RiskAlertRuleRequest 创建或更新风险预警规则请求。
+type RiskAlertRuleRequest struct {
+	Name               string                    `json:"name" binding:"required"`         // 规则名称
+	Kind               string                    `json:"kind" binding:"required"`         // 规则类型：item_answer/factor_score/score_change/risk_level
+	ModelCode          string                    `json:"model_code"`                      // 测评模型编码，空表示全部模型
+	Condition          RiskAlertConditionRequest `json:"condition"`                       // 触发条件
+	Severity           string                    `json:"severity" binding:"required"`     // 预警级别：critical/high/medium
+	PageChannel        string                    `json:"page_channel" binding:"required"` // 首次寻呼渠道
+	EscalationChannels []string                  `json:"escalation_channels"`             // 无人确认时依次升级的渠道
+	AckTimeoutSeconds  int                       `json:"ack_timeout_seconds"`             // 每一级等待确认的秒数
+	Enabled            bool                      `json:"enabled"`                         // 是否启用
+}
+
+// AcknowledgeRiskAlertRequest 确认风险预警请求。
+type AcknowledgeRiskAlertRequest struct {
+	Note string `json:"note"` // 确认说明
+}
@@ -0,0 +1,15 @@
+package request
+
+import "github.com/FangcunMount/qs-server/internal/pkg/meta"
+
+// MergeTesteesRequest 合并重复受试者请求。
+type MergeTesteesRequest struct {
+	SurvivorID  meta.ID `json:"survivor_id" binding:"required"`  // 保留的受试者ID
+	DuplicateID meta.ID `json:"duplicate_id" binding:"required"` // 被合并的重复受试者ID
+	Reason      string  `json:"reason" binding:"required"`       // 合并原因
+}
+
+// RevertTesteeMergeRequest 回滚受试者合并请求。
+type RevertTesteeMergeRequest struct {
+	Reason string `json:"reason" binding:"required"` // 回滚原因
+}
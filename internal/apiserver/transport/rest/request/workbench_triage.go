@@ -0,0 +1,18 @@
+package request
+
+// WorkbenchTriageNoteRequest 认领、重新打开工作台条目请求。
+type WorkbenchTriageNoteRequest struct {
+	Note string `json:"note"` // 操作说明，可选，最多 1000 字
+}
+
+// WorkbenchTriageSnoozeRequest 暂缓工作台条目请求。
+type WorkbenchTriageSnoozeRequest struct {
+	SnoozedUntil *FlexibleTime `json:"snoozed_until" binding:"required"` // 暂缓截止时间，需晚于当前时间且不超过 30 天
+	Note         string        `json:"note"`                             // 暂缓说明，可选，最多 1000 字
+}
+
+// WorkbenchTriageResolveRequest 解决工作台条目请求。
+type WorkbenchTriageResolveRequest struct {
+	ResolutionCode string `json:"resolution_code" binding:"required"` // 结论代码：contacted/appointment_scheduled/referred/false_positive/no_action_needed/other
+	Note           string `json:"note"`                               // 结论说明，resolution_code 为 other 时必填，最多 1000 字
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"strconv"
+	"time"
+
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+)
+
+// AccessAuditEntryResponse 受试者数据访问审计记录。
+type AccessAuditEntryResponse struct {
+	Seq          string `json:"seq"`
+	ActorUserID  string `json:"actor_user_id"`
+	ActorRole    string `json:"actor_role"`
+	Relation     string `json:"relation"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	TesteeID     string `json:"testee_id,omitempty"`
+	Purpose      string `json:"purpose"`
+	Result       string `json:"result"`
+	StatusCode   int    `json:"status_code"`
+	Route        string `json:"route"`
+	RequestID    string `json:"request_id,omitempty"`
+	ClientIP     string `json:"client_ip,omitempty"`
+	OccurredAt   string `json:"occurred_at"`
+	PrevHash     string `json:"prev_hash"`
+	Hash         string `json:"hash"`
+}
+
+// AccessAuditListResponse 访问审计记录分页。
+type AccessAuditListResponse struct {
+	Items      []*AccessAuditEntryResponse `json:"items"`
+	Total      int64                       `json:"total"`
+	Page       int                         `json:"page"`
+	PageSize   int                         `json:"page_size"`
+	TotalPages int                         `json:"total_pages"`
+}
+
+// AccessAuditChainVerificationResponse 访问审计哈希链校验结果。
+type AccessAuditChainVerificationResponse struct {
+	Valid       bool   `json:"valid"`
+	Checked     string `json:"checked"`
+	HeadSeq     string `json:"head_seq"`
+	HeadHash    string `json:"head_hash,omitempty"`
+	BrokenAtSeq string `json:"broken_at_seq,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func NewAccessAuditEntryResponse(entry accessAuditApp.Entry) *AccessAuditEntryResponse {
+	item := &AccessAuditEntryResponse{
+		Seq:          strconv.FormatUint(entry.Seq, 10),
+		ActorUserID:  strconv.FormatInt(entry.ActorUserID, 10),
+		ActorRole:    entry.ActorRole,
+		Relation:     entry.Relation,
+		ResourceType: string(entry.ResourceType),
+		ResourceID:   entry.ResourceID,
+		Purpose:      entry.Purpose,
+		Result:       string(entry.Result),
+		StatusCode:   entry.StatusCode,
+		Route:        entry.Route,
+		RequestID:    entry.RequestID,
+		ClientIP:     entry.ClientIP,
+		OccurredAt:   entry.OccurredAt.Format(time.RFC3339Nano),
+		PrevHash:     entry.PrevHash,
+		Hash:         entry.Hash,
+	}
+	if entry.TesteeID > 0 {
+		item.TesteeID = strconv.FormatUint(entry.TesteeID, 10)
+	}
+	return item
+}
+
+func NewAccessAuditListResponse(result *accessAuditApp.EntryList) *AccessAuditListResponse {
+	if result == nil {
+		return &AccessAuditListResponse{Items: []*AccessAuditEntryResponse{}}
+	}
+	items := make([]*AccessAuditEntryResponse, 0, len(result.Items))
+	for _, entry := range result.Items {
+		items = append(items, NewAccessAuditEntryResponse(entry))
+	}
+	return &AccessAuditListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+func NewAccessAuditChainVerificationResponse(result *accessAuditApp.ChainVerification) *AccessAuditChainVerificationResponse {
+	if result == nil {
+		return &AccessAuditChainVerificationResponse{}
+	}
+	resp := &AccessAuditChainVerificationResponse{
+		Valid:    result.Valid,
+		Checked:  strconv.FormatUint(result.Checked, 10),
+		HeadSeq:  strconv.FormatUint(result.HeadSeq, 10),
+		HeadHash: result.HeadHash,
+		Reason:   result.Reason,
+	}
+	if result.BrokenAtSeq > 0 {
+		resp.BrokenAtSeq = strconv.FormatUint(result.BrokenAtSeq, 10)
+	}
+	return resp
+}
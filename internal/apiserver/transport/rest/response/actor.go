@@ -9,14 +9,17 @@ type TesteeResponse struct {
 	Gender          string                   `json:"gender,omitempty"`             // 性别
 	GenderLabel     string                   `json:"gender_label,omitempty"`       // 性别中文
 	Birthday        *string                  `json:"birthday,omitempty"`           // 出生日期
+	AgeBand         string                   `json:"age_band,omitempty"`           // 年龄段（脱敏时代替出生日期）
 	Source          string                   `json:"source,omitempty"`             // 来源
 	SourceLabel     string                   `json:"source_label,omitempty"`       // 来源中文
 	IsKeyFocus      bool                     `json:"is_key_focus"`                 // 是否重点关注
 	IsKeyFocusLabel string                   `json:"is_key_focus_label,omitempty"` // 是否重点关注中文
+	PreferredLocale string                   `json:"preferred_locale,omitempty"`   // 报告内容偏好语言
 	AssessmentStats *AssessmentStatsResponse `json:"assessment_stats,omitempty"`   // 测评统计
 	Guardians       []GuardianResponse       `json:"guardians,omitempty"`          // 监护人信息列表
 	CreatedAt       string                   `json:"created_at,omitempty"`         // 创建时间
 	UpdatedAt       string                   `json:"updated_at,omitempty"`         // 更新时间
+	Redacted        bool                     `json:"redacted,omitempty"`           // 是否已按查看者权限脱敏
 }
 
 // GuardianResponse 监护人信息响应
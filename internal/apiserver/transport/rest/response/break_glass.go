@@ -0,0 +1,75 @@
+package response
+
+import (
+	"strconv"
+
+	breakGlassApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+)
+
+// BreakGlassGrantResponse 紧急访问授权。
+type BreakGlassGrantResponse struct {
+	ID             string  `json:"id"`
+	ClinicianID    string  `json:"clinician_id"`
+	OperatorUserID string  `json:"operator_user_id"`
+	TesteeID       string  `json:"testee_id"`
+	Justification  string  `json:"justification"`
+	Status         string  `json:"status"`
+	GrantedAt      string  `json:"granted_at"`
+	ExpiresAt      string  `json:"expires_at"`
+	RevokedAt      *string `json:"revoked_at,omitempty"`
+	RevokedBy      string  `json:"revoked_by,omitempty"`
+	ReviewStatus   string  `json:"review_status"`
+	ReviewedBy     string  `json:"reviewed_by,omitempty"`
+	ReviewedAt     *string `json:"reviewed_at,omitempty"`
+	ReviewNote     string  `json:"review_note,omitempty"`
+}
+
+// BreakGlassGrantListResponse 紧急访问授权分页。
+type BreakGlassGrantListResponse struct {
+	Items      []*BreakGlassGrantResponse `json:"items"`
+	Total      int64                      `json:"total"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+func NewBreakGlassGrantResponse(grant *breakGlassApp.Grant) *BreakGlassGrantResponse {
+	if grant == nil {
+		return nil
+	}
+	resp := &BreakGlassGrantResponse{
+		ID:             strconv.FormatUint(grant.ID, 10),
+		ClinicianID:    strconv.FormatUint(grant.ClinicianID, 10),
+		OperatorUserID: strconv.FormatInt(grant.OperatorUserID, 10),
+		TesteeID:       strconv.FormatUint(grant.TesteeID, 10),
+		Justification:  grant.Justification,
+		Status:         string(grant.Status),
+		GrantedAt:      FormatDateTimeValue(grant.GrantedAt),
+		ExpiresAt:      FormatDateTimeValue(grant.ExpiresAt),
+		RevokedAt:      FormatDateTimePtr(grant.RevokedAt),
+		ReviewStatus:   string(grant.ReviewStatus),
+		ReviewedAt:     FormatDateTimePtr(grant.ReviewedAt),
+		ReviewNote:     grant.ReviewNote,
+	}
+	if grant.RevokedBy > 0 {
+		resp.RevokedBy = strconv.FormatInt(grant.RevokedBy, 10)
+	}
+	if grant.ReviewedBy > 0 {
+		resp.ReviewedBy = strconv.FormatInt(grant.ReviewedBy, 10)
+	}
+	return resp
+}
+
+func NewBreakGlassGrantListResponse(result *breakGlassApp.GrantList) *BreakGlassGrantListResponse {
+	if result == nil {
+		return &BreakGlassGrantListResponse{Items: []*BreakGlassGrantResponse{}}
+	}
+	items := make([]*BreakGlassGrantResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, NewBreakGlassGrantResponse(&result.Items[i]))
+	}
+	return &BreakGlassGrantListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
@@ -0,0 +1,189 @@
+package response
+
+import (
+	"strconv"
+
+	careTeamApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
+)
+
+// CareTeamResponse 照护团队。
+type CareTeamResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Department  string `json:"department"`
+	Description string `json:"description"`
+	MemberCount int64  `json:"member_count"`
+	TesteeCount int64  `json:"testee_count"`
+	CreatedBy   string `json:"created_by"`
+	UpdatedBy   string `json:"updated_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// CareTeamListResponse 照护团队列表。
+type CareTeamListResponse struct {
+	Items []*CareTeamResponse `json:"items"`
+}
+
+// CareTeamMemberResponse 团队成员；inherits_access 表示该角色是否继承团队受试者的访问。
+type CareTeamMemberResponse struct {
+	TeamID         string `json:"team_id"`
+	ClinicianID    string `json:"clinician_id"`
+	ClinicianName  string `json:"clinician_name"`
+	Department     string `json:"department"`
+	Role           string `json:"role"`
+	InheritsAccess bool   `json:"inherits_access"`
+	AddedBy        string `json:"added_by"`
+	AddedAt        string `json:"added_at"`
+}
+
+// CareTeamMemberListResponse 团队成员列表。
+type CareTeamMemberListResponse struct {
+	Items []*CareTeamMemberResponse `json:"items"`
+}
+
+// CareTeamTesteeResponse 分配给团队的受试者。
+type CareTeamTesteeResponse struct {
+	TeamID     string `json:"team_id"`
+	TesteeID   string `json:"testee_id"`
+	TesteeName string `json:"testee_name"`
+	AssignedBy string `json:"assigned_by"`
+	AssignedAt string `json:"assigned_at"`
+}
+
+// CareTeamTesteeListResponse 团队受试者分页。
+type CareTeamTesteeListResponse struct {
+	Items      []*CareTeamTesteeResponse `json:"items"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+// CareTeamEventResponse 团队变更审计事件。
+type CareTeamEventResponse struct {
+	ID             string `json:"id"`
+	TeamID         string `json:"team_id"`
+	Action         string `json:"action"`
+	ClinicianID    string `json:"clinician_id,omitempty"`
+	TesteeID       string `json:"testee_id,omitempty"`
+	FromRole       string `json:"from_role,omitempty"`
+	ToRole         string `json:"to_role,omitempty"`
+	OperatorUserID string `json:"operator_user_id"`
+	OccurredAt     string `json:"occurred_at"`
+}
+
+// CareTeamEventListResponse 团队事件分页。
+type CareTeamEventListResponse struct {
+	Items      []*CareTeamEventResponse `json:"items"`
+	Total      int64                    `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+func NewCareTeamResponse(team *careTeamApp.Team) *CareTeamResponse {
+	if team == nil {
+		return nil
+	}
+	return &CareTeamResponse{
+		ID:          strconv.FormatUint(team.ID, 10),
+		Name:        team.Name,
+		Department:  team.Department,
+		Description: team.Description,
+		MemberCount: team.MemberCount,
+		TesteeCount: team.TesteeCount,
+		CreatedBy:   strconv.FormatInt(team.CreatedBy, 10),
+		UpdatedBy:   strconv.FormatInt(team.UpdatedBy, 10),
+		CreatedAt:   FormatDateTimeValue(team.CreatedAt),
+		UpdatedAt:   FormatDateTimeValue(team.UpdatedAt),
+	}
+}
+
+func NewCareTeamListResponse(teams []careTeamApp.Team) *CareTeamListResponse {
+	items := make([]*CareTeamResponse, 0, len(teams))
+	for i := range teams {
+		items = append(items, NewCareTeamResponse(&teams[i]))
+	}
+	return &CareTeamListResponse{Items: items}
+}
+
+func NewCareTeamMemberResponse(member *careTeamApp.Member) *CareTeamMemberResponse {
+	if member == nil {
+		return nil
+	}
+	return &CareTeamMemberResponse{
+		TeamID:         strconv.FormatUint(member.TeamID, 10),
+		ClinicianID:    strconv.FormatUint(member.ClinicianID, 10),
+		ClinicianName:  member.ClinicianName,
+		Department:     member.Department,
+		Role:           string(member.Role),
+		InheritsAccess: member.Role.InheritsAccess(),
+		AddedBy:        strconv.FormatInt(member.AddedBy, 10),
+		AddedAt:        FormatDateTimeValue(member.AddedAt),
+	}
+}
+
+func NewCareTeamMemberListResponse(members []careTeamApp.Member) *CareTeamMemberListResponse {
+	items := make([]*CareTeamMemberResponse, 0, len(members))
+	for i := range members {
+		items = append(items, NewCareTeamMemberResponse(&members[i]))
+	}
+	return &CareTeamMemberListResponse{Items: items}
+}
+
+func NewCareTeamTesteeResponse(assignment *careTeamApp.TesteeAssignment) *CareTeamTesteeResponse {
+	if assignment == nil {
+		return nil
+	}
+	return &CareTeamTesteeResponse{
+		TeamID:     strconv.FormatUint(assignment.TeamID, 10),
+		TesteeID:   strconv.FormatUint(assignment.TesteeID, 10),
+		TesteeName: assignment.TesteeName,
+		AssignedBy: strconv.FormatInt(assignment.AssignedBy, 10),
+		AssignedAt: FormatDateTimeValue(assignment.AssignedAt),
+	}
+}
+
+func NewCareTeamTesteeListResponse(result *careTeamApp.TesteePage) *CareTeamTesteeListResponse {
+	if result == nil {
+		return &CareTeamTesteeListResponse{Items: []*CareTeamTesteeResponse{}}
+	}
+	items := make([]*CareTeamTesteeResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, NewCareTeamTesteeResponse(&result.Items[i]))
+	}
+	return &CareTeamTesteeListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+func NewCareTeamEventListResponse(result *careTeamApp.EventPage) *CareTeamEventListResponse {
+	if result == nil {
+		return &CareTeamEventListResponse{Items: []*CareTeamEventResponse{}}
+	}
+	items := make([]*CareTeamEventResponse, 0, len(result.Items))
+	for _, event := range result.Items {
+		item := &CareTeamEventResponse{
+			ID:             strconv.FormatUint(event.ID, 10),
+			TeamID:         strconv.FormatUint(event.TeamID, 10),
+			Action:         string(event.Action),
+			FromRole:       string(event.FromRole),
+			ToRole:         string(event.ToRole),
+			OperatorUserID: strconv.FormatInt(event.OperatorUserID, 10),
+			OccurredAt:     FormatDateTimeValue(event.OccurredAt),
+		}
+		if event.ClinicianID > 0 {
+			item.ClinicianID = strconv.FormatUint(event.ClinicianID, 10)
+		}
+		if event.TesteeID > 0 {
+			item.TesteeID = strconv.FormatUint(event.TesteeID, 10)
+		}
+		items = append(items, item)
+	}
+	return &CareTeamEventListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
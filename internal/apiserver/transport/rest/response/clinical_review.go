@@ -0,0 +1,87 @@
+package response
+
+import (
+	"strconv"
+
+	clinicalReviewApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
+)
+
+// ClinicalReviewStatusResponse 报告复核状态；reviewer 在签署后出现。
+type ClinicalReviewStatusResponse struct {
+	AssessmentID string                          `json:"assessment_id"`
+	TesteeID     string                          `json:"testee_id"`
+	Status       string                          `json:"status"`
+	NoteVersion  int                             `json:"note_version"`
+	Reviewer     *ClinicalReviewReviewerResponse `json:"reviewer,omitempty"`
+	ReviewedAt   *string                         `json:"reviewed_at,omitempty"`
+}
+
+// ClinicalReviewReviewerResponse 复核人或备注作者。
+type ClinicalReviewReviewerResponse struct {
+	UserID      string `json:"user_id"`
+	ClinicianID string `json:"clinician_id"`
+	Name        string `json:"name"`
+}
+
+// ClinicalNoteResponse 一个临床备注版本。
+type ClinicalNoteResponse struct {
+	ID        string                         `json:"id"`
+	Version   int                            `json:"version"`
+	Content   string                         `json:"content"`
+	Addendum  string                         `json:"addendum,omitempty"`
+	Author    ClinicalReviewReviewerResponse `json:"author"`
+	CreatedAt string                         `json:"created_at"`
+}
+
+// ClinicalReviewResponse 报告复核状态与备注版本历史（按版本升序）。
+type ClinicalReviewResponse struct {
+	ClinicalReviewStatusResponse
+	Notes []ClinicalNoteResponse `json:"notes"`
+}
+
+func NewClinicalReviewStatusResponse(review *clinicalReviewApp.Review) *ClinicalReviewStatusResponse {
+	if review == nil {
+		return nil
+	}
+	result := &ClinicalReviewStatusResponse{
+		AssessmentID: strconv.FormatUint(review.AssessmentID, 10),
+		TesteeID:     strconv.FormatUint(review.TesteeID, 10),
+		Status:       string(review.Status),
+		NoteVersion:  review.NoteVersion,
+		ReviewedAt:   FormatDateTimePtr(review.ReviewedAt),
+	}
+	if review.Reviewer != nil {
+		reviewer := newClinicalReviewReviewerResponse(*review.Reviewer)
+		result.Reviewer = &reviewer
+	}
+	return result
+}
+
+func NewClinicalReviewResponse(view *clinicalReviewApp.ReviewView) *ClinicalReviewResponse {
+	if view == nil {
+		return nil
+	}
+	notes := make([]ClinicalNoteResponse, 0, len(view.Notes))
+	for _, note := range view.Notes {
+		notes = append(notes, ClinicalNoteResponse{
+			ID:        strconv.FormatUint(note.ID, 10),
+			Version:   note.Version,
+			Content:   note.Content,
+			Addendum:  note.Addendum,
+			Author:    newClinicalReviewReviewerResponse(note.Author),
+			CreatedAt: FormatDateTimeValue(note.CreatedAt),
+		})
+	}
+	return &ClinicalReviewResponse{
+		ClinicalReviewStatusResponse: *NewClinicalReviewStatusResponse(&view.Review),
+		Notes:                        notes,
+	}
+}
+
+func newClinicalReviewReviewerResponse(reviewer clinicalReviewApp.Reviewer) ClinicalReviewReviewerResponse {
+	return ClinicalReviewReviewerResponse{
+		UserID:      strconv.FormatInt(reviewer.UserID, 10),
+		ClinicianID: strconv.FormatUint(reviewer.ClinicianID, 10),
+		Name:        reviewer.Name,
+	}
+}
@@ -7,14 +7,24 @@ import (
 	domainPlan "github.com/FangcunMount/qs-server/internal/apiserver/domain/plan"
 )
 
+// ClinicianWorkbenchQueueSummaryResponse 工作台汇总；接入分诊后 counts 为待处理（open 与 claimed）条目数，
+// open 为其中尚未认领的条目数，handled 为已解决或暂缓中的条目数。
 type ClinicianWorkbenchQueueSummaryResponse struct {
-	Counts ClinicianWorkbenchQueueCountsResponse `json:"counts"`
+	Counts  ClinicianWorkbenchQueueCountsResponse `json:"counts"`
+	Open    ClinicianWorkbenchQueueCountsResponse `json:"open"`
+	Handled ClinicianWorkbenchQueueCountsResponse `json:"handled"`
+	// EscalatedHighRisk 超过认领时限升级且仍未认领的高风险条目数。
+	EscalatedHighRisk int64 `json:"escalated_high_risk"`
 }
 
 type ClinicianWorkbenchQueueCountsResponse struct {
 	HighRisk int64 `json:"high_risk"`
 	FollowUp int64 `json:"follow_up"`
 	KeyFocus int64 `json:"key_focus"`
+	// AwaitingReview 待临床复核的报告数。
+	AwaitingReview int64 `json:"awaiting_review"`
+	// CriticalItem 提交时命中关键条目的答卷数。
+	CriticalItem int64 `json:"critical_item"`
 }
 
 type ClinicianWorkbenchQueueResponse struct {
@@ -27,15 +37,48 @@ type ClinicianWorkbenchQueueResponse struct {
 }
 
 type ClinicianWorkbenchQueueItemResponse struct {
-	Testee             *TesteeResponse                        `json:"testee"`
-	ReasonCode         string                                 `json:"reason_code"`
-	Reason             string                                 `json:"reason"`
-	ReasonAt           *string                                `json:"reason_at,omitempty"`
-	RiskLevel          string                                 `json:"risk_level,omitempty"`
-	Task               *ClinicianWorkbenchTaskSummaryResponse `json:"task"`
-	PrimaryClinician   *ClinicianAssignmentResponse           `json:"primary_clinician,omitempty"`
-	AssignedClinicians []ClinicianAssignmentResponse          `json:"assigned_clinicians,omitempty"`
-	IsUnassigned       *bool                                  `json:"is_unassigned,omitempty"`
+	SubjectID          string                                  `json:"subject_id"`
+	Testee             *TesteeResponse                         `json:"testee"`
+	ReasonCode         string                                  `json:"reason_code"`
+	Reason             string                                  `json:"reason"`
+	ReasonAt           *string                                 `json:"reason_at,omitempty"`
+	RiskLevel          string                                  `json:"risk_level,omitempty"`
+	Task               *ClinicianWorkbenchTaskSummaryResponse  `json:"task"`
+	PrimaryClinician   *ClinicianAssignmentResponse            `json:"primary_clinician,omitempty"`
+	AssignedClinicians []ClinicianAssignmentResponse           `json:"assigned_clinicians,omitempty"`
+	IsUnassigned       *bool                                   `json:"is_unassigned,omitempty"`
+	BreakGlass         []ClinicianWorkbenchBreakGlassResponse  `json:"break_glass,omitempty"`
+	Review             *ClinicianWorkbenchReviewResponse       `json:"review,omitempty"`
+	CriticalItem       *ClinicianWorkbenchCriticalItemResponse `json:"critical_item,omitempty"`
+	Triage             *ClinicianWorkbenchTriageStateResponse  `json:"triage,omitempty"`
+}
+
+// ClinicianWorkbenchReviewResponse 待复核队列中的报告；note_version 为 0 表示尚无临床备注。
+type ClinicianWorkbenchReviewResponse struct {
+	AssessmentID string `json:"assessment_id"`
+	NoteVersion  int    `json:"note_version"`
+}
+
+// ClinicianWorkbenchCriticalItemResponse 关键条目队列中的答卷；items 的 trigger 为命中的选项编码、数值或关键词，不含答案原文。
+type ClinicianWorkbenchCriticalItemResponse struct {
+	AnswerSheetID        string                                      `json:"answersheet_id"`
+	QuestionnaireCode    string                                      `json:"questionnaire_code"`
+	QuestionnaireVersion string                                      `json:"questionnaire_version"`
+	Categories           []string                                    `json:"categories"`
+	Items                []ClinicianWorkbenchCriticalItemHitResponse `json:"items"`
+}
+
+type ClinicianWorkbenchCriticalItemHitResponse struct {
+	QuestionCode string `json:"question_code"`
+	Category     string `json:"category"`
+	Trigger      string `json:"trigger"`
+}
+
+// ClinicianWorkbenchBreakGlassResponse 受试者上生效中的紧急访问授权。
+type ClinicianWorkbenchBreakGlassResponse struct {
+	GrantID     string `json:"grant_id"`
+	ClinicianID string `json:"clinician_id"`
+	ExpiresAt   string `json:"expires_at"`
 }
 
 type ClinicianWorkbenchTaskSummaryResponse struct {
@@ -67,11 +110,20 @@ func NewClinicianWorkbenchQueueSummaryResponse(result *workbenchApp.SummaryResul
 		return &ClinicianWorkbenchQueueSummaryResponse{}
 	}
 	return &ClinicianWorkbenchQueueSummaryResponse{
-		Counts: ClinicianWorkbenchQueueCountsResponse{
-			HighRisk: result.Counts.HighRisk,
-			FollowUp: result.Counts.FollowUp,
-			KeyFocus: result.Counts.KeyFocus,
-		},
+		Counts:            newClinicianWorkbenchQueueCountsResponse(result.Counts),
+		Open:              newClinicianWorkbenchQueueCountsResponse(result.Open),
+		Handled:           newClinicianWorkbenchQueueCountsResponse(result.Handled),
+		EscalatedHighRisk: result.EscalatedHighRisk,
+	}
+}
+
+func newClinicianWorkbenchQueueCountsResponse(counts workbenchApp.QueueCounts) ClinicianWorkbenchQueueCountsResponse {
+	return ClinicianWorkbenchQueueCountsResponse{
+		HighRisk:       counts.HighRisk,
+		FollowUp:       counts.FollowUp,
+		KeyFocus:       counts.KeyFocus,
+		AwaitingReview: counts.AwaitingReview,
+		CriticalItem:   counts.CriticalItem,
 	}
 }
 
@@ -95,6 +147,7 @@ func NewClinicianWorkbenchQueueResponse(result *workbenchApp.QueuePage) *Clinici
 
 func newClinicianWorkbenchQueueItemResponse(item workbenchApp.QueueItem) ClinicianWorkbenchQueueItemResponse {
 	return ClinicianWorkbenchQueueItemResponse{
+		SubjectID:          fmt.Sprintf("%d", item.SubjectID),
 		Testee:             newClinicianWorkbenchTesteeResponse(item.Testee),
 		ReasonCode:         item.ReasonCode,
 		Reason:             item.Reason,
@@ -104,7 +157,57 @@ func newClinicianWorkbenchQueueItemResponse(item workbenchApp.QueueItem) Clinici
 		PrimaryClinician:   newClinicianAssignmentResponse(item.PrimaryClinician),
 		AssignedClinicians: newClinicianAssignmentResponses(item.AssignedClinicians),
 		IsUnassigned:       item.IsUnassigned,
+		BreakGlass:         newClinicianWorkbenchBreakGlassResponses(item.BreakGlass),
+		Review:             newClinicianWorkbenchReviewResponse(item.Review),
+		CriticalItem:       newClinicianWorkbenchCriticalItemResponse(item.CriticalItem),
+		Triage:             newClinicianWorkbenchTriageStateResponse(item.Triage),
+	}
+}
+
+func newClinicianWorkbenchReviewResponse(review *workbenchApp.ReviewSummary) *ClinicianWorkbenchReviewResponse {
+	if review == nil {
+		return nil
 	}
+	return &ClinicianWorkbenchReviewResponse{
+		AssessmentID: fmt.Sprintf("%d", review.AssessmentID),
+		NoteVersion:  review.NoteVersion,
+	}
+}
+
+func newClinicianWorkbenchCriticalItemResponse(item *workbenchApp.CriticalItemSummary) *ClinicianWorkbenchCriticalItemResponse {
+	if item == nil {
+		return nil
+	}
+	hits := make([]ClinicianWorkbenchCriticalItemHitResponse, 0, len(item.Items))
+	for _, hit := range item.Items {
+		hits = append(hits, ClinicianWorkbenchCriticalItemHitResponse{
+			QuestionCode: hit.QuestionCode,
+			Category:     hit.Category,
+			Trigger:      hit.Trigger,
+		})
+	}
+	return &ClinicianWorkbenchCriticalItemResponse{
+		AnswerSheetID:        fmt.Sprintf("%d", item.AnswerSheetID),
+		QuestionnaireCode:    item.QuestionnaireCode,
+		QuestionnaireVersion: item.QuestionnaireVersion,
+		Categories:           item.Categories,
+		Items:                hits,
+	}
+}
+
+func newClinicianWorkbenchBreakGlassResponses(items []workbenchApp.BreakGlassAccess) []ClinicianWorkbenchBreakGlassResponse {
+	if len(items) == 0 {
+		return nil
+	}
+	result := make([]ClinicianWorkbenchBreakGlassResponse, 0, len(items))
+	for _, item := range items {
+		result = append(result, ClinicianWorkbenchBreakGlassResponse{
+			GrantID:     fmt.Sprintf("%d", item.GrantID),
+			ClinicianID: fmt.Sprintf("%d", item.ClinicianID),
+			ExpiresAt:   FormatDateTimeValue(item.ExpiresAt),
+		})
+	}
+	return result
 }
 
 func newClinicianWorkbenchTesteeResponse(result workbenchApp.Testee) *TesteeResponse {
@@ -0,0 +1,137 @@
+package response
+
+import (
+	"strconv"
+
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+)
+
+// ConsentDocumentResponse 知情同意书。
+type ConsentDocumentResponse struct {
+	ID          string  `json:"id"`
+	ScopeKind   string  `json:"scope_kind"`
+	ScopeRef    string  `json:"scope_ref,omitempty"`
+	Version     int     `json:"version"`
+	Title       string  `json:"title"`
+	Body        string  `json:"body"`
+	ContentHash string  `json:"content_hash"`
+	Status      string  `json:"status"`
+	CreatedBy   string  `json:"created_by"`
+	CreatedAt   string  `json:"created_at"`
+	PublishedBy *string `json:"published_by,omitempty"`
+	PublishedAt *string `json:"published_at,omitempty"`
+	RetiredAt   *string `json:"retired_at,omitempty"`
+}
+
+// ConsentDocumentListResponse 知情同意书列表。
+type ConsentDocumentListResponse struct {
+	Items      []*ConsentDocumentResponse `json:"items"`
+	Total      int64                      `json:"total"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// ConsentAcceptanceResponse 知情同意签署记录。
+type ConsentAcceptanceResponse struct {
+	ID               string  `json:"id"`
+	DocumentID       string  `json:"document_id"`
+	DocumentVersion  int     `json:"document_version"`
+	ScopeKind        string  `json:"scope_kind"`
+	ScopeRef         string  `json:"scope_ref,omitempty"`
+	ContentHash      string  `json:"content_hash"`
+	TesteeID         string  `json:"testee_id"`
+	FillerUserID     string  `json:"filler_user_id"`
+	FillerType       string  `json:"filler_type"`
+	GuardianRelation string  `json:"guardian_relation,omitempty"`
+	IP               string  `json:"ip,omitempty"`
+	UserAgent        string  `json:"user_agent,omitempty"`
+	RequestID        string  `json:"request_id,omitempty"`
+	AcceptedAt       string  `json:"accepted_at"`
+	Active           bool    `json:"active"`
+	WithdrawnBy      *string `json:"withdrawn_by,omitempty"`
+	WithdrawnAt      *string `json:"withdrawn_at,omitempty"`
+	WithdrawReason   string  `json:"withdraw_reason,omitempty"`
+}
+
+// ConsentAcceptanceListResponse 知情同意签署记录列表。
+type ConsentAcceptanceListResponse struct {
+	Items      []*ConsentAcceptanceResponse `json:"items"`
+	Total      int64                        `json:"total"`
+	Page       int                          `json:"page"`
+	PageSize   int                          `json:"page_size"`
+	TotalPages int                          `json:"total_pages"`
+}
+
+// NewConsentDocumentResponse 转换知情同意书。
+func NewConsentDocumentResponse(result *consentApp.DocumentResult) *ConsentDocumentResponse {
+	if result == nil {
+		return nil
+	}
+	return &ConsentDocumentResponse{
+		ID:          strconv.FormatUint(result.ID, 10),
+		ScopeKind:   result.ScopeKind,
+		ScopeRef:    result.ScopeRef,
+		Version:     result.Version,
+		Title:       result.Title,
+		Body:        result.Body,
+		ContentHash: result.ContentHash,
+		Status:      result.Status,
+		CreatedBy:   strconv.FormatUint(result.CreatedBy, 10),
+		CreatedAt:   FormatDateTimeValue(result.CreatedAt),
+		PublishedBy: optionalIDString(result.PublishedBy),
+		PublishedAt: FormatDateTimePtr(result.PublishedAt),
+		RetiredAt:   FormatDateTimePtr(result.RetiredAt),
+	}
+}
+
+// NewConsentDocumentListResponse 转换知情同意书分页。
+func NewConsentDocumentListResponse(result *consentApp.DocumentListResult) *ConsentDocumentListResponse {
+	items := make([]*ConsentDocumentResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, NewConsentDocumentResponse(item))
+	}
+	return &ConsentDocumentListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+// NewConsentAcceptanceResponse 转换签署记录。
+func NewConsentAcceptanceResponse(result *consentApp.AcceptanceResult) *ConsentAcceptanceResponse {
+	if result == nil {
+		return nil
+	}
+	return &ConsentAcceptanceResponse{
+		ID:               strconv.FormatUint(result.ID, 10),
+		DocumentID:       strconv.FormatUint(result.DocumentID, 10),
+		DocumentVersion:  result.DocumentVersion,
+		ScopeKind:        result.ScopeKind,
+		ScopeRef:         result.ScopeRef,
+		ContentHash:      result.ContentHash,
+		TesteeID:         strconv.FormatUint(result.TesteeID, 10),
+		FillerUserID:     strconv.FormatUint(result.FillerUserID, 10),
+		FillerType:       result.FillerType,
+		GuardianRelation: result.GuardianRelation,
+		IP:               result.IP,
+		UserAgent:        result.UserAgent,
+		RequestID:        result.RequestID,
+		AcceptedAt:       FormatDateTimeValue(result.AcceptedAt),
+		Active:           result.Active,
+		WithdrawnBy:      optionalIDString(result.WithdrawnBy),
+		WithdrawnAt:      FormatDateTimePtr(result.WithdrawnAt),
+		WithdrawReason:   result.WithdrawReason,
+	}
+}
+
+// NewConsentAcceptanceListResponse 转换签署记录分页。
+func NewConsentAcceptanceListResponse(result *consentApp.AcceptanceListResult) *ConsentAcceptanceListResponse {
+	items := make([]*ConsentAcceptanceResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, NewConsentAcceptanceResponse(item))
+	}
+	return &ConsentAcceptanceListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
@@ -0,0 +1,199 @@
+package response
+
+import (
+	"strconv"
+
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
+	authzapp "github.com/FangcunMount/qs-server/internal/apiserver/application/authz"
+)
+
+// CustomRoleScopeResponse 自定义角色资源范围；空数组表示不限。
+type CustomRoleScopeResponse struct {
+	ModelCodes  []string `json:"model_codes"`
+	PlanIDs     []string `json:"plan_ids"`
+	Departments []string `json:"departments"`
+}
+
+// CustomRoleResponse 自定义角色。
+type CustomRoleResponse struct {
+	ID           string                  `json:"id"`
+	Code         string                  `json:"code"`
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description"`
+	Capabilities []string                `json:"capabilities"`
+	Scope        CustomRoleScopeResponse `json:"scope"`
+	Sensitivity  string                  `json:"sensitivity"`
+	CreatedBy    string                  `json:"created_by"`
+	UpdatedBy    string                  `json:"updated_by"`
+	CreatedAt    string                  `json:"created_at"`
+	UpdatedAt    string                  `json:"updated_at"`
+}
+
+// CustomRoleListResponse 自定义角色列表。
+type CustomRoleListResponse struct {
+	Items []*CustomRoleResponse `json:"items"`
+}
+
+// CustomRoleAssignmentResponse 自定义角色分配。
+type CustomRoleAssignmentResponse struct {
+	RoleID       string `json:"role_id"`
+	OperatorID   string `json:"operator_id"`
+	UserID       string `json:"user_id"`
+	OperatorName string `json:"operator_name"`
+	AssignedBy   string `json:"assigned_by"`
+	AssignedAt   string `json:"assigned_at"`
+}
+
+// CustomRoleAssignmentListResponse 自定义角色分配列表。
+type CustomRoleAssignmentListResponse struct {
+	Items []*CustomRoleAssignmentResponse `json:"items"`
+}
+
+// GrantableCapabilitiesResponse 可由自定义角色授予的能力。
+type GrantableCapabilitiesResponse struct {
+	Capabilities  []string `json:"capabilities"`
+	Sensitivities []string `json:"sensitivities"`
+}
+
+// AuthzTargetResponse 能力判定的目标资源。
+type AuthzTargetResponse struct {
+	ModelCode   string `json:"model_code,omitempty"`
+	PlanID      string `json:"plan_id,omitempty"`
+	Department  string `json:"department,omitempty"`
+	Sensitivity string `json:"sensitivity"`
+}
+
+// AuthzGrantEvaluationResponse 单个授权来源的判定结论。
+type AuthzGrantEvaluationResponse struct {
+	Source   string `json:"source"`
+	RoleCode string `json:"role_code,omitempty"`
+	RoleName string `json:"role_name,omitempty"`
+	Matched  bool   `json:"matched"`
+	Reason   string `json:"reason"`
+}
+
+// AuthzExplanationResponse 能力判定解释。
+type AuthzExplanationResponse struct {
+	UserID      string                          `json:"user_id"`
+	Capability  string                          `json:"capability"`
+	Allowed     bool                            `json:"allowed"`
+	Outcome     string                          `json:"outcome"`
+	Reason      string                          `json:"reason"`
+	Target      AuthzTargetResponse             `json:"target"`
+	IAMRoles    []string                        `json:"iam_roles"`
+	Evaluations []*AuthzGrantEvaluationResponse `json:"evaluations"`
+}
+
+func NewCustomRoleResponse(role *customRoleApp.Role) *CustomRoleResponse {
+	if role == nil {
+		return nil
+	}
+	capabilities := make([]string, 0, len(role.Capabilities))
+	for _, capability := range role.Capabilities {
+		capabilities = append(capabilities, string(capability))
+	}
+	planIDs := make([]string, 0, len(role.Scope.PlanIDs))
+	for _, id := range role.Scope.PlanIDs {
+		planIDs = append(planIDs, strconv.FormatUint(id, 10))
+	}
+	return &CustomRoleResponse{
+		ID:           strconv.FormatUint(role.ID, 10),
+		Code:         role.Code,
+		Name:         role.Name,
+		Description:  role.Description,
+		Capabilities: capabilities,
+		Scope: CustomRoleScopeResponse{
+			ModelCodes:  append([]string{}, role.Scope.ModelCodes...),
+			PlanIDs:     planIDs,
+			Departments: append([]string{}, role.Scope.Departments...),
+		},
+		Sensitivity: string(role.Sensitivity),
+		CreatedBy:   strconv.FormatInt(role.CreatedBy, 10),
+		UpdatedBy:   strconv.FormatInt(role.UpdatedBy, 10),
+		CreatedAt:   FormatDateTimeValue(role.CreatedAt),
+		UpdatedAt:   FormatDateTimeValue(role.UpdatedAt),
+	}
+}
+
+func NewCustomRoleListResponse(roles []customRoleApp.Role) *CustomRoleListResponse {
+	items := make([]*CustomRoleResponse, 0, len(roles))
+	for i := range roles {
+		items = append(items, NewCustomRoleResponse(&roles[i]))
+	}
+	return &CustomRoleListResponse{Items: items}
+}
+
+func NewCustomRoleAssignmentResponse(assignment *customRoleApp.Assignment) *CustomRoleAssignmentResponse {
+	if assignment == nil {
+		return nil
+	}
+	return &CustomRoleAssignmentResponse{
+		RoleID:       strconv.FormatUint(assignment.RoleID, 10),
+		OperatorID:   strconv.FormatUint(assignment.OperatorID, 10),
+		UserID:       strconv.FormatInt(assignment.UserID, 10),
+		OperatorName: assignment.OperatorName,
+		AssignedBy:   strconv.FormatInt(assignment.AssignedBy, 10),
+		AssignedAt:   FormatDateTimeValue(assignment.AssignedAt),
+	}
+}
+
+func NewCustomRoleAssignmentListResponse(assignments []customRoleApp.Assignment) *CustomRoleAssignmentListResponse {
+	items := make([]*CustomRoleAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		items = append(items, NewCustomRoleAssignmentResponse(&assignments[i]))
+	}
+	return &CustomRoleAssignmentListResponse{Items: items}
+}
+
+func NewGrantableCapabilitiesResponse() *GrantableCapabilitiesResponse {
+	capabilities := authzapp.GrantableCapabilities()
+	resp := &GrantableCapabilitiesResponse{
+		Capabilities: make([]string, 0, len(capabilities)),
+		Sensitivities: []string{
+			string(authzapp.SensitivityAggregate),
+			string(authzapp.SensitivityDeidentified),
+			string(authzapp.SensitivityIdentified),
+		},
+	}
+	for _, capability := range capabilities {
+		resp.Capabilities = append(resp.Capabilities, string(capability))
+	}
+	return resp
+}
+
+func NewAuthzExplanationResponse(explanation *customRoleApp.Explanation) *AuthzExplanationResponse {
+	if explanation == nil {
+		return nil
+	}
+	target := AuthzTargetResponse{
+		ModelCode:   explanation.Target.ModelCode,
+		Department:  explanation.Target.Department,
+		Sensitivity: string(explanation.Target.Sensitivity),
+	}
+	if target.Sensitivity == "" {
+		target.Sensitivity = string(authzapp.SensitivityIdentified)
+	}
+	if explanation.Target.PlanID > 0 {
+		target.PlanID = strconv.FormatUint(explanation.Target.PlanID, 10)
+	}
+	evaluations := make([]*AuthzGrantEvaluationResponse, 0, len(explanation.Evaluations))
+	for _, evaluation := range explanation.Evaluations {
+		evaluations = append(evaluations, &AuthzGrantEvaluationResponse{
+			Source:   string(evaluation.Source),
+			RoleCode: evaluation.RoleCode,
+			RoleName: evaluation.RoleName,
+			Matched:  evaluation.Matched,
+			Reason:   evaluation.Reason,
+		})
+	}
+	return &AuthzExplanationResponse{
+		UserID:      strconv.FormatInt(explanation.UserID, 10),
+		Capability:  explanation.Capability,
+		Allowed:     explanation.Allowed,
+		Outcome:     string(explanation.Outcome),
+		Reason:      explanation.Reason,
+		Target:      target,
+		IAMRoles:    append([]string{}, explanation.IAMRoles...),
+		Evaluations: evaluations,
+	}
+}
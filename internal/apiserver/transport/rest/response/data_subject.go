@@ -0,0 +1,130 @@
+package response
+
+import (
+	"strconv"
+
+	subjectRights "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+)
+
+// DataSubjectStepResponse 擦除步骤结果。
+type DataSubjectStepResponse struct {
+	Name        string  `json:"name"`
+	Store       string  `json:"store"`
+	Action      string  `json:"action"`
+	Status      string  `json:"status"`
+	Affected    int64   `json:"affected"`
+	Error       string  `json:"error,omitempty"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// DataSubjectRequestResponse 数据主体请求。
+type DataSubjectRequestResponse struct {
+	ID           string                    `json:"id"`
+	TesteeID     string                    `json:"testee_id"`
+	Kind         string                    `json:"kind"`
+	Mode         string                    `json:"mode,omitempty"`
+	Status       string                    `json:"status"`
+	Reason       string                    `json:"reason"`
+	Steps        []DataSubjectStepResponse `json:"steps,omitempty"`
+	BundleStored bool                      `json:"bundle_stored"`
+	BundleSize   int64                     `json:"bundle_size,omitempty"`
+	Attempts     int                       `json:"attempts"`
+	LastError    string                    `json:"last_error,omitempty"`
+	RequestedBy  string                    `json:"requested_by"`
+	RequestedAt  string                    `json:"requested_at"`
+	CompletedAt  *string                   `json:"completed_at,omitempty"`
+}
+
+// DataSubjectRequestListResponse 数据主体请求列表。
+type DataSubjectRequestListResponse struct {
+	Items      []*DataSubjectRequestResponse `json:"items"`
+	Total      int64                         `json:"total"`
+	Page       int                           `json:"page"`
+	PageSize   int                           `json:"page_size"`
+	TotalPages int                           `json:"total_pages"`
+}
+
+// DataSubjectCertificateResponse 擦除证书。
+type DataSubjectCertificateResponse struct {
+	ID         string                    `json:"id"`
+	RequestID  string                    `json:"request_id"`
+	TesteeID   string                    `json:"testee_id"`
+	SubjectRef string                    `json:"subject_ref"`
+	Mode       string                    `json:"mode"`
+	Steps      []DataSubjectStepResponse `json:"steps"`
+	Digest     string                    `json:"digest"`
+	IssuedBy   string                    `json:"issued_by"`
+	IssuedAt   string                    `json:"issued_at"`
+}
+
+func newDataSubjectSteps(steps []subjectRights.StepResult) []DataSubjectStepResponse {
+	items := make([]DataSubjectStepResponse, 0, len(steps))
+	for _, step := range steps {
+		items = append(items, DataSubjectStepResponse{
+			Name:        string(step.Name),
+			Store:       step.Store,
+			Action:      string(step.Action),
+			Status:      string(step.Status),
+			Affected:    step.Affected,
+			Error:       step.Error,
+			CompletedAt: FormatDateTimePtr(step.CompletedAt),
+		})
+	}
+	return items
+}
+
+// NewDataSubjectRequestResponse 转换数据主体请求。
+func NewDataSubjectRequestResponse(req *subjectRights.Request) *DataSubjectRequestResponse {
+	if req == nil {
+		return nil
+	}
+	item := &DataSubjectRequestResponse{
+		ID:           strconv.FormatUint(req.ID, 10),
+		TesteeID:     strconv.FormatUint(req.TesteeID, 10),
+		Kind:         string(req.Kind),
+		Mode:         string(req.Mode),
+		Status:       string(req.Status),
+		Reason:       req.Reason,
+		BundleStored: req.BundleKey != "",
+		BundleSize:   req.BundleSize,
+		Attempts:     req.Attempts,
+		LastError:    req.LastError,
+		RequestedBy:  strconv.FormatUint(req.RequestedBy, 10),
+		RequestedAt:  FormatDateTimeValue(req.RequestedAt),
+		CompletedAt:  FormatDateTimePtr(req.CompletedAt),
+	}
+	if len(req.Steps) > 0 {
+		item.Steps = newDataSubjectSteps(req.Steps)
+	}
+	return item
+}
+
+// NewDataSubjectRequestListResponse 转换数据主体请求分页。
+func NewDataSubjectRequestListResponse(result *subjectRights.RequestList) *DataSubjectRequestListResponse {
+	items := make([]*DataSubjectRequestResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, NewDataSubjectRequestResponse(&result.Items[i]))
+	}
+	return &DataSubjectRequestListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+// NewDataSubjectCertificateResponse 转换擦除证书。
+func NewDataSubjectCertificateResponse(cert *subjectRights.Certificate) *DataSubjectCertificateResponse {
+	if cert == nil {
+		return nil
+	}
+	return &DataSubjectCertificateResponse{
+		ID:         strconv.FormatUint(cert.ID, 10),
+		RequestID:  strconv.FormatUint(cert.RequestID, 10),
+		TesteeID:   strconv.FormatUint(cert.TesteeID, 10),
+		SubjectRef: cert.SubjectRef,
+		Mode:       string(cert.Mode),
+		Steps:      newDataSubjectSteps(cert.Steps),
+		Digest:     cert.Digest,
+		IssuedBy:   strconv.FormatUint(cert.IssuedBy, 10),
+		IssuedAt:   FormatDateTimeValue(cert.IssuedAt),
+	}
+}
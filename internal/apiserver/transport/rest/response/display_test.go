@@ -5,6 +5,7 @@ import (
 	"time"
 
 	evaluationoperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 )
 
 func TestNewAssessmentResponseAddsLabelsAndFormatsTimes(t *testing.T) {
@@ -41,3 +42,30 @@ func TestNewAssessmentResponseAddsLabelsAndFormatsTimes(t *testing.T) {
 		t.Fatalf("submitted_at = %#v, want %q", resp.SubmittedAt, "2026-04-17 13:25:27")
 	}
 }
+
+func TestRedactTesteeResponseMasksPIIForRestrictedPolicy(t *testing.T) {
+	birthday := "2015-06-01"
+	profileID := "9001"
+	resp := &TesteeResponse{
+		ID:        "12",
+		Name:      "王小明",
+		Birthday:  &birthday,
+		ProfileID: &profileID,
+		Guardians: []GuardianResponse{{Name: "王大明", Relation: "father", Phone: "13812345678"}},
+	}
+
+	RedactTesteeResponse(resp, redaction.Restricted, time.Date(2026, 5, 1, 0, 0, 0, 0, time.Local))
+
+	if resp.ID != "12" || resp.Name != "王*明" || resp.Birthday != nil || resp.AgeBand != "6-11" {
+		t.Fatalf("unexpected redacted testee: %+v", resp)
+	}
+	if resp.ProfileID != nil || len(resp.Guardians) != 0 || !resp.Redacted {
+		t.Fatalf("profile/guardians should be hidden: %+v", resp)
+	}
+
+	full := &TesteeResponse{Name: "王小明", Birthday: &birthday}
+	RedactTesteeResponse(full, redaction.Full, time.Now())
+	if full.Name != "王小明" || full.Birthday == nil || full.Redacted {
+		t.Fatalf("full policy should not redact: %+v", full)
+	}
+}
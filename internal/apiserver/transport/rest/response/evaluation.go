@@ -114,6 +114,20 @@ type ReportResponse struct {
 	Dimensions     []*DimensionItem `json:"dimensions"`                 // 维度解读列表
 	Suggestions    []SuggestionItem `json:"suggestions"`                // 建议列表
 	CreatedAt      string           `json:"created_at"`                 // 创建时间
+	Audience       string           `json:"audience,omitempty"`         // 受众版本：canonical/clinician/family/school
+	Locale         string           `json:"locale,omitempty"`           // 内容语言，如 zh-CN、en-US
+	// Charts 由报告维度派生的图表规格；SVG 见 /report/charts/{chart_id}。
+	Charts []ReportChartResponse `json:"charts,omitempty"`
+	// ClinicianAddendum 从业者签署复核后的补充说明；未签署或无补充说明时省略。
+	ClinicianAddendum *ClinicianAddendumItem `json:"clinician_addendum,omitempty"`
+}
+
+// ClinicianAddendumItem 报告临床补充说明
+type ClinicianAddendumItem struct {
+	Content      string `json:"content"`       // 补充说明
+	ReviewerName string `json:"reviewer_name"` // 复核人
+	ReviewStatus string `json:"review_status"` // reviewed/amended
+	ReviewedAt   string `json:"reviewed_at"`   // 签署或最近修订时间
 }
 
 // DimensionItem 维度解读项
@@ -374,6 +388,23 @@ func NewReportResponse(result *interpretation.Report) *ReportResponse {
 		Dimensions:     dimensions,
 		Suggestions:    toSuggestionItems(result.Suggestions),
 		CreatedAt:      FormatDateTimeValue(result.CreatedAt),
+		Audience:       result.Audience,
+		Locale:         result.Locale,
+		Charts:         newReportChartResponses(result.Charts),
+
+		ClinicianAddendum: newClinicianAddendumItem(result.ClinicianAddendum),
+	}
+}
+
+func newClinicianAddendumItem(addendum *interpretation.ClinicianAddendum) *ClinicianAddendumItem {
+	if addendum == nil {
+		return nil
+	}
+	return &ClinicianAddendumItem{
+		Content:      addendum.Content,
+		ReviewerName: addendum.ReviewerName,
+		ReviewStatus: addendum.ReviewStatus,
+		ReviewedAt:   FormatDateTimeValue(addendum.ReviewedAt),
 	}
 }
 
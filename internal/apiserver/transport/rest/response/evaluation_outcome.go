@@ -73,6 +73,9 @@ type ReportOutcomeResponse struct {
 	Suggestions  []SuggestionItem      `json:"suggestions"`
 	ModelExtra   *ModelExtraResponse   `json:"model_extra,omitempty"`
 	CreatedAt    string                `json:"created_at"`
+	Audience     string                `json:"audience,omitempty"`
+	Locale       string                `json:"locale,omitempty"`
+	Charts       []ReportChartResponse `json:"charts,omitempty"`
 }
 
 // ModelExtraResponse carries typology-specific report extensions.
@@ -177,6 +180,9 @@ func NewReportOutcomeResponse(result *interpretation.Report) *ReportOutcomeRespo
 		Suggestions:  toSuggestionItems(result.Suggestions),
 		ModelExtra:   modelExtra,
 		CreatedAt:    FormatDateTimeValue(result.CreatedAt),
+		Audience:     result.Audience,
+		Locale:       result.Locale,
+		Charts:       newReportChartResponses(result.Charts),
 	}
 }
 
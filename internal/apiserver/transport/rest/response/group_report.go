@@ -0,0 +1,51 @@
+package response
+
+import (
+	"strconv"
+
+	groupReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
+)
+
+// GroupReportResponse 群体报告；content 为生成时冻结并已按最小单元格抑制的内容快照。
+// 冻结输入只返回计数，不暴露单个受试者的测评 ID。
+type GroupReportResponse struct {
+	ID               string                 `json:"id"`
+	CohortKind       string                 `json:"cohort_kind"`
+	CohortRef        string                 `json:"cohort_ref,omitempty"`
+	ModelCode        string                 `json:"model_code"`
+	MinCellSize      int                    `json:"min_cell_size"`
+	InputFingerprint string                 `json:"input_fingerprint"`
+	Input            GroupReportInputCounts `json:"input"`
+	Content          groupReportApp.Content `json:"content"`
+	GeneratedBy      string                 `json:"generated_by"`
+	GeneratedAt      string                 `json:"generated_at"`
+}
+
+// GroupReportInputCounts 群体报告冻结输入的规模。
+type GroupReportInputCounts struct {
+	CohortSize       int `json:"cohort_size"`
+	CurrentOutcomes  int `json:"current_outcomes"`
+	PreviousOutcomes int `json:"previous_outcomes"`
+}
+
+func NewGroupReportResponse(report *groupReportApp.Report) *GroupReportResponse {
+	if report == nil {
+		return nil
+	}
+	return &GroupReportResponse{
+		ID:               strconv.FormatUint(report.ID, 10),
+		CohortKind:       string(report.CohortKind),
+		CohortRef:        report.CohortRef,
+		ModelCode:        report.ModelCode,
+		MinCellSize:      report.MinCellSize,
+		InputFingerprint: report.InputFingerprint,
+		Input: GroupReportInputCounts{
+			CohortSize:       report.Input.CohortSize,
+			CurrentOutcomes:  len(report.Input.Current),
+			PreviousOutcomes: len(report.Input.Previous),
+		},
+		Content:     report.Content,
+		GeneratedBy: strconv.FormatInt(report.GeneratedBy, 10),
+		GeneratedAt: FormatDateTimeValue(report.GeneratedAt),
+	}
+}
@@ -8,6 +8,8 @@ type DefinitionV2Wire struct {
 	Conclusions []DefinitionConclusionWire `json:"Conclusions"`
 	Outcomes    []DefinitionOutcomeWire    `json:"Outcomes"`
 	ReportMap   DefinitionReportMapWire    `json:"ReportMap"`
+	// Translations 是非默认语言的报告文案；基础文案以 zh-CN 撰写，未翻译字段回落到基础文案。
+	Translations []DefinitionTranslationWire `json:"Translations,omitempty"`
 }
 
 type DefinitionMeasureWire struct {
@@ -152,6 +154,37 @@ type DefinitionTypeProfileWire struct {
 	Commentary  string   `json:"Commentary,omitempty"`
 }
 
+type DefinitionTranslationWire struct {
+	Locale     string                            `json:"Locale" example:"en-US"`
+	ModelTitle string                            `json:"ModelTitle,omitempty"`
+	Factors    []DefinitionFactorTitleWire       `json:"Factors,omitempty"`
+	Outcomes   []DefinitionTranslatedOutcomeWire `json:"Outcomes,omitempty"`
+	Profiles   []DefinitionTranslatedProfileWire `json:"Profiles,omitempty"`
+}
+
+type DefinitionFactorTitleWire struct {
+	FactorCode string `json:"FactorCode"`
+	Title      string `json:"Title"`
+}
+
+type DefinitionTranslatedOutcomeWire struct {
+	OutcomeCode string `json:"OutcomeCode"`
+	Title       string `json:"Title,omitempty"`
+	Summary     string `json:"Summary,omitempty"`
+	Description string `json:"Description,omitempty"`
+}
+
+type DefinitionTranslatedProfileWire struct {
+	OutcomeCode string   `json:"OutcomeCode"`
+	Pattern     string   `json:"Pattern,omitempty"`
+	Traits      []string `json:"Traits,omitempty"`
+	Strengths   []string `json:"Strengths,omitempty"`
+	Weaknesses  []string `json:"Weaknesses,omitempty"`
+	Suggestions []string `json:"Suggestions,omitempty"`
+	Trigger     string   `json:"Trigger,omitempty"`
+	Commentary  string   `json:"Commentary,omitempty"`
+}
+
 type DefinitionReportMapWire struct {
 	Sections []DefinitionReportSectionWire `json:"Sections"`
 }
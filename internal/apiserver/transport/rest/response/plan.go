@@ -13,7 +13,7 @@ type PlanResponse struct {
 	OrgID             int64    `json:"org_id"`                        // 机构ID
 	ScaleCode         string   `json:"scale_code"`                    // 量表编码（如 "3adyDE"）
 	ScaleTitle        string   `json:"scale_title,omitempty"`         // 量表标题
-	ScheduleType      string   `json:"schedule_type"`                 // 周期类型：by_week/by_day/fixed_date/custom
+	ScheduleType      string   `json:"schedule_type"`                 // 周期类型：by_week/by_day/fixed_date/custom/rrule
 	ScheduleTypeLabel string   `json:"schedule_type_label,omitempty"` // 周期类型中文
 	TriggerTime       string   `json:"trigger_time"`                  // 触发时间：HH:MM:SS
 	Interval          int      `json:"interval"`                      // 间隔（周/天，用于 by_week/by_day）
@@ -22,6 +22,9 @@ type PlanResponse struct {
 	RelativeWeeks     []int    `json:"relative_weeks,omitempty"`      // 相对周次列表（用于 custom）
 	Status            string   `json:"status"`                        // 状态：active/paused/finished/canceled
 	StatusLabel       string   `json:"status_label,omitempty"`        // 状态中文
+	TimeZone          string   `json:"time_zone,omitempty"`           // IANA 时区
+	RecurrenceRule    string   `json:"recurrence_rule,omitempty"`     // RRULE（用于 rrule）
+	ExceptionDates    []string `json:"exception_dates,omitempty"`     // EXDATE 排除日期（用于 rrule）
 }
 
 // TaskResponse 任务响应
@@ -44,6 +47,7 @@ type TaskResponse struct {
 	AssessmentID     *string `json:"assessment_id,omitempty"`     // 关联的测评ID
 	EntryToken       string  `json:"entry_token,omitempty"`       // 入口令牌
 	EntryURL         string  `json:"entry_url,omitempty"`         // 入口URL
+	TimeZone         string  `json:"time_zone,omitempty"`         // 计划时区，非空时各时间点按该时区展示
 }
 
 // EnrollmentResponse 加入计划响应
@@ -114,6 +118,9 @@ func NewPlanResponse(result *plan.PlanResult) *PlanResponse {
 		RelativeWeeks:     result.RelativeWeeks,
 		Status:            result.Status,
 		StatusLabel:       domainPlan.PlanStatus(result.Status).DisplayName(),
+		TimeZone:          result.TimeZone,
+		RecurrenceRule:    result.RecurrenceRule,
+		ExceptionDates:    result.ExceptionDates,
 	}
 }
 
@@ -142,6 +149,7 @@ func NewTaskResponse(result *plan.TaskResult) *TaskResponse {
 		AssessmentID:     result.AssessmentID,
 		EntryToken:       result.EntryToken,
 		EntryURL:         result.EntryURL,
+		TimeZone:         result.TimeZone,
 	}
 }
 
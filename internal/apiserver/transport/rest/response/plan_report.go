@@ -0,0 +1,43 @@
+package response
+
+import (
+	"strconv"
+
+	planReportApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/planreport"
+)
+
+// PlanReportResponse 纵向计划报告；content 为生成时冻结的内容快照，charts 为由其派生的纵向迷你折线。
+type PlanReportResponse struct {
+	ID               string                    `json:"id"`
+	EnrollmentID     string                    `json:"enrollment_id"`
+	PlanID           string                    `json:"plan_id"`
+	TesteeID         string                    `json:"testee_id"`
+	Round            uint32                    `json:"round"`
+	Trigger          string                    `json:"trigger"`
+	TemplateVersion  string                    `json:"template_version"`
+	InputFingerprint string                    `json:"input_fingerprint"`
+	Input            planReportApp.FrozenInput `json:"input"`
+	Content          planReportApp.Content     `json:"content"`
+	Charts           []ReportChartResponse     `json:"charts,omitempty"`
+	GeneratedAt      string                    `json:"generated_at"`
+}
+
+func NewPlanReportResponse(report *planReportApp.Report) *PlanReportResponse {
+	if report == nil {
+		return nil
+	}
+	return &PlanReportResponse{
+		ID:               strconv.FormatUint(report.ID, 10),
+		EnrollmentID:     strconv.FormatUint(report.EnrollmentID, 10),
+		PlanID:           strconv.FormatUint(report.PlanID, 10),
+		TesteeID:         strconv.FormatUint(report.TesteeID, 10),
+		Round:            report.Round,
+		Trigger:          string(report.Trigger),
+		TemplateVersion:  report.TemplateVersion,
+		InputFingerprint: report.InputFingerprint,
+		Input:            report.Input,
+		Content:          report.Content,
+		Charts:           newReportChartResponses(report.Content.Charts()),
+		GeneratedAt:      FormatDateTimeValue(report.GeneratedAt),
+	}
+}
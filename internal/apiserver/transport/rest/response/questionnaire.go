@@ -81,6 +81,17 @@ func NewQuestionnaireResponseFromResult(result *questionnaire.QuestionnaireResul
 			}
 		}
 
+		// 转换 critical_rule
+		var criticalRule *viewmodel.CriticalRuleDTO
+		if q.CriticalRule != nil {
+			criticalRule = &viewmodel.CriticalRuleDTO{
+				Category:    q.CriticalRule.Category,
+				OptionCodes: append([]string(nil), q.CriticalRule.OptionCodes...),
+				MinValue:    q.CriticalRule.MinValue,
+				Keywords:    append([]string(nil), q.CriticalRule.Keywords...),
+			}
+		}
+
 		questions = append(questions, viewmodel.QuestionDTO{
 			Code:           q.Code,
 			Stem:           q.Stem,
@@ -88,6 +99,7 @@ func NewQuestionnaireResponseFromResult(result *questionnaire.QuestionnaireResul
 			Tips:           q.Description,
 			Options:        options,
 			ShowController: showController,
+			CriticalRule:   criticalRule,
 		})
 	}
 
@@ -0,0 +1,53 @@
+package response
+
+import "github.com/FangcunMount/qs-server/internal/pkg/reportchart"
+
+// ReportChartResponse 报告图表规格：客户端按它绘图，或按 id 取服务端渲染的 SVG。
+type ReportChartResponse struct {
+	ID        string                     `json:"id"`                  // 图表 ID，报告内唯一
+	Kind      string                     `json:"kind"`                // profile/radar/pole_bars/sparkline
+	Title     string                     `json:"title"`               // 图表标题
+	Min       float64                    `json:"min"`                 // 数值轴下限
+	Max       float64                    `json:"max"`                 // 数值轴上限；点有 max 时该点按自身满分归一
+	Reference *float64                   `json:"reference,omitempty"` // 参考线，如常模均值、两极分界或基线得分
+	Bands     []ReportChartBandResponse  `json:"bands,omitempty"`     // 数值轴区间带，如 T 分常模区间
+	Points    []ReportChartPointResponse `json:"points"`              // 数据点
+}
+
+// ReportChartBandResponse 数值轴上 [from, to) 的区间带。
+type ReportChartBandResponse struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Label string  `json:"label"`
+	Tone  string  `json:"tone"` // low/normal/elevated/high
+}
+
+// ReportChartPointResponse 图表数据点。
+type ReportChartPointResponse struct {
+	Code  string   `json:"code,omitempty"`
+	Label string   `json:"label"`
+	Value float64  `json:"value"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+func newReportChartResponses(specs []reportchart.Spec) []ReportChartResponse {
+	if len(specs) == 0 {
+		return nil
+	}
+	out := make([]ReportChartResponse, 0, len(specs))
+	for _, spec := range specs {
+		chart := ReportChartResponse{
+			ID: spec.ID, Kind: string(spec.Kind), Title: spec.Title,
+			Min: spec.Min, Max: spec.Max, Reference: spec.Reference,
+			Points: make([]ReportChartPointResponse, 0, len(spec.Points)),
+		}
+		for _, band := range spec.Bands {
+			chart.Bands = append(chart.Bands, ReportChartBandResponse{From: band.From, To: band.To, Label: band.Label, Tone: string(band.Tone)})
+		}
+		for _, point := range spec.Points {
+			chart.Points = append(chart.Points, ReportChartPointResponse{Code: point.Code, Label: point.Label, Value: point.Value, Max: point.Max})
+		}
+		out = append(out, chart)
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"net/url"
+
+	reportPDFApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
+)
+
+// ReportPDFDownloadPath 报告 PDF 公开下载路径前缀，令牌即凭证。
+const ReportPDFDownloadPath = "/api/v1/public/report-pdfs/"
+
+// ReportPDFLinkResponse 报告 PDF 签名下载链接。
+type ReportPDFLinkResponse struct {
+	DownloadURL string `json:"download_url"`
+	ExpiresAt   string `json:"expires_at"`
+	FileName    string `json:"file_name"`
+	ContentHash string `json:"content_hash"`
+}
+
+func NewReportPDFLinkResponse(link *reportPDFApp.Link) *ReportPDFLinkResponse {
+	if link == nil {
+		return nil
+	}
+	return &ReportPDFLinkResponse{
+		DownloadURL: ReportPDFDownloadPath + url.PathEscape(link.Token),
+		ExpiresAt:   FormatDateTimeValue(link.ExpiresAt),
+		FileName:    link.FileName,
+		ContentHash: link.ContentHash,
+	}
+}
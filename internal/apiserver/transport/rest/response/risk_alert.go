@@ -0,0 +1,198 @@
+package response
+
+import (
+	"strconv"
+	"time"
+
+	riskAlertApp "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+)
+
+// RiskAlertConditionResponse 预警规则条件。
+type RiskAlertConditionResponse struct {
+	QuestionCode string   `json:"question_code,omitempty"`
+	FactorCode   string   `json:"factor_code,omitempty"`
+	ScoreKind    string   `json:"score_kind,omitempty"`
+	Operator     string   `json:"operator,omitempty"`
+	Threshold    float64  `json:"threshold"`
+	Levels       []string `json:"levels,omitempty"`
+}
+
+// RiskAlertRuleResponse 风险预警规则。
+type RiskAlertRuleResponse struct {
+	ID                 string                     `json:"id"`
+	Name               string                     `json:"name"`
+	Kind               string                     `json:"kind"`
+	ModelCode          string                     `json:"model_code"`
+	Condition          RiskAlertConditionResponse `json:"condition"`
+	Severity           string                     `json:"severity"`
+	PageChannel        string                     `json:"page_channel"`
+	EscalationChannels []string                   `json:"escalation_channels"`
+	AckTimeoutSeconds  int                        `json:"ack_timeout_seconds"`
+	Enabled            bool                       `json:"enabled"`
+	CreatedBy          string                     `json:"created_by"`
+	UpdatedBy          string                     `json:"updated_by"`
+	CreatedAt          string                     `json:"created_at"`
+	UpdatedAt          string                     `json:"updated_at"`
+}
+
+// RiskAlertRuleListResponse 风险预警规则列表。
+type RiskAlertRuleListResponse struct {
+	Items []*RiskAlertRuleResponse `json:"items"`
+}
+
+// RiskAlertActorResponse 预警操作者。
+type RiskAlertActorResponse struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name,omitempty"`
+}
+
+// RiskAlertResponse 风险预警；rule_name 等为触发时的规则快照。
+type RiskAlertResponse struct {
+	ID                 string                  `json:"id"`
+	RuleID             string                  `json:"rule_id"`
+	RuleName           string                  `json:"rule_name"`
+	RuleKind           string                  `json:"rule_kind"`
+	Severity           string                  `json:"severity"`
+	AssessmentID       string                  `json:"assessment_id"`
+	TesteeID           string                  `json:"testee_id"`
+	ModelCode          string                  `json:"model_code,omitempty"`
+	Status             string                  `json:"status"`
+	Channel            string                  `json:"channel"`
+	EscalationLevel    int                     `json:"escalation_level"`
+	EscalationChannels []string                `json:"escalation_channels"`
+	Summary            string                  `json:"summary"`
+	ObservedValue      float64                 `json:"observed_value"`
+	BaselineValue      *float64                `json:"baseline_value,omitempty"`
+	TriggeredAt        string                  `json:"triggered_at"`
+	NextEscalationAt   *string                 `json:"next_escalation_at,omitempty"`
+	AcknowledgedBy     *RiskAlertActorResponse `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt     *string                 `json:"acknowledged_at,omitempty"`
+	AckNote            string                  `json:"ack_note,omitempty"`
+}
+
+// RiskAlertListResponse 风险预警分页列表。
+type RiskAlertListResponse struct {
+	Items    []*RiskAlertResponse `json:"items"`
+	Total    int64                `json:"total"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"page_size"`
+}
+
+// RiskAlertEventResponse 预警历史；operator 为空表示系统动作（触发、升级）。
+type RiskAlertEventResponse struct {
+	ID              string                  `json:"id"`
+	Action          string                  `json:"action"`
+	Channel         string                  `json:"channel"`
+	EscalationLevel int                     `json:"escalation_level"`
+	Operator        *RiskAlertActorResponse `json:"operator,omitempty"`
+	Note            string                  `json:"note,omitempty"`
+	OccurredAt      string                  `json:"occurred_at"`
+}
+
+// RiskAlertDetailResponse 风险预警及其完整历史（按时间正序）。
+type RiskAlertDetailResponse struct {
+	RiskAlertResponse
+	Events []RiskAlertEventResponse `json:"events"`
+}
+
+func NewRiskAlertRuleResponse(rule *riskAlertApp.Rule) *RiskAlertRuleResponse {
+	if rule == nil {
+		return nil
+	}
+	return &RiskAlertRuleResponse{
+		ID:        strconv.FormatUint(rule.ID, 10),
+		Name:      rule.Name,
+		Kind:      string(rule.Kind),
+		ModelCode: rule.ModelCode,
+		Condition: RiskAlertConditionResponse{
+			QuestionCode: rule.Condition.QuestionCode,
+			FactorCode:   rule.Condition.FactorCode,
+			ScoreKind:    string(rule.Condition.ScoreKind),
+			Operator:     string(rule.Condition.Operator),
+			Threshold:    rule.Condition.Threshold,
+			Levels:       append([]string(nil), rule.Condition.Levels...),
+		},
+		Severity:           string(rule.Severity),
+		PageChannel:        rule.PageChannel,
+		EscalationChannels: append([]string{}, rule.EscalationChannels...),
+		AckTimeoutSeconds:  int(rule.AckTimeout / time.Second),
+		Enabled:            rule.Enabled,
+		CreatedBy:          strconv.FormatInt(rule.CreatedBy, 10),
+		UpdatedBy:          strconv.FormatInt(rule.UpdatedBy, 10),
+		CreatedAt:          FormatDateTimeValue(rule.CreatedAt),
+		UpdatedAt:          FormatDateTimeValue(rule.UpdatedAt),
+	}
+}
+
+func NewRiskAlertRuleListResponse(rules []riskAlertApp.Rule) *RiskAlertRuleListResponse {
+	items := make([]*RiskAlertRuleResponse, 0, len(rules))
+	for i := range rules {
+		items = append(items, NewRiskAlertRuleResponse(&rules[i]))
+	}
+	return &RiskAlertRuleListResponse{Items: items}
+}
+
+func NewRiskAlertResponse(alert *riskAlertApp.Alert) *RiskAlertResponse {
+	if alert == nil {
+		return nil
+	}
+	return &RiskAlertResponse{
+		ID:                 strconv.FormatUint(alert.ID, 10),
+		RuleID:             strconv.FormatUint(alert.RuleID, 10),
+		RuleName:           alert.RuleName,
+		RuleKind:           string(alert.RuleKind),
+		Severity:           string(alert.Severity),
+		AssessmentID:       strconv.FormatUint(alert.AssessmentID, 10),
+		TesteeID:           strconv.FormatUint(alert.TesteeID, 10),
+		ModelCode:          alert.ModelCode,
+		Status:             string(alert.Status),
+		Channel:            alert.Channel,
+		EscalationLevel:    alert.EscalationLevel,
+		EscalationChannels: append([]string{}, alert.EscalationChannels...),
+		Summary:            alert.Summary,
+		ObservedValue:      alert.ObservedValue,
+		BaselineValue:      alert.BaselineValue,
+		TriggeredAt:        FormatDateTimeValue(alert.TriggeredAt),
+		NextEscalationAt:   FormatDateTimePtr(alert.NextEscalationAt),
+		AcknowledgedBy:     newRiskAlertActorResponse(alert.AcknowledgedBy),
+		AcknowledgedAt:     FormatDateTimePtr(alert.AcknowledgedAt),
+		AckNote:            alert.AckNote,
+	}
+}
+
+func NewRiskAlertListResponse(page *riskAlertApp.AlertPage) *RiskAlertListResponse {
+	if page == nil {
+		return &RiskAlertListResponse{Items: []*RiskAlertResponse{}}
+	}
+	items := make([]*RiskAlertResponse, 0, len(page.Items))
+	for i := range page.Items {
+		items = append(items, NewRiskAlertResponse(&page.Items[i]))
+	}
+	return &RiskAlertListResponse{Items: items, Total: page.Total, Page: page.Page, PageSize: page.PageSize}
+}
+
+func NewRiskAlertDetailResponse(view *riskAlertApp.AlertView) *RiskAlertDetailResponse {
+	if view == nil {
+		return nil
+	}
+	events := make([]RiskAlertEventResponse, 0, len(view.Events))
+	for _, event := range view.Events {
+		events = append(events, RiskAlertEventResponse{
+			ID:              strconv.FormatUint(event.ID, 10),
+			Action:          string(event.Action),
+			Channel:         event.Channel,
+			EscalationLevel: event.EscalationLevel,
+			Operator:        newRiskAlertActorResponse(event.Operator),
+			Note:            event.Note,
+			OccurredAt:      FormatDateTimeValue(event.OccurredAt),
+		})
+	}
+	return &RiskAlertDetailResponse{RiskAlertResponse: *NewRiskAlertResponse(&view.Alert), Events: events}
+}
+
+func newRiskAlertActorResponse(actor *riskAlertApp.Actor) *RiskAlertActorResponse {
+	if actor == nil {
+		return nil
+	}
+	return &RiskAlertActorResponse{UserID: strconv.FormatInt(actor.UserID, 10), Name: actor.Name}
+}
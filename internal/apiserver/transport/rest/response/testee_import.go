@@ -0,0 +1,156 @@
+package response
+
+import (
+	"strconv"
+
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+)
+
+// TesteeImportCountsResponse 导入行按结果汇总的计数。
+type TesteeImportCountsResponse struct {
+	Pending   int64 `json:"pending"`
+	Invalid   int64 `json:"invalid"`
+	Duplicate int64 `json:"duplicate"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	Created   int64 `json:"created"`
+	Matched   int64 `json:"matched"`
+	Enrolled  int64 `json:"enrolled"`
+}
+
+// TesteeImportJobResponse 受试者批量导入任务响应。
+type TesteeImportJobResponse struct {
+	ID            string                     `json:"id"`
+	OrgID         string                     `json:"org_id"`
+	FileName      string                     `json:"file_name"`
+	FileFormat    string                     `json:"file_format"`
+	ClinicianID   string                     `json:"clinician_id"`
+	RelationType  string                     `json:"relation_type"`
+	PlanID        *string                    `json:"plan_id,omitempty"`
+	PlanStartDate string                     `json:"plan_start_date,omitempty"`
+	Status        string                     `json:"status"`
+	TotalRows     int                        `json:"total_rows"`
+	Counts        TesteeImportCountsResponse `json:"counts"`
+	CreatedBy     string                     `json:"created_by"`
+	LastError     string                     `json:"last_error,omitempty"`
+	CreatedAt     string                     `json:"created_at"`
+	StartedAt     *string                    `json:"started_at,omitempty"`
+	FinishedAt    *string                    `json:"finished_at,omitempty"`
+}
+
+// TesteeImportJobListResponse 受试者批量导入任务列表响应。
+type TesteeImportJobListResponse struct {
+	Items      []*TesteeImportJobResponse `json:"items"`
+	Total      int64                      `json:"total"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// TesteeImportRowResponse 单行导入结果。
+type TesteeImportRowResponse struct {
+	RowNo        int     `json:"row_no"`
+	Name         string  `json:"name"`
+	Gender       int8    `json:"gender"`
+	Birthday     *string `json:"birthday,omitempty"`
+	ProfileID    *string `json:"profile_id,omitempty"`
+	Status       string  `json:"status"`
+	TesteeID     *string `json:"testee_id,omitempty"`
+	TesteeAction string  `json:"testee_action,omitempty"`
+	RelationID   *string `json:"relation_id,omitempty"`
+	EnrollmentID *string `json:"enrollment_id,omitempty"`
+	Attempts     int     `json:"attempts"`
+	Error        string  `json:"error,omitempty"`
+	ProcessedAt  *string `json:"processed_at,omitempty"`
+}
+
+// TesteeImportRowListResponse 导入行列表响应。
+type TesteeImportRowListResponse struct {
+	Items      []*TesteeImportRowResponse `json:"items"`
+	Total      int64                      `json:"total"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// NewTesteeImportJobResponse 转换导入任务视图。
+func NewTesteeImportJobResponse(result *testeeImport.JobResult) *TesteeImportJobResponse {
+	if result == nil {
+		return nil
+	}
+	job := result.Job
+	return &TesteeImportJobResponse{
+		ID:            strconv.FormatUint(job.ID, 10),
+		OrgID:         strconv.FormatInt(job.OrgID, 10),
+		FileName:      job.FileName,
+		FileFormat:    string(job.FileFormat),
+		ClinicianID:   strconv.FormatUint(job.ClinicianID, 10),
+		RelationType:  job.RelationType,
+		PlanID:        optionalIDString(job.PlanID),
+		PlanStartDate: job.PlanStartDate,
+		Status:        string(job.Status),
+		TotalRows:     job.TotalRows,
+		Counts:        TesteeImportCountsResponse(result.Counts),
+		CreatedBy:     strconv.FormatUint(job.CreatedBy, 10),
+		LastError:     job.LastError,
+		CreatedAt:     FormatDateTimeValue(job.CreatedAt),
+		StartedAt:     FormatDateTimePtr(job.StartedAt),
+		FinishedAt:    FormatDateTimePtr(job.FinishedAt),
+	}
+}
+
+// NewTesteeImportJobListResponse 转换导入任务列表。
+func NewTesteeImportJobListResponse(result *testeeImport.JobListResult) *TesteeImportJobListResponse {
+	items := make([]*TesteeImportJobResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, NewTesteeImportJobResponse(&result.Items[i]))
+	}
+	return &TesteeImportJobListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+// NewTesteeImportRowListResponse 转换导入行列表。
+func NewTesteeImportRowListResponse(result *testeeImport.RowListResult) *TesteeImportRowListResponse {
+	items := make([]*TesteeImportRowResponse, 0, len(result.Items))
+	for _, row := range result.Items {
+		item := &TesteeImportRowResponse{
+			RowNo:        row.RowNo,
+			Name:         row.Name,
+			Gender:       row.Gender,
+			Birthday:     FormatDatePtr(row.Birthday),
+			Status:       string(row.Status),
+			TesteeID:     optionalIDString(row.TesteeID),
+			TesteeAction: string(row.TesteeAction),
+			RelationID:   optionalIDString(row.RelationID),
+			EnrollmentID: optionalIDString(row.EnrollmentID),
+			Attempts:     row.Attempts,
+			Error:        row.Error,
+			ProcessedAt:  FormatDateTimePtr(row.ProcessedAt),
+		}
+		if row.ProfileID != nil {
+			item.ProfileID = optionalIDString(*row.ProfileID)
+		}
+		items = append(items, item)
+	}
+	return &TesteeImportRowListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+func optionalIDString(id uint64) *string {
+	if id == 0 {
+		return nil
+	}
+	value := strconv.FormatUint(id, 10)
+	return &value
+}
+
+func importTotalPages(total int64, pageSize int) int {
+	if pageSize <= 0 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
@@ -0,0 +1,174 @@
+package response
+
+import (
+	"strconv"
+
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
+)
+
+// TesteeMergeTesteeResponse 判重候选中的受试者摘要。
+type TesteeMergeTesteeResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Gender    int8    `json:"gender"`
+	Birthday  *string `json:"birthday,omitempty"`
+	ProfileID *string `json:"profile_id,omitempty"`
+	Source    string  `json:"source"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// TesteeMergeConflictResponse 阻止合并的冲突。
+type TesteeMergeConflictResponse struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+// TesteeDuplicateCandidateResponse 重复受试者候选。
+type TesteeDuplicateCandidateResponse struct {
+	Testees             []TesteeMergeTesteeResponse   `json:"testees"`
+	Reasons             []string                      `json:"reasons"`
+	Score               int                           `json:"score"`
+	SuggestedSurvivorID string                        `json:"suggested_survivor_id"`
+	Conflicts           []TesteeMergeConflictResponse `json:"conflicts,omitempty"`
+}
+
+// TesteeDuplicateCandidateListResponse 重复受试者候选列表。
+type TesteeDuplicateCandidateListResponse struct {
+	Items      []*TesteeDuplicateCandidateResponse `json:"items"`
+	Total      int64                               `json:"total"`
+	Page       int                                 `json:"page"`
+	PageSize   int                                 `json:"page_size"`
+	TotalPages int                                 `json:"total_pages"`
+}
+
+// TesteeMergeMovedResponse 按存储与表汇总的迁移记录数。
+type TesteeMergeMovedResponse struct {
+	Store string `json:"store"`
+	Table string `json:"table"`
+	Count int    `json:"count"`
+}
+
+// TesteeMergeResponse 受试者合并记录。
+type TesteeMergeResponse struct {
+	ID                   string                     `json:"id"`
+	SurvivorID           string                     `json:"survivor_id"`
+	DuplicateID          string                     `json:"duplicate_id"`
+	Status               string                     `json:"status"`
+	MatchReasons         []string                   `json:"match_reasons"`
+	Reason               string                     `json:"reason"`
+	Moved                []TesteeMergeMovedResponse `json:"moved"`
+	SkippedRelationIDs   []string                   `json:"skipped_relation_ids,omitempty"`
+	TransferredProfileID *string                    `json:"transferred_profile_id,omitempty"`
+	MergedBy             string                     `json:"merged_by"`
+	MergedAt             string                     `json:"merged_at"`
+	RevertedBy           *string                    `json:"reverted_by,omitempty"`
+	RevertedAt           *string                    `json:"reverted_at,omitempty"`
+	RevertReason         string                     `json:"revert_reason,omitempty"`
+}
+
+// TesteeMergeListResponse 受试者合并记录列表。
+type TesteeMergeListResponse struct {
+	Items      []*TesteeMergeResponse `json:"items"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+// NewTesteeDuplicateCandidateResponse 转换重复候选。
+func NewTesteeDuplicateCandidateResponse(candidate testeeMerge.Candidate) *TesteeDuplicateCandidateResponse {
+	item := &TesteeDuplicateCandidateResponse{
+		Testees:             make([]TesteeMergeTesteeResponse, 0, len(candidate.Testees)),
+		Reasons:             make([]string, 0, len(candidate.Reasons)),
+		Score:               candidate.Score,
+		SuggestedSurvivorID: strconv.FormatUint(candidate.SuggestedSurvivorID, 10),
+	}
+	for _, snapshot := range candidate.Testees {
+		testee := TesteeMergeTesteeResponse{
+			ID:        strconv.FormatUint(snapshot.ID, 10),
+			Name:      snapshot.Name,
+			Gender:    snapshot.Gender,
+			Birthday:  FormatDatePtr(snapshot.Birthday),
+			Source:    snapshot.Source,
+			CreatedAt: FormatDateTimeValue(snapshot.CreatedAt),
+		}
+		if snapshot.ProfileID != nil {
+			testee.ProfileID = optionalIDString(*snapshot.ProfileID)
+		}
+		item.Testees = append(item.Testees, testee)
+	}
+	for _, reason := range candidate.Reasons {
+		item.Reasons = append(item.Reasons, string(reason))
+	}
+	for _, conflict := range candidate.Conflicts {
+		item.Conflicts = append(item.Conflicts, TesteeMergeConflictResponse{Kind: string(conflict.Kind), Detail: conflict.Detail})
+	}
+	return item
+}
+
+// NewTesteeDuplicateCandidatesResponse 转换单个受试者的重复候选（不分页）。
+func NewTesteeDuplicateCandidatesResponse(candidates []testeeMerge.Candidate) *TesteeDuplicateCandidateListResponse {
+	items := make([]*TesteeDuplicateCandidateResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		items = append(items, NewTesteeDuplicateCandidateResponse(candidate))
+	}
+	return &TesteeDuplicateCandidateListResponse{Items: items, Total: int64(len(items)), Page: 1, PageSize: len(items), TotalPages: 1}
+}
+
+// NewTesteeDuplicateCandidateListResponse 转换机构内重复候选分页。
+func NewTesteeDuplicateCandidateListResponse(result *testeeMerge.CandidateList) *TesteeDuplicateCandidateListResponse {
+	items := make([]*TesteeDuplicateCandidateResponse, 0, len(result.Items))
+	for _, candidate := range result.Items {
+		items = append(items, NewTesteeDuplicateCandidateResponse(candidate))
+	}
+	return &TesteeDuplicateCandidateListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
+
+// NewTesteeMergeResponse 转换合并记录。
+func NewTesteeMergeResponse(log *testeeMerge.MergeLog) *TesteeMergeResponse {
+	if log == nil {
+		return nil
+	}
+	item := &TesteeMergeResponse{
+		ID:           strconv.FormatUint(log.ID, 10),
+		SurvivorID:   strconv.FormatUint(log.SurvivorID, 10),
+		DuplicateID:  strconv.FormatUint(log.DuplicateID, 10),
+		Status:       string(log.Status),
+		MatchReasons: make([]string, 0, len(log.MatchReasons)),
+		Reason:       log.Reason,
+		Moved:        make([]TesteeMergeMovedResponse, 0, len(log.Moved)),
+		MergedBy:     strconv.FormatUint(log.MergedBy, 10),
+		MergedAt:     FormatDateTimeValue(log.MergedAt),
+		RevertedBy:   optionalIDString(log.RevertedBy),
+		RevertedAt:   FormatDateTimePtr(log.RevertedAt),
+		RevertReason: log.RevertReason,
+	}
+	for _, reason := range log.MatchReasons {
+		item.MatchReasons = append(item.MatchReasons, string(reason))
+	}
+	for _, moved := range log.Moved {
+		item.Moved = append(item.Moved, TesteeMergeMovedResponse{Store: moved.Store, Table: moved.Table, Count: len(moved.IDs)})
+	}
+	for _, id := range log.SkippedRelationIDs {
+		item.SkippedRelationIDs = append(item.SkippedRelationIDs, strconv.FormatUint(id, 10))
+	}
+	if log.TransferredProfile != nil {
+		item.TransferredProfileID = optionalIDString(*log.TransferredProfile)
+	}
+	return item
+}
+
+// NewTesteeMergeListResponse 转换合并记录分页。
+func NewTesteeMergeListResponse(result *testeeMerge.MergeLogList) *TesteeMergeListResponse {
+	items := make([]*TesteeMergeResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, NewTesteeMergeResponse(&result.Items[i]))
+	}
+	return &TesteeMergeListResponse{
+		Items: items, Total: result.Total, Page: result.Page, PageSize: result.PageSize,
+		TotalPages: importTotalPages(result.Total, result.PageSize),
+	}
+}
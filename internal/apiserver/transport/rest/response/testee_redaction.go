@@ -0,0 +1,38 @@
+package response
+
+import (
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
+)
+
+// RedactTesteeResponse 按脱敏策略就地改写受试者响应。
+// 受试者ID是系统内部代理键，列表与详情页依赖它跳转，这里不做假名化；假名化只用于导出文件。
+func RedactTesteeResponse(item *TesteeResponse, policy redaction.Policy, now time.Time) {
+	if item == nil || policy.IsFull() {
+		return
+	}
+	item.Name = policy.RedactName(item.Name)
+	if item.Birthday != nil {
+		if birthday, err := time.ParseInLocation(dateLayout, *item.Birthday, time.Local); err == nil {
+			item.AgeBand = policy.AgeBandOf(&birthday, now)
+			item.Birthday = FormatDatePtr(policy.RedactBirthday(&birthday))
+		} else {
+			item.Birthday = nil
+		}
+	}
+	if policy.RedactProfileID(new(uint64)) == nil {
+		item.ProfileID = nil
+	}
+	guardians := make([]GuardianResponse, 0, len(item.Guardians))
+	for _, guardian := range item.Guardians {
+		name := policy.RedactContactName(guardian.Name)
+		phone := policy.RedactPhone(guardian.Phone)
+		if name == "" && phone == "" {
+			continue
+		}
+		guardians = append(guardians, GuardianResponse{Name: name, Relation: guardian.Relation, Phone: phone})
+	}
+	item.Guardians = guardians
+	item.Redacted = true
+}
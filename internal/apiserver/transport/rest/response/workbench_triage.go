@@ -0,0 +1,129 @@
+package response
+
+import (
+	"fmt"
+
+	workbenchApp "github.com/FangcunMount/qs-server/internal/apiserver/application/workbench"
+)
+
+// ClinicianWorkbenchTriageStateResponse 队列条目的分诊状态摘要。
+type ClinicianWorkbenchTriageStateResponse struct {
+	Status         string                                 `json:"status"`
+	ClaimedBy      *ClinicianWorkbenchTriageActorResponse `json:"claimed_by,omitempty"`
+	ClaimedAt      *string                                `json:"claimed_at,omitempty"`
+	SnoozedUntil   *string                                `json:"snoozed_until,omitempty"`
+	ResolutionCode string                                 `json:"resolution_code,omitempty"`
+	EscalatedAt    *string                                `json:"escalated_at,omitempty"`
+	Version        int                                    `json:"version"`
+	// SLADueAt 高风险条目的认领截止时间。
+	SLADueAt    *string `json:"sla_due_at,omitempty"`
+	SLABreached bool    `json:"sla_breached"`
+}
+
+// ClinicianWorkbenchTriageActorResponse 分诊操作人；clinician_id 为空表示机构管理员未绑定从业者。
+type ClinicianWorkbenchTriageActorResponse struct {
+	UserID      string  `json:"user_id"`
+	ClinicianID *string `json:"clinician_id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+}
+
+// ClinicianWorkbenchTriageItemResponse 队列条目的当前分诊状态与完整历史（按时间正序）。
+type ClinicianWorkbenchTriageItemResponse struct {
+	QueueType      string                                  `json:"queue_type"`
+	SubjectID      string                                  `json:"subject_id"`
+	TesteeID       string                                  `json:"testee_id"`
+	Status         string                                  `json:"status"`
+	ClaimedBy      *ClinicianWorkbenchTriageActorResponse  `json:"claimed_by,omitempty"`
+	ClaimedAt      *string                                 `json:"claimed_at,omitempty"`
+	SnoozedUntil   *string                                 `json:"snoozed_until,omitempty"`
+	ResolutionCode string                                  `json:"resolution_code,omitempty"`
+	ResolutionNote string                                  `json:"resolution_note,omitempty"`
+	ResolvedBy     *ClinicianWorkbenchTriageActorResponse  `json:"resolved_by,omitempty"`
+	ResolvedAt     *string                                 `json:"resolved_at,omitempty"`
+	EscalatedAt    *string                                 `json:"escalated_at,omitempty"`
+	Version        int                                     `json:"version"`
+	Events         []ClinicianWorkbenchTriageEventResponse `json:"events"`
+}
+
+// ClinicianWorkbenchTriageEventResponse 分诊历史；operator 为空表示系统动作（超时升级）。
+type ClinicianWorkbenchTriageEventResponse struct {
+	ID           string                                 `json:"id"`
+	Action       string                                 `json:"action"`
+	FromStatus   string                                 `json:"from_status"`
+	ToStatus     string                                 `json:"to_status"`
+	Operator     *ClinicianWorkbenchTriageActorResponse `json:"operator,omitempty"`
+	ReasonCode   string                                 `json:"reason_code,omitempty"`
+	Note         string                                 `json:"note,omitempty"`
+	SnoozedUntil *string                                `json:"snoozed_until,omitempty"`
+	OccurredAt   string                                 `json:"occurred_at"`
+}
+
+func NewClinicianWorkbenchTriageItemResponse(view *workbenchApp.TriageItemView) *ClinicianWorkbenchTriageItemResponse {
+	if view == nil {
+		return nil
+	}
+	item := view.Item
+	events := make([]ClinicianWorkbenchTriageEventResponse, 0, len(view.Events))
+	for _, event := range view.Events {
+		events = append(events, ClinicianWorkbenchTriageEventResponse{
+			ID:           fmt.Sprintf("%d", event.ID),
+			Action:       string(event.Action),
+			FromStatus:   string(event.FromStatus),
+			ToStatus:     string(event.ToStatus),
+			Operator:     newClinicianWorkbenchTriageActorResponse(event.Operator),
+			ReasonCode:   event.ReasonCode,
+			Note:         event.Note,
+			SnoozedUntil: FormatDateTimePtr(event.SnoozedUntil),
+			OccurredAt:   FormatDateTimeValue(event.OccurredAt),
+		})
+	}
+	return &ClinicianWorkbenchTriageItemResponse{
+		QueueType:      string(item.QueueType),
+		SubjectID:      fmt.Sprintf("%d", item.SubjectID),
+		TesteeID:       fmt.Sprintf("%d", item.TesteeID),
+		Status:         string(item.Status),
+		ClaimedBy:      newClinicianWorkbenchTriageActorResponse(item.ClaimedBy),
+		ClaimedAt:      FormatDateTimePtr(item.ClaimedAt),
+		SnoozedUntil:   FormatDateTimePtr(item.SnoozedUntil),
+		ResolutionCode: string(item.ResolutionCode),
+		ResolutionNote: item.ResolutionNote,
+		ResolvedBy:     newClinicianWorkbenchTriageActorResponse(item.ResolvedBy),
+		ResolvedAt:     FormatDateTimePtr(item.ResolvedAt),
+		EscalatedAt:    FormatDateTimePtr(item.EscalatedAt),
+		Version:        item.Version,
+		Events:         events,
+	}
+}
+
+func newClinicianWorkbenchTriageStateResponse(state *workbenchApp.TriageState) *ClinicianWorkbenchTriageStateResponse {
+	if state == nil {
+		return nil
+	}
+	return &ClinicianWorkbenchTriageStateResponse{
+		Status:         string(state.Status),
+		ClaimedBy:      newClinicianWorkbenchTriageActorResponse(state.ClaimedBy),
+		ClaimedAt:      FormatDateTimePtr(state.ClaimedAt),
+		SnoozedUntil:   FormatDateTimePtr(state.SnoozedUntil),
+		ResolutionCode: string(state.ResolutionCode),
+		EscalatedAt:    FormatDateTimePtr(state.EscalatedAt),
+		Version:        state.Version,
+		SLADueAt:       FormatDateTimePtr(state.SLADueAt),
+		SLABreached:    state.SLABreached,
+	}
+}
+
+func newClinicianWorkbenchTriageActorResponse(actor *workbenchApp.TriageActor) *ClinicianWorkbenchTriageActorResponse {
+	if actor == nil {
+		return nil
+	}
+	var clinicianID *string
+	if actor.ClinicianID != 0 {
+		value := fmt.Sprintf("%d", actor.ClinicianID)
+		clinicianID = &value
+	}
+	return &ClinicianWorkbenchTriageActorResponse{
+		UserID:      fmt.Sprintf("%d", actor.UserID),
+		ClinicianID: clinicianID,
+		Name:        actor.Name,
+	}
+}
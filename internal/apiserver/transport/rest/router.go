@@ -7,8 +7,13 @@ import (
 
 	auth "github.com/FangcunMount/iam/v2/pkg/sdk/auth/verifier"
 	actorAccessApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/access"
+	accessAuditApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	assessmentEntryApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/assessmententry"
+	breakGlassApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/breakglass"
+	careTeamApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/careteam"
 	clinicianApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/clinician"
+	consentApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/consent"
+	customRoleApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/customrole"
 	operatorapp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/operator"
 	testeeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/actor/testee"
 	cachegovernance "github.com/FangcunMount/qs-server/internal/apiserver/application/cachegovernance"
@@ -16,11 +21,21 @@ import (
 	evaluationoperator "github.com/FangcunMount/qs-server/internal/apiserver/application/evaluation/operator"
 	appEventing "github.com/FangcunMount/qs-server/internal/apiserver/application/eventing"
 	interpretationcatalog "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/catalogreconcile"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinicalreview"
 	interpretationclinician "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/clinician"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/groupreport"
 	interpretationoperations "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/operations"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/planreport"
+	interpretationregeneration "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/regeneration"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reportpdf"
 	interpretationreporttemplate "github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/reporttemplate"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/interpretation/riskalert"
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
 	reportqueryjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportquery"
 	reportwaitjourney "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/reportwait"
+	subjectRights "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/subjectrights"
+	testeeImport "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeeimport"
+	testeeMerge "github.com/FangcunMount/qs-server/internal/apiserver/application/journey/testeemerge"
 	assessmentModelApp "github.com/FangcunMount/qs-server/internal/apiserver/application/modelcatalog"
 	planApp "github.com/FangcunMount/qs-server/internal/apiserver/application/plan"
 	qrcodeApp "github.com/FangcunMount/qs-server/internal/apiserver/application/qrcode"
@@ -33,6 +48,7 @@ import (
 	iaminfra "github.com/FangcunMount/qs-server/internal/apiserver/infra/iam"
 	objectstorageport "github.com/FangcunMount/qs-server/internal/apiserver/infra/objectstorage/port"
 	"github.com/FangcunMount/qs-server/internal/apiserver/options"
+	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/FangcunMount/qs-server/internal/pkg/middleware"
 	"github.com/FangcunMount/qs-server/internal/pkg/resilience"
 	"github.com/FangcunMount/qs-server/internal/pkg/resilience/ratelimit"
@@ -77,6 +93,13 @@ type Deps struct {
 	Plan            PlanDeps
 	Statistics      StatisticsDeps
 	Workbench       WorkbenchDeps
+	TesteeImport    TesteeImportDeps
+	TesteeMerge     TesteeMergeDeps
+	Consent         ConsentDeps
+	AccessAudit     AccessAuditDeps
+	TesteePrivacy   TesteePrivacyDeps
+	SubjectRights   SubjectRightsDeps
+	FHIR            FHIRDeps
 
 	CodesService             codesapp.CodesService
 	QRCodeObjectStore        objectstorageport.ObjectStore
@@ -97,6 +120,8 @@ type SurveyDeps struct {
 	QuestionnaireQRCodeService    questionnaireApp.QuestionnaireQRCodeQueryService
 	AnswerSheetManagementService  answerSheetApp.AnswerSheetManagementService
 	AnswerSheetSubmissionService  answerSheetApp.AnswerSheetSubmissionService
+	// ModelScopeResolver 答卷按测评模型范围判定自定义角色；为空时有模型范围的角色无法读取答卷。
+	ModelScopeResolver customRoleApp.ModelScopeResolver
 }
 
 type AssessmentModelDeps struct {
@@ -121,6 +146,9 @@ type ActorDeps struct {
 	ClinicianQueryService         clinicianApp.ClinicianQueryService
 	ClinicianRelationshipService  clinicianApp.ClinicianRelationshipService
 	AssessmentEntryService        assessmentEntryApp.AssessmentEntryService
+	BreakGlassService             breakGlassApp.Service
+	CustomRoleService             customRoleApp.Service
+	CareTeamService               careTeamApp.Service
 	QRCodeService                 qrcodeApp.QRCodeService
 	ActiveOperatorChecker         operatorapp.ActiveOperatorChecker
 	OperatorRoleProjectionUpdater operatorapp.OperatorRoleProjectionUpdater
@@ -132,12 +160,19 @@ type EvaluationDeps struct {
 }
 
 type InterpretationDeps struct {
-	ReportQueryJourney reportqueryjourney.Service
-	ReportWaitJourney  reportwaitjourney.Service
-	ClinicianService   interpretationclinician.Service
-	OperationsService  interpretationoperations.Service
-	CatalogReconcile   interpretationcatalog.Service
-	ReportTemplates    interpretationreporttemplate.Service
+	ReportQueryJourney     reportqueryjourney.Service
+	ReportWaitJourney      reportwaitjourney.Service
+	ClinicianService       interpretationclinician.Service
+	OperationsService      interpretationoperations.Service
+	CatalogReconcile       interpretationcatalog.Service
+	ReportTemplates        interpretationreporttemplate.Service
+	ReportTemplateRollouts interpretationreporttemplate.RolloutService
+	ReportRegenerations    interpretationregeneration.Service
+	ClinicalReview         clinicalreview.Service
+	RiskAlerts             riskalert.Service
+	ReportPDF              reportpdf.Service
+	PlanReports            planreport.Service
+	GroupReports           groupreport.Service
 }
 
 type PlanDeps struct {
@@ -151,6 +186,34 @@ type WorkbenchDeps struct {
 	WorkbenchService workbenchApp.Service
 }
 
+type TesteeImportDeps struct {
+	Service testeeImport.Service
+}
+
+type TesteeMergeDeps struct {
+	Service testeeMerge.Service
+}
+
+type ConsentDeps struct {
+	Service consentApp.Service
+}
+
+type AccessAuditDeps struct {
+	Service accessAuditApp.Service
+}
+
+type TesteePrivacyDeps struct {
+	ExportService testeeApp.TesteeExportService
+}
+
+type SubjectRightsDeps struct {
+	Service subjectRights.Service
+}
+
+type FHIRDeps struct {
+	Service fhir.Service
+}
+
 type StatisticsDeps struct {
 	Enabled     bool
 	ReadService *statisticsApp.ReadService
@@ -246,6 +309,17 @@ func (r *Router) rateLimitedHandlers(
 	}
 }
 
+// auditedQueryHandlers 读取受试者数据的查询接口：在限流之后追加访问审计，被限流拒绝的请求不记录。
+func (r *Router) auditedQueryHandlers(route restmiddleware.AccessAuditRoute, handler gin.HandlerFunc) []gin.HandlerFunc {
+	handlers := r.rateLimitedHandlers(rateLimitBudgetQuery, handler)
+	if r.deps.AccessAudit.Service == nil {
+		return handlers
+	}
+	audited := make([]gin.HandlerFunc, 0, len(handlers)+1)
+	audited = append(audited, handlers[:len(handlers)-1]...)
+	return append(audited, restmiddleware.AccessAuditMiddleware(r.deps.AccessAudit.Service, route), handler)
+}
+
 func requestLimitKey(c *gin.Context) string {
 	userID := middleware.GetUserID(c)
 	if userID != "" {
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
 	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,15 @@ type actorHandlers struct {
 	operatorClinician *handler.OperatorClinicianHandler
 	assessmentEntry   *handler.AssessmentEntryHandler
 	workbench         *handler.ClinicianWorkbenchHandler
+	testeeImport      *handler.TesteeImportHandler
+	testeeMerge       *handler.TesteeMergeHandler
+	consent           *handler.ConsentHandler
+	accessAudit       *handler.AccessAuditHandler
+	testeePrivacy     *handler.TesteePrivacyHandler
+	breakGlass        *handler.BreakGlassHandler
+	dataSubject       *handler.DataSubjectHandler
+	customRole        *handler.CustomRoleHandler
+	careTeam          *handler.CareTeamHandler
 }
 
 func (r *Router) actorHandlers() actorHandlers {
@@ -48,7 +58,34 @@ func (r *Router) actorHandlers() actorHandlers {
 		)
 	}
 	if r.deps.Workbench.WorkbenchService != nil {
-		handlers.workbench = handler.NewClinicianWorkbenchHandler(r.deps.Workbench.WorkbenchService)
+		handlers.workbench = handler.NewClinicianWorkbenchHandler(r.deps.Workbench.WorkbenchService, deps.TesteeAccessService)
+	}
+	if r.deps.TesteeImport.Service != nil {
+		handlers.testeeImport = handler.NewTesteeImportHandler(r.deps.TesteeImport.Service)
+	}
+	if r.deps.TesteeMerge.Service != nil {
+		handlers.testeeMerge = handler.NewTesteeMergeHandler(r.deps.TesteeMerge.Service)
+	}
+	if r.deps.Consent.Service != nil {
+		handlers.consent = handler.NewConsentHandler(r.deps.Consent.Service)
+	}
+	if r.deps.AccessAudit.Service != nil {
+		handlers.accessAudit = handler.NewAccessAuditHandler(r.deps.AccessAudit.Service)
+	}
+	if r.deps.TesteePrivacy.ExportService != nil || deps.TesteeBackendQueryService != nil {
+		handlers.testeePrivacy = handler.NewTesteePrivacyHandler(r.deps.TesteePrivacy.ExportService, deps.TesteeBackendQueryService)
+	}
+	if deps.BreakGlassService != nil {
+		handlers.breakGlass = handler.NewBreakGlassHandler(deps.BreakGlassService)
+	}
+	if r.deps.SubjectRights.Service != nil {
+		handlers.dataSubject = handler.NewDataSubjectHandler(r.deps.SubjectRights.Service)
+	}
+	if deps.CustomRoleService != nil {
+		handlers.customRole = handler.NewCustomRoleHandler(deps.CustomRoleService)
+	}
+	if deps.CareTeamService != nil {
+		handlers.careTeam = handler.NewCareTeamHandler(deps.CareTeamService)
 	}
 	return handlers
 }
@@ -70,7 +107,16 @@ func (r *Router) registerActorProtectedRoutes(apiV1 *gin.RouterGroup) {
 	operatorClinicianHandler := handlers.operatorClinician
 	assessmentEntryHandler := handlers.assessmentEntry
 	workbenchHandler := handlers.workbench
-	if testeeHandler == nil && operatorClinicianHandler == nil && assessmentEntryHandler == nil && workbenchHandler == nil {
+	testeeImportHandler := handlers.testeeImport
+	testeeMergeHandler := handlers.testeeMerge
+	consentHandler := handlers.consent
+	accessAuditHandler := handlers.accessAudit
+	testeePrivacyHandler := handlers.testeePrivacy
+	breakGlassHandler := handlers.breakGlass
+	dataSubjectHandler := handlers.dataSubject
+	customRoleHandler := handlers.customRole
+	careTeamHandler := handlers.careTeam
+	if testeeHandler == nil && operatorClinicianHandler == nil && assessmentEntryHandler == nil && workbenchHandler == nil && testeeImportHandler == nil && testeeMergeHandler == nil && consentHandler == nil && accessAuditHandler == nil && testeePrivacyHandler == nil && breakGlassHandler == nil && dataSubjectHandler == nil && customRoleHandler == nil && careTeamHandler == nil {
 		return
 	}
 
@@ -79,9 +125,18 @@ func (r *Router) registerActorProtectedRoutes(apiV1 *gin.RouterGroup) {
 		if testeeHandler != nil {
 			testees.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeHandler.ListTestees)...)
 			testees.GET("/by-profile-id", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeHandler.GetTesteeByProfileID)...)
-			testees.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeHandler.GetTestee)...)
+			testees.GET("/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceTestee, ResourceParam: "id", TesteeParam: "id"}, testeeHandler.GetTestee)...)
 			testees.PUT("/:id", r.rateLimitedHandlers(rateLimitBudgetSubmit, testeeHandler.UpdateTestee)...)
-			testees.GET("/:id/scale-analysis", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeHandler.GetScaleAnalysis)...)
+			testees.GET("/:id/scale-analysis", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceScaleAnalysis, ResourceParam: "id", TesteeParam: "id"}, testeeHandler.GetScaleAnalysis)...)
+		}
+
+		if testeePrivacyHandler != nil {
+			if r.deps.TesteePrivacy.ExportService != nil {
+				testees.Group("", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityReadStatistics)).GET("/export", r.rateLimitedHandlers(rateLimitBudgetQuery, testeePrivacyHandler.ExportTestees)...)
+			}
+			if r.deps.Actor.TesteeBackendQueryService != nil {
+				testees.Group("", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityUnmaskTesteePII)).POST("/:id/unmask", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceTesteeUnmask, ResourceParam: "id", TesteeParam: "id"}, testeePrivacyHandler.UnmaskTestee)...)
+			}
 		}
 
 		if operatorClinicianHandler != nil {
@@ -105,6 +160,103 @@ func (r *Router) registerActorProtectedRoutes(apiV1 *gin.RouterGroup) {
 		adminWorkbench := apiV1.Group("/workbench", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
 		adminWorkbench.GET("/queues/summary", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.GetOrgWorkbenchQueueSummary)...)
 		adminWorkbench.GET("/queues/:queue_type", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.ListOrgWorkbenchQueue)...)
+		adminWorkbench.GET("/queues/:queue_type/items/:subject_id", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.GetOrgWorkbenchTriageItem)...)
+		adminWorkbench.POST("/queues/:queue_type/items/:subject_id/claim", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ClaimOrgWorkbenchItem)...)
+		adminWorkbench.POST("/queues/:queue_type/items/:subject_id/snooze", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.SnoozeOrgWorkbenchItem)...)
+		adminWorkbench.POST("/queues/:queue_type/items/:subject_id/resolve", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ResolveOrgWorkbenchItem)...)
+		adminWorkbench.POST("/queues/:queue_type/items/:subject_id/reopen", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ReopenOrgWorkbenchItem)...)
+	}
+
+	if testeeImportHandler != nil {
+		imports := apiV1.Group("/testee-imports", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		imports.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, testeeImportHandler.CreateTesteeImport)...)
+		imports.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeImportHandler.ListTesteeImports)...)
+		imports.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeImportHandler.GetTesteeImport)...)
+		imports.GET("/:id/rows", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeImportHandler.ListTesteeImportRows)...)
+		imports.POST("/:id/resume", r.rateLimitedHandlers(rateLimitBudgetSubmit, testeeImportHandler.ResumeTesteeImport)...)
+		imports.POST("/:id/cancel", r.rateLimitedHandlers(rateLimitBudgetSubmit, testeeImportHandler.CancelTesteeImport)...)
+	}
+
+	if testeeMergeHandler != nil {
+		requireOrgAdmin := restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin)
+		apiV1.Group("/testee-duplicates", requireOrgAdmin).GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeMergeHandler.ListTesteeDuplicates)...)
+		testees.Group("", requireOrgAdmin).GET("/:id/duplicates", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeMergeHandler.GetTesteeDuplicates)...)
+
+		merges := apiV1.Group("/testee-merges", requireOrgAdmin)
+		merges.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, testeeMergeHandler.MergeTestees)...)
+		merges.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeMergeHandler.ListTesteeMerges)...)
+		merges.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, testeeMergeHandler.GetTesteeMerge)...)
+		merges.POST("/:id/revert", r.rateLimitedHandlers(rateLimitBudgetSubmit, testeeMergeHandler.RevertTesteeMerge)...)
+	}
+
+	if consentHandler != nil {
+		requireOrgAdmin := restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin)
+		documents := apiV1.Group("/consent-documents", requireOrgAdmin)
+		documents.POST("", r.rateLimitedHandlers(rateLimitBudgetSubmit, consentHandler.CreateConsentDocument)...)
+		documents.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, consentHandler.ListConsentDocuments)...)
+		documents.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, consentHandler.GetConsentDocument)...)
+		documents.POST("/:id/publish", r.rateLimitedHandlers(rateLimitBudgetSubmit, consentHandler.PublishConsentDocument)...)
+		documents.POST("/:id/retire", r.rateLimitedHandlers(rateLimitBudgetSubmit, consentHandler.RetireConsentDocument)...)
+
+		acceptances := apiV1.Group("/consent-acceptances", requireOrgAdmin)
+		acceptances.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, consentHandler.ListConsentAcceptances)...)
+		acceptances.POST("/:id/withdraw", r.rateLimitedHandlers(rateLimitBudgetSubmit, consentHandler.WithdrawConsentAcceptance)...)
+	}
+
+	if accessAuditHandler != nil {
+		audits := apiV1.Group("/access-audits", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		audits.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, accessAuditHandler.ListAccessAudits)...)
+		audits.GET("/export", r.rateLimitedHandlers(rateLimitBudgetQuery, accessAuditHandler.ExportAccessAudits)...)
+		audits.GET("/verify", r.rateLimitedHandlers(rateLimitBudgetQuery, accessAuditHandler.VerifyAccessAuditChain)...)
+	}
+
+	if breakGlassHandler != nil {
+		grants := apiV1.Group("/break-glass-grants", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		grants.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, breakGlassHandler.ListBreakGlassGrants)...)
+		grants.POST("/:id/review", r.rateLimitedHandlers(rateLimitBudgetSubmit, breakGlassHandler.ReviewBreakGlassGrant)...)
+		grants.POST("/:id/revoke", r.rateLimitedHandlers(rateLimitBudgetSubmit, breakGlassHandler.RevokeBreakGlassGrant)...)
+	}
+
+	if dataSubjectHandler != nil {
+		subjectRequests := apiV1.Group("/data-subject-requests", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		subjectRequests.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, dataSubjectHandler.CreateDataSubjectRequest)...)
+		subjectRequests.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, dataSubjectHandler.ListDataSubjectRequests)...)
+		subjectRequests.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, dataSubjectHandler.GetDataSubjectRequest)...)
+		subjectRequests.POST("/:id/resume", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, dataSubjectHandler.ResumeDataSubjectRequest)...)
+		subjectRequests.GET("/:id/bundle", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceDataSubjectBundle, ResourceParam: "id"}, dataSubjectHandler.DownloadDataSubjectBundle)...)
+		subjectRequests.GET("/:id/certificate", r.rateLimitedHandlers(rateLimitBudgetQuery, dataSubjectHandler.GetDataSubjectCertificate)...)
+	}
+
+	if customRoleHandler != nil {
+		customRoles := apiV1.Group("/custom-roles", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		customRoles.GET("/capabilities", r.rateLimitedHandlers(rateLimitBudgetQuery, customRoleHandler.ListGrantableCapabilities)...)
+		customRoles.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, customRoleHandler.ListCustomRoles)...)
+		customRoles.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, customRoleHandler.CreateCustomRole)...)
+		customRoles.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, customRoleHandler.GetCustomRole)...)
+		customRoles.PUT("/:id", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, customRoleHandler.UpdateCustomRole)...)
+		customRoles.DELETE("/:id", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, customRoleHandler.DeleteCustomRole)...)
+		customRoles.GET("/:id/assignments", r.rateLimitedHandlers(rateLimitBudgetQuery, customRoleHandler.ListCustomRoleAssignments)...)
+		customRoles.POST("/:id/assignments", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, customRoleHandler.AssignCustomRole)...)
+		customRoles.DELETE("/:id/assignments/:operator_id", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, customRoleHandler.UnassignCustomRole)...)
+
+		// 任何已认证操作者都可以解释自己的授权；解释他人时由 handler 校验 org_admin。
+		apiV1.GET("/authz/explain", r.rateLimitedHandlers(rateLimitBudgetQuery, customRoleHandler.ExplainCapability)...)
+	}
+
+	if careTeamHandler != nil {
+		careTeams := apiV1.Group("/care-teams", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+		careTeams.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.ListCareTeams)...)
+		careTeams.POST("", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.CreateCareTeam)...)
+		careTeams.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.GetCareTeam)...)
+		careTeams.PUT("/:id", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.UpdateCareTeam)...)
+		careTeams.DELETE("/:id", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.DeleteCareTeam)...)
+		careTeams.GET("/:id/members", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.ListCareTeamMembers)...)
+		careTeams.PUT("/:id/members/:clinician_id", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.SetCareTeamMember)...)
+		careTeams.DELETE("/:id/members/:clinician_id", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.RemoveCareTeamMember)...)
+		careTeams.GET("/:id/testees", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.ListCareTeamTestees)...)
+		careTeams.POST("/:id/testees", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.AssignCareTeamTestee)...)
+		careTeams.DELETE("/:id/testees/:testee_id", r.rateLimitedHandlers(rateLimitBudgetSubmit, careTeamHandler.UnassignCareTeamTestee)...)
+		careTeams.GET("/:id/events", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.ListCareTeamEvents)...)
 	}
 
 	registerClinicianRoutes := func(group *gin.RouterGroup) {
@@ -126,10 +278,23 @@ func (r *Router) registerActorProtectedRoutes(apiV1 *gin.RouterGroup) {
 		if workbenchHandler != nil {
 			me.GET("/workbench/queues/summary", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.GetMyClinicianWorkbenchQueueSummary)...)
 			me.GET("/workbench/queues/:queue_type", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.ListMyClinicianWorkbenchQueue)...)
+			me.GET("/workbench/queues/:queue_type/items/:subject_id", r.rateLimitedHandlers(rateLimitBudgetQuery, workbenchHandler.GetMyWorkbenchTriageItem)...)
+			me.POST("/workbench/queues/:queue_type/items/:subject_id/claim", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ClaimMyWorkbenchItem)...)
+			me.POST("/workbench/queues/:queue_type/items/:subject_id/snooze", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.SnoozeMyWorkbenchItem)...)
+			me.POST("/workbench/queues/:queue_type/items/:subject_id/resolve", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ResolveMyWorkbenchItem)...)
+			me.POST("/workbench/queues/:queue_type/items/:subject_id/reopen", r.rateLimitedHandlers(rateLimitBudgetSubmit, workbenchHandler.ReopenMyWorkbenchItem)...)
 		}
 		adminClinicians.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, operatorClinicianHandler.GetClinician)...)
 		adminClinicians.GET("/:id/testees", r.rateLimitedHandlers(rateLimitBudgetQuery, operatorClinicianHandler.ListClinicianTestees)...)
 		adminClinicians.GET("/:id/relations", r.rateLimitedHandlers(rateLimitBudgetQuery, operatorClinicianHandler.ListClinicianRelations)...)
+		if breakGlassHandler != nil {
+			me.POST("/break-glass", r.rateLimitedHandlers(rateLimitBudgetSubmit, breakGlassHandler.RequestBreakGlass)...)
+			me.GET("/break-glass", r.rateLimitedHandlers(rateLimitBudgetQuery, breakGlassHandler.ListMyBreakGlass)...)
+			me.POST("/break-glass/:id/revoke", r.rateLimitedHandlers(rateLimitBudgetSubmit, breakGlassHandler.RevokeMyBreakGlass)...)
+		}
+		if careTeamHandler != nil {
+			me.GET("/care-teams", r.rateLimitedHandlers(rateLimitBudgetQuery, careTeamHandler.ListMyCareTeams)...)
+		}
 		if assessmentEntryHandler != nil {
 			me.POST("/assessment-entries", r.rateLimitedHandlers(rateLimitBudgetSubmit, assessmentEntryHandler.CreateMyAssessmentEntry)...)
 			me.GET("/assessment-entries", r.rateLimitedHandlers(rateLimitBudgetQuery, assessmentEntryHandler.ListMyAssessmentEntries)...)
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
 	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/gin-gonic/gin"
@@ -31,8 +32,9 @@ func (r *Router) registerEvaluationProtectedRoutes(apiV1 *gin.RouterGroup) {
 		{
 			assessments.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, journeyHandler.ListAssessments)...)
 			assessments.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, journeyHandler.GetAssessment)...)
-			assessments.GET("/:id/scores", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.GetScores)...)
-			assessments.GET("/:id/report", r.rateLimitedHandlers(rateLimitBudgetQuery, journeyHandler.GetReport)...)
+			assessments.GET("/:id/scores", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentScores, ResourceParam: "id"}, evalHandler.GetScores)...)
+			assessments.GET("/:id/report", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentReport, ResourceParam: "id"}, journeyHandler.GetReport)...)
+			assessments.GET("/:id/report/charts/:chart_id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentReport, ResourceParam: "id"}, journeyHandler.GetReportChart)...)
 			assessments.GET("/:id/high-risk-factors", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.GetHighRiskFactors)...)
 			assessments.GET("/:id/runs/latest", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.GetLatestAssessmentRun)...)
 			assessments.GET("/:id/runs", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.ListAssessmentRuns)...)
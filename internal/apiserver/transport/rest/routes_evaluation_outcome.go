@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
+	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,7 +28,8 @@ func (r *Router) registerEvaluationOutcomeProtectedRoutes(apiV2 *gin.RouterGroup
 		{
 			assessments.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.ListAssessmentsOutcome)...)
 			assessments.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, evalHandler.GetAssessmentOutcome)...)
-			assessments.GET("/:id/report", r.rateLimitedHandlers(rateLimitBudgetQuery, journeyHandler.GetReportOutcome)...)
+			assessments.GET("/:id/report", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentReport, ResourceParam: "id"}, journeyHandler.GetReportOutcome)...)
+			assessments.GET("/:id/report/charts/:chart_id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentReport, ResourceParam: "id"}, journeyHandler.GetReportChart)...)
 		}
 
 		reports := evaluations.Group("/reports")
@@ -0,0 +1,23 @@
+package rest
+
+import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
+	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
+	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerFHIRProtectedRoutes 注册 FHIR R4 只读接口；涉及受试者数据的读取与批量导出均记录访问审计。
+func (r *Router) registerFHIRProtectedRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.FHIR.Service == nil {
+		return
+	}
+	fhirHandler := handler.NewFHIRHandler(r.deps.FHIR.Service)
+	fhirAPI := apiV1.Group("/fhir", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+	fhirAPI.GET("/Questionnaire/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, fhirHandler.GetQuestionnaire)...)
+	fhirAPI.GET("/Questionnaire/:id/_history/:vid", r.rateLimitedHandlers(rateLimitBudgetQuery, fhirHandler.GetQuestionnaireVersion)...)
+	fhirAPI.GET("/QuestionnaireResponse/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAnswerSheet, ResourceParam: "id"}, fhirHandler.GetQuestionnaireResponse)...)
+	fhirAPI.GET("/Observation/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentScores, ResourceParam: "id"}, fhirHandler.GetObservation)...)
+	fhirAPI.GET("/DiagnosticReport/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAssessmentReport, ResourceParam: "id"}, fhirHandler.GetDiagnosticReport)...)
+	fhirAPI.GET("/$export", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceFHIRExport}, fhirHandler.Export)...)
+}
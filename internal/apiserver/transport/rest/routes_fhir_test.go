@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/journey/fhir"
+	"github.com/FangcunMount/qs-server/internal/apiserver/options"
+	"github.com/gin-gonic/gin"
+)
+
+type fhirRouteServiceStub struct{}
+
+func (fhirRouteServiceStub) GetQuestionnaire(context.Context, fhir.Scope, string, string) (*fhir.Questionnaire, error) {
+	return nil, nil
+}
+func (fhirRouteServiceStub) GetQuestionnaireResponse(context.Context, fhir.Scope, uint64) (*fhir.QuestionnaireResponse, error) {
+	return nil, nil
+}
+func (fhirRouteServiceStub) GetObservation(context.Context, fhir.Scope, string) (*fhir.Observation, error) {
+	return nil, nil
+}
+func (fhirRouteServiceStub) GetDiagnosticReport(context.Context, fhir.Scope, uint64) (*fhir.DiagnosticReport, error) {
+	return nil, nil
+}
+func (fhirRouteServiceStub) Export(context.Context, fhir.Scope, fhir.ExportQuery, io.Writer) (*fhir.ExportSummary, error) {
+	return nil, nil
+}
+
+func TestRegisterFHIRProtectedRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	rateLimit := options.NewRateLimitOptions()
+	rateLimit.Enabled = false
+	router := NewRouter(Deps{RateLimit: rateLimit, FHIR: FHIRDeps{Service: fhirRouteServiceStub{}}})
+	router.registerFHIRProtectedRoutes(engine.Group("/api/v1"))
+
+	want := map[string]bool{
+		"GET /api/v1/fhir/Questionnaire/:id":               false,
+		"GET /api/v1/fhir/Questionnaire/:id/_history/:vid": false,
+		"GET /api/v1/fhir/QuestionnaireResponse/:id":       false,
+		"GET /api/v1/fhir/Observation/:id":                 false,
+		"GET /api/v1/fhir/DiagnosticReport/:id":            false,
+		"GET /api/v1/fhir/$export":                         false,
+	}
+	for _, route := range engine.Routes() {
+		key := route.Method + " " + route.Path
+		if _, ok := want[key]; ok {
+			want[key] = true
+		}
+	}
+	for route, registered := range want {
+		if !registered {
+			t.Errorf("route %s was not registered", route)
+		}
+	}
+}
@@ -1,19 +1,98 @@
 package rest
 
 import (
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	"github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
 	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 func (r *Router) registerInterpretationProtectedRoutes(apiV1 *gin.RouterGroup) {
+	r.registerClinicalReviewRoutes(apiV1)
+	r.registerRiskAlertRoutes(apiV1)
+	r.registerReportPDFRoutes(apiV1)
+	r.registerPlanReportRoutes(apiV1)
+	r.registerGroupReportRoutes(apiV1)
 	if r.deps.Interpretation.ClinicianService == nil {
 		return
 	}
 	h := handler.NewInterpretationClinicianHandler(r.deps.Interpretation.ClinicianService)
 	reports := apiV1.Group("/clinicians/me/testees/:testee_id/reports")
-	reports.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, h.List)...)
-	reports.GET("/:assessment_id", r.rateLimitedHandlers(rateLimitBudgetQuery, h.Get)...)
+	reports.GET("", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceReportList, ResourceParam: "testee_id", TesteeParam: "testee_id"}, h.List)...)
+	reports.GET("/:assessment_id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceInterpretationReport, ResourceParam: "assessment_id", TesteeParam: "testee_id"}, h.Get)...)
+}
+
+func (r *Router) registerClinicalReviewRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.Interpretation.ClinicalReview == nil {
+		return
+	}
+	h := handler.NewClinicalReviewHandler(r.deps.Interpretation.ClinicalReview)
+	report := apiV1.Group("/clinicians/me/testees/:testee_id/reports/:assessment_id")
+	report.GET("/review", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceInterpretationReport, ResourceParam: "assessment_id", TesteeParam: "testee_id"}, h.GetReview)...)
+	report.POST("/notes", r.rateLimitedHandlers(rateLimitBudgetSubmit, h.AddClinicalNote)...)
+	report.POST("/sign-off", r.rateLimitedHandlers(rateLimitBudgetSubmit, h.SignOffReport)...)
+}
+
+func (r *Router) registerReportPDFRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.Interpretation.ReportPDF == nil {
+		return
+	}
+	h := handler.NewReportPDFHandler(r.deps.Interpretation.ReportPDF)
+	apiV1.GET("/clinicians/me/testees/:testee_id/reports/:assessment_id/pdf-link", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceInterpretationReport, ResourceParam: "assessment_id", TesteeParam: "testee_id"}, h.IssueReportPDFLink)...)
+}
+
+func (r *Router) registerPlanReportRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.Interpretation.PlanReports == nil {
+		return
+	}
+	h := handler.NewPlanReportHandler(r.deps.Interpretation.PlanReports)
+	report := apiV1.Group("/clinicians/me/testees/:testee_id/plan-enrollments/:enrollment_id/longitudinal-report")
+	report.GET("", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceInterpretationReport, ResourceParam: "enrollment_id", TesteeParam: "testee_id"}, h.GetLongitudinalReport)...)
+	report.GET("/charts/:chart_id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceInterpretationReport, ResourceParam: "enrollment_id", TesteeParam: "testee_id"}, h.GetLongitudinalReportChart)...)
+	report.POST("", r.rateLimitedHandlers(rateLimitBudgetSubmit, h.GenerateLongitudinalReport)...)
+}
+
+func (r *Router) registerGroupReportRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.Interpretation.GroupReports == nil {
+		return
+	}
+	h := handler.NewGroupReportHandler(r.deps.Interpretation.GroupReports)
+	reports := apiV1.Group("/group-reports", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+	reports.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, h.GenerateGroupReport)...)
+	reports.GET("/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceGroupReport, ResourceParam: "id"}, h.GetGroupReport)...)
+	reports.GET("/:id/pdf", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceGroupReport, ResourceParam: "id"}, h.DownloadGroupReportPDF)...)
+}
+
+// registerInterpretationPublicRoutes 报告 PDF 下载以签名令牌为凭证，不经过 IAM 认证。
+func (r *Router) registerInterpretationPublicRoutes(publicAPI *gin.RouterGroup) {
+	if r.deps.Interpretation.ReportPDF == nil {
+		return
+	}
+	h := handler.NewReportPDFHandler(r.deps.Interpretation.ReportPDF)
+	if r.deps.AccessAudit.Service == nil {
+		publicAPI.GET("/report-pdfs/:token", h.DownloadReportPDF)
+		return
+	}
+	// 令牌即凭证，不进入审计记录；机构、测评与受试者在令牌校验通过后由 handler 提供。
+	publicAPI.GET("/report-pdfs/:token", restmiddleware.AccessAuditMiddleware(r.deps.AccessAudit.Service, restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceReportPDFDownload}), h.DownloadReportPDF)
+}
+
+func (r *Router) registerRiskAlertRoutes(apiV1 *gin.RouterGroup) {
+	if r.deps.Interpretation.RiskAlerts == nil {
+		return
+	}
+	h := handler.NewRiskAlertHandler(r.deps.Interpretation.RiskAlerts)
+	rules := apiV1.Group("/risk-alert-rules", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+	rules.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, h.ListRiskAlertRules)...)
+	rules.POST("", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, h.CreateRiskAlertRule)...)
+	rules.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, h.GetRiskAlertRule)...)
+	rules.PUT("/:id", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, h.UpdateRiskAlertRule)...)
+	rules.DELETE("/:id", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, h.DeleteRiskAlertRule)...)
+
+	alerts := apiV1.Group("/risk-alerts", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
+	alerts.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, h.ListRiskAlerts)...)
+	alerts.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, h.GetRiskAlert)...)
+	alerts.POST("/:id/acknowledge", r.rateLimitedHandlers(rateLimitBudgetSubmit, h.AcknowledgeRiskAlert)...)
 }
 
 func (r *Router) registerInterpretationInternalRoutes(internalV1 *gin.RouterGroup) {
@@ -40,4 +119,17 @@ func (r *Router) registerInterpretationInternalRoutes(internalV1 *gin.RouterGrou
 		g.GET("/report-templates/:template_id/versions/:version", templates.Get)
 		g.POST("/report-templates", templates.CreateDraft)
 	}
+	if r.deps.Interpretation.ReportTemplateRollouts != nil {
+		rollouts := handler.NewInterpretationReportTemplateRolloutHandler(r.deps.Interpretation.ReportTemplateRollouts)
+		g.GET("/report-templates/:template_id/rollouts", rollouts.ListRollouts)
+		g.GET("/report-template-rollouts/:rollout_id", rollouts.GetRollout)
+		g.GET("/report-template-rollouts/:rollout_id/comparisons", rollouts.ListComparisons)
+	}
+	if r.deps.Interpretation.ReportRegenerations != nil {
+		regenerations := handler.NewInterpretationReportRegenerationHandler(r.deps.Interpretation.ReportRegenerations)
+		g.POST("/report-regenerations/dry-run", regenerations.DryRun)
+		g.GET("/report-regenerations", regenerations.ListBatches)
+		g.GET("/report-regenerations/:batch_id", regenerations.GetBatch)
+		g.GET("/report-regenerations/:batch_id/items", regenerations.ListItems)
+	}
 }
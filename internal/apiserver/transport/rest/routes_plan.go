@@ -55,6 +55,7 @@ func (r *Router) registerPlanProtectedRoutes(apiV1 *gin.RouterGroup) {
 		testees.GET("/:id/plans/:plan_id/tasks", r.rateLimitedHandlers(rateLimitBudgetQuery, planHandler.ListTasksByTesteeAndPlan)...)
 		testees.GET("/:id/plans", r.rateLimitedHandlers(rateLimitBudgetQuery, planHandler.ListPlansByTestee)...)
 		testees.GET("/:id/tasks", r.rateLimitedHandlers(rateLimitBudgetQuery, planHandler.ListTasksByTestee)...)
+		testees.GET("/:id/tasks/calendar.ics", r.rateLimitedHandlers(rateLimitBudgetQuery, planHandler.ExportTesteeCalendar)...)
 	}
 }
 
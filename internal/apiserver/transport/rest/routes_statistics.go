@@ -25,6 +25,11 @@ func (r *Router) registerStatisticsProtectedRoutes(apiV2 *gin.RouterGroup) {
 	admin.GET("/clinicians/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, h.Clinician)...)
 	admin.GET("/entries", r.rateLimitedHandlers(rateLimitBudgetQuery, h.Entries)...)
 	admin.GET("/entries/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, h.Entry)...)
+	admin.GET("/plans", r.rateLimitedHandlers(rateLimitBudgetQuery, h.PlanAdherenceList)...)
+	admin.GET("/plans/:id/adherence", r.rateLimitedHandlers(rateLimitBudgetQuery, h.PlanAdherence)...)
+	admin.GET("/plans/:id/adherence/breakdown", r.rateLimitedHandlers(rateLimitBudgetQuery, h.PlanAdherenceBreakdown)...)
+	admin.GET("/plans/:id/adherence/export", r.rateLimitedHandlers(rateLimitBudgetQuery, h.ExportPlanAdherence)...)
+	admin.GET("/care-teams", r.rateLimitedHandlers(rateLimitBudgetQuery, h.CareTeams)...)
 	me := statistics.Group("/clinicians/me")
 	me.GET("/overview", r.rateLimitedHandlers(rateLimitBudgetQuery, h.CurrentClinicianOverview)...)
 	me.GET("/entries", r.rateLimitedHandlers(rateLimitBudgetQuery, h.CurrentClinicianEntries)...)
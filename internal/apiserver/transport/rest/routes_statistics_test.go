@@ -63,6 +63,10 @@ func TestRegisterStatisticsRoutesIsV2Only(t *testing.T) {
 	want := map[string]bool{
 		"GET /api/v2/statistics/overview":                    false,
 		"POST /api/v2/statistics/contents/batch":             false,
+		"GET /api/v2/statistics/plans":                       false,
+		"GET /api/v2/statistics/plans/:id/adherence":         false,
+		"GET /api/v2/statistics/plans/:id/adherence/export":  false,
+		"GET /api/v2/statistics/care-teams":                  false,
 		"POST /internal/v2/statistics/runs":                  false,
 		"POST /internal/v2/statistics/runs/:id/resume-cache": false,
 	}
@@ -3,6 +3,7 @@ package rest
 import (
 	"net/http"
 
+	"github.com/FangcunMount/qs-server/internal/apiserver/application/actor/accessaudit"
 	codesHandler "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/handler"
 	restmiddleware "github.com/FangcunMount/qs-server/internal/apiserver/transport/rest/middleware"
 	"github.com/gin-gonic/gin"
@@ -40,14 +41,15 @@ func (r *Router) registerAnswersheetProtectedRoutes(apiV1 *gin.RouterGroup) {
 		deps.AnswerSheetManagementService,
 		deps.AnswerSheetSubmissionService,
 	)
+	answersheetHandler.SetModelScopeResolver(deps.ModelScopeResolver)
 
 	answersheets := apiV1.Group("/answersheets")
 	{
 		admin := answersheets.Group("", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityOrgAdmin))
-		read := answersheets.Group("", restmiddleware.RequireCapabilityMiddleware(restmiddleware.CapabilityReadAnswersheets))
+		read := answersheets.Group("", restmiddleware.RequireScopedCapabilityMiddleware(restmiddleware.CapabilityReadAnswersheets))
 
 		admin.POST("/admin-submit", r.rateLimitedHandlers(rateLimitBudgetAdminSubmit, answersheetHandler.AdminSubmit)...)
-		read.GET("/:id", r.rateLimitedHandlers(rateLimitBudgetQuery, answersheetHandler.GetByID)...)
+		read.GET("/:id", r.auditedQueryHandlers(restmiddleware.AccessAuditRoute{Resource: accessaudit.ResourceAnswerSheet, ResourceParam: "id"}, answersheetHandler.GetByID)...)
 		read.GET("", r.rateLimitedHandlers(rateLimitBudgetQuery, answersheetHandler.List)...)
 	}
 }
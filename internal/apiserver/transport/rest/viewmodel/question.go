@@ -15,6 +15,7 @@ type QuestionDTO struct {
 	ValidationRules []ValidationRuleDTO `json:"validation_rules,omitempty"` // 校验规则（可选项）
 	CalculationRule *CalculationRuleDTO `json:"calculation_rule,omitempty"` // 问题算分规则（可选项，结构化题型）
 	ShowController  *ShowControllerDTO  `json:"show_controller,omitempty"`  // 显示控制器（可选项）
+	CriticalRule    *CriticalRuleDTO    `json:"critical_rule,omitempty"`    // 关键条目规则（可选项）
 }
 
 // Option 选项
@@ -46,3 +47,11 @@ type ShowControllerConditionDTO struct {
 	Code              string   `json:"code"`                // 问题编码
 	SelectOptionCodes []string `json:"select_option_codes"` // 选中的选项编码列表
 }
+
+// CriticalRuleDTO 关键条目规则：命中时答卷提交即标记关键条目，独立于测评模型评估
+type CriticalRuleDTO struct {
+	Category    string   `json:"category"`               // 类别：self_harm、abuse 或 other
+	OptionCodes []string `json:"option_codes,omitempty"` // 选中即命中的选项编码（单选、多选题）
+	MinValue    *float64 `json:"min_value,omitempty"`    // 答案不小于该值即命中（数字题）
+	Keywords    []string `json:"keywords,omitempty"`     // 答案包含即命中的关键词（文本题）
+}
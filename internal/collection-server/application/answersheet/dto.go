@@ -81,8 +81,9 @@ type Answer struct {
 
 // SubmitAnswerSheetResponse 提交答卷响应
 type SubmitAnswerSheetResponse struct {
-	ID      string `json:"id"`
-	Message string `json:"message"`
+	ID            string         `json:"id"`
+	Message       string         `json:"message"`
+	SafeMessaging *SafeMessaging `json:"safe_messaging,omitempty"`
 }
 
 // SubmitAcceptedResponse 提交受理响应
@@ -90,6 +91,22 @@ type SubmitAcceptedResponse struct {
 	Status        string `json:"status"`
 	RequestID     string `json:"request_id"`
 	AnswerSheetID string `json:"answersheet_id"`
+	// SafeMessaging 答卷命中关键条目时返回，作答端应立即展示；不透露命中的题目与规则
+	SafeMessaging *SafeMessaging `json:"safe_messaging,omitempty"`
+}
+
+// SafeMessaging 安全提示
+type SafeMessaging struct {
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Hotlines []Hotline `json:"hotlines"`
+}
+
+// Hotline 求助热线
+type Hotline struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+	Hours  string `json:"hours,omitempty"`
 }
 
 // AssessmentReadinessResponse describes whether the asynchronous worker has
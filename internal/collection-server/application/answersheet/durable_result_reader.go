@@ -18,6 +18,7 @@ type LookupAcceptedSubmissionInput struct {
 }
 
 type LookupAcceptedSubmissionOutput struct {
-	Found bool
-	ID    uint64
+	Found         bool
+	ID            uint64
+	SafeMessaging *SafeMessaging
 }
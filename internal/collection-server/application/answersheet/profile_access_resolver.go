@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/FangcunMount/component-base/pkg/logger"
+	"github.com/FangcunMount/qs-server/internal/pkg/redaction"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -52,7 +53,7 @@ func (r *ProfileAccessResolver) validateProfileAccess(ctx context.Context, write
 	if testee.IAMProfileID == "" {
 		l.Warnw("受试者未绑定 IAM Profile，拒绝权限校验",
 			"testee_id", resolvedTesteeID,
-			"testee_name", testee.Name,
+			"testee_name", redaction.MaskName(testee.Name),
 		)
 		return nil, 0, status.Error(codes.PermissionDenied, "testee is not bound to an IAM profile")
 	}
@@ -121,7 +122,7 @@ func (r *ProfileAccessResolver) checkProfileLinkAccess(ctx context.Context, writ
 			"writer_id", writerID,
 			"testee_id", testeeID,
 			"iam_profile_id", iamProfileID,
-			"testee_name", testeeName,
+			"testee_name", redaction.MaskName(testeeName),
 			"result", "forbidden",
 		)
 		return status.Error(codes.PermissionDenied, "无权为该受试者提交答卷")
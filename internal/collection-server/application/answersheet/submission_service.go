@@ -30,6 +30,11 @@ type DurableSubmitCoalescer interface {
 	) (answerSheetID string, err error)
 }
 
+// ConsentGate 答卷提交前的知情同意校验；缺少有效同意时返回 FailedPrecondition。
+type ConsentGate interface {
+	CheckSubmission(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error
+}
+
 type profileLinkChecker interface {
 	IsEnabled() bool
 	GetDefaultOrgID() uint64
@@ -59,6 +64,7 @@ type SubmissionService struct {
 	submitCoalescer     DurableSubmitCoalescer
 	assessmentResolver  AssessmentResolver
 	questionnaire       submissionQuestionnaireReader
+	consentGate         ConsentGate
 	acceptTimeout       time.Duration
 }
 
@@ -93,6 +99,11 @@ func NewSubmissionService(
 	}
 }
 
+// SetConsentGate 注入提交前的知情同意校验（可选）。
+func (s *SubmissionService) SetConsentGate(gate ConsentGate) {
+	s.consentGate = gate
+}
+
 var safeIdempotencyKey = regexp.MustCompile(`^[A-Za-z0-9._:-]{8,128}$`)
 
 func observeSubmitStage(stage, outcome string, started time.Time) {
@@ -263,8 +274,9 @@ func (s *SubmissionService) lookupThenAccept(
 			observeSubmitStage("durable_readback", "hit", lookupStarted)
 			resilience.ObserveAnswerSheetSubmitCoalescer("readback_hit")
 			return &SubmitAnswerSheetResponse{
-				ID:      strconv.FormatUint(result.ID, 10),
-				Message: "答卷提交成功",
+				ID:            strconv.FormatUint(result.ID, 10),
+				Message:       "答卷提交成功",
+				SafeMessaging: result.SafeMessaging,
 			}, nil
 		case err == nil && (result == nil || !result.Found):
 			observeSubmitStage("durable_readback", "miss", lookupStarted)
@@ -329,13 +341,24 @@ func (s *SubmissionService) accept(ctx context.Context, requestID string, writer
 	}
 	observeSubmitStage("profile_link", "ok", profileLinkStarted)
 
-	answers := s.answerConverter.Convert(req.Answers)
-
-	// 4. 调用 gRPC 服务提交答卷（传递 OrgID）
 	orgID := uint64(0)
 	if testee != nil {
 		orgID = testee.OrgID
 	}
+
+	// 3. 校验知情同意（apiserver 落库前会再次校验）
+	if s.consentGate != nil {
+		consentStarted := time.Now()
+		if err := s.consentGate.CheckSubmission(ctx, orgID, resolvedTesteeID, req.QuestionnaireCode, consentEntryID(req.OriginRef)); err != nil {
+			observeSubmitStage("consent", "failed", consentStarted)
+			return nil, err
+		}
+		observeSubmitStage("consent", "ok", consentStarted)
+	}
+
+	answers := s.answerConverter.Convert(req.Answers)
+
+	// 4. 调用 gRPC 服务提交答卷（传递 OrgID）
 	grpcSaveStarted := time.Now()
 	result, err := s.committer.Save(ctx, writerID, orgID, resolvedTesteeID, req, answers)
 	if err != nil {
@@ -356,8 +379,9 @@ func (s *SubmissionService) accept(ctx context.Context, requestID string, writer
 	)
 
 	return &SubmitAnswerSheetResponse{
-		ID:      strconv.FormatUint(result.ID, 10),
-		Message: result.Message,
+		ID:            strconv.FormatUint(result.ID, 10),
+		Message:       result.Message,
+		SafeMessaging: result.SafeMessaging,
 	}, nil
 }
 
@@ -546,3 +570,11 @@ func (s *SubmissionService) Get(ctx context.Context, writerID, id uint64) (*Answ
 	)
 	return result, nil
 }
+
+// consentEntryID 经测评入口作答时返回入口ID，用于匹配入口级同意书。
+func consentEntryID(origin *OriginRef) string {
+	if origin == nil || origin.Type != "assessment_entry" {
+		return ""
+	}
+	return origin.ID
+}
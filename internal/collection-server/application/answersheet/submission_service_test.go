@@ -437,6 +437,56 @@ func TestAcceptDurablyReturnsAnswerSheetOnlyAfterWriterSuccess(t *testing.T) {
 	}
 }
 
+func TestAcceptDurablyReturnsSafeMessagingForCriticalSubmission(t *testing.T) {
+	messaging := &SafeMessaging{Title: "你并不孤单", Hotlines: []Hotline{{Name: "全国心理援助热线", Number: "12356"}}}
+	writer := &submissionWriterStub{output: &SaveAnswerSheetOutput{ID: 42, Message: "saved", SafeMessaging: messaging}}
+	service := newAcceptService(writer, nil, nil)
+
+	got, err := service.AcceptDurably(t.Context(), "request-1", 11, validSubmitRequest())
+	if err != nil {
+		t.Fatalf("AcceptDurably() error = %v", err)
+	}
+	if got == nil || got.SafeMessaging == nil || got.SafeMessaging.Hotlines[0].Number != "12356" {
+		t.Fatalf("AcceptDurably() = %#v, want safe messaging", got)
+	}
+}
+
+type consentGateStub struct {
+	err      error
+	orgID    uint64
+	testeeID uint64
+	entryID  string
+}
+
+func (s *consentGateStub) CheckSubmission(_ context.Context, orgID, testeeID uint64, _ string, entryID string) error {
+	s.orgID, s.testeeID, s.entryID = orgID, testeeID, entryID
+	return s.err
+}
+
+func TestAcceptDurablyRejectsSubmissionWithoutConsentBeforeSave(t *testing.T) {
+	writer := &submissionWriterStub{output: &SaveAnswerSheetOutput{ID: 42}}
+	service := newAcceptService(writer, nil, nil)
+	gate := &consentGateStub{err: status.Error(codes.FailedPrecondition, "consent required")}
+	service.SetConsentGate(gate)
+	req := validSubmitRequest()
+	req.OriginRef = &OriginRef{Type: "assessment_entry", ID: "entry-3"}
+
+	if _, err := service.AcceptDurably(t.Context(), "request-consent", 11, req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("AcceptDurably() error = %v, want FailedPrecondition", err)
+	}
+	if writer.calls != 0 {
+		t.Fatalf("writer calls = %d, want 0", writer.calls)
+	}
+	if gate.orgID != 9 || gate.testeeID != 7 || gate.entryID != "entry-3" {
+		t.Fatalf("consent gate called with org=%d testee=%d entry=%q", gate.orgID, gate.testeeID, gate.entryID)
+	}
+
+	gate.err = nil
+	if _, err := service.AcceptDurably(t.Context(), "request-consent", 11, req); err != nil || writer.calls != 1 {
+		t.Fatalf("AcceptDurably() after consent = %v, writer calls = %d", err, writer.calls)
+	}
+}
+
 func TestAcceptDurablyDoesNotRequireAssessment(t *testing.T) {
 	writer := &submissionWriterStub{output: &SaveAnswerSheetOutput{ID: 42}}
 	service := newAcceptService(writer, nil, assessmentResolverStub{err: status.Error(codes.Unavailable, "worker down")})
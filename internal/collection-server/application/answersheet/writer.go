@@ -33,4 +33,6 @@ type AnswerInput struct {
 type SaveAnswerSheetOutput struct {
 	ID      uint64
 	Message string
+	// SafeMessaging 答卷命中关键条目时的安全提示，未命中时为 nil
+	SafeMessaging *SafeMessaging
 }
@@ -0,0 +1,87 @@
+package consent
+
+import "time"
+
+// 签署人类型。
+const (
+	FillerTypeSelf     = "self"
+	FillerTypeGuardian = "guardian"
+)
+
+// ListRequirementsRequest 查询作答前需要签署的同意书
+type ListRequirementsRequest struct {
+	QuestionnaireCode string `form:"questionnaire_code"` // 问卷编码（可选）
+	EntryID           string `form:"entry_id"`           // 测评入口ID（经入口作答时传入）
+}
+
+// AcceptRequest 签署同意书请求
+type AcceptRequest struct {
+	DocumentID       string `json:"document_id" binding:"required"` // 同意书ID
+	FillerType       string `json:"filler_type"`                    // 签署人类型：self/guardian，默认 guardian
+	GuardianRelation string `json:"guardian_relation"`              // 监护关系：parent/legal_guardian，监护人签署时必填
+}
+
+// WithdrawRequest 撤回签署请求
+type WithdrawRequest struct {
+	Reason string `json:"reason"` // 撤回原因
+}
+
+// DocumentResponse 同意书
+type DocumentResponse struct {
+	ID          string     `json:"id"`
+	ScopeKind   string     `json:"scope_kind"` // org/model/entry
+	ScopeRef    string     `json:"scope_ref,omitempty"`
+	Version     int32      `json:"version"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	ContentHash string     `json:"content_hash"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// AcceptanceResponse 签署记录
+type AcceptanceResponse struct {
+	ID               string     `json:"id"`
+	DocumentID       string     `json:"document_id"`
+	DocumentVersion  int32      `json:"document_version"`
+	TesteeID         string     `json:"testee_id"`
+	FillerUserID     string     `json:"filler_user_id"`
+	FillerType       string     `json:"filler_type"`
+	GuardianRelation string     `json:"guardian_relation,omitempty"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	Active           bool       `json:"active"`
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty"`
+	WithdrawReason   string     `json:"withdraw_reason,omitempty"`
+}
+
+// RequirementResponse 一份需要签署的同意书
+type RequirementResponse struct {
+	Document   *DocumentResponse   `json:"document"`
+	Acceptance *AcceptanceResponse `json:"acceptance,omitempty"` // 未签署或已撤回时为空
+}
+
+// RequirementsResponse 受试者作答前的同意要求
+type RequirementsResponse struct {
+	TesteeID    string                `json:"testee_id"`
+	MinorTestee bool                  `json:"minor_testee"` // 未成年受试者只能由监护人签署
+	Satisfied   bool                  `json:"satisfied"`
+	Items       []RequirementResponse `json:"items"`
+}
+
+// AcceptInput 签署同意书的下游参数
+type AcceptInput struct {
+	TesteeID         uint64
+	DocumentID       uint64
+	FillerUserID     uint64
+	FillerType       string
+	GuardianRelation string
+	IP               string
+	UserAgent        string
+	RequestID        string
+}
+
+// ClientInfo 签署留痕的客户端信息
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
@@ -0,0 +1,96 @@
+// Package consent 受试者侧知情同意：查询作答前需要签署的同意书、签署与撤回，
+// 并在答卷提交前校验同意是否有效。
+package consent
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Gateway 知情同意 gRPC 端口（application-owned DTO）。
+type Gateway interface {
+	ListRequirements(ctx context.Context, testeeID uint64, questionnaireCode, entryID string) (*RequirementsResponse, error)
+	Accept(ctx context.Context, input AcceptInput) (*AcceptanceResponse, error)
+	Withdraw(ctx context.Context, testeeID, acceptanceID, operatorUserID uint64, reason string) (*AcceptanceResponse, error)
+	CheckSubmission(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error
+}
+
+// Service 知情同意服务
+// 作为 BFF 层的薄服务：受试者访问权限由路由层 TesteeAccessMiddleware 校验，
+// 签署规则（版本、监护人、未成年人）由 apiserver 判定。
+type Service struct {
+	gateway Gateway
+}
+
+// NewService 创建知情同意服务
+func NewService(gateway Gateway) *Service {
+	return &Service{gateway: gateway}
+}
+
+// ListRequirements 列出受试者作答前需要签署的同意书及当前签署情况
+func (s *Service) ListRequirements(ctx context.Context, testeeID uint64, req *ListRequirementsRequest) (*RequirementsResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "consent service unavailable")
+	}
+	var questionnaireCode, entryID string
+	if req != nil {
+		questionnaireCode = strings.TrimSpace(req.QuestionnaireCode)
+		entryID = strings.TrimSpace(req.EntryID)
+	}
+	return s.gateway.ListRequirements(ctx, testeeID, questionnaireCode, entryID)
+}
+
+// Accept 当前用户为受试者签署同意书；重复签署同一版本幂等返回原记录
+func (s *Service) Accept(ctx context.Context, userID, testeeID uint64, req *AcceptRequest, client ClientInfo) (*AcceptanceResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "consent service unavailable")
+	}
+	documentID, err := strconv.ParseUint(strings.TrimSpace(req.DocumentID), 10, 64)
+	if err != nil || documentID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid document_id")
+	}
+	fillerType := strings.TrimSpace(req.FillerType)
+	if fillerType == "" {
+		fillerType = FillerTypeGuardian
+	}
+	if fillerType != FillerTypeSelf && fillerType != FillerTypeGuardian {
+		return nil, status.Error(codes.InvalidArgument, "filler_type must be self or guardian")
+	}
+	return s.gateway.Accept(ctx, AcceptInput{
+		TesteeID:         testeeID,
+		DocumentID:       documentID,
+		FillerUserID:     userID,
+		FillerType:       fillerType,
+		GuardianRelation: strings.TrimSpace(req.GuardianRelation),
+		IP:               client.IP,
+		UserAgent:        client.UserAgent,
+		RequestID:        client.RequestID,
+	})
+}
+
+// Withdraw 撤回受试者的一条签署记录
+func (s *Service) Withdraw(ctx context.Context, userID, testeeID, acceptanceID uint64, req *WithdrawRequest) (*AcceptanceResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "consent service unavailable")
+	}
+	if acceptanceID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid acceptance id")
+	}
+	reason := ""
+	if req != nil {
+		reason = strings.TrimSpace(req.Reason)
+	}
+	return s.gateway.Withdraw(ctx, testeeID, acceptanceID, userID, reason)
+}
+
+// CheckSubmission 答卷提交前校验同意；缺少有效同意时返回 FailedPrecondition
+func (s *Service) CheckSubmission(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error {
+	if s == nil || s.gateway == nil {
+		return nil
+	}
+	return s.gateway.CheckSubmission(ctx, orgID, testeeID, questionnaireCode, entryID)
+}
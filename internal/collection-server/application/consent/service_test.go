@@ -0,0 +1,72 @@
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type gatewayStub struct {
+	accepted  AcceptInput
+	withdrawn uint64
+}
+
+func (g *gatewayStub) ListRequirements(_ context.Context, testeeID uint64, _, _ string) (*RequirementsResponse, error) {
+	return &RequirementsResponse{TesteeID: "7"}, nil
+}
+
+func (g *gatewayStub) Accept(_ context.Context, input AcceptInput) (*AcceptanceResponse, error) {
+	g.accepted = input
+	return &AcceptanceResponse{ID: "1", Active: true}, nil
+}
+
+func (g *gatewayStub) Withdraw(_ context.Context, _, acceptanceID, _ uint64, _ string) (*AcceptanceResponse, error) {
+	g.withdrawn = acceptanceID
+	return &AcceptanceResponse{ID: "1"}, nil
+}
+
+func (g *gatewayStub) CheckSubmission(context.Context, uint64, uint64, string, string) error {
+	return nil
+}
+
+func TestAcceptDefaultsToGuardianAndRecordsClient(t *testing.T) {
+	gateway := &gatewayStub{}
+	service := NewService(gateway)
+
+	_, err := service.Accept(context.Background(), 11, 7, &AcceptRequest{DocumentID: "42", GuardianRelation: "parent"},
+		ClientInfo{IP: "10.0.0.1", UserAgent: "ua", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	got := gateway.accepted
+	if got.TesteeID != 7 || got.DocumentID != 42 || got.FillerUserID != 11 || got.FillerType != FillerTypeGuardian ||
+		got.GuardianRelation != "parent" || got.IP != "10.0.0.1" || got.RequestID != "req-1" {
+		t.Fatalf("accept input = %+v", got)
+	}
+}
+
+func TestAcceptRejectsInvalidInput(t *testing.T) {
+	service := NewService(&gatewayStub{})
+	for name, req := range map[string]*AcceptRequest{
+		"document id": {DocumentID: "abc"},
+		"filler type": {DocumentID: "42", FillerType: "staff"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := service.Accept(context.Background(), 11, 7, req, ClientInfo{}); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("Accept() error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestServiceWithoutGatewayIsUnavailableButDoesNotBlockSubmission(t *testing.T) {
+	service := NewService(nil)
+	if _, err := service.ListRequirements(context.Background(), 7, nil); status.Code(err) != codes.Unavailable {
+		t.Fatalf("ListRequirements() error = %v, want Unavailable", err)
+	}
+	if err := service.CheckSubmission(context.Background(), 9, 7, "Q", ""); err != nil {
+		t.Fatalf("CheckSubmission() error = %v; apiserver enforces consent when the BFF gate is absent", err)
+	}
+}
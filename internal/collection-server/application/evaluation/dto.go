@@ -75,6 +75,16 @@ type AssessmentReportResponse struct {
 	Suggestions  []SuggestionResponse         `json:"suggestions"`
 	ModelExtra   *ModelExtraResponse          `json:"model_extra,omitempty"`
 	CreatedAt    string                       `json:"created_at"`
+	// ClinicianAddendum 从业者签署复核后的补充说明；未签署时省略。
+	ClinicianAddendum *ClinicianAddendumResponse `json:"clinician_addendum,omitempty"`
+}
+
+// ClinicianAddendumResponse 报告临床补充说明。
+type ClinicianAddendumResponse struct {
+	Content      string `json:"content"`
+	ReviewerName string `json:"reviewer_name"`
+	ReviewStatus string `json:"review_status"`
+	ReviewedAt   string `json:"reviewed_at"`
 }
 
 // ModelExtraResponse 人格等模型的报告扩展。
@@ -0,0 +1,17 @@
+package reportpdf
+
+// LinkResponse 报告 PDF 签名下载链接
+type LinkResponse struct {
+	Token       string `json:"-"`
+	DownloadURL string `json:"download_url"` // 公开下载地址，有效期内无需登录
+	ExpiresAt   string `json:"expires_at"`   // 链接过期时间（RFC3339）
+	FileName    string `json:"file_name"`
+	ContentHash string `json:"content_hash"` // 报告内容 SHA-256，与 PDF 页脚一致
+}
+
+// File 报告 PDF 文件
+type File struct {
+	Content     []byte
+	FileName    string
+	ContentType string
+}
@@ -0,0 +1,67 @@
+// Package reportpdf 受试者侧解读报告 PDF：签发签名下载链接并按令牌下载。
+// PDF 由 apiserver 在报告生成后异步渲染；本服务只转发，令牌的签发与校验均在 apiserver 完成。
+package reportpdf
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadPath 公开下载路径前缀，令牌即凭证。
+const DownloadPath = "/api/v1/public/report-pdfs/"
+
+// Gateway 报告 PDF gRPC 端口（application-owned DTO）。
+type Gateway interface {
+	IssueLink(ctx context.Context, testeeID, assessmentID uint64) (*LinkResponse, error)
+	Download(ctx context.Context, token string) (*File, error)
+}
+
+// Service 报告 PDF 服务
+// 受试者访问权限由路由层 TesteeAccessMiddleware 校验，测评归属由 apiserver 判定。
+type Service struct {
+	gateway Gateway
+}
+
+// NewService 创建报告 PDF 服务
+func NewService(gateway Gateway) *Service {
+	return &Service{gateway: gateway}
+}
+
+// IssueLink 为受试者签发测评最新报告 PDF 的下载链接
+func (s *Service) IssueLink(ctx context.Context, testeeID, assessmentID uint64) (*LinkResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report pdf service unavailable")
+	}
+	link, err := s.gateway.IssueLink(ctx, testeeID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || link.Token == "" {
+		return nil, status.Error(codes.NotFound, "report pdf is not ready yet")
+	}
+	link.DownloadURL = DownloadPath + url.PathEscape(link.Token)
+	return link, nil
+}
+
+// Download 按令牌下载报告 PDF
+func (s *Service) Download(ctx context.Context, token string) (*File, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report pdf service unavailable")
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	file, err := s.gateway.Download(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, status.Error(codes.NotFound, "report pdf not found")
+	}
+	return file, nil
+}
@@ -0,0 +1,56 @@
+package reportpdf
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type gatewayStub struct {
+	link       *LinkResponse
+	downloaded string
+}
+
+func (g *gatewayStub) IssueLink(context.Context, uint64, uint64) (*LinkResponse, error) {
+	return g.link, nil
+}
+
+func (g *gatewayStub) Download(_ context.Context, token string) (*File, error) {
+	g.downloaded = token
+	return &File{Content: []byte("%PDF-1.4"), FileName: "report-42.pdf", ContentType: "application/pdf"}, nil
+}
+
+func TestIssueLinkBuildsPublicDownloadURL(t *testing.T) {
+	service := NewService(&gatewayStub{link: &LinkResponse{Token: "eyJhaWQiOiI0MiJ9.c2ln", FileName: "report-42.pdf"}})
+
+	link, err := service.IssueLink(context.Background(), 7, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.DownloadURL != "/api/v1/public/report-pdfs/eyJhaWQiOiI0MiJ9.c2ln" {
+		t.Fatalf("download url = %q", link.DownloadURL)
+	}
+}
+
+func TestIssueLinkWithoutTokenIsNotReady(t *testing.T) {
+	service := NewService(&gatewayStub{})
+
+	if _, err := service.IssueLink(context.Background(), 7, 42); status.Code(err) != codes.NotFound {
+		t.Fatalf("code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestDownloadTrimsTokenAndRejectsEmpty(t *testing.T) {
+	gateway := &gatewayStub{}
+	service := NewService(gateway)
+
+	if _, err := service.Download(context.Background(), "  "); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+	}
+	file, err := service.Download(context.Background(), " abc.def ")
+	if err != nil || gateway.downloaded != "abc.def" || file.ContentType != "application/pdf" {
+		t.Fatalf("download = %#v, %v (token %q)", file, err, gateway.downloaded)
+	}
+}
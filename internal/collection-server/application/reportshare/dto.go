@@ -0,0 +1,66 @@
+package reportshare
+
+import "github.com/FangcunMount/qs-server/internal/collection-server/application/evaluation"
+
+// CreateRequest 创建报告分享请求
+type CreateRequest struct {
+	Kind     string `json:"kind" binding:"required,oneof=link code"` // link：限时链接；code：访问码
+	Audience string `json:"audience" binding:"required"`             // 分享对象看到的报告受众版本，如 clinician、school
+	TTLHours int64  `json:"ttl_hours"`                               // 有效期（小时），为 0 时使用服务端默认值
+	MaxViews int32  `json:"max_views"`                               // 最多浏览次数，为 0 时使用服务端默认值
+	PIN      string `json:"pin,omitempty"`                           // 可选 4-8 位数字 PIN，访问时需一并提供
+	Label    string `json:"label,omitempty"`                         // 备注，如“王医生”
+}
+
+// ShareResponse 报告分享授权
+type ShareResponse struct {
+	ID           string `json:"id"`
+	AssessmentID string `json:"assessment_id"`
+	Kind         string `json:"kind"`
+	Audience     string `json:"audience"`
+	Label        string `json:"label,omitempty"`
+	PINProtected bool   `json:"pin_protected"`
+	MaxViews     int32  `json:"max_views"`
+	ViewCount    int32  `json:"view_count"`
+	Status       string `json:"status"` // active / expired / revoked / exhausted / locked
+	ExpiresAt    string `json:"expires_at"`
+	CreatedAt    string `json:"created_at"`
+	LastViewedAt string `json:"last_viewed_at,omitempty"`
+	RevokedAt    string `json:"revoked_at,omitempty"`
+}
+
+// CreatedResponse 新建的报告分享；链接或访问码只在创建时返回一次，服务端不保存明文
+type CreatedResponse struct {
+	Share      *ShareResponse `json:"share"`
+	Token      string         `json:"-"`
+	ShareURL   string         `json:"share_url,omitempty"`   // kind=link 时的公开访问地址
+	AccessCode string         `json:"access_code,omitempty"` // kind=code 时的访问码
+}
+
+// ListResponse 报告分享列表
+type ListResponse struct {
+	Items []*ShareResponse `json:"items"`
+}
+
+// AccessRequest 凭访问码查看报告请求
+type AccessRequest struct {
+	AccessCode string `json:"access_code" binding:"required"`
+	PIN        string `json:"pin,omitempty"`
+}
+
+// OpenInput 打开分享报告输入
+type OpenInput struct {
+	Token      string
+	AccessCode string
+	PIN        string
+	ClientIP   string
+	UserAgent  string
+}
+
+// SharedReportResponse 分享报告只读视图
+type SharedReportResponse struct {
+	Report         *evaluation.AssessmentReportResponse `json:"report"`
+	Audience       string                               `json:"audience"`
+	ExpiresAt      string                               `json:"expires_at"`
+	RemainingViews int32                                `json:"remaining_views"`
+}
@@ -0,0 +1,116 @@
+// Package reportshare 受试者侧报告对外分享：为无账号的第三方（咨询师、儿科医生等）创建限时链接或访问码，并提供公开只读查看。
+// 授权的签发、计数、撤销与访问留痕均在 apiserver 完成；本服务只做参数整理与转发。
+package reportshare
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SharePath 公开查看路径前缀，令牌即凭证。
+const SharePath = "/api/v1/public/report-shares/"
+
+// Gateway 报告分享 gRPC 端口（application-owned DTO）。
+type Gateway interface {
+	Create(ctx context.Context, testeeID, assessmentID uint64, req *CreateRequest) (*CreatedResponse, error)
+	List(ctx context.Context, testeeID, assessmentID uint64) (*ListResponse, error)
+	Revoke(ctx context.Context, testeeID, shareID uint64) (*ShareResponse, error)
+	Open(ctx context.Context, input *OpenInput) (*SharedReportResponse, error)
+}
+
+// Service 报告分享服务
+// 受试者访问权限由路由层 TesteeAccessMiddleware 校验，测评归属由 apiserver 判定。
+type Service struct {
+	gateway Gateway
+}
+
+// NewService 创建报告分享服务
+func NewService(gateway Gateway) *Service {
+	return &Service{gateway: gateway}
+}
+
+// Create 为受试者的测评报告创建分享
+func (s *Service) Create(ctx context.Context, testeeID, assessmentID uint64, req *CreateRequest) (*CreatedResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report share service unavailable")
+	}
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request body is required")
+	}
+	if req.TTLHours < 0 || req.MaxViews < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_hours and max_views must not be negative")
+	}
+	req.Audience = strings.TrimSpace(req.Audience)
+	req.Label = strings.TrimSpace(req.Label)
+	created, err := s.gateway.Create(ctx, testeeID, assessmentID, req)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil || created.Share == nil {
+		return nil, status.Error(codes.Internal, "report share was not created")
+	}
+	if created.Token != "" {
+		created.ShareURL = SharePath + url.PathEscape(created.Token)
+	}
+	return created, nil
+}
+
+// List 列出受试者在某次测评上的分享
+func (s *Service) List(ctx context.Context, testeeID, assessmentID uint64) (*ListResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report share service unavailable")
+	}
+	list, err := s.gateway.List(ctx, testeeID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil || list.Items == nil {
+		return &ListResponse{Items: []*ShareResponse{}}, nil
+	}
+	return list, nil
+}
+
+// Revoke 撤销分享
+func (s *Service) Revoke(ctx context.Context, testeeID, shareID uint64) (*ShareResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report share service unavailable")
+	}
+	share, err := s.gateway.Revoke(ctx, testeeID, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, status.Error(codes.NotFound, "report share not found")
+	}
+	return share, nil
+}
+
+// Open 按令牌或访问码查看分享报告；令牌与访问码只能二选一。
+func (s *Service) Open(ctx context.Context, input *OpenInput) (*SharedReportResponse, error) {
+	if s == nil || s.gateway == nil {
+		return nil, status.Error(codes.Unavailable, "report share service unavailable")
+	}
+	if input == nil {
+		return nil, status.Error(codes.InvalidArgument, "token or access_code is required")
+	}
+	input.Token = strings.TrimSpace(input.Token)
+	input.AccessCode = strings.TrimSpace(input.AccessCode)
+	input.PIN = strings.TrimSpace(input.PIN)
+	if (input.Token == "") == (input.AccessCode == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of token or access_code is required")
+	}
+	view, err := s.gateway.Open(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.Report == nil {
+		return nil, status.Error(codes.NotFound, "shared report not found")
+	}
+	// 分享视图不暴露内部测评 ID。
+	view.Report.AssessmentID = ""
+	return view, nil
+}
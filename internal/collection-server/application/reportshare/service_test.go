@@ -0,0 +1,78 @@
+package reportshare
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/evaluation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type gatewayStub struct {
+	created *CreatedResponse
+	opened  *OpenInput
+}
+
+func (g *gatewayStub) Create(context.Context, uint64, uint64, *CreateRequest) (*CreatedResponse, error) {
+	return g.created, nil
+}
+
+func (g *gatewayStub) List(context.Context, uint64, uint64) (*ListResponse, error) {
+	return nil, nil
+}
+
+func (g *gatewayStub) Revoke(context.Context, uint64, uint64) (*ShareResponse, error) {
+	return &ShareResponse{ID: "9", Status: "revoked"}, nil
+}
+
+func (g *gatewayStub) Open(_ context.Context, input *OpenInput) (*SharedReportResponse, error) {
+	g.opened = input
+	return &SharedReportResponse{Report: &evaluation.AssessmentReportResponse{AssessmentID: "0"}, Audience: "clinician"}, nil
+}
+
+func TestCreateLinkBuildsPublicShareURL(t *testing.T) {
+	service := NewService(&gatewayStub{created: &CreatedResponse{Share: &ShareResponse{ID: "9", Kind: "link"}, Token: "q1w2-e3_r4"}})
+	created, err := service.Create(context.Background(), 7, 42, &CreateRequest{Kind: "link", Audience: " clinician "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ShareURL != "/api/v1/public/report-shares/q1w2-e3_r4" {
+		t.Fatalf("share url = %q", created.ShareURL)
+	}
+}
+
+func TestCreateCodeHasNoShareURL(t *testing.T) {
+	service := NewService(&gatewayStub{created: &CreatedResponse{Share: &ShareResponse{ID: "9", Kind: "code"}, AccessCode: "ABCDE-FGHJK"}})
+	created, err := service.Create(context.Background(), 7, 42, &CreateRequest{Kind: "code", Audience: "school"})
+	if err != nil || created.ShareURL != "" || created.AccessCode != "ABCDE-FGHJK" {
+		t.Fatalf("created = %#v, %v", created, err)
+	}
+}
+
+func TestOpenRequiresExactlyOneCredentialAndHidesAssessmentID(t *testing.T) {
+	gateway := &gatewayStub{}
+	service := NewService(gateway)
+	for _, input := range []*OpenInput{{}, {Token: "abc", AccessCode: "ABCDE-FGHJK"}} {
+		if _, err := service.Open(context.Background(), input); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Open(%#v) code = %v, want InvalidArgument", input, status.Code(err))
+		}
+	}
+	view, err := service.Open(context.Background(), &OpenInput{AccessCode: " abcde-fghjk ", PIN: " 2468 "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gateway.opened.AccessCode != "abcde-fghjk" || gateway.opened.PIN != "2468" {
+		t.Fatalf("forwarded input = %#v", gateway.opened)
+	}
+	if view.Report.AssessmentID != "" {
+		t.Fatalf("assessment id leaked: %q", view.Report.AssessmentID)
+	}
+}
+
+func TestListNeverReturnsNilItems(t *testing.T) {
+	list, err := NewService(&gatewayStub{}).List(context.Background(), 7, 42)
+	if err != nil || list.Items == nil {
+		t.Fatalf("list = %#v, %v", list, err)
+	}
+}
@@ -12,6 +12,7 @@ type ActorPort interface {
 	TesteeExists(ctx context.Context, orgID, iamProfileID uint64) (exists bool, testeeID uint64, err error)
 	CreateTestee(ctx context.Context, input CreateTesteeInput) (*TesteeResponse, error)
 	GetTesteeCareContext(ctx context.Context, testeeID uint64) (*TesteeCareContextResponse, error)
+	ExportTesteeCalendar(ctx context.Context, testeeID uint64) (*TesteeCalendarFile, error)
 	UpdateTestee(ctx context.Context, input UpdateTesteeInput) (*TesteeResponse, error)
 	ListTesteesByUser(ctx context.Context, profileIDs []uint64, offset, limit int32) ([]*TesteeResponse, int64, error)
 }
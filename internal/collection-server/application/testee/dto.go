@@ -41,6 +41,8 @@ type TesteeResponse struct {
 	Tags             []string      `json:"tags"`                          // 旧响应兼容字段；当前固定返回空数组
 	Source           string        `json:"source"`                        // 来源
 	IsKeyFocus       bool          `json:"is_key_focus"`                  // 是否重点关注
+	AgeBand          string        `json:"age_band,omitempty"`            // 年龄段（已脱敏时代替出生日期）
+	Redacted         bool          `json:"redacted,omitempty"`            // 是否已由 apiserver 按权限脱敏
 
 	// 测评统计信息
 	AssessmentStats *AssessmentStatsDTO `json:"assessment_stats,omitempty"`
@@ -58,6 +60,13 @@ type TesteeCareContextResponse struct {
 	EntrySourceType string `json:"entry_source_type,omitempty"` // 入口来源类型
 }
 
+// TesteeCalendarFile 受试者待办任务日历文件（iCalendar / RFC 5545）
+type TesteeCalendarFile struct {
+	FileName   string // 建议的下载文件名
+	Content    []byte // text/calendar 内容
+	EventCount int    // 导出的事件数
+}
+
 // AssessmentStatsDTO 测评统计信息
 type AssessmentStatsDTO struct {
 	TotalCount       int32     `json:"total_count"`        // 总测评次数
@@ -179,6 +179,26 @@ func (s *Service) GetTesteeCareContext(ctx context.Context, testeeID uint64) (*T
 	return result, nil
 }
 
+// ExportTesteeCalendar 导出受试者待完成计划任务的 ICS 日历
+// 日历由 apiserver 渲染，与后台导出内容一致；受试者访问权限由路由层 TesteeAccessMiddleware 校验。
+func (s *Service) ExportTesteeCalendar(ctx context.Context, testeeID uint64) (*TesteeCalendarFile, error) {
+	l := logger.L(ctx)
+
+	result, err := s.actorClient.ExportTesteeCalendar(ctx, testeeID)
+	if err != nil {
+		l.Errorw("导出受试者任务日历失败",
+			"action", "export_testee_calendar",
+			"testee_id", testeeID,
+			"error", err.Error(),
+		)
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("testee calendar unavailable")
+	}
+	return result, nil
+}
+
 // UpdateTestee 更新受试者信息
 func (s *Service) UpdateTestee(ctx context.Context, testeeID uint64, req *UpdateTesteeRequest) (*TesteeResponse, error) {
 	l := logger.L(ctx)
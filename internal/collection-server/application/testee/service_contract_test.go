@@ -22,6 +22,9 @@ func (s *testeeActorContractStub) CreateTestee(_ context.Context, input CreateTe
 func (s *testeeActorContractStub) GetTesteeCareContext(context.Context, uint64) (*TesteeCareContextResponse, error) {
 	return nil, nil
 }
+func (s *testeeActorContractStub) ExportTesteeCalendar(context.Context, uint64) (*TesteeCalendarFile, error) {
+	return nil, nil
+}
 func (s *testeeActorContractStub) UpdateTestee(context.Context, UpdateTesteeInput) (*TesteeResponse, error) {
 	return nil, nil
 }
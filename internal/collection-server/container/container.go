@@ -7,10 +7,13 @@ import (
 	"github.com/FangcunMount/component-base/pkg/log"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/answersheet"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/behaviorassessment"
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/consent"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/evaluation"
 	appmodelcatalog "github.com/FangcunMount/qs-server/internal/collection-server/application/modelcatalog"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/questionnaire"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportnotify"
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportpdf"
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportshare"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportwait"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/testee"
 	"github.com/FangcunMount/qs-server/internal/collection-server/application/testeeaccess"
@@ -59,6 +62,7 @@ type Container struct {
 	assessmentIntakeClient       *grpcclient.AssessmentIntakeClient
 	actorClient                  *grpcclient.ActorClient
 	assessmentModelCatalogClient *grpcclient.AssessmentModelCatalogClient
+	consentClient                *grpcclient.ConsentClient
 
 	// 应用层服务
 	submissionService                  *answersheet.SubmissionService
@@ -71,6 +75,9 @@ type Container struct {
 	behaviorAssessmentQueryService     *behaviorassessment.QueryService
 	typologySessionService             *typologysession.Service
 	testeeService                      *testee.Service
+	consentService                     *consent.Service
+	reportPDFService                   *reportpdf.Service
+	reportShareService                 *reportshare.Service
 	testeeAccessAuthorizer             *testeeaccess.Authorizer
 	reportStatusReporter               *reportstatus.Reporter
 	reportNotifier                     reportnotify.Notifier
@@ -90,6 +97,9 @@ type Container struct {
 	behaviorAssessmentHandler        *handler.BehaviorAssessmentHandler
 	typologyAssessmentSessionHandler *handler.TypologyAssessmentSessionHandler
 	testeeHandler                    *handler.TesteeHandler
+	consentHandler                   *handler.ConsentHandler
+	reportPDFHandler                 *handler.ReportPDFHandler
+	reportShareHandler               *handler.ReportShareHandler
 	healthHandler                    *handler.HealthHandler
 
 	queryConcurrencyGate      *concurrency.Gate
@@ -108,6 +118,7 @@ type ClientBundle struct {
 	AssessmentIntake       *grpcclient.AssessmentIntakeClient
 	Actor                  *grpcclient.ActorClient
 	AssessmentModelCatalog *grpcclient.AssessmentModelCatalogClient
+	Consent                *grpcclient.ConsentClient
 }
 
 func (c *Container) TesteeService() *testee.Service {
@@ -296,6 +307,14 @@ func (c *Container) initApplicationServices() {
 	c.typologySessionService = typologysession.NewService(c.typologyModelQueryService, c.questionnaireQueryService)
 	c.testeeService = testee.NewService(acl.NewTesteeActorAdapter(c.actorClient), profileLinkService, profileService)
 	c.testeeAccessAuthorizer = testeeaccess.NewAuthorizer(c.testeeService, profileLinkService)
+	if c.consentClient != nil {
+		c.consentService = consent.NewService(grpcbridge.NewConsentGateway(c.consentClient))
+		c.submissionService.SetConsentGate(c.consentService)
+	}
+	if c.participantReportClient != nil {
+		c.reportPDFService = reportpdf.NewService(grpcbridge.NewReportPDFGateway(c.participantReportClient))
+		c.reportShareService = reportshare.NewService(grpcbridge.NewReportShareGateway(c.participantReportClient))
+	}
 	c.reportEventsHandler = c.buildReportEventsHandler()
 
 	log.Info("✅ Application services initialized")
@@ -320,6 +339,15 @@ func (c *Container) initHandlers() {
 	c.behaviorAssessmentHandler = handler.NewBehaviorAssessmentHandler(c.behaviorAssessmentQueryService, c.waitReportService)
 	c.typologyAssessmentSessionHandler = handler.NewTypologyAssessmentSessionHandler(c.typologySessionService)
 	c.testeeHandler = handler.NewTesteeHandler(c.testeeService, profileLinkService)
+	if c.consentService != nil {
+		c.consentHandler = handler.NewConsentHandler(c.consentService)
+	}
+	if c.reportPDFService != nil {
+		c.reportPDFHandler = handler.NewReportPDFHandler(c.reportPDFService)
+	}
+	if c.reportShareService != nil {
+		c.reportShareHandler = handler.NewReportShareHandler(c.reportShareService)
+	}
 	c.healthHandler = handler.NewHealthHandlerWithResilience("collection-server", "2.0.0", c.familyStatus, c.ResilienceSnapshot, c.resilience.ControlSynchronized)
 
 	log.Info("✅ REST handlers initialized")
@@ -384,6 +412,21 @@ func (c *Container) TesteeHandler() *handler.TesteeHandler {
 	return c.testeeHandler
 }
 
+// ConsentHandler 获取知情同意处理器
+func (c *Container) ConsentHandler() *handler.ConsentHandler {
+	return c.consentHandler
+}
+
+// ReportPDFHandler 获取报告 PDF 处理器
+func (c *Container) ReportPDFHandler() *handler.ReportPDFHandler {
+	return c.reportPDFHandler
+}
+
+// ReportShareHandler 获取报告分享处理器
+func (c *Container) ReportShareHandler() *handler.ReportShareHandler {
+	return c.reportShareHandler
+}
+
 // AssessmentModelCatalogHandler returns the generic published-model catalogue handler.
 func (c *Container) AssessmentModelCatalogHandler() *handler.AssessmentModelCatalogHandler {
 	return c.assessmentModelCatalogHandler
@@ -552,6 +595,7 @@ func (c *Container) InitializeRuntimeClients(bundle ClientBundle) {
 	c.assessmentIntakeClient = bundle.AssessmentIntake
 	c.actorClient = bundle.Actor
 	c.assessmentModelCatalogClient = bundle.AssessmentModelCatalog
+	c.consentClient = bundle.Consent
 }
 
 // ActorClient 获取 Actor 客户端
@@ -4,6 +4,7 @@ import (
 	actorpb "github.com/FangcunMount/qs-server/api/grpc/gen/actor"
 	answersheetpb "github.com/FangcunMount/qs-server/api/grpc/gen/answersheet"
 	assessmentmodelpb "github.com/FangcunMount/qs-server/api/grpc/gen/assessmentmodel"
+	consentpb "github.com/FangcunMount/qs-server/api/grpc/gen/consent"
 	evaluationpb "github.com/FangcunMount/qs-server/api/grpc/gen/evaluation"
 	interpretationpb "github.com/FangcunMount/qs-server/api/grpc/gen/interpretation"
 	questionnairepb "github.com/FangcunMount/qs-server/api/grpc/gen/questionnaire"
@@ -29,6 +30,12 @@ func ACLAllowedMethods() []string {
 		evaluationpb.AssessmentIntakeService_ResolveAssessmentByAnswerSheetID_FullMethodName,
 
 		interpretationpb.ParticipantReportService_GetAssessmentReport_FullMethodName,
+		interpretationpb.ParticipantReportService_IssueReportPDFLink_FullMethodName,
+		interpretationpb.ParticipantReportService_DownloadReportPDF_FullMethodName,
+		interpretationpb.ParticipantReportService_CreateReportShare_FullMethodName,
+		interpretationpb.ParticipantReportService_ListReportShares_FullMethodName,
+		interpretationpb.ParticipantReportService_RevokeReportShare_FullMethodName,
+		interpretationpb.ParticipantReportService_OpenReportShare_FullMethodName,
 
 		actorpb.ActorService_CreateTestee_FullMethodName,
 		actorpb.ActorService_GetTestee_FullMethodName,
@@ -37,10 +44,16 @@ func ACLAllowedMethods() []string {
 		actorpb.ActorService_ListTesteesByOrg_FullMethodName,
 		actorpb.ActorService_ListTesteesByUser_FullMethodName,
 		actorpb.ActorService_GetTesteeCareContext_FullMethodName,
+		actorpb.ActorService_ExportTesteeCalendar_FullMethodName,
 
 		assessmentmodelpb.AssessmentModelCatalogService_GetPublishedModel_FullMethodName,
 		assessmentmodelpb.AssessmentModelCatalogService_ListPublishedModels_FullMethodName,
 		assessmentmodelpb.AssessmentModelCatalogService_ListHotPublishedModels_FullMethodName,
 		assessmentmodelpb.AssessmentModelCatalogService_GetCatalogOptions_FullMethodName,
+
+		consentpb.ConsentService_ListRequiredConsents_FullMethodName,
+		consentpb.ConsentService_AcceptConsent_FullMethodName,
+		consentpb.ConsentService_WithdrawConsent_FullMethodName,
+		consentpb.ConsentService_CheckSubmissionConsent_FullMethodName,
 	}
 }
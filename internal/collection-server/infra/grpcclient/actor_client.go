@@ -49,6 +49,8 @@ type TesteeResponse struct {
 	Tags         []string  // 标签列表
 	Source       string    // 来源
 	IsKeyFocus   bool      // 是否重点关注
+	AgeBand      string    // 年龄段（已脱敏时代替出生日期）
+	Redacted     bool      // 是否已由 apiserver 按权限脱敏
 
 	// 测评统计信息
 	AssessmentStats *AssessmentStats
@@ -73,6 +75,13 @@ type TesteeCareContextResponse struct {
 	EntrySourceType string
 }
 
+// TesteeCalendarResponse 受试者待办任务日历（ICS）
+type TesteeCalendarResponse struct {
+	FileName   string
+	Content    []byte
+	EventCount int32
+}
+
 // CreateTestee 创建受试者
 func (c *ActorClient) CreateTestee(ctx context.Context, req *CreateTesteeRequest) (*TesteeResponse, error) {
 	ctx, cancel := c.base.ContextWithTimeout(ctx)
@@ -154,6 +163,25 @@ func (c *ActorClient) GetTesteeCareContext(ctx context.Context, testeeID uint64)
 	}, nil
 }
 
+// ExportTesteeCalendar 导出受试者待完成计划任务的 ICS 日历
+func (c *ActorClient) ExportTesteeCalendar(ctx context.Context, testeeID uint64) (*TesteeCalendarResponse, error) {
+	ctx, cancel := c.base.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.ExportTesteeCalendar(ctx, &pb.ExportTesteeCalendarRequest{
+		Id: testeeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TesteeCalendarResponse{
+		FileName:   resp.GetFileName(),
+		Content:    resp.GetContent(),
+		EventCount: resp.GetEventCount(),
+	}, nil
+}
+
 // UpdateTesteeRequest 更新受试者请求参数
 type UpdateTesteeRequest struct {
 	ID         uint64     // 受试者ID
@@ -264,6 +292,8 @@ func convertTesteeResponse(resp *pb.TesteeResponse) *TesteeResponse {
 		Tags:         []string{},
 		Source:       resp.Source,
 		IsKeyFocus:   resp.IsKeyFocus,
+		AgeBand:      resp.AgeBand,
+		Redacted:     resp.Redacted,
 	}
 
 	if resp.Birthday != nil {
@@ -39,6 +39,22 @@ type AnswerInput struct {
 type SaveAnswerSheetOutput struct {
 	ID      uint64
 	Message string
+	// SafeMessaging 答卷命中关键条目时的安全提示，未命中时为 nil
+	SafeMessaging *SafeMessagingOutput
+}
+
+// SafeMessagingOutput 安全提示输出
+type SafeMessagingOutput struct {
+	Title    string
+	Message  string
+	Hotlines []HotlineOutput
+}
+
+// HotlineOutput 求助热线输出
+type HotlineOutput struct {
+	Name   string
+	Number string
+	Hours  string
 }
 
 type LookupAnswerSheetSubmissionInput struct {
@@ -53,8 +69,9 @@ type LookupAnswerSheetSubmissionInput struct {
 }
 
 type LookupAnswerSheetSubmissionOutput struct {
-	Found bool
-	ID    uint64
+	Found         bool
+	ID            uint64
+	SafeMessaging *SafeMessagingOutput
 }
 
 // AnswerSheetOutput 答卷输出
@@ -133,11 +150,23 @@ func (c *AnswerSheetClient) SaveAnswerSheet(ctx context.Context, input *SaveAnsw
 	}
 
 	return &SaveAnswerSheetOutput{
-		ID:      resp.GetId(),
-		Message: resp.GetMessage(),
+		ID:            resp.GetId(),
+		Message:       resp.GetMessage(),
+		SafeMessaging: toSafeMessagingOutput(resp.GetSafeMessaging()),
 	}, nil
 }
 
+func toSafeMessagingOutput(messaging *pb.SafeMessaging) *SafeMessagingOutput {
+	if messaging == nil {
+		return nil
+	}
+	output := &SafeMessagingOutput{Title: messaging.GetTitle(), Message: messaging.GetMessage()}
+	for _, hotline := range messaging.GetHotlines() {
+		output.Hotlines = append(output.Hotlines, HotlineOutput{Name: hotline.GetName(), Number: hotline.GetNumber(), Hours: hotline.GetHours()})
+	}
+	return output
+}
+
 func (c *AnswerSheetClient) LookupAnswerSheetSubmission(
 	ctx context.Context,
 	input *LookupAnswerSheetSubmissionInput,
@@ -170,8 +199,9 @@ func (c *AnswerSheetClient) LookupAnswerSheetSubmission(
 		return nil, err
 	}
 	return &LookupAnswerSheetSubmissionOutput{
-		Found: response.GetFound(),
-		ID:    response.GetId(),
+		Found:         response.GetFound(),
+		ID:            response.GetId(),
+		SafeMessaging: toSafeMessagingOutput(response.GetSafeMessaging()),
 	}, nil
 }
 
@@ -0,0 +1,202 @@
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/FangcunMount/qs-server/api/grpc/gen/consent"
+)
+
+// ConsentClient 知情同意服务客户端
+type ConsentClient struct {
+	client pb.ConsentServiceClient
+	base   *Client
+}
+
+// NewConsentClient 创建知情同意服务客户端
+func NewConsentClient(base *Client) *ConsentClient {
+	return &ConsentClient{
+		client: pb.NewConsentServiceClient(base.Conn()),
+		base:   base,
+	}
+}
+
+// ConsentDocumentOutput 同意书
+type ConsentDocumentOutput struct {
+	ID          uint64
+	ScopeKind   string
+	ScopeRef    string
+	Version     int32
+	Title       string
+	Body        string
+	ContentHash string
+	PublishedAt *time.Time
+}
+
+// ConsentAcceptanceOutput 签署记录
+type ConsentAcceptanceOutput struct {
+	ID               uint64
+	DocumentID       uint64
+	DocumentVersion  int32
+	TesteeID         uint64
+	FillerUserID     uint64
+	FillerType       string
+	GuardianRelation string
+	AcceptedAt       *time.Time
+	Active           bool
+	WithdrawnAt      *time.Time
+	WithdrawReason   string
+}
+
+// RequiredConsentOutput 一份需要签署的同意书及当前有效签署
+type RequiredConsentOutput struct {
+	Document   *ConsentDocumentOutput
+	Acceptance *ConsentAcceptanceOutput
+}
+
+// RequiredConsentsOutput 受试者作答前的同意要求
+type RequiredConsentsOutput struct {
+	TesteeID    uint64
+	MinorTestee bool
+	Satisfied   bool
+	Items       []RequiredConsentOutput
+}
+
+// AcceptConsentInput 签署同意书参数
+type AcceptConsentInput struct {
+	TesteeID         uint64
+	DocumentID       uint64
+	FillerUserID     uint64
+	FillerType       string
+	GuardianRelation string
+	IP               string
+	UserAgent        string
+	RequestID        string
+}
+
+// ListRequiredConsents 列出受试者作答前需要签署的同意书
+func (c *ConsentClient) ListRequiredConsents(ctx context.Context, testeeID uint64, questionnaireCode, entryID string) (*RequiredConsentsOutput, error) {
+	ctx, cancel := c.base.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.ListRequiredConsents(ctx, &pb.ListRequiredConsentsRequest{
+		TesteeId:          testeeID,
+		QuestionnaireCode: questionnaireCode,
+		EntryId:           entryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &RequiredConsentsOutput{
+		TesteeID:    resp.GetTesteeId(),
+		MinorTestee: resp.GetMinorTestee(),
+		Satisfied:   resp.GetSatisfied(),
+		Items:       make([]RequiredConsentOutput, 0, len(resp.GetItems())),
+	}
+	for _, item := range resp.GetItems() {
+		out.Items = append(out.Items, RequiredConsentOutput{
+			Document:   convertConsentDocument(item.GetDocument()),
+			Acceptance: convertConsentAcceptance(item.GetAcceptance()),
+		})
+	}
+	return out, nil
+}
+
+// AcceptConsent 签署同意书
+func (c *ConsentClient) AcceptConsent(ctx context.Context, input *AcceptConsentInput) (*ConsentAcceptanceOutput, error) {
+	ctx, cancel := c.base.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.AcceptConsent(ctx, &pb.AcceptConsentRequest{
+		TesteeId:         input.TesteeID,
+		DocumentId:       input.DocumentID,
+		FillerUserId:     input.FillerUserID,
+		FillerType:       input.FillerType,
+		GuardianRelation: input.GuardianRelation,
+		Ip:               input.IP,
+		UserAgent:        input.UserAgent,
+		RequestId:        input.RequestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertConsentAcceptance(resp), nil
+}
+
+// WithdrawConsent 撤回签署
+func (c *ConsentClient) WithdrawConsent(ctx context.Context, testeeID, acceptanceID, operatorUserID uint64, reason string) (*ConsentAcceptanceOutput, error) {
+	ctx, cancel := c.base.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.WithdrawConsent(ctx, &pb.WithdrawConsentRequest{
+		TesteeId:       testeeID,
+		AcceptanceId:   acceptanceID,
+		OperatorUserId: operatorUserID,
+		Reason:         reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertConsentAcceptance(resp), nil
+}
+
+// CheckSubmissionConsent 答卷提交前校验同意；缺少有效同意时返回 FailedPrecondition
+func (c *ConsentClient) CheckSubmissionConsent(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error {
+	ctx, cancel := c.base.ContextWithTimeout(ctx)
+	defer cancel()
+
+	_, err := c.client.CheckSubmissionConsent(ctx, &pb.CheckSubmissionConsentRequest{
+		OrgId:             orgID,
+		TesteeId:          testeeID,
+		QuestionnaireCode: questionnaireCode,
+		EntryId:           entryID,
+	})
+	return err
+}
+
+func convertConsentDocument(doc *pb.ConsentDocument) *ConsentDocumentOutput {
+	if doc == nil {
+		return nil
+	}
+	out := &ConsentDocumentOutput{
+		ID:          doc.GetId(),
+		ScopeKind:   doc.GetScopeKind(),
+		ScopeRef:    doc.GetScopeRef(),
+		Version:     doc.GetVersion(),
+		Title:       doc.GetTitle(),
+		Body:        doc.GetBody(),
+		ContentHash: doc.GetContentHash(),
+	}
+	if doc.GetPublishedAt() != nil {
+		publishedAt := doc.GetPublishedAt().AsTime()
+		out.PublishedAt = &publishedAt
+	}
+	return out
+}
+
+func convertConsentAcceptance(acceptance *pb.ConsentAcceptance) *ConsentAcceptanceOutput {
+	if acceptance == nil {
+		return nil
+	}
+	out := &ConsentAcceptanceOutput{
+		ID:               acceptance.GetId(),
+		DocumentID:       acceptance.GetDocumentId(),
+		DocumentVersion:  acceptance.GetDocumentVersion(),
+		TesteeID:         acceptance.GetTesteeId(),
+		FillerUserID:     acceptance.GetFillerUserId(),
+		FillerType:       acceptance.GetFillerType(),
+		GuardianRelation: acceptance.GetGuardianRelation(),
+		Active:           acceptance.GetActive(),
+		WithdrawReason:   acceptance.GetWithdrawReason(),
+	}
+	if acceptance.GetAcceptedAt() != nil {
+		acceptedAt := acceptance.GetAcceptedAt().AsTime()
+		out.AcceptedAt = &acceptedAt
+	}
+	if acceptance.GetWithdrawnAt() != nil {
+		withdrawnAt := acceptance.GetWithdrawnAt().AsTime()
+		out.WithdrawnAt = &withdrawnAt
+	}
+	return out
+}
@@ -259,6 +259,15 @@ type AssessmentReportOutput struct {
 	Suggestions  []SuggestionOutput
 	ModelExtra   *ModelExtraOutput
 	CreatedAt    string
+	// ClinicianAddendum 从业者签署复核后的补充说明
+	ClinicianAddendum *ClinicianAddendumOutput
+}
+
+type ClinicianAddendumOutput struct {
+	Content      string
+	ReviewerName string
+	ReviewStatus string
+	ReviewedAt   string
 }
 
 type ListAssessmentsOutput struct {
@@ -394,6 +403,233 @@ func (c *ParticipantReportClient) GetAssessmentReport(ctx context.Context, teste
 	return convertAssessmentReport(resp.GetReport()), nil
 }
 
+// ReportPDFLinkOutput 报告 PDF 下载令牌
+type ReportPDFLinkOutput struct {
+	Token       string
+	ExpiresAt   string
+	FileName    string
+	ContentHash string
+}
+
+// ReportPDFOutput 报告 PDF 内容
+type ReportPDFOutput struct {
+	Content     []byte
+	FileName    string
+	ContentType string
+}
+
+// IssueReportPDFLink 为受试者签发测评最新报告 PDF 的下载令牌
+func (c *ParticipantReportClient) IssueReportPDFLink(ctx context.Context, testeeID, assessmentID uint64) (*ReportPDFLinkOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	ctx, err := c.attachDelegatedSubject(ctx, testeeID, delegatedsubject.PurposeIssueReportPDFLink)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.reportClient.IssueReportPDFLink(ctx, &interpretationpb.IssueReportPDFLinkRequest{
+		AssessmentId: assessmentID,
+		TesteeId:     testeeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ReportPDFLinkOutput{
+		Token:       resp.GetToken(),
+		ExpiresAt:   resp.GetExpiresAt(),
+		FileName:    resp.GetFileName(),
+		ContentHash: resp.GetContentHash(),
+	}, nil
+}
+
+// DownloadReportPDF 按下载令牌获取报告 PDF
+func (c *ParticipantReportClient) DownloadReportPDF(ctx context.Context, token string) (*ReportPDFOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.reportClient.DownloadReportPDF(ctx, &interpretationpb.DownloadReportPDFRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	return &ReportPDFOutput{
+		Content:     resp.GetContent(),
+		FileName:    resp.GetFileName(),
+		ContentType: resp.GetContentType(),
+	}, nil
+}
+
+// ReportShareOutput 报告分享授权
+type ReportShareOutput struct {
+	ID           uint64
+	AssessmentID uint64
+	Kind         string
+	Audience     string
+	Label        string
+	PINProtected bool
+	MaxViews     int32
+	ViewCount    int32
+	Status       string
+	ExpiresAt    string
+	CreatedAt    string
+	LastViewedAt string
+	RevokedAt    string
+}
+
+// CreateReportShareInput 创建报告分享授权输入
+type CreateReportShareInput struct {
+	TesteeID     uint64
+	AssessmentID uint64
+	Kind         string
+	Audience     string
+	TTLSeconds   int64
+	MaxViews     int32
+	PIN          string
+	Label        string
+}
+
+// CreatedReportShareOutput 新建的分享授权；令牌或访问码只在创建时返回一次
+type CreatedReportShareOutput struct {
+	Share      *ReportShareOutput
+	Token      string
+	AccessCode string
+}
+
+// OpenReportShareInput 打开分享报告输入
+type OpenReportShareInput struct {
+	Token      string
+	AccessCode string
+	PIN        string
+	ClientIP   string
+	UserAgent  string
+}
+
+// SharedReportOutput 分享报告只读视图
+type SharedReportOutput struct {
+	Report         *AssessmentReportOutput
+	Audience       string
+	ExpiresAt      string
+	RemainingViews int32
+}
+
+// CreateReportShare 为受试者的测评报告创建对外分享授权
+func (c *ParticipantReportClient) CreateReportShare(ctx context.Context, input *CreateReportShareInput) (*CreatedReportShareOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	ctx, err := c.attachDelegatedSubject(ctx, input.TesteeID, delegatedsubject.PurposeManageReportShares)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.reportClient.CreateReportShare(ctx, &interpretationpb.CreateReportShareRequest{
+		AssessmentId: input.AssessmentID,
+		TesteeId:     input.TesteeID,
+		Kind:         input.Kind,
+		Audience:     input.Audience,
+		TtlSeconds:   input.TTLSeconds,
+		MaxViews:     input.MaxViews,
+		Pin:          input.PIN,
+		Label:        input.Label,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedReportShareOutput{
+		Share:      convertReportShare(resp.GetShare()),
+		Token:      resp.GetToken(),
+		AccessCode: resp.GetAccessCode(),
+	}, nil
+}
+
+// ListReportShares 列出受试者在某次测评上的分享授权
+func (c *ParticipantReportClient) ListReportShares(ctx context.Context, testeeID, assessmentID uint64) ([]*ReportShareOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	ctx, err := c.attachDelegatedSubject(ctx, testeeID, delegatedsubject.PurposeManageReportShares)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.reportClient.ListReportShares(ctx, &interpretationpb.ListReportSharesRequest{
+		AssessmentId: assessmentID,
+		TesteeId:     testeeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*ReportShareOutput, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		items = append(items, convertReportShare(item))
+	}
+	return items, nil
+}
+
+// RevokeReportShare 撤销受试者创建的分享授权
+func (c *ParticipantReportClient) RevokeReportShare(ctx context.Context, testeeID, shareID uint64) (*ReportShareOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	ctx, err := c.attachDelegatedSubject(ctx, testeeID, delegatedsubject.PurposeManageReportShares)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.reportClient.RevokeReportShare(ctx, &interpretationpb.RevokeReportShareRequest{
+		ShareId:  shareID,
+		TesteeId: testeeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertReportShare(resp.GetShare()), nil
+}
+
+// OpenReportShare 按分享令牌或访问码获取只读报告
+func (c *ParticipantReportClient) OpenReportShare(ctx context.Context, input *OpenReportShareInput) (*SharedReportOutput, error) {
+	ctx, cancel := c.client.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.reportClient.OpenReportShare(ctx, &interpretationpb.OpenReportShareRequest{
+		Token:      input.Token,
+		AccessCode: input.AccessCode,
+		Pin:        input.PIN,
+		ClientIp:   input.ClientIP,
+		UserAgent:  input.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SharedReportOutput{
+		Report:         convertAssessmentReport(resp.GetReport()),
+		Audience:       resp.GetAudience(),
+		ExpiresAt:      resp.GetExpiresAt(),
+		RemainingViews: resp.GetRemainingViews(),
+	}, nil
+}
+
+func convertReportShare(share *interpretationpb.ReportShare) *ReportShareOutput {
+	if share == nil {
+		return nil
+	}
+	return &ReportShareOutput{
+		ID:           share.GetId(),
+		AssessmentID: share.GetAssessmentId(),
+		Kind:         share.GetKind(),
+		Audience:     share.GetAudience(),
+		Label:        share.GetLabel(),
+		PINProtected: share.GetPinProtected(),
+		MaxViews:     share.GetMaxViews(),
+		ViewCount:    share.GetViewCount(),
+		Status:       share.GetStatus(),
+		ExpiresAt:    share.GetExpiresAt(),
+		CreatedAt:    share.GetCreatedAt(),
+		LastViewedAt: share.GetLastViewedAt(),
+		RevokedAt:    share.GetRevokedAt(),
+	}
+}
+
 // ResolveAssessmentByAnswerSheetID resolves the asynchronous Assessment for the readiness contract.
 type AssessmentIntakeClient struct {
 	client       *Client
@@ -498,6 +734,20 @@ func convertAssessmentReport(report *interpretationpb.AssessmentReport) *Assessm
 		Suggestions:  fromProtoSuggestions(report.GetSuggestions()),
 		ModelExtra:   convertModelExtra(report.GetModelExtra()),
 		CreatedAt:    report.GetCreatedAt(),
+
+		ClinicianAddendum: convertClinicianAddendum(report.GetClinicianAddendum()),
+	}
+}
+
+func convertClinicianAddendum(addendum *interpretationpb.ClinicianAddendum) *ClinicianAddendumOutput {
+	if addendum == nil {
+		return nil
+	}
+	return &ClinicianAddendumOutput{
+		Content:      addendum.GetContent(),
+		ReviewerName: addendum.GetReviewerName(),
+		ReviewStatus: addendum.GetReviewStatus(),
+		ReviewedAt:   addendum.GetReviewedAt(),
 	}
 }
 
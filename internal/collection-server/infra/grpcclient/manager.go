@@ -64,6 +64,7 @@ type Manager struct {
 	assessmentIntakeClient       *AssessmentIntakeClient
 	actorClient                  *ActorClient
 	assessmentModelCatalogClient *AssessmentModelCatalogClient
+	consentClient                *ConsentClient
 }
 
 // NewManager 创建 gRPC 客户端管理器
@@ -274,6 +275,10 @@ func (m *Manager) RegisterClients() error {
 	m.clients["assessmentModelCatalog"] = m.assessmentModelCatalogClient
 	log.Info("   📚 Assessment model catalog client registered")
 
+	m.consentClient = NewConsentClient(baseClient)
+	m.clients["consent"] = m.consentClient
+	log.Info("   📝 Consent client registered")
+
 	log.Infof("✅ All gRPC clients registered (endpoint: %s)", m.config.Endpoint)
 	return nil
 }
@@ -305,6 +310,11 @@ func (m *Manager) AssessmentModelCatalogClient() *AssessmentModelCatalogClient {
 	return m.assessmentModelCatalogClient
 }
 
+// ConsentClient 获取知情同意客户端
+func (m *Manager) ConsentClient() *ConsentClient {
+	return m.consentClient
+}
+
 // GetClient 根据名称获取客户端
 func (m *Manager) GetClient(name string) interface{} {
 	m.mu.RLock()
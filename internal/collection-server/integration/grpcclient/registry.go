@@ -38,6 +38,7 @@ func (r *GRPCClientRegistry) ClientBundle() container.ClientBundle {
 		AssessmentIntake:       r.manager.AssessmentIntakeClient(),
 		Actor:                  r.actorClient(),
 		AssessmentModelCatalog: r.assessmentModelCatalogClient(),
+		Consent:                r.consentClient(),
 	}
 	log.Info("✅ Collection gRPC client bundle built")
 	return bundle
@@ -83,6 +84,16 @@ func (r *GRPCClientRegistry) assessmentModelCatalogClient() *grpcclient.Assessme
 	return client
 }
 
+func (r *GRPCClientRegistry) consentClient() *grpcclient.ConsentClient {
+	client := r.manager.ConsentClient()
+	if client == nil {
+		log.Warn("Consent client is not initialized, skipping registration")
+		return nil
+	}
+	log.Info("   📝 Consent client added to bundle")
+	return client
+}
+
 // CreateGRPCClientManager 创建 gRPC 客户端管理器。
 // perRPC 非 nil 时（通常为 IAM ServiceAuthHelper）对 apiserver 的每次 RPC 附加服务 JWT metadata。
 func CreateGRPCClientManager(endpoint string, timeout int, insecure bool, tlsCertFile, tlsKeyFile, tlsCAFile, tlsServerName string, inflightWaitMs int, inflightSemaphore admission.Semaphore, perRPC credentials.PerRPCCredentials, delegatedSigner *delegatedsubject.Signer) (*grpcclient.Manager, error) {
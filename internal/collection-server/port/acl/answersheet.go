@@ -46,13 +46,25 @@ func (w *AnswerSheetBFFWriter) SaveAnswerSheet(ctx context.Context, input *answe
 		},
 		func(result *grpcbridge.SaveAnswerSheetOutput) *answersheet.SaveAnswerSheetOutput {
 			return &answersheet.SaveAnswerSheetOutput{
-				ID:      result.ID,
-				Message: result.Message,
+				ID:            result.ID,
+				Message:       result.Message,
+				SafeMessaging: toSafeMessaging(result.SafeMessaging),
 			}
 		},
 	)
 }
 
+func toSafeMessaging(messaging *grpcbridge.SafeMessagingOutput) *answersheet.SafeMessaging {
+	if messaging == nil {
+		return nil
+	}
+	result := &answersheet.SafeMessaging{Title: messaging.Title, Message: messaging.Message, Hotlines: []answersheet.Hotline{}}
+	for _, hotline := range messaging.Hotlines {
+		result.Hotlines = append(result.Hotlines, answersheet.Hotline{Name: hotline.Name, Number: hotline.Number, Hours: hotline.Hours})
+	}
+	return result
+}
+
 func toGRPCSaveAnswerSheetInput(input *answersheet.SaveAnswerSheetInput) *grpcbridge.SaveAnswerSheetInput {
 	if input == nil {
 		return nil
@@ -115,7 +127,7 @@ func (r *AnswerSheetDurableResultReader) LookupAcceptedSubmission(
 			return r.inner.LookupAnswerSheetSubmission(ctx, grpcInput)
 		},
 		func(result *grpcbridge.LookupAnswerSheetSubmissionOutput) *answersheet.LookupAcceptedSubmissionOutput {
-			return &answersheet.LookupAcceptedSubmissionOutput{Found: result.Found, ID: result.ID}
+			return &answersheet.LookupAcceptedSubmissionOutput{Found: result.Found, ID: result.ID, SafeMessaging: toSafeMessaging(result.SafeMessaging)}
 		},
 	)
 }
@@ -86,6 +86,24 @@ func TestAnswerSheetBFFWriterMapsApplicationInputToGRPC(t *testing.T) {
 	}
 }
 
+func TestAnswerSheetBFFWriterMapsSafeMessaging(t *testing.T) {
+	t.Parallel()
+
+	inner := &grpcAnswerSheetWriterStub{
+		output: &grpcbridge.SaveAnswerSheetOutput{ID: 8080, SafeMessaging: &grpcbridge.SafeMessagingOutput{
+			Title:    "你并不孤单",
+			Hotlines: []grpcbridge.HotlineOutput{{Name: "全国心理援助热线", Number: "12356", Hours: "24小时"}},
+		}},
+	}
+	got, err := NewAnswerSheetBFFWriter(inner).SaveAnswerSheet(context.Background(), &answersheet.SaveAnswerSheetInput{QuestionnaireCode: "Q-1"})
+	if err != nil {
+		t.Fatalf("SaveAnswerSheet() error = %v", err)
+	}
+	if got.SafeMessaging == nil || got.SafeMessaging.Title != "你并不孤单" || len(got.SafeMessaging.Hotlines) != 1 || got.SafeMessaging.Hotlines[0].Hours != "24小时" {
+		t.Fatalf("safe messaging = %#v", got.SafeMessaging)
+	}
+}
+
 func TestAnswerSheetBFFWriterPropagatesGatewayError(t *testing.T) {
 	t.Parallel()
 
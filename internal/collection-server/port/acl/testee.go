@@ -87,6 +87,21 @@ func (a *TesteeActorAdapter) GetTesteeCareContext(ctx context.Context, testeeID
 	}, nil
 }
 
+func (a *TesteeActorAdapter) ExportTesteeCalendar(ctx context.Context, testeeID uint64) (*testee.TesteeCalendarFile, error) {
+	if a == nil || a.inner == nil {
+		return nil, nil
+	}
+	out, err := a.inner.ExportTesteeCalendar(ctx, testeeID)
+	if err != nil || out == nil {
+		return nil, err
+	}
+	return &testee.TesteeCalendarFile{
+		FileName:   out.FileName,
+		Content:    out.Content,
+		EventCount: int(out.EventCount),
+	}, nil
+}
+
 func (a *TesteeActorAdapter) UpdateTestee(ctx context.Context, input testee.UpdateTesteeInput) (*testee.TesteeResponse, error) {
 	if a == nil || a.inner == nil {
 		return nil, nil
@@ -134,9 +149,14 @@ func toTesteeResponse(from *grpcbridge.TesteeResponse) *testee.TesteeResponse {
 		Tags:         from.Tags,
 		Source:       from.Source,
 		IsKeyFocus:   from.IsKeyFocus,
+		AgeBand:      from.AgeBand,
+		Redacted:     from.Redacted,
 		CreatedAt:    from.CreatedAt,
 		UpdatedAt:    from.UpdatedAt,
 	}
+	if from.Birthday.IsZero() {
+		resp.Birthday = meta.NewBirthday("")
+	}
 	if from.AssessmentStats != nil {
 		resp.AssessmentStats = &testee.AssessmentStatsDTO{
 			TotalCount:       from.AssessmentStats.TotalCount,
@@ -25,6 +25,7 @@ func (s *grpcActorReaderStub) TesteeExists(context.Context, uint64, uint64) (boo
 
 type grpcActorWriterStub struct {
 	grpcActorReaderStub
+	calendar *grpcbridge.TesteeCalendarResponse
 }
 
 func (s *grpcActorWriterStub) CreateTestee(context.Context, *grpcbridge.CreateTesteeRequest) (*grpcbridge.TesteeResponse, error) {
@@ -35,6 +36,10 @@ func (s *grpcActorWriterStub) GetTesteeCareContext(context.Context, uint64) (*gr
 	return nil, nil
 }
 
+func (s *grpcActorWriterStub) ExportTesteeCalendar(context.Context, uint64) (*grpcbridge.TesteeCalendarResponse, error) {
+	return s.calendar, nil
+}
+
 func (s *grpcActorWriterStub) UpdateTestee(context.Context, *grpcbridge.UpdateTesteeRequest) (*grpcbridge.TesteeResponse, error) {
 	return nil, nil
 }
@@ -99,3 +104,18 @@ func TestActorReaderBridgeTesteeExists(t *testing.T) {
 		t.Fatalf("TesteeExists() = (%v, %d)", exists, id)
 	}
 }
+
+func TestTesteeActorAdapterMapsCalendarFile(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewTesteeActorAdapter(&grpcActorWriterStub{
+		calendar: &grpcbridge.TesteeCalendarResponse{FileName: "testee_9_tasks.ics", Content: []byte("BEGIN:VCALENDAR"), EventCount: 2},
+	})
+	got, err := adapter.ExportTesteeCalendar(context.Background(), 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.FileName != "testee_9_tasks.ics" || string(got.Content) != "BEGIN:VCALENDAR" || got.EventCount != 2 {
+		t.Fatalf("ExportTesteeCalendar() = %+v", got)
+	}
+}
@@ -35,6 +35,7 @@ type ActorWriter interface {
 	ActorReader
 	CreateTestee(ctx context.Context, req *CreateTesteeRequest) (*TesteeResponse, error)
 	GetTesteeCareContext(ctx context.Context, testeeID uint64) (*TesteeCareContextResponse, error)
+	ExportTesteeCalendar(ctx context.Context, testeeID uint64) (*TesteeCalendarResponse, error)
 	UpdateTestee(ctx context.Context, req *UpdateTesteeRequest) (*TesteeResponse, error)
 	ListTesteesByUser(ctx context.Context, profileIDs []uint64, offset, limit int32) ([]*TesteeResponse, int64, error)
 }
@@ -45,3 +46,25 @@ type AnswerSheetWriter interface {
 	LookupAnswerSheetSubmission(ctx context.Context, input *LookupAnswerSheetSubmissionInput) (*LookupAnswerSheetSubmissionOutput, error)
 	GetAnswerSheet(ctx context.Context, writerID, id uint64) (*AnswerSheetOutput, error)
 }
+
+// ConsentClient 知情同意端口。
+type ConsentClient interface {
+	ListRequiredConsents(ctx context.Context, testeeID uint64, questionnaireCode, entryID string) (*RequiredConsentsOutput, error)
+	AcceptConsent(ctx context.Context, input *AcceptConsentInput) (*ConsentAcceptanceOutput, error)
+	WithdrawConsent(ctx context.Context, testeeID, acceptanceID, operatorUserID uint64, reason string) (*ConsentAcceptanceOutput, error)
+	CheckSubmissionConsent(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error
+}
+
+// ReportPDFClient 报告 PDF 端口。
+type ReportPDFClient interface {
+	IssueReportPDFLink(ctx context.Context, testeeID, assessmentID uint64) (*ReportPDFLinkOutput, error)
+	DownloadReportPDF(ctx context.Context, token string) (*ReportPDFOutput, error)
+}
+
+// ReportShareClient 报告分享端口。
+type ReportShareClient interface {
+	CreateReportShare(ctx context.Context, input *CreateReportShareInput) (*CreatedReportShareOutput, error)
+	ListReportShares(ctx context.Context, testeeID, assessmentID uint64) ([]*ReportShareOutput, error)
+	RevokeReportShare(ctx context.Context, testeeID, shareID uint64) (*ReportShareOutput, error)
+	OpenReportShare(ctx context.Context, input *OpenReportShareInput) (*SharedReportOutput, error)
+}
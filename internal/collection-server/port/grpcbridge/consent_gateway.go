@@ -0,0 +1,110 @@
+package grpcbridge
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/consent"
+)
+
+// ConsentGateway 将 infra gRPC 输出转换为 consent application DTO。
+type ConsentGateway struct {
+	client ConsentClient
+}
+
+// NewConsentGateway 构造知情同意适配器。
+func NewConsentGateway(client ConsentClient) *ConsentGateway {
+	return &ConsentGateway{client: client}
+}
+
+func (g *ConsentGateway) ListRequirements(ctx context.Context, testeeID uint64, questionnaireCode, entryID string) (*consent.RequirementsResponse, error) {
+	return CallBridge(g.client,
+		func() (*RequiredConsentsOutput, error) {
+			return g.client.ListRequiredConsents(ctx, testeeID, questionnaireCode, entryID)
+		},
+		func(out *RequiredConsentsOutput) *consent.RequirementsResponse {
+			resp := &consent.RequirementsResponse{
+				TesteeID:    strconv.FormatUint(out.TesteeID, 10),
+				MinorTestee: out.MinorTestee,
+				Satisfied:   out.Satisfied,
+				Items:       make([]consent.RequirementResponse, 0, len(out.Items)),
+			}
+			for _, item := range out.Items {
+				resp.Items = append(resp.Items, consent.RequirementResponse{
+					Document:   toConsentDocumentResponse(item.Document),
+					Acceptance: toConsentAcceptanceResponse(item.Acceptance),
+				})
+			}
+			return resp
+		},
+	)
+}
+
+func (g *ConsentGateway) Accept(ctx context.Context, input consent.AcceptInput) (*consent.AcceptanceResponse, error) {
+	return CallBridge(g.client,
+		func() (*ConsentAcceptanceOutput, error) {
+			return g.client.AcceptConsent(ctx, &AcceptConsentInput{
+				TesteeID:         input.TesteeID,
+				DocumentID:       input.DocumentID,
+				FillerUserID:     input.FillerUserID,
+				FillerType:       input.FillerType,
+				GuardianRelation: input.GuardianRelation,
+				IP:               input.IP,
+				UserAgent:        input.UserAgent,
+				RequestID:        input.RequestID,
+			})
+		},
+		toConsentAcceptanceResponse,
+	)
+}
+
+func (g *ConsentGateway) Withdraw(ctx context.Context, testeeID, acceptanceID, operatorUserID uint64, reason string) (*consent.AcceptanceResponse, error) {
+	return CallBridge(g.client,
+		func() (*ConsentAcceptanceOutput, error) {
+			return g.client.WithdrawConsent(ctx, testeeID, acceptanceID, operatorUserID, reason)
+		},
+		toConsentAcceptanceResponse,
+	)
+}
+
+func (g *ConsentGateway) CheckSubmission(ctx context.Context, orgID, testeeID uint64, questionnaireCode, entryID string) error {
+	if g == nil || g.client == nil {
+		return nil
+	}
+	return g.client.CheckSubmissionConsent(ctx, orgID, testeeID, questionnaireCode, entryID)
+}
+
+func toConsentDocumentResponse(out *ConsentDocumentOutput) *consent.DocumentResponse {
+	if out == nil {
+		return nil
+	}
+	return &consent.DocumentResponse{
+		ID:          strconv.FormatUint(out.ID, 10),
+		ScopeKind:   out.ScopeKind,
+		ScopeRef:    out.ScopeRef,
+		Version:     out.Version,
+		Title:       out.Title,
+		Body:        out.Body,
+		ContentHash: out.ContentHash,
+		PublishedAt: out.PublishedAt,
+	}
+}
+
+func toConsentAcceptanceResponse(out *ConsentAcceptanceOutput) *consent.AcceptanceResponse {
+	if out == nil {
+		return nil
+	}
+	return &consent.AcceptanceResponse{
+		ID:               strconv.FormatUint(out.ID, 10),
+		DocumentID:       strconv.FormatUint(out.DocumentID, 10),
+		DocumentVersion:  out.DocumentVersion,
+		TesteeID:         strconv.FormatUint(out.TesteeID, 10),
+		FillerUserID:     strconv.FormatUint(out.FillerUserID, 10),
+		FillerType:       out.FillerType,
+		GuardianRelation: out.GuardianRelation,
+		AcceptedAt:       out.AcceptedAt,
+		Active:           out.Active,
+		WithdrawnAt:      out.WithdrawnAt,
+		WithdrawReason:   out.WithdrawReason,
+	}
+}
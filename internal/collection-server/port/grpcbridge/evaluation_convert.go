@@ -153,6 +153,20 @@ func toAssessmentReportResponse(report *AssessmentReportOutput) *evaluation.Asse
 		Suggestions:  toSuggestionResponses(report.Suggestions),
 		ModelExtra:   toModelExtraResponse(report.ModelExtra),
 		CreatedAt:    report.CreatedAt,
+
+		ClinicianAddendum: toClinicianAddendumResponse(report.ClinicianAddendum),
+	}
+}
+
+func toClinicianAddendumResponse(addendum *ClinicianAddendumOutput) *evaluation.ClinicianAddendumResponse {
+	if addendum == nil {
+		return nil
+	}
+	return &evaluation.ClinicianAddendumResponse{
+		Content:      addendum.Content,
+		ReviewerName: addendum.ReviewerName,
+		ReviewStatus: addendum.ReviewStatus,
+		ReviewedAt:   addendum.ReviewedAt,
 	}
 }
 
@@ -0,0 +1,44 @@
+package grpcbridge
+
+import (
+	"context"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportpdf"
+)
+
+// ReportPDFGateway 将 infra gRPC 输出转换为 reportpdf application DTO。
+type ReportPDFGateway struct {
+	client ReportPDFClient
+}
+
+// NewReportPDFGateway 构造报告 PDF 适配器。
+func NewReportPDFGateway(client ReportPDFClient) *ReportPDFGateway {
+	return &ReportPDFGateway{client: client}
+}
+
+func (g *ReportPDFGateway) IssueLink(ctx context.Context, testeeID, assessmentID uint64) (*reportpdf.LinkResponse, error) {
+	return CallBridge(g.client,
+		func() (*ReportPDFLinkOutput, error) {
+			return g.client.IssueReportPDFLink(ctx, testeeID, assessmentID)
+		},
+		func(out *ReportPDFLinkOutput) *reportpdf.LinkResponse {
+			return &reportpdf.LinkResponse{
+				Token:       out.Token,
+				ExpiresAt:   out.ExpiresAt,
+				FileName:    out.FileName,
+				ContentHash: out.ContentHash,
+			}
+		},
+	)
+}
+
+func (g *ReportPDFGateway) Download(ctx context.Context, token string) (*reportpdf.File, error) {
+	return CallBridge(g.client,
+		func() (*ReportPDFOutput, error) {
+			return g.client.DownloadReportPDF(ctx, token)
+		},
+		func(out *ReportPDFOutput) *reportpdf.File {
+			return &reportpdf.File{Content: out.Content, FileName: out.FileName, ContentType: out.ContentType}
+		},
+	)
+}
@@ -0,0 +1,111 @@
+package grpcbridge
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportshare"
+)
+
+// ReportShareGateway 将 infra gRPC 输出转换为 reportshare application DTO。
+type ReportShareGateway struct {
+	client ReportShareClient
+}
+
+// NewReportShareGateway 构造报告分享适配器。
+func NewReportShareGateway(client ReportShareClient) *ReportShareGateway {
+	return &ReportShareGateway{client: client}
+}
+
+var _ reportshare.Gateway = (*ReportShareGateway)(nil)
+
+func (g *ReportShareGateway) Create(ctx context.Context, testeeID, assessmentID uint64, req *reportshare.CreateRequest) (*reportshare.CreatedResponse, error) {
+	return CallBridge(g.client,
+		func() (*CreatedReportShareOutput, error) {
+			return g.client.CreateReportShare(ctx, &CreateReportShareInput{
+				TesteeID:     testeeID,
+				AssessmentID: assessmentID,
+				Kind:         req.Kind,
+				Audience:     req.Audience,
+				TTLSeconds:   req.TTLHours * 3600,
+				MaxViews:     req.MaxViews,
+				PIN:          req.PIN,
+				Label:        req.Label,
+			})
+		},
+		func(out *CreatedReportShareOutput) *reportshare.CreatedResponse {
+			return &reportshare.CreatedResponse{
+				Share:      toReportShareResponse(out.Share),
+				Token:      out.Token,
+				AccessCode: out.AccessCode,
+			}
+		},
+	)
+}
+
+func (g *ReportShareGateway) List(ctx context.Context, testeeID, assessmentID uint64) (*reportshare.ListResponse, error) {
+	return CallBridge(g.client,
+		func() ([]*ReportShareOutput, error) {
+			return g.client.ListReportShares(ctx, testeeID, assessmentID)
+		},
+		func(out []*ReportShareOutput) *reportshare.ListResponse {
+			items := make([]*reportshare.ShareResponse, 0, len(out))
+			for _, item := range out {
+				items = append(items, toReportShareResponse(item))
+			}
+			return &reportshare.ListResponse{Items: items}
+		},
+	)
+}
+
+func (g *ReportShareGateway) Revoke(ctx context.Context, testeeID, shareID uint64) (*reportshare.ShareResponse, error) {
+	return CallBridge(g.client,
+		func() (*ReportShareOutput, error) {
+			return g.client.RevokeReportShare(ctx, testeeID, shareID)
+		},
+		toReportShareResponse,
+	)
+}
+
+func (g *ReportShareGateway) Open(ctx context.Context, input *reportshare.OpenInput) (*reportshare.SharedReportResponse, error) {
+	return CallBridge(g.client,
+		func() (*SharedReportOutput, error) {
+			return g.client.OpenReportShare(ctx, &OpenReportShareInput{
+				Token:      input.Token,
+				AccessCode: input.AccessCode,
+				PIN:        input.PIN,
+				ClientIP:   input.ClientIP,
+				UserAgent:  input.UserAgent,
+			})
+		},
+		func(out *SharedReportOutput) *reportshare.SharedReportResponse {
+			return &reportshare.SharedReportResponse{
+				Report:         toAssessmentReportResponse(out.Report),
+				Audience:       out.Audience,
+				ExpiresAt:      out.ExpiresAt,
+				RemainingViews: out.RemainingViews,
+			}
+		},
+	)
+}
+
+func toReportShareResponse(share *ReportShareOutput) *reportshare.ShareResponse {
+	if share == nil {
+		return nil
+	}
+	return &reportshare.ShareResponse{
+		ID:           strconv.FormatUint(share.ID, 10),
+		AssessmentID: strconv.FormatUint(share.AssessmentID, 10),
+		Kind:         share.Kind,
+		Audience:     share.Audience,
+		Label:        share.Label,
+		PINProtected: share.PINProtected,
+		MaxViews:     share.MaxViews,
+		ViewCount:    share.ViewCount,
+		Status:       share.Status,
+		ExpiresAt:    share.ExpiresAt,
+		CreatedAt:    share.CreatedAt,
+		LastViewedAt: share.LastViewedAt,
+		RevokedAt:    share.RevokedAt,
+	}
+}
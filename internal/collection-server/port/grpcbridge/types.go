@@ -15,6 +15,10 @@ type (
 	AssessmentReportOutput            = grpcclient.AssessmentReportOutput
 	AssessmentSummaryOutput           = grpcclient.AssessmentSummaryOutput
 	DimensionInterpretOutput          = grpcclient.DimensionInterpretOutput
+	AcceptConsentInput                = grpcclient.AcceptConsentInput
+	ConsentAcceptanceOutput           = grpcclient.ConsentAcceptanceOutput
+	ClinicianAddendumOutput           = grpcclient.ClinicianAddendumOutput
+	ConsentDocumentOutput             = grpcclient.ConsentDocumentOutput
 	CreateTesteeRequest               = grpcclient.CreateTesteeRequest
 	FactorScoreOutput                 = grpcclient.FactorScoreOutput
 	ListAssessmentsOutput             = grpcclient.ListAssessmentsOutput
@@ -26,13 +30,24 @@ type (
 	ModelIdentityOutput               = grpcclient.ModelIdentityOutput
 	QuestionOutput                    = grpcclient.QuestionOutput
 	QuestionnaireOutput               = grpcclient.QuestionnaireOutput
+	ReportPDFLinkOutput               = grpcclient.ReportPDFLinkOutput
+	ReportPDFOutput                   = grpcclient.ReportPDFOutput
+	ReportShareOutput                 = grpcclient.ReportShareOutput
+	CreateReportShareInput            = grpcclient.CreateReportShareInput
+	CreatedReportShareOutput          = grpcclient.CreatedReportShareOutput
+	OpenReportShareInput              = grpcclient.OpenReportShareInput
+	SharedReportOutput                = grpcclient.SharedReportOutput
+	RequiredConsentsOutput            = grpcclient.RequiredConsentsOutput
 	ResultLevelOutput                 = grpcclient.ResultLevelOutput
 	SaveAnswerSheetInput              = grpcclient.SaveAnswerSheetInput
 	SaveAnswerSheetOutput             = grpcclient.SaveAnswerSheetOutput
+	SafeMessagingOutput               = grpcclient.SafeMessagingOutput
+	HotlineOutput                     = grpcclient.HotlineOutput
 	ScoreValueOutput                  = grpcclient.ScoreValueOutput
 	SuggestionOutput                  = grpcclient.SuggestionOutput
 	TesteeResponse                    = grpcclient.TesteeResponse
 	TesteeCareContextResponse         = grpcclient.TesteeCareContextResponse
+	TesteeCalendarResponse            = grpcclient.TesteeCalendarResponse
 	TrendPointOutput                  = grpcclient.TrendPointOutput
 	UpdateTesteeRequest               = grpcclient.UpdateTesteeRequest
 )
@@ -48,6 +48,7 @@ func NewAnswerSheetHandler(submissionService answerSheetSubmissionService) *Answ
 // @Failure 401 {object} core.ErrResponse
 // @Failure 403 {object} core.ErrResponse
 // @Failure 409 {object} core.ErrResponse
+// @Failure 412 {object} core.ErrResponse
 // @Failure 503 {object} core.ErrResponse
 // @Security BearerAuth
 // @Router /api/v1/answersheets [post]
@@ -93,6 +94,7 @@ func (h *AnswerSheetHandler) Submit(c *gin.Context) {
 			Status:        "accepted",
 			RequestID:     requestID,
 			AnswerSheetID: result.ID,
+			SafeMessaging: result.SafeMessaging,
 		},
 	})
 }
@@ -139,6 +141,8 @@ func (h *AnswerSheetHandler) respondSubmitError(c *gin.Context, err error) {
 		})
 	case codes.AlreadyExists:
 		c.JSON(http.StatusConflict, core.ErrResponse{Code: http.StatusConflict, Message: st.Message()})
+	case codes.FailedPrecondition:
+		c.JSON(http.StatusPreconditionFailed, core.ErrResponse{Code: http.StatusPreconditionFailed, Message: st.Message()})
 	default:
 		ratelimit.ApplyRetryAfterSeconds(c.Writer.Header(), 1)
 		c.JSON(http.StatusServiceUnavailable, core.ErrResponse{Code: http.StatusServiceUnavailable, Message: "submit dependency unavailable"})
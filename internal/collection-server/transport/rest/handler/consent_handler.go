@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/consent"
+	pkgmiddleware "github.com/FangcunMount/qs-server/internal/pkg/middleware"
+	"github.com/FangcunMount/qs-server/pkg/core"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// ConsentHandler 知情同意处理器
+type ConsentHandler struct {
+	*BaseHandler
+	consentService *consent.Service
+}
+
+// NewConsentHandler 创建知情同意处理器
+func NewConsentHandler(consentService *consent.Service) *ConsentHandler {
+	return &ConsentHandler{
+		BaseHandler:    NewBaseHandler(),
+		consentService: consentService,
+	}
+}
+
+// ListRequirements 查询作答前需要签署的同意书
+// @Summary 查询作答前需要签署的同意书
+// @Description 按机构、问卷（测评模型）与测评入口三级范围返回当前生效的同意书版本及受试者的有效签署；satisfied 为 false 时提交答卷会被拒绝（412）。
+// @Tags 知情同意
+// @Produce json
+// @Param id path int true "受试者ID"
+// @Param questionnaire_code query string false "问卷编码"
+// @Param entry_id query string false "测评入口ID"
+// @Success 200 {object} core.Response{data=consent.RequirementsResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/testees/{id}/consents [get]
+func (h *ConsentHandler) ListRequirements(c *gin.Context) {
+	testeeID, ok := h.testeeID(c)
+	if !ok {
+		return
+	}
+	var req consent.ListRequirementsRequest
+	if err := h.BindQuery(c, &req); err != nil {
+		return
+	}
+	result, err := h.consentService.ListRequirements(c.Request.Context(), testeeID, &req)
+	if err != nil {
+		h.respondConsentError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+// Accept 签署同意书
+// @Summary 签署同意书
+// @Description 当前用户以受试者本人（self）或监护人（guardian）身份签署已发布的同意书；未成年受试者只能由监护人签署。重复签署同一版本幂等返回原记录。
+// @Tags 知情同意
+// @Accept json
+// @Produce json
+// @Param id path int true "受试者ID"
+// @Param request body consent.AcceptRequest true "签署数据"
+// @Success 200 {object} core.Response{data=consent.AcceptanceResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 412 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/testees/{id}/consents [post]
+func (h *ConsentHandler) Accept(c *gin.Context) {
+	testeeID, ok := h.testeeID(c)
+	if !ok {
+		return
+	}
+	var req consent.AcceptRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+	userID := h.GetUserID(c)
+	if userID == 0 {
+		h.UnauthorizedResponse(c, "user not authenticated")
+		return
+	}
+	requestID := pkgmiddleware.GetRequestIDFromContext(c)
+	if requestID == "" {
+		requestID = pkgmiddleware.GetRequestIDFromHeaders(c)
+	}
+	result, err := h.consentService.Accept(c.Request.Context(), userID, testeeID, &req, consent.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: requestID,
+	})
+	if err != nil {
+		h.respondConsentError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+// Withdraw 撤回签署
+// @Summary 撤回知情同意
+// @Description 撤回后需重新签署才能继续提交答卷；撤回会通知机构。
+// @Tags 知情同意
+// @Accept json
+// @Produce json
+// @Param id path int true "受试者ID"
+// @Param acceptance_id path int true "签署记录ID"
+// @Param request body consent.WithdrawRequest false "撤回原因"
+// @Success 200 {object} core.Response{data=consent.AcceptanceResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 412 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/testees/{id}/consents/{acceptance_id}/withdraw [post]
+func (h *ConsentHandler) Withdraw(c *gin.Context) {
+	testeeID, ok := h.testeeID(c)
+	if !ok {
+		return
+	}
+	acceptanceID, err := strconv.ParseUint(h.GetPathParam(c, "acceptance_id"), 10, 64)
+	if err != nil || acceptanceID == 0 {
+		h.BadRequestResponse(c, "invalid acceptance id", err)
+		return
+	}
+	var req consent.WithdrawRequest
+	if c.Request.ContentLength > 0 {
+		if err := h.BindJSON(c, &req); err != nil {
+			return
+		}
+	}
+	userID := h.GetUserID(c)
+	if userID == 0 {
+		h.UnauthorizedResponse(c, "user not authenticated")
+		return
+	}
+	result, err := h.consentService.Withdraw(c.Request.Context(), userID, testeeID, acceptanceID, &req)
+	if err != nil {
+		h.respondConsentError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+func (h *ConsentHandler) testeeID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(h.GetPathParam(c, "id"), 10, 64)
+	if err != nil || id == 0 {
+		h.BadRequestResponse(c, "invalid id format", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *ConsentHandler) respondConsentError(c *gin.Context, err error) {
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		h.InternalErrorResponse(c, "consent request failed", err)
+		return
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, core.ErrResponse{Code: http.StatusBadRequest, Message: st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, core.ErrResponse{Code: http.StatusForbidden, Message: st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, core.ErrResponse{Code: http.StatusNotFound, Message: st.Message()})
+	case codes.FailedPrecondition:
+		c.JSON(http.StatusPreconditionFailed, core.ErrResponse{Code: http.StatusPreconditionFailed, Message: st.Message()})
+	default:
+		c.JSON(http.StatusServiceUnavailable, core.ErrResponse{Code: http.StatusServiceUnavailable, Message: "consent dependency unavailable"})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportpdf"
+	"github.com/FangcunMount/qs-server/pkg/core"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// ReportPDFHandler 报告 PDF 处理器
+type ReportPDFHandler struct {
+	*BaseHandler
+	reportPDFService *reportpdf.Service
+}
+
+// NewReportPDFHandler 创建报告 PDF 处理器
+func NewReportPDFHandler(reportPDFService *reportpdf.Service) *ReportPDFHandler {
+	return &ReportPDFHandler{
+		BaseHandler:      NewBaseHandler(),
+		reportPDFService: reportPDFService,
+	}
+}
+
+// IssueLink 签发报告 PDF 下载链接
+// @Summary 获取报告 PDF 下载链接
+// @Description 返回测评最新报告 PDF 的短期签名下载地址；PDF 在报告生成后异步渲染，尚未完成时返回 404，可稍后重试。
+// @Tags 测评
+// @Produce json
+// @Param id path int true "测评ID"
+// @Param testee_id query int true "受试者ID"
+// @Success 200 {object} core.Response{data=reportpdf.LinkResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/assessments/{id}/report/pdf-link [get]
+func (h *ReportPDFHandler) IssueLink(c *gin.Context) {
+	testeeID, err := strconv.ParseUint(h.GetQueryParam(c, "testee_id"), 10, 64)
+	if err != nil || testeeID == 0 {
+		h.BadRequestResponse(c, "invalid testee_id format", err)
+		return
+	}
+	assessmentID, err := strconv.ParseUint(h.GetPathParam(c, "id"), 10, 64)
+	if err != nil || assessmentID == 0 {
+		h.BadRequestResponse(c, "invalid assessment id", err)
+		return
+	}
+	result, err := h.reportPDFService.IssueLink(c.Request.Context(), testeeID, assessmentID)
+	if err != nil {
+		h.respondReportPDFError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+// Download 按令牌下载报告 PDF
+// @Summary 下载报告 PDF
+// @Description 按签名令牌下载报告 PDF，无需登录；令牌过期或被篡改时返回 403。
+// @Tags 测评
+// @Produce application/pdf
+// @Param token path string true "下载令牌"
+// @Success 200 {file} binary
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v1/public/report-pdfs/{token} [get]
+func (h *ReportPDFHandler) Download(c *gin.Context) {
+	file, err := h.reportPDFService.Download(c.Request.Context(), h.GetPathParam(c, "token"))
+	if err != nil {
+		h.respondReportPDFError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (h *ReportPDFHandler) respondReportPDFError(c *gin.Context, err error) {
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		h.InternalErrorResponse(c, "report pdf request failed", err)
+		return
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, core.ErrResponse{Code: http.StatusBadRequest, Message: st.Message()})
+	case codes.PermissionDenied, codes.Unauthenticated:
+		c.JSON(http.StatusForbidden, core.ErrResponse{Code: http.StatusForbidden, Message: st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, core.ErrResponse{Code: http.StatusNotFound, Message: st.Message()})
+	default:
+		c.JSON(http.StatusServiceUnavailable, core.ErrResponse{Code: http.StatusServiceUnavailable, Message: "report pdf dependency unavailable"})
+	}
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/collection-server/application/reportshare"
+	"github.com/FangcunMount/qs-server/pkg/core"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// SharePINHeader 通过链接查看受 PIN 保护的分享时携带 PIN 的请求头。
+const SharePINHeader = "X-Share-PIN"
+
+// ReportShareHandler 报告分享处理器
+type ReportShareHandler struct {
+	*BaseHandler
+	reportShareService *reportshare.Service
+}
+
+// NewReportShareHandler 创建报告分享处理器
+func NewReportShareHandler(reportShareService *reportshare.Service) *ReportShareHandler {
+	return &ReportShareHandler{
+		BaseHandler:        NewBaseHandler(),
+		reportShareService: reportShareService,
+	}
+}
+
+// Create 创建报告分享
+// @Summary 创建报告分享
+// @Description 为测评最新报告创建限时链接（kind=link）或访问码（kind=code），可设置 PIN、浏览次数上限，并指定分享对象看到的受众版本。链接或访问码只在本次响应中返回。
+// @Tags 测评
+// @Accept json
+// @Produce json
+// @Param id path int true "测评ID"
+// @Param testee_id query int true "受试者ID"
+// @Param request body reportshare.CreateRequest true "分享设置"
+// @Success 200 {object} core.Response{data=reportshare.CreatedResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 409 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/assessments/{id}/report/shares [post]
+func (h *ReportShareHandler) Create(c *gin.Context) {
+	testeeID, assessmentID, ok := h.testeeAssessment(c)
+	if !ok {
+		return
+	}
+	var req reportshare.CreateRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+	result, err := h.reportShareService.Create(c.Request.Context(), testeeID, assessmentID, &req)
+	if err != nil {
+		h.respondReportShareError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	h.Success(c, result)
+}
+
+// List 列出报告分享
+// @Summary 列出报告分享
+// @Description 列出测评报告的全部分享及其状态与浏览次数；不返回链接或访问码。
+// @Tags 测评
+// @Produce json
+// @Param id path int true "测评ID"
+// @Param testee_id query int true "受试者ID"
+// @Success 200 {object} core.Response{data=reportshare.ListResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/assessments/{id}/report/shares [get]
+func (h *ReportShareHandler) List(c *gin.Context) {
+	testeeID, assessmentID, ok := h.testeeAssessment(c)
+	if !ok {
+		return
+	}
+	result, err := h.reportShareService.List(c.Request.Context(), testeeID, assessmentID)
+	if err != nil {
+		h.respondReportShareError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+// Revoke 撤销报告分享
+// @Summary 撤销报告分享
+// @Description 立即撤销分享，之后的访问一律拒绝；重复撤销幂等。
+// @Tags 测评
+// @Produce json
+// @Param id path int true "测评ID"
+// @Param share_id path int true "分享ID"
+// @Param testee_id query int true "受试者ID"
+// @Success 200 {object} core.Response{data=reportshare.ShareResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/assessments/{id}/report/shares/{share_id}/revoke [post]
+func (h *ReportShareHandler) Revoke(c *gin.Context) {
+	testeeID, _, ok := h.testeeAssessment(c)
+	if !ok {
+		return
+	}
+	shareID, err := strconv.ParseUint(h.GetPathParam(c, "share_id"), 10, 64)
+	if err != nil || shareID == 0 {
+		h.BadRequestResponse(c, "invalid share id", err)
+		return
+	}
+	result, err := h.reportShareService.Revoke(c.Request.Context(), testeeID, shareID)
+	if err != nil {
+		h.respondReportShareError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+// OpenLink 按分享链接查看报告
+// @Summary 查看分享报告（链接）
+// @Description 按分享令牌返回只读报告，无需登录；受 PIN 保护的分享需在 X-Share-PIN 请求头中提供 PIN。每次访问都会留痕并计入浏览次数。
+// @Tags 测评
+// @Produce json
+// @Param token path string true "分享令牌"
+// @Param X-Share-PIN header string false "分享 PIN"
+// @Success 200 {object} core.Response{data=reportshare.SharedReportResponse}
+// @Failure 401 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 404 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v1/public/report-shares/{token} [get]
+func (h *ReportShareHandler) OpenLink(c *gin.Context) {
+	h.open(c, &reportshare.OpenInput{
+		Token: h.GetPathParam(c, "token"),
+		PIN:   c.GetHeader(SharePINHeader),
+	})
+}
+
+// OpenCode 凭访问码查看报告
+// @Summary 查看分享报告（访问码）
+// @Description 凭访问码（及可选 PIN）返回只读报告，无需登录；访问码不区分大小写，可省略连字符。
+// @Tags 测评
+// @Accept json
+// @Produce json
+// @Param request body reportshare.AccessRequest true "访问码"
+// @Success 200 {object} core.Response{data=reportshare.SharedReportResponse}
+// @Failure 400 {object} core.ErrResponse
+// @Failure 401 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 503 {object} core.ErrResponse
+// @Router /api/v1/public/report-shares/access [post]
+func (h *ReportShareHandler) OpenCode(c *gin.Context) {
+	var req reportshare.AccessRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+	h.open(c, &reportshare.OpenInput{AccessCode: req.AccessCode, PIN: req.PIN})
+}
+
+func (h *ReportShareHandler) open(c *gin.Context, input *reportshare.OpenInput) {
+	input.ClientIP = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	result, err := h.reportShareService.Open(c.Request.Context(), input)
+	if err != nil {
+		h.respondReportShareError(c, err)
+		return
+	}
+	h.Success(c, result)
+}
+
+func (h *ReportShareHandler) testeeAssessment(c *gin.Context) (uint64, uint64, bool) {
+	testeeID, err := strconv.ParseUint(h.GetQueryParam(c, "testee_id"), 10, 64)
+	if err != nil || testeeID == 0 {
+		h.BadRequestResponse(c, "invalid testee_id format", err)
+		return 0, 0, false
+	}
+	assessmentID, err := strconv.ParseUint(h.GetPathParam(c, "id"), 10, 64)
+	if err != nil || assessmentID == 0 {
+		h.BadRequestResponse(c, "invalid assessment id", err)
+		return 0, 0, false
+	}
+	return testeeID, assessmentID, true
+}
+
+func (h *ReportShareHandler) respondReportShareError(c *gin.Context, err error) {
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		h.InternalErrorResponse(c, "report share request failed", err)
+		return
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, core.ErrResponse{Code: http.StatusBadRequest, Message: st.Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, core.ErrResponse{Code: http.StatusUnauthorized, Message: st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, core.ErrResponse{Code: http.StatusForbidden, Message: st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, core.ErrResponse{Code: http.StatusNotFound, Message: st.Message()})
+	case codes.ResourceExhausted:
+		c.JSON(http.StatusConflict, core.ErrResponse{Code: http.StatusConflict, Message: st.Message()})
+	default:
+		c.JSON(http.StatusServiceUnavailable, core.ErrResponse{Code: http.StatusServiceUnavailable, Message: "report share dependency unavailable"})
+	}
+}
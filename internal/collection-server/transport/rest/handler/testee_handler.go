@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/FangcunMount/component-base/pkg/log"
@@ -118,6 +119,37 @@ func (h *TesteeHandler) GetCareContext(c *gin.Context) {
 	h.Success(c, result)
 }
 
+// ExportCalendar 导出受试者待完成任务日历
+// @Summary 导出受试者待完成任务的 ICS 日历
+// @Description 导出受试者 pending/opened 且未过截止时间的计划任务（iCalendar / RFC 5545），供家长导入手机或邮箱日历
+// @Tags 受试者
+// @Produce text/calendar
+// @Param id path int true "受试者ID"
+// @Success 200 {string} string "ICS 文件内容"
+// @Failure 400 {object} core.ErrResponse
+// @Failure 403 {object} core.ErrResponse
+// @Failure 500 {object} core.ErrResponse
+// @Security BearerAuth
+// @Router /api/v1/testees/{id}/tasks/calendar.ics [get]
+func (h *TesteeHandler) ExportCalendar(c *gin.Context) {
+	idStr := h.GetPathParam(c, "id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.BadRequestResponse(c, "invalid id format", err)
+		return
+	}
+
+	result, err := h.testeeService.ExportTesteeCalendar(c.Request.Context(), id)
+	if err != nil {
+		h.InternalErrorResponse(c, "export testee calendar failed", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+result.FileName+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", result.Content)
+}
+
 // Update 更新受试者信息
 // @Summary 更新受试者信息
 // @Description 更新受试者的基本信息
@@ -39,6 +39,17 @@ func TestCollectionOpenAPIContractCoversKeyRoutes(t *testing.T) {
 	assertOpenAPIOperation(t, spec, "/behavior-assessments/{id}/wait-report", "get")
 	assertOpenAPIOperation(t, spec, "/report-events", "get")
 	assertOpenAPIOperation(t, spec, "/testees/{id}/care-context", "get")
+	assertOpenAPIOperation(t, spec, "/assessments/{id}/report/pdf-link", "get")
+	assertOpenAPIOperation(t, spec, "/public/report-pdfs/{token}", "get")
+	assertOpenAPIOperation(t, spec, "/assessments/{id}/report/shares", "post")
+	assertOpenAPIOperation(t, spec, "/assessments/{id}/report/shares", "get")
+	assertOpenAPIOperation(t, spec, "/assessments/{id}/report/shares/{share_id}/revoke", "post")
+	assertOpenAPIOperation(t, spec, "/public/report-shares/{token}", "get")
+	assertOpenAPIOperation(t, spec, "/public/report-shares/access", "post")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/consents", "get")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/consents", "post")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/consents/{acceptance_id}/withdraw", "post")
+	assertOpenAPIOperation(t, spec, "/testees/{id}/tasks/calendar.ics", "get")
 	assertOpenAPIOperation(t, spec, "/health", "get")
 }
 
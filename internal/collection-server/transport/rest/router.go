@@ -81,6 +81,34 @@ func (r *Router) registerPublicRoutes(engine *gin.Engine) {
 	publicAPI := engine.Group("/api/v1/public")
 	{
 		publicAPI.GET("/info", healthHandler.Info)
+		// 报告 PDF 下载：签名令牌即凭证，不经过 IAM 认证
+		if reportPDFHandler := r.container.ReportPDFHandler(); reportPDFHandler != nil {
+			publicAPI.GET("/report-pdfs/:token", reportPDFHandler.Download)
+		}
+		// 报告分享查看：链接令牌或访问码即凭证，按客户端 IP 限流以抑制猜测
+		if reportShareHandler := r.container.ReportShareHandler(); reportShareHandler != nil {
+			rateCfg := ensureRateLimitOptions(r.container.RateLimitOptions())
+			publicAPI.GET("/report-shares/:token", r.rateLimitedQueryHandlers(
+				r.container.RateLimitBackend(),
+				"query",
+				rateCfg,
+				rateCfg.QueryGlobalQPS,
+				rateCfg.QueryGlobalBurst,
+				rateCfg.QueryUserQPS,
+				rateCfg.QueryUserBurst,
+				reportShareHandler.OpenLink,
+			)...)
+			publicAPI.POST("/report-shares/access", r.rateLimitedQueryHandlers(
+				r.container.RateLimitBackend(),
+				"query",
+				rateCfg,
+				rateCfg.QueryGlobalQPS,
+				rateCfg.QueryGlobalBurst,
+				rateCfg.QueryUserQPS,
+				rateCfg.QueryUserBurst,
+				reportShareHandler.OpenCode,
+			)...)
+		}
 	}
 }
 
@@ -414,6 +442,25 @@ func (r *Router) registerEvaluationRoutes(api *gin.RouterGroup) {
 			rateCfg.QueryUserBurst,
 			evaluationHandler.GetAssessmentReport,
 		)...)...)
+		// 报告 PDF 签名下载链接
+		if reportPDFHandler := r.container.ReportPDFHandler(); reportPDFHandler != nil {
+			assessments.GET("/:id/report/pdf-link", append([]gin.HandlerFunc{reportIdentity}, r.rateLimitedQueryHandlers(
+				r.container.RateLimitBackend(),
+				"query",
+				rateCfg,
+				rateCfg.QueryGlobalQPS,
+				rateCfg.QueryGlobalBurst,
+				rateCfg.QueryUserQPS,
+				rateCfg.QueryUserBurst,
+				reportPDFHandler.IssueLink,
+			)...)...)
+		}
+		// 报告对外分享：限时链接或访问码
+		if reportShareHandler := r.container.ReportShareHandler(); reportShareHandler != nil {
+			assessments.POST("/:id/report/shares", append([]gin.HandlerFunc{reportIdentity}, r.submitHandlers(reportShareHandler.Create)...)...)
+			assessments.GET("/:id/report/shares", append([]gin.HandlerFunc{reportIdentity}, r.queryHandlers(reportShareHandler.List)...)...)
+			assessments.POST("/:id/report/shares/:share_id/revoke", append([]gin.HandlerFunc{reportIdentity}, r.submitHandlers(reportShareHandler.Revoke)...)...)
+		}
 		// 测评趋势摘要
 		assessments.GET("/:id/trend-summary", r.rateLimitedQueryHandlers(
 			r.container.RateLimitBackend(),
@@ -591,6 +638,7 @@ func (r *Router) registerTypologyAssessmentSessionRoutes(api *gin.RouterGroup) {
 // registerTesteeRoutes 注册受试者相关路由
 func (r *Router) registerTesteeRoutes(api *gin.RouterGroup) {
 	testeeHandler := r.container.TesteeHandler()
+	testeeAccess := collectionmiddleware.TesteeAccessMiddleware(r.container.TesteeAccessAuthorizer(), "id")
 
 	testees := api.Group("/testees")
 	{
@@ -604,9 +652,25 @@ func (r *Router) registerTesteeRoutes(api *gin.RouterGroup) {
 		testees.GET("/:id", r.queryHandlers(testeeHandler.Get)...)
 		// 获取受试者照护上下文
 		testees.GET("/:id/care-context", r.queryHandlers(testeeHandler.GetCareContext)...)
+		// 导出待完成任务日历（ICS）
+		testees.GET("/:id/tasks/calendar.ics", append([]gin.HandlerFunc{testeeAccess}, r.queryHandlers(testeeHandler.ExportCalendar)...)...)
 		// 更新受试者信息
 		testees.PUT("/:id", r.submitHandlers(testeeHandler.Update)...)
 	}
+
+	consentHandler := r.container.ConsentHandler()
+	if consentHandler == nil {
+		return
+	}
+	consents := testees.Group("/:id/consents", testeeAccess)
+	{
+		// 作答前需要签署的同意书
+		consents.GET("", r.queryHandlers(consentHandler.ListRequirements)...)
+		// 签署同意书
+		consents.POST("", r.submitHandlers(consentHandler.Accept)...)
+		// 撤回签署
+		consents.POST("/:acceptance_id/withdraw", r.submitHandlers(consentHandler.Withdraw)...)
+	}
 }
 
 func (r *Router) registerReportEventsRoutes(api *gin.RouterGroup) {
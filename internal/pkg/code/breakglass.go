@@ -0,0 +1,15 @@
+package code
+
+// break-glass errors (118xxx).
+const (
+	// ErrBreakGlassGrantNotFound - 404: Break-glass grant not found.
+	ErrBreakGlassGrantNotFound int = iota + 118001
+
+	// ErrBreakGlassConflict - 409: Break-glass request conflicts with current access state.
+	ErrBreakGlassConflict
+)
+
+func init() {
+	register(ErrBreakGlassGrantNotFound, 404, "Break-glass grant not found")
+	register(ErrBreakGlassConflict, 409, "Break-glass request conflicts with current access")
+}
@@ -0,0 +1,15 @@
+package code
+
+// care team errors (122xxx).
+const (
+	// ErrCareTeamNotFound - 404: Care team not found.
+	ErrCareTeamNotFound int = iota + 122001
+
+	// ErrCareTeamConflict - 409: Care team conflicts with an existing team or membership.
+	ErrCareTeamConflict
+)
+
+func init() {
+	register(ErrCareTeamNotFound, 404, "Care team not found")
+	register(ErrCareTeamConflict, 409, "Care team conflicts with an existing team or membership")
+}
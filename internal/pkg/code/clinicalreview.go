@@ -0,0 +1,11 @@
+package code
+
+// clinical review errors (123xxx).
+const (
+	// ErrReportReviewConflict - 409: Report review state conflicts with the requested transition.
+	ErrReportReviewConflict int = iota + 123001
+)
+
+func init() {
+	register(ErrReportReviewConflict, 409, "Report review state conflicts with the requested transition")
+}
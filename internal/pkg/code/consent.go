@@ -0,0 +1,23 @@
+package code
+
+// consent errors (117xxx).
+const (
+	// ErrConsentRequired - 403: A valid informed consent is required.
+	ErrConsentRequired int = iota + 117001
+
+	// ErrConsentDocumentNotFound - 404: Consent document not found.
+	ErrConsentDocumentNotFound
+
+	// ErrConsentInvalid - 400: Consent document or acceptance is invalid.
+	ErrConsentInvalid
+
+	// ErrConsentAcceptanceNotFound - 404: Consent acceptance not found.
+	ErrConsentAcceptanceNotFound
+)
+
+func init() {
+	register(ErrConsentRequired, 403, "Informed consent is required")
+	register(ErrConsentDocumentNotFound, 404, "Consent document not found")
+	register(ErrConsentInvalid, 400, "Consent is invalid")
+	register(ErrConsentAcceptanceNotFound, 404, "Consent acceptance not found")
+}
@@ -0,0 +1,15 @@
+package code
+
+// custom role errors (121xxx).
+const (
+	// ErrCustomRoleNotFound - 404: Custom role not found.
+	ErrCustomRoleNotFound int = iota + 121001
+
+	// ErrCustomRoleConflict - 409: Custom role conflicts with an existing role or assignment.
+	ErrCustomRoleConflict
+)
+
+func init() {
+	register(ErrCustomRoleNotFound, 404, "Custom role not found")
+	register(ErrCustomRoleConflict, 409, "Custom role conflicts with an existing role or assignment")
+}
@@ -0,0 +1,19 @@
+package code
+
+// data subject request errors (119xxx).
+const (
+	// ErrDataSubjectRequestNotFound - 404: Data subject request not found.
+	ErrDataSubjectRequestNotFound int = iota + 119001
+
+	// ErrDataSubjectConflict - 409: Data subject request conflicts with its current state.
+	ErrDataSubjectConflict
+
+	// ErrDataSubjectBundleUnavailable - 404: Export bundle is no longer available.
+	ErrDataSubjectBundleUnavailable
+)
+
+func init() {
+	register(ErrDataSubjectRequestNotFound, 404, "Data subject request not found")
+	register(ErrDataSubjectConflict, 409, "Data subject request conflicts with its current state")
+	register(ErrDataSubjectBundleUnavailable, 404, "Data subject export bundle is no longer available")
+}
@@ -15,7 +15,21 @@
 //	113xxx: 计算错误 (calculation.go)
 //	114xxx: 量表错误 (medical-scale.go)
 //	115xxx: 报告错误 (interpret-report.go)
+//	116xxx: 统计错误 (statistics.go)
+//	117xxx: 知情同意错误 (consent.go)
+//	118xxx: 紧急访问错误 (breakglass.go)
+//	119xxx: 数据主体请求错误 (datasubject.go)
 //	120xxx: 问卷错误 (questionnaire.go)
+//	121xxx: 自定义角色错误 (customrole.go)
+//	122xxx: 照护团队错误 (careteam.go)
+//	123xxx: 临床复核错误 (clinicalreview.go)
+//	124xxx: 工作台分诊错误 (workbenchtriage.go)
+//	125xxx: 风险预警错误 (riskalert.go)
+//	126xxx: 报告 PDF 错误 (reportpdf.go)
+//	127xxx: 纵向计划报告错误 (planreport.go)
+//	128xxx: 报告分享错误 (reportshare.go)
+//	129xxx: 群体报告错误 (groupreport.go)
+//	130xxx: 报告图表错误 (reportchart.go)
 //
 // Allowed HTTP status codes:
 //
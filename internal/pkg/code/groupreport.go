@@ -0,0 +1,19 @@
+package code
+
+// group report errors (129xxx).
+const (
+	// ErrGroupReportNotFound - 404: Group report does not exist.
+	ErrGroupReportNotFound int = iota + 129001
+
+	// ErrGroupReportCohortTooSmall - 409: Cohort has fewer assessed members than the minimum cell size.
+	ErrGroupReportCohortTooSmall
+
+	// ErrGroupReportUnsupported - 400: Assessment model has no factor scores to aggregate.
+	ErrGroupReportUnsupported
+)
+
+func init() {
+	register(ErrGroupReportNotFound, 404, "Group report not found")
+	register(ErrGroupReportCohortTooSmall, 409, "Cohort is smaller than the minimum cell size")
+	register(ErrGroupReportUnsupported, 400, "Assessment model does not support group reports")
+}
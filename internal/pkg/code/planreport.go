@@ -0,0 +1,19 @@
+package code
+
+// plan report errors (127xxx).
+const (
+	// ErrPlanReportNotFound - 404: Longitudinal plan report has not been generated.
+	ErrPlanReportNotFound int = iota + 127001
+
+	// ErrPlanReportNotReady - 409: Enrollment outcomes are not ready for a longitudinal report.
+	ErrPlanReportNotReady
+
+	// ErrPlanReportUnsupported - 400: Assessment model has no score trajectory.
+	ErrPlanReportUnsupported
+)
+
+func init() {
+	register(ErrPlanReportNotFound, 404, "Longitudinal plan report not found")
+	register(ErrPlanReportNotReady, 409, "Enrollment outcomes are not ready for a longitudinal report")
+	register(ErrPlanReportUnsupported, 400, "Assessment model does not support longitudinal reports")
+}
@@ -0,0 +1,11 @@
+package code
+
+// report chart errors (130xxx).
+const (
+	// ErrReportChartNotFound - 404: Report has no chart with the requested id.
+	ErrReportChartNotFound int = iota + 130001
+)
+
+func init() {
+	register(ErrReportChartNotFound, 404, "Report chart not found")
+}
@@ -0,0 +1,15 @@
+package code
+
+// report pdf errors (126xxx).
+const (
+	// ErrReportPDFNotReady - 404: Report PDF has not been rendered yet.
+	ErrReportPDFNotReady int = iota + 126001
+
+	// ErrReportPDFLinkInvalid - 403: Report PDF download link is invalid or expired.
+	ErrReportPDFLinkInvalid
+)
+
+func init() {
+	register(ErrReportPDFNotReady, 404, "Report PDF is not ready yet")
+	register(ErrReportPDFLinkInvalid, 403, "Report PDF download link is invalid or expired")
+}
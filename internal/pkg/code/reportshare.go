@@ -0,0 +1,23 @@
+package code
+
+// report share errors (128xxx).
+const (
+	// ErrReportShareNotFound - 404: Report share grant does not exist.
+	ErrReportShareNotFound int = iota + 128001
+
+	// ErrReportShareUnavailable - 403: Report share is invalid, expired, revoked or used up.
+	ErrReportShareUnavailable
+
+	// ErrReportSharePINRequired - 401: Report share requires a correct PIN.
+	ErrReportSharePINRequired
+
+	// ErrReportShareLimitExceeded - 409: Too many active shares on one report.
+	ErrReportShareLimitExceeded
+)
+
+func init() {
+	register(ErrReportShareNotFound, 404, "Report share not found")
+	register(ErrReportShareUnavailable, 403, "Report share is invalid, expired, revoked or used up")
+	register(ErrReportSharePINRequired, 401, "Report share requires a correct PIN")
+	register(ErrReportShareLimitExceeded, 409, "Too many active shares on this report")
+}
@@ -0,0 +1,19 @@
+package code
+
+// risk alert errors (125xxx).
+const (
+	// ErrRiskAlertRuleNotFound - 404: Risk alert rule not found.
+	ErrRiskAlertRuleNotFound int = iota + 125001
+
+	// ErrRiskAlertNotFound - 404: Risk alert not found.
+	ErrRiskAlertNotFound
+
+	// ErrRiskAlertConflict - 409: Risk alert state conflicts with the requested action.
+	ErrRiskAlertConflict
+)
+
+func init() {
+	register(ErrRiskAlertRuleNotFound, 404, "Risk alert rule not found")
+	register(ErrRiskAlertNotFound, 404, "Risk alert not found")
+	register(ErrRiskAlertConflict, 409, "Risk alert state conflicts with the requested action")
+}
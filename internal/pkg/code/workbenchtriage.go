@@ -0,0 +1,15 @@
+package code
+
+// workbench triage errors (124xxx).
+const (
+	// ErrWorkbenchTriageItemNotFound - 404: Workbench queue item not found.
+	ErrWorkbenchTriageItemNotFound int = iota + 124001
+
+	// ErrWorkbenchTriageConflict - 409: Workbench queue item triage state conflicts with the requested action.
+	ErrWorkbenchTriageConflict
+)
+
+func init() {
+	register(ErrWorkbenchTriageItemNotFound, 404, "Workbench queue item not found")
+	register(ErrWorkbenchTriageConflict, 409, "Workbench queue item triage state conflicts with the requested action")
+}
@@ -33,6 +33,8 @@ func TestAPIServerDevProdConfigContracts(t *testing.T) {
 			opts := apiserveroptions.NewOptions()
 			loadConfig(t, filepath.Join(repoRoot(t), "configs", name), opts)
 			prepareDelegatedSubjectContract(t, name, opts.DelegatedSubject)
+			prepareRedactionContract(t, name, opts.Redaction)
+			prepareReportPDFContract(t, name, opts.ReportPDF)
 			stubSecureTLSFiles(t, opts.SecureServing)
 			completeAndValidate(t, opts)
 			cfg, err := apiserverconfig.CreateConfigFromOptions(opts)
@@ -145,6 +147,36 @@ func prepareDelegatedSubjectContract(t *testing.T, configName string, opts *dele
 	opts.CurrentKey = "config-contract-test-key"
 }
 
+func prepareRedactionContract(t *testing.T, configName string, opts *apiserveroptions.RedactionOptions) {
+	t.Helper()
+	if !strings.Contains(configName, ".prod.") {
+		return
+	}
+	if opts == nil {
+		t.Fatalf("%s redaction config must be traceable", configName)
+	}
+	if opts.PseudonymSecret != "" {
+		t.Fatalf("%s redaction.pseudonym_secret must not be committed to config", configName)
+	}
+	// Production injects this value through QS_APISERVER_REDACTION_PSEUDONYM_SECRET.
+	opts.PseudonymSecret = "config-contract-test-pseudonym-secret"
+}
+
+func prepareReportPDFContract(t *testing.T, configName string, opts *apiserveroptions.ReportPDFOptions) {
+	t.Helper()
+	if !strings.Contains(configName, ".prod.") {
+		return
+	}
+	if opts == nil {
+		t.Fatalf("%s report_pdf config must be traceable", configName)
+	}
+	if opts.SigningSecret != "" {
+		t.Fatalf("%s report_pdf.signing_secret must not be committed to config", configName)
+	}
+	// Production injects this value through QS_APISERVER_REPORT_PDF_SIGNING_SECRET.
+	opts.SigningSecret = "config-contract-test-signing-secret"
+}
+
 func assertAPIServerGRPCTrustContract(t *testing.T, configName string, opts *apiserveroptions.Options) {
 	t.Helper()
 	if strings.Contains(configName, ".dev.") {
@@ -0,0 +1,42 @@
+// Package csvexport 后台 CSV 导出的公共编码：带 UTF-8 BOM 便于表格软件识别编码，
+// 并中和以公式字符开头的文本单元格（CSV 注入）。
+package csvexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+// Encode 写出 CSV；以 = + - @ 开头的文本单元格加前导单引号，防止表格软件将其当作公式执行。
+func Encode(rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("\ufeff")
+	writer := csv.NewWriter(&buffer)
+	for _, row := range rows {
+		safe := make([]string, len(row))
+		for index, cell := range row {
+			safe[index] = NeutralizeFormula(cell)
+		}
+		if err := writer.Write(safe); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// NeutralizeFormula 中和公式前缀；带符号的数字保持原样。
+func NeutralizeFormula(cell string) string {
+	if cell == "" || strings.IndexByte("=+-@", cell[0]) < 0 {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
@@ -0,0 +1,24 @@
+package csvexport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNeutralizeFormulaKeepsSignedNumbers(t *testing.T) {
+	for input, want := range map[string]string{"-1.5": "-1.5", "+3": "+3", "@cmd": "'@cmd", "-x": "'-x", "plain": "plain"} {
+		if got := NeutralizeFormula(input); got != want {
+			t.Fatalf("NeutralizeFormula(%q)=%q want %q", input, got, want)
+		}
+	}
+}
+
+func TestEncodeWritesBOMAndNeutralizesCells(t *testing.T) {
+	content, err := Encode([][]string{{"name", "note"}, {"=SUM(A1)", "ok"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(content); !strings.HasPrefix(got, "\ufeffname,note\n") || !strings.Contains(got, "'=SUM(A1),ok") {
+		t.Fatalf("content=%q", got)
+	}
+}
@@ -21,6 +21,8 @@ const (
 
 	PurposeGetAssessmentReport = "participant_report.get_assessment_report"
 	PurposeListMyReports       = "participant_report.list_my_reports"
+	PurposeIssueReportPDFLink  = "participant_report.issue_report_pdf_link"
+	PurposeManageReportShares  = "participant_report.manage_report_shares"
 
 	TrustedCallerQSCollection = serviceidentity.CollectionServerServiceID
 )
@@ -113,6 +113,7 @@ func TestCatalogDeliveryClass(t *testing.T) {
 		{TaskExpired, DeliveryClassBestEffort, false},
 		{TaskCanceled, DeliveryClassBestEffort, false},
 		{AnswerSheetSubmitted, DeliveryClassDurableOutbox, true},
+		{AnswerSheetCriticalItemFlagged, DeliveryClassDurableOutbox, true},
 		{EvaluationRequested, DeliveryClassDurableOutbox, true},
 		{EvaluationRetryRequested, DeliveryClassDurableOutbox, true},
 		{EvaluationOutcomeCommitted, DeliveryClassDurableOutbox, true},
@@ -120,6 +121,12 @@ func TestCatalogDeliveryClass(t *testing.T) {
 		{InterpretationReportGenerated, DeliveryClassDurableOutbox, true},
 		{InterpretationReportFailed, DeliveryClassDurableOutbox, true},
 		{InterpretationRetryRequested, DeliveryClassDurableOutbox, true},
+		{ConsentWithdrawn, DeliveryClassDurableOutbox, true},
+		{BreakGlassGranted, DeliveryClassDurableOutbox, true},
+		{BreakGlassReviewed, DeliveryClassDurableOutbox, true},
+		{BreakGlassRevoked, DeliveryClassDurableOutbox, true},
+		{RiskAlertRaised, DeliveryClassDurableOutbox, true},
+		{RiskAlertEscalated, DeliveryClassDurableOutbox, true},
 	}
 
 	if len(tests) != len(EventTypes()) {
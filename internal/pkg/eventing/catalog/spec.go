@@ -97,17 +97,53 @@ func DefaultSpecs() []EventSpec {
 				SettlementPolicy:  SettlementHandlerErrorNack,
 			}},
 		},
+		{
+			Type:              AnswerSheetCriticalItemFlagged,
+			Owner:             "survey/answersheet",
+			OutboxProfile:     OutboxProfileMongoDomain,
+			Immediate:         true,
+			Priority:          PriorityP0,
+			IdempotencyPolicy: "answersheet-id-critical-item-page",
+			SettlementPolicy:  SettlementHandlerErrorNack,
+			AdditionalConsumers: []ConsumerSpec{{
+				ID:                "workbench.critical_item_projection",
+				Runtime:           "apiserver",
+				Channel:           "qs-apiserver-workbench-critical-item-v1",
+				IdempotencyPolicy: "critical-item-answersheet-id-unique",
+				SettlementPolicy:  SettlementHandlerErrorNack,
+			}},
+		},
 		durableSpec(EvaluationRequested, "evaluation", OutboxProfileAssessmentMySQL, true, PriorityP0, "evaluation-run-state-claim"),
 		durableSpec(EvaluationRetryRequested, "evaluation", OutboxProfileAssessmentMySQL, false, PriorityP1, "evaluation-latest-run-retry-decision"),
 		durableSpec(EvaluationOutcomeCommitted, "evaluation", OutboxProfileAssessmentMySQL, true, PriorityP1, "report-business-key-run-claim-cas"),
 		durableSpec(EvaluationFailed, "evaluation", OutboxProfileAssessmentMySQL, false, PriorityP1, "report-status-overwrite"),
-		durableSpec(InterpretationReportGenerated, "interpretation/report", OutboxProfileMongoDomain, false, PriorityP1, "repeatable-attention-projection"),
+		{
+			Type:              InterpretationReportGenerated,
+			Owner:             "interpretation/report",
+			OutboxProfile:     OutboxProfileMongoDomain,
+			Priority:          PriorityP1,
+			IdempotencyPolicy: "repeatable-attention-projection",
+			SettlementPolicy:  SettlementHandlerErrorNack,
+			AdditionalConsumers: []ConsumerSpec{{
+				ID:                "interpretation.report_pdf_render",
+				Runtime:           "apiserver",
+				Channel:           "qs-apiserver-interpretation-report-pdf-v1",
+				IdempotencyPolicy: "report-pdf-report-id-unique",
+				SettlementPolicy:  SettlementHandlerErrorNack,
+			}},
+		},
 		durableSpec(InterpretationReportFailed, "interpretation/report", OutboxProfileMongoDomain, false, PriorityP1, "terminal-failure-fact"),
 		durableSpec(InterpretationRetryRequested, "interpretation", OutboxProfileMongoDomain, false, PriorityP1, "generation-latest-run-retry-decision"),
 		bestEffortSpec(TaskOpened, "plan", "notification-event-metadata"),
-		bestEffortSpec(TaskCompleted, "plan", "notification-event-metadata"),
-		bestEffortSpec(TaskExpired, "plan", "notification-event-metadata"),
-		bestEffortSpec(TaskCanceled, "plan", "notification-event-metadata"),
+		taskTerminalSpec(TaskCompleted, "completed"),
+		taskTerminalSpec(TaskExpired, "expired"),
+		taskTerminalSpec(TaskCanceled, "canceled"),
+		durableSpec(ConsentWithdrawn, "actor/consent", OutboxProfileAssessmentMySQL, false, PriorityP2, "acceptance-withdrawal-fact"),
+		durableSpec(BreakGlassGranted, "actor/breakglass", OutboxProfileAssessmentMySQL, false, PriorityP1, "grant-id-admin-notification"),
+		durableSpec(BreakGlassReviewed, "actor/breakglass", OutboxProfileAssessmentMySQL, false, PriorityP2, "grant-id-review-decision"),
+		durableSpec(BreakGlassRevoked, "actor/breakglass", OutboxProfileAssessmentMySQL, false, PriorityP2, "grant-id-revocation"),
+		durableSpec(RiskAlertRaised, "interpretation/riskalert", OutboxProfileAssessmentMySQL, false, PriorityP0, "alert-id-escalation-level-page"),
+		durableSpec(RiskAlertEscalated, "interpretation/riskalert", OutboxProfileAssessmentMySQL, false, PriorityP0, "alert-id-escalation-level-page"),
 	}
 }
 
@@ -115,6 +151,20 @@ func bestEffortSpec(eventType, owner, idempotency string) EventSpec {
 	return EventSpec{Type: eventType, Owner: owner, IdempotencyPolicy: idempotency, SettlementPolicy: SettlementHandlerErrorNack}
 }
 
+// taskTerminalSpec declares a task terminal event that may close a plan
+// enrollment; the longitudinal plan report consumer checks it on its own channel.
+func taskTerminalSpec(eventType, outcome string) EventSpec {
+	spec := bestEffortSpec(eventType, "plan", "notification-event-metadata")
+	spec.AdditionalConsumers = []ConsumerSpec{{
+		ID:                "interpretation.plan_report_on_task_" + outcome,
+		Runtime:           "apiserver",
+		Channel:           "qs-apiserver-interpretation-plan-report-" + outcome + "-v1",
+		IdempotencyPolicy: "plan-report-enrollment-input-fingerprint-unique",
+		SettlementPolicy:  SettlementHandlerErrorNack,
+	}}
+	return spec
+}
+
 func durableSpec(eventType, owner string, profile OutboxProfile, immediate bool, priority Priority, idempotency string) EventSpec {
 	return EventSpec{
 		Type: eventType, Owner: owner, OutboxProfile: profile, Immediate: immediate,
@@ -4,7 +4,8 @@ package eventcatalog
 const (
 	QuestionnaireChanged = "questionnaire.changed"
 
-	AnswerSheetSubmitted = "answersheet.submitted"
+	AnswerSheetSubmitted           = "answersheet.submitted"
+	AnswerSheetCriticalItemFlagged = "answersheet.critical_item_flagged"
 
 	EvaluationRequested        = "evaluation.requested"
 	EvaluationRetryRequested   = "evaluation.retry.requested"
@@ -21,6 +22,15 @@ const (
 	TaskCompleted = "task.completed"
 	TaskExpired   = "task.expired"
 	TaskCanceled  = "task.canceled"
+
+	ConsentWithdrawn = "consent.withdrawn"
+
+	BreakGlassGranted  = "break_glass.granted"
+	BreakGlassReviewed = "break_glass.reviewed"
+	BreakGlassRevoked  = "break_glass.revoked"
+
+	RiskAlertRaised    = "risk_alert.raised"
+	RiskAlertEscalated = "risk_alert.escalated"
 )
 
 // EventTypes returns all event types known by code.
@@ -28,6 +38,7 @@ func EventTypes() []string {
 	return []string{
 		QuestionnaireChanged,
 		AnswerSheetSubmitted,
+		AnswerSheetCriticalItemFlagged,
 		EvaluationRequested,
 		EvaluationRetryRequested,
 		EvaluationOutcomeCommitted,
@@ -40,6 +51,12 @@ func EventTypes() []string {
 		TaskCompleted,
 		TaskExpired,
 		TaskCanceled,
+		ConsentWithdrawn,
+		BreakGlassGranted,
+		BreakGlassReviewed,
+		BreakGlassRevoked,
+		RiskAlertRaised,
+		RiskAlertEscalated,
 	}
 }
 
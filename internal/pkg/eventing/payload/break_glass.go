@@ -0,0 +1,37 @@
+package eventpayload
+
+import "time"
+
+// BreakGlassGrantedData is the break-glass grant event body.
+type BreakGlassGrantedData struct {
+	OrgID          int64     `json:"org_id"`
+	GrantID        string    `json:"grant_id"`
+	ClinicianID    string    `json:"clinician_id"`
+	OperatorUserID string    `json:"operator_user_id"`
+	TesteeID       string    `json:"testee_id"`
+	Justification  string    `json:"justification"`
+	GrantedAt      time.Time `json:"granted_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// BreakGlassReviewedData is the break-glass review event body.
+type BreakGlassReviewedData struct {
+	OrgID          int64     `json:"org_id"`
+	GrantID        string    `json:"grant_id"`
+	OperatorUserID string    `json:"operator_user_id"`
+	TesteeID       string    `json:"testee_id"`
+	ReviewerUserID string    `json:"reviewer_user_id"`
+	Decision       string    `json:"decision"`
+	Note           string    `json:"note"`
+	ReviewedAt     time.Time `json:"reviewed_at"`
+}
+
+// BreakGlassRevokedData is the break-glass revocation event body.
+type BreakGlassRevokedData struct {
+	OrgID          int64     `json:"org_id"`
+	GrantID        string    `json:"grant_id"`
+	OperatorUserID string    `json:"operator_user_id"`
+	TesteeID       string    `json:"testee_id"`
+	RevokedBy      string    `json:"revoked_by"`
+	RevokedAt      time.Time `json:"revoked_at"`
+}
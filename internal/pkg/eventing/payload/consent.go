@@ -0,0 +1,19 @@
+package eventpayload
+
+import "time"
+
+// ConsentWithdrawnData is the consent withdrawn event body.
+type ConsentWithdrawnData struct {
+	OrgID           int64     `json:"org_id"`
+	AcceptanceID    string    `json:"acceptance_id"`
+	DocumentID      string    `json:"document_id"`
+	DocumentVersion int       `json:"document_version"`
+	ScopeKind       string    `json:"scope_kind"`
+	ScopeRef        string    `json:"scope_ref,omitempty"`
+	TesteeID        string    `json:"testee_id"`
+	FillerUserID    string    `json:"filler_user_id"`
+	FillerType      string    `json:"filler_type"`
+	WithdrawnBy     string    `json:"withdrawn_by"`
+	Reason          string    `json:"reason,omitempty"`
+	WithdrawnAt     time.Time `json:"withdrawn_at"`
+}
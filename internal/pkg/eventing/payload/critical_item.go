@@ -0,0 +1,24 @@
+package eventpayload
+
+import "time"
+
+// AnswerSheetCriticalItemFlaggedData is the answersheet.critical_item_flagged
+// event body. It is staged in the same transaction as answersheet.submitted
+// and never carries free-text answers; Trigger is the matched option code,
+// numeric value or configured keyword.
+type AnswerSheetCriticalItemFlaggedData struct {
+	AnswerSheetID        string             `json:"answersheet_id"`
+	QuestionnaireCode    string             `json:"questionnaire_code"`
+	QuestionnaireVersion string             `json:"questionnaire_version"`
+	OrgID                uint64             `json:"org_id"`
+	TesteeID             uint64             `json:"testee_id"`
+	FillerID             uint64             `json:"filler_id"`
+	Items                []CriticalItemData `json:"items"`
+	FlaggedAt            time.Time          `json:"flagged_at"`
+}
+
+type CriticalItemData struct {
+	QuestionCode string `json:"question_code"`
+	Category     string `json:"category"`
+	Trigger      string `json:"trigger"`
+}
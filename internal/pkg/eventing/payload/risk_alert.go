@@ -0,0 +1,23 @@
+package eventpayload
+
+import "time"
+
+// RiskAlertPageData is the risk_alert.raised / risk_alert.escalated event body.
+// Channel is the on-call channel to page at EscalationLevel (0 = first page).
+type RiskAlertPageData struct {
+	OrgID           int64     `json:"org_id"`
+	AlertID         string    `json:"alert_id"`
+	RuleID          string    `json:"rule_id"`
+	RuleName        string    `json:"rule_name"`
+	RuleKind        string    `json:"rule_kind"`
+	Severity        string    `json:"severity"`
+	Channel         string    `json:"channel"`
+	EscalationLevel int       `json:"escalation_level"`
+	AssessmentID    string    `json:"assessment_id"`
+	TesteeID        string    `json:"testee_id"`
+	ModelCode       string    `json:"model_code,omitempty"`
+	Summary         string    `json:"summary"`
+	ObservedValue   float64   `json:"observed_value"`
+	TriggeredAt     time.Time `json:"triggered_at"`
+	PagedAt         time.Time `json:"paged_at"`
+}
@@ -46,3 +46,101 @@ func TestLifecycleActionWireValues(t *testing.T) {
 		t.Fatal("assessment model lifecycle action wire values changed")
 	}
 }
+
+func TestConsentWithdrawnWireContract(t *testing.T) {
+	t.Parallel()
+
+	payload, err := json.Marshal(ConsentWithdrawnData{
+		OrgID: 7, AcceptanceID: "11", DocumentID: "3", DocumentVersion: 2, ScopeKind: "model", ScopeRef: "SDS",
+		TesteeID: "9", FillerUserID: "21", FillerType: "guardian", WithdrawnBy: "21",
+		WithdrawnAt: time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"org_id":7,"acceptance_id":"11","document_id":"3","document_version":2,"scope_kind":"model","scope_ref":"SDS","testee_id":"9","filler_user_id":"21","filler_type":"guardian","withdrawn_by":"21","withdrawn_at":"2026-10-01T08:00:00Z"}`
+	if got := string(payload); got != want {
+		t.Fatalf("wire JSON = %s, want %s", got, want)
+	}
+}
+
+func TestBreakGlassGrantedWireContract(t *testing.T) {
+	t.Parallel()
+
+	grantedAt := time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(BreakGlassGrantedData{
+		OrgID: 7, GrantID: "41", ClinicianID: "301", OperatorUserID: "101", TesteeID: "9",
+		Justification: "夜间值班，受试者急诊就诊", GrantedAt: grantedAt, ExpiresAt: grantedAt.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"org_id":7,"grant_id":"41","clinician_id":"301","operator_user_id":"101","testee_id":"9","justification":"夜间值班，受试者急诊就诊","granted_at":"2026-10-01T08:00:00Z","expires_at":"2026-10-01T09:00:00Z"}`
+	if got := string(payload); got != want {
+		t.Fatalf("wire JSON = %s, want %s", got, want)
+	}
+}
+
+func TestBreakGlassReviewedAndRevokedWireContract(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, time.October, 2, 9, 0, 0, 0, time.UTC)
+	reviewed, err := json.Marshal(BreakGlassReviewedData{
+		OrgID: 7, GrantID: "41", OperatorUserID: "101", TesteeID: "9",
+		ReviewerUserID: "201", Decision: "flagged", Note: "理由不充分", ReviewedAt: at,
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"org_id":7,"grant_id":"41","operator_user_id":"101","testee_id":"9","reviewer_user_id":"201","decision":"flagged","note":"理由不充分","reviewed_at":"2026-10-02T09:00:00Z"}`
+	if got := string(reviewed); got != want {
+		t.Fatalf("reviewed wire JSON = %s, want %s", got, want)
+	}
+
+	revoked, err := json.Marshal(BreakGlassRevokedData{
+		OrgID: 7, GrantID: "41", OperatorUserID: "101", TesteeID: "9", RevokedBy: "201", RevokedAt: at,
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want = `{"org_id":7,"grant_id":"41","operator_user_id":"101","testee_id":"9","revoked_by":"201","revoked_at":"2026-10-02T09:00:00Z"}`
+	if got := string(revoked); got != want {
+		t.Fatalf("revoked wire JSON = %s, want %s", got, want)
+	}
+}
+
+func TestRiskAlertPageWireContract(t *testing.T) {
+	t.Parallel()
+
+	triggeredAt := time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(RiskAlertPageData{
+		OrgID: 7, AlertID: "31", RuleID: "5", RuleName: "自杀意念条目", RuleKind: "item_answer", Severity: "critical",
+		Channel: "oncall-secondary", EscalationLevel: 1, AssessmentID: "101", TesteeID: "9", ModelCode: "PHQ9",
+		Summary: "题目 Q9 得分 2", ObservedValue: 2, TriggeredAt: triggeredAt, PagedAt: triggeredAt.Add(15 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"org_id":7,"alert_id":"31","rule_id":"5","rule_name":"自杀意念条目","rule_kind":"item_answer","severity":"critical","channel":"oncall-secondary","escalation_level":1,"assessment_id":"101","testee_id":"9","model_code":"PHQ9","summary":"题目 Q9 得分 2","observed_value":2,"triggered_at":"2026-10-01T08:00:00Z","paged_at":"2026-10-01T08:15:00Z"}`
+	if got := string(payload); got != want {
+		t.Fatalf("wire JSON = %s, want %s", got, want)
+	}
+}
+
+func TestAnswerSheetCriticalItemFlaggedWireContract(t *testing.T) {
+	t.Parallel()
+
+	payload, err := json.Marshal(AnswerSheetCriticalItemFlaggedData{
+		AnswerSheetID: "501", QuestionnaireCode: "PHQ9", QuestionnaireVersion: "1.0.2",
+		OrgID: 7, TesteeID: 9, FillerID: 3,
+		Items:     []CriticalItemData{{QuestionCode: "Q9", Category: "self_harm", Trigger: "A3"}},
+		FlaggedAt: time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"answersheet_id":"501","questionnaire_code":"PHQ9","questionnaire_version":"1.0.2","org_id":7,"testee_id":9,"filler_id":3,"items":[{"question_code":"Q9","category":"self_harm","trigger":"A3"}],"flagged_at":"2026-10-01T08:00:00Z"}`
+	if got := string(payload); got != want {
+		t.Fatalf("wire JSON = %s, want %s", got, want)
+	}
+}
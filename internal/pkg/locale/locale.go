@@ -0,0 +1,82 @@
+// Package locale 规范化报告内容使用的语言标签，并在偏好语言与可用语言之间协商。
+// 标签采用 BCP 47 的常用子集：两位小写语言码，可选两位大写地区码，如 zh-CN、en、en-US。
+package locale
+
+import (
+	"errors"
+	"strings"
+)
+
+// Default 是未声明语言时的内容语言；模型解读资产的基础文案即以此语言撰写。
+const Default = "zh-CN"
+
+// ErrInvalid 表示语言标签不符合 ll 或 ll-RR 形式。
+var ErrInvalid = errors.New("invalid locale")
+
+// Normalize 把 "en_us"、"EN-us" 等写法规范为 "en-US"；空串原样返回空串。
+func Normalize(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", nil
+	}
+	parts := strings.Split(strings.ReplaceAll(value, "_", "-"), "-")
+	if len(parts) > 2 || !isLetters(parts[0], 2) {
+		return "", ErrInvalid
+	}
+	language := strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return language, nil
+	}
+	if !isLetters(parts[1], 2) {
+		return "", ErrInvalid
+	}
+	return language + "-" + strings.ToUpper(parts[1]), nil
+}
+
+// Language 返回标签的语言部分，如 "en-US" 返回 "en"。
+func Language(tag string) string {
+	if index := strings.IndexByte(tag, '-'); index >= 0 {
+		return tag[:index]
+	}
+	return tag
+}
+
+// Negotiate 按偏好顺序挑选可用语言：先精确匹配，再按语言部分匹配，都没有时回落到 Default。
+// 无法规范化的偏好会被忽略。
+func Negotiate(preferred []string, available []string) string {
+	normalized := make([]string, 0, len(available))
+	for _, item := range available {
+		if tag, err := Normalize(item); err == nil && tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	for _, item := range preferred {
+		tag, err := Normalize(item)
+		if err != nil || tag == "" {
+			continue
+		}
+		for _, candidate := range normalized {
+			if candidate == tag {
+				return candidate
+			}
+		}
+		for _, candidate := range normalized {
+			if Language(candidate) == Language(tag) {
+				return candidate
+			}
+		}
+	}
+	return Default
+}
+
+func isLetters(value string, size int) bool {
+	if len(value) != size {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
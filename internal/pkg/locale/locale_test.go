@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{"": "", " zh-cn ": "zh-CN", "en_us": "en-US", "EN": "en"}
+	for raw, want := range cases {
+		got, err := Normalize(raw)
+		if err != nil || got != want {
+			t.Fatalf("Normalize(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"english", "en-USA", "e1", "zh-Hans-CN"} {
+		if _, err := Normalize(raw); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Normalize(%q) error = %v, want ErrInvalid", raw, err)
+		}
+	}
+}
+
+func TestNegotiatePrefersExactThenLanguageThenDefault(t *testing.T) {
+	available := []string{"zh-CN", "en-US", "ja"}
+	if got := Negotiate([]string{"en-us"}, available); got != "en-US" {
+		t.Fatalf("exact = %q", got)
+	}
+	if got := Negotiate([]string{"en-GB"}, available); got != "en-US" {
+		t.Fatalf("language = %q", got)
+	}
+	if got := Negotiate([]string{"fr", "ja-JP"}, available); got != "ja" {
+		t.Fatalf("second preference = %q", got)
+	}
+	if got := Negotiate([]string{"fr", "bad tag"}, available); got != Default {
+		t.Fatalf("fallback = %q", got)
+	}
+}
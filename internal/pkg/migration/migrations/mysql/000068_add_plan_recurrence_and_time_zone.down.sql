@@ -0,0 +1,7 @@
+ALTER TABLE `assessment_task`
+  DROP COLUMN `time_zone`;
+
+ALTER TABLE `assessment_plan`
+  DROP COLUMN `exception_dates`,
+  DROP COLUMN `recurrence_rule`,
+  DROP COLUMN `time_zone`;
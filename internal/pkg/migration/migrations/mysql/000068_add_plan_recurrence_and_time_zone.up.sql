@@ -0,0 +1,7 @@
+ALTER TABLE `assessment_plan`
+  ADD COLUMN `time_zone` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'IANA 时区，空值沿用默认业务时区' AFTER `trigger_time`,
+  ADD COLUMN `recurrence_rule` VARCHAR(512) NOT NULL DEFAULT '' COMMENT 'RRULE 日历规则（schedule_type=rrule）' AFTER `relative_weeks`,
+  ADD COLUMN `exception_dates` JSON NULL COMMENT 'EXDATE 排除日期列表（schedule_type=rrule）' AFTER `recurrence_rule`;
+
+ALTER TABLE `assessment_task`
+  ADD COLUMN `time_zone` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '继承自计划的 IANA 时区' AFTER `schedule_defined_at`;
@@ -0,0 +1,15 @@
+CREATE TABLE `statistics_plan_adherence_task` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, `org_id` BIGINT NOT NULL, `plan_id` BIGINT UNSIGNED NOT NULL,
+  `enrollment_id` BIGINT UNSIGNED NOT NULL DEFAULT 0, `testee_id` BIGINT UNSIGNED NOT NULL, `task_id` BIGINT UNSIGNED NOT NULL,
+  `task_seq` INT NOT NULL DEFAULT 0, `scale_code` VARCHAR(100) NOT NULL DEFAULT '',
+  `clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0, `entry_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `enrolled_at` DATETIME(3) NOT NULL, `enrollment_week` INT NOT NULL DEFAULT 0,
+  `planned_at` DATETIME(3) NOT NULL, `due_at` DATETIME(3) NULL, `opened_at` DATETIME(3) NULL,
+  `completed_at` DATETIME(3) NULL, `expired_at` DATETIME(3) NULL,
+  `outcome` VARCHAR(16) NOT NULL, `open_to_complete_seconds` BIGINT UNSIGNED NULL,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`), UNIQUE KEY `uk_statistics_plan_adherence_task` (`org_id`,`task_id`),
+  KEY `idx_statistics_plan_adherence_cohort` (`org_id`,`plan_id`,`enrolled_at`),
+  KEY `idx_statistics_plan_adherence_clinician` (`org_id`,`plan_id`,`clinician_id`),
+  KEY `idx_statistics_plan_adherence_entry` (`org_id`,`plan_id`,`entry_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `testee_import_row`;
+DROP TABLE IF EXISTS `testee_import_job`;
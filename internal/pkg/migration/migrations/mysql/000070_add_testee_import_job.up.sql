@@ -0,0 +1,26 @@
+CREATE TABLE `testee_import_job` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `file_name` VARCHAR(255) NOT NULL DEFAULT '', `file_format` VARCHAR(16) NOT NULL,
+  `clinician_id` BIGINT UNSIGNED NOT NULL, `relation_type` VARCHAR(32) NOT NULL,
+  `plan_id` BIGINT UNSIGNED NOT NULL DEFAULT 0, `plan_start_date` VARCHAR(10) NOT NULL DEFAULT '',
+  `status` VARCHAR(16) NOT NULL, `total_rows` INT NOT NULL DEFAULT 0,
+  `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `last_error` VARCHAR(512) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL, `started_at` DATETIME(3) NULL, `finished_at` DATETIME(3) NULL,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_testee_import_job_org_created` (`org_id`,`created_at`),
+  KEY `idx_testee_import_job_runnable` (`status`,`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `testee_import_row` (
+  `job_id` BIGINT UNSIGNED NOT NULL, `row_no` INT NOT NULL,
+  `name` VARCHAR(100) NOT NULL DEFAULT '', `gender` TINYINT NOT NULL DEFAULT 0,
+  `birthday` DATE NULL, `profile_id` BIGINT UNSIGNED NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL DEFAULT 0, `testee_action` VARCHAR(16) NOT NULL DEFAULT '',
+  `relation_id` BIGINT UNSIGNED NOT NULL DEFAULT 0, `enrollment_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `attempts` INT NOT NULL DEFAULT 0, `error` VARCHAR(1024) NOT NULL DEFAULT '',
+  `processed_at` DATETIME(3) NULL,
+  PRIMARY KEY (`job_id`,`row_no`),
+  KEY `idx_testee_import_row_status` (`job_id`,`status`,`row_no`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+ALTER TABLE `testee`
+    DROP INDEX `idx_testee_org_name_deleted`;
+
+DROP TABLE IF EXISTS `testee_merge_log`;
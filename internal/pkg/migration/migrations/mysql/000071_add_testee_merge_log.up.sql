@@ -0,0 +1,21 @@
+CREATE TABLE `testee_merge_log` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `survivor_id` BIGINT UNSIGNED NOT NULL, `duplicate_id` BIGINT UNSIGNED NOT NULL,
+  `status` VARCHAR(16) NOT NULL COMMENT 'merged/reverted',
+  `match_reasons` JSON NULL COMMENT '合并时的判重依据',
+  `reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `moved_records` JSON NOT NULL COMMENT '按存储与表记录被迁移的记录ID，用于回滚',
+  `skipped_relation_ids` JSON NULL COMMENT '保留受试者已有相同关系而未迁移的从业者关系',
+  `transferred_profile_id` BIGINT UNSIGNED NULL COMMENT '从重复受试者转移到保留受试者的用户档案',
+  `merged_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `merged_at` DATETIME(3) NOT NULL,
+  `reverted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `reverted_at` DATETIME(3) NULL,
+  `revert_reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_testee_merge_log_org_merged` (`org_id`,`merged_at`),
+  KEY `idx_testee_merge_log_survivor` (`org_id`,`survivor_id`,`status`),
+  KEY `idx_testee_merge_log_duplicate` (`org_id`,`duplicate_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='受试者合并记录（可回滚）';
+
+ALTER TABLE `testee`
+    ADD INDEX `idx_testee_org_name_deleted` (`org_id`, `name`, `deleted_at`);
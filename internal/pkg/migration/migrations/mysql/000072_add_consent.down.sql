@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `consent_acceptance`;
+DROP TABLE IF EXISTS `consent_document`;
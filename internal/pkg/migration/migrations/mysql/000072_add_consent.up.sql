@@ -0,0 +1,36 @@
+CREATE TABLE `consent_document` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `scope_kind` VARCHAR(16) NOT NULL COMMENT 'org/model/entry',
+  `scope_ref` VARCHAR(100) NOT NULL DEFAULT '' COMMENT '问卷编码或测评入口ID，机构范围为空',
+  `version` INT NOT NULL COMMENT '同一机构同一范围内递增',
+  `title` VARCHAR(200) NOT NULL,
+  `body` MEDIUMTEXT NOT NULL,
+  `content_hash` CHAR(64) NOT NULL COMMENT 'sha256(title + "\\n" + body)',
+  `status` VARCHAR(16) NOT NULL COMMENT 'draft/published/superseded/retired',
+  `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `created_at` DATETIME(3) NOT NULL,
+  `published_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `published_at` DATETIME(3) NULL,
+  `retired_at` DATETIME(3) NULL,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_consent_document_scope_version` (`org_id`,`scope_kind`,`scope_ref`,`version`),
+  KEY `idx_consent_document_scope_status` (`org_id`,`status`,`scope_kind`,`scope_ref`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知情同意书（按范围版本化）';
+
+CREATE TABLE `consent_acceptance` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `document_id` BIGINT UNSIGNED NOT NULL, `document_version` INT NOT NULL,
+  `scope_kind` VARCHAR(16) NOT NULL, `scope_ref` VARCHAR(100) NOT NULL DEFAULT '',
+  `content_hash` CHAR(64) NOT NULL COMMENT '签署时同意书的内容摘要',
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `filler_user_id` BIGINT NOT NULL, `filler_type` VARCHAR(16) NOT NULL COMMENT 'self/guardian',
+  `guardian_relation` VARCHAR(32) NOT NULL DEFAULT '' COMMENT 'parent/legal_guardian，监护人签署时填写',
+  `ip` VARCHAR(64) NOT NULL DEFAULT '', `user_agent` VARCHAR(512) NOT NULL DEFAULT '',
+  `request_id` VARCHAR(64) NOT NULL DEFAULT '',
+  `accepted_at` DATETIME(3) NOT NULL,
+  `withdrawn_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `withdrawn_at` DATETIME(3) NULL,
+  `withdraw_reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_consent_acceptance_testee` (`org_id`,`testee_id`,`document_id`,`withdrawn_at`),
+  KEY `idx_consent_acceptance_document` (`org_id`,`document_id`,`accepted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知情同意签署记录';
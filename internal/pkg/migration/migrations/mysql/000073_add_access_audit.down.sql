@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `access_audit_chain_head`;
+DROP TABLE IF EXISTS `access_audit_log`;
@@ -0,0 +1,32 @@
+CREATE TABLE `access_audit_log` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `seq` BIGINT UNSIGNED NOT NULL COMMENT '机构内从 1 连续递增的链序号',
+  `actor_user_id` BIGINT NOT NULL,
+  `actor_role` VARCHAR(32) NOT NULL COMMENT 'qs_admin/clinician/operator/unresolved',
+  `relation` VARCHAR(128) NOT NULL COMMENT 'org_admin、生效中的授权关系类型（逗号分隔）、none 或 unknown',
+  `resource_type` VARCHAR(32) NOT NULL,
+  `resource_id` VARCHAR(64) NOT NULL DEFAULT '',
+  `testee_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `purpose` VARCHAR(64) NOT NULL,
+  `result` VARCHAR(16) NOT NULL COMMENT 'allowed/denied/not_found/invalid/error',
+  `status_code` INT NOT NULL,
+  `route` VARCHAR(200) NOT NULL DEFAULT '',
+  `request_id` VARCHAR(64) NOT NULL DEFAULT '',
+  `client_ip` VARCHAR(64) NOT NULL DEFAULT '',
+  `occurred_at` DATETIME(3) NOT NULL,
+  `prev_hash` CHAR(64) NOT NULL DEFAULT '' COMMENT '前一条记录的 hash，链首为空',
+  `hash` CHAR(64) NOT NULL COMMENT 'sha256(prev_hash + "\\n" + 规范化字段 JSON)',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_access_audit_log_org_seq` (`org_id`,`seq`),
+  KEY `idx_access_audit_log_org_occurred` (`org_id`,`occurred_at`),
+  KEY `idx_access_audit_log_org_testee` (`org_id`,`testee_id`,`occurred_at`),
+  KEY `idx_access_audit_log_org_actor` (`org_id`,`actor_user_id`,`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='受试者数据访问审计（追加写，哈希链）';
+
+CREATE TABLE `access_audit_chain_head` (
+  `org_id` BIGINT NOT NULL,
+  `last_seq` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `last_hash` CHAR(64) NOT NULL DEFAULT '',
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`org_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问审计链尾（追加时行锁）';
@@ -0,0 +1,20 @@
+CREATE TABLE `break_glass_grant` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `clinician_id` BIGINT UNSIGNED NOT NULL,
+  `operator_user_id` BIGINT NOT NULL COMMENT '申请人 IAM 用户ID',
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `justification` VARCHAR(500) NOT NULL COMMENT '申请理由',
+  `granted_at` DATETIME(3) NOT NULL,
+  `expires_at` DATETIME(3) NOT NULL COMMENT '到期即失效，无需后台任务',
+  `revoked_at` DATETIME(3) NULL DEFAULT NULL,
+  `revoked_by` BIGINT NOT NULL DEFAULT 0,
+  `review_status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/approved/flagged',
+  `reviewed_by` BIGINT NOT NULL DEFAULT 0,
+  `reviewed_at` DATETIME(3) NULL DEFAULT NULL,
+  `review_note` VARCHAR(500) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `idx_break_glass_grant_org_clinician` (`org_id`,`clinician_id`,`expires_at`),
+  KEY `idx_break_glass_grant_org_testee` (`org_id`,`testee_id`,`expires_at`),
+  KEY `idx_break_glass_grant_org_review` (`org_id`,`review_status`,`granted_at`),
+  KEY `idx_break_glass_grant_org_operator` (`org_id`,`operator_user_id`,`granted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='紧急访问授权（短时，事后复核）';
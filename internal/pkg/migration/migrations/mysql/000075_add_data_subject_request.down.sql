@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `data_subject_certificate`;
+DROP TABLE IF EXISTS `data_subject_request`;
@@ -0,0 +1,33 @@
+CREATE TABLE `data_subject_request` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `kind` VARCHAR(16) NOT NULL COMMENT 'export/erasure',
+  `mode` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '擦除方式：anonymize/delete',
+  `status` VARCHAR(16) NOT NULL COMMENT 'pending/running/failed/completed',
+  `reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `steps` JSON NULL COMMENT '擦除步骤结果，用于断点续做',
+  `bundle_key` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '导出包对象存储 key',
+  `bundle_size` BIGINT NOT NULL DEFAULT 0,
+  `attempts` INT NOT NULL DEFAULT 0,
+  `last_error` VARCHAR(1000) NOT NULL DEFAULT '',
+  `requested_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `requested_at` DATETIME(3) NOT NULL,
+  `started_at` DATETIME(3) NULL COMMENT '最近一次认领时间，超时视为中断',
+  `completed_at` DATETIME(3) NULL,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_data_subject_request_org_requested` (`org_id`,`requested_at`),
+  KEY `idx_data_subject_request_testee` (`org_id`,`testee_id`,`kind`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据主体请求（导出/擦除）';
+
+CREATE TABLE `data_subject_certificate` (
+  `id` BIGINT UNSIGNED NOT NULL, `request_id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL, `testee_id` BIGINT UNSIGNED NOT NULL,
+  `subject_ref` VARCHAR(64) NOT NULL COMMENT '受试者假名',
+  `mode` VARCHAR(16) NOT NULL,
+  `steps` JSON NOT NULL,
+  `digest` CHAR(64) NOT NULL COMMENT '证书内容 SHA-256',
+  `issued_by` BIGINT UNSIGNED NOT NULL DEFAULT 0, `issued_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_data_subject_certificate_request` (`request_id`),
+  KEY `idx_data_subject_certificate_org_testee` (`org_id`,`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='受试者擦除证书（只写不改）';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `custom_role_assignment`;
+DROP TABLE IF EXISTS `custom_role`;
@@ -0,0 +1,27 @@
+CREATE TABLE `custom_role` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `code` VARCHAR(64) NOT NULL COMMENT '机构内唯一编码',
+  `name` VARCHAR(100) NOT NULL,
+  `description` VARCHAR(500) NOT NULL DEFAULT '',
+  `capabilities` JSON NOT NULL COMMENT 'authz.Capability 列表',
+  `scope` JSON NOT NULL COMMENT '资源范围：model_codes/plan_ids/departments，空数组表示不限',
+  `sensitivity` VARCHAR(16) NOT NULL COMMENT 'aggregate/deidentified/identified',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_custom_role_org_code` (`org_id`,`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='机构自定义角色';
+
+CREATE TABLE `custom_role_assignment` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `role_id` BIGINT UNSIGNED NOT NULL,
+  `operator_id` BIGINT UNSIGNED NOT NULL,
+  `user_id` BIGINT NOT NULL COMMENT 'IAM 用户ID，授权快照按此叠加',
+  `assigned_by` BIGINT NOT NULL DEFAULT 0,
+  `assigned_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_custom_role_assignment_role_operator` (`role_id`,`operator_id`),
+  KEY `idx_custom_role_assignment_org_user` (`org_id`,`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自定义角色分配';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `care_team_event`;
+DROP TABLE IF EXISTS `care_team_testee`;
+DROP TABLE IF EXISTS `care_team_member`;
+DROP TABLE IF EXISTS `care_team`;
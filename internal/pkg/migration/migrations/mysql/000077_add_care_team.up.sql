@@ -0,0 +1,46 @@
+CREATE TABLE `care_team` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `name` VARCHAR(100) NOT NULL COMMENT '机构内唯一名称',
+  `department` VARCHAR(50) NOT NULL DEFAULT '' COMMENT '所属科室，空表示跨科室团队',
+  `description` VARCHAR(500) NOT NULL DEFAULT '',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_care_team_org_name` (`org_id`,`name`),
+  KEY `idx_care_team_org_department` (`org_id`,`department`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='照护团队';
+
+CREATE TABLE `care_team_member` (
+  `team_id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `clinician_id` BIGINT UNSIGNED NOT NULL,
+  `role` VARCHAR(16) NOT NULL COMMENT 'lead/member/observer；observer 不继承受试者访问',
+  `added_by` BIGINT NOT NULL DEFAULT 0,
+  `added_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`team_id`,`clinician_id`),
+  KEY `idx_care_team_member_org_clinician` (`org_id`,`clinician_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='照护团队成员';
+
+CREATE TABLE `care_team_testee` (
+  `team_id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `assigned_by` BIGINT NOT NULL DEFAULT 0,
+  `assigned_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`team_id`,`testee_id`),
+  KEY `idx_care_team_testee_org_testee` (`org_id`,`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='照护团队负责的受试者';
+
+CREATE TABLE `care_team_event` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `team_id` BIGINT UNSIGNED NOT NULL,
+  `action` VARCHAR(32) NOT NULL COMMENT 'team_created/team_updated/team_deleted/member_added/member_role_changed/member_removed/testee_assigned/testee_unassigned',
+  `clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `testee_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `from_role` VARCHAR(16) NOT NULL DEFAULT '',
+  `to_role` VARCHAR(16) NOT NULL DEFAULT '',
+  `operator_user_id` BIGINT NOT NULL DEFAULT 0,
+  `occurred_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_care_team_event_team` (`org_id`,`team_id`,`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='照护团队成员与受试者变更审计';
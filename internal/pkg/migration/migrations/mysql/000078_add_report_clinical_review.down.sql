@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `report_clinical_note`;
+DROP TABLE IF EXISTS `report_review`;
@@ -0,0 +1,30 @@
+CREATE TABLE `report_review` (
+  `assessment_id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `status` VARCHAR(16) NOT NULL COMMENT 'pending_review/reviewed/amended；无记录视为 pending_review',
+  `note_version` INT NOT NULL DEFAULT 0 COMMENT '当前生效的临床备注版本，0 表示尚无备注',
+  `reviewer_user_id` BIGINT NOT NULL DEFAULT 0,
+  `reviewer_clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `reviewer_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `reviewed_at` DATETIME(3) NULL COMMENT '签署或最近一次修订时间',
+  `updated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`assessment_id`),
+  KEY `idx_report_review_org_status` (`org_id`,`status`),
+  KEY `idx_report_review_org_testee` (`org_id`,`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='报告临床复核状态';
+
+CREATE TABLE `report_clinical_note` (
+  `id` BIGINT UNSIGNED NOT NULL, `org_id` BIGINT NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `version` INT NOT NULL COMMENT '同一测评内从 1 递增',
+  `content` TEXT NOT NULL COMMENT '临床印象，仅从业者可见',
+  `addendum` VARCHAR(2000) NOT NULL DEFAULT '' COMMENT '面向受试者的补充说明，签署后展示',
+  `author_user_id` BIGINT NOT NULL DEFAULT 0,
+  `author_clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `author_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_report_clinical_note_version` (`assessment_id`,`version`),
+  KEY `idx_report_clinical_note_org` (`org_id`,`assessment_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='报告临床备注版本';
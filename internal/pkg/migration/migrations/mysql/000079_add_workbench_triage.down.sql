@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `workbench_triage_event`;
+DROP TABLE IF EXISTS `workbench_triage_item`;
@@ -0,0 +1,44 @@
+CREATE TABLE `workbench_triage_item` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `queue_type` VARCHAR(32) NOT NULL COMMENT 'high_risk/follow_up/key_focus/awaiting_review',
+  `subject_id` BIGINT UNSIGNED NOT NULL COMMENT 'high_risk/awaiting_review 为测评ID，follow_up 为任务ID，key_focus 为受试者ID',
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `status` VARCHAR(16) NOT NULL COMMENT 'open/claimed/snoozed/resolved；无记录视为 open，暂缓到期后视为 open',
+  `claimed_by_user_id` BIGINT NOT NULL DEFAULT 0,
+  `claimed_by_clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `claimed_by_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `claimed_at` DATETIME(3) NULL,
+  `snoozed_until` DATETIME(3) NULL,
+  `resolution_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `resolution_note` VARCHAR(1000) NOT NULL DEFAULT '',
+  `resolved_by_user_id` BIGINT NOT NULL DEFAULT 0,
+  `resolved_by_clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `resolved_by_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `resolved_at` DATETIME(3) NULL,
+  `escalated_at` DATETIME(3) NULL COMMENT '高风险条目超过认领时限未被认领时的升级时间',
+  `version` INT NOT NULL DEFAULT 1 COMMENT '乐观锁版本',
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_workbench_triage_item_subject` (`org_id`,`queue_type`,`subject_id`),
+  KEY `idx_workbench_triage_item_org_testee` (`org_id`,`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='工作台队列条目分诊状态';
+
+CREATE TABLE `workbench_triage_event` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `item_id` BIGINT UNSIGNED NOT NULL,
+  `action` VARCHAR(16) NOT NULL COMMENT 'claim/snooze/resolve/reopen/escalate',
+  `from_status` VARCHAR(16) NOT NULL,
+  `to_status` VARCHAR(16) NOT NULL,
+  `operator_user_id` BIGINT NOT NULL DEFAULT 0 COMMENT '0 表示系统（SLA 升级）',
+  `operator_clinician_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `operator_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `reason_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `note` VARCHAR(1000) NOT NULL DEFAULT '',
+  `snoozed_until` DATETIME(3) NULL,
+  `occurred_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_workbench_triage_event_item` (`item_id`,`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='工作台队列条目分诊历史';
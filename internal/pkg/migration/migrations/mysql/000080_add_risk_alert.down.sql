@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `risk_alert_scan`;
+DROP TABLE IF EXISTS `risk_alert_event`;
+DROP TABLE IF EXISTS `risk_alert`;
+DROP TABLE IF EXISTS `risk_alert_rule`;
@@ -0,0 +1,79 @@
+CREATE TABLE `risk_alert_rule` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `name` VARCHAR(100) NOT NULL,
+  `kind` VARCHAR(32) NOT NULL COMMENT 'item_answer/factor_score/score_change/risk_level',
+  `model_code` VARCHAR(100) NOT NULL DEFAULT '' COMMENT '适用的测评模型编码，空表示全部模型',
+  `condition_json` JSON NOT NULL COMMENT '规则条件：题目/因子编码、比较运算符与阈值或风险等级集合',
+  `severity` VARCHAR(16) NOT NULL COMMENT 'critical/high/medium',
+  `page_channel` VARCHAR(100) NOT NULL COMMENT '首次寻呼的值班渠道',
+  `escalation_channels_json` JSON NOT NULL COMMENT '未确认时依次升级的值班渠道',
+  `ack_timeout_seconds` INT NOT NULL COMMENT '每一级寻呼等待确认的时长',
+  `enabled` TINYINT(1) NOT NULL DEFAULT 1,
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL COMMENT '规则只评估此时间之后完成的测评',
+  `updated_at` DATETIME(3) NOT NULL,
+  `deleted_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_risk_alert_rule_org` (`org_id`,`enabled`,`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='机构配置的风险预警规则';
+
+CREATE TABLE `risk_alert` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `rule_id` BIGINT UNSIGNED NOT NULL,
+  `rule_name` VARCHAR(100) NOT NULL COMMENT '触发时的规则名称快照',
+  `rule_kind` VARCHAR(32) NOT NULL,
+  `severity` VARCHAR(16) NOT NULL,
+  `outcome_id` BIGINT UNSIGNED NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `model_code` VARCHAR(100) NOT NULL DEFAULT '',
+  `status` VARCHAR(16) NOT NULL COMMENT 'open/acknowledged',
+  `channel` VARCHAR(100) NOT NULL COMMENT '当前寻呼的值班渠道',
+  `escalation_level` INT NOT NULL DEFAULT 0 COMMENT '0 为首次寻呼，之后每升级一级加一',
+  `escalation_channels_json` JSON NOT NULL COMMENT '触发时的升级链快照',
+  `ack_timeout_seconds` INT NOT NULL,
+  `summary` VARCHAR(255) NOT NULL,
+  `observed_value` DOUBLE NOT NULL,
+  `baseline_value` DOUBLE NULL COMMENT 'score_change 规则的上次测评分数',
+  `triggered_at` DATETIME(3) NOT NULL,
+  `next_escalation_at` DATETIME(3) NULL COMMENT '未确认时的下一次升级时间；升级链耗尽或已确认时为空',
+  `acknowledged_by_user_id` BIGINT NOT NULL DEFAULT 0,
+  `acknowledged_by_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `acknowledged_at` DATETIME(3) NULL,
+  `ack_note` VARCHAR(1000) NOT NULL DEFAULT '',
+  `version` INT NOT NULL DEFAULT 1 COMMENT '乐观锁版本',
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_risk_alert_rule_assessment` (`rule_id`,`assessment_id`),
+  KEY `idx_risk_alert_org_status` (`org_id`,`status`,`triggered_at`),
+  KEY `idx_risk_alert_escalation` (`status`,`next_escalation_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='风险预警';
+
+CREATE TABLE `risk_alert_event` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `alert_id` BIGINT UNSIGNED NOT NULL,
+  `action` VARCHAR(16) NOT NULL COMMENT 'raised/escalated/acknowledged',
+  `channel` VARCHAR(100) NOT NULL DEFAULT '',
+  `escalation_level` INT NOT NULL DEFAULT 0,
+  `operator_user_id` BIGINT NOT NULL DEFAULT 0 COMMENT '0 表示系统（触发、升级）',
+  `operator_name` VARCHAR(100) NOT NULL DEFAULT '',
+  `note` VARCHAR(1000) NOT NULL DEFAULT '',
+  `occurred_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_risk_alert_event_alert` (`alert_id`,`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='风险预警寻呼与确认历史';
+
+CREATE TABLE `risk_alert_scan` (
+  `outcome_id` BIGINT UNSIGNED NOT NULL,
+  `org_id` BIGINT NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `alerts` INT NOT NULL DEFAULT 0 COMMENT '本次评估触发的预警数',
+  `scanned_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`outcome_id`),
+  KEY `idx_risk_alert_scan_org` (`org_id`,`scanned_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='已按预警规则评估的测评结果';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `critical_item_flag`;
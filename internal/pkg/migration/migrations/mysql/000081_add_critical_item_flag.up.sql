@@ -0,0 +1,16 @@
+CREATE TABLE `critical_item_flag` (
+  `id` BIGINT UNSIGNED NOT NULL COMMENT '答卷 ID；每份答卷最多一条关键条目标记',
+  `org_id` BIGINT NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `questionnaire_code` VARCHAR(100) NOT NULL,
+  `questionnaire_version` VARCHAR(50) NOT NULL DEFAULT '',
+  `categories` VARCHAR(255) NOT NULL COMMENT '命中的关键条目类别，逗号分隔',
+  `items_json` JSON NOT NULL COMMENT '命中的题目编码、类别与触发条件；不含答案原文',
+  `flagged_at` DATETIME(3) NOT NULL COMMENT '答卷提交时间',
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  `deleted_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_critical_item_flag_org` (`org_id`,`deleted_at`,`flagged_at`),
+  KEY `idx_critical_item_flag_testee` (`org_id`,`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='答卷提交时命中的关键条目，供工作台关键条目队列';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `interpretation_report_pdf`;
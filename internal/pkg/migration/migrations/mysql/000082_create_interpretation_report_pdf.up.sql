@@ -0,0 +1,16 @@
+CREATE TABLE `interpretation_report_pdf` (
+  `report_id` VARCHAR(64) NOT NULL COMMENT '解读报告 ID；每份报告最多渲染一次',
+  `org_id` BIGINT NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `object_key` VARCHAR(255) NOT NULL COMMENT '对象存储中的 PDF 路径',
+  `content_hash` CHAR(64) NOT NULL COMMENT '报告投影内容的 SHA-256，与 PDF 页脚一致',
+  `template_version` VARCHAR(100) NOT NULL DEFAULT '',
+  `model_code` VARCHAR(100) NOT NULL DEFAULT '',
+  `model_version` VARCHAR(50) NOT NULL DEFAULT '',
+  `size_bytes` BIGINT NOT NULL,
+  `report_created_at` DATETIME(3) NOT NULL COMMENT '报告生成时间；同一测评以最新报告的 PDF 为准',
+  `rendered_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`report_id`),
+  KEY `idx_interpretation_report_pdf_assessment` (`assessment_id`,`report_created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='解读报告 PDF 渲染记录';
@@ -0,0 +1,19 @@
+CREATE TABLE `interpretation_plan_report` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `org_id` BIGINT NOT NULL,
+  `enrollment_id` BIGINT UNSIGNED NOT NULL COMMENT '计划参与轮次 ID',
+  `plan_id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `round` INT UNSIGNED NOT NULL,
+  `trigger` VARCHAR(32) NOT NULL COMMENT 'enrollment_closed / on_demand',
+  `template_version` VARCHAR(100) NOT NULL DEFAULT '',
+  `builder_identity` VARCHAR(100) NOT NULL,
+  `content_schema_version` VARCHAR(50) NOT NULL,
+  `input_fingerprint` CHAR(64) NOT NULL COMMENT '冻结输入与模板版本的 SHA-256；同一轮次相同输入只生成一份',
+  `input_snapshot` JSON NOT NULL COMMENT '参与报告的测评结果 ID、版本令牌与任务履约计数',
+  `content` JSON NOT NULL,
+  `generated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_interpretation_plan_report_input` (`enrollment_id`,`input_fingerprint`),
+  KEY `idx_interpretation_plan_report_testee` (`testee_id`,`generated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='纵向计划报告';
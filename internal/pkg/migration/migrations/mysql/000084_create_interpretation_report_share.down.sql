@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `interpretation_report_share_access`;
+DROP TABLE IF EXISTS `interpretation_report_share`;
@@ -0,0 +1,39 @@
+DROP TABLE IF EXISTS `interpretation_report_share_access`;
+DROP TABLE IF EXISTS `interpretation_report_share`;
+CREATE TABLE `interpretation_report_share` (
+  `id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `kind` VARCHAR(16) NOT NULL COMMENT 'link / code',
+  `audience` VARCHAR(32) NOT NULL COMMENT '分享的报告受众版本：clinician / family / school',
+  `label` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '分享者填写的接收方备注',
+  `secret_hash` CHAR(64) NOT NULL COMMENT '链接令牌或规范化访问码的 SHA-256；明文只在创建时返回一次',
+  `pin_salt` VARCHAR(64) NOT NULL DEFAULT '',
+  `pin_hash` CHAR(64) NOT NULL DEFAULT '' COMMENT '为空表示不要求 PIN',
+  `max_views` INT NOT NULL,
+  `view_count` INT NOT NULL DEFAULT 0,
+  `failed_pin_attempts` INT NOT NULL DEFAULT 0 COMMENT '连续错误达到上限后授权锁定',
+  `expires_at` DATETIME(3) NOT NULL,
+  `created_at` DATETIME(3) NOT NULL,
+  `last_viewed_at` DATETIME(3) NULL,
+  `revoked_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_interpretation_report_share_secret` (`secret_hash`),
+  KEY `idx_interpretation_report_share_assessment` (`assessment_id`,`created_at`),
+  KEY `idx_interpretation_report_share_testee` (`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='解读报告对外分享授权';
+
+CREATE TABLE `interpretation_report_share_access` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `share_id` BIGINT UNSIGNED NOT NULL,
+  `testee_id` BIGINT UNSIGNED NOT NULL,
+  `assessment_id` BIGINT UNSIGNED NOT NULL,
+  `report_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '放行时展示的报告；拒绝时为 0',
+  `outcome` VARCHAR(32) NOT NULL COMMENT 'viewed / pin_required / pin_rejected / expired / revoked / exhausted / locked',
+  `client_ip` VARCHAR(64) NOT NULL DEFAULT '',
+  `user_agent` VARCHAR(255) NOT NULL DEFAULT '',
+  `accessed_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_interpretation_report_share_access_share` (`share_id`,`accessed_at`),
+  KEY `idx_interpretation_report_share_access_testee` (`testee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='解读报告分享访问记录，只插入不更新';
@@ -0,0 +1,18 @@
+CREATE TABLE `interpretation_group_report` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `org_id` BIGINT NOT NULL,
+  `cohort_kind` VARCHAR(16) NOT NULL COMMENT 'entry / plan / tag / testees',
+  `cohort_ref` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '入口 ID、计划 ID 或标签；显式受试者集合为空',
+  `model_code` VARCHAR(100) NOT NULL,
+  `term_from` DATETIME(3) NOT NULL COMMENT '学期起点（含）',
+  `term_to` DATETIME(3) NOT NULL COMMENT '学期终点（不含）',
+  `min_cell_size` INT UNSIGNED NOT NULL COMMENT '生成时的最小单元格阈值',
+  `input_fingerprint` CHAR(64) NOT NULL COMMENT '群体、学期、阈值与冻结结果的 SHA-256；相同输入只生成一份',
+  `input_snapshot` JSON NOT NULL COMMENT '群体人数与两个学期采用的测评结果 ID、版本令牌',
+  `content` JSON NOT NULL COMMENT '已按最小单元格抑制的汇总内容',
+  `generated_by` BIGINT NOT NULL DEFAULT 0,
+  `generated_at` DATETIME(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_interpretation_group_report_input` (`org_id`,`input_fingerprint`),
+  KEY `idx_interpretation_group_report_cohort` (`org_id`,`cohort_kind`,`cohort_ref`,`generated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='群体报告';
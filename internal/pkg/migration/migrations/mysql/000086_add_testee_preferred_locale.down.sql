@@ -0,0 +1,2 @@
+ALTER TABLE `testee`
+  DROP COLUMN `preferred_locale`;
@@ -0,0 +1,2 @@
+ALTER TABLE `testee`
+  ADD COLUMN `preferred_locale` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '报告内容偏好语言（BCP 47，如 en-US），空值使用默认语言' AFTER `is_key_focus`;
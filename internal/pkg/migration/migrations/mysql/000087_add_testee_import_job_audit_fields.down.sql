@@ -0,0 +1,6 @@
+ALTER TABLE `testee_import_job`
+  DROP KEY `idx_testee_import_job_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `deleted_at`;
@@ -0,0 +1,7 @@
+-- 导入任务改由通用仓储基座持久化，补齐软删除、操作人与乐观锁审计列。
+ALTER TABLE `testee_import_job`
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_testee_import_job_deleted_at` (`deleted_at`);
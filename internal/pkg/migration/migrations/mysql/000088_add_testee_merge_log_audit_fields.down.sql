@@ -0,0 +1,8 @@
+ALTER TABLE `testee_merge_log`
+  DROP KEY `idx_testee_merge_log_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `created_at`;
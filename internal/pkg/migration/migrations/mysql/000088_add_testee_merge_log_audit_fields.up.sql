@@ -0,0 +1,12 @@
+-- 合并记录改由通用仓储基座持久化，补齐创建、软删除、操作人与乐观锁审计列；
+-- 已有记录的创建人与创建时间即合并操作人与合并时间。
+ALTER TABLE `testee_merge_log`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `revert_reason`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_testee_merge_log_deleted_at` (`deleted_at`);
+
+UPDATE `testee_merge_log` SET `created_at` = `merged_at`, `created_by` = `merged_by`, `updated_by` = IF(`status` = 'reverted', `reverted_by`, `merged_by`);
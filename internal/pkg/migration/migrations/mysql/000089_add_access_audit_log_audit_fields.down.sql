@@ -0,0 +1,8 @@
+ALTER TABLE `access_audit_log`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
@@ -0,0 +1,12 @@
+-- 访问审计记录改由通用仓储基座持久化，补齐通用审计列；记录仍只追加，
+-- 已有记录的创建人与创建时间即访问者与访问时间。新增列不参与哈希，链校验结果不变。
+ALTER TABLE `access_audit_log`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `hash`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `access_audit_log` SET `created_at` = `occurred_at`, `updated_at` = `occurred_at`, `created_by` = GREATEST(`actor_user_id`, 0);
@@ -0,0 +1,14 @@
+-- 紧急访问授权改由通用仓储基座持久化，补齐创建、更新、软删除、操作人与乐观锁审计列；
+-- 已有授权的创建人与创建时间即申请人与授予时间。
+ALTER TABLE `break_glass_grant`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `review_note`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_break_glass_grant_deleted_at` (`deleted_at`);
+
+UPDATE `break_glass_grant` SET `created_at` = `granted_at`, `updated_at` = COALESCE(`reviewed_at`, `revoked_at`, `granted_at`),
+  `created_by` = `operator_user_id`, `updated_by` = IF(`reviewed_by` > 0, `reviewed_by`, IF(`revoked_by` > 0, `revoked_by`, `operator_user_id`));
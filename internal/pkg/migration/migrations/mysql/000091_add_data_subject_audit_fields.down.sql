@@ -0,0 +1,18 @@
+ALTER TABLE `data_subject_certificate`
+  DROP KEY `idx_data_subject_certificate_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `data_subject_request`
+  DROP KEY `idx_data_subject_request_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `created_at`;
@@ -0,0 +1,24 @@
+-- 数据主体请求与擦除证书改由通用仓储基座持久化，补齐创建、更新、软删除、操作人与乐观锁审计列；
+-- 已有请求的创建人与创建时间即受理人与受理时间，证书的即签发人与签发时间。
+ALTER TABLE `data_subject_request`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `completed_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_data_subject_request_deleted_at` (`deleted_at`);
+
+UPDATE `data_subject_request` SET `created_at` = `requested_at`, `created_by` = `requested_by`, `updated_by` = `requested_by`;
+
+ALTER TABLE `data_subject_certificate`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `issued_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_data_subject_certificate_deleted_at` (`deleted_at`);
+
+UPDATE `data_subject_certificate` SET `created_at` = `issued_at`, `updated_at` = `issued_at`, `created_by` = `issued_by`, `updated_by` = `issued_by`;
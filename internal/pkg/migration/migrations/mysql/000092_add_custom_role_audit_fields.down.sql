@@ -0,0 +1,15 @@
+ALTER TABLE `custom_role_assignment`
+  DROP KEY `idx_custom_role_assignment_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `custom_role`
+  DROP KEY `idx_custom_role_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `deleted_at`;
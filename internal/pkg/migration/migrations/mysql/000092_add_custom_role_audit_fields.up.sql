@@ -0,0 +1,19 @@
+-- 自定义角色与角色分配改由通用仓储基座持久化，补齐软删除、操作人与乐观锁审计列；
+-- 已有分配的创建人与创建时间即分配人与分配时间。
+ALTER TABLE `custom_role`
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_custom_role_deleted_at` (`deleted_at`);
+
+ALTER TABLE `custom_role_assignment`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `assigned_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_custom_role_assignment_deleted_at` (`deleted_at`);
+
+UPDATE `custom_role_assignment` SET `created_at` = `assigned_at`, `updated_at` = `assigned_at`, `created_by` = `assigned_by`, `updated_by` = `assigned_by`;
@@ -0,0 +1,14 @@
+ALTER TABLE `care_team_event`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `care_team`
+  DROP KEY `idx_care_team_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `deleted_at`;
@@ -0,0 +1,20 @@
+-- 照护团队与团队事件改由通用仓储基座持久化，补齐软删除、操作人与乐观锁审计列；
+-- 事件仍只追加，已有事件的创建人与创建时间即操作人与发生时间。
+-- 成员与受试者分配以组合主键标识，加入人与分配人即其审计信息，不补审计列。
+ALTER TABLE `care_team`
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_care_team_deleted_at` (`deleted_at`);
+
+ALTER TABLE `care_team_event`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `occurred_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `care_team_event` SET `created_at` = `occurred_at`, `updated_at` = `occurred_at`,
+  `created_by` = `operator_user_id`, `updated_by` = `operator_user_id`;
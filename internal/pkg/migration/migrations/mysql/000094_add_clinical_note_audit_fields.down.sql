@@ -0,0 +1,8 @@
+ALTER TABLE `report_clinical_note`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  CHANGE COLUMN `note_version` `version` INT NOT NULL COMMENT '同一测评内从 1 递增';
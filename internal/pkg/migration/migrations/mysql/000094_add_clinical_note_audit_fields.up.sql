@@ -0,0 +1,13 @@
+-- 临床备注改由通用仓储基座持久化，补齐更新、软删除、操作人与乐观锁审计列；
+-- 备注版本改存于 note_version，version 留给通用乐观锁版本。备注仍只追加，
+-- 已有备注的创建人即作者。复核状态以测评 ID 为主键，复核人与复核时间即其审计信息，不补审计列。
+ALTER TABLE `report_clinical_note`
+  CHANGE COLUMN `version` `note_version` INT NOT NULL COMMENT '同一测评内从 1 递增',
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `report_clinical_note` SET `updated_at` = `created_at`, `created_by` = `author_user_id`, `updated_by` = `author_user_id`;
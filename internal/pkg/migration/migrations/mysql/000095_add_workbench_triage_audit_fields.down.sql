@@ -0,0 +1,16 @@
+ALTER TABLE `workbench_triage_event`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `workbench_triage_item`
+  DROP KEY `idx_workbench_triage_item_deleted_at`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  MODIFY COLUMN `version` INT NOT NULL DEFAULT 1 COMMENT '乐观锁版本';
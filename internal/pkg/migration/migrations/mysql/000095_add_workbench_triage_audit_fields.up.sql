@@ -0,0 +1,32 @@
+-- 工作台分诊状态与分诊历史改由通用仓储基座持久化，补齐软删除、操作人审计列；
+-- 分诊条目原有的 version 即乐观锁版本，改为与通用审计列一致的无符号整数。
+-- 历史仍只追加，已有历史的创建人与创建时间即操作人与发生时间；已有条目的创建人与更新人分别取首次与最近一次历史的操作人。
+ALTER TABLE `workbench_triage_item`
+  MODIFY COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '乐观锁版本',
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD KEY `idx_workbench_triage_item_deleted_at` (`deleted_at`);
+
+ALTER TABLE `workbench_triage_event`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `occurred_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `workbench_triage_event` SET `created_at` = `occurred_at`, `updated_at` = `occurred_at`,
+  `created_by` = `operator_user_id`, `updated_by` = `operator_user_id`;
+
+UPDATE `workbench_triage_item` AS i
+  JOIN (
+    SELECT e.`item_id`,
+      SUBSTRING_INDEX(GROUP_CONCAT(e.`operator_user_id` ORDER BY e.`occurred_at` ASC, e.`id` ASC), ',', 1) AS `first_operator`,
+      SUBSTRING_INDEX(GROUP_CONCAT(e.`operator_user_id` ORDER BY e.`occurred_at` DESC, e.`id` DESC), ',', 1) AS `last_operator`
+    FROM `workbench_triage_event` AS e
+    GROUP BY e.`item_id`
+  ) AS h ON h.`item_id` = i.`id`
+  SET i.`created_by` = h.`first_operator`, i.`updated_by` = h.`last_operator`;
@@ -0,0 +1,20 @@
+ALTER TABLE `risk_alert_event`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `risk_alert`
+  DROP KEY `idx_risk_alert_deleted_at`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  MODIFY COLUMN `version` INT NOT NULL DEFAULT 1 COMMENT '乐观锁版本';
+
+ALTER TABLE `risk_alert_rule`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`;
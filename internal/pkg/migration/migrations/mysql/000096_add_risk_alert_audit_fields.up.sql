@@ -0,0 +1,29 @@
+-- 风险预警规则、预警与预警历史改由通用仓储基座持久化，补齐软删除、操作人审计列与版本列；
+-- 预警原有的 version 即乐观锁版本，改为与通用审计列一致的无符号整数。
+-- 历史仍只追加，已有历史的创建人与创建时间即操作人与发生时间；已确认预警的更新人取确认人。
+-- 评估进度以测评结果 ID 为主键、只写一次，保持不变。
+ALTER TABLE `risk_alert_rule`
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+ALTER TABLE `risk_alert`
+  MODIFY COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '乐观锁版本',
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD KEY `idx_risk_alert_deleted_at` (`deleted_at`);
+
+ALTER TABLE `risk_alert_event`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `occurred_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `risk_alert_event` SET `created_at` = `occurred_at`, `updated_at` = `occurred_at`,
+  `created_by` = `operator_user_id`, `updated_by` = `operator_user_id`;
+
+UPDATE `risk_alert` SET `updated_by` = `acknowledged_by_user_id` WHERE `acknowledged_by_user_id` <> 0;
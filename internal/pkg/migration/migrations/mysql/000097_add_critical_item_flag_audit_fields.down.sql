@@ -0,0 +1,5 @@
+ALTER TABLE `critical_item_flag`
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`;
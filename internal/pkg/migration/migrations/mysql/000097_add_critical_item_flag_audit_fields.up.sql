@@ -0,0 +1,7 @@
+-- 关键条目投影改由通用仓储基座持久化，补齐操作人审计列与版本列。
+-- 投影由系统消费答卷事件写入，已有记录的操作人保持为 0。
+ALTER TABLE `critical_item_flag`
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
@@ -0,0 +1,13 @@
+ALTER TABLE `interpretation_report_pdf`
+  DROP PRIMARY KEY,
+  DROP KEY `uk_interpretation_report_pdf_report`,
+  DROP KEY `idx_interpretation_report_pdf_deleted_at`,
+  ADD PRIMARY KEY (`report_id`),
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`,
+  DROP COLUMN `id`;
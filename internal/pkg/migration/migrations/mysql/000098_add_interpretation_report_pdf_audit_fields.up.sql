@@ -0,0 +1,24 @@
+-- 报告 PDF 渲染记录改由通用仓储基座持久化：新增代理主键 id 与软删除、操作人审计列、版本列，
+-- report_id 改为唯一键，同一报告仍最多一条渲染记录。
+-- 已有记录按渲染时间顺序编号，创建与更新时间即渲染时间；渲染由系统完成，操作人保持为 0。
+ALTER TABLE `interpretation_report_pdf`
+  ADD COLUMN `id` BIGINT UNSIGNED NOT NULL DEFAULT 0 FIRST,
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `rendered_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+SET @rendition_id := 0;
+UPDATE `interpretation_report_pdf`
+  SET `id` = (@rendition_id := @rendition_id + 1), `created_at` = `rendered_at`, `updated_at` = `rendered_at`
+  ORDER BY `rendered_at` ASC, `report_id` ASC;
+
+ALTER TABLE `interpretation_report_pdf`
+  DROP PRIMARY KEY,
+  MODIFY COLUMN `id` BIGINT UNSIGNED NOT NULL,
+  ADD PRIMARY KEY (`id`),
+  ADD UNIQUE KEY `uk_interpretation_report_pdf_report` (`report_id`),
+  ADD KEY `idx_interpretation_report_pdf_deleted_at` (`deleted_at`);
@@ -0,0 +1,25 @@
+-- 报告分享授权与访问记录改由通用仓储基座持久化，补齐软删除、操作人审计列与版本列。
+-- 授权的更新时间取最近一次撤销或浏览时间；访问记录只追加，创建与更新时间即访问时间，
+-- 新记录的 ID 由应用生成，已有访问记录保留自增 ID。分享与访问均无后台操作者，操作人保持为 0。
+ALTER TABLE `interpretation_report_share`
+  MODIFY COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `revoked_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_interpretation_report_share_deleted_at` (`deleted_at`);
+
+UPDATE `interpretation_report_share` SET `updated_at` = COALESCE(`revoked_at`, `last_viewed_at`, `created_at`);
+
+ALTER TABLE `interpretation_report_share_access`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `accessed_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`;
+
+UPDATE `interpretation_report_share_access` SET `created_at` = `accessed_at`, `updated_at` = `accessed_at`;
@@ -0,0 +1,9 @@
+ALTER TABLE `interpretation_group_report`
+  DROP KEY `idx_interpretation_group_report_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `updated_at`,
+  DROP COLUMN `created_at`;
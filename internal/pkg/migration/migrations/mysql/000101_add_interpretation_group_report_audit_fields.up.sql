@@ -0,0 +1,14 @@
+-- 群体报告改由通用仓储基座持久化，补齐软删除、操作人审计列与版本列；
+-- 新报告的 ID 由应用生成，已有记录保留自增 ID，创建与更新时间即生成时间，创建与更新人即生成人。
+ALTER TABLE `interpretation_group_report`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `generated_at`,
+  ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `created_at`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_interpretation_group_report_deleted_at` (`deleted_at`);
+
+UPDATE `interpretation_group_report` SET `created_at` = `generated_at`, `updated_at` = `generated_at`,
+  `created_by` = `generated_by`, `updated_by` = `generated_by`;
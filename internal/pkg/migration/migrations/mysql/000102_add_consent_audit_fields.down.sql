@@ -0,0 +1,16 @@
+ALTER TABLE `consent_acceptance`
+  DROP KEY `idx_consent_acceptance_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `created_by`,
+  DROP COLUMN `deleted_at`,
+  DROP COLUMN `created_at`;
+
+ALTER TABLE `consent_document`
+  DROP KEY `idx_consent_document_deleted_at`,
+  DROP COLUMN `version`,
+  DROP COLUMN `deleted_by`,
+  DROP COLUMN `updated_by`,
+  DROP COLUMN `deleted_at`,
+  CHANGE COLUMN `document_version` `version` INT NOT NULL COMMENT '同一机构同一范围内递增';
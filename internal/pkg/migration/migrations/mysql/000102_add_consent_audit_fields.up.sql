@@ -0,0 +1,24 @@
+-- 知情同意书与签署记录改由通用仓储基座持久化，补齐更新、软删除、操作人与乐观锁审计列；
+-- 同意书版本改存于 document_version，version 留给通用乐观锁版本。已有同意书的创建人即起草人，
+-- 更新人取发布人；已有签署记录的创建人与创建时间即填写人与签署时间，更新人取撤回人。
+ALTER TABLE `consent_document`
+  CHANGE COLUMN `version` `document_version` INT NOT NULL COMMENT '同一机构同一范围内递增',
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_consent_document_deleted_at` (`deleted_at`);
+
+UPDATE `consent_document` SET `updated_by` = IF(`published_by` > 0, `published_by`, `created_by`);
+
+ALTER TABLE `consent_acceptance`
+  ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) AFTER `withdraw_reason`,
+  ADD COLUMN `deleted_at` DATETIME(3) NULL DEFAULT NULL AFTER `updated_at`,
+  ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `deleted_at`,
+  ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `created_by`,
+  ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER `updated_by`,
+  ADD COLUMN `version` INT UNSIGNED NOT NULL DEFAULT 1 AFTER `deleted_by`,
+  ADD KEY `idx_consent_acceptance_deleted_at` (`deleted_at`);
+
+UPDATE `consent_acceptance` SET `created_at` = `accepted_at`, `created_by` = `filler_user_id`,
+  `updated_by` = IF(`withdrawn_by` > 0, `withdrawn_by`, `filler_user_id`);
@@ -0,0 +1,69 @@
+package migration
+
+import (
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	createTablePattern = regexp.MustCompile("(?is)^CREATE TABLE(?: IF NOT EXISTS)? `([a-z0-9_]+)`(.*)$")
+	alterTablePattern  = regexp.MustCompile("(?is)ALTER TABLE `([a-z0-9_]+)`(.*)$")
+	dropTablePattern   = regexp.MustCompile("(?i)^DROP TABLE(?: IF EXISTS)? (.+)$")
+	renameTablePattern = regexp.MustCompile("(?i)^RENAME TABLE `([a-z0-9_]+)` TO `([a-z0-9_]+)`$")
+	tableNamePattern   = regexp.MustCompile("`([a-z0-9_]+)`")
+	lineCommentPattern = regexp.MustCompile(`(?m)^\s*--.*$`)
+)
+
+// MySQLTablesWithColumn 按版本顺序重放嵌入的 MySQL up 迁移中的建表、加列、改名与删表语句，
+// 返回当前 schema 中带有指定列的表（按表名排序）。只识别迁移中实际使用的语句形态，
+// 用于"按受试者归属的表必须登记"这类约束测试，不是通用 SQL 解析器。
+func MySQLTablesWithColumn(column string) ([]string, error) {
+	files, err := fs.Glob(migrations, "migrations/mysql/*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	definition := regexp.MustCompile("[(,]\\s*`" + regexp.QuoteMeta(column) + "`\\s+[A-Za-z]")
+	addColumn := regexp.MustCompile("(?i)ADD COLUMN(?: IF NOT EXISTS)? `" + regexp.QuoteMeta(column) + "`")
+	tables := map[string]struct{}{}
+	for _, file := range files {
+		data, err := migrations.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, statement := range strings.Split(lineCommentPattern.ReplaceAllString(string(data), ""), ";") {
+			statement = strings.TrimSpace(statement)
+			if match := createTablePattern.FindStringSubmatch(statement); match != nil {
+				if definition.MatchString(match[2]) {
+					tables[match[1]] = struct{}{}
+				}
+				continue
+			}
+			if match := dropTablePattern.FindStringSubmatch(statement); match != nil {
+				for _, name := range tableNamePattern.FindAllStringSubmatch(match[1], -1) {
+					delete(tables, name[1])
+				}
+				continue
+			}
+			if match := renameTablePattern.FindStringSubmatch(statement); match != nil {
+				if _, ok := tables[match[1]]; ok {
+					delete(tables, match[1])
+					tables[match[2]] = struct{}{}
+				}
+				continue
+			}
+			// 条件加列写在 PREPARE 语句的字符串里，因此不要求 ALTER 位于语句开头。
+			if match := alterTablePattern.FindStringSubmatch(statement); match != nil && addColumn.MatchString(match[2]) {
+				tables[match[1]] = struct{}{}
+			}
+		}
+	}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
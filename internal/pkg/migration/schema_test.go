@@ -0,0 +1,26 @@
+package migration
+
+import "testing"
+
+func TestMySQLTablesWithColumnReplaysCreateAlterAndDrop(t *testing.T) {
+	tables, err := MySQLTablesWithColumn("testee_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, table := range tables {
+		got[table] = true
+	}
+	// assessment_task 的建表语句前有注释；evaluation_outcome 的 testee_id 由后续迁移加列；
+	// behavior_footprint 已被退役迁移删除；testee 本身没有 testee_id 列。
+	for table, want := range map[string]bool{
+		"assessment_task":    true,
+		"evaluation_outcome": true,
+		"behavior_footprint": false,
+		"testee":             false,
+	} {
+		if got[table] != want {
+			t.Errorf("%s listed = %v, want %v", table, got[table], want)
+		}
+	}
+}
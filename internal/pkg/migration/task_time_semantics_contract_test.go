@@ -43,3 +43,27 @@ func TestTaskScheduleRevisionMigrationIsAdditiveAndDoesNotRewriteFacts(t *testin
 		t.Fatal("schema migration must not rewrite source tasks or immutable facts")
 	}
 }
+
+func TestPlanRecurrenceAndTimeZoneMigrationIsAdditive(t *testing.T) {
+	up := readMySQLMigration(t, "000068_add_plan_recurrence_and_time_zone.up.sql")
+	for _, required := range []string{
+		"`time_zone` VARCHAR(64) NOT NULL DEFAULT ''",
+		"`recurrence_rule` VARCHAR(512) NOT NULL DEFAULT ''",
+		"`exception_dates` JSON NULL",
+		"ALTER TABLE `assessment_task`",
+	} {
+		if !strings.Contains(up, required) {
+			t.Fatalf("migration missing %q", required)
+		}
+	}
+	if strings.Contains(strings.ToUpper(up), "UPDATE `ASSESSMENT_TASK`") {
+		t.Fatal("schema migration must not rewrite the large task table")
+	}
+
+	down := readMySQLMigration(t, "000068_add_plan_recurrence_and_time_zone.down.sql")
+	for _, required := range []string{"DROP COLUMN `time_zone`", "DROP COLUMN `recurrence_rule`", "DROP COLUMN `exception_dates`"} {
+		if !strings.Contains(down, required) {
+			t.Fatalf("down migration missing %q", required)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Package pdfdoc 生成简单的 A4 PDF 文档：文字、填充矩形、线段、折线、多边形与圆，足以排版报告与向量图表。
+//
+// 文字统一使用 PDF 阅读器内置的 CJK 字体 STSong-Light（UniGB-UCS2-H 编码），不嵌入字体文件，
+// 因此只支持基本多文种平面字符；超出范围的字符以 "?" 代替。
+// 坐标以页面左上角为原点、向下为正，单位为 point（1/72 英寸）。
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A4 页面尺寸（point）。
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Color RGB 颜色。
+type Color struct{ R, G, B uint8 }
+
+// Black 与 White 为常用颜色。
+var (
+	Black = Color{}
+	White = Color{R: 255, G: 255, B: 255}
+)
+
+// ParseHexColor 解析 "#RRGGBB" 或 "RRGGBB"；格式不合法时返回 false。
+func ParseHexColor(value string) (Color, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(value) != 6 {
+		return Color{}, false
+	}
+	n, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return Color{}, false
+	}
+	return Color{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, true
+}
+
+// Document 多页 PDF 文档。
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page 单个页面的绘制指令。
+type Page struct {
+	content bytes.Buffer
+}
+
+// New 创建空文档。
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage 追加一页并返回它。
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// PageCount 当前页数。
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Page 返回第 index 页（从 0 开始）。
+func (d *Document) Page(index int) *Page {
+	return d.pages[index]
+}
+
+// Text 以 (x, y) 为基线左端绘制单行文字。
+func (p *Page) Text(x, y, size float64, color Color, text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(&p.content, "BT %s rg /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		rgb(color), num(size), num(x), num(PageHeight-y), encodeText(text))
+}
+
+// FillRect 填充左上角为 (x, y) 的矩形。
+func (p *Page) FillRect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		rgb(color), num(x), num(PageHeight-y-height), num(width), num(height))
+}
+
+// Line 绘制线段。
+func (p *Page) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n",
+		rgb(color), num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Point 页面坐标点。
+type Point struct{ X, Y float64 }
+
+// Polyline 以 width 粗细依次连接各点；少于两个点时不绘制。
+func (p *Page) Polyline(points []Point, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+	fmt.Fprintf(&p.content, "%s RG %s w 1 j %s S\n", rgb(color), num(width), path(points))
+}
+
+// Polygon 填充以各点为顶点的闭合多边形；少于三个点时不绘制。
+func (p *Page) Polygon(points []Point, color Color) {
+	if len(points) < 3 {
+		return
+	}
+	fmt.Fprintf(&p.content, "%s rg %s h f\n", rgb(color), path(points))
+}
+
+// Circle 填充圆心为 (cx, cy) 的圆，以四段三次贝塞尔曲线近似。
+func (p *Page) Circle(cx, cy, r float64, color Color) {
+	const k = 0.5523 // 四分之一圆弧的贝塞尔控制点系数
+	y := PageHeight - cy
+	fmt.Fprintf(&p.content, "%s rg %s %s m "+
+		"%s %s %s %s %s %s c %s %s %s %s %s %s c "+
+		"%s %s %s %s %s %s c %s %s %s %s %s %s c f\n",
+		rgb(color), num(cx+r), num(y),
+		num(cx+r), num(y+k*r), num(cx+k*r), num(y+r), num(cx), num(y+r),
+		num(cx-k*r), num(y+r), num(cx-r), num(y+k*r), num(cx-r), num(y),
+		num(cx-r), num(y-k*r), num(cx-k*r), num(y-r), num(cx), num(y-r),
+		num(cx+k*r), num(y-r), num(cx+r), num(y-k*r), num(cx+r), num(y))
+}
+
+func path(points []Point) string {
+	var builder strings.Builder
+	for index, point := range points {
+		op := "l"
+		if index == 0 {
+			op = "m"
+		}
+		if index > 0 {
+			builder.WriteByte(' ')
+		}
+		fmt.Fprintf(&builder, "%s %s %s", num(point.X), num(PageHeight-point.Y), op)
+	}
+	return builder.String()
+}
+
+// TextWidth 估算文字宽度：半角字符按 0.5 em，其余按 1 em，与字体声明的字宽一致。
+func TextWidth(text string, size float64) float64 {
+	var em float64
+	for _, r := range text {
+		if r < 0x80 {
+			em += 0.5
+		} else {
+			em++
+		}
+	}
+	return em * size
+}
+
+// Wrap 按最大宽度折行；保留原有换行，半角单词尽量不拆开。
+func Wrap(text string, size, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lines = append(lines, wrapParagraph(paragraph, size, maxWidth)...)
+	}
+	return lines
+}
+
+func wrapParagraph(paragraph string, size, maxWidth float64) []string {
+	if paragraph == "" {
+		return []string{""}
+	}
+	var (
+		lines   []string
+		current []rune
+		width   float64
+	)
+	runes := []rune(paragraph)
+	for i := 0; i < len(runes); {
+		token := nextToken(runes, i)
+		tokenWidth := TextWidth(string(token), size)
+		if width+tokenWidth > maxWidth && len(current) > 0 {
+			lines = append(lines, strings.TrimRight(string(current), " "))
+			current, width = nil, 0
+			if token[0] == ' ' {
+				i += len(token)
+				continue
+			}
+		}
+		if tokenWidth > maxWidth {
+			// 超长半角单词按字符硬拆。
+			for _, r := range token {
+				w := TextWidth(string(r), size)
+				if width+w > maxWidth && len(current) > 0 {
+					lines = append(lines, string(current))
+					current, width = nil, 0
+				}
+				current = append(current, r)
+				width += w
+			}
+		} else {
+			current = append(current, token...)
+			width += tokenWidth
+		}
+		i += len(token)
+	}
+	if len(current) > 0 {
+		lines = append(lines, strings.TrimRight(string(current), " "))
+	}
+	return lines
+}
+
+// nextToken 返回从 i 开始的一个半角单词、一串空格或一个全角字符。
+func nextToken(runes []rune, i int) []rune {
+	start := i
+	switch {
+	case runes[i] == ' ':
+		for i < len(runes) && runes[i] == ' ' {
+			i++
+		}
+	case runes[i] < 0x80:
+		for i < len(runes) && runes[i] < 0x80 && runes[i] != ' ' {
+			i++
+		}
+	default:
+		i++
+	}
+	return runes[start:i]
+}
+
+// Bytes 序列化文档。没有页面时补一张空白页。
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var (
+		out     bytes.Buffer
+		offsets []int
+	)
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 Catalog, 2 Pages, 3 Type0 字体, 4 CIDFont, 5 FontDescriptor, 6 Info，之后每页为 Page 与内容流两个对象。
+	const firstPageObject = 7
+	kids := make([]string, len(d.pages))
+	for index := range d.pages {
+		kids[index] = fmt.Sprintf("%d 0 R", firstPageObject+index*2)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light /Encoding /UniGB-UCS2-H /DescendantFonts [4 0 R] >>")
+	writeObject("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light " +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 2 >> " +
+		"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>")
+	writeObject("<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 /FontBBox [-25 -254 1000 880] " +
+		"/ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>")
+	writeObject(fmt.Sprintf("<< /Title <FEFF%s> /Producer (qs-server) >>", encodeText(d.title)))
+	for index, page := range d.pages {
+		contentObject := firstPageObject + index*2 + 1
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", num(PageWidth), num(PageHeight), contentObject))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// encodeText 将文字编码为 UTF-16BE 十六进制串；控制字符与增补平面字符以 "?" 代替。
+func encodeText(text string) string {
+	var builder strings.Builder
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		if r < 0x20 || r > 0xFFFF || (r >= 0xD800 && r <= 0xDFFF) {
+			r = '?'
+		}
+		fmt.Fprintf(&builder, "%04X", r)
+	}
+	return builder.String()
+}
+
+func rgb(c Color) string {
+	return fmt.Sprintf("%s %s %s", num(float64(c.R)/255), num(float64(c.G)/255), num(float64(c.B)/255))
+}
+
+// num 输出保留两位小数的数值，足够 point 级精度。
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
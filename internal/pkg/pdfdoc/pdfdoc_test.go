@@ -0,0 +1,95 @@
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBytesWritesValidCrossReference(t *testing.T) {
+	doc := New("测评报告")
+	page := doc.AddPage()
+	page.FillRect(40, 40, 100, 20, Color{R: 31, G: 111, B: 235})
+	page.Text(40, 80, 12, Black, "总分 42")
+	page.Line(40, 90, 200, 90, 0.5, Black)
+	doc.AddPage().Text(40, 80, 12, Black, "第二页")
+	content := doc.Bytes()
+
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatalf("missing header or trailer")
+	}
+	if !bytes.Contains(content, []byte("/Count 2")) {
+		t.Fatalf("page count not written")
+	}
+	// 总 = U+603B，分 = U+5206，空格 = 0020。
+	if !bytes.Contains(content, []byte("<603B5206002000340032> Tj")) {
+		t.Fatalf("text not encoded as UTF-16BE hex: %s", content)
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	if startxref == nil {
+		t.Fatal("startxref missing")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(content[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content[xref:], -1)
+	for index, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", index+1); !bytes.HasPrefix(content[offset:], []byte(want)) {
+			t.Fatalf("xref entry %d points at %q", index+1, content[offset:offset+10])
+		}
+	}
+}
+
+func TestWrapBreaksCJKAndKeepsWords(t *testing.T) {
+	lines := Wrap("抑郁 screening result 结果提示需要关注", 10, 60)
+	for _, line := range lines {
+		if TextWidth(line, 10) > 60 {
+			t.Fatalf("line %q exceeds width", line)
+		}
+	}
+	joined := strings.Join(lines, "|")
+	if !strings.Contains(joined, "screening") || !strings.Contains(joined, "result") {
+		t.Fatalf("words split: %q", joined)
+	}
+	if got := Wrap("a\n\nb", 10, 100); len(got) != 3 || got[1] != "" {
+		t.Fatalf("Wrap kept paragraphs = %q", got)
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	if c, ok := ParseHexColor("#1F6FEB"); !ok || c != (Color{R: 0x1f, G: 0x6f, B: 0xeb}) {
+		t.Fatalf("ParseHexColor = %#v, %v", c, ok)
+	}
+	if _, ok := ParseHexColor("blue"); ok {
+		t.Fatal("invalid color accepted")
+	}
+}
+
+func TestShapesWritePathOperators(t *testing.T) {
+	page := New("图表").AddPage()
+	page.Polyline([]Point{{X: 10, Y: 10}, {X: 20, Y: 30}}, 1.5, Black)
+	page.Polygon([]Point{{X: 10, Y: 10}, {X: 20, Y: 10}, {X: 15, Y: 20}}, Black)
+	page.Circle(50, 50, 4, Black)
+	page.Polyline([]Point{{X: 1, Y: 1}}, 1, Black)
+	page.Polygon([]Point{{X: 1, Y: 1}, {X: 2, Y: 2}}, Black)
+	content := page.content.String()
+
+	if !strings.Contains(content, "10 831.89 m 20 811.89 l S") {
+		t.Fatalf("polyline not flipped to PDF coordinates: %s", content)
+	}
+	if !strings.Contains(content, "15 821.89 l h f") {
+		t.Fatalf("polygon not closed and filled: %s", content)
+	}
+	if strings.Count(content, " c") != 4 || !strings.Contains(content, "54 791.89 m") {
+		t.Fatalf("circle not drawn as four curves: %s", content)
+	}
+	if strings.Count(content, "\n") != 3 {
+		t.Fatalf("degenerate shapes drawn: %s", content)
+	}
+}
@@ -0,0 +1,45 @@
+package redaction
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// pseudonymHexLen 假名的十六进制长度（64 bit），在单机构规模下碰撞概率可忽略。
+const pseudonymHexLen = 16
+
+// Pseudonymizer 用 HMAC-SHA256 把内部ID映射为稳定假名；同一密钥下同一ID的假名不变，
+// 多次导出的数据可以关联，但不持有密钥无法还原或枚举原始ID。
+type Pseudonymizer struct {
+	key       []byte
+	ephemeral bool
+}
+
+// NewPseudonymizer 创建假名生成器；secret 为空时使用进程级随机密钥，假名仅在本进程生命周期内稳定。
+func NewPseudonymizer(secret string) *Pseudonymizer {
+	if secret != "" {
+		return &Pseudonymizer{key: []byte(secret)}
+	}
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic("redaction: failed to generate pseudonym key: " + err.Error())
+	}
+	return &Pseudonymizer{key: key, ephemeral: true}
+}
+
+// Ephemeral 报告是否使用了进程级随机密钥。
+func (p *Pseudonymizer) Ephemeral() bool {
+	return p != nil && p.ephemeral
+}
+
+// Pseudonym 返回 kind 命名空间下 id 的假名，例如 "testee_3f2a…"；不同 kind 的同一 id 假名不同。
+func (p *Pseudonymizer) Pseudonym(kind string, id uint64) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatUint(id, 10)))
+	return kind + "_" + hex.EncodeToString(mac.Sum(nil))[:pseudonymHexLen]
+}
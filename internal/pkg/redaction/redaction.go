@@ -0,0 +1,190 @@
+// Package redaction 受试者个人信息（PII）的字段级脱敏策略：姓名打码、生日换算为年龄段、
+// 外部身份标识隐藏或假名化、监护人联系方式打码。策略本身不依赖权限模型，
+// 由 apiserver 按能力与关系类型决定，apiserver 与 collection-server 的响应映射统一按策略输出。
+package redaction
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Mode 单个字段的输出方式。
+type Mode string
+
+const (
+	ModeFull      Mode = "full"      // 原样输出
+	ModeMasked    Mode = "masked"    // 部分打码，保留可辨认的首尾字符
+	ModeAgeBand   Mode = "age_band"  // 仅生日：以年龄段代替出生日期
+	ModePseudonym Mode = "pseudonym" // 仅标识：以稳定假名代替原始ID
+	ModeHidden    Mode = "hidden"    // 不输出
+)
+
+// Policy 受试者 PII 的字段级脱敏策略。零值等同于 Full。
+type Policy struct {
+	Name       Mode // 受试者姓名：full/masked/hidden
+	Birthday   Mode // 出生日期：full/age_band/hidden
+	ProfileID  Mode // 外部身份档案ID：full/hidden
+	Identifier Mode // 受试者ID：full/pseudonym
+	Contact    Mode // 监护人姓名与电话：full/masked/hidden
+}
+
+var (
+	// Full 不脱敏：机构管理员与有授权关系的从业者。
+	Full = Policy{Name: ModeFull, Birthday: ModeFull, ProfileID: ModeFull, Identifier: ModeFull, Contact: ModeFull}
+	// Restricted 可辨认但不暴露完整身份：只有来源关系的从业者、无关系的操作者与服务间枚举。
+	Restricted = Policy{Name: ModeMasked, Birthday: ModeAgeBand, ProfileID: ModeHidden, Identifier: ModeFull, Contact: ModeHidden}
+	// Deidentified 去标识：统计人员与数据导出，ID 假名化、不含姓名。
+	Deidentified = Policy{Name: ModeHidden, Birthday: ModeAgeBand, ProfileID: ModeHidden, Identifier: ModePseudonym, Contact: ModeHidden}
+)
+
+// IsFull 判断策略是否不做任何脱敏。
+func (p Policy) IsFull() bool {
+	return p.normalized() == Full
+}
+
+func (p Policy) normalized() Policy {
+	fill := func(mode Mode) Mode {
+		if mode == "" {
+			return ModeFull
+		}
+		return mode
+	}
+	return Policy{Name: fill(p.Name), Birthday: fill(p.Birthday), ProfileID: fill(p.ProfileID), Identifier: fill(p.Identifier), Contact: fill(p.Contact)}
+}
+
+// RedactName 按策略输出受试者姓名。
+func (p Policy) RedactName(name string) string {
+	switch p.normalized().Name {
+	case ModeFull:
+		return name
+	case ModeMasked:
+		return MaskName(name)
+	default:
+		return ""
+	}
+}
+
+// RedactBirthday 仅在不脱敏时返回出生日期。
+func (p Policy) RedactBirthday(birthday *time.Time) *time.Time {
+	if p.normalized().Birthday != ModeFull {
+		return nil
+	}
+	return birthday
+}
+
+// AgeBandOf 策略要求以年龄段代替生日时返回年龄段，否则返回空串。
+func (p Policy) AgeBandOf(birthday *time.Time, now time.Time) string {
+	if p.normalized().Birthday != ModeAgeBand || birthday == nil || birthday.IsZero() {
+		return ""
+	}
+	return AgeBand(*birthday, now)
+}
+
+// RedactProfileID 仅在不脱敏时返回外部身份档案ID。
+func (p Policy) RedactProfileID(profileID *uint64) *uint64 {
+	if p.normalized().ProfileID != ModeFull {
+		return nil
+	}
+	return profileID
+}
+
+// RedactIdentifier 按策略输出受试者ID；假名化需要 pseudonyms，未配置时不输出。
+func (p Policy) RedactIdentifier(pseudonyms *Pseudonymizer, kind string, id uint64) string {
+	switch p.normalized().Identifier {
+	case ModeFull:
+		return strconv.FormatUint(id, 10)
+	case ModePseudonym:
+		if pseudonyms == nil {
+			return ""
+		}
+		return pseudonyms.Pseudonym(kind, id)
+	default:
+		return ""
+	}
+}
+
+// RedactContactName 按策略输出监护人姓名。
+func (p Policy) RedactContactName(name string) string {
+	switch p.normalized().Contact {
+	case ModeFull:
+		return name
+	case ModeMasked:
+		return MaskName(name)
+	default:
+		return ""
+	}
+}
+
+// RedactPhone 按策略输出监护人电话。
+func (p Policy) RedactPhone(phone string) string {
+	switch p.normalized().Contact {
+	case ModeFull:
+		return phone
+	case ModeMasked:
+		return MaskPhone(phone)
+	default:
+		return ""
+	}
+}
+
+// MaskName 姓名打码：两个字保留姓，三个字及以上保留首尾字符（"张三" → "张*"，"欧阳娜娜" → "欧**娜"）。
+func MaskName(name string) string {
+	name = strings.TrimSpace(name)
+	runes := []rune(name)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1:
+		return "*"
+	case 2:
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-1])
+	}
+}
+
+// MaskPhone 电话打码：保留前 3 位与后 4 位，过短的号码只保留后 2 位。
+func MaskPhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	count := utf8.RuneCountInString(phone)
+	runes := []rune(phone)
+	switch {
+	case count == 0:
+		return ""
+	case count >= 11:
+		return string(runes[:3]) + strings.Repeat("*", count-7) + string(runes[count-4:])
+	case count > 2:
+		return strings.Repeat("*", count-2) + string(runes[count-2:])
+	default:
+		return strings.Repeat("*", count)
+	}
+}
+
+// ageBands 年龄段边界（含下界）；儿童青少年按学段细分，成人按十年分段。
+var ageBands = []struct {
+	min   int
+	label string
+}{
+	{0, "0-5"}, {6, "6-11"}, {12, "12-17"}, {18, "18-29"}, {30, "30-39"},
+	{40, "40-49"}, {50, "50-59"}, {60, "60-69"}, {70, "70+"},
+}
+
+// AgeBand 按周岁返回年龄段；出生日期晚于 now 时返回空串。
+func AgeBand(birthday, now time.Time) string {
+	age := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		age--
+	}
+	if age < 0 {
+		return ""
+	}
+	label := ageBands[0].label
+	for _, band := range ageBands {
+		if age >= band.min {
+			label = band.label
+		}
+	}
+	return label
+}
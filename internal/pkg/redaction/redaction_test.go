@@ -0,0 +1,75 @@
+package redaction
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaskNameAndPhone(t *testing.T) {
+	for input, want := range map[string]string{"": "", "李": "*", "张三": "张*", "王小明": "王*明", "欧阳娜娜": "欧**娜"} {
+		if got := MaskName(input); got != want {
+			t.Fatalf("MaskName(%q) = %q, want %q", input, got, want)
+		}
+	}
+	for input, want := range map[string]string{"13812345678": "138****5678", "12345": "***45", "": ""} {
+		if got := MaskPhone(input); got != want {
+			t.Fatalf("MaskPhone(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestAgeBandUsesCompletedYears(t *testing.T) {
+	now := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	cases := map[time.Time]string{
+		time.Date(2014, 6, 16, 0, 0, 0, 0, time.UTC): "6-11",
+		time.Date(2014, 6, 15, 0, 0, 0, 0, time.UTC): "12-17",
+		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC):  "30-39",
+		time.Date(1940, 1, 1, 0, 0, 0, 0, time.UTC):  "70+",
+		time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC):  "",
+	}
+	for birthday, want := range cases {
+		if got := AgeBand(birthday, now); got != want {
+			t.Fatalf("AgeBand(%s) = %q, want %q", birthday.Format("2006-01-02"), got, want)
+		}
+	}
+}
+
+func TestPolicyRedactsFields(t *testing.T) {
+	now := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	birthday := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
+	profileID := uint64(88)
+
+	if !(Policy{}).IsFull() || Restricted.IsFull() {
+		t.Fatal("zero policy must be full, restricted must not")
+	}
+	if Full.RedactName("王小明") != "王小明" || Full.RedactBirthday(&birthday) == nil || Full.AgeBandOf(&birthday, now) != "" {
+		t.Fatal("full policy must keep fields")
+	}
+	if Restricted.RedactName("王小明") != "王*明" || Restricted.RedactBirthday(&birthday) != nil ||
+		Restricted.AgeBandOf(&birthday, now) != "6-11" || Restricted.RedactProfileID(&profileID) != nil ||
+		Restricted.RedactPhone("13812345678") != "" {
+		t.Fatal("restricted policy leaked a field")
+	}
+	pseudonyms := NewPseudonymizer("secret")
+	ref := Deidentified.RedactIdentifier(pseudonyms, "testee", 42)
+	if Deidentified.RedactName("王小明") != "" || !strings.HasPrefix(ref, "testee_") || len(ref) != len("testee_")+pseudonymHexLen {
+		t.Fatalf("deidentified ref = %q", ref)
+	}
+	if Deidentified.RedactIdentifier(nil, "testee", 42) != "" {
+		t.Fatal("pseudonym without key must not fall back to raw id")
+	}
+}
+
+func TestPseudonymIsStablePerKeyAndKind(t *testing.T) {
+	a, b := NewPseudonymizer("k1"), NewPseudonymizer("k1")
+	if a.Pseudonym("testee", 1) != b.Pseudonym("testee", 1) {
+		t.Fatal("same key must produce same pseudonym")
+	}
+	if a.Pseudonym("testee", 1) == a.Pseudonym("profile", 1) || a.Pseudonym("testee", 1) == NewPseudonymizer("k2").Pseudonym("testee", 1) {
+		t.Fatal("pseudonym must depend on kind and key")
+	}
+	if ephemeral := NewPseudonymizer(""); !ephemeral.Ephemeral() || ephemeral.Pseudonym("testee", 1) == a.Pseudonym("testee", 1) {
+		t.Fatal("empty secret must use a random key")
+	}
+}
@@ -0,0 +1,307 @@
+package reportchart
+
+import (
+	"math"
+	"strconv"
+)
+
+// Anchor 文字相对于 x 的水平对齐方式。
+type Anchor int
+
+const (
+	AnchorStart Anchor = iota
+	AnchorMiddle
+	AnchorEnd
+)
+
+// Vec 画布坐标点。
+type Vec struct{ X, Y float64 }
+
+// Canvas 图表绘制目标。坐标以图表左上角为原点、向下为正；Text 的 y 为基线。
+type Canvas interface {
+	Rect(x, y, width, height float64, fill Color)
+	Line(x1, y1, x2, y2, width float64, stroke Color)
+	Polyline(points []Vec, width float64, stroke Color)
+	Polygon(points []Vec, fill Color)
+	Circle(cx, cy, r float64, fill Color)
+	Text(x, y, size float64, color Color, anchor Anchor, text string)
+}
+
+// Width 所有图表的固定宽度。
+const Width = 480.0
+
+const (
+	titleSize    = 11.0
+	labelSize    = 8.0
+	plotTop      = 30.0
+	profileH     = 260.0
+	radarH       = 320.0
+	poleRowH     = 26.0
+	sparklineH   = 96.0
+	poleLabelW   = 120.0
+	poleValueW   = 56.0
+	radarLabelW  = 96.0
+	profileLeftW = 36.0
+)
+
+// Size 返回图表的宽高；高度只取决于图表类型与点数。
+func Size(spec Spec) (float64, float64) {
+	switch spec.Kind {
+	case KindProfile:
+		return Width, profileH
+	case KindRadar:
+		return Width, radarH
+	case KindPoleBars:
+		return Width, plotTop + 8 + poleRowH*float64(len(spec.Points))
+	default:
+		return Width, sparklineH
+	}
+}
+
+// Draw 按图表类型的固定版式把 spec 画到 canvas 上。相同的 spec 与 theme 总是产生相同的绘制指令。
+func Draw(spec Spec, canvas Canvas, theme Theme) {
+	if spec.Title != "" {
+		canvas.Text(0, 16, titleSize, theme.Text, AnchorStart, truncate(spec.Title, titleSize, Width))
+	}
+	switch spec.Kind {
+	case KindProfile:
+		drawProfile(spec, canvas, theme)
+	case KindRadar:
+		drawRadar(spec, canvas, theme)
+	case KindPoleBars:
+		drawPoleBars(spec, canvas, theme)
+	case KindSparkline:
+		drawSparkline(spec, canvas, theme)
+	}
+}
+
+// drawProfile 剖面图：横轴为因子，纵轴为 [Min, Max]，背景为区间带。
+func drawProfile(spec Spec, canvas Canvas, theme Theme) {
+	left, right, top, bottom := profileLeftW, Width-4, plotTop, profileH-40
+	y := func(value float64) float64 {
+		return bottom - clampRatio(value, spec.Min, spec.Max)*(bottom-top)
+	}
+	drawBands(spec, canvas, theme, left, right, y)
+	canvas.Line(left, top, left, bottom, 0.75, theme.Grid)
+	canvas.Line(left, bottom, right, bottom, 0.75, theme.Grid)
+	for _, tick := range axisTicks(spec) {
+		canvas.Text(left-4, y(tick)+3, labelSize, theme.Muted, AnchorEnd, formatNumber(tick))
+	}
+	if spec.Reference != nil {
+		canvas.Line(left, y(*spec.Reference), right, y(*spec.Reference), 0.75, theme.Muted)
+	}
+	if len(spec.Points) == 0 {
+		return
+	}
+	column := (right - left) / float64(len(spec.Points))
+	labelWidth := column - 4
+	stagger := labelWidth < 48
+	if stagger {
+		labelWidth = column*2 - 4
+	}
+	line := make([]Vec, len(spec.Points))
+	for index, point := range spec.Points {
+		line[index] = Vec{X: left + (float64(index)+0.5)*column, Y: y(point.Value)}
+	}
+	canvas.Polyline(line, 1.75, theme.Primary)
+	for index, point := range spec.Points {
+		at := line[index]
+		canvas.Circle(at.X, at.Y, 3.5, theme.Primary)
+		canvas.Text(at.X, at.Y-7, labelSize, theme.Text, AnchorMiddle, formatNumber(point.Value))
+		labelY := bottom + 14
+		if stagger && index%2 == 1 {
+			labelY += 12
+		}
+		canvas.Text(at.X, labelY, labelSize, theme.Muted, AnchorMiddle, truncate(point.Label, labelSize, labelWidth))
+	}
+}
+
+// drawRadar 雷达图：每个点一条辐射轴，半径为按满分归一的得分率。
+func drawRadar(spec Spec, canvas Canvas, theme Theme) {
+	cx, cy := Width/2, plotTop+(radarH-plotTop)/2
+	radius := (radarH-plotTop)/2 - 28
+	n := len(spec.Points)
+	if n < 3 {
+		return
+	}
+	at := func(index int, ratio float64) Vec {
+		angle := -math.Pi/2 + 2*math.Pi*float64(index)/float64(n)
+		return Vec{X: cx + math.Cos(angle)*radius*ratio, Y: cy + math.Sin(angle)*radius*ratio}
+	}
+	for _, ring := range []float64{0.25, 0.5, 0.75, 1} {
+		outline := make([]Vec, 0, n+1)
+		for index := 0; index <= n; index++ {
+			outline = append(outline, at(index%n, ring))
+		}
+		canvas.Polyline(outline, 0.5, theme.Grid)
+	}
+	for index := range spec.Points {
+		end := at(index, 1)
+		canvas.Line(cx, cy, end.X, end.Y, 0.5, theme.Grid)
+	}
+	shape := make([]Vec, n)
+	for index, point := range spec.Points {
+		shape[index] = at(index, spec.ratio(point))
+	}
+	canvas.Polygon(shape, theme.Primary.Mix(white, 0.75))
+	canvas.Polyline(append(append([]Vec{}, shape...), shape[0]), 1.5, theme.Primary)
+	for index, point := range spec.Points {
+		canvas.Circle(shape[index].X, shape[index].Y, 2.5, theme.Primary)
+		label := at(index, 1+12/radius)
+		anchor := AnchorMiddle
+		switch dx := label.X - cx; {
+		case dx > 1:
+			anchor = AnchorStart
+		case dx < -1:
+			anchor = AnchorEnd
+		}
+		text := truncate(point.Label, labelSize, radarLabelW) + " " + formatNumber(point.Value)
+		canvas.Text(label.X, label.Y+3, labelSize, theme.Muted, anchor, text)
+	}
+}
+
+// drawPoleBars 两极条形图：条形从参考点（中点）延伸到得分，左端为下限、右端为满分。
+func drawPoleBars(spec Spec, canvas Canvas, theme Theme) {
+	left, right := poleLabelW, Width-poleValueW
+	for index, point := range spec.Points {
+		top := plotTop + 8 + float64(index)*poleRowH
+		x := func(ratio float64) float64 { return left + ratio*(right-left) }
+		canvas.Text(0, top+13, labelSize+1, theme.Text, AnchorStart, truncate(point.Label, labelSize+1, poleLabelW-8))
+		canvas.Rect(left, top+4, right-left, 10, theme.Track)
+		origin := 0.0
+		if spec.Reference != nil {
+			origin = clampRatio(*spec.Reference, spec.Min, spec.scale(point))
+		}
+		value := spec.ratio(point)
+		from, to := math.Min(origin, value), math.Max(origin, value)
+		if to > from {
+			canvas.Rect(x(from), top+4, x(to)-x(from), 10, theme.Primary)
+		}
+		if spec.Reference != nil {
+			canvas.Line(x(origin), top+1, x(origin), top+17, 1, theme.Muted)
+		}
+		text := formatNumber(point.Value)
+		if point.Max != nil {
+			text += "/" + formatNumber(*point.Max)
+		}
+		canvas.Text(right+8, top+13, labelSize, theme.Muted, AnchorStart, text)
+	}
+}
+
+// drawSparkline 迷你折线：各时间点依次排开，末点加粗并标注数值。
+func drawSparkline(spec Spec, canvas Canvas, theme Theme) {
+	left, right, top, bottom := 4.0, Width-poleValueW, plotTop, sparklineH-22
+	lo, hi := spec.Min, spec.Max
+	if hi <= lo {
+		lo, hi = valueRange(spec.Points)
+	}
+	y := func(value float64) float64 {
+		return bottom - clampRatio(value, lo, hi)*(bottom-top)
+	}
+	drawBands(spec, canvas, theme, left, right, y)
+	canvas.Line(left, bottom, right, bottom, 0.5, theme.Grid)
+	if spec.Reference != nil {
+		canvas.Line(left, y(*spec.Reference), right, y(*spec.Reference), 0.5, theme.Muted)
+	}
+	n := len(spec.Points)
+	if n == 0 {
+		return
+	}
+	step := 0.0
+	if n > 1 {
+		step = (right - left) / float64(n-1)
+	}
+	line := make([]Vec, n)
+	for index, point := range spec.Points {
+		line[index] = Vec{X: left + step*float64(index), Y: y(point.Value)}
+	}
+	if n > 1 {
+		canvas.Polyline(line, 1.5, theme.Primary)
+	}
+	for index := range line[:n-1] {
+		canvas.Circle(line[index].X, line[index].Y, 2, theme.Primary)
+	}
+	last := line[n-1]
+	canvas.Circle(last.X, last.Y, 3.5, theme.Primary)
+	canvas.Text(right+8, last.Y+3, labelSize+1, theme.Text, AnchorStart, formatNumber(spec.Points[n-1].Value))
+	canvas.Text(left, bottom+14, labelSize, theme.Muted, AnchorStart, truncate(spec.Points[0].Label, labelSize, (right-left)/2-4))
+	if n > 1 {
+		canvas.Text(right, bottom+14, labelSize, theme.Muted, AnchorEnd, truncate(spec.Points[n-1].Label, labelSize, (right-left)/2-4))
+	}
+}
+
+// drawBands 画出纵轴上的区间带，并在右侧内缘标注区间名称。
+func drawBands(spec Spec, canvas Canvas, theme Theme, left, right float64, y func(float64) float64) {
+	for _, band := range spec.Bands {
+		top, bottom := y(band.To), y(band.From)
+		if bottom-top <= 0 {
+			continue
+		}
+		canvas.Rect(left, top, right-left, bottom-top, theme.tone(band.Tone))
+		if band.Label != "" && bottom-top >= labelSize+4 {
+			canvas.Text(right-4, top+labelSize+2, labelSize-1, theme.Muted, AnchorEnd, band.Label)
+		}
+	}
+}
+
+// axisTicks 数值轴刻度：区间带边界，没有区间带时为上下限。
+func axisTicks(spec Spec) []float64 {
+	seen := map[float64]bool{}
+	var ticks []float64
+	add := func(value float64) {
+		if value < spec.Min || value > spec.Max || seen[value] {
+			return
+		}
+		seen[value] = true
+		ticks = append(ticks, value)
+	}
+	add(spec.Min)
+	for _, band := range spec.Bands {
+		add(band.From)
+		add(band.To)
+	}
+	add(spec.Max)
+	return ticks
+}
+
+func valueRange(points []Point) (float64, float64) {
+	if len(points) == 0 {
+		return 0, 1
+	}
+	lo, hi := points[0].Value, points[0].Value
+	for _, point := range points[1:] {
+		lo, hi = math.Min(lo, point.Value), math.Max(hi, point.Value)
+	}
+	if hi == lo {
+		return lo - 1, hi + 1
+	}
+	return lo, hi
+}
+
+// TextWidth 估算文字宽度：半角字符按 0.5 em，其余按 1 em，与 PDF 报告字体的字宽一致。
+func TextWidth(text string, size float64) float64 {
+	var em float64
+	for _, r := range text {
+		if r < 0x80 {
+			em += 0.5
+		} else {
+			em++
+		}
+	}
+	return em * size
+}
+
+func truncate(text string, size, width float64) string {
+	if TextWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,127 @@
+package reportchart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func sampleSpecs() []Spec {
+	fifty := floatPtr(50)
+	return []Spec{
+		{ID: "profile", Kind: KindProfile, Title: "T 分剖面", Min: 20, Max: 80, Reference: fifty,
+			Bands:  []Band{{From: 20, To: 40, Label: "低于平均", Tone: ToneLow}, {From: 40, To: 60, Label: "平均", Tone: ToneNormal}, {From: 60, To: 70, Label: "偏高", Tone: ToneElevated}, {From: 70, To: 80, Label: "显著偏高", Tone: ToneHigh}},
+			Points: []Point{{Code: "anx", Label: "焦虑", Value: 72}, {Code: "dep", Label: "抑郁", Value: 55}, {Code: "som", Label: "躯体化 & <其他>", Value: 18}}},
+		{ID: "radar", Kind: KindRadar, Title: "因子雷达", Max: 10,
+			Points: []Point{{Code: "a", Label: "A", Value: 5, Max: floatPtr(10)}, {Code: "b", Label: "B", Value: 20, Max: floatPtr(40)}, {Code: "c", Label: "C", Value: 3}}},
+		{ID: "pole_bars", Kind: KindPoleBars, Title: "类型倾向", Max: 40, Reference: floatPtr(20),
+			Points: []Point{{Code: "EI", Label: "外向-内向", Value: 28, Max: floatPtr(40)}, {Code: "SN", Label: "实感-直觉", Value: 12, Max: floatPtr(40)}}},
+		{ID: "sparkline", Kind: KindSparkline, Title: "总分趋势",
+			Points: []Point{{Label: "基线", Value: 30}, {Label: "第 2 次", Value: 24}, {Label: "第 3 次", Value: 18}}},
+	}
+}
+
+func TestSVGIsWellFormedAndDeterministic(t *testing.T) {
+	for _, spec := range sampleSpecs() {
+		first := SVG(spec, DefaultTheme())
+		if !bytes.Equal(first, SVG(spec, DefaultTheme())) {
+			t.Fatalf("%s: SVG output is not deterministic", spec.ID)
+		}
+		decoder := xml.NewDecoder(bytes.NewReader(first))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: SVG is not well-formed: %v\n%s", spec.ID, err, first)
+			}
+		}
+		if !bytes.Contains(first, []byte("<title>"+spec.Title+"</title>")) {
+			t.Fatalf("%s: SVG misses title", spec.ID)
+		}
+	}
+}
+
+func TestSVGEscapesLabels(t *testing.T) {
+	out := string(SVG(sampleSpecs()[0], DefaultTheme()))
+	if strings.Contains(out, "<其他>") || !strings.Contains(out, "&amp;") {
+		t.Fatalf("labels not escaped:\n%s", out)
+	}
+}
+
+type recorder struct {
+	polygons [][]Vec
+	rects    []Color
+	texts    []string
+}
+
+func (r *recorder) Rect(_, _, _, _ float64, fill Color)  { r.rects = append(r.rects, fill) }
+func (r *recorder) Line(_, _, _, _, _ float64, _ Color)  {}
+func (r *recorder) Polyline(_ []Vec, _ float64, _ Color) {}
+func (r *recorder) Polygon(points []Vec, _ Color)        { r.polygons = append(r.polygons, points) }
+func (r *recorder) Circle(_, _, _ float64, _ Color)      {}
+func (r *recorder) Text(_, _, _ float64, _ Color, _ Anchor, text string) {
+	r.texts = append(r.texts, text)
+}
+
+func TestDrawRadarNormalizesByPointMax(t *testing.T) {
+	canvas := &recorder{}
+	Draw(sampleSpecs()[1], canvas, DefaultTheme())
+	if len(canvas.polygons) != 1 || len(canvas.polygons[0]) != 3 {
+		t.Fatalf("radar polygon = %v", canvas.polygons)
+	}
+	cy := plotTop + (radarH-plotTop)/2
+	radius := (radarH-plotTop)/2 - 28
+	// A 与 B 都是满分的一半，C 以图表上限 10 归一为 0.3。
+	if got := cy - canvas.polygons[0][0].Y; got < radius*0.5-0.01 || got > radius*0.5+0.01 {
+		t.Fatalf("point A radius = %v, want %v", got, radius*0.5)
+	}
+}
+
+func TestDrawProfileUsesBandTones(t *testing.T) {
+	canvas := &recorder{}
+	theme := DefaultTheme()
+	Draw(sampleSpecs()[0], canvas, theme)
+	want := []Color{theme.Tones[ToneLow], theme.Tones[ToneNormal], theme.Tones[ToneElevated], theme.Tones[ToneHigh]}
+	if len(canvas.rects) != len(want) {
+		t.Fatalf("band rects = %v", canvas.rects)
+	}
+	for index := range want {
+		if canvas.rects[index] != want[index] {
+			t.Fatalf("band %d color = %v, want %v", index, canvas.rects[index], want[index])
+		}
+	}
+	if !contains(canvas.texts, "显著偏高") || !contains(canvas.texts, "72") {
+		t.Fatalf("profile texts = %q", canvas.texts)
+	}
+}
+
+func TestSizeGrowsWithPoleRows(t *testing.T) {
+	spec := sampleSpecs()[2]
+	_, two := Size(spec)
+	spec.Points = append(spec.Points, Point{Label: "思考-情感", Value: 20})
+	if _, three := Size(spec); three-two != poleRowH {
+		t.Fatalf("pole bar height grew by %v", three-two)
+	}
+}
+
+func TestFind(t *testing.T) {
+	if spec, ok := Find(sampleSpecs(), "radar"); !ok || spec.Kind != KindRadar {
+		t.Fatalf("Find(radar) = %v, %v", spec, ok)
+	}
+	if _, ok := Find(sampleSpecs(), "missing"); ok {
+		t.Fatal("Find(missing) found a chart")
+	}
+}
+
+func contains(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
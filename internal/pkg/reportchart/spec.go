@@ -0,0 +1,93 @@
+// Package reportchart 描述报告图表并以确定的版式绘制它们。
+//
+// Spec 只携带数据（点、区间带、参考线），不含坐标；Draw 按图表类型的固定版式把 Spec
+// 画到任意 Canvas 上，SVG 与 PDF 共用同一版式，因此同一份报告在各端的图形一致。
+// 坐标以图表左上角为原点、向下为正，单位与 SVG 用户单位、PDF point 相同。
+package reportchart
+
+// Kind 图表类型。
+type Kind string
+
+const (
+	// KindProfile 剖面图：各因子 T 分连线，背景为常模 T 分区间带。
+	KindProfile Kind = "profile"
+	// KindRadar 因子雷达图：各因子按满分归一。
+	KindRadar Kind = "radar"
+	// KindPoleBars 类型学两极条形图：以中点为参考，条形朝得分所在的一极延伸。
+	KindPoleBars Kind = "pole_bars"
+	// KindSparkline 纵向迷你折线：同一得分在各时间点上的变化。
+	KindSparkline Kind = "sparkline"
+)
+
+// Tone 区间带的语义色调，由 Theme 映射为颜色。
+type Tone string
+
+const (
+	ToneLow      Tone = "low"
+	ToneNormal   Tone = "normal"
+	ToneElevated Tone = "elevated"
+	ToneHigh     Tone = "high"
+)
+
+// Spec 单个图表的数据。Min/Max 为数值轴范围；Point.Max 非空时该点按自身满分归一。
+type Spec struct {
+	ID        string
+	Kind      Kind
+	Title     string
+	Min, Max  float64
+	Reference *float64
+	Bands     []Band
+	Points    []Point
+}
+
+// Band 数值轴上 [From, To) 的区间带。
+type Band struct {
+	From, To float64
+	Label    string
+	Tone     Tone
+}
+
+// Point 图表上的一个数据点。
+type Point struct {
+	Code, Label string
+	Value       float64
+	Max         *float64
+}
+
+// Find 按 ID 查找图表。
+func Find(specs []Spec, id string) (Spec, bool) {
+	for _, spec := range specs {
+		if spec.ID == id {
+			return spec, true
+		}
+	}
+	return Spec{}, false
+}
+
+// scale 返回点的数值轴上限：点自身满分优先，否则为图表上限。
+func (s Spec) scale(point Point) float64 {
+	if point.Max != nil && *point.Max > s.Min {
+		return *point.Max
+	}
+	return s.Max
+}
+
+// ratio 把点的值映射到 [0, 1]；数值轴无效时为 0。
+func (s Spec) ratio(point Point) float64 {
+	return clampRatio(point.Value, s.Min, s.scale(point))
+}
+
+func clampRatio(value, lo, hi float64) float64 {
+	if hi <= lo {
+		return 0
+	}
+	ratio := (value - lo) / (hi - lo)
+	switch {
+	case ratio < 0:
+		return 0
+	case ratio > 1:
+		return 1
+	default:
+		return ratio
+	}
+}
@@ -0,0 +1,97 @@
+package reportchart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentTypeSVG SVG 图表的 MIME 类型。
+const ContentTypeSVG = "image/svg+xml"
+
+// SVG 把图表渲染为独立的 SVG 文档。输出只取决于 spec 与 theme，可以按内容缓存。
+func SVG(spec Spec, theme Theme) []byte {
+	width, height := Size(spec)
+	canvas := &svgCanvas{}
+	fmt.Fprintf(&canvas.out, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" role="img" font-family="sans-serif">`,
+		svgNum(width), svgNum(height), svgNum(width), svgNum(height))
+	canvas.out.WriteString("\n<title>")
+	canvas.out.WriteString(escape(spec.Title))
+	canvas.out.WriteString("</title>\n")
+	canvas.Rect(0, 0, width, height, white)
+	Draw(spec, canvas, theme)
+	canvas.out.WriteString("</svg>\n")
+	return canvas.out.Bytes()
+}
+
+type svgCanvas struct {
+	out bytes.Buffer
+}
+
+func (c *svgCanvas) Rect(x, y, width, height float64, fill Color) {
+	fmt.Fprintf(&c.out, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+		svgNum(x), svgNum(y), svgNum(width), svgNum(height), fill.Hex())
+}
+
+func (c *svgCanvas) Line(x1, y1, x2, y2, width float64, stroke Color) {
+	fmt.Fprintf(&c.out, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+		svgNum(x1), svgNum(y1), svgNum(x2), svgNum(y2), stroke.Hex(), svgNum(width))
+}
+
+func (c *svgCanvas) Polyline(points []Vec, width float64, stroke Color) {
+	fmt.Fprintf(&c.out, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%s" stroke-linejoin="round"/>`+"\n",
+		svgPoints(points), stroke.Hex(), svgNum(width))
+}
+
+func (c *svgCanvas) Polygon(points []Vec, fill Color) {
+	fmt.Fprintf(&c.out, `<polygon points="%s" fill="%s"/>`+"\n", svgPoints(points), fill.Hex())
+}
+
+func (c *svgCanvas) Circle(cx, cy, r float64, fill Color) {
+	fmt.Fprintf(&c.out, `<circle cx="%s" cy="%s" r="%s" fill="%s"/>`+"\n", svgNum(cx), svgNum(cy), svgNum(r), fill.Hex())
+}
+
+func (c *svgCanvas) Text(x, y, size float64, color Color, anchor Anchor, text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(&c.out, `<text x="%s" y="%s" font-size="%s" fill="%s"%s>%s</text>`+"\n",
+		svgNum(x), svgNum(y), svgNum(size), color.Hex(), svgAnchor(anchor), escape(text))
+}
+
+func svgAnchor(anchor Anchor) string {
+	switch anchor {
+	case AnchorMiddle:
+		return ` text-anchor="middle"`
+	case AnchorEnd:
+		return ` text-anchor="end"`
+	default:
+		return ""
+	}
+}
+
+func svgPoints(points []Vec) string {
+	parts := make([]string, len(points))
+	for index, point := range points {
+		parts[index] = svgNum(point.X) + "," + svgNum(point.Y)
+	}
+	return strings.Join(parts, " ")
+}
+
+func escape(text string) string {
+	var out strings.Builder
+	_ = xml.EscapeText(&out, []byte(text))
+	return out.String()
+}
+
+// svgNum 输出保留两位小数的数值，并把 -0 规范为 0，保证输出稳定。
+func svgNum(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,78 @@
+package reportchart
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Color RGB 颜色。
+type Color struct{ R, G, B uint8 }
+
+// Hex 以 "#RRGGBB" 输出颜色。
+func (c Color) Hex() string {
+	const digits = "0123456789ABCDEF"
+	out := []byte{'#', 0, 0, 0, 0, 0, 0}
+	for index, v := range []uint8{c.R, c.G, c.B} {
+		out[1+index*2] = digits[v>>4]
+		out[2+index*2] = digits[v&0x0F]
+	}
+	return string(out)
+}
+
+// Mix 按 weight（0..1）把 c 与 other 混合；weight 为 1 时得到 other。
+func (c Color) Mix(other Color, weight float64) Color {
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*weight + 0.5)
+	}
+	return Color{R: blend(c.R, other.R), G: blend(c.G, other.G), B: blend(c.B, other.B)}
+}
+
+// ParseHexColor 解析 "#RRGGBB" 或 "RRGGBB"；格式不合法时返回 false。
+func ParseHexColor(value string) (Color, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(value) != 6 {
+		return Color{}, false
+	}
+	n, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return Color{}, false
+	}
+	return Color{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, true
+}
+
+var white = Color{R: 255, G: 255, B: 255}
+
+// Theme 图表配色。
+type Theme struct {
+	Primary, Text, Muted, Grid, Track Color
+	Tones                             map[Tone]Color
+}
+
+// DefaultTheme 与 PDF 报告默认品牌色一致的配色。
+func DefaultTheme() Theme {
+	return ThemeFor(Color{R: 0x1F, G: 0x6F, B: 0xEB})
+}
+
+// ThemeFor 以机构品牌色为主色的配色；区间带色调与品牌无关，保证风险语义一致。
+func ThemeFor(primary Color) Theme {
+	return Theme{
+		Primary: primary,
+		Text:    Color{R: 33, G: 33, B: 33},
+		Muted:   Color{R: 96, G: 96, B: 96},
+		Grid:    Color{R: 210, G: 210, B: 210},
+		Track:   Color{R: 236, G: 238, B: 241},
+		Tones: map[Tone]Color{
+			ToneLow:      Color{R: 232, G: 240, B: 254},
+			ToneNormal:   Color{R: 237, G: 247, B: 237},
+			ToneElevated: Color{R: 255, G: 244, B: 222},
+			ToneHigh:     Color{R: 253, G: 228, B: 228},
+		},
+	}
+}
+
+func (t Theme) tone(tone Tone) Color {
+	if color, ok := t.Tones[tone]; ok {
+		return color
+	}
+	return t.Track
+}
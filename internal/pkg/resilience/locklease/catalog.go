@@ -16,6 +16,10 @@ const (
 	WorkloadStatisticsSync                 WorkloadID = "statistics_sync"
 	WorkloadEvaluationConsistencyReconcile WorkloadID = "evaluation_consistency_reconcile"
 	WorkloadReportCatalogAudit             WorkloadID = "report_catalog_audit"
+	WorkloadTesteeImport                   WorkloadID = "testee_import"
+	WorkloadWorkbenchTriageEscalation      WorkloadID = "workbench_triage_escalation"
+	WorkloadRiskAlert                      WorkloadID = "risk_alert"
+	WorkloadReportRegeneration             WorkloadID = "report_regeneration"
 	WorkloadAttentionProjectionReconcile   WorkloadID = "attention_projection_reconcile"
 	WorkloadCollectionSubmit               WorkloadID = "collection_submit"
 )
@@ -53,6 +57,10 @@ var capabilities = [...]Capability{
 	{WorkloadStatisticsSync, "apiserver", KindTaskLock, Spec{Name: string(WorkloadStatisticsSync), Description: "用于 apiserver 统计同步任务串行化执行的分布式锁。", DefaultTTL: 30 * time.Minute}, RenewalModeAuto},
 	{WorkloadEvaluationConsistencyReconcile, "apiserver", KindLeader, Spec{Name: string(WorkloadEvaluationConsistencyReconcile), Description: "用于 apiserver evaluation consistency reconcile 多实例串行化执行的分布式锁。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
 	{WorkloadReportCatalogAudit, "apiserver", KindLeader, Spec{Name: string(WorkloadReportCatalogAudit), Description: "用于 apiserver 有界报告目录审计多实例 leader 选举与自动续租。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
+	{WorkloadTesteeImport, "apiserver", KindLeader, Spec{Name: string(WorkloadTesteeImport), Description: "用于 apiserver 受试者批量导入任务多实例串行化处理的分布式锁。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
+	{WorkloadWorkbenchTriageEscalation, "apiserver", KindLeader, Spec{Name: string(WorkloadWorkbenchTriageEscalation), Description: "用于 apiserver 工作台高风险条目超时升级扫描的多实例 leader 选举。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
+	{WorkloadRiskAlert, "apiserver", KindLeader, Spec{Name: string(WorkloadRiskAlert), Description: "用于 apiserver 风险预警规则评估与未确认预警升级的多实例 leader 选举。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
+	{WorkloadReportRegeneration, "apiserver", KindLeader, Spec{Name: string(WorkloadReportRegeneration), Description: "用于 apiserver 报告批量重生成批次多实例串行化推进的分布式锁。", DefaultTTL: 30 * time.Second}, RenewalModeAuto},
 	{WorkloadAttentionProjectionReconcile, "worker", KindLeader, Spec{Name: string(WorkloadAttentionProjectionReconcile), Description: "用于 worker Attention 失败重试与历史事实恢复的多实例 leader 选举。", DefaultTTL: 30 * time.Minute}, RenewalModeAuto},
 	{WorkloadCollectionSubmit, "collection-server", KindDuplicateSuppression, Spec{Name: string(WorkloadCollectionSubmit), Description: "用于 collection-server 跨实例合并相同答卷提交的建议性 lease；最终幂等由 Mongo 裁决。", DefaultTTL: 5 * time.Minute}, RenewalModeAuto},
 }
@@ -7,8 +7,8 @@ func TestCatalogIsCompleteImmutableAndValid(t *testing.T) {
 		t.Fatalf("ValidateCatalog() error = %v", err)
 	}
 	all := All()
-	if len(all) != 8 {
-		t.Fatalf("len(All()) = %d, want 8", len(all))
+	if len(all) != 12 {
+		t.Fatalf("len(All()) = %d, want 12", len(all))
 	}
 
 	want := []WorkloadID{
@@ -18,6 +18,10 @@ func TestCatalogIsCompleteImmutableAndValid(t *testing.T) {
 		WorkloadStatisticsSync,
 		WorkloadEvaluationConsistencyReconcile,
 		WorkloadReportCatalogAudit,
+		WorkloadTesteeImport,
+		WorkloadWorkbenchTriageEscalation,
+		WorkloadRiskAlert,
+		WorkloadReportRegeneration,
 		WorkloadAttentionProjectionReconcile,
 		WorkloadCollectionSubmit,
 	}
@@ -17,7 +17,12 @@ It supports multiple event types:
 - evaluation.requested: Triggers evaluation workflow
 - evaluation.outcome.committed: Triggers interpretation report generation
 - evaluation.failed / interpretation.report.generated / interpretation.report.failed: Handle outcome projections
-- task.opened / task.completed / task.expired / task.canceled: Handle task notifications`
+- task.opened / task.completed / task.expired / task.canceled: Handle task notifications
+- consent.withdrawn: Notifies the organization of a withdrawn informed consent
+- break_glass.granted: Notifies organization admins of a break-glass grant awaiting review
+- break_glass.reviewed / break_glass.revoked: Records the review decision or early revocation of a break-glass grant
+- risk_alert.raised / risk_alert.escalated: Pages the on-call channel of a risk alert rule
+- answersheet.critical_item_flagged: Pages the organization when a submission hits a critical item`
 
 // NewApp 创建 Worker App
 func NewApp(basename string) *app.App {
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/catalog"
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+// handleBreakGlassGranted 处理紧急访问授予事件。
+// 授权与访问审计记录已在 apiserver 同一事务内落库，这里只负责提醒机构管理员事后复核；
+// 通知失败仅记录，不 NACK，授权仍在复核队列中等待处理。
+func handleBreakGlassGranted(deps *Dependencies) HandlerFunc {
+	return func(ctx context.Context, _ string, payload []byte) error {
+		var data eventpayload.BreakGlassGrantedData
+		env, err := ParseEventData(payload, &data)
+		if err != nil {
+			return fmt.Errorf("failed to parse break-glass granted event: %w", err)
+		}
+
+		deps.Logger.Info("processing break-glass granted",
+			slog.String("event_id", env.ID),
+			slog.Int64("org_id", data.OrgID),
+			slog.String("grant_id", data.GrantID),
+			slog.String("clinician_id", data.ClinicianID),
+			slog.String("testee_id", data.TesteeID),
+			slog.Time("expires_at", data.ExpiresAt),
+		)
+
+		notifier, ok := deps.Notifier.(port.BreakGlassNotifier)
+		if !ok || notifier == nil {
+			return nil
+		}
+		if err := notifier.NotifyBreakGlassGranted(ctx, notificationMetaFromEnvelope(env), port.BreakGlassGrantedNotification{
+			OrgID:          data.OrgID,
+			GrantID:        data.GrantID,
+			ClinicianID:    data.ClinicianID,
+			OperatorUserID: data.OperatorUserID,
+			TesteeID:       data.TesteeID,
+			Justification:  data.Justification,
+			GrantedAt:      data.GrantedAt,
+			ExpiresAt:      data.ExpiresAt,
+		}); err != nil {
+			deps.Logger.Warn("failed to notify break-glass granted",
+				slog.String("grant_id", data.GrantID),
+				slog.String("testee_id", data.TesteeID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil
+	}
+}
+
+// handleBreakGlassSettled 处理紧急访问复核与撤销事件。
+// 复核结论、撤销与对应的访问审计记录已在 apiserver 同一事务内落库，这里只记录结果供运维追踪。
+func handleBreakGlassSettled(deps *Dependencies) HandlerFunc {
+	return func(_ context.Context, eventType string, payload []byte) error {
+		switch eventType {
+		case eventcatalog.BreakGlassReviewed:
+			var data eventpayload.BreakGlassReviewedData
+			env, err := ParseEventData(payload, &data)
+			if err != nil {
+				return fmt.Errorf("failed to parse break-glass reviewed event: %w", err)
+			}
+			deps.Logger.Info("processing break-glass reviewed",
+				slog.String("event_id", env.ID),
+				slog.Int64("org_id", data.OrgID),
+				slog.String("grant_id", data.GrantID),
+				slog.String("testee_id", data.TesteeID),
+				slog.String("reviewer_user_id", data.ReviewerUserID),
+				slog.String("decision", data.Decision),
+			)
+		case eventcatalog.BreakGlassRevoked:
+			var data eventpayload.BreakGlassRevokedData
+			env, err := ParseEventData(payload, &data)
+			if err != nil {
+				return fmt.Errorf("failed to parse break-glass revoked event: %w", err)
+			}
+			deps.Logger.Info("processing break-glass revoked",
+				slog.String("event_id", env.ID),
+				slog.Int64("org_id", data.OrgID),
+				slog.String("grant_id", data.GrantID),
+				slog.String("testee_id", data.TesteeID),
+				slog.String("revoked_by", data.RevokedBy),
+			)
+		default:
+			return fmt.Errorf("unexpected break-glass event type %q", eventType)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+type breakGlassRecordingNotifier struct {
+	recordingNotifier
+	grantedMeta []port.NotificationMeta
+	granted     []port.BreakGlassGrantedNotification
+}
+
+func (n *breakGlassRecordingNotifier) NotifyBreakGlassGranted(_ context.Context, meta port.NotificationMeta, payload port.BreakGlassGrantedNotification) error {
+	n.grantedMeta = append(n.grantedMeta, meta)
+	n.granted = append(n.granted, payload)
+	return nil
+}
+
+func breakGlassGrantedPayload(t *testing.T) []byte {
+	t.Helper()
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(map[string]any{
+		"id":            "evt-break-glass",
+		"eventType":     "break_glass.granted",
+		"occurredAt":    now,
+		"aggregateType": "BreakGlassGrant",
+		"aggregateID":   "41",
+		"data": map[string]any{
+			"org_id": 7, "grant_id": "41", "clinician_id": "301", "operator_user_id": "101", "testee_id": "9",
+			"justification": "夜间值班，受试者急诊就诊", "granted_at": now, "expires_at": now.Add(time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return payload
+}
+
+func TestBreakGlassGrantedNotifiesBreakGlassNotifier(t *testing.T) {
+	notifier := &breakGlassRecordingNotifier{}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleBreakGlassGranted(deps)(context.Background(), "break_glass.granted", breakGlassGrantedPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if len(notifier.granted) != 1 || notifier.granted[0].GrantID != "41" || notifier.granted[0].OrgID != 7 {
+		t.Fatalf("granted notifications = %#v", notifier.granted)
+	}
+	if len(notifier.grantedMeta) != 1 || notifier.grantedMeta[0].EventID != "evt-break-glass" {
+		t.Fatalf("granted meta = %#v", notifier.grantedMeta)
+	}
+}
+
+func TestBreakGlassGrantedSkipsTaskOnlyNotifier(t *testing.T) {
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: &recordingNotifier{}}
+
+	if err := handleBreakGlassGranted(deps)(context.Background(), "break_glass.granted", breakGlassGrantedPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+}
+
+func TestBreakGlassSettledAcceptsReviewedAndRevokedEvents(t *testing.T) {
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: &recordingNotifier{}}
+	now := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	events := map[string]map[string]any{
+		"break_glass.reviewed": {"org_id": 7, "grant_id": "41", "testee_id": "9", "reviewer_user_id": "201", "decision": "flagged", "note": "理由不充分", "reviewed_at": now},
+		"break_glass.revoked":  {"org_id": 7, "grant_id": "41", "testee_id": "9", "revoked_by": "201", "revoked_at": now},
+	}
+	for eventType, data := range events {
+		payload, err := json.Marshal(map[string]any{
+			"id": "evt-" + eventType, "eventType": eventType, "occurredAt": now,
+			"aggregateType": "BreakGlassGrant", "aggregateID": "41", "data": data,
+		})
+		if err != nil {
+			t.Fatalf("marshal payload: %v", err)
+		}
+		if err := handleBreakGlassSettled(deps)(context.Background(), eventType, payload); err != nil {
+			t.Fatalf("%s: handler returned error: %v", eventType, err)
+		}
+	}
+	if err := handleBreakGlassSettled(deps)(context.Background(), "break_glass.revoked", []byte("not json")); err == nil {
+		t.Fatal("malformed payload must be NACKed")
+	}
+}
@@ -45,5 +45,20 @@ func NewRegistry() *Registry {
 		"task_canceled_handler": func(deps *Dependencies) HandlerFunc {
 			return handleTaskCanceled(deps)
 		},
+		"consent_withdrawn_handler": func(deps *Dependencies) HandlerFunc {
+			return handleConsentWithdrawn(deps)
+		},
+		"break_glass_granted_handler": func(deps *Dependencies) HandlerFunc {
+			return handleBreakGlassGranted(deps)
+		},
+		"break_glass_settled_handler": func(deps *Dependencies) HandlerFunc {
+			return handleBreakGlassSettled(deps)
+		},
+		"risk_alert_page_handler": func(deps *Dependencies) HandlerFunc {
+			return handleRiskAlertPage(deps)
+		},
+		"critical_item_page_handler": func(deps *Dependencies) HandlerFunc {
+			return handleCriticalItemPage(deps)
+		},
 	})
 }
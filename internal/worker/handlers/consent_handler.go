@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+// handleConsentWithdrawn 处理知情同意撤回事件。
+// 撤回事实已在 apiserver 事务内落库，这里只负责把撤回通知给机构侧；
+// 通知失败仅记录，不 NACK，避免重复投递放大外部通知。
+func handleConsentWithdrawn(deps *Dependencies) HandlerFunc {
+	return func(ctx context.Context, _ string, payload []byte) error {
+		var data eventpayload.ConsentWithdrawnData
+		env, err := ParseEventData(payload, &data)
+		if err != nil {
+			return fmt.Errorf("failed to parse consent withdrawn event: %w", err)
+		}
+
+		deps.Logger.Info("processing consent withdrawn",
+			slog.String("event_id", env.ID),
+			slog.Int64("org_id", data.OrgID),
+			slog.String("acceptance_id", data.AcceptanceID),
+			slog.String("document_id", data.DocumentID),
+			slog.Int("document_version", data.DocumentVersion),
+			slog.String("testee_id", data.TesteeID),
+			slog.String("withdrawn_by", data.WithdrawnBy),
+			slog.Time("withdrawn_at", data.WithdrawnAt),
+		)
+
+		notifier, ok := deps.Notifier.(port.ConsentNotifier)
+		if !ok || notifier == nil {
+			return nil
+		}
+		if err := notifier.NotifyConsentWithdrawn(ctx, notificationMetaFromEnvelope(env), port.ConsentWithdrawnNotification{
+			AcceptanceID:    data.AcceptanceID,
+			DocumentID:      data.DocumentID,
+			DocumentVersion: data.DocumentVersion,
+			TesteeID:        data.TesteeID,
+			FillerUserID:    data.FillerUserID,
+			FillerType:      data.FillerType,
+			WithdrawnBy:     data.WithdrawnBy,
+			Reason:          data.Reason,
+			WithdrawnAt:     data.WithdrawnAt,
+		}); err != nil {
+			deps.Logger.Warn("failed to notify consent withdrawn",
+				slog.String("acceptance_id", data.AcceptanceID),
+				slog.String("testee_id", data.TesteeID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil
+	}
+}
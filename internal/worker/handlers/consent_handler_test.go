@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+type consentRecordingNotifier struct {
+	recordingNotifier
+	withdrawnMeta []port.NotificationMeta
+	withdrawn     []port.ConsentWithdrawnNotification
+}
+
+func (n *consentRecordingNotifier) NotifyConsentWithdrawn(_ context.Context, meta port.NotificationMeta, payload port.ConsentWithdrawnNotification) error {
+	n.withdrawnMeta = append(n.withdrawnMeta, meta)
+	n.withdrawn = append(n.withdrawn, payload)
+	return nil
+}
+
+func consentWithdrawnPayload(t *testing.T) []byte {
+	t.Helper()
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(map[string]any{
+		"id":            "evt-consent",
+		"eventType":     "consent.withdrawn",
+		"occurredAt":    now,
+		"aggregateType": "ConsentAcceptance",
+		"aggregateID":   "11",
+		"data": map[string]any{
+			"org_id": 7, "acceptance_id": "11", "document_id": "3", "document_version": 2,
+			"scope_kind": "org", "testee_id": "9", "filler_user_id": "21", "filler_type": "guardian",
+			"withdrawn_by": "21", "reason": "不再参与", "withdrawn_at": now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return payload
+}
+
+func TestConsentWithdrawnNotifiesConsentNotifier(t *testing.T) {
+	notifier := &consentRecordingNotifier{}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleConsentWithdrawn(deps)(context.Background(), "consent.withdrawn", consentWithdrawnPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if len(notifier.withdrawn) != 1 || notifier.withdrawn[0].AcceptanceID != "11" || notifier.withdrawn[0].DocumentVersion != 2 {
+		t.Fatalf("withdrawn notifications = %#v", notifier.withdrawn)
+	}
+	if len(notifier.withdrawnMeta) != 1 || notifier.withdrawnMeta[0].EventID != "evt-consent" {
+		t.Fatalf("withdrawn meta = %#v", notifier.withdrawnMeta)
+	}
+}
+
+func TestConsentWithdrawnSkipsTaskOnlyNotifier(t *testing.T) {
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: &recordingNotifier{}}
+
+	if err := handleConsentWithdrawn(deps)(context.Background(), "consent.withdrawn", consentWithdrawnPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+}
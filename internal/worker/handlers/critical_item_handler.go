@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+// handleCriticalItemPage 处理答卷命中关键条目事件，寻呼机构值班人员。
+// 事件在答卷提交时写入，不等待测评模型评估；寻呼失败返回错误 NACK 重投，网关按 event_id 去重。
+func handleCriticalItemPage(deps *Dependencies) HandlerFunc {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		var data eventpayload.AnswerSheetCriticalItemFlaggedData
+		env, err := ParseEventData(payload, &data)
+		if err != nil {
+			return fmt.Errorf("failed to parse critical item event: %w", err)
+		}
+
+		deps.Logger.Info("processing critical item page",
+			slog.String("event_id", env.ID),
+			slog.String("event_type", eventType),
+			slog.Uint64("org_id", data.OrgID),
+			slog.String("answersheet_id", data.AnswerSheetID),
+			slog.Uint64("testee_id", data.TesteeID),
+			slog.String("questionnaire_code", data.QuestionnaireCode),
+			slog.Int("item_count", len(data.Items)),
+		)
+
+		notifier, ok := deps.Notifier.(port.CriticalItemNotifier)
+		if !ok || notifier == nil {
+			deps.Logger.Warn("critical item page skipped (notifier does not support paging)",
+				slog.String("answersheet_id", data.AnswerSheetID),
+			)
+			return nil
+		}
+		items := make([]port.CriticalItemHitDetail, 0, len(data.Items))
+		for _, item := range data.Items {
+			items = append(items, port.CriticalItemHitDetail{QuestionCode: item.QuestionCode, Category: item.Category, Trigger: item.Trigger})
+		}
+		if err := notifier.NotifyCriticalItemFlagged(ctx, notificationMetaFromEnvelope(env), port.CriticalItemNotification{
+			OrgID:                data.OrgID,
+			AnswerSheetID:        data.AnswerSheetID,
+			TesteeID:             strconv.FormatUint(data.TesteeID, 10),
+			QuestionnaireCode:    data.QuestionnaireCode,
+			QuestionnaireVersion: data.QuestionnaireVersion,
+			Items:                items,
+			FlaggedAt:            data.FlaggedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to page critical item for answersheet %s: %w", data.AnswerSheetID, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+type criticalItemRecordingNotifier struct {
+	recordingNotifier
+	pages []port.CriticalItemNotification
+	err   error
+}
+
+func (n *criticalItemRecordingNotifier) NotifyCriticalItemFlagged(_ context.Context, _ port.NotificationMeta, payload port.CriticalItemNotification) error {
+	n.pages = append(n.pages, payload)
+	return n.err
+}
+
+func criticalItemFlaggedPayload(t *testing.T) []byte {
+	t.Helper()
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(map[string]any{
+		"id":            "evt-critical-item",
+		"eventType":     "answersheet.critical_item_flagged",
+		"occurredAt":    now,
+		"aggregateType": "AnswerSheet",
+		"aggregateID":   "42",
+		"data": map[string]any{
+			"answersheet_id": "42", "questionnaire_code": "PHQ9", "questionnaire_version": "1.0.0",
+			"org_id": 7, "testee_id": 9, "filler_id": 3, "flagged_at": now,
+			"items": []map[string]any{{"question_code": "Q9", "category": "self_harm", "trigger": "opt-3"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return payload
+}
+
+func TestCriticalItemPageNotifiesWithoutAnswerText(t *testing.T) {
+	notifier := &criticalItemRecordingNotifier{}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleCriticalItemPage(deps)(context.Background(), "answersheet.critical_item_flagged", criticalItemFlaggedPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if len(notifier.pages) != 1 || notifier.pages[0].TesteeID != "9" || len(notifier.pages[0].Items) != 1 || notifier.pages[0].Items[0].Trigger != "opt-3" {
+		t.Fatalf("pages = %#v", notifier.pages)
+	}
+}
+
+func TestCriticalItemPageNacksWhenPagingFails(t *testing.T) {
+	notifier := &criticalItemRecordingNotifier{err: errors.New("gateway unavailable")}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleCriticalItemPage(deps)(context.Background(), "answersheet.critical_item_flagged", criticalItemFlaggedPayload(t)); err == nil {
+		t.Fatal("expected paging failure to be returned for redelivery")
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/FangcunMount/qs-server/internal/pkg/eventing/payload"
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+// handleRiskAlertPage 处理风险预警触发与升级事件，向事件中的值班渠道寻呼。
+// 与其他通知不同，寻呼失败返回错误 NACK 重投：漏掉一次寻呼意味着高风险测评无人跟进。
+// 重复投递可能造成重复寻呼，网关按 event_id 去重。
+func handleRiskAlertPage(deps *Dependencies) HandlerFunc {
+	return func(ctx context.Context, eventType string, payload []byte) error {
+		var data eventpayload.RiskAlertPageData
+		env, err := ParseEventData(payload, &data)
+		if err != nil {
+			return fmt.Errorf("failed to parse risk alert event: %w", err)
+		}
+
+		deps.Logger.Info("processing risk alert page",
+			slog.String("event_id", env.ID),
+			slog.String("event_type", eventType),
+			slog.Int64("org_id", data.OrgID),
+			slog.String("alert_id", data.AlertID),
+			slog.String("rule_id", data.RuleID),
+			slog.String("severity", data.Severity),
+			slog.String("channel", data.Channel),
+			slog.Int("escalation_level", data.EscalationLevel),
+			slog.String("assessment_id", data.AssessmentID),
+			slog.String("testee_id", data.TesteeID),
+		)
+
+		notifier, ok := deps.Notifier.(port.RiskAlertNotifier)
+		if !ok || notifier == nil {
+			deps.Logger.Warn("risk alert page skipped (notifier does not support paging)",
+				slog.String("alert_id", data.AlertID),
+				slog.String("channel", data.Channel),
+			)
+			return nil
+		}
+		if err := notifier.NotifyRiskAlertPage(ctx, notificationMetaFromEnvelope(env), port.RiskAlertPageNotification{
+			AlertID:         data.AlertID,
+			RuleID:          data.RuleID,
+			RuleName:        data.RuleName,
+			RuleKind:        data.RuleKind,
+			Severity:        data.Severity,
+			Channel:         data.Channel,
+			EscalationLevel: data.EscalationLevel,
+			AssessmentID:    data.AssessmentID,
+			TesteeID:        data.TesteeID,
+			ModelCode:       data.ModelCode,
+			Summary:         data.Summary,
+			ObservedValue:   data.ObservedValue,
+			TriggeredAt:     data.TriggeredAt,
+			PagedAt:         data.PagedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to page risk alert %s on %s: %w", data.AlertID, data.Channel, err)
+		}
+		return nil
+	}
+}
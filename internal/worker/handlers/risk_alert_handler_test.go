@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/FangcunMount/qs-server/internal/worker/port"
+)
+
+type riskAlertRecordingNotifier struct {
+	recordingNotifier
+	pages []port.RiskAlertPageNotification
+	err   error
+}
+
+func (n *riskAlertRecordingNotifier) NotifyRiskAlertPage(_ context.Context, _ port.NotificationMeta, payload port.RiskAlertPageNotification) error {
+	n.pages = append(n.pages, payload)
+	return n.err
+}
+
+func riskAlertEscalatedPayload(t *testing.T) []byte {
+	t.Helper()
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(map[string]any{
+		"id":            "evt-risk-alert",
+		"eventType":     "risk_alert.escalated",
+		"occurredAt":    now,
+		"aggregateType": "RiskAlert",
+		"aggregateID":   "31",
+		"data": map[string]any{
+			"org_id": 7, "alert_id": "31", "rule_id": "5", "rule_name": "自伤条目", "rule_kind": "item_answer",
+			"severity": "critical", "channel": "duty-manager", "escalation_level": 1,
+			"assessment_id": "111", "testee_id": "9", "summary": "题目 Q9 得分 2", "observed_value": 2,
+			"triggered_at": now.Add(-15 * time.Minute), "paged_at": now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return payload
+}
+
+func TestRiskAlertPageNotifiesEscalationChannel(t *testing.T) {
+	notifier := &riskAlertRecordingNotifier{}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleRiskAlertPage(deps)(context.Background(), "risk_alert.escalated", riskAlertEscalatedPayload(t)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if len(notifier.pages) != 1 || notifier.pages[0].Channel != "duty-manager" || notifier.pages[0].EscalationLevel != 1 {
+		t.Fatalf("pages = %#v", notifier.pages)
+	}
+}
+
+func TestRiskAlertPageNacksWhenPagingFails(t *testing.T) {
+	notifier := &riskAlertRecordingNotifier{err: errors.New("gateway unavailable")}
+	deps := &Dependencies{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Notifier: notifier}
+
+	if err := handleRiskAlertPage(deps)(context.Background(), "risk_alert.escalated", riskAlertEscalatedPayload(t)); err == nil {
+		t.Fatal("expected paging failure to be returned for redelivery")
+	}
+}
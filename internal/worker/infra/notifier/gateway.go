@@ -24,6 +24,10 @@ type gatewayEnvelope struct {
 
 type gatewayRecipient struct {
 	TesteeID string `json:"testee_id"`
+	// Channel 寻呼类通知的值班渠道，由网关解析为具体的接收人。
+	Channel string `json:"channel,omitempty"`
+	// Role 面向机构角色的通知，由网关解析为该机构内持有该角色的成员。
+	Role string `json:"role,omitempty"`
 }
 
 // GatewayNotifier 将任务通知发送到内部通知网关，由网关决定具体渠道。
@@ -88,6 +92,60 @@ func (n *GatewayNotifier) NotifyTaskCanceled(ctx context.Context, meta port.Noti
 	})
 }
 
+func (n *GatewayNotifier) NotifyConsentWithdrawn(ctx context.Context, meta port.NotificationMeta, payload port.ConsentWithdrawnNotification) error {
+	return n.notify(ctx, gatewayEnvelope{
+		SchemaVersion:    gatewaySchemaVersion,
+		NotificationType: meta.EventType,
+		TemplateCode:     "consent_withdrawn",
+		Event:            meta,
+		Recipient: gatewayRecipient{
+			TesteeID: payload.TesteeID,
+		},
+		Data: payload,
+	})
+}
+
+func (n *GatewayNotifier) NotifyBreakGlassGranted(ctx context.Context, meta port.NotificationMeta, payload port.BreakGlassGrantedNotification) error {
+	return n.notify(ctx, gatewayEnvelope{
+		SchemaVersion:    gatewaySchemaVersion,
+		NotificationType: meta.EventType,
+		TemplateCode:     "break_glass_granted",
+		Event:            meta,
+		Recipient: gatewayRecipient{
+			TesteeID: payload.TesteeID,
+			Role:     "org_admin",
+		},
+		Data: payload,
+	})
+}
+
+func (n *GatewayNotifier) NotifyRiskAlertPage(ctx context.Context, meta port.NotificationMeta, payload port.RiskAlertPageNotification) error {
+	return n.notify(ctx, gatewayEnvelope{
+		SchemaVersion:    gatewaySchemaVersion,
+		NotificationType: meta.EventType,
+		TemplateCode:     "risk_alert_page",
+		Event:            meta,
+		Recipient: gatewayRecipient{
+			TesteeID: payload.TesteeID,
+			Channel:  payload.Channel,
+		},
+		Data: payload,
+	})
+}
+
+func (n *GatewayNotifier) NotifyCriticalItemFlagged(ctx context.Context, meta port.NotificationMeta, payload port.CriticalItemNotification) error {
+	return n.notify(ctx, gatewayEnvelope{
+		SchemaVersion:    gatewaySchemaVersion,
+		NotificationType: meta.EventType,
+		TemplateCode:     "critical_item_flagged",
+		Event:            meta,
+		Recipient: gatewayRecipient{
+			TesteeID: payload.TesteeID,
+		},
+		Data: payload,
+	})
+}
+
 func (n *GatewayNotifier) notify(ctx context.Context, payload gatewayEnvelope) error {
 	if n == nil || n.gatewayURL == "" {
 		return nil
@@ -129,4 +187,9 @@ func (n *GatewayNotifier) notify(ctx context.Context, payload gatewayEnvelope) e
 	return nil
 }
 
-var _ port.TaskNotifier = (*GatewayNotifier)(nil)
+var (
+	_ port.TaskNotifier         = (*GatewayNotifier)(nil)
+	_ port.ConsentNotifier      = (*GatewayNotifier)(nil)
+	_ port.RiskAlertNotifier    = (*GatewayNotifier)(nil)
+	_ port.CriticalItemNotifier = (*GatewayNotifier)(nil)
+)
@@ -63,6 +63,26 @@ func (n *WebhookNotifier) NotifyTaskCanceled(ctx context.Context, meta port.Noti
 	return n.notify(ctx, meta, payload)
 }
 
+// NotifyConsentWithdrawn 发送知情同意撤回通知。
+func (n *WebhookNotifier) NotifyConsentWithdrawn(ctx context.Context, meta port.NotificationMeta, payload port.ConsentWithdrawnNotification) error {
+	return n.notify(ctx, meta, payload)
+}
+
+// NotifyBreakGlassGranted 发送紧急访问授予通知。
+func (n *WebhookNotifier) NotifyBreakGlassGranted(ctx context.Context, meta port.NotificationMeta, payload port.BreakGlassGrantedNotification) error {
+	return n.notify(ctx, meta, payload)
+}
+
+// NotifyRiskAlertPage 发送风险预警寻呼。
+func (n *WebhookNotifier) NotifyRiskAlertPage(ctx context.Context, meta port.NotificationMeta, payload port.RiskAlertPageNotification) error {
+	return n.notify(ctx, meta, payload)
+}
+
+// NotifyCriticalItemFlagged 发送答卷关键条目寻呼。
+func (n *WebhookNotifier) NotifyCriticalItemFlagged(ctx context.Context, meta port.NotificationMeta, payload port.CriticalItemNotification) error {
+	return n.notify(ctx, meta, payload)
+}
+
 func (n *WebhookNotifier) notify(ctx context.Context, meta port.NotificationMeta, payload any) error {
 	if n == nil || n.webhookURL == "" {
 		return nil
@@ -116,7 +136,12 @@ func (n *WebhookNotifier) notify(ctx context.Context, meta port.NotificationMeta
 	return nil
 }
 
-var _ port.TaskNotifier = (*WebhookNotifier)(nil)
+var (
+	_ port.TaskNotifier         = (*WebhookNotifier)(nil)
+	_ port.ConsentNotifier      = (*WebhookNotifier)(nil)
+	_ port.RiskAlertNotifier    = (*WebhookNotifier)(nil)
+	_ port.CriticalItemNotifier = (*WebhookNotifier)(nil)
+)
 
 func signWebhookPayload(secret []byte, body []byte) string {
 	mac := hmac.New(sha256.New, secret)
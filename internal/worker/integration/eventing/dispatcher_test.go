@@ -56,8 +56,8 @@ func TestDispatcherInitializesCurrentRuntimeTopics(t *testing.T) {
 	}
 
 	subs := dispatcher.GetTopicSubscriptions()
-	if len(subs) != 3 {
-		t.Fatalf("expected 3 topic subscriptions, got %d", len(subs))
+	if len(subs) != 7 {
+		t.Fatalf("expected 7 topic subscriptions, got %d", len(subs))
 	}
 
 	for _, eventType := range cfg.ListEventTypes() {
@@ -45,3 +45,87 @@ type TaskNotifier interface {
 	NotifyTaskExpired(ctx context.Context, meta NotificationMeta, payload TaskExpiredNotification) error
 	NotifyTaskCanceled(ctx context.Context, meta NotificationMeta, payload TaskCanceledNotification) error
 }
+
+// ConsentWithdrawnNotification 是知情同意撤回通知的标准载荷。
+type ConsentWithdrawnNotification struct {
+	AcceptanceID    string    `json:"acceptance_id"`
+	DocumentID      string    `json:"document_id"`
+	DocumentVersion int       `json:"document_version"`
+	TesteeID        string    `json:"testee_id"`
+	FillerUserID    string    `json:"filler_user_id"`
+	FillerType      string    `json:"filler_type"`
+	WithdrawnBy     string    `json:"withdrawn_by"`
+	Reason          string    `json:"reason,omitempty"`
+	WithdrawnAt     time.Time `json:"withdrawn_at"`
+}
+
+// BreakGlassGrantedNotification 是紧急访问授予通知的标准载荷，接收人为机构管理员。
+type BreakGlassGrantedNotification struct {
+	OrgID          int64     `json:"org_id"`
+	GrantID        string    `json:"grant_id"`
+	ClinicianID    string    `json:"clinician_id"`
+	OperatorUserID string    `json:"operator_user_id"`
+	TesteeID       string    `json:"testee_id"`
+	Justification  string    `json:"justification"`
+	GrantedAt      time.Time `json:"granted_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// RiskAlertPageNotification 是风险预警寻呼的标准载荷；Channel 为本次寻呼的值班渠道。
+type RiskAlertPageNotification struct {
+	AlertID         string    `json:"alert_id"`
+	RuleID          string    `json:"rule_id"`
+	RuleName        string    `json:"rule_name"`
+	RuleKind        string    `json:"rule_kind"`
+	Severity        string    `json:"severity"`
+	Channel         string    `json:"channel"`
+	EscalationLevel int       `json:"escalation_level"`
+	AssessmentID    string    `json:"assessment_id"`
+	TesteeID        string    `json:"testee_id"`
+	ModelCode       string    `json:"model_code,omitempty"`
+	Summary         string    `json:"summary"`
+	ObservedValue   float64   `json:"observed_value"`
+	TriggeredAt     time.Time `json:"triggered_at"`
+	PagedAt         time.Time `json:"paged_at"`
+}
+
+// RiskAlertNotifier 定义风险预警寻呼能力。
+// 通知器可选实现该接口；未实现时寻呼事件只记录日志，预警仍按时限升级直至有人确认。
+type RiskAlertNotifier interface {
+	NotifyRiskAlertPage(ctx context.Context, meta NotificationMeta, payload RiskAlertPageNotification) error
+}
+
+// CriticalItemNotification 是答卷命中关键条目的寻呼载荷；Items 只含触发条件，不含答案原文。
+type CriticalItemNotification struct {
+	OrgID                uint64                  `json:"org_id"`
+	AnswerSheetID        string                  `json:"answersheet_id"`
+	TesteeID             string                  `json:"testee_id"`
+	QuestionnaireCode    string                  `json:"questionnaire_code"`
+	QuestionnaireVersion string                  `json:"questionnaire_version"`
+	Items                []CriticalItemHitDetail `json:"items"`
+	FlaggedAt            time.Time               `json:"flagged_at"`
+}
+
+type CriticalItemHitDetail struct {
+	QuestionCode string `json:"question_code"`
+	Category     string `json:"category"`
+	Trigger      string `json:"trigger"`
+}
+
+// CriticalItemNotifier 定义关键条目寻呼能力。
+// 通知器可选实现该接口；未实现时事件只记录日志，条目仍进入工作台关键条目队列。
+type CriticalItemNotifier interface {
+	NotifyCriticalItemFlagged(ctx context.Context, meta NotificationMeta, payload CriticalItemNotification) error
+}
+
+// BreakGlassNotifier 定义紧急访问通知能力。
+// 通知器可选实现该接口；未实现时授予事件只记录日志，授权仍进入机构管理员的复核队列。
+type BreakGlassNotifier interface {
+	NotifyBreakGlassGranted(ctx context.Context, meta NotificationMeta, payload BreakGlassGrantedNotification) error
+}
+
+// ConsentNotifier 定义知情同意相关通知能力。
+// 通知器可选实现该接口；未实现时撤回事件只记录日志。
+type ConsentNotifier interface {
+	NotifyConsentWithdrawn(ctx context.Context, meta NotificationMeta, payload ConsentWithdrawnNotification) error
+}
@@ -63,7 +63,8 @@ case "$SERVICE" in
       MONGODB_HOST MONGODB_PORT MONGODB_USERNAME MONGODB_PASSWORD MONGODB_DBNAME \
       MYSQL_HOST MYSQL_PORT MYSQL_USERNAME MYSQL_PASSWORD MYSQL_DATABASE \
       REDIS_HOST REDIS_PORT JWT_SECRET NSQ_NSQD_HOST NSQ_NSQD_PORT \
-      OSS_ACCESS_KEY_ID OSS_ACCESS_KEY_SECRET DELEGATED_SUBJECT_CURRENT_KEY
+      OSS_ACCESS_KEY_ID OSS_ACCESS_KEY_SECRET DELEGATED_SUBJECT_CURRENT_KEY \
+      REDACTION_PSEUDONYM_SECRET REPORT_PDF_SIGNING_SECRET
 
     cat > "$ENV_FILE" <<EOF
 # Auto-generated production environment configuration for QS API Server
@@ -97,6 +98,9 @@ QS_APISERVER_MESSAGING_NSQ_ADDR=${NSQ_NSQD_HOST}:${NSQ_NSQD_PORT}
 QS_APISERVER_OSS_ACCESS_KEY_ID=${OSS_ACCESS_KEY_ID}
 QS_APISERVER_OSS_ACCESS_KEY_SECRET=${OSS_ACCESS_KEY_SECRET}
 QS_APISERVER_OSS_SESSION_TOKEN=${OSS_SESSION_TOKEN:-}
+
+QS_APISERVER_REDACTION_PSEUDONYM_SECRET=${REDACTION_PSEUDONYM_SECRET}
+QS_APISERVER_REPORT_PDF_SIGNING_SECRET=${REPORT_PDF_SIGNING_SECRET}
 EOF
     ;;
   collection)